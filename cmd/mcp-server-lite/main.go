@@ -4,14 +4,20 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/bulk"
+	"github.com/acmg-amp-mcp-server/internal/cli"
 	"github.com/acmg-amp-mcp-server/internal/config"
 	"github.com/acmg-amp-mcp-server/internal/mcp"
 	"github.com/acmg-amp-mcp-server/internal/setup"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
 )
 
 func main() {
@@ -24,6 +30,14 @@ func main() {
 		return
 	}
 
+	// Classify a variant or a table of variants offline and exit
+	if len(os.Args) > 1 && os.Args[1] == "classify" {
+		os.Exit(runClassify(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "classify-table" {
+		os.Exit(runClassifyTable(os.Args[2:]))
+	}
+
 	// Load lightweight configuration
 	cfg := config.LoadLiteConfig()
 
@@ -47,7 +61,22 @@ func main() {
 
 	go func() {
 		<-sigChan
-		log.Println("Shutdown signal received, gracefully shutting down...")
+		log.Printf("Shutdown signal received, draining in-flight requests for up to %s...", cfg.ShutdownTimeout)
+		drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancelDrain()
+
+		// A second signal stops without waiting for the drain
+		go func() {
+			select {
+			case <-sigChan:
+				log.Println("Second shutdown signal received, aborting in-flight requests")
+				cancelDrain()
+			case <-drainCtx.Done():
+			}
+		}()
+
+		report := server.Shutdown(drainCtx)
+		log.Printf("Drained %d in-flight requests, aborted %d, checkpointed %d jobs", report.Drained, report.Aborted, report.CheckpointedJobs)
 		cancel()
 	}()
 
@@ -58,3 +87,85 @@ func main() {
 
 	log.Println("ACMG-AMP MCP Server (Lite) stopped")
 }
+
+// runClassify classifies one variant with the full pipeline and prints the
+// result on stdout
+func runClassify(args []string) int {
+	if len(args) > 0 && (args[0] == "help" || args[0] == "--help" || args[0] == "-h") {
+		fmt.Print(cli.ClassifyUsage)
+		return 0
+	}
+	opts, err := cli.ParseClassifyArgs(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "classify: %v\n\n%s", err, cli.ClassifyUsage)
+		return 2
+	}
+
+	server, err := newOfflineServer(config.LoadLiteConfig())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "classify: %v\n", err)
+		return 1
+	}
+	defer server.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := cli.Classify(ctx, server, opts, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "classify: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runClassifyTable classifies the variants of a TSV or CSV table, writing
+// the results table on stdout and the summary on stderr
+func runClassifyTable(args []string) int {
+	if len(args) > 0 && (args[0] == "help" || args[0] == "--help" || args[0] == "-h") {
+		fmt.Print(bulk.TableUsage)
+		return 0
+	}
+	opts, err := bulk.ParseTableArgs(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "classify-table: %v\n\n%s", err, bulk.TableUsage)
+		return 2
+	}
+
+	cfg := config.LoadLiteConfig()
+	if opts.Assembly == "" {
+		if opts.Assembly, err = hgvs.ParseAssembly(cfg.GenomeAssembly); err != nil {
+			fmt.Fprintf(os.Stderr, "classify-table: invalid ACMG_GENOME_ASSEMBLY: %v\n", err)
+			return 2
+		}
+	}
+	opts.Options.ChunkSize = cfg.BatchClassifyLimit
+
+	server, err := newOfflineServer(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "classify-table: %v\n", err)
+		return 1
+	}
+	defer server.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if _, err := bulk.RunTable(ctx, server, opts, os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "classify-table: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// newOfflineServer creates the lite server for the offline subcommands.
+// Logs go to stderr at warning level unless ACMG_LOG_LEVEL says otherwise,
+// so stdout carries only results.
+func newOfflineServer(cfg *config.LiteConfig) (*mcp.LiteServer, error) {
+	logger := logrus.New()
+	logger.SetOutput(os.Stderr)
+	logger.SetLevel(logrus.WarnLevel)
+	if level, err := logrus.ParseLevel(os.Getenv("ACMG_LOG_LEVEL")); err == nil {
+		logger.SetLevel(level)
+	}
+	return mcp.NewLiteServer(cfg, mcp.WithLogger(logger))
+}
@@ -0,0 +1,123 @@
+// Package main provides a mock ClinVar, gnomAD and COSMIC server for integration
+// environments without internet access. Point the full server's
+// external_api base URLs at it to run realistic end-to-end tests.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/mocksources"
+)
+
+func main() {
+	addr := flag.String("addr", ":8090", "listen address")
+	datasetPath := flag.String("dataset", "", "JSON or YAML dataset (default: built-in dataset)")
+	latency := flag.Duration("latency", 0, "delay before every response")
+	jitter := flag.Duration("jitter", 0, "random extra delay, up to this duration")
+	sourceLatency := flag.String("source-latency", "", "per-source latency, e.g. clinvar=800ms,gnomad=150ms")
+	quiet := flag.Bool("quiet", false, "do not log requests")
+	flag.Parse()
+
+	dataset, err := loadDataset(*datasetPath)
+	if err != nil {
+		log.Fatalf("Failed to load dataset: %v", err)
+	}
+	perSource, err := parseSourceLatency(*sourceLatency)
+	if err != nil {
+		log.Fatalf("Invalid -source-latency: %v", err)
+	}
+
+	handler := mocksources.NewServer(dataset, mocksources.Options{
+		Latency:       *latency,
+		Jitter:        *jitter,
+		SourceLatency: perSource,
+	}).Handler()
+	if !*quiet {
+		handler = logRequests(handler)
+	}
+
+	server := &http.Server{Addr: *addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	// Setup graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		log.Println("Shutdown signal received, gracefully shutting down...")
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	}()
+
+	log.Printf("Mock evidence sources listening on %s with %d variants", *addr, len(dataset.Variants))
+	log.Printf("  ClinVar: http://<host>%s/clinvar/", *addr)
+	log.Printf("  gnomAD:  http://<host>%s/gnomad", *addr)
+	log.Printf("  COSMIC:  http://<host>%s/cosmic", *addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Mock server failed: %v", err)
+	}
+	log.Println("Mock evidence sources stopped")
+}
+
+// loadDataset loads the dataset file, or the built-in dataset when path is empty
+func loadDataset(path string) (*mocksources.Dataset, error) {
+	if path == "" {
+		return mocksources.DefaultDataset()
+	}
+	return mocksources.LoadDataset(path)
+}
+
+// parseSourceLatency parses "clinvar=800ms,gnomad=150ms"
+func parseSourceLatency(value string) (map[string]time.Duration, error) {
+	latencies := make(map[string]time.Duration)
+	if strings.TrimSpace(value) == "" {
+		return latencies, nil
+	}
+	for _, entry := range strings.Split(value, ",") {
+		source, duration, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("expected source=duration, got %q", entry)
+		}
+		switch source {
+		case mocksources.SourceClinVar, mocksources.SourceGnomAD, mocksources.SourceCOSMIC:
+		default:
+			return nil, fmt.Errorf("unknown source %q", source)
+		}
+		d, err := time.ParseDuration(duration)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		latencies[source] = d
+	}
+	return latencies, nil
+}
+
+// logRequests logs each request with its status and duration
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		log.Printf("%s %s %d %s", r.Method, r.URL.RequestURI(), recorder.status, time.Since(start).Round(time.Millisecond))
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,390 @@
+// Package admin provides the authenticated HTTP API used to view and schedule
+// changes to the rule engine thresholds and to inspect and reset client
+// request quotas. Requests authenticate with the admin token or, when an
+// authenticator is set, with any credential granting the admin role. The API
+// is documented in api/openapi.yaml.
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/middleware"
+	"github.com/acmg-amp-mcp-server/internal/readiness"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+// AdminUserHeader identifies the administrator making a change, for the audit trail
+const AdminUserHeader = "X-Admin-User"
+
+// defaultHistoryLimit bounds history responses when no limit is given
+const defaultHistoryLimit = 100
+
+// Server serves the admin API
+type Server struct {
+	logger  *logrus.Logger
+	store   thresholds.Store
+	token   string
+	limiter *middleware.RateLimiter
+	authn   *auth.Authenticator
+	router  *gin.Engine
+	server  *http.Server
+}
+
+// ScheduleRequest is the body of POST /admin/v1/thresholds
+type ScheduleRequest struct {
+	Thresholds    thresholds.Thresholds `json:"thresholds"`
+	EffectiveFrom time.Time             `json:"effective_from"` // Zero means immediately
+	Reason        string                `json:"reason"`
+}
+
+// CurrentResponse is the body of GET /admin/v1/thresholds
+type CurrentResponse struct {
+	Thresholds thresholds.Thresholds  `json:"thresholds"`
+	Active     *thresholds.Revision   `json:"active,omitempty"` // Nil when defaults apply
+	Pending    []*thresholds.Revision `json:"pending"`
+}
+
+// NewServer creates an admin API server. A bearer token is required.
+func NewServer(logger *logrus.Logger, store thresholds.Store, token string) (*Server, error) {
+	if strings.TrimSpace(token) == "" {
+		return nil, fmt.Errorf("admin API token is required")
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery(), middleware.CorrelationID(), middleware.SecurityHeaders())
+
+	s := &Server{
+		logger: logger,
+		store:  store,
+		token:  token,
+		router: router,
+	}
+	s.setupRoutes()
+	return s, nil
+}
+
+// setupRoutes configures the admin routes
+func (s *Server) setupRoutes() {
+	v1 := s.router.Group("/admin/v1", s.authenticate)
+	v1.GET("/thresholds", s.handleCurrent)
+	v1.GET("/thresholds/history", s.handleHistory)
+	v1.POST("/thresholds", s.handleSchedule)
+	v1.DELETE("/thresholds/revisions/:id", s.handleCancel)
+	v1.GET("/quotas", s.handleQuotas)
+	v1.GET("/quotas/:client", s.handleQuota)
+	v1.DELETE("/quotas/:client", s.handleResetQuota)
+
+	// Dependency status for liveness and readiness probes, not authenticated
+	s.router.GET("/healthz", gin.WrapH(readiness.Default.LivenessHandler()))
+	s.router.GET("/readyz", gin.WrapH(readiness.Default.ReadinessHandler()))
+}
+
+// SetRateLimiter exposes the quotas of a rate limiter through the quota
+// endpoints, which respond 404 without one
+func (s *Server) SetRateLimiter(limiter *middleware.RateLimiter) {
+	s.limiter = limiter
+}
+
+// SetAuthenticator also accepts API keys and bearer tokens granting the
+// admin role; the admin token remains valid
+func (s *Server) SetAuthenticator(authenticator *auth.Authenticator) {
+	s.authn = authenticator
+}
+
+// Handler returns the HTTP handler for the admin API
+func (s *Server) Handler() http.Handler {
+	return s.router
+}
+
+// Start listens on addr in the background
+func (s *Server) Start(addr string) error {
+	if s.server != nil {
+		return fmt.Errorf("admin API already started")
+	}
+	s.server = &http.Server{
+		Addr:              addr,
+		Handler:           s.router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	s.logger.WithField("address", addr).Info("Starting admin API")
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.logger.WithError(err).Error("Admin API server failed")
+		}
+	}()
+	return nil
+}
+
+// Close shuts the admin API down
+func (s *Server) Close() error {
+	if s.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// authenticate requires the admin token or a principal with the admin role
+func (s *Server) authenticate(c *gin.Context) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+		c.Next()
+		return
+	}
+
+	if s.authn != nil {
+		principal, err := s.authn.Authenticate(c.Request)
+		if err == nil && principal.Method != auth.MethodAnonymous {
+			if err := auth.Authorize(principal, auth.RoleAdmin); err != nil {
+				s.logger.WithFields(logrus.Fields{
+					"path":    c.Request.URL.Path,
+					"subject": principal.Subject,
+					"role":    principal.Role,
+				}).Warn("Rejected admin API request without the admin role")
+				s.abort(c, http.StatusForbidden, protocol.ErrorCodeForbidden, "Admin role required", "")
+				return
+			}
+			c.Set(middleware.PrincipalKey, principal)
+			c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+			c.Next()
+			return
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"path":      c.Request.URL.Path,
+		"client_ip": c.ClientIP(),
+	}).Warn("Rejected unauthenticated admin API request")
+	s.abort(c, http.StatusUnauthorized, protocol.ErrorCodeUnauthorized, "Valid bearer token required", "")
+}
+
+// adminUser returns the administrator named by the X-Admin-User header,
+// falling back to the subject of an authenticated principal
+func adminUser(c *gin.Context) string {
+	if admin := strings.TrimSpace(c.GetHeader(AdminUserHeader)); admin != "" {
+		return admin
+	}
+	if principal := auth.PrincipalFrom(c.Request.Context()); principal != nil {
+		return principal.Subject
+	}
+	return ""
+}
+
+func (s *Server) handleCurrent(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	active, err := s.store.Effective(ctx, time.Now())
+	if err != nil {
+		s.abort(c, http.StatusInternalServerError, protocol.ErrorCodeInternal, "Failed to load thresholds", err.Error())
+		return
+	}
+	history, err := s.store.History(ctx, 0)
+	if err != nil {
+		s.abort(c, http.StatusInternalServerError, protocol.ErrorCodeInternal, "Failed to load thresholds", err.Error())
+		return
+	}
+
+	response := CurrentResponse{Thresholds: thresholds.Defaults(), Active: active, Pending: []*thresholds.Revision{}}
+	if active != nil {
+		active.Status = thresholds.StatusActive
+		response.Thresholds = active.Thresholds
+	}
+	// History is newest first; list pending revisions in the order they take effect
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Status == thresholds.StatusPending {
+			response.Pending = append(response.Pending, history[i])
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (s *Server) handleHistory(c *gin.Context) {
+	limit := defaultHistoryLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			s.abort(c, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, "limit must be a positive integer", raw)
+			return
+		}
+		limit = n
+	}
+
+	revisions, err := s.store.History(c.Request.Context(), limit)
+	if err != nil {
+		s.abort(c, http.StatusInternalServerError, protocol.ErrorCodeInternal, "Failed to load threshold history", err.Error())
+		return
+	}
+	if revisions == nil {
+		revisions = []*thresholds.Revision{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+func (s *Server) handleSchedule(c *gin.Context) {
+	admin := adminUser(c)
+	if admin == "" {
+		s.abort(c, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, AdminUserHeader+" header is required", "")
+		return
+	}
+
+	var req ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.abort(c, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, "Invalid request body", err.Error())
+		return
+	}
+
+	revision := &thresholds.Revision{
+		Thresholds:    req.Thresholds,
+		EffectiveFrom: req.EffectiveFrom,
+		Reason:        req.Reason,
+		CreatedBy:     admin,
+	}
+	if err := s.store.Schedule(c.Request.Context(), revision); err != nil {
+		s.abort(c, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, "Threshold revision rejected", err.Error())
+		return
+	}
+
+	revision.Status = thresholds.StatusPending
+	if !revision.EffectiveFrom.After(time.Now()) {
+		revision.Status = thresholds.StatusActive
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"revision_id":    revision.ID,
+		"admin":          admin,
+		"effective_from": revision.EffectiveFrom,
+		"reason":         revision.Reason,
+		"correlation_id": c.GetString("correlation_id"),
+	}).Info("Threshold revision scheduled")
+
+	c.JSON(http.StatusCreated, revision)
+}
+
+func (s *Server) handleCancel(c *gin.Context) {
+	admin := adminUser(c)
+	if admin == "" {
+		s.abort(c, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, AdminUserHeader+" header is required", "")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		s.abort(c, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, "Revision ID must be an integer", c.Param("id"))
+		return
+	}
+
+	revision, err := s.store.Cancel(c.Request.Context(), id, admin)
+	switch {
+	case errors.Is(err, thresholds.ErrRevisionNotFound):
+		s.abort(c, http.StatusNotFound, protocol.ErrorCodeNotFound, "Threshold revision not found", c.Param("id"))
+		return
+	case errors.Is(err, thresholds.ErrRevisionInEffect):
+		s.abort(c, http.StatusConflict, protocol.ErrorCodeConflict, "Revision has already taken effect; schedule a new revision instead", c.Param("id"))
+		return
+	case err != nil:
+		s.abort(c, http.StatusInternalServerError, protocol.ErrorCodeInternal, "Failed to cancel threshold revision", err.Error())
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"revision_id":    revision.ID,
+		"admin":          admin,
+		"correlation_id": c.GetString("correlation_id"),
+	}).Info("Threshold revision cancelled")
+
+	c.JSON(http.StatusOK, revision)
+}
+
+func (s *Server) handleQuotas(c *gin.Context) {
+	if !s.requireLimiter(c) {
+		return
+	}
+	config := s.limiter.Config()
+	c.JSON(http.StatusOK, gin.H{
+		"requests_per_second": config.RequestsPerSecond,
+		"burst":               config.Burst,
+		"daily_quota":         config.DailyQuota,
+		"clients":             s.limiter.Quotas(),
+	})
+}
+
+func (s *Server) handleQuota(c *gin.Context) {
+	if !s.requireLimiter(c) {
+		return
+	}
+	status, ok := s.limiter.Quota(c.Param("client"))
+	if !ok {
+		s.abort(c, http.StatusNotFound, protocol.ErrorCodeNotFound, "No requests recorded for client", c.Param("client"))
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+func (s *Server) handleResetQuota(c *gin.Context) {
+	if !s.requireLimiter(c) {
+		return
+	}
+	admin := adminUser(c)
+	if admin == "" {
+		s.abort(c, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, AdminUserHeader+" header is required", "")
+		return
+	}
+
+	client := c.Param("client")
+	if !s.limiter.Reset(client) {
+		s.abort(c, http.StatusNotFound, protocol.ErrorCodeNotFound, "No requests recorded for client", client)
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"client":         client,
+		"admin":          admin,
+		"correlation_id": c.GetString("correlation_id"),
+	}).Info("Client quota reset")
+
+	c.Status(http.StatusNoContent)
+}
+
+// requireLimiter responds 404 when rate limiting is not enabled
+func (s *Server) requireLimiter(c *gin.Context) bool {
+	if s.limiter == nil {
+		s.abort(c, http.StatusNotFound, protocol.ErrorCodeNotFound, "Rate limiting is not enabled", "")
+		return false
+	}
+	return true
+}
+
+// abort responds with the standard error envelope shared with the MCP tools
+func (s *Server) abort(c *gin.Context, status int, code, message, details string) {
+	var detail interface{}
+	if details != "" {
+		detail = details
+	}
+	source := "rest:" + c.Request.Method + " " + c.FullPath()
+	envelope := protocol.NewErrorEnvelope(code, message, source, c.GetString("correlation_id"), detail)
+	s.logger.WithFields(logrus.Fields{
+		"correlation_id": envelope.CorrelationID,
+		"code":           envelope.Code,
+		"source":         envelope.Source,
+		"status":         status,
+	}).Warn(message)
+	c.AbortWithStatusJSON(status, envelope)
+}
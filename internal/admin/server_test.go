@@ -0,0 +1,212 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/middleware"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+const testToken = "s3cret"
+
+func createTestServer(t *testing.T) *Server {
+	t.Helper()
+	store, err := thresholds.NewSQLiteStore(filepath.Join(t.TempDir(), "thresholds.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	logger, _ := test.NewNullLogger()
+	server, err := NewServer(logger, store, testToken)
+	require.NoError(t, err)
+	return server
+}
+
+func doRequest(t *testing.T, s *Server, method, path string, body interface{}, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	var payload bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&payload).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(AdminUserHeader, "admin1")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewServer_RequiresToken(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	_, err := NewServer(logger, nil, " ")
+	assert.Error(t, err)
+}
+
+func TestAdminAPI_RejectsMissingOrWrongToken(t *testing.T) {
+	s := createTestServer(t)
+
+	assert.Equal(t, http.StatusUnauthorized, doRequest(t, s, http.MethodGet, "/admin/v1/thresholds", nil, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, doRequest(t, s, http.MethodGet, "/admin/v1/thresholds", nil, "wrong").Code)
+}
+
+func TestAdminAPI_ScheduleAndCancel(t *testing.T) {
+	s := createTestServer(t)
+
+	// Defaults apply before any revision exists
+	rec := doRequest(t, s, http.MethodGet, "/admin/v1/thresholds", nil, testToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var current CurrentResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &current))
+	assert.Nil(t, current.Active)
+	assert.Equal(t, thresholds.Defaults().BA1AlleleFrequency, current.Thresholds.BA1AlleleFrequency)
+
+	// Schedule an immediate and a future revision
+	immediate := thresholds.Defaults()
+	immediate.PM2AlleleFrequency = 0.00002
+	rec = doRequest(t, s, http.MethodPost, "/admin/v1/thresholds", ScheduleRequest{Thresholds: immediate, Reason: "Tighten PM2"}, testToken)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	future := ScheduleRequest{Thresholds: thresholds.Defaults(), Reason: "Revert", EffectiveFrom: time.Now().Add(7 * 24 * time.Hour)}
+	rec = doRequest(t, s, http.MethodPost, "/admin/v1/thresholds", future, testToken)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var scheduled thresholds.Revision
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &scheduled))
+	assert.Equal(t, thresholds.StatusPending, scheduled.Status)
+	assert.Equal(t, "admin1", scheduled.CreatedBy)
+
+	rec = doRequest(t, s, http.MethodGet, "/admin/v1/thresholds", nil, testToken)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &current))
+	require.NotNil(t, current.Active)
+	assert.Equal(t, 0.00002, current.Thresholds.PM2AlleleFrequency)
+	require.Len(t, current.Pending, 1)
+
+	// Only pending revisions can be cancelled
+	assert.Equal(t, http.StatusConflict, doRequest(t, s, http.MethodDelete, "/admin/v1/thresholds/revisions/1", nil, testToken).Code)
+	assert.Equal(t, http.StatusNotFound, doRequest(t, s, http.MethodDelete, "/admin/v1/thresholds/revisions/99", nil, testToken).Code)
+	rec = doRequest(t, s, http.MethodDelete, "/admin/v1/thresholds/revisions/2", nil, testToken)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = doRequest(t, s, http.MethodGet, "/admin/v1/thresholds/history", nil, testToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var history struct {
+		Revisions []thresholds.Revision `json:"revisions"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &history))
+	require.Len(t, history.Revisions, 2)
+	assert.Equal(t, thresholds.StatusCancelled, history.Revisions[0].Status)
+	assert.Equal(t, thresholds.StatusActive, history.Revisions[1].Status)
+}
+
+func TestAdminAPI_ScheduleRejectsInvalidThresholds(t *testing.T) {
+	s := createTestServer(t)
+
+	invalid := thresholds.Defaults()
+	invalid.BS1AlleleFrequency = 0.2 // Above BA1
+	rec := doRequest(t, s, http.MethodPost, "/admin/v1/thresholds", ScheduleRequest{Thresholds: invalid, Reason: "Typo"}, testToken)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "bs1_allele_frequency")
+}
+
+func TestAdminAPI_ErrorEnvelope(t *testing.T) {
+	s := createTestServer(t)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/v1/thresholds/revisions/abc", nil)
+	req.Header.Set(AdminUserHeader, "admin1")
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	req.Header.Set(protocol.CorrelationHeader, "ticket-4711")
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "ticket-4711", rec.Header().Get(protocol.CorrelationHeader))
+
+	var envelope protocol.ErrorEnvelope
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	assert.Equal(t, protocol.ErrorCodeInvalidInput, envelope.Code)
+	assert.Equal(t, "ticket-4711", envelope.CorrelationID)
+	assert.Equal(t, "rest:DELETE /admin/v1/thresholds/revisions/:id", envelope.Source)
+	assert.Equal(t, "abc", envelope.Details)
+	assert.False(t, envelope.Retryable)
+	assert.False(t, envelope.Timestamp.IsZero())
+}
+
+func TestAdminAPI_Quotas(t *testing.T) {
+	s := createTestServer(t)
+
+	rec := doRequest(t, s, http.MethodGet, "/admin/v1/quotas", nil, testToken)
+	assert.Equal(t, http.StatusNotFound, rec.Code, "quotas are unavailable without a rate limiter")
+
+	limiter := middleware.NewRateLimiter(middleware.RateLimitConfig{DailyQuota: 2})
+	s.SetRateLimiter(limiter)
+	limiter.Allow("ip:192.0.2.1")
+	limiter.Allow("ip:192.0.2.1")
+	allowed, _ := limiter.Allow("ip:192.0.2.1")
+	require.False(t, allowed)
+
+	rec = doRequest(t, s, http.MethodGet, "/admin/v1/quotas", nil, testToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var listing struct {
+		DailyQuota int                      `json:"daily_quota"`
+		Clients    []middleware.QuotaStatus `json:"clients"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listing))
+	assert.Equal(t, 2, listing.DailyQuota)
+	require.Len(t, listing.Clients, 1)
+	assert.Equal(t, 2, listing.Clients[0].Used)
+	assert.Equal(t, 0, listing.Clients[0].Remaining)
+	assert.Equal(t, int64(1), listing.Clients[0].Throttled)
+
+	rec = doRequest(t, s, http.MethodDelete, "/admin/v1/quotas/ip:192.0.2.1", nil, testToken)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	allowed, _ = limiter.Allow("ip:192.0.2.1")
+	assert.True(t, allowed, "a reset client may make requests again")
+
+	rec = doRequest(t, s, http.MethodGet, "/admin/v1/quotas/ip:192.0.2.1", nil, testToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var status middleware.QuotaStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, 1, status.Used)
+
+	assert.Equal(t, http.StatusNotFound, doRequest(t, s, http.MethodDelete, "/admin/v1/quotas/ip:198.51.100.7", nil, testToken).Code)
+	assert.Equal(t, http.StatusUnauthorized, doRequest(t, s, http.MethodGet, "/admin/v1/quotas", nil, "").Code)
+}
+
+func TestAdminAPI_AuthenticatorRoles(t *testing.T) {
+	s := createTestServer(t)
+	authenticator, err := auth.NewAuthenticator(auth.Config{
+		APIKeys: []auth.APIKey{
+			{Name: "lab-director", Role: auth.RoleAdmin, Key: "admin-key"},
+			{Name: "curator", Role: auth.RoleClassify, Key: "classify-key"},
+		},
+		AnonymousRole: auth.RoleAdmin,
+	})
+	require.NoError(t, err)
+	s.SetAuthenticator(authenticator)
+
+	assert.Equal(t, http.StatusOK, doRequest(t, s, http.MethodGet, "/admin/v1/thresholds", nil, testToken).Code, "the admin token remains valid")
+	assert.Equal(t, http.StatusOK, doRequest(t, s, http.MethodGet, "/admin/v1/thresholds", nil, "admin-key").Code)
+
+	rec := doRequest(t, s, http.MethodGet, "/admin/v1/thresholds", nil, "classify-key")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	var envelope protocol.ErrorEnvelope
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	assert.Equal(t, protocol.ErrorCodeForbidden, envelope.Code)
+
+	assert.Equal(t, http.StatusUnauthorized, doRequest(t, s, http.MethodGet, "/admin/v1/thresholds", nil, "").Code,
+		"anonymous access never reaches the admin API")
+}
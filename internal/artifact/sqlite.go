@@ -0,0 +1,289 @@
+package artifact
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite artifact blacklist store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables and indexes.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS artifact_blacklist (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		normalized_hgvs TEXT NOT NULL UNIQUE,
+		evidence TEXT NOT NULL,
+		submitted_by TEXT NOT NULL,
+		reviewed_by TEXT DEFAULT '',
+		reviewed_at DATETIME,
+		created_at DATETIME NOT NULL
+	);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+const entryColumns = `id, normalized_hgvs, evidence, submitted_by, reviewed_by, reviewed_at, created_at`
+
+// scanner is an interface for sql.Row and sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEntry scans a row into an Entry struct.
+func scanEntry(s scanner) (*Entry, error) {
+	e := &Entry{}
+	var reviewedAt sql.NullTime
+
+	err := s.Scan(&e.ID, &e.NormalizedHGVS, &e.Evidence, &e.SubmittedBy, &e.ReviewedBy, &reviewedAt, &e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if reviewedAt.Valid {
+		t := reviewedAt.Time
+		e.ReviewedAt = &t
+	}
+	return e, nil
+}
+
+// Propose adds a pending entry, setting its ID and creation time.
+func (s *SQLiteStore) Propose(ctx context.Context, entry *Entry) error {
+	entry.ReviewedBy = ""
+	entry.ReviewedAt = nil
+	entry.CreatedAt = time.Now().UTC()
+	return s.insert(ctx, entry)
+}
+
+func (s *SQLiteStore) insert(ctx context.Context, entry *Entry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := s.getByVariant(ctx, entry.NormalizedHGVS)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("%w: %s", ErrAlreadyListed, entry.NormalizedHGVS)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO artifact_blacklist (normalized_hgvs, evidence, submitted_by, reviewed_by, reviewed_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, entry.NormalizedHGVS, entry.Evidence, entry.SubmittedBy, entry.ReviewedBy, entry.ReviewedAt, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert artifact entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get artifact entry ID: %w", err)
+	}
+	entry.ID = id
+	return nil
+}
+
+// SignOff activates a pending entry.
+func (s *SQLiteStore) SignOff(ctx context.Context, id int64, reviewer string) (*Entry, error) {
+	reviewer = strings.TrimSpace(reviewer)
+	if reviewer == "" {
+		return nil, fmt.Errorf("%w: reviewer is required", ErrInvalidEntry)
+	}
+
+	entry, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Active() {
+		return nil, ErrAlreadySignedOff
+	}
+	if strings.EqualFold(entry.SubmittedBy, reviewer) {
+		return nil, ErrSelfReview
+	}
+
+	now := time.Now().UTC()
+	if _, err := s.db.ExecContext(ctx,
+		`UPDATE artifact_blacklist SET reviewed_by = ?, reviewed_at = ? WHERE id = ?`,
+		reviewer, now, id); err != nil {
+		return nil, fmt.Errorf("failed to sign off artifact entry: %w", err)
+	}
+
+	entry.ReviewedBy = reviewer
+	entry.ReviewedAt = &now
+	return entry, nil
+}
+
+// Remove deletes an entry.
+func (s *SQLiteStore) Remove(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM artifact_blacklist WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to remove artifact entry: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Lookup returns the active entry for a variant, or nil.
+func (s *SQLiteStore) Lookup(ctx context.Context, normalizedHGVS string) (*Entry, error) {
+	entry, err := s.getByVariant(ctx, strings.TrimSpace(normalizedHGVS))
+	if err != nil || entry == nil || !entry.Active() {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// List returns entries ordered by variant.
+func (s *SQLiteStore) List(ctx context.Context, includePending bool) ([]*Entry, error) {
+	query := "SELECT " + entryColumns + " FROM artifact_blacklist"
+	if !includePending {
+		query += " WHERE reviewed_by != ''"
+	}
+	query += " ORDER BY normalized_hgvs ASC"
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*Entry
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// ExportJSON exports all entries to a JSON writer.
+func (s *SQLiteStore) ExportJSON(ctx context.Context, writer io.Writer) error {
+	entries, err := s.List(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to list artifact entries: %w", err)
+	}
+
+	export := &BlacklistExport{
+		Version:    "1.0",
+		ExportedAt: time.Now(),
+		Count:      len(entries),
+		Entries:    entries,
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
+
+// ImportJSON imports entries from a JSON reader, keeping their sign-off.
+func (s *SQLiteStore) ImportJSON(ctx context.Context, reader io.Reader) (imported int, skipped int, err error) {
+	var export BlacklistExport
+	if err := json.NewDecoder(reader).Decode(&export); err != nil {
+		return 0, 0, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	for _, entry := range export.Entries {
+		if entry.CreatedAt.IsZero() {
+			entry.CreatedAt = time.Now().UTC()
+		}
+		if entry.ReviewedBy != "" && entry.ReviewedAt == nil {
+			now := time.Now().UTC()
+			entry.ReviewedAt = &now
+		}
+
+		err := s.insert(ctx, entry)
+		if errors.Is(err, ErrAlreadyListed) {
+			skipped++
+			continue
+		}
+		if err != nil {
+			return imported, skipped, fmt.Errorf("failed to import %s: %w", entry.NormalizedHGVS, err)
+		}
+		imported++
+	}
+
+	return imported, skipped, nil
+}
+
+// Close closes the store and releases resources.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) get(ctx context.Context, id int64) (*Entry, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+entryColumns+" FROM artifact_blacklist WHERE id = ?", id)
+	entry, err := scanEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query artifact entry: %w", err)
+	}
+	return entry, nil
+}
+
+// getByVariant returns the entry for a variant regardless of status, or nil
+func (s *SQLiteStore) getByVariant(ctx context.Context, normalizedHGVS string) (*Entry, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+entryColumns+" FROM artifact_blacklist WHERE normalized_hgvs = ?", normalizedHGVS)
+	entry, err := scanEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query artifact entry: %w", err)
+	}
+	return entry, nil
+}
@@ -0,0 +1,116 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "artifacts.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func proposeEntry(t *testing.T, store *SQLiteStore, hgvs string) *Entry {
+	t.Helper()
+	entry := &Entry{
+		NormalizedHGVS: hgvs,
+		Evidence:       "Seen in 14% of exomes since the v2 capture kit; absent on Sanger",
+		SubmittedBy:    "curator1",
+	}
+	require.NoError(t, store.Propose(context.Background(), entry))
+	return entry
+}
+
+func TestSQLiteStore_PendingEntryIsNotActive(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	entry := proposeEntry(t, store, "NM_000059.4:c.68-7T>A")
+
+	found, err := store.Lookup(ctx, entry.NormalizedHGVS)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	active, err := store.List(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+
+	all, err := store.List(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, StatusPending, all[0].Status())
+}
+
+func TestSQLiteStore_SignOff(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	entry := proposeEntry(t, store, "NM_000059.4:c.68-7T>A")
+
+	_, err := store.SignOff(ctx, entry.ID, "CURATOR1")
+	assert.True(t, errors.Is(err, ErrSelfReview))
+
+	signed, err := store.SignOff(ctx, entry.ID, "director")
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, signed.Status())
+	require.NotNil(t, signed.ReviewedAt)
+
+	_, err = store.SignOff(ctx, entry.ID, "director")
+	assert.True(t, errors.Is(err, ErrAlreadySignedOff))
+
+	found, err := store.Lookup(ctx, entry.NormalizedHGVS)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "director", found.ReviewedBy)
+}
+
+func TestSQLiteStore_ProposeDuplicateAndInvalid(t *testing.T) {
+	store := createTestStore(t)
+	proposeEntry(t, store, "NM_000059.4:c.68-7T>A")
+
+	err := store.Propose(context.Background(), &Entry{NormalizedHGVS: "NM_000059.4:c.68-7T>A", Evidence: "again", SubmittedBy: "curator2"})
+	assert.True(t, errors.Is(err, ErrAlreadyListed))
+
+	err = store.Propose(context.Background(), &Entry{NormalizedHGVS: "NM_000546.6:c.743G>A", SubmittedBy: "curator2"})
+	assert.True(t, errors.Is(err, ErrInvalidEntry))
+}
+
+func TestSQLiteStore_Remove(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	entry := proposeEntry(t, store, "NM_000059.4:c.68-7T>A")
+
+	require.NoError(t, store.Remove(ctx, entry.ID))
+	assert.True(t, errors.Is(store.Remove(ctx, entry.ID), ErrNotFound))
+}
+
+func TestSQLiteStore_ExportImportKeepsSignOff(t *testing.T) {
+	ctx := context.Background()
+	source := createTestStore(t)
+	signed := proposeEntry(t, source, "NM_000059.4:c.68-7T>A")
+	_, err := source.SignOff(ctx, signed.ID, "director")
+	require.NoError(t, err)
+	proposeEntry(t, source, "NM_007294.4:c.4096+3A>G")
+
+	var buf bytes.Buffer
+	require.NoError(t, source.ExportJSON(ctx, &buf))
+
+	target := createTestStore(t)
+	proposeEntry(t, target, "NM_007294.4:c.4096+3A>G")
+	imported, skipped, err := target.ImportJSON(ctx, &buf)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+	assert.Equal(t, 1, skipped)
+	found, err := target.Lookup(ctx, "NM_000059.4:c.68-7T>A")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "director", found.ReviewedBy)
+}
@@ -0,0 +1,116 @@
+// Package artifact manages the lab's blacklist of known sequencing and
+// pipeline artifact variants. Entries are proposed with supporting evidence
+// and only take effect once signed off by a second reviewer. Matching inputs
+// are annotated as probable artifacts and excluded from batch summaries.
+// The list can be exported and imported to move it between environments.
+package artifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when a blacklist entry does not exist.
+	ErrNotFound = errors.New("artifact entry not found")
+	// ErrInvalidEntry is returned when an entry is missing required fields.
+	ErrInvalidEntry = errors.New("invalid artifact entry")
+	// ErrAlreadyListed is returned when the variant is already on the blacklist.
+	ErrAlreadyListed = errors.New("variant already on artifact blacklist")
+	// ErrSelfReview is returned when the submitter tries to sign off their own entry.
+	ErrSelfReview = errors.New("artifact entry must be signed off by a different reviewer")
+	// ErrAlreadySignedOff is returned when signing off an active entry.
+	ErrAlreadySignedOff = errors.New("artifact entry already signed off")
+)
+
+// Entry status values
+const (
+	StatusPending = "pending"
+	StatusActive  = "active"
+)
+
+// Entry is a variant on the artifact blacklist.
+type Entry struct {
+	ID             int64      `json:"id,omitempty"`
+	NormalizedHGVS string     `json:"normalized_hgvs"`
+	Evidence       string     `json:"evidence"` // Why the variant is believed to be an artifact
+	SubmittedBy    string     `json:"submitted_by"`
+	ReviewedBy     string     `json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Active reports whether the entry has been signed off.
+func (e *Entry) Active() bool {
+	return e.ReviewedBy != ""
+}
+
+// Status returns the entry's review status.
+func (e *Entry) Status() string {
+	if e.Active() {
+		return StatusActive
+	}
+	return StatusPending
+}
+
+// Validate normalizes the entry and checks required fields.
+func (e *Entry) Validate() error {
+	e.NormalizedHGVS = strings.TrimSpace(e.NormalizedHGVS)
+	e.Evidence = strings.TrimSpace(e.Evidence)
+	e.SubmittedBy = strings.TrimSpace(e.SubmittedBy)
+	e.ReviewedBy = strings.TrimSpace(e.ReviewedBy)
+
+	if e.NormalizedHGVS == "" {
+		return fmt.Errorf("%w: variant is required", ErrInvalidEntry)
+	}
+	if e.Evidence == "" {
+		return fmt.Errorf("%w: evidence is required", ErrInvalidEntry)
+	}
+	if e.SubmittedBy == "" {
+		return fmt.Errorf("%w: submitter is required", ErrInvalidEntry)
+	}
+	if e.ReviewedBy != "" && strings.EqualFold(e.ReviewedBy, e.SubmittedBy) {
+		return ErrSelfReview
+	}
+	return nil
+}
+
+// Store defines the interface for artifact blacklist storage.
+type Store interface {
+	// Propose adds a pending entry awaiting sign-off.
+	Propose(ctx context.Context, entry *Entry) error
+
+	// SignOff activates a pending entry. The reviewer must differ from the submitter.
+	SignOff(ctx context.Context, id int64, reviewer string) (*Entry, error)
+
+	// Remove deletes an entry.
+	Remove(ctx context.Context, id int64) error
+
+	// Lookup returns the active entry for a variant, or nil if it is not blacklisted.
+	Lookup(ctx context.Context, normalizedHGVS string) (*Entry, error)
+
+	// List returns entries ordered by variant; pending entries are included on request.
+	List(ctx context.Context, includePending bool) ([]*Entry, error)
+
+	// ExportJSON exports all entries to a JSON writer.
+	ExportJSON(ctx context.Context, writer io.Writer) error
+
+	// ImportJSON imports entries from a JSON reader, keeping their sign-off.
+	// Variants already on the blacklist are skipped.
+	ImportJSON(ctx context.Context, reader io.Reader) (imported int, skipped int, err error)
+
+	// Close closes the store.
+	Close() error
+}
+
+// BlacklistExport represents the JSON export format.
+type BlacklistExport struct {
+	Version    string    `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	Count      int       `json:"count"`
+	Entries    []*Entry  `json:"entries"`
+}
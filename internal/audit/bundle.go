@@ -0,0 +1,226 @@
+package audit
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BundleFormat identifies the layout of export bundles.
+const BundleFormat = "acmg-audit-bundle/v1"
+
+// SignatureAlgorithm is the algorithm bundles are signed with.
+const SignatureAlgorithm = "ed25519"
+
+// MaxBundleRecords caps the number of records in one export bundle.
+const MaxBundleRecords = 10000
+
+var (
+	// ErrInvalidSigningKey is returned when a signing key cannot be parsed.
+	ErrInvalidSigningKey = errors.New("invalid audit signing key")
+	// ErrInvalidBundle is returned when a bundle is malformed or its signature does not verify.
+	ErrInvalidBundle = errors.New("invalid audit bundle")
+)
+
+// Bundle is an exported run of consecutive audit records. The records carry
+// their chain hashes, so an auditor can recompute every hash offline; the
+// signature covers the range and the head hash, which in turn covers every
+// record before it. PrevHash anchors the bundle to the record before FromID,
+// so consecutive bundles link up.
+type Bundle struct {
+	Format     string           `json:"format"`
+	ExportedAt time.Time        `json:"exported_at"`
+	FromID     int64            `json:"from_id"`
+	ToID       int64            `json:"to_id"`
+	PrevHash   string           `json:"prev_hash"`
+	HeadHash   string           `json:"head_hash"`
+	Records    []*Record        `json:"records"`
+	Signature  *BundleSignature `json:"signature,omitempty"` // Unset when no signing key is configured
+}
+
+// BundleSignature is the detached signature of a bundle.
+type BundleSignature struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`     // First 16 hex digits of the SHA-256 of the public key
+	PublicKey string `json:"public_key"` // Base64
+	Value     string `json:"value"`      // Base64
+}
+
+// signedContent is the message a bundle signature covers
+func (b *Bundle) signedContent() []byte {
+	return []byte(strings.Join([]string{
+		b.Format,
+		fmt.Sprint(b.FromID),
+		fmt.Sprint(b.ToID),
+		b.PrevHash,
+		b.HeadHash,
+		b.ExportedAt.UTC().Format(time.RFC3339Nano),
+		fmt.Sprint(len(b.Records)),
+	}, "\n"))
+}
+
+// Signer signs export bundles with an Ed25519 key.
+type Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewSigner creates a signer from a base64 Ed25519 seed (32 bytes) or
+// private key (64 bytes), e.g. generated with `openssl rand -base64 32`.
+func NewSigner(encoded string) (*Signer, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSigningKey, err)
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return &Signer{key: ed25519.NewKeyFromSeed(raw)}, nil
+	case ed25519.PrivateKeySize:
+		return &Signer{key: ed25519.PrivateKey(raw)}, nil
+	}
+	return nil, fmt.Errorf("%w: expected a %d-byte seed or %d-byte private key, got %d bytes",
+		ErrInvalidSigningKey, ed25519.SeedSize, ed25519.PrivateKeySize, len(raw))
+}
+
+// PublicKey returns the public key auditors verify bundles with.
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// Sign sets the signature of a bundle.
+func (s *Signer) Sign(bundle *Bundle) {
+	publicKey := s.PublicKey()
+	bundle.Signature = &BundleSignature{
+		Algorithm: SignatureAlgorithm,
+		KeyID:     KeyID(publicKey),
+		PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, bundle.signedContent())),
+	}
+}
+
+// KeyID returns the short identifier of a public key.
+func KeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Export bundles the records from fromID through toID, or through the newest
+// record when toID is 0, up to limit records (MaxBundleRecords when 0). The
+// bundle is signed when signer is set.
+func Export(ctx context.Context, store Store, fromID, toID int64, limit int, signer *Signer) (*Bundle, error) {
+	if fromID < 1 {
+		fromID = 1
+	}
+	if toID != 0 && toID < fromID {
+		return nil, fmt.Errorf("to_id must not be before from_id")
+	}
+	if limit <= 0 || limit > MaxBundleRecords {
+		limit = MaxBundleRecords
+	}
+
+	bundle := &Bundle{
+		Format:     BundleFormat,
+		ExportedAt: time.Now().UTC(),
+		Records:    []*Record{},
+	}
+	afterID := fromID - 1
+	for len(bundle.Records) < limit {
+		records, err := store.Chain(ctx, afterID, min(chainBatchSize, limit-len(bundle.Records)))
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if toID != 0 && record.ID > toID {
+				break
+			}
+			bundle.Records = append(bundle.Records, record)
+			afterID = record.ID
+		}
+		if len(records) == 0 || afterID == toID || records[len(records)-1].ID != afterID {
+			break
+		}
+	}
+
+	if len(bundle.Records) > 0 {
+		bundle.FromID = bundle.Records[0].ID
+		bundle.ToID = bundle.Records[len(bundle.Records)-1].ID
+		bundle.PrevHash = firstChained(bundle.Records).PrevHash
+		bundle.HeadHash = VerifyRecords(bundle.Records).HeadHash
+	}
+	if signer != nil {
+		signer.Sign(bundle)
+	}
+	return bundle, nil
+}
+
+// firstChained returns the first record with a hash, or an empty record
+func firstChained(records []*Record) *Record {
+	for _, record := range records {
+		if record.Hash != "" {
+			return record
+		}
+	}
+	return &Record{}
+}
+
+// VerifyBundle checks the signature of a bundle and the chain of its records.
+// When trusted is set the bundle must be signed with that key; otherwise the
+// key embedded in the bundle is used, and the caller should compare its key ID
+// with the one the lab publishes. Unsigned bundles fail verification.
+func VerifyBundle(bundle *Bundle, trusted ed25519.PublicKey) (*Verification, error) {
+	if bundle.Format != BundleFormat {
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidBundle, bundle.Format)
+	}
+	sig := bundle.Signature
+	if sig == nil {
+		return nil, fmt.Errorf("%w: bundle is not signed", ErrInvalidBundle)
+	}
+	if sig.Algorithm != SignatureAlgorithm {
+		return nil, fmt.Errorf("%w: unsupported signature algorithm %q", ErrInvalidBundle, sig.Algorithm)
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(sig.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: malformed public key", ErrInvalidBundle)
+	}
+	if trusted != nil && !trusted.Equal(ed25519.PublicKey(publicKey)) {
+		return nil, fmt.Errorf("%w: bundle was signed with key %s, not the trusted key %s", ErrInvalidBundle, KeyID(publicKey), KeyID(trusted))
+	}
+	value, err := base64.StdEncoding.DecodeString(sig.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidBundle)
+	}
+	if !ed25519.Verify(publicKey, bundle.signedContent(), value) {
+		return nil, fmt.Errorf("%w: signature does not match the bundle", ErrInvalidBundle)
+	}
+
+	verification := VerifyRecords(bundle.Records)
+	if !verification.Verified {
+		return verification, nil
+	}
+	switch {
+	case len(bundle.Records) > 0 && (bundle.Records[0].ID != bundle.FromID || bundle.Records[len(bundle.Records)-1].ID != bundle.ToID):
+		verification.Verified = false
+		verification.Problem = "records do not match the signed range"
+	case firstChained(bundle.Records).PrevHash != bundle.PrevHash:
+		verification.Verified = false
+		verification.Problem = "first record does not match the signed previous hash"
+	case verification.HeadHash != bundle.HeadHash:
+		verification.Verified = false
+		verification.Problem = "records do not match the signed head hash"
+	}
+	return verification, nil
+}
+
+// ParsePublicKey parses a base64 Ed25519 public key.
+func ParsePublicKey(encoded string) (ed25519.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: expected a base64 %d-byte Ed25519 public key", ErrInvalidSigningKey, ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(raw), nil
+}
@@ -0,0 +1,169 @@
+package audit
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSigningKey = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=" // 32-byte seed 0x00..0x1f
+
+func createTestSigner(t *testing.T) *Signer {
+	t.Helper()
+	signer, err := NewSigner(testSigningKey)
+	require.NoError(t, err)
+	return signer
+}
+
+func TestExport_SignedBundle(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	signer := createTestSigner(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i, variant := range []string{"VAR_1", "VAR_2", "VAR_3", "VAR_4"} {
+		appendRecord(t, store, variant, "NM_000492.4:c.1521_1523del", "Pathogenic", base.Add(time.Duration(i)*time.Hour))
+	}
+
+	// Act
+	bundle, err := Export(ctx, store, 2, 3, 0, signer)
+	require.NoError(t, err)
+
+	// Assert: the bundle holds the range and links to the record before it
+	require.Len(t, bundle.Records, 2)
+	assert.Equal(t, BundleFormat, bundle.Format)
+	assert.Equal(t, int64(2), bundle.FromID)
+	assert.Equal(t, int64(3), bundle.ToID)
+	first, err := store.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, first.Hash, bundle.PrevHash)
+	assert.Equal(t, bundle.Records[1].Hash, bundle.HeadHash)
+	require.NotNil(t, bundle.Signature)
+	assert.Equal(t, KeyID(signer.PublicKey()), bundle.Signature.KeyID)
+
+	// The bundle verifies after a round trip through JSON
+	raw, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	var exported Bundle
+	require.NoError(t, json.Unmarshal(raw, &exported))
+	verification, err := VerifyBundle(&exported, signer.PublicKey())
+	require.NoError(t, err)
+	assert.True(t, verification.Verified)
+	assert.Equal(t, 2, verification.RecordsChecked)
+
+	// Export through the newest record, limited
+	rest, err := Export(ctx, store, 3, 0, 0, nil)
+	require.NoError(t, err)
+	assert.Len(t, rest.Records, 2)
+	assert.Nil(t, rest.Signature)
+	limited, err := Export(ctx, store, 1, 0, 3, nil)
+	require.NoError(t, err)
+	assert.Len(t, limited.Records, 3)
+
+	_, err = Export(ctx, store, 3, 2, 0, nil)
+	assert.Error(t, err)
+}
+
+func TestVerifyBundle_DetectsTampering(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	signer := createTestSigner(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i, variant := range []string{"VAR_1", "VAR_2", "VAR_3"} {
+		appendRecord(t, store, variant, "NM_000492.4:c.1521_1523del", "Pathogenic", base.Add(time.Duration(i)*time.Hour))
+	}
+	export := func() *Bundle {
+		bundle, err := Export(ctx, store, 0, 0, 0, signer)
+		require.NoError(t, err)
+		return bundle
+	}
+
+	// A record edited in the bundle no longer matches its hash
+	edited := export()
+	edited.Records[1].Classification = "Benign"
+	verification, err := VerifyBundle(edited, nil)
+	require.NoError(t, err)
+	assert.False(t, verification.Verified)
+	assert.Equal(t, edited.Records[1].ID, verification.BrokenAt)
+
+	// A record dropped from the bundle no longer matches the signed record count
+	dropped := export()
+	dropped.Records = append(dropped.Records[:1], dropped.Records[2:]...)
+	_, err = VerifyBundle(dropped, nil)
+	assert.True(t, errors.Is(err, ErrInvalidBundle))
+
+	// The last record dropped and the range changed to match: the signature fails
+	truncated := export()
+	truncated.Records = truncated.Records[:2]
+	truncated.ToID = truncated.Records[1].ID
+	_, err = VerifyBundle(truncated, nil)
+	assert.True(t, errors.Is(err, ErrInvalidBundle))
+
+	// Re-signed with another key
+	other, err := NewSigner(base64.StdEncoding.EncodeToString(make([]byte, ed25519.SeedSize)))
+	require.NoError(t, err)
+	resigned := export()
+	other.Sign(resigned)
+	_, err = VerifyBundle(resigned, signer.PublicKey())
+	assert.True(t, errors.Is(err, ErrInvalidBundle))
+
+	unsigned := export()
+	unsigned.Signature = nil
+	_, err = VerifyBundle(unsigned, nil)
+	assert.True(t, errors.Is(err, ErrInvalidBundle))
+}
+
+func TestVerifyBundle_RedactedRequests(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	signer := createTestSigner(t)
+	require.NoError(t, store.Append(ctx, &Record{
+		HGVSNotation:  "NM_000492.4:c.1521_1523del",
+		Request:       json.RawMessage(`{"hgvs_notation":"NM_000492.4:c.1521_1523del","proband_id":"P-0042"}`),
+		EngineVersion: "v0.1.0",
+	}))
+	appendRecord(t, store, "VAR_2", "NM_007294.4:c.5266dupC", "Pathogenic", time.Time{})
+
+	// Act: redact the first request before signing
+	bundle, err := Export(ctx, store, 0, 0, 0, nil)
+	require.NoError(t, err)
+	require.NoError(t, bundle.Records[0].RedactRequest(json.RawMessage(`{"hgvs_notation":"NM_000492.4:c.1521_1523del","proband_id":"[REDACTED]"}`)))
+	signer.Sign(bundle)
+
+	// Assert: the record hash still verifies through the original request's digest
+	assert.True(t, bundle.Records[0].RequestRedacted)
+	assert.Len(t, bundle.Records[0].RequestDigest, 64)
+	verification, err := VerifyBundle(bundle, signer.PublicKey())
+	require.NoError(t, err)
+	assert.True(t, verification.Verified)
+
+	bundle.Records[0].RequestDigest = strings.Repeat("0", 64)
+	verification, err = VerifyBundle(bundle, signer.PublicKey())
+	require.NoError(t, err)
+	assert.False(t, verification.Verified)
+}
+
+func TestNewSigner(t *testing.T) {
+	signer := createTestSigner(t)
+	fromPrivate, err := NewSigner(base64.StdEncoding.EncodeToString(signer.key))
+	require.NoError(t, err)
+	assert.True(t, signer.PublicKey().Equal(fromPrivate.PublicKey()))
+
+	publicKey, err := ParsePublicKey(base64.StdEncoding.EncodeToString(signer.PublicKey()))
+	require.NoError(t, err)
+	assert.True(t, signer.PublicKey().Equal(publicKey))
+
+	for _, invalid := range []string{"", "not base64!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		_, err := NewSigner(invalid)
+		assert.True(t, errors.Is(err, ErrInvalidSigningKey), invalid)
+	}
+	_, err = ParsePublicKey(strings.Repeat("A", 8))
+	assert.True(t, errors.Is(err, ErrInvalidSigningKey))
+}
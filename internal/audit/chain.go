@@ -0,0 +1,240 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Records are hash-chained so the trail is tamper-evident: each record holds
+// the hash of the record appended before it and a SHA-256 hash over its own
+// content and that previous hash. Editing a stored record changes its hash
+// and deleting one breaks the link of the record after it, so VerifyChain
+// detects both. Records written before chaining was introduced have no hash;
+// they are counted as unchained and must all precede the first chained record.
+
+// hashPrecision is the time precision kept in hashed records. PostgreSQL
+// stores timestamps in microseconds, so finer times would not verify.
+const hashPrecision = time.Microsecond
+
+// chainBatchSize is the number of records read per page while walking the chain
+const chainBatchSize = 500
+
+// hashedRecord is the canonical content covered by a record hash. JSON
+// documents are re-encoded so whitespace and key order, which PostgreSQL
+// JSONB does not preserve, do not change the hash. The request is covered
+// through its digest, so a record whose request was redacted for export
+// still verifies.
+type hashedRecord struct {
+	PrevHash          string            `json:"prev_hash"`
+	VariantID         string            `json:"variant_id"`
+	HGVSNotation      string            `json:"hgvs_notation"`
+	RequestDigest     string            `json:"request_sha256"`
+	Evidence          json.RawMessage   `json:"evidence"`
+	AppliedRules      json.RawMessage   `json:"applied_rules"`
+	Classification    string            `json:"classification"`
+	Confidence        string            `json:"confidence"`
+	Error             string            `json:"error"`
+	EngineVersion     string            `json:"engine_version"`
+	ScoringMode       string            `json:"scoring_mode"`
+	ThresholdRevision int64             `json:"threshold_revision"`
+	Specification     string            `json:"specification"`
+	ConfigCommit      string            `json:"config_commit"`
+	DatasetVersions   map[string]string `json:"dataset_versions"`
+	CreatedAt         string            `json:"created_at"`
+}
+
+// ComputeHash returns the hex SHA-256 hash of the record content and its
+// previous hash. The ID is not covered; order is kept by the chain itself.
+func (r *Record) ComputeHash() (string, error) {
+	content := hashedRecord{
+		PrevHash:          r.PrevHash,
+		VariantID:         r.VariantID,
+		HGVSNotation:      r.HGVSNotation,
+		Classification:    r.Classification,
+		Confidence:        r.Confidence,
+		Error:             r.Error,
+		EngineVersion:     r.EngineVersion,
+		ScoringMode:       r.ScoringMode,
+		ThresholdRevision: r.ThresholdRevision,
+		Specification:     r.Specification,
+		ConfigCommit:      r.ConfigCommit,
+		CreatedAt:         r.CreatedAt.UTC().Truncate(hashPrecision).Format(time.RFC3339Nano),
+	}
+	if len(r.DatasetVersions) > 0 {
+		content.DatasetVersions = r.DatasetVersions
+	}
+	var err error
+	if content.RequestDigest, err = r.requestDigest(); err != nil {
+		return "", err
+	}
+	if content.Evidence, err = canonicalJSON(r.Evidence); err != nil {
+		return "", fmt.Errorf("invalid evidence: %w", err)
+	}
+	if content.AppliedRules, err = canonicalJSON(r.AppliedRules); err != nil {
+		return "", fmt.Errorf("invalid applied rules: %w", err)
+	}
+
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// requestDigest returns the hex SHA-256 of the canonical request, or the
+// recorded digest of a redacted request
+func (r *Record) requestDigest() (string, error) {
+	if r.RequestRedacted {
+		return r.RequestDigest, nil
+	}
+	request, err := canonicalJSON(r.Request)
+	if err != nil {
+		return "", fmt.Errorf("invalid request: %w", err)
+	}
+	sum := sha256.Sum256(request)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RedactRequest replaces the request with a redacted copy, keeping the
+// digest of the original so the record hash still verifies.
+func (r *Record) RedactRequest(redacted json.RawMessage) error {
+	digest, err := r.requestDigest()
+	if err != nil {
+		return err
+	}
+	r.Request = redacted
+	r.RequestDigest = digest
+	r.RequestRedacted = true
+	return nil
+}
+
+// canonicalJSON re-encodes a JSON document with sorted keys and no
+// whitespace; an empty document stays empty
+func canonicalJSON(raw json.RawMessage) (json.RawMessage, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// seal links a record to the previous one and sets its hash. Stores call it
+// while holding the chain lock, just before inserting the record.
+func (r *Record) seal(prevHash string) error {
+	r.CreatedAt = r.CreatedAt.UTC().Truncate(hashPrecision)
+	r.PrevHash = prevHash
+	r.RequestRedacted = false
+	r.RequestDigest = ""
+	hash, err := r.ComputeHash()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+	r.Hash = hash
+	return nil
+}
+
+// Verification is the outcome of checking a run of records.
+type Verification struct {
+	Verified       bool   `json:"verified"`
+	RecordsChecked int    `json:"records_checked"`
+	Unchained      int    `json:"unchained_records,omitempty"` // Written before hash chaining
+	FirstID        int64  `json:"first_id,omitempty"`
+	LastID         int64  `json:"last_id,omitempty"`
+	HeadHash       string `json:"head_hash,omitempty"` // Hash of the last chained record
+	BrokenAt       int64  `json:"broken_at,omitempty"` // First record that failed verification
+	Problem        string `json:"problem,omitempty"`
+}
+
+// chainVerifier checks records one at a time, in ID order
+type chainVerifier struct {
+	result      Verification
+	chained     bool // A chained record was seen
+	fromGenesis bool // The first chained record must have no previous hash
+}
+
+// check verifies the next record, returning false at the first break
+func (v *chainVerifier) check(record *Record) bool {
+	if v.result.RecordsChecked == 0 {
+		v.result.FirstID = record.ID
+	}
+	v.result.RecordsChecked++
+	v.result.LastID = record.ID
+
+	if record.Hash == "" {
+		if v.chained {
+			return v.fail(record.ID, "record has no hash but follows chained records")
+		}
+		v.result.Unchained++
+		return true
+	}
+	if (v.chained || v.fromGenesis) && record.PrevHash != v.result.HeadHash {
+		return v.fail(record.ID, "previous hash does not match the preceding record; a record was removed or reordered")
+	}
+	hash, err := record.ComputeHash()
+	if err != nil {
+		return v.fail(record.ID, err.Error())
+	}
+	if hash != record.Hash {
+		return v.fail(record.ID, "record content does not match its hash; the record was modified")
+	}
+	v.chained = true
+	v.result.HeadHash = record.Hash
+	return true
+}
+
+func (v *chainVerifier) fail(id int64, problem string) bool {
+	v.result.BrokenAt = id
+	v.result.Problem = problem
+	return false
+}
+
+func (v *chainVerifier) verification() *Verification {
+	result := v.result
+	result.Verified = result.BrokenAt == 0
+	return &result
+}
+
+// VerifyRecords checks that records, in ID order, form an unbroken chain.
+// The first chained record anchors the run; its previous hash is not checked.
+func VerifyRecords(records []*Record) *Verification {
+	v := &chainVerifier{}
+	for _, record := range records {
+		if !v.check(record) {
+			break
+		}
+	}
+	return v.verification()
+}
+
+// VerifyChain walks the whole audit trail of a store and checks its chain
+// from the first chained record on.
+func VerifyChain(ctx context.Context, store Store) (*Verification, error) {
+	v := &chainVerifier{fromGenesis: true}
+	var afterID int64
+	for {
+		records, err := store.Chain(ctx, afterID, chainBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if !v.check(record) {
+				return v.verification(), nil
+			}
+			afterID = record.ID
+		}
+		if len(records) < chainBatchSize {
+			return v.verification(), nil
+		}
+	}
+}
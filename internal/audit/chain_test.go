@@ -0,0 +1,155 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+)
+
+func TestSQLiteStore_HashChain(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	base := time.Date(2026, 3, 1, 9, 30, 0, 123456789, time.UTC)
+	first := appendRecord(t, store, "VAR_1", "NM_000492.4:c.1521_1523del", "Pathogenic", base)
+	second := appendRecord(t, store, "VAR_2", "NM_007294.4:c.5266dupC", "Pathogenic", base.Add(time.Hour))
+
+	// Assert: each record links to the one before it
+	assert.Empty(t, first.PrevHash)
+	assert.Len(t, first.Hash, 64)
+	assert.Equal(t, first.Hash, second.PrevHash)
+
+	got, err := store.Get(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, second.Hash, got.Hash)
+	hash, err := got.ComputeHash()
+	require.NoError(t, err)
+	assert.Equal(t, got.Hash, hash, "a stored record hashes to its stored hash")
+
+	verification, err := VerifyChain(ctx, store)
+	require.NoError(t, err)
+	assert.True(t, verification.Verified)
+	assert.Equal(t, 2, verification.RecordsChecked)
+	assert.Equal(t, second.Hash, verification.HeadHash)
+}
+
+func TestVerifyChain_DetectsTampering(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("modified record", func(t *testing.T) {
+		store := createTestStore(t)
+		appendRecord(t, store, "VAR_1", "NM_000492.4:c.1521_1523del", "Pathogenic", base)
+		edited := appendRecord(t, store, "VAR_2", "NM_007294.4:c.5266dupC", "Pathogenic", base)
+		appendRecord(t, store, "VAR_3", "NM_000546.6:c.743G>A", "Likely pathogenic", base)
+		_, err := store.db.Exec(`UPDATE classification_audit SET classification = 'Benign' WHERE id = ?`, edited.ID)
+		require.NoError(t, err)
+
+		verification, err := VerifyChain(ctx, store)
+		require.NoError(t, err)
+		assert.False(t, verification.Verified)
+		assert.Equal(t, edited.ID, verification.BrokenAt)
+		assert.Contains(t, verification.Problem, "modified")
+	})
+
+	t.Run("removed record", func(t *testing.T) {
+		store := createTestStore(t)
+		appendRecord(t, store, "VAR_1", "NM_000492.4:c.1521_1523del", "Pathogenic", base)
+		removed := appendRecord(t, store, "VAR_2", "NM_007294.4:c.5266dupC", "Pathogenic", base)
+		next := appendRecord(t, store, "VAR_3", "NM_000546.6:c.743G>A", "Likely pathogenic", base)
+		_, err := store.db.Exec(`DELETE FROM classification_audit WHERE id = ?`, removed.ID)
+		require.NoError(t, err)
+
+		verification, err := VerifyChain(ctx, store)
+		require.NoError(t, err)
+		assert.False(t, verification.Verified)
+		assert.Equal(t, next.ID, verification.BrokenAt)
+		assert.Contains(t, verification.Problem, "removed")
+	})
+
+	t.Run("removed first record", func(t *testing.T) {
+		store := createTestStore(t)
+		removed := appendRecord(t, store, "VAR_1", "NM_000492.4:c.1521_1523del", "Pathogenic", base)
+		next := appendRecord(t, store, "VAR_2", "NM_007294.4:c.5266dupC", "Pathogenic", base)
+		_, err := store.db.Exec(`DELETE FROM classification_audit WHERE id = ?`, removed.ID)
+		require.NoError(t, err)
+
+		verification, err := VerifyChain(ctx, store)
+		require.NoError(t, err)
+		assert.False(t, verification.Verified)
+		assert.Equal(t, next.ID, verification.BrokenAt)
+	})
+}
+
+func TestVerifyChain_UnchainedLegacyRecords(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "audit.db")
+
+	// A database created before records were chained
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE classification_audit (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, variant_id TEXT DEFAULT '', hgvs_notation TEXT DEFAULT '',
+		request TEXT NOT NULL, evidence TEXT, applied_rules TEXT, classification TEXT DEFAULT '',
+		confidence TEXT DEFAULT '', error TEXT DEFAULT '', engine_version TEXT NOT NULL,
+		scoring_mode TEXT DEFAULT '', threshold_revision INTEGER DEFAULT 0, specification TEXT DEFAULT '',
+		config_commit TEXT DEFAULT '', dataset_versions TEXT, created_at DATETIME NOT NULL)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO classification_audit (variant_id, request, engine_version, created_at) VALUES ('VAR_0', '{}', 'v0.1.0', ?)`, time.Now().UTC())
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	store, err := NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	defer store.Close()
+
+	// Act
+	record := &Record{
+		VariantID: "VAR_1", Request: json.RawMessage(`{ "b": 1, "a": [1, 2] }`), EngineVersion: "v0.1.0",
+		DatasetVersions: provenance.Versions{provenance.ClinVar: "2024-05-01"},
+	}
+	require.NoError(t, store.Append(ctx, record))
+
+	// Assert: the chain starts after the legacy record
+	assert.Empty(t, record.PrevHash)
+	verification, err := VerifyChain(ctx, store)
+	require.NoError(t, err)
+	assert.True(t, verification.Verified)
+	assert.Equal(t, 1, verification.Unchained)
+	assert.Equal(t, 2, verification.RecordsChecked)
+
+	// A record without a hash after the chain started was inserted around it
+	_, err = store.db.Exec(`INSERT INTO classification_audit (variant_id, request, engine_version, created_at) VALUES ('VAR_2', '{}', 'v0.1.0', ?)`, time.Now().UTC())
+	require.NoError(t, err)
+	verification, err = VerifyChain(ctx, store)
+	require.NoError(t, err)
+	assert.False(t, verification.Verified)
+	assert.Equal(t, int64(3), verification.BrokenAt)
+}
+
+func TestRecord_ComputeHashCanonicalJSON(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	a := &Record{HGVSNotation: "NM_000492.4:c.1521_1523del", Request: json.RawMessage(`{"a":1,"b":[true,null]}`), EngineVersion: "v0.1.0", CreatedAt: created}
+	b := &Record{HGVSNotation: "NM_000492.4:c.1521_1523del", Request: json.RawMessage("{\n  \"b\": [true, null],\n  \"a\": 1.0\n}"), EngineVersion: "v0.1.0", CreatedAt: created.In(time.FixedZone("JST", 9*3600))}
+
+	hashA, err := a.ComputeHash()
+	require.NoError(t, err)
+	hashB, err := b.ComputeHash()
+	require.NoError(t, err)
+	assert.Equal(t, hashA, hashB, "formatting, key order and time zone do not change the hash")
+
+	b.Classification = "Pathogenic"
+	hashB, err = b.ComputeHash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hashA, hashB)
+
+	_, err = (&Record{Request: json.RawMessage(`{`)}).ComputeHash()
+	assert.Error(t, err)
+}
@@ -0,0 +1,352 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+)
+
+// Direction of a classification change
+const (
+	DirectionUnchanged  = "unchanged"
+	DirectionUpgraded   = "upgraded"   // Moved toward pathogenic
+	DirectionDowngraded = "downgraded" // Moved toward benign
+)
+
+// Evidence source change kinds
+const (
+	SourceAdded   = "added"
+	SourceRemoved = "removed"
+	SourceUpdated = "updated"
+)
+
+// classRank orders classifications from benign to pathogenic, and somatic
+// tiers from benign to strong clinical significance
+var classRank = map[string]int{
+	string(domain.BENIGN):            0,
+	string(domain.LIKELY_BENIGN):     1,
+	string(domain.VUS):               2,
+	string(domain.LIKELY_PATHOGENIC): 3,
+	string(domain.PATHOGENIC):        4,
+	string(domain.TIER_IV):           0,
+	string(domain.TIER_III):          1,
+	string(domain.TIER_II):           2,
+	string(domain.TIER_I):            3,
+}
+
+// classTier groups classifications into the tiers that drive clinical action.
+// Moving between tiers warrants a reclassification notice; moving within one,
+// e.g. likely pathogenic to pathogenic, usually does not.
+func classTier(classification string) string {
+	switch classification {
+	case string(domain.PATHOGENIC), string(domain.LIKELY_PATHOGENIC):
+		return "pathogenic"
+	case string(domain.VUS):
+		return "uncertain"
+	case string(domain.LIKELY_BENIGN), string(domain.BENIGN):
+		return "benign"
+	case string(domain.TIER_I), string(domain.TIER_II):
+		return "actionable"
+	case string(domain.TIER_III):
+		return "uncertain"
+	case string(domain.TIER_IV):
+		return "benign"
+	}
+	return ""
+}
+
+// CriterionChange describes an ACMG/AMP criterion that was added, removed or
+// applied at a different strength between two classifications.
+type CriterionChange struct {
+	Code             string `json:"code"`
+	PreviousStrength string `json:"previous_strength,omitempty"`
+	CurrentStrength  string `json:"current_strength,omitempty"`
+}
+
+// SourceChange describes an evidence source whose data changed.
+type SourceChange struct {
+	Source string `json:"source"`
+	Change string `json:"change"` // added, removed or updated
+}
+
+// Diff compares two classifications of the same variant.
+type Diff struct {
+	Variant                 string            `json:"variant"`
+	PreviousRecordID        int64             `json:"previous_record_id"`
+	CurrentRecordID         int64             `json:"current_record_id"`
+	PreviousAt              time.Time         `json:"previous_at"`
+	CurrentAt               time.Time         `json:"current_at"`
+	PreviousClassification  string            `json:"previous_classification"`
+	CurrentClassification   string            `json:"current_classification"`
+	ClassChanged            bool              `json:"class_changed"`
+	Direction               string            `json:"direction"`
+	NotificationRecommended bool              `json:"notification_recommended"` // Moved between benign, uncertain and pathogenic tiers
+	PreviousConfidence      string            `json:"previous_confidence,omitempty"`
+	CurrentConfidence       string            `json:"current_confidence,omitempty"`
+	CriteriaCompared        bool              `json:"criteria_compared"` // False when either call has no recorded rules, e.g. a known benign shortcut
+	CriteriaAdded           []CriterionChange `json:"criteria_added,omitempty"`
+	CriteriaRemoved         []CriterionChange `json:"criteria_removed,omitempty"`
+	CriteriaStrengthChanged []CriterionChange `json:"criteria_strength_changed,omitempty"`
+	EvidenceSources         []SourceChange    `json:"evidence_sources_changed,omitempty"`
+	ConfigurationChanges    []string          `json:"configuration_changes,omitempty"` // Engine version, scoring mode, thresholds or VCEP specification
+	Summary                 string            `json:"summary"`
+}
+
+// Changed reports whether anything differs between the two classifications.
+func (d *Diff) Changed() bool {
+	return d.ClassChanged || d.PreviousConfidence != d.CurrentConfidence ||
+		len(d.CriteriaAdded) > 0 || len(d.CriteriaRemoved) > 0 || len(d.CriteriaStrengthChanged) > 0 ||
+		len(d.EvidenceSources) > 0 || len(d.ConfigurationChanges) > 0
+}
+
+// Previous returns the most recent successful classification of the same
+// variant recorded before the given record, or nil if there is none.
+func Previous(ctx context.Context, store Store, record *Record) (*Record, error) {
+	records, err := store.Query(ctx, Filter{
+		Variant:       record.HGVSNotation,
+		SucceededOnly: true,
+		Until:         record.CreatedAt.Add(time.Nanosecond),
+		Limit:         2,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		if r.ID != record.ID {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// ruleOutcome is the part of a recorded rule result the diff needs
+type ruleOutcome struct {
+	Code     string `json:"rule_code"`
+	Strength string `json:"strength"`
+	Applied  bool   `json:"applied"`
+}
+
+// Compare builds the diff from a previous to a current classification.
+// Both records must be successful classifications.
+func Compare(previous, current *Record) (*Diff, error) {
+	if previous == nil || current == nil {
+		return nil, fmt.Errorf("%w: two records are required", ErrInvalidRecord)
+	}
+	if previous.Error != "" || current.Error != "" {
+		return nil, fmt.Errorf("%w: failed classifications cannot be compared", ErrInvalidRecord)
+	}
+
+	diff := &Diff{
+		Variant:                current.HGVSNotation,
+		PreviousRecordID:       previous.ID,
+		CurrentRecordID:        current.ID,
+		PreviousAt:             previous.CreatedAt,
+		CurrentAt:              current.CreatedAt,
+		PreviousClassification: previous.Classification,
+		CurrentClassification:  current.Classification,
+		ClassChanged:           previous.Classification != current.Classification,
+		Direction:              DirectionUnchanged,
+		PreviousConfidence:     previous.Confidence,
+		CurrentConfidence:      current.Confidence,
+	}
+	if diff.Variant == "" {
+		diff.Variant = previous.HGVSNotation
+	}
+
+	if diff.ClassChanged {
+		prevRank, okPrev := classRank[previous.Classification]
+		curRank, okCur := classRank[current.Classification]
+		if okPrev && okCur {
+			if curRank > prevRank {
+				diff.Direction = DirectionUpgraded
+			} else {
+				diff.Direction = DirectionDowngraded
+			}
+		}
+		diff.NotificationRecommended = classTier(previous.Classification) != classTier(current.Classification)
+	}
+
+	if err := diff.compareCriteria(previous.AppliedRules, current.AppliedRules); err != nil {
+		return nil, err
+	}
+	if err := diff.compareEvidence(previous.Evidence, current.Evidence); err != nil {
+		return nil, err
+	}
+	diff.compareConfiguration(previous, current)
+	diff.Summary = diff.summarize()
+	return diff, nil
+}
+
+// compareCriteria diffs the criteria that were met in each classification
+func (d *Diff) compareCriteria(previousRules, currentRules json.RawMessage) error {
+	if len(previousRules) == 0 || len(currentRules) == 0 {
+		return nil
+	}
+	previous, err := appliedCriteria(previousRules)
+	if err != nil {
+		return err
+	}
+	current, err := appliedCriteria(currentRules)
+	if err != nil {
+		return err
+	}
+	d.CriteriaCompared = true
+
+	for code, strength := range current {
+		prevStrength, ok := previous[code]
+		switch {
+		case !ok:
+			d.CriteriaAdded = append(d.CriteriaAdded, CriterionChange{Code: code, CurrentStrength: strength})
+		case prevStrength != strength:
+			d.CriteriaStrengthChanged = append(d.CriteriaStrengthChanged, CriterionChange{Code: code, PreviousStrength: prevStrength, CurrentStrength: strength})
+		}
+	}
+	for code, strength := range previous {
+		if _, ok := current[code]; !ok {
+			d.CriteriaRemoved = append(d.CriteriaRemoved, CriterionChange{Code: code, PreviousStrength: strength})
+		}
+	}
+
+	for _, list := range [][]CriterionChange{d.CriteriaAdded, d.CriteriaRemoved, d.CriteriaStrengthChanged} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	}
+	return nil
+}
+
+// appliedCriteria returns the strength of each met criterion
+func appliedCriteria(raw json.RawMessage) (map[string]string, error) {
+	var rules []ruleOutcome
+	if err := json.Unmarshal(raw, &rules); err != nil {
+		return nil, fmt.Errorf("failed to decode applied rules: %w", err)
+	}
+	applied := make(map[string]string)
+	for _, rule := range rules {
+		if rule.Applied {
+			applied[rule.Code] = rule.Strength
+		}
+	}
+	return applied, nil
+}
+
+// compareEvidence diffs the evidence sources; the gathering timestamp is ignored
+func (d *Diff) compareEvidence(previousEvidence, currentEvidence json.RawMessage) error {
+	if len(previousEvidence) == 0 && len(currentEvidence) == 0 {
+		return nil
+	}
+	previous, err := evidenceSources(previousEvidence)
+	if err != nil {
+		return err
+	}
+	current, err := evidenceSources(currentEvidence)
+	if err != nil {
+		return err
+	}
+
+	for source, data := range current {
+		prevData, ok := previous[source]
+		switch {
+		case !ok:
+			d.EvidenceSources = append(d.EvidenceSources, SourceChange{Source: source, Change: SourceAdded})
+		case !bytes.Equal(prevData, data):
+			d.EvidenceSources = append(d.EvidenceSources, SourceChange{Source: source, Change: SourceUpdated})
+		}
+	}
+	for source := range previous {
+		if _, ok := current[source]; !ok {
+			d.EvidenceSources = append(d.EvidenceSources, SourceChange{Source: source, Change: SourceRemoved})
+		}
+	}
+	sort.Slice(d.EvidenceSources, func(i, j int) bool { return d.EvidenceSources[i].Source < d.EvidenceSources[j].Source })
+	return nil
+}
+
+// evidenceSources splits aggregated evidence into canonical JSON per source
+func evidenceSources(raw json.RawMessage) (map[string][]byte, error) {
+	sources := make(map[string][]byte)
+	if len(raw) == 0 {
+		return sources, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode evidence: %w", err)
+	}
+	for key, value := range fields {
+		if key == "gathered_at" || value == nil {
+			continue
+		}
+		// Re-encoding a decoded value sorts map keys, so equal data compares equal
+		canonical, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode evidence source %s: %w", key, err)
+		}
+		sources[strings.TrimSuffix(key, "_data")] = canonical
+	}
+	return sources, nil
+}
+
+// compareConfiguration notes changes in the engine and rule configuration
+func (d *Diff) compareConfiguration(previous, current *Record) {
+	note := func(name, prev, cur string) {
+		if prev != cur {
+			d.ConfigurationChanges = append(d.ConfigurationChanges, fmt.Sprintf("%s: %s -> %s", name, orNone(prev), orNone(cur)))
+		}
+	}
+	note("engine_version", previous.EngineVersion, current.EngineVersion)
+	note("scoring_mode", previous.ScoringMode, current.ScoringMode)
+	note("threshold_revision", revision(previous.ThresholdRevision), revision(current.ThresholdRevision))
+	note("vcep_specification", previous.Specification, current.Specification)
+	note("config_commit", previous.ConfigCommit, current.ConfigCommit)
+	for _, change := range provenance.Changes(previous.DatasetVersions, current.DatasetVersions) {
+		d.ConfigurationChanges = append(d.ConfigurationChanges, "dataset "+change)
+	}
+}
+
+func revision(r int64) string {
+	if r == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", r)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// summarize describes the diff in one sentence
+func (d *Diff) summarize() string {
+	var summary string
+	if d.ClassChanged {
+		summary = fmt.Sprintf("Classification %s from %s to %s", d.Direction, d.PreviousClassification, d.CurrentClassification)
+	} else {
+		summary = fmt.Sprintf("Classification unchanged (%s)", d.CurrentClassification)
+	}
+
+	var parts []string
+	if n := len(d.CriteriaAdded) + len(d.CriteriaRemoved) + len(d.CriteriaStrengthChanged); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d criteria changed", n))
+	}
+	if n := len(d.EvidenceSources); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d evidence sources changed", n))
+	}
+	if len(d.ConfigurationChanges) > 0 {
+		parts = append(parts, "rule configuration changed")
+	}
+	if len(parts) > 0 {
+		summary += "; " + strings.Join(parts, ", ")
+	}
+	if d.NotificationRecommended {
+		summary += "; reclassification notice recommended"
+	}
+	return summary
+}
@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+)
+
+func classifiedRecord(classification, rules, evidence string) *Record {
+	return &Record{
+		HGVSNotation:   "NM_000492.4:c.350G>A",
+		Request:        json.RawMessage(`{}`),
+		Classification: classification,
+		Confidence:     "Medium",
+		AppliedRules:   json.RawMessage(rules),
+		Evidence:       json.RawMessage(evidence),
+		EngineVersion:  "v0.1.0",
+		ScoringMode:    "bayesian",
+	}
+}
+
+func TestCompare_ClassUpgradeAcrossTiers(t *testing.T) {
+	previous := classifiedRecord("VUS",
+		`[{"rule_code":"PM2","strength":"moderate","applied":true},{"rule_code":"PP3","strength":"supporting","applied":true},{"rule_code":"PS3","strength":"strong","applied":false}]`,
+		`{"population_data":{"allele_frequency":0.0001},"clinvar_data":{"clinical_significance":"Uncertain significance"},"gathered_at":"2026-01-01T00:00:00Z"}`)
+	current := classifiedRecord("LIKELY_PATHOGENIC",
+		`[{"rule_code":"PM2","strength":"supporting","applied":true},{"rule_code":"PS3","strength":"strong","applied":true}]`,
+		`{"population_data":{"allele_frequency":0.0001},"clinvar_data":{"clinical_significance":"Likely pathogenic"},"literature_data":{"citations":3},"gathered_at":"2026-06-01T00:00:00Z"}`)
+	current.ThresholdRevision = 2
+	current.ConfigCommit = "3f2c9a1e"
+	previous.DatasetVersions = provenance.Versions{provenance.ClinVar: "2026-01-05", provenance.GnomAD: "gnomad_r4"}
+	current.DatasetVersions = provenance.Versions{provenance.ClinVar: "2026-06-02", provenance.GnomAD: "gnomad_r4"}
+
+	diff, err := Compare(previous, current)
+
+	require.NoError(t, err)
+	assert.True(t, diff.ClassChanged)
+	assert.Equal(t, DirectionUpgraded, diff.Direction)
+	assert.True(t, diff.NotificationRecommended)
+	assert.True(t, diff.CriteriaCompared)
+	assert.Equal(t, []CriterionChange{{Code: "PS3", CurrentStrength: "strong"}}, diff.CriteriaAdded)
+	assert.Equal(t, []CriterionChange{{Code: "PP3", PreviousStrength: "supporting"}}, diff.CriteriaRemoved)
+	assert.Equal(t, []CriterionChange{{Code: "PM2", PreviousStrength: "moderate", CurrentStrength: "supporting"}}, diff.CriteriaStrengthChanged)
+	assert.Equal(t, []SourceChange{{Source: "clinvar", Change: SourceUpdated}, {Source: "literature", Change: SourceAdded}}, diff.EvidenceSources)
+	assert.Equal(t, []string{
+		"threshold_revision: none -> 2", "config_commit: none -> 3f2c9a1e", "dataset clinvar: 2026-01-05 -> 2026-06-02",
+	}, diff.ConfigurationChanges)
+	assert.Contains(t, diff.Summary, "upgraded from VUS to LIKELY_PATHOGENIC")
+	assert.True(t, diff.Changed())
+}
+
+func TestCompare_WithinTierAndUnchanged(t *testing.T) {
+	rules := `[{"rule_code":"PVS1","strength":"very_strong","applied":true}]`
+	evidence := `{"population_data":{"allele_frequency":0,"allele_count":0}}`
+
+	within, err := Compare(classifiedRecord("PATHOGENIC", rules, evidence), classifiedRecord("LIKELY_PATHOGENIC", rules, evidence))
+	require.NoError(t, err)
+	assert.Equal(t, DirectionDowngraded, within.Direction)
+	assert.False(t, within.NotificationRecommended)
+
+	// Key order in stored evidence does not matter
+	same, err := Compare(classifiedRecord("PATHOGENIC", rules, evidence),
+		classifiedRecord("PATHOGENIC", rules, `{"population_data":{"allele_count":0,"allele_frequency":0}}`))
+	require.NoError(t, err)
+	assert.False(t, same.Changed())
+	assert.Equal(t, DirectionUnchanged, same.Direction)
+}
+
+func TestCompare_SomaticTiers(t *testing.T) {
+	evidence := `{"somatic_data":{"sample_count":12}}`
+
+	actionable, err := Compare(classifiedRecord("TIER_III", `[]`, evidence), classifiedRecord("TIER_I", `[]`, evidence))
+	require.NoError(t, err)
+	assert.Equal(t, DirectionUpgraded, actionable.Direction)
+	assert.True(t, actionable.NotificationRecommended)
+
+	within, err := Compare(classifiedRecord("TIER_I", `[]`, evidence), classifiedRecord("TIER_II", `[]`, evidence))
+	require.NoError(t, err)
+	assert.Equal(t, DirectionDowngraded, within.Direction)
+	assert.False(t, within.NotificationRecommended)
+}
+
+func TestCompare_SkipsCriteriaWithoutRules(t *testing.T) {
+	shortcut := classifiedRecord("LIKELY_BENIGN", "", "")
+	full := classifiedRecord("LIKELY_BENIGN", `[{"rule_code":"BS1","strength":"strong","applied":true}]`, "")
+
+	diff, err := Compare(shortcut, full)
+
+	require.NoError(t, err)
+	assert.False(t, diff.CriteriaCompared)
+	assert.Empty(t, diff.CriteriaAdded)
+}
+
+func TestCompare_RejectsFailedRecords(t *testing.T) {
+	failed := classifiedRecord("", "", "")
+	failed.Error = "classification service failed"
+
+	_, err := Compare(failed, classifiedRecord("VUS", "", ""))
+
+	assert.True(t, errors.Is(err, ErrInvalidRecord))
+}
+
+func TestPrevious(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	first := appendRecord(t, store, "VAR_1", "NM_000492.4:c.350G>A", "VUS", base)
+	failed := &Record{HGVSNotation: "NM_000492.4:c.350G>A", Request: json.RawMessage(`{}`), Error: "timeout", EngineVersion: "v0.1.0", CreatedAt: base.Add(time.Hour)}
+	require.NoError(t, store.Append(ctx, failed))
+	latest := appendRecord(t, store, "VAR_2", "NM_000492.4:c.350G>A", "LIKELY_PATHOGENIC", base.Add(2*time.Hour))
+
+	previous, err := Previous(ctx, store, latest)
+	require.NoError(t, err)
+	require.NotNil(t, previous)
+	assert.Equal(t, first.ID, previous.ID)
+
+	none, err := Previous(ctx, store, first)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+}
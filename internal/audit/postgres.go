@@ -0,0 +1,225 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// PostgresStore implements the Store interface using PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a new PostgreSQL audit store.
+// It expects the database and schema to already exist (created via migrations).
+func NewPostgresStore(db *sql.DB) (*PostgresStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is required")
+	}
+
+	// Verify connection
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresStore{db: db}, nil
+}
+
+// NewPostgresStoreFromURL creates a new PostgreSQL audit store from a connection URL.
+func NewPostgresStoreFromURL(databaseURL string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Configure connection pool
+	db.SetMaxOpenConns(25)
+	db.SetMaxIdleConns(5)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	store, err := NewPostgresStore(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+const postgresRecordColumns = `id, variant_id, hgvs_notation, request::text, evidence::text, applied_rules::text,
+	classification, confidence, error, engine_version, scoring_mode,
+	threshold_revision, specification, config_commit, dataset_versions::text, created_at, prev_hash, hash`
+
+// chainLockKey is the advisory lock serializing appends across servers, so
+// each record links to the latest hash
+const chainLockKey = 0x61636d67 // "acmg"
+
+// Append saves a record, chaining it to the previous one.
+func (s *PostgresStore) Append(ctx context.Context, record *Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	record.CreatedAt = record.CreatedAt.UTC()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, chainLockKey); err != nil {
+		return fmt.Errorf("failed to lock audit chain: %w", err)
+	}
+	var prevHash string
+	err = tx.QueryRowContext(ctx, `SELECT hash FROM classification_audit ORDER BY id DESC LIMIT 1`).Scan(&prevHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to read previous audit hash: %w", err)
+	}
+	if err := record.seal(prevHash); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO classification_audit (
+			variant_id, hgvs_notation, request, evidence, applied_rules,
+			classification, confidence, error, engine_version, scoring_mode,
+			threshold_revision, specification, config_commit, dataset_versions, created_at,
+			prev_hash, hash
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		RETURNING id
+	`
+
+	err = tx.QueryRowContext(ctx, query,
+		record.VariantID, record.HGVSNotation, nullJSON(record.Request), nullJSON(record.Evidence), nullJSON(record.AppliedRules),
+		record.Classification, record.Confidence, record.Error, record.EngineVersion, record.ScoringMode,
+		record.ThresholdRevision, record.Specification, record.ConfigCommit, versionsJSON(record.DatasetVersions), record.CreatedAt,
+		record.PrevHash, record.Hash,
+	).Scan(&record.ID)
+	if err != nil {
+		return fmt.Errorf("failed to append audit record: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to append audit record: %w", err)
+	}
+	return nil
+}
+
+// Get returns a record by ID.
+func (s *PostgresStore) Get(ctx context.Context, id int64) (*Record, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+postgresRecordColumns+` FROM classification_audit WHERE id = $1`, id)
+	record, err := scanRecord(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit record: %w", err)
+	}
+	return record, nil
+}
+
+// Query returns matching records, newest first.
+func (s *PostgresStore) Query(ctx context.Context, filter Filter) ([]*Record, error) {
+	var conditions []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Variant != "" {
+		p := arg(filter.Variant)
+		conditions = append(conditions, fmt.Sprintf("(variant_id = %s OR hgvs_notation = %s)", p, p))
+	}
+	if filter.Classification != "" {
+		conditions = append(conditions, "classification = "+arg(filter.Classification))
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "created_at >= "+arg(filter.Since))
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "created_at < "+arg(filter.Until))
+	}
+	if filter.FailedOnly {
+		conditions = append(conditions, "error != ''")
+	}
+	if filter.SucceededOnly {
+		conditions = append(conditions, "error = ''")
+	}
+
+	query := `SELECT ` + postgresRecordColumns + ` FROM classification_audit`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT ` + arg(queryLimit(filter.Limit))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit records: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*Record, 0)
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit record: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// Latest returns the newest successful record of each variant.
+func (s *PostgresStore) Latest(ctx context.Context, limit, offset int) ([]*Record, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+postgresRecordColumns+` FROM classification_audit
+		WHERE id IN (SELECT MAX(id) FROM classification_audit WHERE error = '' GROUP BY hgvs_notation)
+		ORDER BY hgvs_notation LIMIT $1 OFFSET $2`, queryLimit(limit), offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest audit records: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*Record, 0)
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit record: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// Chain returns records after afterID, oldest first.
+func (s *PostgresStore) Chain(ctx context.Context, afterID int64, limit int) ([]*Record, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+postgresRecordColumns+` FROM classification_audit
+		WHERE id > $1 ORDER BY id LIMIT $2`, afterID, queryLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit chain: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*Record, 0)
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit record: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// Close closes the database connection.
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getTestDB returns a database connection for testing.
+// Skip test if DATABASE_URL is not set.
+func getTestDB(t *testing.T) *sql.DB {
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("TEST_DATABASE_URL not set, skipping PostgreSQL tests")
+	}
+
+	db, err := sql.Open("postgres", dbURL)
+	require.NoError(t, err)
+
+	// Create audit table for testing
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS classification_audit (
+			id BIGSERIAL PRIMARY KEY,
+			variant_id VARCHAR(100) NOT NULL DEFAULT '',
+			hgvs_notation TEXT NOT NULL DEFAULT '',
+			request JSONB NOT NULL,
+			evidence JSONB,
+			applied_rules JSONB,
+			classification VARCHAR(50) NOT NULL DEFAULT '',
+			confidence VARCHAR(50) NOT NULL DEFAULT '',
+			error TEXT NOT NULL DEFAULT '',
+			engine_version VARCHAR(50) NOT NULL,
+			scoring_mode VARCHAR(20) NOT NULL DEFAULT '',
+			threshold_revision BIGINT NOT NULL DEFAULT 0,
+			specification VARCHAR(100) NOT NULL DEFAULT '',
+			config_commit VARCHAR(64) NOT NULL DEFAULT '',
+			dataset_versions JSONB,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			prev_hash VARCHAR(64) NOT NULL DEFAULT '',
+			hash VARCHAR(64) NOT NULL DEFAULT ''
+		)
+	`)
+	require.NoError(t, err)
+
+	// Clean up before test
+	_, err = db.Exec("DELETE FROM classification_audit")
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestPostgresStore_AppendAndQuery(t *testing.T) {
+	db := getTestDB(t)
+	store, err := NewPostgresStore(db)
+	require.NoError(t, err)
+	defer store.Close()
+
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour)
+	first := appendRecord(t, store, "VAR_1", "NM_000492.4:c.1521_1523del", "Pathogenic", base)
+	appendRecord(t, store, "VAR_2", "NM_007294.4:c.5266dupC", "Pathogenic", base.Add(time.Minute))
+
+	got, err := store.Get(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "VAR_1", got.VariantID)
+	assert.JSONEq(t, string(first.AppliedRules), string(got.AppliedRules))
+
+	records, err := store.Query(ctx, Filter{Variant: "NM_007294.4:c.5266dupC"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "VAR_2", records[0].VariantID)
+
+	failed := &Record{
+		HGVSNotation:  "NM_000492.4:c.bogus",
+		Request:       json.RawMessage(`{}`),
+		Error:         "invalid HGVS notation",
+		EngineVersion: "v0.1.0",
+	}
+	require.NoError(t, store.Append(ctx, failed))
+	records, err = store.Query(ctx, Filter{FailedOnly: true})
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+func TestNewPostgresStore_NilDB(t *testing.T) {
+	_, err := NewPostgresStore(nil)
+	assert.Error(t, err)
+}
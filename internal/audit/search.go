@@ -0,0 +1,160 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidQuery is returned when a search query cannot be run.
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// SearchQuery selects successful classifications. Zero values match
+// everything; every given condition must hold.
+type SearchQuery struct {
+	Gene           string    // HGNC symbol, case-insensitive
+	Classification string    // Final call, e.g. Pathogenic
+	Criteria       []string  // Criteria that were all applied, e.g. PM2 and PP3
+	Text           string    // Words that must all appear in the applied criteria's justifications
+	Since          time.Time // Inclusive
+	Until          time.Time // Exclusive
+	Limit          int       // Defaults to DefaultQueryLimit
+}
+
+// SearchHit is a classification matching a search. Hits for text searches
+// are ordered by relevance and carry a snippet of the matching
+// justification; otherwise the newest come first.
+type SearchHit struct {
+	RecordID       int64     `json:"record_id"`
+	VariantID      string    `json:"variant_id,omitempty"`
+	HGVSNotation   string    `json:"hgvs_notation"`
+	Gene           string    `json:"gene,omitempty"`
+	Classification string    `json:"classification"`
+	Confidence     string    `json:"confidence,omitempty"`
+	Criteria       []string  `json:"criteria"` // Applied criteria, sorted
+	Snippet        string    `json:"snippet,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Searcher is implemented by stores with a search index over the gene,
+// applied criteria and justifications of each classification.
+type Searcher interface {
+	// Search returns the classifications matching the query.
+	Search(ctx context.Context, query SearchQuery) ([]*SearchHit, error)
+}
+
+// searchTerms is what the search index holds for a record
+type searchTerms struct {
+	Gene          string
+	Criteria      []string
+	Justification string
+}
+
+// searchRule is the part of a recorded rule result the search index needs
+type searchRule struct {
+	Code      string `json:"rule_code"`
+	Applied   bool   `json:"applied"`
+	Evidence  string `json:"evidence"`
+	Reasoning string `json:"reasoning"`
+}
+
+// indexTerms extracts the searchable terms of a record. Undecodable request,
+// evidence or rules are treated as absent so a record is always indexed.
+func indexTerms(record *Record) searchTerms {
+	terms := searchTerms{Gene: recordGene(record)}
+
+	var rules []searchRule
+	if len(record.AppliedRules) > 0 {
+		json.Unmarshal(record.AppliedRules, &rules)
+	}
+	var justifications []string
+	for _, rule := range rules {
+		if !rule.Applied || rule.Code == "" {
+			continue
+		}
+		terms.Criteria = append(terms.Criteria, strings.ToUpper(rule.Code))
+		for _, text := range []string{rule.Evidence, rule.Reasoning} {
+			if text = strings.TrimSpace(text); text != "" {
+				justifications = append(justifications, text)
+			}
+		}
+	}
+	sort.Strings(terms.Criteria)
+	terms.Justification = strings.Join(justifications, "\n")
+	return terms
+}
+
+// recordGene returns the gene a record was classified in: the symbol given
+// in the request, else the gene of the curation or constraint evidence
+func recordGene(record *Record) string {
+	var request struct {
+		GeneSymbolNotation string `json:"gene_symbol_notation"`
+		GeneSymbol         string `json:"gene_symbol"`
+	}
+	if len(record.Request) > 0 && json.Unmarshal(record.Request, &request) == nil {
+		if gene, _, _ := strings.Cut(request.GeneSymbolNotation, ":"); strings.TrimSpace(gene) != "" {
+			return strings.ToUpper(strings.TrimSpace(gene))
+		}
+		if gene := strings.TrimSpace(request.GeneSymbol); gene != "" {
+			return strings.ToUpper(gene)
+		}
+	}
+
+	var evidence struct {
+		GeneCuration *struct {
+			Gene string `json:"gene"`
+		} `json:"gene_curation"`
+		GeneConstraint *struct {
+			Gene string `json:"gene"`
+		} `json:"gene_constraint"`
+	}
+	if len(record.Evidence) > 0 && json.Unmarshal(record.Evidence, &evidence) == nil {
+		if evidence.GeneCuration != nil && evidence.GeneCuration.Gene != "" {
+			return strings.ToUpper(evidence.GeneCuration.Gene)
+		}
+		if evidence.GeneConstraint != nil && evidence.GeneConstraint.Gene != "" {
+			return strings.ToUpper(evidence.GeneConstraint.Gene)
+		}
+	}
+	return ""
+}
+
+// matchExpression builds the full-text query for the gene, criteria and
+// text conditions, empty when there are none. Every word is quoted so user
+// input is never parsed as query syntax; a trailing * on a text word
+// matches it as a prefix.
+func (q *SearchQuery) matchExpression() (string, error) {
+	var clauses []string
+	if gene := strings.TrimSpace(q.Gene); gene != "" {
+		clauses = append(clauses, "gene : "+quoteTerm(gene))
+	}
+	for _, criterion := range q.Criteria {
+		criterion = strings.TrimSpace(criterion)
+		if criterion == "" {
+			return "", fmt.Errorf("%w: criteria must not be empty", ErrInvalidQuery)
+		}
+		clauses = append(clauses, "criteria : "+quoteTerm(criterion))
+	}
+	for _, word := range strings.Fields(q.Text) {
+		prefix := strings.HasSuffix(word, "*")
+		word = strings.TrimRight(word, "*")
+		if word == "" {
+			continue
+		}
+		clause := "justification : " + quoteTerm(word)
+		if prefix {
+			clause += "*"
+		}
+		clauses = append(clauses, clause)
+	}
+	return strings.Join(clauses, " AND "), nil
+}
+
+// quoteTerm quotes a word as a full-text string
+func quoteTerm(word string) string {
+	return `"` + strings.ReplaceAll(word, `"`, `""`) + `"`
+}
@@ -0,0 +1,146 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendClassification appends a successful classification with the given
+// request and applied rules
+func appendClassification(t *testing.T, store Store, hgvs, request, rules, classification string, createdAt time.Time) *Record {
+	t.Helper()
+	record := &Record{
+		HGVSNotation:   hgvs,
+		Request:        json.RawMessage(request),
+		AppliedRules:   json.RawMessage(rules),
+		Classification: classification,
+		EngineVersion:  "v0.1.0",
+		CreatedAt:      createdAt,
+	}
+	require.NoError(t, store.Append(context.Background(), record))
+	return record
+}
+
+func searchedHGVS(hits []*SearchHit) []string {
+	hgvs := make([]string, len(hits))
+	for i, hit := range hits {
+		hgvs[i] = hit.HGVSNotation
+	}
+	return hgvs
+}
+
+func TestSQLiteStore_Search(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	appendClassification(t, store, "NM_007294.4:c.5096G>A", `{"gene_symbol_notation": "BRCA1:p.Arg1699Gln"}`,
+		`[{"rule_code": "PM2", "applied": true, "evidence": "Absent from gnomAD"},
+		  {"rule_code": "PP3", "applied": true, "reasoning": "REVEL 0.93 predicts a damaging effect"},
+		  {"rule_code": "BA1", "applied": false, "reasoning": "Frequency below threshold"}]`,
+		"Likely pathogenic", day)
+	appendClassification(t, store, "NM_000059.4:c.7007G>A", `{"gene_symbol": "brca2"}`,
+		`[{"rule_code": "PM2", "applied": true, "evidence": "Absent from population databases"},
+		  {"rule_code": "PS3", "applied": true, "reasoning": "Functional studies show damaging splicing"}]`,
+		"Pathogenic", day.AddDate(0, 0, 1))
+	appendClassification(t, store, "NM_000492.4:c.1521_1523del", `{"hgvs_notation": "NM_000492.4:c.1521_1523del"}`,
+		`[{"rule_code": "PP3", "applied": true, "reasoning": "Damaging in silico predictions"}]`,
+		"Uncertain significance", day.AddDate(0, 0, 2))
+	require.NoError(t, store.Append(ctx, &Record{
+		HGVSNotation:  "NM_007294.4:c.68_69del",
+		Request:       json.RawMessage(`{"gene_symbol_notation": "BRCA1:c.68_69del"}`),
+		Error:         "evidence unavailable",
+		EngineVersion: "v0.1.0",
+	}))
+
+	t.Run("every criterion must be applied", func(t *testing.T) {
+		hits, err := store.Search(ctx, SearchQuery{Criteria: []string{"PM2", "pp3"}})
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, "BRCA1", hits[0].Gene)
+		assert.Equal(t, []string{"PM2", "PP3"}, hits[0].Criteria, "unmet criteria are not indexed")
+	})
+
+	t.Run("gene matches whole symbols", func(t *testing.T) {
+		hits, err := store.Search(ctx, SearchQuery{Gene: "brca2"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"NM_000059.4:c.7007G>A"}, searchedHGVS(hits))
+
+		hits, err = store.Search(ctx, SearchQuery{Gene: "BRCA"})
+		require.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+
+	t.Run("structured filters and newest first", func(t *testing.T) {
+		hits, err := store.Search(ctx, SearchQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"NM_000492.4:c.1521_1523del", "NM_000059.4:c.7007G>A", "NM_007294.4:c.5096G>A"}, searchedHGVS(hits),
+			"failed classifications are excluded")
+
+		hits, err = store.Search(ctx, SearchQuery{Criteria: []string{"PM2"}, Since: day.AddDate(0, 0, 1), Until: day.AddDate(0, 0, 2)})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"NM_000059.4:c.7007G>A"}, searchedHGVS(hits))
+
+		hits, err = store.Search(ctx, SearchQuery{Classification: "Pathogenic", Limit: 5})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"NM_000059.4:c.7007G>A"}, searchedHGVS(hits))
+	})
+
+	t.Run("free text over justifications", func(t *testing.T) {
+		hits, err := store.Search(ctx, SearchQuery{Text: "damaging"})
+		require.NoError(t, err)
+		assert.Len(t, hits, 3)
+		for _, hit := range hits {
+			assert.Contains(t, strings.ToLower(hit.Snippet), "[damaging]")
+		}
+
+		hits, err = store.Search(ctx, SearchQuery{Text: "absent gnom*"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"NM_007294.4:c.5096G>A"}, searchedHGVS(hits))
+
+		hits, err = store.Search(ctx, SearchQuery{Text: "threshold"})
+		require.NoError(t, err)
+		assert.Empty(t, hits, "justifications of unmet criteria are not indexed")
+
+		hits, err = store.Search(ctx, SearchQuery{Text: `splicing" OR "frequency NEAR(`})
+		require.NoError(t, err, "query syntax is quoted")
+		assert.Empty(t, hits)
+	})
+
+	t.Run("invalid criteria", func(t *testing.T) {
+		_, err := store.Search(ctx, SearchQuery{Criteria: []string{" "}})
+		assert.ErrorIs(t, err, ErrInvalidQuery)
+	})
+}
+
+func TestSQLiteStore_SearchIndexesExistingRecords(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "audit.db")
+	store, err := NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	appendClassification(t, store, "NM_007294.4:c.5096G>A", `{"gene_symbol_notation": "BRCA1:p.Arg1699Gln"}`,
+		`[{"rule_code": "PM2", "applied": true}]`, "Likely pathogenic", time.Now())
+	require.NoError(t, store.Close())
+
+	// Simulate a trail written before the search index existed
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`DROP TABLE classification_search`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	store, err = NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	hits, err := store.Search(context.Background(), SearchQuery{Gene: "BRCA1", Criteria: []string{"PM2"}})
+	require.NoError(t, err)
+	assert.Len(t, hits, 1)
+}
@@ -0,0 +1,491 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+	mu     sync.Mutex // Serializes appends so each links to the latest hash
+}
+
+// NewSQLiteStore creates a new SQLite audit store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	store := &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}
+	// Index records written before the search index was added
+	if err := store.indexMissing(context.Background()); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to build search index: %w", err)
+	}
+	return store, nil
+}
+
+// createSchema creates the database tables and indexes.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS classification_audit (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		variant_id TEXT DEFAULT '',
+		hgvs_notation TEXT DEFAULT '',
+		request TEXT NOT NULL,
+		evidence TEXT,
+		applied_rules TEXT,
+		classification TEXT DEFAULT '',
+		confidence TEXT DEFAULT '',
+		error TEXT DEFAULT '',
+		engine_version TEXT NOT NULL,
+		scoring_mode TEXT DEFAULT '',
+		threshold_revision INTEGER DEFAULT 0,
+		specification TEXT DEFAULT '',
+		config_commit TEXT DEFAULT '',
+		dataset_versions TEXT,
+		created_at DATETIME NOT NULL,
+		prev_hash TEXT DEFAULT '',
+		hash TEXT DEFAULT ''
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_audit_variant_id ON classification_audit(variant_id);
+	CREATE INDEX IF NOT EXISTS idx_audit_hgvs ON classification_audit(hgvs_notation);
+	CREATE INDEX IF NOT EXISTS idx_audit_created_at ON classification_audit(created_at);
+
+	CREATE VIRTUAL TABLE IF NOT EXISTS classification_search USING fts5(
+		gene, criteria, justification, tokenize = 'unicode61'
+	);
+	`
+
+	if _, err := db.Exec(schema); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "classification_audit", "config_commit", "TEXT DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "classification_audit", "dataset_versions", "TEXT"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "classification_audit", "prev_hash", "TEXT DEFAULT ''"); err != nil {
+		return err
+	}
+	return addColumnIfMissing(db, "classification_audit", "hash", "TEXT DEFAULT ''")
+}
+
+// addColumnIfMissing upgrades a table created before the column was added
+func addColumnIfMissing(db *sql.DB, table, column, definition string) error {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+const recordColumns = `id, variant_id, hgvs_notation, request, evidence, applied_rules,
+	classification, confidence, error, engine_version, scoring_mode,
+	threshold_revision, specification, config_commit, dataset_versions, created_at, prev_hash, hash`
+
+// scanner is an interface for sql.Row and sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRecord scans a record row in recordColumns order
+func scanRecord(s scanner) (*Record, error) {
+	r := &Record{}
+	var request, evidence, appliedRules, datasetVersions, prevHash, hash sql.NullString
+	err := s.Scan(
+		&r.ID, &r.VariantID, &r.HGVSNotation, &request, &evidence, &appliedRules,
+		&r.Classification, &r.Confidence, &r.Error, &r.EngineVersion, &r.ScoringMode,
+		&r.ThresholdRevision, &r.Specification, &r.ConfigCommit, &datasetVersions, &r.CreatedAt,
+		&prevHash, &hash,
+	)
+	if err != nil {
+		return nil, err
+	}
+	r.PrevHash = prevHash.String
+	r.Hash = hash.String
+	if raw := rawJSON(datasetVersions); raw != nil {
+		if err := json.Unmarshal(raw, &r.DatasetVersions); err != nil {
+			return nil, fmt.Errorf("invalid dataset versions: %w", err)
+		}
+	}
+	r.Request = rawJSON(request)
+	r.Evidence = rawJSON(evidence)
+	r.AppliedRules = rawJSON(appliedRules)
+	return r, nil
+}
+
+// Append saves a record, chaining it to the previous one.
+func (s *SQLiteStore) Append(ctx context.Context, record *Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	// Times are stored as text and compared as text, so keep them in UTC
+	record.CreatedAt = record.CreatedAt.UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var prevHash sql.NullString
+	err = tx.QueryRowContext(ctx, `SELECT hash FROM classification_audit ORDER BY id DESC LIMIT 1`).Scan(&prevHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to read previous audit hash: %w", err)
+	}
+	if err := record.seal(prevHash.String); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO classification_audit (
+			variant_id, hgvs_notation, request, evidence, applied_rules,
+			classification, confidence, error, engine_version, scoring_mode,
+			threshold_revision, specification, config_commit, dataset_versions, created_at,
+			prev_hash, hash
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		record.VariantID, record.HGVSNotation, nullJSON(record.Request), nullJSON(record.Evidence), nullJSON(record.AppliedRules),
+		record.Classification, record.Confidence, record.Error, record.EngineVersion, record.ScoringMode,
+		record.ThresholdRevision, record.Specification, record.ConfigCommit, versionsJSON(record.DatasetVersions), record.CreatedAt,
+		record.PrevHash, record.Hash,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to append audit record: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to append audit record: %w", err)
+	}
+	if err := indexRecord(ctx, tx, id, record); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to append audit record: %w", err)
+	}
+
+	record.ID = id
+	return nil
+}
+
+// Get returns a record by ID.
+func (s *SQLiteStore) Get(ctx context.Context, id int64) (*Record, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+recordColumns+` FROM classification_audit WHERE id = ?`, id)
+	record, err := scanRecord(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit record: %w", err)
+	}
+	return record, nil
+}
+
+// Query returns matching records, newest first.
+func (s *SQLiteStore) Query(ctx context.Context, filter Filter) ([]*Record, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Variant != "" {
+		conditions = append(conditions, "(variant_id = ? OR hgvs_notation = ?)")
+		args = append(args, filter.Variant, filter.Variant)
+	}
+	if filter.Classification != "" {
+		conditions = append(conditions, "classification = ?")
+		args = append(args, filter.Classification)
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.Until.UTC())
+	}
+	if filter.FailedOnly {
+		conditions = append(conditions, "error != ''")
+	}
+	if filter.SucceededOnly {
+		conditions = append(conditions, "error = ''")
+	}
+
+	query := `SELECT ` + recordColumns + ` FROM classification_audit`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT ?`
+	args = append(args, queryLimit(filter.Limit))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit records: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*Record, 0)
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit record: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// Latest returns the newest successful record of each variant.
+func (s *SQLiteStore) Latest(ctx context.Context, limit, offset int) ([]*Record, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+recordColumns+` FROM classification_audit
+		WHERE id IN (SELECT MAX(id) FROM classification_audit WHERE error = '' GROUP BY hgvs_notation)
+		ORDER BY hgvs_notation LIMIT ? OFFSET ?`, queryLimit(limit), offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest audit records: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*Record, 0)
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit record: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// Chain returns records after afterID, oldest first.
+func (s *SQLiteStore) Chain(ctx context.Context, afterID int64, limit int) ([]*Record, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+recordColumns+` FROM classification_audit
+		WHERE id > ? ORDER BY id LIMIT ?`, afterID, queryLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit chain: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*Record, 0)
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit record: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// execer is an interface for sql.DB and sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// indexRecord adds a record to the search index under its ID
+func indexRecord(ctx context.Context, db execer, id int64, record *Record) error {
+	terms := indexTerms(record)
+	_, err := db.ExecContext(ctx, `INSERT INTO classification_search (rowid, gene, criteria, justification) VALUES (?, ?, ?, ?)`,
+		id, terms.Gene, strings.Join(terms.Criteria, " "), terms.Justification)
+	if err != nil {
+		return fmt.Errorf("failed to index audit record: %w", err)
+	}
+	return nil
+}
+
+// indexMissing indexes the records that are not in the search index
+func (s *SQLiteStore) indexMissing(ctx context.Context) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+recordColumns+` FROM classification_audit
+		WHERE id NOT IN (SELECT rowid FROM classification_search) ORDER BY id`)
+	if err != nil {
+		return err
+	}
+	var records []*Record
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		records = append(records, record)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, record := range records {
+		if err := indexRecord(ctx, tx, record.ID, record); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Search returns the successful classifications matching the query from
+// the FTS5 search index.
+func (s *SQLiteStore) Search(ctx context.Context, query SearchQuery) ([]*SearchHit, error) {
+	match, err := query.matchExpression()
+	if err != nil {
+		return nil, err
+	}
+	ranked := strings.TrimSpace(query.Text) != "" && match != ""
+
+	conditions := []string{"a.error = ''"}
+	var args []interface{}
+	if match != "" {
+		conditions = append(conditions, "classification_search MATCH ?")
+		args = append(args, match)
+	}
+	if gene := strings.TrimSpace(query.Gene); gene != "" {
+		// The index matches words; the gene must match as a whole
+		conditions = append(conditions, "classification_search.gene = ?")
+		args = append(args, strings.ToUpper(gene))
+	}
+	if query.Classification != "" {
+		conditions = append(conditions, "a.classification = ?")
+		args = append(args, query.Classification)
+	}
+	if !query.Since.IsZero() {
+		conditions = append(conditions, "a.created_at >= ?")
+		args = append(args, query.Since.UTC())
+	}
+	if !query.Until.IsZero() {
+		conditions = append(conditions, "a.created_at < ?")
+		args = append(args, query.Until.UTC())
+	}
+
+	snippet, order := `''`, `a.created_at DESC, a.id DESC`
+	if ranked {
+		snippet = `snippet(classification_search, 2, '[', ']', '...', 16)`
+		order = `bm25(classification_search), ` + order
+	}
+	sqlQuery := `SELECT a.id, a.variant_id, a.hgvs_notation, a.classification, a.confidence, a.created_at,
+		classification_search.gene, classification_search.criteria, ` + snippet + `
+		FROM classification_search JOIN classification_audit a ON a.id = classification_search.rowid
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY ` + order + ` LIMIT ?`
+	args = append(args, queryLimit(query.Limit))
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search audit records: %w", err)
+	}
+	defer rows.Close()
+
+	hits := make([]*SearchHit, 0)
+	for rows.Next() {
+		hit := &SearchHit{}
+		var criteria string
+		if err := rows.Scan(&hit.RecordID, &hit.VariantID, &hit.HGVSNotation, &hit.Classification, &hit.Confidence,
+			&hit.CreatedAt, &hit.Gene, &criteria, &hit.Snippet); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		hit.Criteria = strings.Fields(criteria)
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
+
+// Close closes the database connection.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// nullJSON stores an empty JSON document as NULL
+func nullJSON(raw []byte) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
+
+// versionsJSON encodes dataset versions for storage, NULL when there are none
+func versionsJSON(versions provenance.Versions) interface{} {
+	if len(versions) == 0 {
+		return nil
+	}
+	raw, _ := json.Marshal(versions)
+	return string(raw)
+}
+
+func rawJSON(s sql.NullString) []byte {
+	if !s.Valid || s.String == "" {
+		return nil
+	}
+	return []byte(s.String)
+}
+
+func queryLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultQueryLimit
+	}
+	return limit
+}
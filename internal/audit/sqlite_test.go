@@ -0,0 +1,198 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "audit.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func appendRecord(t *testing.T, store Store, variantID, hgvs, classification string, createdAt time.Time) *Record {
+	t.Helper()
+	record := &Record{
+		VariantID:      variantID,
+		HGVSNotation:   hgvs,
+		Request:        json.RawMessage(`{"hgvs_notation":"` + hgvs + `"}`),
+		Evidence:       json.RawMessage(`{"population_data":{"gnomad_frequency":0.0001}}`),
+		AppliedRules:   json.RawMessage(`[{"code":"PM2","met":true}]`),
+		Classification: classification,
+		Confidence:     "High",
+		EngineVersion:  "v0.1.0",
+		ScoringMode:    "bayesian",
+		CreatedAt:      createdAt,
+	}
+	require.NoError(t, store.Append(context.Background(), record))
+	return record
+}
+
+func TestSQLiteStore_AppendAndGet(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	created := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	record := appendRecord(t, store, "VAR_1", "NM_000492.4:c.1521_1523del", "Pathogenic", created)
+	require.NotZero(t, record.ID)
+
+	got, err := store.Get(ctx, record.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "VAR_1", got.VariantID)
+	assert.Equal(t, "Pathogenic", got.Classification)
+	assert.Equal(t, "v0.1.0", got.EngineVersion)
+	assert.JSONEq(t, string(record.Request), string(got.Request))
+	assert.JSONEq(t, string(record.Evidence), string(got.Evidence))
+	assert.JSONEq(t, string(record.AppliedRules), string(got.AppliedRules))
+	assert.True(t, created.Equal(got.CreatedAt))
+
+	_, err = store.Get(ctx, record.ID+100)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestSQLiteStore_ConfigCommit(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "audit.db")
+
+	// A database created before config commits were recorded
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE classification_audit (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, variant_id TEXT DEFAULT '', hgvs_notation TEXT DEFAULT '',
+		request TEXT NOT NULL, evidence TEXT, applied_rules TEXT, classification TEXT DEFAULT '',
+		confidence TEXT DEFAULT '', error TEXT DEFAULT '', engine_version TEXT NOT NULL,
+		scoring_mode TEXT DEFAULT '', threshold_revision INTEGER DEFAULT 0, specification TEXT DEFAULT '',
+		created_at DATETIME NOT NULL)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO classification_audit (variant_id, request, engine_version, created_at) VALUES ('VAR_0', '{}', 'v0.1.0', ?)`, time.Now().UTC())
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	store, err := NewSQLiteStore(dbPath)
+	require.NoError(t, err)
+	defer store.Close()
+
+	legacy, err := store.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, legacy.ConfigCommit)
+	assert.Nil(t, legacy.DatasetVersions)
+
+	record := &Record{
+		VariantID: "VAR_1", Request: json.RawMessage(`{}`), EngineVersion: "v0.1.0", ConfigCommit: "3f2c9a1e",
+		DatasetVersions: provenance.Versions{provenance.ClinVar: "2024-05-01", provenance.GnomAD: "gnomad_r4"},
+	}
+	require.NoError(t, store.Append(ctx, record))
+	got, err := store.Get(ctx, record.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "3f2c9a1e", got.ConfigCommit)
+	assert.Equal(t, record.DatasetVersions, got.DatasetVersions)
+}
+
+func TestSQLiteStore_AppendValidates(t *testing.T) {
+	store := createTestStore(t)
+
+	err := store.Append(context.Background(), &Record{Request: json.RawMessage(`{}`), EngineVersion: "v0.1.0"})
+	assert.True(t, errors.Is(err, ErrInvalidRecord))
+
+	err = store.Append(context.Background(), &Record{HGVSNotation: "NM_000492.4:c.1521_1523del", EngineVersion: "v0.1.0"})
+	assert.True(t, errors.Is(err, ErrInvalidRecord))
+}
+
+func TestSQLiteStore_FailedClassification(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+
+	record := &Record{
+		HGVSNotation:  "NM_000492.4:c.bogus",
+		Request:       json.RawMessage(`{"hgvs_notation":"NM_000492.4:c.bogus"}`),
+		Error:         "invalid HGVS notation",
+		EngineVersion: "v0.1.0",
+	}
+	require.NoError(t, store.Append(ctx, record))
+	appendRecord(t, store, "VAR_2", "NM_000492.4:c.1521_1523del", "Pathogenic", time.Time{})
+
+	got, err := store.Get(ctx, record.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.Evidence)
+	assert.Nil(t, got.AppliedRules)
+
+	failed, err := store.Query(ctx, Filter{FailedOnly: true})
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, "invalid HGVS notation", failed[0].Error)
+}
+
+func TestSQLiteStore_Query(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	appendRecord(t, store, "VAR_1", "NM_000492.4:c.1521_1523del", "Pathogenic", base)
+	appendRecord(t, store, "VAR_2", "NM_000492.4:c.1521_1523del", "Likely pathogenic", base.Add(24*time.Hour))
+	appendRecord(t, store, "VAR_3", "NM_007294.4:c.5266dupC", "Pathogenic", base.Add(48*time.Hour))
+
+	all, err := store.Query(ctx, Filter{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "VAR_3", all[0].VariantID, "newest first")
+
+	byHGVS, err := store.Query(ctx, Filter{Variant: "NM_000492.4:c.1521_1523del"})
+	require.NoError(t, err)
+	assert.Len(t, byHGVS, 2)
+
+	byID, err := store.Query(ctx, Filter{Variant: "VAR_3"})
+	require.NoError(t, err)
+	require.Len(t, byID, 1)
+	assert.Equal(t, "NM_007294.4:c.5266dupC", byID[0].HGVSNotation)
+
+	pathogenic, err := store.Query(ctx, Filter{Classification: "Pathogenic"})
+	require.NoError(t, err)
+	assert.Len(t, pathogenic, 2)
+
+	window, err := store.Query(ctx, Filter{Since: base.Add(time.Hour), Until: base.Add(48 * time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, window, 1)
+	assert.Equal(t, "VAR_2", window[0].VariantID)
+
+	limited, err := store.Query(ctx, Filter{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+}
+
+func TestSQLiteStore_Latest(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	appendRecord(t, store, "VAR_1", "NM_007294.4:c.5266dupC", "Pathogenic", base)
+	appendRecord(t, store, "VAR_2", "NM_000492.4:c.1521_1523del", "Pathogenic", base)
+	appendRecord(t, store, "VAR_3", "NM_000492.4:c.1521_1523del", "Likely pathogenic", base.Add(time.Hour))
+	require.NoError(t, store.Append(ctx, &Record{
+		HGVSNotation:  "NM_000492.4:c.1521_1523del",
+		Request:       json.RawMessage(`{}`),
+		Error:         "evidence lookup failed",
+		EngineVersion: "v0.1.0",
+		CreatedAt:     base.Add(2 * time.Hour),
+	}))
+
+	latest, err := store.Latest(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, latest, 2)
+	assert.Equal(t, "VAR_3", latest[0].VariantID, "newest successful record, ordered by notation")
+	assert.Equal(t, "VAR_1", latest[1].VariantID)
+
+	page, err := store.Latest(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "VAR_1", page[0].VariantID)
+}
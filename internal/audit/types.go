@@ -0,0 +1,105 @@
+// Package audit keeps a persistent trail of classification requests so a lab
+// can reconstruct how a call was made long after it was issued. Each record
+// holds the request as received, the evidence retrieved, the rules applied,
+// the final call and the engine and rule versions in effect. Records are
+// append-only and hash-chained so tampering is detectable, and runs of them
+// can be exported as signed bundles for auditors. SQLite backs the lite server and PostgreSQL the full server.
+// Compare diffs two classifications of a variant so reclassifications can be
+// detected and notified.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+)
+
+var (
+	// ErrNotFound is returned when an audit record does not exist.
+	ErrNotFound = errors.New("audit record not found")
+	// ErrInvalidRecord is returned when a record is missing required fields.
+	ErrInvalidRecord = errors.New("invalid audit record")
+)
+
+// DefaultQueryLimit caps query results when no limit is given.
+const DefaultQueryLimit = 100
+
+// Record is one classification request and its outcome.
+type Record struct {
+	ID                int64               `json:"id,omitempty"`
+	VariantID         string              `json:"variant_id,omitempty"` // Variant ID returned with the classification
+	HGVSNotation      string              `json:"hgvs_notation"`
+	Request           json.RawMessage     `json:"request"`                 // Parameters as received
+	Evidence          json.RawMessage     `json:"evidence,omitempty"`      // Evidence retrieved from external databases
+	AppliedRules      json.RawMessage     `json:"applied_rules,omitempty"` // Every rule evaluated, met or not
+	Classification    string              `json:"classification,omitempty"`
+	Confidence        string              `json:"confidence,omitempty"`
+	Error             string              `json:"error,omitempty"` // Set when the classification failed
+	EngineVersion     string              `json:"engine_version"`
+	ScoringMode       string              `json:"scoring_mode,omitempty"`
+	ThresholdRevision int64               `json:"threshold_revision,omitempty"` // 0 when the built-in thresholds were used
+	Specification     string              `json:"vcep_specification,omitempty"`
+	ConfigCommit      string              `json:"config_commit,omitempty"`    // Config repository commit, when one is configured
+	DatasetVersions   provenance.Versions `json:"dataset_versions,omitempty"` // Version of each dataset the evidence came from
+	CreatedAt         time.Time           `json:"created_at"`
+	PrevHash          string              `json:"prev_hash,omitempty"`        // Hash of the record appended before this one
+	Hash              string              `json:"hash,omitempty"`             // Empty for records written before hash chaining
+	RequestRedacted   bool                `json:"request_redacted,omitempty"` // Set on exported records whose request was redacted
+	RequestDigest     string              `json:"request_sha256,omitempty"`   // Digest of the original request, set when redacted
+}
+
+// Validate normalizes the record and checks required fields.
+func (r *Record) Validate() error {
+	r.VariantID = strings.TrimSpace(r.VariantID)
+	r.HGVSNotation = strings.TrimSpace(r.HGVSNotation)
+	if r.VariantID == "" && r.HGVSNotation == "" {
+		return fmt.Errorf("%w: variant ID or HGVS notation is required", ErrInvalidRecord)
+	}
+	if len(r.Request) == 0 {
+		return fmt.Errorf("%w: request is required", ErrInvalidRecord)
+	}
+	if r.EngineVersion == "" {
+		return fmt.Errorf("%w: engine version is required", ErrInvalidRecord)
+	}
+	return nil
+}
+
+// Filter selects audit records. Zero values match everything.
+type Filter struct {
+	Variant        string    // Matches the variant ID or the HGVS notation
+	Classification string    // Final call, e.g. Pathogenic
+	Since          time.Time // Inclusive
+	Until          time.Time // Exclusive
+	FailedOnly     bool
+	SucceededOnly  bool
+	Limit          int // Defaults to DefaultQueryLimit
+}
+
+// Store defines the interface for audit trail storage.
+type Store interface {
+	// Append saves a record, chaining it to the previous one by hash.
+	// Records are never modified once written.
+	Append(ctx context.Context, record *Record) error
+
+	// Get returns a record by ID.
+	Get(ctx context.Context, id int64) (*Record, error)
+
+	// Query returns matching records, newest first.
+	Query(ctx context.Context, filter Filter) ([]*Record, error)
+
+	// Latest returns the newest successful record of each variant, ordered by
+	// HGVS notation, skipping the first offset variants.
+	Latest(ctx context.Context, limit, offset int) ([]*Record, error)
+
+	// Chain returns up to limit records with IDs after afterID, oldest
+	// first, for verifying and exporting the hash chain.
+	Chain(ctx context.Context, afterID int64, limit int) ([]*Record, error)
+
+	// Close closes the store.
+	Close() error
+}
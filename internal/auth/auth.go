@@ -0,0 +1,207 @@
+// Package auth authenticates clients of the HTTP transport and admin API by
+// API key or JWT bearer token, and authorizes them by role: read-only
+// clients may query evidence and past classifications, classify clients may
+// also classify variants and record curation, reviewers may also sign out
+// classifications others submitted for review, and admins may change shared
+// lab data such as the artifact blacklist and gene playbooks.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APIKeyHeader carries an API key; keys may also be sent as bearer tokens
+const APIKeyHeader = "X-API-Key"
+
+// Role grants access to a set of operations. Each role includes the
+// operations of the roles below it.
+type Role string
+
+const (
+	RoleReadOnly Role = "read_only"
+	RoleClassify Role = "classify"
+	RoleReviewer Role = "reviewer"
+	RoleAdmin    Role = "admin"
+)
+
+// roleRank orders roles from least to most privileged
+var roleRank = map[Role]int{
+	RoleReadOnly: 1,
+	RoleClassify: 2,
+	RoleReviewer: 3,
+	RoleAdmin:    4,
+}
+
+// ParseRole parses a role name, accepting read-only and readonly for read_only
+func ParseRole(s string) (Role, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	switch normalized {
+	case "read-only", "readonly":
+		return RoleReadOnly, nil
+	}
+	if _, ok := roleRank[Role(normalized)]; ok {
+		return Role(normalized), nil
+	}
+	return "", fmt.Errorf("unknown role %q: must be read_only, classify, reviewer or admin", s)
+}
+
+// Allows reports whether the role includes the required role
+func (r Role) Allows(required Role) bool {
+	return roleRank[r] >= roleRank[required] && roleRank[r] > 0
+}
+
+// Authentication methods reported in Principal.Method
+const (
+	MethodAPIKey    = "api_key"
+	MethodJWT       = "jwt"
+	MethodAnonymous = "anonymous"
+)
+
+// Principal is an authenticated client
+type Principal struct {
+	Subject string `json:"subject"` // API key name or JWT subject
+	Role    Role   `json:"role"`
+	Method  string `json:"method"`
+}
+
+// Authentication and authorization failures
+var (
+	ErrUnauthenticated = errors.New("valid API key or bearer token required")
+	ErrForbidden       = errors.New("role does not permit this operation")
+)
+
+// APIKey is a key issued to a client
+type APIKey struct {
+	Name string // Identifies the client in logs and audit records
+	Role Role
+	Key  string
+}
+
+// Config configures the accepted credentials
+type Config struct {
+	APIKeys     []APIKey
+	JWTSecret   string // HS256 secret; JWTs are not accepted when empty
+	JWTIssuer   string // Required iss claim, if set
+	JWTAudience string // Required aud claim, if set
+	// Role granted to requests without credentials; empty requires
+	// credentials. Intended for local development only.
+	AnonymousRole Role
+}
+
+// Authenticator verifies API keys and JWT bearer tokens
+type Authenticator struct {
+	keys      map[[sha256.Size]byte]Principal
+	jwtSecret []byte
+	issuer    string
+	audience  string
+	anonymous *Principal
+	now       func() time.Time
+}
+
+// NewAuthenticator creates an authenticator from a configuration
+func NewAuthenticator(config Config) (*Authenticator, error) {
+	a := &Authenticator{
+		keys:      make(map[[sha256.Size]byte]Principal, len(config.APIKeys)),
+		jwtSecret: []byte(config.JWTSecret),
+		issuer:    config.JWTIssuer,
+		audience:  config.JWTAudience,
+		now:       time.Now,
+	}
+	for _, key := range config.APIKeys {
+		if strings.TrimSpace(key.Name) == "" || strings.TrimSpace(key.Key) == "" {
+			return nil, fmt.Errorf("API keys need a name and a key")
+		}
+		if _, ok := roleRank[key.Role]; !ok {
+			return nil, fmt.Errorf("API key %s: unknown role %q", key.Name, key.Role)
+		}
+		// Keys are looked up by digest so the lookup does not leak key prefixes
+		digest := sha256.Sum256([]byte(key.Key))
+		if _, exists := a.keys[digest]; exists {
+			return nil, fmt.Errorf("API key %s duplicates another key", key.Name)
+		}
+		a.keys[digest] = Principal{Subject: key.Name, Role: key.Role, Method: MethodAPIKey}
+	}
+	if config.AnonymousRole != "" {
+		if _, ok := roleRank[config.AnonymousRole]; !ok {
+			return nil, fmt.Errorf("unknown anonymous role %q", config.AnonymousRole)
+		}
+		a.anonymous = &Principal{Subject: "anonymous", Role: config.AnonymousRole, Method: MethodAnonymous}
+	}
+	return a, nil
+}
+
+// Enabled reports whether any credentials are accepted or anonymous access
+// is granted. The HTTP transport refuses to start without either.
+func (a *Authenticator) Enabled() bool {
+	return len(a.keys) > 0 || len(a.jwtSecret) > 0 || a.anonymous != nil
+}
+
+// Authenticate identifies the client of an HTTP request from its X-API-Key
+// header or Authorization bearer token
+func (a *Authenticator) Authenticate(r *http.Request) (*Principal, error) {
+	return a.AuthenticateCredentials(r.Header.Get(APIKeyHeader), r.Header.Get("Authorization"))
+}
+
+// AuthenticateCredentials identifies a client from the values of its API key
+// and Authorization credentials, for transports other than HTTP
+func (a *Authenticator) AuthenticateCredentials(apiKey, authorization string) (*Principal, error) {
+	if key := strings.TrimSpace(apiKey); key != "" {
+		return a.AuthenticateToken(key)
+	}
+	if token, ok := strings.CutPrefix(authorization, "Bearer "); ok {
+		return a.AuthenticateToken(strings.TrimSpace(token))
+	}
+	if a.anonymous != nil {
+		principal := *a.anonymous
+		return &principal, nil
+	}
+	return nil, ErrUnauthenticated
+}
+
+// AuthenticateToken verifies an API key or, when JWTs are accepted, a JWT
+func (a *Authenticator) AuthenticateToken(token string) (*Principal, error) {
+	if token == "" {
+		return nil, ErrUnauthenticated
+	}
+	if principal, ok := a.keys[sha256.Sum256([]byte(token))]; ok {
+		return &principal, nil
+	}
+	if len(a.jwtSecret) > 0 && strings.Count(token, ".") == 2 {
+		principal, err := a.verifyJWT(token)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+		}
+		return principal, nil
+	}
+	return nil, ErrUnauthenticated
+}
+
+// Authorize checks that a principal holds the required role
+func Authorize(principal *Principal, required Role) error {
+	if principal == nil {
+		return ErrUnauthenticated
+	}
+	if !principal.Role.Allows(required) {
+		return fmt.Errorf("%w: requires the %s role, %s has %s", ErrForbidden, required, principal.Subject, principal.Role)
+	}
+	return nil
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the authenticated principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the principal carried by a context, or nil
+func PrincipalFrom(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
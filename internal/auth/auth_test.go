@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "jwt-test-secret"
+
+func signJWT(t *testing.T, secret string, alg string, claims map[string]interface{}) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newTestAuthenticator(t *testing.T, config Config) *Authenticator {
+	t.Helper()
+	a, err := NewAuthenticator(config)
+	require.NoError(t, err)
+	a.now = func() time.Time { return time.Unix(1_700_000_000, 0) }
+	return a
+}
+
+func TestParseRole(t *testing.T) {
+	for input, want := range map[string]Role{
+		"read_only": RoleReadOnly,
+		"read-only": RoleReadOnly,
+		"ReadOnly":  RoleReadOnly,
+		"classify":  RoleClassify,
+		"Reviewer":  RoleReviewer,
+		" admin ":   RoleAdmin,
+	} {
+		role, err := ParseRole(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, role, input)
+	}
+	_, err := ParseRole("superuser")
+	assert.Error(t, err)
+}
+
+func TestRole_Allows(t *testing.T) {
+	assert.True(t, RoleAdmin.Allows(RoleClassify))
+	assert.True(t, RoleClassify.Allows(RoleReadOnly))
+	assert.True(t, RoleClassify.Allows(RoleClassify))
+	assert.False(t, RoleReadOnly.Allows(RoleClassify))
+	assert.True(t, RoleReviewer.Allows(RoleClassify))
+	assert.True(t, RoleAdmin.Allows(RoleReviewer))
+	assert.False(t, RoleClassify.Allows(RoleReviewer))
+	assert.False(t, RoleClassify.Allows(RoleAdmin))
+	assert.False(t, Role("").Allows(Role("")))
+}
+
+func TestNewAuthenticator_RejectsInvalidKeys(t *testing.T) {
+	_, err := NewAuthenticator(Config{APIKeys: []APIKey{{Name: "lims", Role: "owner", Key: "k"}}})
+	assert.Error(t, err, "unknown role")
+
+	_, err = NewAuthenticator(Config{APIKeys: []APIKey{{Name: "lims", Role: RoleClassify}}})
+	assert.Error(t, err, "empty key")
+
+	_, err = NewAuthenticator(Config{APIKeys: []APIKey{
+		{Name: "lims", Role: RoleClassify, Key: "same"},
+		{Name: "viewer", Role: RoleReadOnly, Key: "same"},
+	}})
+	assert.Error(t, err, "duplicate key")
+
+	_, err = NewAuthenticator(Config{AnonymousRole: "guest"})
+	assert.Error(t, err, "unknown anonymous role")
+
+	a, err := NewAuthenticator(Config{})
+	require.NoError(t, err)
+	assert.False(t, a.Enabled())
+}
+
+func TestAuthenticate_APIKeys(t *testing.T) {
+	a := newTestAuthenticator(t, Config{APIKeys: []APIKey{
+		{Name: "lims", Role: RoleClassify, Key: "classify-key"},
+		{Name: "dashboard", Role: RoleReadOnly, Key: "read-key"},
+	}})
+
+	req := httptest.NewRequest("GET", "/mcp/sse", nil)
+	req.Header.Set(APIKeyHeader, "classify-key")
+	principal, err := a.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, Principal{Subject: "lims", Role: RoleClassify, Method: MethodAPIKey}, *principal)
+
+	req = httptest.NewRequest("GET", "/mcp/sse", nil)
+	req.Header.Set("Authorization", "Bearer read-key")
+	principal, err = a.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, "dashboard", principal.Subject)
+
+	req = httptest.NewRequest("GET", "/mcp/sse", nil)
+	req.Header.Set(APIKeyHeader, "wrong")
+	_, err = a.Authenticate(req)
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	_, err = a.Authenticate(httptest.NewRequest("GET", "/mcp/sse", nil))
+	assert.ErrorIs(t, err, ErrUnauthenticated, "credentials are required without an anonymous role")
+}
+
+func TestAuthenticate_Anonymous(t *testing.T) {
+	a := newTestAuthenticator(t, Config{AnonymousRole: RoleReadOnly})
+	principal, err := a.Authenticate(httptest.NewRequest("GET", "/mcp/sse", nil))
+	require.NoError(t, err)
+	assert.Equal(t, MethodAnonymous, principal.Method)
+	assert.Equal(t, RoleReadOnly, principal.Role)
+
+	// A wrong credential is not downgraded to anonymous access
+	req := httptest.NewRequest("GET", "/mcp/sse", nil)
+	req.Header.Set(APIKeyHeader, "wrong")
+	_, err = a.Authenticate(req)
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+}
+
+func TestAuthenticate_JWT(t *testing.T) {
+	a := newTestAuthenticator(t, Config{JWTSecret: testSecret, JWTIssuer: "https://idp.example.org", JWTAudience: "acmg-amp-mcp"})
+	now := a.now().Unix()
+	valid := map[string]interface{}{
+		"sub":   "jdoe",
+		"roles": []string{"read_only", "classify", "billing"},
+		"iss":   "https://idp.example.org",
+		"aud":   []string{"other", "acmg-amp-mcp"},
+		"exp":   now + 3600,
+	}
+
+	principal, err := a.AuthenticateToken(signJWT(t, testSecret, "HS256", valid))
+	require.NoError(t, err)
+	assert.Equal(t, Principal{Subject: "jdoe", Role: RoleClassify, Method: MethodJWT}, *principal)
+
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := make(map[string]interface{}, len(valid))
+		for k, v := range valid {
+			claims[k] = v
+		}
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	for name, token := range map[string]string{
+		"wrong secret":     signJWT(t, "other-secret", "HS256", valid),
+		"alg none":         signJWT(t, testSecret, "none", valid),
+		"expired":          signJWT(t, testSecret, "HS256", with("exp", now-3600)),
+		"no expiry":        signJWT(t, testSecret, "HS256", with("exp", nil)),
+		"not yet valid":    signJWT(t, testSecret, "HS256", with("nbf", now+3600)),
+		"wrong issuer":     signJWT(t, testSecret, "HS256", with("iss", "https://evil.example.org")),
+		"wrong audience":   signJWT(t, testSecret, "HS256", with("aud", "other")),
+		"no subject":       signJWT(t, testSecret, "HS256", with("sub", nil)),
+		"no known role":    signJWT(t, testSecret, "HS256", with("roles", []string{"billing"})),
+		"tampered payload": signJWT(t, testSecret, "HS256", valid)[:20] + "x" + signJWT(t, testSecret, "HS256", valid)[21:],
+	} {
+		_, err := a.AuthenticateToken(token)
+		assert.ErrorIs(t, err, ErrUnauthenticated, name)
+	}
+
+	// JWTs are not accepted without a secret
+	keysOnly := newTestAuthenticator(t, Config{APIKeys: []APIKey{{Name: "lims", Role: RoleClassify, Key: "k"}}})
+	_, err = keysOnly.AuthenticateToken(signJWT(t, testSecret, "HS256", valid))
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+}
+
+func TestAuthorize(t *testing.T) {
+	reader := &Principal{Subject: "dashboard", Role: RoleReadOnly, Method: MethodAPIKey}
+	assert.NoError(t, Authorize(reader, RoleReadOnly))
+	assert.True(t, errors.Is(Authorize(reader, RoleClassify), ErrForbidden))
+	assert.True(t, errors.Is(Authorize(nil, RoleReadOnly), ErrUnauthenticated))
+
+	ctx := WithPrincipal(context.Background(), reader)
+	assert.Equal(t, reader, PrincipalFrom(ctx))
+	assert.Nil(t, PrincipalFrom(context.Background()))
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// jwtClaims are the JWT claims the authenticator reads. The role comes from
+// a role claim or, for identity providers that issue lists, the most
+// privileged recognized entry of a roles claim.
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Role      string          `json:"role"`
+	Roles     []string        `json:"roles"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"` // A string or an array of strings
+	ExpiresAt *int64          `json:"exp"`
+	NotBefore *int64          `json:"nbf"`
+}
+
+// jwtLeeway tolerates clock skew between the issuer and this server
+const jwtLeeway = 30 * time.Second
+
+// verifyJWT verifies an HS256 JWT and returns its principal. Expiry is
+// required so that a leaked token cannot be used indefinitely.
+func (a *Authenticator) verifyJWT(token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed JWT")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed JWT header: %w", err)
+	}
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT signature")
+	}
+	mac := hmac.New(sha256.New, a.jwtSecret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("invalid JWT signature")
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed JWT claims: %w", err)
+	}
+
+	now := a.now()
+	if claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("JWT has no exp claim")
+	}
+	if now.After(time.Unix(*claims.ExpiresAt, 0).Add(jwtLeeway)) {
+		return nil, fmt.Errorf("JWT expired")
+	}
+	if claims.NotBefore != nil && now.Add(jwtLeeway).Before(time.Unix(*claims.NotBefore, 0)) {
+		return nil, fmt.Errorf("JWT not yet valid")
+	}
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return nil, fmt.Errorf("JWT issuer %q not accepted", claims.Issuer)
+	}
+	if a.audience != "" && !audienceContains(claims.Audience, a.audience) {
+		return nil, fmt.Errorf("JWT audience does not include %q", a.audience)
+	}
+	if strings.TrimSpace(claims.Subject) == "" {
+		return nil, fmt.Errorf("JWT has no sub claim")
+	}
+
+	role, ok := claims.role()
+	if !ok {
+		return nil, fmt.Errorf("JWT grants no recognized role")
+	}
+	return &Principal{Subject: claims.Subject, Role: role, Method: MethodJWT}, nil
+}
+
+// role returns the most privileged recognized role among the claims
+func (c jwtClaims) role() (Role, bool) {
+	var best Role
+	for _, name := range append([]string{c.Role}, c.Roles...) {
+		if name == "" {
+			continue
+		}
+		if role, err := ParseRole(name); err == nil && roleRank[role] > roleRank[best] {
+			best = role
+		}
+	}
+	return best, best != ""
+}
+
+// audienceContains reports whether an aud claim names the audience
+func audienceContains(raw json.RawMessage, audience string) bool {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return single == audience
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		for _, aud := range list {
+			if aud == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// decodeSegment decodes a base64url JWT segment into v
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,131 @@
+// Package beacon serves the lab knowledge base as a GA4GH Beacon v2 API, so
+// the lab can take part in federated variant sharing. A genomic variant
+// query is answered from the lab's signed-out assertions at the granularity
+// the client is entitled to: whether the lab has seen the allele (boolean),
+// how many assertions match (count), or the matching assertions with their
+// classification and condition (record). Internal evidence, proband counts
+// and curator names are never returned.
+package beacon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+)
+
+// APIVersion is the Beacon specification version served.
+const APIVersion = "v2.0.0"
+
+// BasePath is where the Beacon API is served.
+const BasePath = "/api/beacon/v2"
+
+// Granularity is the level of detail of a Beacon response.
+type Granularity string
+
+const (
+	// GranularityNone grants no access.
+	GranularityNone Granularity = ""
+	// GranularityBoolean answers whether any variant matches.
+	GranularityBoolean Granularity = "boolean"
+	// GranularityCount answers how many variants match.
+	GranularityCount Granularity = "count"
+	// GranularityRecord returns the matching variants.
+	GranularityRecord Granularity = "record"
+)
+
+// granularityRank orders granularities from least to most detailed
+var granularityRank = map[Granularity]int{
+	GranularityNone:    0,
+	GranularityBoolean: 1,
+	GranularityCount:   2,
+	GranularityRecord:  3,
+}
+
+// ParseGranularity parses a granularity name, accepting full for record and
+// none or an empty string for no access.
+func ParseGranularity(s string) (Granularity, error) {
+	switch normalized := strings.ToLower(strings.TrimSpace(s)); normalized {
+	case "", "none":
+		return GranularityNone, nil
+	case "full":
+		return GranularityRecord, nil
+	default:
+		if _, ok := granularityRank[Granularity(normalized)]; ok {
+			return Granularity(normalized), nil
+		}
+	}
+	return "", fmt.Errorf("unknown beacon granularity %q: must be none, boolean, count or record", s)
+}
+
+// Includes reports whether the granularity is at least as detailed as other.
+func (g Granularity) Includes(other Granularity) bool {
+	return granularityRank[g] >= granularityRank[other]
+}
+
+// Tiers grants response granularities. Clients without credentials get
+// Public; an authenticated client gets the most detailed granularity of the
+// roles it holds, and at least Public.
+type Tiers struct {
+	Public Granularity
+	Roles  map[auth.Role]Granularity
+}
+
+// ParseTiers parses the public granularity and role grants such as
+// "read_only=count,reviewer=record".
+func ParseTiers(public, roles string) (Tiers, error) {
+	granularity, err := ParseGranularity(public)
+	if err != nil {
+		return Tiers{}, err
+	}
+	tiers := Tiers{Public: granularity, Roles: make(map[auth.Role]Granularity)}
+	for _, grant := range strings.Split(roles, ",") {
+		if strings.TrimSpace(grant) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(grant, "=")
+		if !ok {
+			return Tiers{}, fmt.Errorf("invalid beacon role grant %q: expected role=granularity", grant)
+		}
+		role, err := auth.ParseRole(name)
+		if err != nil {
+			return Tiers{}, err
+		}
+		granularity, err := ParseGranularity(value)
+		if err != nil {
+			return Tiers{}, err
+		}
+		tiers.Roles[role] = granularity
+	}
+	return tiers, nil
+}
+
+// Granted returns the granularity a client is entitled to. A nil or
+// anonymous principal is a client without credentials.
+func (t Tiers) Granted(principal *auth.Principal) Granularity {
+	granted := t.Public
+	if principal == nil || principal.Method == auth.MethodAnonymous {
+		return granted
+	}
+	for role, granularity := range t.Roles {
+		if principal.Role.Allows(role) && !granted.Includes(granularity) {
+			granted = granularity
+		}
+	}
+	return granted
+}
+
+// Config describes the beacon in its info response.
+type Config struct {
+	ID           string // Reverse domain name, e.g. org.example.lab.beacon
+	Name         string
+	Organization string // Name of the organization running the beacon
+	Assembly     string // Genome assembly sequence queries are interpreted against
+	Version      string // Version of the server
+	Tiers        Tiers
+}
+
+// Public reports whether clients without credentials may query the beacon.
+func (c Config) Public() bool {
+	return c.Tiers.Public != GranularityNone
+}
@@ -0,0 +1,187 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+func createTestHandler(t *testing.T) *Handler {
+	t.Helper()
+	store, err := labkb.NewSQLiteStore(filepath.Join(t.TempDir(), "labkb.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	ctx := context.Background()
+	for _, assertion := range []*labkb.Assertion{
+		{
+			NormalizedHGVS: "NC_000017.11:g.43057051A>G", GeneSymbol: "BRCA1", HGVSProtein: "p.Arg1699Trp",
+			Classification: "PATHOGENIC", Condition: "Hereditary breast ovarian cancer syndrome", ConditionID: "MONDO:0011450",
+			AffectedProbands: 4, Evidence: "Segregates in family F-12", EvaluatedBy: "curator-7",
+		},
+		{
+			NormalizedHGVS: "NM_007294.4:c.5266dupC", GeneSymbol: "BRCA1", HGVSProtein: "p.Gln1756ProfsTer74",
+			Classification: "PATHOGENIC", EvaluatedBy: "curator-7",
+		},
+		{
+			NormalizedHGVS: "NM_000492.4:c.1521_1523del", GeneSymbol: "CFTR", HGVSProtein: "p.Phe508del",
+			Classification: "PATHOGENIC", EvaluatedBy: "curator-7",
+		},
+	} {
+		require.NoError(t, store.Save(ctx, assertion))
+	}
+
+	tiers, err := ParseTiers("boolean", "read_only=count,reviewer=record")
+	require.NoError(t, err)
+	logger, _ := test.NewNullLogger()
+	return NewHandler(logger, store, Config{
+		ID:       "org.example.lab.beacon",
+		Name:     "Example lab beacon",
+		Assembly: hgvs.AssemblyGRCh38,
+		Tiers:    tiers,
+	})
+}
+
+func doQuery(t *testing.T, h *Handler, method, target, body string, principal *auth.Principal) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if principal != nil {
+		req = req.WithContext(auth.WithPrincipal(req.Context(), principal))
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return rec, response
+}
+
+func TestHandler_Granularity(t *testing.T) {
+	h := createTestHandler(t)
+	reader := &auth.Principal{Subject: "partner-lab", Role: auth.RoleReadOnly, Method: auth.MethodAPIKey}
+	reviewer := &auth.Principal{Subject: "network-curator", Role: auth.RoleReviewer, Method: auth.MethodJWT}
+	query := BasePath + "/g_variants?geneId=BRCA1&requestedGranularity=record"
+
+	t.Run("public clients get boolean answers", func(t *testing.T) {
+		rec, response := doQuery(t, h, http.MethodGet, query, "", nil)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "boolean", response["meta"].(map[string]interface{})["returnedGranularity"])
+		assert.Equal(t, map[string]interface{}{"exists": true}, response["responseSummary"])
+		assert.NotContains(t, response, "response")
+	})
+
+	t.Run("read_only clients get counts", func(t *testing.T) {
+		_, response := doQuery(t, h, http.MethodGet, query, "", reader)
+
+		assert.Equal(t, "count", response["meta"].(map[string]interface{})["returnedGranularity"])
+		assert.Equal(t, float64(2), response["responseSummary"].(map[string]interface{})["numTotalResults"])
+		assert.NotContains(t, response, "response")
+	})
+
+	t.Run("reviewers get records", func(t *testing.T) {
+		rec, response := doQuery(t, h, http.MethodGet, query, "", reviewer)
+
+		assert.Equal(t, "record", response["meta"].(map[string]interface{})["returnedGranularity"])
+		resultSets := response["response"].(map[string]interface{})["resultSets"].([]interface{})
+		require.Len(t, resultSets, 1)
+		results := resultSets[0].(map[string]interface{})["results"].([]interface{})
+		require.Len(t, results, 2)
+		assert.NotContains(t, rec.Body.String(), "curator-7", "curators are not disclosed")
+		assert.NotContains(t, rec.Body.String(), "F-12", "internal evidence is not disclosed")
+	})
+
+	t.Run("a lower granularity can be requested", func(t *testing.T) {
+		_, response := doQuery(t, h, http.MethodGet, BasePath+"/g_variants?geneId=BRCA1&requestedGranularity=boolean", "", reviewer)
+
+		assert.Equal(t, "boolean", response["meta"].(map[string]interface{})["returnedGranularity"])
+	})
+}
+
+func TestHandler_Queries(t *testing.T) {
+	h := createTestHandler(t)
+	reviewer := &auth.Principal{Subject: "network-curator", Role: auth.RoleReviewer, Method: auth.MethodJWT}
+
+	t.Run("sequence query with a 0-based start", func(t *testing.T) {
+		_, response := doQuery(t, h, http.MethodGet, BasePath+"/g_variants?assemblyId=GRCh38&referenceName=17&start=43057050&referenceBases=A&alternateBases=G", "", reviewer)
+
+		results := response["response"].(map[string]interface{})["resultSets"].([]interface{})[0].(map[string]interface{})["results"].([]interface{})
+		require.Len(t, results, 1)
+		variant := results[0].(map[string]interface{})
+		assert.Equal(t, "NC_000017.11:g.43057051A>G", variant["identifiers"].(map[string]interface{})["genomicHGVSId"])
+		assert.Equal(t, []interface{}{"R1699W"}, variant["molecularAttributes"].(map[string]interface{})["aminoacidChanges"])
+		interpretation := variant["variantLevelData"].(map[string]interface{})["clinicalInterpretations"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "pathogenic", interpretation["clinicalRelevance"])
+		assert.Equal(t, "MONDO:0011450", interpretation["conditionId"])
+	})
+
+	t.Run("gene and amino acid change by POST", func(t *testing.T) {
+		body := `{"meta": {"apiVersion": "v2.0"}, "query": {"requestParameters": {"geneId": "BRCA1", "aminoacidChange": "p.Arg1699Trp"}, "requestedGranularity": "count"}}`
+
+		rec, response := doQuery(t, h, http.MethodPost, BasePath+"/g_variants", body, reviewer)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, float64(1), response["responseSummary"].(map[string]interface{})["numTotalResults"])
+	})
+
+	t.Run("allele not seen", func(t *testing.T) {
+		_, response := doQuery(t, h, http.MethodGet, BasePath+"/g_variants?genomicAlleleShortForm=NC_000017.11:g.43057051A>T", "", nil)
+
+		assert.Equal(t, false, response["responseSummary"].(map[string]interface{})["exists"])
+	})
+
+	for name, target := range map[string]string{
+		"no query":               BasePath + "/g_variants",
+		"other assembly":         BasePath + "/g_variants?assemblyId=GRCh37&geneId=BRCA1",
+		"range query":            BasePath + "/g_variants?referenceName=17&start=43057050,43057060&referenceBases=A&alternateBases=G",
+		"filters":                BasePath + "/g_variants?geneId=BRCA1&filters=MONDO:0011450",
+		"unknown granularity":    BasePath + "/g_variants?geneId=BRCA1&requestedGranularity=everything",
+		"unknown chromosome":     BasePath + "/g_variants?referenceName=chrQ&start=1&referenceBases=A&alternateBases=G",
+		"non-numeric pagination": BasePath + "/g_variants?geneId=BRCA1&limit=ten",
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec, response := doQuery(t, h, http.MethodGet, target, "", nil)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, float64(http.StatusBadRequest), response["error"].(map[string]interface{})["errorCode"])
+		})
+	}
+}
+
+func TestHandler_Info(t *testing.T) {
+	h := createTestHandler(t)
+
+	rec, response := doQuery(t, h, http.MethodGet, BasePath+"/info", "", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	info := response["response"].(map[string]interface{})
+	assert.Equal(t, "org.example.lab.beacon", info["id"])
+	assert.Equal(t, APIVersion, info["apiVersion"])
+}
+
+func TestTiers(t *testing.T) {
+	tiers, err := ParseTiers("none", "read_only=count, classify=full")
+	require.NoError(t, err)
+
+	assert.Equal(t, GranularityNone, tiers.Granted(nil))
+	assert.Equal(t, GranularityNone, tiers.Granted(&auth.Principal{Role: auth.RoleAdmin, Method: auth.MethodAnonymous}))
+	assert.Equal(t, GranularityCount, tiers.Granted(&auth.Principal{Role: auth.RoleReadOnly, Method: auth.MethodAPIKey}))
+	assert.Equal(t, GranularityRecord, tiers.Granted(&auth.Principal{Role: auth.RoleAdmin, Method: auth.MethodAPIKey}))
+	assert.False(t, Config{Tiers: tiers}.Public())
+
+	for _, invalid := range [][2]string{{"everything", ""}, {"boolean", "read_only"}, {"boolean", "curator=count"}, {"boolean", "read_only=all"}} {
+		_, err := ParseTiers(invalid[0], invalid[1])
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,501 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// Pagination limits of record responses
+const (
+	defaultLimit = 10
+	maxLimit     = 100
+)
+
+// maxRequestBytes bounds the body of POST queries
+const maxRequestBytes = 1 << 20
+
+// resultSetID identifies the lab knowledge base in record responses
+const resultSetID = "lab-knowledge-base"
+
+// Endpoint is a method and path of the Beacon API.
+type Endpoint struct {
+	Method   string
+	Path     string
+	Summary  string
+	Response interface{} // Body of a successful response
+}
+
+// Endpoints are the endpoints the handler serves.
+var Endpoints = []Endpoint{
+	{http.MethodGet, BasePath, "Beacon information", InfoResponse{}},
+	{http.MethodGet, BasePath + "/info", "Beacon information", InfoResponse{}},
+	{http.MethodGet, BasePath + "/g_variants", "Query genomic variants", Response{}},
+	{http.MethodPost, BasePath + "/g_variants", "Query genomic variants with a Beacon request body", Response{}},
+}
+
+// Schema names the schema of returned entities.
+type Schema struct {
+	EntityType string `json:"entityType"`
+	Schema     string `json:"schema"`
+}
+
+var (
+	infoSchema    = Schema{EntityType: "info", Schema: "beacon-info-v2.0.0"}
+	variantSchema = Schema{EntityType: "genomicVariant", Schema: "beacon-g_variant-v2.0.0"}
+)
+
+// RequestParameters are the genomic variant query parameters supported: a
+// sequence query (referenceName, start, referenceBases and alternateBases),
+// a genomic HGVS allele, or a gene with an optional amino acid change.
+type RequestParameters struct {
+	AssemblyID             string  `json:"assemblyId,omitempty"`
+	ReferenceName          string  `json:"referenceName,omitempty"`
+	Start                  []int64 `json:"start,omitempty"` // 0-based; only a single position is supported
+	ReferenceBases         string  `json:"referenceBases,omitempty"`
+	AlternateBases         string  `json:"alternateBases,omitempty"`
+	GenomicAlleleShortForm string  `json:"genomicAlleleShortForm,omitempty"`
+	GeneID                 string  `json:"geneId,omitempty"`
+	AminoacidChange        string  `json:"aminoacidChange,omitempty"`
+}
+
+// Pagination selects a page of record results.
+type Pagination struct {
+	Skip  int `json:"skip"`
+	Limit int `json:"limit"`
+}
+
+// Query is the query of a POST request.
+type Query struct {
+	RequestParameters    RequestParameters `json:"requestParameters"`
+	Filters              []json.RawMessage `json:"filters,omitempty"`
+	Pagination           Pagination        `json:"pagination"`
+	RequestedGranularity string            `json:"requestedGranularity,omitempty"`
+}
+
+// Request is the body of a POST request.
+type Request struct {
+	Meta struct {
+		APIVersion string `json:"apiVersion,omitempty"`
+	} `json:"meta"`
+	Query Query `json:"query"`
+}
+
+// Meta describes a response.
+type Meta struct {
+	BeaconID               string          `json:"beaconId"`
+	APIVersion             string          `json:"apiVersion"`
+	ReturnedGranularity    Granularity     `json:"returnedGranularity,omitempty"`
+	ReceivedRequestSummary *RequestSummary `json:"receivedRequestSummary,omitempty"`
+	ReturnedSchemas        []Schema        `json:"returnedSchemas"`
+}
+
+// RequestSummary echoes the query a response answers.
+type RequestSummary struct {
+	APIVersion           string            `json:"apiVersion"`
+	RequestedSchemas     []Schema          `json:"requestedSchemas"`
+	Pagination           Pagination        `json:"pagination"`
+	RequestedGranularity Granularity       `json:"requestedGranularity"`
+	RequestParameters    RequestParameters `json:"requestParameters"`
+}
+
+// ResponseSummary answers whether, and at count granularity how many,
+// variants match.
+type ResponseSummary struct {
+	Exists          bool `json:"exists"`
+	NumTotalResults *int `json:"numTotalResults,omitempty"`
+}
+
+// ResultSet holds the matching variants of one dataset.
+type ResultSet struct {
+	ID           string     `json:"id"`
+	SetType      string     `json:"setType"`
+	Exists       bool       `json:"exists"`
+	ResultsCount int        `json:"resultsCount"`
+	Results      []*Variant `json:"results"`
+}
+
+// ResultSets is the body of a record response.
+type ResultSets struct {
+	ResultSets []*ResultSet `json:"resultSets"`
+}
+
+// Response answers a genomic variant query.
+type Response struct {
+	Meta            Meta            `json:"meta"`
+	ResponseSummary ResponseSummary `json:"responseSummary"`
+	Response        *ResultSets     `json:"response,omitempty"` // Only at record granularity
+}
+
+// Variant is a genomic variant record.
+type Variant struct {
+	VariantInternalID   string               `json:"variantInternalId"`
+	Identifiers         Identifiers          `json:"identifiers"`
+	MolecularAttributes *MolecularAttributes `json:"molecularAttributes,omitempty"`
+	VariantLevelData    VariantLevelData     `json:"variantLevelData"`
+}
+
+// Identifiers are the HGVS descriptions of a variant.
+type Identifiers struct {
+	GenomicHGVSID     string   `json:"genomicHGVSId,omitempty"`
+	TranscriptHGVSIDs []string `json:"transcriptHGVSIds,omitempty"`
+	ProteinHGVSIDs    []string `json:"proteinHGVSIds,omitempty"`
+}
+
+// MolecularAttributes are the gene and protein change of a variant.
+type MolecularAttributes struct {
+	GeneIDs          []string `json:"geneIds,omitempty"`
+	AminoacidChanges []string `json:"aminoacidChanges,omitempty"`
+}
+
+// VariantLevelData carries the lab's interpretation of a variant.
+type VariantLevelData struct {
+	ClinicalInterpretations []ClinicalInterpretation `json:"clinicalInterpretations"`
+}
+
+// ClinicalInterpretation is the lab's classification of a variant for a condition.
+type ClinicalInterpretation struct {
+	ConditionID       string        `json:"conditionId"`
+	ClinicalRelevance string        `json:"clinicalRelevance"`
+	Effect            *OntologyTerm `json:"effect,omitempty"`
+}
+
+// OntologyTerm is a term of an ontology such as OMIM or MONDO.
+type OntologyTerm struct {
+	ID    string `json:"id"`
+	Label string `json:"label,omitempty"`
+}
+
+// Info describes the beacon.
+type Info struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	APIVersion   string       `json:"apiVersion"`
+	Environment  string       `json:"environment"`
+	Organization Organization `json:"organization"`
+	Description  string       `json:"description"`
+	Version      string       `json:"version,omitempty"`
+}
+
+// Organization runs the beacon.
+type Organization struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// InfoResponse is the body of GET /info.
+type InfoResponse struct {
+	Meta     Meta `json:"meta"`
+	Response Info `json:"response"`
+}
+
+// Error is a failed request.
+type Error struct {
+	ErrorCode    int    `json:"errorCode"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// ErrorResponse is the body of a failed request.
+type ErrorResponse struct {
+	Meta  Meta  `json:"meta"`
+	Error Error `json:"error"`
+}
+
+// clinicalRelevance maps classifications to Beacon clinical relevance terms
+var clinicalRelevance = map[string]string{
+	string(domain.PATHOGENIC):        "pathogenic",
+	string(domain.LIKELY_PATHOGENIC): "likely pathogenic",
+	string(domain.VUS):               "uncertain significance",
+	string(domain.LIKELY_BENIGN):     "likely benign",
+	string(domain.BENIGN):            "benign",
+}
+
+// errInvalidQuery is returned for queries the beacon cannot answer
+var errInvalidQuery = errors.New("invalid query")
+
+// Handler serves the Beacon API.
+type Handler struct {
+	logger *logrus.Logger
+	store  labkb.Store
+	config Config
+	mux    *http.ServeMux
+}
+
+// NewHandler creates a Beacon API handler over the lab knowledge base.
+// Clients are entitled to granularities by config.Tiers, from the
+// principal the transport authenticated.
+func NewHandler(logger *logrus.Logger, store labkb.Store, config Config) *Handler {
+	h := &Handler{logger: logger, store: store, config: config, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET "+BasePath, h.handleInfo)
+	h.mux.HandleFunc("GET "+BasePath+"/info", h.handleInfo)
+	h.mux.HandleFunc("GET "+BasePath+"/g_variants", h.handleVariants)
+	h.mux.HandleFunc("POST "+BasePath+"/g_variants", h.handleVariants)
+	return h
+}
+
+// ServeHTTP serves a Beacon API request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// meta returns the response metadata
+func (h *Handler) meta(schema Schema) Meta {
+	return Meta{BeaconID: h.config.ID, APIVersion: APIVersion, ReturnedSchemas: []Schema{schema}}
+}
+
+func (h *Handler) handleInfo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, InfoResponse{
+		Meta: h.meta(infoSchema),
+		Response: Info{
+			ID:           h.config.ID,
+			Name:         h.config.Name,
+			APIVersion:   APIVersion,
+			Environment:  "prod",
+			Organization: Organization{ID: h.config.Organization, Name: h.config.Organization},
+			Description:  fmt.Sprintf("Variants classified by the lab under ACMG/AMP guidelines, on %s", h.config.Assembly),
+			Version:      h.config.Version,
+		},
+	})
+}
+
+func (h *Handler) handleVariants(w http.ResponseWriter, r *http.Request) {
+	granted := h.config.Tiers.Granted(auth.PrincipalFrom(r.Context()))
+	if granted == GranularityNone {
+		h.writeError(w, http.StatusForbidden, "role is not granted access to the beacon")
+		return
+	}
+
+	query, err := readQuery(w, r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	requested := granted
+	if query.RequestedGranularity != "" {
+		requested, err = ParseGranularity(query.RequestedGranularity)
+		if err != nil || requested == GranularityNone {
+			h.writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown requestedGranularity %q", query.RequestedGranularity))
+			return
+		}
+	}
+	returned := requested
+	if !granted.Includes(requested) {
+		returned = granted
+	}
+	pagination := query.Pagination
+	if pagination.Skip < 0 {
+		pagination.Skip = 0
+	}
+	if pagination.Limit <= 0 {
+		pagination.Limit = defaultLimit
+	}
+	pagination.Limit = min(pagination.Limit, maxLimit)
+
+	assertions, err := h.find(r.Context(), query)
+	if err != nil {
+		if errors.Is(err, errInvalidQuery) {
+			h.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.WithError(err).Error("Failed to query lab knowledge base for beacon")
+		h.writeError(w, http.StatusInternalServerError, "failed to query the lab knowledge base")
+		return
+	}
+
+	meta := h.meta(variantSchema)
+	meta.ReturnedGranularity = returned
+	meta.ReceivedRequestSummary = &RequestSummary{
+		APIVersion:           APIVersion,
+		RequestedSchemas:     []Schema{variantSchema},
+		Pagination:           pagination,
+		RequestedGranularity: requested,
+		RequestParameters:    query.RequestParameters,
+	}
+	response := Response{Meta: meta, ResponseSummary: ResponseSummary{Exists: len(assertions) > 0}}
+	if returned.Includes(GranularityCount) {
+		total := len(assertions)
+		response.ResponseSummary.NumTotalResults = &total
+	}
+	if returned == GranularityRecord {
+		results := make([]*Variant, 0, pagination.Limit)
+		for i := pagination.Skip; i < len(assertions) && len(results) < pagination.Limit; i++ {
+			results = append(results, toVariant(assertions[i]))
+		}
+		response.Response = &ResultSets{ResultSets: []*ResultSet{{
+			ID:           resultSetID,
+			SetType:      "dataset",
+			Exists:       len(assertions) > 0,
+			ResultsCount: len(assertions),
+			Results:      results,
+		}}}
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"granularity": returned,
+		"exists":      response.ResponseSummary.Exists,
+		"results":     len(assertions),
+	}).Info("Answered beacon query")
+	writeJSON(w, http.StatusOK, response)
+}
+
+// readQuery reads the query from the URL of a GET request or the body of a POST request
+func readQuery(w http.ResponseWriter, r *http.Request) (Query, error) {
+	if r.Method == http.MethodPost {
+		var request Request
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&request); err != nil {
+			return Query{}, fmt.Errorf("invalid request body: %v", err)
+		}
+		return request.Query, nil
+	}
+
+	values := r.URL.Query()
+	query := Query{
+		RequestParameters: RequestParameters{
+			AssemblyID:             values.Get("assemblyId"),
+			ReferenceName:          values.Get("referenceName"),
+			ReferenceBases:         values.Get("referenceBases"),
+			AlternateBases:         values.Get("alternateBases"),
+			GenomicAlleleShortForm: values.Get("genomicAlleleShortForm"),
+			GeneID:                 values.Get("geneId"),
+			AminoacidChange:        values.Get("aminoacidChange"),
+		},
+		RequestedGranularity: values.Get("requestedGranularity"),
+	}
+	if v := values.Get("start"); v != "" {
+		for _, position := range strings.Split(v, ",") {
+			start, err := strconv.ParseInt(strings.TrimSpace(position), 10, 64)
+			if err != nil {
+				return Query{}, fmt.Errorf("start must be an integer, got %q", position)
+			}
+			query.RequestParameters.Start = append(query.RequestParameters.Start, start)
+		}
+	}
+	if v := values.Get("filters"); v != "" {
+		query.Filters = []json.RawMessage{json.RawMessage(strconv.Quote(v))}
+	}
+	for name, target := range map[string]*int{"skip": &query.Pagination.Skip, "limit": &query.Pagination.Limit} {
+		if v := values.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return Query{}, fmt.Errorf("%s must be an integer, got %q", name, v)
+			}
+			*target = n
+		}
+	}
+	return query, nil
+}
+
+// find returns the assertions matching a query. Filters are rejected rather
+// than ignored, so a filtered query is never answered as if unfiltered.
+func (h *Handler) find(ctx context.Context, query Query) ([]*labkb.Assertion, error) {
+	params := query.RequestParameters
+	if len(query.Filters) > 0 {
+		return nil, fmt.Errorf("%w: filters are not supported", errInvalidQuery)
+	}
+	if params.AssemblyID != "" {
+		assembly, err := hgvs.ParseAssembly(params.AssemblyID)
+		if err != nil || assembly != h.config.Assembly {
+			return nil, fmt.Errorf("%w: assemblyId %q is not served; the beacon serves %s", errInvalidQuery, params.AssemblyID, h.config.Assembly)
+		}
+	}
+
+	allele := strings.TrimSpace(params.GenomicAlleleShortForm)
+	if params.ReferenceName != "" || len(params.Start) > 0 || params.ReferenceBases != "" || params.AlternateBases != "" {
+		if params.ReferenceName == "" || len(params.Start) != 1 || params.ReferenceBases == "" || params.AlternateBases == "" {
+			return nil, fmt.Errorf("%w: sequence queries need referenceName, a single start, referenceBases and alternateBases", errInvalidQuery)
+		}
+		notation, err := hgvs.FromVCF(h.config.Assembly, params.ReferenceName, params.Start[0]+1, params.ReferenceBases, params.AlternateBases)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidQuery, err)
+		}
+		allele = notation
+	}
+
+	gene := strings.ToUpper(strings.TrimSpace(params.GeneID))
+	var candidates []*labkb.Assertion
+	switch {
+	case allele != "":
+		assertion, err := h.store.Lookup(ctx, allele)
+		if err != nil {
+			return nil, err
+		}
+		if assertion != nil {
+			candidates = append(candidates, assertion)
+		}
+	case gene != "":
+		assertions, err := h.store.List(ctx, labkb.Filter{GeneSymbol: gene})
+		if err != nil {
+			return nil, err
+		}
+		candidates = assertions
+	default:
+		return nil, fmt.Errorf("%w: a sequence query, genomicAlleleShortForm or geneId is required", errInvalidQuery)
+	}
+
+	change := hgvs.ShortProteinChange(params.AminoacidChange)
+	matches := make([]*labkb.Assertion, 0, len(candidates))
+	for _, assertion := range candidates {
+		if gene != "" && assertion.GeneSymbol != gene {
+			continue
+		}
+		if change != "" && hgvs.ShortProteinChange(assertion.HGVSProtein) != change {
+			continue
+		}
+		matches = append(matches, assertion)
+	}
+	return matches, nil
+}
+
+// toVariant describes an assertion as a genomic variant record
+func toVariant(a *labkb.Assertion) *Variant {
+	variant := &Variant{VariantInternalID: fmt.Sprintf("%s:%d", resultSetID, a.ID)}
+	switch {
+	case strings.Contains(a.NormalizedHGVS, ":g."):
+		variant.Identifiers.GenomicHGVSID = a.NormalizedHGVS
+	default:
+		variant.Identifiers.TranscriptHGVSIDs = []string{a.NormalizedHGVS}
+	}
+	if a.HGVSProtein != "" {
+		variant.Identifiers.ProteinHGVSIDs = []string{a.HGVSProtein}
+	}
+	if a.GeneSymbol != "" {
+		variant.MolecularAttributes = &MolecularAttributes{GeneIDs: []string{a.GeneSymbol}}
+		if change := hgvs.ShortProteinChange(a.HGVSProtein); change != "" {
+			variant.MolecularAttributes.AminoacidChanges = []string{change}
+		}
+	}
+
+	interpretation := ClinicalInterpretation{ConditionID: a.ConditionID, ClinicalRelevance: clinicalRelevance[a.Classification]}
+	if a.ConditionID != "" {
+		interpretation.Effect = &OntologyTerm{ID: a.ConditionID, Label: a.Condition}
+	} else {
+		interpretation.ConditionID = a.Condition
+	}
+	variant.VariantLevelData.ClinicalInterpretations = []ClinicalInterpretation{interpretation}
+	return variant
+}
+
+// writeError responds with a Beacon error
+func (h *Handler) writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, ErrorResponse{
+		Meta:  h.meta(variantSchema),
+		Error: Error{ErrorCode: status, ErrorMessage: message},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,179 @@
+package benign
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite known benign store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables and indexes.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS known_benign (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		normalized_hgvs TEXT NOT NULL UNIQUE,
+		gene_symbol TEXT DEFAULT '',
+		classification TEXT NOT NULL,
+		evidence TEXT NOT NULL,
+		confirmed_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_known_benign_gene ON known_benign(gene_symbol);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+const entryColumns = `id, normalized_hgvs, gene_symbol, classification, evidence, confirmed_by, created_at`
+
+// scanner is an interface for sql.Row and sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEntry scans a row into an Entry struct.
+func scanEntry(s scanner) (*Entry, error) {
+	e := &Entry{}
+	err := s.Scan(&e.ID, &e.NormalizedHGVS, &e.GeneSymbol, &e.Classification, &e.Evidence, &e.ConfirmedBy, &e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Add lists a variant, setting the entry's ID and creation time.
+func (s *SQLiteStore) Add(ctx context.Context, entry *Entry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := s.Lookup(ctx, entry.NormalizedHGVS)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("%w: %s", ErrAlreadyListed, entry.NormalizedHGVS)
+	}
+
+	entry.CreatedAt = time.Now().UTC()
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO known_benign (normalized_hgvs, gene_symbol, classification, evidence, confirmed_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, entry.NormalizedHGVS, entry.GeneSymbol, entry.Classification, entry.Evidence, entry.ConfirmedBy, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert known benign entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get known benign entry ID: %w", err)
+	}
+	entry.ID = id
+	return nil
+}
+
+// Remove deletes an entry.
+func (s *SQLiteStore) Remove(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM known_benign WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to remove known benign entry: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Lookup returns the entry for a variant, or nil.
+func (s *SQLiteStore) Lookup(ctx context.Context, normalizedHGVS string) (*Entry, error) {
+	row := s.db.QueryRowContext(ctx,
+		"SELECT "+entryColumns+" FROM known_benign WHERE normalized_hgvs = ?", strings.TrimSpace(normalizedHGVS))
+	entry, err := scanEntry(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up known benign entry: %w", err)
+	}
+	return entry, nil
+}
+
+// List returns entries ordered by variant.
+func (s *SQLiteStore) List(ctx context.Context, geneSymbol string) ([]*Entry, error) {
+	query := "SELECT " + entryColumns + " FROM known_benign"
+	var args []interface{}
+	if gene := strings.ToUpper(strings.TrimSpace(geneSymbol)); gene != "" {
+		query += " WHERE gene_symbol = ?"
+		args = append(args, gene)
+	}
+	query += " ORDER BY normalized_hgvs ASC"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*Entry, 0)
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// Close closes the database connection.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
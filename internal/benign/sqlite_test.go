@@ -0,0 +1,105 @@
+package benign
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "known_benign.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func addEntry(t *testing.T, store *SQLiteStore, hgvs, gene, classification string) *Entry {
+	t.Helper()
+	entry := &Entry{
+		NormalizedHGVS: hgvs,
+		GeneSymbol:     gene,
+		Classification: classification,
+		Evidence:       "BA1: gnomAD popmax AF 0.21",
+		ConfirmedBy:    "curator1",
+	}
+	require.NoError(t, store.Add(context.Background(), entry))
+	return entry
+}
+
+func TestNormalizeClassification(t *testing.T) {
+	for input, want := range map[string]string{
+		"benign":        Benign,
+		"B":             Benign,
+		"likely_benign": LikelyBenign,
+		"Likely Benign": LikelyBenign,
+		"LB":            LikelyBenign,
+	} {
+		got, ok := NormalizeClassification(input)
+		assert.True(t, ok, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, ok := NormalizeClassification("VUS")
+	assert.False(t, ok)
+}
+
+func TestSQLiteStore_AddAndLookup(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	entry := addEntry(t, store, "NM_007294.4:c.4308T>C", "brca1", "benign")
+
+	assert.Equal(t, "BRCA1", entry.GeneSymbol)
+	assert.Equal(t, Benign, entry.Classification)
+
+	found, err := store.Lookup(ctx, " NM_007294.4:c.4308T>C ")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, entry.ID, found.ID)
+	assert.Equal(t, "curator1", found.ConfirmedBy)
+
+	missing, err := store.Lookup(ctx, "NM_007294.4:c.5266dup")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestSQLiteStore_AddValidates(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	addEntry(t, store, "NM_007294.4:c.4308T>C", "BRCA1", "benign")
+
+	err := store.Add(ctx, &Entry{NormalizedHGVS: "NM_007294.4:c.4308T>C", Classification: "benign", Evidence: "BA1", ConfirmedBy: "curator2"})
+	assert.True(t, errors.Is(err, ErrAlreadyListed))
+
+	err = store.Add(ctx, &Entry{NormalizedHGVS: "NM_007294.4:c.5266dup", Classification: "pathogenic", Evidence: "PVS1", ConfirmedBy: "curator2"})
+	assert.True(t, errors.Is(err, ErrInvalidEntry))
+
+	err = store.Add(ctx, &Entry{NormalizedHGVS: "NM_007294.4:c.3113A>G", Classification: "benign", Evidence: "BA1"})
+	assert.True(t, errors.Is(err, ErrInvalidEntry))
+}
+
+func TestSQLiteStore_ListAndRemove(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	brca1 := addEntry(t, store, "NM_007294.4:c.4308T>C", "BRCA1", "benign")
+	addEntry(t, store, "NM_000059.4:c.7242A>G", "BRCA2", "likely benign")
+
+	all, err := store.List(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "NM_000059.4:c.7242A>G", all[0].NormalizedHGVS)
+
+	byGene, err := store.List(ctx, "brca1")
+	require.NoError(t, err)
+	require.Len(t, byGene, 1)
+
+	require.NoError(t, store.Remove(ctx, brca1.ID))
+	assert.True(t, errors.Is(store.Remove(ctx, brca1.ID), ErrNotFound))
+	found, err := store.Lookup(ctx, brca1.NormalizedHGVS)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
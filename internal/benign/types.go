@@ -0,0 +1,98 @@
+// Package benign maintains the lab's curated list of confirmed benign and
+// likely benign variants that recur on its panels. Each entry is endorsed by
+// a curator with the evidence behind the call. Batch classification returns
+// the endorsed call for listed variants instead of re-running the evidence
+// lookups, which keeps recurring panel noise from hitting external databases.
+package benign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+var (
+	// ErrNotFound is returned when a known benign entry does not exist.
+	ErrNotFound = errors.New("known benign entry not found")
+	// ErrInvalidEntry is returned when an entry is missing required fields.
+	ErrInvalidEntry = errors.New("invalid known benign entry")
+	// ErrAlreadyListed is returned when the variant is already on the list.
+	ErrAlreadyListed = errors.New("variant already on known benign list")
+)
+
+// Endorsed classifications, as reported by the classification engine
+const (
+	Benign       = string(domain.BENIGN)
+	LikelyBenign = string(domain.LIKELY_BENIGN)
+)
+
+// NormalizeClassification maps common spellings to an endorsed classification.
+// It returns false for anything other than benign or likely benign.
+func NormalizeClassification(classification string) (string, bool) {
+	key := strings.ToLower(strings.TrimSpace(classification))
+	key = strings.NewReplacer("_", " ", "-", " ").Replace(key)
+	switch key {
+	case "benign", "b":
+		return Benign, true
+	case "likely benign", "lb":
+		return LikelyBenign, true
+	}
+	return "", false
+}
+
+// Entry is a curator-confirmed benign variant.
+type Entry struct {
+	ID             int64     `json:"id,omitempty"`
+	NormalizedHGVS string    `json:"normalized_hgvs"`
+	GeneSymbol     string    `json:"gene_symbol,omitempty"`
+	Classification string    `json:"classification"` // BENIGN or LIKELY_BENIGN
+	Evidence       string    `json:"evidence"`       // Basis for the call, e.g. BA1 with gnomAD AF
+	ConfirmedBy    string    `json:"confirmed_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Validate normalizes the entry and checks required fields.
+func (e *Entry) Validate() error {
+	e.NormalizedHGVS = strings.TrimSpace(e.NormalizedHGVS)
+	e.GeneSymbol = strings.ToUpper(strings.TrimSpace(e.GeneSymbol))
+	e.Evidence = strings.TrimSpace(e.Evidence)
+	e.ConfirmedBy = strings.TrimSpace(e.ConfirmedBy)
+
+	if e.NormalizedHGVS == "" {
+		return fmt.Errorf("%w: variant is required", ErrInvalidEntry)
+	}
+	classification, ok := NormalizeClassification(e.Classification)
+	if !ok {
+		return fmt.Errorf("%w: classification must be %s or %s, got %q", ErrInvalidEntry, Benign, LikelyBenign, e.Classification)
+	}
+	e.Classification = classification
+	if e.Evidence == "" {
+		return fmt.Errorf("%w: evidence is required", ErrInvalidEntry)
+	}
+	if e.ConfirmedBy == "" {
+		return fmt.Errorf("%w: confirming curator is required", ErrInvalidEntry)
+	}
+	return nil
+}
+
+// Store defines the interface for known benign variant storage.
+type Store interface {
+	// Add lists a curator-confirmed variant.
+	Add(ctx context.Context, entry *Entry) error
+
+	// Remove deletes an entry.
+	Remove(ctx context.Context, id int64) error
+
+	// Lookup returns the entry for a variant, or nil if it is not listed.
+	Lookup(ctx context.Context, normalizedHGVS string) (*Entry, error)
+
+	// List returns entries ordered by variant, optionally for one gene.
+	List(ctx context.Context, geneSymbol string) ([]*Entry, error)
+
+	// Close closes the store.
+	Close() error
+}
@@ -0,0 +1,223 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// fakeBatch answers classify_variants_batch, failing notations containing
+// "c.999" and classifying substitutions as Pathogenic and the rest as Benign
+type fakeBatch struct {
+	calls [][]string
+}
+
+func (f *fakeBatch) CallTool(_ context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response {
+	params := arguments.(tools.ClassifyVariantsBatchParams)
+	f.calls = append(f.calls, params.HGVSNotations)
+	result := &tools.ClassifyVariantsBatchResult{TotalVariants: len(params.HGVSNotations)}
+	for i, notation := range params.HGVSNotations {
+		item := tools.BatchClassificationItem{Index: i, HGVSNotation: notation}
+		switch {
+		case strings.Contains(notation, "c.999"):
+			item.Error = "transcript not found"
+		case strings.Contains(notation, ">"):
+			item.Result = &tools.ClassifyVariantResult{VariantID: notation, Classification: "Pathogenic", Confidence: "High"}
+		default:
+			item.Result = &tools.ClassifyVariantResult{VariantID: notation, Classification: "Benign", Confidence: "High"}
+		}
+		result.Results = append(result.Results, item)
+	}
+	return &protocol.JSONRPC2Response{Result: map[string]interface{}{"batch_classification": result}}
+}
+
+const coordinateTable = "##fileformat=VCFv4.2\n" +
+	"#CHROM\tPOS\tREF\tALT\tsample\n" +
+	"17\t43094464\tA\tG\tS1\n" +
+	"chr7\t117559590\tATCT\tA\tS2\n" +
+	"17\tnot-a-number\tA\tG\tS3\n" +
+	"17\t43094464\tA\tG,T\tS4\n"
+
+func TestReadTable_Coordinates(t *testing.T) {
+	rows, delimiter, err := ReadTable(strings.NewReader(coordinateTable), hgvs.AssemblyGRCh38, 0)
+	require.NoError(t, err)
+	assert.Equal(t, '\t', delimiter)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, Row{Line: 3, Input: "17:43094464:A>G", Notation: "NC_000017.11:g.43094464A>G"}, rows[0])
+	assert.Equal(t, "NC_000007.14:g.117559591_117559593del", rows[1].Notation)
+	assert.Equal(t, 5, rows[2].Line)
+	assert.Contains(t, rows[2].Error, "invalid position")
+	assert.Contains(t, rows[3].Error, "multi-allelic")
+}
+
+func TestReadTable_HGVS(t *testing.T) {
+	input := "sample,HGVS\nS1,NM_000492.3:c.1521_1523del\nS2,\"NM_007294.4:c.68_69del\"\nS3,\n"
+	rows, delimiter, err := ReadTable(strings.NewReader(input), hgvs.AssemblyGRCh38, 0)
+	require.NoError(t, err)
+	assert.Equal(t, ',', delimiter)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "NM_000492.3:c.1521_1523del", rows[0].Notation)
+	assert.Equal(t, "NM_007294.4:c.68_69del", rows[1].Notation)
+	assert.NotEmpty(t, rows[2].Error)
+}
+
+func TestReadTable_Invalid(t *testing.T) {
+	for name, input := range map[string]string{
+		"empty":          "",
+		"header only":    "hgvs\n",
+		"unknown header": "sample,gene\nS1,BRCA1\n",
+		"too many rows":  "hgvs\nNM_000492.3:c.1A>G\nNM_000492.3:c.2A>G\nNM_000492.3:c.3A>G\n",
+	} {
+		_, _, err := ReadTable(strings.NewReader(input), hgvs.AssemblyGRCh38, 2)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	rows := []Row{
+		{Line: 2, Input: "NM_000492.3:c.1A>G", Notation: "NM_000492.3:c.1A>G"},
+		{Line: 3, Input: "17:x:A>G", Error: "invalid position \"x\""},
+		{Line: 4, Input: "NM_000492.3:c.999del", Notation: "NM_000492.3:c.999del"},
+		{Line: 5, Input: "NM_000492.3:c.5del", Notation: "NM_000492.3:c.5del"},
+		{Line: 6, Input: "NM_000492.3:c.6A>G", Notation: "NM_000492.3:c.6A>G"},
+	}
+	caller := &fakeBatch{}
+	results, summary := Classify(context.Background(), caller, rows, Options{ChunkSize: 2})
+
+	assert.Equal(t, [][]string{
+		{"NM_000492.3:c.1A>G", "NM_000492.3:c.999del"},
+		{"NM_000492.3:c.5del", "NM_000492.3:c.6A>G"},
+	}, caller.calls, "rows with errors are not sent")
+	require.Len(t, results, 5)
+	assert.Equal(t, "Pathogenic", results[0].Result.Classification)
+	assert.Equal(t, "invalid position \"x\"", results[1].Error)
+	assert.Equal(t, "transcript not found", results[2].Error)
+	assert.Equal(t, "Benign", results[3].Result.Classification)
+
+	assert.Equal(t, Summary{
+		TotalRows:            5,
+		Classified:           3,
+		Failed:               2,
+		ClassificationCounts: map[string]int{"Pathogenic": 2, "Benign": 1},
+	}, summary)
+	assert.Equal(t, []string{"Pathogenic", "Benign"}, SortedClassifications(summary))
+
+	var out bytes.Buffer
+	require.NoError(t, WriteTable(&out, ',', results))
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 6)
+	assert.Equal(t, TableHeader, records[0])
+	assert.Equal(t, "Pathogenic", records[1][3])
+	assert.Equal(t, []string{"3", "17:x:A>G"}, records[2][:2])
+	assert.Equal(t, "invalid position \"x\"", records[2][len(TableHeader)-1])
+
+	out.Reset()
+	require.NoError(t, WriteSummary(&out, summary))
+	assert.Contains(t, out.String(), "Failed:      2\n")
+	assert.Contains(t, out.String(), "Pathogenic")
+}
+
+func TestHandler(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	handler := Handler(logger, &fakeBatch{}, hgvs.AssemblyGRCh38, Options{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/classify/table", strings.NewReader(coordinateTable))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/tab-separated-values; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "total=4; classified=2; failed=2; Benign=1; Pathogenic=1", rec.Header().Get(SummaryHeader))
+	assert.Len(t, strings.Split(strings.TrimSpace(rec.Body.String()), "\n"), 5)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/classify/table?assembly=hg19", strings.NewReader(coordinateTable))
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response TableResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, hgvs.AssemblyGRCh37, response.Assembly)
+	assert.Equal(t, "NC_000017.10:g.43094464A>G", response.Results[0].Notation)
+	assert.Equal(t, 2, response.Summary.Failed)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/classify/table", strings.NewReader(coordinateTable))
+	req.Header.Set("Accept", "application/fhir+json")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/fhir+json", rec.Header().Get("Content-Type"))
+	var bundle struct {
+		ResourceType string `json:"resourceType"`
+		Entry        []struct {
+			Resource struct {
+				ResourceType string `json:"resourceType"`
+				Conclusion   string `json:"conclusion"`
+			} `json:"resource"`
+		} `json:"entry"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &bundle))
+	assert.Equal(t, "Bundle", bundle.ResourceType)
+	require.Len(t, bundle.Entry, 5, "a report and two observations per classified row")
+	assert.Equal(t, "DiagnosticReport", bundle.Entry[0].Resource.ResourceType)
+	assert.Equal(t, "NC_000017.11:g.43094464A>G: Pathogenic; NC_000007.14:g.117559591_117559593del: Benign", bundle.Entry[0].Resource.Conclusion)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/classify/table?assembly=hg17", strings.NewReader(coordinateTable))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/classify/table", strings.NewReader("sample\nS1\n"))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var envelope protocol.ErrorEnvelope
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	assert.Equal(t, protocol.ErrorCodeInvalidInput, envelope.Code)
+}
+
+func TestParseTableArgs(t *testing.T) {
+	opts, err := ParseTableArgs([]string{"cohort.csv", "--tsv", "--assembly", "hg19", "--condition", "cystic fibrosis", "--no-summary"})
+	require.NoError(t, err)
+	assert.Equal(t, "cohort.csv", opts.Path)
+	assert.Equal(t, "tsv", opts.Format)
+	assert.Equal(t, hgvs.AssemblyGRCh37, opts.Assembly)
+	assert.Equal(t, "cystic fibrosis", opts.Options.Condition)
+	assert.False(t, opts.Summary)
+
+	for _, args := range [][]string{
+		{"a.tsv", "b.tsv"},
+		{"--assembly", "hg17"},
+		{"--condition"},
+		{"--verbose"},
+	} {
+		_, err := ParseTableArgs(args)
+		assert.Error(t, err, args)
+	}
+}
+
+func TestRunTable(t *testing.T) {
+	opts, err := ParseTableArgs([]string{"-", "--assembly", "GRCh38"})
+	require.NoError(t, err)
+
+	var out, summary bytes.Buffer
+	result, err := RunTable(context.Background(), &fakeBatch{}, opts, strings.NewReader(coordinateTable), &out, &summary)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Classified)
+	assert.True(t, strings.HasPrefix(out.String(), strings.Join(TableHeader, "\t")+"\n"))
+	assert.Contains(t, summary.String(), "Variants:    4\n")
+}
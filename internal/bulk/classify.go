@@ -0,0 +1,205 @@
+package bulk
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/cli"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// Options apply to every variant of a table
+type Options struct {
+	ClinicalContext   string `json:"clinical_context,omitempty"`
+	OrderingSpecialty string `json:"ordering_specialty,omitempty"`
+	Condition         string `json:"condition,omitempty"`
+	ChunkSize         int    `json:"-"` // Variants per classify_variants_batch call; the tool's default limit if zero
+}
+
+// Result is a row with its classification or error
+type Result struct {
+	Row
+	Result *tools.ClassifyVariantResult `json:"result,omitempty"`
+}
+
+// Summary counts the outcomes of a table
+type Summary struct {
+	TotalRows            int            `json:"total_rows"`
+	Classified           int            `json:"classified"`
+	Failed               int            `json:"failed"`
+	Artifacts            int            `json:"artifacts"` // Probable artifacts, excluded from the classification counts
+	ClassificationCounts map[string]int `json:"classification_counts"`
+}
+
+// Classify classifies the rows without errors with classify_variants_batch,
+// in chunks, and returns every row in input order with the summary. A
+// failed chunk is reported on each of its rows.
+func Classify(ctx context.Context, caller cli.ToolCaller, rows []Row, opts Options) ([]Result, Summary) {
+	results := make([]Result, len(rows))
+	var pending []int
+	for i, row := range rows {
+		results[i].Row = row
+		if row.Error == "" {
+			pending = append(pending, i)
+		}
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = tools.DefaultBatchClassifyLimit
+	}
+	for start := 0; start < len(pending); start += chunkSize {
+		chunk := pending[start:min(start+chunkSize, len(pending))]
+		notations := make([]string, len(chunk))
+		for j, i := range chunk {
+			notations[j] = rows[i].Notation
+		}
+		items, err := classifyChunk(ctx, caller, notations, opts)
+		for j, i := range chunk {
+			switch {
+			case err != nil:
+				results[i].Error = err.Error()
+			case items[j].Error != "":
+				results[i].Error = items[j].Error
+			default:
+				results[i].Result = items[j].Result
+			}
+		}
+	}
+
+	return results, summarize(results)
+}
+
+// classifyChunk calls classify_variants_batch and returns its items in input order
+func classifyChunk(ctx context.Context, caller cli.ToolCaller, notations []string, opts Options) ([]tools.BatchClassificationItem, error) {
+	response := caller.CallTool(ctx, "classify_variants_batch", tools.ClassifyVariantsBatchParams{
+		HGVSNotations:     notations,
+		ClinicalContext:   opts.ClinicalContext,
+		OrderingSpecialty: opts.OrderingSpecialty,
+		Condition:         opts.Condition,
+	})
+	if response == nil {
+		return nil, fmt.Errorf("classify_variants_batch returned no response")
+	}
+	if response.Error != nil {
+		if detail, ok := response.Error.Data.(string); ok && detail != "" {
+			return nil, fmt.Errorf("%s: %s", response.Error.Message, detail)
+		}
+		return nil, fmt.Errorf("%s", response.Error.Message)
+	}
+
+	raw, err := json.Marshal(response.Result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	var decoded struct {
+		Batch *tools.ClassifyVariantsBatchResult `json:"batch_classification"`
+	}
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.Batch == nil {
+		return nil, fmt.Errorf("unexpected classify_variants_batch result")
+	}
+
+	items := make([]tools.BatchClassificationItem, len(notations))
+	for _, item := range decoded.Batch.Results {
+		if item.Index >= 0 && item.Index < len(items) {
+			items[item.Index] = item
+		}
+	}
+	for i := range items {
+		if items[i].Result == nil && items[i].Error == "" {
+			items[i].Error = "no result returned"
+		}
+	}
+	return items, nil
+}
+
+func summarize(results []Result) Summary {
+	summary := Summary{TotalRows: len(results), ClassificationCounts: make(map[string]int)}
+	for _, result := range results {
+		switch {
+		case result.Result == nil:
+			summary.Failed++
+		case result.Result.ProbableArtifact != nil:
+			summary.Classified++
+			summary.Artifacts++
+		default:
+			summary.Classified++
+			summary.ClassificationCounts[result.Result.Classification]++
+		}
+	}
+	return summary
+}
+
+// TableHeader names the columns of the results table: the input line, the
+// classify subcommand's TSV columns and the row's error
+var TableHeader = append(append([]string{"line"}, cli.TSVHeader...), "error")
+
+// WriteTable writes the results as TSV, or as CSV when delimiter is a comma
+func WriteTable(out io.Writer, delimiter rune, results []Result) error {
+	records := make([][]string, 0, len(results)+1)
+	records = append(records, TableHeader)
+	for _, result := range results {
+		record := []string{fmt.Sprint(result.Line)}
+		if result.Result != nil {
+			record = append(record, cli.TSVRow(result.Input, result.Result)...)
+		} else {
+			record = append(record, result.Input)
+			record = append(record, make([]string, len(cli.TSVHeader)-1)...)
+		}
+		records = append(records, append(record, result.Error))
+	}
+
+	if delimiter == ',' {
+		writer := csv.NewWriter(out)
+		writer.WriteAll(records)
+		return writer.Error()
+	}
+	replacer := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+	for _, record := range records {
+		for i, value := range record {
+			record[i] = replacer.Replace(value)
+		}
+		if _, err := fmt.Fprintln(out, strings.Join(record, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteSummary writes the summary as text, classifications most frequent first
+func WriteSummary(out io.Writer, summary Summary) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Variants:    %d\n", summary.TotalRows)
+	fmt.Fprintf(&b, "Classified:  %d\n", summary.Classified)
+	fmt.Fprintf(&b, "Failed:      %d\n", summary.Failed)
+	if summary.Artifacts > 0 {
+		fmt.Fprintf(&b, "Artifacts:   %d\n", summary.Artifacts)
+	}
+	for _, name := range SortedClassifications(summary) {
+		fmt.Fprintf(&b, "  %-24s %d\n", name, summary.ClassificationCounts[name])
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// SortedClassifications returns the classifications of a summary, most
+// frequent first and alphabetically among equals
+func SortedClassifications(summary Summary) []string {
+	names := make([]string, 0, len(summary.ClassificationCounts))
+	for name := range summary.ClassificationCounts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ci, cj := summary.ClassificationCounts[names[i]], summary.ClassificationCounts[names[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
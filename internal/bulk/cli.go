@@ -0,0 +1,136 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/cli"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// TableOptions are the classify-table subcommand's arguments
+type TableOptions struct {
+	Path     string // Input table; empty or "-" reads stdin
+	Assembly string // Empty uses the configured assembly
+	Format   string // tsv, csv or json; empty follows the input's delimiter
+	Summary  bool   // Write the summary to stderr
+	Options  Options
+}
+
+// ParseTableArgs parses `classify-table [file] [options]`
+func ParseTableArgs(args []string) (*TableOptions, error) {
+	opts := &TableOptions{Summary: true}
+
+	value := func(i *int) (string, error) {
+		if *i+1 >= len(args) {
+			return "", fmt.Errorf("%s requires a value", args[*i])
+		}
+		*i++
+		return args[*i], nil
+	}
+
+	for i := 0; i < len(args); i++ {
+		var err error
+		switch args[i] {
+		case "--tsv":
+			opts.Format = cli.FormatTSV
+		case "--csv":
+			opts.Format = "csv"
+		case "--json":
+			opts.Format = cli.FormatJSON
+		case "--assembly":
+			opts.Assembly, err = value(&i)
+			if err == nil {
+				opts.Assembly, err = hgvs.ParseAssembly(opts.Assembly)
+			}
+		case "--context":
+			opts.Options.ClinicalContext, err = value(&i)
+		case "--condition":
+			opts.Options.Condition, err = value(&i)
+		case "--specialty":
+			opts.Options.OrderingSpecialty, err = value(&i)
+		case "--no-summary":
+			opts.Summary = false
+		default:
+			if args[i] != "-" && strings.HasPrefix(args[i], "-") {
+				return nil, fmt.Errorf("unknown option %s", args[i])
+			}
+			if opts.Path != "" {
+				return nil, fmt.Errorf("unexpected argument %q; classify-table reads one table", args[i])
+			}
+			opts.Path = args[i]
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return opts, nil
+}
+
+// RunTable reads the table, classifies it and writes the results to out
+// and the summary to summaryOut. It returns the summary so callers can
+// choose an exit status.
+func RunTable(ctx context.Context, caller cli.ToolCaller, opts *TableOptions, stdin io.Reader, out, summaryOut io.Writer) (Summary, error) {
+	in := stdin
+	if opts.Path != "" && opts.Path != "-" {
+		f, err := os.Open(opts.Path)
+		if err != nil {
+			return Summary{}, err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	rows, delimiter, err := ReadTable(in, opts.Assembly, DefaultMaxRows)
+	if err != nil {
+		return Summary{}, err
+	}
+	results, summary := Classify(ctx, caller, rows, opts.Options)
+
+	switch opts.Format {
+	case cli.FormatJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(TableResponse{Assembly: opts.Assembly, Summary: summary, Results: results})
+	case "csv":
+		err = WriteTable(out, ',', results)
+	case cli.FormatTSV:
+		err = WriteTable(out, '\t', results)
+	default:
+		err = WriteTable(out, delimiter, results)
+	}
+	if err != nil {
+		return summary, err
+	}
+	if opts.Summary {
+		WriteSummary(summaryOut, summary)
+	}
+	return summary, nil
+}
+
+// TableUsage describes the classify-table subcommand
+const TableUsage = `Usage:
+  mcp-server-lite classify-table [file|-] [options]
+
+Classifies every variant of a TSV or CSV table with the full pipeline,
+without starting a server, and writes a results table on stdout with one
+row per input row and the summary on stderr. The header must name an hgvs
+column, or chrom, pos, ref and alt columns (VCF-style, 1-based, with the
+padding base); other columns are ignored. Rows that cannot be classified
+report the reason in the error column. Reads stdin without a file.
+
+Options:
+  --tsv | --csv | --json  Output format (default: the input's)
+  --assembly GRCh38|GRCh37
+                          Assembly of chrom/pos rows (default ACMG_GENOME_ASSEMBLY)
+  --context TEXT          Clinical context applied to every variant
+  --condition NAME        Condition for gene/condition-specific frequency thresholds
+  --specialty NAME        Ordering specialty selecting the transcript set
+  --no-summary            Do not write the summary to stderr
+
+Exits with status 1 when the table cannot be read.
+`
@@ -0,0 +1,158 @@
+package bulk
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/cli"
+	"github.com/acmg-amp-mcp-server/internal/fhir"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// MaxTableBytes bounds the request body of the table endpoint
+const MaxTableBytes = 8 << 20
+
+// SummaryHeader carries the summary on table responses, e.g.
+// "total=3; classified=2; failed=1; Pathogenic=1; Benign=1"
+const SummaryHeader = "X-Classification-Summary"
+
+// TableResponse is the JSON body of the table endpoint
+type TableResponse struct {
+	Assembly string   `json:"assembly"`
+	Summary  Summary  `json:"summary"`
+	Results  []Result `json:"results"`
+}
+
+// Handler serves POST /api/v1/classify/table. The body is the TSV or CSV
+// table; the query may set assembly (default defaultAssembly),
+// clinical_context, ordering_specialty and condition. The response is the
+// results table in the input's format with the summary in the
+// X-Classification-Summary header, JSON with the summary and every row
+// when the client accepts application/json or passes format=json, or an HL7
+// FHIR R4 Genomics Reporting bundle of the classified rows when it accepts
+// application/fhir+json or passes format=fhir.
+func Handler(logger *logrus.Logger, caller cli.ToolCaller, defaultAssembly string, opts Options) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assembly := defaultAssembly
+		if name := query.Get("assembly"); name != "" {
+			parsed, err := hgvs.ParseAssembly(name)
+			if err != nil {
+				writeError(w, r, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, "Invalid assembly", err.Error())
+				return
+			}
+			assembly = parsed
+		}
+
+		rows, delimiter, err := ReadTable(http.MaxBytesReader(w, r.Body, MaxTableBytes), assembly, DefaultMaxRows)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, "Invalid variant table", err.Error())
+			return
+		}
+
+		tableOpts := opts
+		tableOpts.ClinicalContext = query.Get("clinical_context")
+		tableOpts.OrderingSpecialty = query.Get("ordering_specialty")
+		tableOpts.Condition = query.Get("condition")
+		results, summary := Classify(r.Context(), caller, rows, tableOpts)
+
+		logger.WithFields(logrus.Fields{
+			"rows":       summary.TotalRows,
+			"classified": summary.Classified,
+			"failed":     summary.Failed,
+		}).Info("Classified variant table")
+
+		w.Header().Set("Cache-Control", "no-store")
+		switch responseFormat(r) {
+		case formatJSON:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(TableResponse{Assembly: assembly, Summary: summary, Results: results})
+			return
+		case formatFHIR:
+			w.Header().Set(SummaryHeader, summaryHeader(summary))
+			w.Header().Set("Content-Type", fhir.MediaType)
+			json.NewEncoder(w).Encode(FHIRBundle(results, time.Now()))
+			return
+		}
+		w.Header().Set(SummaryHeader, summaryHeader(summary))
+		if delimiter == ',' {
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		} else {
+			w.Header().Set("Content-Type", "text/tab-separated-values; charset=utf-8")
+		}
+		WriteTable(w, delimiter, results)
+	})
+}
+
+// Response formats other than the results table
+const (
+	formatJSON = "json"
+	formatFHIR = "fhir"
+)
+
+// responseFormat returns the response format the client asked for with the
+// format parameter or the Accept header, or "" for the results table
+func responseFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		if format == formatJSON || format == formatFHIR {
+			return format
+		}
+		return ""
+	}
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "application/json":
+			return formatJSON
+		case fhir.MediaType:
+			return formatFHIR
+		}
+	}
+	return ""
+}
+
+// FHIRBundle reports the classified rows as an HL7 FHIR R4 Genomics
+// Reporting bundle; rows that failed are left out
+func FHIRBundle(results []Result, issued time.Time) *fhir.Bundle {
+	var variants []fhir.Variant
+	for _, result := range results {
+		if result.Result != nil {
+			variants = append(variants, tools.FHIRVariant(result.Notation, result.Result))
+		}
+	}
+	return fhir.NewReportBundle(variants, issued)
+}
+
+func summaryHeader(summary Summary) string {
+	parts := []string{
+		fmt.Sprintf("total=%d", summary.TotalRows),
+		fmt.Sprintf("classified=%d", summary.Classified),
+		fmt.Sprintf("failed=%d", summary.Failed),
+	}
+	if summary.Artifacts > 0 {
+		parts = append(parts, fmt.Sprintf("artifacts=%d", summary.Artifacts))
+	}
+	for _, name := range SortedClassifications(summary) {
+		parts = append(parts, fmt.Sprintf("%s=%d", name, summary.ClassificationCounts[name]))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// writeError responds with the standard error envelope
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, message, details string) {
+	envelope := protocol.NewErrorEnvelope(code, message, "rest:"+r.Method+" "+r.URL.Path, r.Header.Get("X-Correlation-ID"), details)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(envelope)
+}
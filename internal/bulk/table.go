@@ -0,0 +1,186 @@
+// Package bulk classifies the variants of a small TSV or CSV table, such as
+// a cohort export, and writes a results table with per-row errors and a
+// summary of the classifications. It backs the lite binary's
+// classify-table subcommand and the POST /api/v1/classify/table endpoint.
+package bulk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// DefaultMaxRows bounds the variants read from one table
+const DefaultMaxRows = 5000
+
+// Row is one variant read from an input table
+type Row struct {
+	Line     int    `json:"line"`               // Line number in the input, counting the header
+	Input    string `json:"input"`              // The variant as given: the HGVS column or chrom:pos:ref>alt
+	Notation string `json:"notation,omitempty"` // HGVS notation classified
+	Error    string `json:"error,omitempty"`    // Why the row cannot be classified
+}
+
+// columnAliases maps recognised header names onto columns
+var columnAliases = map[string]string{
+	"chrom":         "chrom",
+	"chr":           "chrom",
+	"chromosome":    "chrom",
+	"pos":           "pos",
+	"position":      "pos",
+	"start":         "pos",
+	"ref":           "ref",
+	"reference":     "ref",
+	"alt":           "alt",
+	"alternate":     "alt",
+	"hgvs":          "hgvs",
+	"hgvs_notation": "hgvs",
+	"variant":       "hgvs",
+	"notation":      "hgvs",
+}
+
+// ReadTable reads a tab- or comma-separated table whose header names either
+// an hgvs column or chrom, pos, ref and alt columns. The delimiter is the
+// header's: tab if it contains one, otherwise comma. "##" meta lines are
+// skipped and a leading "#" on the header is ignored, so VCF-like exports
+// read as is. Rows that cannot be turned into HGVS carry an Error instead
+// of failing the table; chromosome positions are resolved on assembly.
+func ReadTable(r io.Reader, assembly string, maxRows int) ([]Row, rune, error) {
+	if maxRows <= 0 {
+		maxRows = DefaultMaxRows
+	}
+
+	lines, delimiter, err := readLines(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reader := csv.NewReader(strings.NewReader(lines))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, 0, fmt.Errorf("the table is empty")
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+		if column, ok := columnAliases[name]; ok {
+			if _, seen := columns[column]; !seen {
+				columns[column] = i
+			}
+		}
+	}
+	_, hasHGVS := columns["hgvs"]
+	hasCoordinates := true
+	for _, column := range []string{"chrom", "pos", "ref", "alt"} {
+		if _, ok := columns[column]; !ok {
+			hasCoordinates = false
+		}
+	}
+	if !hasHGVS && !hasCoordinates {
+		return nil, 0, fmt.Errorf("the header must name an hgvs column or chrom, pos, ref and alt columns")
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, 0, err
+			}
+			rows = append(rows, Row{Line: parseErr.StartLine, Error: parseErr.Err.Error()})
+		} else {
+			line, _ := reader.FieldPos(0)
+			rows = append(rows, parseRow(line, record, columns, assembly))
+		}
+		if len(rows) > maxRows {
+			return nil, 0, fmt.Errorf("the table has more than %d variants", maxRows)
+		}
+	}
+	if len(rows) == 0 {
+		return nil, 0, fmt.Errorf("the table has no variants")
+	}
+	return rows, delimiter, nil
+}
+
+// readLines reads the input, blanking "##" meta lines so line numbers are
+// kept, and picks the delimiter from the header
+func readLines(r io.Reader) (string, rune, error) {
+	var b strings.Builder
+	delimiter := rune(0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "##") {
+			line = ""
+		}
+		if delimiter == 0 && strings.TrimSpace(line) != "" {
+			delimiter = ','
+			if strings.Contains(line, "\t") {
+				delimiter = '\t'
+			}
+		}
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return "", 0, fmt.Errorf("failed to read table: %w", err)
+	}
+	if delimiter == 0 {
+		return "", 0, fmt.Errorf("the table is empty")
+	}
+	return b.String(), delimiter, nil
+}
+
+// parseRow turns a record into HGVS, preferring a non-empty hgvs column
+func parseRow(line int, record []string, columns map[string]int, assembly string) Row {
+	field := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	if notation := field("hgvs"); notation != "" {
+		return Row{Line: line, Input: notation, Notation: notation}
+	}
+
+	chrom, pos, ref, alt := field("chrom"), field("pos"), field("ref"), field("alt")
+	row := Row{Line: line, Input: fmt.Sprintf("%s:%s:%s>%s", chrom, pos, ref, alt)}
+	if chrom == "" && pos == "" && ref == "" && alt == "" {
+		row.Input = ""
+		row.Error = "no variant: the hgvs or chrom, pos, ref and alt columns are empty"
+		return row
+	}
+	position, err := strconv.ParseInt(pos, 10, 64)
+	if err != nil {
+		row.Error = fmt.Sprintf("invalid position %q", pos)
+		return row
+	}
+	notation, err := hgvs.FromVCF(assembly, chrom, position, ref, alt)
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.Notation = notation
+	return row
+}
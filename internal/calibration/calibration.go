@@ -0,0 +1,263 @@
+// Package calibration loads calibrated score intervals for in silico
+// predictors: tables that map a predictor score to PP3 or BP4 at supporting,
+// moderate or strong strength, as calibrated by ClinGen for missense
+// predictors such as REVEL, BayesDel and CADD (Pejaver et al. 2022) and for
+// SpliceAI (Walker et al. 2023). Tables are read from a file, so intervals
+// can be recalibrated without code changes.
+package calibration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/weighting"
+)
+
+// ErrInvalidCalibration is returned when calibration tables fail validation.
+var ErrInvalidCalibration = errors.New("invalid predictor calibration")
+
+// Splicing predictors, as named in splicing predictions
+const (
+	SpliceAI = "SpliceAI"
+	Pangolin = "Pangolin"
+)
+
+// MissensePredictors lists the missense predictors a table may calibrate.
+var MissensePredictors = []string{
+	domain.PredictorREVEL, domain.PredictorBayesDel, domain.PredictorCADD, domain.PredictorAlphaMissense,
+	domain.PredictorSIFT, domain.PredictorPolyPhen,
+}
+
+// SplicingPredictors lists the splicing predictors a table may calibrate.
+var SplicingPredictors = []string{SpliceAI, Pangolin}
+
+// Calibration is a set of predictor calibration tables. Missense tables are
+// tried in order and the first predictor that scored the variant decides
+// PP3/BP4, as ClinGen recommends relying on a single predictor chosen in
+// advance; splicing tables are tried likewise for splicing predictions.
+type Calibration struct {
+	Name        string  `json:"name" yaml:"name"`
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Tables      []Table `json:"tables" yaml:"tables"`
+	File        string  `json:"file,omitempty" yaml:"-"` // File the tables were loaded from
+}
+
+// Table holds the calibrated intervals of one predictor.
+type Table struct {
+	Predictor string     `json:"predictor" yaml:"predictor"`
+	Source    string     `json:"source,omitempty" yaml:"source,omitempty"` // Citation of the calibration
+	Intervals []Interval `json:"intervals" yaml:"intervals"`
+}
+
+// Interval assigns a criterion at a strength to scores in an inclusive
+// range. Intervals are tried in order, so list the strongest first; scores
+// no interval covers are indeterminate.
+type Interval struct {
+	Criterion string              `json:"criterion" yaml:"criterion"` // PP3 or BP4
+	Strength  domain.RuleStrength `json:"strength" yaml:"strength"`
+	MinScore  *float64            `json:"min_score,omitempty" yaml:"min_score,omitempty"`
+	MaxScore  *float64            `json:"max_score,omitempty" yaml:"max_score,omitempty"`
+}
+
+// Call is the calibrated call for a predictor score. Criterion is empty when
+// the score is indeterminate.
+type Call struct {
+	Predictor string              `json:"predictor"`
+	Score     float64             `json:"score"`
+	Criterion string              `json:"criterion,omitempty"`
+	Strength  domain.RuleStrength `json:"strength,omitempty"`
+	Interval  string              `json:"interval,omitempty"` // e.g. "REVEL >= 0.932"
+	Source    string              `json:"source,omitempty"`
+}
+
+// String formats the call as "REVEL 0.95 (REVEL >= 0.932): PP3 STRONG".
+func (c *Call) String() string {
+	if c.Criterion == "" {
+		return fmt.Sprintf("%s %g: indeterminate", c.Predictor, c.Score)
+	}
+	return fmt.Sprintf("%s %g (%s): %s %s", c.Predictor, c.Score, c.Interval, c.Criterion, c.Strength)
+}
+
+// Validate normalizes the calibration and checks it for errors.
+func (c *Calibration) Validate() error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCalibration)
+	}
+	if len(c.Tables) == 0 {
+		return fmt.Errorf("%w: %s has no tables", ErrInvalidCalibration, c.Name)
+	}
+	seen := make(map[string]bool, len(c.Tables))
+	for i := range c.Tables {
+		table := &c.Tables[i]
+		if err := table.validate(); err != nil {
+			return fmt.Errorf("%w: %s table %d: %v", ErrInvalidCalibration, c.Name, i+1, err)
+		}
+		if seen[table.Predictor] {
+			return fmt.Errorf("%w: %s calibrates %s twice", ErrInvalidCalibration, c.Name, table.Predictor)
+		}
+		seen[table.Predictor] = true
+	}
+	return nil
+}
+
+func (t *Table) validate() error {
+	predictor, ok := predictorName(t.Predictor)
+	if !ok {
+		return fmt.Errorf("unknown predictor %q; use one of: %s", t.Predictor, strings.Join(append(append([]string{}, MissensePredictors...), SplicingPredictors...), ", "))
+	}
+	t.Predictor = predictor
+	if len(t.Intervals) == 0 {
+		return fmt.Errorf("%s has no intervals", t.Predictor)
+	}
+	for i := range t.Intervals {
+		interval := &t.Intervals[i]
+		interval.Criterion = strings.ToUpper(strings.TrimSpace(interval.Criterion))
+		if interval.Criterion != "PP3" && interval.Criterion != "BP4" {
+			return fmt.Errorf("%s interval %d: criterion must be PP3 or BP4, not %q", t.Predictor, i+1, interval.Criterion)
+		}
+		interval.Strength = domain.RuleStrength(strings.ToUpper(strings.TrimSpace(string(interval.Strength))))
+		if !interval.Strength.IsValid() {
+			return fmt.Errorf("%s interval %d: invalid strength %q", t.Predictor, i+1, interval.Strength)
+		}
+		if interval.MinScore == nil && interval.MaxScore == nil {
+			return fmt.Errorf("%s interval %d: min_score or max_score is required", t.Predictor, i+1)
+		}
+		if interval.MinScore != nil && interval.MaxScore != nil && *interval.MaxScore < *interval.MinScore {
+			return fmt.Errorf("%s interval %d: max_score is below min_score", t.Predictor, i+1)
+		}
+	}
+	return nil
+}
+
+// predictorName returns the canonical name of a predictor, ignoring case
+func predictorName(name string) (string, bool) {
+	for _, predictors := range [][]string{MissensePredictors, SplicingPredictors} {
+		for _, predictor := range predictors {
+			if strings.EqualFold(strings.TrimSpace(name), predictor) {
+				return predictor, true
+			}
+		}
+	}
+	return "", false
+}
+
+func isSplicing(predictor string) bool {
+	for _, name := range SplicingPredictors {
+		if name == predictor {
+			return true
+		}
+	}
+	return false
+}
+
+// Call returns the calibrated call for a score.
+func (t *Table) Call(score float64) *Call {
+	call := &Call{Predictor: t.Predictor, Score: score, Source: t.Source}
+	for _, interval := range t.Intervals {
+		if interval.contains(score) {
+			call.Criterion = interval.Criterion
+			call.Strength = interval.Strength
+			call.Interval = interval.describe(t.Predictor)
+			break
+		}
+	}
+	return call
+}
+
+func (i Interval) contains(score float64) bool {
+	return (i.MinScore == nil || score >= *i.MinScore) && (i.MaxScore == nil || score <= *i.MaxScore)
+}
+
+// describe formats the interval as "REVEL >= 0.932" or "0.13 <= BayesDel <= 0.27"
+func (i Interval) describe(predictor string) string {
+	switch {
+	case i.MinScore != nil && i.MaxScore != nil:
+		return fmt.Sprintf("%g <= %s <= %g", *i.MinScore, predictor, *i.MaxScore)
+	case i.MinScore != nil:
+		return fmt.Sprintf("%s >= %g", predictor, *i.MinScore)
+	default:
+		return fmt.Sprintf("%s <= %g", predictor, *i.MaxScore)
+	}
+}
+
+// Missense returns the call of the first missense table whose predictor
+// scored the variant, or nil when none did.
+func (c *Calibration) Missense(data *domain.ComputationalData) *Call {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Tables {
+		table := &c.Tables[i]
+		if isSplicing(table.Predictor) {
+			continue
+		}
+		if score, ok := weighting.Score(data, table.Predictor); ok {
+			return table.Call(score)
+		}
+	}
+	return nil
+}
+
+// Splicing returns the call of the first splicing table with predictions
+// for the variant, made from the highest score, or nil when there are none.
+func (c *Calibration) Splicing(predictions []domain.SplicingPrediction) *Call {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Tables {
+		table := &c.Tables[i]
+		if !isSplicing(table.Predictor) {
+			continue
+		}
+		found := false
+		var best float64
+		for _, p := range predictions {
+			if strings.EqualFold(p.Tool, table.Predictor) && (!found || p.Score > best) {
+				best, found = p.Score, true
+			}
+		}
+		if found {
+			return table.Call(best)
+		}
+	}
+	return nil
+}
+
+// Load reads JSON or YAML calibration tables. A missing file yields no
+// calibration.
+func Load(path string) (*Calibration, error) {
+	unmarshal := yaml.Unmarshal
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		unmarshal = json.Unmarshal
+	case ".yaml", ".yml":
+	default:
+		return nil, fmt.Errorf("unsupported predictor calibration format: %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read predictor calibration: %w", err)
+	}
+
+	calibration := &Calibration{}
+	if err := unmarshal(data, calibration); err != nil {
+		return nil, fmt.Errorf("failed to parse predictor calibration %s: %w", filepath.Base(path), err)
+	}
+	if err := calibration.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	calibration.File = path
+	return calibration, nil
+}
@@ -0,0 +1,143 @@
+package calibration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func score(f float64) *float64 { return &f }
+
+func TestCalibration_Validate(t *testing.T) {
+	calibration := &Calibration{Name: " lab ", Tables: []Table{
+		{Predictor: "revel", Intervals: []Interval{{Criterion: "pp3", Strength: "strong", MinScore: score(0.932)}}},
+		{Predictor: "spliceai", Intervals: []Interval{{Criterion: "bp4", Strength: "supporting", MaxScore: score(0.1)}}},
+	}}
+	require.NoError(t, calibration.Validate())
+	assert.Equal(t, "lab", calibration.Name)
+	assert.Equal(t, domain.PredictorREVEL, calibration.Tables[0].Predictor)
+	assert.Equal(t, "PP3", calibration.Tables[0].Intervals[0].Criterion)
+	assert.Equal(t, domain.STRONG, calibration.Tables[0].Intervals[0].Strength)
+	assert.Equal(t, SpliceAI, calibration.Tables[1].Predictor)
+
+	interval := func(criterion string, strength domain.RuleStrength, min, max *float64) []Interval {
+		return []Interval{{Criterion: criterion, Strength: strength, MinScore: min, MaxScore: max}}
+	}
+	tests := []struct {
+		name        string
+		calibration Calibration
+		want        string
+	}{
+		{"no name", Calibration{Tables: []Table{{Predictor: "REVEL", Intervals: interval("PP3", domain.STRONG, score(0.9), nil)}}}, "name is required"},
+		{"no tables", Calibration{Name: "lab"}, "no tables"},
+		{"unknown predictor", Calibration{Name: "lab", Tables: []Table{{Predictor: "MutPred", Intervals: interval("PP3", domain.STRONG, score(0.9), nil)}}}, "unknown predictor"},
+		{"no intervals", Calibration{Name: "lab", Tables: []Table{{Predictor: "REVEL"}}}, "no intervals"},
+		{"wrong criterion", Calibration{Name: "lab", Tables: []Table{{Predictor: "REVEL", Intervals: interval("PM1", domain.STRONG, score(0.9), nil)}}}, "PP3 or BP4"},
+		{"bad strength", Calibration{Name: "lab", Tables: []Table{{Predictor: "REVEL", Intervals: interval("PP3", "HIGH", score(0.9), nil)}}}, "invalid strength"},
+		{"empty range", Calibration{Name: "lab", Tables: []Table{{Predictor: "REVEL", Intervals: interval("PP3", domain.STRONG, nil, nil)}}}, "min_score or max_score"},
+		{"inverted range", Calibration{Name: "lab", Tables: []Table{{Predictor: "REVEL", Intervals: interval("PP3", domain.STRONG, score(0.9), score(0.5))}}}, "below min_score"},
+		{"duplicate table", Calibration{Name: "lab", Tables: []Table{
+			{Predictor: "REVEL", Intervals: interval("PP3", domain.STRONG, score(0.9), nil)},
+			{Predictor: "revel", Intervals: interval("BP4", domain.STRONG, nil, score(0.01))},
+		}}, "twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.calibration.Validate()
+			assert.ErrorIs(t, err, ErrInvalidCalibration)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestCalibration_Missense(t *testing.T) {
+	calibration, err := Load("../../examples/predictor_calibration.yaml")
+	require.NoError(t, err)
+	require.NotNil(t, calibration)
+	assert.Equal(t, "clingen-2023", calibration.Name)
+
+	revel := func(s float64) *domain.ComputationalData {
+		return &domain.ComputationalData{REVELScore: s, CADDScore: 30, ScoredBy: []string{domain.PredictorREVEL, domain.PredictorCADD}}
+	}
+	tests := []struct {
+		score     float64
+		criterion string
+		strength  domain.RuleStrength
+	}{
+		{0.95, "PP3", domain.STRONG},
+		{0.932, "PP3", domain.STRONG},
+		{0.8, "PP3", domain.MODERATE},
+		{0.7, "PP3", domain.SUPPORTING},
+		{0.5, "", ""},
+		{0.25, "BP4", domain.SUPPORTING},
+		{0.1, "BP4", domain.MODERATE},
+		{0.01, "BP4", domain.STRONG},
+	}
+	for _, tt := range tests {
+		call := calibration.Missense(revel(tt.score))
+		require.NotNil(t, call)
+		assert.Equal(t, domain.PredictorREVEL, call.Predictor, "REVEL is tried before CADD")
+		assert.Equal(t, tt.criterion, call.Criterion, "REVEL %g", tt.score)
+		assert.Equal(t, tt.strength, call.Strength, "REVEL %g", tt.score)
+	}
+	assert.Equal(t, "REVEL 0.95 (REVEL >= 0.932): PP3 STRONG", calibration.Missense(revel(0.95)).String())
+	assert.Equal(t, "REVEL 0.5: indeterminate", calibration.Missense(revel(0.5)).String())
+
+	bayesDel := calibration.Missense(&domain.ComputationalData{BayesDelScore: -0.4, ScoredBy: []string{domain.PredictorBayesDel}})
+	require.NotNil(t, bayesDel)
+	assert.Equal(t, "BP4", bayesDel.Criterion)
+	assert.Equal(t, domain.MODERATE, bayesDel.Strength)
+
+	assert.Nil(t, calibration.Missense(&domain.ComputationalData{GERPScore: 5, ScoredBy: []string{domain.PredictorGERP}}), "no calibrated predictor scored the variant")
+	assert.Nil(t, calibration.Missense(nil))
+	assert.Nil(t, (*Calibration)(nil).Missense(revel(0.95)))
+}
+
+func TestCalibration_Splicing(t *testing.T) {
+	calibration, err := Load("../../examples/predictor_calibration.yaml")
+	require.NoError(t, err)
+
+	call := calibration.Splicing([]domain.SplicingPrediction{
+		{Tool: "SpliceAI", Score: 0.05, Event: domain.SpliceEventDonorGain},
+		{Tool: "SpliceAI", Score: 0.35, Event: domain.SpliceEventDonorLoss},
+		{Tool: "Pangolin", Score: 0.9},
+	})
+	require.NotNil(t, call)
+	assert.Equal(t, 0.35, call.Score, "the highest SpliceAI delta score decides")
+	assert.Equal(t, "PP3", call.Criterion)
+
+	call = calibration.Splicing([]domain.SplicingPrediction{{Tool: "SpliceAI", Score: 0.02}})
+	require.NotNil(t, call)
+	assert.Equal(t, "BP4", call.Criterion)
+
+	assert.Empty(t, calibration.Splicing([]domain.SplicingPrediction{{Tool: "SpliceAI", Score: 0.15}}).Criterion)
+	assert.Nil(t, calibration.Splicing([]domain.SplicingPrediction{{Tool: "Pangolin", Score: 0.9}}), "Pangolin is not calibrated in the example")
+	assert.Nil(t, calibration.Splicing(nil))
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	calibration, err := Load(filepath.Join(dir, "missing.yaml"))
+	require.NoError(t, err)
+	assert.Nil(t, calibration, "a missing file yields no calibration")
+
+	path := filepath.Join(dir, "calibration.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"name":"lab","tables":[{"predictor":"CADD","intervals":[{"criterion":"PP3","strength":"MODERATE","min_score":28.1}]}]}`), 0644))
+	calibration, err = Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, path, calibration.File)
+	assert.Equal(t, domain.PredictorCADD, calibration.Tables[0].Predictor)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"name":"lab","tables":[]}`), 0644))
+	_, err = Load(path)
+	assert.ErrorIs(t, err, ErrInvalidCalibration)
+
+	_, err = Load(filepath.Join(dir, "calibration.txt"))
+	assert.ErrorContains(t, err, "unsupported")
+}
@@ -0,0 +1,268 @@
+// Package cli implements the lite binary's offline subcommands, which run
+// the classification pipeline in-process without starting an MCP transport.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/internal/variantid"
+)
+
+// ToolCaller runs an MCP tool in-process
+type ToolCaller interface {
+	CallTool(ctx context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response
+}
+
+// Output formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatTSV  = "tsv"
+)
+
+// ClassifyOptions are the classify subcommand's arguments
+type ClassifyOptions struct {
+	Notation string
+	Format   string
+	Header   bool // Print the TSV header row
+	Params   tools.ClassifyVariantParams
+}
+
+// hgvsAccession matches notations on a reference sequence, as opposed to
+// gene symbol notations such as BRCA1:c.68_69del
+var hgvsAccession = regexp.MustCompile(`^(N[CGMRP]_|X[MR]_|ENS[TG]|LRG_)`)
+
+// ParseClassifyArgs parses `classify <notation> [options]`
+func ParseClassifyArgs(args []string) (*ClassifyOptions, error) {
+	opts := &ClassifyOptions{Format: FormatText, Header: true}
+
+	value := func(i *int) (string, error) {
+		if *i+1 >= len(args) {
+			return "", fmt.Errorf("%s requires a value", args[*i])
+		}
+		*i++
+		return args[*i], nil
+	}
+
+	for i := 0; i < len(args); i++ {
+		var err error
+		switch args[i] {
+		case "--json":
+			opts.Format = FormatJSON
+		case "--tsv":
+			opts.Format = FormatTSV
+		case "--format", "-f":
+			opts.Format, err = value(&i)
+		case "--no-header":
+			opts.Header = false
+		case "--context":
+			opts.Params.ClassificationContext, err = value(&i)
+		case "--tumor-type":
+			opts.Params.TumorType, err = value(&i)
+		case "--condition":
+			opts.Params.Condition, err = value(&i)
+		case "--specialty":
+			opts.Params.OrderingSpecialty, err = value(&i)
+		case "--transcript":
+			opts.Params.TranscriptID, err = value(&i)
+		case "--scoring-mode":
+			opts.Params.ScoringMode, err = value(&i)
+		case "--evidence":
+			opts.Params.IncludeEvidence = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return nil, fmt.Errorf("unknown option %s", args[i])
+			}
+			if opts.Notation != "" {
+				return nil, fmt.Errorf("unexpected argument %q; classify takes one variant", args[i])
+			}
+			opts.Notation = args[i]
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Notation == "" {
+		return nil, fmt.Errorf("a variant notation is required")
+	}
+	switch opts.Format {
+	case FormatText, FormatJSON, FormatTSV:
+	default:
+		return nil, fmt.Errorf("unknown format %q; use text, json or tsv", opts.Format)
+	}
+
+	if hgvsAccession.MatchString(opts.Notation) || variantid.IsIdentifier(opts.Notation) {
+		opts.Params.HGVSNotation = opts.Notation
+	} else {
+		opts.Params.GeneSymbolNotation = opts.Notation
+	}
+	return opts, nil
+}
+
+// Classify classifies one variant with the classify_variant tool and writes
+// the result in the requested format
+func Classify(ctx context.Context, caller ToolCaller, opts *ClassifyOptions, out io.Writer) error {
+	result, err := classify(ctx, caller, opts.Params)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case FormatJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case FormatTSV:
+		if opts.Header {
+			fmt.Fprintln(out, strings.Join(TSVHeader, "\t"))
+		}
+		fmt.Fprintln(out, strings.Join(TSVRow(opts.Notation, result), "\t"))
+		return nil
+	default:
+		return WriteSummary(out, opts.Notation, result)
+	}
+}
+
+// classify calls classify_variant and decodes its result
+func classify(ctx context.Context, caller ToolCaller, params tools.ClassifyVariantParams) (*tools.ClassifyVariantResult, error) {
+	response := caller.CallTool(ctx, "classify_variant", params)
+	if response == nil {
+		return nil, fmt.Errorf("classify_variant returned no response")
+	}
+	if response.Error != nil {
+		if detail, ok := response.Error.Data.(string); ok && detail != "" {
+			return nil, fmt.Errorf("%s: %s", response.Error.Message, detail)
+		}
+		return nil, fmt.Errorf("%s", response.Error.Message)
+	}
+
+	// Round-trip through JSON so summarized or in-process results decode alike
+	raw, err := json.Marshal(response.Result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	var decoded struct {
+		Classification *tools.ClassifyVariantResult `json:"classification"`
+	}
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.Classification == nil {
+		return nil, fmt.Errorf("unexpected classify_variant result")
+	}
+	return decoded.Classification, nil
+}
+
+// TSVHeader names the columns of TSVRow
+var TSVHeader = []string{"input", "variant_id", "classification", "confidence", "criteria", "point_total", "specification", "recommendations"}
+
+// TSVRow formats a result as tab-separated columns; tabs and newlines in
+// values are replaced by spaces
+func TSVRow(input string, result *tools.ClassifyVariantResult) []string {
+	row := []string{
+		input,
+		result.VariantID,
+		result.Classification,
+		result.Confidence,
+		strings.Join(appliedCriteria(result), ","),
+		fmt.Sprint(result.PointTotal),
+		result.Specification,
+		strings.Join(result.Recommendations, "; "),
+	}
+	for i, value := range row {
+		row[i] = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(value)
+	}
+	return row
+}
+
+// appliedCriteria lists the codes of the criteria met
+func appliedCriteria(result *tools.ClassifyVariantResult) []string {
+	var codes []string
+	for _, rule := range result.AppliedRules {
+		if rule.Applied {
+			codes = append(codes, rule.RuleCode)
+		}
+	}
+	return codes
+}
+
+// WriteSummary writes a human-readable summary of a result
+func WriteSummary(out io.Writer, input string, result *tools.ClassifyVariantResult) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Variant:         %s\n", input)
+	if result.VariantID != "" && result.VariantID != input {
+		fmt.Fprintf(&b, "Variant ID:      %s\n", result.VariantID)
+	}
+	if result.Somatic != nil {
+		fmt.Fprintf(&b, "Classification:  %s (somatic)\n", result.Classification)
+	} else if result.VUSTier != "" {
+		fmt.Fprintf(&b, "Classification:  %s (%s)\n", result.Classification, result.VUSTier)
+	} else {
+		fmt.Fprintf(&b, "Classification:  %s\n", result.Classification)
+	}
+	if result.ConfidenceScore > 0 {
+		fmt.Fprintf(&b, "Confidence:      %s (score %.3f)\n", result.Confidence, result.ConfidenceScore)
+	} else {
+		fmt.Fprintf(&b, "Confidence:      %s\n", result.Confidence)
+	}
+
+	var met []string
+	for _, rule := range result.AppliedRules {
+		if rule.Applied {
+			met = append(met, fmt.Sprintf("%s (%s)", rule.RuleCode, strings.ToLower(strings.ReplaceAll(rule.Strength, "_", " "))))
+		}
+	}
+	if len(met) == 0 {
+		met = []string{"none"}
+	}
+	fmt.Fprintf(&b, "Criteria met:    %s\n", strings.Join(met, ", "))
+	if result.ScoringMode != "" {
+		fmt.Fprintf(&b, "Points:          %d (%s)\n", result.PointTotal, result.ScoringMode)
+	}
+	if result.Specification != "" {
+		fmt.Fprintf(&b, "Specification:   %s\n", result.Specification)
+	}
+	if result.KnownBenign != nil {
+		fmt.Fprintf(&b, "Known benign:    on the lab's known benign list\n")
+	}
+	if result.ProbableArtifact != nil {
+		fmt.Fprintf(&b, "Artifact:        on the lab's artifact blacklist\n")
+	}
+	if len(result.Recommendations) > 0 {
+		b.WriteString("Recommendations:\n")
+		for _, rec := range result.Recommendations {
+			fmt.Fprintf(&b, "  - %s\n", rec)
+		}
+	}
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// ClassifyUsage describes the classify subcommand
+const ClassifyUsage = `Usage:
+  mcp-server-lite classify <variant> [options]
+
+Classifies one variant offline with the full pipeline, without starting a
+server. The variant is an HGVS notation (NM_000492.3:c.1521_1523del), a gene
+symbol notation (CFTR:c.1521_1523del), an rsID or a ClinVar accession.
+
+Options:
+  --json                 Print the full result as JSON
+  --tsv                  Print a tab-separated row (with a header unless --no-header)
+  --format text|json|tsv Output format (default text)
+  --context germline|somatic
+  --tumor-type TYPE      Tumor type for somatic tiering
+  --condition NAME       Condition for gene/condition-specific frequency thresholds
+  --specialty NAME       Ordering specialty selecting the transcript set
+  --transcript ID        Transcript to interpret on
+  --scoring-mode MODE    combining_rules or points
+  --evidence             Include the gathered evidence (JSON output)
+
+Exits with status 1 and a message on stderr when the variant cannot be classified.
+`
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// fakeCaller answers classify_variant with a fixed result or error
+type fakeCaller struct {
+	result    *tools.ClassifyVariantResult
+	err       *protocol.RPCError
+	arguments interface{}
+}
+
+func (f *fakeCaller) CallTool(_ context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response {
+	f.arguments = arguments
+	if f.err != nil {
+		return &protocol.JSONRPC2Response{Error: f.err}
+	}
+	return &protocol.JSONRPC2Response{Result: map[string]interface{}{"classification": f.result}}
+}
+
+func testResult() *tools.ClassifyVariantResult {
+	return &tools.ClassifyVariantResult{
+		VariantID:      "NM_000492.3:c.1521_1523del",
+		Classification: "Pathogenic",
+		Confidence:     "High",
+		AppliedRules: []tools.ACMGAMPRuleResult{
+			{RuleCode: "PVS1", Strength: "VERY_STRONG", Applied: true},
+			{RuleCode: "PM2", Strength: "SUPPORTING", Applied: true},
+			{RuleCode: "BA1", Strength: "STAND_ALONE", Applied: false},
+		},
+		Recommendations: []string{"Confirm by Sanger sequencing", "Offer cascade\\ttesting"},
+		ScoringMode:     "points",
+		PointTotal:      9,
+		ConfidenceScore: 0.989,
+	}
+}
+
+func TestParseClassifyArgs(t *testing.T) {
+	opts, err := ParseClassifyArgs([]string{"NM_000492.3:c.1521_1523del", "--json", "--condition", "cystic fibrosis"})
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, opts.Format)
+	assert.Equal(t, "NM_000492.3:c.1521_1523del", opts.Params.HGVSNotation)
+	assert.Equal(t, "cystic fibrosis", opts.Params.Condition)
+
+	opts, err = ParseClassifyArgs([]string{"--tsv", "--no-header", "CFTR:c.1521_1523del"})
+	require.NoError(t, err)
+	assert.Equal(t, FormatTSV, opts.Format)
+	assert.False(t, opts.Header)
+	assert.Equal(t, "CFTR:c.1521_1523del", opts.Params.GeneSymbolNotation)
+
+	opts, err = ParseClassifyArgs([]string{"rs113993960"})
+	require.NoError(t, err)
+	assert.Equal(t, "rs113993960", opts.Params.HGVSNotation)
+
+	for _, args := range [][]string{
+		{},
+		{"--json"},
+		{"NM_000492.3:c.1521_1523del", "NM_000492.3:c.1522C>T"},
+		{"NM_000492.3:c.1521_1523del", "--format", "xml"},
+		{"NM_000492.3:c.1521_1523del", "--condition"},
+		{"NM_000492.3:c.1521_1523del", "--verbose"},
+	} {
+		_, err := ParseClassifyArgs(args)
+		assert.Error(t, err, args)
+	}
+}
+
+func TestClassify_Formats(t *testing.T) {
+	caller := &fakeCaller{result: testResult()}
+
+	var out bytes.Buffer
+	opts := &ClassifyOptions{Notation: "NM_000492.3:c.1521_1523del", Format: FormatJSON}
+	require.NoError(t, Classify(context.Background(), caller, opts, &out))
+	var decoded tools.ClassifyVariantResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "Pathogenic", decoded.Classification)
+
+	out.Reset()
+	opts.Format, opts.Header = FormatTSV, true
+	require.NoError(t, Classify(context.Background(), caller, opts, &out))
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, strings.Join(TSVHeader, "\t"), lines[0])
+	columns := strings.Split(lines[1], "\t")
+	require.Len(t, columns, len(TSVHeader))
+	assert.Equal(t, "Pathogenic", columns[2])
+	assert.Equal(t, "PVS1,PM2", columns[4])
+	assert.Equal(t, "9", columns[5])
+	assert.Equal(t, "Confirm by Sanger sequencing; Offer cascade\\ttesting", columns[7])
+
+	out.Reset()
+	opts.Format = FormatText
+	require.NoError(t, Classify(context.Background(), caller, opts, &out))
+	assert.Contains(t, out.String(), "Classification:  Pathogenic\n")
+	assert.Contains(t, out.String(), "Confidence:      High (score 0.989)\n")
+	assert.Contains(t, out.String(), "Criteria met:    PVS1 (very strong), PM2 (supporting)\n")
+	assert.Contains(t, out.String(), "  - Confirm by Sanger sequencing\n")
+}
+
+func TestClassify_Error(t *testing.T) {
+	caller := &fakeCaller{err: &protocol.RPCError{Code: protocol.MCPToolError, Message: "Classification failed", Data: "unknown transcript"}}
+	opts := &ClassifyOptions{Notation: "NM_999999.1:c.1A>G", Format: FormatText}
+
+	var out bytes.Buffer
+	err := Classify(context.Background(), caller, opts, &out)
+	require.Error(t, err)
+	assert.Equal(t, "Classification failed: unknown transcript", err.Error())
+	assert.Empty(t, out.String())
+}
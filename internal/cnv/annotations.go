@@ -0,0 +1,264 @@
+package cnv
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ClinGen dosage sensitivity scores
+const (
+	DosageSufficientEvidence = 3  // Established haploinsufficiency or triplosensitivity
+	DosageRecessive          = 30 // Associated with an autosomal recessive phenotype
+	DosageUnlikely           = 40 // Dosage sensitivity unlikely
+	DosageNotEvaluated       = -1 // Not yet evaluated or absent from the curation list
+)
+
+// Feature is an annotated gene or region
+type Feature struct {
+	Name               string   `json:"name"`
+	Interval           Interval `json:"interval"`
+	Haploinsufficiency int      `json:"haploinsufficiency_score"` // ClinGen HI score, -1 if not evaluated
+	Triplosensitivity  int      `json:"triplosensitivity_score"`  // ClinGen TS score, -1 if not evaluated
+	BenignType         Type     `json:"benign_type,omitempty"`    // For benign regions: deletion, duplication or both when empty
+	Source             string   `json:"source,omitempty"`         // File the feature came from
+}
+
+// Annotations holds the gene and region annotations CNVs are scored against,
+// by assembly. Annotations is read-only after loading and safe for
+// concurrent use.
+type Annotations struct {
+	genes         map[string][]Feature // Protein-coding genes, from genes.bed
+	dosageGenes   map[string][]Feature // ClinGen gene curation list
+	dosageRegions map[string][]Feature // ClinGen region curation list
+	benign        map[string][]Feature // Established benign CNV regions
+	count         int
+}
+
+// NewAnnotations creates an empty annotation set
+func NewAnnotations() *Annotations {
+	return &Annotations{
+		genes:         make(map[string][]Feature),
+		dosageGenes:   make(map[string][]Feature),
+		dosageRegions: make(map[string][]Feature),
+		benign:        make(map[string][]Feature),
+	}
+}
+
+// LoadDir loads annotations from per-assembly subdirectories, e.g.
+// dir/GRCh38. Each may hold genes.bed (protein-coding genes: chrom, start,
+// end, symbol), the ClinGen dosage curation lists (files with
+// gene_curation or region_curation in their name, tab-separated) and benign
+// CNV regions in BED files named benign*.bed, with the type (loss or gain)
+// in the fifth column. A missing directory yields empty annotations.
+func LoadDir(dir string) (*Annotations, error) {
+	annotations := NewAnnotations()
+
+	for _, assembly := range []string{GRCh37, GRCh38} {
+		assemblyDir := filepath.Join(dir, assembly)
+		entries, err := os.ReadDir(assemblyDir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CNV annotation directory: %w", err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(assemblyDir, entry.Name())
+			name := strings.ToLower(entry.Name())
+			switch {
+			case name == "genes.bed":
+				err = annotations.loadBED(annotations.genes, assembly, path, false)
+			case strings.HasPrefix(name, "benign") && strings.HasSuffix(name, ".bed"):
+				err = annotations.loadBED(annotations.benign, assembly, path, true)
+			case strings.Contains(name, "gene_curation") && strings.HasSuffix(name, ".tsv"):
+				err = annotations.loadCuration(annotations.dosageGenes, assembly, path)
+			case strings.Contains(name, "region_curation") && strings.HasSuffix(name, ".tsv"):
+				err = annotations.loadCuration(annotations.dosageRegions, assembly, path)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return annotations, nil
+}
+
+// Count returns the number of loaded genes and regions
+func (a *Annotations) Count() int {
+	if a == nil {
+		return 0
+	}
+	return a.count
+}
+
+// HasGenes reports whether protein-coding genes are loaded for an assembly,
+// which sections 1 and 3 are scored from
+func (a *Annotations) HasGenes(assembly string) bool {
+	return a != nil && len(a.genes[assembly]) > 0
+}
+
+// loadBED loads features from a BED file, converting to 1-based coordinates
+func (a *Annotations) loadBED(into map[string][]Feature, assembly, path string, benign bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open CNV annotation: %w", err)
+	}
+	defer file.Close()
+
+	base := filepath.Base(path)
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "track") || strings.HasPrefix(text, "browser") {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if len(fields) < 4 {
+			fields = strings.Fields(text)
+		}
+		if len(fields) < 4 {
+			return fmt.Errorf("%s line %d: expected chrom, start, end and name", base, line)
+		}
+		start, err1 := strconv.ParseInt(fields[1], 10, 64)
+		end, err2 := strconv.ParseInt(fields[2], 10, 64)
+		if err1 != nil || err2 != nil || start < 0 || end <= start {
+			return fmt.Errorf("%s line %d: invalid interval", base, line)
+		}
+
+		feature := Feature{
+			Name:               strings.TrimSpace(fields[3]),
+			Interval:           Interval{Assembly: assembly, Chromosome: normalizeChromosome(fields[0]), Start: start + 1, End: end},
+			Haploinsufficiency: DosageNotEvaluated,
+			Triplosensitivity:  DosageNotEvaluated,
+			Source:             base,
+		}
+		if benign && len(fields) > 4 {
+			if benignType, err := ParseType(fields[4]); err == nil {
+				feature.BenignType = benignType
+			}
+		}
+		into[assembly] = append(into[assembly], feature)
+		a.count++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read CNV annotation %s: %w", base, err)
+	}
+	return nil
+}
+
+// loadCuration loads a ClinGen dosage sensitivity curation list. The last
+// comment line names the columns; genes are keyed by Gene Symbol and regions
+// by ISCA Region Name.
+func (a *Annotations) loadCuration(into map[string][]Feature, assembly, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open ClinGen curation list: %w", err)
+	}
+	defer file.Close()
+
+	base := filepath.Base(path)
+	columns := map[string]int{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if strings.HasPrefix(text, "#") {
+			header := strings.Split(strings.TrimPrefix(text, "#"), "\t")
+			if len(header) > 1 {
+				columns = map[string]int{}
+				for i, name := range header {
+					columns[strings.ToLower(strings.TrimSpace(name))] = i
+				}
+			}
+			continue
+		}
+
+		nameColumn, ok := columns["gene symbol"]
+		if !ok {
+			nameColumn, ok = columns["isca region name"]
+		}
+		locationColumn, hasLocation := columns["genomic location"]
+		if !ok || !hasLocation {
+			return fmt.Errorf("%s: missing Gene Symbol or ISCA Region Name and Genomic Location columns", base)
+		}
+
+		fields := strings.Split(text, "\t")
+		if len(fields) <= nameColumn || len(fields) <= locationColumn {
+			return fmt.Errorf("%s line %d: too few columns", base, line)
+		}
+		location, err := parseLocation(fields[locationColumn])
+		if err != nil {
+			// Entries without a mapped location cannot be overlapped
+			continue
+		}
+		location.Assembly = assembly
+
+		into[assembly] = append(into[assembly], Feature{
+			Name:               strings.TrimSpace(fields[nameColumn]),
+			Interval:           location,
+			Haploinsufficiency: dosageScore(fields, columns, "haploinsufficiency score"),
+			Triplosensitivity:  dosageScore(fields, columns, "triplosensitivity score"),
+			Source:             base,
+		})
+		a.count++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read ClinGen curation list %s: %w", base, err)
+	}
+	return nil
+}
+
+// parseLocation reads a ClinGen genomic location, e.g. chr17:36459258-37856255
+func parseLocation(s string) (Interval, error) {
+	m := intervalPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Interval{}, fmt.Errorf("invalid genomic location %q", s)
+	}
+	start, _ := strconv.ParseInt(strings.ReplaceAll(m[2], ",", ""), 10, 64)
+	end, _ := strconv.ParseInt(strings.ReplaceAll(m[3], ",", ""), 10, 64)
+	if start <= 0 || end < start {
+		return Interval{}, fmt.Errorf("invalid genomic location %q", s)
+	}
+	return Interval{Chromosome: normalizeChromosome(m[1]), Start: start, End: end}, nil
+}
+
+// dosageScore reads a dosage score column; missing or "Not yet evaluated" is -1
+func dosageScore(fields []string, columns map[string]int, column string) int {
+	i, ok := columns[column]
+	if !ok || i >= len(fields) {
+		return DosageNotEvaluated
+	}
+	score, err := strconv.Atoi(strings.TrimSpace(fields[i]))
+	if err != nil {
+		return DosageNotEvaluated
+	}
+	return score
+}
+
+// overlapping returns the features of an assembly overlapping an interval
+func overlapping(features map[string][]Feature, interval Interval) []Feature {
+	var found []Feature
+	for _, feature := range features[interval.Assembly] {
+		if feature.Interval.overlaps(interval) {
+			found = append(found, feature)
+		}
+	}
+	return found
+}
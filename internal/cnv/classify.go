@@ -0,0 +1,425 @@
+package cnv
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// Score sources
+const (
+	SourceAutomatic = "automatic" // Scored from the loaded annotations
+	SourceSubmitted = "submitted" // Submitted by the curator
+)
+
+// Evidence is a curator-submitted evidence category. Points defaults to the
+// suggested points of the category and must lie within its allowed range.
+type Evidence struct {
+	Code   string   `json:"code"`
+	Points *float64 `json:"points,omitempty"`
+	Note   string   `json:"note,omitempty"`
+}
+
+// Score is one scored evidence category
+type Score struct {
+	Code        string  `json:"code"`
+	Section     int     `json:"section"`
+	Points      float64 `json:"points"`
+	Description string  `json:"description"`
+	Source      string  `json:"source"`
+	Detail      string  `json:"detail,omitempty"`
+}
+
+// SectionScore is the point total of one section
+type SectionScore struct {
+	Section int     `json:"section"`
+	Name    string  `json:"name"`
+	Points  float64 `json:"points"`
+}
+
+// Result is a scored CNV with its point breakdown
+type Result struct {
+	Type               Type                  `json:"type"`
+	Interval           Interval              `json:"interval"`
+	Length             int64                 `json:"length"`
+	CopyNumber         int                   `json:"copy_number,omitempty"`
+	ProteinCodingGenes []string              `json:"protein_coding_genes,omitempty"`
+	DosageFeatures     []Feature             `json:"dosage_sensitive_features,omitempty"`
+	Criteria           []Score               `json:"criteria"`
+	Sections           []SectionScore        `json:"sections"`
+	TotalPoints        float64               `json:"total_points"`
+	Classification     domain.Classification `json:"classification"`
+	Notes              []string              `json:"notes,omitempty"`
+}
+
+// Classifier scores CNVs against gene and dosage annotations
+type Classifier struct {
+	annotations *Annotations
+}
+
+// NewClassifier creates a CNV classifier. Without annotations only
+// submitted evidence is scored.
+func NewClassifier(annotations *Annotations) *Classifier {
+	if annotations == nil {
+		annotations = NewAnnotations()
+	}
+	return &Classifier{annotations: annotations}
+}
+
+// Evaluate scores a CNV. Sections 1 to 3 are scored automatically where
+// the annotations allow; submitted evidence in those sections replaces the
+// automatic score for the section. Sections 4 and 5 come from submitted
+// evidence only.
+func (c *Classifier) Evaluate(call *Call, evidence []Evidence) (*Result, error) {
+	if call == nil {
+		return nil, fmt.Errorf("%w: no CNV given", ErrInvalidCNV)
+	}
+
+	submitted, err := scoreEvidence(call.Type, evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Type:       call.Type,
+		Interval:   call.Interval,
+		Length:     call.Interval.Length(),
+		CopyNumber: call.CopyNumber,
+	}
+
+	replaced := map[int]bool{}
+	for _, score := range submitted {
+		replaced[score.Section] = true
+	}
+	for _, score := range c.automatic(call, result) {
+		if !replaced[score.Section] {
+			result.Criteria = append(result.Criteria, score)
+		}
+	}
+	result.Criteria = append(result.Criteria, submitted...)
+	sort.SliceStable(result.Criteria, func(i, j int) bool {
+		return result.Criteria[i].Code < result.Criteria[j].Code
+	})
+
+	result.Sections = sectionTotals(result.Criteria)
+	for _, section := range result.Sections {
+		result.TotalPoints += section.Points
+	}
+	result.TotalPoints = round2(result.TotalPoints)
+	result.Classification = Classify(result.TotalPoints)
+	return result, nil
+}
+
+// scoreEvidence validates submitted evidence against the scoring scheme
+func scoreEvidence(cnvType Type, evidence []Evidence) ([]Score, error) {
+	seen := map[string]bool{}
+	var scores []Score
+	for _, e := range evidence {
+		code := normalizeCode(e.Code)
+		c, ok := lookupCriterion(cnvType, code)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not a %s evidence category", ErrInvalidCNV, e.Code, cnvType)
+		}
+		if seen[code] {
+			return nil, fmt.Errorf("%w: %s submitted more than once", ErrInvalidCNV, code)
+		}
+		seen[code] = true
+
+		points := c.points
+		if e.Points != nil {
+			points = round2(*e.Points)
+			if points < c.min || points > c.max {
+				return nil, fmt.Errorf("%w: %s allows %s points", ErrInvalidCNV, code, pointRange(c))
+			}
+		}
+		scores = append(scores, Score{
+			Code:        code,
+			Section:     c.section,
+			Points:      points,
+			Description: c.description,
+			Source:      SourceSubmitted,
+			Detail:      e.Note,
+		})
+	}
+	return scores, nil
+}
+
+func pointRange(c criterion) string {
+	if c.min == c.max {
+		return fmt.Sprintf("%.2f", c.min)
+	}
+	return fmt.Sprintf("%.2f to %.2f", c.min, c.max)
+}
+
+// sectionTotals sums the points of each section, capping the related
+// section 4 categories
+func sectionTotals(scores []Score) []SectionScore {
+	totals := map[int]float64{}
+	byCode := map[string]float64{}
+	for _, score := range scores {
+		if score.Section == 4 {
+			byCode[score.Code] = score.Points
+			continue
+		}
+		totals[score.Section] += score.Points
+	}
+	for _, group := range section4Caps {
+		sum := 0.0
+		for _, code := range group.codes {
+			sum += byCode[code]
+		}
+		totals[4] += math.Max(group.min, math.Min(group.max, sum))
+	}
+
+	sections := make([]SectionScore, 0, len(sectionNames))
+	for section := 1; section <= len(sectionNames); section++ {
+		sections = append(sections, SectionScore{
+			Section: section,
+			Name:    sectionNames[section],
+			Points:  round2(totals[section]),
+		})
+	}
+	return sections
+}
+
+// automatic scores sections 1 to 3 from the annotations and records the
+// overlapping genes and dosage sensitive features on the result
+func (c *Classifier) automatic(call *Call, result *Result) []Score {
+	a := c.annotations
+	interval := call.Interval
+
+	genes := overlapping(a.genes, interval)
+	for _, gene := range genes {
+		result.ProteinCodingGenes = append(result.ProteinCodingGenes, gene.Name)
+	}
+	dosage := append(overlapping(a.dosageGenes, interval), overlapping(a.dosageRegions, interval)...)
+	for _, feature := range dosage {
+		if feature.Haploinsufficiency == DosageSufficientEvidence || feature.Triplosensitivity == DosageSufficientEvidence {
+			result.DosageFeatures = append(result.DosageFeatures, feature)
+		}
+	}
+
+	var scores []Score
+	if a.HasGenes(interval.Assembly) {
+		if len(genes) > 0 || len(result.DosageFeatures) > 0 {
+			scores = append(scores, automaticScore(call.Type, "1A", fmt.Sprintf("%d protein-coding genes", len(genes))))
+		} else {
+			scores = append(scores, automaticScore(call.Type, "1B", "No protein-coding genes or dosage sensitive regions overlap"))
+		}
+		scores = append(scores, automaticScore(call.Type, geneCountCode(call.Type, len(genes)), fmt.Sprintf("%d protein-coding genes", len(genes))))
+	} else {
+		result.Notes = append(result.Notes, fmt.Sprintf("No %s gene annotations loaded: submit sections 1 and 3", interval.Assembly))
+	}
+
+	var section2 *Score
+	var notes []string
+	if call.Type == Loss {
+		section2, notes = c.scoreLoss(interval)
+	} else {
+		section2, notes = c.scoreGain(interval, genes)
+	}
+	if section2 != nil {
+		scores = append(scores, *section2)
+	}
+	result.Notes = append(result.Notes, notes...)
+	if len(a.dosageGenes[interval.Assembly]) == 0 && len(a.dosageRegions[interval.Assembly]) == 0 {
+		result.Notes = append(result.Notes, fmt.Sprintf("No %s ClinGen dosage curations loaded: review section 2", interval.Assembly))
+	}
+	return scores
+}
+
+// scoreLoss scores section 2 of a deletion: full overlap of a
+// haploinsufficient gene or region (2A), containment in or overlap with a
+// benign loss (2F, 2G), or partial overlap of a haploinsufficient region (2B)
+func (c *Classifier) scoreLoss(interval Interval) (*Score, []string) {
+	var notes []string
+	var partialRegion string
+
+	for _, feature := range overlapping(c.annotations.dosageGenes, interval) {
+		if feature.Haploinsufficiency != DosageSufficientEvidence {
+			continue
+		}
+		if interval.contains(feature.Interval) {
+			score := automaticScore(Loss, "2A", "Contains haploinsufficient gene "+feature.Name)
+			return &score, nil
+		}
+		notes = append(notes, fmt.Sprintf("Breakpoint within haploinsufficient gene %s: evaluate 2C to 2E", feature.Name))
+	}
+	for _, feature := range overlapping(c.annotations.dosageRegions, interval) {
+		if feature.Haploinsufficiency != DosageSufficientEvidence {
+			continue
+		}
+		if interval.contains(feature.Interval) {
+			score := automaticScore(Loss, "2A", "Contains haploinsufficient region "+feature.Name)
+			return &score, notes
+		}
+		if partialRegion == "" {
+			partialRegion = feature.Name
+		}
+	}
+
+	if benign, contained := benignOverlap(c.annotations, Loss, interval); benign != nil {
+		if contained {
+			score := automaticScore(Loss, "2F", "Within benign loss "+benign.Name)
+			return &score, notes
+		}
+		if partialRegion == "" {
+			score := automaticScore(Loss, "2G", "Overlaps benign loss "+benign.Name)
+			return &score, notes
+		}
+	}
+	if partialRegion != "" {
+		score := automaticScore(Loss, "2B", "Partially overlaps haploinsufficient region "+partialRegion)
+		notes = append(notes, "Review whether the causative gene or critical region of "+partialRegion+" is included")
+		return &score, notes
+	}
+	return nil, notes
+}
+
+// scoreGain scores section 2 of a duplication: full overlap of a
+// triplosensitive gene or region (2A), comparison with benign gains (2C to
+// 2G), or haploinsufficient genes inside or at a breakpoint (2H, 2J)
+func (c *Classifier) scoreGain(interval Interval, genes []Feature) (*Score, []string) {
+	var notes []string
+
+	dosage := append(overlapping(c.annotations.dosageGenes, interval), overlapping(c.annotations.dosageRegions, interval)...)
+	var partialRegion string
+	for _, feature := range dosage {
+		if feature.Triplosensitivity != DosageSufficientEvidence {
+			continue
+		}
+		if interval.contains(feature.Interval) {
+			score := automaticScore(Gain, "2A", "Contains triplosensitive "+feature.Name)
+			return &score, nil
+		}
+		if partialRegion == "" {
+			partialRegion = feature.Name
+		}
+	}
+
+	if benign, contained := benignOverlap(c.annotations, Gain, interval); benign != nil {
+		benignGenes := c.geneNames(benign.Interval)
+		cnvGenes := map[string]bool{}
+		for _, gene := range genes {
+			cnvGenes[gene.Name] = true
+		}
+		var code, detail string
+		larger := interval.contains(benign.Interval) && interval != benign.Interval
+		switch {
+		case larger && subsetGenes(cnvGenes, benignGenes):
+			code, detail = "2F", "Larger than benign gain "+benign.Name+" without additional genes"
+		case sameGenes(cnvGenes, benignGenes):
+			code, detail = "2C", "Same gene content as benign gain "+benign.Name
+		case contained && interruptsGene(interval, genes):
+			code, detail = "2E", "Within benign gain "+benign.Name+", a breakpoint interrupts a gene"
+		case contained:
+			code, detail = "2D", "Within benign gain "+benign.Name
+		default:
+			code, detail = "2G", "Overlaps benign gain "+benign.Name
+		}
+		score := automaticScore(Gain, code, detail)
+		return &score, notes
+	}
+	if partialRegion != "" {
+		score := automaticScore(Gain, "2B", "Partially overlaps triplosensitive "+partialRegion)
+		return &score, notes
+	}
+
+	for _, feature := range overlapping(c.annotations.dosageGenes, interval) {
+		if feature.Haploinsufficiency != DosageSufficientEvidence {
+			continue
+		}
+		if !interval.contains(feature.Interval) {
+			score := automaticScore(Gain, "2J", "Breakpoint within haploinsufficient gene "+feature.Name)
+			notes = append(notes, "Submit 2K if the patient's phenotype is highly specific and consistent with "+feature.Name)
+			return &score, notes
+		}
+	}
+	for _, feature := range overlapping(c.annotations.dosageGenes, interval) {
+		if feature.Haploinsufficiency == DosageSufficientEvidence {
+			score := automaticScore(Gain, "2H", "Contains haploinsufficient gene "+feature.Name)
+			return &score, notes
+		}
+	}
+	return nil, notes
+}
+
+// benignOverlap returns the benign region of a type that best covers an
+// interval: one containing it if any, otherwise the first overlapping one
+func benignOverlap(a *Annotations, cnvType Type, interval Interval) (*Feature, bool) {
+	var first *Feature
+	for _, feature := range overlapping(a.benign, interval) {
+		if feature.BenignType != "" && feature.BenignType != cnvType {
+			continue
+		}
+		if feature.Interval.contains(interval) {
+			return &feature, true
+		}
+		if first == nil {
+			first = &feature
+		}
+	}
+	return first, false
+}
+
+// geneNames returns the protein-coding genes overlapping an interval
+func (c *Classifier) geneNames(interval Interval) map[string]bool {
+	names := map[string]bool{}
+	for _, gene := range overlapping(c.annotations.genes, interval) {
+		names[gene.Name] = true
+	}
+	return names
+}
+
+// interruptsGene reports whether a breakpoint of an interval falls inside a gene
+func interruptsGene(interval Interval, genes []Feature) bool {
+	for _, gene := range genes {
+		if !interval.contains(gene.Interval) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameGenes(a, b map[string]bool) bool {
+	return len(a) == len(b) && subsetGenes(a, b)
+}
+
+func subsetGenes(a, b map[string]bool) bool {
+	for gene := range a {
+		if !b[gene] {
+			return false
+		}
+	}
+	return true
+}
+
+// geneCountCode returns the section 3 category for a number of genes
+func geneCountCode(cnvType Type, genes int) string {
+	moderate, high := 25, 35
+	if cnvType == Gain {
+		moderate, high = 35, 50
+	}
+	switch {
+	case genes >= high:
+		return "3C"
+	case genes >= moderate:
+		return "3B"
+	default:
+		return "3A"
+	}
+}
+
+func automaticScore(cnvType Type, code, detail string) Score {
+	c, _ := lookupCriterion(cnvType, code)
+	return Score{
+		Code:        code,
+		Section:     c.section,
+		Points:      c.points,
+		Description: c.description,
+		Source:      SourceAutomatic,
+		Detail:      detail,
+	}
+}
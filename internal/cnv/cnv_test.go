@@ -0,0 +1,264 @@
+package cnv
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func writeAnnotation(t *testing.T, dir, assembly, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, assembly), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, assembly, name), []byte(content), 0644))
+}
+
+const testGeneCuration = "#ClinGen Gene Curation Results\n" +
+	"#Gene Symbol\tGene ID\tGenomic Location\tHaploinsufficiency Score\tTriplosensitivity Score\n" +
+	"HIGENE\t1\tchr1:10001-20000\t3\t1\n" +
+	"TSGENE\t2\tchr1:50001-60000\t0\t3\n" +
+	"ARGENE\t3\tchr1:80001-90000\t30\tNot yet evaluated\n" +
+	"UNMAPPED\t4\ttbd\t3\t3\n"
+
+const testRegionCuration = "#ISCA ID\tISCA Region Name\tGenomic Location\tHaploinsufficiency Score\tTriplosensitivity Score\n" +
+	"ISCA-1\tHI region\tchr2:100001-200000\t3\t1\n"
+
+func testAnnotations(t *testing.T) *Annotations {
+	t.Helper()
+	dir := t.TempDir()
+	writeAnnotation(t, dir, GRCh38, "genes.bed", "# genes\n"+
+		"chr1\t10000\t20000\tHIGENE\n"+
+		"chr1\t50000\t60000\tTSGENE\n"+
+		"chr1\t80000\t90000\tARGENE\n"+
+		"chr2\t120000\t130000\tREGIONGENE\n"+
+		"chr3\t1000\t2000\tBENIGN1\n"+
+		"chr3\t3000\t4000\tBENIGN2\n")
+	writeAnnotation(t, dir, GRCh38, "ClinGen_gene_curation_list_GRCh38.tsv", testGeneCuration)
+	writeAnnotation(t, dir, GRCh38, "ClinGen_region_curation_list_GRCh38.tsv", testRegionCuration)
+	writeAnnotation(t, dir, GRCh38, "benign_cnvs.bed", "chr3\t500\t5000\tbenign gain\tgain\nchr4\t1000\t90000\tbenign loss\tloss\n")
+
+	annotations, err := LoadDir(dir)
+	require.NoError(t, err)
+	return annotations
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		notation, cnvType, assembly string
+		want                        Call
+		wantErr                     bool
+	}{
+		{"arr[GRCh38] 17q12(36459258_37856255)x1", "", "", Call{Type: Loss, CopyNumber: 1,
+			Interval: Interval{GRCh38, "17", 36459258, 37856255}}, false},
+		{"arr[hg19] Xp22.31(6,455,151_8,135,053)x3", "", "", Call{Type: Gain, CopyNumber: 3,
+			Interval: Interval{GRCh37, "X", 6455151, 8135053}}, false},
+		{"chr17:36459258-37856255", "del", "", Call{Type: Loss, Interval: Interval{GRCh38, "17", 36459258, 37856255}}, false},
+		{"22:100-200", "gain", "hg19", Call{Type: Gain, Interval: Interval{GRCh37, "22", 100, 200}}, false},
+		{"arr[GRCh38] 17q12(36459258_37856255)x2", "", "", Call{}, true},
+		{"arr[GRCh38] 17q12(36459258_37856255)x1", "duplication", "", Call{}, true},
+		{"arr[GRCh38] 17q12(36459258_37856255)x1", "", "GRCh37", Call{}, true},
+		{"chr17:36459258-37856255", "", "", Call{}, true},
+		{"chr17:200-100", "del", "", Call{}, true},
+		{"NM_000458.4:c.1del", "del", "", Call{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			call, err := Parse(tt.notation, tt.cnvType, tt.assembly)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidCNV), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			tt.want.Notation = tt.notation
+			assert.Equal(t, tt.want, *call)
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	assert.Equal(t, domain.PATHOGENIC, Classify(1.0))
+	assert.Equal(t, domain.PATHOGENIC, Classify(0.99))
+	assert.Equal(t, domain.LIKELY_PATHOGENIC, Classify(0.90))
+	assert.Equal(t, domain.VUS, Classify(0.89))
+	assert.Equal(t, domain.VUS, Classify(-0.89))
+	assert.Equal(t, domain.LIKELY_BENIGN, Classify(-0.90))
+	assert.Equal(t, domain.BENIGN, Classify(-0.99))
+	assert.Equal(t, domain.LIKELY_PATHOGENIC, Classify(0.45+0.45), "rounded before comparison")
+}
+
+func TestLoadDir(t *testing.T) {
+	annotations := testAnnotations(t)
+	assert.Equal(t, 12, annotations.Count(), "the unmapped curation is skipped")
+	assert.True(t, annotations.HasGenes(GRCh38))
+	assert.False(t, annotations.HasGenes(GRCh37))
+
+	// BED start 10000 is 1-based position 10001
+	genes := overlapping(annotations.genes, Interval{GRCh38, "1", 10001, 10001})
+	require.Len(t, genes, 1)
+	assert.Equal(t, "HIGENE", genes[0].Name)
+	assert.Empty(t, overlapping(annotations.genes, Interval{GRCh38, "1", 10000, 10000}))
+
+	dosage := overlapping(annotations.dosageGenes, Interval{GRCh38, "1", 80001, 80001})
+	require.Len(t, dosage, 1)
+	assert.Equal(t, DosageRecessive, dosage[0].Haploinsufficiency)
+	assert.Equal(t, DosageNotEvaluated, dosage[0].Triplosensitivity)
+
+	empty, err := LoadDir(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Zero(t, empty.Count())
+
+	dir := t.TempDir()
+	writeAnnotation(t, dir, GRCh38, "genes.bed", "chr1\t100\n")
+	_, err = LoadDir(dir)
+	assert.ErrorContains(t, err, "genes.bed line 1")
+}
+
+func TestEvaluate_Loss(t *testing.T) {
+	classifier := NewClassifier(testAnnotations(t))
+
+	tests := []struct {
+		name     string
+		interval string
+		codes    []string
+		total    float64
+		class    domain.Classification
+	}{
+		{"contains HI gene", "chr1:5000-25000", []string{"1A", "2A", "3A"}, 1.00, domain.PATHOGENIC},
+		{"contains HI region", "chr2:100001-200000", []string{"1A", "2A", "3A"}, 1.00, domain.PATHOGENIC},
+		{"partial HI region", "chr2:150001-250000", []string{"1A", "2B", "3A"}, 0, domain.VUS},
+		{"within benign loss", "chr4:2000-3000", []string{"1B", "2F", "3A"}, -1.60, domain.BENIGN},
+		{"no genes", "chr5:1000-2000", []string{"1B", "3A"}, -0.60, domain.VUS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call, err := Parse(tt.interval, "deletion", "")
+			require.NoError(t, err)
+
+			result, err := classifier.Evaluate(call, nil)
+			require.NoError(t, err)
+
+			var codes []string
+			for _, score := range result.Criteria {
+				codes = append(codes, score.Code)
+				assert.Equal(t, SourceAutomatic, score.Source)
+			}
+			assert.Equal(t, tt.codes, codes)
+			assert.InDelta(t, tt.total, result.TotalPoints, 0.001)
+			assert.Equal(t, tt.class, result.Classification)
+			assert.Len(t, result.Sections, 5)
+		})
+	}
+
+	// A breakpoint in a haploinsufficient gene is left to the curator
+	call, err := Parse("chr1:15000-30000", "deletion", "")
+	require.NoError(t, err)
+	result, err := classifier.Evaluate(call, nil)
+	require.NoError(t, err)
+	assert.Contains(t, result.Notes, "Breakpoint within haploinsufficient gene HIGENE: evaluate 2C to 2E")
+}
+
+func TestEvaluate_Gain(t *testing.T) {
+	classifier := NewClassifier(testAnnotations(t))
+
+	tests := []struct {
+		name     string
+		interval string
+		section2 string
+	}{
+		{"contains TS gene", "chr1:40000-70000", "2A"},
+		{"same genes as benign gain", "chr3:800-4500", "2C"},
+		{"within benign gain", "chr3:900-2500", "2D"},
+		{"within benign gain interrupting a gene", "chr3:1500-2500", "2E"},
+		{"larger than benign gain", "chr3:400-5500", "2F"},
+		{"overlaps benign gain with more genes", "chr3:3500-9000", "2G"},
+		{"contains HI gene", "chr1:5000-25000", "2H"},
+		{"breakpoint in HI gene", "chr1:15000-30000", "2J"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call, err := Parse(tt.interval, "duplication", "")
+			require.NoError(t, err)
+
+			result, err := classifier.Evaluate(call, nil)
+			require.NoError(t, err)
+
+			var section2 []string
+			for _, score := range result.Criteria {
+				if score.Section == 2 {
+					section2 = append(section2, score.Code)
+				}
+			}
+			assert.Equal(t, []string{tt.section2}, section2)
+		})
+	}
+}
+
+func TestEvaluate_SubmittedEvidence(t *testing.T) {
+	classifier := NewClassifier(testAnnotations(t))
+	call, err := Parse("arr[GRCh38] 1p36(40000_45000)x3", "", "")
+	require.NoError(t, err)
+
+	points := 0.45
+	result, err := classifier.Evaluate(call, []Evidence{
+		{Code: "4a", Points: &points, Note: "PMID 12345"},
+		{Code: "4B"},
+		{Code: "4C", Points: &points},
+		{Code: "3C"},
+	})
+	require.NoError(t, err)
+
+	// 1B (-0.60) + 3C (0.90, replacing 3A) + section 4 capped at 0.90
+	assert.InDelta(t, 1.20, result.TotalPoints, 0.001)
+	assert.Equal(t, domain.PATHOGENIC, result.Classification)
+	assert.InDelta(t, 0.90, result.Sections[3].Points, 0.001)
+	for _, score := range result.Criteria {
+		assert.NotEqual(t, "3A", score.Code, "a submitted section replaces the automatic score")
+	}
+	assert.Equal(t, "PMID 12345", result.Criteria[2].Detail)
+
+	tooMany := 1.5
+	invalid := [][]Evidence{
+		{{Code: "2K"}, {Code: "2K"}},
+		{{Code: "4A", Points: &tooMany}},
+		{{Code: "9Z"}},
+	}
+	for _, evidence := range invalid {
+		_, err := classifier.Evaluate(call, evidence)
+		assert.True(t, errors.Is(err, ErrInvalidCNV), "got %v", err)
+	}
+
+	// 2C-1 applies to losses only
+	_, err = classifier.Evaluate(call, []Evidence{{Code: "2c1"}})
+	assert.True(t, errors.Is(err, ErrInvalidCNV))
+}
+
+func TestEvaluate_WithoutAnnotations(t *testing.T) {
+	call, err := Parse("chr17:36459258-37856255", "loss", "")
+	require.NoError(t, err)
+
+	result, err := NewClassifier(nil).Evaluate(call, []Evidence{{Code: "2A"}, {Code: "1A"}})
+	require.NoError(t, err)
+	assert.InDelta(t, 1.00, result.TotalPoints, 0.001)
+	assert.Equal(t, domain.PATHOGENIC, result.Classification)
+	assert.Len(t, result.Notes, 2)
+}
+
+func TestLoadDir_Example(t *testing.T) {
+	annotations, err := LoadDir(filepath.Join("..", "..", "examples", "cnv"))
+	require.NoError(t, err)
+
+	call, err := Parse("arr[GRCh38] 17q12(36459258_37856255)x1", "", "")
+	require.NoError(t, err)
+	result, err := NewClassifier(annotations).Evaluate(call, nil)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PATHOGENIC, result.Classification)
+	assert.Equal(t, []string{"LHX1", "ACACA", "HNF1B"}, result.ProteinCodingGenes)
+}
@@ -0,0 +1,182 @@
+package cnv
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// criterion is one evidence category of the ClinGen CNV scoring scheme with
+// its suggested points and the range a curator may assign
+type criterion struct {
+	section     int
+	points      float64
+	min, max    float64
+	description string
+}
+
+// Sections of the scoring scheme
+var sectionNames = map[int]string{
+	1: "Initial assessment of genomic content",
+	2: "Overlap with established or predicted dosage sensitive or benign regions",
+	3: "Number of protein-coding genes",
+	4: "Detailed evaluation of genomic content using literature, databases and lab data",
+	5: "Evaluation of inheritance pattern and family history",
+}
+
+// sharedCriteria apply to both losses and gains (sections 1, 4 and 5)
+var sharedCriteria = map[string]criterion{
+	"1A": {1, 0, 0, 0, "Contains protein-coding or other known functionally important elements"},
+	"1B": {1, -0.60, -0.60, -0.60, "Does not contain protein-coding or any known functionally important elements"},
+
+	"4A": {4, 0.45, 0.15, 0.45, "Reported proband with a consistent phenotype and a confirmed de novo overlapping CNV"},
+	"4B": {4, 0.30, 0.15, 0.45, "Reported proband with a consistent phenotype and an assumed de novo overlapping CNV"},
+	"4C": {4, 0.15, 0, 0.45, "Reported proband with a consistent phenotype and an inherited overlapping CNV"},
+	"4D": {4, 0, -0.30, 0, "Reported proband with an overlapping CNV and an inconsistent phenotype"},
+	"4E": {4, 0.10, 0, 0.15, "Reported proband with a highly specific consistent phenotype, inheritance unknown"},
+	"4F": {4, 0.15, 0.15, 0.15, "3-4 observed segregations"},
+	"4G": {4, 0.30, 0.30, 0.30, "5-6 observed segregations"},
+	"4H": {4, 0.45, 0.45, 0.45, "7 or more observed segregations"},
+	"4I": {4, -0.45, -0.45, 0, "Variant not found in another affected family member (nonsegregation)"},
+	"4J": {4, -0.30, -0.30, 0, "Variant found in an unaffected family member (nonsegregation)"},
+	"4K": {4, -0.15, -0.30, 0, "Variant found in an unaffected family member, phenotype may be nonpenetrant"},
+	"4L": {4, 0.45, 0, 0.45, "Statistically significant increase in cases over controls"},
+	"4M": {4, 0.30, 0, 0.45, "Increase in cases over controls that is not statistically significant"},
+	"4N": {4, -0.90, -0.90, 0, "No statistically significant difference between cases and controls"},
+	"4O": {4, -1.00, -1.00, 0, "Overlap with common population variation"},
+
+	"5A": {5, 0, 0, 0, "De novo in the patient; score with 4A-4D"},
+	"5B": {5, -0.30, -0.45, 0, "Inherited from an unaffected parent, patient with a specific phenotype"},
+	"5C": {5, -0.15, -0.30, 0, "Inherited from an unaffected parent, patient with a nonspecific phenotype"},
+	"5D": {5, 0, 0, 0, "Segregates with a consistent phenotype in the family; score with 4F-4H"},
+	"5E": {5, 0, 0, 0, "Does not segregate with the phenotype in the family; score with 4I-4K"},
+	"5F": {5, 0, 0, 0, "Inheritance information unavailable or uninformative"},
+	"5G": {5, 0.10, 0, 0.15, "Inheritance unknown, nonspecific phenotype consistent with the region"},
+	"5H": {5, 0.30, 0, 0.30, "Inheritance unknown, highly specific phenotype consistent with the region"},
+}
+
+// lossCriteria are sections 2 and 3 for copy-number losses
+var lossCriteria = map[string]criterion{
+	"2A":   {2, 1.00, 1.00, 1.00, "Complete overlap of an established haploinsufficient gene or region"},
+	"2B":   {2, 0, 0, 0, "Partial overlap of an established haploinsufficient region, causative gene or critical region not included"},
+	"2C-1": {2, 0.90, 0.45, 1.00, "Partial overlap with the 5' end of an established haploinsufficient gene, coding sequence involved"},
+	"2C-2": {2, 0, 0, 0.45, "Partial overlap with the 5' end of an established haploinsufficient gene, only the 5' UTR involved"},
+	"2D-1": {2, 0, 0, 0, "Partial overlap with the 3' end of an established haploinsufficient gene, only the 3' UTR involved"},
+	"2D-2": {2, 0.90, 0.45, 0.90, "Only the last exon of an established haploinsufficient gene, with other pathogenic variants in it"},
+	"2D-3": {2, 0.30, 0, 0.45, "Only the last exon of an established haploinsufficient gene, no other pathogenic variants in it"},
+	"2D-4": {2, 0.90, 0.45, 1.00, "Partial overlap with the 3' end of an established haploinsufficient gene including other exons; NMD expected"},
+	"2E":   {2, 0.90, 0, 0.90, "Both breakpoints within the same gene; score as PVS1"},
+	"2F":   {2, -1.00, -1.00, -1.00, "Completely contained within an established benign CNV region"},
+	"2G":   {2, 0, 0, 0, "Overlaps an established benign CNV region but includes additional genomic material"},
+	"2H":   {2, 0.15, 0.15, 0.15, "Two or more haploinsufficiency predictors suggest at least one gene is haploinsufficient"},
+
+	"3A": {3, 0, 0, 0, "0-24 protein-coding genes"},
+	"3B": {3, 0.45, 0.45, 0.45, "25-34 protein-coding genes"},
+	"3C": {3, 0.90, 0.90, 0.90, "35 or more protein-coding genes"},
+}
+
+// gainCriteria are sections 2 and 3 for copy-number gains
+var gainCriteria = map[string]criterion{
+	"2A": {2, 1.00, 1.00, 1.00, "Complete overlap of an established triplosensitive gene or region"},
+	"2B": {2, 0, 0, 0, "Partial overlap of an established triplosensitive region, causative gene or critical region not included"},
+	"2C": {2, -1.00, -1.00, -1.00, "Identical in gene content to an established benign copy-number gain"},
+	"2D": {2, -1.00, -1.00, -1.00, "Smaller than an established benign gain, breakpoints do not interrupt protein-coding genes"},
+	"2E": {2, 0, 0, 0, "Smaller than an established benign gain, a breakpoint potentially interrupts a protein-coding gene"},
+	"2F": {2, -0.90, -1.00, 0, "Larger than an established benign gain without additional protein-coding genes"},
+	"2G": {2, 0, 0, 0, "Overlaps an established benign gain but includes additional genomic material"},
+	"2H": {2, 0, 0, 0, "Established haploinsufficient gene fully contained within the gain"},
+	"2I": {2, 0.90, 0, 0.90, "Both breakpoints within the same gene; score as PVS1"},
+	"2J": {2, 0, 0, 0, "One breakpoint within an established haploinsufficient gene, phenotype inconsistent or unknown"},
+	"2K": {2, 0.45, 0.45, 0.45, "One breakpoint within an established haploinsufficient gene, highly specific consistent phenotype"},
+	"2L": {2, 0, 0, 0, "One or both breakpoints within genes of no established clinical significance"},
+
+	"3A": {3, 0, 0, 0, "0-34 protein-coding genes"},
+	"3B": {3, 0.45, 0.45, 0.45, "35-49 protein-coding genes"},
+	"3C": {3, 0.90, 0.90, 0.90, "50 or more protein-coding genes"},
+}
+
+// section4Caps bound the combined points of related section 4 categories
+var section4Caps = []struct {
+	codes    []string
+	min, max float64
+}{
+	{[]string{"4A", "4B", "4C", "4D", "4E"}, -0.90, 0.90},
+	{[]string{"4F", "4G", "4H"}, 0, 0.45},
+	{[]string{"4I", "4J", "4K"}, -0.45, 0},
+	{[]string{"4L", "4M"}, 0, 0.45},
+	{[]string{"4N"}, -0.90, 0},
+	{[]string{"4O"}, -1.00, 0},
+}
+
+// lookupCriterion returns the evidence category for a code and CNV type
+func lookupCriterion(cnvType Type, code string) (criterion, bool) {
+	if c, ok := sharedCriteria[code]; ok {
+		return c, true
+	}
+	if cnvType == Gain {
+		c, ok := gainCriteria[code]
+		return c, ok
+	}
+	c, ok := lossCriteria[code]
+	return c, ok
+}
+
+// Codes lists the evidence codes that apply to a CNV type, in order
+func Codes(cnvType Type) []string {
+	table := lossCriteria
+	if cnvType == Gain {
+		table = gainCriteria
+	}
+	codes := make([]string, 0, len(sharedCriteria)+len(table))
+	for code := range sharedCriteria {
+		codes = append(codes, code)
+	}
+	for code := range table {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// normalizeCode upper-cases a code, accepting 2c1 or 2C_1 for 2C-1
+func normalizeCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "_", "-")
+	if len(code) == 3 && code[2] >= '0' && code[2] <= '9' {
+		code = code[:2] + "-" + code[2:]
+	}
+	return code
+}
+
+// Classification thresholds of the scoring scheme
+const (
+	pathogenicMinPoints       = 0.99
+	likelyPathogenicMinPoints = 0.90
+	likelyBenignMaxPoints     = -0.90
+	benignMaxPoints           = -0.99
+)
+
+// Classify maps a point total to a classification: 0.99 or more is
+// pathogenic, 0.90 to 0.98 likely pathogenic, -0.90 to -0.98 likely benign,
+// -0.99 or less benign, and anything between a VUS
+func Classify(points float64) domain.Classification {
+	points = round2(points)
+	switch {
+	case points >= pathogenicMinPoints:
+		return domain.PATHOGENIC
+	case points >= likelyPathogenicMinPoints:
+		return domain.LIKELY_PATHOGENIC
+	case points <= benignMaxPoints:
+		return domain.BENIGN
+	case points <= likelyBenignMaxPoints:
+		return domain.LIKELY_BENIGN
+	default:
+		return domain.VUS
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
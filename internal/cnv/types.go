@@ -0,0 +1,183 @@
+// Package cnv classifies copy-number variants with the ACMG/ClinGen 2019
+// technical standards (Riggs et al., Genet Med 2020). Deletions and
+// duplications are scored on the five sections of the ClinGen scoring
+// scheme: genomic content, overlap with established dosage sensitive or
+// benign regions, gene count, case and population evidence, and inheritance.
+// Sections 1 to 3 are scored from locally bundled gene and ClinGen dosage
+// annotations where possible; the rest is submitted by the curator.
+package cnv
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Type is the direction of a copy-number change
+type Type string
+
+const (
+	// Loss is a deletion: fewer copies than expected
+	Loss Type = "deletion"
+	// Gain is a duplication or higher-order amplification
+	Gain Type = "duplication"
+)
+
+// Supported reference assemblies
+const (
+	GRCh37 = "GRCh37"
+	GRCh38 = "GRCh38"
+)
+
+// ErrInvalidCNV is returned for a CNV that cannot be parsed or scored
+var ErrInvalidCNV = errors.New("invalid CNV")
+
+// ParseType accepts deletion, loss, del, duplication, gain or dup in any case
+func ParseType(s string) (Type, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "deletion", "loss", "del", "copy_number_loss":
+		return Loss, nil
+	case "duplication", "gain", "dup", "copy_number_gain":
+		return Gain, nil
+	}
+	return "", fmt.Errorf("%w: unknown CNV type %q (use deletion or duplication)", ErrInvalidCNV, s)
+}
+
+// ParseAssembly accepts GRCh37/hg19 and GRCh38/hg38 in any case
+func ParseAssembly(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "grch38", "hg38":
+		return GRCh38, nil
+	case "grch37", "hg19":
+		return GRCh37, nil
+	}
+	return "", fmt.Errorf("%w: unsupported assembly %q (use GRCh37 or GRCh38)", ErrInvalidCNV, s)
+}
+
+// Interval is a 1-based inclusive genomic interval
+type Interval struct {
+	Assembly   string `json:"assembly"`
+	Chromosome string `json:"chromosome"` // Without "chr" prefix, e.g. 17, X
+	Start      int64  `json:"start"`
+	End        int64  `json:"end"`
+}
+
+// Length returns the number of bases in the interval
+func (i Interval) Length() int64 {
+	return i.End - i.Start + 1
+}
+
+// String formats the interval as chr:start-end
+func (i Interval) String() string {
+	return fmt.Sprintf("chr%s:%d-%d", i.Chromosome, i.Start, i.End)
+}
+
+// contains reports whether the interval wholly contains another on the same chromosome
+func (i Interval) contains(o Interval) bool {
+	return i.Chromosome == o.Chromosome && i.Start <= o.Start && o.End <= i.End
+}
+
+// overlaps reports whether two intervals share at least one base
+func (i Interval) overlaps(o Interval) bool {
+	return i.Chromosome == o.Chromosome && i.Start <= o.End && o.Start <= i.End
+}
+
+// Call is a parsed copy-number variant
+type Call struct {
+	Type       Type     `json:"type"`
+	Interval   Interval `json:"interval"`
+	CopyNumber int      `json:"copy_number,omitempty"` // From ISCN notation; 0 when not given
+	Notation   string   `json:"notation,omitempty"`    // Input as given
+}
+
+var (
+	// arr[GRCh38] 17q12(36459258_37856255)x1, also seq[...] and hg19/hg38
+	iscnPattern = regexp.MustCompile(`(?i)^(?:arr|seq)\s*\[\s*(GRCh3[78]|hg19|hg38)\s*\]\s*([0-9]{1,2}|X|Y)([pq][0-9.]*(?:[pq][0-9.]*)?)?\s*\(\s*([0-9,]+)\s*_\s*([0-9,]+)\s*\)\s*x\s*([0-9]+)$`)
+	// chr17:36459258-37856255, with optional thousands separators
+	intervalPattern = regexp.MustCompile(`(?i)^(?:chr)?([0-9]{1,2}|X|Y):([0-9,]+)-([0-9,]+)$`)
+)
+
+// Parse reads a CNV given in ISCN microarray or sequencing nomenclature, e.g.
+// "arr[GRCh38] 17q12(36459258_37856255)x1", or as a genomic interval, e.g.
+// "chr17:36459258-37856255". ISCN gives the assembly and, through the copy
+// number, the type; for an interval both come from the arguments. A
+// non-empty cnvType must agree with the ISCN copy number.
+func Parse(notation, cnvType, assembly string) (*Call, error) {
+	notation = strings.TrimSpace(notation)
+	call := &Call{Notation: notation}
+
+	var chromosome, start, end string
+	if m := iscnPattern.FindStringSubmatch(notation); m != nil {
+		parsedAssembly, err := ParseAssembly(m[1])
+		if err != nil {
+			return nil, err
+		}
+		call.Interval.Assembly = parsedAssembly
+		chromosome, start, end = m[2], m[4], m[5]
+		call.CopyNumber, _ = strconv.Atoi(m[6])
+		switch {
+		case call.CopyNumber < 2:
+			call.Type = Loss
+		case call.CopyNumber > 2:
+			call.Type = Gain
+		default:
+			return nil, fmt.Errorf("%w: copy number 2 is not a copy-number change", ErrInvalidCNV)
+		}
+	} else if m := intervalPattern.FindStringSubmatch(notation); m != nil {
+		chromosome, start, end = m[1], m[2], m[3]
+	} else {
+		return nil, fmt.Errorf("%w: %q is neither ISCN (arr[GRCh38] 17q12(36459258_37856255)x1) nor an interval (chr17:36459258-37856255)", ErrInvalidCNV, notation)
+	}
+
+	if cnvType != "" {
+		parsed, err := ParseType(cnvType)
+		if err != nil {
+			return nil, err
+		}
+		if call.Type != "" && call.Type != parsed {
+			return nil, fmt.Errorf("%w: copy number x%d is a %s, not a %s", ErrInvalidCNV, call.CopyNumber, call.Type, parsed)
+		}
+		call.Type = parsed
+	}
+	if call.Type == "" {
+		return nil, fmt.Errorf("%w: the CNV type is required for an interval", ErrInvalidCNV)
+	}
+
+	if call.Interval.Assembly == "" || assembly != "" {
+		if assembly == "" {
+			assembly = GRCh38
+		}
+		parsed, err := ParseAssembly(assembly)
+		if err != nil {
+			return nil, err
+		}
+		if call.Interval.Assembly != "" && call.Interval.Assembly != parsed {
+			return nil, fmt.Errorf("%w: notation is on %s, not %s", ErrInvalidCNV, call.Interval.Assembly, parsed)
+		}
+		call.Interval.Assembly = parsed
+	}
+
+	call.Interval.Chromosome = normalizeChromosome(chromosome)
+	call.Interval.Start, _ = strconv.ParseInt(strings.ReplaceAll(start, ",", ""), 10, 64)
+	call.Interval.End, _ = strconv.ParseInt(strings.ReplaceAll(end, ",", ""), 10, 64)
+	if call.Interval.Start <= 0 || call.Interval.End < call.Interval.Start {
+		return nil, fmt.Errorf("%w: interval %s is empty or reversed", ErrInvalidCNV, call.Interval)
+	}
+	return call, nil
+}
+
+// normalizeChromosome strips the chr prefix and maps numeric sex chromosomes
+func normalizeChromosome(chrom string) string {
+	chrom = strings.TrimPrefix(strings.TrimPrefix(chrom, "chr"), "Chr")
+	switch strings.ToUpper(chrom) {
+	case "23":
+		return "X"
+	case "24":
+		return "Y"
+	case "M", "MT":
+		return "MT"
+	}
+	return strings.ToUpper(chrom)
+}
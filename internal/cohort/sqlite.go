@@ -0,0 +1,281 @@
+package cohort
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite cohort observation store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables and indexes.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS cohort_observations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		proband_id TEXT NOT NULL,
+		normalized_hgvs TEXT NOT NULL,
+		zygosity TEXT NOT NULL,
+		gene_symbol TEXT DEFAULT '',
+		classification TEXT DEFAULT '',
+		hpo_terms TEXT,
+		observed_at DATETIME NOT NULL,
+		UNIQUE(proband_id, normalized_hgvs)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_cohort_variant ON cohort_observations(normalized_hgvs);
+	`
+
+	if _, err := db.Exec(schema); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "cohort_observations", "gene_symbol", "TEXT DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "cohort_observations", "classification", "TEXT DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(db, "cohort_observations", "hpo_terms", "TEXT"); err != nil {
+		return err
+	}
+	_, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_cohort_gene ON cohort_observations(gene_symbol)`)
+	return err
+}
+
+// addColumnIfMissing upgrades a table created before the column was added
+func addColumnIfMissing(db *sql.DB, table, column, definition string) error {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+// Record saves an observation, deduplicated by proband and variant.
+func (s *SQLiteStore) Record(ctx context.Context, obs *Observation) (bool, error) {
+	if err := obs.Validate(); err != nil {
+		return false, err
+	}
+	if obs.ObservedAt.IsZero() {
+		obs.ObservedAt = time.Now()
+	}
+	var hpoTerms interface{} // NULL when the phenotype is not given
+	if len(obs.HPOTerms) > 0 {
+		encoded, err := json.Marshal(obs.HPOTerms)
+		if err != nil {
+			return false, fmt.Errorf("failed to encode HPO terms: %w", err)
+		}
+		hpoTerms = string(encoded)
+	}
+
+	var existing int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id FROM cohort_observations WHERE proband_id = ? AND normalized_hgvs = ?`,
+		obs.ProbandID, obs.NormalizedHGVS).Scan(&existing)
+	switch {
+	case err == sql.ErrNoRows:
+		result, err := s.db.ExecContext(ctx, `
+			INSERT INTO cohort_observations (proband_id, normalized_hgvs, zygosity, gene_symbol, classification, hpo_terms, observed_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			obs.ProbandID, obs.NormalizedHGVS, string(obs.Zygosity), obs.GeneSymbol, obs.Classification, hpoTerms, obs.ObservedAt)
+		if err != nil {
+			return false, fmt.Errorf("failed to insert observation: %w", err)
+		}
+		obs.ID, _ = result.LastInsertId()
+		return true, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to check existing observation: %w", err)
+	}
+
+	// Same proband seen again: keep one observation with the latest zygosity
+	// call, and the latest gene, classification and phenotype given
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE cohort_observations SET zygosity = ?, observed_at = ?,
+			gene_symbol = CASE WHEN ? = '' THEN gene_symbol ELSE ? END,
+			classification = CASE WHEN ? = '' THEN classification ELSE ? END,
+			hpo_terms = COALESCE(?, hpo_terms)
+		WHERE id = ?`,
+		string(obs.Zygosity), obs.ObservedAt, obs.GeneSymbol, obs.GeneSymbol,
+		obs.Classification, obs.Classification, hpoTerms, existing); err != nil {
+		return false, fmt.Errorf("failed to update observation: %w", err)
+	}
+	obs.ID = existing
+	return false, nil
+}
+
+// Frequency computes the cohort frequency of a variant.
+func (s *SQLiteStore) Frequency(ctx context.Context, normalizedHGVS string) (*Frequency, error) {
+	cohortSize, err := s.Size(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Frequency{NormalizedHGVS: normalizedHGVS}
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*),
+			COALESCE(SUM(CASE WHEN zygosity = 'homozygous' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN zygosity = 'homozygous' THEN 2 ELSE 1 END), 0)
+		FROM cohort_observations WHERE normalized_hgvs = ?`,
+		normalizedHGVS).Scan(&f.Carriers, &f.Homozygotes, &f.AlleleCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute cohort frequency: %w", err)
+	}
+
+	f.fill(cohortSize)
+	return f, nil
+}
+
+// Recurrent returns variants carried by at least minCarriers probands, most frequent first.
+func (s *SQLiteStore) Recurrent(ctx context.Context, minCarriers, limit int) ([]*Frequency, error) {
+	cohortSize, err := s.Size(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT normalized_hgvs, COUNT(*) AS carriers,
+			SUM(CASE WHEN zygosity = 'homozygous' THEN 1 ELSE 0 END),
+			SUM(CASE WHEN zygosity = 'homozygous' THEN 2 ELSE 1 END)
+		FROM cohort_observations
+		GROUP BY normalized_hgvs
+		HAVING COUNT(*) >= ?
+		ORDER BY carriers DESC, normalized_hgvs
+		LIMIT ?`,
+		minCarriers, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurrent variants: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*Frequency
+	for rows.Next() {
+		f := &Frequency{}
+		if err := rows.Scan(&f.NormalizedHGVS, &f.Carriers, &f.Homozygotes, &f.AlleleCount); err != nil {
+			return nil, fmt.Errorf("failed to scan recurrent variant: %w", err)
+		}
+		f.fill(cohortSize)
+		results = append(results, f)
+	}
+	return results, rows.Err()
+}
+
+// Cases returns the observations of variants in a gene, ordered by proband.
+func (s *SQLiteStore) Cases(ctx context.Context, geneSymbol string) ([]*Observation, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, proband_id, normalized_hgvs, zygosity, gene_symbol, classification, hpo_terms, observed_at
+		FROM cohort_observations
+		WHERE gene_symbol = ?
+		ORDER BY proband_id, normalized_hgvs`,
+		strings.ToUpper(strings.TrimSpace(geneSymbol)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query gene cases: %w", err)
+	}
+	defer rows.Close()
+
+	var cases []*Observation
+	for rows.Next() {
+		obs := &Observation{}
+		var zygosity string
+		var hpoTerms sql.NullString
+		if err := rows.Scan(&obs.ID, &obs.ProbandID, &obs.NormalizedHGVS, &zygosity, &obs.GeneSymbol,
+			&obs.Classification, &hpoTerms, &obs.ObservedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan case: %w", err)
+		}
+		obs.Zygosity = Zygosity(zygosity)
+		if hpoTerms.Valid && hpoTerms.String != "" {
+			if err := json.Unmarshal([]byte(hpoTerms.String), &obs.HPOTerms); err != nil {
+				return nil, fmt.Errorf("failed to decode HPO terms: %w", err)
+			}
+		}
+		cases = append(cases, obs)
+	}
+	return cases, rows.Err()
+}
+
+// Close closes the database connection.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// Size returns the number of distinct probands with any recorded observation.
+func (s *SQLiteStore) Size(ctx context.Context) (int, error) {
+	var size int
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(DISTINCT proband_id) FROM cohort_observations`).Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to count cohort: %w", err)
+	}
+	return size, nil
+}
+
+// fill derives the allele number and fractions from the counts
+func (f *Frequency) fill(cohortSize int) {
+	f.CohortSize = cohortSize
+	f.AlleleNumber = 2 * cohortSize
+	if cohortSize > 0 {
+		f.AlleleFrequency = float64(f.AlleleCount) / float64(f.AlleleNumber)
+		f.CarrierFraction = float64(f.Carriers) / float64(cohortSize)
+	}
+}
@@ -0,0 +1,147 @@
+package cohort
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "cohort.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSQLiteStore_RecordDeduplicatesByProband(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	hgvs := "NM_000492.4:c.1521_1523del"
+
+	isNew, err := store.Record(ctx, &Observation{ProbandID: "P1", NormalizedHGVS: hgvs})
+	require.NoError(t, err)
+	assert.True(t, isNew)
+
+	// Retesting the same proband updates rather than double-counts
+	isNew, err = store.Record(ctx, &Observation{ProbandID: "P1", NormalizedHGVS: hgvs, Zygosity: Homozygous})
+	require.NoError(t, err)
+	assert.False(t, isNew)
+
+	_, err = store.Record(ctx, &Observation{ProbandID: "P2", NormalizedHGVS: hgvs})
+	require.NoError(t, err)
+	_, err = store.Record(ctx, &Observation{ProbandID: "P3", NormalizedHGVS: "NM_007294.4:c.5266dup"})
+	require.NoError(t, err)
+
+	f, err := store.Frequency(ctx, hgvs)
+	require.NoError(t, err)
+	assert.Equal(t, 2, f.Carriers)
+	assert.Equal(t, 1, f.Homozygotes)
+	assert.Equal(t, 3, f.AlleleCount)
+	assert.Equal(t, 3, f.CohortSize)
+	assert.Equal(t, 6, f.AlleleNumber)
+	assert.InDelta(t, 0.5, f.AlleleFrequency, 1e-9)
+	assert.InDelta(t, 2.0/3.0, f.CarrierFraction, 1e-9)
+}
+
+func TestSQLiteStore_FrequencyUnseenVariant(t *testing.T) {
+	store := createTestStore(t)
+
+	f, err := store.Frequency(context.Background(), "NM_000546.6:c.743G>A")
+
+	require.NoError(t, err)
+	assert.Zero(t, f.Carriers)
+	assert.Zero(t, f.AlleleFrequency)
+}
+
+func TestSQLiteStore_RecordInvalid(t *testing.T) {
+	store := createTestStore(t)
+
+	_, err := store.Record(context.Background(), &Observation{NormalizedHGVS: "NM_000546.6:c.743G>A"})
+	assert.True(t, errors.Is(err, ErrInvalidObservation))
+
+	_, err = store.Record(context.Background(), &Observation{ProbandID: "P1", NormalizedHGVS: "NM_000546.6:c.743G>A", Zygosity: "mosaic"})
+	assert.True(t, errors.Is(err, ErrInvalidObservation))
+}
+
+func TestSQLiteStore_Recurrent(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	artifact := "NM_000059.4:c.68-7T>A"
+
+	for i := 0; i < 10; i++ {
+		proband := fmt.Sprintf("P%d", i)
+		_, err := store.Record(ctx, &Observation{ProbandID: proband, NormalizedHGVS: fmt.Sprintf("NM_000001.1:c.%dA>G", i+1)})
+		require.NoError(t, err)
+		if i < 6 {
+			_, err = store.Record(ctx, &Observation{ProbandID: proband, NormalizedHGVS: artifact})
+			require.NoError(t, err)
+		}
+	}
+
+	recurrent, err := store.Recurrent(ctx, 2, 10)
+
+	require.NoError(t, err)
+	require.Len(t, recurrent, 1)
+	assert.Equal(t, artifact, recurrent[0].NormalizedHGVS)
+	assert.Equal(t, 6, recurrent[0].Carriers)
+	assert.Equal(t, 10, recurrent[0].CohortSize)
+}
+
+func TestSQLiteStore_Cases(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	_, err := store.Record(ctx, &Observation{ProbandID: "P2", NormalizedHGVS: "NM_007294.4:c.5266dup", GeneSymbol: "brca1",
+		Classification: "PATHOGENIC", HPOTerms: []string{"HP:0003002"}})
+	require.NoError(t, err)
+	_, err = store.Record(ctx, &Observation{ProbandID: "P1", NormalizedHGVS: "NM_007294.4:c.5123C>A", GeneSymbol: "BRCA1", Classification: "VUS"})
+	require.NoError(t, err)
+	_, err = store.Record(ctx, &Observation{ProbandID: "P3", NormalizedHGVS: "NM_000492.4:c.1521_1523del", GeneSymbol: "CFTR"})
+	require.NoError(t, err)
+
+	// Retesting without a phenotype keeps the one recorded
+	_, err = store.Record(ctx, &Observation{ProbandID: "P2", NormalizedHGVS: "NM_007294.4:c.5266dup", Zygosity: Homozygous})
+	require.NoError(t, err)
+
+	cases, err := store.Cases(ctx, "BRCA1")
+	require.NoError(t, err)
+	require.Len(t, cases, 2)
+	assert.Equal(t, "P1", cases[0].ProbandID)
+	assert.Empty(t, cases[0].HPOTerms)
+	assert.Equal(t, "P2", cases[1].ProbandID)
+	assert.Equal(t, "BRCA1", cases[1].GeneSymbol)
+	assert.Equal(t, "PATHOGENIC", cases[1].Classification)
+	assert.Equal(t, []string{"HP:0003002"}, cases[1].HPOTerms)
+	assert.Equal(t, Homozygous, cases[1].Zygosity)
+
+	size, err := store.Size(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, size)
+}
+
+func TestArtifactCriteria_Assess(t *testing.T) {
+	criteria := ArtifactCriteria{MinCohortSize: 10, MinCarrierFraction: 0.1, PopulationRatio: 10}
+
+	recurrent := &Frequency{Carriers: 6, CohortSize: 20, AlleleCount: 6, AlleleNumber: 40, AlleleFrequency: 0.15, CarrierFraction: 0.3}
+	criteria.Assess(recurrent, 0)
+	assert.True(t, recurrent.SuspectArtifact)
+	assert.Contains(t, recurrent.ArtifactRationale, "6 of 20 probands")
+
+	// Common in the population too: not an artifact
+	criteria.Assess(recurrent, 0.1)
+	assert.False(t, recurrent.SuspectArtifact)
+
+	// Rare in the population: artifact, with the ratio reported
+	criteria.Assess(recurrent, 0.001)
+	assert.True(t, recurrent.SuspectArtifact)
+	assert.Contains(t, recurrent.ArtifactRationale, "150x")
+
+	small := &Frequency{Carriers: 3, CohortSize: 5, CarrierFraction: 0.6}
+	criteria.Assess(small, 0)
+	assert.False(t, small.SuspectArtifact)
+}
@@ -0,0 +1,151 @@
+// Package cohort maintains an in-house allele frequency computed from the
+// deployment's own classified cases. Observations are deduplicated by proband,
+// so repeat testing or reclassification of the same patient counts once.
+// The cohort frequency is supplementary evidence only; it also flags variants
+// recurring across unrelated probands far more often than expected, which are
+// often artifacts of the lab's own sequencing pipeline.
+package cohort
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidObservation is returned when an observation is missing required fields.
+var ErrInvalidObservation = errors.New("invalid cohort observation")
+
+// Zygosity of an observed variant.
+type Zygosity string
+
+const (
+	Heterozygous Zygosity = "heterozygous"
+	Homozygous   Zygosity = "homozygous"
+	Hemizygous   Zygosity = "hemizygous"
+)
+
+// IsValid reports whether the zygosity is supported.
+func (z Zygosity) IsValid() bool {
+	switch z {
+	case Heterozygous, Homozygous, Hemizygous:
+		return true
+	}
+	return false
+}
+
+// Observation records that a proband carries a variant. The gene,
+// classification and phenotype, when known, let cases be matched by gene and
+// phenotype; a later observation without them keeps the earlier values.
+type Observation struct {
+	ID             int64     `json:"id,omitempty"`
+	ProbandID      string    `json:"proband_id"`
+	NormalizedHGVS string    `json:"normalized_hgvs"`
+	Zygosity       Zygosity  `json:"zygosity"`
+	GeneSymbol     string    `json:"gene_symbol,omitempty"`
+	Classification string    `json:"classification,omitempty"`
+	HPOTerms       []string  `json:"hpo_terms,omitempty"` // Proband's phenotype
+	ObservedAt     time.Time `json:"observed_at"`
+}
+
+// Validate normalizes the observation and checks required fields.
+// An empty zygosity defaults to heterozygous.
+func (o *Observation) Validate() error {
+	o.ProbandID = strings.TrimSpace(o.ProbandID)
+	o.NormalizedHGVS = strings.TrimSpace(o.NormalizedHGVS)
+	o.GeneSymbol = strings.ToUpper(strings.TrimSpace(o.GeneSymbol))
+	o.Classification = strings.TrimSpace(o.Classification)
+	o.Zygosity = Zygosity(strings.ToLower(strings.TrimSpace(string(o.Zygosity))))
+	if o.Zygosity == "" {
+		o.Zygosity = Heterozygous
+	}
+
+	if o.ProbandID == "" {
+		return fmt.Errorf("%w: proband ID is required", ErrInvalidObservation)
+	}
+	if o.NormalizedHGVS == "" {
+		return fmt.Errorf("%w: variant is required", ErrInvalidObservation)
+	}
+	if !o.Zygosity.IsValid() {
+		return fmt.Errorf("%w: unknown zygosity %q", ErrInvalidObservation, o.Zygosity)
+	}
+	return nil
+}
+
+// Frequency is the cohort allele frequency of a variant.
+// The allele number assumes two alleles per proband in the cohort.
+type Frequency struct {
+	NormalizedHGVS    string  `json:"normalized_hgvs"`
+	Carriers          int     `json:"carriers"` // Distinct probands carrying the variant
+	Homozygotes       int     `json:"homozygotes"`
+	AlleleCount       int     `json:"allele_count"`
+	CohortSize        int     `json:"cohort_size"` // Distinct probands with any recorded observation
+	AlleleNumber      int     `json:"allele_number"`
+	AlleleFrequency   float64 `json:"allele_frequency"`
+	CarrierFraction   float64 `json:"carrier_fraction"`
+	PopulationAF      float64 `json:"population_af,omitempty"` // Population frequency used for the artifact check, if known
+	SuspectArtifact   bool    `json:"suspect_artifact"`
+	ArtifactRationale string  `json:"artifact_rationale,omitempty"`
+}
+
+// ArtifactCriteria decides when a recurrent variant is flagged as a suspected pipeline artifact.
+type ArtifactCriteria struct {
+	MinCohortSize      int     // Cohort must have at least this many probands
+	MinCarrierFraction float64 // Fraction of probands carrying the variant
+	PopulationRatio    float64 // Cohort AF must exceed population AF by this factor, when population AF is known
+}
+
+// DefaultArtifactCriteria returns the default artifact criteria.
+func DefaultArtifactCriteria() ArtifactCriteria {
+	return ArtifactCriteria{
+		MinCohortSize:      50,
+		MinCarrierFraction: 0.05,
+		PopulationRatio:    10,
+	}
+}
+
+// Assess flags the frequency as a suspected artifact. Unrelated referrals
+// rarely share a variant at a high rate unless the pipeline introduces it;
+// a population frequency, when given, must also be far below the cohort's.
+func (c ArtifactCriteria) Assess(f *Frequency, populationAF float64) {
+	f.PopulationAF = populationAF
+	f.SuspectArtifact = false
+	f.ArtifactRationale = ""
+
+	if f.CohortSize < c.MinCohortSize || f.CarrierFraction < c.MinCarrierFraction {
+		return
+	}
+	if populationAF > 0 && f.AlleleFrequency < populationAF*c.PopulationRatio {
+		return
+	}
+
+	f.SuspectArtifact = true
+	f.ArtifactRationale = fmt.Sprintf("Carried by %d of %d probands (%.1f%%)", f.Carriers, f.CohortSize, f.CarrierFraction*100)
+	if populationAF > 0 {
+		f.ArtifactRationale += fmt.Sprintf(", %.0fx the population frequency", f.AlleleFrequency/populationAF)
+	}
+	f.ArtifactRationale += "; review read-level evidence for a recurrent pipeline artifact"
+}
+
+// Store defines the interface for cohort observation storage.
+type Store interface {
+	// Record saves an observation, replacing any earlier observation of the
+	// same variant in the same proband. It reports whether the pair is new.
+	Record(ctx context.Context, obs *Observation) (bool, error)
+
+	// Frequency computes the cohort frequency of a variant.
+	Frequency(ctx context.Context, normalizedHGVS string) (*Frequency, error)
+
+	// Recurrent returns variants carried by at least minCarriers probands, most frequent first.
+	Recurrent(ctx context.Context, minCarriers, limit int) ([]*Frequency, error)
+
+	// Cases returns the observations of variants in a gene, ordered by proband.
+	Cases(ctx context.Context, geneSymbol string) ([]*Observation, error)
+
+	// Size returns the number of distinct probands with any recorded observation.
+	Size(ctx context.Context) (int, error)
+
+	// Close closes the store.
+	Close() error
+}
@@ -2,12 +2,27 @@ package config
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/acmg-amp-mcp-server/internal/calibration"
+	"github.com/acmg-amp-mcp-server/internal/conflicts"
 	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/phenotype"
+	"github.com/acmg-amp-mcp-server/internal/phi"
+	"github.com/acmg-amp-mcp-server/internal/proteindomains"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+	"github.com/acmg-amp-mcp-server/internal/weighting"
 	"github.com/spf13/viper"
 )
 
+// Sources of transcript consequences selectable with
+// classification.consequence_annotator
+const (
+	ConsequenceAnnotatorInternal = "internal" // Annotated from the transcript GTF by the HGVS normalizer
+	ConsequenceAnnotatorVEP      = "vep"      // Ensembl VEP REST API, falling back to internal annotation
+)
+
 // Manager implements the ConfigManager interface using Viper
 type Manager struct {
 	config *domain.Config
@@ -88,18 +103,74 @@ func (m *Manager) setDefaults() {
 	viper.SetDefault("external_api.gnomad.timeout", "30s")
 	viper.SetDefault("external_api.gnomad.rate_limit", 10)
 	viper.SetDefault("external_api.gnomad.retry_count", 3)
+	viper.SetDefault("external_api.gnomad.dataset", "gnomad_r4")
 
 	viper.SetDefault("external_api.cosmic.base_url", "https://cancer.sanger.ac.uk/cosmic/")
 	viper.SetDefault("external_api.cosmic.timeout", "30s")
 	viper.SetDefault("external_api.cosmic.rate_limit", 10)
 	viper.SetDefault("external_api.cosmic.retry_count", 3)
 
+	// Splicing predictions are disabled until a lookup API or scores file is set
+	viper.SetDefault("external_api.splicing.base_url", "")
+	viper.SetDefault("external_api.splicing.scores_file", "")
+	viper.SetDefault("external_api.splicing.tools", []string{"SpliceAI", "Pangolin"})
+	viper.SetDefault("external_api.splicing.genome", "38")
+	viper.SetDefault("external_api.splicing.distance", 500)
+	viper.SetDefault("external_api.splicing.timeout", "60s")
+	viper.SetDefault("external_api.splicing.rate_limit", 2)
+	viper.SetDefault("external_api.dbnsfp.file", "")
+	viper.SetDefault("external_api.constraint.file", "")
+
+	// ClinGen gene-disease validity and dosage curations (PVS1)
+	viper.SetDefault("external_api.clingen.base_url", "https://genegraph.clinicalgenome.org/api")
+	viper.SetDefault("external_api.clingen.timeout", "30s")
+	viper.SetDefault("external_api.clingen.rate_limit", 5)
+
+	// Somatic tiering evidence; OncoKB is used only with an API token
+	viper.SetDefault("external_api.civic.base_url", "https://civicdb.org/api/graphql")
+	viper.SetDefault("external_api.civic.timeout", "30s")
+	viper.SetDefault("external_api.civic.rate_limit", 5)
+	viper.SetDefault("external_api.oncokb.base_url", "https://www.oncokb.org/api/v1")
+	viper.SetDefault("external_api.oncokb.token", "")
+	viper.SetDefault("external_api.oncokb.timeout", "30s")
+	viper.SetDefault("external_api.oncokb.rate_limit", 5)
+
+	// Gene-disease associations; OMIM is used only with an API key
+	viper.SetDefault("external_api.omim.base_url", "https://api.omim.org/api")
+	viper.SetDefault("external_api.omim.api_key", "")
+	viper.SetDefault("external_api.omim.timeout", "30s")
+	viper.SetDefault("external_api.omim.rate_limit", 4)
+	viper.SetDefault("external_api.orphanet.dir", "")
+
+	// Evidence sources missing their deadline leave a classification provisional
+	viper.SetDefault("external_api.source_deadline", "20s")
+
+	// Pooled HTTP/2 transport shared by the external API clients
+	viper.SetDefault("external_api.http.max_idle_conns", 100)
+	viper.SetDefault("external_api.http.max_idle_conns_per_host", 16)
+	viper.SetDefault("external_api.http.max_conns_per_host", 32)
+	viper.SetDefault("external_api.http.idle_conn_timeout", "90s")
+	viper.SetDefault("external_api.http.disable_http2", false)
+	viper.SetDefault("external_api.http.max_retries", 2)
+	viper.SetDefault("external_api.http.retry_base_delay", "250ms")
+	viper.SetDefault("external_api.http.retry_max_delay", "10s")
+
+	// Ensembl VEP, used only when selected as the consequence annotator
+	viper.SetDefault("external_api.vep.base_url", "https://rest.ensembl.org")
+	viper.SetDefault("external_api.vep.transcripts", "ensembl")
+	viper.SetDefault("external_api.vep.plugins", []string{})
+	viper.SetDefault("external_api.vep.timeout", "30s")
+	viper.SetDefault("external_api.vep.rate_limit", 15)
+
 	// Cache defaults
 	viper.SetDefault("cache.redis_url", "redis://localhost:6379")
 	viper.SetDefault("cache.default_ttl", "24h")
 	viper.SetDefault("cache.max_retries", 3)
 	viper.SetDefault("cache.pool_size", 10)
 	viper.SetDefault("cache.pool_timeout", "4s")
+	viper.SetDefault("cache.max_entries", 10000)
+	viper.SetDefault("cache.max_bytes", 0)
+	viper.SetDefault("cache.spill_path", "")
 
 	// Logging defaults
 	viper.SetDefault("logging.level", "info")
@@ -109,6 +180,36 @@ func (m *Manager) setDefaults() {
 	viper.SetDefault("logging.max_backups", 3)
 	viper.SetDefault("logging.max_age", 28)
 	viper.SetDefault("logging.compress", true)
+	viper.SetDefault("logging.phi_safe_logging", false)
+	viper.SetDefault("logging.phi_redaction_mode", "redact")
+
+	// MCP defaults
+	viper.SetDefault("mcp.max_response_bytes_stdio", 256*1024)
+	viper.SetDefault("mcp.max_response_bytes_http", 4*1024*1024)
+	viper.SetDefault("mcp.batch_classify_limit", 500)
+	viper.SetDefault("mcp.batch_classify_workers", 8)
+	viper.SetDefault("mcp.locale", "en")
+	viper.SetDefault("mcp.evidence_mock_fallback", false)
+	viper.SetDefault("mcp.rate_limit_rps", 10)
+	viper.SetDefault("mcp.rate_limit_burst", 20)
+	viper.SetDefault("mcp.daily_quota", 0)
+
+	// Classification defaults
+	viper.SetDefault("classification.frequency_thresholds_file", "")
+	viper.SetDefault("classification.conflict_policies_file", "")
+	viper.SetDefault("classification.weighting_policy_file", "")
+	viper.SetDefault("classification.predictor_calibration_file", "")
+	viper.SetDefault("classification.protein_domain_dir", "")
+	viper.SetDefault("classification.hpo_dir", "")
+	viper.SetDefault("classification.consequence_annotator", ConsequenceAnnotatorInternal)
+	viper.SetDefault("classification.result_cache_size", 1000)
+	viper.SetDefault("classification.result_cache_ttl", "1h")
+
+	// Auth defaults
+	viper.SetDefault("auth.jwt_secret", "")
+	viper.SetDefault("auth.jwt_issuer", "")
+	viper.SetDefault("auth.jwt_audience", "")
+	viper.SetDefault("auth.anonymous_role", "")
 }
 
 // GetConfig returns the complete configuration
@@ -131,6 +232,79 @@ func (m *Manager) GetServerConfig() *domain.ServerConfig {
 	return &m.config.Server
 }
 
+// GetFrequencyOverrides loads the per-gene and per-condition frequency
+// thresholds. Without a configured file no overrides apply.
+func (m *Manager) GetFrequencyOverrides() (*thresholds.FrequencyOverrides, error) {
+	path := m.config.Classification.FrequencyThresholdsFile
+	if path == "" {
+		return thresholds.NewFrequencyOverrides(nil)
+	}
+	return thresholds.LoadFrequencyOverrides(path)
+}
+
+// GetConflictPolicies loads the resolution policies for conflicting
+// criteria. Without a configured file every conflict is flagged for review.
+func (m *Manager) GetConflictPolicies() (*conflicts.Config, error) {
+	path := m.config.Classification.ConflictPoliciesFile
+	if path == "" {
+		return conflicts.Defaults(), nil
+	}
+	return conflicts.Load(path)
+}
+
+// GetWeightingPolicy loads the laboratory's criterion weighting policy.
+// Without a configured file, or when the file is missing, there is no
+// policy and criteria keep their strengths.
+func (m *Manager) GetWeightingPolicy() (*weighting.Policy, error) {
+	path := m.config.Classification.WeightingPolicyFile
+	if path == "" {
+		return nil, nil
+	}
+	return weighting.Load(path)
+}
+
+// GetPredictorCalibration loads calibrated predictor intervals for PP3 and
+// BP4. Without a configured file, or when the file is missing, there is no
+// calibration and PP3/BP4 count concordant predictors.
+func (m *Manager) GetPredictorCalibration() (*calibration.Calibration, error) {
+	path := m.config.Classification.PredictorCalibrationFile
+	if path == "" {
+		return nil, nil
+	}
+	return calibration.Load(path)
+}
+
+// GetProteinDomains loads the protein domain and hotspot annotations used
+// for PM1. Without a configured directory no domains are annotated.
+func (m *Manager) GetProteinDomains() (*proteindomains.Registry, error) {
+	dir := m.config.Classification.ProteinDomainDir
+	if dir == "" {
+		return proteindomains.NewRegistry(), nil
+	}
+	return proteindomains.LoadDir(dir)
+}
+
+// GetPhenotypeMatcher loads the HPO ontology and gene annotations used for
+// PP4. Without a configured directory no gene has phenotype annotations.
+func (m *Manager) GetPhenotypeMatcher() (*phenotype.Matcher, error) {
+	dir := m.config.Classification.HPODir
+	if dir == "" {
+		return phenotype.NewMatcher(), nil
+	}
+	return phenotype.LoadDir(dir)
+}
+
+// GetConsequenceAnnotator returns the source of transcript consequences:
+// internal, or vep for the Ensembl VEP REST API configured under
+// external_api.vep
+func (m *Manager) GetConsequenceAnnotator() string {
+	annotator := strings.ToLower(m.config.Classification.ConsequenceAnnotator)
+	if annotator == "" {
+		return ConsequenceAnnotatorInternal
+	}
+	return annotator
+}
+
 // Reload reloads the configuration
 func (m *Manager) Reload() error {
 	return m.loadConfig()
@@ -167,11 +341,76 @@ func (m *Manager) Validate() error {
 		return fmt.Errorf("COSMIC base URL is required")
 	}
 
+	if path := config.ExternalAPI.Splicing.ScoresFile; path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("splicing scores file: %w", err)
+		}
+	}
+	if path := config.ExternalAPI.DbNSFP.File; path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("dbNSFP file: %w", err)
+		}
+	}
+
 	// Validate cache configuration
 	if config.Cache.RedisURL == "" {
 		return fmt.Errorf("Redis URL is required")
 	}
 
+	// Validate frequency threshold overrides
+	if path := config.Classification.FrequencyThresholdsFile; path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("frequency thresholds file: %w", err)
+		}
+		if _, err := m.GetFrequencyOverrides(); err != nil {
+			return err
+		}
+	}
+
+	// Validate conflict resolution policies
+	if path := config.Classification.ConflictPoliciesFile; path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("conflict policies file: %w", err)
+		}
+		if _, err := m.GetConflictPolicies(); err != nil {
+			return err
+		}
+	}
+
+	// Validate protein domain tables
+	if dir := config.Classification.ProteinDomainDir; dir != "" {
+		if _, err := os.Stat(dir); err != nil {
+			return fmt.Errorf("protein domain directory: %w", err)
+		}
+		if _, err := m.GetProteinDomains(); err != nil {
+			return err
+		}
+	}
+
+	// Validate the HPO ontology and annotations
+	if dir := config.Classification.HPODir; dir != "" {
+		if _, err := os.Stat(dir); err != nil {
+			return fmt.Errorf("HPO directory: %w", err)
+		}
+		if _, err := m.GetPhenotypeMatcher(); err != nil {
+			return err
+		}
+	}
+
+	// Validate the consequence annotator
+	switch m.GetConsequenceAnnotator() {
+	case ConsequenceAnnotatorInternal:
+	case ConsequenceAnnotatorVEP:
+		if config.ExternalAPI.VEP.BaseURL == "" {
+			return fmt.Errorf("VEP base URL is required for the vep consequence annotator")
+		}
+		if !validVEPTranscripts(config.ExternalAPI.VEP.Transcripts) {
+			return fmt.Errorf("invalid VEP transcripts: %s (must be ensembl, refseq or merged)", config.ExternalAPI.VEP.Transcripts)
+		}
+	default:
+		return fmt.Errorf("invalid consequence annotator: %s (must be internal or vep)", config.Classification.ConsequenceAnnotator)
+	}
+
 	// Validate logging configuration
 	validLogLevels := map[string]bool{
 		"debug": true, "info": true, "warn": true, "error": true, "fatal": true, "panic": true,
@@ -179,6 +418,9 @@ func (m *Manager) Validate() error {
 	if !validLogLevels[strings.ToLower(config.Logging.Level)] {
 		return fmt.Errorf("invalid log level: %s", config.Logging.Level)
 	}
+	if _, err := phi.ParseMode(config.Logging.PHIRedactionMode); err != nil {
+		return fmt.Errorf("invalid logging.phi_redaction_mode: %w", err)
+	}
 
 	return nil
 }
@@ -205,3 +447,13 @@ func (m *Manager) IsDevelopment() bool {
 	env := strings.ToLower(viper.GetString("environment"))
 	return env == "development" || env == "dev" || env == ""
 }
+
+// validVEPTranscripts reports whether a transcript set is one VEP annotates
+// against; empty selects Ensembl
+func validVEPTranscripts(transcripts string) bool {
+	switch strings.ToLower(transcripts) {
+	case "", "ensembl", "refseq", "merged":
+		return true
+	}
+	return false
+}
@@ -6,7 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
 )
 
 // LiteConfig is a simplified configuration for standalone operation.
@@ -16,20 +19,205 @@ type LiteConfig struct {
 	DataDir string // Base directory for data files
 
 	// Cache settings
-	CacheMaxItems int           // Maximum items in memory cache
-	CacheTTL      time.Duration // Default cache TTL
+	CacheMaxItems  int           // Maximum items in memory cache
+	CacheMaxBytes  int64         // Maximum size of cached keys and values in memory; unbounded if zero
+	CacheTTL       time.Duration // Default cache TTL
+	CacheSpillFile string        // SQLite file receiving entries evicted from memory; none if empty
+
+	// Evidence cache policies; sources without an override keep their defaults
+	// (ClinVar and PubMed weekly, gnomAD and HGMD quarterly, COSMIC and LOVD monthly)
+	CacheSourceTTLs  map[string]time.Duration // Per-source TTL overrides, keyed by clinvar, gnomad, cosmic, pubmed, lovd or hgmd
+	CacheStaleWindow time.Duration            // How long expired evidence is served while it is refreshed
+
+	// Classification result cache, dropped by each surveillance run
+	ResultCacheSize int           // Maximum memoized classifications; 0 disables the cache
+	ResultCacheTTL  time.Duration // How long a classification is memoized
 
 	// API settings
 	ClinVarAPIKey string // Optional: NCBI API key for higher rate limits
 	COSMICAPIKey  string // Optional: COSMIC API key
+	OncoKBToken   string // Optional: OncoKB API token; enables OncoKB evidence for somatic tiering
+	OMIMAPIKey    string // Optional: OMIM API key; enables OMIM gene-disease associations
+
+	// External API connections; all clients share one pooled HTTP/2 transport
+	ExternalMaxConnsPerHost int                      // Connections per external host, idle or in use; 0 is unlimited
+	ExternalMaxRetries      int                      // Retries of requests failing with a network error, 429 or 5xx
+	ExternalHostTimeouts    map[string]time.Duration // Per-attempt timeouts keyed by host name, overriding the client's
+
+	// Evidence gathering; sources are queried concurrently and one missing its
+	// deadline leaves the classification provisional
+	EvidenceSourceDeadline  time.Duration            // Deadline of sources without their own
+	EvidenceSourceDeadlines map[string]time.Duration // Per-source deadlines, keyed by clinvar, gnomad, cosmic, pubmed, lovd, hgmd, clingen, splicing, predictors, residues or constraint
+
+	// Splicing predictions; disabled unless a lookup API or scores file is set
+	SplicingLookupURL  string // SpliceAI lookup API serving SpliceAI and Pangolin scores
+	SplicingScoresFile string // Precomputed SpliceAI/Pangolin scores (VCF or TSV, optionally gzipped)
+
+	// In silico scores from a local dbNSFP copy; used when the file exists
+	DbNSFPFile string // SQLite import or tabix-indexed dbNSFP file; defaults to <DataDir>/dbnsfp.db
+
+	// gnomAD gene constraint for PVS1, PP2 and BP1; used when the file exists
+	ConstraintFile string // gnomAD constraint metrics TSV, optionally gzipped; defaults to <DataDir>/gnomad_constraint.tsv
+
+	// Local ClinVar release; used in place of NCBI E-utilities when the file exists
+	ClinVarDBFile string // SQLite import made by "setup clinvar"; defaults to <DataDir>/clinvar.db
+
+	// Local gnomAD copy; used in place of the gnomAD API when the file exists
+	GnomADFile string // SQLite import made by "setup gnomad" or tabix-indexed sites VCF; defaults to <DataDir>/gnomad.db
+
+	// Dataset versions the server must run with, keyed by clinvar, gnomad or
+	// dbnsfp, e.g. clinvar=2024-05-01; startup fails when a dataset differs
+	DatasetPins map[string]string
+
+	// HGVS normalization; enabled when the reference genome FASTA exists
+	GenomeAssembly     string // Assembly of the reference genome and transcripts: GRCh38 or GRCh37
+	ReferenceFastaFile string // samtools-indexed reference genome; defaults to <DataDir>/reference/genome.fa
+	TranscriptGTFFile  string // RefSeq or Ensembl transcript GTF, optionally gzipped; defaults to <DataDir>/reference/transcripts.gtf.gz
+	LiftoverChainDir   string // Directory of UCSC hg19ToHg38/hg38ToHg19 chain files; defaults to <DataDir>/liftover
+
+	// Transcript consequences; annotated from the GTF unless VEP is selected
+	ConsequenceAnnotator string   // internal (default) or vep
+	VEPURL               string   // Ensembl VEP REST API; defaults to https://rest.ensembl.org
+	VEPTranscripts       string   // ensembl (default), refseq or merged
+	VEPPlugins           []string // VEP plugins enabled per request, e.g. LoF, SpliceAI
 
 	// Transport settings
-	Transport string // Transport type: stdio, http
-	HTTPPort  int    // HTTP port (if transport is http)
+	Transport string // Transport type: stdio, http, websocket
+	HTTPPort  int    // HTTP port (if transport is http or websocket)
+
+	// WebSocket keepalive
+	WebSocketPingInterval time.Duration // Interval between pings
+	WebSocketIdleTimeout  time.Duration // Sessions without messages for this long are closed; negative disables
+
+	// HTTP rate limiting per client, identified by API key or IP
+	RateLimitRPS   float64  // Sustained requests per second; 0 disables the rate limit
+	RateLimitBurst int      // Requests a client may make at once
+	DailyQuota     int      // Requests per client per UTC day; 0 is unlimited
+	APIKeys        []string // X-API-Key values identifying clients; other requests are limited per IP
+
+	// HTTP authentication; the HTTP transport requires API keys, a JWT
+	// secret or an anonymous role
+	AuthAPIKeys       []domain.AuthAPIKey // API keys and the roles they grant
+	AuthJWTSecret     string              // HS256 secret for JWT bearer tokens
+	AuthJWTIssuer     string              // Required JWT iss claim, if set
+	AuthJWTAudience   string              // Required JWT aud claim, if set
+	AuthAnonymousRole string              // Role of requests without credentials; for local development only
+
+	// Response size limits per transport (bytes); oversized results are summarized
+	MaxResponseBytesStdio int
+	MaxResponseBytesHTTP  int
+	MaxMessageBytes       int // Largest incoming stdio message; larger requests get a -32600 error
+
+	// Batch classification settings
+	BatchClassifyLimit   int // Maximum variants per classify_variants_batch request
+	BatchClassifyWorkers int // Concurrent classifications per batch
+
+	// Background classification job settings
+	JobWorkers     int // Jobs run concurrently
+	JobMaxVariants int // Maximum variants per job
+
+	// Shutdown: in-flight tool calls and running jobs get this long to finish
+	ShutdownTimeout time.Duration
+
+	// Classification settings
+	ScoringMode             string // Default scoring mode: combining_rules or points
+	VCEPSpecDir             string // Directory of VCEP rule specifications; defaults to <DataDir>/specifications
+	RegionTrackDir          string // Directory of problematic region BED tracks; defaults to <DataDir>/regions
+	TranscriptSetDir        string // Directory of per-specialty transcript sets; defaults to <DataDir>/transcript_sets
+	ProteinDomainDir        string // Directory of protein domain and hotspot tables for PM1; defaults to <DataDir>/protein_domains
+	PhenotypeDir            string // Directory of hp.obo and genes_to_phenotype.txt for PP4; defaults to <DataDir>/hpo
+	OrphanetDir             string // Directory of Orphadata XML products for gene-disease resources; defaults to <DataDir>/orphanet
+	FrequencyThresholdsFile string // Per-gene/condition BA1, BS1 and PM2 thresholds; defaults to <DataDir>/frequency_thresholds.yaml
+	ConflictPoliciesFile    string // Resolution policies for conflicting criteria; defaults to <DataDir>/conflict_policies.yaml
+	WeightingPolicyFile     string // Laboratory criterion weighting policy; defaults to <DataDir>/weighting_policy.yaml
+	CalibrationFile         string // Calibrated predictor intervals for PP3/BP4; defaults to <DataDir>/predictor_calibration.yaml
+	CNVAnnotationDir        string // Directory of gene, ClinGen dosage and benign CNV annotations; defaults to <DataDir>/cnv
+	PGxDir                  string // Directory of PharmVar star allele and CPIC tables; defaults to <DataDir>/pgx
+
+	// Git-backed clinical configuration; replaces the local specification,
+	// transcript set, region track and frequency threshold files when set
+	ConfigRepoURL              string        // Config repository URL or path
+	ConfigRepoBranch           string        // Branch to follow
+	ConfigRepoInterval         time.Duration // How often the repository is fetched
+	ConfigRepoVerifySignatures bool          // Apply only signed commits
+	ConfigRepoAllowedSigners   string        // SSH allowed signers file for verifying commits
+
+	// In-house cohort settings; recurrent variants meeting both are flagged as suspected artifacts
+	CohortMinSize          int     // Probands required before artifacts are flagged
+	CohortArtifactFraction float64 // Fraction of probands carrying a variant that flags it
+
+	// Evidence archive settings
+	ArchiveAfter    time.Duration // Age after which evidence snapshots move to the archive tier
+	ArchiveInterval time.Duration // How often the archiver runs
+	EvidenceBundles bool          // Store each classification's evidence bundle for replay_classification
+
+	// Evidence resources
+	EvidenceMockFallback bool // Serve mock evidence, marked with the mock origin, when no live or cached evidence exists
+
+	// Dual review
+	RequireReview bool // Report only classifications approved in dual review
+
+	// Audit trail
+	AuditSigningKey string // Base64 Ed25519 seed signing audit export bundles; bundles are unsigned when empty
+
+	// Literature monitoring
+	LiteratureCheckInterval time.Duration // How often cited articles are checked for retractions and errata; 0 disables
+
+	// Evidence surveillance
+	SurveillanceSchedule    string // Cron expression (UTC) for re-evaluating stored variants, e.g. "0 2 * * 0"; disabled when empty
+	SurveillanceMaxVariants int    // Variants re-evaluated per run; 0 re-evaluates all
+
+	// Curation settings
+	SeniorCurators []string // Curator IDs allowed to edit gene playbooks
+
+	// Admin API settings; the API is disabled unless both are set
+	AdminAddr  string // Listen address for the threshold admin API, e.g. 127.0.0.1:8090
+	AdminToken string // Bearer token required by the admin API
+
+	// GA4GH Beacon v2 over the lab knowledge base, served at /api/beacon/v2 by
+	// the HTTP and WebSocket transports
+	BeaconEnabled         bool
+	BeaconID              string // Reverse domain name identifying the beacon, e.g. org.example.lab.beacon
+	BeaconName            string
+	BeaconOrganization    string // Organization running the beacon
+	BeaconGranularity     string // Granularity served without credentials: none, boolean, count or record
+	BeaconRoleGranularity string // Granularity granted per role, e.g. "read_only=count,classify=record"
+
+	// gRPC API for pipeline integrations, authenticated and rate limited like
+	// the HTTP transport
+	GRPCAddr string // Listen address of the gRPC API, e.g. 0.0.0.0:9443; disabled when empty
+
+	// Prometheus metrics; also served at /metrics by the HTTP and WebSocket transports
+	MetricsAddr string // Listen address of the /metrics endpoint, e.g. 127.0.0.1:9090; disabled when empty
+
+	// OpenTelemetry tracing
+	TracingEndpoint  string  // OTLP/HTTP collector base URL, e.g. http://localhost:4318; disabled when empty
+	TraceSampleRatio float64 // Fraction of new traces recorded
+
+	// Weekly digest delivery; each channel is enabled when its destination is set
+	DigestSlackWebhook string       // Slack incoming webhook URL
+	DigestSMTPAddr     string       // SMTP server host:port
+	DigestSMTPUser     string       // Optional SMTP username
+	DigestSMTPPassword string       // Optional SMTP password
+	DigestEmailFrom    string       // Sender address for digest emails
+	DigestEmailTo      []string     // Digest email recipients
+	DigestWeekday      time.Weekday // Day the previous week's digest is sent
+	DigestHour         int          // Hour (UTC) the digest is sent
+
+	// Localization
+	Locale string // Default locale of reports, prompt output and error messages: en, ja or zh-Hant
 
 	// Logging
 	LogLevel  string // Log level: debug, info, warn, error
 	LogFormat string // Log format: json, text
+
+	// PHI-safe logging hides patient identifiers and clinical free text in
+	// log fields and audit exports
+	PHISafeLogging      bool
+	PHIRedactionMode    string   // Identifiers are redacted (default) or tokenized
+	PHITokenKey         string   // Keys identifier tokens so they stay stable across restarts
+	PHIIdentifierFields []string // Request fields holding patient identifiers, added to the defaults
+	PHIFreeTextFields   []string // Request fields holding clinical free text, added to the defaults
 }
 
 // DefaultLiteConfig returns a configuration with sensible defaults.
@@ -38,13 +226,53 @@ func DefaultLiteConfig() *LiteConfig {
 	dataDir := filepath.Join(homeDir, ".acmg-amp-mcp")
 
 	return &LiteConfig{
-		DataDir:       dataDir,
-		CacheMaxItems: 1000,
-		CacheTTL:      24 * time.Hour,
-		Transport:     "stdio",
-		HTTPPort:      8080,
-		LogLevel:      "info",
-		LogFormat:     "json",
+		DataDir:                    dataDir,
+		CacheMaxItems:              1000,
+		CacheMaxBytes:              256 << 20,
+		CacheTTL:                   24 * time.Hour,
+		ResultCacheSize:            1000,
+		ResultCacheTTL:             time.Hour,
+		ExternalMaxConnsPerHost:    32,
+		ExternalMaxRetries:         2,
+		EvidenceSourceDeadline:     20 * time.Second,
+		Transport:                  "stdio",
+		HTTPPort:                   8080,
+		WebSocketPingInterval:      30 * time.Second,
+		WebSocketIdleTimeout:       10 * time.Minute,
+		RateLimitRPS:               10,
+		RateLimitBurst:             20,
+		MaxResponseBytesStdio:      256 * 1024,
+		MaxResponseBytesHTTP:       4 * 1024 * 1024,
+		MaxMessageBytes:            64 * 1024 * 1024,
+		BatchClassifyLimit:         500,
+		BatchClassifyWorkers:       8,
+		JobWorkers:                 2,
+		JobMaxVariants:             50000,
+		ShutdownTimeout:            30 * time.Second,
+		ScoringMode:                "combining_rules",
+		GenomeAssembly:             "GRCh38",
+		ConsequenceAnnotator:       ConsequenceAnnotatorInternal,
+		VEPURL:                     "https://rest.ensembl.org",
+		VEPTranscripts:             "ensembl",
+		ConfigRepoBranch:           "main",
+		ConfigRepoInterval:         5 * time.Minute,
+		ConfigRepoVerifySignatures: true,
+		CohortMinSize:              50,
+		CohortArtifactFraction:     0.05,
+		TraceSampleRatio:           1,
+		ArchiveAfter:               90 * 24 * time.Hour,
+		ArchiveInterval:            24 * time.Hour,
+		LiteratureCheckInterval:    24 * time.Hour,
+		DigestWeekday:              time.Monday,
+		DigestHour:                 7,
+		Locale:                     "en",
+		LogLevel:                   "info",
+		LogFormat:                  "json",
+		PHIRedactionMode:           "redact",
+		BeaconID:                   "local.acmg-amp-mcp-server.beacon",
+		BeaconName:                 "ACMG/AMP lab variant beacon",
+		BeaconGranularity:          "boolean",
+		BeaconRoleGranularity:      "read_only=count,classify=record",
 	}
 }
 
@@ -64,15 +292,156 @@ func LoadLiteConfig() *LiteConfig {
 			cfg.CacheMaxItems = n
 		}
 	}
+	if v := os.Getenv("ACMG_CACHE_MAX_BYTES"); v != "" {
+		if n, err := parseByteSize(v); err == nil && n >= 0 {
+			cfg.CacheMaxBytes = n
+		}
+	}
+	cfg.CacheSpillFile = os.Getenv("ACMG_CACHE_SPILL_FILE")
 	if v := os.Getenv("ACMG_CACHE_TTL"); v != "" {
 		if d, err := time.ParseDuration(v); err == nil {
 			cfg.CacheTTL = d
 		}
 	}
+	if v := os.Getenv("ACMG_CACHE_SOURCE_TTLS"); v != "" {
+		for _, entry := range strings.Split(v, ",") {
+			source, value, ok := strings.Cut(entry, "=")
+			if !ok {
+				continue
+			}
+			if d, err := time.ParseDuration(strings.TrimSpace(value)); err == nil && d > 0 {
+				if cfg.CacheSourceTTLs == nil {
+					cfg.CacheSourceTTLs = make(map[string]time.Duration)
+				}
+				cfg.CacheSourceTTLs[strings.ToLower(strings.TrimSpace(source))] = d
+			}
+		}
+	}
+	if v := os.Getenv("ACMG_CACHE_STALE_WINDOW"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.CacheStaleWindow = d
+		}
+	}
+	if v := os.Getenv("ACMG_RESULT_CACHE_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.ResultCacheSize = n
+		}
+	}
+	if v := os.Getenv("ACMG_RESULT_CACHE_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.ResultCacheTTL = d
+		}
+	}
 
 	// API keys
 	cfg.ClinVarAPIKey = os.Getenv("CLINVAR_API_KEY")
 	cfg.COSMICAPIKey = os.Getenv("COSMIC_API_KEY")
+	cfg.OncoKBToken = os.Getenv("ONCOKB_API_TOKEN")
+	cfg.OMIMAPIKey = os.Getenv("OMIM_API_KEY")
+
+	// External API connections
+	if v := os.Getenv("ACMG_EXTERNAL_MAX_CONNS_PER_HOST"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.ExternalMaxConnsPerHost = n
+		}
+	}
+	if v := os.Getenv("ACMG_EXTERNAL_MAX_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.ExternalMaxRetries = n
+		}
+	}
+	if v := os.Getenv("ACMG_EXTERNAL_HOST_TIMEOUTS"); v != "" {
+		for _, entry := range strings.Split(v, ",") {
+			host, value, ok := strings.Cut(entry, "=")
+			if !ok {
+				continue
+			}
+			if d, err := time.ParseDuration(strings.TrimSpace(value)); err == nil && d > 0 {
+				if cfg.ExternalHostTimeouts == nil {
+					cfg.ExternalHostTimeouts = make(map[string]time.Duration)
+				}
+				cfg.ExternalHostTimeouts[strings.ToLower(strings.TrimSpace(host))] = d
+			}
+		}
+	}
+
+	// Evidence gathering
+	if v := os.Getenv("ACMG_EVIDENCE_SOURCE_DEADLINE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.EvidenceSourceDeadline = d
+		}
+	}
+	if v := os.Getenv("ACMG_EVIDENCE_SOURCE_DEADLINES"); v != "" {
+		for _, entry := range strings.Split(v, ",") {
+			source, value, ok := strings.Cut(entry, "=")
+			if !ok {
+				continue
+			}
+			if d, err := time.ParseDuration(strings.TrimSpace(value)); err == nil && d > 0 {
+				if cfg.EvidenceSourceDeadlines == nil {
+					cfg.EvidenceSourceDeadlines = make(map[string]time.Duration)
+				}
+				cfg.EvidenceSourceDeadlines[strings.ToLower(strings.TrimSpace(source))] = d
+			}
+		}
+	}
+
+	// Splicing predictions
+	cfg.SplicingLookupURL = os.Getenv("ACMG_SPLICING_LOOKUP_URL")
+	cfg.SplicingScoresFile = os.Getenv("ACMG_SPLICING_SCORES_FILE")
+
+	// dbNSFP in silico scores
+	cfg.DbNSFPFile = os.Getenv("ACMG_DBNSFP_FILE")
+
+	// gnomAD gene constraint
+	cfg.ConstraintFile = os.Getenv("ACMG_CONSTRAINT_FILE")
+
+	// Local ClinVar release
+	cfg.ClinVarDBFile = os.Getenv("ACMG_CLINVAR_DB")
+
+	// Local gnomAD copy
+	cfg.GnomADFile = os.Getenv("ACMG_GNOMAD_FILE")
+
+	// Dataset version pins
+	if v := os.Getenv("ACMG_DATASET_PINS"); v != "" {
+		for _, entry := range strings.Split(v, ",") {
+			source, version, ok := strings.Cut(entry, "=")
+			source, version = strings.ToLower(strings.TrimSpace(source)), strings.TrimSpace(version)
+			if !ok || source == "" || version == "" {
+				continue
+			}
+			if cfg.DatasetPins == nil {
+				cfg.DatasetPins = make(map[string]string)
+			}
+			cfg.DatasetPins[source] = version
+		}
+	}
+
+	// HGVS normalization
+	if v := os.Getenv("ACMG_GENOME_ASSEMBLY"); v != "" {
+		cfg.GenomeAssembly = v
+	}
+	cfg.ReferenceFastaFile = os.Getenv("ACMG_REFERENCE_FASTA")
+	cfg.TranscriptGTFFile = os.Getenv("ACMG_TRANSCRIPT_GTF")
+	cfg.LiftoverChainDir = os.Getenv("ACMG_LIFTOVER_DIR")
+
+	// Transcript consequences
+	if v := strings.ToLower(os.Getenv("ACMG_CONSEQUENCE_ANNOTATOR")); v == ConsequenceAnnotatorVEP {
+		cfg.ConsequenceAnnotator = v
+	}
+	if v := os.Getenv("ACMG_VEP_URL"); v != "" {
+		cfg.VEPURL = v
+	}
+	if v := strings.ToLower(os.Getenv("ACMG_VEP_TRANSCRIPTS")); validVEPTranscripts(v) && v != "" {
+		cfg.VEPTranscripts = v
+	}
+	if v := os.Getenv("ACMG_VEP_PLUGINS"); v != "" {
+		for _, plugin := range strings.Split(v, ",") {
+			if plugin = strings.TrimSpace(plugin); plugin != "" {
+				cfg.VEPPlugins = append(cfg.VEPPlugins, plugin)
+			}
+		}
+	}
 
 	// Transport
 	if v := os.Getenv("ACMG_TRANSPORT"); v != "" {
@@ -83,6 +452,274 @@ func LoadLiteConfig() *LiteConfig {
 			cfg.HTTPPort = n
 		}
 	}
+	if v := os.Getenv("ACMG_WS_PING_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.WebSocketPingInterval = d
+		}
+	}
+	if v := os.Getenv("ACMG_WS_IDLE_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d != 0 {
+			cfg.WebSocketIdleTimeout = d
+		}
+	}
+
+	// HTTP rate limiting
+	if v := os.Getenv("ACMG_RATE_LIMIT_RPS"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 {
+			cfg.RateLimitRPS = f
+		}
+	}
+	if v := os.Getenv("ACMG_RATE_LIMIT_BURST"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.RateLimitBurst = n
+		}
+	}
+	if v := os.Getenv("ACMG_DAILY_QUOTA"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.DailyQuota = n
+		}
+	}
+	if v := os.Getenv("ACMG_API_KEYS"); v != "" {
+		for _, key := range strings.Split(v, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				cfg.APIKeys = append(cfg.APIKeys, key)
+			}
+		}
+	}
+
+	// HTTP authentication; API keys are "name:role:key" entries
+	if v := os.Getenv("ACMG_AUTH_API_KEYS"); v != "" {
+		for _, entry := range strings.Split(v, ",") {
+			parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+			if len(parts) == 3 && parts[0] != "" && parts[2] != "" {
+				cfg.AuthAPIKeys = append(cfg.AuthAPIKeys, domain.AuthAPIKey{Name: parts[0], Role: parts[1], Key: parts[2]})
+			}
+		}
+	}
+	cfg.AuthJWTSecret = os.Getenv("ACMG_AUTH_JWT_SECRET")
+	cfg.AuthJWTIssuer = os.Getenv("ACMG_AUTH_JWT_ISSUER")
+	cfg.AuthJWTAudience = os.Getenv("ACMG_AUTH_JWT_AUDIENCE")
+	cfg.AuthAnonymousRole = os.Getenv("ACMG_AUTH_ANONYMOUS_ROLE")
+
+	// Response size limits
+	if v := os.Getenv("ACMG_MAX_RESPONSE_BYTES_STDIO"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.MaxResponseBytesStdio = n
+		}
+	}
+	if v := os.Getenv("ACMG_MAX_RESPONSE_BYTES_HTTP"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.MaxResponseBytesHTTP = n
+		}
+	}
+	if v := os.Getenv("ACMG_MAX_MESSAGE_BYTES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.MaxMessageBytes = n
+		}
+	}
+
+	// Batch classification
+	if v := os.Getenv("ACMG_BATCH_CLASSIFY_LIMIT"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.BatchClassifyLimit = n
+		}
+	}
+	if v := os.Getenv("ACMG_BATCH_CLASSIFY_WORKERS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.BatchClassifyWorkers = n
+		}
+	}
+
+	// Background classification jobs
+	if v := os.Getenv("ACMG_JOB_WORKERS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.JobWorkers = n
+		}
+	}
+	if v := os.Getenv("ACMG_JOB_MAX_VARIANTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.JobMaxVariants = n
+		}
+	}
+
+	// Shutdown
+	if v := os.Getenv("ACMG_SHUTDOWN_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.ShutdownTimeout = d
+		}
+	}
+
+	// Classification
+	if v := os.Getenv("ACMG_SCORING_MODE"); v != "" {
+		cfg.ScoringMode = strings.ToLower(strings.TrimSpace(v))
+	}
+	if v := os.Getenv("ACMG_LOCALE"); v != "" {
+		cfg.Locale = strings.TrimSpace(v)
+	}
+	cfg.VCEPSpecDir = os.Getenv("ACMG_VCEP_SPEC_DIR")
+	cfg.RegionTrackDir = os.Getenv("ACMG_REGION_TRACK_DIR")
+	cfg.TranscriptSetDir = os.Getenv("ACMG_TRANSCRIPT_SET_DIR")
+	cfg.ProteinDomainDir = os.Getenv("ACMG_PROTEIN_DOMAIN_DIR")
+	cfg.PhenotypeDir = os.Getenv("ACMG_HPO_DIR")
+	cfg.OrphanetDir = os.Getenv("ACMG_ORPHANET_DIR")
+	cfg.FrequencyThresholdsFile = os.Getenv("ACMG_FREQUENCY_THRESHOLDS_FILE")
+	cfg.ConflictPoliciesFile = os.Getenv("ACMG_CONFLICT_POLICIES_FILE")
+	cfg.WeightingPolicyFile = os.Getenv("ACMG_WEIGHTING_POLICY_FILE")
+	cfg.CalibrationFile = os.Getenv("ACMG_PREDICTOR_CALIBRATION_FILE")
+	cfg.CNVAnnotationDir = os.Getenv("ACMG_CNV_ANNOTATION_DIR")
+	cfg.PGxDir = os.Getenv("ACMG_PGX_DIR")
+
+	// Git-backed clinical configuration
+	cfg.ConfigRepoURL = os.Getenv("ACMG_CONFIG_REPO_URL")
+	if v := os.Getenv("ACMG_CONFIG_REPO_BRANCH"); v != "" {
+		cfg.ConfigRepoBranch = v
+	}
+	if v := os.Getenv("ACMG_CONFIG_REPO_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.ConfigRepoInterval = d
+		}
+	}
+	if v := os.Getenv("ACMG_CONFIG_REPO_VERIFY_SIGNATURES"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.ConfigRepoVerifySignatures = b
+		}
+	}
+	cfg.ConfigRepoAllowedSigners = os.Getenv("ACMG_CONFIG_REPO_ALLOWED_SIGNERS")
+
+	// In-house cohort
+	if v := os.Getenv("ACMG_COHORT_MIN_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.CohortMinSize = n
+		}
+	}
+	if v := os.Getenv("ACMG_COHORT_ARTIFACT_FRACTION"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f > 0 && f <= 1 {
+			cfg.CohortArtifactFraction = f
+		}
+	}
+
+	// Evidence archive
+	if v := os.Getenv("ACMG_ARCHIVE_AFTER"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.ArchiveAfter = d
+		}
+	}
+	if v := os.Getenv("ACMG_ARCHIVE_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.ArchiveInterval = d
+		}
+	}
+	if v := os.Getenv("ACMG_EVIDENCE_BUNDLES"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.EvidenceBundles = b
+		}
+	}
+
+	// Evidence resources
+	if v := os.Getenv("ACMG_EVIDENCE_MOCK_FALLBACK"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.EvidenceMockFallback = b
+		}
+	}
+
+	// Dual review
+	if v := os.Getenv("ACMG_REQUIRE_REVIEW"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.RequireReview = b
+		}
+	}
+
+	// Audit trail
+	cfg.AuditSigningKey = strings.TrimSpace(os.Getenv("ACMG_AUDIT_SIGNING_KEY"))
+
+	// Literature monitoring
+	if v := os.Getenv("ACMG_LITERATURE_CHECK_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.LiteratureCheckInterval = d
+		}
+	}
+
+	// Evidence surveillance
+	cfg.SurveillanceSchedule = strings.TrimSpace(os.Getenv("ACMG_SURVEILLANCE_SCHEDULE"))
+	if v := os.Getenv("ACMG_SURVEILLANCE_MAX_VARIANTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.SurveillanceMaxVariants = n
+		}
+	}
+
+	// Curation
+	if v := os.Getenv("ACMG_SENIOR_CURATORS"); v != "" {
+		for _, curator := range strings.Split(v, ",") {
+			if curator = strings.TrimSpace(curator); curator != "" {
+				cfg.SeniorCurators = append(cfg.SeniorCurators, curator)
+			}
+		}
+	}
+
+	// Admin API
+	cfg.AdminAddr = os.Getenv("ACMG_ADMIN_ADDR")
+	cfg.AdminToken = os.Getenv("ACMG_ADMIN_TOKEN")
+
+	// Beacon API
+	if v := os.Getenv("ACMG_BEACON_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.BeaconEnabled = b
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("ACMG_BEACON_ID")); v != "" {
+		cfg.BeaconID = v
+	}
+	if v := strings.TrimSpace(os.Getenv("ACMG_BEACON_NAME")); v != "" {
+		cfg.BeaconName = v
+	}
+	cfg.BeaconOrganization = strings.TrimSpace(os.Getenv("ACMG_BEACON_ORGANIZATION"))
+	if v, ok := os.LookupEnv("ACMG_BEACON_GRANULARITY"); ok {
+		cfg.BeaconGranularity = strings.ToLower(strings.TrimSpace(v))
+	}
+	if v, ok := os.LookupEnv("ACMG_BEACON_ROLE_GRANULARITY"); ok {
+		cfg.BeaconRoleGranularity = strings.TrimSpace(v)
+	}
+
+	// gRPC API
+	cfg.GRPCAddr = strings.TrimSpace(os.Getenv("ACMG_GRPC_ADDR"))
+
+	// Prometheus metrics
+	cfg.MetricsAddr = os.Getenv("ACMG_METRICS_ADDR")
+
+	// OpenTelemetry tracing, honouring the standard OTLP variable
+	cfg.TracingEndpoint = os.Getenv("ACMG_OTLP_ENDPOINT")
+	if cfg.TracingEndpoint == "" {
+		cfg.TracingEndpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	if v := os.Getenv("ACMG_TRACE_SAMPLE_RATIO"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 && f <= 1 {
+			cfg.TraceSampleRatio = f
+		}
+	}
+
+	// Weekly digest
+	cfg.DigestSlackWebhook = os.Getenv("ACMG_DIGEST_SLACK_WEBHOOK")
+	cfg.DigestSMTPAddr = os.Getenv("ACMG_DIGEST_SMTP_ADDR")
+	cfg.DigestSMTPUser = os.Getenv("ACMG_DIGEST_SMTP_USER")
+	cfg.DigestSMTPPassword = os.Getenv("ACMG_DIGEST_SMTP_PASSWORD")
+	cfg.DigestEmailFrom = os.Getenv("ACMG_DIGEST_EMAIL_FROM")
+	if v := os.Getenv("ACMG_DIGEST_EMAIL_TO"); v != "" {
+		for _, recipient := range strings.Split(v, ",") {
+			if recipient = strings.TrimSpace(recipient); recipient != "" {
+				cfg.DigestEmailTo = append(cfg.DigestEmailTo, recipient)
+			}
+		}
+	}
+	if v := os.Getenv("ACMG_DIGEST_WEEKDAY"); v != "" {
+		if day, ok := parseWeekday(v); ok {
+			cfg.DigestWeekday = day
+		}
+	}
+	if v := os.Getenv("ACMG_DIGEST_HOUR"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 && n < 24 {
+			cfg.DigestHour = n
+		}
+	}
 
 	// Logging
 	if v := os.Getenv("ACMG_LOG_LEVEL"); v != "" {
@@ -91,20 +728,349 @@ func LoadLiteConfig() *LiteConfig {
 	if v := os.Getenv("ACMG_LOG_FORMAT"); v != "" {
 		cfg.LogFormat = v
 	}
+	if v := os.Getenv("ACMG_PHI_SAFE_LOGGING"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.PHISafeLogging = b
+		}
+	}
+	if v := os.Getenv("ACMG_PHI_REDACTION_MODE"); v != "" {
+		cfg.PHIRedactionMode = strings.ToLower(strings.TrimSpace(v))
+	}
+	cfg.PHITokenKey = os.Getenv("ACMG_PHI_TOKEN_KEY")
+	cfg.PHIIdentifierFields = splitFields(os.Getenv("ACMG_PHI_IDENTIFIER_FIELDS"))
+	cfg.PHIFreeTextFields = splitFields(os.Getenv("ACMG_PHI_FREE_TEXT_FIELDS"))
 
 	return cfg
 }
 
+// splitFields splits a comma-separated list, dropping empty entries
+func splitFields(v string) []string {
+	var fields []string
+	for _, field := range strings.Split(v, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
 // FeedbackDBPath returns the path to the feedback SQLite database.
 func (c *LiteConfig) FeedbackDBPath() string {
 	return filepath.Join(c.DataDir, "feedback.db")
 }
 
+// SnapshotDBPath returns the path to the evidence snapshot SQLite database.
+func (c *LiteConfig) SnapshotDBPath() string {
+	return filepath.Join(c.DataDir, "evidence.db")
+}
+
+// PlaybookDBPath returns the path to the gene playbook SQLite database.
+func (c *LiteConfig) PlaybookDBPath() string {
+	return filepath.Join(c.DataDir, "playbooks.db")
+}
+
+// FollowUpDBPath returns the path to the follow-up flag SQLite database.
+func (c *LiteConfig) FollowUpDBPath() string {
+	return filepath.Join(c.DataDir, "followup.db")
+}
+
+// CohortDBPath returns the path to the in-house cohort SQLite database.
+func (c *LiteConfig) CohortDBPath() string {
+	return filepath.Join(c.DataDir, "cohort.db")
+}
+
+// ArtifactsDBPath returns the path to the artifact blacklist SQLite database.
+func (c *LiteConfig) ArtifactsDBPath() string {
+	return filepath.Join(c.DataDir, "artifacts.db")
+}
+
+// KnownBenignDBPath returns the path to the known benign list SQLite database.
+func (c *LiteConfig) KnownBenignDBPath() string {
+	return filepath.Join(c.DataDir, "known_benign.db")
+}
+
+// LabKnowledgeDBPath returns the path to the lab knowledge base SQLite database.
+func (c *LiteConfig) LabKnowledgeDBPath() string {
+	return filepath.Join(c.DataDir, "lab_knowledge.db")
+}
+
+// WebhooksDBPath returns the path to the webhook SQLite database.
+func (c *LiteConfig) WebhooksDBPath() string {
+	return filepath.Join(c.DataDir, "webhooks.db")
+}
+
+// JobsDBPath returns the path to the classification job SQLite database.
+func (c *LiteConfig) JobsDBPath() string {
+	return filepath.Join(c.DataDir, "jobs.db")
+}
+
+// ClassificationSessionsDBPath returns the path to the guided classification session SQLite database.
+func (c *LiteConfig) ClassificationSessionsDBPath() string {
+	return filepath.Join(c.DataDir, "classification_sessions.db")
+}
+
+// ReviewsDBPath returns the path to the classification review SQLite database.
+func (c *LiteConfig) ReviewsDBPath() string {
+	return filepath.Join(c.DataDir, "reviews.db")
+}
+
+// AuditDBPath returns the path to the classification audit trail SQLite database.
+func (c *LiteConfig) AuditDBPath() string {
+	return filepath.Join(c.DataDir, "audit.db")
+}
+
+// LiteratureDBPath returns the path to the literature notice SQLite database.
+func (c *LiteConfig) LiteratureDBPath() string {
+	return filepath.Join(c.DataDir, "literature.db")
+}
+
+// IdentifiersDBPath returns the path to the rsID and ClinVar accession mapping cache.
+func (c *LiteConfig) IdentifiersDBPath() string {
+	return filepath.Join(c.DataDir, "identifiers.db")
+}
+
+// ThresholdsDBPath returns the path to the threshold revision SQLite database.
+func (c *LiteConfig) ThresholdsDBPath() string {
+	return filepath.Join(c.DataDir, "thresholds.db")
+}
+
+// SpecificationsDir returns the directory VCEP rule specifications are loaded from.
+func (c *LiteConfig) SpecificationsDir() string {
+	if c.VCEPSpecDir != "" {
+		return c.VCEPSpecDir
+	}
+	return filepath.Join(c.DataDir, "specifications")
+}
+
+// RegionTracksDir returns the directory problematic region BED tracks are loaded from.
+func (c *LiteConfig) RegionTracksDir() string {
+	if c.RegionTrackDir != "" {
+		return c.RegionTrackDir
+	}
+	return filepath.Join(c.DataDir, "regions")
+}
+
+// CNVAnnotationsDir returns the directory CNV gene and dosage annotations are loaded from.
+func (c *LiteConfig) CNVAnnotationsDir() string {
+	if c.CNVAnnotationDir != "" {
+		return c.CNVAnnotationDir
+	}
+	return filepath.Join(c.DataDir, "cnv")
+}
+
+// PGxTablesDir returns the directory star allele definitions and CPIC tables are loaded from.
+func (c *LiteConfig) PGxTablesDir() string {
+	if c.PGxDir != "" {
+		return c.PGxDir
+	}
+	return filepath.Join(c.DataDir, "pgx")
+}
+
+// TranscriptSetsDir returns the directory per-specialty transcript sets are loaded from.
+func (c *LiteConfig) TranscriptSetsDir() string {
+	if c.TranscriptSetDir != "" {
+		return c.TranscriptSetDir
+	}
+	return filepath.Join(c.DataDir, "transcript_sets")
+}
+
+// ProteinDomainsDir returns the directory protein domain and hotspot tables are loaded from.
+func (c *LiteConfig) ProteinDomainsDir() string {
+	if c.ProteinDomainDir != "" {
+		return c.ProteinDomainDir
+	}
+	return filepath.Join(c.DataDir, "protein_domains")
+}
+
+// HPODir returns the directory the HPO ontology and gene annotations are loaded from.
+func (c *LiteConfig) HPODir() string {
+	if c.PhenotypeDir != "" {
+		return c.PhenotypeDir
+	}
+	return filepath.Join(c.DataDir, "hpo")
+}
+
+// OrphanetPath returns the directory Orphadata gene-disease products are loaded from.
+func (c *LiteConfig) OrphanetPath() string {
+	if c.OrphanetDir != "" {
+		return c.OrphanetDir
+	}
+	return filepath.Join(c.DataDir, "orphanet")
+}
+
+// SplicingEnabled reports whether SpliceAI/Pangolin predictions are configured.
+func (c *LiteConfig) SplicingEnabled() bool {
+	return c.SplicingLookupURL != "" || c.SplicingScoresFile != ""
+}
+
+// VEPEnabled reports whether transcript consequences come from Ensembl VEP.
+func (c *LiteConfig) VEPEnabled() bool {
+	return c.ConsequenceAnnotator == ConsequenceAnnotatorVEP
+}
+
+// DbNSFPPath returns the dbNSFP SQLite import or tabix-indexed file scores are looked up in.
+func (c *LiteConfig) DbNSFPPath() string {
+	if c.DbNSFPFile != "" {
+		return c.DbNSFPFile
+	}
+	return filepath.Join(c.DataDir, "dbnsfp.db")
+}
+
+// ConstraintPath returns the gnomAD gene constraint table constraint metrics are looked up in.
+func (c *LiteConfig) ConstraintPath() string {
+	if c.ConstraintFile != "" {
+		return c.ConstraintFile
+	}
+	return filepath.Join(c.DataDir, "gnomad_constraint.tsv")
+}
+
+// ClinVarDBPath returns the local ClinVar import ClinVar records are looked up in.
+func (c *LiteConfig) ClinVarDBPath() string {
+	if c.ClinVarDBFile != "" {
+		return c.ClinVarDBFile
+	}
+	return filepath.Join(c.DataDir, "clinvar.db")
+}
+
+// GnomADPath returns the local gnomAD import or sites VCF frequencies are looked up in.
+func (c *LiteConfig) GnomADPath() string {
+	if c.GnomADFile != "" {
+		return c.GnomADFile
+	}
+	return filepath.Join(c.DataDir, "gnomad.db")
+}
+
+// ReferenceFastaPath returns the indexed reference genome FASTA variants are normalized against.
+func (c *LiteConfig) ReferenceFastaPath() string {
+	if c.ReferenceFastaFile != "" {
+		return c.ReferenceFastaFile
+	}
+	return filepath.Join(c.DataDir, "reference", "genome.fa")
+}
+
+// TranscriptGTFPath returns the GTF file reference transcripts are loaded from.
+func (c *LiteConfig) TranscriptGTFPath() string {
+	if c.TranscriptGTFFile != "" {
+		return c.TranscriptGTFFile
+	}
+	return filepath.Join(c.DataDir, "reference", "transcripts.gtf.gz")
+}
+
+// LiftoverDir returns the directory GRCh37/GRCh38 liftover chain files are loaded from.
+func (c *LiteConfig) LiftoverDir() string {
+	if c.LiftoverChainDir != "" {
+		return c.LiftoverChainDir
+	}
+	return filepath.Join(c.DataDir, "liftover")
+}
+
+// FrequencyThresholdsPath returns the file per-gene and per-condition frequency thresholds are loaded from.
+func (c *LiteConfig) FrequencyThresholdsPath() string {
+	if c.FrequencyThresholdsFile != "" {
+		return c.FrequencyThresholdsFile
+	}
+	return filepath.Join(c.DataDir, "frequency_thresholds.yaml")
+}
+
+// ConflictPoliciesPath returns the file conflicting-criteria resolution policies are loaded from.
+func (c *LiteConfig) ConflictPoliciesPath() string {
+	if c.ConflictPoliciesFile != "" {
+		return c.ConflictPoliciesFile
+	}
+	return filepath.Join(c.DataDir, "conflict_policies.yaml")
+}
+
+// WeightingPolicyPath returns the file the laboratory's criterion weighting policy is loaded from.
+func (c *LiteConfig) WeightingPolicyPath() string {
+	if c.WeightingPolicyFile != "" {
+		return c.WeightingPolicyFile
+	}
+	return filepath.Join(c.DataDir, "weighting_policy.yaml")
+}
+
+// CalibrationPath returns the file calibrated predictor intervals are loaded from.
+func (c *LiteConfig) CalibrationPath() string {
+	if c.CalibrationFile != "" {
+		return c.CalibrationFile
+	}
+	return filepath.Join(c.DataDir, "predictor_calibration.yaml")
+}
+
+// ConfigRepoEnabled reports whether clinical configuration is loaded from a Git repository.
+func (c *LiteConfig) ConfigRepoEnabled() bool {
+	return c.ConfigRepoURL != ""
+}
+
+// ConfigRepoDir returns the directory the config repository is checked out to.
+func (c *LiteConfig) ConfigRepoDir() string {
+	return filepath.Join(c.DataDir, "config_repo")
+}
+
+// AdminEnabled reports whether the admin API should be started.
+func (c *LiteConfig) AdminEnabled() bool {
+	return c.AdminAddr != "" && c.AdminToken != ""
+}
+
+// DigestEmailEnabled reports whether digests should be emailed.
+func (c *LiteConfig) DigestEmailEnabled() bool {
+	return c.DigestSMTPAddr != "" && c.DigestEmailFrom != "" && len(c.DigestEmailTo) > 0
+}
+
+// parseWeekday parses a day name such as "monday" or "Mon".
+func parseWeekday(v string) (time.Weekday, bool) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if len(v) < 3 {
+		return 0, false
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.HasPrefix(strings.ToLower(day.String()), v) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// byteSizeUnits are the suffixes parseByteSize accepts, in binary multiples
+var byteSizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1},
+}
+
+// parseByteSize parses a size such as "268435456", "256MB" or "1GB"
+func parseByteSize(v string) (int64, error) {
+	v = strings.ToUpper(strings.TrimSpace(v))
+	multiplier := int64(1)
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(v, unit.suffix) {
+			v = strings.TrimSpace(strings.TrimSuffix(v, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n * multiplier, nil
+}
+
+// ArchiveDir returns the directory for compressed evidence archives.
+func (c *LiteConfig) ArchiveDir() string {
+	return filepath.Join(c.DataDir, "archive")
+}
+
 // ExportDir returns the directory for JSON exports.
 func (c *LiteConfig) ExportDir() string {
 	return filepath.Join(c.DataDir, "exports")
 }
 
+// SurveillanceReportDir returns the directory for reclassification reports.
+func (c *LiteConfig) SurveillanceReportDir() string {
+	return filepath.Join(c.ExportDir(), "surveillance")
+}
+
 // EnsureDataDir creates the data directory if it doesn't exist.
 func (c *LiteConfig) EnsureDataDir() error {
 	if err := os.MkdirAll(c.DataDir, 0755); err != nil {
@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
 )
 
 func TestDefaultLiteConfig(t *testing.T) {
@@ -15,11 +17,49 @@ func TestDefaultLiteConfig(t *testing.T) {
 
 	assert.NotEmpty(t, cfg.DataDir)
 	assert.Equal(t, 1000, cfg.CacheMaxItems)
+	assert.Equal(t, int64(256<<20), cfg.CacheMaxBytes)
 	assert.Equal(t, 24*time.Hour, cfg.CacheTTL)
+	assert.Empty(t, cfg.CacheSpillFile)
+	assert.Equal(t, 1000, cfg.ResultCacheSize)
+	assert.Equal(t, time.Hour, cfg.ResultCacheTTL)
+	assert.Equal(t, 32, cfg.ExternalMaxConnsPerHost)
+	assert.Equal(t, 2, cfg.ExternalMaxRetries)
+	assert.Empty(t, cfg.ExternalHostTimeouts)
+	assert.Equal(t, 20*time.Second, cfg.EvidenceSourceDeadline)
+	assert.Empty(t, cfg.EvidenceSourceDeadlines)
 	assert.Equal(t, "stdio", cfg.Transport)
 	assert.Equal(t, 8080, cfg.HTTPPort)
+	assert.Equal(t, 256*1024, cfg.MaxResponseBytesStdio)
+	assert.Equal(t, 4*1024*1024, cfg.MaxResponseBytesHTTP)
+	assert.Equal(t, 500, cfg.BatchClassifyLimit)
+	assert.Equal(t, 8, cfg.BatchClassifyWorkers)
+	assert.Equal(t, 2, cfg.JobWorkers)
+	assert.Equal(t, 50000, cfg.JobMaxVariants)
+	assert.Equal(t, 30*time.Second, cfg.ShutdownTimeout)
+	assert.Empty(t, cfg.DatasetPins)
+	assert.Equal(t, "combining_rules", cfg.ScoringMode)
+	assert.Equal(t, "en", cfg.Locale)
+	assert.Equal(t, "GRCh38", cfg.GenomeAssembly)
+	assert.Equal(t, 50, cfg.CohortMinSize)
+	assert.Equal(t, 0.05, cfg.CohortArtifactFraction)
+	assert.Equal(t, 90*24*time.Hour, cfg.ArchiveAfter)
+	assert.Equal(t, 24*time.Hour, cfg.ArchiveInterval)
+	assert.False(t, cfg.EvidenceBundles)
+	assert.False(t, cfg.EvidenceMockFallback)
+	assert.False(t, cfg.RequireReview)
+	assert.Empty(t, cfg.AuditSigningKey)
+	assert.Equal(t, 24*time.Hour, cfg.LiteratureCheckInterval)
+	assert.Equal(t, time.Monday, cfg.DigestWeekday)
+	assert.Equal(t, 7, cfg.DigestHour)
 	assert.Equal(t, "info", cfg.LogLevel)
 	assert.Equal(t, "json", cfg.LogFormat)
+	assert.False(t, cfg.PHISafeLogging)
+	assert.Equal(t, "redact", cfg.PHIRedactionMode)
+	assert.Empty(t, cfg.PHIIdentifierFields)
+	assert.False(t, cfg.BeaconEnabled)
+	assert.Equal(t, "boolean", cfg.BeaconGranularity)
+	assert.Equal(t, "read_only=count,classify=record", cfg.BeaconRoleGranularity)
+	assert.Empty(t, cfg.GRPCAddr)
 }
 
 func TestLoadLiteConfig_Defaults(t *testing.T) {
@@ -31,6 +71,12 @@ func TestLoadLiteConfig_Defaults(t *testing.T) {
 	assert.NotEmpty(t, cfg.DataDir)
 	assert.Equal(t, 1000, cfg.CacheMaxItems)
 	assert.Equal(t, "stdio", cfg.Transport)
+	assert.False(t, cfg.AdminEnabled())
+	assert.False(t, cfg.DigestEmailEnabled())
+	assert.False(t, cfg.ConfigRepoEnabled())
+	assert.True(t, cfg.ConfigRepoVerifySignatures)
+	assert.False(t, cfg.VEPEnabled())
+	assert.Equal(t, "https://rest.ensembl.org", cfg.VEPURL)
 }
 
 func TestLoadLiteConfig_EnvironmentOverrides(t *testing.T) {
@@ -39,10 +85,71 @@ func TestLoadLiteConfig_EnvironmentOverrides(t *testing.T) {
 	// Set environment variables
 	os.Setenv("ACMG_DATA_DIR", "/tmp/test-acmg")
 	os.Setenv("ACMG_CACHE_MAX_ITEMS", "500")
+	os.Setenv("ACMG_CACHE_MAX_BYTES", "64MB")
+	os.Setenv("ACMG_CACHE_SPILL_FILE", "/tmp/test-acmg/evidence_cache.db")
 	os.Setenv("ACMG_CACHE_TTL", "12h")
+	os.Setenv("ACMG_CACHE_SOURCE_TTLS", "ClinVar=72h, gnomad=720h, pubmed=bogus")
+	os.Setenv("ACMG_CACHE_STALE_WINDOW", "6h")
+	os.Setenv("ACMG_RESULT_CACHE_SIZE", "0")
+	os.Setenv("ACMG_RESULT_CACHE_TTL", "10m")
+	os.Setenv("ACMG_EXTERNAL_MAX_CONNS_PER_HOST", "8")
+	os.Setenv("ACMG_EXTERNAL_MAX_RETRIES", "0")
+	os.Setenv("ACMG_EVIDENCE_SOURCE_DEADLINE", "5s")
+	os.Setenv("ACMG_EVIDENCE_SOURCE_DEADLINES", "PubMed=30s, clinvar=bogus")
+	os.Setenv("ACMG_EXTERNAL_HOST_TIMEOUTS", "eutils.ncbi.nlm.nih.gov=20s, Gnomad.broadinstitute.org=45s, rest.ensembl.org=-1s")
 	os.Setenv("ACMG_TRANSPORT", "http")
 	os.Setenv("ACMG_HTTP_PORT", "9090")
+	os.Setenv("ACMG_RATE_LIMIT_RPS", "2.5")
+	os.Setenv("ACMG_DAILY_QUOTA", "5000")
+	os.Setenv("ACMG_API_KEYS", "key-a, key-b")
+	os.Setenv("ACMG_AUTH_API_KEYS", "lims:classify:k1, malformed, dashboard:read_only:k2:with-colon")
+	os.Setenv("ACMG_AUTH_JWT_SECRET", "jwt-secret")
+	os.Setenv("ACMG_AUTH_JWT_AUDIENCE", "acmg-amp-mcp")
 	os.Setenv("ACMG_LOG_LEVEL", "debug")
+	os.Setenv("ACMG_MAX_RESPONSE_BYTES_STDIO", "65536")
+	os.Setenv("ACMG_BATCH_CLASSIFY_WORKERS", "16")
+	os.Setenv("ACMG_JOB_WORKERS", "4")
+	os.Setenv("ACMG_SHUTDOWN_TIMEOUT", "2m")
+	os.Setenv("ACMG_DATASET_PINS", "ClinVar=2024-05-01, gnomad = gnomad_r4, dbnsfp=, malformed")
+	os.Setenv("ACMG_SCORING_MODE", "Points")
+	os.Setenv("ACMG_LOCALE", " zh-TW ")
+	os.Setenv("ACMG_GENOME_ASSEMBLY", "GRCh37")
+	os.Setenv("ACMG_REFERENCE_FASTA", "/refs/hs37d5.fa")
+	os.Setenv("ACMG_CONSEQUENCE_ANNOTATOR", "VEP")
+	os.Setenv("ACMG_VEP_TRANSCRIPTS", "merged")
+	os.Setenv("ACMG_VEP_PLUGINS", "LoF, SpliceAI")
+	os.Setenv("ACMG_COHORT_MIN_SIZE", "200")
+	os.Setenv("ACMG_COHORT_ARTIFACT_FRACTION", "0.1")
+	os.Setenv("ACMG_ARCHIVE_AFTER", "720h")
+	os.Setenv("ACMG_EVIDENCE_BUNDLES", "true")
+	os.Setenv("ACMG_EVIDENCE_MOCK_FALLBACK", "true")
+	os.Setenv("ACMG_REQUIRE_REVIEW", "true")
+	os.Setenv("ACMG_AUDIT_SIGNING_KEY", " c2VlZA== ")
+	os.Setenv("ACMG_LITERATURE_CHECK_INTERVAL", "0")
+	os.Setenv("ACMG_SURVEILLANCE_SCHEDULE", " 0 2 * * 0 ")
+	os.Setenv("ACMG_SURVEILLANCE_MAX_VARIANTS", "250")
+	os.Setenv("ACMG_SENIOR_CURATORS", "alice, bob")
+	os.Setenv("ACMG_PHI_SAFE_LOGGING", "true")
+	os.Setenv("ACMG_PHI_REDACTION_MODE", " Tokenize ")
+	os.Setenv("ACMG_PHI_TOKEN_KEY", "token-key")
+	os.Setenv("ACMG_PHI_IDENTIFIER_FIELDS", "lims_accession, ")
+	os.Setenv("ACMG_PHI_FREE_TEXT_FIELDS", "referral_notes,ordering_notes")
+	os.Setenv("ACMG_BEACON_ENABLED", "true")
+	os.Setenv("ACMG_BEACON_ID", "org.example.lab.beacon")
+	os.Setenv("ACMG_BEACON_ORGANIZATION", " Example Genomics Lab ")
+	os.Setenv("ACMG_BEACON_GRANULARITY", "None")
+	os.Setenv("ACMG_BEACON_ROLE_GRANULARITY", "read_only=record")
+	os.Setenv("ACMG_GRPC_ADDR", "0.0.0.0:9443")
+	os.Setenv("ACMG_ADMIN_ADDR", "127.0.0.1:8090")
+	os.Setenv("ACMG_ADMIN_TOKEN", "admin-token")
+	os.Setenv("ACMG_DIGEST_SMTP_ADDR", "smtp.example.org:587")
+	os.Setenv("ACMG_DIGEST_EMAIL_FROM", "acmg@example.org")
+	os.Setenv("ACMG_DIGEST_EMAIL_TO", "lab@example.org, director@example.org")
+	os.Setenv("ACMG_DIGEST_WEEKDAY", "fri")
+	os.Setenv("ACMG_DIGEST_HOUR", "14")
+	os.Setenv("ACMG_CONFIG_REPO_URL", "git@example.org:lab/clinical-config.git")
+	os.Setenv("ACMG_CONFIG_REPO_INTERVAL", "1m")
+	os.Setenv("ACMG_CONFIG_REPO_VERIFY_SIGNATURES", "false")
 	os.Setenv("CLINVAR_API_KEY", "test-key")
 
 	defer clearEnvVars(t)
@@ -51,10 +158,78 @@ func TestLoadLiteConfig_EnvironmentOverrides(t *testing.T) {
 
 	assert.Equal(t, "/tmp/test-acmg", cfg.DataDir)
 	assert.Equal(t, 500, cfg.CacheMaxItems)
+	assert.Equal(t, int64(64<<20), cfg.CacheMaxBytes)
+	assert.Equal(t, "/tmp/test-acmg/evidence_cache.db", cfg.CacheSpillFile)
 	assert.Equal(t, 12*time.Hour, cfg.CacheTTL)
+	assert.Equal(t, map[string]time.Duration{"clinvar": 72 * time.Hour, "gnomad": 720 * time.Hour}, cfg.CacheSourceTTLs)
+	assert.Equal(t, 6*time.Hour, cfg.CacheStaleWindow)
+	assert.Equal(t, 0, cfg.ResultCacheSize)
+	assert.Equal(t, 10*time.Minute, cfg.ResultCacheTTL)
+	assert.Equal(t, 8, cfg.ExternalMaxConnsPerHost)
+	assert.Equal(t, 5*time.Second, cfg.EvidenceSourceDeadline)
+	assert.Equal(t, map[string]time.Duration{"pubmed": 30 * time.Second}, cfg.EvidenceSourceDeadlines)
+	assert.Equal(t, 0, cfg.ExternalMaxRetries)
+	assert.Equal(t, map[string]time.Duration{"eutils.ncbi.nlm.nih.gov": 20 * time.Second, "gnomad.broadinstitute.org": 45 * time.Second}, cfg.ExternalHostTimeouts)
 	assert.Equal(t, "http", cfg.Transport)
 	assert.Equal(t, 9090, cfg.HTTPPort)
+	assert.Equal(t, 2.5, cfg.RateLimitRPS)
+	assert.Equal(t, 20, cfg.RateLimitBurst)
+	assert.Equal(t, 5000, cfg.DailyQuota)
+	assert.Equal(t, []string{"key-a", "key-b"}, cfg.APIKeys)
+	assert.Equal(t, []domain.AuthAPIKey{
+		{Name: "lims", Role: "classify", Key: "k1"},
+		{Name: "dashboard", Role: "read_only", Key: "k2:with-colon"},
+	}, cfg.AuthAPIKeys)
+	assert.Equal(t, "jwt-secret", cfg.AuthJWTSecret)
+	assert.Equal(t, "acmg-amp-mcp", cfg.AuthJWTAudience)
+	assert.Empty(t, cfg.AuthAnonymousRole)
 	assert.Equal(t, "debug", cfg.LogLevel)
+	assert.True(t, cfg.PHISafeLogging)
+	assert.Equal(t, "tokenize", cfg.PHIRedactionMode)
+	assert.Equal(t, "token-key", cfg.PHITokenKey)
+	assert.Equal(t, []string{"lims_accession"}, cfg.PHIIdentifierFields)
+	assert.Equal(t, []string{"referral_notes", "ordering_notes"}, cfg.PHIFreeTextFields)
+	assert.True(t, cfg.BeaconEnabled)
+	assert.Equal(t, "org.example.lab.beacon", cfg.BeaconID)
+	assert.Equal(t, "ACMG/AMP lab variant beacon", cfg.BeaconName)
+	assert.Equal(t, "Example Genomics Lab", cfg.BeaconOrganization)
+	assert.Equal(t, "none", cfg.BeaconGranularity)
+	assert.Equal(t, "read_only=record", cfg.BeaconRoleGranularity)
+	assert.Equal(t, "0.0.0.0:9443", cfg.GRPCAddr)
+	assert.Equal(t, 65536, cfg.MaxResponseBytesStdio)
+	assert.Equal(t, 16, cfg.BatchClassifyWorkers)
+	assert.Equal(t, 4, cfg.JobWorkers)
+	assert.Equal(t, 2*time.Minute, cfg.ShutdownTimeout)
+	assert.Equal(t, map[string]string{"clinvar": "2024-05-01", "gnomad": "gnomad_r4"}, cfg.DatasetPins)
+	assert.Equal(t, "points", cfg.ScoringMode)
+	assert.Equal(t, "zh-TW", cfg.Locale)
+	assert.Equal(t, "GRCh37", cfg.GenomeAssembly)
+	assert.Equal(t, "/refs/hs37d5.fa", cfg.ReferenceFastaPath())
+	assert.True(t, cfg.VEPEnabled())
+	assert.Equal(t, "merged", cfg.VEPTranscripts)
+	assert.Equal(t, []string{"LoF", "SpliceAI"}, cfg.VEPPlugins)
+	assert.Equal(t, 200, cfg.CohortMinSize)
+	assert.Equal(t, 0.1, cfg.CohortArtifactFraction)
+	assert.Equal(t, 720*time.Hour, cfg.ArchiveAfter)
+	assert.True(t, cfg.EvidenceBundles)
+	assert.True(t, cfg.EvidenceMockFallback)
+	assert.True(t, cfg.RequireReview)
+	assert.Equal(t, "c2VlZA==", cfg.AuditSigningKey)
+	assert.Zero(t, cfg.LiteratureCheckInterval, "0 disables the literature check")
+	assert.Equal(t, "0 2 * * 0", cfg.SurveillanceSchedule)
+	assert.Equal(t, 250, cfg.SurveillanceMaxVariants)
+	assert.Equal(t, []string{"alice", "bob"}, cfg.SeniorCurators)
+	assert.Equal(t, "127.0.0.1:8090", cfg.AdminAddr)
+	assert.True(t, cfg.AdminEnabled())
+	assert.Equal(t, []string{"lab@example.org", "director@example.org"}, cfg.DigestEmailTo)
+	assert.True(t, cfg.DigestEmailEnabled())
+	assert.Equal(t, time.Friday, cfg.DigestWeekday)
+	assert.Equal(t, 14, cfg.DigestHour)
+	assert.True(t, cfg.ConfigRepoEnabled())
+	assert.Equal(t, "main", cfg.ConfigRepoBranch)
+	assert.Equal(t, time.Minute, cfg.ConfigRepoInterval)
+	assert.False(t, cfg.ConfigRepoVerifySignatures)
+	assert.Equal(t, filepath.Join("/tmp/test-acmg", "config_repo"), cfg.ConfigRepoDir())
 	assert.Equal(t, "test-key", cfg.ClinVarAPIKey)
 }
 
@@ -72,6 +247,69 @@ func TestLiteConfig_ExportDir(t *testing.T) {
 	path := cfg.ExportDir()
 
 	assert.Equal(t, "/home/user/.acmg-amp-mcp/exports", path)
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/exports/surveillance", cfg.SurveillanceReportDir())
+}
+
+func TestLiteConfig_SnapshotPaths(t *testing.T) {
+	cfg := &LiteConfig{DataDir: "/home/user/.acmg-amp-mcp"}
+
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/evidence.db", cfg.SnapshotDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/archive", cfg.ArchiveDir())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/playbooks.db", cfg.PlaybookDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/thresholds.db", cfg.ThresholdsDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/cohort.db", cfg.CohortDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/artifacts.db", cfg.ArtifactsDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/audit.db", cfg.AuditDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/jobs.db", cfg.JobsDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/classification_sessions.db", cfg.ClassificationSessionsDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/reviews.db", cfg.ReviewsDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/literature.db", cfg.LiteratureDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/known_benign.db", cfg.KnownBenignDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/lab_knowledge.db", cfg.LabKnowledgeDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/identifiers.db", cfg.IdentifiersDBPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/specifications", cfg.SpecificationsDir())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/regions", cfg.RegionTracksDir())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/cnv", cfg.CNVAnnotationsDir())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/pgx", cfg.PGxTablesDir())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/gnomad_constraint.tsv", cfg.ConstraintPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/transcript_sets", cfg.TranscriptSetsDir())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/protein_domains", cfg.ProteinDomainsDir())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/hpo", cfg.HPODir())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/orphanet", cfg.OrphanetPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/frequency_thresholds.yaml", cfg.FrequencyThresholdsPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/conflict_policies.yaml", cfg.ConflictPoliciesPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/weighting_policy.yaml", cfg.WeightingPolicyPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/predictor_calibration.yaml", cfg.CalibrationPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/reference/genome.fa", cfg.ReferenceFastaPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/reference/transcripts.gtf.gz", cfg.TranscriptGTFPath())
+	assert.Equal(t, "/home/user/.acmg-amp-mcp/liftover", cfg.LiftoverDir())
+
+	cfg.VCEPSpecDir = "/etc/acmg/vcep"
+	assert.Equal(t, "/etc/acmg/vcep", cfg.SpecificationsDir())
+	cfg.RegionTrackDir = "/etc/acmg/regions"
+	assert.Equal(t, "/etc/acmg/regions", cfg.RegionTracksDir())
+	cfg.CNVAnnotationDir = "/etc/acmg/cnv"
+	assert.Equal(t, "/etc/acmg/cnv", cfg.CNVAnnotationsDir())
+	cfg.PGxDir = "/etc/acmg/pgx"
+	assert.Equal(t, "/etc/acmg/pgx", cfg.PGxTablesDir())
+	cfg.ConstraintFile = "/etc/acmg/constraint_metrics.tsv.gz"
+	assert.Equal(t, "/etc/acmg/constraint_metrics.tsv.gz", cfg.ConstraintPath())
+	cfg.TranscriptSetDir = "/etc/acmg/transcripts"
+	assert.Equal(t, "/etc/acmg/transcripts", cfg.TranscriptSetsDir())
+	cfg.ProteinDomainDir = "/etc/acmg/domains"
+	assert.Equal(t, "/etc/acmg/domains", cfg.ProteinDomainsDir())
+	cfg.PhenotypeDir = "/etc/acmg/hpo"
+	assert.Equal(t, "/etc/acmg/hpo", cfg.HPODir())
+	cfg.OrphanetDir = "/etc/acmg/orphadata"
+	assert.Equal(t, "/etc/acmg/orphadata", cfg.OrphanetPath())
+	cfg.FrequencyThresholdsFile = "/etc/acmg/frequency_thresholds.json"
+	assert.Equal(t, "/etc/acmg/frequency_thresholds.json", cfg.FrequencyThresholdsPath())
+	cfg.ConflictPoliciesFile = "/etc/acmg/conflict_policies.json"
+	assert.Equal(t, "/etc/acmg/conflict_policies.json", cfg.ConflictPoliciesPath())
+	cfg.WeightingPolicyFile = "/etc/acmg/weighting_policy.json"
+	assert.Equal(t, "/etc/acmg/weighting_policy.json", cfg.WeightingPolicyPath())
+	cfg.CalibrationFile = "/etc/acmg/predictor_calibration.json"
+	assert.Equal(t, "/etc/acmg/predictor_calibration.json", cfg.CalibrationPath())
 }
 
 func TestLiteConfig_EnsureDataDir(t *testing.T) {
@@ -96,14 +334,105 @@ func clearEnvVars(t *testing.T) {
 	t.Helper()
 	vars := []string{
 		"ACMG_DATA_DIR",
+		"ACMG_CONSEQUENCE_ANNOTATOR",
+		"ACMG_VEP_URL",
+		"ACMG_VEP_TRANSCRIPTS",
+		"ACMG_VEP_PLUGINS",
 		"ACMG_CACHE_MAX_ITEMS",
+		"ACMG_CACHE_MAX_BYTES",
+		"ACMG_CACHE_SPILL_FILE",
 		"ACMG_CACHE_TTL",
+		"ACMG_CACHE_SOURCE_TTLS",
+		"ACMG_CACHE_STALE_WINDOW",
+		"ACMG_RESULT_CACHE_SIZE",
+		"ACMG_RESULT_CACHE_TTL",
+		"ACMG_EXTERNAL_MAX_CONNS_PER_HOST",
+		"ACMG_EXTERNAL_MAX_RETRIES",
+		"ACMG_EXTERNAL_HOST_TIMEOUTS",
+		"ACMG_EVIDENCE_SOURCE_DEADLINE",
+		"ACMG_EVIDENCE_SOURCE_DEADLINES",
 		"ACMG_TRANSPORT",
 		"ACMG_HTTP_PORT",
+		"ACMG_RATE_LIMIT_RPS",
+		"ACMG_RATE_LIMIT_BURST",
+		"ACMG_DAILY_QUOTA",
+		"ACMG_API_KEYS",
+		"ACMG_AUTH_API_KEYS",
+		"ACMG_AUTH_JWT_SECRET",
+		"ACMG_AUTH_JWT_ISSUER",
+		"ACMG_AUTH_JWT_AUDIENCE",
+		"ACMG_AUTH_ANONYMOUS_ROLE",
 		"ACMG_LOG_LEVEL",
 		"ACMG_LOG_FORMAT",
+		"ACMG_PHI_SAFE_LOGGING",
+		"ACMG_PHI_REDACTION_MODE",
+		"ACMG_PHI_TOKEN_KEY",
+		"ACMG_PHI_IDENTIFIER_FIELDS",
+		"ACMG_PHI_FREE_TEXT_FIELDS",
+		"ACMG_MAX_RESPONSE_BYTES_STDIO",
+		"ACMG_MAX_RESPONSE_BYTES_HTTP",
+		"ACMG_BATCH_CLASSIFY_LIMIT",
+		"ACMG_BATCH_CLASSIFY_WORKERS",
+		"ACMG_JOB_WORKERS",
+		"ACMG_JOB_MAX_VARIANTS",
+		"ACMG_SHUTDOWN_TIMEOUT",
+		"ACMG_DATASET_PINS",
+		"ACMG_SCORING_MODE",
+		"ACMG_LOCALE",
+		"ACMG_GENOME_ASSEMBLY",
+		"ACMG_REFERENCE_FASTA",
+		"ACMG_TRANSCRIPT_GTF",
+		"ACMG_LIFTOVER_DIR",
+		"ACMG_VCEP_SPEC_DIR",
+		"ACMG_REGION_TRACK_DIR",
+		"ACMG_TRANSCRIPT_SET_DIR",
+		"ACMG_PROTEIN_DOMAIN_DIR",
+		"ACMG_HPO_DIR",
+		"ACMG_ORPHANET_DIR",
+		"ACMG_FREQUENCY_THRESHOLDS_FILE",
+		"ACMG_CONFLICT_POLICIES_FILE",
+		"ACMG_WEIGHTING_POLICY_FILE",
+		"ACMG_PREDICTOR_CALIBRATION_FILE",
+		"ACMG_CNV_ANNOTATION_DIR",
+		"ACMG_PGX_DIR",
+		"ACMG_CONFIG_REPO_URL",
+		"ACMG_CONFIG_REPO_BRANCH",
+		"ACMG_CONFIG_REPO_INTERVAL",
+		"ACMG_CONFIG_REPO_VERIFY_SIGNATURES",
+		"ACMG_CONFIG_REPO_ALLOWED_SIGNERS",
+		"ACMG_COHORT_MIN_SIZE",
+		"ACMG_COHORT_ARTIFACT_FRACTION",
+		"ACMG_ARCHIVE_AFTER",
+		"ACMG_ARCHIVE_INTERVAL",
+		"ACMG_EVIDENCE_BUNDLES",
+		"ACMG_EVIDENCE_MOCK_FALLBACK",
+		"ACMG_REQUIRE_REVIEW",
+		"ACMG_AUDIT_SIGNING_KEY",
+		"ACMG_LITERATURE_CHECK_INTERVAL",
+		"ACMG_SURVEILLANCE_SCHEDULE",
+		"ACMG_SURVEILLANCE_MAX_VARIANTS",
+		"ACMG_SENIOR_CURATORS",
+		"ACMG_ADMIN_ADDR",
+		"ACMG_ADMIN_TOKEN",
+		"ACMG_BEACON_ENABLED",
+		"ACMG_BEACON_ID",
+		"ACMG_BEACON_NAME",
+		"ACMG_BEACON_ORGANIZATION",
+		"ACMG_BEACON_GRANULARITY",
+		"ACMG_BEACON_ROLE_GRANULARITY",
+		"ACMG_GRPC_ADDR",
+		"ACMG_DIGEST_SLACK_WEBHOOK",
+		"ACMG_DIGEST_SMTP_ADDR",
+		"ACMG_DIGEST_SMTP_USER",
+		"ACMG_DIGEST_SMTP_PASSWORD",
+		"ACMG_DIGEST_EMAIL_FROM",
+		"ACMG_DIGEST_EMAIL_TO",
+		"ACMG_DIGEST_WEEKDAY",
+		"ACMG_DIGEST_HOUR",
 		"CLINVAR_API_KEY",
 		"COSMIC_API_KEY",
+		"ONCOKB_API_TOKEN",
+		"OMIM_API_KEY",
 	}
 	for _, v := range vars {
 		os.Unsetenv(v)
@@ -0,0 +1,108 @@
+// Package configrepo loads the lab's clinical configuration (gene panels,
+// gene disease models and rule customizations) from a Git repository. The
+// repository is fetched periodically and, when signature verification is
+// enabled, only signed commits are applied, so configuration changes go through
+// the lab's existing review workflow. Every classification records the commit
+// it was made with.
+package configrepo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/acmg-amp-mcp-server/internal/regions"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+	"github.com/acmg-amp-mcp-server/internal/transcriptset"
+	"github.com/acmg-amp-mcp-server/internal/vcep"
+)
+
+// Repository layout. Every entry is optional; a missing one loads empty.
+const (
+	SpecificationsDir       = "specifications"            // VCEP rule specifications
+	TranscriptSetsDir       = "transcript_sets"           // Per-specialty gene panels and their transcripts
+	RegionTracksDir         = "regions"                   // Problematic region BED tracks
+	FrequencyThresholdsFile = "frequency_thresholds.yaml" // Per-gene and per-condition frequency thresholds
+	GeneModelsFile          = "gene_models.yaml"          // Gene disease models used by PVS1 and frequency thresholds
+)
+
+// ErrInvalidConfig is returned when a commit's configuration fails validation.
+var ErrInvalidConfig = errors.New("invalid clinical configuration")
+
+// Snapshot is the configuration loaded from one commit.
+type Snapshot struct {
+	Commit             string
+	LoadedAt           time.Time
+	Specifications     *vcep.Registry
+	TranscriptSets     *transcriptset.Registry
+	RegionTracks       *regions.Tracks
+	FrequencyOverrides *thresholds.FrequencyOverrides
+	GeneModels         map[string]thresholds.GeneDiseaseModel // Keyed by upper-case gene symbol
+}
+
+// geneModelsDocument is the gene_models.yaml file format
+type geneModelsDocument struct {
+	GeneModels map[string]thresholds.GeneDiseaseModel `json:"gene_models" yaml:"gene_models"`
+}
+
+// LoadSnapshot loads and validates the configuration checked out in dir.
+func LoadSnapshot(dir, commit string) (*Snapshot, error) {
+	snapshot := &Snapshot{Commit: commit, LoadedAt: time.Now()}
+
+	var err error
+	if snapshot.Specifications, err = vcep.LoadDir(filepath.Join(dir, SpecificationsDir)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if snapshot.TranscriptSets, err = transcriptset.LoadDir(filepath.Join(dir, TranscriptSetsDir)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if snapshot.RegionTracks, err = regions.LoadDir(filepath.Join(dir, RegionTracksDir)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if snapshot.FrequencyOverrides, err = thresholds.LoadFrequencyOverrides(filepath.Join(dir, FrequencyThresholdsFile)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if snapshot.GeneModels, err = loadGeneModels(filepath.Join(dir, GeneModelsFile)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	return snapshot, nil
+}
+
+// loadGeneModels reads gene disease models; a missing file yields none
+func loadGeneModels(path string) (map[string]thresholds.GeneDiseaseModel, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gene models: %w", err)
+	}
+
+	var doc geneModelsDocument
+	unmarshal := yaml.Unmarshal
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		unmarshal = json.Unmarshal
+	}
+	if err := unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse gene models: %w", err)
+	}
+
+	models := make(map[string]thresholds.GeneDiseaseModel, len(doc.GeneModels))
+	for gene, model := range doc.GeneModels {
+		models[strings.ToUpper(strings.TrimSpace(gene))] = model
+	}
+
+	// Validate the models the same way a threshold revision would
+	check := thresholds.Defaults()
+	check.GeneModels = models
+	if err := check.Validate(); err != nil {
+		return nil, err
+	}
+	return models, nil
+}
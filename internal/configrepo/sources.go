@@ -0,0 +1,131 @@
+package configrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/regions"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+	"github.com/acmg-amp-mcp-server/internal/transcriptset"
+	"github.com/acmg-amp-mcp-server/internal/vcep"
+)
+
+// The sources below read the syncer's current snapshot on every lookup, so the
+// classifier picks up a reload without being rewired.
+
+// SpecificationSource looks up VCEP rule specifications in the current snapshot.
+type SpecificationSource struct{ syncer *Syncer }
+
+// Specifications returns a specification source backed by the syncer.
+func (s *Syncer) Specifications() *SpecificationSource {
+	return &SpecificationSource{syncer: s}
+}
+
+// Lookup returns the specification for a gene, or nil.
+func (src *SpecificationSource) Lookup(gene string) *vcep.Specification {
+	if snapshot := src.syncer.Current(); snapshot != nil {
+		return snapshot.Specifications.Lookup(gene)
+	}
+	return nil
+}
+
+// TranscriptSetSource looks up specialty gene panels in the current snapshot.
+type TranscriptSetSource struct{ syncer *Syncer }
+
+// TranscriptSets returns a transcript set source backed by the syncer.
+func (s *Syncer) TranscriptSets() *TranscriptSetSource {
+	return &TranscriptSetSource{syncer: s}
+}
+
+// Lookup returns the transcript a specialty mandates for a gene.
+func (src *TranscriptSetSource) Lookup(specialty, gene string) (string, error) {
+	snapshot := src.syncer.Current()
+	if snapshot == nil {
+		return "", transcriptset.ErrUnknownSpecialty
+	}
+	return snapshot.TranscriptSets.Lookup(specialty, gene)
+}
+
+// RegionSource annotates problematic regions from the current snapshot.
+type RegionSource struct{ syncer *Syncer }
+
+// RegionTracks returns a region source backed by the syncer.
+func (s *Syncer) RegionTracks() *RegionSource {
+	return &RegionSource{syncer: s}
+}
+
+// AnnotateHGVS returns the caveats for the regions a variant overlaps.
+func (src *RegionSource) AnnotateHGVS(hgvs string) []regions.Caveat {
+	if snapshot := src.syncer.Current(); snapshot != nil {
+		return snapshot.RegionTracks.AnnotateHGVS(hgvs)
+	}
+	return nil
+}
+
+// FrequencyOverrideSource looks up frequency thresholds in the current snapshot.
+type FrequencyOverrideSource struct{ syncer *Syncer }
+
+// FrequencyOverrides returns a frequency override source backed by the syncer.
+func (s *Syncer) FrequencyOverrides() *FrequencyOverrideSource {
+	return &FrequencyOverrideSource{syncer: s}
+}
+
+// Lookup returns the most specific override for a gene and condition, or nil.
+func (src *FrequencyOverrideSource) Lookup(gene, condition string) *thresholds.FrequencyOverride {
+	if snapshot := src.syncer.Current(); snapshot != nil {
+		return snapshot.FrequencyOverrides.Lookup(gene, condition)
+	}
+	return nil
+}
+
+// RevisionSource supplies the threshold revision in effect, such as the
+// threshold store.
+type RevisionSource interface {
+	Effective(ctx context.Context, at time.Time) (*thresholds.Revision, error)
+}
+
+// ThresholdSource overlays the repository's gene disease models on the
+// threshold revision in effect. Repository models replace a revision's model
+// for the same gene; other thresholds come from the revision unchanged.
+type ThresholdSource struct {
+	syncer *Syncer
+	base   RevisionSource
+}
+
+// Thresholds returns a threshold source that adds the repository's gene
+// models to base, which may be nil for the built-in defaults.
+func (s *Syncer) Thresholds(base RevisionSource) *ThresholdSource {
+	return &ThresholdSource{syncer: s, base: base}
+}
+
+// Effective returns the revision in effect at the given time with the
+// repository's gene models applied.
+func (src *ThresholdSource) Effective(ctx context.Context, at time.Time) (*thresholds.Revision, error) {
+	var revision *thresholds.Revision
+	if src.base != nil {
+		var err error
+		if revision, err = src.base.Effective(ctx, at); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot := src.syncer.Current()
+	if snapshot == nil || len(snapshot.GeneModels) == 0 {
+		return revision, nil
+	}
+
+	// Copy so the store's revision is never modified
+	merged := thresholds.Revision{Thresholds: thresholds.Defaults()}
+	if revision != nil {
+		merged = *revision
+	}
+	models := make(map[string]thresholds.GeneDiseaseModel, len(merged.Thresholds.GeneModels)+len(snapshot.GeneModels))
+	for gene, model := range merged.Thresholds.GeneModels {
+		models[gene] = model
+	}
+	for gene, model := range snapshot.GeneModels {
+		models[gene] = model
+	}
+	merged.Thresholds.GeneModels = models
+	return &merged, nil
+}
@@ -0,0 +1,229 @@
+package configrepo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrUnverifiedCommit is returned when signature verification is enabled and
+// the fetched commit is unsigned or signed by an unknown key.
+var ErrUnverifiedCommit = errors.New("config commit signature verification failed")
+
+// Default settings
+const (
+	DefaultBranch   = "main"
+	DefaultInterval = 5 * time.Minute
+)
+
+// Options configures the config repository.
+type Options struct {
+	URL                string        // Remote repository URL or path
+	Branch             string        // Branch to follow; defaults to main
+	Dir                string        // Local checkout directory
+	Interval           time.Duration // Fetch interval; defaults to 5 minutes
+	VerifySignatures   bool          // Apply only commits with a valid GPG or SSH signature
+	AllowedSignersFile string        // SSH allowed signers file for git verify-commit; GPG uses the keyring
+}
+
+// Syncer keeps a local checkout of the config repository and the snapshot
+// loaded from its verified head. Lookups read the snapshot lock-free, so a
+// reload never blocks classification.
+type Syncer struct {
+	options  Options
+	logger   *logrus.Logger
+	current  atomic.Pointer[Snapshot]
+	onReload []func(*Snapshot)
+}
+
+// NewSyncer creates a syncer; call Start to load the initial configuration.
+func NewSyncer(options Options, logger *logrus.Logger) (*Syncer, error) {
+	if options.URL == "" {
+		return nil, fmt.Errorf("config repository URL is required")
+	}
+	if options.Dir == "" {
+		return nil, fmt.Errorf("config repository directory is required")
+	}
+	if options.Branch == "" {
+		options.Branch = DefaultBranch
+	}
+	if options.Interval <= 0 {
+		options.Interval = DefaultInterval
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("config repository requires git: %w", err)
+	}
+	return &Syncer{options: options, logger: logger}, nil
+}
+
+// OnReload registers a callback run after each new snapshot is applied.
+func (s *Syncer) OnReload(fn func(*Snapshot)) {
+	s.onReload = append(s.onReload, fn)
+}
+
+// Current returns the snapshot in effect, or nil before the first sync.
+func (s *Syncer) Current() *Snapshot {
+	return s.current.Load()
+}
+
+// ConfigCommit returns the commit of the configuration in effect.
+func (s *Syncer) ConfigCommit() string {
+	if snapshot := s.Current(); snapshot != nil {
+		return snapshot.Commit
+	}
+	return ""
+}
+
+// Start performs the initial sync. When the remote cannot be fetched it falls
+// back to the existing checkout, so the server can start offline with the
+// configuration it last applied.
+func (s *Syncer) Start(ctx context.Context) error {
+	_, err := s.Sync(ctx)
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrUnverifiedCommit) || errors.Is(err, ErrInvalidConfig) {
+		return err
+	}
+
+	commit, headErr := s.git(ctx, "rev-parse", "HEAD")
+	if headErr != nil {
+		return err
+	}
+	s.logger.WithError(err).WithField("commit", commit).Warn("Failed to fetch config repository, using existing checkout")
+	return s.apply(ctx, commit)
+}
+
+// Sync fetches the branch and applies its head if it changed. It reports
+// whether a new snapshot was applied; on error the previous one stays in effect.
+func (s *Syncer) Sync(ctx context.Context) (bool, error) {
+	if err := s.ensureRepository(ctx); err != nil {
+		return false, err
+	}
+
+	ref := "refs/remotes/origin/" + s.options.Branch
+	if _, err := s.git(ctx, "fetch", "--quiet", "origin", "+refs/heads/"+s.options.Branch+":"+ref); err != nil {
+		return false, fmt.Errorf("failed to fetch config repository: %w", err)
+	}
+	commit, err := s.git(ctx, "rev-parse", ref+"^{commit}")
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve %s: %w", s.options.Branch, err)
+	}
+
+	if current := s.Current(); current != nil && current.Commit == commit {
+		return false, nil
+	}
+	if err := s.apply(ctx, commit); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Run syncs on the configured interval until the context is cancelled.
+func (s *Syncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := s.Sync(ctx); err != nil && ctx.Err() == nil {
+			s.logger.WithError(err).WithField("commit", s.ConfigCommit()).Error("Failed to reload clinical configuration, keeping current")
+		}
+	}
+}
+
+// apply verifies a commit, checks it out and loads its configuration
+func (s *Syncer) apply(ctx context.Context, commit string) error {
+	if s.options.VerifySignatures {
+		args := []string{"verify-commit", commit}
+		if s.options.AllowedSignersFile != "" {
+			args = append([]string{"-c", "gpg.ssh.allowedSignersFile=" + s.options.AllowedSignersFile}, args...)
+		}
+		if _, err := s.git(ctx, args...); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrUnverifiedCommit, shortCommit(commit), err)
+		}
+	}
+
+	if _, err := s.git(ctx, "checkout", "--quiet", "--force", "--detach", commit); err != nil {
+		return fmt.Errorf("failed to check out %s: %w", shortCommit(commit), err)
+	}
+	snapshot, err := LoadSnapshot(s.options.Dir, commit)
+	if err != nil {
+		return fmt.Errorf("commit %s: %w", shortCommit(commit), err)
+	}
+
+	previous := s.current.Swap(snapshot)
+	fields := logrus.Fields{"commit": commit, "specifications": len(snapshot.Specifications.List()), "gene_models": len(snapshot.GeneModels)}
+	if previous != nil {
+		fields["previous_commit"] = previous.Commit
+	}
+	s.logger.WithFields(fields).Info("Applied clinical configuration")
+
+	for _, fn := range s.onReload {
+		fn(snapshot)
+	}
+	return nil
+}
+
+// ensureRepository initializes the checkout directory and points origin at the URL
+func (s *Syncer) ensureRepository(ctx context.Context) error {
+	if _, err := os.Stat(filepath.Join(s.options.Dir, ".git")); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(s.options.Dir, 0755); err != nil {
+			return fmt.Errorf("failed to create config repository directory: %w", err)
+		}
+		if _, err := s.git(ctx, "init", "--quiet"); err != nil {
+			return fmt.Errorf("failed to initialize config repository: %w", err)
+		}
+	}
+
+	if _, err := s.git(ctx, "remote", "set-url", "origin", s.options.URL); err != nil {
+		if _, err := s.git(ctx, "remote", "add", "origin", s.options.URL); err != nil {
+			return fmt.Errorf("failed to configure config repository remote: %w", err)
+		}
+	}
+	return nil
+}
+
+// git runs a git command in the checkout directory and returns its trimmed output
+func (s *Syncer) git(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = s.options.Dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		command := args[0]
+		if command == "-c" && len(args) > 2 {
+			command = args[2]
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("git %s: %s", command, message)
+		}
+		return "", fmt.Errorf("git %s: %w", command, err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// shortCommit abbreviates a commit hash for messages
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
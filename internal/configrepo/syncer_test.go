@@ -0,0 +1,214 @@
+package configrepo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+// testRepo is an origin repository the syncer fetches from
+type testRepo struct {
+	t   *testing.T
+	dir string
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := &testRepo{t: t, dir: t.TempDir()}
+	repo.git("init", "--quiet", "--initial-branch=main")
+	return repo
+}
+
+func (r *testRepo) git(args ...string) string {
+	r.t.Helper()
+	base := []string{"-c", "user.name=Curator", "-c", "user.email=curator@example.org", "-c", "commit.gpgsign=false"}
+	cmd := exec.Command("git", append(base, args...)...)
+	cmd.Dir = r.dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(r.t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// commit writes a file and commits it, returning the commit hash
+func (r *testRepo) commit(name, content string, extra ...string) string {
+	r.t.Helper()
+	require.NoError(r.t, os.WriteFile(filepath.Join(r.dir, name), []byte(content), 0644))
+	r.git("add", name)
+	r.git(append([]string{"commit", "--quiet", "-m", "Update " + name}, extra...)...)
+	return r.git("rev-parse", "HEAD")
+}
+
+func newTestSyncer(t *testing.T, repo *testRepo, verify bool) *Syncer {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	syncer, err := NewSyncer(Options{URL: repo.dir, Dir: filepath.Join(t.TempDir(), "checkout"), VerifySignatures: verify}, logger)
+	require.NoError(t, err)
+	return syncer
+}
+
+const scn5aModel = `gene_models:
+  scn5a:
+    disease: Brugada syndrome
+    inheritance: AD
+    mechanism: gain_of_function
+`
+
+func TestSyncer_LoadsAndReloads(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	first := repo.commit(GeneModelsFile, scn5aModel)
+
+	syncer := newTestSyncer(t, repo, false)
+	var reloads []string
+	syncer.OnReload(func(s *Snapshot) { reloads = append(reloads, s.Commit) })
+	require.NoError(t, syncer.Start(ctx))
+
+	assert.Equal(t, first, syncer.ConfigCommit())
+	assert.Equal(t, thresholds.MechanismGainOfFunction, syncer.Current().GeneModels["SCN5A"].Mechanism)
+
+	changed, err := syncer.Sync(ctx)
+	require.NoError(t, err)
+	assert.False(t, changed, "an unchanged branch is not reloaded")
+
+	second := repo.commit(GeneModelsFile, scn5aModel+`  MYH7:
+    inheritance: AD
+    mechanism: dominant_negative
+`)
+	changed, err = syncer.Sync(ctx)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, second, syncer.ConfigCommit())
+	assert.Len(t, syncer.Current().GeneModels, 2)
+	assert.Equal(t, []string{first, second}, reloads)
+}
+
+func TestSyncer_InvalidConfigKeepsCurrent(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	good := repo.commit(GeneModelsFile, scn5aModel)
+
+	syncer := newTestSyncer(t, repo, false)
+	require.NoError(t, syncer.Start(ctx))
+
+	repo.commit(GeneModelsFile, "gene_models:\n  SCN5A:\n    inheritance: AD\n    mechanism: mostly_harmful\n")
+	changed, err := syncer.Sync(ctx)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.False(t, changed)
+	assert.Equal(t, good, syncer.ConfigCommit())
+}
+
+func TestSyncer_RejectsUnsignedCommits(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.commit(GeneModelsFile, scn5aModel)
+
+	syncer := newTestSyncer(t, repo, true)
+	err := syncer.Start(context.Background())
+	assert.ErrorIs(t, err, ErrUnverifiedCommit)
+	assert.Nil(t, syncer.Current())
+}
+
+func TestSyncer_AcceptsSSHSignedCommits(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not installed")
+	}
+	repo := newTestRepo(t)
+	keyDir := t.TempDir()
+	key := filepath.Join(keyDir, "curator")
+	out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "curator", "-f", key).CombinedOutput()
+	require.NoError(t, err, string(out))
+	publicKey, err := os.ReadFile(key + ".pub")
+	require.NoError(t, err)
+	allowedSigners := filepath.Join(keyDir, "allowed_signers")
+	require.NoError(t, os.WriteFile(allowedSigners, []byte("curator@example.org "+string(publicKey)), 0644))
+
+	repo.git("config", "gpg.format", "ssh")
+	repo.git("config", "user.signingkey", key)
+	signed := repo.commit(GeneModelsFile, scn5aModel, "-S")
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	syncer, err := NewSyncer(Options{
+		URL: repo.dir, Dir: filepath.Join(t.TempDir(), "checkout"),
+		VerifySignatures: true, AllowedSignersFile: allowedSigners,
+	}, logger)
+	require.NoError(t, err)
+	require.NoError(t, syncer.Start(context.Background()))
+	assert.Equal(t, signed, syncer.ConfigCommit())
+
+	// An unsigned commit on top is not applied
+	repo.commit(GeneModelsFile, scn5aModel+"  MYH7:\n    inheritance: AD\n    mechanism: dominant_negative\n")
+	_, err = syncer.Sync(context.Background())
+	assert.ErrorIs(t, err, ErrUnverifiedCommit)
+	assert.Equal(t, signed, syncer.ConfigCommit())
+}
+
+func TestSyncer_StartFallsBackToCheckout(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	commit := repo.commit(GeneModelsFile, scn5aModel)
+
+	checkout := filepath.Join(t.TempDir(), "checkout")
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	syncer, err := NewSyncer(Options{URL: repo.dir, Dir: checkout}, logger)
+	require.NoError(t, err)
+	require.NoError(t, syncer.Start(ctx))
+
+	// Restarted with the remote unreachable
+	offline, err := NewSyncer(Options{URL: filepath.Join(t.TempDir(), "missing"), Dir: checkout}, logger)
+	require.NoError(t, err)
+	require.NoError(t, offline.Start(ctx))
+	assert.Equal(t, commit, offline.ConfigCommit())
+}
+
+type stubRevisionSource struct{ revision *thresholds.Revision }
+
+func (s stubRevisionSource) Effective(context.Context, time.Time) (*thresholds.Revision, error) {
+	return s.revision, nil
+}
+
+func TestThresholdSource_OverlaysGeneModels(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.commit(GeneModelsFile, scn5aModel)
+	syncer := newTestSyncer(t, repo, false)
+
+	// Before the first sync the base revision passes through
+	base := thresholds.Defaults()
+	base.GeneModels = map[string]thresholds.GeneDiseaseModel{
+		"SCN5A": {Inheritance: "AD", Mechanism: thresholds.MechanismLossOfFunction},
+		"TTN":   {Inheritance: "AD", Mechanism: thresholds.MechanismLossOfFunction},
+	}
+	stored := &thresholds.Revision{ID: 4, Thresholds: base}
+	source := syncer.Thresholds(stubRevisionSource{revision: stored})
+	revision, err := source.Effective(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Same(t, stored, revision)
+
+	require.NoError(t, syncer.Start(context.Background()))
+	revision, err = source.Effective(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), revision.ID)
+	assert.Equal(t, thresholds.MechanismGainOfFunction, revision.Thresholds.GeneModels["SCN5A"].Mechanism)
+	assert.Contains(t, revision.Thresholds.GeneModels, "TTN")
+	assert.Equal(t, thresholds.MechanismLossOfFunction, stored.Thresholds.GeneModels["SCN5A"].Mechanism, "the stored revision is not modified")
+
+	// Without a stored revision the repository models apply to the defaults
+	revision, err = syncer.Thresholds(nil).Effective(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), revision.ID)
+	assert.Len(t, revision.Thresholds.GeneModels, 1)
+}
@@ -0,0 +1,144 @@
+// Package conflicts detects ACMG/AMP criteria that contradict each other in
+// one evaluation, such as PS3 with BS3 or PM2 with a common ancestry group
+// frequency, and resolves them with configurable policies instead of letting
+// the combining rules silently weigh both. Every conflict found is reported
+// as a Decision naming the policy applied and what it changed.
+package conflicts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidPolicy is returned when a conflict policy fails validation.
+var ErrInvalidPolicy = errors.New("invalid conflict policy")
+
+// Resolution policies
+const (
+	// PolicyFlagForReview keeps both criteria and flags the conflict for curator review
+	PolicyFlagForReview = "flag_for_review"
+	// PolicyPreferStrongerReview keeps the criterion backed by the stronger
+	// review status, then the greater strength, and withdraws the other
+	PolicyPreferStrongerReview = "prefer_stronger_review"
+	// PolicyDowngradeBoth lowers both criteria one strength level;
+	// supporting criteria are withdrawn
+	PolicyDowngradeBoth = "downgrade_both"
+)
+
+// Policies lists the supported resolution policies.
+var Policies = []string{PolicyFlagForReview, PolicyPreferStrongerReview, PolicyDowngradeBoth}
+
+// Conflict kinds
+const (
+	KindFunctional    = "functional_studies"      // PS3 and BS3
+	KindComputational = "computational"           // PP3 and BP4
+	KindReputable     = "reputable_source"        // PP5 and BP6
+	KindSegregation   = "segregation"             // PP1 and BS4
+	KindFrequency     = "population_frequency"    // PM2 with BA1 or BS1
+	KindSubpopulation = "subpopulation_frequency" // PM2 with an ancestry group above the PM2 cutoff
+)
+
+// Kinds lists the conflicts detected.
+var Kinds = []string{KindFunctional, KindComputational, KindReputable, KindSegregation, KindFrequency, KindSubpopulation}
+
+// Config selects the policy for each kind of conflict. Kinds without an
+// entry use the default, which is flag_for_review unless configured.
+type Config struct {
+	Default string            `json:"default,omitempty" yaml:"default,omitempty"`
+	Kinds   map[string]string `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+}
+
+// Defaults returns the configuration used without a policies file: every
+// conflict is flagged for review and no criterion is changed.
+func Defaults() *Config {
+	return &Config{Default: PolicyFlagForReview}
+}
+
+// Validate checks the policies and kinds named and fills in the default.
+func (c *Config) Validate() error {
+	c.Default = strings.TrimSpace(c.Default)
+	if c.Default == "" {
+		c.Default = PolicyFlagForReview
+	}
+	if !contains(Policies, c.Default) {
+		return fmt.Errorf("%w: default must be one of: %s", ErrInvalidPolicy, strings.Join(Policies, ", "))
+	}
+	for kind, policy := range c.Kinds {
+		if !contains(Kinds, kind) {
+			return fmt.Errorf("%w: unknown conflict %q; use one of: %s", ErrInvalidPolicy, kind, strings.Join(Kinds, ", "))
+		}
+		if !contains(Policies, policy) {
+			return fmt.Errorf("%w: %s must be one of: %s", ErrInvalidPolicy, kind, strings.Join(Policies, ", "))
+		}
+	}
+	return nil
+}
+
+// Policy returns the policy applied to a kind of conflict.
+func (c *Config) Policy(kind string) string {
+	if c == nil {
+		return PolicyFlagForReview
+	}
+	if policy, ok := c.Kinds[kind]; ok {
+		return policy
+	}
+	if c.Default == "" {
+		return PolicyFlagForReview
+	}
+	return c.Default
+}
+
+// Load reads a JSON or YAML policies file. A missing file yields the defaults.
+func Load(path string) (*Config, error) {
+	unmarshal := yaml.Unmarshal
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		unmarshal = json.Unmarshal
+	case ".yaml", ".yml":
+	default:
+		return nil, fmt.Errorf("unsupported conflict policies format: %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Defaults(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conflict policies: %w", err)
+	}
+
+	config := &Config{}
+	if err := unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse conflict policies %s: %w", filepath.Base(path), err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return config, nil
+}
+
+// Configured returns the kinds with their own policy, sorted, for logging.
+func (c *Config) Configured() []string {
+	kinds := make([]string, 0, len(c.Kinds))
+	for kind := range c.Kinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
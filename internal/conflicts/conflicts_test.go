@@ -0,0 +1,177 @@
+package conflicts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func functionalConflict() []domain.ACMGAMPRuleResult {
+	return []domain.ACMGAMPRuleResult{
+		{Code: "PS3", Category: domain.PATHOGENIC_RULE, Strength: domain.STRONG, Applied: true, Confidence: 0.8, Reasoning: "Functional studies show damaging effect"},
+		{Code: "BS3", Category: domain.BENIGN_RULE, Strength: domain.STRONG, Applied: true, Confidence: 0.6, Reasoning: "Functional studies show no damaging effect"},
+		{Code: "PM2", Category: domain.PATHOGENIC_RULE, Strength: domain.MODERATE, Applied: false},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	config := &Config{Kinds: map[string]string{KindFunctional: PolicyDowngradeBoth}}
+	require.NoError(t, config.Validate())
+	assert.Equal(t, PolicyFlagForReview, config.Default)
+	assert.Equal(t, PolicyDowngradeBoth, config.Policy(KindFunctional))
+	assert.Equal(t, PolicyFlagForReview, config.Policy(KindComputational))
+
+	assert.ErrorIs(t, (&Config{Default: "ignore"}).Validate(), ErrInvalidPolicy)
+	assert.ErrorIs(t, (&Config{Kinds: map[string]string{"PS3": PolicyFlagForReview}}).Validate(), ErrInvalidPolicy)
+	assert.ErrorIs(t, (&Config{Kinds: map[string]string{KindSegregation: "drop"}}).Validate(), ErrInvalidPolicy)
+
+	var none *Config
+	assert.Equal(t, PolicyFlagForReview, none.Policy(KindFrequency))
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	config, err := Load(filepath.Join(dir, "missing.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, Defaults(), config)
+
+	path := filepath.Join(dir, "conflict_policies.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("default: downgrade_both\nconflicts:\n  reputable_source: prefer_stronger_review\n"), 0o600))
+	config, err = Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, PolicyDowngradeBoth, config.Policy(KindFunctional))
+	assert.Equal(t, PolicyPreferStrongerReview, config.Policy(KindReputable))
+	assert.Equal(t, []string{KindReputable}, config.Configured())
+
+	path = filepath.Join(dir, "conflict_policies.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"conflicts":{"computational":"skip"}}`), 0o600))
+	_, err = Load(path)
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+	assert.Contains(t, err.Error(), "conflict_policies.json")
+
+	_, err = Load(filepath.Join(dir, "conflict_policies.txt"))
+	assert.Error(t, err)
+}
+
+func TestResolve_FlagForReview(t *testing.T) {
+	results := functionalConflict()
+
+	decisions := Resolve(results, nil, 0.0001, Defaults())
+
+	require.Len(t, decisions, 1)
+	decision := decisions[0]
+	assert.Equal(t, "PS3", decision.Source1)
+	assert.Equal(t, "BS3", decision.Source2)
+	assert.Equal(t, KindFunctional, decision.Kind)
+	assert.Equal(t, PolicyFlagForReview, decision.Policy)
+	assert.True(t, decision.NeedsReview)
+	assert.Equal(t, functionalConflict(), results, "flagging leaves criteria unchanged")
+}
+
+func TestResolve_PreferStrongerReview(t *testing.T) {
+	config := &Config{Default: PolicyPreferStrongerReview}
+
+	// Equal strength and no review status: confidence decides
+	results := functionalConflict()
+	decisions := Resolve(results, nil, 0.0001, config)
+	require.Len(t, decisions, 1)
+	assert.True(t, results[0].Applied)
+	assert.False(t, results[1].Applied)
+	assert.Contains(t, results[1].Reasoning, "[Conflict: withdrawn in favour of PS3 (confidence 0.80 against 0.60)]")
+	assert.False(t, decisions[0].NeedsReview)
+
+	// Review status outranks strength
+	results = []domain.ACMGAMPRuleResult{
+		{Code: "PP5", Category: domain.PATHOGENIC_RULE, Strength: domain.STRONG, Applied: true},
+		{Code: "BP6", Category: domain.BENIGN_RULE, Strength: domain.SUPPORTING, Applied: true},
+	}
+	evidence := &domain.AggregatedEvidence{ClinVarData: &domain.ClinVarData{Submissions: []domain.ClinVarSubmission{
+		{ClinicalSignificance: "Pathogenic", ReviewStatus: "criteria provided, single submitter"},
+		{ClinicalSignificance: "Likely benign", ReviewStatus: "reviewed by expert panel"},
+	}}}
+	decisions = Resolve(results, evidence, 0.0001, config)
+	require.Len(t, decisions, 1)
+	assert.False(t, results[0].Applied)
+	assert.True(t, results[1].Applied)
+	assert.Equal(t, "Kept BP6 and withdrew PP5: review status 3 stars against 1", decisions[0].Resolution)
+
+	// A full tie falls back to review
+	results = []domain.ACMGAMPRuleResult{
+		{Code: "PP3", Strength: domain.SUPPORTING, Applied: true},
+		{Code: "BP4", Strength: domain.SUPPORTING, Applied: true},
+	}
+	decisions = Resolve(results, nil, 0.0001, config)
+	require.Len(t, decisions, 1)
+	assert.True(t, decisions[0].NeedsReview)
+	assert.Equal(t, PolicyFlagForReview, decisions[0].Policy)
+	assert.True(t, results[0].Applied)
+	assert.True(t, results[1].Applied)
+}
+
+func TestResolve_DowngradeBoth(t *testing.T) {
+	config := &Config{Kinds: map[string]string{KindFunctional: PolicyDowngradeBoth, KindFrequency: PolicyDowngradeBoth}}
+	results := append(functionalConflict(), domain.ACMGAMPRuleResult{Code: "BS1", Strength: domain.STRONG, Applied: true})
+	results[2].Applied = true
+
+	decisions := Resolve(results, nil, 0.0001, config)
+
+	require.Len(t, decisions, 2)
+	assert.Equal(t, domain.MODERATE, results[0].Strength)
+	assert.Equal(t, domain.MODERATE, results[1].Strength)
+	assert.Equal(t, "Downgraded both: PS3 strong to moderate; BS3 strong to moderate", decisions[0].Resolution)
+
+	// PM2 drops to supporting and BS1 to moderate
+	assert.Equal(t, KindFrequency, decisions[1].Kind)
+	assert.Equal(t, "BS1", decisions[1].Source2)
+	assert.Equal(t, domain.SUPPORTING, results[2].Strength)
+	assert.Equal(t, domain.MODERATE, results[3].Strength)
+	assert.True(t, results[2].Applied)
+
+	// Supporting criteria are withdrawn
+	results = []domain.ACMGAMPRuleResult{
+		{Code: "PP3", Strength: domain.SUPPORTING, Applied: true},
+		{Code: "BP4", Strength: domain.SUPPORTING, Applied: true},
+	}
+	Resolve(results, nil, 0.0001, &Config{Default: PolicyDowngradeBoth})
+	assert.False(t, results[0].Applied)
+	assert.False(t, results[1].Applied)
+}
+
+func TestResolve_Subpopulation(t *testing.T) {
+	pm2 := func() []domain.ACMGAMPRuleResult {
+		return []domain.ACMGAMPRuleResult{{Code: "PM2", Strength: domain.MODERATE, Applied: true}}
+	}
+	evidence := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{
+		AlleleFrequency:       0.00005,
+		PopulationFrequencies: map[string]float64{"afr": 0.0004, "eas": 0.0002, "asj": 0.002},
+	}}
+
+	results := pm2()
+	decisions := Resolve(results, evidence, 0.0001, Defaults())
+	require.Len(t, decisions, 1)
+	assert.Equal(t, KindSubpopulation, decisions[0].Kind)
+	assert.Equal(t, "afr", decisions[0].Source2, "founder populations are excluded")
+	assert.True(t, decisions[0].NeedsReview)
+	assert.True(t, results[0].Applied)
+
+	results = pm2()
+	Resolve(results, evidence, 0.0001, &Config{Kinds: map[string]string{KindSubpopulation: PolicyDowngradeBoth}})
+	assert.Equal(t, domain.SUPPORTING, results[0].Strength)
+
+	results = pm2()
+	Resolve(results, evidence, 0.0001, &Config{Kinds: map[string]string{KindSubpopulation: PolicyPreferStrongerReview}})
+	assert.False(t, results[0].Applied)
+
+	// A filtering allele frequency already accounts for the most frequent group
+	evidence.PopulationData.FAF95 = 0.00008
+	assert.Empty(t, Resolve(pm2(), evidence, 0.0001, Defaults()))
+
+	// No conflicts without opposing applied criteria
+	assert.Empty(t, Resolve(pm2(), nil, 0.0001, Defaults()))
+}
@@ -0,0 +1,275 @@
+package conflicts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// Decision records one conflict between applied criteria and how it was
+// resolved. Its JSON shape extends the evidence resource's conflicting
+// evidence entries.
+type Decision struct {
+	Source1     string `json:"source1"`              // Criterion code, e.g. PS3
+	Source2     string `json:"source2"`              // Opposing criterion code or ancestry group
+	Conflict    string `json:"conflict"`             // What contradicts what
+	Resolution  string `json:"resolution,omitempty"` // What the policy did
+	Impact      string `json:"impact"`               // Effect on the criteria combined
+	Kind        string `json:"kind,omitempty"`
+	Policy      string `json:"policy,omitempty"`
+	NeedsReview bool   `json:"needs_review,omitempty"`
+}
+
+// opposingPairs are the pathogenic and benign criteria that assert opposite
+// conclusions from the same kind of evidence
+var opposingPairs = []struct {
+	kind, pathogenic, benign, subject string
+}{
+	{KindFunctional, "PS3", "BS3", "functional studies"},
+	{KindComputational, "PP3", "BP4", "computational predictions"},
+	{KindReputable, "PP5", "BP6", "reputable source reports"},
+	{KindSegregation, "PP1", "BS4", "segregation data"},
+}
+
+// Ancestry groups excluded from the subpopulation check, as gnomAD excludes
+// them from the filtering allele frequency: bottlenecked or founder
+// populations and the remaining (unassigned) group
+var excludedGroups = map[string]bool{
+	"ami": true, "asj": true, "fin": true, "mid": true, "oth": true, "remaining": true,
+}
+
+// Resolve detects conflicting applied criteria in results, applies the
+// configured policy to each conflict and returns the decisions made.
+// Criteria changed by a policy have their Strength or Applied updated and
+// the resolution appended to their Reasoning. pm2Cutoff is the PM2 allele
+// frequency cutoff in effect, used for the subpopulation check.
+func Resolve(results []domain.ACMGAMPRuleResult, evidence *domain.AggregatedEvidence, pm2Cutoff float64, config *Config) []Decision {
+	index := make(map[string]int, len(results))
+	for i := range results {
+		index[results[i].Code] = i
+	}
+	applied := func(code string) *domain.ACMGAMPRuleResult {
+		if i, ok := index[code]; ok && results[i].Applied {
+			return &results[i]
+		}
+		return nil
+	}
+
+	var decisions []Decision
+	for _, pair := range opposingPairs {
+		pathogenic, benign := applied(pair.pathogenic), applied(pair.benign)
+		if pathogenic == nil || benign == nil {
+			continue
+		}
+		decision := Decision{
+			Source1:  pathogenic.Code,
+			Source2:  benign.Code,
+			Conflict: fmt.Sprintf("%s support pathogenicity (%s) and benign impact (%s)", capitalize(pair.subject), pathogenic.Code, benign.Code),
+			Kind:     pair.kind,
+		}
+		resolvePair(&decision, pathogenic, benign, evidence, config.Policy(pair.kind))
+		decisions = append(decisions, decision)
+	}
+
+	if pm2 := applied("PM2"); pm2 != nil {
+		for _, code := range []string{"BA1", "BS1"} {
+			frequency := applied(code)
+			if frequency == nil {
+				continue
+			}
+			decision := Decision{
+				Source1:  pm2.Code,
+				Source2:  frequency.Code,
+				Conflict: fmt.Sprintf("PM2 treats the variant as rare while %s treats it as too common for the disorder", frequency.Code),
+				Kind:     KindFrequency,
+			}
+			resolvePair(&decision, pm2, frequency, evidence, config.Policy(KindFrequency))
+			decisions = append(decisions, decision)
+			break
+		}
+	}
+
+	if pm2 := applied("PM2"); pm2 != nil {
+		if group, frequency, ok := commonSubpopulation(evidence, pm2Cutoff); ok {
+			decision := Decision{
+				Source1:  pm2.Code,
+				Source2:  group,
+				Conflict: fmt.Sprintf("PM2 applied from the overall frequency, but the %s ancestry group has allele frequency %g, above the PM2 cutoff %g", group, frequency, pm2Cutoff),
+				Kind:     KindSubpopulation,
+			}
+			resolveSubpopulation(&decision, pm2, config.Policy(KindSubpopulation))
+			decisions = append(decisions, decision)
+		}
+	}
+
+	return decisions
+}
+
+// commonSubpopulation returns the continental ancestry group with the
+// highest allele frequency above the PM2 cutoff. It is only consulted
+// without a filtering allele frequency, which already accounts for the
+// most frequent group when PM2 is assessed.
+func commonSubpopulation(evidence *domain.AggregatedEvidence, cutoff float64) (string, float64, bool) {
+	if evidence == nil || evidence.PopulationData == nil || cutoff <= 0 {
+		return "", 0, false
+	}
+	population := evidence.PopulationData
+	if population.FAF95 > 0 {
+		return "", 0, false
+	}
+	groups := make([]string, 0, len(population.PopulationFrequencies))
+	for group := range population.PopulationFrequencies {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	best, bestFrequency := "", 0.0
+	for _, group := range groups {
+		frequency := population.PopulationFrequencies[group]
+		if excludedGroups[strings.ToLower(group)] || frequency <= cutoff || frequency <= bestFrequency {
+			continue
+		}
+		best, bestFrequency = group, frequency
+	}
+	return best, bestFrequency, best != ""
+}
+
+// resolvePair applies a policy to two applied criteria that contradict
+// each other
+func resolvePair(decision *Decision, first, second *domain.ACMGAMPRuleResult, evidence *domain.AggregatedEvidence, policy string) {
+	decision.Policy = policy
+	switch policy {
+	case PolicyPreferStrongerReview:
+		kept, withdrawn, basis := stronger(first, second, evidence)
+		if kept == nil {
+			flag(decision, fmt.Sprintf("Neither %s nor %s has stronger review status or strength; flagged for review", first.Code, second.Code))
+			return
+		}
+		withdraw(withdrawn, fmt.Sprintf("withdrawn in favour of %s (%s)", kept.Code, basis))
+		decision.Resolution = fmt.Sprintf("Kept %s and withdrew %s: %s", kept.Code, withdrawn.Code, basis)
+		decision.Impact = fmt.Sprintf("%s no longer contributes to the classification", withdrawn.Code)
+	case PolicyDowngradeBoth:
+		decision.Resolution = fmt.Sprintf("Downgraded both: %s; %s", downgrade(first, second.Code), downgrade(second, first.Code))
+		decision.Impact = "Both criteria contribute with reduced weight"
+	default:
+		flag(decision, "Both criteria kept; flagged for curator review")
+	}
+}
+
+// resolveSubpopulation applies a policy to PM2 contradicted by an ancestry
+// group frequency
+func resolveSubpopulation(decision *Decision, pm2 *domain.ACMGAMPRuleResult, policy string) {
+	decision.Policy = policy
+	switch policy {
+	case PolicyPreferStrongerReview:
+		withdraw(pm2, fmt.Sprintf("withdrawn: the %s ancestry group frequency exceeds the PM2 cutoff", decision.Source2))
+		decision.Resolution = "Withdrew PM2: the observed ancestry group frequency outweighs the overall frequency"
+		decision.Impact = "PM2 no longer contributes to the classification"
+	case PolicyDowngradeBoth:
+		decision.Resolution = "Downgraded " + downgrade(pm2, decision.Source2+" ancestry group frequency")
+		decision.Impact = "PM2 contributes with reduced weight"
+	default:
+		flag(decision, "PM2 kept; flagged for curator review of the ancestry group frequency")
+	}
+}
+
+func flag(decision *Decision, resolution string) {
+	decision.Policy = PolicyFlagForReview
+	decision.NeedsReview = true
+	decision.Resolution = resolution
+	decision.Impact = "Classification combines both criteria and needs curator review"
+}
+
+// stronger picks the criterion backed by the higher ClinVar review status,
+// then the greater strength, then the higher confidence. It returns nil
+// when the two are tied on all three.
+func stronger(first, second *domain.ACMGAMPRuleResult, evidence *domain.AggregatedEvidence) (kept, withdrawn *domain.ACMGAMPRuleResult, basis string) {
+	firstStars, secondStars := reviewStars(first, evidence), reviewStars(second, evidence)
+	switch {
+	case firstStars > secondStars:
+		return first, second, fmt.Sprintf("review status %d stars against %d", firstStars, secondStars)
+	case secondStars > firstStars:
+		return second, first, fmt.Sprintf("review status %d stars against %d", secondStars, firstStars)
+	}
+
+	firstLevel, secondLevel := level(first.Strength), level(second.Strength)
+	switch {
+	case firstLevel > secondLevel:
+		return first, second, fmt.Sprintf("%s strength outweighs %s", strings.ToLower(string(first.Strength)), strings.ToLower(string(second.Strength)))
+	case secondLevel > firstLevel:
+		return second, first, fmt.Sprintf("%s strength outweighs %s", strings.ToLower(string(second.Strength)), strings.ToLower(string(first.Strength)))
+	}
+
+	switch {
+	case first.Confidence > second.Confidence:
+		return first, second, fmt.Sprintf("confidence %.2f against %.2f", first.Confidence, second.Confidence)
+	case second.Confidence > first.Confidence:
+		return second, first, fmt.Sprintf("confidence %.2f against %.2f", second.Confidence, first.Confidence)
+	}
+	return nil, nil, ""
+}
+
+// reviewStars returns the best ClinVar review status behind a criterion:
+// the matching submissions for PP5 and BP6, otherwise the curated variants
+// the rule relied on
+func reviewStars(result *domain.ACMGAMPRuleResult, evidence *domain.AggregatedEvidence) int {
+	best := 0
+	if evidence != nil && evidence.ClinVarData != nil && (result.Code == "PP5" || result.Code == "BP6") {
+		want := "pathogenic"
+		if result.Code == "BP6" {
+			want = "benign"
+		}
+		for _, submission := range evidence.ClinVarData.Submissions {
+			if strings.Contains(strings.ToLower(submission.ClinicalSignificance), want) {
+				best = max(best, domain.ReviewStatusStars(submission.ReviewStatus))
+			}
+		}
+	}
+	for _, variant := range result.MatchedVariants {
+		best = max(best, variant.Stars)
+	}
+	return best
+}
+
+var strengths = []domain.RuleStrength{domain.SUPPORTING, domain.MODERATE, domain.STRONG, domain.VERY_STRONG}
+
+func level(strength domain.RuleStrength) int {
+	for i, s := range strengths {
+		if s == strength {
+			return i
+		}
+	}
+	return -1
+}
+
+// downgrade lowers a criterion one strength level, withdrawing it when
+// already supporting, and describes the change
+func downgrade(result *domain.ACMGAMPRuleResult, against string) string {
+	i := level(result.Strength)
+	if i <= 0 {
+		withdraw(result, "withdrawn: supporting evidence contradicted by "+against)
+		return result.Code + " withdrawn"
+	}
+	from := result.Strength
+	result.Strength = strengths[i-1]
+	note(result, fmt.Sprintf("downgraded from %s to %s: contradicted by %s", strings.ToLower(string(from)), strings.ToLower(string(result.Strength)), against))
+	return fmt.Sprintf("%s %s to %s", result.Code, strings.ToLower(string(from)), strings.ToLower(string(result.Strength)))
+}
+
+func withdraw(result *domain.ACMGAMPRuleResult, reason string) {
+	result.Applied = false
+	note(result, reason)
+}
+
+func note(result *domain.ACMGAMPRuleResult, text string) {
+	result.Reasoning = strings.TrimSpace(result.Reasoning + " [Conflict: " + text + "]")
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
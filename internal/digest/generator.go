@@ -0,0 +1,258 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/feedback"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+// feedbackPageSize is the page size used when scanning the feedback store
+const feedbackPageSize = 500
+
+// Generator builds digests from the feedback, snapshot and threshold stores.
+// Only the feedback store is required; sections backed by an unset store are left empty.
+type Generator struct {
+	logger     *logrus.Logger
+	feedback   feedback.Store
+	snapshots  snapshot.Store
+	thresholds thresholds.Store
+}
+
+// clinvarPayload is the part of an evidence snapshot the digest reads
+type clinvarPayload struct {
+	AggregatedEvidence struct {
+		ClinicalEvidence struct {
+			OverallSignificance string `json:"overall_significance"`
+			ReviewStatus        string `json:"review_status"`
+		} `json:"clinical_evidence"`
+	} `json:"aggregated_evidence"`
+}
+
+// NewGenerator creates a digest generator.
+func NewGenerator(logger *logrus.Logger, store feedback.Store) *Generator {
+	return &Generator{
+		logger:   logger,
+		feedback: store,
+	}
+}
+
+// SetSnapshotStore enables ClinVar discordance and evidence update reporting.
+func (g *Generator) SetSnapshotStore(store snapshot.Store) {
+	g.snapshots = store
+}
+
+// SetThresholdStore enables reporting of threshold revisions.
+func (g *Generator) SetThresholdStore(store thresholds.Store) {
+	g.thresholds = store
+}
+
+// Generate builds the digest for the week starting at weekStart.
+func (g *Generator) Generate(ctx context.Context, weekStart time.Time) (*Digest, error) {
+	weekStart = WeekStart(weekStart)
+	weekEnd := weekStart.Add(Week)
+
+	d := &Digest{
+		WeekStart:   weekStart,
+		WeekEnd:     weekEnd,
+		GeneratedAt: time.Now().UTC(),
+		Classifications: ClassificationSummary{
+			ByClassification: make(map[string]int),
+		},
+		Reclassifications:   []*feedback.ClassificationChange{},
+		ClinVarDiscordances: []Discordance{},
+		SourceUpdates: SourceUpdates{
+			ClinVarChanges:     []ClinVarChange{},
+			ThresholdRevisions: []ThresholdEvent{},
+		},
+	}
+
+	entries, err := g.allFeedback(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var signedOut []*feedback.Feedback
+	for _, fb := range entries {
+		if inWindow(fb.UpdatedAt, weekStart, weekEnd) {
+			signedOut = append(signedOut, fb)
+		}
+	}
+	g.summarizeClassifications(d, signedOut)
+
+	if changeLog, ok := g.feedback.(feedback.ChangeLog); ok {
+		changes, err := changeLog.Changes(ctx, weekStart, weekEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load reclassifications: %w", err)
+		}
+		if changes != nil {
+			d.Reclassifications = changes
+		}
+	}
+
+	if g.snapshots != nil {
+		if err := g.compareWithEvidence(ctx, d, entries, signedOut); err != nil {
+			return nil, err
+		}
+	}
+
+	if g.thresholds != nil {
+		revisions, err := g.thresholds.History(ctx, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load threshold history: %w", err)
+		}
+		// History is newest first; report in the order they took effect
+		for i := len(revisions) - 1; i >= 0; i-- {
+			r := revisions[i]
+			if r.CancelledAt == nil && inWindow(r.EffectiveFrom, weekStart, weekEnd) {
+				d.SourceUpdates.ThresholdRevisions = append(d.SourceUpdates.ThresholdRevisions, ThresholdEvent{
+					RevisionID:    r.ID,
+					EffectiveFrom: r.EffectiveFrom,
+					Reason:        r.Reason,
+					CreatedBy:     r.CreatedBy,
+				})
+			}
+		}
+	}
+
+	return d, nil
+}
+
+func (g *Generator) allFeedback(ctx context.Context) ([]*feedback.Feedback, error) {
+	var all []*feedback.Feedback
+	for offset := 0; ; offset += feedbackPageSize {
+		page, err := g.feedback.List(ctx, feedbackPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list feedback: %w", err)
+		}
+		all = append(all, page...)
+		if len(page) < feedbackPageSize {
+			return all, nil
+		}
+	}
+}
+
+func (g *Generator) summarizeClassifications(d *Digest, signedOut []*feedback.Feedback) {
+	summary := &d.Classifications
+	for _, fb := range signedOut {
+		summary.Total++
+		if inWindow(fb.CreatedAt, d.WeekStart, d.WeekEnd) {
+			summary.New++
+		} else {
+			summary.Updated++
+		}
+		if fb.UserAgreed {
+			summary.AgreedWithSuggestion++
+		}
+		summary.ByClassification[string(fb.UserClassification)]++
+	}
+}
+
+// compareWithEvidence reports evidence refreshes and ClinVar changes for every
+// signed-out variant, and ClinVar discordances for this week's sign-outs
+func (g *Generator) compareWithEvidence(ctx context.Context, d *Digest, entries, signedOut []*feedback.Feedback) error {
+	payloads := make(map[int64]*clinvarPayload)
+	load := func(id int64) *clinvarPayload {
+		if p, ok := payloads[id]; ok {
+			return p
+		}
+		var p *clinvarPayload
+		s, err := g.snapshots.Get(ctx, id)
+		if err == nil && s != nil {
+			p = &clinvarPayload{}
+			if err := json.Unmarshal(s.Payload, p); err != nil {
+				g.logger.WithError(err).WithField("snapshot_id", id).Warn("Failed to decode evidence snapshot")
+				p = nil
+			}
+		} else if err != nil {
+			g.logger.WithError(err).WithField("snapshot_id", id).Warn("Failed to load evidence snapshot")
+		}
+		payloads[id] = p
+		return p
+	}
+
+	history := make(map[string][]*snapshot.Snapshot)
+	for _, fb := range entries {
+		if _, seen := history[fb.NormalizedHGVS]; seen {
+			continue
+		}
+		snaps, err := g.snapshots.ListByVariant(ctx, fb.NormalizedHGVS)
+		if err != nil {
+			return fmt.Errorf("failed to list evidence snapshots: %w", err)
+		}
+		history[fb.NormalizedHGVS] = snaps
+
+		// Snapshots are newest first
+		var latestInWeek, latestBefore *snapshot.Snapshot
+		for _, s := range snaps {
+			switch {
+			case inWindow(s.CapturedAt, d.WeekStart, d.WeekEnd):
+				d.SourceUpdates.EvidenceRefreshes++
+				if latestInWeek == nil {
+					latestInWeek = s
+				}
+			case s.CapturedAt.Before(d.WeekStart) && latestBefore == nil:
+				latestBefore = s
+			}
+		}
+		if latestInWeek == nil || latestBefore == nil {
+			continue
+		}
+
+		current, previous := load(latestInWeek.ID), load(latestBefore.ID)
+		if current == nil || previous == nil {
+			continue
+		}
+		was := previous.AggregatedEvidence.ClinicalEvidence.OverallSignificance
+		now := current.AggregatedEvidence.ClinicalEvidence.OverallSignificance
+		if was != "" && now != "" && was != now {
+			d.SourceUpdates.ClinVarChanges = append(d.SourceUpdates.ClinVarChanges, ClinVarChange{
+				NormalizedHGVS: fb.NormalizedHGVS,
+				Previous:       was,
+				Current:        now,
+				CapturedAt:     latestInWeek.CapturedAt,
+			})
+		}
+	}
+
+	for _, fb := range signedOut {
+		// Compare against the most recent evidence available by the end of the week
+		var latest *snapshot.Snapshot
+		for _, s := range history[fb.NormalizedHGVS] {
+			if s.CapturedAt.Before(d.WeekEnd) {
+				latest = s
+				break
+			}
+		}
+		if latest == nil {
+			continue
+		}
+		p := load(latest.ID)
+		if p == nil {
+			continue
+		}
+		clinical := p.AggregatedEvidence.ClinicalEvidence
+		if discordant(string(fb.UserClassification), clinical.OverallSignificance) {
+			d.ClinVarDiscordances = append(d.ClinVarDiscordances, Discordance{
+				NormalizedHGVS:      fb.NormalizedHGVS,
+				CancerType:          fb.CancerType,
+				LabClassification:   string(fb.UserClassification),
+				ClinVarSignificance: clinical.OverallSignificance,
+				ReviewStatus:        clinical.ReviewStatus,
+				EvidenceCapturedAt:  latest.CapturedAt,
+			})
+		}
+	}
+
+	return nil
+}
+
+func inWindow(t, start, end time.Time) bool {
+	return !t.Before(start) && t.Before(end)
+}
@@ -0,0 +1,158 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/feedback"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+func clinvarSnapshot(t *testing.T, hgvs, significance string, capturedAt time.Time) *snapshot.Snapshot {
+	t.Helper()
+	payload, err := json.Marshal(map[string]interface{}{
+		"aggregated_evidence": map[string]interface{}{
+			"clinical_evidence": map[string]interface{}{
+				"overall_significance": significance,
+				"review_status":        "criteria provided, multiple submitters, no conflicts",
+			},
+		},
+	})
+	require.NoError(t, err)
+	return &snapshot.Snapshot{NormalizedHGVS: hgvs, Payload: payload, CapturedAt: capturedAt}
+}
+
+func TestWeekStart(t *testing.T) {
+	// Thursday 2026-10-15 falls in the week starting Monday 2026-10-12
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), WeekStart(time.Date(2026, 10, 15, 17, 30, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), WeekStart(time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), WeekStart(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestNextRun(t *testing.T) {
+	thursday := time.Date(2026, 10, 15, 17, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC), NextRun(thursday, time.Monday, 7))
+	assert.Equal(t, time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC), NextRun(thursday, time.Thursday, 18))
+	assert.Equal(t, time.Date(2026, 10, 22, 7, 0, 0, 0, time.UTC), NextRun(thursday, time.Thursday, 7))
+}
+
+func TestDiscordant(t *testing.T) {
+	assert.True(t, discordant("VUS", "Pathogenic"))
+	assert.True(t, discordant("Likely Benign", "Likely pathogenic"))
+	assert.False(t, discordant("Likely Pathogenic", "Pathogenic/Likely pathogenic"))
+	assert.False(t, discordant("VUS", "Conflicting interpretations of pathogenicity"))
+	assert.False(t, discordant("Benign", ""))
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	logger, _ := test.NewNullLogger()
+
+	fbStore, err := feedback.NewSQLiteStore(filepath.Join(dir, "feedback.db"))
+	require.NoError(t, err)
+	defer fbStore.Close()
+	archive, err := snapshot.NewFileArchive(filepath.Join(dir, "archive"))
+	require.NoError(t, err)
+	snapStore, err := snapshot.NewSQLiteStore(filepath.Join(dir, "evidence.db"), archive)
+	require.NoError(t, err)
+	defer snapStore.Close()
+	thrStore, err := thresholds.NewSQLiteStore(filepath.Join(dir, "thresholds.db"))
+	require.NoError(t, err)
+	defer thrStore.Close()
+
+	now := time.Now()
+	weekStart := WeekStart(now)
+
+	// TP53 was VUS, reclassified this week after ClinVar moved to Pathogenic
+	tp53 := &feedback.Feedback{Variant: "TP53:c.743G>A", NormalizedHGVS: "NM_000546.6:c.743G>A", SuggestedClassification: "VUS", UserClassification: "VUS", UserAgreed: true}
+	require.NoError(t, fbStore.Save(ctx, tp53))
+	tp53.UserClassification = "Likely Pathogenic"
+	tp53.UserAgreed = false
+	require.NoError(t, fbStore.Save(ctx, tp53))
+	require.NoError(t, snapStore.Save(ctx, clinvarSnapshot(t, tp53.NormalizedHGVS, "Uncertain significance", weekStart.Add(-48*time.Hour))))
+	require.NoError(t, snapStore.Save(ctx, clinvarSnapshot(t, tp53.NormalizedHGVS, "Pathogenic", now)))
+
+	// BRCA2 signed out Benign, but ClinVar says Pathogenic
+	brca2 := &feedback.Feedback{Variant: "BRCA2:c.68-7T>A", NormalizedHGVS: "NM_000059.4:c.68-7T>A", CancerType: "breast", SuggestedClassification: "Benign", UserClassification: "Benign", UserAgreed: true}
+	require.NoError(t, fbStore.Save(ctx, brca2))
+	require.NoError(t, snapStore.Save(ctx, clinvarSnapshot(t, brca2.NormalizedHGVS, "Pathogenic", now)))
+
+	require.NoError(t, thrStore.Schedule(ctx, &thresholds.Revision{Thresholds: thresholds.Defaults(), Reason: "Baseline", CreatedBy: "admin1"}))
+
+	generator := NewGenerator(logger, fbStore)
+	generator.SetSnapshotStore(snapStore)
+	generator.SetThresholdStore(thrStore)
+
+	// Act
+	d, err := generator.Generate(ctx, now)
+	require.NoError(t, err)
+	previous, err := generator.Generate(ctx, weekStart.Add(-Week))
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, weekStart, d.WeekStart)
+	assert.Equal(t, 2, d.Classifications.Total)
+	assert.Equal(t, 2, d.Classifications.New)
+	assert.Equal(t, 1, d.Classifications.AgreedWithSuggestion)
+	assert.Equal(t, 1, d.Classifications.ByClassification["Benign"])
+
+	require.Len(t, d.Reclassifications, 1)
+	assert.Equal(t, feedback.Classification("VUS"), d.Reclassifications[0].From)
+
+	require.Len(t, d.ClinVarDiscordances, 1)
+	assert.Equal(t, brca2.NormalizedHGVS, d.ClinVarDiscordances[0].NormalizedHGVS)
+	assert.Equal(t, "breast", d.ClinVarDiscordances[0].CancerType)
+
+	assert.Equal(t, 2, d.SourceUpdates.EvidenceRefreshes)
+	require.Len(t, d.SourceUpdates.ClinVarChanges, 1)
+	assert.Equal(t, "Uncertain significance", d.SourceUpdates.ClinVarChanges[0].Previous)
+	assert.Len(t, d.SourceUpdates.ThresholdRevisions, 1)
+
+	assert.Zero(t, previous.Classifications.Total)
+	assert.Empty(t, previous.Reclassifications)
+
+	text := d.Text()
+	assert.Contains(t, text, "Reclassifications: 1")
+	assert.Contains(t, text, "NM_000059.4:c.68-7T>A (breast): lab Benign, ClinVar Pathogenic")
+}
+
+func TestSlackNotifier_Notify(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	d := &Digest{WeekStart: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Classifications: ClassificationSummary{Total: 3}}
+
+	err := NewSlackNotifier(server.URL).Notify(context.Background(), d)
+
+	require.NoError(t, err)
+	assert.Contains(t, received["text"], "week of 2026-10-12")
+	assert.Contains(t, received["text"], "Classifications signed out: 3")
+}
+
+func TestSlackNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := NewSlackNotifier(server.URL).Notify(context.Background(), &Digest{})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,144 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Notifier delivers a digest to the lab.
+type Notifier interface {
+	Notify(ctx context.Context, d *Digest) error
+}
+
+// Text renders the digest as plain text suitable for email and chat.
+func (d *Digest) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Variant review digest: week of %s\n\n", d.WeekStart.Format("2006-01-02"))
+
+	c := d.Classifications
+	fmt.Fprintf(&b, "Classifications signed out: %d (%d new, %d revisited; %d agreed with suggestion)\n", c.Total, c.New, c.Updated, c.AgreedWithSuggestion)
+	classes := make([]string, 0, len(c.ByClassification))
+	for class := range c.ByClassification {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	for _, class := range classes {
+		fmt.Fprintf(&b, "  - %s: %d\n", class, c.ByClassification[class])
+	}
+
+	fmt.Fprintf(&b, "\nReclassifications: %d\n", len(d.Reclassifications))
+	for _, r := range d.Reclassifications {
+		fmt.Fprintf(&b, "  - %s%s: %s -> %s\n", r.NormalizedHGVS, contextSuffix(r.CancerType), r.From, r.To)
+	}
+
+	fmt.Fprintf(&b, "\nDiscordant with ClinVar: %d\n", len(d.ClinVarDiscordances))
+	for _, x := range d.ClinVarDiscordances {
+		fmt.Fprintf(&b, "  - %s%s: lab %s, ClinVar %s\n", x.NormalizedHGVS, contextSuffix(x.CancerType), x.LabClassification, x.ClinVarSignificance)
+	}
+
+	u := d.SourceUpdates
+	fmt.Fprintf(&b, "\nData source updates: %d evidence refreshes, %d ClinVar changes, %d threshold revisions\n",
+		u.EvidenceRefreshes, len(u.ClinVarChanges), len(u.ThresholdRevisions))
+	for _, x := range u.ClinVarChanges {
+		fmt.Fprintf(&b, "  - %s: ClinVar %s -> %s\n", x.NormalizedHGVS, x.Previous, x.Current)
+	}
+	for _, x := range u.ThresholdRevisions {
+		fmt.Fprintf(&b, "  - Threshold revision %d (%s): %s\n", x.RevisionID, x.CreatedBy, x.Reason)
+	}
+
+	return b.String()
+}
+
+func contextSuffix(cancerType string) string {
+	if cancerType == "" {
+		return ""
+	}
+	return " (" + cancerType + ")"
+}
+
+// SlackNotifier posts digests to a Slack incoming webhook.
+type SlackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackNotifier creates a notifier for the given incoming webhook URL.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Notify posts the digest text to Slack.
+func (n *SlackNotifier) Notify(ctx context.Context, d *Digest) error {
+	body, err := json.Marshal(map[string]string{"text": "```\n" + d.Text() + "```"})
+	if err != nil {
+		return fmt.Errorf("failed to encode Slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post digest to Slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// EmailNotifier sends digests over SMTP.
+type EmailNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+}
+
+// NewEmailNotifier creates an SMTP notifier. Authentication is used when a username is given.
+func NewEmailNotifier(addr, username, password, from string, to []string) *EmailNotifier {
+	n := &EmailNotifier{addr: addr, from: from, to: to}
+	if username != "" {
+		host := addr
+		if i := strings.LastIndex(addr, ":"); i > 0 {
+			host = addr[:i]
+		}
+		n.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return n
+}
+
+// Notify emails the digest text to the configured recipients.
+func (n *EmailNotifier) Notify(ctx context.Context, d *Digest) error {
+	if len(n.to) == 0 {
+		return fmt.Errorf("no digest email recipients configured")
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", n.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&msg, "Subject: Variant review digest: week of %s\r\n", d.WeekStart.Format("2006-01-02"))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(d.Text(), "\n", "\r\n"))
+
+	if err := smtp.SendMail(n.addr, n.auth, n.from, n.to, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send digest email: %w", err)
+	}
+	return nil
+}
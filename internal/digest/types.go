@@ -0,0 +1,117 @@
+// Package digest builds the weekly variant review digest: classification
+// activity, reclassifications, discordances with ClinVar and evidence source
+// updates. Digests are served as an MCP resource and can be pushed to email
+// or Slack ahead of the lab's weekly review meeting.
+package digest
+
+import (
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/feedback"
+)
+
+// Week is the length of a digest period.
+const Week = 7 * 24 * time.Hour
+
+// Digest summarizes one week of classification activity.
+type Digest struct {
+	WeekStart           time.Time                        `json:"week_start"`
+	WeekEnd             time.Time                        `json:"week_end"`
+	GeneratedAt         time.Time                        `json:"generated_at"`
+	Classifications     ClassificationSummary            `json:"classifications"`
+	Reclassifications   []*feedback.ClassificationChange `json:"reclassifications"`
+	ClinVarDiscordances []Discordance                    `json:"clinvar_discordances"`
+	SourceUpdates       SourceUpdates                    `json:"source_updates"`
+}
+
+// ClassificationSummary counts classifications signed out during the week.
+type ClassificationSummary struct {
+	Total                int            `json:"total"`
+	New                  int            `json:"new"`     // First sign-out for the variant and context
+	Updated              int            `json:"updated"` // Existing sign-outs revisited this week
+	AgreedWithSuggestion int            `json:"agreed_with_suggestion"`
+	ByClassification     map[string]int `json:"by_classification"`
+}
+
+// Discordance is a classification signed out this week that disagrees with ClinVar.
+type Discordance struct {
+	NormalizedHGVS      string    `json:"normalized_hgvs"`
+	CancerType          string    `json:"cancer_type,omitempty"`
+	LabClassification   string    `json:"lab_classification"`
+	ClinVarSignificance string    `json:"clinvar_significance"`
+	ReviewStatus        string    `json:"review_status,omitempty"`
+	EvidenceCapturedAt  time.Time `json:"evidence_captured_at"`
+}
+
+// SourceUpdates describes changes to the evidence behind signed-out classifications.
+type SourceUpdates struct {
+	EvidenceRefreshes  int              `json:"evidence_refreshes"` // Evidence snapshots captured for signed-out variants
+	ClinVarChanges     []ClinVarChange  `json:"clinvar_changes"`
+	ThresholdRevisions []ThresholdEvent `json:"threshold_revisions"`
+}
+
+// ClinVarChange is a change in ClinVar significance seen between evidence snapshots.
+type ClinVarChange struct {
+	NormalizedHGVS string    `json:"normalized_hgvs"`
+	Previous       string    `json:"previous"`
+	Current        string    `json:"current"`
+	CapturedAt     time.Time `json:"captured_at"`
+}
+
+// ThresholdEvent is a rule threshold revision that took effect during the week.
+type ThresholdEvent struct {
+	RevisionID    int64     `json:"revision_id"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	Reason        string    `json:"reason"`
+	CreatedBy     string    `json:"created_by"`
+}
+
+// WeekStart returns the Monday 00:00 UTC that starts the week containing t.
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7 // Days since Monday
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// NextRun returns the first time after now that falls on weekday at hour:00 UTC.
+func NextRun(now time.Time, weekday time.Weekday, hour int) time.Time {
+	now = now.UTC()
+	days := (int(weekday) - int(now.Weekday()) + 7) % 7
+	next := time.Date(now.Year(), now.Month(), now.Day()+days, hour, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.Add(Week)
+	}
+	return next
+}
+
+// Classification tiers used to detect discordance
+const (
+	tierPathogenic = "pathogenic"
+	tierUncertain  = "uncertain"
+	tierBenign     = "benign"
+)
+
+// tier maps a lab classification or ClinVar significance onto a broad tier.
+// Conflicting or unrecognized values return "" and are not compared.
+func tier(significance string) string {
+	s := strings.ToLower(significance)
+	switch {
+	case s == "" || strings.Contains(s, "conflicting"):
+		return ""
+	case strings.Contains(s, "pathogenic"):
+		return tierPathogenic
+	case strings.Contains(s, "benign"):
+		return tierBenign
+	case strings.Contains(s, "uncertain") || s == "vus":
+		return tierUncertain
+	default:
+		return ""
+	}
+}
+
+// discordant reports whether the lab call and ClinVar fall in different tiers.
+func discordant(lab, clinvar string) bool {
+	labTier, clinvarTier := tier(lab), tier(clinvar)
+	return labTier != "" && clinvarTier != "" && labTier != clinvarTier
+}
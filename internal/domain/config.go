@@ -33,12 +33,14 @@ type InterpretationRecord struct {
 
 // Config represents the main application configuration
 type Config struct {
-	Server      ServerConfig      `mapstructure:"server"`
-	Database    DatabaseConfig    `mapstructure:"database"`
-	ExternalAPI ExternalAPIConfig `mapstructure:"external_api"`
-	Cache       CacheConfig       `mapstructure:"cache"`
-	Logging     LoggingConfig     `mapstructure:"logging"`
-	MCP         MCPConfig         `mapstructure:"mcp"`
+	Server         ServerConfig         `mapstructure:"server"`
+	Database       DatabaseConfig       `mapstructure:"database"`
+	ExternalAPI    ExternalAPIConfig    `mapstructure:"external_api"`
+	Cache          CacheConfig          `mapstructure:"cache"`
+	Logging        LoggingConfig        `mapstructure:"logging"`
+	MCP            MCPConfig            `mapstructure:"mcp"`
+	Classification ClassificationConfig `mapstructure:"classification"`
+	Auth           AuthConfig           `mapstructure:"auth"`
 }
 
 // ServerConfig represents HTTP server configuration
@@ -68,12 +70,40 @@ type DatabaseConfig struct {
 
 // ExternalAPIConfig represents external API configuration
 type ExternalAPIConfig struct {
-	ClinVar ClinVarConfig `mapstructure:"clinvar"`
-	GnomAD  GnomADConfig  `mapstructure:"gnomad"`
-	COSMIC  COSMICConfig  `mapstructure:"cosmic"`
-	PubMed  PubMedConfig  `mapstructure:"pubmed"`
-	LOVD    LOVDConfig    `mapstructure:"lovd"`
-	HGMD    HGMDConfig    `mapstructure:"hgmd"`
+	ClinVar    ClinVarConfig    `mapstructure:"clinvar"`
+	GnomAD     GnomADConfig     `mapstructure:"gnomad"`
+	COSMIC     COSMICConfig     `mapstructure:"cosmic"`
+	PubMed     PubMedConfig     `mapstructure:"pubmed"`
+	LOVD       LOVDConfig       `mapstructure:"lovd"`
+	HGMD       HGMDConfig       `mapstructure:"hgmd"`
+	Splicing   SplicingConfig   `mapstructure:"splicing"`
+	DbNSFP     DbNSFPConfig     `mapstructure:"dbnsfp"`
+	OncoKB     OncoKBConfig     `mapstructure:"oncokb"`
+	CIViC      CIViCConfig      `mapstructure:"civic"`
+	ClinGen    ClinGenConfig    `mapstructure:"clingen"`
+	Constraint ConstraintConfig `mapstructure:"constraint"`
+	VEP        VEPConfig        `mapstructure:"vep"`
+	OMIM       OMIMConfig       `mapstructure:"omim"`
+	Orphanet   OrphanetConfig   `mapstructure:"orphanet"`
+	HTTP       HTTPConfig       `mapstructure:"http"`
+
+	// How long evidence gathering waits on each source, keyed by source
+	// name, and on sources without a deadline of their own
+	SourceDeadline  time.Duration            `mapstructure:"source_deadline"`
+	SourceDeadlines map[string]time.Duration `mapstructure:"source_deadlines"`
+}
+
+// HTTPConfig tunes the pooled transport shared by the external API clients
+type HTTPConfig struct {
+	MaxIdleConns        int                      `mapstructure:"max_idle_conns"`
+	MaxIdleConnsPerHost int                      `mapstructure:"max_idle_conns_per_host"`
+	MaxConnsPerHost     int                      `mapstructure:"max_conns_per_host"` // 0 is unlimited
+	IdleConnTimeout     time.Duration            `mapstructure:"idle_conn_timeout"`
+	DisableHTTP2        bool                     `mapstructure:"disable_http2"`
+	HostTimeouts        map[string]time.Duration `mapstructure:"host_timeouts"` // Per-attempt timeouts keyed by host name
+	MaxRetries          int                      `mapstructure:"max_retries"`   // For clients without their own retry_count
+	RetryBaseDelay      time.Duration            `mapstructure:"retry_base_delay"`
+	RetryMaxDelay       time.Duration            `mapstructure:"retry_max_delay"`
 }
 
 // ClinVarConfig represents ClinVar API configuration
@@ -92,6 +122,7 @@ type GnomADConfig struct {
 	Timeout    time.Duration `mapstructure:"timeout"`
 	RateLimit  int           `mapstructure:"rate_limit"`
 	RetryCount int           `mapstructure:"retry_count"`
+	Dataset    string        `mapstructure:"dataset"` // gnomad_r4 (default, joint exome+genome) or gnomad_r3
 }
 
 // COSMICConfig represents COSMIC API configuration
@@ -105,11 +136,17 @@ type COSMICConfig struct {
 
 // CacheConfig represents cache configuration
 type CacheConfig struct {
-	RedisURL    string        `mapstructure:"redis_url"`
+	RedisURL    string        `mapstructure:"redis_url"` // Empty for an in-memory LRU cache
 	DefaultTTL  time.Duration `mapstructure:"default_ttl"`
 	MaxRetries  int           `mapstructure:"max_retries"`
 	PoolSize    int           `mapstructure:"pool_size"`
 	PoolTimeout time.Duration `mapstructure:"pool_timeout"`
+
+	MaxEntries           int                      `mapstructure:"max_entries"`            // Size of the in-memory LRU cache
+	MaxBytes             int64                    `mapstructure:"max_bytes"`              // Memory bound of the in-memory LRU cache; unbounded if zero
+	SpillPath            string                   `mapstructure:"spill_path"`             // SQLite file receiving entries evicted from memory; none if empty
+	SourceTTLs           map[string]time.Duration `mapstructure:"source_ttls"`            // Per-source TTL overrides, keyed by source (clinvar, gnomad, ...)
+	StaleWhileRevalidate time.Duration            `mapstructure:"stale_while_revalidate"` // Overrides every source's stale window
 }
 
 // LoggingConfig represents logging configuration
@@ -122,13 +159,20 @@ type LoggingConfig struct {
 	MaxBackups int    `mapstructure:"max_backups"`
 	MaxAge     int    `mapstructure:"max_age"`
 	Compress   bool   `mapstructure:"compress"`
+	// PHI-safe logging hides patient identifiers and clinical free text in
+	// log fields and audit exports
+	PHISafeLogging      bool     `mapstructure:"phi_safe_logging"`
+	PHIRedactionMode    string   `mapstructure:"phi_redaction_mode"`    // Identifiers are redacted (default) or tokenized
+	PHITokenKey         string   `mapstructure:"phi_token_key"`         // Keys identifier tokens so they stay stable across restarts
+	PHIIdentifierFields []string `mapstructure:"phi_identifier_fields"` // Added to the default identifier fields
+	PHIFreeTextFields   []string `mapstructure:"phi_free_text_fields"`  // Added to the default free text fields
 }
 
 // MCPConfig represents MCP server configuration
 type MCPConfig struct {
 	ServerName       string        `mapstructure:"server_name"`
 	ServerVersion    string        `mapstructure:"server_version"`
-	TransportType    string        `mapstructure:"transport_type"`    // "stdio", "http"
+	TransportType    string        `mapstructure:"transport_type"` // "stdio", "http"
 	HTTPPort         int           `mapstructure:"http_port"`
 	HTTPHost         string        `mapstructure:"http_host"`
 	MaxClients       int           `mapstructure:"max_clients"`
@@ -137,18 +181,163 @@ type MCPConfig struct {
 	EnableCaching    bool          `mapstructure:"enable_caching"`
 	ToolCacheTTL     time.Duration `mapstructure:"tool_cache_ttl"`
 	ResourceCacheTTL time.Duration `mapstructure:"resource_cache_ttl"`
+	// Maximum serialized tool response size per transport; larger results are summarized
+	MaxResponseBytesStdio int `mapstructure:"max_response_bytes_stdio"`
+	MaxResponseBytesHTTP  int `mapstructure:"max_response_bytes_http"`
+	// Largest incoming stdio message; larger requests are rejected with -32600
+	MaxMessageBytes int `mapstructure:"max_message_bytes"`
+	// WebSocket keepalive: ping interval, and how long a session may go
+	// without messages before it is closed (negative disables)
+	WebSocketPingInterval time.Duration `mapstructure:"websocket_ping_interval"`
+	WebSocketIdleTimeout  time.Duration `mapstructure:"websocket_idle_timeout"`
+	// Listen address of the Prometheus /metrics endpoint; disabled when empty
+	MetricsAddr string `mapstructure:"metrics_addr"`
+	// OTLP/HTTP collector receiving traces; disabled when empty
+	TracingEndpoint  string  `mapstructure:"tracing_endpoint"`
+	TraceSampleRatio float64 `mapstructure:"trace_sample_ratio"`
+	// Batch classification limits for classify_variants_batch
+	BatchClassifyLimit   int `mapstructure:"batch_classify_limit"`
+	BatchClassifyWorkers int `mapstructure:"batch_classify_workers"`
+	// Default locale of reports, prompt output and error messages: en, ja or zh-Hant
+	Locale string `mapstructure:"locale"`
+	// Serve mock evidence resources, marked with the mock origin, when no
+	// live or cached evidence exists for a variant
+	EvidenceMockFallback bool `mapstructure:"evidence_mock_fallback"`
+	// Base64 Ed25519 seed signing audit export bundles; bundles are unsigned when empty
+	AuditSigningKey string `mapstructure:"audit_signing_key"`
+	// Per-client throttling of the HTTP transport; clients are identified by
+	// one of APIKeys (X-API-Key header) or else by IP
+	RateLimitRPS   float64  `mapstructure:"rate_limit_rps"`   // Sustained requests per second; 0 disables
+	RateLimitBurst int      `mapstructure:"rate_limit_burst"` // Requests allowed at once
+	DailyQuota     int      `mapstructure:"daily_quota"`      // Requests per client per UTC day; 0 is unlimited
+	APIKeys        []string `mapstructure:"api_keys"`
+}
+
+// AuthConfig represents authentication of HTTP clients. The HTTP transport
+// requires at least one API key, a JWT secret or an anonymous role.
+type AuthConfig struct {
+	APIKeys       []AuthAPIKey `mapstructure:"api_keys"`
+	JWTSecret     string       `mapstructure:"jwt_secret"`     // HS256 secret; JWT bearer tokens are rejected when empty
+	JWTIssuer     string       `mapstructure:"jwt_issuer"`     // Required iss claim, if set
+	JWTAudience   string       `mapstructure:"jwt_audience"`   // Required aud claim, if set
+	AnonymousRole string       `mapstructure:"anonymous_role"` // Role of requests without credentials; empty requires credentials
+}
+
+// AuthAPIKey represents an API key and the role it grants
+type AuthAPIKey struct {
+	Name string `mapstructure:"name"`
+	Role string `mapstructure:"role"` // read_only, classify, reviewer or admin
+	Key  string `mapstructure:"key"`
+}
+
+// ClassificationConfig represents rule engine configuration
+type ClassificationConfig struct {
+	// JSON or YAML file of per-gene and per-condition BA1/BS1/PM2 frequency thresholds
+	FrequencyThresholdsFile string `mapstructure:"frequency_thresholds_file"`
+	// JSON or YAML file of resolution policies for conflicting criteria, such as PS3 with BS3
+	ConflictPoliciesFile string `mapstructure:"conflict_policies_file"`
+	// JSON or YAML laboratory policy overriding criterion strengths, such as PP3 at moderate for high REVEL scores
+	WeightingPolicyFile string `mapstructure:"weighting_policy_file"`
+	// JSON or YAML tables of calibrated predictor score intervals assigning PP3/BP4 strength
+	PredictorCalibrationFile string `mapstructure:"predictor_calibration_file"`
+	// Source of transcript consequences: internal (default) or vep
+	ConsequenceAnnotator string `mapstructure:"consequence_annotator"`
+	// Directory of UniProt, Pfam and hotspot tables (.tsv) used for PM1
+	ProteinDomainDir string `mapstructure:"protein_domain_dir"`
+	// Directory holding hp.obo and genes_to_phenotype.txt, used for PP4
+	HPODir string `mapstructure:"hpo_dir"`
+	// Maximum memoized classification results; 0 disables the result cache
+	ResultCacheSize int `mapstructure:"result_cache_size"`
+	// How long a classification result is memoized
+	ResultCacheTTL time.Duration `mapstructure:"result_cache_ttl"`
 }
 
 // PubMedConfig represents PubMed API configuration
 type PubMedConfig struct {
 	BaseURL    string        `mapstructure:"base_url"`
+	LitVarURL  string        `mapstructure:"litvar_url"` // LitVar2 API used to find the articles mentioning a variant
 	APIKey     string        `mapstructure:"api_key"`
-	Email      string        `mapstructure:"email"`      // Required by NCBI
+	Email      string        `mapstructure:"email"` // Required by NCBI
 	Timeout    time.Duration `mapstructure:"timeout"`
 	RateLimit  int           `mapstructure:"rate_limit"`
 	RetryCount int           `mapstructure:"retry_count"`
 }
 
+// SplicingConfig represents SpliceAI/Pangolin prediction configuration.
+// ScoresFile (precomputed VCF or TSV) is consulted first; BaseURL is a
+// SpliceAI lookup API serving /spliceai/ and /pangolin/.
+type SplicingConfig struct {
+	BaseURL    string        `mapstructure:"base_url"`
+	ScoresFile string        `mapstructure:"scores_file"`
+	Tools      []string      `mapstructure:"tools"`    // "SpliceAI", "Pangolin"
+	Genome     string        `mapstructure:"genome"`   // "37" or "38"
+	Distance   int           `mapstructure:"distance"` // bases either side of the variant
+	Timeout    time.Duration `mapstructure:"timeout"`
+	RateLimit  int           `mapstructure:"rate_limit"`
+}
+
+// DbNSFPConfig represents local dbNSFP in silico score configuration.
+// File is a SQLite import made by "setup dbnsfp" or a bgzipped dbNSFP file
+// with a tabix index alongside it.
+type DbNSFPConfig struct {
+	File string `mapstructure:"file"`
+}
+
+// OncoKBConfig represents OncoKB API configuration. OncoKB requires an
+// API token; somatic evidence from OncoKB is disabled without one.
+type OncoKBConfig struct {
+	BaseURL   string        `mapstructure:"base_url"`
+	Token     string        `mapstructure:"token"`
+	Timeout   time.Duration `mapstructure:"timeout"`
+	RateLimit int           `mapstructure:"rate_limit"`
+}
+
+// VEPConfig represents Ensembl VEP REST API configuration, used when
+// classification.consequence_annotator is "vep"
+type VEPConfig struct {
+	BaseURL     string        `mapstructure:"base_url"`
+	Transcripts string        `mapstructure:"transcripts"` // ensembl (default), refseq or merged
+	Plugins     []string      `mapstructure:"plugins"`     // VEP plugins to enable per request, e.g. LoF, SpliceAI
+	Timeout     time.Duration `mapstructure:"timeout"`
+	RateLimit   int           `mapstructure:"rate_limit"`
+}
+
+// ConstraintConfig represents the local gnomAD gene constraint table used
+// by PVS1, PP2 and BP1. Disabled when File is empty.
+type ConstraintConfig struct {
+	File string `mapstructure:"file"`
+}
+
+// OMIMConfig represents OMIM API configuration, used for gene-disease
+// associations. Disabled without an API key.
+type OMIMConfig struct {
+	BaseURL   string        `mapstructure:"base_url"`
+	APIKey    string        `mapstructure:"api_key"`
+	Timeout   time.Duration `mapstructure:"timeout"`
+	RateLimit int           `mapstructure:"rate_limit"`
+}
+
+// OrphanetConfig represents the local Orphadata files used for gene-disease
+// associations, prevalence and natural history. Disabled when Dir is empty.
+type OrphanetConfig struct {
+	Dir string `mapstructure:"dir"`
+}
+
+// ClinGenConfig represents ClinGen GeneGraph API configuration, used for
+// gene-disease validity and dosage sensitivity curations
+type ClinGenConfig struct {
+	BaseURL   string        `mapstructure:"base_url"`
+	Timeout   time.Duration `mapstructure:"timeout"`
+	RateLimit int           `mapstructure:"rate_limit"`
+}
+
+// CIViCConfig represents CIViC GraphQL API configuration
+type CIViCConfig struct {
+	BaseURL   string        `mapstructure:"base_url"`
+	Timeout   time.Duration `mapstructure:"timeout"`
+	RateLimit int           `mapstructure:"rate_limit"`
+}
+
 // LOVDConfig represents LOVD API configuration
 type LOVDConfig struct {
 	BaseURL    string        `mapstructure:"base_url"`
@@ -162,8 +351,8 @@ type LOVDConfig struct {
 type HGMDConfig struct {
 	BaseURL        string        `mapstructure:"base_url"`
 	APIKey         string        `mapstructure:"api_key"`
-	License        string        `mapstructure:"license"`          // Professional license
-	IsProfessional bool          `mapstructure:"is_professional"`  // Use professional API
+	License        string        `mapstructure:"license"`         // Professional license
+	IsProfessional bool          `mapstructure:"is_professional"` // Use professional API
 	Timeout        time.Duration `mapstructure:"timeout"`
 	RateLimit      int           `mapstructure:"rate_limit"`
 	RetryCount     int           `mapstructure:"retry_count"`
@@ -0,0 +1,227 @@
+// Command enumgen generates the domain enum constants and their JSON schema
+// from internal/domain/enums.json. Run it through go generate in
+// internal/domain after editing the definitions.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// Enum is a string enum definition
+type Enum struct {
+	Type        string  `json:"type"`
+	Description string  `json:"description"`
+	Error       string  `json:"error"` // Sentinel error returned for unknown values
+	Values      []Value `json:"values"`
+}
+
+// Value is one enum member
+type Value struct {
+	Name    string   `json:"name"`  // Go constant name
+	Value   string   `json:"value"` // Canonical wire value
+	Label   string   `json:"label"` // Display label
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// Spellings returns every accepted spelling keyed by its normalized form
+func (v Value) Spellings() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, s := range append([]string{v.Value, v.Label}, v.Aliases...) {
+		key := normalize(s)
+		if key != "" && !seen[key] {
+			seen[key] = true
+			out = append(out, key)
+		}
+	}
+	return out
+}
+
+// Receiver returns the receiver name used for the type's methods
+func (e Enum) Receiver() string {
+	var r []rune
+	for _, c := range e.Type {
+		if c >= 'A' && c <= 'Z' {
+			r = append(r, c+'a'-'A')
+		}
+	}
+	return string(r)
+}
+
+// Var returns the name of the registry variable for the type
+func (e Enum) Var() string {
+	return strings.ToLower(e.Type[:1]) + e.Type[1:] + "Enum"
+}
+
+// normalize must match normalizeEnumKey in the domain package
+func normalize(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}), "_")
+}
+
+func main() {
+	in := flag.String("in", "enums.json", "enum definitions")
+	goOut := flag.String("go", "enums_gen.go", "generated Go file")
+	schemaOut := flag.String("schema", "", "generated JSON schema file")
+	flag.Parse()
+
+	enums, err := load(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source, err := renderGo(enums)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*goOut, source, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	if *schemaOut != "" {
+		schema, err := renderSchema(enums)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*schemaOut, schema, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// load reads and checks the enum definitions
+func load(path string) ([]Enum, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read enum definitions: %w", err)
+	}
+	var enums []Enum
+	if err := json.Unmarshal(data, &enums); err != nil {
+		return nil, fmt.Errorf("failed to parse enum definitions: %w", err)
+	}
+
+	for _, e := range enums {
+		if e.Type == "" || e.Error == "" || len(e.Values) == 0 {
+			return nil, fmt.Errorf("enum %q needs a type, an error and values", e.Type)
+		}
+		owner := make(map[string]string)
+		for _, v := range e.Values {
+			if v.Name == "" || v.Value == "" || v.Label == "" {
+				return nil, fmt.Errorf("%s: every value needs a name, value and label", e.Type)
+			}
+			for _, key := range v.Spellings() {
+				if other, ok := owner[key]; ok && other != v.Name {
+					return nil, fmt.Errorf("%s: spelling %q is ambiguous between %s and %s", e.Type, key, other, v.Name)
+				}
+				owner[key] = v.Name
+			}
+		}
+	}
+	return enums, nil
+}
+
+var goTemplate = template.Must(template.New("go").Parse(`// Code generated by enumgen from enums.json; DO NOT EDIT.
+
+package domain
+{{range .}}{{$type := .Type}}
+// {{.Type}} values
+const (
+{{- range .Values}}
+	{{.Name}} {{$type}} = {{printf "%q" .Value}}
+{{- end}}
+)
+{{end}}
+{{- range .}}
+var {{.Var}} = &enum[{{.Type}}]{
+	values: []{{.Type}}{ {{- range $i, $v := .Values}}{{if $i}}, {{end}}{{$v.Name}}{{end}}},
+	labels: map[{{.Type}}]string{
+{{- range .Values}}
+		{{.Name}}: {{printf "%q" .Label}},
+{{- end}}
+	},
+	lookup: map[string]{{.Type}}{
+{{- range $v := .Values}}{{range .Spellings}}
+		{{printf "%q" .}}: {{$v.Name}},
+{{- end}}{{end}}
+	},
+	invalid: {{.Error}},
+}
+
+// {{.Type}}Values returns every {{.Type}} in canonical order.
+func {{.Type}}Values() []{{.Type}} {
+	return append([]{{.Type}}(nil), {{.Var}}.values...)
+}
+
+// {{.Type}}Enum returns the canonical {{.Type}} values for JSON schema enums.
+func {{.Type}}Enum() []string {
+	return {{.Var}}.strings()
+}
+
+// Parse{{.Type}} accepts a canonical value, display label or known alias
+// in any case and returns the canonical {{.Type}}.
+func Parse{{.Type}}(s string) ({{.Type}}, error) {
+	return {{.Var}}.parse(s)
+}
+
+// Label returns the display label, e.g. for reports.
+func ({{.Receiver}} {{.Type}}) Label() string {
+	return {{.Var}}.labels[{{.Receiver}}]
+}
+{{end}}`))
+
+// renderGo renders the generated Go source
+func renderGo(enums []Enum) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := goTemplate.Execute(&buf, enums); err != nil {
+		return nil, fmt.Errorf("failed to render Go source: %w", err)
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format Go source: %w\n%s", err, buf.String())
+	}
+	return source, nil
+}
+
+// schemaDefinition is a JSON schema string enum
+type schemaDefinition struct {
+	Type        string            `json:"type"`
+	Description string            `json:"description"`
+	Enum        []string          `json:"enum"`
+	Labels      map[string]string `json:"x-labels"`
+}
+
+// renderSchema renders the JSON schema with one definition per enum
+func renderSchema(enums []Enum) ([]byte, error) {
+	defs := make(map[string]schemaDefinition, len(enums))
+	for _, e := range enums {
+		def := schemaDefinition{Type: "string", Description: e.Description, Labels: make(map[string]string)}
+		for _, v := range e.Values {
+			def.Enum = append(def.Enum, v.Value)
+			def.Labels[v.Value] = v.Label
+		}
+		defs[e.Type] = def
+	}
+
+	schema := map[string]interface{}{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"$comment":    "Generated by enumgen from internal/domain/enums.json; DO NOT EDIT.",
+		"title":       "ACMG/AMP enums",
+		"description": "Canonical values shared by the MCP tools, resources and REST API",
+		"$defs":       defs,
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render JSON schema: %w", err)
+	}
+	return append(data, '\n'), nil
+}
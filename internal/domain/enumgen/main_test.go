@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedFilesUpToDate fails when enums.json changed without go generate
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	enums, err := load("../enums.json")
+	if err != nil {
+		t.Fatalf("Failed to load definitions: %v", err)
+	}
+
+	source, err := renderGo(enums)
+	if err != nil {
+		t.Fatalf("Failed to render Go source: %v", err)
+	}
+	assertFile(t, "../enums_gen.go", source)
+
+	schema, err := renderSchema(enums)
+	if err != nil {
+		t.Fatalf("Failed to render schema: %v", err)
+	}
+	assertFile(t, "../../../api/schemas/enums.json", schema)
+}
+
+func TestLoadRejectsAmbiguousSpellings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "enums.json")
+	definitions := `[{"type": "Classification", "error": "ErrInvalidClassification", "values": [
+		{"name": "LIKELY_BENIGN", "value": "LIKELY_BENIGN", "label": "Likely benign", "aliases": ["LB"]},
+		{"name": "BENIGN", "value": "BENIGN", "label": "Benign", "aliases": ["lb"]}]}]`
+	if err := os.WriteFile(path, []byte(definitions), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := load(path)
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("Expected ambiguous spelling error, got %v", err)
+	}
+}
+
+func assertFile(t *testing.T, path string, want []byte) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date; run go generate ./internal/domain", path)
+	}
+}
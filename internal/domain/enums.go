@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The classification, strength, category, confidence and evidence type enums
+// are defined once in enums.json. The constants, parsers and the JSON schema
+// in api/schemas/enums.json are generated from it, so tool schemas, resource
+// payloads and the domain model cannot drift apart.
+//go:generate go run ./enumgen -in enums.json -go enums_gen.go -schema ../../api/schemas/enums.json
+
+// enum is the registry entry for a string enum: canonical values in order,
+// display labels, and every accepted spelling keyed by its normalized form
+type enum[T ~string] struct {
+	values  []T
+	labels  map[T]string
+	lookup  map[string]T
+	invalid error
+}
+
+func (e *enum[T]) contains(value T) bool {
+	for _, v := range e.values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *enum[T]) strings() []string {
+	out := make([]string, len(e.values))
+	for i, v := range e.values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// parse maps a canonical value, display label or known alias to its canonical value
+func (e *enum[T]) parse(s string) (T, error) {
+	if v, ok := e.lookup[normalizeEnumKey(s)]; ok {
+		return v, nil
+	}
+	var zero T
+	return zero, fmt.Errorf("%w: %q", e.invalid, s)
+}
+
+// normalizeEnumKey folds case and separators, so "Likely pathogenic",
+// "likely-pathogenic" and "LIKELY_PATHOGENIC" compare equal
+func normalizeEnumKey(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}), "_")
+}
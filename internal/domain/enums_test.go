@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseClassification(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Classification
+	}{
+		{"LIKELY_PATHOGENIC", LIKELY_PATHOGENIC},
+		{"Likely pathogenic", LIKELY_PATHOGENIC},
+		{"Likely Pathogenic", LIKELY_PATHOGENIC},
+		{" likely-pathogenic ", LIKELY_PATHOGENIC},
+		{"Uncertain significance", VUS},
+		{"vus", VUS},
+		{"LB", LIKELY_BENIGN},
+	}
+	for _, tt := range tests {
+		got, err := ParseClassification(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseClassification(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	if _, err := ParseClassification("Probably fine"); !errors.Is(err, ErrInvalidClassification) {
+		t.Errorf("Expected ErrInvalidClassification, got %v", err)
+	}
+}
+
+func TestParseRuleStrengthAndCategory(t *testing.T) {
+	for input, want := range map[string]RuleStrength{"very_strong": VERY_STRONG, "Stand-alone": VERY_STRONG, "Supporting": SUPPORTING} {
+		if got, err := ParseRuleStrength(input); err != nil || got != want {
+			t.Errorf("ParseRuleStrength(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if got, err := ParseRuleCategory("benign"); err != nil || got != BENIGN_RULE {
+		t.Errorf("ParseRuleCategory(benign) = %q, %v", got, err)
+	}
+	if got, err := ParseConfidenceLevel("moderate"); err != nil || got != MEDIUM {
+		t.Errorf("ParseConfidenceLevel(moderate) = %q, %v", got, err)
+	}
+	if _, err := ParseEvidenceType("astrology"); !errors.Is(err, ErrInvalidEvidenceType) {
+		t.Errorf("Expected ErrInvalidEvidenceType, got %v", err)
+	}
+}
+
+func TestEnumValuesAreValidAndLabelled(t *testing.T) {
+	for _, c := range ClassificationValues() {
+		if !c.IsValid() || c.Label() == "" {
+			t.Errorf("Classification %q is not valid or has no label", c)
+		}
+	}
+	for _, s := range RuleStrengthValues() {
+		if !s.IsValid() {
+			t.Errorf("RuleStrength %q is not valid", s)
+		}
+	}
+	for _, c := range RuleCategoryValues() {
+		if !c.IsValid() {
+			t.Errorf("RuleCategory %q is not valid", c)
+		}
+	}
+	for _, c := range ConfidenceLevelValues() {
+		if !c.IsValid() {
+			t.Errorf("ConfidenceLevel %q is not valid", c)
+		}
+	}
+	if EvidenceType("population").IsValid() != true || EvidenceType("Population").IsValid() {
+		t.Error("Expected only canonical evidence types to be valid")
+	}
+}
+
+// TestOpenAPIEnumsMatchRegistry guards the hand-written OpenAPI document against drift
+func TestOpenAPIEnumsMatchRegistry(t *testing.T) {
+	data, err := os.ReadFile("../../api/openapi.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read OpenAPI document: %v", err)
+	}
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				Enum       []string `yaml:"enum"`
+				Properties map[string]struct {
+					Enum []string `yaml:"enum"`
+				} `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Failed to parse OpenAPI document: %v", err)
+	}
+
+	schemas := doc.Components.Schemas
+	checks := map[string][]string{
+		"Classification":  ClassificationEnum(),
+		"ConfidenceLevel": ConfidenceLevelEnum(),
+	}
+	for name, want := range checks {
+		assertEnum(t, name, schemas[name].Enum, want)
+	}
+	assertEnum(t, "ACMGAMPRule.category", schemas["ACMGAMPRule"].Properties["category"].Enum, RuleCategoryEnum())
+	assertEnum(t, "ACMGAMPRule.strength", schemas["ACMGAMPRule"].Properties["strength"].Enum, RuleStrengthEnum())
+}
+
+func assertEnum(t *testing.T, name string, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s enum = %v, want %v", name, got, want)
+		return
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s enum = %v, want %v", name, got, want)
+			return
+		}
+	}
+}
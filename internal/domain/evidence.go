@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
@@ -13,7 +14,194 @@ type AggregatedEvidence struct {
 	LiteratureData    *LiteratureData    `json:"literature_data,omitempty"`
 	LOVDData          *LOVDData          `json:"lovd_data,omitempty"`
 	HGMDData          *HGMDData          `json:"hgmd_data,omitempty"`
-	GatheredAt        time.Time          `json:"gathered_at"`
+	// SplicingPredictions holds the strongest SpliceAI/Pangolin prediction per tool
+	SplicingPredictions []SplicingPrediction `json:"splicing_predictions,omitempty"`
+	// ResidueVariants are curated pathogenic ClinVar variants at the same
+	// protein residue as a missense variant, for PS1 and PM5. Nil when no
+	// lookup was made; empty when the lookup found none.
+	ResidueVariants []ResidueVariant `json:"residue_variants,omitempty"`
+	// GeneCuration is ClinGen's gene-disease validity and dosage curation
+	// of the variant's gene, when a curation source is configured
+	GeneCuration *GeneCuration `json:"gene_curation,omitempty"`
+	// GeneConstraint is the gnomAD constraint of the variant's gene, used by
+	// PVS1, PP2 and BP1
+	GeneConstraint *GeneConstraint `json:"gene_constraint,omitempty"`
+	GatheredAt     time.Time       `json:"gathered_at"`
+	// Sources reports how each source fared while the evidence was gathered.
+	// IncompleteCategories lists the evidence categories missing a source
+	// that failed or missed its deadline; a classification made from the
+	// evidence is provisional.
+	Sources              []SourceStatus `json:"sources,omitempty"`
+	IncompleteCategories []string       `json:"incomplete_categories,omitempty"`
+}
+
+// Evidence categories of the sources gathered for a variant
+const (
+	EvidenceCategoryClinical      = "clinical"      // ClinVar, LOVD, HGMD and residue matches
+	EvidenceCategoryPopulation    = "population"    // gnomAD
+	EvidenceCategorySomatic       = "somatic"       // COSMIC
+	EvidenceCategoryLiterature    = "literature"    // PubMed
+	EvidenceCategoryComputational = "computational" // In silico and splicing predictions
+	EvidenceCategoryGene          = "gene"          // ClinGen curations and gene constraint
+)
+
+// Outcomes of querying an evidence source
+const (
+	SourceStatusOK      = "ok"
+	SourceStatusFailed  = "failed"
+	SourceStatusTimeout = "timeout" // Missed its deadline; gathering went on without it
+)
+
+// SourceStatus is the outcome of querying one evidence source
+type SourceStatus struct {
+	Source     string `json:"source"`
+	Category   string `json:"category"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Incomplete reports whether a source failed or missed its deadline
+func (e *AggregatedEvidence) Incomplete() bool {
+	return e != nil && len(e.IncompleteCategories) > 0
+}
+
+// GeneConstraint holds gnomAD gene constraint metrics of one transcript.
+// Metrics gnomAD does not report for the transcript are nil.
+type GeneConstraint struct {
+	Gene       string   `json:"gene"`
+	Transcript string   `json:"transcript,omitempty"`
+	PLI        *float64 `json:"pli,omitempty"`        // Probability of loss-of-function intolerance
+	LOEUF      *float64 `json:"loeuf,omitempty"`      // Upper bound of the observed/expected LoF ratio
+	MissenseZ  *float64 `json:"missense_z,omitempty"` // Missense constraint Z score
+	Source     string   `json:"source"`               // e.g. gnomAD v4.1
+}
+
+// ClinGen dosage sensitivity scores
+const (
+	DosageNoEvidence         = 0  // No evidence for dosage pathogenicity
+	DosageLittleEvidence     = 1  // Little evidence
+	DosageEmergingEvidence   = 2  // Emerging evidence
+	DosageSufficientEvidence = 3  // Sufficient evidence
+	DosageAutosomalRecessive = 30 // Gene associated with autosomal recessive phenotype
+	DosageUnlikely           = 40 // Dosage sensitivity unlikely
+	DosageNotCurated         = -1 // Not curated by ClinGen
+)
+
+// GeneCuration is ClinGen's curated knowledge about a gene
+type GeneCuration struct {
+	Gene               string                `json:"gene"`
+	HGNCID             string                `json:"hgnc_id,omitempty"`
+	Haploinsufficiency int                   `json:"haploinsufficiency_score"` // ClinGen score, -1 when not curated
+	Triplosensitivity  int                   `json:"triplosensitivity_score"`  // ClinGen score, -1 when not curated
+	Validity           []GeneDiseaseValidity `json:"gene_disease_validity,omitempty"`
+	Source             string                `json:"source"`
+}
+
+// GeneDiseaseValidity is a ClinGen gene-disease validity classification
+type GeneDiseaseValidity struct {
+	Disease           string `json:"disease"`
+	DiseaseID         string `json:"disease_id,omitempty"` // MONDO identifier
+	Classification    string `json:"classification"`       // Definitive, Strong, Moderate, Limited, Disputed, Refuted or No Known Disease Relationship
+	ModeOfInheritance string `json:"mode_of_inheritance,omitempty"`
+	ExpertPanel       string `json:"expert_panel,omitempty"`
+	ReportDate        string `json:"report_date,omitempty"`
+}
+
+// LossOfFunctionMechanism reports whether ClinGen's haploinsufficiency
+// curation establishes loss of function as a disease mechanism, including
+// for autosomal recessive disease, and whether the gene is curated at all
+func (g *GeneCuration) LossOfFunctionMechanism() (established, curated bool) {
+	if g == nil || g.Haploinsufficiency == DosageNotCurated {
+		return false, false
+	}
+	return g.Haploinsufficiency == DosageSufficientEvidence || g.Haploinsufficiency == DosageAutosomalRecessive, true
+}
+
+// SupportedDiseases returns the diseases with a definitive, strong or
+// moderate gene-disease validity classification
+func (g *GeneCuration) SupportedDiseases() []GeneDiseaseValidity {
+	if g == nil {
+		return nil
+	}
+	var supported []GeneDiseaseValidity
+	for _, validity := range g.Validity {
+		switch strings.ToLower(validity.Classification) {
+		case "definitive", "strong", "moderate":
+			supported = append(supported, validity)
+		}
+	}
+	return supported
+}
+
+// Disease association sources
+const (
+	DiseaseSourceOMIM     = "OMIM"
+	DiseaseSourceOrphanet = "Orphanet"
+)
+
+// DiseaseAssociation is a disease associated with a gene in OMIM or Orphanet
+type DiseaseAssociation struct {
+	Source           string              `json:"source"` // OMIM or Orphanet
+	ID               string              `json:"id"`     // e.g. OMIM:219700 or ORPHA:586
+	Name             string              `json:"name"`
+	Inheritance      []string            `json:"inheritance,omitempty"`
+	MappingKey       int                 `json:"mapping_key,omitempty"`       // OMIM phenotype mapping key; 3 when the molecular basis is known
+	PhenotypicSeries string              `json:"phenotypic_series,omitempty"` // OMIM phenotypic series, e.g. PS219700
+	AssociationType  string              `json:"association_type,omitempty"`  // Orphanet gene-disease association type
+	AgeOfOnset       []string            `json:"age_of_onset,omitempty"`
+	Prevalence       []DiseasePrevalence `json:"prevalence,omitempty"`
+}
+
+// DiseasePrevalence is an Orphanet prevalence or incidence estimate
+type DiseasePrevalence struct {
+	Type       string  `json:"type"`            // Point prevalence, Birth prevalence, Lifetime prevalence, Annual incidence or Cases/families
+	Class      string  `json:"class,omitempty"` // e.g. 1-9 / 100 000
+	Value      float64 `json:"value,omitempty"` // Mean value, e.g. the number of reported cases
+	Geographic string  `json:"geographic,omitempty"`
+	Validated  bool    `json:"validated"`
+}
+
+// GeneDiseases collects the diseases associated with a gene across sources
+type GeneDiseases struct {
+	Gene        string               `json:"gene"`
+	Diseases    []DiseaseAssociation `json:"diseases"`
+	Inheritance []string             `json:"inheritance,omitempty"` // Modes of inheritance across the diseases
+	Sources     []string             `json:"sources"`
+}
+
+// ResidueSourceLab marks a residue match from the lab knowledge base
+const ResidueSourceLab = "lab"
+
+// ResidueVariant is a pathogenic ClinVar variant altering the same amino
+// acid residue as the variant being classified, or a pathogenic assertion
+// from the lab knowledge base when Source is ResidueSourceLab
+type ResidueVariant struct {
+	Source               string `json:"source,omitempty"` // Empty for ClinVar
+	VariationID          string `json:"variation_id"`
+	Name                 string `json:"name"`           // ClinVar variant name, e.g. NM_007294.4(BRCA1):c.5095C>T (p.Arg1699Trp)
+	ProteinChange        string `json:"protein_change"` // One-letter form, e.g. R1699W
+	ClinicalSignificance string `json:"clinical_significance"`
+	ReviewStatus         string `json:"review_status"`
+	Stars                int    `json:"stars"`
+	SameChange           bool   `json:"same_change"` // Same amino acid change (PS1) rather than a different change at the residue (PM5)
+}
+
+// ReviewStatusStars converts a ClinVar review status to its star rating
+func ReviewStatusStars(status string) int {
+	status = strings.ToLower(status)
+	switch {
+	case strings.Contains(status, "practice guideline"):
+		return 4
+	case strings.Contains(status, "expert panel"):
+		return 3
+	case strings.Contains(status, "multiple submitters") && strings.Contains(status, "no conflicts"):
+		return 2
+	case strings.Contains(status, "criteria provided"):
+		return 1
+	default:
+		return 0
+	}
 }
 
 // ClinVarData represents data from ClinVar database
@@ -42,7 +230,12 @@ type PopulationData struct {
 	AlleleNumber          int                `json:"allele_number"`
 	PopulationFrequencies map[string]float64 `json:"population_frequencies"`
 	HomozygoteCount       int                `json:"homozygote_count"`
+	HemizygoteCount       int                `json:"hemizygote_count,omitempty"` // XY individuals carrying a chrX or chrY variant outside the pseudoautosomal regions
 	QualityMetrics        *QualityMetrics    `json:"quality_metrics"`
+	FAF95                 float64            `json:"faf95,omitempty"`          // Popmax filtering allele frequency, 95% confidence (gnomAD v4)
+	FAF99                 float64            `json:"faf99,omitempty"`          // Popmax filtering allele frequency, 99% confidence (gnomAD v4)
+	FAFPopulation         string             `json:"faf_population,omitempty"` // Genetic ancestry group with the highest FAF95
+	Dataset               string             `json:"dataset,omitempty"`        // gnomAD dataset, e.g. gnomad_r4
 }
 
 // QualityMetrics represents quality metrics for population data
@@ -61,13 +254,72 @@ type SomaticData struct {
 	Pathogenicity string   `json:"pathogenicity"`
 }
 
+// In silico predictor names, as listed in ComputationalData.ScoredBy
+const (
+	PredictorSIFT          = "SIFT"
+	PredictorPolyPhen      = "PolyPhen"
+	PredictorCADD          = "CADD"
+	PredictorREVEL         = "REVEL"
+	PredictorAlphaMissense = "AlphaMissense"
+	PredictorBayesDel      = "BayesDel"
+	PredictorGERP          = "GERP"
+	PredictorPhyloP        = "phyloP"
+)
+
 // ComputationalData represents computational prediction scores
 type ComputationalData struct {
-	SIFTScore     float64 `json:"sift_score"`
-	PolyPhenScore float64 `json:"polyphen_score"`
-	CADDScore     float64 `json:"cadd_score"`
-	GERPScore     float64 `json:"gerp_score"`
-	PhyloPScore   float64 `json:"phylop_score"`
+	SIFTScore          float64  `json:"sift_score"`
+	PolyPhenScore      float64  `json:"polyphen_score"`
+	CADDScore          float64  `json:"cadd_score"`
+	REVELScore         float64  `json:"revel_score,omitempty"`
+	AlphaMissenseScore float64  `json:"alphamissense_score,omitempty"`
+	BayesDelScore      float64  `json:"bayesdel_score,omitempty"` // BayesDel without allele frequency
+	GERPScore          float64  `json:"gerp_score"`
+	PhyloPScore        float64  `json:"phylop_score"`
+	ScoredBy           []string `json:"scored_by,omitempty"` // Predictors with a score for the variant
+	Source             string   `json:"source,omitempty"`    // e.g. "dbNSFP 4.9a"
+}
+
+// legacyPredictors are the scores present when ScoredBy is not set
+var legacyPredictors = []string{PredictorSIFT, PredictorPolyPhen, PredictorCADD, PredictorGERP, PredictorPhyloP}
+
+// Has reports whether a predictor scored the variant. Data without ScoredBy
+// carries SIFT, PolyPhen, CADD, GERP and phyloP scores only.
+func (c *ComputationalData) Has(predictor string) bool {
+	scoredBy := c.ScoredBy
+	if len(scoredBy) == 0 {
+		scoredBy = legacyPredictors
+	}
+	for _, name := range scoredBy {
+		if name == predictor {
+			return true
+		}
+	}
+	return false
+}
+
+// Splicing prediction events, as reported by SpliceAI (acceptor/donor) and
+// Pangolin (gain/loss)
+const (
+	SpliceEventAcceptorGain = "acceptor_gain"
+	SpliceEventAcceptorLoss = "acceptor_loss"
+	SpliceEventDonorGain    = "donor_gain"
+	SpliceEventDonorLoss    = "donor_loss"
+	SpliceEventGain         = "splice_gain"
+	SpliceEventLoss         = "splice_loss"
+)
+
+// SplicingPrediction is a splicing impact prediction from SpliceAI or Pangolin.
+// Score is the largest delta score across events; Scores holds every event's
+// delta score and Position the offset of the strongest event from the variant.
+type SplicingPrediction struct {
+	Tool     string             `json:"tool"`
+	Gene     string             `json:"gene,omitempty"`
+	Score    float64            `json:"score"`
+	Event    string             `json:"event,omitempty"`
+	Position int                `json:"position,omitempty"`
+	Scores   map[string]float64 `json:"scores,omitempty"`
+	Source   string             `json:"source,omitempty"`
 }
 
 // LiteratureData represents literature evidence from PubMed and other sources
@@ -83,21 +335,70 @@ type LiteratureData struct {
 
 // Citation represents a single literature citation
 type Citation struct {
-	PMID         string   `json:"pmid"`
-	DOI          string   `json:"doi,omitempty"`
-	Title        string   `json:"title"`
-	Authors      []string `json:"authors"`
-	Journal      string   `json:"journal"`
-	Year         int      `json:"year"`
-	Volume       string   `json:"volume,omitempty"`
-	Pages        string   `json:"pages,omitempty"`
-	ISSN         string   `json:"issn,omitempty"`
-	Abstract     string   `json:"abstract,omitempty"`
-	StudyType    string   `json:"study_type"`    // functional_study, clinical_study, case_report, etc.
-	Relevance    string   `json:"relevance"`     // high, moderate, low
-	Database     string   `json:"database"`      // PubMed, EMBASE, etc.
-	ImpactFactor float64  `json:"impact_factor,omitempty"`
-	KeyFindings  []string `json:"key_findings,omitempty"`
+	PMID         string           `json:"pmid"`
+	DOI          string           `json:"doi,omitempty"`
+	Title        string           `json:"title"`
+	Authors      []string         `json:"authors"`
+	Journal      string           `json:"journal"`
+	Year         int              `json:"year"`
+	Volume       string           `json:"volume,omitempty"`
+	Pages        string           `json:"pages,omitempty"`
+	ISSN         string           `json:"issn,omitempty"`
+	Abstract     string           `json:"abstract,omitempty"`
+	StudyType    string           `json:"study_type"` // functional_study, clinical_study, case_report, etc.
+	Relevance    string           `json:"relevance"`  // high, moderate, low
+	Database     string           `json:"database"`   // PubMed, EMBASE, etc.
+	ImpactFactor float64          `json:"impact_factor,omitempty"`
+	KeyFindings  []string         `json:"key_findings,omitempty"`
+	Signals      []string         `json:"signals,omitempty"` // Evidence signals mined from the abstract
+	Retracted    bool             `json:"retracted,omitempty"`
+	Notices      []CitationNotice `json:"notices,omitempty"` // Retractions and errata published for the article
+}
+
+// Literature signals mined from an article's title and abstract. They point
+// a curator at candidate evidence; they are not evidence on their own.
+const (
+	SignalFunctionalDamaging = "functional_damaging" // Functional study reports a damaging effect (PS3)
+	SignalFunctionalNormal   = "functional_normal"   // Functional study reports no damaging effect (BS3)
+	SignalCaseReport         = "case_report"         // Affected individuals carrying the variant are described
+	SignalReportedPathogenic = "reported_pathogenic" // Variant is reported as pathogenic or disease-causing (PP5)
+)
+
+// HasSignal reports whether the citation carries a literature signal
+func (c Citation) HasSignal(signal string) bool {
+	for _, s := range c.Signals {
+		if s == signal {
+			return true
+		}
+	}
+	return false
+}
+
+// Citation notice kinds
+const (
+	NoticeRetraction = "retraction"
+	NoticeErratum    = "erratum"
+)
+
+// CitationNotice is a retraction or erratum published for a cited article
+type CitationNotice struct {
+	Kind       string `json:"kind"`                  // retraction or erratum
+	NoticePMID string `json:"notice_pmid,omitempty"` // PMID of the notice, when indexed
+	Source     string `json:"source,omitempty"`      // Citation of the notice as given by PubMed
+}
+
+// ApplyNotices annotates citations with the notices recorded for their PMIDs
+func (l *LiteratureData) ApplyNotices(notices map[string][]CitationNotice) {
+	for i := range l.Citations {
+		citation := &l.Citations[i]
+		citation.Notices = notices[citation.PMID]
+		citation.Retracted = false
+		for _, notice := range citation.Notices {
+			if notice.Kind == NoticeRetraction {
+				citation.Retracted = true
+			}
+		}
+	}
 }
 
 // LOVDData represents data from LOVD (Leiden Open Variation Database)
@@ -140,3 +441,25 @@ type HGMDData struct {
 	Inheritance     string    `json:"inheritance"`      // AD, AR, XL, etc.
 	Tag             string    `json:"tag"`              // Additional classification tags
 }
+
+// Somatic evidence types, following the AMP/ASCO/CAP 2017 categories of
+// clinical significance
+const (
+	SomaticTherapeutic = "therapeutic"
+	SomaticDiagnostic  = "diagnostic"
+	SomaticPrognostic  = "prognostic"
+)
+
+// SomaticEvidence is one therapeutic, diagnostic or prognostic association of
+// a somatic variant from a cancer knowledge base, graded on the AMP/ASCO/CAP
+// levels of evidence
+type SomaticEvidence struct {
+	Source       string               `json:"source"`       // Knowledge base, e.g. OncoKB or CIViC
+	SourceLevel  string               `json:"source_level"` // Level as graded by the source, e.g. LEVEL_1 or B
+	Level        SomaticEvidenceLevel `json:"level"`
+	Type         string               `json:"type"`                   // therapeutic, diagnostic or prognostic
+	Significance string               `json:"significance,omitempty"` // e.g. sensitivity, resistance, poor outcome
+	TumorType    string               `json:"tumor_type,omitempty"`   // Tumor type the evidence applies to
+	Therapies    []string             `json:"therapies,omitempty"`
+	References   []string             `json:"references,omitempty"` // PMIDs
+}
@@ -16,16 +16,10 @@ import (
 // and represent the clinical significance of a genetic variant.
 //
 // Reference: ACMG/AMP 2015 Guidelines, Table 5
+//
+// The values are generated from enums.json; see enums_gen.go.
 type Classification string
 
-const (
-	PATHOGENIC        Classification = "PATHOGENIC"
-	LIKELY_PATHOGENIC Classification = "LIKELY_PATHOGENIC"
-	VUS               Classification = "VUS"
-	LIKELY_BENIGN     Classification = "LIKELY_BENIGN"
-	BENIGN            Classification = "BENIGN"
-)
-
 // VariantType represents the type of genetic variant
 type VariantType string
 
@@ -34,32 +28,33 @@ const (
 	SOMATIC  VariantType = "SOMATIC"
 )
 
-// RuleStrength represents the strength of ACMG/AMP evidence rules
+// RuleStrength represents the strength of ACMG/AMP evidence rules.
+// The values are generated from enums.json.
 type RuleStrength string
 
-const (
-	VERY_STRONG RuleStrength = "VERY_STRONG"
-	STRONG      RuleStrength = "STRONG"
-	MODERATE    RuleStrength = "MODERATE"
-	SUPPORTING  RuleStrength = "SUPPORTING"
-)
-
-// RuleCategory represents the category of ACMG/AMP rules
+// RuleCategory represents the category of ACMG/AMP rules.
+// The values are generated from enums.json.
 type RuleCategory string
 
-const (
-	PATHOGENIC_RULE RuleCategory = "PATHOGENIC"
-	BENIGN_RULE     RuleCategory = "BENIGN"
-)
-
-// ConfidenceLevel represents the confidence in the classification
+// ConfidenceLevel represents the confidence in the classification.
+// The values are generated from enums.json.
 type ConfidenceLevel string
 
-const (
-	HIGH   ConfidenceLevel = "High"
-	MEDIUM ConfidenceLevel = "Medium"
-	LOW    ConfidenceLevel = "Low"
-)
+// EvidenceType represents the kind of evidence considered in an interpretation.
+// The values are generated from enums.json.
+type EvidenceType string
+
+// SomaticTier represents the AMP/ASCO/CAP 2017 tier of a somatic variant.
+// The values are generated from enums.json.
+//
+// Reference: Li et al. (2017) Standards and Guidelines for the Interpretation
+// and Reporting of Sequence Variants in Cancer. J Mol Diagn. 19(1):4-23.
+type SomaticTier string
+
+// SomaticEvidenceLevel represents the AMP/ASCO/CAP 2017 level of evidence
+// (A-D) behind a somatic variant's clinical significance.
+// The values are generated from enums.json.
+type SomaticEvidenceLevel string
 
 // Validation errors for medical data integrity
 var (
@@ -67,7 +62,11 @@ var (
 	ErrInvalidClassification = errors.New("invalid ACMG/AMP classification")
 	ErrInvalidVariantType    = errors.New("invalid variant type")
 	ErrInvalidRuleStrength   = errors.New("invalid ACMG/AMP rule strength")
+	ErrInvalidRuleCategory   = errors.New("invalid ACMG/AMP rule category")
 	ErrInvalidConfidence     = errors.New("invalid confidence level")
+	ErrInvalidEvidenceType   = errors.New("invalid evidence type")
+	ErrInvalidSomaticTier    = errors.New("invalid AMP/ASCO/CAP somatic tier")
+	ErrInvalidEvidenceLevel  = errors.New("invalid AMP/ASCO/CAP evidence level")
 )
 
 // IsValid validates that the Classification follows ACMG/AMP guidelines.
@@ -179,6 +178,11 @@ func (cl ConfidenceLevel) IsValid() bool {
 	}
 }
 
+// IsValid validates the evidence type.
+func (et EvidenceType) IsValid() bool {
+	return evidenceTypeEnum.contains(et)
+}
+
 // Variant represents a genetic variant with all necessary information
 // for ACMG/AMP classification. This struct ensures all required data
 // is captured for clinical decision-making.
@@ -304,6 +308,26 @@ func (cl ConfidenceLevel) String() string {
 	return string(cl)
 }
 
+// IsValid validates the somatic tier.
+func (st SomaticTier) IsValid() bool {
+	return somaticTierEnum.contains(st)
+}
+
+// String returns the string representation of the somatic tier
+func (st SomaticTier) String() string {
+	return string(st)
+}
+
+// IsValid validates the somatic evidence level.
+func (sel SomaticEvidenceLevel) IsValid() bool {
+	return somaticEvidenceLevelEnum.contains(sel)
+}
+
+// String returns the string representation of the somatic evidence level
+func (sel SomaticEvidenceLevel) String() string {
+	return string(sel)
+}
+
 // String returns the string representation of the variant type
 func (vt VariantType) String() string {
 	return string(vt)
@@ -340,4 +364,105 @@ type ACMGAMPRuleResult struct {
 	Evidence    string       `json:"evidence"`     // Supporting evidence text
 	Reasoning   string       `json:"reasoning"`    // Reasoning for rule application/rejection
 	MetCriteria []string     `json:"met_criteria"` // Specific criteria that were met
+	// Curated variants the rule relied on, such as the ClinVar variants at the same residue for PS1 and PM5
+	MatchedVariants []ResidueVariant `json:"matched_variants,omitempty"`
+	// Protein domain or hotspot the variant lies in, for PM1
+	MatchedDomain *ProteinDomain `json:"matched_domain,omitempty"`
+	// Segregation LOD computation, for PP1 and BS4
+	Segregation *SegregationAnalysis `json:"segregation,omitempty"`
+	// Patient HPO terms scored against the gene's phenotypes, for PP4
+	PhenotypeMatch *PhenotypeMatch `json:"phenotype_match,omitempty"`
+	// Probands carrying the variant with a pathogenic variant in trans or homozygously, for PM3
+	InTrans *InTransTally `json:"in_trans,omitempty"`
+	// Laboratory weighting policy override applied, e.g. "PP3 SUPPORTING -> MODERATE (REVEL >= 0.932)"
+	PolicyOverride string `json:"policy_override,omitempty"`
+}
+
+// PhenotypeMatch is the semantic similarity of a patient's HPO terms to the
+// phenotypes associated with a gene
+type PhenotypeMatch struct {
+	Gene         string               `json:"gene"`
+	Score        float64              `json:"score"` // 0 (unrelated) to 1 (every term annotated to the gene)
+	Method       string               `json:"method"`
+	Terms        []PhenotypeTermMatch `json:"terms"`                   // Best match of each informative patient term, best first
+	UnknownTerms []string             `json:"unknown_terms,omitempty"` // Terms not in the ontology
+}
+
+// PhenotypeTermMatch is the best match of one patient HPO term among the
+// gene's phenotypes
+type PhenotypeTermMatch struct {
+	PatientTerm         string  `json:"patient_term"`
+	PatientLabel        string  `json:"patient_label,omitempty"`
+	GeneTerm            string  `json:"gene_term,omitempty"` // Gene phenotype sharing the common ancestor
+	GeneLabel           string  `json:"gene_label,omitempty"`
+	CommonAncestor      string  `json:"common_ancestor,omitempty"` // Most informative common ancestor
+	CommonAncestorLabel string  `json:"common_ancestor_label,omitempty"`
+	InformationContent  float64 `json:"information_content"` // Of the patient term
+	Similarity          float64 `json:"similarity"`          // Common ancestor information as a fraction of the patient term's
+}
+
+// SegregationRatios are the Bayes factors one relative contributes, by
+// phenotype and genotype
+type SegregationRatios struct {
+	AffectedCarrier      float64 `json:"affected_carrier"`
+	AffectedNoncarrier   float64 `json:"affected_noncarrier"`
+	UnaffectedCarrier    float64 `json:"unaffected_carrier"`
+	UnaffectedNoncarrier float64 `json:"unaffected_noncarrier"`
+}
+
+// SegregationAnalysis is the cosegregation likelihood computed from the
+// genotyped relatives of a proband
+type SegregationAnalysis struct {
+	Families              int               `json:"families,omitempty"`
+	Meioses               int               `json:"meioses"` // Informative meioses: the relatives counted
+	AffectedCarriers      int               `json:"affected_carriers"`
+	AffectedNoncarriers   int               `json:"affected_noncarriers"`
+	UnaffectedCarriers    int               `json:"unaffected_carriers"`
+	UnaffectedNoncarriers int               `json:"unaffected_noncarriers"`
+	Penetrance            float64           `json:"penetrance"`
+	PhenocopyRate         float64           `json:"phenocopy_rate"`
+	Ratios                SegregationRatios `json:"ratios"`
+	BayesFactor           float64           `json:"bayes_factor"` // Causal over neutral
+	LOD                   float64           `json:"lod"`          // log10 of the Bayes factor
+}
+
+// InTransTally is the ClinGen SVI PM3 point tally of the probands carrying
+// a variant for a recessive disorder. Each proband counts once, with its
+// best observation.
+type InTransTally struct {
+	Probands         int                  `json:"probands"`
+	Observations     []InTransObservation `json:"observations"`
+	HomozygousPoints float64              `json:"homozygous_points"` // Included in Points, capped at 1
+	Points           float64              `json:"points"`
+}
+
+// InTransObservation is the best PM3 observation in one proband
+type InTransObservation struct {
+	ProbandID           string  `json:"proband_id,omitempty"` // Empty for the proband being classified
+	Kind                string  `json:"kind"`                 // in_trans, phase_unknown, homozygous or none
+	OtherVariant        string  `json:"other_variant,omitempty"`
+	OtherClassification string  `json:"other_classification,omitempty"`
+	PhaseBasis          string  `json:"phase_basis,omitempty"`
+	Points              float64 `json:"points"` // Before the cap on homozygous points
+}
+
+// Protein domain annotation sources
+const (
+	DomainSourceUniProt = "uniprot" // UniProt domain, region or site features
+	DomainSourcePfam    = "pfam"    // Pfam protein families
+	DomainSourceHotspot = "hotspot" // Published mutational hotspots
+)
+
+// ProteinDomain is a functional domain or mutational hotspot of a protein,
+// with the ClinVar missense variation observed across it
+type ProteinDomain struct {
+	Gene               string  `json:"gene"`
+	Source             string  `json:"source"`              // uniprot, pfam or hotspot
+	Accession          string  `json:"accession,omitempty"` // e.g. PF00533, or a PubMed ID for a hotspot
+	Name               string  `json:"name"`
+	Start              int     `json:"start"` // First residue, 1-based
+	End                int     `json:"end"`   // Last residue, inclusive
+	BenignMissense     int     `json:"benign_missense"`
+	PathogenicMissense int     `json:"pathogenic_missense"`
+	BenignDensity      float64 `json:"benign_density"` // Benign missense variants per residue
 }
@@ -18,8 +18,57 @@ type StandardizedVariant struct {
 	GeneID       string      `json:"gene_id,omitempty" db:"gene_id"`
 	TranscriptID string      `json:"transcript_id,omitempty" db:"transcript_id"`
 	VariantType  VariantType `json:"variant_type" db:"variant_type"`
+	Consequence  string      `json:"consequence,omitempty" db:"-"` // Annotated consequence on TranscriptID, if known
 	CreatedAt    time.Time   `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time   `json:"updated_at" db:"updated_at"`
+
+	// TranscriptConsequences holds per-transcript annotations (e.g. from VEP)
+	TranscriptConsequences []TranscriptConsequence `json:"transcript_consequences,omitempty" db:"-"`
+
+	// Structural is set for repeat expansions and balanced structural
+	// variants, which the sequence variant criteria do not cover
+	Structural *StructuralVariant `json:"structural,omitempty" db:"-"`
+}
+
+// StructuralKind is a class of variant outside the ACMG/AMP sequence variant criteria
+type StructuralKind string
+
+const (
+	RepeatExpansion StructuralKind = "repeat_expansion" // Tandem repeat with a unit count, e.g. NM_002111.8:c.52CAG[45]
+	Inversion       StructuralKind = "inversion"        // e.g. NC_000023.11:g.154000000_154500000inv
+	Translocation   StructuralKind = "translocation"    // Junction between two chromosomes, e.g. NC_000009.12:g.130714455::NC_000022.11:g.23180365
+)
+
+// StructuralVariant describes a repeat expansion or a balanced structural variant
+type StructuralVariant struct {
+	Kind     StructuralKind `json:"kind"`
+	Notation string         `json:"notation"` // Normalized HGVS
+
+	// Repeat expansions
+	RepeatUnit     string `json:"repeat_unit,omitempty"`
+	RepeatCount    int    `json:"repeat_count,omitempty"`     // Number of units, or the lower bound of an uncertain count
+	RepeatCountMax int    `json:"repeat_count_max,omitempty"` // Upper bound of an uncertain count, e.g. CAG[(40_45)]
+
+	// Breakpoints are the ends of an inversion or the two sides of a translocation junction
+	Breakpoints []Breakend `json:"breakpoints,omitempty"`
+}
+
+// Breakend is one breakpoint of a structural variant
+type Breakend struct {
+	Reference  string `json:"reference"`            // Accession, e.g. NC_000009.12
+	Chromosome string `json:"chromosome,omitempty"` // When the reference is a chromosome
+	Position   int64  `json:"position"`
+}
+
+// TranscriptConsequence represents a variant's predicted consequence on a single transcript
+type TranscriptConsequence struct {
+	TranscriptID       string `json:"transcript_id"`
+	GeneSymbol         string `json:"gene_symbol,omitempty"`
+	HGVSCoding         string `json:"hgvs_coding,omitempty"`
+	HGVSProtein        string `json:"hgvs_protein,omitempty"`
+	Consequence        string `json:"consequence,omitempty"`         // Sequence Ontology term, e.g. stop_gained, missense_variant
+	ClinicallyRelevant bool   `json:"clinically_relevant,omitempty"` // MANE Select, MANE Plus Clinical or lab-designated
+	MANE               string `json:"mane,omitempty"`                // "MANE Select" or "MANE Plus Clinical"
 }
 
 // VariantRequest represents an incoming variant interpretation request
@@ -42,5 +91,56 @@ type VariantResponse struct {
 	Report         *InterpretationReport `json:"report"`
 	ProcessingTime string                `json:"processing_time"`
 	ProcessedAt    time.Time             `json:"processed_at"`
+	Normalized     *NormalizedVariant    `json:"normalized,omitempty"`
 	Errors         []string              `json:"errors,omitempty"`
 }
+
+// NormalizedVariant is a variant described against the reference genome and
+// transcript after HGVS normalization: indels are shifted to their most 3'
+// position and reference alleles are checked against the genome.
+type NormalizedVariant struct {
+	Input        string `json:"input"`
+	Assembly     string `json:"assembly"`
+	HGVSGenomic  string `json:"hgvs_genomic"`
+	HGVSCoding   string `json:"hgvs_coding,omitempty"`
+	TranscriptID string `json:"transcript_id,omitempty"`
+	GeneSymbol   string `json:"gene_symbol,omitempty"`
+	Chromosome   string `json:"chromosome"`
+	Start        int64  `json:"start"` // First affected base; for insertions, the base after the insertion point
+	End          int64  `json:"end"`   // Last affected base; Start-1 for insertions
+	Reference    string `json:"reference"`
+	Alternative  string `json:"alternative"`
+	Shifted      bool   `json:"shifted,omitempty"` // Indel was moved 3' from the input position
+
+	// Liftover is the same variant on the other supported assembly
+	Liftover *LiftedVariant `json:"liftover,omitempty"`
+	Warnings []string       `json:"warnings,omitempty"`
+}
+
+// LiftedVariant is a normalized variant converted to another assembly
+type LiftedVariant struct {
+	Assembly    string `json:"assembly"`
+	HGVSGenomic string `json:"hgvs_genomic"`
+	Chromosome  string `json:"chromosome"`
+	Start       int64  `json:"start"`
+	End         int64  `json:"end"`
+}
+
+// IdentifierKind identifies the database an accession belongs to
+type IdentifierKind string
+
+const (
+	IdentifierRSID IdentifierKind = "rsid" // dbSNP reference SNP, e.g. rs80357906
+	IdentifierVCV  IdentifierKind = "vcv"  // ClinVar variation record, e.g. VCV000017661
+	IdentifierRCV  IdentifierKind = "rcv"  // ClinVar variant-condition record, e.g. RCV000019241
+)
+
+// IdentifierMapping records the variants an rsID or ClinVar accession
+// resolves to. A multi-allelic rsID maps to one variant per allele.
+type IdentifierMapping struct {
+	Identifier string                `json:"identifier"`
+	Kind       IdentifierKind        `json:"kind"`
+	Variants   []StandardizedVariant `json:"variants"`
+	Source     string                `json:"source"`
+	ResolvedAt time.Time             `json:"resolved_at"`
+}
@@ -97,6 +97,17 @@ func createSchema(db *sql.DB) error {
 	CREATE INDEX IF NOT EXISTS idx_normalized_hgvs ON feedback(normalized_hgvs);
 	CREATE INDEX IF NOT EXISTS idx_cancer_type ON feedback(cancer_type);
 	CREATE INDEX IF NOT EXISTS idx_created_at ON feedback(created_at);
+
+	CREATE TABLE IF NOT EXISTS classification_changes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		normalized_hgvs TEXT NOT NULL,
+		cancer_type TEXT DEFAULT '',
+		from_classification TEXT NOT NULL,
+		to_classification TEXT NOT NULL,
+		changed_at DATETIME NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_changes_changed_at ON classification_changes(changed_at);
 	`
 
 	_, err := db.Exec(schema)
@@ -109,10 +120,11 @@ func (s *SQLiteStore) Save(ctx context.Context, feedback *Feedback) error {
 
 	// Check if exists
 	var existingID int64
+	var existingClass string
 	err := s.db.QueryRowContext(ctx,
-		"SELECT id FROM feedback WHERE normalized_hgvs = ? AND cancer_type = ?",
+		"SELECT id, user_classification FROM feedback WHERE normalized_hgvs = ? AND cancer_type = ?",
 		feedback.NormalizedHGVS, feedback.CancerType,
-	).Scan(&existingID)
+	).Scan(&existingID, &existingClass)
 
 	if err == nil {
 		// Update existing
@@ -139,7 +151,20 @@ func (s *SQLiteStore) Save(ctx context.Context, feedback *Feedback) error {
 			now,
 			existingID,
 		)
-		return err
+		if err != nil {
+			return err
+		}
+
+		// Record reclassifications of the signed-out call
+		if existingClass != string(feedback.UserClassification) {
+			if _, err := s.db.ExecContext(ctx, `
+				INSERT INTO classification_changes (normalized_hgvs, cancer_type, from_classification, to_classification, changed_at)
+				VALUES (?, ?, ?, ?, ?)
+			`, feedback.NormalizedHGVS, feedback.CancerType, existingClass, string(feedback.UserClassification), now.UTC()); err != nil {
+				return fmt.Errorf("failed to record classification change: %w", err)
+			}
+		}
+		return nil
 	}
 
 	if err != sql.ErrNoRows {
@@ -181,6 +206,33 @@ func (s *SQLiteStore) Save(ctx context.Context, feedback *Feedback) error {
 	return nil
 }
 
+// Changes returns classification changes made in [since, until), oldest first.
+func (s *SQLiteStore) Changes(ctx context.Context, since, until time.Time) ([]*ClassificationChange, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, normalized_hgvs, cancer_type, from_classification, to_classification, changed_at
+		FROM classification_changes
+		WHERE changed_at >= ? AND changed_at < ?
+		ORDER BY changed_at ASC, id ASC
+	`, since.UTC(), until.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*ClassificationChange
+	for rows.Next() {
+		c := &ClassificationChange{}
+		var from, to string
+		if err := rows.Scan(&c.ID, &c.NormalizedHGVS, &c.CancerType, &from, &to, &c.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan change: %w", err)
+		}
+		c.From = Classification(from)
+		c.To = Classification(to)
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
 // Get retrieves the most recent feedback for a variant.
 func (s *SQLiteStore) Get(ctx context.Context, normalizedHGVS string, cancerType string) (*Feedback, error) {
 	row := s.db.QueryRowContext(ctx, `
@@ -97,6 +97,42 @@ func TestSQLiteStore_Save_Update(t *testing.T) {
 	assert.Equal(t, "Updated after review", retrieved.Notes)
 }
 
+func TestSQLiteStore_Changes(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	start := time.Now().Add(-time.Minute)
+
+	feedback := &Feedback{
+		Variant:                 "TP53:c.743G>A",
+		NormalizedHGVS:          "NM_000546.6:c.743G>A",
+		SuggestedClassification: ClassificationVUS,
+		UserClassification:      ClassificationVUS,
+	}
+	require.NoError(t, store.Save(ctx, feedback))
+
+	// Re-saving the same call is not a reclassification
+	feedback.Notes = "Reviewed"
+	require.NoError(t, store.Save(ctx, feedback))
+
+	feedback.UserClassification = ClassificationLikelyPathogenic
+	require.NoError(t, store.Save(ctx, feedback))
+
+	// Act
+	changes, err := store.Changes(ctx, start, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	none, err := store.Changes(ctx, start.Add(-time.Hour), start)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, changes, 1)
+	assert.Equal(t, ClassificationVUS, changes[0].From)
+	assert.Equal(t, ClassificationLikelyPathogenic, changes[0].To)
+	assert.Equal(t, "NM_000546.6:c.743G>A", changes[0].NormalizedHGVS)
+	assert.Empty(t, none)
+}
+
 func TestSQLiteStore_Get(t *testing.T) {
 	store := createTestStore(t)
 	defer store.Close()
@@ -64,6 +64,22 @@ type Store interface {
 	Close() error
 }
 
+// ClassificationChange records a change to the user classification of an existing entry.
+type ClassificationChange struct {
+	ID             int64          `json:"id"`
+	NormalizedHGVS string         `json:"normalized_hgvs"`
+	CancerType     string         `json:"cancer_type,omitempty"`
+	From           Classification `json:"from"`
+	To             Classification `json:"to"`
+	ChangedAt      time.Time      `json:"changed_at"`
+}
+
+// ChangeLog is implemented by stores that keep a history of reclassifications.
+type ChangeLog interface {
+	// Changes returns classification changes made in [since, until), oldest first.
+	Changes(ctx context.Context, since, until time.Time) ([]*ClassificationChange, error)
+}
+
 // FeedbackExport represents the JSON export format.
 type FeedbackExport struct {
 	Version   string      `json:"version"`
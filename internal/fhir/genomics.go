@@ -0,0 +1,237 @@
+package fhir
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// Code systems
+const (
+	SystemLOINC    = "http://loinc.org"
+	SystemHGVS     = "http://varnomen.hgvs.org"
+	SystemRefSeq   = "http://www.ncbi.nlm.nih.gov/refseq"
+	SystemVRS      = "https://w3id.org/ga4gh/vrs"
+	SystemTBDCodes = "http://hl7.org/fhir/uv/genomics-reporting/CodeSystem/tbd-codes-cs"
+	SystemV20074   = "http://terminology.hl7.org/CodeSystem/v2-0074"
+	SystemCategory = "http://terminology.hl7.org/CodeSystem/observation-category"
+)
+
+// profileBase is the canonical URL of the Genomics Reporting profiles
+const profileBase = "http://hl7.org/fhir/uv/genomics-reporting/StructureDefinition/"
+
+// LOINC codes of the Genomics Reporting observations and components
+var (
+	codeGeneticAnalysisReport = loinc("51969-4", "Genetic analysis report")
+	codeVariantAssessment     = loinc("69548-6", "Genetic variant assessment")
+	codeGeneStudied           = loinc("48018-6", "Gene studied [ID]")
+	codeTranscriptReference   = loinc("51958-7", "Transcript reference sequence [ID]")
+	codeGenomicReference      = loinc("48013-7", "Genomic reference sequence [ID]")
+	codeAssembly              = loinc("62374-4", "Human reference sequence assembly version")
+	codeCodingChange          = loinc("48004-6", "DNA change (c.HGVS)")
+	codeGenomicChange         = loinc("81290-9", "Genomic DNA change (gHGVS)")
+	codeProteinChange         = loinc("48005-3", "Amino acid change (pHGVS)")
+	codeCoordinateSystem      = loinc("92822-6", "Genomic coordinate system")
+	codeAlleleStartEnd        = loinc("81254-5", "Genomic allele start-end")
+	codeRefAllele             = loinc("69547-8", "Genomic ref allele [ID]")
+	codeAltAllele             = loinc("69551-0", "Genomic alt allele [ID]")
+	codeClinicalSignificance  = loinc("53037-8", "Genetic variation clinical significance [Imp]")
+	answerPresent             = loinc("LA9633-4", "Present")
+	answerOneBasedCoordinates = loinc("LA30102-0", "1-based character counting")
+	codeDiagnosticImplication = CodeableConcept{Coding: []Coding{{System: SystemTBDCodes, Code: "diagnostic-implication", Display: "Diagnostic Implication"}}}
+	categoryGenetics          = CodeableConcept{Coding: []Coding{{System: SystemV20074, Code: "GE", Display: "Genetics"}}}
+	categoryLaboratory        = CodeableConcept{Coding: []Coding{{System: SystemCategory, Code: "laboratory", Display: "Laboratory"}}}
+	methodACMG                = CodeableConcept{Text: "ACMG/AMP 2015 sequence variant interpretation guidelines"}
+)
+
+// assemblyAnswers are the LOINC answers for the reference assemblies
+var assemblyAnswers = map[string]Coding{
+	"GRCh37": {System: SystemLOINC, Code: "LA14029-5", Display: "GRCh37"},
+	"GRCh38": {System: SystemLOINC, Code: "LA26806-2", Display: "GRCh38"},
+}
+
+// significanceAnswers are the LOINC answers for the ACMG/AMP classifications
+var significanceAnswers = map[domain.Classification]Coding{
+	domain.PATHOGENIC:        {System: SystemLOINC, Code: "LA6668-3", Display: "Pathogenic"},
+	domain.LIKELY_PATHOGENIC: {System: SystemLOINC, Code: "LA26332-9", Display: "Likely pathogenic"},
+	domain.VUS:               {System: SystemLOINC, Code: "LA26333-7", Display: "Uncertain significance"},
+	domain.LIKELY_BENIGN:     {System: SystemLOINC, Code: "LA26334-5", Display: "Likely benign"},
+	domain.BENIGN:            {System: SystemLOINC, Code: "LA6675-8", Display: "Benign"},
+}
+
+// Variant is a classified variant to report
+type Variant struct {
+	GeneSymbol   string
+	TranscriptID string
+	HGVSCoding   string
+	HGVSGenomic  string
+	HGVSProtein  string
+	Assembly     string // GRCh38 or GRCh37
+	Start, End   int64  // 1-based genomic positions, zero when not normalized
+	Reference    string
+	Alternative  string
+	VRSID        string
+
+	Classification string
+	Criteria       []string // Met criteria, e.g. PS3 or PM2_Supporting
+	Summary        string
+}
+
+// Name describes the variant in the report conclusion
+func (v Variant) Name() string {
+	notation := v.HGVSCoding
+	if notation == "" {
+		notation = v.HGVSGenomic
+	}
+	if v.GeneSymbol != "" {
+		return v.GeneSymbol + " " + notation
+	}
+	return notation
+}
+
+// NewReportBundle returns a collection Bundle with a DiagnosticReport and,
+// for each variant, a variant Observation and the diagnostic implication
+// Observation derived from it.
+func NewReportBundle(variants []Variant, issued time.Time) *Bundle {
+	timestamp := issued.UTC().Format(time.RFC3339)
+	bundle := &Bundle{ResourceType: "Bundle", ID: uuid.NewString(), Type: "collection", Timestamp: timestamp}
+	report := &DiagnosticReport{
+		ResourceType: "DiagnosticReport",
+		ID:           uuid.NewString(),
+		Meta:         Meta{Profile: []string{profileBase + "genomics-report"}},
+		Status:       "final",
+		Category:     []CodeableConcept{categoryGenetics},
+		Code:         codeGeneticAnalysisReport,
+		Issued:       timestamp,
+		Result:       []Reference{},
+	}
+	bundle.Entry = append(bundle.Entry, entry(report.ID, report))
+
+	var conclusions []string
+	for _, variant := range variants {
+		observation := NewVariantObservation(variant)
+		implication := NewImplicationObservation(variant, urn(observation.ID))
+		bundle.Entry = append(bundle.Entry, entry(observation.ID, observation), entry(implication.ID, implication))
+		report.Result = append(report.Result, Reference{urn(observation.ID)}, Reference{urn(implication.ID)})
+		conclusions = append(conclusions, fmt.Sprintf("%s: %s", variant.Name(), classificationText(variant.Classification)))
+	}
+	report.Conclusion = strings.Join(conclusions, "; ")
+	return bundle
+}
+
+// NewVariantObservation describes the variant as a Genomics Reporting
+// variant Observation. Components are given for what is known: without
+// normalization only the HGVS notation classified is reported.
+func NewVariantObservation(v Variant) *Observation {
+	present := answerPresent
+	observation := &Observation{
+		ResourceType:         "Observation",
+		ID:                   uuid.NewString(),
+		Meta:                 Meta{Profile: []string{profileBase + "variant"}},
+		Status:               "final",
+		Category:             []CodeableConcept{categoryLaboratory},
+		Code:                 codeVariantAssessment,
+		ValueCodeableConcept: &present,
+	}
+	if v.VRSID != "" {
+		observation.Identifier = []Identifier{{System: SystemVRS, Value: v.VRSID}}
+	}
+
+	add := func(code CodeableConcept, value CodeableConcept) {
+		observation.Component = append(observation.Component, Component{Code: code, ValueCodeableConcept: &value})
+	}
+	if v.GeneSymbol != "" {
+		add(codeGeneStudied, CodeableConcept{Text: v.GeneSymbol})
+	}
+	if v.TranscriptID != "" {
+		add(codeTranscriptReference, coded(SystemRefSeq, v.TranscriptID))
+	}
+	if v.HGVSCoding != "" {
+		add(codeCodingChange, coded(SystemHGVS, v.HGVSCoding))
+	}
+	if v.HGVSProtein != "" {
+		add(codeProteinChange, coded(SystemHGVS, v.HGVSProtein))
+	}
+	if v.HGVSGenomic != "" {
+		add(codeGenomicChange, coded(SystemHGVS, v.HGVSGenomic))
+		if accession, _, ok := strings.Cut(v.HGVSGenomic, ":"); ok {
+			add(codeGenomicReference, coded(SystemRefSeq, accession))
+		}
+	}
+	if answer, ok := assemblyAnswers[v.Assembly]; ok {
+		add(codeAssembly, CodeableConcept{Coding: []Coding{answer}})
+	}
+	if v.Start > 0 {
+		add(codeCoordinateSystem, answerOneBasedCoordinates)
+		observation.Component = append(observation.Component, Component{
+			Code:       codeAlleleStartEnd,
+			ValueRange: &Range{Low: Quantity{v.Start}, High: Quantity{v.End}},
+		})
+		if v.Reference != "" {
+			observation.Component = append(observation.Component, Component{Code: codeRefAllele, ValueString: v.Reference})
+		}
+		if v.Alternative != "" {
+			observation.Component = append(observation.Component, Component{Code: codeAltAllele, ValueString: v.Alternative})
+		}
+	}
+	return observation
+}
+
+// NewImplicationObservation reports the classification as a Genomics
+// Reporting diagnostic implication derived from the variant Observation.
+// The met criteria and evidence summary are given as notes.
+func NewImplicationObservation(v Variant, variantReference string) *Observation {
+	significance := CodeableConcept{Text: classificationText(v.Classification)}
+	if classification, err := domain.ParseClassification(v.Classification); err == nil {
+		significance.Coding = []Coding{significanceAnswers[classification]}
+	}
+
+	method := methodACMG
+	observation := &Observation{
+		ResourceType: "Observation",
+		ID:           uuid.NewString(),
+		Meta:         Meta{Profile: []string{profileBase + "diagnostic-implication"}},
+		Status:       "final",
+		Category:     []CodeableConcept{categoryLaboratory},
+		Code:         codeDiagnosticImplication,
+		Method:       &method,
+		DerivedFrom:  []Reference{{variantReference}},
+		Component:    []Component{{Code: codeClinicalSignificance, ValueCodeableConcept: &significance}},
+	}
+	if len(v.Criteria) > 0 {
+		observation.Note = append(observation.Note, Annotation{"ACMG/AMP criteria met: " + strings.Join(v.Criteria, ", ")})
+	}
+	if v.Summary != "" {
+		observation.Note = append(observation.Note, Annotation{v.Summary})
+	}
+	return observation
+}
+
+// classificationText returns the display label of a classification, or the
+// classification as given when it is not an ACMG/AMP class, e.g. a somatic tier
+func classificationText(value string) string {
+	if classification, err := domain.ParseClassification(value); err == nil {
+		return classification.Label()
+	}
+	return value
+}
+
+func loinc(code, display string) CodeableConcept {
+	return CodeableConcept{Coding: []Coding{{System: SystemLOINC, Code: code, Display: display}}}
+}
+
+func coded(system, code string) CodeableConcept {
+	return CodeableConcept{Coding: []Coding{{System: system, Code: code}}}
+}
+
+func urn(id string) string {
+	return "urn:uuid:" + id
+}
+
+func entry(id string, resource interface{}) BundleEntry {
+	return BundleEntry{FullURL: urn(id), Resource: resource}
+}
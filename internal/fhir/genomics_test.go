@@ -0,0 +1,78 @@
+package fhir
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// componentCodes lists the LOINC codes of an observation's components
+func componentCodes(observation *Observation) []string {
+	var codes []string
+	for _, component := range observation.Component {
+		codes = append(codes, component.Code.Coding[0].Code)
+	}
+	return codes
+}
+
+func TestNewReportBundle(t *testing.T) {
+	normalized := Variant{
+		GeneSymbol:     "BRCA1",
+		TranscriptID:   "NM_007294.4",
+		HGVSCoding:     "NM_007294.4:c.5095C>T",
+		HGVSGenomic:    "NC_000017.11:g.43057062G>A",
+		HGVSProtein:    "NP_009225.1:p.Arg1699Trp",
+		Assembly:       "GRCh38",
+		Start:          43057062,
+		End:            43057062,
+		Reference:      "G",
+		Alternative:    "A",
+		VRSID:          "ga4gh:VA.0AePZIWZUNsUlQTamyLrjm2HWUw2opLt",
+		Classification: "LIKELY_PATHOGENIC",
+		Criteria:       []string{"PS3", "PM2_Supporting"},
+		Summary:        "Functional studies show a damaging effect",
+	}
+	parsed := Variant{HGVSCoding: "NM_000492.3:c.1521_1523del", Classification: "Tier II"}
+	issued := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+
+	// Act
+	bundle := NewReportBundle([]Variant{normalized, parsed}, issued)
+
+	// Assert
+	assert.Equal(t, "Bundle", bundle.ResourceType)
+	assert.Equal(t, "collection", bundle.Type)
+	assert.Equal(t, "2026-03-02T09:30:00Z", bundle.Timestamp)
+	require.Len(t, bundle.Entry, 5)
+
+	report := bundle.Entry[0].Resource.(*DiagnosticReport)
+	assert.Equal(t, "51969-4", report.Code.Coding[0].Code)
+	require.Len(t, report.Result, 4)
+	assert.Equal(t, bundle.Entry[1].FullURL, report.Result[0].Reference)
+	assert.Equal(t, "BRCA1 NM_007294.4:c.5095C>T: Likely pathogenic; NM_000492.3:c.1521_1523del: Tier II", report.Conclusion)
+
+	variant := bundle.Entry[1].Resource.(*Observation)
+	assert.Equal(t, "69548-6", variant.Code.Coding[0].Code)
+	assert.Equal(t, []Identifier{{System: SystemVRS, Value: normalized.VRSID}}, variant.Identifier)
+	assert.Equal(t, []string{"48018-6", "51958-7", "48004-6", "48005-3", "81290-9", "48013-7", "62374-4", "92822-6", "81254-5", "69547-8", "69551-0"}, componentCodes(variant))
+
+	implication := bundle.Entry[2].Resource.(*Observation)
+	assert.Equal(t, []Reference{{bundle.Entry[1].FullURL}}, implication.DerivedFrom)
+	require.Len(t, implication.Component, 1)
+	assert.Equal(t, "53037-8", implication.Component[0].Code.Coding[0].Code)
+	assert.Equal(t, "LA26332-9", implication.Component[0].ValueCodeableConcept.Coding[0].Code)
+	assert.Equal(t, "ACMG/AMP criteria met: PS3, PM2_Supporting", implication.Note[0].Text)
+
+	unnormalized := bundle.Entry[3].Resource.(*Observation)
+	assert.Equal(t, []string{"48004-6"}, componentCodes(unnormalized))
+	tier := bundle.Entry[4].Resource.(*Observation)
+	assert.Empty(t, tier.Component[0].ValueCodeableConcept.Coding)
+	assert.Equal(t, "Tier II", tier.Component[0].ValueCodeableConcept.Text)
+
+	encoded, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"resourceType":"DiagnosticReport"`)
+	assert.Contains(t, string(encoded), `"valueRange":{"low":{"value":43057062},"high":{"value":43057062}}`)
+}
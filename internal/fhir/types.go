@@ -0,0 +1,107 @@
+// Package fhir renders classification results as HL7 FHIR R4 resources
+// following the HL7 Genomics Reporting implementation guide: a variant
+// Observation, a diagnostic implication Observation carrying the ACMG/AMP
+// classification, and a DiagnosticReport tying them together in a Bundle.
+// Only the elements the classification engine can fill are emitted; the
+// patient, specimen and performer are left to the receiving system.
+package fhir
+
+// MediaType is the FHIR JSON media type, for content negotiation
+const MediaType = "application/fhir+json"
+
+// Bundle is a FHIR Bundle of resources
+type Bundle struct {
+	ResourceType string        `json:"resourceType"`
+	ID           string        `json:"id"`
+	Type         string        `json:"type"`
+	Timestamp    string        `json:"timestamp"`
+	Entry        []BundleEntry `json:"entry"`
+}
+
+// BundleEntry is a resource in a bundle, addressed by its full URL
+type BundleEntry struct {
+	FullURL  string      `json:"fullUrl"`
+	Resource interface{} `json:"resource"`
+}
+
+// Meta lists the profiles a resource conforms to
+type Meta struct {
+	Profile []string `json:"profile"`
+}
+
+// Coding is a code from a code system
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// CodeableConcept is a concept given by codings, text or both
+type CodeableConcept struct {
+	Coding []Coding `json:"coding,omitempty"`
+	Text   string   `json:"text,omitempty"`
+}
+
+// Reference points at another resource in the bundle
+type Reference struct {
+	Reference string `json:"reference"`
+}
+
+// Identifier is a business identifier of a resource
+type Identifier struct {
+	System string `json:"system"`
+	Value  string `json:"value"`
+}
+
+// Annotation is a free-text note
+type Annotation struct {
+	Text string `json:"text"`
+}
+
+// Quantity is a numeric value
+type Quantity struct {
+	Value int64 `json:"value"`
+}
+
+// Range is a closed numeric interval
+type Range struct {
+	Low  Quantity `json:"low"`
+	High Quantity `json:"high"`
+}
+
+// Component is one coded part of an observation with its value
+type Component struct {
+	Code                 CodeableConcept  `json:"code"`
+	ValueCodeableConcept *CodeableConcept `json:"valueCodeableConcept,omitempty"`
+	ValueString          string           `json:"valueString,omitempty"`
+	ValueRange           *Range           `json:"valueRange,omitempty"`
+}
+
+// Observation is a FHIR Observation
+type Observation struct {
+	ResourceType         string            `json:"resourceType"`
+	ID                   string            `json:"id"`
+	Meta                 Meta              `json:"meta"`
+	Identifier           []Identifier      `json:"identifier,omitempty"`
+	Status               string            `json:"status"`
+	Category             []CodeableConcept `json:"category"`
+	Code                 CodeableConcept   `json:"code"`
+	ValueCodeableConcept *CodeableConcept  `json:"valueCodeableConcept,omitempty"`
+	Method               *CodeableConcept  `json:"method,omitempty"`
+	DerivedFrom          []Reference       `json:"derivedFrom,omitempty"`
+	Component            []Component       `json:"component,omitempty"`
+	Note                 []Annotation      `json:"note,omitempty"`
+}
+
+// DiagnosticReport is a FHIR DiagnosticReport
+type DiagnosticReport struct {
+	ResourceType string            `json:"resourceType"`
+	ID           string            `json:"id"`
+	Meta         Meta              `json:"meta"`
+	Status       string            `json:"status"`
+	Category     []CodeableConcept `json:"category"`
+	Code         CodeableConcept   `json:"code"`
+	Issued       string            `json:"issued"`
+	Result       []Reference       `json:"result"`
+	Conclusion   string            `json:"conclusion,omitempty"`
+}
@@ -0,0 +1,209 @@
+package followup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite follow-up flag store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables and indexes.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS followup_flags (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		normalized_hgvs TEXT NOT NULL,
+		cancer_type TEXT DEFAULT '',
+		kind TEXT NOT NULL,
+		note TEXT DEFAULT '',
+		blocking INTEGER NOT NULL DEFAULT 0,
+		created_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		resolved_by TEXT DEFAULT '',
+		resolved_at DATETIME,
+		resolution TEXT DEFAULT ''
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_followup_variant ON followup_flags(normalized_hgvs, resolved_at);
+	CREATE INDEX IF NOT EXISTS idx_followup_open ON followup_flags(resolved_at, created_at);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+const flagColumns = `id, normalized_hgvs, cancer_type, kind, note, blocking,
+	created_by, created_at, resolved_by, resolved_at, resolution`
+
+// scanner is an interface for sql.Row and sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanFlag scans a row into a Flag struct.
+func scanFlag(s scanner) (*Flag, error) {
+	f := &Flag{}
+	var kind string
+	var resolvedAt sql.NullTime
+
+	err := s.Scan(
+		&f.ID, &f.NormalizedHGVS, &f.CancerType, &kind, &f.Note, &f.Blocking,
+		&f.CreatedBy, &f.CreatedAt, &f.ResolvedBy, &resolvedAt, &f.Resolution,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	f.Kind = FlagKind(kind)
+	if resolvedAt.Valid {
+		t := resolvedAt.Time
+		f.ResolvedAt = &t
+	}
+	return f, nil
+}
+
+// Add creates a new flag, setting its ID and creation time.
+func (s *SQLiteStore) Add(ctx context.Context, flag *Flag) error {
+	flag.NormalizedHGVS = strings.TrimSpace(flag.NormalizedHGVS)
+	if flag.NormalizedHGVS == "" {
+		return fmt.Errorf("normalized HGVS is required")
+	}
+	if !flag.Kind.IsValid() {
+		return fmt.Errorf("unsupported flag kind: %s", flag.Kind)
+	}
+
+	flag.CreatedAt = time.Now().UTC()
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO followup_flags (normalized_hgvs, cancer_type, kind, note, blocking, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, flag.NormalizedHGVS, flag.CancerType, string(flag.Kind), flag.Note, flag.Blocking, flag.CreatedBy, flag.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert flag: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get flag ID: %w", err)
+	}
+	flag.ID = id
+	return nil
+}
+
+// Resolve closes an open flag.
+func (s *SQLiteStore) Resolve(ctx context.Context, id int64, resolvedBy, resolution string) (*Flag, error) {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE followup_flags SET resolved_by = ?, resolved_at = ?, resolution = ?
+		WHERE id = ? AND resolved_at IS NULL
+	`, resolvedBy, time.Now().UTC(), resolution, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve flag: %w", err)
+	}
+
+	// An already-resolved flag is returned unchanged; a missing one yields ErrFlagNotFound
+	return s.get(ctx, id)
+}
+
+func (s *SQLiteStore) get(ctx context.Context, id int64) (*Flag, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+flagColumns+" FROM followup_flags WHERE id = ?", id)
+	flag, err := scanFlag(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrFlagNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query flag: %w", err)
+	}
+	return flag, nil
+}
+
+// Open returns unresolved flags for a variant.
+func (s *SQLiteStore) Open(ctx context.Context, normalizedHGVS, cancerType string) ([]*Flag, error) {
+	query := "SELECT " + flagColumns + " FROM followup_flags WHERE normalized_hgvs = ? AND resolved_at IS NULL"
+	args := []interface{}{strings.TrimSpace(normalizedHGVS)}
+	if cancerType != "" {
+		query += " AND (cancer_type = '' OR cancer_type = ?)"
+		args = append(args, cancerType)
+	}
+	query += " ORDER BY created_at ASC, id ASC"
+
+	return s.query(ctx, query, args...)
+}
+
+// Worklist returns all unresolved flags, oldest first, optionally filtered by kind.
+func (s *SQLiteStore) Worklist(ctx context.Context, kind FlagKind) ([]*Flag, error) {
+	query := "SELECT " + flagColumns + " FROM followup_flags WHERE resolved_at IS NULL"
+	var args []interface{}
+	if kind != "" {
+		query += " AND kind = ?"
+		args = append(args, string(kind))
+	}
+	query += " ORDER BY created_at ASC, id ASC"
+
+	return s.query(ctx, query, args...)
+}
+
+func (s *SQLiteStore) query(ctx context.Context, query string, args ...interface{}) ([]*Flag, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []*Flag
+	for rows.Next() {
+		flag, err := scanFlag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		flags = append(flags, flag)
+	}
+	return flags, rows.Err()
+}
+
+// Close closes the store and releases resources.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,93 @@
+package followup
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "followup.db"))
+	require.NoError(t, err)
+	return store
+}
+
+func TestSQLiteStore_AddAndOpen(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	flag := &Flag{
+		NormalizedHGVS: "NM_000546.6:c.743G>A",
+		Kind:           KindAwaitingParentalTesting,
+		Note:           "Trio samples received",
+		Blocking:       true,
+		CreatedBy:      "curator1",
+	}
+
+	// Act
+	require.NoError(t, store.Add(ctx, flag))
+	require.NoError(t, store.Add(ctx, &Flag{
+		NormalizedHGVS: "NM_000546.6:c.743G>A",
+		CancerType:     "breast",
+		Kind:           KindOther,
+		CreatedBy:      "curator1",
+	}))
+
+	// Assert
+	assert.NotZero(t, flag.ID)
+
+	flags, err := store.Open(ctx, "NM_000546.6:c.743G>A", "")
+	require.NoError(t, err)
+	assert.Len(t, flags, 2)
+	assert.True(t, HasBlocking(flags))
+
+	flags, err = store.Open(ctx, "NM_000546.6:c.743G>A", "colorectal")
+	require.NoError(t, err)
+	require.Len(t, flags, 1, "context-free flags apply to every cancer type")
+	assert.Equal(t, KindAwaitingParentalTesting, flags[0].Kind)
+}
+
+func TestSQLiteStore_ResolveAndWorklist(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	rna := &Flag{NormalizedHGVS: "NM_007294.4:c.5277+3A>G", Kind: KindRNAStudyOrdered, Blocking: true, CreatedBy: "curator1"}
+	seg := &Flag{NormalizedHGVS: "NM_000492.4:c.350G>A", Kind: KindSegregationPending, CreatedBy: "curator2"}
+	require.NoError(t, store.Add(ctx, rna))
+	require.NoError(t, store.Add(ctx, seg))
+
+	worklist, err := store.Worklist(ctx, KindRNAStudyOrdered)
+	require.NoError(t, err)
+	require.Len(t, worklist, 1)
+
+	// Act
+	resolved, err := store.Resolve(ctx, rna.ID, "curator1", "RNA study confirmed exon skipping")
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, resolved.IsOpen())
+	assert.Equal(t, "curator1", resolved.ResolvedBy)
+
+	worklist, err = store.Worklist(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, worklist, 1)
+	assert.Equal(t, seg.ID, worklist[0].ID)
+
+	_, err = store.Resolve(ctx, 9999, "curator1", "")
+	assert.ErrorIs(t, err, ErrFlagNotFound)
+}
+
+func TestSQLiteStore_AddRejectsUnknownKind(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	err := store.Add(context.Background(), &Flag{NormalizedHGVS: "NM_000546.6:c.743G>A", Kind: "waiting"})
+
+	assert.Error(t, err)
+}
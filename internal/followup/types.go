@@ -0,0 +1,112 @@
+// Package followup provides structured follow-up flags for signed-out
+// classifications whose evidence is still incomplete, such as pending
+// parental testing or an ordered RNA study.
+package followup
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrFlagNotFound is returned when a flag ID does not exist.
+var ErrFlagNotFound = errors.New("follow-up flag not found")
+
+// FlagKind identifies the outstanding work a flag tracks.
+type FlagKind string
+
+const (
+	KindAwaitingParentalTesting  FlagKind = "awaiting_parental_testing"
+	KindRNAStudyOrdered          FlagKind = "rna_study_ordered"
+	KindFunctionalStudyPending   FlagKind = "functional_study_pending"
+	KindSegregationPending       FlagKind = "segregation_pending"
+	KindAdditionalCasesNeeded    FlagKind = "additional_cases_needed"
+	KindLiteratureRetracted      FlagKind = "literature_retracted"      // Raised by the literature monitor
+	KindLiteratureErratum        FlagKind = "literature_erratum"        // Raised by the literature monitor
+	KindReclassificationDetected FlagKind = "reclassification_detected" // Raised by scheduled re-evaluation
+	KindOther                    FlagKind = "other"
+)
+
+// Kinds lists all supported flag kinds.
+var Kinds = []FlagKind{
+	KindAwaitingParentalTesting,
+	KindRNAStudyOrdered,
+	KindFunctionalStudyPending,
+	KindSegregationPending,
+	KindAdditionalCasesNeeded,
+	KindLiteratureRetracted,
+	KindLiteratureErratum,
+	KindReclassificationDetected,
+	KindOther,
+}
+
+// IsValid reports whether the kind is supported.
+func (k FlagKind) IsValid() bool {
+	for _, kind := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// BlocksByDefault reports whether flags of this kind block reclassification
+// unless the curator states otherwise. Pending test results block; general
+// evidence requests only annotate.
+func (k FlagKind) BlocksByDefault() bool {
+	switch k {
+	case KindAwaitingParentalTesting, KindRNAStudyOrdered, KindFunctionalStudyPending, KindSegregationPending:
+		return true
+	default:
+		return false
+	}
+}
+
+// Flag is a follow-up item attached to a signed-out classification.
+type Flag struct {
+	ID             int64      `json:"id,omitempty"`
+	NormalizedHGVS string     `json:"normalized_hgvs"`
+	CancerType     string     `json:"cancer_type,omitempty"` // Empty applies to every clinical context
+	Kind           FlagKind   `json:"kind"`
+	Note           string     `json:"note,omitempty"`
+	Blocking       bool       `json:"blocking"` // Blocks reclassification until resolved
+	CreatedBy      string     `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ResolvedBy     string     `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	Resolution     string     `json:"resolution,omitempty"`
+}
+
+// IsOpen reports whether the flag is still unresolved.
+func (f *Flag) IsOpen() bool {
+	return f.ResolvedAt == nil
+}
+
+// Store defines the interface for follow-up flag storage operations.
+type Store interface {
+	// Add creates a new flag, setting its ID and creation time.
+	Add(ctx context.Context, flag *Flag) error
+
+	// Resolve closes an open flag. Returns ErrFlagNotFound if it does not exist.
+	Resolve(ctx context.Context, id int64, resolvedBy, resolution string) (*Flag, error)
+
+	// Open returns unresolved flags for a variant. Flags without a cancer type
+	// always match; an empty cancerType matches every flag for the variant.
+	Open(ctx context.Context, normalizedHGVS, cancerType string) ([]*Flag, error)
+
+	// Worklist returns all unresolved flags, oldest first, optionally filtered by kind.
+	Worklist(ctx context.Context, kind FlagKind) ([]*Flag, error)
+
+	// Close closes the store and releases resources.
+	Close() error
+}
+
+// HasBlocking reports whether any of the flags blocks reclassification.
+func HasBlocking(flags []*Flag) bool {
+	for _, f := range flags {
+		if f.Blocking && f.IsOpen() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+// Package graphqlapi serves evidence aggregation and stored classifications
+// over GraphQL so clients fetch only the evidence categories they need in
+// one round trip instead of the full EvidenceData payload. Evidence queries
+// run through the query_evidence tool, restricted to the databases behind
+// the selected categories; classifications are read from the audit trail.
+// The schema is defined in schema.graphql.
+package graphqlapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/cli"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// Path is where the GraphQL API is served.
+const Path = "/api/v1/graphql"
+
+// Query limits guarding the server against expensive documents
+const (
+	maxRequestBytes = 1 << 20
+	maxQueryLength  = 16 << 10
+	maxDepth        = 8
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Request is a GraphQL-over-HTTP request body
+type Request struct {
+	Query         string                 `json:"query" validate:"required,min=1"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is a GraphQL-over-HTTP response body. Resolver errors carry the
+// error envelope code and correlation ID in their extensions.
+type Response struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []Error         `json:"errors,omitempty"`
+}
+
+// Error is a GraphQL error
+type Error struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// NewHandler serves POST /api/v1/graphql. Evidence is queried through
+// caller with the request's principal; classifications are read from
+// store, and the classification fields resolve to errors when it is nil.
+func NewHandler(logger *logrus.Logger, caller cli.ToolCaller, store audit.Store) (http.Handler, error) {
+	schema, err := graphql.ParseSchema(schemaSDL, &resolver{caller: caller, store: store},
+		graphql.UseFieldResolvers(),
+		graphql.MaxQueryLength(maxQueryLength),
+		graphql.MaxDepth(maxDepth),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GraphQL schema: %w", err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request Request
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&request); err != nil {
+			writeError(w, r, http.StatusBadRequest, protocol.ErrorCodeParseError, "Invalid GraphQL request", err.Error())
+			return
+		}
+		if request.Query == "" {
+			writeError(w, r, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, "Invalid GraphQL request", "query is required")
+			return
+		}
+
+		ctx := r.Context()
+		if correlationID := r.Header.Get(protocol.CorrelationHeader); correlationID != "" {
+			ctx = protocol.WithCorrelationID(ctx, correlationID)
+		}
+		response := schema.Exec(ctx, request.Query, request.OperationName, request.Variables)
+		if len(response.Errors) > 0 {
+			logger.WithFields(logrus.Fields{
+				"operation": request.OperationName,
+				"errors":    len(response.Errors),
+			}).Debug("GraphQL query returned errors")
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}), nil
+}
+
+// writeError responds with the standard error envelope
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, message, details string) {
+	envelope := protocol.NewErrorEnvelope(code, message, "rest:"+r.Method+" "+r.URL.Path, r.Header.Get(protocol.CorrelationHeader), details)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(envelope)
+}
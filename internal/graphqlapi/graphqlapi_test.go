@@ -0,0 +1,225 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+// fakeTools answers query_evidence with a canned result, failing for the
+// notation "bad"
+type fakeTools struct {
+	params []tools.QueryEvidenceParams
+}
+
+func (f *fakeTools) CallTool(ctx context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response {
+	params := arguments.(tools.QueryEvidenceParams)
+	f.params = append(f.params, params)
+	if params.HGVSNotation == "bad" {
+		return &protocol.JSONRPC2Response{Error: &protocol.RPCError{Code: protocol.InvalidParams, Message: "Invalid HGVS notation"}}
+	}
+	result := &tools.QueryEvidenceResult{
+		VariantID:       "VAR_1",
+		HGVSNotation:    params.HGVSNotation,
+		DatabaseResults: map[string]interface{}{"gnomad": map[string]interface{}{}, "clinvar": map[string]interface{}{}},
+		AggregatedEvidence: tools.AggregatedEvidence{
+			PopulationFrequency: tools.PopulationFrequencyData{
+				MaxFrequency:        0.00001,
+				PopulationFreqs:     map[string]float64{"nfe": 0.00001, "afr": 0},
+				AlleleCount:         3,
+				FrequencyAssessment: "rare - compatible with pathogenicity",
+				Thresholds:          &service.FrequencyThresholds{BA1AlleleFrequency: 0.05, BS1AlleleFrequency: 0.01, PM2AlleleFrequency: 0.0001, Source: "default"},
+			},
+			ClinicalEvidence: tools.ClinicalEvidenceData{
+				OverallSignificance: "Pathogenic",
+				ClinVarEntries:      []tools.ClinVarEntry{{AccessionID: "VCV000007105", ClinicalSignificance: "Pathogenic"}},
+			},
+			ComputationalData: tools.ComputationalData{CADDScore: 25.3, ConsensusPrediction: "damaging"},
+		},
+		RecommendedActions: []string{"Review segregation data"},
+	}
+	return &protocol.JSONRPC2Response{Result: map[string]interface{}{"evidence": result}}
+}
+
+// post sends a GraphQL query and decodes the response
+func post(t *testing.T, handler http.Handler, query string, variables map[string]interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	body, err := json.Marshal(Request{Query: query, Variables: variables})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(string(body)))
+	req.Header.Set(protocol.CorrelationHeader, "corr-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return rec.Code, response
+}
+
+func TestEvidenceQuery(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	caller := &fakeTools{}
+	handler, err := NewHandler(logger, caller, nil)
+	require.NoError(t, err)
+
+	t.Run("only the databases behind selected categories are queried", func(t *testing.T) {
+		status, response := post(t, handler, `query($hgvs: String!) {
+			evidence(hgvs: $hgvs, gene: "CFTR") {
+				population { maxFrequency alleleCount populations { population } thresholds { pm2 } }
+				clinvar { overallSignificance entries { accession } }
+			}
+		}`, map[string]interface{}{"hgvs": "NM_000492.4:c.1521_1523delCTT"})
+
+		require.Equal(t, http.StatusOK, status)
+		require.Nil(t, response["errors"])
+		assert.Equal(t, []string{"gnomad", "clinvar"}, caller.params[len(caller.params)-1].Databases)
+		assert.Equal(t, "CFTR", caller.params[len(caller.params)-1].GeneSymbol)
+
+		evidence := response["data"].(map[string]interface{})["evidence"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{
+			"maxFrequency": 0.00001,
+			"alleleCount":  float64(3),
+			"populations":  []interface{}{map[string]interface{}{"population": "afr"}, map[string]interface{}{"population": "nfe"}},
+			"thresholds":   map[string]interface{}{"pm2": 0.0001},
+		}, evidence["population"])
+		assert.Equal(t, map[string]interface{}{
+			"overallSignificance": "Pathogenic",
+			"entries":             []interface{}{map[string]interface{}{"accession": "VCV000007105"}},
+		}, evidence["clinvar"])
+		assert.NotContains(t, evidence, "computational", "unselected categories are not returned")
+	})
+
+	t.Run("explicit databases override the selection", func(t *testing.T) {
+		_, response := post(t, handler, `{ evidence(hgvs: "NM_000492.4:c.1521_1523delCTT", databases: ["lovd"]) {
+			computational { caddScore revelScore consensusPrediction }
+		} }`, nil)
+
+		assert.Equal(t, []string{"lovd"}, caller.params[len(caller.params)-1].Databases)
+		assert.Equal(t, map[string]interface{}{"caddScore": 25.3, "revelScore": nil, "consensusPrediction": "damaging"},
+			response["data"].(map[string]interface{})["evidence"].(map[string]interface{})["computational"])
+	})
+
+	t.Run("categories without a database use the tool defaults", func(t *testing.T) {
+		post(t, handler, `{ evidence(hgvs: "NM_000492.4:c.1521_1523delCTT") { recommendedActions } }`, nil)
+
+		assert.Empty(t, caller.params[len(caller.params)-1].Databases)
+	})
+
+	t.Run("tool errors carry the envelope code", func(t *testing.T) {
+		status, response := post(t, handler, `{ evidence(hgvs: "bad") { variantId } }`, nil)
+
+		assert.Equal(t, http.StatusOK, status)
+		errs := response["errors"].([]interface{})
+		require.Len(t, errs, 1)
+		gqlErr := errs[0].(map[string]interface{})
+		assert.Equal(t, "Invalid HGVS notation", gqlErr["message"])
+		assert.Equal(t, protocol.ErrorCodeInvalidInput, gqlErr["extensions"].(map[string]interface{})["code"])
+		assert.Equal(t, "corr-1", gqlErr["extensions"].(map[string]interface{})["correlation_id"])
+	})
+
+	t.Run("classifications need the audit trail", func(t *testing.T) {
+		_, response := post(t, handler, `{ classifications { id } }`, nil)
+
+		errs := response["errors"].([]interface{})
+		require.Len(t, errs, 1)
+		assert.Equal(t, protocol.ErrorCodeResourceError, errs[0].(map[string]interface{})["extensions"].(map[string]interface{})["code"])
+	})
+}
+
+func TestClassificationQueries(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store, err := audit.NewSQLiteStore(filepath.Join(t.TempDir(), "audit.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	ctx := context.Background()
+	require.NoError(t, store.Append(ctx, &audit.Record{
+		HGVSNotation:   "NM_000492.4:c.1521_1523delCTT",
+		Request:        json.RawMessage(`{"hgvs_notation": "NM_000492.4:c.1521_1523delCTT"}`),
+		AppliedRules:   json.RawMessage(`[{"rule_code": "PVS1", "strength": "very_strong", "applied": true}, {"rule_code": "BA1", "applied": false}]`),
+		Classification: "Pathogenic",
+		EngineVersion:  "1.0.0",
+	}))
+	require.NoError(t, store.Append(ctx, &audit.Record{
+		HGVSNotation:   "NM_007294.4:c.68_69del",
+		Request:        json.RawMessage(`{"hgvs_notation": "NM_007294.4:c.68_69del"}`),
+		Classification: "Likely pathogenic",
+		EngineVersion:  "1.0.0",
+	}))
+
+	handler, err := NewHandler(logger, &fakeTools{}, store)
+	require.NoError(t, err)
+
+	t.Run("classifications are filtered and newest first", func(t *testing.T) {
+		_, response := post(t, handler, `{
+			all: classifications { hgvsNotation }
+			pathogenic: classifications(classification: "Pathogenic", limit: 500) { id classification criteria { code strength } everything: criteria(all: true) { code met } }
+		}`, nil)
+
+		require.Nil(t, response["errors"])
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"hgvsNotation": "NM_007294.4:c.68_69del"},
+			map[string]interface{}{"hgvsNotation": "NM_000492.4:c.1521_1523delCTT"},
+		}, data["all"])
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"id":             "1",
+			"classification": "Pathogenic",
+			"criteria":       []interface{}{map[string]interface{}{"code": "PVS1", "strength": "very_strong"}},
+			"everything": []interface{}{
+				map[string]interface{}{"code": "PVS1", "met": true},
+				map[string]interface{}{"code": "BA1", "met": false},
+			},
+		}}, data["pathogenic"])
+	})
+
+	t.Run("classification by ID", func(t *testing.T) {
+		_, response := post(t, handler, `{ found: classification(id: "2") { hgvsNotation } missing: classification(id: "99") { hgvsNotation } }`, nil)
+
+		require.Nil(t, response["errors"])
+		assert.Equal(t, map[string]interface{}{
+			"found":   map[string]interface{}{"hgvsNotation": "NM_007294.4:c.68_69del"},
+			"missing": nil,
+		}, response["data"])
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, response := post(t, handler, `{ classifications(limit: 0) { id } }`, nil)
+
+		require.Len(t, response["errors"], 1)
+	})
+}
+
+func TestHandler_InvalidRequests(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	handler, err := NewHandler(logger, &fakeTools{}, nil)
+	require.NoError(t, err)
+
+	for body, code := range map[string]string{
+		`{"query":`:     protocol.ErrorCodeParseError,
+		`{"query": ""}`: protocol.ErrorCodeInvalidInput,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+		assert.Contains(t, rec.Body.String(), code, body)
+	}
+
+	_, response := post(t, handler, `{ unknown }`, nil)
+	assert.Nil(t, response["data"])
+	assert.NotEmpty(t, response["errors"], "queries are validated against the schema")
+}
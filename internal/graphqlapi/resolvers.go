@@ -0,0 +1,484 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/cli"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// categorySources lists the databases each evidence category aggregates.
+// Categories not listed are derived without a database of their own.
+var categorySources = []struct {
+	Category  string
+	Databases []string
+}{
+	{"population", []string{"gnomad"}},
+	{"clinvar", []string{"clinvar"}},
+	{"literature", []string{"pubmed"}},
+}
+
+// resolver is the root Query resolver
+type resolver struct {
+	caller cli.ToolCaller
+	store  audit.Store
+}
+
+// resolverError is a resolver failure reported with its error envelope code
+type resolverError struct {
+	envelope *protocol.ErrorEnvelope
+}
+
+func (e *resolverError) Error() string {
+	return e.envelope.Message
+}
+
+// Extensions adds the envelope code to the GraphQL error
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":           e.envelope.Code,
+		"correlation_id": e.envelope.CorrelationID,
+		"retryable":      e.envelope.Retryable,
+	}
+}
+
+// newError creates a resolver error for a field
+func newError(ctx context.Context, code, message, source string) error {
+	return &resolverError{envelope: protocol.NewErrorEnvelope(code, message, source, protocol.CorrelationIDFromContext(ctx), nil)}
+}
+
+// Evidence runs query_evidence for the databases behind the selected
+// evidence categories, or the databases given
+func (r *resolver) Evidence(ctx context.Context, args struct {
+	HGVS      string
+	Gene      *string
+	Condition *string
+	Databases *[]string
+}) (*evidence, error) {
+	params := tools.QueryEvidenceParams{HGVSNotation: args.HGVS}
+	if args.Gene != nil {
+		params.GeneSymbol = *args.Gene
+	}
+	if args.Condition != nil {
+		params.Condition = *args.Condition
+	}
+	if args.Databases != nil {
+		params.Databases = *args.Databases
+	} else {
+		params.Databases = selectedDatabases(ctx)
+	}
+
+	correlationID := protocol.CorrelationIDFromContext(ctx)
+	response := r.caller.CallTool(ctx, "query_evidence", params)
+	if response == nil {
+		return nil, newError(ctx, protocol.ErrorCodeInternal, "query_evidence returned no response", "tool:query_evidence")
+	}
+	if response.Error != nil {
+		return nil, &resolverError{envelope: response.Error.Envelope("tool:query_evidence", correlationID)}
+	}
+
+	// Round-trip through JSON so summarized or in-process results decode alike
+	var decoded struct {
+		Evidence *tools.QueryEvidenceResult `json:"evidence"`
+	}
+	encoded, err := json.Marshal(response.Result)
+	if err == nil {
+		err = json.Unmarshal(encoded, &decoded)
+	}
+	if err != nil || decoded.Evidence == nil {
+		return nil, newError(ctx, protocol.ErrorCodeInternal, "Unexpected query_evidence result", "tool:query_evidence")
+	}
+	return newEvidence(decoded.Evidence), nil
+}
+
+// selectedDatabases returns the databases behind the selected evidence
+// categories. It returns nil, querying the tool's default databases, when
+// no selected category needs one.
+func selectedDatabases(ctx context.Context) []string {
+	var databases []string
+	for _, category := range categorySources {
+		if graphql.HasSelectedField(ctx, category.Category) {
+			databases = append(databases, category.Databases...)
+		}
+	}
+	return databases
+}
+
+// Classification returns a stored classification, or null when none has
+// the ID
+func (r *resolver) Classification(ctx context.Context, args struct{ ID graphql.ID }) (*classification, error) {
+	if r.store == nil {
+		return nil, newError(ctx, protocol.ErrorCodeResourceError, "Audit trail is not enabled", "graphql:classification")
+	}
+	id, err := strconv.ParseInt(string(args.ID), 10, 64)
+	if err != nil {
+		return nil, newError(ctx, protocol.ErrorCodeInvalidInput, "Classification ID must be an integer", "graphql:classification")
+	}
+	record, err := r.store.Get(ctx, id)
+	if errors.Is(err, audit.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, newError(ctx, protocol.ErrorCodeInternal, "Failed to read the audit trail", "graphql:classification")
+	}
+	return &classification{record}, nil
+}
+
+// Classifications returns matching stored classifications, newest first
+func (r *resolver) Classifications(ctx context.Context, args struct {
+	Variant        *string
+	Classification *string
+	Since          *graphql.Time
+	Until          *graphql.Time
+	FailedOnly     bool
+	Limit          int32
+}) ([]*classification, error) {
+	if r.store == nil {
+		return nil, newError(ctx, protocol.ErrorCodeResourceError, "Audit trail is not enabled", "graphql:classifications")
+	}
+	if args.Limit < 1 {
+		return nil, newError(ctx, protocol.ErrorCodeInvalidInput, "limit must be at least 1", "graphql:classifications")
+	}
+
+	filter := audit.Filter{FailedOnly: args.FailedOnly, Limit: int(args.Limit)}
+	if filter.Limit > audit.DefaultQueryLimit {
+		filter.Limit = audit.DefaultQueryLimit
+	}
+	if args.Variant != nil {
+		filter.Variant = *args.Variant
+	}
+	if args.Classification != nil {
+		filter.Classification = *args.Classification
+	}
+	if args.Since != nil {
+		filter.Since = args.Since.Time
+	}
+	if args.Until != nil {
+		filter.Until = args.Until.Time
+	}
+
+	records, err := r.store.Query(ctx, filter)
+	if err != nil {
+		return nil, newError(ctx, protocol.ErrorCodeInternal, "Failed to read the audit trail", "graphql:classifications")
+	}
+	classifications := make([]*classification, 0, len(records))
+	for _, record := range records {
+		classifications = append(classifications, &classification{record})
+	}
+	return classifications, nil
+}
+
+// evidence is the Evidence type
+type evidence struct {
+	VariantID          string                 `graphql:"variantId"`
+	HGVSNotation       string                 `graphql:"hgvsNotation"`
+	QueriedAt          string                 `graphql:"queriedAt"`
+	Sources            []string               `graphql:"sources"`
+	Population         *populationFrequency   `graphql:"population"`
+	ClinVar            *clinicalEvidence      `graphql:"clinvar"`
+	Functional         *functionalEvidence    `graphql:"functional"`
+	Computational      *computationalEvidence `graphql:"computational"`
+	Literature         *literatureEvidence    `graphql:"literature"`
+	Quality            *evidenceQuality       `graphql:"quality"`
+	RecommendedActions []string               `graphql:"recommendedActions"`
+}
+
+type populationFrequency struct {
+	MaxFrequency    float64                    `graphql:"maxFrequency"`
+	Populations     []populationFrequencyEntry `graphql:"populations"`
+	AlleleCount     int32                      `graphql:"alleleCount"`
+	AlleleNumber    int32                      `graphql:"alleleNumber"`
+	HomozygoteCount int32                      `graphql:"homozygoteCount"`
+	Assessment      string                     `graphql:"assessment"`
+	Thresholds      *frequencyThresholds       `graphql:"thresholds"`
+}
+
+type populationFrequencyEntry struct {
+	Population string  `graphql:"population"`
+	Frequency  float64 `graphql:"frequency"`
+}
+
+type frequencyThresholds struct {
+	BA1    float64 `graphql:"ba1"`
+	BS1    float64 `graphql:"bs1"`
+	PM2    float64 `graphql:"pm2"`
+	Source string  `graphql:"source"`
+}
+
+type clinicalEvidence struct {
+	OverallSignificance string         `graphql:"overallSignificance"`
+	ReviewStatus        string         `graphql:"reviewStatus"`
+	Conflicting         bool           `graphql:"conflicting"`
+	Entries             []clinVarEntry `graphql:"entries"`
+}
+
+type clinVarEntry struct {
+	Accession      string   `graphql:"accession"`
+	Significance   string   `graphql:"significance"`
+	ReviewStatus   string   `graphql:"reviewStatus"`
+	Submitter      string   `graphql:"submitter"`
+	SubmissionDate string   `graphql:"submissionDate"`
+	Conditions     []string `graphql:"conditions"`
+}
+
+type functionalEvidence struct {
+	HasData    bool              `graphql:"hasData"`
+	Prediction string            `graphql:"prediction"`
+	Studies    []functionalStudy `graphql:"studies"`
+}
+
+type functionalStudy struct {
+	StudyType   string `graphql:"studyType"`
+	Result      string `graphql:"result"`
+	Description string `graphql:"description"`
+	Reference   string `graphql:"reference"`
+	Reliability string `graphql:"reliability"`
+}
+
+// computationalEvidence leaves scores the predictors did not report null
+type computationalEvidence struct {
+	SIFTScore           *float64 `graphql:"siftScore"`
+	SIFTPrediction      *string  `graphql:"siftPrediction"`
+	PolyPhenScore       *float64 `graphql:"polyphenScore"`
+	PolyPhenPrediction  *string  `graphql:"polyphenPrediction"`
+	CADDScore           *float64 `graphql:"caddScore"`
+	REVELScore          *float64 `graphql:"revelScore"`
+	AlphaMissenseScore  *float64 `graphql:"alphaMissenseScore"`
+	ConsensusScore      float64  `graphql:"consensusScore"`
+	ConsensusPrediction string   `graphql:"consensusPrediction"`
+}
+
+type literatureEvidence struct {
+	TotalCitations      int32      `graphql:"totalCitations"`
+	RecentCitations     int32      `graphql:"recentCitations"`
+	HighImpactCitations int32      `graphql:"highImpactCitations"`
+	Citations           []citation `graphql:"citations"`
+}
+
+type citation struct {
+	PMID      string   `graphql:"pmid"`
+	Title     string   `graphql:"title"`
+	Authors   []string `graphql:"authors"`
+	Journal   string   `graphql:"journal"`
+	Year      int32    `graphql:"year"`
+	Relevance string   `graphql:"relevance"`
+}
+
+type evidenceQuality struct {
+	Overall       string  `graphql:"overall"`
+	Completeness  float64 `graphql:"completeness"`
+	ConflictScore float64 `graphql:"conflictScore"`
+}
+
+// newEvidence converts a query_evidence result to the Evidence type
+func newEvidence(result *tools.QueryEvidenceResult) *evidence {
+	aggregated := result.AggregatedEvidence
+	e := &evidence{
+		VariantID:          result.VariantID,
+		HGVSNotation:       result.HGVSNotation,
+		QueriedAt:          result.QueryTimestamp,
+		Sources:            make([]string, 0, len(result.DatabaseResults)),
+		RecommendedActions: result.RecommendedActions,
+		Quality: &evidenceQuality{
+			Overall:       result.QualityScores.OverallQuality,
+			Completeness:  result.QualityScores.DataCompleteness,
+			ConflictScore: result.QualityScores.ConflictScore,
+		},
+	}
+	for database := range result.DatabaseResults {
+		e.Sources = append(e.Sources, database)
+	}
+	sort.Strings(e.Sources)
+	if e.RecommendedActions == nil {
+		e.RecommendedActions = []string{}
+	}
+
+	population := aggregated.PopulationFrequency
+	e.Population = &populationFrequency{
+		MaxFrequency:    population.MaxFrequency,
+		Populations:     make([]populationFrequencyEntry, 0, len(population.PopulationFreqs)),
+		AlleleCount:     int32(population.AlleleCount),
+		AlleleNumber:    int32(population.AlleleNumber),
+		HomozygoteCount: int32(population.HomozygoteCount),
+		Assessment:      population.FrequencyAssessment,
+	}
+	for name, frequency := range population.PopulationFreqs {
+		e.Population.Populations = append(e.Population.Populations, populationFrequencyEntry{Population: name, Frequency: frequency})
+	}
+	sort.Slice(e.Population.Populations, func(i, j int) bool {
+		return e.Population.Populations[i].Population < e.Population.Populations[j].Population
+	})
+	if cutoffs := population.Thresholds; cutoffs != nil {
+		e.Population.Thresholds = &frequencyThresholds{
+			BA1:    cutoffs.BA1AlleleFrequency,
+			BS1:    cutoffs.BS1AlleleFrequency,
+			PM2:    cutoffs.PM2AlleleFrequency,
+			Source: cutoffs.Source,
+		}
+	}
+
+	clinical := aggregated.ClinicalEvidence
+	e.ClinVar = &clinicalEvidence{
+		OverallSignificance: clinical.OverallSignificance,
+		ReviewStatus:        clinical.ReviewStatus,
+		Conflicting:         clinical.ConflictingInterpretations,
+		Entries:             make([]clinVarEntry, 0, len(clinical.ClinVarEntries)),
+	}
+	for _, entry := range clinical.ClinVarEntries {
+		e.ClinVar.Entries = append(e.ClinVar.Entries, clinVarEntry{
+			Accession:      entry.AccessionID,
+			Significance:   entry.ClinicalSignificance,
+			ReviewStatus:   entry.ReviewStatus,
+			Submitter:      entry.Submitter,
+			SubmissionDate: entry.SubmissionDate,
+			Conditions:     nonNil(entry.Conditions),
+		})
+	}
+
+	functional := aggregated.FunctionalEvidence
+	e.Functional = &functionalEvidence{
+		HasData:    functional.HasFunctionalData,
+		Prediction: functional.FunctionalPrediction,
+		Studies:    make([]functionalStudy, 0, len(functional.FunctionalStudies)),
+	}
+	for _, study := range functional.FunctionalStudies {
+		e.Functional.Studies = append(e.Functional.Studies, functionalStudy(study))
+	}
+
+	computational := aggregated.ComputationalData
+	e.Computational = &computationalEvidence{
+		SIFTScore:           optionalScore(computational.SIFTScore),
+		SIFTPrediction:      optionalString(computational.SIFTPrediction),
+		PolyPhenScore:       optionalScore(computational.PolyPhenScore),
+		PolyPhenPrediction:  optionalString(computational.PolyPhenPrediction),
+		CADDScore:           optionalScore(computational.CADDScore),
+		REVELScore:          optionalScore(computational.REVEL),
+		AlphaMissenseScore:  optionalScore(computational.AlphaMissense),
+		ConsensusScore:      computational.ConsensusScore,
+		ConsensusPrediction: computational.ConsensusPrediction,
+	}
+
+	literature := aggregated.LiteratureEvidence
+	e.Literature = &literatureEvidence{
+		TotalCitations:      int32(literature.TotalCitations),
+		RecentCitations:     int32(literature.RecentCitations),
+		HighImpactCitations: int32(literature.HighImpactCitations),
+		Citations:           make([]citation, 0, len(literature.PubMedCitations)),
+	}
+	for _, c := range literature.PubMedCitations {
+		e.Literature.Citations = append(e.Literature.Citations, citation{
+			PMID:      c.PMID,
+			Title:     c.Title,
+			Authors:   nonNil(c.Authors),
+			Journal:   c.Journal,
+			Year:      int32(c.Year),
+			Relevance: c.Relevance,
+		})
+	}
+	return e
+}
+
+// optionalScore returns nil for scores the tool omits when unreported
+func optionalScore(score float64) *float64 {
+	if score == 0 {
+		return nil
+	}
+	return &score
+}
+
+// optionalString returns nil for an empty string
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nonNil returns an empty list for nil so non-null lists resolve
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// classification is the Classification type over an audit record
+type classification struct {
+	record *audit.Record
+}
+
+func (c *classification) ID() graphql.ID {
+	return graphql.ID(strconv.FormatInt(c.record.ID, 10))
+}
+
+func (c *classification) VariantID() *string {
+	return optionalString(c.record.VariantID)
+}
+
+func (c *classification) HGVSNotation() string {
+	return c.record.HGVSNotation
+}
+
+func (c *classification) Classification() *string {
+	return optionalString(c.record.Classification)
+}
+
+func (c *classification) Confidence() *string {
+	return optionalString(c.record.Confidence)
+}
+
+func (c *classification) Error() *string {
+	return optionalString(c.record.Error)
+}
+
+func (c *classification) EngineVersion() string {
+	return c.record.EngineVersion
+}
+
+func (c *classification) ScoringMode() *string {
+	return optionalString(c.record.ScoringMode)
+}
+
+func (c *classification) Specification() *string {
+	return optionalString(c.record.Specification)
+}
+
+func (c *classification) CreatedAt() graphql.Time {
+	return graphql.Time{Time: c.record.CreatedAt}
+}
+
+// criterion is an ACMG/AMP criterion evaluated for a classification
+type criterion struct {
+	Code     string  `json:"rule_code" graphql:"code"`
+	Name     string  `json:"rule_name" graphql:"name"`
+	Category string  `json:"category" graphql:"category"`
+	Strength string  `json:"strength" graphql:"strength"`
+	Met      bool    `json:"applied" graphql:"met"`
+	Evidence *string `json:"evidence,omitempty" graphql:"evidence"`
+}
+
+// Criteria returns the criteria met, or every criterion evaluated when all
+// is true
+func (c *classification) Criteria(ctx context.Context, args struct{ All bool }) ([]*criterion, error) {
+	var evaluated []*criterion
+	if len(c.record.AppliedRules) > 0 {
+		if err := json.Unmarshal(c.record.AppliedRules, &evaluated); err != nil {
+			return nil, newError(ctx, protocol.ErrorCodeInternal, "Stored criteria could not be decoded", "graphql:criteria")
+		}
+	}
+	criteria := make([]*criterion, 0, len(evaluated))
+	for _, rule := range evaluated {
+		if rule != nil && (args.All || rule.Met) {
+			criteria = append(criteria, rule)
+		}
+	}
+	return criteria, nil
+}
@@ -0,0 +1,444 @@
+// Package grpcapi serves the classify, validate and evidence operations over
+// gRPC for pipeline integrations that call the engine at high volume, such
+// as Nextflow or Snakemake steps. Calls run through the same MCP tools as
+// the HTTP API, so roles, rate limits, the audit trail and error codes are
+// shared; the service is defined in api/proto/acmg/v1/classifier.proto.
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	acmgv1 "github.com/acmg-amp-mcp-server/api/proto/acmg/v1"
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/cli"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/internal/middleware"
+	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/shutdown"
+)
+
+// Metadata keys read from and set on calls
+const (
+	apiKeyMetadata        = "x-api-key"
+	authorizationMetadata = "authorization"
+	correlationMetadata   = "x-correlation-id"
+	errorCodeMetadata     = "x-error-code"
+	retryAfterMetadata    = "retry-after"
+)
+
+// stopTimeout bounds how long Close waits for calls to finish
+const stopTimeout = 5 * time.Second
+
+// Server serves the ClassifierService
+type Server struct {
+	acmgv1.UnimplementedClassifierServiceServer
+
+	logger   *logrus.Logger
+	caller   cli.ToolCaller
+	authn    *auth.Authenticator
+	limiter  *middleware.RateLimiter
+	shutdown *shutdown.Coordinator
+	server   *grpc.Server
+}
+
+// NewServer creates a gRPC server calling tools through caller. Clients
+// authenticate as on the HTTP transport, so the authenticator must accept
+// credentials or grant an anonymous role.
+func NewServer(logger *logrus.Logger, caller cli.ToolCaller, authenticator *auth.Authenticator) (*Server, error) {
+	if authenticator == nil || !authenticator.Enabled() {
+		return nil, fmt.Errorf("gRPC API requires authentication: configure API keys, a JWT secret or an anonymous role")
+	}
+	s := &Server{logger: logger, caller: caller, authn: authenticator}
+	s.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.unaryInterceptor),
+		grpc.ChainStreamInterceptor(s.streamInterceptor),
+	)
+	acmgv1.RegisterClassifierServiceServer(s.server, s)
+	return s, nil
+}
+
+// SetRateLimiter applies the HTTP API's rate limits and quotas to calls
+func (s *Server) SetRateLimiter(limiter *middleware.RateLimiter) {
+	s.limiter = limiter
+}
+
+// SetShutdown tracks calls so a shutdown drains them, and refuses new calls
+// once it begins
+func (s *Server) SetShutdown(coordinator *shutdown.Coordinator) {
+	s.shutdown = coordinator
+}
+
+// Start listens on addr in the background
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	s.logger.WithField("address", addr).Info("Starting gRPC API")
+	go s.Serve(listener)
+	return nil
+}
+
+// Serve accepts connections on the listener until Close
+func (s *Server) Serve(listener net.Listener) {
+	if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		s.logger.WithError(err).Error("gRPC API server failed")
+	}
+}
+
+// Close stops the server, waiting briefly for calls in flight
+func (s *Server) Close() {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(stopTimeout):
+		s.server.Stop()
+	}
+}
+
+// ClassifyVariant classifies one variant
+func (s *Server) ClassifyVariant(ctx context.Context, req *acmgv1.ClassifyVariantRequest) (*acmgv1.ClassifyVariantResponse, error) {
+	response, envelope := s.classify(ctx, req)
+	if envelope != nil {
+		return nil, s.statusError(ctx, envelope)
+	}
+	return response, nil
+}
+
+// ClassifyVariants classifies each streamed variant in turn. Failures are
+// reported per variant; only authentication, rate limiting and transport
+// errors end the stream.
+func (s *Server) ClassifyVariants(stream acmgv1.ClassifierService_ClassifyVariantsServer) error {
+	ctx := stream.Context()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		response, envelope := s.classify(ctx, req)
+		if envelope != nil {
+			response = &acmgv1.ClassifyVariantResponse{
+				RequestId: req.GetRequestId(),
+				Error: &acmgv1.Error{
+					Code:          envelope.Code,
+					Message:       envelope.Message,
+					CorrelationId: envelope.CorrelationID,
+					Retryable:     envelope.Retryable,
+				},
+			}
+		}
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+}
+
+// ValidateHGVS validates and normalizes an HGVS notation
+func (s *Server) ValidateHGVS(ctx context.Context, req *acmgv1.ValidateHGVSRequest) (*acmgv1.ValidateHGVSResponse, error) {
+	var result tools.ValidateHGVSResult
+	if _, envelope := s.call(ctx, "validate_hgvs", "validation", tools.ValidateHGVSParams{
+		HGVSNotation: req.GetHgvsNotation(),
+		StrictMode:   req.GetStrictMode(),
+	}, &result); envelope != nil {
+		return nil, s.statusError(ctx, envelope)
+	}
+
+	response := &acmgv1.ValidateHGVSResponse{
+		IsValid:        result.IsValid,
+		HgvsNotation:   result.HGVSNotation,
+		NormalizedHgvs: result.NormalizedHGVS,
+		Suggestions:    result.Suggestions,
+	}
+	for _, issue := range result.ValidationIssues {
+		response.Issues = append(response.Issues, &acmgv1.ValidationIssue{
+			Severity:   issue.Severity,
+			Code:       issue.Code,
+			Message:    issue.Message,
+			Position:   int32(issue.Position),
+			Suggestion: issue.Suggestion,
+		})
+	}
+	if result.GeneInfo != nil {
+		response.GeneSymbol = result.GeneInfo.Symbol
+	}
+	return response, nil
+}
+
+// QueryEvidence gathers the evidence for a variant
+func (s *Server) QueryEvidence(ctx context.Context, req *acmgv1.QueryEvidenceRequest) (*acmgv1.QueryEvidenceResponse, error) {
+	var result tools.QueryEvidenceResult
+	raw, envelope := s.call(ctx, "query_evidence", "evidence", tools.QueryEvidenceParams{
+		HGVSNotation: req.GetHgvsNotation(),
+		GeneSymbol:   req.GetGeneSymbol(),
+		Condition:    req.GetCondition(),
+		Databases:    req.GetDatabases(),
+	}, &result)
+	if envelope != nil {
+		return nil, s.statusError(ctx, envelope)
+	}
+
+	evidence := result.AggregatedEvidence
+	response := &acmgv1.QueryEvidenceResponse{
+		VariantId:    result.VariantID,
+		HgvsNotation: result.HGVSNotation,
+		PopulationFrequency: &acmgv1.PopulationFrequency{
+			MaxFrequency:        evidence.PopulationFrequency.MaxFrequency,
+			AlleleCount:         int32(evidence.PopulationFrequency.AlleleCount),
+			AlleleNumber:        int32(evidence.PopulationFrequency.AlleleNumber),
+			HomozygoteCount:     int32(evidence.PopulationFrequency.HomozygoteCount),
+			FrequencyAssessment: evidence.PopulationFrequency.FrequencyAssessment,
+		},
+		ClinicalEvidence: &acmgv1.ClinicalEvidence{
+			OverallSignificance:        evidence.ClinicalEvidence.OverallSignificance,
+			ReviewStatus:               evidence.ClinicalEvidence.ReviewStatus,
+			ConflictingInterpretations: evidence.ClinicalEvidence.ConflictingInterpretations,
+		},
+		ComputationalEvidence: &acmgv1.ComputationalEvidence{
+			RevelScore:          evidence.ComputationalData.REVEL,
+			CaddScore:           evidence.ComputationalData.CADDScore,
+			AlphamissenseScore:  evidence.ComputationalData.AlphaMissense,
+			ConsensusPrediction: evidence.ComputationalData.ConsensusPrediction,
+		},
+		RecommendedActions: result.RecommendedActions,
+	}
+	for _, entry := range evidence.ClinicalEvidence.ClinVarEntries {
+		if entry.AccessionID != "" {
+			response.ClinicalEvidence.ClinvarAccessions = append(response.ClinicalEvidence.ClinvarAccessions, entry.AccessionID)
+		}
+	}
+	if req.GetIncludeFullResult() {
+		response.ResultJson = raw
+	}
+	return response, nil
+}
+
+// classify runs classify_variant for one request
+func (s *Server) classify(ctx context.Context, req *acmgv1.ClassifyVariantRequest) (*acmgv1.ClassifyVariantResponse, *protocol.ErrorEnvelope) {
+	params := tools.ClassifyVariantParams{
+		HGVSNotation:          req.GetHgvsNotation(),
+		GeneSymbolNotation:    req.GetGeneSymbolNotation(),
+		TranscriptID:          req.GetTranscriptId(),
+		ClinicalContext:       req.GetClinicalContext(),
+		Condition:             req.GetCondition(),
+		OrderingSpecialty:     req.GetOrderingSpecialty(),
+		ScoringMode:           req.GetScoringMode(),
+		RulesVersion:          req.GetRulesVersion(),
+		ClassificationContext: req.GetClassificationContext(),
+		TumorType:             req.GetTumorType(),
+		ProbandID:             req.GetProbandId(),
+		Zygosity:              req.GetZygosity(),
+	}
+	if len(req.GetHpoTerms()) > 0 {
+		params.PatientContext = &service.PatientContext{HPOTerms: req.GetHpoTerms()}
+	}
+
+	var result tools.ClassifyVariantResult
+	raw, envelope := s.call(ctx, "classify_variant", "classification", params, &result)
+	if envelope != nil {
+		return nil, envelope
+	}
+
+	response := &acmgv1.ClassifyVariantResponse{
+		RequestId:         req.GetRequestId(),
+		VariantId:         result.VariantID,
+		Classification:    result.Classification,
+		Confidence:        result.Confidence,
+		PointTotal:        int32(result.PointTotal),
+		EvidenceSummary:   result.EvidenceSummary,
+		Recommendations:   result.Recommendations,
+		Transcript:        result.Transcript,
+		VcepSpecification: result.Specification,
+		Provisional:       result.Provisional,
+		VrsId:             result.VRSID,
+	}
+	for _, rule := range result.AppliedRules {
+		if rule.Applied {
+			response.Criteria = append(response.Criteria, &acmgv1.Criterion{Code: rule.RuleCode, Strength: rule.Strength, Category: rule.Category})
+		}
+	}
+	if req.GetIncludeFullResult() {
+		response.ResultJson = raw
+	}
+	return response, nil
+}
+
+// call runs a tool in-process and decodes the result held under key into
+// out, returning the result's JSON
+func (s *Server) call(ctx context.Context, name, key string, params, out interface{}) (json.RawMessage, *protocol.ErrorEnvelope) {
+	correlationID := protocol.CorrelationIDFromContext(ctx)
+	response := s.caller.CallTool(ctx, name, params)
+	if response == nil {
+		return nil, protocol.NewErrorEnvelope(protocol.ErrorCodeInternal, name+" returned no response", "tool:"+name, correlationID, nil)
+	}
+	if response.Error != nil {
+		return nil, response.Error.Envelope("tool:"+name, correlationID)
+	}
+
+	// Round-trip through JSON so summarized or in-process results decode alike
+	encoded, err := json.Marshal(response.Result)
+	if err != nil {
+		return nil, protocol.NewErrorEnvelope(protocol.ErrorCodeInternal, "Failed to encode result", "tool:"+name, correlationID, err.Error())
+	}
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &decoded); err != nil || decoded[key] == nil {
+		return nil, protocol.NewErrorEnvelope(protocol.ErrorCodeInternal, "Unexpected "+name+" result", "tool:"+name, correlationID, nil)
+	}
+	if err := json.Unmarshal(decoded[key], out); err != nil {
+		return nil, protocol.NewErrorEnvelope(protocol.ErrorCodeInternal, "Unexpected "+name+" result", "tool:"+name, correlationID, err.Error())
+	}
+	return decoded[key], nil
+}
+
+// statusError converts an error envelope to a gRPC status, reporting the
+// envelope code in the trailer
+func (s *Server) statusError(ctx context.Context, envelope *protocol.ErrorEnvelope) error {
+	grpc.SetTrailer(ctx, metadata.Pairs(errorCodeMetadata, envelope.Code))
+	return status.Error(StatusCode(envelope.Code), envelope.Message)
+}
+
+// StatusCode maps an error envelope code to a gRPC status code
+func StatusCode(code string) codes.Code {
+	switch code {
+	case protocol.ErrorCodeInvalidInput, protocol.ErrorCodeInvalidRequest, protocol.ErrorCodeParseError:
+		return codes.InvalidArgument
+	case protocol.ErrorCodeNotFound:
+		return codes.NotFound
+	case protocol.ErrorCodeConflict:
+		return codes.Aborted
+	case protocol.ErrorCodeUnauthorized:
+		return codes.Unauthenticated
+	case protocol.ErrorCodeForbidden:
+		return codes.PermissionDenied
+	case protocol.ErrorCodeRateLimited:
+		return codes.ResourceExhausted
+	case protocol.ErrorCodeResourceError:
+		return codes.Unavailable
+	case protocol.ErrorCodeTimeout:
+		return codes.DeadlineExceeded
+	case protocol.ErrorCodeCancelled:
+		return codes.Canceled
+	default:
+		return codes.Internal
+	}
+}
+
+// admit authenticates and rate limits a call, returning its context with
+// the principal and correlation ID
+func (s *Server) admit(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(correlationMetadata); len(ids) > 0 && ids[0] != "" {
+		ctx = protocol.WithCorrelationID(ctx, ids[0])
+	}
+	ctx, correlationID := protocol.EnsureCorrelationID(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs(correlationMetadata, correlationID))
+
+	principal, err := s.authn.AuthenticateCredentials(first(md, apiKeyMetadata), first(md, authorizationMetadata))
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{"method": method, "correlation_id": correlationID}).Warn("Rejected unauthenticated gRPC call")
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	ctx = auth.WithPrincipal(ctx, principal)
+
+	if s.limiter != nil {
+		client := clientID(ctx, principal)
+		if allowed, retryAfter := s.limiter.Allow(client); !allowed {
+			grpc.SetTrailer(ctx, metadata.Pairs(
+				errorCodeMetadata, protocol.ErrorCodeRateLimited,
+				retryAfterMetadata, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))),
+			))
+			return nil, status.Error(codes.ResourceExhausted, "Rate limit exceeded")
+		}
+	}
+	return ctx, nil
+}
+
+// unaryInterceptor admits unary calls and tracks them for the shutdown drain
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if s.shutdown != nil {
+		var done func()
+		var err error
+		if ctx, done, err = s.shutdown.Begin(ctx); err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		defer done()
+	}
+	ctx, err := s.admit(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamInterceptor admits streams and tracks them for the shutdown drain
+func (s *Server) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := stream.Context()
+	if s.shutdown != nil {
+		var done func()
+		var err error
+		if ctx, done, err = s.shutdown.Begin(ctx); err != nil {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		defer done()
+	}
+	ctx, err := s.admit(ctx, info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &admittedStream{ServerStream: stream, ctx: ctx})
+}
+
+// admittedStream carries the admitted context to the stream handler
+type admittedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (a *admittedStream) Context() context.Context {
+	return a.ctx
+}
+
+// clientID identifies the client for rate limiting as the HTTP API does:
+// by subject when authenticated, otherwise by peer address
+func clientID(ctx context.Context, principal *auth.Principal) string {
+	if principal.Method != auth.MethodAnonymous {
+		return "user:" + principal.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return "ip:" + host
+		}
+		return "ip:" + p.Addr.String()
+	}
+	return "ip:unknown"
+}
+
+// first returns the first value of a metadata key
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
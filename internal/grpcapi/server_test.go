@@ -0,0 +1,231 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	acmgv1 "github.com/acmg-amp-mcp-server/api/proto/acmg/v1"
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/internal/middleware"
+	"github.com/acmg-amp-mcp-server/internal/shutdown"
+)
+
+const testKey = "pipeline-key"
+
+// fakeTools answers tool calls with canned results, failing classify_variant
+// for the notation "bad"
+type fakeTools struct {
+	principals []*auth.Principal
+	params     []interface{}
+}
+
+func (f *fakeTools) CallTool(ctx context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response {
+	f.principals = append(f.principals, auth.PrincipalFrom(ctx))
+	f.params = append(f.params, arguments)
+	switch name {
+	case "classify_variant":
+		params := arguments.(tools.ClassifyVariantParams)
+		if params.HGVSNotation == "bad" {
+			return &protocol.JSONRPC2Response{Error: &protocol.RPCError{Code: protocol.InvalidParams, Message: "Invalid HGVS notation"}}
+		}
+		return &protocol.JSONRPC2Response{Result: map[string]interface{}{"classification": &tools.ClassifyVariantResult{
+			VariantID:      params.HGVSNotation,
+			Classification: "LIKELY_PATHOGENIC",
+			Confidence:     "High",
+			PointTotal:     7,
+			AppliedRules: []tools.ACMGAMPRuleResult{
+				{RuleCode: "PVS1", Category: "PATHOGENIC", Strength: "VERY_STRONG", Applied: true},
+				{RuleCode: "BA1", Category: "BENIGN", Strength: "STAND_ALONE"},
+			},
+		}}}
+	case "validate_hgvs":
+		return &protocol.JSONRPC2Response{Result: map[string]interface{}{"validation": &tools.ValidateHGVSResult{
+			IsValid:          false,
+			HGVSNotation:     "NM_000492.4:c.1521_1523delCTT",
+			ValidationIssues: []tools.ValidationIssue{{Severity: "warning", Code: "REDUNDANT_SEQUENCE", Message: "Deleted bases are redundant", Position: 21}},
+			GeneInfo:         &tools.GeneInfo{Symbol: "CFTR"},
+		}}}
+	case "query_evidence":
+		result := &tools.QueryEvidenceResult{VariantID: "v1", HGVSNotation: "NM_000492.4:c.1521_1523del"}
+		result.AggregatedEvidence.PopulationFrequency.MaxFrequency = 0.012
+		result.AggregatedEvidence.ClinicalEvidence.ClinVarEntries = []tools.ClinVarEntry{{AccessionID: "VCV000007105"}}
+		result.AggregatedEvidence.ComputationalData.REVEL = 0.91
+		return &protocol.JSONRPC2Response{Result: map[string]interface{}{"evidence": result}}
+	}
+	return &protocol.JSONRPC2Response{Error: &protocol.RPCError{Code: protocol.MethodNotFound, Message: "Tool not found"}}
+}
+
+func startTestServer(t *testing.T, configure func(*Server)) (acmgv1.ClassifierServiceClient, *fakeTools) {
+	t.Helper()
+	authenticator, err := auth.NewAuthenticator(auth.Config{APIKeys: []auth.APIKey{{Name: "pipeline", Role: auth.RoleClassify, Key: testKey}}})
+	require.NoError(t, err)
+	logger, _ := test.NewNullLogger()
+	caller := &fakeTools{}
+	server, err := NewServer(logger, caller, authenticator)
+	require.NoError(t, err)
+	if configure != nil {
+		configure(server)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Close)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return acmgv1.NewClassifierServiceClient(conn), caller
+}
+
+func withKey(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, apiKeyMetadata, testKey)
+}
+
+func TestServer_ClassifyVariant(t *testing.T) {
+	client, caller := startTestServer(t, nil)
+	ctx := withKey(context.Background())
+
+	var header metadata.MD
+	response, err := client.ClassifyVariant(ctx, &acmgv1.ClassifyVariantRequest{
+		RequestId:         "row-1",
+		HgvsNotation:      "NM_000492.4:c.1521_1523del",
+		HpoTerms:          []string{"HP:0002110"},
+		IncludeFullResult: true,
+	}, grpc.Header(&header))
+
+	require.NoError(t, err)
+	assert.Equal(t, "row-1", response.RequestId)
+	assert.Equal(t, "LIKELY_PATHOGENIC", response.Classification)
+	assert.Equal(t, int32(7), response.PointTotal)
+	require.Len(t, response.Criteria, 1, "only criteria met are listed")
+	assert.Equal(t, "PVS1", response.Criteria[0].Code)
+	assert.Contains(t, string(response.ResultJson), `"applied_rules"`)
+	assert.NotEmpty(t, header.Get(correlationMetadata))
+
+	require.Len(t, caller.principals, 1)
+	assert.Equal(t, "pipeline", caller.principals[0].Subject)
+	params := caller.params[0].(tools.ClassifyVariantParams)
+	assert.Equal(t, []string{"HP:0002110"}, params.PatientContext.HPOTerms)
+
+	t.Run("tool errors map to status codes", func(t *testing.T) {
+		var trailer metadata.MD
+		_, err := client.ClassifyVariant(ctx, &acmgv1.ClassifyVariantRequest{HgvsNotation: "bad"}, grpc.Trailer(&trailer))
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, []string{protocol.ErrorCodeInvalidInput}, trailer.Get(errorCodeMetadata))
+	})
+}
+
+func TestServer_ClassifyVariants(t *testing.T) {
+	client, _ := startTestServer(t, nil)
+	stream, err := client.ClassifyVariants(withKey(context.Background()))
+	require.NoError(t, err)
+
+	for i, notation := range []string{"NM_000492.4:c.1521_1523del", "bad", "NM_007294.4:c.5266dupC"} {
+		require.NoError(t, stream.Send(&acmgv1.ClassifyVariantRequest{RequestId: string(rune('a' + i)), HgvsNotation: notation}))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	var responses []*acmgv1.ClassifyVariantResponse
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		responses = append(responses, response)
+	}
+
+	require.Len(t, responses, 3)
+	assert.Equal(t, "a", responses[0].RequestId)
+	assert.Nil(t, responses[0].Error)
+	assert.Equal(t, "b", responses[1].RequestId)
+	require.NotNil(t, responses[1].Error, "a failed variant does not end the stream")
+	assert.Equal(t, protocol.ErrorCodeInvalidInput, responses[1].Error.Code)
+	assert.NotEmpty(t, responses[1].Error.CorrelationId)
+	assert.Equal(t, "NM_007294.4:c.5266dupC", responses[2].VariantId)
+}
+
+func TestServer_ValidateAndEvidence(t *testing.T) {
+	client, _ := startTestServer(t, nil)
+	ctx := withKey(context.Background())
+
+	validation, err := client.ValidateHGVS(ctx, &acmgv1.ValidateHGVSRequest{HgvsNotation: "NM_000492.4:c.1521_1523delCTT"})
+	require.NoError(t, err)
+	assert.Equal(t, "CFTR", validation.GeneSymbol)
+	require.Len(t, validation.Issues, 1)
+	assert.Equal(t, int32(21), validation.Issues[0].Position)
+
+	evidence, err := client.QueryEvidence(ctx, &acmgv1.QueryEvidenceRequest{HgvsNotation: "NM_000492.4:c.1521_1523del"})
+	require.NoError(t, err)
+	assert.Equal(t, 0.012, evidence.PopulationFrequency.MaxFrequency)
+	assert.Equal(t, []string{"VCV000007105"}, evidence.ClinicalEvidence.ClinvarAccessions)
+	assert.Equal(t, 0.91, evidence.ComputationalEvidence.RevelScore)
+	assert.Empty(t, evidence.ResultJson)
+}
+
+func TestServer_Admission(t *testing.T) {
+	t.Run("credentials are required", func(t *testing.T) {
+		client, caller := startTestServer(t, nil)
+
+		_, err := client.ValidateHGVS(context.Background(), &acmgv1.ValidateHGVSRequest{HgvsNotation: "x"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = client.ValidateHGVS(metadata.AppendToOutgoingContext(context.Background(), authorizationMetadata, "Bearer wrong"), &acmgv1.ValidateHGVSRequest{HgvsNotation: "x"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Empty(t, caller.principals)
+	})
+
+	t.Run("calls are rate limited", func(t *testing.T) {
+		client, _ := startTestServer(t, func(s *Server) {
+			s.SetRateLimiter(middleware.NewRateLimiter(middleware.RateLimitConfig{RequestsPerSecond: 0.001, Burst: 1}))
+		})
+		ctx := withKey(context.Background())
+
+		_, err := client.ValidateHGVS(ctx, &acmgv1.ValidateHGVSRequest{HgvsNotation: "x"})
+		require.NoError(t, err)
+		var trailer metadata.MD
+		_, err = client.ValidateHGVS(ctx, &acmgv1.ValidateHGVSRequest{HgvsNotation: "x"}, grpc.Trailer(&trailer))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.NotEmpty(t, trailer.Get(retryAfterMetadata))
+	})
+
+	t.Run("calls are refused while draining", func(t *testing.T) {
+		coordinator := shutdown.NewCoordinator()
+		client, _ := startTestServer(t, func(s *Server) { s.SetShutdown(coordinator) })
+		coordinator.Drain(context.Background())
+
+		_, err := client.ValidateHGVS(withKey(context.Background()), &acmgv1.ValidateHGVSRequest{HgvsNotation: "x"})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("authentication must be configured", func(t *testing.T) {
+		authenticator, err := auth.NewAuthenticator(auth.Config{})
+		require.NoError(t, err)
+		logger, _ := test.NewNullLogger()
+
+		_, err = NewServer(logger, &fakeTools{}, authenticator)
+		assert.Error(t, err)
+	})
+}
+
+func TestStatusCode(t *testing.T) {
+	assert.Equal(t, codes.PermissionDenied, StatusCode(protocol.ErrorCodeForbidden))
+	assert.Equal(t, codes.Unavailable, StatusCode(protocol.ErrorCodeResourceError))
+	assert.Equal(t, codes.Internal, StatusCode(protocol.ErrorCodeToolError))
+}
@@ -0,0 +1,95 @@
+package i18n
+
+// english is the English catalog and the fallback for keys missing from
+// the others
+var english = map[string]string{
+	// Reports
+	"report.title.germline":                    "Germline Variant Interpretation Report",
+	"report.title.somatic":                     "Somatic Variant Interpretation Report",
+	"report.field.report_id":                   "Report ID",
+	"report.field.report_date":                 "Report date",
+	"report.field.gene":                        "Gene",
+	"report.field.variant_id":                  "Variant ID",
+	"report.field.patient_id":                  "Patient ID",
+	"report.field.clinical_indication":         "Clinical indication",
+	"report.field.referring_physician":         "Referring physician",
+	"report.field.test_date":                   "Test date",
+	"report.field.analyst":                     "Analyst",
+	"report.field.reviewed_by":                 "Reviewed by",
+	"report.field.review_date":                 "Review date",
+	"report.field.tumor_type":                  "Tumor type",
+	"report.field.evidence_level":              "Evidence level",
+	"report.field.confidence":                  "Confidence",
+	"report.field.scoring":                     "Scoring",
+	"report.field.vcep_specification":          "VCEP specification",
+	"report.field.data_sources":                "Data sources",
+	"report.field.sources":                     "Sources",
+	"report.section.result":                    "Result",
+	"report.section.evidence_summary":          "Evidence Summary",
+	"report.section.criteria_met":              "ACMG/AMP Criteria Met",
+	"report.section.criteria_not_met":          "ACMG/AMP Criteria Not Met",
+	"report.section.tier_rationale":            "AMP/ASCO/CAP Tier Rationale",
+	"report.section.limitations":               "Limitations",
+	"report.section.recommendations":           "Recommendations",
+	"report.section.overrides":                 "Manual Criterion Overrides",
+	"report.column.criterion":                  "Criterion",
+	"report.column.strength":                   "Strength",
+	"report.column.rationale":                  "Rationale",
+	"report.column.level":                      "Level",
+	"report.column.type":                       "Type",
+	"report.column.tumor_type":                 "Tumor type",
+	"report.column.significance":               "Significance",
+	"report.column.therapies":                  "Therapies",
+	"report.column.source":                     "Source",
+	"report.column.change":                     "Change",
+	"report.column.justification":              "Justification",
+	"report.column.overridden_by":              "Overridden by",
+	"report.column.date":                       "Date",
+	"report.none":                              "None.",
+	"report.override.marker":                   "(manual override)",
+	"report.override.apply":                    "Applied at %s",
+	"report.override.remove":                   "Removed (was %s)",
+	"report.override.strength":                 "Strength changed from %s to %s",
+	"report.limitation.evidence":               "Classification is based on currently available evidence and may change as new data becomes available",
+	"report.limitation.guidelines":             "Variant interpretation follows ACMG/AMP guidelines which have inherent limitations",
+	"report.limitation.population":             "Population frequency data may not be representative of all ethnic groups",
+	"report.recommendation.guidelines":         "Follow ACMG/AMP guidelines for variant interpretation",
+	"report.recommendation.family_testing":     "Consider family testing if clinically indicated",
+	"report.recommendation.reevaluation":       "Periodic re-evaluation of variant as new evidence becomes available",
+	"report.recommendation.functional_studies": "Consider functional studies if clinically warranted",
+	"report.disclaimer.purpose":                "This report is for research/clinical decision support purposes only",
+	"report.disclaimer.may_change":             "Classification may change as new evidence becomes available",
+	"report.disclaimer.patient_factors":        "Clinical decisions should consider additional patient-specific factors",
+	"report.disclaimer.automated":              "Report generated using automated ACMG/AMP classification algorithms",
+
+	// Classifications and criterion strengths
+	"classification.PATHOGENIC":        "Pathogenic",
+	"classification.LIKELY_PATHOGENIC": "Likely pathogenic",
+	"classification.VUS":               "Uncertain significance",
+	"classification.LIKELY_BENIGN":     "Likely benign",
+	"classification.BENIGN":            "Benign",
+	"strength.VERY_STRONG":             "Very strong",
+	"strength.STRONG":                  "Strong",
+	"strength.MODERATE":                "Moderate",
+	"strength.SUPPORTING":              "Supporting",
+
+	// Prompts
+	"prompt.respond_in": "Write every response in English. Keep ACMG/AMP criterion codes such as PVS1 and PM2, gene symbols and HGVS notation as they are.",
+
+	// Errors, by error envelope code
+	"error.INVALID_INPUT":            "Invalid input",
+	"error.INVALID_REQUEST":          "Invalid request",
+	"error.PARSE_ERROR":              "The request could not be parsed",
+	"error.NOT_FOUND":                "Not found",
+	"error.CONFLICT":                 "Conflicts with the current state",
+	"error.UNAUTHORIZED":             "Authentication required",
+	"error.FORBIDDEN":                "Permission denied",
+	"error.RATE_LIMITED":             "Too many requests; retry later",
+	"error.RESOURCE_ERROR":           "Resource unavailable",
+	"error.TOOL_ERROR":               "Tool execution failed",
+	"error.TIMEOUT":                  "Timed out",
+	"error.CANCELLED":                "Cancelled",
+	"error.INTERNAL_ERROR":           "Internal error",
+	"error.invalid_prompt_arguments": "Invalid prompt arguments",
+	"error.prompt_render_failed":     "Failed to render prompt",
+}
@@ -0,0 +1,94 @@
+package i18n
+
+// japanese is the Japanese catalog
+var japanese = map[string]string{
+	// Reports
+	"report.title.germline":                    "生殖細胞系列バリアント解釈報告書",
+	"report.title.somatic":                     "体細胞バリアント解釈報告書",
+	"report.field.report_id":                   "報告書ID",
+	"report.field.report_date":                 "報告日",
+	"report.field.gene":                        "遺伝子",
+	"report.field.variant_id":                  "バリアントID",
+	"report.field.patient_id":                  "患者ID",
+	"report.field.clinical_indication":         "臨床的適応",
+	"report.field.referring_physician":         "依頼医",
+	"report.field.test_date":                   "検査日",
+	"report.field.analyst":                     "解析担当者",
+	"report.field.reviewed_by":                 "レビュー担当者",
+	"report.field.review_date":                 "レビュー日",
+	"report.field.tumor_type":                  "腫瘍型",
+	"report.field.evidence_level":              "エビデンスレベル",
+	"report.field.confidence":                  "信頼度",
+	"report.field.scoring":                     "スコアリング",
+	"report.field.vcep_specification":          "VCEP仕様",
+	"report.field.data_sources":                "データソース",
+	"report.field.sources":                     "出典",
+	"report.section.result":                    "結果",
+	"report.section.evidence_summary":          "エビデンスの要約",
+	"report.section.criteria_met":              "該当するACMG/AMP基準",
+	"report.section.criteria_not_met":          "該当しないACMG/AMP基準",
+	"report.section.tier_rationale":            "AMP/ASCO/CAPティア判定の根拠",
+	"report.section.limitations":               "限界",
+	"report.section.recommendations":           "推奨事項",
+	"report.section.overrides":                 "基準の手動上書き",
+	"report.column.criterion":                  "基準",
+	"report.column.strength":                   "強度",
+	"report.column.rationale":                  "根拠",
+	"report.column.level":                      "レベル",
+	"report.column.type":                       "種類",
+	"report.column.tumor_type":                 "腫瘍型",
+	"report.column.significance":               "臨床的意義",
+	"report.column.therapies":                  "治療",
+	"report.column.source":                     "出典",
+	"report.column.change":                     "変更内容",
+	"report.column.justification":              "理由",
+	"report.column.overridden_by":              "上書き者",
+	"report.column.date":                       "日付",
+	"report.none":                              "なし。",
+	"report.override.marker":                   "（手動上書き）",
+	"report.override.apply":                    "%sで適用",
+	"report.override.remove":                   "除外（元は%s）",
+	"report.override.strength":                 "強度を%sから%sに変更",
+	"report.limitation.evidence":               "分類は現時点で入手可能なエビデンスに基づいており、新しいデータにより変更される可能性があります",
+	"report.limitation.guidelines":             "バリアント解釈はACMG/AMPガイドラインに従っており、ガイドライン自体に固有の限界があります",
+	"report.limitation.population":             "集団頻度データはすべての民族集団を代表していない可能性があります",
+	"report.recommendation.guidelines":         "バリアント解釈にはACMG/AMPガイドラインに従ってください",
+	"report.recommendation.family_testing":     "臨床的に必要な場合は家族の検査を検討してください",
+	"report.recommendation.reevaluation":       "新しいエビデンスが得られた際にはバリアントを定期的に再評価してください",
+	"report.recommendation.functional_studies": "臨床的に妥当な場合は機能解析を検討してください",
+	"report.disclaimer.purpose":                "本報告書は研究および臨床判断の支援のみを目的としています",
+	"report.disclaimer.may_change":             "新しいエビデンスが得られた場合、分類が変更されることがあります",
+	"report.disclaimer.patient_factors":        "臨床判断では患者固有の要因も考慮してください",
+	"report.disclaimer.automated":              "本報告書は自動化されたACMG/AMP分類アルゴリズムにより作成されました",
+
+	// Classifications and criterion strengths
+	"classification.PATHOGENIC":        "病的",
+	"classification.LIKELY_PATHOGENIC": "病的の可能性が高い",
+	"classification.VUS":               "意義不明",
+	"classification.LIKELY_BENIGN":     "良性の可能性が高い",
+	"classification.BENIGN":            "良性",
+	"strength.VERY_STRONG":             "非常に強い",
+	"strength.STRONG":                  "強い",
+	"strength.MODERATE":                "中程度",
+	"strength.SUPPORTING":              "支持的",
+
+	// Prompts
+	"prompt.respond_in": "回答はすべて日本語で作成してください。PVS1やPM2などのACMG/AMP基準コード、遺伝子記号、HGVS表記は原文のまま残してください。",
+
+	// Errors, by error envelope code
+	"error.INVALID_INPUT":            "入力が無効です",
+	"error.INVALID_REQUEST":          "リクエストが無効です",
+	"error.PARSE_ERROR":              "リクエストを解析できませんでした",
+	"error.NOT_FOUND":                "見つかりません",
+	"error.CONFLICT":                 "現在の状態と競合しています",
+	"error.UNAUTHORIZED":             "認証が必要です",
+	"error.FORBIDDEN":                "権限がありません",
+	"error.RATE_LIMITED":             "リクエストが多すぎます。しばらくしてから再試行してください",
+	"error.RESOURCE_ERROR":           "リソースを利用できません",
+	"error.TOOL_ERROR":               "ツールの実行に失敗しました",
+	"error.TIMEOUT":                  "タイムアウトしました",
+	"error.CANCELLED":                "キャンセルされました",
+	"error.INTERNAL_ERROR":           "内部エラーが発生しました",
+	"error.invalid_prompt_arguments": "プロンプトの引数が無効です",
+	"error.prompt_render_failed":     "プロンプトを生成できませんでした",
+}
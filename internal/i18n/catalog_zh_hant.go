@@ -0,0 +1,94 @@
+package i18n
+
+// traditionalChinese is the Traditional Chinese catalog
+var traditionalChinese = map[string]string{
+	// Reports
+	"report.title.germline":                    "生殖細胞系變異解讀報告",
+	"report.title.somatic":                     "體細胞變異解讀報告",
+	"report.field.report_id":                   "報告編號",
+	"report.field.report_date":                 "報告日期",
+	"report.field.gene":                        "基因",
+	"report.field.variant_id":                  "變異編號",
+	"report.field.patient_id":                  "病患編號",
+	"report.field.clinical_indication":         "臨床適應症",
+	"report.field.referring_physician":         "轉介醫師",
+	"report.field.test_date":                   "檢測日期",
+	"report.field.analyst":                     "分析人員",
+	"report.field.reviewed_by":                 "審核人員",
+	"report.field.review_date":                 "審核日期",
+	"report.field.tumor_type":                  "腫瘤類型",
+	"report.field.evidence_level":              "證據等級",
+	"report.field.confidence":                  "信賴度",
+	"report.field.scoring":                     "評分方式",
+	"report.field.vcep_specification":          "VCEP 規範",
+	"report.field.data_sources":                "資料來源",
+	"report.field.sources":                     "來源",
+	"report.section.result":                    "結果",
+	"report.section.evidence_summary":          "證據摘要",
+	"report.section.criteria_met":              "符合的 ACMG/AMP 準則",
+	"report.section.criteria_not_met":          "未符合的 ACMG/AMP 準則",
+	"report.section.tier_rationale":            "AMP/ASCO/CAP 分級依據",
+	"report.section.limitations":               "限制",
+	"report.section.recommendations":           "建議",
+	"report.section.overrides":                 "準則手動覆寫",
+	"report.column.criterion":                  "準則",
+	"report.column.strength":                   "強度",
+	"report.column.rationale":                  "依據",
+	"report.column.level":                      "等級",
+	"report.column.type":                       "類型",
+	"report.column.tumor_type":                 "腫瘤類型",
+	"report.column.significance":               "臨床意義",
+	"report.column.therapies":                  "治療",
+	"report.column.source":                     "來源",
+	"report.column.change":                     "變更",
+	"report.column.justification":              "理由",
+	"report.column.overridden_by":              "覆寫者",
+	"report.column.date":                       "日期",
+	"report.none":                              "無。",
+	"report.override.marker":                   "（手動覆寫）",
+	"report.override.apply":                    "以%s套用",
+	"report.override.remove":                   "移除（原為%s）",
+	"report.override.strength":                 "強度由%s改為%s",
+	"report.limitation.evidence":               "分類依據目前可取得的證據，可能隨新資料出現而變更",
+	"report.limitation.guidelines":             "變異解讀遵循 ACMG/AMP 指引，而指引本身有其固有限制",
+	"report.limitation.population":             "族群頻率資料可能無法代表所有族群",
+	"report.recommendation.guidelines":         "變異解讀請遵循 ACMG/AMP 指引",
+	"report.recommendation.family_testing":     "如有臨床需要，請考慮進行家族檢測",
+	"report.recommendation.reevaluation":       "隨新證據出現定期重新評估此變異",
+	"report.recommendation.functional_studies": "如臨床上有必要，請考慮進行功能性研究",
+	"report.disclaimer.purpose":                "本報告僅供研究及臨床決策支援之用",
+	"report.disclaimer.may_change":             "分類可能隨新證據出現而變更",
+	"report.disclaimer.patient_factors":        "臨床決策應考量其他病患個別因素",
+	"report.disclaimer.automated":              "本報告由自動化 ACMG/AMP 分類演算法產生",
+
+	// Classifications and criterion strengths
+	"classification.PATHOGENIC":        "致病性",
+	"classification.LIKELY_PATHOGENIC": "可能致病性",
+	"classification.VUS":               "意義未明",
+	"classification.LIKELY_BENIGN":     "可能良性",
+	"classification.BENIGN":            "良性",
+	"strength.VERY_STRONG":             "非常強",
+	"strength.STRONG":                  "強",
+	"strength.MODERATE":                "中等",
+	"strength.SUPPORTING":              "支持性",
+
+	// Prompts
+	"prompt.respond_in": "請以繁體中文撰寫所有回覆。PVS1、PM2 等 ACMG/AMP 準則代碼、基因符號及 HGVS 表示法請保留原文。",
+
+	// Errors, by error envelope code
+	"error.INVALID_INPUT":            "輸入無效",
+	"error.INVALID_REQUEST":          "請求無效",
+	"error.PARSE_ERROR":              "無法解析請求",
+	"error.NOT_FOUND":                "找不到",
+	"error.CONFLICT":                 "與目前狀態衝突",
+	"error.UNAUTHORIZED":             "需要驗證",
+	"error.FORBIDDEN":                "權限不足",
+	"error.RATE_LIMITED":             "請求過多，請稍後再試",
+	"error.RESOURCE_ERROR":           "資源無法使用",
+	"error.TOOL_ERROR":               "工具執行失敗",
+	"error.TIMEOUT":                  "逾時",
+	"error.CANCELLED":                "已取消",
+	"error.INTERNAL_ERROR":           "發生內部錯誤",
+	"error.invalid_prompt_arguments": "提示參數無效",
+	"error.prompt_render_failed":     "無法產生提示",
+}
@@ -0,0 +1,102 @@
+// Package i18n localizes the text the server writes for people: rendered
+// reports, prompt output and error messages. Messages are looked up by key
+// in a catalog per locale; a key missing from a catalog falls back to
+// English, so a partial translation never leaves a blank. The locale comes
+// from a request's locale parameter, or else from the configured default.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Locale identifies a message catalog
+type Locale string
+
+// Supported locales
+const (
+	English            Locale = "en"
+	Japanese           Locale = "ja"
+	TraditionalChinese Locale = "zh-Hant"
+)
+
+// Locales lists the supported locales in the order offered to clients
+var Locales = []Locale{English, Japanese, TraditionalChinese}
+
+// catalogs holds the messages of each locale by key
+var catalogs = map[Locale]map[string]string{
+	English:            english,
+	Japanese:           japanese,
+	TraditionalChinese: traditionalChinese,
+}
+
+// localeAliases maps lowercased language tags to the locale serving them.
+// Simplified Chinese tags are not aliases: its users are not served by the
+// Traditional Chinese catalog.
+var localeAliases = map[string]Locale{
+	"en":      English,
+	"en-us":   English,
+	"en-gb":   English,
+	"ja":      Japanese,
+	"ja-jp":   Japanese,
+	"zh-hant": TraditionalChinese,
+	"zh-tw":   TraditionalChinese,
+	"zh-hk":   TraditionalChinese,
+	"zh-mo":   TraditionalChinese,
+}
+
+// ParseLocale resolves a language tag such as "ja", "ja-JP" or "zh-TW" to a
+// supported locale, ignoring case and accepting underscores for hyphens
+func ParseLocale(s string) (Locale, error) {
+	tag := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "_", "-"))
+	if locale, ok := localeAliases[tag]; ok {
+		return locale, nil
+	}
+	// Region subtags after the script, e.g. zh-Hant-TW
+	if strings.HasPrefix(tag, "zh-hant-") {
+		return TraditionalChinese, nil
+	}
+	return "", fmt.Errorf("unsupported locale %q; supported locales are %s", s, strings.Join(Strings(), ", "))
+}
+
+// Strings returns the supported locales as strings, for JSON schema enums
+func Strings() []string {
+	values := make([]string, len(Locales))
+	for i, locale := range Locales {
+		values[i] = string(locale)
+	}
+	return values
+}
+
+// T returns the message for key in the locale, formatted with args when
+// given. A key missing from the catalog falls back to English, and a key
+// missing from English is returned as is.
+func (l Locale) T(key string, args ...interface{}) string {
+	message, ok := catalogs[l][key]
+	if !ok {
+		message, ok = english[key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+type localeKey struct{}
+
+// WithLocale returns a context carrying the locale of a request
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the context's locale, or English if none
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(localeKey{}).(Locale); ok && locale != "" {
+		return locale
+	}
+	return English
+}
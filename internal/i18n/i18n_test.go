@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogs_Complete(t *testing.T) {
+	for _, locale := range Locales {
+		catalog := catalogs[locale]
+		require.NotNil(t, catalog, locale)
+		for key, message := range english {
+			translated, ok := catalog[key]
+			if assert.True(t, ok, "%s is missing %s", locale, key) {
+				assert.NotEmpty(t, translated, "%s %s", locale, key)
+				assert.Equal(t, strings.Count(message, "%"), strings.Count(translated, "%"), "%s %s formatting verbs", locale, key)
+			}
+		}
+		for key := range catalog {
+			_, ok := english[key]
+			assert.True(t, ok, "%s has %s, which English lacks", locale, key)
+		}
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	for tag, want := range map[string]Locale{
+		"en":         English,
+		"EN-us":      English,
+		"ja":         Japanese,
+		"ja_JP":      Japanese,
+		"zh-Hant":    TraditionalChinese,
+		"zh-TW":      TraditionalChinese,
+		"zh-hant-hk": TraditionalChinese,
+	} {
+		locale, err := ParseLocale(tag)
+		require.NoError(t, err, tag)
+		assert.Equal(t, want, locale, tag)
+	}
+
+	for _, tag := range []string{"", "fr", "zh", "zh-CN", "zh-Hans"} {
+		_, err := ParseLocale(tag)
+		assert.Error(t, err, tag)
+	}
+}
+
+func TestLocale_T(t *testing.T) {
+	assert.Equal(t, "病的", Japanese.T("classification.PATHOGENIC"))
+	assert.Equal(t, "意義未明", TraditionalChinese.T("classification.VUS"))
+
+	// Missing keys fall back to English, then to the key
+	english["test.greeting"] = "Hello, %s"
+	defer delete(english, "test.greeting")
+	assert.Equal(t, "Hello, Ada", Japanese.T("test.greeting", "Ada"))
+	assert.Equal(t, "test.unknown", Japanese.T("test.unknown"))
+	assert.Equal(t, "Pathogenic", Locale("").T("classification.PATHOGENIC"))
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, English, FromContext(context.Background()))
+	assert.Equal(t, Japanese, FromContext(WithLocale(context.Background(), Japanese)))
+}
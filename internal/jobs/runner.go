@@ -0,0 +1,301 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// Runner defaults
+const (
+	DefaultWorkers   = 2
+	DefaultChunkSize = 100
+)
+
+// pollInterval is how often idle workers look for jobs queued elsewhere
+const pollInterval = 30 * time.Second
+
+// ToolCaller runs an MCP tool in-process
+type ToolCaller interface {
+	CallTool(ctx context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response
+}
+
+// Runner classifies queued jobs with a bounded number of workers. Each
+// worker runs one job at a time.
+type Runner struct {
+	logger       *logrus.Logger
+	store        Store
+	caller       ToolCaller
+	workers      int
+	chunkSize    int
+	wake         chan struct{}
+	stop         chan struct{}
+	stopOnce     sync.Once
+	checkpointed atomic.Int64
+	wg           sync.WaitGroup
+}
+
+// NewRunner creates a job runner with workers concurrent jobs.
+func NewRunner(logger *logrus.Logger, store Store, caller ToolCaller, workers int) *Runner {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	return &Runner{
+		logger:    logger,
+		store:     store,
+		caller:    caller,
+		workers:   workers,
+		chunkSize: DefaultChunkSize,
+		wake:      make(chan struct{}, workers),
+		stop:      make(chan struct{}),
+	}
+}
+
+// SetChunkSize sets the variants classified per classify_variants_batch
+// call. Results are saved after each chunk.
+func (r *Runner) SetChunkSize(n int) {
+	if n > 0 {
+		r.chunkSize = n
+	}
+}
+
+// Submit queues a job.
+func (r *Runner) Submit(ctx context.Context, job *Job, items []*Item) error {
+	if err := r.store.Create(ctx, job, items); err != nil {
+		return err
+	}
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Start requeues jobs left running by a previous process and starts the
+// workers, which stop when ctx is done.
+func (r *Runner) Start(ctx context.Context) {
+	if n, err := r.store.Requeue(ctx); err != nil {
+		r.logger.WithError(err).Error("Failed to requeue interrupted jobs")
+	} else if n > 0 {
+		r.logger.WithField("jobs", n).Info("Resuming interrupted classification jobs")
+	}
+
+	for i := 0; i < r.workers; i++ {
+		r.wg.Add(1)
+		go r.work(ctx)
+	}
+}
+
+// Wait blocks until the workers have stopped.
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+// Shutdown stops the workers after their current chunk and returns their
+// jobs to the queue, waiting until ctx is done. It reports how many jobs
+// were checkpointed; a job whose chunk outlasts ctx stays running and is
+// requeued on the next start, losing only that chunk.
+func (r *Runner) Shutdown(ctx context.Context) int {
+	r.stopOnce.Do(func() { close(r.stop) })
+
+	stopped := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+	}
+	return int(r.checkpointed.Load())
+}
+
+// stopping reports whether Shutdown has been called
+func (r *Runner) stopping() bool {
+	select {
+	case <-r.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// work runs queued jobs until ctx is done
+func (r *Runner) work(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if r.stopping() {
+			return
+		}
+		job, err := r.store.Claim(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.WithError(err).Error("Failed to claim classification job")
+		}
+		if job != nil {
+			r.run(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stop:
+			return
+		case <-r.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// run classifies a job's pending items chunk by chunk. On Shutdown the job
+// is checkpointed after the current chunk; a job interrupted by ctx stays
+// running and is requeued on the next start.
+func (r *Runner) run(ctx context.Context, job *Job) {
+	logger := r.logger.WithField("job_id", job.ID)
+	logger.WithField("total", job.Total).Info("Running classification job")
+
+	for {
+		items, err := r.store.Pending(ctx, job.ID, r.chunkSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.fail(job, err)
+			}
+			return
+		}
+		if len(items) == 0 {
+			break
+		}
+
+		r.classify(ctx, job.Options, items)
+		if ctx.Err() != nil {
+			logger.Info("Classification job interrupted; it resumes on restart")
+			return
+		}
+		if err := r.store.SaveResults(ctx, job.ID, items); err != nil {
+			r.fail(job, err)
+			return
+		}
+		if r.stopping() {
+			r.checkpoint(ctx, job)
+			return
+		}
+	}
+
+	if err := r.store.Finish(ctx, job.ID, StatusCompleted, ""); err != nil {
+		logger.WithError(err).Error("Failed to complete classification job")
+		return
+	}
+	logger.Info("Completed classification job")
+}
+
+// checkpoint returns a job stopped by Shutdown to the queue
+func (r *Runner) checkpoint(ctx context.Context, job *Job) {
+	logger := r.logger.WithField("job_id", job.ID)
+	if err := r.store.Release(ctx, job.ID); err != nil {
+		logger.WithError(err).Error("Failed to checkpoint classification job; it resumes on restart")
+		return
+	}
+	r.checkpointed.Add(1)
+	logger.Info("Checkpointed classification job; it resumes on restart")
+}
+
+// fail marks a job failed; the store error is recorded on the job
+func (r *Runner) fail(job *Job, err error) {
+	r.logger.WithError(err).WithField("job_id", job.ID).Error("Classification job failed")
+	if err := r.store.Finish(context.Background(), job.ID, StatusFailed, err.Error()); err != nil {
+		r.logger.WithError(err).WithField("job_id", job.ID).Error("Failed to record job failure")
+	}
+}
+
+// classify classifies items with one classify_variants_batch call and sets
+// their outcome. A failed call is reported on each item.
+func (r *Runner) classify(ctx context.Context, options Options, items []*Item) {
+	var notations []string
+	var classified []*Item
+	for _, item := range items {
+		if item.Notation != "" && item.Error == "" {
+			notations = append(notations, item.Notation)
+			classified = append(classified, item)
+		}
+	}
+	if len(notations) == 0 {
+		return
+	}
+
+	results, err := r.classifyBatch(ctx, notations, options)
+	for i, item := range classified {
+		switch {
+		case err != nil:
+			item.Error = err.Error()
+		case i >= len(results) || (results[i].Result == nil && results[i].Error == ""):
+			item.Error = "no result returned"
+		case results[i].Error != "":
+			item.Error = results[i].Error
+		default:
+			item.Result = results[i].Result
+			var outcome struct {
+				Classification string `json:"classification"`
+			}
+			_ = json.Unmarshal(item.Result, &outcome)
+			item.Classification = outcome.Classification
+		}
+	}
+}
+
+// batchItem is the part of a classify_variants_batch result item a job stores
+type batchItem struct {
+	Index  int             `json:"index"`
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+}
+
+// classifyBatch calls classify_variants_batch and returns its items in input order
+func (r *Runner) classifyBatch(ctx context.Context, notations []string, options Options) ([]batchItem, error) {
+	response := r.caller.CallTool(ctx, "classify_variants_batch", map[string]interface{}{
+		"hgvs_notations":     notations,
+		"clinical_context":   options.ClinicalContext,
+		"ordering_specialty": options.OrderingSpecialty,
+		"condition":          options.Condition,
+	})
+	if response == nil {
+		return nil, fmt.Errorf("classify_variants_batch returned no response")
+	}
+	if response.Error != nil {
+		if detail, ok := response.Error.Data.(string); ok && detail != "" {
+			return nil, fmt.Errorf("%s: %s", response.Error.Message, detail)
+		}
+		return nil, fmt.Errorf("%s", response.Error.Message)
+	}
+
+	raw, err := json.Marshal(response.Result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	var decoded struct {
+		Batch *struct {
+			Results []batchItem `json:"results"`
+		} `json:"batch_classification"`
+	}
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.Batch == nil {
+		return nil, fmt.Errorf("unexpected classify_variants_batch result")
+	}
+
+	items := make([]batchItem, len(notations))
+	for _, item := range decoded.Batch.Results {
+		if item.Index >= 0 && item.Index < len(items) {
+			items[item.Index] = item
+		}
+	}
+	return items, nil
+}
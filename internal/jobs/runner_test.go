@@ -0,0 +1,171 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// fakeBatch classifies every notation as a VUS, except those listed as failing
+type fakeBatch struct {
+	mu      sync.Mutex
+	calls   [][]string
+	failing map[string]bool
+}
+
+func (f *fakeBatch) CallTool(_ context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response {
+	notations := arguments.(map[string]interface{})["hgvs_notations"].([]string)
+	f.mu.Lock()
+	f.calls = append(f.calls, notations)
+	f.mu.Unlock()
+
+	var results []map[string]interface{}
+	for i, n := range notations {
+		item := map[string]interface{}{"index": i, "hgvs_notation": n}
+		if f.failing[n] {
+			item["error"] = "invalid HGVS notation"
+		} else {
+			item["result"] = map[string]interface{}{"variant_id": n, "classification": "VUS"}
+		}
+		results = append(results, item)
+	}
+	return &protocol.JSONRPC2Response{Result: map[string]interface{}{
+		"batch_classification": map[string]interface{}{"results": results},
+	}}
+}
+
+// waitDone polls a job until it finishes
+func waitDone(t *testing.T, store Store, id int64) *Job {
+	t.Helper()
+	var job *Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = store.Get(context.Background(), id)
+		return err == nil && job.Done()
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestRunner_Submit(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestStore(t)
+	caller := &fakeBatch{failing: map[string]bool{"NM_000000.0:c.bad": true}}
+	runner := NewRunner(logger, store, caller, 2)
+	runner.SetChunkSize(2)
+	ctx, cancel := context.WithCancel(context.Background())
+	runner.Start(ctx)
+	defer func() {
+		cancel()
+		runner.Wait()
+	}()
+
+	// Act
+	job := &Job{}
+	require.NoError(t, runner.Submit(ctx, job, []*Item{
+		{Notation: "NM_007294.4:c.5266dup"},
+		{Notation: "NM_000000.0:c.bad"},
+		{Input: "1:100:A>T", Error: "unsupported chromosome"},
+		{Notation: "NM_000492.4:c.1521_1523del"},
+	}))
+
+	// Assert
+	done := waitDone(t, store, job.ID)
+	assert.Equal(t, StatusCompleted, done.Status)
+	assert.Equal(t, 4, done.Processed)
+	assert.Equal(t, 2, done.Failed)
+	assert.Equal(t, map[string]int{"VUS": 2}, done.ClassificationCounts)
+	assert.Equal(t, [][]string{{"NM_007294.4:c.5266dup", "NM_000000.0:c.bad"}, {"NM_000492.4:c.1521_1523del"}}, caller.calls)
+
+	results, err := store.Results(context.Background(), job.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, "VUS", results[0].Classification)
+	assert.Equal(t, "invalid HGVS notation", results[1].Error)
+	assert.Contains(t, string(results[3].Result), "NM_000492.4:c.1521_1523del")
+}
+
+func TestRunner_ResumesInterruptedJob(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestStore(t)
+	ctx := context.Background()
+
+	// A previous process classified the first variant, then stopped
+	job := &Job{}
+	require.NoError(t, store.Create(ctx, job, []*Item{{Notation: "NM_007294.4:c.5266dup"}, {Notation: "NM_000492.4:c.1521_1523del"}}))
+	_, err := store.Claim(ctx)
+	require.NoError(t, err)
+	first, err := store.Pending(ctx, job.ID, 1)
+	require.NoError(t, err)
+	first[0].Classification = "PATHOGENIC"
+	require.NoError(t, store.SaveResults(ctx, job.ID, first))
+
+	caller := &fakeBatch{}
+	runner := NewRunner(logger, store, caller, 1)
+	runCtx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		runner.Wait()
+	}()
+
+	// Act
+	runner.Start(runCtx)
+
+	// Assert
+	done := waitDone(t, store, job.ID)
+	assert.Equal(t, StatusCompleted, done.Status)
+	assert.Equal(t, [][]string{{"NM_000492.4:c.1521_1523del"}}, caller.calls)
+	assert.Equal(t, map[string]int{"PATHOGENIC": 1, "VUS": 1}, done.ClassificationCounts)
+}
+
+// blockingBatch holds each classify_variants_batch call until released
+type blockingBatch struct {
+	fakeBatch
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingBatch) CallTool(ctx context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response {
+	b.started <- struct{}{}
+	<-b.release
+	return b.fakeBatch.CallTool(ctx, name, arguments)
+}
+
+func TestRunner_ShutdownCheckpointsJob(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestStore(t)
+	caller := &blockingBatch{started: make(chan struct{}, 1), release: make(chan struct{})}
+	runner := NewRunner(logger, store, caller, 1)
+	runner.SetChunkSize(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner.Start(ctx)
+
+	job := &Job{}
+	require.NoError(t, runner.Submit(ctx, job, []*Item{{Notation: "NM_007294.4:c.5266dup"}, {Notation: "NM_000492.4:c.1521_1523del"}}))
+	<-caller.started
+
+	// Act: shut down while the first chunk is being classified
+	checkpointed := make(chan int)
+	go func() {
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelShutdown()
+		checkpointed <- runner.Shutdown(shutdownCtx)
+	}()
+	require.Eventually(t, runner.stopping, time.Second, time.Millisecond)
+	close(caller.release)
+
+	// Assert: the chunk finished, the job went back to the queue
+	assert.Equal(t, 1, <-checkpointed)
+	stopped, err := store.Get(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusQueued, stopped.Status)
+	assert.Equal(t, 1, stopped.Processed)
+	assert.Len(t, caller.calls, 1)
+}
@@ -0,0 +1,318 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite job store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// Workers save results concurrently; one connection serializes the writes
+	db.SetMaxOpenConns(1)
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS jobs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		status TEXT NOT NULL,
+		options TEXT NOT NULL DEFAULT '{}',
+		total INTEGER NOT NULL,
+		error TEXT NOT NULL DEFAULT '',
+		created_by TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL,
+		started_at DATETIME,
+		finished_at DATETIME
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status, id);
+
+	CREATE TABLE IF NOT EXISTS job_items (
+		job_id INTEGER NOT NULL REFERENCES jobs(id),
+		idx INTEGER NOT NULL,
+		input TEXT NOT NULL,
+		notation TEXT NOT NULL DEFAULT '',
+		done INTEGER NOT NULL DEFAULT 0,
+		classification TEXT NOT NULL DEFAULT '',
+		result TEXT,
+		error TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (job_id, idx)
+	);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+// Create queues a job with its items.
+func (s *SQLiteStore) Create(ctx context.Context, job *Job, items []*Item) error {
+	if err := Validate(items); err != nil {
+		return err
+	}
+	job.Status = StatusQueued
+	job.Total = len(items)
+	job.CreatedAt = time.Now().UTC()
+	options, _ := json.Marshal(job.Options)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO jobs (status, options, total, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, job.Status, string(options), job.Total, job.CreatedBy, job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert job: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get job ID: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO job_items (job_id, idx, input, notation, done, error)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare item insert: %w", err)
+	}
+	defer stmt.Close()
+	for _, item := range items {
+		if _, err := stmt.ExecContext(ctx, id, item.Index, item.Input, item.Notation, item.Error != "", item.Error); err != nil {
+			return fmt.Errorf("failed to insert job item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit job: %w", err)
+	}
+	job.ID = id
+	return nil
+}
+
+// Get returns a job with its progress counts.
+func (s *SQLiteStore) Get(ctx context.Context, id int64) (*Job, error) {
+	job := &Job{ID: id, ClassificationCounts: make(map[string]int)}
+	var options string
+	var startedAt, finishedAt sql.NullTime
+	err := s.db.QueryRowContext(ctx, `
+		SELECT status, options, total, error, created_by, created_at, started_at, finished_at
+		FROM jobs WHERE id = ?
+	`, id).Scan(&job.Status, &options, &job.Total, &job.Error, &job.CreatedBy, &job.CreatedAt, &startedAt, &finishedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if err := json.Unmarshal([]byte(options), &job.Options); err != nil {
+		return nil, fmt.Errorf("failed to decode options of job %d: %w", id, err)
+	}
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT classification, error != '', COUNT(*) FROM job_items
+		WHERE job_id = ? AND done = 1
+		GROUP BY classification, error != ''
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count job items: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var classification string
+		var failed bool
+		var count int
+		if err := rows.Scan(&classification, &failed, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan job item counts: %w", err)
+		}
+		job.Processed += count
+		switch {
+		case failed:
+			job.Failed += count
+		case classification != "":
+			job.ClassificationCounts[classification] += count
+		}
+	}
+	return job, rows.Err()
+}
+
+// Claim marks the oldest queued job running and returns it.
+func (s *SQLiteStore) Claim(ctx context.Context) (*Job, error) {
+	var id int64
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE jobs SET status = ?, started_at = COALESCE(started_at, ?)
+		WHERE id = (SELECT id FROM jobs WHERE status = ? ORDER BY id LIMIT 1)
+		RETURNING id
+	`, StatusRunning, time.Now().UTC(), StatusQueued).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+	return s.Get(ctx, id)
+}
+
+// Pending returns up to limit items of a job not yet classified.
+func (s *SQLiteStore) Pending(ctx context.Context, id int64, limit int) ([]*Item, error) {
+	return s.items(ctx, `
+		SELECT idx, input, notation, classification, result, error FROM job_items
+		WHERE job_id = ? AND done = 0 ORDER BY idx LIMIT ?
+	`, id, limit)
+}
+
+// SaveResults stores the outcome of classified items.
+func (s *SQLiteStore) SaveResults(ctx context.Context, id int64, items []*Item) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, item := range items {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE job_items SET done = 1, classification = ?, result = ?, error = ?
+			WHERE job_id = ? AND idx = ?
+		`, item.Classification, nullJSON(item.Result), item.Error, id, item.Index)
+		if err != nil {
+			return fmt.Errorf("failed to save job item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit job results: %w", err)
+	}
+	return nil
+}
+
+// Results returns a page of a job's classified items.
+func (s *SQLiteStore) Results(ctx context.Context, id int64, offset, limit int) ([]*Item, error) {
+	return s.items(ctx, `
+		SELECT idx, input, notation, classification, result, error FROM job_items
+		WHERE job_id = ? AND done = 1 ORDER BY idx LIMIT ? OFFSET ?
+	`, id, limit, offset)
+}
+
+// Finish marks a job completed or failed.
+func (s *SQLiteStore) Finish(ctx context.Context, id int64, status Status, errMsg string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE jobs SET status = ?, error = ?, finished_at = ? WHERE id = ?
+	`, status, errMsg, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to finish job: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	return nil
+}
+
+// Release returns a running job to the queue.
+func (s *SQLiteStore) Release(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `UPDATE jobs SET status = ? WHERE id = ? AND status = ?`, StatusQueued, id, StatusRunning)
+	if err != nil {
+		return fmt.Errorf("failed to release job: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: %d is not running", ErrNotFound, id)
+	}
+	return nil
+}
+
+// Requeue returns running jobs to the queue.
+func (s *SQLiteStore) Requeue(ctx context.Context) (int, error) {
+	result, err := s.db.ExecContext(ctx, `UPDATE jobs SET status = ? WHERE status = ?`, StatusQueued, StatusRunning)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue jobs: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return int(n), nil
+}
+
+// Close closes the store and releases resources.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) items(ctx context.Context, query string, args ...interface{}) ([]*Item, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job items: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]*Item, 0)
+	for rows.Next() {
+		item := &Item{}
+		var result sql.NullString
+		if err := rows.Scan(&item.Index, &item.Input, &item.Notation, &item.Classification, &result, &item.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan job item: %w", err)
+		}
+		if result.Valid && result.String != "" {
+			item.Result = json.RawMessage(result.String)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// nullJSON stores an empty JSON document as NULL
+func nullJSON(raw json.RawMessage) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
@@ -0,0 +1,108 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "jobs.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSQLiteStore_JobLifecycle(t *testing.T) {
+	store := createTestStore(t)
+	ctx := context.Background()
+
+	job := &Job{Options: Options{Condition: "hereditary cancer"}, CreatedBy: "lims"}
+	require.NoError(t, store.Create(ctx, job, []*Item{
+		{Notation: "NM_007294.4:c.5266dup"},
+		{Input: "chr7:117559590:ATCT>A", Error: "reference allele does not match"},
+		{Notation: " NM_000492.4:c.1521_1523del "},
+	}))
+	assert.NotZero(t, job.ID)
+	assert.Equal(t, StatusQueued, job.Status)
+	assert.Equal(t, 3, job.Total)
+
+	claimed, err := store.Claim(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Equal(t, StatusRunning, claimed.Status)
+	assert.Equal(t, "hereditary cancer", claimed.Options.Condition)
+	assert.NotNil(t, claimed.StartedAt)
+	assert.Equal(t, 1, claimed.Failed, "unreadable inputs fail on submission")
+	none, err := store.Claim(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	pending, err := store.Pending(ctx, job.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, 2, pending[1].Index)
+	assert.Equal(t, "NM_000492.4:c.1521_1523del", pending[1].Input)
+
+	pending[0].Classification = "PATHOGENIC"
+	pending[0].Result = json.RawMessage(`{"classification":"PATHOGENIC"}`)
+	require.NoError(t, store.SaveResults(ctx, job.ID, pending[:1]))
+
+	// A restart returns the running job to the queue with its progress kept
+	requeued, err := store.Requeue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requeued)
+	claimed, err = store.Claim(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, claimed.Processed)
+	pending, err = store.Pending(ctx, job.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	// A shutdown checkpoints the job back to the queue
+	require.NoError(t, store.Release(ctx, job.ID))
+	released, err := store.Get(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusQueued, released.Status)
+	assert.ErrorIs(t, store.Release(ctx, job.ID), ErrNotFound, "only running jobs are released")
+	_, err = store.Claim(ctx)
+	require.NoError(t, err)
+
+	pending[0].Error = "transcript not found"
+	require.NoError(t, store.SaveResults(ctx, job.ID, pending))
+	require.NoError(t, store.Finish(ctx, job.ID, StatusCompleted, ""))
+
+	done, err := store.Get(ctx, job.ID)
+	require.NoError(t, err)
+	assert.True(t, done.Done())
+	assert.Equal(t, 3, done.Processed)
+	assert.Equal(t, 2, done.Failed)
+	assert.Equal(t, map[string]int{"PATHOGENIC": 1}, done.ClassificationCounts)
+	assert.NotNil(t, done.FinishedAt)
+
+	results, err := store.Results(ctx, job.ID, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.JSONEq(t, `{"classification":"PATHOGENIC"}`, string(results[0].Result))
+	assert.Equal(t, "reference allele does not match", results[1].Error)
+	page, err := store.Results(ctx, job.ID, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "transcript not found", page[0].Error)
+}
+
+func TestSQLiteStore_Invalid(t *testing.T) {
+	store := createTestStore(t)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, store.Create(ctx, &Job{}, nil), ErrInvalidJob)
+	_, err := store.Get(ctx, 42)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, store.Finish(ctx, 42, StatusFailed, "boom"), ErrNotFound)
+	assert.ErrorIs(t, store.Release(ctx, 42), ErrNotFound)
+}
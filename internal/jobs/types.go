@@ -0,0 +1,129 @@
+// Package jobs runs large classification requests in the background. A job
+// holds the variants to classify; workers classify them in chunks through
+// classify_variants_batch and store each chunk's results as it finishes, so
+// clients poll for status and results instead of holding one MCP call open.
+// Jobs are persisted, and a job interrupted by a restart resumes with the
+// variants not yet classified.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when a job does not exist.
+	ErrNotFound = errors.New("job not found")
+	// ErrInvalidJob is returned when a job has nothing to classify.
+	ErrInvalidJob = errors.New("invalid job")
+)
+
+// Status is the state of a job.
+type Status string
+
+// Job states
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed" // The job could not run; per-variant errors do not fail a job
+)
+
+// Options apply to every variant of a job.
+type Options struct {
+	ClinicalContext   string `json:"clinical_context,omitempty"`
+	OrderingSpecialty string `json:"ordering_specialty,omitempty"`
+	Condition         string `json:"condition,omitempty"`
+}
+
+// Item is one variant of a job and, once classified, its outcome.
+type Item struct {
+	Index          int             `json:"index"`
+	Input          string          `json:"input"`                    // The variant as submitted
+	Notation       string          `json:"notation,omitempty"`       // HGVS notation classified; empty when the input could not be read
+	Classification string          `json:"classification,omitempty"` // Final call, for summaries
+	Result         json.RawMessage `json:"result,omitempty"`         // classify_variant result
+	Error          string          `json:"error,omitempty"`
+}
+
+// Job is a background classification request.
+type Job struct {
+	ID                   int64          `json:"id"`
+	Status               Status         `json:"status"`
+	Options              Options        `json:"options"`
+	Total                int            `json:"total"`
+	Processed            int            `json:"processed"` // Variants classified or failed so far
+	Failed               int            `json:"failed"`
+	ClassificationCounts map[string]int `json:"classification_counts"`
+	Error                string         `json:"error,omitempty"` // Why a failed job could not run
+	CreatedBy            string         `json:"created_by,omitempty"`
+	CreatedAt            time.Time      `json:"created_at"`
+	StartedAt            *time.Time     `json:"started_at,omitempty"`
+	FinishedAt           *time.Time     `json:"finished_at,omitempty"`
+}
+
+// Done reports whether the job has finished.
+func (j *Job) Done() bool {
+	return j.Status == StatusCompleted || j.Status == StatusFailed
+}
+
+// Validate normalizes the items and checks that there is something to classify.
+// Items are numbered in order, and items without a notation fail with an error.
+func Validate(items []*Item) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: no variants to classify", ErrInvalidJob)
+	}
+	for i, item := range items {
+		item.Index = i
+		item.Input = strings.TrimSpace(item.Input)
+		item.Notation = strings.TrimSpace(item.Notation)
+		if item.Input == "" {
+			item.Input = item.Notation
+		}
+		if item.Notation == "" && item.Error == "" {
+			item.Error = "no variant notation"
+		}
+	}
+	return nil
+}
+
+// Store defines the interface for job storage.
+type Store interface {
+	// Create queues a job with its items, setting the job's ID, status,
+	// total and creation time. Items with an error are stored as failed.
+	Create(ctx context.Context, job *Job, items []*Item) error
+
+	// Get returns a job with its progress counts.
+	Get(ctx context.Context, id int64) (*Job, error)
+
+	// Claim marks the oldest queued job running and returns it, or nil when
+	// no job is queued.
+	Claim(ctx context.Context) (*Job, error)
+
+	// Pending returns up to limit items of a job not yet classified, in order.
+	Pending(ctx context.Context, id int64, limit int) ([]*Item, error)
+
+	// SaveResults stores the outcome of classified items.
+	SaveResults(ctx context.Context, id int64, items []*Item) error
+
+	// Results returns a page of a job's classified items, in order.
+	Results(ctx context.Context, id int64, offset, limit int) ([]*Item, error)
+
+	// Finish marks a job completed or failed.
+	Finish(ctx context.Context, id int64, status Status, errMsg string) error
+
+	// Release returns a running job to the queue; its classified items are
+	// kept, so it resumes where it stopped.
+	Release(ctx context.Context, id int64) error
+
+	// Requeue returns running jobs to the queue, e.g. after a restart, and
+	// reports how many there were.
+	Requeue(ctx context.Context) (int, error)
+
+	// Close closes the store.
+	Close() error
+}
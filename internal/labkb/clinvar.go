@@ -0,0 +1,359 @@
+package labkb
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// Submission formats
+const (
+	FormatJSON = "json" // ClinVar Submission API
+	FormatXML  = "xml"
+	FormatTSV  = "tsv"
+)
+
+// Submission defaults
+const (
+	DefaultAssertionMethod         = "ACMG Guidelines, 2015"
+	DefaultAssertionMethodCitation = "PMID:25741868"
+	DefaultCollectionMethod        = "clinical testing"
+	DefaultAlleleOrigin            = "germline"
+)
+
+// notProvided is ClinVar's placeholder for an unknown condition
+const notProvided = "not provided"
+
+// SubmissionOptions describes the submitter and how the variants were observed.
+// Empty fields take the defaults.
+type SubmissionOptions struct {
+	OrgID                   string // ClinVar organization ID of the submitter
+	AssertionMethod         string // Name of the lab's classification method
+	AssertionMethodCitation string // PubMed ID or URL describing the method
+	CollectionMethod        string
+	AlleleOrigin            string
+	Date                    time.Time // Submission date; defaults to today
+}
+
+// withDefaults fills in the empty options
+func (o SubmissionOptions) withDefaults() SubmissionOptions {
+	if o.AssertionMethod == "" {
+		o.AssertionMethod = DefaultAssertionMethod
+	}
+	if o.AssertionMethodCitation == "" {
+		o.AssertionMethodCitation = DefaultAssertionMethodCitation
+	}
+	if o.CollectionMethod == "" {
+		o.CollectionMethod = DefaultCollectionMethod
+	}
+	if o.AlleleOrigin == "" {
+		o.AlleleOrigin = DefaultAlleleOrigin
+	}
+	if o.Date.IsZero() {
+		o.Date = time.Now().UTC()
+	}
+	return o
+}
+
+// WriteSubmission writes assertions in a ClinVar submission format.
+func WriteSubmission(w io.Writer, format string, assertions []*Assertion, options SubmissionOptions) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case FormatJSON:
+		return WriteClinVarJSON(w, assertions, options)
+	case FormatXML:
+		return WriteClinVarXML(w, assertions, options)
+	case FormatTSV:
+		return WriteClinVarTSV(w, assertions, options)
+	}
+	return fmt.Errorf("unsupported submission format %q: use %s, %s or %s", format, FormatJSON, FormatXML, FormatTSV)
+}
+
+// clinVarTSVColumns are the columns of the ClinVar variant submission
+// spreadsheet that lab assertions fill
+var clinVarTSVColumns = []string{
+	"Local ID", "Gene symbol", "Reference sequence", "HGVS",
+	"Condition ID type", "Condition ID value", "Preferred condition name",
+	"Germline classification", "Date last evaluated", "Assertion method", "Assertion method citation",
+	"Mode of inheritance", "PMID", "Comment on classification",
+	"Collection method", "Allele origin", "Affected status", "Number of individuals",
+}
+
+// WriteClinVarTSV writes assertions as rows of the ClinVar variant submission
+// spreadsheet, ready to paste into the Variant sheet.
+func WriteClinVarTSV(w io.Writer, assertions []*Assertion, options SubmissionOptions) error {
+	options = options.withDefaults()
+	writer := csv.NewWriter(w)
+	writer.Comma = '\t'
+	if err := writer.Write(clinVarTSVColumns); err != nil {
+		return fmt.Errorf("failed to write submission header: %w", err)
+	}
+
+	for _, a := range assertions {
+		reference, change := splitHGVS(a.NormalizedHGVS)
+		conditionType, conditionValue, conditionName := submissionCondition(a)
+		affected, individuals := submissionObservation(a)
+		row := []string{
+			localID(a), a.GeneSymbol, reference, change,
+			conditionType, conditionValue, conditionName,
+			classificationLabel(a.Classification), a.EvaluatedAt.Format("2006-01-02"), options.AssertionMethod, options.AssertionMethodCitation,
+			a.ModeOfInheritance, strings.Join(a.Citations, ","), submissionComment(a),
+			options.CollectionMethod, options.AlleleOrigin, affected, individuals,
+		}
+		for i, field := range row {
+			row[i] = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(field)
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write submission row: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// clinVarSubmissionSet is the root of the ClinVar submission XML
+type clinVarSubmissionSet struct {
+	XMLName     xml.Name            `xml:"ClinvarSubmissionSet"`
+	Date        string              `xml:"Date,attr"`
+	OrgID       string              `xml:"SubmitterOfRecord>OrgID,omitempty"`
+	Submissions []clinVarSubmission `xml:"ClinvarSubmission"`
+}
+
+type clinVarSubmission struct {
+	RecordStatus         string              `xml:"recordStatus,attr"`
+	ReleaseStatus        string              `xml:"releaseStatus,attr"`
+	LocalID              string              `xml:"LocalID"`
+	AssertionType        string              `xml:"AssertionType"`
+	ClinicalSignificance clinVarSignificance `xml:"ClinicalSignificance"`
+	AssertionMethod      clinVarAttributeSet `xml:"AttributeSet"`
+	ObservedIn           clinVarObservedIn   `xml:"ObservedIn"`
+	MeasureSet           clinVarMeasureSet   `xml:"MeasureSet"`
+	TraitSet             clinVarTraitSet     `xml:"TraitSet"`
+}
+
+type clinVarSignificance struct {
+	ReviewStatus      string            `xml:"ReviewStatus"`
+	Description       string            `xml:"Description"`
+	Citations         []clinVarCitation `xml:"Citation"`
+	Comment           string            `xml:"Comment,omitempty"`
+	DateLastEvaluated string            `xml:"DateLastEvaluated"`
+	ModeOfInheritance string            `xml:"ModeOfInheritance,omitempty"`
+}
+
+type clinVarCitation struct {
+	ID  *clinVarCitationID `xml:"ID,omitempty"`
+	URL string             `xml:"URL,omitempty"`
+}
+
+type clinVarCitationID struct {
+	Source string `xml:"Source,attr,omitempty"`
+	Value  string `xml:",chardata"`
+}
+
+type clinVarAttributeSet struct {
+	Attribute clinVarAttribute `xml:"Attribute"`
+	Citation  clinVarCitation  `xml:"Citation"`
+}
+
+type clinVarObservedIn struct {
+	Origin              string `xml:"Sample>Origin"`
+	Species             string `xml:"Sample>Species"`
+	AffectedStatus      string `xml:"Sample>AffectedStatus"`
+	NumberOfIndividuals int    `xml:"Sample>NumberOfIndividuals,omitempty"`
+	MethodType          string `xml:"Method>MethodType"`
+}
+
+type clinVarMeasureSet struct {
+	Type    string         `xml:"Type,attr"`
+	Measure clinVarMeasure `xml:"Measure"`
+}
+
+type clinVarMeasure struct {
+	Type         string                      `xml:"Type,attr"`
+	HGVS         clinVarAttribute            `xml:"AttributeSet>Attribute"`
+	Relationship *clinVarMeasureRelationship `xml:"MeasureRelationship,omitempty"`
+}
+
+type clinVarAttribute struct {
+	Type  string `xml:"Type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type clinVarMeasureRelationship struct {
+	Type   string           `xml:"Type,attr"`
+	Symbol clinVarAttribute `xml:"Symbol>ElementValue"`
+}
+
+type clinVarTraitSet struct {
+	Type  string       `xml:"Type,attr"`
+	Trait clinVarTrait `xml:"Trait"`
+}
+
+type clinVarTrait struct {
+	Type string            `xml:"Type,attr"`
+	Name *clinVarAttribute `xml:"Name>ElementValue,omitempty"`
+	XRef *clinVarXRef      `xml:"XRef,omitempty"`
+}
+
+type clinVarXRef struct {
+	DB string `xml:"db,attr"`
+	ID string `xml:"id,attr"`
+}
+
+// WriteClinVarXML writes assertions as a ClinVar submission set, one novel
+// variation to disease submission per assertion.
+func WriteClinVarXML(w io.Writer, assertions []*Assertion, options SubmissionOptions) error {
+	options = options.withDefaults()
+	set := clinVarSubmissionSet{
+		Date:        options.Date.Format("2006-01-02"),
+		OrgID:       options.OrgID,
+		Submissions: make([]clinVarSubmission, 0, len(assertions)),
+	}
+	for _, a := range assertions {
+		set.Submissions = append(set.Submissions, xmlSubmission(a, options))
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write submission: %w", err)
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(set); err != nil {
+		return fmt.Errorf("failed to encode submission: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// xmlSubmission converts an assertion to a ClinVar submission
+func xmlSubmission(a *Assertion, options SubmissionOptions) clinVarSubmission {
+	affected, individuals := submissionObservation(a)
+	submission := clinVarSubmission{
+		RecordStatus:  "novel",
+		ReleaseStatus: "public",
+		LocalID:       localID(a),
+		AssertionType: "variation to disease",
+		ClinicalSignificance: clinVarSignificance{
+			ReviewStatus:      "criteria provided, single submitter",
+			Description:       classificationLabel(a.Classification),
+			Comment:           submissionComment(a),
+			DateLastEvaluated: a.EvaluatedAt.Format("2006-01-02"),
+			ModeOfInheritance: a.ModeOfInheritance,
+		},
+		AssertionMethod: clinVarAttributeSet{
+			Attribute: clinVarAttribute{Type: "AssertionMethod", Value: options.AssertionMethod},
+			Citation:  methodCitation(options.AssertionMethodCitation),
+		},
+		ObservedIn: clinVarObservedIn{
+			Origin:         options.AlleleOrigin,
+			Species:        "human",
+			AffectedStatus: affected,
+			MethodType:     options.CollectionMethod,
+		},
+		MeasureSet: clinVarMeasureSet{
+			Type: "Variant",
+			Measure: clinVarMeasure{
+				Type: "Variation",
+				HGVS: clinVarAttribute{Type: "HGVS", Value: a.NormalizedHGVS},
+			},
+		},
+		TraitSet: clinVarTraitSet{Type: "Disease", Trait: clinVarTrait{Type: "Disease"}},
+	}
+	if individuals != "" {
+		submission.ObservedIn.NumberOfIndividuals = a.AffectedProbands
+	}
+	for _, pmid := range a.Citations {
+		submission.ClinicalSignificance.Citations = append(submission.ClinicalSignificance.Citations,
+			clinVarCitation{ID: &clinVarCitationID{Source: "PubMed", Value: pmid}})
+	}
+	if a.GeneSymbol != "" {
+		submission.MeasureSet.Measure.Relationship = &clinVarMeasureRelationship{
+			Type:   "variant in gene",
+			Symbol: clinVarAttribute{Type: "Preferred", Value: a.GeneSymbol},
+		}
+	}
+
+	source, value, name := submissionCondition(a)
+	if source != "" {
+		submission.TraitSet.Trait.XRef = &clinVarXRef{DB: source, ID: value}
+	} else {
+		submission.TraitSet.Trait.Name = &clinVarAttribute{Type: "Preferred", Value: name}
+	}
+	return submission
+}
+
+// methodCitation cites the assertion method by PubMed ID or URL
+func methodCitation(citation string) clinVarCitation {
+	if pmid, ok := ParsePMID(citation); ok {
+		return clinVarCitation{ID: &clinVarCitationID{Source: "PubMed", Value: pmid}}
+	}
+	return clinVarCitation{URL: citation}
+}
+
+// localID identifies an assertion to ClinVar across updates: its ID, or the
+// variant for an assertion not saved in the knowledge base
+func localID(a *Assertion) string {
+	if a.ID == 0 {
+		return a.NormalizedHGVS
+	}
+	return strconv.FormatInt(a.ID, 10)
+}
+
+// classificationLabel returns ClinVar's term for a classification
+func classificationLabel(classification string) string {
+	if label := domain.Classification(classification).Label(); label != "" {
+		return label
+	}
+	return classification
+}
+
+// splitHGVS splits a notation into its reference sequence and change
+func splitHGVS(notation string) (reference, change string) {
+	if reference, change, ok := strings.Cut(notation, ":"); ok {
+		return reference, change
+	}
+	return "", notation
+}
+
+// submissionCondition returns the condition ID type and value, or the
+// preferred name when there is no ID
+func submissionCondition(a *Assertion) (source, value, name string) {
+	if a.ConditionID != "" {
+		if source, value, ok := ParseConditionID(a.ConditionID); ok {
+			return source, value, a.Condition
+		}
+	}
+	if a.Condition != "" {
+		return "", "", a.Condition
+	}
+	return "", "", notProvided
+}
+
+// submissionObservation returns the affected status and number of
+// individuals the lab observed the variant in
+func submissionObservation(a *Assertion) (affected, individuals string) {
+	if a.AffectedProbands > 0 {
+		return "yes", strconv.Itoa(a.AffectedProbands)
+	}
+	return "unknown", ""
+}
+
+// submissionComment summarizes the internal evidence and criteria met
+func submissionComment(a *Assertion) string {
+	var parts []string
+	if a.Evidence != "" {
+		parts = append(parts, strings.TrimRight(a.Evidence, ". "))
+	}
+	if len(a.CriteriaMet) > 0 {
+		parts = append(parts, "ACMG/AMP criteria met: "+strings.Join(a.CriteriaMet, ", "))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, ". ") + "."
+}
@@ -0,0 +1,207 @@
+package labkb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// Gap is a field ClinVar requires that an assertion does not fill.
+type Gap struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// SubmissionGaps lists what an assertion is missing before it can be
+// submitted to ClinVar: a classification ClinVar accepts, a transcript or
+// genomic HGVS, a condition, the mode of inheritance and at least one
+// citation.
+func (a *Assertion) SubmissionGaps() []Gap {
+	var gaps []Gap
+	if _, err := domain.ParseClassification(a.Classification); err != nil {
+		gaps = append(gaps, Gap{"classification", fmt.Sprintf("%q is not a germline classification", a.Classification)})
+	}
+	if reference, _ := splitHGVS(a.NormalizedHGVS); reference == "" {
+		gaps = append(gaps, Gap{"normalized_hgvs", "HGVS must name its reference sequence, e.g. NM_007294.4:c.5095C>T"})
+	}
+	if source, _, name := submissionCondition(a); source == "" && name == notProvided {
+		gaps = append(gaps, Gap{"condition", "a condition ID or name is required"})
+	}
+	if a.ModeOfInheritance == "" {
+		gaps = append(gaps, Gap{"mode_of_inheritance", "mode of inheritance is required"})
+	}
+	if len(a.Citations) == 0 {
+		gaps = append(gaps, Gap{"citations", "at least one PubMed citation is required"})
+	}
+	return gaps
+}
+
+// CriterionLabel names a met criterion the way ClinGen does, with the
+// strength appended when it differs from the criterion's default, e.g.
+// PM2_Supporting or PVS1_Strong.
+func CriterionLabel(code, strength string) string {
+	applied, err := domain.ParseRuleStrength(strength)
+	if err != nil || applied == defaultStrength(code) {
+		return code
+	}
+	words := strings.Fields(applied.Label())
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return code + "_" + strings.Join(words, "")
+}
+
+// defaultStrength returns the strength a criterion has in the 2015
+// guidelines, from its prefix
+func defaultStrength(code string) domain.RuleStrength {
+	switch {
+	case strings.HasPrefix(code, "PVS"), strings.HasPrefix(code, "BA"):
+		return domain.VERY_STRONG
+	case strings.HasPrefix(code, "PS"), strings.HasPrefix(code, "BS"):
+		return domain.STRONG
+	case strings.HasPrefix(code, "PM"):
+		return domain.MODERATE
+	}
+	return domain.SUPPORTING
+}
+
+// clinVarAPIRequest is the body of a ClinVar Submission API request
+type clinVarAPIRequest struct {
+	Actions []clinVarAPIAction `json:"actions"`
+}
+
+type clinVarAPIAction struct {
+	Type     string `json:"type"`
+	TargetDB string `json:"targetDb"`
+	Data     struct {
+		Content clinVarAPIContent `json:"content"`
+	} `json:"data"`
+}
+
+type clinVarAPIContent struct {
+	AssertionCriteria clinVarAPICitation `json:"assertionCriteria"`
+	ClinvarSubmission []clinVarAPIRecord `json:"clinvarSubmission"`
+}
+
+type clinVarAPIRecord struct {
+	RecordStatus           string                   `json:"recordStatus"`
+	LocalID                string                   `json:"localID"`
+	LocalKey               string                   `json:"localKey"`
+	GermlineClassification clinVarAPIClassification `json:"germlineClassification"`
+	ConditionSet           clinVarAPIConditionSet   `json:"conditionSet"`
+	ObservedIn             []clinVarAPIObservation  `json:"observedIn"`
+	VariantSet             clinVarAPIVariantSet     `json:"variantSet"`
+}
+
+type clinVarAPIClassification struct {
+	Description       string               `json:"germlineClassificationDescription"`
+	Citation          []clinVarAPICitation `json:"citation,omitempty"`
+	Comment           string               `json:"comment,omitempty"`
+	DateLastEvaluated string               `json:"dateLastEvaluated"`
+	ModeOfInheritance string               `json:"modeOfInheritance,omitempty"`
+}
+
+type clinVarAPICitation struct {
+	DB  string `json:"db,omitempty"`
+	ID  string `json:"id,omitempty"`
+	URL string `json:"url,omitempty"`
+}
+
+type clinVarAPIConditionSet struct {
+	Condition []clinVarAPICondition `json:"condition"`
+}
+
+type clinVarAPICondition struct {
+	DB   string `json:"db,omitempty"`
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type clinVarAPIObservation struct {
+	AffectedStatus      string `json:"affectedStatus"`
+	AlleleOrigin        string `json:"alleleOrigin"`
+	CollectionMethod    string `json:"collectionMethod"`
+	NumberOfIndividuals int    `json:"numberOfIndividuals,omitempty"`
+}
+
+type clinVarAPIVariantSet struct {
+	Variant []clinVarAPIVariant `json:"variant"`
+}
+
+type clinVarAPIVariant struct {
+	HGVS string           `json:"hgvs"`
+	Gene []clinVarAPIGene `json:"gene,omitempty"`
+}
+
+type clinVarAPIGene struct {
+	Symbol string `json:"symbol"`
+}
+
+// WriteClinVarJSON writes assertions as a ClinVar Submission API request
+// body, one novel germline submission per assertion, ready to POST to the
+// submissions endpoint.
+func WriteClinVarJSON(w io.Writer, assertions []*Assertion, options SubmissionOptions) error {
+	options = options.withDefaults()
+	action := clinVarAPIAction{Type: "AddData", TargetDB: "clinvar"}
+	content := &action.Data.Content
+	content.AssertionCriteria = apiMethodCitation(options.AssertionMethodCitation)
+	content.ClinvarSubmission = make([]clinVarAPIRecord, 0, len(assertions))
+	for _, a := range assertions {
+		content.ClinvarSubmission = append(content.ClinvarSubmission, apiRecord(a, options))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(clinVarAPIRequest{Actions: []clinVarAPIAction{action}}); err != nil {
+		return fmt.Errorf("failed to encode submission: %w", err)
+	}
+	return nil
+}
+
+// apiRecord converts an assertion to a Submission API record
+func apiRecord(a *Assertion, options SubmissionOptions) clinVarAPIRecord {
+	affected, _ := submissionObservation(a)
+	record := clinVarAPIRecord{
+		RecordStatus: "novel",
+		LocalID:      localID(a),
+		LocalKey:     a.NormalizedHGVS,
+		GermlineClassification: clinVarAPIClassification{
+			Description:       classificationLabel(a.Classification),
+			Comment:           submissionComment(a),
+			DateLastEvaluated: a.EvaluatedAt.Format("2006-01-02"),
+			ModeOfInheritance: a.ModeOfInheritance,
+		},
+		ObservedIn: []clinVarAPIObservation{{
+			AffectedStatus:      affected,
+			AlleleOrigin:        options.AlleleOrigin,
+			CollectionMethod:    options.CollectionMethod,
+			NumberOfIndividuals: a.AffectedProbands,
+		}},
+		VariantSet: clinVarAPIVariantSet{Variant: []clinVarAPIVariant{{HGVS: a.NormalizedHGVS}}},
+	}
+	for _, pmid := range a.Citations {
+		record.GermlineClassification.Citation = append(record.GermlineClassification.Citation, clinVarAPICitation{DB: "PubMed", ID: pmid})
+	}
+	if a.GeneSymbol != "" {
+		record.VariantSet.Variant[0].Gene = []clinVarAPIGene{{Symbol: a.GeneSymbol}}
+	}
+
+	source, value, name := submissionCondition(a)
+	if source != "" {
+		record.ConditionSet.Condition = []clinVarAPICondition{{DB: source, ID: value}}
+	} else {
+		record.ConditionSet.Condition = []clinVarAPICondition{{Name: name}}
+	}
+	return record
+}
+
+// apiMethodCitation cites the assertion method by PubMed ID or URL
+func apiMethodCitation(citation string) clinVarAPICitation {
+	if pmid, ok := ParsePMID(citation); ok {
+		return clinVarAPICitation{DB: "PubMed", ID: pmid}
+	}
+	return clinVarAPICitation{URL: citation}
+}
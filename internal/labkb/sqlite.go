@@ -0,0 +1,296 @@
+package labkb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite lab assertion store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables and indexes.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS lab_assertions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		normalized_hgvs TEXT NOT NULL UNIQUE,
+		gene_symbol TEXT DEFAULT '',
+		hgvs_protein TEXT DEFAULT '',
+		protein_change TEXT DEFAULT '',
+		classification TEXT NOT NULL,
+		condition TEXT DEFAULT '',
+		condition_id TEXT DEFAULT '',
+		mode_of_inheritance TEXT DEFAULT '',
+		criteria_met TEXT NOT NULL DEFAULT '[]',
+		affected_probands INTEGER NOT NULL DEFAULT 0,
+		evidence TEXT DEFAULT '',
+		citations TEXT NOT NULL DEFAULT '[]',
+		evaluated_at DATETIME NOT NULL,
+		evaluated_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_lab_assertions_gene ON lab_assertions(gene_symbol);
+	CREATE INDEX IF NOT EXISTS idx_lab_assertions_protein ON lab_assertions(gene_symbol, protein_change);
+
+	CREATE TABLE IF NOT EXISTS lab_revision (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		revision INTEGER NOT NULL
+	);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+const assertionColumns = `id, normalized_hgvs, gene_symbol, hgvs_protein, protein_change, classification, condition, condition_id,
+	mode_of_inheritance, criteria_met, affected_probands, evidence, citations, evaluated_at, evaluated_by, created_at, updated_at`
+
+// scanner is an interface for sql.Row and sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAssertion scans a row into an Assertion struct.
+func scanAssertion(s scanner) (*Assertion, error) {
+	a := &Assertion{}
+	var criteria, citations string
+	err := s.Scan(&a.ID, &a.NormalizedHGVS, &a.GeneSymbol, &a.HGVSProtein, &a.ProteinChange, &a.Classification, &a.Condition, &a.ConditionID,
+		&a.ModeOfInheritance, &criteria, &a.AffectedProbands, &a.Evidence, &citations, &a.EvaluatedAt, &a.EvaluatedBy, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(criteria), &a.CriteriaMet); err != nil {
+		return nil, fmt.Errorf("failed to decode criteria met: %w", err)
+	}
+	if err := json.Unmarshal([]byte(citations), &a.Citations); err != nil {
+		return nil, fmt.Errorf("failed to decode citations: %w", err)
+	}
+	return a, nil
+}
+
+// Save stores an assertion, setting its ID and timestamps. Saving a variant
+// that already has an assertion replaces it and keeps its ID.
+func (s *SQLiteStore) Save(ctx context.Context, assertion *Assertion) error {
+	if err := assertion.Validate(); err != nil {
+		return err
+	}
+	criteria, err := json.Marshal(assertion.CriteriaMet)
+	if err != nil {
+		return fmt.Errorf("failed to encode criteria met: %w", err)
+	}
+	citations, err := json.Marshal(assertion.Citations)
+	if err != nil {
+		return fmt.Errorf("failed to encode citations: %w", err)
+	}
+
+	now := time.Now().UTC()
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO lab_assertions (normalized_hgvs, gene_symbol, hgvs_protein, protein_change, classification, condition, condition_id,
+			mode_of_inheritance, criteria_met, affected_probands, evidence, citations, evaluated_at, evaluated_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(normalized_hgvs) DO UPDATE SET
+			gene_symbol = excluded.gene_symbol,
+			hgvs_protein = excluded.hgvs_protein,
+			protein_change = excluded.protein_change,
+			classification = excluded.classification,
+			condition = excluded.condition,
+			condition_id = excluded.condition_id,
+			mode_of_inheritance = excluded.mode_of_inheritance,
+			criteria_met = excluded.criteria_met,
+			affected_probands = excluded.affected_probands,
+			evidence = excluded.evidence,
+			citations = excluded.citations,
+			evaluated_at = excluded.evaluated_at,
+			evaluated_by = excluded.evaluated_by,
+			updated_at = excluded.updated_at
+	`, assertion.NormalizedHGVS, assertion.GeneSymbol, assertion.HGVSProtein, assertion.ProteinChange, assertion.Classification,
+		assertion.Condition, assertion.ConditionID, assertion.ModeOfInheritance, string(criteria), assertion.AffectedProbands,
+		assertion.Evidence, string(citations), assertion.EvaluatedAt, assertion.EvaluatedBy, now, now)
+	if err != nil {
+		return fmt.Errorf("failed to save lab assertion: %w", err)
+	}
+	if err := s.bumpRevision(ctx); err != nil {
+		return err
+	}
+
+	saved, err := s.Lookup(ctx, assertion.NormalizedHGVS)
+	if err != nil {
+		return err
+	}
+	if saved == nil {
+		return fmt.Errorf("failed to read back lab assertion for %s", assertion.NormalizedHGVS)
+	}
+	assertion.ID, assertion.CreatedAt, assertion.UpdatedAt = saved.ID, saved.CreatedAt, saved.UpdatedAt
+	return nil
+}
+
+// Get returns an assertion by ID.
+func (s *SQLiteStore) Get(ctx context.Context, id int64) (*Assertion, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+assertionColumns+" FROM lab_assertions WHERE id = ?", id)
+	assertion, err := scanAssertion(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lab assertion: %w", err)
+	}
+	return assertion, nil
+}
+
+// Lookup returns the assertion for a variant, or nil.
+func (s *SQLiteStore) Lookup(ctx context.Context, normalizedHGVS string) (*Assertion, error) {
+	row := s.db.QueryRowContext(ctx,
+		"SELECT "+assertionColumns+" FROM lab_assertions WHERE normalized_hgvs = ?", strings.TrimSpace(normalizedHGVS))
+	assertion, err := scanAssertion(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up lab assertion: %w", err)
+	}
+	return assertion, nil
+}
+
+// FindProteinChange returns the assertions for a gene's variants causing a
+// missense change, ordered by variant.
+func (s *SQLiteStore) FindProteinChange(ctx context.Context, geneSymbol, proteinChange string) ([]*Assertion, error) {
+	gene := strings.ToUpper(strings.TrimSpace(geneSymbol))
+	change := strings.TrimSpace(proteinChange)
+	if gene == "" || change == "" {
+		return []*Assertion{}, nil
+	}
+	return s.query(ctx, " WHERE gene_symbol = ? AND protein_change = ?", gene, change)
+}
+
+// List returns assertions ordered by variant.
+func (s *SQLiteStore) List(ctx context.Context, filter Filter) ([]*Assertion, error) {
+	var conditions []string
+	var args []interface{}
+	if gene := strings.ToUpper(strings.TrimSpace(filter.GeneSymbol)); gene != "" {
+		conditions = append(conditions, "gene_symbol = ?")
+		args = append(args, gene)
+	}
+	if filter.Classification != "" {
+		conditions = append(conditions, "classification = ?")
+		args = append(args, filter.Classification)
+	}
+	if !filter.EvaluatedSince.IsZero() {
+		conditions = append(conditions, "evaluated_at >= ?")
+		args = append(args, filter.EvaluatedSince.UTC())
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return s.query(ctx, where, args...)
+}
+
+// query returns the assertions matching a WHERE clause, ordered by variant
+func (s *SQLiteStore) query(ctx context.Context, where string, args ...interface{}) ([]*Assertion, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+assertionColumns+" FROM lab_assertions"+where+" ORDER BY normalized_hgvs ASC", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	assertions := make([]*Assertion, 0)
+	for rows.Next() {
+		assertion, err := scanAssertion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+		assertions = append(assertions, assertion)
+	}
+	return assertions, rows.Err()
+}
+
+// Remove deletes an assertion.
+func (s *SQLiteStore) Remove(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM lab_assertions WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to remove lab assertion: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return s.bumpRevision(ctx)
+}
+
+// bumpRevision advances the revision after a write. It runs after the write,
+// so a reader never pairs the new revision with the old assertions.
+func (s *SQLiteStore) bumpRevision(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO lab_revision (id, revision) VALUES (1, 1)
+		ON CONFLICT(id) DO UPDATE SET revision = revision + 1
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to update lab knowledge base revision: %w", err)
+	}
+	return nil
+}
+
+// Revision returns the revision of the knowledge base.
+func (s *SQLiteStore) Revision(ctx context.Context) (int64, error) {
+	var revision int64
+	err := s.db.QueryRowContext(ctx, `SELECT revision FROM lab_revision WHERE id = 1`).Scan(&revision)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get lab knowledge base revision: %w", err)
+	}
+	return revision, nil
+}
+
+// Close closes the database connection.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
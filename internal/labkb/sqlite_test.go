@@ -0,0 +1,266 @@
+package labkb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "lab_knowledge.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func saveAssertion(t *testing.T, store *SQLiteStore, hgvs, protein, classification string, probands int) *Assertion {
+	t.Helper()
+	assertion := &Assertion{
+		NormalizedHGVS:   hgvs,
+		GeneSymbol:       "brca1",
+		HGVSProtein:      protein,
+		Classification:   classification,
+		Condition:        "Hereditary breast ovarian cancer syndrome",
+		ConditionID:      "mondo:0011450",
+		CriteriaMet:      []string{"PS4_Moderate", " PM2_Supporting "},
+		AffectedProbands: probands,
+		Evidence:         "Observed in unrelated HBOC probands.",
+		Citations:        []string{"PMID: 25741868"},
+		EvaluatedAt:      time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		EvaluatedBy:      "curator1",
+	}
+	require.NoError(t, store.Save(context.Background(), assertion))
+	return assertion
+}
+
+func TestSQLiteStore_SaveAndLookup(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	saved := saveAssertion(t, store, "NM_007294.4:c.5095C>T", "p.Arg1699Trp", "likely pathogenic", 4)
+
+	assert.NotZero(t, saved.ID)
+	assert.Equal(t, "BRCA1", saved.GeneSymbol)
+	assert.Equal(t, "R1699W", saved.ProteinChange)
+	assert.Equal(t, "LIKELY_PATHOGENIC", saved.Classification)
+	assert.Equal(t, "MONDO:0011450", saved.ConditionID)
+	assert.Equal(t, []string{"PS4_Moderate", "PM2_Supporting"}, saved.CriteriaMet)
+	assert.Equal(t, []string{"25741868"}, saved.Citations)
+	assert.True(t, saved.IsPathogenic())
+
+	found, err := store.Lookup(ctx, " NM_007294.4:c.5095C>T ")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, saved.ID, found.ID)
+	assert.Equal(t, 4, found.AffectedProbands)
+	assert.Equal(t, saved.CriteriaMet, found.CriteriaMet)
+
+	missing, err := store.Lookup(ctx, "NM_007294.4:c.1A>G")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestSQLiteStore_SaveReplacesVariantAssertion(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	first := saveAssertion(t, store, "NM_007294.4:c.5095C>T", "p.Arg1699Trp", "VUS", 1)
+	second := saveAssertion(t, store, "NM_007294.4:c.5095C>T", "p.Arg1699Trp", "LP", 4)
+
+	assert.Equal(t, first.ID, second.ID)
+	all, err := store.List(ctx, Filter{})
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "LIKELY_PATHOGENIC", all[0].Classification)
+	assert.Equal(t, 4, all[0].AffectedProbands)
+}
+
+func TestSQLiteStore_FindProteinChangeAndList(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	saveAssertion(t, store, "NM_007294.4:c.5095C>T", "p.Arg1699Trp", "pathogenic", 6)
+	saveAssertion(t, store, "NM_007294.4:c.5096G>A", "p.Arg1699Gln", "VUS", 0)
+
+	matches, err := store.FindProteinChange(ctx, "brca1", "R1699W")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "NM_007294.4:c.5095C>T", matches[0].NormalizedHGVS)
+
+	vus, err := store.List(ctx, Filter{GeneSymbol: "BRCA1", Classification: "VUS"})
+	require.NoError(t, err)
+	require.Len(t, vus, 1)
+	assert.Equal(t, "R1699Q", vus[0].ProteinChange)
+
+	recent, err := store.List(ctx, Filter{EvaluatedSince: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	assert.Empty(t, recent)
+}
+
+func TestSQLiteStore_Remove(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	saved := saveAssertion(t, store, "NM_007294.4:c.5095C>T", "p.Arg1699Trp", "pathogenic", 2)
+
+	require.NoError(t, store.Remove(ctx, saved.ID))
+	_, err := store.Get(ctx, saved.ID)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(store.Remove(ctx, saved.ID), ErrNotFound))
+}
+
+func TestSQLiteStore_Revision(t *testing.T) {
+	ctx := context.Background()
+	store := createTestStore(t)
+	revision, err := store.Revision(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, revision)
+
+	saved := saveAssertion(t, store, "NM_007294.4:c.5095C>T", "p.Arg1699Trp", "VUS", 1)
+	saveAssertion(t, store, "NM_007294.4:c.5095C>T", "p.Arg1699Trp", "LP", 4)
+	require.NoError(t, store.Remove(ctx, saved.ID))
+
+	revision, err = store.Revision(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), revision)
+}
+
+func TestAssertion_Validate(t *testing.T) {
+	for name, assertion := range map[string]Assertion{
+		"missing variant":        {Classification: "VUS", EvaluatedBy: "curator1"},
+		"bad classification":     {NormalizedHGVS: "NM_000059.4:c.1A>G", Classification: "damaging", EvaluatedBy: "curator1"},
+		"missing curator":        {NormalizedHGVS: "NM_000059.4:c.1A>G", Classification: "VUS"},
+		"negative probands":      {NormalizedHGVS: "NM_000059.4:c.1A>G", Classification: "VUS", EvaluatedBy: "curator1", AffectedProbands: -1},
+		"unknown condition":      {NormalizedHGVS: "NM_000059.4:c.1A>G", Classification: "VUS", EvaluatedBy: "curator1", ConditionID: "DOID:1612"},
+		"citation is not a PMID": {NormalizedHGVS: "NM_000059.4:c.1A>G", Classification: "VUS", EvaluatedBy: "curator1", Citations: []string{"doi:10.1038/gim.2015.30"}},
+	} {
+		err := assertion.Validate()
+		assert.True(t, errors.Is(err, ErrInvalidAssertion), name)
+	}
+}
+
+func TestWriteClinVarTSV(t *testing.T) {
+	store := createTestStore(t)
+	saved := saveAssertion(t, store, "NM_007294.4:c.5095C>T", "p.Arg1699Trp", "pathogenic", 3)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSubmission(&buf, "TSV", []*Assertion{saved}, SubmissionOptions{}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	header := strings.Split(lines[0], "\t")
+	row := strings.Split(lines[1], "\t")
+	require.Len(t, row, len(header))
+	field := func(column string) string {
+		for i, name := range header {
+			if name == column {
+				return row[i]
+			}
+		}
+		t.Fatalf("no column %q", column)
+		return ""
+	}
+	assert.Equal(t, "NM_007294.4", field("Reference sequence"))
+	assert.Equal(t, "c.5095C>T", field("HGVS"))
+	assert.Equal(t, "MONDO", field("Condition ID type"))
+	assert.Equal(t, "MONDO:0011450", field("Condition ID value"))
+	assert.Equal(t, "Pathogenic", field("Germline classification"))
+	assert.Equal(t, "2026-03-02", field("Date last evaluated"))
+	assert.Equal(t, DefaultAssertionMethod, field("Assertion method"))
+	assert.Equal(t, "25741868", field("PMID"))
+	assert.Equal(t, "Observed in unrelated HBOC probands. ACMG/AMP criteria met: PS4_Moderate, PM2_Supporting.", field("Comment on classification"))
+	assert.Equal(t, "yes", field("Affected status"))
+	assert.Equal(t, "3", field("Number of individuals"))
+
+	assert.Error(t, WriteSubmission(&buf, "vcf", nil, SubmissionOptions{}))
+}
+
+func TestWriteClinVarXML(t *testing.T) {
+	assertion := &Assertion{
+		ID:             7,
+		NormalizedHGVS: "NM_000059.4:c.7007G>A",
+		GeneSymbol:     "BRCA2",
+		Classification: "LIKELY_BENIGN",
+		Condition:      "Fanconi anemia",
+		EvaluatedAt:    time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		EvaluatedBy:    "curator1",
+	}
+
+	var buf bytes.Buffer
+	options := SubmissionOptions{OrgID: "500031", Date: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, WriteClinVarXML(&buf, []*Assertion{assertion}, options))
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var set clinVarSubmissionSet
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &set))
+	assert.Equal(t, "2026-02-01", set.Date)
+	assert.Equal(t, "500031", set.OrgID)
+	require.Len(t, set.Submissions, 1)
+
+	submission := set.Submissions[0]
+	assert.Equal(t, "7", submission.LocalID)
+	assert.Equal(t, "Likely benign", submission.ClinicalSignificance.Description)
+	assert.Equal(t, "2026-01-05", submission.ClinicalSignificance.DateLastEvaluated)
+	assert.Equal(t, "NM_000059.4:c.7007G>A", submission.MeasureSet.Measure.HGVS.Value)
+	require.NotNil(t, submission.MeasureSet.Measure.Relationship)
+	assert.Equal(t, "BRCA2", submission.MeasureSet.Measure.Relationship.Symbol.Value)
+	require.NotNil(t, submission.TraitSet.Trait.Name)
+	assert.Equal(t, "Fanconi anemia", submission.TraitSet.Trait.Name.Value)
+	assert.Equal(t, "unknown", submission.ObservedIn.AffectedStatus)
+	require.NotNil(t, submission.AssertionMethod.Citation.ID)
+	assert.Equal(t, "25741868", submission.AssertionMethod.Citation.ID.Value)
+}
+
+func TestWriteClinVarJSON(t *testing.T) {
+	store := createTestStore(t)
+	saved := saveAssertion(t, store, "NM_007294.4:c.5095C>T", "p.Arg1699Trp", "likely pathogenic", 3)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSubmission(&buf, "json", []*Assertion{saved}, SubmissionOptions{}))
+	var request clinVarAPIRequest
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &request))
+	require.Len(t, request.Actions, 1)
+	assert.Equal(t, "AddData", request.Actions[0].Type)
+
+	content := request.Actions[0].Data.Content
+	assert.Equal(t, clinVarAPICitation{DB: "PubMed", ID: "25741868"}, content.AssertionCriteria)
+	require.Len(t, content.ClinvarSubmission, 1)
+	record := content.ClinvarSubmission[0]
+	assert.Equal(t, "Likely pathogenic", record.GermlineClassification.Description)
+	assert.Equal(t, "2026-03-02", record.GermlineClassification.DateLastEvaluated)
+	assert.Equal(t, []clinVarAPICitation{{DB: "PubMed", ID: "25741868"}}, record.GermlineClassification.Citation)
+	assert.Equal(t, []clinVarAPICondition{{DB: "MONDO", ID: "MONDO:0011450"}}, record.ConditionSet.Condition)
+	assert.Equal(t, "BRCA1", record.VariantSet.Variant[0].Gene[0].Symbol)
+	assert.Equal(t, 3, record.ObservedIn[0].NumberOfIndividuals)
+}
+
+func TestAssertion_SubmissionGaps(t *testing.T) {
+	complete := Assertion{
+		NormalizedHGVS:    "NM_007294.4:c.5095C>T",
+		Classification:    "PATHOGENIC",
+		ConditionID:       "MONDO:0011450",
+		ModeOfInheritance: "Autosomal dominant inheritance",
+		Citations:         []string{"25741868"},
+	}
+	assert.Empty(t, complete.SubmissionGaps())
+
+	incomplete := Assertion{NormalizedHGVS: "c.5095C>T", Classification: "PATHOGENIC"}
+	var fields []string
+	for _, gap := range incomplete.SubmissionGaps() {
+		fields = append(fields, gap.Field)
+	}
+	assert.Equal(t, []string{"normalized_hgvs", "condition", "mode_of_inheritance", "citations"}, fields)
+}
+
+func TestCriterionLabel(t *testing.T) {
+	assert.Equal(t, "PS3", CriterionLabel("PS3", "STRONG"))
+	assert.Equal(t, "PM2_Supporting", CriterionLabel("PM2", "SUPPORTING"))
+	assert.Equal(t, "PS2_VeryStrong", CriterionLabel("PS2", "VERY_STRONG"))
+	assert.Equal(t, "BA1", CriterionLabel("BA1", "stand_alone"))
+}
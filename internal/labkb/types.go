@@ -0,0 +1,203 @@
+// Package labkb is the lab's own variant knowledge base: the assertions it
+// has signed out, with the condition, the criteria met, the number of
+// unrelated affected probands it has seen and its internal evidence. The rule
+// engine consults it for PS4 proband counts and for PS1 matches against the
+// lab's pathogenic calls, and assertions can be exported for ClinVar
+// submission.
+package labkb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+var (
+	// ErrNotFound is returned when an assertion does not exist.
+	ErrNotFound = errors.New("lab assertion not found")
+	// ErrInvalidAssertion is returned when an assertion is missing required fields.
+	ErrInvalidAssertion = errors.New("invalid lab assertion")
+)
+
+// ConditionSources are the databases a condition ID may come from, as
+// ClinVar names them
+var ConditionSources = []string{"OMIM", "MONDO", "Orphanet", "MedGen", "HP"}
+
+// conditionPrefixes maps condition ID prefixes to their source
+var conditionPrefixes = map[string]string{
+	"omim":     "OMIM",
+	"mim":      "OMIM",
+	"mondo":    "MONDO",
+	"orpha":    "Orphanet",
+	"orphanet": "Orphanet",
+	"medgen":   "MedGen",
+	"hp":       "HP",
+}
+
+// pmidPattern matches a PubMed ID, with or without a PMID prefix
+var pmidPattern = regexp.MustCompile(`^(?i:pmid:?\s*)?(\d+)$`)
+
+// Assertion is the lab's current classification of a variant.
+type Assertion struct {
+	ID                int64     `json:"id,omitempty"`
+	NormalizedHGVS    string    `json:"normalized_hgvs"`
+	GeneSymbol        string    `json:"gene_symbol,omitempty"`
+	HGVSProtein       string    `json:"hgvs_protein,omitempty"`
+	ProteinChange     string    `json:"protein_change,omitempty"` // One-letter missense change derived from HGVSProtein, e.g. R1699W
+	Classification    string    `json:"classification"`           // PATHOGENIC, LIKELY_PATHOGENIC, VUS, LIKELY_BENIGN or BENIGN
+	Condition         string    `json:"condition,omitempty"`      // Preferred condition name
+	ConditionID       string    `json:"condition_id,omitempty"`   // e.g. OMIM:113705 or MONDO:0011450
+	ModeOfInheritance string    `json:"mode_of_inheritance,omitempty"`
+	CriteriaMet       []string  `json:"criteria_met,omitempty"` // ACMG/AMP criteria, e.g. PS4_Moderate
+	AffectedProbands  int       `json:"affected_probands"`      // Unrelated affected probands the lab has observed with the variant
+	Evidence          string    `json:"evidence,omitempty"`     // Internal evidence summary
+	Citations         []string  `json:"citations,omitempty"`    // PubMed IDs
+	EvaluatedAt       time.Time `json:"evaluated_at"`           // Date last evaluated
+	EvaluatedBy       string    `json:"evaluated_by"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// Validate normalizes the assertion and checks required fields.
+func (a *Assertion) Validate() error {
+	a.NormalizedHGVS = strings.TrimSpace(a.NormalizedHGVS)
+	a.GeneSymbol = strings.ToUpper(strings.TrimSpace(a.GeneSymbol))
+	a.HGVSProtein = strings.TrimSpace(a.HGVSProtein)
+	a.Condition = strings.TrimSpace(a.Condition)
+	a.ModeOfInheritance = strings.TrimSpace(a.ModeOfInheritance)
+	a.Evidence = strings.TrimSpace(a.Evidence)
+	a.EvaluatedBy = strings.TrimSpace(a.EvaluatedBy)
+
+	if a.NormalizedHGVS == "" {
+		return fmt.Errorf("%w: variant is required", ErrInvalidAssertion)
+	}
+	classification, err := domain.ParseClassification(a.Classification)
+	if err != nil {
+		return fmt.Errorf("%w: classification must be one of %s, got %q", ErrInvalidAssertion, strings.Join(domain.ClassificationEnum(), ", "), a.Classification)
+	}
+	a.Classification = string(classification)
+	if a.EvaluatedBy == "" {
+		return fmt.Errorf("%w: evaluating curator is required", ErrInvalidAssertion)
+	}
+	if a.AffectedProbands < 0 {
+		return fmt.Errorf("%w: affected_probands must not be negative", ErrInvalidAssertion)
+	}
+
+	a.ProteinChange = ""
+	if change, ok := hgvs.ParseMissense(a.HGVSProtein); ok {
+		a.ProteinChange = change.String()
+	}
+	if id := strings.TrimSpace(a.ConditionID); id != "" {
+		source, value, ok := ParseConditionID(id)
+		if !ok {
+			return fmt.Errorf("%w: condition_id must be PREFIX:value with a prefix of %s, got %q", ErrInvalidAssertion, strings.Join(ConditionSources, ", "), id)
+		}
+		a.ConditionID = source + ":" + value
+		if strings.HasPrefix(value, source+":") {
+			a.ConditionID = value
+		}
+	}
+
+	criteria := make([]string, 0, len(a.CriteriaMet))
+	for _, c := range a.CriteriaMet {
+		if c = strings.TrimSpace(c); c != "" {
+			criteria = append(criteria, c)
+		}
+	}
+	a.CriteriaMet = criteria
+
+	citations := make([]string, 0, len(a.Citations))
+	for _, c := range a.Citations {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		pmid, ok := ParsePMID(c)
+		if !ok {
+			return fmt.Errorf("%w: citation %q is not a PubMed ID", ErrInvalidAssertion, c)
+		}
+		citations = append(citations, pmid)
+	}
+	a.Citations = citations
+
+	if a.EvaluatedAt.IsZero() {
+		a.EvaluatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// IsPathogenic reports whether the assertion is pathogenic or likely pathogenic.
+func (a *Assertion) IsPathogenic() bool {
+	return a.Classification == string(domain.PATHOGENIC) || a.Classification == string(domain.LIKELY_PATHOGENIC)
+}
+
+// ParseConditionID splits a condition ID such as OMIM:113705 into its
+// ClinVar source name and value. MONDO and HP IDs keep their prefix in the
+// value, as ClinVar expects.
+func ParseConditionID(id string) (source, value string, ok bool) {
+	prefix, value, found := strings.Cut(strings.TrimSpace(id), ":")
+	if !found {
+		return "", "", false
+	}
+	source, ok = conditionPrefixes[strings.ToLower(strings.TrimSpace(prefix))]
+	value = strings.TrimSpace(value)
+	if !ok || value == "" {
+		return "", "", false
+	}
+	if source == "MONDO" || source == "HP" {
+		value = source + ":" + value
+	}
+	return source, value, true
+}
+
+// ParsePMID returns the PubMed ID of a citation such as PMID:25741868.
+func ParsePMID(citation string) (string, bool) {
+	match := pmidPattern.FindStringSubmatch(strings.TrimSpace(citation))
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// Filter selects assertions to list or export.
+type Filter struct {
+	GeneSymbol     string    // Only this gene
+	Classification string    // Only this classification
+	EvaluatedSince time.Time // Only assertions evaluated at or after this time
+}
+
+// Store defines the interface for lab assertion storage.
+type Store interface {
+	// Save stores the lab's assertion for a variant, replacing any earlier
+	// assertion for the same variant.
+	Save(ctx context.Context, assertion *Assertion) error
+
+	// Get returns an assertion by ID.
+	Get(ctx context.Context, id int64) (*Assertion, error)
+
+	// Lookup returns the assertion for a variant, or nil if there is none.
+	Lookup(ctx context.Context, normalizedHGVS string) (*Assertion, error)
+
+	// FindProteinChange returns the assertions for a gene's variants that
+	// cause a missense change, e.g. R1699W.
+	FindProteinChange(ctx context.Context, geneSymbol, proteinChange string) ([]*Assertion, error)
+
+	// List returns assertions ordered by variant.
+	List(ctx context.Context, filter Filter) ([]*Assertion, error)
+
+	// Remove deletes an assertion.
+	Remove(ctx context.Context, id int64) error
+
+	// Revision returns a number that increases with every Save and Remove,
+	// so results drawn from the knowledge base can tell when they are stale.
+	Revision(ctx context.Context) (int64, error)
+
+	// Close closes the store.
+	Close() error
+}
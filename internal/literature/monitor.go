@@ -0,0 +1,288 @@
+package literature
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/feedback"
+	"github.com/acmg-amp-mcp-server/internal/followup"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+)
+
+// MonitorUser is recorded as the creator of the follow-up flags the monitor raises
+const MonitorUser = "literature-monitor"
+
+// feedbackPageSize is the page size used when scanning the feedback store
+const feedbackPageSize = 500
+
+// citationPayload is the part of an evidence snapshot that lists citations
+type citationPayload struct {
+	DatabaseResults struct {
+		PubMed struct {
+			Citations []struct {
+				PMID string `json:"pmid"`
+			} `json:"citations"`
+		} `json:"pubmed"`
+	} `json:"database_results"`
+	AggregatedEvidence struct {
+		LiteratureEvidence struct {
+			PubMedCitations []struct {
+				PMID string `json:"pmid"`
+			} `json:"pubmed_citations"`
+		} `json:"literature_evidence"`
+	} `json:"aggregated_evidence"`
+}
+
+// pmids returns the distinct PMIDs cited in the payload
+func (p *citationPayload) pmids() []string {
+	seen := make(map[string]bool)
+	var pmids []string
+	add := func(pmid string) {
+		if pmid != "" && !seen[pmid] {
+			seen[pmid] = true
+			pmids = append(pmids, pmid)
+		}
+	}
+	for _, c := range p.DatabaseResults.PubMed.Citations {
+		add(c.PMID)
+	}
+	for _, c := range p.AggregatedEvidence.LiteratureEvidence.PubMedCitations {
+		add(c.PMID)
+	}
+	return pmids
+}
+
+// FlaggedClassification is a signed-out classification flagged for review
+// because literature it cites received a notice.
+type FlaggedClassification struct {
+	NormalizedHGVS string                `json:"normalized_hgvs"`
+	CancerType     string                `json:"cancer_type,omitempty"`
+	Classification string                `json:"classification"`
+	PMID           string                `json:"pmid"`
+	Notice         domain.CitationNotice `json:"notice"`
+	FlagID         int64                 `json:"flag_id,omitempty"` // 0 when no follow-up store is configured
+}
+
+// Report summarizes one check of the cited literature.
+type Report struct {
+	CheckedAt       time.Time               `json:"checked_at"`
+	Classifications int                     `json:"classifications"` // Signed-out classifications with cited literature
+	Articles        int                     `json:"articles"`        // Distinct articles checked
+	NewNotices      []*Notice               `json:"new_notices"`
+	Flagged         []FlaggedClassification `json:"flagged"`
+}
+
+// Monitor checks the literature cited by signed-out classifications. The
+// cited articles are those in each variant's latest evidence snapshot.
+type Monitor struct {
+	logger    *logrus.Logger
+	store     Store
+	checker   Checker
+	feedback  feedback.Store
+	snapshots snapshot.Store
+	flags     followup.Store
+}
+
+// NewMonitor creates a literature monitor.
+func NewMonitor(logger *logrus.Logger, store Store, checker Checker, feedbackStore feedback.Store, snapshots snapshot.Store) *Monitor {
+	return &Monitor{
+		logger:    logger,
+		store:     store,
+		checker:   checker,
+		feedback:  feedbackStore,
+		snapshots: snapshots,
+	}
+}
+
+// SetFollowUpStore enables review tasks: each affected classification gets a
+// follow-up flag on the reviewers' worklist.
+func (m *Monitor) SetFollowUpStore(store followup.Store) {
+	m.flags = store
+}
+
+// Check looks up notices for every cited article, records new ones and flags
+// the classifications citing an article with a notice. Each classification is
+// flagged once per notice, so repeated checks only raise new review tasks.
+func (m *Monitor) Check(ctx context.Context) (*Report, error) {
+	report := &Report{
+		CheckedAt:  time.Now().UTC(),
+		NewNotices: []*Notice{},
+		Flagged:    []FlaggedClassification{},
+	}
+
+	entries, err := m.allFeedback(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cited articles per variant, from its latest evidence snapshot
+	cited := make(map[string][]string)
+	articles := make(map[string]bool)
+	for _, fb := range entries {
+		if _, seen := cited[fb.NormalizedHGVS]; seen {
+			continue
+		}
+		pmids, err := m.citedPMIDs(ctx, fb.NormalizedHGVS)
+		if err != nil {
+			return nil, err
+		}
+		cited[fb.NormalizedHGVS] = pmids
+		for _, pmid := range pmids {
+			articles[pmid] = true
+		}
+	}
+	for _, fb := range entries {
+		if len(cited[fb.NormalizedHGVS]) > 0 {
+			report.Classifications++
+		}
+	}
+	report.Articles = len(articles)
+	if len(articles) == 0 {
+		return report, nil
+	}
+
+	pmids := make([]string, 0, len(articles))
+	for pmid := range articles {
+		pmids = append(pmids, pmid)
+	}
+	sort.Strings(pmids)
+
+	found, err := m.checker.QueryNotices(ctx, pmids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check literature notices: %w", err)
+	}
+	var notices []*Notice
+	for _, pmid := range pmids {
+		for _, n := range found[pmid] {
+			notices = append(notices, &Notice{PMID: pmid, CitationNotice: n})
+		}
+	}
+	added, err := m.store.Record(ctx, notices)
+	if err != nil {
+		return nil, err
+	}
+	report.NewNotices = append(report.NewNotices, added...)
+
+	// Flag against every recorded notice so classifications signed out after
+	// a notice was first seen are flagged too
+	recorded, err := m.store.CitationNotices(ctx, pmids)
+	if err != nil {
+		return nil, err
+	}
+	for _, fb := range entries {
+		for _, pmid := range cited[fb.NormalizedHGVS] {
+			for _, n := range recorded[pmid] {
+				flagged, err := m.flag(ctx, fb, &Notice{PMID: pmid, CitationNotice: n})
+				if err != nil {
+					return nil, err
+				}
+				if flagged != nil {
+					report.Flagged = append(report.Flagged, *flagged)
+				}
+			}
+		}
+	}
+
+	m.logger.WithFields(logrus.Fields{
+		"articles":    report.Articles,
+		"new_notices": len(report.NewNotices),
+		"flagged":     len(report.Flagged),
+	}).Info("Checked cited literature for retractions and errata")
+	return report, nil
+}
+
+// flag raises a review task for a classification unless one was already raised
+func (m *Monitor) flag(ctx context.Context, fb *feedback.Feedback, notice *Notice) (*FlaggedClassification, error) {
+	isNew, err := m.store.MarkFlagged(ctx, fb.NormalizedHGVS, fb.CancerType, notice)
+	if err != nil || !isNew {
+		return nil, err
+	}
+
+	flagged := &FlaggedClassification{
+		NormalizedHGVS: fb.NormalizedHGVS,
+		CancerType:     fb.CancerType,
+		Classification: string(fb.UserClassification),
+		PMID:           notice.PMID,
+		Notice:         notice.CitationNotice,
+	}
+	if m.flags == nil {
+		return flagged, nil
+	}
+
+	kind := followup.KindLiteratureErratum
+	if notice.Kind == domain.NoticeRetraction {
+		kind = followup.KindLiteratureRetracted
+	}
+	flag := &followup.Flag{
+		NormalizedHGVS: fb.NormalizedHGVS,
+		CancerType:     fb.CancerType,
+		Kind:           kind,
+		Note:           noteFor(notice),
+		Blocking:       kind.BlocksByDefault(),
+		CreatedBy:      MonitorUser,
+	}
+	if err := m.flags.Add(ctx, flag); err != nil {
+		return nil, fmt.Errorf("failed to add follow-up flag: %w", err)
+	}
+	flagged.FlagID = flag.ID
+	return flagged, nil
+}
+
+// noteFor describes a notice for the follow-up flag
+func noteFor(notice *Notice) string {
+	note := fmt.Sprintf("Cited article PMID %s has a %s notice", notice.PMID, notice.Kind)
+	if notice.Kind == domain.NoticeRetraction {
+		note = fmt.Sprintf("Cited article PMID %s has been retracted", notice.PMID)
+	}
+	if notice.NoticePMID != "" {
+		note += fmt.Sprintf(" (notice PMID %s)", notice.NoticePMID)
+	}
+	return note + "; review the evidence it supports"
+}
+
+// citedPMIDs returns the articles cited in a variant's latest evidence snapshot
+func (m *Monitor) citedPMIDs(ctx context.Context, normalizedHGVS string) ([]string, error) {
+	if m.snapshots == nil {
+		return nil, nil
+	}
+	snaps, err := m.snapshots.ListByVariant(ctx, normalizedHGVS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list evidence snapshots: %w", err)
+	}
+	if len(snaps) == 0 {
+		return nil, nil
+	}
+
+	// Snapshots are newest first
+	s, err := m.snapshots.Get(ctx, snaps[0].ID)
+	if err != nil {
+		m.logger.WithError(err).WithField("snapshot_id", snaps[0].ID).Warn("Failed to load evidence snapshot")
+		return nil, nil
+	}
+	var payload citationPayload
+	if err := json.Unmarshal(s.Payload, &payload); err != nil {
+		m.logger.WithError(err).WithField("snapshot_id", s.ID).Warn("Failed to decode evidence snapshot")
+		return nil, nil
+	}
+	return payload.pmids(), nil
+}
+
+func (m *Monitor) allFeedback(ctx context.Context) ([]*feedback.Feedback, error) {
+	var all []*feedback.Feedback
+	for offset := 0; ; offset += feedbackPageSize {
+		page, err := m.feedback.List(ctx, feedbackPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list feedback: %w", err)
+		}
+		all = append(all, page...)
+		if len(page) < feedbackPageSize {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package literature
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/feedback"
+	"github.com/acmg-amp-mcp-server/internal/followup"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+)
+
+// fakeChecker returns fixed notices and records the articles it was asked about
+type fakeChecker struct {
+	notices map[string][]domain.CitationNotice
+	checked []string
+}
+
+func (c *fakeChecker) QueryNotices(ctx context.Context, pmids []string) (map[string][]domain.CitationNotice, error) {
+	c.checked = append([]string(nil), pmids...)
+	return c.notices, nil
+}
+
+func citationSnapshot(t *testing.T, hgvs string, pmids ...string) *snapshot.Snapshot {
+	t.Helper()
+	citations := make([]map[string]string, len(pmids))
+	for i, pmid := range pmids {
+		citations[i] = map[string]string{"pmid": pmid}
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"database_results": map[string]interface{}{
+			"pubmed": map[string]interface{}{"citations": citations},
+		},
+	})
+	require.NoError(t, err)
+	return &snapshot.Snapshot{NormalizedHGVS: hgvs, Payload: payload}
+}
+
+func TestMonitor_Check(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	logger, _ := test.NewNullLogger()
+
+	fbStore, err := feedback.NewSQLiteStore(filepath.Join(dir, "feedback.db"))
+	require.NoError(t, err)
+	defer fbStore.Close()
+	archive, err := snapshot.NewFileArchive(filepath.Join(dir, "archive"))
+	require.NoError(t, err)
+	snapStore, err := snapshot.NewSQLiteStore(filepath.Join(dir, "evidence.db"), archive)
+	require.NoError(t, err)
+	defer snapStore.Close()
+	flagStore, err := followup.NewSQLiteStore(filepath.Join(dir, "followup.db"))
+	require.NoError(t, err)
+	defer flagStore.Close()
+	store := createTestStore(t)
+	defer store.Close()
+
+	tp53 := &feedback.Feedback{Variant: "TP53:c.743G>A", NormalizedHGVS: "NM_000546.6:c.743G>A", SuggestedClassification: "Pathogenic", UserClassification: "Pathogenic", UserAgreed: true}
+	require.NoError(t, fbStore.Save(ctx, tp53))
+	require.NoError(t, snapStore.Save(ctx, citationSnapshot(t, tp53.NormalizedHGVS, "20301425", "15920490")))
+	brca2 := &feedback.Feedback{Variant: "BRCA2:c.68-7T>A", NormalizedHGVS: "NM_000059.4:c.68-7T>A", CancerType: "breast", SuggestedClassification: "Benign", UserClassification: "Benign", UserAgreed: true}
+	require.NoError(t, fbStore.Save(ctx, brca2))
+	require.NoError(t, snapStore.Save(ctx, citationSnapshot(t, brca2.NormalizedHGVS, "15920490")))
+
+	checker := &fakeChecker{notices: map[string][]domain.CitationNotice{
+		"20301425": {{Kind: domain.NoticeRetraction, NoticePMID: "31000001"}},
+		"15920490": {{Kind: domain.NoticeErratum, NoticePMID: "16000002"}},
+	}}
+	monitor := NewMonitor(logger, store, checker, fbStore, snapStore)
+	monitor.SetFollowUpStore(flagStore)
+
+	// Act
+	report, err := monitor.Check(ctx)
+	require.NoError(t, err)
+	again, err := monitor.Check(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"15920490", "20301425"}, checker.checked)
+	assert.Equal(t, 2, report.Classifications)
+	assert.Equal(t, 2, report.Articles)
+	assert.Len(t, report.NewNotices, 2)
+	assert.Len(t, report.Flagged, 3, "TP53 for both notices, BRCA2 for the erratum")
+	assert.Empty(t, again.NewNotices)
+	assert.Empty(t, again.Flagged, "repeated checks do not raise duplicate review tasks")
+
+	retracted, err := flagStore.Worklist(ctx, followup.KindLiteratureRetracted)
+	require.NoError(t, err)
+	require.Len(t, retracted, 1)
+	assert.Equal(t, tp53.NormalizedHGVS, retracted[0].NormalizedHGVS)
+	assert.Equal(t, MonitorUser, retracted[0].CreatedBy)
+	assert.False(t, retracted[0].Blocking)
+	assert.Contains(t, retracted[0].Note, "PMID 20301425 has been retracted")
+
+	errata, err := flagStore.Worklist(ctx, followup.KindLiteratureErratum)
+	require.NoError(t, err)
+	require.Len(t, errata, 2)
+	contexts := []string{errata[0].CancerType, errata[1].CancerType}
+	assert.ElementsMatch(t, []string{"", "breast"}, contexts, "flags keep the clinical context of the sign-out")
+}
+
+func TestLiteratureData_ApplyNotices(t *testing.T) {
+	data := &domain.LiteratureData{Citations: []domain.Citation{{PMID: "20301425"}, {PMID: "15920490"}}}
+
+	data.ApplyNotices(map[string][]domain.CitationNotice{
+		"20301425": {{Kind: domain.NoticeRetraction}},
+		"15920490": {{Kind: domain.NoticeErratum}},
+	})
+
+	assert.True(t, data.Citations[0].Retracted)
+	assert.False(t, data.Citations[1].Retracted)
+	assert.Len(t, data.Citations[1].Notices, 1)
+}
@@ -0,0 +1,187 @@
+package literature
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite literature notice store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables and indexes.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS literature_notices (
+		pmid TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		notice_pmid TEXT NOT NULL DEFAULT '',
+		source TEXT DEFAULT '',
+		detected_at DATETIME NOT NULL,
+		PRIMARY KEY (pmid, kind, notice_pmid)
+	);
+
+	CREATE TABLE IF NOT EXISTS literature_flags (
+		normalized_hgvs TEXT NOT NULL,
+		cancer_type TEXT NOT NULL DEFAULT '',
+		pmid TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		notice_pmid TEXT NOT NULL DEFAULT '',
+		flagged_at DATETIME NOT NULL,
+		PRIMARY KEY (normalized_hgvs, cancer_type, pmid, kind, notice_pmid)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_literature_notices_detected ON literature_notices(detected_at);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+// Record saves notices not already recorded and returns the new ones.
+func (s *SQLiteStore) Record(ctx context.Context, notices []*Notice) ([]*Notice, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	var added []*Notice
+	for _, n := range notices {
+		n.PMID = strings.TrimSpace(n.PMID)
+		if n.PMID == "" || n.Kind == "" {
+			return nil, fmt.Errorf("notice PMID and kind are required")
+		}
+		result, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO literature_notices (pmid, kind, notice_pmid, source, detected_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, n.PMID, n.Kind, n.NoticePMID, n.Source, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert notice: %w", err)
+		}
+		if rows, _ := result.RowsAffected(); rows > 0 {
+			n.DetectedAt = now
+			added = append(added, n)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit notices: %w", err)
+	}
+	return added, nil
+}
+
+// CitationNotices returns the recorded notices for the given articles.
+func (s *SQLiteStore) CitationNotices(ctx context.Context, pmids []string) (map[string][]domain.CitationNotice, error) {
+	result := make(map[string][]domain.CitationNotice)
+	if len(pmids) == 0 {
+		return result, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(pmids)), ",")
+	args := make([]interface{}, len(pmids))
+	for i, pmid := range pmids {
+		args[i] = strings.TrimSpace(pmid)
+	}
+	notices, err := s.query(ctx, `
+		SELECT pmid, kind, notice_pmid, source, detected_at FROM literature_notices
+		WHERE pmid IN (`+placeholders+`) ORDER BY detected_at ASC, kind ASC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range notices {
+		result[n.PMID] = append(result[n.PMID], n.CitationNotice)
+	}
+	return result, nil
+}
+
+// List returns all recorded notices, newest first.
+func (s *SQLiteStore) List(ctx context.Context) ([]*Notice, error) {
+	return s.query(ctx, `
+		SELECT pmid, kind, notice_pmid, source, detected_at FROM literature_notices
+		ORDER BY detected_at DESC, pmid ASC`)
+}
+
+// MarkFlagged records that a classification was flagged for a notice.
+func (s *SQLiteStore) MarkFlagged(ctx context.Context, normalizedHGVS, cancerType string, notice *Notice) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO literature_flags (normalized_hgvs, cancer_type, pmid, kind, notice_pmid, flagged_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, strings.TrimSpace(normalizedHGVS), cancerType, notice.PMID, notice.Kind, notice.NoticePMID, time.Now().UTC())
+	if err != nil {
+		return false, fmt.Errorf("failed to mark flagged: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark flagged: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (s *SQLiteStore) query(ctx context.Context, query string, args ...interface{}) ([]*Notice, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var notices []*Notice
+	for rows.Next() {
+		n := &Notice{}
+		if err := rows.Scan(&n.PMID, &n.Kind, &n.NoticePMID, &n.Source, &n.DetectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		notices = append(notices, n)
+	}
+	return notices, rows.Err()
+}
+
+// Close closes the store and releases resources.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,70 @@
+package literature
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "literature.db"))
+	require.NoError(t, err)
+	return store
+}
+
+func TestSQLiteStore_RecordAndLookup(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	retraction := &Notice{PMID: "20301425", CitationNotice: domain.CitationNotice{Kind: domain.NoticeRetraction, NoticePMID: "31000001", Source: "Hum Mutat. 2019"}}
+	erratum := &Notice{PMID: "20301425", CitationNotice: domain.CitationNotice{Kind: domain.NoticeErratum, NoticePMID: "29000002"}}
+
+	// Act
+	added, err := store.Record(ctx, []*Notice{retraction, erratum})
+	require.NoError(t, err)
+	again, err := store.Record(ctx, []*Notice{{PMID: "20301425", CitationNotice: domain.CitationNotice{Kind: domain.NoticeRetraction, NoticePMID: "31000001"}}})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Len(t, added, 2)
+	assert.False(t, added[0].DetectedAt.IsZero())
+	assert.Empty(t, again, "a notice is recorded once")
+
+	notices, err := store.CitationNotices(ctx, []string{"20301425", "11111111"})
+	require.NoError(t, err)
+	require.Len(t, notices["20301425"], 2)
+	assert.Empty(t, notices["11111111"])
+
+	all, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	_, err = store.Record(ctx, []*Notice{{PMID: "20301425"}})
+	assert.Error(t, err, "kind is required")
+}
+
+func TestSQLiteStore_MarkFlagged(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	notice := &Notice{PMID: "20301425", CitationNotice: domain.CitationNotice{Kind: domain.NoticeRetraction}}
+
+	first, err := store.MarkFlagged(ctx, "NM_000546.6:c.743G>A", "", notice)
+	require.NoError(t, err)
+	second, err := store.MarkFlagged(ctx, "NM_000546.6:c.743G>A", "", notice)
+	require.NoError(t, err)
+	otherContext, err := store.MarkFlagged(ctx, "NM_000546.6:c.743G>A", "breast", notice)
+	require.NoError(t, err)
+
+	assert.True(t, first)
+	assert.False(t, second)
+	assert.True(t, otherContext)
+}
@@ -0,0 +1,45 @@
+// Package literature watches the articles cited by signed-out classifications
+// for retractions and errata. Notices found in PubMed are recorded once per
+// article, each affected classification gets a follow-up flag for review, and
+// citations of a noticed article are annotated wherever evidence is shown.
+package literature
+
+import (
+	"context"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// Notice is a retraction or erratum recorded for a cited article.
+type Notice struct {
+	PMID string `json:"pmid"` // Article the notice applies to
+	domain.CitationNotice
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// Checker looks up notices for articles, such as the PubMed client.
+type Checker interface {
+	// QueryNotices returns the notices for each article that has any.
+	QueryNotices(ctx context.Context, pmids []string) (map[string][]domain.CitationNotice, error)
+}
+
+// Store defines the interface for notice storage operations.
+type Store interface {
+	// Record saves notices not already recorded, setting their detection
+	// time, and returns the new ones.
+	Record(ctx context.Context, notices []*Notice) ([]*Notice, error)
+
+	// CitationNotices returns the recorded notices for the given articles, keyed by PMID.
+	CitationNotices(ctx context.Context, pmids []string) (map[string][]domain.CitationNotice, error)
+
+	// List returns all recorded notices, newest first.
+	List(ctx context.Context) ([]*Notice, error)
+
+	// MarkFlagged records that a classification was flagged for a notice.
+	// It reports false if it had already been flagged.
+	MarkFlagged(ctx context.Context, normalizedHGVS, cancerType string, notice *Notice) (bool, error)
+
+	// Close closes the store and releases resources.
+	Close() error
+}
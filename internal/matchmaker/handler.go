@@ -0,0 +1,113 @@
+package matchmaker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// Path is where the match API is served.
+const Path = "/api/v1/match"
+
+// maxRequestBytes bounds the request body
+const maxRequestBytes = 1 << 20
+
+// MatchRequest is a Matchmaker Exchange style match request: the patient's
+// phenotypic features and the genes carrying their candidate variants.
+type MatchRequest struct {
+	Patient struct {
+		ID       string `json:"id"` // Excluded from the counts
+		Features []struct {
+			ID       string `json:"id" validate:"required"`
+			Observed string `json:"observed,omitempty"` // "no" excludes the feature
+		} `json:"features"`
+		GenomicFeatures []struct {
+			Gene struct {
+				ID string `json:"id" validate:"required,min=1"`
+			} `json:"gene" validate:"required"`
+		} `json:"genomicFeatures" validate:"required,min=1"`
+	} `json:"patient" validate:"required"`
+	MinSimilarity float64 `json:"min_similarity,omitempty" validate:"min=0,max=1"`
+}
+
+// MatchResponse holds one result per queried gene.
+type MatchResponse struct {
+	Results []*Result `json:"results"`
+}
+
+// queries returns one query per distinct gene in the request
+func (r *MatchRequest) queries() ([]Query, error) {
+	var terms []string
+	for _, feature := range r.Patient.Features {
+		if strings.EqualFold(strings.TrimSpace(feature.Observed), "no") || strings.TrimSpace(feature.ID) == "" {
+			continue
+		}
+		terms = append(terms, feature.ID)
+	}
+
+	var queries []Query
+	seen := make(map[string]bool)
+	for _, feature := range r.Patient.GenomicFeatures {
+		gene := strings.ToUpper(strings.TrimSpace(feature.Gene.ID))
+		if gene == "" || seen[gene] {
+			continue
+		}
+		seen[gene] = true
+		queries = append(queries, Query{GeneSymbol: gene, HPOTerms: terms, ProbandID: r.Patient.ID, MinSimilarity: r.MinSimilarity})
+	}
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("%w: patient.genomicFeatures must name at least one gene", ErrInvalidQuery)
+	}
+	return queries, nil
+}
+
+// NewHandler serves POST /api/v1/match, answering a Matchmaker Exchange
+// style request with anonymized counts of matching internal cases.
+func NewHandler(logger *logrus.Logger, matchmaker *Matchmaker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request MatchRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&request); err != nil {
+			writeError(w, r, http.StatusBadRequest, protocol.ErrorCodeParseError, "Invalid match request", err.Error())
+			return
+		}
+		queries, err := request.queries()
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, "Invalid match request", err.Error())
+			return
+		}
+
+		response := MatchResponse{Results: make([]*Result, 0, len(queries))}
+		for _, query := range queries {
+			result, err := matchmaker.Match(r.Context(), query)
+			if errors.Is(err, ErrInvalidQuery) {
+				writeError(w, r, http.StatusBadRequest, protocol.ErrorCodeInvalidInput, "Invalid match request", err.Error())
+				return
+			}
+			if err != nil {
+				logger.WithError(err).WithField("gene", query.GeneSymbol).Error("Failed to match internal cases")
+				writeError(w, r, http.StatusInternalServerError, protocol.ErrorCodeInternal, "Failed to match internal cases", "")
+				return
+			}
+			response.Results = append(response.Results, result)
+		}
+
+		logger.WithField("genes", len(queries)).Info("Matched internal cases")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+}
+
+// writeError responds with the standard error envelope
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, message, details string) {
+	envelope := protocol.NewErrorEnvelope(code, message, "rest:"+r.Method+" "+r.URL.Path, r.Header.Get("X-Correlation-ID"), details)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(envelope)
+}
@@ -0,0 +1,191 @@
+// Package matchmaker matches a case against the lab's own cases in the style
+// of the Matchmaker Exchange: given a gene and a patient's phenotype, it
+// counts the internal cases carrying candidate variants in the gene and how
+// many of them have a similar phenotype. Only counts are returned, never
+// proband IDs or phenotypes. Unrelated phenotype-matched cases carrying the
+// same variant are case-level evidence for PS4; matched cases are also the
+// families to approach for the segregation data PP1 needs.
+package matchmaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/cohort"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/phenotype"
+)
+
+// DefaultMinSimilarity is the phenotype similarity a case needs to match.
+const DefaultMinSimilarity = 0.5
+
+// ErrInvalidQuery is returned when a query is missing required fields or out of range.
+var ErrInvalidQuery = errors.New("invalid match query")
+
+// Query asks for internal cases with candidate variants in a gene.
+type Query struct {
+	GeneSymbol    string   `json:"gene_symbol"`
+	HPOTerms      []string `json:"hpo_terms,omitempty"`      // Phenotype of the patient being matched
+	ProbandID     string   `json:"proband_id,omitempty"`     // Patient being matched, excluded from the counts
+	MinSimilarity float64  `json:"min_similarity,omitempty"` // Defaults to DefaultMinSimilarity
+}
+
+// Validate normalizes the query and checks required fields.
+func (q *Query) Validate() error {
+	q.GeneSymbol = strings.ToUpper(strings.TrimSpace(q.GeneSymbol))
+	q.ProbandID = strings.TrimSpace(q.ProbandID)
+	if q.GeneSymbol == "" {
+		return fmt.Errorf("%w: gene symbol is required", ErrInvalidQuery)
+	}
+	if q.MinSimilarity < 0 || q.MinSimilarity > 1 {
+		return fmt.Errorf("%w: minimum similarity must be between 0 and 1", ErrInvalidQuery)
+	}
+	if q.MinSimilarity == 0 {
+		q.MinSimilarity = DefaultMinSimilarity
+	}
+	return nil
+}
+
+// VariantCount counts the cases carrying one candidate variant.
+type VariantCount struct {
+	NormalizedHGVS        string `json:"normalized_hgvs"`
+	Classification        string `json:"classification,omitempty"` // Most recent classification recorded
+	Cases                 int    `json:"cases"`
+	PhenotypeMatchedCases int    `json:"phenotype_matched_cases"`
+}
+
+// Result holds the anonymized counts of matching internal cases.
+type Result struct {
+	GeneSymbol            string         `json:"gene_symbol"`
+	CohortSize            int            `json:"cohort_size"`             // Distinct probands in the cohort
+	Cases                 int            `json:"cases"`                   // Distinct probands with a candidate variant in the gene
+	PhenotypedCases       int            `json:"phenotyped_cases"`        // Cases with a recorded phenotype
+	PhenotypeMatchedCases int            `json:"phenotype_matched_cases"` // Cases whose phenotype is similar to the query's
+	MinSimilarity         float64        `json:"min_similarity"`
+	Method                string         `json:"method"`
+	Variants              []VariantCount `json:"variants"`
+	Evidence              []string       `json:"evidence,omitempty"` // How the matches bear on PS4 and PP1
+}
+
+// Matchmaker matches cases against the in-house cohort.
+type Matchmaker struct {
+	store      cohort.Store
+	phenotypes *phenotype.Matcher
+}
+
+// NewMatchmaker creates a matchmaker over the cohort. Without a phenotype
+// matcher only identical HPO terms match.
+func NewMatchmaker(store cohort.Store, phenotypes *phenotype.Matcher) *Matchmaker {
+	if phenotypes == nil {
+		phenotypes = phenotype.NewMatcher()
+	}
+	return &Matchmaker{store: store, phenotypes: phenotypes}
+}
+
+// Match counts the internal cases with candidate variants in the query gene.
+// Variants classified benign or likely benign are not candidates; variants
+// not yet classified are. Without query HPO terms no case matches on phenotype.
+func (m *Matchmaker) Match(ctx context.Context, q Query) (*Result, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	cohortSize, err := m.store.Size(ctx)
+	if err != nil {
+		return nil, err
+	}
+	observations, err := m.store.Cases(ctx, q.GeneSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		GeneSymbol:    q.GeneSymbol,
+		CohortSize:    cohortSize,
+		MinSimilarity: q.MinSimilarity,
+		Method:        phenotype.SimilarityMethod,
+		Variants:      []VariantCount{},
+	}
+	variants := make(map[string]*VariantCount)
+	matched := make(map[string]bool)               // Proband -> phenotype matches
+	latest := make(map[string]*cohort.Observation) // Variant -> most recent observation
+	for _, obs := range observations {
+		if obs.ProbandID == q.ProbandID || !isCandidate(obs.Classification) {
+			continue
+		}
+		isMatch, seen := matched[obs.ProbandID]
+		if !seen {
+			isMatch = len(q.HPOTerms) > 0 && m.phenotypes.Similarity(q.HPOTerms, obs.HPOTerms) >= q.MinSimilarity
+			matched[obs.ProbandID] = isMatch
+			result.Cases++
+			if len(obs.HPOTerms) > 0 {
+				result.PhenotypedCases++
+			}
+			if isMatch {
+				result.PhenotypeMatchedCases++
+			}
+		}
+
+		count, ok := variants[obs.NormalizedHGVS]
+		if !ok {
+			count = &VariantCount{NormalizedHGVS: obs.NormalizedHGVS}
+			variants[obs.NormalizedHGVS] = count
+		}
+		count.Cases++
+		if isMatch {
+			count.PhenotypeMatchedCases++
+		}
+		if prev := latest[obs.NormalizedHGVS]; prev == nil || obs.ObservedAt.After(prev.ObservedAt) {
+			latest[obs.NormalizedHGVS] = obs
+			count.Classification = obs.Classification
+		}
+	}
+
+	for _, count := range variants {
+		result.Variants = append(result.Variants, *count)
+	}
+	// Best supported variants first
+	sort.Slice(result.Variants, func(i, j int) bool {
+		a, b := result.Variants[i], result.Variants[j]
+		if a.PhenotypeMatchedCases != b.PhenotypeMatchedCases {
+			return a.PhenotypeMatchedCases > b.PhenotypeMatchedCases
+		}
+		if a.Cases != b.Cases {
+			return a.Cases > b.Cases
+		}
+		return a.NormalizedHGVS < b.NormalizedHGVS
+	})
+	result.Evidence = evidenceNotes(result)
+	return result, nil
+}
+
+// isCandidate reports whether a recorded classification leaves the variant a
+// candidate
+func isCandidate(classification string) bool {
+	parsed, err := domain.ParseClassification(classification)
+	if err != nil {
+		return true // Not classified, or classified in terms we do not know
+	}
+	return parsed != domain.BENIGN && parsed != domain.LIKELY_BENIGN
+}
+
+// evidenceNotes describes how the matches bear on PS4 and PP1
+func evidenceNotes(result *Result) []string {
+	var notes []string
+	for _, variant := range result.Variants {
+		if variant.PhenotypeMatchedCases == 0 {
+			break
+		}
+		notes = append(notes, fmt.Sprintf(
+			"PS4: %s is carried by %d other phenotype-matched case(s); confirm they are unrelated before counting them as affected probands",
+			variant.NormalizedHGVS, variant.PhenotypeMatchedCases))
+	}
+	if result.PhenotypeMatchedCases > 0 {
+		notes = append(notes, fmt.Sprintf(
+			"PP1: %d phenotype-matched case(s) carry candidate variants in %s; request family studies from their ordering teams for segregation data",
+			result.PhenotypeMatchedCases, result.GeneSymbol))
+	}
+	return notes
+}
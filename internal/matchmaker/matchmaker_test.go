@@ -0,0 +1,122 @@
+package matchmaker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/cohort"
+)
+
+const (
+	seizure       = "HP:0001250"
+	delay         = "HP:0001263"
+	visual        = "HP:0000505"
+	variantA      = "NM_001165963.4:c.2836C>T"
+	variantB      = "NM_001165963.4:c.4573C>T"
+	benignVariant = "NM_001165963.4:c.3199G>A"
+)
+
+func createTestMatchmaker(t *testing.T) *Matchmaker {
+	t.Helper()
+	store, err := cohort.NewSQLiteStore(filepath.Join(t.TempDir(), "cohort.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	ctx := context.Background()
+	base := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i, obs := range []*cohort.Observation{
+		{ProbandID: "P1", NormalizedHGVS: variantA, GeneSymbol: "SCN1A", Classification: "VUS", HPOTerms: []string{seizure, delay}},
+		{ProbandID: "P2", NormalizedHGVS: variantA, GeneSymbol: "SCN1A", Classification: "LIKELY_PATHOGENIC", HPOTerms: []string{seizure, delay}},
+		{ProbandID: "P3", NormalizedHGVS: variantB, GeneSymbol: "SCN1A", Classification: "VUS", HPOTerms: []string{visual}},
+		{ProbandID: "P4", NormalizedHGVS: variantB, GeneSymbol: "SCN1A"},
+		{ProbandID: "P5", NormalizedHGVS: benignVariant, GeneSymbol: "SCN1A", Classification: "BENIGN", HPOTerms: []string{seizure, delay}},
+		{ProbandID: "P6", NormalizedHGVS: "NM_000492.4:c.1521_1523del", GeneSymbol: "CFTR", Classification: "PATHOGENIC"},
+	} {
+		obs.ObservedAt = base.Add(time.Duration(i) * time.Hour)
+		_, err := store.Record(ctx, obs)
+		require.NoError(t, err)
+	}
+	return NewMatchmaker(store, nil)
+}
+
+func TestMatchmaker_Match(t *testing.T) {
+	mm := createTestMatchmaker(t)
+
+	// Act: P1 queries with its own phenotype
+	result, err := mm.Match(context.Background(), Query{GeneSymbol: " scn1a ", HPOTerms: []string{seizure, delay}, ProbandID: "P1"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "SCN1A", result.GeneSymbol)
+	assert.Equal(t, 6, result.CohortSize)
+	assert.Equal(t, 3, result.Cases, "the querying case and benign variants are not counted")
+	assert.Equal(t, 2, result.PhenotypedCases)
+	assert.Equal(t, 1, result.PhenotypeMatchedCases)
+	assert.Equal(t, DefaultMinSimilarity, result.MinSimilarity)
+	assert.Equal(t, []VariantCount{
+		{NormalizedHGVS: variantA, Classification: "LIKELY_PATHOGENIC", Cases: 1, PhenotypeMatchedCases: 1},
+		{NormalizedHGVS: variantB, Cases: 2},
+	}, result.Variants)
+	require.Len(t, result.Evidence, 2)
+	assert.Contains(t, result.Evidence[0], "PS4: "+variantA)
+	assert.Contains(t, result.Evidence[1], "PP1: 1 phenotype-matched case")
+
+	// Without a phenotype nothing matches on phenotype
+	counts, err := mm.Match(context.Background(), Query{GeneSymbol: "SCN1A"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, counts.Cases)
+	assert.Zero(t, counts.PhenotypeMatchedCases)
+	assert.Empty(t, counts.Evidence)
+
+	_, err = mm.Match(context.Background(), Query{})
+	assert.True(t, errors.Is(err, ErrInvalidQuery))
+	_, err = mm.Match(context.Background(), Query{GeneSymbol: "SCN1A", MinSimilarity: 1.5})
+	assert.True(t, errors.Is(err, ErrInvalidQuery))
+}
+
+func TestHandler(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	h := NewHandler(logger, createTestMatchmaker(t))
+
+	t.Run("MME request", func(t *testing.T) {
+		body := `{"patient": {"id": "P9", "features": [{"id": "HP:0001250"}, {"id": "HP:0001263"}, {"id": "HP:0000505", "observed": "no"}],
+			"genomicFeatures": [{"gene": {"id": "SCN1A"}}, {"gene": {"id": "scn1a"}}, {"gene": {"id": "KCNQ2"}}]}}`
+		req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response MatchResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Results, 2)
+		assert.Equal(t, 2, response.Results[0].PhenotypeMatchedCases)
+		assert.Equal(t, "KCNQ2", response.Results[1].GeneSymbol)
+		assert.Zero(t, response.Results[1].Cases)
+		assert.NotContains(t, rec.Body.String(), `"P1"`, "proband IDs are not disclosed")
+	})
+
+	for name, body := range map[string]string{
+		"malformed":      `{"patient":`,
+		"no gene":        `{"patient": {"features": [{"id": "HP:0001250"}]}}`,
+		"bad similarity": `{"patient": {"genomicFeatures": [{"gene": {"id": "SCN1A"}}]}, "min_similarity": 2}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body)))
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
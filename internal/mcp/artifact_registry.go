@@ -0,0 +1,33 @@
+// Package mcp provides the MCP server implementation.
+// This file contains artifact blacklist tool registration logic.
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/artifact"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// registerArtifactTools registers tools for managing the artifact blacklist.
+func registerArtifactTools(registry *tools.ToolRegistry, logger *logrus.Logger, store artifact.Store, exportDir string) error {
+	artifactTools := []tools.Tool{
+		tools.NewProposeArtifactTool(logger, store),
+		tools.NewSignOffArtifactTool(logger, store),
+		tools.NewRemoveArtifactTool(logger, store),
+		tools.NewListArtifactsTool(logger, store),
+		tools.NewExportArtifactsTool(logger, store, exportDir),
+		tools.NewImportArtifactsTool(logger, store),
+	}
+
+	for _, tool := range artifactTools {
+		if err := registry.RegisterTool(tool); err != nil {
+			return fmt.Errorf("failed to register %s: %w", tool.GetToolInfo().Name, err)
+		}
+		logger.WithField("tool_name", tool.GetToolInfo().Name).Debug("Registered artifact tool")
+	}
+
+	return nil
+}
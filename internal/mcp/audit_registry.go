@@ -0,0 +1,84 @@
+// Package mcp provides the MCP server implementation.
+// This file contains classification audit trail tool and resource registration logic.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/resources"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/internal/phi"
+)
+
+// registerAuditTools registers tools for querying, verifying and exporting the
+// classification audit trail. Export bundles are signed when signer is set
+// and their requests redacted when redactor is set. search_variants is
+// registered when the store keeps a search index.
+func registerAuditTools(registry *tools.ToolRegistry, logger *logrus.Logger, store audit.Store, signer *audit.Signer, redactor *phi.Redactor) error {
+	exportTool := tools.NewExportAuditBundleTool(logger, store, signer)
+	if redactor != nil {
+		exportTool.SetRedactor(redactor)
+	}
+	auditTools := []tools.Tool{
+		tools.NewQueryAuditTrailTool(logger, store),
+		tools.NewGetAuditRecordTool(logger, store),
+		tools.NewCompareClassificationsTool(logger, store),
+		tools.NewVerifyAuditChainTool(logger, store),
+		exportTool,
+		tools.NewVerifyAuditBundleTool(logger),
+	}
+	if searcher, ok := store.(audit.Searcher); ok {
+		auditTools = append(auditTools, tools.NewSearchVariantsTool(logger, searcher))
+	}
+
+	for _, tool := range auditTools {
+		if err := registry.RegisterTool(tool); err != nil {
+			return fmt.Errorf("failed to register %s: %w", tool.GetToolInfo().Name, err)
+		}
+		logger.WithField("tool_name", tool.GetToolInfo().Name).Debug("Registered audit tool")
+	}
+
+	return nil
+}
+
+// registerAuditResource registers the /audit/{variant_id} resource template,
+// the audit trail of a variant ID or HGVS notation.
+func registerAuditResource(mcpServer *mcp.Server, logger *logrus.Logger, store audit.Store) {
+	provider := resources.NewAuditResourceProvider(logger, store)
+	template := &mcp.ResourceTemplate{
+		Name:        "variant_audit",
+		Title:       "Variant Audit Trail",
+		Description: "Every recorded classification of a variant, newest first: the request, evidence, applied rules and final call. The variant is a variant ID or a percent-encoded HGVS notation",
+		MIMEType:    "application/json",
+		URITemplate: diseaseURIScheme + "/audit/{variant_id}",
+	}
+	mcpServer.AddResourceTemplate(template, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		// HGVS notations are percent-encoded in URIs
+		path, err := url.PathUnescape(strings.TrimPrefix(uri, diseaseURIScheme))
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InvalidParams, fmt.Errorf("invalid audit URI %s: %w", uri, err))
+		}
+		content, err := provider.GetResource(ctx, path)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.MCPResourceError, err)
+		}
+		text, err := json.Marshal(content.Content)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InternalError, fmt.Errorf("failed to encode audit trail: %w", err))
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: content.MimeType, Text: string(text)}},
+		}, nil
+	})
+	logger.WithField("uri_template", template.URITemplate).Debug("Registered audit resource")
+}
@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+func TestRegisterAuditResource(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	ctx := context.Background()
+	store, err := audit.NewSQLiteStore(filepath.Join(t.TempDir(), "audit.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	require.NoError(t, store.Append(ctx, &audit.Record{
+		VariantID:      "VAR_1",
+		HGVSNotation:   "NM_007294.4:c.5266dup",
+		Request:        json.RawMessage(`{"hgvs_notation":"NM_007294.4:c.5266dup"}`),
+		Classification: "PATHOGENIC",
+		EngineVersion:  service.EngineVersion,
+	}))
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.1"}, nil)
+	registerAuditResource(server, logger, store)
+	session := connectClient(t, server)
+
+	// Act
+	templates, err := session.ListResourceTemplates(ctx, nil)
+	require.NoError(t, err)
+	byHGVS, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "acmg://audit/NM_007294.4%3Ac.5266dup"})
+	require.NoError(t, err)
+	byID, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "acmg://audit/VAR_1"})
+	require.NoError(t, err)
+	_, unknown := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "acmg://audit/VAR_2"})
+
+	// Assert
+	require.Len(t, templates.ResourceTemplates, 1)
+	assert.Equal(t, "acmg://audit/{variant_id}", templates.ResourceTemplates[0].URITemplate)
+
+	for _, result := range []*mcp.ReadResourceResult{byHGVS, byID} {
+		require.Len(t, result.Contents, 1)
+		var trail struct {
+			Total   int            `json:"total"`
+			Records []audit.Record `json:"records"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), &trail))
+		assert.Equal(t, 1, trail.Total)
+		assert.Equal(t, "PATHOGENIC", trail.Records[0].Classification)
+	}
+	assert.Error(t, unknown)
+}
@@ -0,0 +1,30 @@
+// Package mcp provides the MCP server implementation.
+// This file contains known benign list tool registration logic.
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/benign"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// registerKnownBenignTools registers tools for managing the known benign list.
+func registerKnownBenignTools(registry *tools.ToolRegistry, logger *logrus.Logger, store benign.Store) error {
+	benignTools := []tools.Tool{
+		tools.NewAddKnownBenignTool(logger, store),
+		tools.NewRemoveKnownBenignTool(logger, store),
+		tools.NewListKnownBenignTool(logger, store),
+	}
+
+	for _, tool := range benignTools {
+		if err := registry.RegisterTool(tool); err != nil {
+			return fmt.Errorf("failed to register %s: %w", tool.GetToolInfo().Name, err)
+		}
+		logger.WithField("tool_name", tool.GetToolInfo().Name).Debug("Registered known benign tool")
+	}
+
+	return nil
+}
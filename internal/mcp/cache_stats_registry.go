@@ -0,0 +1,44 @@
+// Package mcp provides the MCP server implementation.
+// This file contains evidence cache statistics resource registration logic.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/resources"
+)
+
+// registerCacheStatsResource registers the /cache/stats resource.
+func registerCacheStatsResource(mcpServer *mcp.Server, logger *logrus.Logger, source resources.CacheStatsSource) {
+	provider := resources.NewCacheStatsResourceProvider(logger, source)
+	resource := &mcp.Resource{
+		Name:        "cache_stats",
+		Title:       "Evidence Cache Statistics",
+		Description: "Evidence cache backend and entry count, and each source's TTLs, hits, stale hits, misses, background refreshes and hit ratio",
+		MIMEType:    "application/json",
+		URI:         diseaseURIScheme + "/cache/stats",
+		Meta:        resourceTags(provider, "/cache/stats"),
+	}
+	mcpServer.AddResource(resource, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		content, err := provider.GetResource(ctx, strings.TrimPrefix(uri, diseaseURIScheme))
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.MCPResourceError, err)
+		}
+		text, err := json.Marshal(content.Content)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InternalError, fmt.Errorf("failed to encode cache statistics: %w", err))
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: content.MimeType, Text: string(text)}},
+		}, nil
+	})
+	logger.WithField("uri", resource.URI).Debug("Registered cache statistics resource")
+}
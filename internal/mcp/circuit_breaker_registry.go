@@ -0,0 +1,65 @@
+// Package mcp provides the MCP server implementation.
+// This file contains circuit breaker tool and resource registration logic.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/resources"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// registerCircuitBreakerTools registers tools for overriding external API
+// circuit breakers.
+func registerCircuitBreakerTools(registry *tools.ToolRegistry, logger *logrus.Logger, breakers *external.BreakerRegistry) error {
+	breakerTools := []tools.Tool{
+		tools.NewForceOpenCircuitBreakerTool(logger, breakers),
+		tools.NewResetCircuitBreakerTool(logger, breakers),
+	}
+
+	for _, tool := range breakerTools {
+		if err := registry.RegisterTool(tool); err != nil {
+			return fmt.Errorf("failed to register %s: %w", tool.GetToolInfo().Name, err)
+		}
+		logger.WithField("tool_name", tool.GetToolInfo().Name).Debug("Registered circuit breaker tool")
+	}
+
+	return nil
+}
+
+// registerCircuitBreakerResource registers the /system/circuit-breakers
+// dashboard resource.
+func registerCircuitBreakerResource(mcpServer *mcp.Server, logger *logrus.Logger, breakers *external.BreakerRegistry) {
+	provider := resources.NewCircuitBreakerResourceProvider(logger, breakers)
+	resource := &mcp.Resource{
+		Name:        "circuit_breakers",
+		Title:       "Circuit Breakers",
+		Description: "State (closed, open or half-open), failure counts, manual overrides and next retry of each external API circuit breaker",
+		MIMEType:    "application/json",
+		URI:         diseaseURIScheme + "/system/circuit-breakers",
+		Meta:        resourceTags(provider, "/system/circuit-breakers"),
+	}
+	mcpServer.AddResource(resource, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		content, err := provider.GetResource(ctx, strings.TrimPrefix(uri, diseaseURIScheme))
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.MCPResourceError, err)
+		}
+		text, err := json.Marshal(content.Content)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InternalError, fmt.Errorf("failed to encode circuit breakers: %w", err))
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: content.MimeType, Text: string(text)}},
+		}, nil
+	})
+	logger.WithField("uri", resource.URI).Debug("Registered circuit breaker resource")
+}
@@ -0,0 +1,23 @@
+// Package mcp provides the MCP server implementation.
+// This file contains copy-number variant classification tool registration logic.
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/cnv"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// registerCNVTools registers the copy-number variant classification tool.
+func registerCNVTools(registry *tools.ToolRegistry, logger *logrus.Logger, annotations *cnv.Annotations) error {
+	cnvTool := tools.NewClassifyCNVTool(logger, cnv.NewClassifier(annotations))
+	if err := registry.RegisterTool(cnvTool); err != nil {
+		return fmt.Errorf("failed to register %s: %w", cnvTool.GetToolInfo().Name, err)
+	}
+	logger.WithField("tool_name", cnvTool.GetToolInfo().Name).Debug("Registered CNV tool")
+
+	return nil
+}
@@ -0,0 +1,32 @@
+// Package mcp provides the MCP server implementation.
+// This file contains in-house cohort tool registration logic.
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/cohort"
+	"github.com/acmg-amp-mcp-server/internal/matchmaker"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// registerCohortTools registers tools for querying the in-house cohort
+// frequency and matching cases against the cohort.
+func registerCohortTools(registry *tools.ToolRegistry, logger *logrus.Logger, store cohort.Store, criteria cohort.ArtifactCriteria, mm *matchmaker.Matchmaker) error {
+	cohortTools := []tools.Tool{
+		tools.NewCohortFrequencyTool(logger, store, criteria),
+		tools.NewCohortArtifactsTool(logger, store, criteria),
+		tools.NewMatchInternalCasesTool(logger, mm),
+	}
+
+	for _, tool := range cohortTools {
+		if err := registry.RegisterTool(tool); err != nil {
+			return fmt.Errorf("failed to register %s: %w", tool.GetToolInfo().Name, err)
+		}
+		logger.WithField("tool_name", tool.GetToolInfo().Name).Debug("Registered cohort tool")
+	}
+
+	return nil
+}
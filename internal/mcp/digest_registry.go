@@ -0,0 +1,70 @@
+// Package mcp provides the MCP server implementation.
+// This file contains weekly digest tool and resource registration logic.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/digest"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/resources"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// registerDigestTools registers the weekly review digest tool.
+func registerDigestTools(registry *tools.ToolRegistry, logger *logrus.Logger, generator *digest.Generator) error {
+	digestTool := tools.NewGetWeeklyDigestTool(logger, generator)
+	if err := registry.RegisterTool(digestTool); err != nil {
+		return fmt.Errorf("failed to register %s: %w", digestTool.GetToolInfo().Name, err)
+	}
+	logger.WithField("tool_name", digestTool.GetToolInfo().Name).Debug("Registered digest tool")
+
+	return nil
+}
+
+// registerDigestResources registers the /digests/weekly resource for the
+// current week and the /digests/weekly/{date} template for the week
+// containing a date.
+func registerDigestResources(mcpServer *mcp.Server, logger *logrus.Logger, generator *digest.Generator) {
+	provider := resources.NewDigestResourceProvider(logger, generator)
+	handler := func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		content, err := provider.GetResource(ctx, strings.TrimPrefix(uri, diseaseURIScheme))
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.MCPResourceError, err)
+		}
+		text, err := json.Marshal(content.Content)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InternalError, fmt.Errorf("failed to encode weekly digest: %w", err))
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: content.MimeType, Text: string(text)}},
+		}, nil
+	}
+
+	resource := &mcp.Resource{
+		Name:        "weekly_digest_current",
+		Title:       "Weekly Review Digest",
+		Description: "This week's sign-outs, reclassifications, ClinVar discordances and data source updates",
+		MIMEType:    "application/json",
+		URI:         diseaseURIScheme + "/digests/weekly",
+		Meta:        resourceTags(provider, "/digests/weekly"),
+	}
+	mcpServer.AddResource(resource, handler)
+
+	template := &mcp.ResourceTemplate{
+		Name:        "weekly_digest",
+		Title:       "Weekly Review Digest for a Week",
+		Description: "The review digest of the week (Monday to Sunday, UTC) containing a date in YYYY-MM-DD form",
+		MIMEType:    "application/json",
+		URITemplate: diseaseURIScheme + "/digests/weekly/{date}",
+	}
+	mcpServer.AddResourceTemplate(template, handler)
+	logger.WithField("uri_template", template.URITemplate).Debug("Registered weekly digest resources")
+}
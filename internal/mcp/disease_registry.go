@@ -0,0 +1,52 @@
+// Package mcp provides the MCP server implementation.
+// This file contains gene-disease resource registration logic.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/resources"
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// diseaseURIScheme prefixes the gene-disease resource paths in MCP URIs,
+// e.g. acmg://genes/CFTR/diseases
+const diseaseURIScheme = "acmg:/"
+
+// registerDiseaseResources registers the /genes/{symbol}/diseases resource
+// template when OMIM or Orphanet is configured. Either source may be nil.
+func registerDiseaseResources(mcpServer *mcp.Server, logger *logrus.Logger, omim, orphanet external.GeneDiseaseClient) {
+	if omim == nil && orphanet == nil {
+		return
+	}
+	provider := resources.NewDiseaseResourceProvider(logger, omim, orphanet)
+	template := &mcp.ResourceTemplate{
+		Name:        "gene_diseases",
+		Title:       "Gene Disease Associations",
+		Description: "OMIM and Orphanet diseases associated with a gene, with inheritance, phenotypic series, prevalence and age of onset",
+		MIMEType:    "application/json",
+		URITemplate: diseaseURIScheme + "/genes/{symbol}/diseases",
+	}
+	mcpServer.AddResourceTemplate(template, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		content, err := provider.GetResource(ctx, strings.TrimPrefix(uri, diseaseURIScheme))
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.MCPResourceError, err)
+		}
+		text, err := json.Marshal(content.Content)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InternalError, fmt.Errorf("failed to encode gene diseases: %w", err))
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: content.MimeType, Text: string(text)}},
+		}, nil
+	})
+	logger.WithField("uri_template", template.URITemplate).Debug("Registered gene disease resource")
+}
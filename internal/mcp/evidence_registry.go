@@ -0,0 +1,89 @@
+// Package mcp provides the MCP server implementation.
+// This file contains evidence resource registration logic.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/resources"
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// evidenceURIScheme prefixes the evidence resource paths in MCP URIs,
+// e.g. acmg://evidence/rs80357906/clinical
+const evidenceURIScheme = "acmg:/"
+
+// registerEvidenceResources registers the /evidence/{variant_id} resource
+// templates, resolved live through the knowledge base. The first somatic
+// source, if any, adds clinical evidence to somatic resources. Mock evidence
+// is only served when mockFallback is set; every response names its origin
+// (live, cached or mock) in _meta.
+func registerEvidenceResources(mcpServer *mcp.Server, logger *logrus.Logger, kb domain.KnowledgeBaseAccess, somatic []external.SomaticEvidenceClient, mockFallback bool) {
+	provider := resources.NewEvidenceResourceProvider(logger)
+	provider.SetKnowledgeBase(kb)
+	if len(somatic) > 0 {
+		provider.SetSomaticEvidenceSource(somatic[0])
+	}
+	provider.SetFallbackToMock(mockFallback)
+
+	list, err := provider.ListResources(context.Background(), "")
+	if err != nil {
+		logger.WithError(err).Warn("Failed to list evidence resources")
+		return
+	}
+
+	handler := func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		// Variant IDs such as HGVS notations are percent-encoded in URIs
+		path, err := url.PathUnescape(strings.TrimPrefix(uri, evidenceURIScheme))
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InvalidParams, fmt.Errorf("invalid evidence URI %s: %w", uri, err))
+		}
+		content, err := provider.GetResource(ctx, path)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.MCPResourceError, err)
+		}
+		text, err := json.Marshal(content.Content)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InternalError, fmt.Errorf("failed to encode evidence: %w", err))
+		}
+		meta := mcp.Meta{"origin": content.Metadata["origin"]}
+		return &mcp.ReadResourceResult{
+			Meta:     meta,
+			Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: content.MimeType, Text: string(text), Meta: meta}},
+		}, nil
+	}
+
+	for _, info := range list.Resources {
+		template := &mcp.ResourceTemplate{
+			Name:        evidenceTemplateName(info.URI),
+			Title:       info.Name,
+			Description: info.Description,
+			MIMEType:    info.MimeType,
+			URITemplate: evidenceURIScheme + info.URI,
+		}
+		mcpServer.AddResourceTemplate(template, handler)
+		logger.WithField("uri_template", template.URITemplate).Debug("Registered evidence resource")
+	}
+}
+
+// evidenceTemplateName names an evidence resource template after its path,
+// e.g. evidence_literature_page for /evidence/{variant_id}/literature/page/{page}
+func evidenceTemplateName(uriTemplate string) string {
+	name := "evidence"
+	for _, segment := range strings.Split(strings.TrimPrefix(uriTemplate, "/evidence/{variant_id}"), "/") {
+		if segment != "" && !strings.HasPrefix(segment, "{") {
+			name += "_" + segment
+		}
+	}
+	return name
+}
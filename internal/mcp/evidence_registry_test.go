@@ -0,0 +1,172 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	litecfg "github.com/acmg-amp-mcp-server/internal/config"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// stubEvidenceKnowledgeBase returns fixed evidence or a fixed error
+type stubEvidenceKnowledgeBase struct {
+	evidence *domain.AggregatedEvidence
+	err      error
+}
+
+func (s *stubEvidenceKnowledgeBase) GatherEvidence(ctx context.Context, variant *domain.StandardizedVariant) (*domain.AggregatedEvidence, error) {
+	return s.evidence, s.err
+}
+
+func (s *stubEvidenceKnowledgeBase) QueryClinVar(variant *domain.StandardizedVariant) (*domain.ClinVarData, error) {
+	return nil, s.err
+}
+
+func (s *stubEvidenceKnowledgeBase) QueryGnomAD(variant *domain.StandardizedVariant) (*domain.PopulationData, error) {
+	return nil, s.err
+}
+
+func (s *stubEvidenceKnowledgeBase) QueryCOSMIC(variant *domain.StandardizedVariant) (*domain.SomaticData, error) {
+	return nil, s.err
+}
+
+// connectClient connects an in-memory MCP client to the server
+func connectClient(t *testing.T, server *mcp.Server) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { serverSession.Close() })
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.1"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func TestRegisterEvidenceResources_Templates(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.1"}, nil)
+	registerEvidenceResources(server, logger, &stubEvidenceKnowledgeBase{}, nil, false)
+	session := connectClient(t, server)
+
+	// Act
+	templates, err := session.ListResourceTemplates(context.Background(), nil)
+
+	// Assert
+	require.NoError(t, err)
+	registered := make(map[string]string)
+	for _, template := range templates.ResourceTemplates {
+		registered[template.Name] = template.URITemplate
+	}
+	assert.Len(t, registered, 10)
+	assert.Equal(t, "acmg://evidence/{variant_id}", registered["evidence"])
+	assert.Equal(t, "acmg://evidence/{variant_id}/clinical", registered["evidence_clinical"])
+	assert.Equal(t, "acmg://evidence/{variant_id}/literature/page/{page}", registered["evidence_literature_page"])
+}
+
+func TestLiteServer_EvidenceResources(t *testing.T) {
+	cfg := litecfg.DefaultLiteConfig()
+	cfg.DataDir = t.TempDir()
+	server, err := NewLiteServer(cfg)
+	require.NoError(t, err)
+	session := connectClient(t, server.mcpServer)
+
+	// Act
+	templates, err := session.ListResourceTemplates(context.Background(), nil)
+
+	// Assert
+	require.NoError(t, err)
+	var uriTemplates []string
+	for _, template := range templates.ResourceTemplates {
+		uriTemplates = append(uriTemplates, template.URITemplate)
+	}
+	assert.Contains(t, uriTemplates, "acmg://evidence/{variant_id}/clinical")
+}
+
+func TestRegisterEvidenceResources_Origin(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	kb := &stubEvidenceKnowledgeBase{evidence: &domain.AggregatedEvidence{
+		ClinVarData: &domain.ClinVarData{VariationID: "VCV000017661", ClinicalSignificance: "Pathogenic"},
+	}}
+	ctx := context.Background()
+
+	read := func(session *mcp.ClientSession, uri string) (*mcp.ReadResourceResult, error) {
+		return session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+	}
+
+	t.Run("live, then cached when the knowledge base fails", func(t *testing.T) {
+		server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.1"}, nil)
+		registerEvidenceResources(server, logger, kb, nil, false)
+		session := connectClient(t, server)
+		kb.err = nil
+
+		result, err := read(session, "acmg://evidence/NM_007294.4%3Ac.5266dupC/clinical")
+		require.NoError(t, err)
+		assert.Equal(t, "live", result.Meta["origin"])
+		require.Len(t, result.Contents, 1)
+		assert.Equal(t, "live", result.Contents[0].Meta["origin"])
+		assert.Contains(t, result.Contents[0].Text, "VCV000017661")
+
+		kb.err = errors.New("connection refused")
+		result, err = read(session, "acmg://evidence/NM_007294.4%3Ac.5266dupC/clinical")
+		require.NoError(t, err)
+		assert.Equal(t, "cached", result.Meta["origin"])
+		assert.Contains(t, result.Contents[0].Text, "VCV000017661")
+	})
+
+	t.Run("mock data only when the fallback is enabled", func(t *testing.T) {
+		kb.err = errors.New("connection refused")
+
+		server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.1"}, nil)
+		registerEvidenceResources(server, logger, kb, nil, false)
+		_, err := read(connectClient(t, server), "acmg://evidence/rs80357906/clinical")
+		assert.Error(t, err)
+
+		server = mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.1"}, nil)
+		registerEvidenceResources(server, logger, kb, nil, true)
+		result, err := read(connectClient(t, server), "acmg://evidence/rs80357906/clinical")
+		require.NoError(t, err)
+		assert.Equal(t, "mock", result.Meta["origin"])
+		assert.Equal(t, "mock", result.Contents[0].Meta["origin"])
+	})
+}
+
+func TestRegisterEvidenceResources_ErrorEnvelope(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.1"}, nil)
+	registerEvidenceResources(server, logger, &stubEvidenceKnowledgeBase{err: errors.New("connection refused")}, nil, false)
+	session := connectClient(t, server)
+	uri := "acmg://evidence/rs80357906/clinical"
+
+	// Act
+	_, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri})
+
+	// Assert
+	require.Error(t, err)
+	// The client wraps the server's JSON-RPC error
+	data, marshalErr := json.Marshal(errors.Unwrap(err))
+	require.NoError(t, marshalErr)
+	var wire struct {
+		Code int64                  `json:"code"`
+		Data protocol.ErrorEnvelope `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(data, &wire))
+	assert.Equal(t, int64(protocol.MCPResourceError), wire.Code)
+	assert.Equal(t, protocol.ErrorCodeResourceError, wire.Data.Code)
+	assert.Equal(t, "resource:"+uri, wire.Data.Source)
+	assert.NotEmpty(t, wire.Data.CorrelationID)
+	assert.NotEmpty(t, wire.Data.Message)
+}
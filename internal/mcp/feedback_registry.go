@@ -8,13 +8,18 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/acmg-amp-mcp-server/internal/feedback"
+	"github.com/acmg-amp-mcp-server/internal/followup"
 	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
 )
 
 // registerFeedbackTools registers feedback-related MCP tools.
-func registerFeedbackTools(registry *tools.ToolRegistry, logger *logrus.Logger, store feedback.Store, exportDir string) error {
+// When flags is non-nil, blocking follow-up flags guard changes to signed-out classifications.
+func registerFeedbackTools(registry *tools.ToolRegistry, logger *logrus.Logger, store feedback.Store, flags followup.Store, exportDir string) error {
 	// Create feedback tools
 	submitTool := tools.NewSubmitFeedbackTool(logger, store)
+	if flags != nil {
+		submitTool.SetFollowUpStore(flags)
+	}
 	queryTool := tools.NewQueryFeedbackTool(logger, store)
 	listTool := tools.NewListFeedbackTool(logger, store)
 	exportTool := tools.NewExportFeedbackTool(logger, store, exportDir)
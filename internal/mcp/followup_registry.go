@@ -0,0 +1,30 @@
+// Package mcp provides the MCP server implementation.
+// This file contains follow-up flag tool registration logic.
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/followup"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// registerFollowUpTools registers tools for managing follow-up flags on classifications.
+func registerFollowUpTools(registry *tools.ToolRegistry, logger *logrus.Logger, store followup.Store) error {
+	followUpTools := []tools.Tool{
+		tools.NewFlagClassificationTool(logger, store),
+		tools.NewResolveFlagTool(logger, store),
+		tools.NewFollowUpWorklistTool(logger, store),
+	}
+
+	for _, tool := range followUpTools {
+		if err := registry.RegisterTool(tool); err != nil {
+			return fmt.Errorf("failed to register %s: %w", tool.GetToolInfo().Name, err)
+		}
+		logger.WithField("tool_name", tool.GetToolInfo().Name).Debug("Registered follow-up tool")
+	}
+
+	return nil
+}
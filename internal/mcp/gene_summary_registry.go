@@ -0,0 +1,50 @@
+// Package mcp provides the MCP server implementation.
+// This file contains gene summary resource registration logic.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/resources"
+)
+
+// registerGeneSummaryResource registers the /genes/{symbol}/summary resource
+// template when the audit store keeps a search index to find a gene's
+// classifications.
+func registerGeneSummaryResource(mcpServer *mcp.Server, logger *logrus.Logger, store audit.Store) {
+	searcher, ok := store.(audit.Searcher)
+	if !ok {
+		return
+	}
+	provider := resources.NewGeneSummaryResourceProvider(logger, store, searcher)
+	template := &mcp.ResourceTemplate{
+		Name:        "gene_summary",
+		Title:       "Gene Classification Summary",
+		Description: "Statistics of the classifications stored for a gene: class distribution, most common criteria, hotspot positions and mean evidence completeness",
+		MIMEType:    "application/json",
+		URITemplate: diseaseURIScheme + "/genes/{symbol}/summary",
+	}
+	mcpServer.AddResourceTemplate(template, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		content, err := provider.GetResource(ctx, strings.TrimPrefix(uri, diseaseURIScheme))
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.MCPResourceError, err)
+		}
+		text, err := json.Marshal(content.Content)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InternalError, fmt.Errorf("failed to encode gene summary: %w", err))
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: content.MimeType, Text: string(text)}},
+		}, nil
+	})
+	logger.WithField("uri_template", template.URITemplate).Debug("Registered gene summary resource")
+}
@@ -0,0 +1,46 @@
+// Package mcp provides the MCP server implementation.
+// This file contains background classification job tool registration logic.
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/bulk"
+	"github.com/acmg-amp-mcp-server/internal/jobs"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// registerJobTools registers tools for submitting and polling classification
+// jobs. Tables are read on assembly, like uploads to the table endpoint.
+func registerJobTools(registry *tools.ToolRegistry, logger *logrus.Logger, runner *jobs.Runner, store jobs.Store, maxVariants int, assembly string) error {
+	submit := tools.NewSubmitClassificationJobTool(logger, runner, maxVariants)
+	submit.SetTableParser(func(table string) ([]*jobs.Item, error) {
+		rows, _, err := bulk.ReadTable(strings.NewReader(table), assembly, maxVariants)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]*jobs.Item, len(rows))
+		for i, row := range rows {
+			items[i] = &jobs.Item{Input: row.Input, Notation: row.Notation, Error: row.Error}
+		}
+		return items, nil
+	})
+
+	jobTools := []tools.Tool{
+		submit,
+		tools.NewGetJobStatusTool(logger, store),
+		tools.NewGetJobResultsTool(logger, store),
+	}
+
+	for _, tool := range jobTools {
+		if err := registry.RegisterTool(tool); err != nil {
+			return fmt.Errorf("failed to register %s: %w", tool.GetToolInfo().Name, err)
+		}
+		logger.WithField("tool_name", tool.GetToolInfo().Name).Debug("Registered job tool")
+	}
+
+	return nil
+}
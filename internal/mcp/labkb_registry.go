@@ -0,0 +1,35 @@
+// Package mcp provides the MCP server implementation.
+// This file contains lab knowledge base tool registration logic.
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// registerLabKnowledgeTools registers tools for managing the lab knowledge
+// base and preparing ClinVar submissions from it and the audit trail.
+func registerLabKnowledgeTools(registry *tools.ToolRegistry, logger *logrus.Logger, store labkb.Store, auditStore audit.Store) error {
+	labTools := []tools.Tool{
+		tools.NewSaveLabAssertionTool(logger, store),
+		tools.NewGetLabAssertionTool(logger, store),
+		tools.NewListLabAssertionsTool(logger, store),
+		tools.NewRemoveLabAssertionTool(logger, store),
+		tools.NewExportClinVarSubmissionTool(logger, store),
+		tools.NewPrepareClinVarSubmissionTool(logger, auditStore, store),
+	}
+
+	for _, tool := range labTools {
+		if err := registry.RegisterTool(tool); err != nil {
+			return fmt.Errorf("failed to register %s: %w", tool.GetToolInfo().Name, err)
+		}
+		logger.WithField("tool_name", tool.GetToolInfo().Name).Debug("Registered lab knowledge tool")
+	}
+
+	return nil
+}
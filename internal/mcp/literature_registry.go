@@ -0,0 +1,23 @@
+// Package mcp provides the MCP server implementation.
+// This file contains literature retraction tool registration logic.
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/literature"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// registerLiteratureTools registers the cited literature check tool.
+func registerLiteratureTools(registry *tools.ToolRegistry, logger *logrus.Logger, monitor *literature.Monitor, store literature.Store) error {
+	checkTool := tools.NewCheckCitedLiteratureTool(logger, monitor, store)
+	if err := registry.RegisterTool(checkTool); err != nil {
+		return fmt.Errorf("failed to register %s: %w", checkTool.GetToolInfo().Name, err)
+	}
+	logger.WithField("tool_name", checkTool.GetToolInfo().Name).Debug("Registered literature tool")
+
+	return nil
+}
@@ -0,0 +1,23 @@
+// Package mcp provides the MCP server implementation.
+// This file contains pharmacogenomic annotation tool registration logic.
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/internal/pgx"
+)
+
+// registerPGxTools registers the pharmacogenomic annotation tool.
+func registerPGxTools(registry *tools.ToolRegistry, logger *logrus.Logger, knowledge *pgx.Knowledge) error {
+	pgxTool := tools.NewAnnotatePGxTool(logger, pgx.NewAnnotator(knowledge))
+	if err := registry.RegisterTool(pgxTool); err != nil {
+		return fmt.Errorf("failed to register %s: %w", pgxTool.GetToolInfo().Name, err)
+	}
+	logger.WithField("tool_name", pgxTool.GetToolInfo().Name).Debug("Registered pharmacogenomics tool")
+
+	return nil
+}
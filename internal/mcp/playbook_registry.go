@@ -0,0 +1,59 @@
+// Package mcp provides the MCP server implementation.
+// This file contains gene playbook tool and resource registration logic.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/resources"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/internal/playbook"
+)
+
+// registerPlaybookTools registers gene playbook curation tools. When
+// authRequired is set, edits are only accepted from authenticated curators.
+func registerPlaybookTools(registry *tools.ToolRegistry, logger *logrus.Logger, store playbook.Store, authRequired bool) error {
+	updateTool := tools.NewUpdateGenePlaybookTool(logger, store)
+	updateTool.SetAuthenticationRequired(authRequired)
+	if err := registry.RegisterTool(updateTool); err != nil {
+		return fmt.Errorf("failed to register %s: %w", updateTool.GetToolInfo().Name, err)
+	}
+	logger.WithField("tool_name", updateTool.GetToolInfo().Name).Debug("Registered playbook tool")
+
+	return nil
+}
+
+// registerPlaybookResource registers the /genes/{symbol}/playbook resource
+// template.
+func registerPlaybookResource(mcpServer *mcp.Server, logger *logrus.Logger, store playbook.Store) {
+	provider := resources.NewPlaybookResourceProvider(logger, store)
+	template := &mcp.ResourceTemplate{
+		Name:        "gene_playbook",
+		Title:       "Gene Interpretation Playbook",
+		Description: "Curated interpretation guidance for a gene: VCEP highlights, common pitfalls, frequent benign variants and lab notes",
+		MIMEType:    "application/json",
+		URITemplate: diseaseURIScheme + "/genes/{symbol}/playbook",
+	}
+	mcpServer.AddResourceTemplate(template, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		content, err := provider.GetResource(ctx, strings.TrimPrefix(uri, diseaseURIScheme))
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.MCPResourceError, err)
+		}
+		text, err := json.Marshal(content.Content)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InternalError, fmt.Errorf("failed to encode gene playbook: %w", err))
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: content.MimeType, Text: string(text)}},
+		}, nil
+	})
+	logger.WithField("uri_template", template.URITemplate).Debug("Registered gene playbook resource")
+}
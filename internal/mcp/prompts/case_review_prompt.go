@@ -0,0 +1,568 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// caseReviewHistoryLimit is how many stored classifications of a variant the
+// agenda draws on
+const caseReviewHistoryLimit = 20
+
+// ClassificationHistory reads stored classifications, such as an audit.Store
+type ClassificationHistory interface {
+	Query(ctx context.Context, filter audit.Filter) ([]*audit.Record, error)
+}
+
+// CaseReviewPrompt prepares the agenda of a variant review board meeting
+// from the stored classifications of the variants under discussion
+type CaseReviewPrompt struct {
+	logger    *logrus.Logger
+	renderer  *TemplateRenderer
+	validator *ArgumentValidator
+	history   ClassificationHistory
+}
+
+// NewCaseReviewPrompt creates a case review preparation prompt template that
+// reads classifications from history
+func NewCaseReviewPrompt(logger *logrus.Logger, history ClassificationHistory) *CaseReviewPrompt {
+	return &CaseReviewPrompt{
+		logger:    logger,
+		renderer:  NewTemplateRenderer(logger),
+		validator: NewArgumentValidator(logger),
+		history:   history,
+	}
+}
+
+// GetPromptInfo returns metadata about this prompt template
+func (crp *CaseReviewPrompt) GetPromptInfo() PromptInfo {
+	return PromptInfo{
+		Name:        "case_review_prep",
+		Description: "Structured agenda for a variant review board meeting, with each variant's stored classification, conflicting evidence and discussion points",
+		Version:     "1.0.0",
+		Arguments: []ArgumentInfo{
+			{
+				Name:        "variant_ids",
+				Description: "Variants to review, by variant ID or HGVS notation; an array or a comma-separated list",
+				Type:        "array",
+				Required:    true,
+				Examples:    []string{"[\"NM_007294.4:c.5266dup\", \"NM_000492.4:c.1521_1523del\"]", "NM_007294.4:c.5266dup, NM_000059.4:c.68-7T>A"},
+			},
+			{
+				Name:        "meeting_date",
+				Description: "Date of the review board meeting",
+				Type:        "string",
+				Required:    false,
+				Examples:    []string{"2026-11-03"},
+			},
+			{
+				Name:         "minutes_per_variant",
+				Description:  "Discussion time allotted to each variant with open questions",
+				Type:         "integer",
+				Required:     false,
+				DefaultValue: 10,
+			},
+			{
+				Name:         "include_history",
+				Description:  "Whether to list each variant's earlier classifications",
+				Type:         "boolean",
+				Required:     false,
+				DefaultValue: true,
+			},
+		},
+		Examples: []PromptExample{
+			{
+				Name:        "Monthly review board",
+				Description: "Agenda for the variants queued for discussion",
+				Arguments: map[string]interface{}{
+					"variant_ids":         []string{"NM_007294.4:c.5266dup", "NM_000492.4:c.1521_1523del", "NM_000059.4:c.68-7T>A"},
+					"meeting_date":        "2026-11-03",
+					"minutes_per_variant": 15,
+				},
+				ExpectedUse: "Circulate before a variant review board meeting",
+			},
+		},
+		Tags:       []string{"review", "meeting", "variant_review_board", "classification", "conflicts"},
+		Category:   "clinical_review",
+		Difficulty: "intermediate",
+		UsageNotes: []string{
+			"Variants must have been classified; unclassified variants are flagged on the agenda",
+			"Variants with conflicting criteria or changed classifications are discussed first",
+			"Record the board's decision for each variant in the lab knowledge base",
+		},
+		Metadata: map[string]interface{}{
+			"data_sources": []string{"classification_audit_trail"},
+			"target_users": []string{"variant_review_board", "clinical_geneticists", "laboratory_directors", "genetic_counselors"},
+		},
+	}
+}
+
+// caseReview is what the agenda says about one variant
+type caseReview struct {
+	variant    string
+	records    []*audit.Record // Successful classifications, newest first
+	failures   int
+	err        error
+	pathogenic []string // Applied pathogenic criteria of the latest classification
+	benign     []string // Applied benign criteria of the latest classification
+	clinVar    *domain.ClinVarData
+	points     []string // Discussion points
+}
+
+// latest returns the variant's newest successful classification, or nil
+func (r *caseReview) latest() *audit.Record {
+	if len(r.records) == 0 {
+		return nil
+	}
+	return r.records[0]
+}
+
+// previous returns the newest earlier classification that differs from the
+// latest, or nil
+func (r *caseReview) previous() *audit.Record {
+	latest := r.latest()
+	if latest == nil {
+		return nil
+	}
+	for _, record := range r.records[min(1, len(r.records)):] {
+		if !strings.EqualFold(record.Classification, latest.Classification) {
+			return record
+		}
+	}
+	return nil
+}
+
+// contested reports whether the variant has an open question for the board
+// beyond confirming its classification
+func (r *caseReview) contested() bool {
+	return r.latest() == nil || (len(r.pathogenic) > 0 && len(r.benign) > 0) || r.previous() != nil || clinVarConflicting(r.clinVar)
+}
+
+// RenderPrompt renders the prompt with given arguments
+func (crp *CaseReviewPrompt) RenderPrompt(ctx context.Context, args map[string]interface{}) (*RenderedPrompt, error) {
+	crp.logger.WithField("args", args).Debug("Rendering case review preparation prompt")
+
+	args = crp.normalizeArguments(args)
+	variants := crp.getArrayArg(args, "variant_ids", nil)
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("no variants to review")
+	}
+	meetingDate := crp.getStringArg(args, "meeting_date", "")
+	minutes := crp.getIntArg(args, "minutes_per_variant", 10)
+	includeHistory := crp.getBoolArg(args, "include_history", true)
+
+	reviews := make([]*caseReview, len(variants))
+	for i, variant := range variants {
+		reviews[i] = crp.review(ctx, variant)
+	}
+	// Open questions first, otherwise in the order given
+	sort.SliceStable(reviews, func(i, j int) bool {
+		return reviews[i].contested() && !reviews[j].contested()
+	})
+
+	content := crp.buildPromptContent(reviews, meetingDate, minutes, includeHistory)
+
+	contested := 0
+	for _, review := range reviews {
+		if review.contested() {
+			contested++
+		}
+	}
+
+	rendered := &RenderedPrompt{
+		Name:         "case_review_prep",
+		Content:      content,
+		SystemPrompt: crp.buildSystemPrompt(),
+		UserPrompt:   fmt.Sprintf("Prepare the variant review board meeting for %d variants, %d with open questions. Refine the discussion points and propose a decision for each variant to confirm at the meeting.", len(reviews), contested),
+		Context:      fmt.Sprintf("Variant review board meeting %s: %d variants", meetingDateLabel(meetingDate), len(reviews)),
+		Instructions: []string{
+			"Discuss variants with conflicting criteria or changed classifications first",
+			"For each variant, agree a classification and the evidence it rests on",
+			"Record dissenting opinions and the evidence that would change the decision",
+			"Assign follow-up actions, such as segregation studies or patient recontact, with an owner",
+		},
+		References: []string{
+			"Richards, S. et al. Standards and guidelines for the interpretation of sequence variants. Genet Med. 2015;17(5):405-24.",
+			"Harrison, S.M. et al. Clinical laboratories collaborate to resolve differences in variant interpretations submitted to ClinVar. Genet Med. 2017;19(10):1096-1104.",
+		},
+		Arguments:   args,
+		GeneratedAt: time.Now(),
+		Metadata: map[string]interface{}{
+			"variants":     len(reviews),
+			"contested":    contested,
+			"meeting_date": meetingDate,
+			"generated_by": "case_review_prompt_v1.0.0",
+		},
+	}
+
+	crp.logger.WithFields(logrus.Fields{
+		"variants":       len(reviews),
+		"contested":      contested,
+		"content_length": len(content),
+	}).Info("Generated case review preparation prompt")
+
+	return rendered, nil
+}
+
+// review gathers a variant's stored classifications and derives its
+// discussion points
+func (crp *CaseReviewPrompt) review(ctx context.Context, variant string) *caseReview {
+	review := &caseReview{variant: variant}
+	if crp.history == nil {
+		review.err = fmt.Errorf("no classification store configured")
+	} else {
+		records, err := crp.history.Query(ctx, audit.Filter{Variant: variant, Limit: caseReviewHistoryLimit})
+		if err != nil {
+			review.err = err
+		}
+		for _, record := range records {
+			if record.Error != "" {
+				review.failures++
+				continue
+			}
+			review.records = append(review.records, record)
+		}
+	}
+
+	if latest := review.latest(); latest != nil {
+		review.pathogenic, review.benign = appliedCriteria(latest.AppliedRules)
+		var evidence domain.AggregatedEvidence
+		if len(latest.Evidence) > 0 && json.Unmarshal(latest.Evidence, &evidence) == nil {
+			review.clinVar = evidence.ClinVarData
+		}
+	}
+	review.points = discussionPoints(review)
+	return review
+}
+
+// appliedCriteria lists the applied pathogenic and benign criteria of a
+// stored classification with their strengths, e.g. "PS3 (strong)"
+func appliedCriteria(appliedRules json.RawMessage) (pathogenic, benign []string) {
+	var rules []struct {
+		RuleCode string `json:"rule_code"`
+		Category string `json:"category"`
+		Strength string `json:"strength"`
+		Applied  bool   `json:"applied"`
+	}
+	if len(appliedRules) == 0 || json.Unmarshal(appliedRules, &rules) != nil {
+		return nil, nil
+	}
+	for _, rule := range rules {
+		if !rule.Applied {
+			continue
+		}
+		label := rule.RuleCode
+		if rule.Strength != "" {
+			label += " (" + strings.ToLower(rule.Strength) + ")"
+		}
+		if strings.EqualFold(rule.Category, string(domain.BENIGN_RULE)) || strings.HasPrefix(rule.RuleCode, "B") {
+			benign = append(benign, label)
+		} else {
+			pathogenic = append(pathogenic, label)
+		}
+	}
+	return pathogenic, benign
+}
+
+// clinVarConflicting reports whether ClinVar submitters disagree on the variant
+func clinVarConflicting(clinVar *domain.ClinVarData) bool {
+	return clinVar != nil && strings.Contains(strings.ToLower(clinVar.ClinicalSignificance), "conflicting")
+}
+
+// discussionPoints are the questions the board should settle for a variant
+func discussionPoints(review *caseReview) []string {
+	latest := review.latest()
+	if latest == nil {
+		if review.err != nil {
+			return []string{fmt.Sprintf("Classification history unavailable (%v); check the variant before the meeting", review.err)}
+		}
+		return []string{"No stored classification: classify the variant before the meeting or remove it from the agenda"}
+	}
+
+	var points []string
+	if len(review.pathogenic) > 0 && len(review.benign) > 0 {
+		points = append(points, fmt.Sprintf("Conflicting criteria: %s against %s; agree which evidence prevails and at what strength",
+			strings.Join(review.pathogenic, ", "), strings.Join(review.benign, ", ")))
+	}
+	if previous := review.previous(); previous != nil {
+		points = append(points, fmt.Sprintf("Classification changed from %s (%s) to %s; confirm the change and decide whether reported patients need recontact",
+			previous.Classification, previous.CreatedAt.Format("2006-01-02"), latest.Classification))
+	}
+	if clinVarConflicting(review.clinVar) {
+		point := "ClinVar reports conflicting interpretations"
+		if review.clinVar.ReviewStatus != "" {
+			point += " (" + review.clinVar.ReviewStatus + ")"
+		}
+		points = append(points, point+"; compare the submitters' evidence with ours")
+	}
+	if classification, err := domain.ParseClassification(latest.Classification); err == nil && classification == domain.VUS {
+		points = append(points, "Uncertain significance: identify the evidence that would resolve it, such as segregation, functional or de novo data")
+	}
+	if strings.EqualFold(latest.Confidence, domain.LOW.String()) {
+		points = append(points, "Low confidence call; review the evidence summary before signing out")
+	}
+	if review.failures > 0 {
+		points = append(points, fmt.Sprintf("%d classification attempts failed; check the evidence sources", review.failures))
+	}
+	if len(points) == 0 {
+		points = append(points, "No open questions: confirm the classification for sign-out")
+	}
+	return points
+}
+
+// buildPromptContent renders the agenda
+func (crp *CaseReviewPrompt) buildPromptContent(reviews []*caseReview, meetingDate string, minutes int, includeHistory bool) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("# Variant Review Board Agenda: %s\n\n", meetingDateLabel(meetingDate)))
+
+	// Overview table, in agenda order
+	builder.WriteString("## Overview\n\n")
+	rows := make([][]string, len(reviews))
+	total := 0
+	for i, review := range reviews {
+		classification, classified := "not classified", ""
+		if latest := review.latest(); latest != nil {
+			classification = latest.Classification
+			classified = latest.CreatedAt.Format("2006-01-02")
+		}
+		allotted := minutes
+		if !review.contested() {
+			allotted = max(1, minutes/2)
+		}
+		total += allotted
+		rows[i] = []string{fmt.Sprintf("%d", i+1), review.variant, classification, classified, fmt.Sprintf("%d min", allotted)}
+	}
+	builder.WriteString(crp.renderer.FormatTable([]string{"#", "Variant", "Classification", "Classified", "Time"}, rows))
+	builder.WriteString(fmt.Sprintf("\nTotal discussion time: %d minutes\n\n", total))
+
+	// One section per variant
+	builder.WriteString("## Agenda\n\n")
+	for i, review := range reviews {
+		builder.WriteString(fmt.Sprintf("### %d. %s\n\n", i+1, review.variant))
+		if latest := review.latest(); latest != nil {
+			details := []string{
+				fmt.Sprintf("**Classification:** %s (confidence %s), %s", latest.Classification, orNone(latest.Confidence), latest.CreatedAt.Format("2006-01-02")),
+				fmt.Sprintf("**Applied pathogenic criteria:** %s", orNone(strings.Join(review.pathogenic, ", "))),
+				fmt.Sprintf("**Applied benign criteria:** %s", orNone(strings.Join(review.benign, ", "))),
+			}
+			if review.clinVar != nil && review.clinVar.ClinicalSignificance != "" {
+				details = append(details, fmt.Sprintf("**ClinVar:** %s", review.clinVar.ClinicalSignificance))
+			}
+			if latest.Specification != "" {
+				details = append(details, fmt.Sprintf("**VCEP specification:** %s", latest.Specification))
+			}
+			builder.WriteString(crp.renderer.FormatList(details, false))
+			builder.WriteString("\n")
+
+			if includeHistory && len(review.records) > 1 {
+				builder.WriteString("**History:**\n\n")
+				history := make([]string, 0, len(review.records)-1)
+				for _, record := range review.records[1:] {
+					history = append(history, fmt.Sprintf("%s: %s", record.CreatedAt.Format("2006-01-02"), record.Classification))
+				}
+				builder.WriteString(crp.renderer.FormatList(history, false))
+				builder.WriteString("\n")
+			}
+		}
+		builder.WriteString("**Discussion points:**\n\n")
+		builder.WriteString(crp.renderer.FormatList(review.points, true))
+		builder.WriteString("\n**Decision:** ____________________\n\n")
+	}
+
+	builder.WriteString("## Actions\n\n")
+	builder.WriteString(crp.renderer.FormatList([]string{
+		"Record each decision, with dissenting opinions, in the lab knowledge base",
+		"Reclassify variants whose evidence changed and sign out the new classification",
+		"Raise follow-up flags for recontact, segregation studies or functional assays",
+	}, false))
+
+	return builder.String()
+}
+
+// buildSystemPrompt builds the system prompt
+func (crp *CaseReviewPrompt) buildSystemPrompt() string {
+	return `You are assisting a clinical laboratory's variant review board. Using the agenda's stored classifications and evidence, help the board reach a consensus classification for each variant under the ACMG/AMP guidelines. Highlight conflicting evidence, state which criteria are disputed and at what strength, and distinguish the laboratory's evidence from external assertions. Do not change a classification without stating the evidence that supports the change.`
+}
+
+// meetingDateLabel names the meeting by its date, if given
+func meetingDateLabel(meetingDate string) string {
+	if meetingDate == "" {
+		return "upcoming meeting"
+	}
+	return meetingDate
+}
+
+// orNone returns s, or "none" when s is empty
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// ValidateArguments validates the provided arguments
+func (crp *CaseReviewPrompt) ValidateArguments(args map[string]interface{}) error {
+	args = crp.normalizeArguments(args)
+	if err := crp.validator.ValidateArguments(args, crp.GetPromptInfo().Arguments); err != nil {
+		return err
+	}
+	if len(crp.getArrayArg(args, "variant_ids", nil)) == 0 {
+		return fmt.Errorf("argument 'variant_ids' must list at least one variant")
+	}
+	return nil
+}
+
+// normalizeArguments converts arguments given as strings, as MCP clients
+// pass them, to their types: variant_ids from a comma-separated list, and
+// minutes_per_variant and include_history from their text
+func (crp *CaseReviewPrompt) normalizeArguments(args map[string]interface{}) map[string]interface{} {
+	normalized := make(map[string]interface{}, len(args))
+	for name, value := range args {
+		normalized[name] = value
+	}
+	switch value := args["variant_ids"].(type) {
+	case string:
+		var variants []interface{}
+		for _, variant := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' }) {
+			variants = append(variants, variant)
+		}
+		normalized["variant_ids"] = variants
+	case []string:
+		variants := make([]interface{}, len(value))
+		for i, variant := range value {
+			variants[i] = variant
+		}
+		normalized["variant_ids"] = variants
+	}
+	if value, ok := args["minutes_per_variant"].(string); ok {
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			normalized["minutes_per_variant"] = n
+		}
+	}
+	if value, ok := args["include_history"].(string); ok {
+		if b, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			normalized["include_history"] = b
+		}
+	}
+	return normalized
+}
+
+// GetArgumentSchema returns the JSON schema for prompt arguments
+func (crp *CaseReviewPrompt) GetArgumentSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"variant_ids": map[string]interface{}{
+				"description": "Variants to review, by variant ID or HGVS notation",
+				"oneOf": []interface{}{
+					map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "minItems": 1},
+					map[string]interface{}{"type": "string"},
+				},
+			},
+			"meeting_date": map[string]interface{}{
+				"type":        "string",
+				"description": "Date of the review board meeting",
+			},
+			"minutes_per_variant": map[string]interface{}{
+				"type":        "integer",
+				"description": "Discussion time allotted to each variant with open questions",
+				"minimum":     1,
+				"default":     10,
+			},
+			"include_history": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Whether to list each variant's earlier classifications",
+				"default":     true,
+			},
+		},
+		"required": []string{"variant_ids"},
+	}
+}
+
+// SupportsPrompt checks if this template can handle the given prompt name
+func (crp *CaseReviewPrompt) SupportsPrompt(name string) bool {
+	supportedNames := []string{
+		"case_review_prep",
+		"case-review-prep",
+		"case_review",
+		"case-review",
+		"variant_review_board",
+		"variant-review-board",
+	}
+
+	for _, supported := range supportedNames {
+		if name == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper methods for argument extraction
+func (crp *CaseReviewPrompt) getArrayArg(args map[string]interface{}, key string, defaultValue []string) []string {
+	value, exists := args[key]
+	if !exists {
+		return defaultValue
+	}
+	arr, ok := value.([]interface{})
+	if !ok {
+		return defaultValue
+	}
+	seen := make(map[string]bool, len(arr))
+	result := make([]string, 0, len(arr))
+	for _, item := range arr {
+		str, ok := item.(string)
+		str = strings.TrimSpace(str)
+		if ok && str != "" && !seen[str] {
+			seen[str] = true
+			result = append(result, str)
+		}
+	}
+	return result
+}
+
+func (crp *CaseReviewPrompt) getStringArg(args map[string]interface{}, key, defaultValue string) string {
+	if value, exists := args[key]; exists {
+		if str, ok := value.(string); ok {
+			return str
+		}
+	}
+	return defaultValue
+}
+
+func (crp *CaseReviewPrompt) getIntArg(args map[string]interface{}, key string, defaultValue int) int {
+	if value, exists := args[key]; exists {
+		switch v := value.(type) {
+		case int:
+			if v > 0 {
+				return v
+			}
+		case float64:
+			if v >= 1 {
+				return int(v)
+			}
+		}
+	}
+	return defaultValue
+}
+
+func (crp *CaseReviewPrompt) getBoolArg(args map[string]interface{}, key string, defaultValue bool) bool {
+	if value, exists := args[key]; exists {
+		if b, ok := value.(bool); ok {
+			return b
+		}
+	}
+	return defaultValue
+}
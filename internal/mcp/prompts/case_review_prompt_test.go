@@ -0,0 +1,138 @@
+package prompts
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/i18n"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// fakeHistory serves stored classifications by variant, newest first
+type fakeHistory map[string][]*audit.Record
+
+func (h fakeHistory) Query(_ context.Context, filter audit.Filter) ([]*audit.Record, error) {
+	return h[filter.Variant], nil
+}
+
+func TestCaseReviewPrompt_RenderPrompt(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	history := fakeHistory{
+		"NM_000492.4:c.1521_1523del": {
+			{Classification: "Pathogenic", Confidence: "HIGH", CreatedAt: day,
+				AppliedRules: []byte(`[{"rule_code":"PS3","category":"PATHOGENIC","strength":"STRONG","applied":true}]`)},
+		},
+		"NM_007294.4:c.5096G>A": {
+			{Classification: "Likely pathogenic", Confidence: "MEDIUM", CreatedAt: day,
+				AppliedRules: []byte(`[{"rule_code":"PS3","category":"PATHOGENIC","strength":"STRONG","applied":true},` +
+					`{"rule_code":"BP4","category":"BENIGN","strength":"SUPPORTING","applied":true},` +
+					`{"rule_code":"PM2","category":"PATHOGENIC","strength":"MODERATE","applied":false}]`)},
+			{Classification: "Uncertain significance", CreatedAt: day.AddDate(0, -6, 0)},
+			{Error: "ClinVar unavailable", CreatedAt: day.AddDate(0, -7, 0)},
+		},
+	}
+	prompt := NewCaseReviewPrompt(logger, history)
+
+	// Act: variant IDs as MCP clients send them, as one string
+	args := map[string]interface{}{
+		"variant_ids":         "NM_000492.4:c.1521_1523del, NM_007294.4:c.5096G>A, NM_000059.4:c.68-7T>A",
+		"meeting_date":        "2026-03-10",
+		"minutes_per_variant": "8",
+	}
+	require.NoError(t, prompt.ValidateArguments(args))
+	rendered, err := prompt.RenderPrompt(context.Background(), args)
+
+	// Assert
+	require.NoError(t, err)
+	content := rendered.Content
+	assert.Contains(t, content, "# Variant Review Board Agenda: 2026-03-10")
+	assert.Contains(t, content, "Total discussion time: 20 minutes")
+
+	// Contested variants first, otherwise in the order given
+	assert.Contains(t, content, "### 1. NM_007294.4:c.5096G>A")
+	assert.Contains(t, content, "### 2. NM_000059.4:c.68-7T>A")
+	assert.Contains(t, content, "### 3. NM_000492.4:c.1521_1523del")
+
+	assert.Contains(t, content, "Conflicting criteria: PS3 (strong) against BP4 (supporting)")
+	assert.NotContains(t, content, "PM2", "criteria not met are not listed")
+	assert.Contains(t, content, "Classification changed from Uncertain significance (2025-09-02) to Likely pathogenic")
+	assert.Contains(t, content, "1 classification attempts failed")
+	assert.Contains(t, content, "No stored classification")
+	assert.Contains(t, content, "No open questions: confirm the classification for sign-out")
+	assert.Equal(t, 2, rendered.Metadata["contested"])
+
+	// History is left out on request
+	args["include_history"] = "false"
+	rendered, err = prompt.RenderPrompt(context.Background(), args)
+	require.NoError(t, err)
+	assert.NotContains(t, rendered.Content, "**History:**")
+}
+
+func TestCaseReviewPrompt_ValidateArguments(t *testing.T) {
+	prompt := NewCaseReviewPrompt(logrus.New(), fakeHistory{})
+
+	assert.NoError(t, prompt.ValidateArguments(map[string]interface{}{"variant_ids": []interface{}{"NM_000492.4:c.1521_1523del"}}))
+	assert.Error(t, prompt.ValidateArguments(map[string]interface{}{}))
+	assert.Error(t, prompt.ValidateArguments(map[string]interface{}{"variant_ids": " , "}))
+	assert.True(t, prompt.SupportsPrompt("variant-review-board"))
+}
+
+func TestHandler_HandlePrompt(t *testing.T) {
+	handler := NewHandler(logrus.New(), NewCaseReviewPrompt(logrus.New(), fakeHistory{}))
+	assert.Equal(t, "case_review_prep", handler.GetPromptInfo().Name)
+
+	resp := handler.HandlePrompt(context.Background(), &protocol.JSONRPC2Request{
+		Params: map[string]interface{}{"variant_ids": "NM_000059.4:c.68-7T>A"},
+	})
+	require.Nil(t, resp.Error)
+	result := resp.Result.(map[string]interface{})
+	messages := result["messages"].([]map[string]interface{})
+	require.Len(t, messages, 1)
+	assert.Equal(t, "user", messages[0]["role"])
+	text := messages[0]["content"].(map[string]interface{})["text"].(string)
+	assert.True(t, strings.HasPrefix(text, "You are assisting a clinical laboratory's variant review board."))
+	assert.Contains(t, text, "### 1. NM_000059.4:c.68-7T>A")
+
+	resp = handler.HandlePrompt(context.Background(), &protocol.JSONRPC2Request{})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, protocol.InvalidParams, resp.Error.Code)
+}
+
+func TestHandler_Locale(t *testing.T) {
+	handler := NewHandler(logrus.New(), NewCaseReviewPrompt(logrus.New(), fakeHistory{}))
+	handler.SetLocale(i18n.Japanese)
+	info := handler.GetPromptInfo()
+	assert.Equal(t, "locale", info.Arguments[len(info.Arguments)-1].Name)
+
+	resp := handler.HandlePrompt(context.Background(), &protocol.JSONRPC2Request{
+		Params: map[string]interface{}{"variant_ids": "NM_000059.4:c.68-7T>A"},
+	})
+	require.Nil(t, resp.Error)
+	text := resp.Result.(map[string]interface{})["messages"].([]map[string]interface{})[0]["content"].(map[string]interface{})["text"].(string)
+	assert.True(t, strings.HasPrefix(text, "回答はすべて日本語で作成してください。"))
+
+	// A request's locale overrides the handler's
+	resp = handler.HandlePrompt(context.Background(), &protocol.JSONRPC2Request{
+		Params: map[string]interface{}{"variant_ids": "NM_000059.4:c.68-7T>A", "locale": "en"},
+	})
+	require.Nil(t, resp.Error)
+	text = resp.Result.(map[string]interface{})["messages"].([]map[string]interface{})[0]["content"].(map[string]interface{})["text"].(string)
+	assert.True(t, strings.HasPrefix(text, "You are assisting"))
+
+	resp = handler.HandlePrompt(context.Background(), &protocol.JSONRPC2Request{
+		Params: map[string]interface{}{"locale": "zh-Hant"},
+	})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "提示參數無效", resp.Error.Message)
+	assert.Error(t, handler.ValidateParams(map[string]interface{}{"variant_ids": "NM_000059.4:c.68-7T>A", "locale": "fr"}))
+}
@@ -0,0 +1,123 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/i18n"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// Handler serves a prompt template through the MCP prompts/get method,
+// returning the rendered prompt as a single user message. A locale argument,
+// or else the handler's locale, sets the language the model is asked to
+// respond in and the language of error messages.
+type Handler struct {
+	logger   *logrus.Logger
+	template PromptTemplate
+	locale   i18n.Locale
+}
+
+// NewHandler creates a prompt handler for template
+func NewHandler(logger *logrus.Logger, template PromptTemplate) *Handler {
+	return &Handler{logger: logger, template: template}
+}
+
+// SetLocale sets the locale of requests that do not give one
+func (h *Handler) SetLocale(locale i18n.Locale) {
+	h.locale = locale
+}
+
+// HandlePrompt renders the prompt with the request's arguments
+func (h *Handler) HandlePrompt(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	args, locale, err := h.arguments(req.Params)
+	if err == nil {
+		err = h.template.ValidateArguments(args)
+	}
+	if err != nil {
+		return &protocol.JSONRPC2Response{
+			Error: &protocol.RPCError{Code: protocol.InvalidParams, Message: locale.T("error.invalid_prompt_arguments"), Data: err.Error()},
+		}
+	}
+
+	rendered, err := h.template.RenderPrompt(i18n.WithLocale(ctx, locale), args)
+	if err != nil {
+		h.logger.WithError(err).WithField("prompt", h.template.GetPromptInfo().Name).Error("Failed to render prompt")
+		return &protocol.JSONRPC2Response{
+			Error: &protocol.RPCError{Code: protocol.InternalError, Message: locale.T("error.prompt_render_failed"), Data: err.Error()},
+		}
+	}
+
+	// MCP prompts carry no system role: the system prompt leads the message
+	text := rendered.Content
+	if rendered.SystemPrompt != "" {
+		text = rendered.SystemPrompt + "\n\n" + text
+	}
+	// A template's own language argument takes precedence over the locale
+	if _, ok := args["language"]; !ok && locale != i18n.English {
+		text = locale.T("prompt.respond_in") + "\n\n" + text
+	}
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"description": h.template.GetPromptInfo().Description,
+			"messages": []map[string]interface{}{{
+				"role":    "user",
+				"content": map[string]interface{}{"type": "text", "text": text},
+			}},
+		},
+	}
+}
+
+// GetPromptInfo returns the prompt's name, description and arguments
+func (h *Handler) GetPromptInfo() protocol.PromptInfo {
+	info := h.template.GetPromptInfo()
+	arguments := make([]protocol.PromptArgument, len(info.Arguments))
+	for i, arg := range info.Arguments {
+		arguments[i] = protocol.PromptArgument{Name: arg.Name, Description: arg.Description, Required: arg.Required}
+	}
+	arguments = append(arguments, protocol.PromptArgument{
+		Name:        "locale",
+		Description: "Language to respond in: " + strings.Join(i18n.Strings(), ", ") + "; defaults to the server's locale",
+	})
+	return protocol.PromptInfo{Name: info.Name, Description: info.Description, Arguments: arguments}
+}
+
+// ValidateParams validates prompt arguments
+func (h *Handler) ValidateParams(params interface{}) error {
+	if _, ok := params.(map[string]interface{}); !ok {
+		return fmt.Errorf("prompt arguments must be an object")
+	}
+	args, _, err := h.arguments(params)
+	if err != nil {
+		return err
+	}
+	return h.template.ValidateArguments(args)
+}
+
+// arguments separates the locale argument, which every prompt accepts, from
+// the template's arguments
+func (h *Handler) arguments(params interface{}) (map[string]interface{}, i18n.Locale, error) {
+	locale := h.locale
+	if locale == "" {
+		locale = i18n.English
+	}
+	given, _ := params.(map[string]interface{})
+	args := make(map[string]interface{}, len(given))
+	for name, value := range given {
+		args[name] = value
+	}
+	tag, ok := args["locale"]
+	if !ok {
+		return args, locale, nil
+	}
+	delete(args, "locale")
+	tagString, _ := tag.(string)
+	parsed, err := i18n.ParseLocale(tagString)
+	if err != nil {
+		return args, locale, err
+	}
+	return args, parsed, nil
+}
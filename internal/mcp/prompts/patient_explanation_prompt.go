@@ -0,0 +1,566 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/i18n"
+)
+
+// explanationHistoryLimit is how many stored classifications are searched for
+// the latest successful one
+const explanationHistoryLimit = 10
+
+// readingLevel describes how plainly a patient explanation is written
+type readingLevel struct {
+	grade    string // Approximate school grade, for the writer
+	sentence int    // Longest sentence, in words
+	rules    []string
+}
+
+// readingLevels are the supported reading levels, by argument value
+var readingLevels = map[string]readingLevel{
+	"basic": {
+		grade:    "about 6th grade",
+		sentence: 15,
+		rules: []string{
+			"Use everyday words; replace every genetic term with a plain description",
+			"Do not use percentages or odds; say \"very likely\" or \"we do not know yet\"",
+			"Keep to one idea per sentence and short paragraphs of two or three sentences",
+		},
+	},
+	"standard": {
+		grade:    "about 8th grade",
+		sentence: 20,
+		rules: []string{
+			"Use everyday words; explain a genetic term in plain words the first time it is used",
+			"Give likelihoods in words, and as \"9 out of 10\" style numbers only when they help",
+			"Use headings and short paragraphs",
+		},
+	},
+	"advanced": {
+		grade:    "high school or college",
+		sentence: 25,
+		rules: []string{
+			"Genetic terms such as variant, pathogenic and uncertain significance may be used with a short definition",
+			"Likelihoods may be given as numbers with what they mean for the patient",
+			"Explain briefly how the laboratory weighed the evidence",
+		},
+	},
+}
+
+// languageNames names the languages given by code; other values are used as given
+var languageNames = map[string]string{
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"de": "German",
+	"it": "Italian",
+	"pt": "Portuguese",
+	"nl": "Dutch",
+	"zh": "Chinese",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"vi": "Vietnamese",
+	"ar": "Arabic",
+
+	"zh-hant": "Traditional Chinese",
+}
+
+// PatientExplanationPrompt renders a plain-language explanation of a
+// classification result for genetic counselors preparing patient
+// communications
+type PatientExplanationPrompt struct {
+	logger    *logrus.Logger
+	renderer  *TemplateRenderer
+	validator *ArgumentValidator
+	history   ClassificationHistory
+}
+
+// NewPatientExplanationPrompt creates a patient explanation prompt template
+// that reads a variant's latest classification from history when none is
+// given
+func NewPatientExplanationPrompt(logger *logrus.Logger, history ClassificationHistory) *PatientExplanationPrompt {
+	return &PatientExplanationPrompt{
+		logger:    logger,
+		renderer:  NewTemplateRenderer(logger),
+		validator: NewArgumentValidator(logger),
+		history:   history,
+	}
+}
+
+// GetPromptInfo returns metadata about this prompt template
+func (pep *PatientExplanationPrompt) GetPromptInfo() PromptInfo {
+	return PromptInfo{
+		Name:        "patient_explanation",
+		Description: "Plain-language explanation of a variant classification for a patient, at a chosen reading level and language",
+		Version:     "1.0.0",
+		Arguments: []ArgumentInfo{
+			{
+				Name:        "variant",
+				Description: "Variant to explain, by variant ID or HGVS notation",
+				Type:        "string",
+				Required:    true,
+				Examples:    []string{"NM_007294.4:c.5266dup", "NM_000492.4:c.1521_1523del"},
+			},
+			{
+				Name:        "classification",
+				Description: "Classification to explain; the variant's latest stored classification when omitted",
+				Type:        "string",
+				Required:    false,
+				Examples:    []string{"Pathogenic", "Uncertain significance", "LIKELY_BENIGN"},
+			},
+			{
+				Name:        "gene",
+				Description: "Gene symbol of the variant",
+				Type:        "string",
+				Required:    false,
+				Examples:    []string{"BRCA1", "CFTR"},
+			},
+			{
+				Name:        "condition",
+				Description: "Condition the variant was tested for",
+				Type:        "string",
+				Required:    false,
+				Examples:    []string{"hereditary breast and ovarian cancer", "cystic fibrosis"},
+			},
+			{
+				Name:         "reading_level",
+				Description:  "Reading level of the explanation",
+				Type:         "string",
+				Required:     false,
+				DefaultValue: "standard",
+				Examples:     []string{"basic", "standard", "advanced"},
+				Constraints:  []string{"enum:basic,standard,advanced"},
+			},
+			{
+				Name:         "language",
+				Description:  "Language of the explanation, by code or name; the request's locale when omitted",
+				Type:         "string",
+				Required:     false,
+				DefaultValue: "en",
+				Examples:     []string{"en", "es", "Tagalog"},
+			},
+			{
+				Name:         "include_actionability",
+				Description:  "Whether to say what the result means for medical care and for relatives",
+				Type:         "boolean",
+				Required:     false,
+				DefaultValue: true,
+			},
+		},
+		Examples: []PromptExample{
+			{
+				Name:        "Pathogenic result in Spanish",
+				Description: "Explanation of a pathogenic BRCA1 variant at a basic reading level",
+				Arguments: map[string]interface{}{
+					"variant":       "NM_007294.4:c.5266dup",
+					"gene":          "BRCA1",
+					"condition":     "hereditary breast and ovarian cancer",
+					"reading_level": "basic",
+					"language":      "es",
+				},
+				ExpectedUse: "Draft a result letter for the patient",
+			},
+			{
+				Name:        "Uncertain result without care statements",
+				Description: "Explanation of a variant of uncertain significance, leaving care to the clinician",
+				Arguments: map[string]interface{}{
+					"variant":               "NM_000059.4:c.68-7T>A",
+					"classification":        "Uncertain significance",
+					"include_actionability": false,
+				},
+				ExpectedUse: "Prepare talking points for a counseling session",
+			},
+		},
+		Tags:       []string{"patient", "counseling", "plain_language", "communication", "classification"},
+		Category:   "patient_communication",
+		Difficulty: "beginner",
+		UsageNotes: []string{
+			"A genetic counselor should review the explanation before it reaches the patient",
+			"Explanations of uncertain results always say the result should not change care",
+			"Leave out actionability statements when the ordering clinician will discuss care",
+		},
+		Metadata: map[string]interface{}{
+			"data_sources": []string{"classification_audit_trail"},
+			"target_users": []string{"genetic_counselors", "clinical_geneticists"},
+		},
+	}
+}
+
+// explanationSubject is the classification result being explained
+type explanationSubject struct {
+	variant        string
+	gene           string
+	condition      string
+	classification domain.Classification
+	classified     time.Time // Zero when the classification was given
+}
+
+// RenderPrompt renders the prompt with given arguments
+func (pep *PatientExplanationPrompt) RenderPrompt(ctx context.Context, args map[string]interface{}) (*RenderedPrompt, error) {
+	pep.logger.WithField("args", args).Debug("Rendering patient explanation prompt")
+
+	args = pep.normalizeArguments(args)
+	subject, err := pep.subject(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	levelName := pep.getStringArg(args, "reading_level", "standard")
+	level, ok := readingLevels[levelName]
+	if !ok {
+		return nil, fmt.Errorf("unknown reading level %q", levelName)
+	}
+	// The explanation is in the request's locale unless a language is given
+	language := pep.getStringArg(args, "language", string(i18n.FromContext(ctx)))
+	if name, ok := languageNames[strings.ToLower(language)]; ok {
+		language = name
+	}
+	includeActionability := pep.getBoolArg(args, "include_actionability", true)
+
+	content := pep.buildPromptContent(subject, levelName, level, language, includeActionability)
+
+	rendered := &RenderedPrompt{
+		Name:         "patient_explanation",
+		Content:      content,
+		SystemPrompt: pep.buildSystemPrompt(),
+		UserPrompt:   fmt.Sprintf("Write a plain-language explanation of this %s result for the patient, in %s, at %s reading level.", strings.ToLower(subject.classification.Label()), language, level.grade),
+		Context:      fmt.Sprintf("Patient explanation of %s: %s", subject.variant, subject.classification.Label()),
+		Instructions: []string{
+			"Lead with what the result means for the patient in one or two sentences",
+			"Keep to the key messages; do not add medical advice beyond them",
+			"End with who the patient can contact with questions",
+			"Mark the draft for review by a genetic counselor",
+		},
+		References: []string{
+			"Richards, S. et al. Standards and guidelines for the interpretation of sequence variants. Genet Med. 2015;17(5):405-24.",
+			"Brach, C. et al. Ten attributes of health literate health care organizations. National Academy of Medicine. 2012.",
+		},
+		Arguments:   args,
+		GeneratedAt: time.Now(),
+		Metadata: map[string]interface{}{
+			"classification":        string(subject.classification),
+			"reading_level":         levelName,
+			"language":              language,
+			"include_actionability": includeActionability,
+			"generated_by":          "patient_explanation_prompt_v1.0.0",
+		},
+	}
+
+	pep.logger.WithFields(logrus.Fields{
+		"classification": subject.classification,
+		"reading_level":  levelName,
+		"language":       language,
+		"content_length": len(content),
+	}).Info("Generated patient explanation prompt")
+
+	return rendered, nil
+}
+
+// subject resolves the classification to explain, from the arguments or the
+// variant's latest stored classification
+func (pep *PatientExplanationPrompt) subject(ctx context.Context, args map[string]interface{}) (*explanationSubject, error) {
+	subject := &explanationSubject{
+		variant:   strings.TrimSpace(pep.getStringArg(args, "variant", "")),
+		gene:      strings.TrimSpace(pep.getStringArg(args, "gene", "")),
+		condition: strings.TrimSpace(pep.getStringArg(args, "condition", "")),
+	}
+	if subject.variant == "" {
+		return nil, fmt.Errorf("no variant to explain")
+	}
+
+	given := strings.TrimSpace(pep.getStringArg(args, "classification", ""))
+	if given != "" {
+		classification, err := domain.ParseClassification(given)
+		if err != nil {
+			return nil, fmt.Errorf("invalid classification %q: %w", given, err)
+		}
+		subject.classification = classification
+		return subject, nil
+	}
+
+	if pep.history == nil {
+		return nil, fmt.Errorf("no classification given for %s and no classification store configured", subject.variant)
+	}
+	records, err := pep.history.Query(ctx, audit.Filter{Variant: subject.variant, Limit: explanationHistoryLimit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read classifications of %s: %w", subject.variant, err)
+	}
+	for _, record := range records {
+		if record.Error != "" {
+			continue
+		}
+		classification, err := domain.ParseClassification(record.Classification)
+		if err != nil {
+			continue
+		}
+		subject.classification = classification
+		subject.classified = record.CreatedAt
+		return subject, nil
+	}
+	return nil, fmt.Errorf("no stored classification for %s; classify the variant or give its classification", subject.variant)
+}
+
+// keyMessages are what the patient must take away from the result. The
+// caution against acting on an uncertain result is given whether or not
+// actionability statements are included.
+func keyMessages(subject *explanationSubject) []string {
+	condition := subject.condition
+	if condition == "" {
+		condition = "the condition you were tested for"
+	}
+	switch subject.classification {
+	case domain.PATHOGENIC:
+		return []string{
+			fmt.Sprintf("The test found a change in a gene that is known to cause %s", condition),
+			"The result explains, or adds to, the chance of developing the condition; it does not mean the condition will certainly develop",
+		}
+	case domain.LIKELY_PATHOGENIC:
+		return []string{
+			fmt.Sprintf("The test found a change in a gene that very likely causes %s", condition),
+			"Doctors treat this result the same way as a change known to cause the condition",
+			"It does not mean the condition will certainly develop",
+		}
+	case domain.VUS:
+		return []string{
+			"The test found a change in a gene, but we do not yet know whether it affects health",
+			"This result should not be used to make medical decisions; care should be based on personal and family history",
+			"The laboratory reviews these changes as more is learned and will let your care team know if the result changes",
+		}
+	case domain.LIKELY_BENIGN:
+		return []string{
+			fmt.Sprintf("The test found a change in a gene that is very likely harmless and not expected to cause %s", condition),
+			"Many people have changes like this one",
+		}
+	default:
+		return []string{
+			fmt.Sprintf("The test found a change in a gene that is harmless and does not cause %s", condition),
+			"Many people have changes like this one",
+		}
+	}
+}
+
+// actionabilityStatements say what the result means for medical care and for
+// relatives
+func actionabilityStatements(classification domain.Classification) []string {
+	switch classification {
+	case domain.PATHOGENIC, domain.LIKELY_PATHOGENIC:
+		return []string{
+			"Your doctor may suggest extra screening, prevention or treatment options; talk about them before making decisions",
+			"Parents, brothers, sisters and children may have the same change; they can ask about testing for it",
+			"Genetic counseling is available to help you and your family understand the options",
+		}
+	case domain.VUS:
+		return []string{
+			"Your medical care should follow your personal and family history, not this result",
+			"Testing relatives for this change is usually not recommended unless the laboratory asks for it to help understand the change",
+		}
+	default:
+		return []string{
+			"This result does not change your medical care; follow the care your doctor recommends based on your personal and family history",
+			"Relatives do not need testing for this change",
+		}
+	}
+}
+
+// explanationTerms are genetic terms and their plain descriptions, in the
+// order an explanation usually meets them
+var explanationTerms = [][2]string{
+	{"gene", "an instruction in the body's cells that tells the body how to grow and work"},
+	{"variant", "a change in a gene, like a spelling change in the instruction"},
+	{"pathogenic", "known to cause a health condition"},
+	{"uncertain significance", "not enough is known yet to say whether the change affects health"},
+	{"benign", "harmless; not expected to cause a health condition"},
+	{"inherited", "passed down from a parent"},
+}
+
+// buildPromptContent renders the writing brief
+func (pep *PatientExplanationPrompt) buildPromptContent(subject *explanationSubject, levelName string, level readingLevel, language string, includeActionability bool) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("# Patient Explanation: %s\n\n", subject.variant))
+
+	builder.WriteString("## Result\n\n")
+	classification := subject.classification.Label()
+	if !subject.classified.IsZero() {
+		classification += fmt.Sprintf(" (classified %s)", subject.classified.Format("2006-01-02"))
+	}
+	rows := [][]string{{"Variant", subject.variant}, {"Classification", classification}}
+	if subject.gene != "" {
+		rows = append(rows, []string{"Gene", subject.gene})
+	}
+	if subject.condition != "" {
+		rows = append(rows, []string{"Condition", subject.condition})
+	}
+	builder.WriteString(pep.renderer.FormatTable([]string{"Field", "Value"}, rows))
+	builder.WriteString("\n")
+
+	builder.WriteString("## Key Messages\n\n")
+	builder.WriteString(pep.renderer.FormatList(keyMessages(subject), true))
+	builder.WriteString("\n")
+
+	if includeActionability {
+		builder.WriteString("## What This Means for Care\n\n")
+		builder.WriteString(pep.renderer.FormatList(actionabilityStatements(subject.classification), false))
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("## Writing Guidance\n\n")
+	guidance := []string{
+		fmt.Sprintf("**Language:** write in %s", language),
+		fmt.Sprintf("**Reading level:** %s (%s); keep sentences under %d words", levelName, level.grade, level.sentence),
+	}
+	guidance = append(guidance, level.rules...)
+	guidance = append(guidance, "Address the patient as \"you\" and use a warm, calm tone")
+	if !includeActionability {
+		guidance = append(guidance, "Do not recommend screening, treatment or testing of relatives; the care team will discuss them")
+	}
+	builder.WriteString(pep.renderer.FormatList(guidance, false))
+	builder.WriteString("\n")
+
+	if levelName != "advanced" {
+		builder.WriteString("## Words to Explain\n\n")
+		terms := make([]string, len(explanationTerms))
+		for i, term := range explanationTerms {
+			terms[i] = fmt.Sprintf("**%s:** %s", term[0], term[1])
+		}
+		builder.WriteString(pep.renderer.FormatList(terms, false))
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("## Avoid\n\n")
+	builder.WriteString(pep.renderer.FormatList([]string{
+		"Variant nomenclature such as HGVS notation, except in a reference line at the end",
+		"ACMG/AMP criteria codes such as PVS1 or PM2",
+		"Words that blame or alarm, such as \"mutant\", \"defective\" or \"abnormal\"",
+		"Promises about whether the condition will or will not develop",
+	}, false))
+
+	return builder.String()
+}
+
+// buildSystemPrompt builds the system prompt
+func (pep *PatientExplanationPrompt) buildSystemPrompt() string {
+	return `You are helping a genetic counselor explain a genetic test result to a patient. Write clearly and kindly, at the requested reading level and in the requested language. Say only what the key messages support; do not change the classification, overstate certainty or give medical advice the brief does not include. The explanation is a draft for the counselor to review before it reaches the patient.`
+}
+
+// ValidateArguments validates the provided arguments
+func (pep *PatientExplanationPrompt) ValidateArguments(args map[string]interface{}) error {
+	args = pep.normalizeArguments(args)
+	if err := pep.validator.ValidateArguments(args, pep.GetPromptInfo().Arguments); err != nil {
+		return err
+	}
+	if strings.TrimSpace(pep.getStringArg(args, "variant", "")) == "" {
+		return fmt.Errorf("argument 'variant' must not be empty")
+	}
+	if classification := strings.TrimSpace(pep.getStringArg(args, "classification", "")); classification != "" {
+		if _, err := domain.ParseClassification(classification); err != nil {
+			return fmt.Errorf("argument 'classification': %w", err)
+		}
+	}
+	return nil
+}
+
+// normalizeArguments converts include_actionability given as a string, as
+// MCP clients pass it, to a boolean
+func (pep *PatientExplanationPrompt) normalizeArguments(args map[string]interface{}) map[string]interface{} {
+	normalized := make(map[string]interface{}, len(args))
+	for name, value := range args {
+		normalized[name] = value
+	}
+	if value, ok := args["include_actionability"].(string); ok {
+		if b, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			normalized["include_actionability"] = b
+		}
+	}
+	return normalized
+}
+
+// GetArgumentSchema returns the JSON schema for prompt arguments
+func (pep *PatientExplanationPrompt) GetArgumentSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"variant": map[string]interface{}{
+				"type":        "string",
+				"description": "Variant to explain, by variant ID or HGVS notation",
+				"minLength":   1,
+			},
+			"classification": map[string]interface{}{
+				"type":        "string",
+				"description": "Classification to explain; the variant's latest stored classification when omitted",
+			},
+			"gene": map[string]interface{}{
+				"type":        "string",
+				"description": "Gene symbol of the variant",
+			},
+			"condition": map[string]interface{}{
+				"type":        "string",
+				"description": "Condition the variant was tested for",
+			},
+			"reading_level": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"basic", "standard", "advanced"},
+				"description": "Reading level of the explanation",
+				"default":     "standard",
+			},
+			"language": map[string]interface{}{
+				"type":        "string",
+				"description": "Language of the explanation, by code or name; the request's locale when omitted",
+				"default":     "en",
+			},
+			"include_actionability": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Whether to say what the result means for medical care and for relatives",
+				"default":     true,
+			},
+		},
+		"required": []string{"variant"},
+	}
+}
+
+// SupportsPrompt checks if this template can handle the given prompt name
+func (pep *PatientExplanationPrompt) SupportsPrompt(name string) bool {
+	supportedNames := []string{
+		"patient_explanation",
+		"patient-explanation",
+		"patient_summary",
+		"patient-summary",
+		"plain_language_result",
+		"plain-language-result",
+	}
+
+	for _, supported := range supportedNames {
+		if name == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper methods for argument extraction
+func (pep *PatientExplanationPrompt) getStringArg(args map[string]interface{}, key, defaultValue string) string {
+	if value, exists := args[key]; exists {
+		if str, ok := value.(string); ok {
+			return str
+		}
+	}
+	return defaultValue
+}
+
+func (pep *PatientExplanationPrompt) getBoolArg(args map[string]interface{}, key string, defaultValue bool) bool {
+	if value, exists := args[key]; exists {
+		if b, ok := value.(bool); ok {
+			return b
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,93 @@
+package prompts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/i18n"
+)
+
+func TestPatientExplanationPrompt_RenderPrompt(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	classified := time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)
+	history := fakeHistory{
+		"NM_007294.4:c.5266dup": {
+			{Error: "ClinVar unavailable", CreatedAt: classified.AddDate(0, 0, 1)},
+			{Classification: "Pathogenic", CreatedAt: classified},
+		},
+	}
+	prompt := NewPatientExplanationPrompt(logger, history)
+
+	t.Run("Stored classification", func(t *testing.T) {
+		args := map[string]interface{}{
+			"variant":       "NM_007294.4:c.5266dup",
+			"gene":          "BRCA1",
+			"condition":     "hereditary breast and ovarian cancer",
+			"reading_level": "basic",
+			"language":      "es",
+		}
+		require.NoError(t, prompt.ValidateArguments(args))
+		rendered, err := prompt.RenderPrompt(context.Background(), args)
+		require.NoError(t, err)
+
+		assert.Contains(t, rendered.Content, "Pathogenic (classified 2026-05-04)")
+		assert.Contains(t, rendered.Content, "known to cause hereditary breast and ovarian cancer")
+		assert.Contains(t, rendered.Content, "## What This Means for Care")
+		assert.Contains(t, rendered.Content, "write in Spanish")
+		assert.Contains(t, rendered.Content, "about 6th grade")
+		assert.Contains(t, rendered.Content, "## Words to Explain")
+		assert.Equal(t, "PATHOGENIC", rendered.Metadata["classification"])
+	})
+
+	t.Run("Uncertain result without actionability", func(t *testing.T) {
+		args := map[string]interface{}{
+			"variant":               "NM_000059.4:c.68-7T>A",
+			"classification":        "Uncertain significance",
+			"reading_level":         "advanced",
+			"language":              "Tagalog",
+			"include_actionability": "false",
+		}
+		require.NoError(t, prompt.ValidateArguments(args))
+		rendered, err := prompt.RenderPrompt(context.Background(), args)
+		require.NoError(t, err)
+
+		assert.Contains(t, rendered.Content, "should not be used to make medical decisions", "the caution is always given")
+		assert.NotContains(t, rendered.Content, "## What This Means for Care")
+		assert.Contains(t, rendered.Content, "Do not recommend screening")
+		assert.Contains(t, rendered.Content, "write in Tagalog")
+		assert.NotContains(t, rendered.Content, "## Words to Explain")
+	})
+
+	t.Run("Language from the locale", func(t *testing.T) {
+		ctx := i18n.WithLocale(context.Background(), i18n.TraditionalChinese)
+		rendered, err := prompt.RenderPrompt(ctx, map[string]interface{}{"variant": "NM_007294.4:c.5266dup"})
+		require.NoError(t, err)
+		assert.Contains(t, rendered.Content, "write in Traditional Chinese")
+	})
+
+	t.Run("No classification", func(t *testing.T) {
+		_, err := prompt.RenderPrompt(context.Background(), map[string]interface{}{"variant": "NM_000492.4:c.1521_1523del"})
+		assert.ErrorContains(t, err, "no stored classification")
+
+		_, err = NewPatientExplanationPrompt(logger, nil).RenderPrompt(context.Background(), map[string]interface{}{"variant": "NM_000492.4:c.1521_1523del"})
+		assert.ErrorContains(t, err, "no classification store configured")
+	})
+}
+
+func TestPatientExplanationPrompt_ValidateArguments(t *testing.T) {
+	prompt := NewPatientExplanationPrompt(logrus.New(), fakeHistory{})
+
+	assert.NoError(t, prompt.ValidateArguments(map[string]interface{}{"variant": "NM_000492.4:c.1521_1523del", "classification": "lb"}))
+	assert.Error(t, prompt.ValidateArguments(map[string]interface{}{}))
+	assert.Error(t, prompt.ValidateArguments(map[string]interface{}{"variant": " "}))
+	assert.Error(t, prompt.ValidateArguments(map[string]interface{}{"variant": "NM_000492.4:c.1521_1523del", "classification": "harmful"}))
+	assert.Error(t, prompt.ValidateArguments(map[string]interface{}{"variant": "NM_000492.4:c.1521_1523del", "reading_level": "expert"}))
+	assert.True(t, prompt.SupportsPrompt("plain-language-result"))
+}
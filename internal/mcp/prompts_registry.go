@@ -0,0 +1,34 @@
+// Package mcp provides the MCP server implementation.
+// This file contains prompt registration logic.
+package mcp
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/i18n"
+	"github.com/acmg-amp-mcp-server/internal/mcp/prompts"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// registerPrompts registers the prompt templates with the message router.
+// The case review and patient explanation prompts draw on the stored
+// classifications in auditStore; prompts respond in locale unless a request
+// names another.
+func registerPrompts(router *protocol.MessageRouter, logger *logrus.Logger, auditStore audit.Store, locale i18n.Locale) {
+	templates := []prompts.PromptTemplate{
+		prompts.NewACMGTrainingPrompt(logger),
+		prompts.NewClinicalInterpretationPrompt(logger),
+		prompts.NewEvidenceReviewPrompt(logger),
+		prompts.NewReportGenerationPrompt(logger),
+		prompts.NewCaseReviewPrompt(logger, auditStore),
+		prompts.NewPatientExplanationPrompt(logger, auditStore),
+	}
+	for _, template := range templates {
+		name := template.GetPromptInfo().Name
+		handler := prompts.NewHandler(logger, template)
+		handler.SetLocale(locale)
+		router.RegisterPromptHandler(name, handler)
+		logger.WithField("prompt_name", name).Debug("Registered prompt")
+	}
+}
@@ -0,0 +1,132 @@
+package protocol
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Error envelope codes shared by MCP tools, resources and the REST API
+const (
+	ErrorCodeInvalidInput   = "INVALID_INPUT"
+	ErrorCodeInvalidRequest = "INVALID_REQUEST"
+	ErrorCodeParseError     = "PARSE_ERROR"
+	ErrorCodeNotFound       = "NOT_FOUND"
+	ErrorCodeConflict       = "CONFLICT"
+	ErrorCodeUnauthorized   = "UNAUTHORIZED"
+	ErrorCodeForbidden      = "FORBIDDEN"
+	ErrorCodeRateLimited    = "RATE_LIMITED"
+	ErrorCodeResourceError  = "RESOURCE_ERROR"
+	ErrorCodeToolError      = "TOOL_ERROR"
+	ErrorCodeTimeout        = "TIMEOUT"
+	ErrorCodeCancelled      = "CANCELLED"
+	ErrorCodeInternal       = "INTERNAL_ERROR"
+)
+
+// CorrelationHeader carries the correlation ID on HTTP requests and responses
+const CorrelationHeader = "X-Correlation-ID"
+
+// ErrorEnvelope is the error body returned by every MCP tool, resource and
+// REST endpoint. The correlation ID is also logged with the failure, so a
+// support ticket quoting it can be matched to the server logs.
+type ErrorEnvelope struct {
+	Code          string      `json:"code"`
+	Message       string      `json:"message"`
+	Details       interface{} `json:"details,omitempty"`
+	CorrelationID string      `json:"correlation_id"`
+	Retryable     bool        `json:"retryable"`
+	Source        string      `json:"source"` // Component that failed, e.g. tool:classify_variant
+	Timestamp     time.Time   `json:"timestamp"`
+}
+
+// NewErrorEnvelope creates an envelope; retryability follows the code.
+func NewErrorEnvelope(code, message, source, correlationID string, details interface{}) *ErrorEnvelope {
+	return &ErrorEnvelope{
+		Code:          code,
+		Message:       message,
+		Details:       details,
+		CorrelationID: correlationID,
+		Retryable:     IsRetryable(code),
+		Source:        source,
+		Timestamp:     time.Now().UTC(),
+	}
+}
+
+// IsRetryable reports whether a request failing with the code may succeed
+// unchanged on a later attempt.
+func IsRetryable(code string) bool {
+	switch code {
+	case ErrorCodeRateLimited, ErrorCodeResourceError, ErrorCodeTimeout:
+		return true
+	}
+	return false
+}
+
+// ErrorCodeFor maps a JSON-RPC error code to an envelope code.
+func ErrorCodeFor(rpcCode int) string {
+	switch rpcCode {
+	case ParseError:
+		return ErrorCodeParseError
+	case InvalidRequest:
+		return ErrorCodeInvalidRequest
+	case MethodNotFound:
+		return ErrorCodeNotFound
+	case InvalidParams:
+		return ErrorCodeInvalidInput
+	case MCPUnauthorized:
+		return ErrorCodeUnauthorized
+	case MCPForbidden:
+		return ErrorCodeForbidden
+	case MCPRateLimited:
+		return ErrorCodeRateLimited
+	case MCPResourceError:
+		return ErrorCodeResourceError
+	case MCPToolError:
+		return ErrorCodeToolError
+	case RequestCancelled:
+		return ErrorCodeCancelled
+	default:
+		return ErrorCodeInternal
+	}
+}
+
+// Envelope wraps the error in the standard envelope, keeping any existing
+// Data as details. An error already carrying an envelope keeps its code and
+// source and only gains a correlation ID if it lacked one.
+func (e *RPCError) Envelope(source, correlationID string) *ErrorEnvelope {
+	if envelope, ok := e.Data.(*ErrorEnvelope); ok {
+		if envelope.CorrelationID == "" {
+			envelope.CorrelationID = correlationID
+		}
+		return envelope
+	}
+	envelope := NewErrorEnvelope(ErrorCodeFor(e.Code), e.Message, source, correlationID, e.Data)
+	e.Data = envelope
+	return envelope
+}
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context carrying the correlation ID.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationIDFromContext returns the context's correlation ID, or "" if none.
+func CorrelationIDFromContext(ctx context.Context) string {
+	if correlationID, ok := ctx.Value(correlationIDKey{}).(string); ok {
+		return correlationID
+	}
+	return ""
+}
+
+// EnsureCorrelationID returns the context's correlation ID, assigning a new
+// one when the caller did not supply it.
+func EnsureCorrelationID(ctx context.Context) (context.Context, string) {
+	if correlationID := CorrelationIDFromContext(ctx); correlationID != "" {
+		return ctx, correlationID
+	}
+	correlationID := uuid.New().String()
+	return WithCorrelationID(ctx, correlationID), correlationID
+}
@@ -0,0 +1,103 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestRPCErrorEnvelope(t *testing.T) {
+	rpcErr := &RPCError{Code: MCPRateLimited, Message: "Rate limit exceeded", Data: "client over quota"}
+
+	envelope := rpcErr.Envelope("tool:classify_variant", "corr-1")
+	if envelope.Code != ErrorCodeRateLimited {
+		t.Errorf("Expected code %s, got %s", ErrorCodeRateLimited, envelope.Code)
+	}
+	if !envelope.Retryable {
+		t.Error("Expected rate limited errors to be retryable")
+	}
+	if envelope.Details != "client over quota" {
+		t.Errorf("Expected original data as details, got %v", envelope.Details)
+	}
+	if rpcErr.Data != envelope {
+		t.Error("Expected the envelope to replace the error data")
+	}
+
+	// Wrapping again keeps the original attribution
+	again := rpcErr.Envelope("protocol", "corr-2")
+	if again != envelope || again.Source != "tool:classify_variant" || again.CorrelationID != "corr-1" {
+		t.Errorf("Expected existing envelope to be kept, got %+v", again)
+	}
+}
+
+func TestErrorCodeFor(t *testing.T) {
+	tests := map[int]string{
+		ParseError:      ErrorCodeParseError,
+		InvalidParams:   ErrorCodeInvalidInput,
+		MethodNotFound:  ErrorCodeNotFound,
+		MCPUnauthorized: ErrorCodeUnauthorized,
+		MCPForbidden:    ErrorCodeForbidden,
+		InternalError:   ErrorCodeInternal,
+		-1:              ErrorCodeInternal,
+	}
+	for rpcCode, want := range tests {
+		if got := ErrorCodeFor(rpcCode); got != want {
+			t.Errorf("ErrorCodeFor(%d) = %s, want %s", rpcCode, got, want)
+		}
+	}
+	if IsRetryable(ErrorCodeInvalidInput) || IsRetryable(ErrorCodeInternal) {
+		t.Error("Expected invalid input and internal errors not to be retryable")
+	}
+}
+
+func TestEnsureCorrelationID(t *testing.T) {
+	ctx, id := EnsureCorrelationID(context.Background())
+	if id == "" || CorrelationIDFromContext(ctx) != id {
+		t.Fatalf("Expected a generated correlation ID in the context, got %q", id)
+	}
+
+	_, again := EnsureCorrelationID(WithCorrelationID(context.Background(), "ticket-1"))
+	if again != "ticket-1" {
+		t.Errorf("Expected caller correlation ID to be kept, got %q", again)
+	}
+}
+
+func TestProcessMessageErrorEnvelope(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	core := NewProtocolCore(logger)
+	core.InitializeClient("test-client", map[string]interface{}{})
+
+	ctx := WithCorrelationID(context.Background(), "ticket-2")
+	raw, err := core.ProcessMessage(ctx, "test-client", []byte(`{"jsonrpc":"2.0","method":"unknown/method","id":1}`))
+	if err != nil {
+		t.Fatalf("ProcessMessage failed: %v", err)
+	}
+
+	var response struct {
+		Error struct {
+			Code int           `json:"code"`
+			Data ErrorEnvelope `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Error.Code != MethodNotFound {
+		t.Errorf("Expected method not found, got %d", response.Error.Code)
+	}
+	envelope := response.Error.Data
+	if envelope.Code != ErrorCodeNotFound || envelope.CorrelationID != "ticket-2" || envelope.Source != "unknown/method" {
+		t.Errorf("Unexpected envelope: %+v", envelope)
+	}
+	if !strings.Contains(envelope.Details.(string), "unknown/method") {
+		t.Errorf("Expected original data as details, got %v", envelope.Details)
+	}
+
+	entry := hook.LastEntry()
+	if entry == nil || entry.Data["correlation_id"] != "ticket-2" {
+		t.Error("Expected the failure to be logged with its correlation ID")
+	}
+}
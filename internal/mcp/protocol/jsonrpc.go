@@ -46,6 +46,11 @@ const (
 	MCPRateLimited    = -32001
 	MCPResourceError  = -32002
 	MCPToolError      = -32003
+	MCPForbidden      = -32004
+
+	// RequestCancelled is returned for a request the client cancelled
+	// with notifications/cancelled or $/cancelRequest
+	RequestCancelled = -32800
 )
 
 // MessageHandler defines the interface for handling JSON-RPC messages
@@ -91,6 +96,8 @@ func (p *ProtocolCore) ProcessMessage(ctx context.Context, clientID string, rawM
 		"message_length": len(rawMessage),
 	}).Debug("Processing JSON-RPC message")
 
+	ctx, correlationID := EnsureCorrelationID(ctx)
+
 	// Parse JSON-RPC request
 	var req JSONRPC2Request
 	if err := json.Unmarshal(rawMessage, &req); err != nil {
@@ -103,7 +110,7 @@ func (p *ProtocolCore) ProcessMessage(ctx context.Context, clientID string, rawM
 			},
 			ID: nil,
 		}
-		return json.Marshal(response)
+		return p.marshalResponse(response, "protocol", correlationID)
 	}
 
 	// Validate JSON-RPC 2.0 format
@@ -117,7 +124,7 @@ func (p *ProtocolCore) ProcessMessage(ctx context.Context, clientID string, rawM
 			},
 			ID: req.ID,
 		}
-		return json.Marshal(response)
+		return p.marshalResponse(response, "protocol", correlationID)
 	}
 
 	// Check rate limiting
@@ -131,7 +138,7 @@ func (p *ProtocolCore) ProcessMessage(ctx context.Context, clientID string, rawM
 			},
 			ID: req.ID,
 		}
-		return json.Marshal(response)
+		return p.marshalResponse(response, "protocol", correlationID)
 	}
 
 	// Update client activity
@@ -140,6 +147,20 @@ func (p *ProtocolCore) ProcessMessage(ctx context.Context, clientID string, rawM
 	// Handle the request
 	response := p.handleRequest(ctx, &req)
 	
+	return p.marshalResponse(response, req.Method, correlationID)
+}
+
+// marshalResponse wraps any error in the standard envelope, logs it with
+// its correlation ID and encodes the response
+func (p *ProtocolCore) marshalResponse(response *JSONRPC2Response, source, correlationID string) ([]byte, error) {
+	if response.Error != nil {
+		envelope := response.Error.Envelope(source, correlationID)
+		p.logger.WithFields(logrus.Fields{
+			"correlation_id": envelope.CorrelationID,
+			"code":           envelope.Code,
+			"source":         envelope.Source,
+		}).Warn(envelope.Message)
+	}
 	return json.Marshal(response)
 }
 
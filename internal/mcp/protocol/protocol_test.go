@@ -3,6 +3,7 @@ package protocol
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -295,6 +296,62 @@ func TestMessageRouter(t *testing.T) {
 	}
 }
 
+// stubResource is a resource handler with fixed info
+type stubResource struct {
+	info ResourceInfo
+}
+
+func (r *stubResource) HandleResource(ctx context.Context, req *JSONRPC2Request) *JSONRPC2Response {
+	return &JSONRPC2Response{Result: map[string]interface{}{}}
+}
+
+func (r *stubResource) GetResourceInfo() ResourceInfo { return r.info }
+
+func (r *stubResource) ValidateURI(uri string) error { return nil }
+
+// TestResourcesListPagination tests that resources/list pages by cursor and
+// filters by MIME type
+func TestResourcesListPagination(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	router := NewMessageRouter(logger)
+	for _, uri := range []string{"/c", "/a", "/d", "/b"} {
+		router.RegisterResourceHandler(uri, &stubResource{info: ResourceInfo{URI: uri, Name: uri, MimeType: "application/json"}})
+	}
+	router.RegisterResourceHandler("/report", &stubResource{info: ResourceInfo{URI: "/report", Name: "report", MimeType: "text/markdown"}})
+
+	list := func(params map[string]interface{}) *JSONRPC2Response {
+		return router.HandleRequest(context.Background(), &JSONRPC2Request{JSONRPC: "2.0", Method: "resources/list", Params: params, ID: 1})
+	}
+	var uris []string
+	cursor := ""
+	for pages := 0; pages < 5; pages++ {
+		params := map[string]interface{}{"pageSize": 2, "mimeType": "application/json"}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		response := list(params)
+		if response.Error != nil {
+			t.Fatalf("resources/list failed: %v", response.Error)
+		}
+		result := response.Result.(map[string]interface{})
+		for _, resource := range result["resources"].([]map[string]interface{}) {
+			uris = append(uris, resource["uri"].(string))
+		}
+		next, _ := result["nextCursor"].(string)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if strings.Join(uris, ",") != "/a,/b,/c,/d" {
+		t.Errorf("Expected every JSON resource once in URI order, got %v", uris)
+	}
+
+	if response := list(map[string]interface{}{"cursor": "not a cursor"}); response.Error == nil || response.Error.Code != InvalidParams {
+		t.Errorf("Expected an invalid params error for a bad cursor, got %+v", response)
+	}
+}
+
 // TestErrorCodes tests JSON-RPC error code constants
 func TestErrorCodes(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,101 @@
+package protocol
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// DefaultResourceChunkBytes is the chunk size of a ranged resources/read
+// that does not give a length, and the largest chunk returned
+const DefaultResourceChunkBytes = 1024 * 1024
+
+// ResourceRange selects a byte range of each resource content in a
+// resources/read request, so large evidence bundles can be read in chunks
+type ResourceRange struct {
+	Offset int `json:"offset"`
+	Length int `json:"length,omitempty"`
+}
+
+// ResourceChunk describes the part of a content returned by a ranged read.
+// NextOffset is set while more of the content remains.
+type ResourceChunk struct {
+	Offset     int  `json:"offset"`
+	Length     int  `json:"length"`
+	Total      int  `json:"total"`
+	NextOffset *int `json:"nextOffset,omitempty"`
+}
+
+// applyResourceRange cuts the text or blob of each content in a
+// resources/read result down to the requested range
+func applyResourceRange(result interface{}, r ResourceRange) (interface{}, error) {
+	if r.Offset < 0 || r.Length < 0 {
+		return nil, fmt.Errorf("range offset and length must not be negative")
+	}
+	length := r.Length
+	if length == 0 || length > DefaultResourceChunkBytes {
+		length = DefaultResourceChunkBytes
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resource: %w", err)
+	}
+	var read struct {
+		Contents []map[string]interface{} `json:"contents"`
+	}
+	if err := json.Unmarshal(data, &read); err != nil {
+		return nil, fmt.Errorf("resource result has no contents: %w", err)
+	}
+
+	for _, content := range read.Contents {
+		if text, ok := content["text"].(string); ok {
+			chunk, info := textChunk(text, r.Offset, length)
+			content["text"] = chunk
+			content["range"] = info
+		} else if blob, ok := content["blob"].(string); ok {
+			raw, err := base64.StdEncoding.DecodeString(blob)
+			if err != nil {
+				return nil, fmt.Errorf("invalid resource blob: %w", err)
+			}
+			start, end := chunkBounds(len(raw), r.Offset, length)
+			content["blob"] = base64.StdEncoding.EncodeToString(raw[start:end])
+			content["range"] = newResourceChunk(start, end, len(raw))
+		}
+	}
+	return map[string]interface{}{"contents": read.Contents}, nil
+}
+
+// textChunk returns a byte range of text, shortened so it does not split a
+// UTF-8 sequence; the next chunk starts where this one ends
+func textChunk(text string, offset, length int) (string, ResourceChunk) {
+	start, end := chunkBounds(len(text), offset, length)
+	for start < len(text) && !utf8.RuneStart(text[start]) {
+		start++
+	}
+	end = max(end, start)
+	for end < len(text) && end > start && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	// Always make progress, even when one character is longer than length
+	if end == start && start < len(text) {
+		_, size := utf8.DecodeRuneInString(text[start:])
+		end = start + size
+	}
+	return text[start:end], newResourceChunk(start, end, len(text))
+}
+
+// chunkBounds clamps a range to a content of total bytes
+func chunkBounds(total, offset, length int) (int, int) {
+	start := min(offset, total)
+	return start, min(start+length, total)
+}
+
+func newResourceChunk(start, end, total int) ResourceChunk {
+	chunk := ResourceChunk{Offset: start, Length: end - start, Total: total}
+	if end < total {
+		chunk.NextOffset = &end
+	}
+	return chunk
+}
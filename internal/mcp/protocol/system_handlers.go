@@ -2,7 +2,11 @@ package protocol
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -186,16 +190,69 @@ type ResourcesListHandler struct {
 	router *MessageRouter
 }
 
-// HandleSystem implements the resources/list handler
+// Page size limits for resources/list
+const (
+	defaultResourcePageSize = 50
+	maxResourcePageSize     = 500
+)
+
+// HandleSystem implements the resources/list handler. Resources are listed
+// in URI order, a page at a time; nextCursor is set while more remain. The
+// optional pageSize and mimeType parameters extend the MCP request.
 func (h *ResourcesListHandler) HandleSystem(ctx context.Context, req *JSONRPC2Request) *JSONRPC2Response {
 	h.logger.Debug("Handling resources/list request")
 
-	resources := make([]map[string]interface{}, 0)
-	
-	// Get all registered resource handlers
-	resourceHandlers := h.router.GetResourceHandlers()
-	for pattern, handler := range resourceHandlers {
-		resourceInfo := handler.GetResourceInfo()
+	var params struct {
+		Cursor   string `json:"cursor,omitempty"`
+		PageSize int    `json:"pageSize,omitempty"`
+		MimeType string `json:"mimeType,omitempty"`
+	}
+	if req.Params != nil {
+		if paramsData, err := json.Marshal(req.Params); err == nil {
+			json.Unmarshal(paramsData, &params)
+		}
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = defaultResourcePageSize
+	}
+	if params.PageSize > maxResourcePageSize {
+		params.PageSize = maxResourcePageSize
+	}
+	offset := 0
+	if params.Cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(params.Cursor)
+		if err == nil {
+			offset, err = strconv.Atoi(string(decoded))
+		}
+		if err != nil || offset < 0 {
+			return &JSONRPC2Response{
+				Error: &RPCError{
+					Code:    InvalidParams,
+					Message: "Invalid cursor",
+					Data:    NewErrorEnvelope(ErrorCodeInvalidInput, "Invalid cursor", "resources/list", CorrelationIDFromContext(ctx), params.Cursor),
+				},
+			}
+		}
+	}
+
+	infos := make([]ResourceInfo, 0)
+	for _, handler := range h.router.GetResourceHandlers() {
+		info := handler.GetResourceInfo()
+		if params.MimeType == "" || strings.EqualFold(info.MimeType, params.MimeType) {
+			infos = append(infos, info)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].URI < infos[j].URI })
+	if offset > len(infos) {
+		offset = len(infos)
+	}
+	end := offset + params.PageSize
+	if end > len(infos) {
+		end = len(infos)
+	}
+
+	resources := make([]map[string]interface{}, 0, end-offset)
+	for _, resourceInfo := range infos[offset:end] {
 		resource := map[string]interface{}{
 			"uri":  resourceInfo.URI,
 			"name": resourceInfo.Name,
@@ -207,15 +264,15 @@ func (h *ResourcesListHandler) HandleSystem(ctx context.Context, req *JSONRPC2Re
 			resource["mimeType"] = resourceInfo.MimeType
 		}
 		resources = append(resources, resource)
-		
-		h.logger.WithField("pattern", pattern).Debug("Added resource")
 	}
 
-	return &JSONRPC2Response{
-		Result: map[string]interface{}{
-			"resources": resources,
-		},
+	result := map[string]interface{}{
+		"resources": resources,
+	}
+	if end < len(infos) {
+		result["nextCursor"] = base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(end)))
 	}
+	return &JSONRPC2Response{Result: result}
 }
 
 // GetSystemInfo returns system handler info
@@ -238,7 +295,8 @@ func (h *ResourcesReadHandler) HandleSystem(ctx context.Context, req *JSONRPC2Re
 
 	// Parse read parameters
 	var params struct {
-		URI string `json:"uri"`
+		URI   string         `json:"uri"`
+		Range *ResourceRange `json:"range,omitempty"`
 	}
 
 	if req.Params != nil {
@@ -263,7 +321,7 @@ func (h *ResourcesReadHandler) HandleSystem(ctx context.Context, req *JSONRPC2Re
 			Error: &RPCError{
 				Code:    InvalidParams,
 				Message: "Resource not found",
-				Data:    params.URI,
+				Data:    NewErrorEnvelope(ErrorCodeNotFound, "Resource not found", "resource:"+params.URI, CorrelationIDFromContext(ctx), params.URI),
 			},
 		}
 	}
@@ -276,8 +334,27 @@ func (h *ResourcesReadHandler) HandleSystem(ctx context.Context, req *JSONRPC2Re
 		ID:      req.ID,
 	}
 
-	// Delegate to resource handler
-	return resourceHandler.HandleResource(ctx, resourceReq)
+	// Delegate to resource handler, attributing failures to the resource
+	response := resourceHandler.HandleResource(ctx, resourceReq)
+	if response != nil && response.Error != nil {
+		response.Error.Envelope("resource:"+params.URI, CorrelationIDFromContext(ctx))
+	}
+
+	// Return only the requested chunk of a ranged read
+	if params.Range != nil && response != nil && response.Error == nil {
+		chunk, err := applyResourceRange(response.Result, *params.Range)
+		if err != nil {
+			return &JSONRPC2Response{
+				Error: &RPCError{
+					Code:    InvalidParams,
+					Message: err.Error(),
+					Data:    NewErrorEnvelope(ErrorCodeInvalidInput, err.Error(), "resource:"+params.URI, CorrelationIDFromContext(ctx), params.URI),
+				},
+			}
+		}
+		response.Result = chunk
+	}
+	return response
 }
 
 // GetSystemInfo returns system handler info
@@ -0,0 +1,31 @@
+// Package mcp provides the MCP server implementation.
+// This file contains the dependency probes behind /healthz and /readyz.
+package mcp
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/readiness"
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// registerReadinessProbes probes ClinVar and gnomAD reachability, the
+// evidence cache and each local SQLite database. The cache and databases
+// are critical; the external sources only degrade the instance since
+// classification proceeds with the evidence available. An empty clinVarURL
+// or gnomADURL skips that source's probe, as when it is read from a local
+// copy.
+func registerReadinessProbes(checker *readiness.Checker, clinVarURL, gnomADURL string, databases map[string]string, knowledgeBase *external.KnowledgeBaseService) {
+	client := &http.Client{Timeout: readiness.DefaultTimeout}
+	if clinVarURL != "" {
+		checker.Register("clinvar", false, readiness.HTTPProbe(client, strings.TrimRight(clinVarURL, "/")+"/einfo.fcgi?db=clinvar"))
+	}
+	if gnomADURL != "" {
+		checker.Register("gnomad", false, readiness.HTTPProbe(client, gnomADURL))
+	}
+	checker.Register("cache", true, knowledgeBase.PingCache)
+	for name, path := range databases {
+		checker.Register("database:"+name, true, readiness.SQLiteProbe(path))
+	}
+}
@@ -0,0 +1,47 @@
+// Package mcp provides the MCP server implementation.
+// This file contains resource error reporting logic.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// resourceError reports a failed resources/read as a JSON-RPC error whose
+// data is the standard error envelope, attributed to the resource and logged
+// with its correlation ID.
+func resourceError(ctx context.Context, logger *logrus.Logger, uri string, rpcCode int, err error) error {
+	_, correlationID := protocol.EnsureCorrelationID(ctx)
+	envelope := protocol.NewErrorEnvelope(protocol.ErrorCodeFor(rpcCode), err.Error(), "resource:"+uri, correlationID, nil)
+	logger.WithError(err).WithFields(logrus.Fields{
+		"uri":            uri,
+		"correlation_id": correlationID,
+	}).Warn("Failed to read resource")
+	return jsonrpcError(int64(rpcCode), err.Error(), envelope)
+}
+
+// jsonrpcError returns an error the SDK sends as a JSON-RPC error with the
+// given code and data. The SDK only keeps the code and data of its own wire
+// errors, which are built by decoding an error response.
+func jsonrpcError(code int64, message string, data interface{}) error {
+	raw, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      0,
+		"error":   map[string]interface{}{"code": code, "message": message, "data": data},
+	})
+	if err == nil {
+		var msg jsonrpc.Message
+		if msg, err = jsonrpc.DecodeMessage(raw); err == nil {
+			if resp, ok := msg.(*jsonrpc.Response); ok && resp.Error != nil {
+				return resp.Error
+			}
+		}
+	}
+	return errors.New(message)
+}
@@ -0,0 +1,119 @@
+// Package mcp provides the MCP server implementation.
+// This file contains resources/list pagination and filtering logic.
+package mcp
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/resources"
+)
+
+// listResourcesMiddleware pages and filters resources/list responses. The
+// optional pageSize, tags and mimeType filters are read from the request's
+// _meta, since the MCP request has no room for them; cursors are bound to the
+// filters they were issued for (see resources.Paginate).
+func listResourcesMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		listReq, ok := req.(*mcp.ListResourcesRequest)
+		if method != "resources/list" || !ok {
+			return next(ctx, method, req)
+		}
+		var params mcp.ListResourcesParams
+		if listReq.Params != nil {
+			params = *listReq.Params
+		}
+
+		// Collect every registered resource before filtering
+		all := make([]*mcp.Resource, 0)
+		cursor := ""
+		for {
+			page := &mcp.ListResourcesRequest{
+				Session: listReq.Session,
+				Params:  &mcp.ListResourcesParams{Meta: params.Meta, Cursor: cursor},
+				Extra:   listReq.Extra,
+			}
+			result, err := next(ctx, method, page)
+			if err != nil {
+				return nil, err
+			}
+			list := result.(*mcp.ListResourcesResult)
+			all = append(all, list.Resources...)
+			if list.NextCursor == "" {
+				break
+			}
+			cursor = list.NextCursor
+		}
+
+		byURI := make(map[string]*mcp.Resource, len(all))
+		infos := make([]resources.ResourceInfo, 0, len(all))
+		for _, resource := range all {
+			byURI[resource.URI] = resource
+			infos = append(infos, resources.ResourceInfo{
+				URI:      resource.URI,
+				Name:     resource.Name,
+				MimeType: resource.MIMEType,
+				Tags:     metaStrings(resource.Meta["tags"]),
+			})
+		}
+
+		ctx = resources.WithListOptions(ctx, listOptionsFromMeta(params.Meta))
+		page, err := resources.Paginate(ctx, infos, params.Cursor)
+		if err != nil {
+			return nil, jsonrpcError(protocol.InvalidParams, "Invalid cursor",
+				protocol.NewErrorEnvelope(protocol.ErrorCodeInvalidInput, "Invalid cursor", "resources/list", protocol.CorrelationIDFromContext(ctx), params.Cursor))
+		}
+
+		listed := make([]*mcp.Resource, 0, len(page.Resources))
+		for _, info := range page.Resources {
+			listed = append(listed, byURI[info.URI])
+		}
+		return &mcp.ListResourcesResult{Resources: listed, NextCursor: page.NextCursor}, nil
+	}
+}
+
+// listOptionsFromMeta reads the pageSize, tags and mimeType listing options
+// from a request's _meta
+func listOptionsFromMeta(meta mcp.Meta) resources.ListOptions {
+	var opts resources.ListOptions
+	switch pageSize := meta["pageSize"].(type) {
+	case float64:
+		opts.PageSize = int(pageSize)
+	case int:
+		opts.PageSize = pageSize
+	}
+	opts.Tags = metaStrings(meta["tags"])
+	opts.MimeType, _ = meta["mimeType"].(string)
+	return opts
+}
+
+// metaStrings reads a string or list of strings from a _meta value
+func metaStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// resourceTags returns the _meta tagging a registered resource with its
+// provider's tags, so that resources/list can filter on them
+func resourceTags(provider resources.ResourceProvider, path string) mcp.Meta {
+	info, err := provider.GetResourceInfo(context.Background(), path)
+	if err != nil || len(info.Tags) == 0 {
+		return nil
+	}
+	return mcp.Meta{"tags": info.Tags}
+}
@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	litecfg "github.com/acmg-amp-mcp-server/internal/config"
+)
+
+func TestLiteServer_ListResources(t *testing.T) {
+	cfg := litecfg.DefaultLiteConfig()
+	cfg.DataDir = t.TempDir()
+	server, err := NewLiteServer(cfg)
+	require.NoError(t, err)
+	session := connectClient(t, server.mcpServer)
+	ctx := context.Background()
+
+	list := func(meta mcp.Meta, cursor string) (*mcp.ListResourcesResult, error) {
+		return session.ListResources(ctx, &mcp.ListResourcesParams{Meta: meta, Cursor: cursor})
+	}
+	uris := func(result *mcp.ListResourcesResult) []string {
+		var listed []string
+		for _, resource := range result.Resources {
+			listed = append(listed, resource.URI)
+		}
+		return listed
+	}
+
+	t.Run("pages with a cursor", func(t *testing.T) {
+		all, err := list(nil, "")
+		require.NoError(t, err)
+		require.Greater(t, len(all.Resources), 2)
+		assert.Empty(t, all.NextCursor)
+
+		first, err := list(mcp.Meta{"pageSize": 2}, "")
+		require.NoError(t, err)
+		require.NotEmpty(t, first.NextCursor)
+		second, err := list(mcp.Meta{"pageSize": 2}, first.NextCursor)
+		require.NoError(t, err)
+
+		assert.Equal(t, uris(all)[:2], uris(first))
+		assert.Equal(t, uris(all)[2:min(4, len(all.Resources))], uris(second))
+	})
+
+	t.Run("filters by tag and MIME type", func(t *testing.T) {
+		observability, err := list(mcp.Meta{"tags": []string{"Observability"}}, "")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"acmg://system/circuit-breakers", "acmg://cache/stats"}, uris(observability))
+
+		text, err := list(mcp.Meta{"mimeType": "text/*"}, "")
+		require.NoError(t, err)
+		assert.Empty(t, text.Resources)
+	})
+
+	t.Run("rejects a cursor issued for other filters", func(t *testing.T) {
+		first, err := list(mcp.Meta{"pageSize": 1}, "")
+		require.NoError(t, err)
+		require.NotEmpty(t, first.NextCursor)
+
+		_, err = list(mcp.Meta{"pageSize": 1, "tags": "cache"}, first.NextCursor)
+
+		require.Error(t, err)
+		// The client wraps the server's JSON-RPC error
+		data, marshalErr := json.Marshal(errors.Unwrap(err))
+		require.NoError(t, marshalErr)
+		var wire struct {
+			Code int64 `json:"code"`
+			Data struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(data, &wire))
+		assert.Equal(t, int64(-32602), wire.Code)
+		assert.Equal(t, "Invalid cursor", wire.Data.Message)
+		assert.NotEmpty(t, wire.Data.Code)
+	})
+}
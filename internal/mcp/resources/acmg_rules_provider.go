@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
 )
 
 // ACMGRulesResourceProvider provides access to ACMG/AMP classification rules
@@ -334,12 +336,12 @@ func (p *ACMGRulesResourceProvider) ListResources(ctx context.Context, cursor st
 		},
 	}
 
-	result := &ResourceList{
-		Resources: resources,
-		Total:     len(resources),
+	result, err := Paginate(ctx, resources, cursor)
+	if err != nil {
+		return nil, err
 	}
 
-	p.logger.WithField("count", len(resources)).Info("Listed ACMG rules resources")
+	p.logger.WithField("count", len(result.Resources)).Info("Listed ACMG rules resources")
 	return result, nil
 }
 
@@ -439,8 +441,8 @@ func (p *ACMGRulesResourceProvider) generatePathogenicRules() PathogenicRulesDat
 			{
 				Code:        "PVS1",
 				Name:        "Null variant in gene with established loss of function",
-				Category:    "pathogenic",
-				Strength:    "very_strong",
+				Category:    string(domain.PATHOGENIC_RULE),
+				Strength:    string(domain.VERY_STRONG),
 				Description: "Null variant (nonsense, frameshift, canonical ±1 or 2 splice sites, initiation codon, single or multiexon deletion) in a gene where LOF is a known mechanism of disease",
 				DetailedCriteria: "Applies to nonsense, frameshift, canonical splice site (±1,2), initiation codon, and single/multi-exon deletions in genes with established loss-of-function disease mechanism. Requires careful evaluation of gene constraint, clinical validity of gene-disease association, and potential for escape mechanisms.",
 				EvidenceRequired: []string{
@@ -480,8 +482,8 @@ func (p *ACMGRulesResourceProvider) generatePathogenicRules() PathogenicRulesDat
 			{
 				Code:        "PS1",
 				Name:        "Same amino acid change as previously established pathogenic variant",
-				Category:    "pathogenic",
-				Strength:    "strong",
+				Category:    string(domain.PATHOGENIC_RULE),
+				Strength:    string(domain.STRONG),
 				Description: "Same amino acid change as a previously established pathogenic variant regardless of nucleotide change",
 				DetailedCriteria: "The variant results in the same amino acid change as a variant that has been previously classified as pathogenic/likely pathogenic in a well-curated database. Different nucleotide changes resulting in the same amino acid change qualify.",
 				EvidenceRequired: []string{
@@ -510,8 +512,8 @@ func (p *ACMGRulesResourceProvider) generatePathogenicRules() PathogenicRulesDat
 			{
 				Code:        "PS2",
 				Name:        "De novo in patient with disease and no family history",
-				Category:    "pathogenic",
-				Strength:    "strong",
+				Category:    string(domain.PATHOGENIC_RULE),
+				Strength:    string(domain.STRONG),
 				Description: "De novo (both maternity and paternity confirmed) in a patient with the disease and no family history",
 				DetailedCriteria: "Variant occurred de novo with confirmed maternity and paternity in an individual with the phenotype consistent with the associated gene-disease relationship, and there is no family history of the disease.",
 				EvidenceRequired: []string{
@@ -543,8 +545,8 @@ func (p *ACMGRulesResourceProvider) generatePathogenicRules() PathogenicRulesDat
 			{
 				Code:        "PM1",
 				Name:        "Missense in critical functional domain",
-				Category:    "pathogenic",
-				Strength:    "moderate",
+				Category:    string(domain.PATHOGENIC_RULE),
+				Strength:    string(domain.MODERATE),
 				Description: "Located in a mutational hot spot and/or critical and well-established functional domain (e.g., active site of an enzyme) without benign variation",
 				DetailedCriteria: "Missense variant located in a well-established functional domain that is critical for protein function, is a known mutational hotspot, and lacks benign variation at the same position or in the immediate vicinity.",
 				EvidenceRequired: []string{
@@ -576,8 +578,8 @@ func (p *ACMGRulesResourceProvider) generatePathogenicRules() PathogenicRulesDat
 			{
 				Code:        "PP1",
 				Name:        "Cosegregation in multiple affected family members",
-				Category:    "pathogenic",
-				Strength:    "supporting",
+				Category:    string(domain.PATHOGENIC_RULE),
+				Strength:    string(domain.SUPPORTING),
 				Description: "Cosegregation with disease in multiple affected family members in a gene definitively known to cause the disease",
 				DetailedCriteria: "The variant segregates with disease in multiple affected family members (typically 3 or more meioses) in a gene with definitive evidence for causation of the disease. Statistical significance may strengthen this evidence.",
 				EvidenceRequired: []string{
@@ -615,8 +617,8 @@ func (p *ACMGRulesResourceProvider) generateBenignRules() BenignRulesData {
 			{
 				Code:        "BA1",
 				Name:        "High frequency in general population",
-				Category:    "benign",
-				Strength:    "stand_alone",
+				Category:    string(domain.BENIGN_RULE),
+				Strength:    string(domain.VERY_STRONG),
 				Description: "Allele frequency is >5% in Exome Sequencing Project, 1000 Genomes Project, or Exome Aggregation Consortium",
 				DetailedCriteria: "The variant has an allele frequency greater than 5% in large population databases such as gnomAD, ESP, or 1000 Genomes. This frequency is generally incompatible with causation of Mendelian disease.",
 				EvidenceRequired: []string{
@@ -647,8 +649,8 @@ func (p *ACMGRulesResourceProvider) generateBenignRules() BenignRulesData {
 			{
 				Code:        "BS1",
 				Name:        "Frequency too high for disease",
-				Category:    "benign",
-				Strength:    "strong",
+				Category:    string(domain.BENIGN_RULE),
+				Strength:    string(domain.STRONG),
 				Description: "Allele frequency is greater than expected for disorder",
 				DetailedCriteria: "Allele frequency in population databases exceeds the maximum expected frequency for the disorder, taking into account disease prevalence, penetrance, and genetic heterogeneity.",
 				EvidenceRequired: []string{
@@ -680,8 +682,8 @@ func (p *ACMGRulesResourceProvider) generateBenignRules() BenignRulesData {
 			{
 				Code:        "BP1",
 				Name:        "Missense in gene with low rate of pathogenic missense",
-				Category:    "benign",
-				Strength:    "supporting",
+				Category:    string(domain.BENIGN_RULE),
+				Strength:    string(domain.SUPPORTING),
 				Description: "Missense variant in a gene for which primarily truncating variants are known to cause disease",
 				DetailedCriteria: "The variant is a missense change in a gene where the disease mechanism is primarily through loss-of-function and missense variants are rarely pathogenic.",
 				EvidenceRequired: []string{
@@ -0,0 +1,125 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+)
+
+// AuditResourceProvider provides access to the classification audit trail of a variant
+type AuditResourceProvider struct {
+	logger    *logrus.Logger
+	store     audit.Store
+	uriParser *URIParser
+}
+
+// NewAuditResourceProvider creates a new audit trail resource provider
+func NewAuditResourceProvider(logger *logrus.Logger, store audit.Store) *AuditResourceProvider {
+	provider := &AuditResourceProvider{
+		logger:    logger,
+		store:     store,
+		uriParser: NewURIParser(),
+	}
+
+	// The variant may be a variant ID or an HGVS notation
+	provider.uriParser.AddPattern("variant_audit", `^/audit/(?P<variant_id>.+)$`)
+
+	return provider
+}
+
+// GetResource returns every audit record for a variant, newest first
+func (ap *AuditResourceProvider) GetResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	ap.logger.WithField("uri", uri).Debug("Getting audit resource")
+
+	variant, err := ap.parseVariant(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := ap.store.Query(ctx, audit.Filter{Variant: variant})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit trail for %s: %w", variant, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no audit records found for variant %s", variant)
+	}
+
+	return &ResourceContent{
+		URI:          uri,
+		Name:         fmt.Sprintf("Audit Trail for %s", variant),
+		Description:  "Classification requests, evidence, applied rules and final calls for the variant",
+		MimeType:     "application/json",
+		Content:      map[string]interface{}{"variant": variant, "records": records, "total": len(records)},
+		LastModified: records[0].CreatedAt,
+		ETag:         fmt.Sprintf("audit-%d", records[0].ID),
+		Metadata: map[string]interface{}{
+			"provider": "audit",
+			"variant":  variant,
+			"count":    len(records),
+		},
+	}, nil
+}
+
+// ListResources lists the audit trail URI template; the trail is not enumerable
+func (ap *AuditResourceProvider) ListResources(ctx context.Context, cursor string) (*ResourceList, error) {
+	resources := []ResourceInfo{
+		{
+			URI:         "/audit/{variant_id}",
+			Name:        "Classification Audit Trail",
+			Description: "Audit trail of a variant by variant ID or HGVS notation",
+			MimeType:    "application/json",
+			Tags:        []string{"audit", "classification", "compliance"},
+		},
+	}
+
+	return Paginate(ctx, resources, cursor)
+}
+
+// GetResourceInfo returns metadata about an audit trail resource
+func (ap *AuditResourceProvider) GetResourceInfo(ctx context.Context, uri string) (*ResourceInfo, error) {
+	variant, err := ap.parseVariant(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResourceInfo{
+		URI:         uri,
+		Name:        fmt.Sprintf("Audit Trail for %s", variant),
+		Description: "Classification audit trail",
+		MimeType:    "application/json",
+		Tags:        []string{"audit", "classification", "compliance"},
+		Metadata: map[string]interface{}{
+			"variant": variant,
+		},
+	}, nil
+}
+
+// SupportsURI checks if this provider supports the given URI
+func (ap *AuditResourceProvider) SupportsURI(uri string) bool {
+	_, _, err := ap.uriParser.ParseURI(uri)
+	return err == nil
+}
+
+// GetProviderInfo returns information about this provider
+func (ap *AuditResourceProvider) GetProviderInfo() ProviderInfo {
+	return ProviderInfo{
+		Name:        "audit",
+		Description: "Persistent classification audit trail",
+		Version:     "1.0.0",
+		URIPatterns: []string{
+			"/audit/{variant_id}",
+		},
+	}
+}
+
+// parseVariant extracts the variant ID or HGVS notation from the URI
+func (ap *AuditResourceProvider) parseVariant(uri string) (string, error) {
+	_, params, err := ap.uriParser.ParseURI(uri)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse audit URI: %w", err)
+	}
+	return params["variant_id"], nil
+}
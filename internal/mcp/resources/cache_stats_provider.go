@@ -0,0 +1,103 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// CacheStatsSource reports the statistics of the evidence cache, such as the
+// KnowledgeBaseService in pkg/external
+type CacheStatsSource interface {
+	CacheStats(ctx context.Context) external.CacheStats
+}
+
+// CacheStatsResourceProvider provides evidence cache statistics: the backend,
+// its size, and each source's TTLs, hits, misses and background refreshes
+type CacheStatsResourceProvider struct {
+	logger    *logrus.Logger
+	source    CacheStatsSource
+	uriParser *URIParser
+}
+
+// NewCacheStatsResourceProvider creates a new cache statistics resource provider
+func NewCacheStatsResourceProvider(logger *logrus.Logger, source CacheStatsSource) *CacheStatsResourceProvider {
+	provider := &CacheStatsResourceProvider{
+		logger:    logger,
+		source:    source,
+		uriParser: NewURIParser(),
+	}
+
+	provider.uriParser.AddPattern("cache_stats", `^/cache/stats$`)
+
+	return provider
+}
+
+// GetResource returns the current evidence cache statistics
+func (cp *CacheStatsResourceProvider) GetResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	cp.logger.WithField("uri", uri).Debug("Getting cache stats resource")
+
+	if !cp.SupportsURI(uri) {
+		return nil, fmt.Errorf("unsupported cache resource URI: %s", uri)
+	}
+
+	stats := cp.source.CacheStats(ctx)
+	return &ResourceContent{
+		URI:          "/cache/stats",
+		Name:         "Evidence Cache Statistics",
+		Description:  "Per-source TTLs, hit ratios and stale-while-revalidate refreshes of the evidence cache",
+		MimeType:     "application/json",
+		Content:      stats,
+		LastModified: time.Now(),
+		Metadata: map[string]interface{}{
+			"provider": "cache_stats",
+			"backend":  stats.Backend,
+			"entries":  stats.Entries,
+		},
+	}, nil
+}
+
+// ListResources lists the cache statistics resource
+func (cp *CacheStatsResourceProvider) ListResources(ctx context.Context, cursor string) (*ResourceList, error) {
+	info, err := cp.GetResourceInfo(ctx, "/cache/stats")
+	if err != nil {
+		return nil, err
+	}
+	return Paginate(ctx, []ResourceInfo{*info}, cursor)
+}
+
+// GetResourceInfo returns metadata about the cache statistics resource
+func (cp *CacheStatsResourceProvider) GetResourceInfo(ctx context.Context, uri string) (*ResourceInfo, error) {
+	if !cp.SupportsURI(uri) {
+		return nil, fmt.Errorf("unsupported cache resource URI: %s", uri)
+	}
+	return &ResourceInfo{
+		URI:         uri,
+		Name:        "Evidence Cache Statistics",
+		Description: "Evidence cache size, per-source TTLs and hit ratios",
+		MimeType:    "application/json",
+		Tags:        []string{"cache", "observability"},
+	}, nil
+}
+
+// SupportsURI checks if this provider supports the given URI
+func (cp *CacheStatsResourceProvider) SupportsURI(uri string) bool {
+	_, _, err := cp.uriParser.ParseURI(uri)
+	return err == nil
+}
+
+// GetProviderInfo returns information about this provider
+func (cp *CacheStatsResourceProvider) GetProviderInfo() ProviderInfo {
+	return ProviderInfo{
+		Name:        "cache_stats",
+		Description: "Evidence cache statistics for observability",
+		Version:     "1.0.0",
+		URIPatterns: []string{
+			"/cache/stats",
+		},
+	}
+}
@@ -0,0 +1,109 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// CircuitBreakerSource reports the state of the external API circuit
+// breakers, such as external.CircuitBreakers
+type CircuitBreakerSource interface {
+	Statuses() []external.BreakerStatus
+}
+
+// CircuitBreakerResourceProvider provides the circuit breaker dashboard:
+// each breaker's state, failure counts and next retry
+type CircuitBreakerResourceProvider struct {
+	logger    *logrus.Logger
+	source    CircuitBreakerSource
+	uriParser *URIParser
+}
+
+// NewCircuitBreakerResourceProvider creates a new circuit breaker resource provider
+func NewCircuitBreakerResourceProvider(logger *logrus.Logger, source CircuitBreakerSource) *CircuitBreakerResourceProvider {
+	provider := &CircuitBreakerResourceProvider{
+		logger:    logger,
+		source:    source,
+		uriParser: NewURIParser(),
+	}
+
+	provider.uriParser.AddPattern("circuit_breakers", `^/system/circuit-breakers$`)
+
+	return provider
+}
+
+// GetResource returns the current state of every circuit breaker
+func (cp *CircuitBreakerResourceProvider) GetResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	cp.logger.WithField("uri", uri).Debug("Getting circuit breaker resource")
+
+	if !cp.SupportsURI(uri) {
+		return nil, fmt.Errorf("unsupported circuit breaker resource URI: %s", uri)
+	}
+
+	statuses := cp.source.Statuses()
+	open := 0
+	for _, status := range statuses {
+		if status.State != "closed" {
+			open++
+		}
+	}
+	return &ResourceContent{
+		URI:          "/system/circuit-breakers",
+		Name:         "Circuit Breakers",
+		Description:  "State, failure counts and next retry of each external API circuit breaker",
+		MimeType:     "application/json",
+		Content:      map[string]interface{}{"circuit_breakers": statuses},
+		LastModified: time.Now(),
+		Metadata: map[string]interface{}{
+			"provider":   "circuit_breakers",
+			"breakers":   len(statuses),
+			"not_closed": open,
+		},
+	}, nil
+}
+
+// ListResources lists the circuit breaker resource
+func (cp *CircuitBreakerResourceProvider) ListResources(ctx context.Context, cursor string) (*ResourceList, error) {
+	info, err := cp.GetResourceInfo(ctx, "/system/circuit-breakers")
+	if err != nil {
+		return nil, err
+	}
+	return Paginate(ctx, []ResourceInfo{*info}, cursor)
+}
+
+// GetResourceInfo returns metadata about the circuit breaker resource
+func (cp *CircuitBreakerResourceProvider) GetResourceInfo(ctx context.Context, uri string) (*ResourceInfo, error) {
+	if !cp.SupportsURI(uri) {
+		return nil, fmt.Errorf("unsupported circuit breaker resource URI: %s", uri)
+	}
+	return &ResourceInfo{
+		URI:         uri,
+		Name:        "Circuit Breakers",
+		Description: "External API circuit breaker states for operators",
+		MimeType:    "application/json",
+		Tags:        []string{"circuit-breaker", "observability"},
+	}, nil
+}
+
+// SupportsURI checks if this provider supports the given URI
+func (cp *CircuitBreakerResourceProvider) SupportsURI(uri string) bool {
+	_, _, err := cp.uriParser.ParseURI(uri)
+	return err == nil
+}
+
+// GetProviderInfo returns information about this provider
+func (cp *CircuitBreakerResourceProvider) GetProviderInfo() ProviderInfo {
+	return ProviderInfo{
+		Name:        "circuit_breakers",
+		Description: "External API circuit breaker dashboard",
+		Version:     "1.0.0",
+		URIPatterns: []string{
+			"/system/circuit-breakers",
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// stubBreakerSource returns fixed breaker states
+type stubBreakerSource []external.BreakerStatus
+
+func (s stubBreakerSource) Statuses() []external.BreakerStatus {
+	return s
+}
+
+func TestCircuitBreakerResourceProvider_GetResource(t *testing.T) {
+	source := stubBreakerSource{
+		{Name: "ClinVar", State: "open", ForcedOpen: true, ForceReason: "maintenance"},
+		{Name: "gnomAD", State: "closed"},
+	}
+	provider := NewCircuitBreakerResourceProvider(logrus.New(), source)
+
+	assert.True(t, provider.SupportsURI("/system/circuit-breakers"))
+	assert.False(t, provider.SupportsURI("/system/health"))
+
+	content, err := provider.GetResource(context.Background(), "/system/circuit-breakers")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"circuit_breakers": []external.BreakerStatus(source)}, content.Content)
+	assert.Equal(t, 2, content.Metadata["breakers"])
+	assert.Equal(t, 1, content.Metadata["not_closed"])
+
+	_, err = provider.GetResource(context.Background(), "/cache/stats")
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/digest"
+)
+
+// digestListWeeks is the number of recent weeks listed by the digest provider
+const digestListWeeks = 4
+
+// DigestResourceProvider provides the weekly variant review digest
+type DigestResourceProvider struct {
+	logger    *logrus.Logger
+	generator *digest.Generator
+	uriParser *URIParser
+}
+
+// NewDigestResourceProvider creates a new weekly digest resource provider
+func NewDigestResourceProvider(logger *logrus.Logger, generator *digest.Generator) *DigestResourceProvider {
+	provider := &DigestResourceProvider{
+		logger:    logger,
+		generator: generator,
+		uriParser: NewURIParser(),
+	}
+
+	provider.uriParser.AddPattern("weekly_digest_current", `^/digests/weekly$`)
+	provider.uriParser.AddPattern("weekly_digest", `^/digests/weekly/(?P<date>\d{4}-\d{2}-\d{2})$`)
+
+	return provider
+}
+
+// GetResource generates the digest for the current week or the week containing the given date
+func (dp *DigestResourceProvider) GetResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	dp.logger.WithField("uri", uri).Debug("Getting digest resource")
+
+	weekStart, err := dp.weekFromURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := dp.generator.Generate(ctx, weekStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate digest: %w", err)
+	}
+
+	week := d.WeekStart.Format("2006-01-02")
+	return &ResourceContent{
+		URI:          fmt.Sprintf("/digests/weekly/%s", week),
+		Name:         fmt.Sprintf("Variant Review Digest: Week of %s", week),
+		Description:  "Classifications, reclassifications, ClinVar discordances and data source updates",
+		MimeType:     "application/json",
+		Content:      d,
+		LastModified: d.GeneratedAt,
+		Metadata: map[string]interface{}{
+			"provider":   "digest",
+			"week_start": week,
+			"text":       d.Text(),
+		},
+	}, nil
+}
+
+// ListResources lists the digests for the most recent weeks
+func (dp *DigestResourceProvider) ListResources(ctx context.Context, cursor string) (*ResourceList, error) {
+	current := digest.WeekStart(time.Now())
+
+	resources := make([]ResourceInfo, 0, digestListWeeks)
+	for i := 0; i < digestListWeeks; i++ {
+		week := current.Add(-time.Duration(i) * digest.Week).Format("2006-01-02")
+		resources = append(resources, ResourceInfo{
+			URI:         fmt.Sprintf("/digests/weekly/%s", week),
+			Name:        fmt.Sprintf("Variant Review Digest: Week of %s", week),
+			Description: "Weekly variant review digest",
+			MimeType:    "application/json",
+			Tags:        []string{"digest", "review", "weekly"},
+		})
+	}
+
+	return Paginate(ctx, resources, cursor)
+}
+
+// GetResourceInfo returns metadata about a digest resource
+func (dp *DigestResourceProvider) GetResourceInfo(ctx context.Context, uri string) (*ResourceInfo, error) {
+	weekStart, err := dp.weekFromURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	week := weekStart.Format("2006-01-02")
+	return &ResourceInfo{
+		URI:         uri,
+		Name:        fmt.Sprintf("Variant Review Digest: Week of %s", week),
+		Description: "Weekly variant review digest",
+		MimeType:    "application/json",
+		Tags:        []string{"digest", "review", "weekly"},
+		Metadata: map[string]interface{}{
+			"week_start": week,
+		},
+	}, nil
+}
+
+// SupportsURI checks if this provider supports the given URI
+func (dp *DigestResourceProvider) SupportsURI(uri string) bool {
+	_, _, err := dp.uriParser.ParseURI(uri)
+	return err == nil
+}
+
+// GetProviderInfo returns information about this provider
+func (dp *DigestResourceProvider) GetProviderInfo() ProviderInfo {
+	return ProviderInfo{
+		Name:        "digest",
+		Description: "Weekly variant review digest for the lab's review meeting",
+		Version:     "1.0.0",
+		URIPatterns: []string{
+			"/digests/weekly",
+			"/digests/weekly/{date}",
+		},
+	}
+}
+
+// weekFromURI resolves a digest URI to the start of its week
+func (dp *DigestResourceProvider) weekFromURI(uri string) (time.Time, error) {
+	_, params, err := dp.uriParser.ParseURI(uri)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse digest URI: %w", err)
+	}
+
+	date, ok := params["date"]
+	if !ok {
+		return digest.WeekStart(time.Now()), nil
+	}
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid digest date %q: %w", date, err)
+	}
+	return digest.WeekStart(t), nil
+}
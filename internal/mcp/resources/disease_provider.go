@@ -0,0 +1,170 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// DiseaseResourceProvider provides the OMIM and Orphanet diseases
+// associated with a gene: inheritance, phenotypic series, prevalence and
+// age of onset, for putting a classification and BS2 penetrance reasoning
+// in the context of the disease
+type DiseaseResourceProvider struct {
+	logger    *logrus.Logger
+	omim      external.GeneDiseaseClient
+	orphanet  external.GeneDiseaseClient
+	uriParser *URIParser
+}
+
+// NewDiseaseResourceProvider creates a new gene-disease resource provider.
+// Either source may be nil when it is not configured.
+func NewDiseaseResourceProvider(logger *logrus.Logger, omim, orphanet external.GeneDiseaseClient) *DiseaseResourceProvider {
+	provider := &DiseaseResourceProvider{
+		logger:    logger,
+		omim:      omim,
+		orphanet:  orphanet,
+		uriParser: NewURIParser(),
+	}
+
+	provider.uriParser.AddPattern("gene_diseases", `^/genes/(?P<symbol>[A-Za-z0-9-]+)/diseases$`)
+
+	return provider
+}
+
+// GetResource returns the diseases associated with a gene. A source that
+// fails is reported in the metadata as long as another source answers.
+func (dp *DiseaseResourceProvider) GetResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	dp.logger.WithField("uri", uri).Debug("Getting gene disease resource")
+
+	_, params, err := dp.uriParser.ParseURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse gene disease URI: %w", err)
+	}
+	symbol := strings.ToUpper(params["symbol"])
+
+	sources := []struct {
+		name   string
+		client external.GeneDiseaseClient
+	}{
+		{domain.DiseaseSourceOMIM, dp.omim},
+		{domain.DiseaseSourceOrphanet, dp.orphanet},
+	}
+	result := &domain.GeneDiseases{Gene: symbol, Diseases: []domain.DiseaseAssociation{}, Sources: []string{}}
+	sourceErrors := map[string]string{}
+	for _, source := range sources {
+		if source.client == nil {
+			continue
+		}
+		diseases, err := source.client.QueryGeneDiseases(ctx, symbol)
+		if err != nil {
+			dp.logger.WithError(err).WithField("source", source.name).Warn("Gene disease lookup failed")
+			sourceErrors[source.name] = err.Error()
+			continue
+		}
+		result.Sources = append(result.Sources, source.name)
+		result.Diseases = append(result.Diseases, diseases...)
+	}
+	if len(result.Sources) == 0 {
+		if len(sourceErrors) > 0 {
+			return nil, fmt.Errorf("failed to look up diseases for %s: %v", symbol, sourceErrors)
+		}
+		return nil, fmt.Errorf("no gene-disease sources are configured")
+	}
+	result.Inheritance = inheritanceModes(result.Diseases)
+
+	metadata := map[string]interface{}{
+		"provider": "diseases",
+		"gene":     symbol,
+		"count":    len(result.Diseases),
+		"sources":  result.Sources,
+	}
+	if len(sourceErrors) > 0 {
+		metadata["errors"] = sourceErrors
+	}
+	return &ResourceContent{
+		URI:          fmt.Sprintf("/genes/%s/diseases", symbol),
+		Name:         fmt.Sprintf("%s Disease Associations", symbol),
+		Description:  "OMIM and Orphanet diseases with inheritance, phenotypic series, prevalence and age of onset",
+		MimeType:     "application/json",
+		Content:      result,
+		LastModified: time.Now(),
+		Metadata:     metadata,
+	}, nil
+}
+
+// inheritanceModes lists the distinct modes of inheritance of the diseases,
+// in the order first seen
+func inheritanceModes(diseases []domain.DiseaseAssociation) []string {
+	seen := make(map[string]bool)
+	var modes []string
+	for _, disease := range diseases {
+		for _, mode := range disease.Inheritance {
+			key := strings.ToLower(mode)
+			if !seen[key] {
+				seen[key] = true
+				modes = append(modes, mode)
+			}
+		}
+	}
+	return modes
+}
+
+// ListResources lists the gene disease URI template; genes are not enumerated
+func (dp *DiseaseResourceProvider) ListResources(ctx context.Context, cursor string) (*ResourceList, error) {
+	resources := []ResourceInfo{
+		{
+			URI:         "/genes/{symbol}/diseases",
+			Name:        "Gene Disease Associations",
+			Description: "OMIM and Orphanet diseases associated with a gene",
+			MimeType:    "application/json",
+			Tags:        []string{"gene", "disease", "omim", "orphanet"},
+		},
+	}
+
+	return Paginate(ctx, resources, cursor)
+}
+
+// GetResourceInfo returns metadata about a gene disease resource
+func (dp *DiseaseResourceProvider) GetResourceInfo(ctx context.Context, uri string) (*ResourceInfo, error) {
+	_, params, err := dp.uriParser.ParseURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse gene disease URI: %w", err)
+	}
+
+	symbol := strings.ToUpper(params["symbol"])
+	return &ResourceInfo{
+		URI:         uri,
+		Name:        fmt.Sprintf("%s Disease Associations", symbol),
+		Description: "OMIM and Orphanet diseases associated with the gene",
+		MimeType:    "application/json",
+		Tags:        []string{"gene", "disease", "omim", "orphanet"},
+		Metadata: map[string]interface{}{
+			"gene": symbol,
+		},
+	}, nil
+}
+
+// SupportsURI checks if this provider supports the given URI
+func (dp *DiseaseResourceProvider) SupportsURI(uri string) bool {
+	_, _, err := dp.uriParser.ParseURI(uri)
+	return err == nil
+}
+
+// GetProviderInfo returns information about this provider
+func (dp *DiseaseResourceProvider) GetProviderInfo() ProviderInfo {
+	return ProviderInfo{
+		Name:        "diseases",
+		Description: "Gene-disease associations from OMIM and Orphanet",
+		Version:     "1.0.0",
+		URIPatterns: []string{
+			"/genes/{symbol}/diseases",
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// stubDiseaseSource returns fixed diseases or a fixed error
+type stubDiseaseSource struct {
+	diseases []domain.DiseaseAssociation
+	err      error
+}
+
+func (s *stubDiseaseSource) QueryGeneDiseases(ctx context.Context, gene string) ([]domain.DiseaseAssociation, error) {
+	return s.diseases, s.err
+}
+
+func TestDiseaseResourceProvider_GetResource(t *testing.T) {
+	omim := &stubDiseaseSource{diseases: []domain.DiseaseAssociation{
+		{Source: domain.DiseaseSourceOMIM, ID: "OMIM:219700", Name: "Cystic fibrosis", Inheritance: []string{"Autosomal recessive"}},
+	}}
+	orphanet := &stubDiseaseSource{diseases: []domain.DiseaseAssociation{
+		{Source: domain.DiseaseSourceOrphanet, ID: "ORPHA:586", Name: "Cystic fibrosis", Inheritance: []string{"Autosomal recessive"}},
+		{Source: domain.DiseaseSourceOrphanet, ID: "ORPHA:48", Name: "Congenital bilateral absence of vas deferens", Inheritance: []string{"Autosomal recessive", "Not applicable"}},
+	}}
+	provider := NewDiseaseResourceProvider(logrus.New(), omim, orphanet)
+
+	assert.True(t, provider.SupportsURI("/genes/CFTR/diseases"))
+	assert.False(t, provider.SupportsURI("/genes/CFTR/playbook"))
+
+	content, err := provider.GetResource(context.Background(), "/genes/cftr/diseases")
+	require.NoError(t, err)
+	assert.Equal(t, "/genes/CFTR/diseases", content.URI)
+	diseases, ok := content.Content.(*domain.GeneDiseases)
+	require.True(t, ok)
+	assert.Equal(t, "CFTR", diseases.Gene)
+	assert.Len(t, diseases.Diseases, 3)
+	assert.Equal(t, []string{"OMIM", "Orphanet"}, diseases.Sources)
+	assert.Equal(t, []string{"Autosomal recessive", "Not applicable"}, diseases.Inheritance)
+
+	// A failing source is reported while the other still answers
+	omim.err = errors.New("OMIM returned status 401")
+	content, err = provider.GetResource(context.Background(), "/genes/CFTR/diseases")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Orphanet"}, content.Content.(*domain.GeneDiseases).Sources)
+	assert.Contains(t, content.Metadata["errors"], "OMIM")
+
+	_, err = NewDiseaseResourceProvider(logrus.New(), omim, nil).GetResource(context.Background(), "/genes/CFTR/diseases")
+	assert.Error(t, err)
+	_, err = NewDiseaseResourceProvider(logrus.New(), nil, nil).GetResource(context.Background(), "/genes/CFTR/diseases")
+	assert.Error(t, err)
+}
@@ -0,0 +1,474 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+// Evidence origins reported in EvidenceData.Origin
+const (
+	EvidenceOriginLive   = "live"
+	EvidenceOriginCached = "cached"
+	EvidenceOriginMock   = "mock"
+)
+
+// maxCachedEvidence bounds the last-known-good evidence cache
+const maxCachedEvidence = 1000
+
+// HotspotSampleCount is the number of COSMIC tumor samples at which a
+// variant is reported as a recurrent hotspot
+const HotspotSampleCount = 10
+
+// SomaticEvidenceSource provides clinical evidence items for somatic
+// variants, such as the CIViC client in pkg/external
+type SomaticEvidenceSource interface {
+	Name() string
+	QuerySomaticEvidence(ctx context.Context, variant *domain.StandardizedVariant, tumorType string) ([]domain.SomaticEvidence, error)
+}
+
+// SetKnowledgeBase configures the backend used to resolve live evidence.
+// Without one, evidence resources fail unless the mock fallback is enabled.
+func (p *EvidenceResourceProvider) SetKnowledgeBase(kb domain.KnowledgeBaseAccess) {
+	p.knowledgeBase = kb
+}
+
+// SetSomaticEvidenceSource configures the knowledge base queried for the
+// clinical evidence and therapy associations of somatic evidence resources
+func (p *EvidenceResourceProvider) SetSomaticEvidenceSource(source SomaticEvidenceSource) {
+	p.somaticSource = source
+}
+
+// SetFallbackToMock controls whether mock data is served when the knowledge
+// base is missing or unavailable and no cached evidence exists for the
+// variant. It is disabled by default; mock responses carry the mock origin.
+func (p *EvidenceResourceProvider) SetFallbackToMock(enabled bool) {
+	p.fallbackToMock = enabled
+}
+
+// loadEvidence resolves evidence from the knowledge base, falling back to the
+// last successful lookup and then, if enabled, to mock data
+func (p *EvidenceResourceProvider) loadEvidence(ctx context.Context, variantID string) (*EvidenceData, error) {
+	if p.knowledgeBase == nil {
+		if p.fallbackToMock {
+			return p.generateFullEvidenceData(variantID), nil
+		}
+		return nil, fmt.Errorf("no evidence knowledge base is configured for %s", variantID)
+	}
+
+	variant := variantFromID(variantID)
+	aggregated, err := p.knowledgeBase.GatherEvidence(ctx, variant)
+	if err == nil && aggregated != nil {
+		evidence := convertAggregatedEvidence(variantID, aggregated)
+		p.addSomaticEvidence(ctx, evidence, variant)
+		p.cacheEvidence(variantID, evidence)
+		return evidence, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("knowledge base returned no evidence")
+	}
+
+	p.logger.WithError(err).WithField("variant_id", variantID).Warn("Knowledge base unavailable for evidence resource")
+
+	p.cacheMu.RLock()
+	cached, ok := p.cache[variantID]
+	p.cacheMu.RUnlock()
+	if ok {
+		fallback := *cached
+		fallback.Origin = EvidenceOriginCached
+		return &fallback, nil
+	}
+
+	if p.fallbackToMock {
+		return p.generateFullEvidenceData(variantID), nil
+	}
+
+	return nil, fmt.Errorf("failed to gather evidence for %s: %w", variantID, err)
+}
+
+// addSomaticEvidence adds clinical evidence items and the therapies they
+// associate with the variant; lookup failures are logged and leave them empty
+func (p *EvidenceResourceProvider) addSomaticEvidence(ctx context.Context, data *EvidenceData, variant *domain.StandardizedVariant) {
+	if p.somaticSource == nil {
+		return
+	}
+
+	items, err := p.somaticSource.QuerySomaticEvidence(ctx, variant, "")
+	if err != nil {
+		p.logger.WithError(err).WithFields(logrus.Fields{
+			"variant_id": variant.ID,
+			"source":     p.somaticSource.Name(),
+		}).Warn("Failed to query somatic clinical evidence")
+		return
+	}
+
+	data.SomaticEvidence.ClinicalEvidence = items
+	data.SomaticEvidence.TherapyAssociations = therapyAssociations(items)
+	data.DataSources = append(data.DataSources, liveDataSource(p.somaticSource.Name(), "somatic_clinical_database", time.Now()))
+}
+
+// therapyAssociations lists each therapy of the therapeutic evidence items
+// with the response, tumor type and level it was reported at
+func therapyAssociations(items []domain.SomaticEvidence) []TherapyAssociationData {
+	associations := make([]TherapyAssociationData, 0)
+	for _, item := range items {
+		if item.Type != domain.SomaticTherapeutic {
+			continue
+		}
+		for _, therapy := range item.Therapies {
+			associations = append(associations, TherapyAssociationData{
+				Therapy:       therapy,
+				Response:      item.Significance,
+				TumorType:     item.TumorType,
+				EvidenceLevel: string(item.Level),
+				Source:        item.Source,
+				References:    item.References,
+			})
+		}
+	}
+	return associations
+}
+
+func (p *EvidenceResourceProvider) cacheEvidence(variantID string, evidence *EvidenceData) {
+	p.cacheMu.Lock()
+	defer p.cacheMu.Unlock()
+
+	if _, exists := p.cache[variantID]; !exists && len(p.cache) >= maxCachedEvidence {
+		for key := range p.cache {
+			delete(p.cache, key)
+			break
+		}
+	}
+	p.cache[variantID] = evidence
+}
+
+// variantFromID builds a lookup variant from a resource variant ID, which is
+// either an HGVS expression, on an accession or a gene (e.g. BRAF:p.V600E),
+// or an opaque identifier
+func variantFromID(variantID string) *domain.StandardizedVariant {
+	variant := &domain.StandardizedVariant{ID: variantID}
+
+	if idx := strings.Index(variantID, ":"); idx > 0 {
+		if reference := variantID[:idx]; isAccession(reference) {
+			variant.TranscriptID = reference
+		} else {
+			variant.GeneSymbol = reference
+		}
+		switch {
+		case strings.Contains(variantID, ":c."):
+			variant.HGVSCoding = variantID
+		case strings.Contains(variantID, ":p."):
+			variant.HGVSProtein = variantID
+		default:
+			variant.HGVSGenomic = variantID
+		}
+	}
+
+	return variant
+}
+
+// isAccession reports whether an HGVS reference is a sequence accession, such
+// as NM_000546.6 or ENST00000269305, rather than a gene symbol
+func isAccession(reference string) bool {
+	return strings.Contains(reference, "_") || strings.HasPrefix(reference, "ENS")
+}
+
+// convertAggregatedEvidence maps knowledge base evidence onto the resource representation
+func convertAggregatedEvidence(variantID string, evidence *domain.AggregatedEvidence) *EvidenceData {
+	now := time.Now()
+	gatheredAt := evidence.GatheredAt
+	if gatheredAt.IsZero() {
+		gatheredAt = now
+	}
+
+	data := &EvidenceData{
+		VariantID:   variantID,
+		LastUpdated: gatheredAt,
+		Origin:      EvidenceOriginLive,
+		DataSources: []DataSourceInfo{},
+		SomaticEvidence: SomaticEvidenceData{
+			ClinicalEvidence:    []domain.SomaticEvidence{},
+			TherapyAssociations: []TherapyAssociationData{},
+		},
+		EvidenceQuality: EvidenceQualityMetrics{
+			QualityByCategory: map[string]string{},
+		},
+	}
+
+	categories := make([]EvidenceCategoryData, 0)
+	available := 0
+
+	if pop := evidence.PopulationData; pop != nil {
+		available++
+		data.PopulationEvidence = PopulationEvidenceData{
+			GnomAD: PopulationFrequencyData{
+				AlleleCount:         pop.AlleleCount,
+				AlleleNumber:        pop.AlleleNumber,
+				AlleleFrequency:     pop.AlleleFrequency,
+				HomozygousCount:     pop.HomozygoteCount,
+				PopulationBreakdown: pop.PopulationFrequencies,
+				LastUpdated:         gatheredAt,
+			},
+			FrequencyAssessment: assessFrequency(pop.AlleleFrequency),
+		}
+		if pop.QualityMetrics != nil {
+			data.PopulationEvidence.GnomAD.QualityMetrics = FrequencyQualityData{
+				DepthCoverage:   float64(pop.QualityMetrics.Coverage),
+				GenotypeQuality: pop.QualityMetrics.Quality,
+			}
+		}
+		categories = append(categories, EvidenceCategoryData{
+			Category:    "Population",
+			Sources:     1,
+			Description: data.PopulationEvidence.FrequencyAssessment.Assessment,
+			Supporting:  []string{fmt.Sprintf("gnomAD allele frequency %.6g", pop.AlleleFrequency)},
+		})
+		data.DataSources = append(data.DataSources, liveDataSource("gnomAD", "population_database", gatheredAt))
+	}
+
+	if cv := evidence.ClinVarData; cv != nil {
+		available++
+		clinvar := ClinVarData{
+			VariationID:   cv.VariationID,
+			ReviewStatus:  cv.ReviewStatus,
+			LastEvaluated: cv.LastEvaluated,
+			Stars:         domain.ReviewStatusStars(cv.ReviewStatus),
+		}
+		for _, sub := range cv.Submissions {
+			clinvar.ClinicalSignificance = append(clinvar.ClinicalSignificance, ClinicalSignificanceData{
+				Classification: sub.ClinicalSignificance,
+				DateLastEval:   sub.SubmissionDate,
+				Submitter:      sub.Submitter,
+			})
+			clinvar.Submitters = append(clinvar.Submitters, SubmitterData{Name: sub.Submitter})
+		}
+		for _, condition := range cv.Conditions {
+			clinvar.Conditions = append(clinvar.Conditions, ConditionData{Name: condition})
+		}
+		data.ClinicalEvidence.ClinVar = clinvar
+		categories = append(categories, EvidenceCategoryData{
+			Category:    "Clinical",
+			Sources:     len(cv.Submissions),
+			Description: fmt.Sprintf("ClinVar: %s (%s)", cv.ClinicalSignificance, cv.ReviewStatus),
+			Supporting:  []string{"ClinVar " + cv.ClinicalSignificance},
+		})
+		data.DataSources = append(data.DataSources, liveDataSource("ClinVar", "clinical_database", gatheredAt))
+	}
+
+	if comp := evidence.ComputationalData; comp != nil {
+		available++
+		data.ComputationalEvidence = ComputationalEvidenceData{
+			ConservationScores:  map[string]float64{},
+			PathogenicityScores: map[string]float64{},
+		}
+		var descriptions []string
+		for _, score := range []struct {
+			predictor    string
+			value        float64
+			conservation bool
+			format       string
+		}{
+			{domain.PredictorCADD, comp.CADDScore, false, "%s %.1f"},
+			{domain.PredictorREVEL, comp.REVELScore, false, "%s %.3f"},
+			{domain.PredictorAlphaMissense, comp.AlphaMissenseScore, false, "%s %.3f"},
+			{domain.PredictorSIFT, comp.SIFTScore, false, "%s %.2f"},
+			{domain.PredictorPolyPhen, comp.PolyPhenScore, false, "%s %.2f"},
+			{domain.PredictorGERP, comp.GERPScore, true, ""},
+			{domain.PredictorPhyloP, comp.PhyloPScore, true, ""},
+		} {
+			if !comp.Has(score.predictor) {
+				continue
+			}
+			if score.conservation {
+				data.ComputationalEvidence.ConservationScores[score.predictor] = score.value
+				continue
+			}
+			data.ComputationalEvidence.PathogenicityScores[score.predictor] = score.value
+			descriptions = append(descriptions, fmt.Sprintf(score.format, score.predictor, score.value))
+		}
+		categories = append(categories, EvidenceCategoryData{
+			Category:    "Computational",
+			Sources:     1,
+			Description: strings.Join(descriptions, ", "),
+		})
+		source := "In silico predictors"
+		if comp.Source != "" {
+			source = comp.Source
+		}
+		data.DataSources = append(data.DataSources, liveDataSource(source, "computational_predictions", gatheredAt))
+	}
+
+	if len(evidence.SplicingPredictions) > 0 {
+		if evidence.ComputationalData == nil {
+			available++
+		}
+		data.ComputationalEvidence.SplicingPredictions = make(map[string]SplicingPrediction, len(evidence.SplicingPredictions))
+		var descriptions []string
+		for _, p := range evidence.SplicingPredictions {
+			data.ComputationalEvidence.SplicingPredictions[p.Tool] = convertSplicingPrediction(p)
+			descriptions = append(descriptions, fmt.Sprintf("%s %.2f", p.Tool, p.Score))
+		}
+		categories = append(categories, EvidenceCategoryData{
+			Category:    "Splicing",
+			Sources:     len(evidence.SplicingPredictions),
+			Description: strings.Join(descriptions, ", "),
+		})
+		data.DataSources = append(data.DataSources, liveDataSource("Splicing predictors", "computational_predictions", gatheredAt))
+	}
+
+	if somatic := evidence.SomaticData; somatic != nil {
+		data.SomaticEvidence.COSMIC = COSMICRecurrenceData{
+			CosmicID:      somatic.CosmicID,
+			SampleCount:   somatic.SampleCount,
+			MutationCount: somatic.MutationCount,
+			TumorTypes:    somatic.TumorTypes,
+			Hotspot:       somatic.SampleCount >= HotspotSampleCount,
+			Pathogenicity: somatic.Pathogenicity,
+		}
+		description := fmt.Sprintf("COSMIC: observed in %d tumor samples", somatic.SampleCount)
+		if data.SomaticEvidence.COSMIC.Hotspot {
+			description += " (recurrent hotspot)"
+		}
+		categories = append(categories, EvidenceCategoryData{
+			Category:    "Somatic",
+			Sources:     1,
+			Description: description,
+			Supporting:  somatic.TumorTypes,
+		})
+		data.DataSources = append(data.DataSources, liveDataSource("COSMIC", "somatic_database", gatheredAt))
+	}
+
+	if lit := evidence.LiteratureData; lit != nil {
+		available++
+		retracted := 0
+		distribution := make(map[string]int)
+		var keyFindings []string
+		for _, citation := range lit.Citations {
+			if citation.Retracted {
+				retracted++
+			}
+			data.LiteratureEvidence.PubMedArticles = append(data.LiteratureEvidence.PubMedArticles, LiteratureArticleData{
+				PMID:            citation.PMID,
+				Title:           citation.Title,
+				Authors:         citation.Authors,
+				Journal:         citation.Journal,
+				PublicationDate: time.Date(citation.Year, time.January, 1, 0, 0, 0, 0, time.UTC),
+				StudyType:       citation.StudyType,
+				Findings:        strings.Join(citation.KeyFindings, " "),
+				EvidenceLevel:   citation.Relevance,
+				Signals:         citation.Signals,
+				Retracted:       citation.Retracted,
+				Notices:         citation.Notices,
+			})
+
+			// Retracted articles are listed but not counted as evidence
+			if citation.Retracted {
+				continue
+			}
+			for _, signal := range citation.Signals {
+				distribution[signal]++
+			}
+			if citation.HasSignal(domain.SignalCaseReport) {
+				data.LiteratureEvidence.CaseReports = append(data.LiteratureEvidence.CaseReports, CaseReportData{
+					PMID:            citation.PMID,
+					ClinicalDetails: citation.Title,
+				})
+			}
+			for _, finding := range citation.KeyFindings {
+				keyFindings = append(keyFindings, fmt.Sprintf("%s (PMID %s)", finding, citation.PMID))
+			}
+		}
+		data.LiteratureEvidence.LiteratureSummary = LiteratureSummaryData{
+			TotalArticles:        lit.TotalCitations,
+			EvidenceDistribution: distribution,
+			KeyFindings:          keyFindings,
+		}
+		categories = append(categories, EvidenceCategoryData{
+			Category:    "Literature",
+			Sources:     lit.RetrievedCitations,
+			Description: literatureDescription(lit, retracted),
+		})
+		data.DataSources = append(data.DataSources, liveDataSource("PubMed", "literature_database", gatheredAt))
+	}
+
+	data.EvidenceSummary = EvidenceSummaryData{
+		OverallStrength:    "Not assessed",
+		EvidenceCategories: categories,
+		Recommendations:    []string{"Apply ACMG/AMP criteria with classify_variant for an overall assessment"},
+	}
+	data.EvidenceQuality.DataCompletion = float64(available) / 4
+
+	return data
+}
+
+// convertSplicingPrediction labels a SpliceAI/Pangolin delta score using the
+// default splice cutoffs; the rule engine applies the effective thresholds
+func convertSplicingPrediction(p domain.SplicingPrediction) SplicingPrediction {
+	prediction := SplicingPrediction{
+		Score:      p.Score,
+		Prediction: "indeterminate",
+		Confidence: p.Score,
+	}
+	switch {
+	case p.Score >= thresholds.DefaultSpliceDeleterious:
+		prediction.Prediction = "splice_altering"
+	case p.Score <= thresholds.DefaultSpliceBenign:
+		prediction.Prediction = "no_impact"
+		prediction.Confidence = 1 - p.Score
+	}
+
+	// Events are acceptor_gain, donor_loss, ... or splice_gain/splice_loss for Pangolin
+	if site, effect, ok := strings.Cut(p.Event, "_"); ok {
+		if site == "splice" {
+			site = "splice_site"
+		}
+		prediction.SiteType = site
+		prediction.Effect = effect
+	}
+	return prediction
+}
+
+// assessFrequency applies the ACMG/AMP population frequency thresholds
+func assessFrequency(frequency float64) FrequencyAssessmentData {
+	assessment := FrequencyAssessmentData{FrequencyThreshold: 0.05}
+
+	switch {
+	case frequency > 0.05:
+		assessment.ACMGCategory = "BA1"
+		assessment.TooCommonForDisease = true
+		assessment.Assessment = "Allele frequency above 5% supports benign classification"
+	case frequency < 0.0001:
+		assessment.ACMGCategory = "PM2"
+		assessment.FrequencyThreshold = 0.0001
+		assessment.IsRareVariant = true
+		assessment.Assessment = "Absent or extremely rare in population databases"
+	default:
+		assessment.Assessment = "Frequency not informative on its own"
+	}
+
+	return assessment
+}
+
+func liveDataSource(name, sourceType string, accessed time.Time) DataSourceInfo {
+	return DataSourceInfo{
+		SourceName:   name,
+		SourceType:   sourceType,
+		LastAccessed: accessed,
+		AccessMethod: "API",
+	}
+}
+
+// literatureDescription summarizes retrieved citations, calling out retracted ones
+func literatureDescription(lit *domain.LiteratureData, retracted int) string {
+	description := fmt.Sprintf("%d citations retrieved of %d", lit.RetrievedCitations, lit.TotalCitations)
+	if retracted > 0 {
+		description += fmt.Sprintf(" (%d retracted)", retracted)
+	}
+	return description
+}
@@ -0,0 +1,214 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// stubKnowledgeBase returns fixed evidence or a fixed error
+type stubKnowledgeBase struct {
+	evidence *domain.AggregatedEvidence
+	err      error
+	lastSeen *domain.StandardizedVariant
+}
+
+func (s *stubKnowledgeBase) GatherEvidence(ctx context.Context, variant *domain.StandardizedVariant) (*domain.AggregatedEvidence, error) {
+	s.lastSeen = variant
+	return s.evidence, s.err
+}
+
+func (s *stubKnowledgeBase) QueryClinVar(variant *domain.StandardizedVariant) (*domain.ClinVarData, error) {
+	return s.evidence.ClinVarData, s.err
+}
+
+func (s *stubKnowledgeBase) QueryGnomAD(variant *domain.StandardizedVariant) (*domain.PopulationData, error) {
+	return s.evidence.PopulationData, s.err
+}
+
+func (s *stubKnowledgeBase) QueryCOSMIC(variant *domain.StandardizedVariant) (*domain.SomaticData, error) {
+	return s.evidence.SomaticData, s.err
+}
+
+func newTestEvidenceProvider() *EvidenceResourceProvider {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	return NewEvidenceResourceProvider(logger)
+}
+
+func TestEvidenceResourceProvider_LiveKnowledgeBase(t *testing.T) {
+	provider := newTestEvidenceProvider()
+	kb := &stubKnowledgeBase{evidence: &domain.AggregatedEvidence{
+		ClinVarData: &domain.ClinVarData{
+			VariationID:          "VCV000017661",
+			ClinicalSignificance: "Pathogenic",
+			ReviewStatus:         "reviewed by expert panel",
+		},
+		PopulationData:    &domain.PopulationData{AlleleFrequency: 0.00001},
+		ComputationalData: &domain.ComputationalData{CADDScore: 35},
+	}}
+	provider.SetKnowledgeBase(kb)
+
+	// Act
+	evidence, err := provider.loadEvidence(context.Background(), "NM_007294.4:c.5266dupC")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, EvidenceOriginLive, evidence.Origin)
+	assert.Equal(t, "NM_007294.4:c.5266dupC", kb.lastSeen.HGVSCoding)
+	assert.Equal(t, "VCV000017661", evidence.ClinicalEvidence.ClinVar.VariationID)
+	assert.Equal(t, 3, evidence.ClinicalEvidence.ClinVar.Stars)
+	assert.Equal(t, "PM2", evidence.PopulationEvidence.FrequencyAssessment.ACMGCategory)
+	assert.Equal(t, 35.0, evidence.ComputationalEvidence.PathogenicityScores["CADD"])
+}
+
+func TestEvidenceResourceProvider_Fallbacks(t *testing.T) {
+	provider := newTestEvidenceProvider()
+	kb := &stubKnowledgeBase{evidence: &domain.AggregatedEvidence{
+		ClinVarData: &domain.ClinVarData{VariationID: "VCV1"},
+	}}
+	provider.SetKnowledgeBase(kb)
+	ctx := context.Background()
+
+	_, err := provider.loadEvidence(ctx, "var-1")
+	require.NoError(t, err)
+
+	kb.evidence, kb.err = nil, errors.New("connection refused")
+
+	// Previously resolved variant is served from cache
+	evidence, err := provider.loadEvidence(ctx, "var-1")
+	require.NoError(t, err)
+	assert.Equal(t, EvidenceOriginCached, evidence.Origin)
+	assert.Equal(t, "VCV1", evidence.ClinicalEvidence.ClinVar.VariationID)
+
+	// Mock fallback is opt-in, so the failure is surfaced by default
+	_, err = provider.GetResource(ctx, "/evidence/var-2/clinical")
+	assert.Error(t, err)
+
+	// Once enabled, an unknown variant falls back to mock data
+	provider.SetFallbackToMock(true)
+	evidence, err = provider.loadEvidence(ctx, "var-2")
+	require.NoError(t, err)
+	assert.Equal(t, EvidenceOriginMock, evidence.Origin)
+}
+
+func TestEvidenceResourceProvider_NoKnowledgeBase(t *testing.T) {
+	provider := newTestEvidenceProvider()
+	ctx := context.Background()
+
+	_, err := provider.GetResource(ctx, "/evidence/var-1")
+	assert.Error(t, err, "mock data is not served unless enabled")
+
+	provider.SetFallbackToMock(true)
+	resource, err := provider.GetResource(ctx, "/evidence/var-1")
+	require.NoError(t, err)
+	assert.Equal(t, EvidenceOriginMock, resource.Metadata["origin"])
+}
+
+func TestEvidenceResourceProvider_SplicingPredictions(t *testing.T) {
+	provider := newTestEvidenceProvider()
+	provider.SetKnowledgeBase(&stubKnowledgeBase{evidence: &domain.AggregatedEvidence{
+		SplicingPredictions: []domain.SplicingPrediction{
+			{Tool: "SpliceAI", Score: 0.64, Event: domain.SpliceEventDonorLoss},
+			{Tool: "Pangolin", Score: 0.03, Event: domain.SpliceEventGain},
+		},
+	}})
+
+	evidence, err := provider.loadEvidence(context.Background(), "NM_000492.4:c.1585-1G>A")
+
+	require.NoError(t, err)
+	splicing := evidence.ComputationalEvidence.SplicingPredictions
+	require.Len(t, splicing, 2)
+	assert.Equal(t, "splice_altering", splicing["SpliceAI"].Prediction)
+	assert.Equal(t, "donor", splicing["SpliceAI"].SiteType)
+	assert.Equal(t, "loss", splicing["SpliceAI"].Effect)
+	assert.Equal(t, "no_impact", splicing["Pangolin"].Prediction)
+	assert.Equal(t, "splice_site", splicing["Pangolin"].SiteType)
+	assert.Equal(t, 0.25, evidence.EvidenceQuality.DataCompletion)
+}
+
+// stubSomaticSource returns fixed somatic evidence and records the variant queried
+type stubSomaticSource struct {
+	evidence []domain.SomaticEvidence
+	err      error
+	lastSeen *domain.StandardizedVariant
+}
+
+func (s *stubSomaticSource) Name() string { return "CIViC" }
+
+func (s *stubSomaticSource) QuerySomaticEvidence(ctx context.Context, variant *domain.StandardizedVariant, tumorType string) ([]domain.SomaticEvidence, error) {
+	s.lastSeen = variant
+	return s.evidence, s.err
+}
+
+func TestEvidenceResourceProvider_SomaticEvidence(t *testing.T) {
+	provider := newTestEvidenceProvider()
+	provider.SetKnowledgeBase(&stubKnowledgeBase{evidence: &domain.AggregatedEvidence{
+		SomaticData: &domain.SomaticData{CosmicID: "COSV56056643", SampleCount: 48213, TumorTypes: []string{"skin", "thyroid"}},
+	}})
+	civic := &stubSomaticSource{evidence: []domain.SomaticEvidence{
+		{Source: "CIViC", Level: domain.LEVEL_A, Type: domain.SomaticTherapeutic, Significance: "sensitivityresponse",
+			TumorType: "Melanoma", Therapies: []string{"Dabrafenib", "Trametinib"}},
+		{Source: "CIViC", Level: domain.LEVEL_B, Type: domain.SomaticPrognostic, Significance: "poor outcome", TumorType: "Colorectal Cancer"},
+	}}
+	provider.SetSomaticEvidenceSource(civic)
+
+	// Act
+	resource, err := provider.GetResource(context.Background(), "/evidence/BRAF:p.V600E/somatic")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "BRAF", civic.lastSeen.GeneSymbol)
+	assert.Equal(t, "BRAF:p.V600E", civic.lastSeen.HGVSProtein)
+	raw, err := json.Marshal(resource.Content)
+	require.NoError(t, err)
+	var somatic SomaticEvidenceData
+	require.NoError(t, json.Unmarshal(raw, &somatic))
+	assert.True(t, somatic.COSMIC.Hotspot)
+	assert.Equal(t, 48213, somatic.COSMIC.SampleCount)
+	assert.Len(t, somatic.ClinicalEvidence, 2)
+	require.Len(t, somatic.TherapyAssociations, 2, "one per therapy of therapeutic evidence")
+	assert.Equal(t, "Trametinib", somatic.TherapyAssociations[1].Therapy)
+	assert.Equal(t, "A", somatic.TherapyAssociations[1].EvidenceLevel)
+
+	// A failing source leaves the COSMIC recurrence in place
+	civic.evidence, civic.err = nil, errors.New("CIViC returned status 503")
+	evidence, err := provider.loadEvidence(context.Background(), "NM_004333.6:c.1799T>A")
+	require.NoError(t, err)
+	assert.Equal(t, "NM_004333.6", civic.lastSeen.TranscriptID)
+	assert.Empty(t, evidence.SomaticEvidence.ClinicalEvidence)
+	assert.Equal(t, "COSV56056643", evidence.SomaticEvidence.COSMIC.CosmicID)
+}
+
+func TestEvidenceResourceProvider_LiteratureSignals(t *testing.T) {
+	provider := newTestEvidenceProvider()
+	provider.SetKnowledgeBase(&stubKnowledgeBase{evidence: &domain.AggregatedEvidence{
+		LiteratureData: &domain.LiteratureData{
+			TotalCitations:     3,
+			RetrievedCitations: 3,
+			Citations: []domain.Citation{
+				{PMID: "20301425", Year: 2010, Signals: []string{domain.SignalFunctionalDamaging}, KeyFindings: []string{"In vitro assays showed abolished activity"}},
+				{PMID: "15920490", Year: 2005, Title: "A founder mutation in an Ashkenazi family", Signals: []string{domain.SignalCaseReport}},
+				{PMID: "12345678", Year: 2008, Signals: []string{domain.SignalFunctionalDamaging}, Retracted: true},
+			},
+		},
+	}})
+
+	evidence, err := provider.loadEvidence(context.Background(), "rs80357906")
+	require.NoError(t, err)
+
+	literature := evidence.LiteratureEvidence
+	require.Len(t, literature.PubMedArticles, 3)
+	assert.Equal(t, "In vitro assays showed abolished activity", literature.PubMedArticles[0].Findings)
+	assert.Equal(t, []string{domain.SignalFunctionalDamaging}, literature.PubMedArticles[0].Signals)
+	assert.Equal(t, []CaseReportData{{PMID: "15920490", ClinicalDetails: "A founder mutation in an Ashkenazi family"}}, literature.CaseReports)
+	assert.Equal(t, map[string]int{domain.SignalFunctionalDamaging: 1, domain.SignalCaseReport: 1}, literature.LiteratureSummary.EvidenceDistribution, "retracted articles are not counted")
+	assert.Equal(t, []string{"In vitro assays showed abolished activity (PMID 20301425)"}, literature.LiteratureSummary.KeyFindings)
+}
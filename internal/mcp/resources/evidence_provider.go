@@ -4,16 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/conflicts"
+	"github.com/acmg-amp-mcp-server/internal/domain"
 )
 
 // EvidenceResourceProvider provides access to evidence data resources
 type EvidenceResourceProvider struct {
-	logger    *logrus.Logger
-	uriParser *URIParser
+	logger         *logrus.Logger
+	uriParser      *URIParser
+	knowledgeBase  domain.KnowledgeBaseAccess
+	somaticSource  SomaticEvidenceSource
+	fallbackToMock bool
+	cacheMu        sync.RWMutex
+	cache          map[string]*EvidenceData // Last successful live lookup per variant
 }
 
 // EvidenceData represents aggregated evidence for a variant
@@ -25,9 +36,11 @@ type EvidenceData struct {
 	FunctionalEvidence  FunctionalEvidenceData   `json:"functional_evidence"`
 	ComputationalEvidence ComputationalEvidenceData `json:"computational_evidence"`
 	LiteratureEvidence  LiteratureEvidenceData   `json:"literature_evidence"`
+	SomaticEvidence     SomaticEvidenceData      `json:"somatic_evidence"`
 	EvidenceQuality     EvidenceQualityMetrics   `json:"evidence_quality"`
 	LastUpdated         time.Time                 `json:"last_updated"`
 	DataSources         []DataSourceInfo         `json:"data_sources"`
+	Origin              string                    `json:"origin"` // live, cached or mock
 }
 
 // EvidenceSummaryData provides overall evidence assessment
@@ -52,14 +65,9 @@ type EvidenceCategoryData struct {
 	Contradicting []string `json:"contradicting,omitempty"`
 }
 
-// ConflictingEvidenceData represents conflicting evidence
-type ConflictingEvidenceData struct {
-	Source1      string `json:"source1"`
-	Source2      string `json:"source2"`
-	Conflict     string `json:"conflict"`
-	Resolution   string `json:"resolution,omitempty"`
-	Impact       string `json:"impact"`
-}
+// ConflictingEvidenceData represents conflicting evidence and, for
+// conflicting criteria, the policy that resolved it
+type ConflictingEvidenceData = conflicts.Decision
 
 // PopulationEvidenceData contains population frequency data
 type PopulationEvidenceData struct {
@@ -382,16 +390,19 @@ type LiteratureEvidenceData struct {
 
 // LiteratureArticleData represents literature article evidence
 type LiteratureArticleData struct {
-	PMID            string    `json:"pmid"`
-	Title           string    `json:"title"`
-	Authors         []string  `json:"authors"`
-	Journal         string    `json:"journal"`
-	PublicationDate time.Time `json:"publication_date"`
-	StudyType       string    `json:"study_type"`
-	SampleSize      int       `json:"sample_size"`
-	Findings        string    `json:"findings"`
-	EvidenceLevel   string    `json:"evidence_level"`
-	Relevance       float64   `json:"relevance"`
+	PMID            string                  `json:"pmid"`
+	Title           string                  `json:"title"`
+	Authors         []string                `json:"authors"`
+	Journal         string                  `json:"journal"`
+	PublicationDate time.Time               `json:"publication_date"`
+	StudyType       string                  `json:"study_type"`
+	SampleSize      int                     `json:"sample_size"`
+	Findings        string                  `json:"findings"`
+	EvidenceLevel   string                  `json:"evidence_level"`
+	Relevance       float64                 `json:"relevance"`
+	Signals         []string                `json:"signals,omitempty"` // Functional study, case report and pathogenicity signals mined from the abstract
+	Retracted       bool                    `json:"retracted,omitempty"`
+	Notices         []domain.CitationNotice `json:"notices,omitempty"` // Retractions and errata published for the article
 }
 
 // CaseReportData represents case report evidence
@@ -438,6 +449,34 @@ type LiteratureSummaryData struct {
 	OverallConclusion  string               `json:"overall_conclusion"`
 }
 
+// SomaticEvidenceData contains tumor recurrence and clinical evidence for
+// somatic interpretation
+type SomaticEvidenceData struct {
+	COSMIC              COSMICRecurrenceData     `json:"cosmic"`
+	ClinicalEvidence    []domain.SomaticEvidence `json:"clinical_evidence"` // CIViC evidence items
+	TherapyAssociations []TherapyAssociationData `json:"therapy_associations"`
+}
+
+// COSMICRecurrenceData represents how often a variant recurs in tumors
+type COSMICRecurrenceData struct {
+	CosmicID      string   `json:"cosmic_id,omitempty"`
+	SampleCount   int      `json:"sample_count"`
+	MutationCount int      `json:"mutation_count"`
+	TumorTypes    []string `json:"tumor_types"`
+	Hotspot       bool     `json:"hotspot"` // Recurrent in at least HotspotSampleCount tumor samples
+	Pathogenicity string   `json:"pathogenicity,omitempty"`
+}
+
+// TherapyAssociationData links a therapy to a variant's response in a tumor type
+type TherapyAssociationData struct {
+	Therapy       string   `json:"therapy"`
+	Response      string   `json:"response"` // e.g. sensitivity or resistance
+	TumorType     string   `json:"tumor_type"`
+	EvidenceLevel string   `json:"evidence_level"`
+	Source        string   `json:"source"`
+	References    []string `json:"references,omitempty"`
+}
+
 // EvidenceQualityMetrics provides quality assessment of evidence
 type EvidenceQualityMetrics struct {
 	OverallQuality      string                    `json:"overall_quality"`
@@ -472,11 +511,25 @@ type DataSourceInfo struct {
 	Limitations       []string  `json:"limitations,omitempty"`
 }
 
+// LiteraturePageSize is the number of articles returned per literature page
+const LiteraturePageSize = 20
+
+// LiteraturePageData represents a single page of literature evidence
+type LiteraturePageData struct {
+	Page          int                     `json:"page"`
+	PageSize      int                     `json:"page_size"`
+	TotalPages    int                     `json:"total_pages"`
+	TotalArticles int                     `json:"total_articles"`
+	Articles      []LiteratureArticleData `json:"articles"`
+	NextPageURI   string                  `json:"next_page_uri,omitempty"`
+}
+
 // NewEvidenceResourceProvider creates a new evidence resource provider
 func NewEvidenceResourceProvider(logger *logrus.Logger) *EvidenceResourceProvider {
 	provider := &EvidenceResourceProvider{
 		logger:    logger,
 		uriParser: NewURIParser(),
+		cache:     make(map[string]*EvidenceData),
 	}
 
 	// Register URI patterns
@@ -488,7 +541,9 @@ func NewEvidenceResourceProvider(logger *logrus.Logger) *EvidenceResourceProvide
 		"evidence_functional":  `^/evidence/(?P<variant_id>[^/]+)/functional$`,
 		"evidence_computational": `^/evidence/(?P<variant_id>[^/]+)/computational$`,
 		"evidence_literature":  `^/evidence/(?P<variant_id>[^/]+)/literature$`,
+		"evidence_literature_page": `^/evidence/(?P<variant_id>[^/]+)/literature/page/(?P<page>[0-9]+)$`,
 		"evidence_quality":     `^/evidence/(?P<variant_id>[^/]+)/quality$`,
+		"evidence_somatic":     `^/evidence/(?P<variant_id>[^/]+)/somatic$`,
 	}
 
 	for name, pattern := range patterns {
@@ -523,58 +578,70 @@ func (p *EvidenceResourceProvider) GetResource(ctx context.Context, uri string)
 		return nil, fmt.Errorf("variant_id parameter is required")
 	}
 
-	// Generate evidence data based on pattern
+	evidence, err := p.loadEvidence(ctx, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select evidence data based on pattern
 	var content interface{}
 	var name, description string
 
 	switch patternName {
 	case "evidence_variant":
-		content = p.generateFullEvidenceData(variantID)
+		content = evidence
 		name = fmt.Sprintf("Complete Evidence for Variant %s", variantID)
 		description = "Comprehensive evidence aggregation for genetic variant including all evidence types"
 
 	case "evidence_summary":
-		evidence := p.generateFullEvidenceData(variantID)
 		content = evidence.EvidenceSummary
 		name = fmt.Sprintf("Evidence Summary for Variant %s", variantID)
 		description = "Summary of evidence assessment and overall pathogenicity evaluation"
 
 	case "evidence_population":
-		evidence := p.generateFullEvidenceData(variantID)
 		content = evidence.PopulationEvidence
 		name = fmt.Sprintf("Population Evidence for Variant %s", variantID)
 		description = "Population frequency data from multiple databases and populations"
 
 	case "evidence_clinical":
-		evidence := p.generateFullEvidenceData(variantID)
 		content = evidence.ClinicalEvidence
 		name = fmt.Sprintf("Clinical Evidence for Variant %s", variantID)
 		description = "Clinical significance data from ClinVar, HGMD, and other clinical databases"
 
 	case "evidence_functional":
-		evidence := p.generateFullEvidenceData(variantID)
 		content = evidence.FunctionalEvidence
 		name = fmt.Sprintf("Functional Evidence for Variant %s", variantID)
 		description = "Functional studies including in vitro assays, animal models, and protein studies"
 
 	case "evidence_computational":
-		evidence := p.generateFullEvidenceData(variantID)
 		content = evidence.ComputationalEvidence
 		name = fmt.Sprintf("Computational Evidence for Variant %s", variantID)
 		description = "Computational predictions for pathogenicity, conservation, and structural impact"
 
 	case "evidence_literature":
-		evidence := p.generateFullEvidenceData(variantID)
 		content = evidence.LiteratureEvidence
 		name = fmt.Sprintf("Literature Evidence for Variant %s", variantID)
 		description = "Literature-based evidence from PubMed articles, case reports, and reviews"
 
+	case "evidence_literature_page":
+		page, err := strconv.Atoi(params["page"])
+		if err != nil || page < 1 {
+			return nil, fmt.Errorf("invalid page number: %s", params["page"])
+		}
+		content = paginateLiterature(variantID, evidence.LiteratureEvidence, page)
+		name = fmt.Sprintf("Literature Evidence for Variant %s (page %d)", variantID, page)
+		description = "Paginated literature articles for clients with limited response sizes"
+
 	case "evidence_quality":
-		evidence := p.generateFullEvidenceData(variantID)
 		content = evidence.EvidenceQuality
 		name = fmt.Sprintf("Evidence Quality Metrics for Variant %s", variantID)
 		description = "Quality assessment and bias analysis of evidence data"
 
+	case "evidence_somatic":
+		content = evidence.SomaticEvidence
+		name = fmt.Sprintf("Somatic Evidence for Variant %s", variantID)
+		description = "COSMIC tumor recurrence and CIViC clinical evidence with therapy associations"
+
 	default:
 		return nil, fmt.Errorf("unsupported evidence resource pattern: %s", patternName)
 	}
@@ -603,6 +670,7 @@ func (p *EvidenceResourceProvider) GetResource(ctx context.Context, uri string)
 			"variant_id":    variantID,
 			"pattern":       patternName,
 			"version":       "1.0",
+			"origin":        evidence.Origin,
 		},
 	}
 
@@ -610,6 +678,7 @@ func (p *EvidenceResourceProvider) GetResource(ctx context.Context, uri string)
 		"uri":        uri,
 		"variant_id": variantID,
 		"pattern":    patternName,
+		"origin":     evidence.Origin,
 		"size":       len(contentBytes),
 	}).Info("Generated evidence resource")
 
@@ -713,6 +782,19 @@ func (p *EvidenceResourceProvider) ListResources(ctx context.Context, cursor str
 				"article_types": []string{"research", "case_report", "review", "meta_analysis"},
 			},
 		},
+		{
+			URI:         "/evidence/{variant_id}/literature/page/{page}",
+			Name:        "Paginated Literature Evidence",
+			Description: "Literature articles split into fixed-size pages",
+			MimeType:    "application/json",
+			Tags:        []string{"evidence", "literature", "pubmed", "paginated"},
+			LastModified: time.Now().Add(-2 * time.Hour),
+			Metadata: map[string]interface{}{
+				"template":   true,
+				"parameters": []string{"variant_id", "page"},
+				"page_size":  LiteraturePageSize,
+			},
+		},
 		{
 			URI:         "/evidence/{variant_id}/quality",
 			Name:        "Evidence Quality Metrics",
@@ -726,14 +808,27 @@ func (p *EvidenceResourceProvider) ListResources(ctx context.Context, cursor str
 				"quality_metrics": []string{"consistency", "completeness", "bias", "reliability"},
 			},
 		},
+		{
+			URI:         "/evidence/{variant_id}/somatic",
+			Name:        "Somatic Evidence",
+			Description: "COSMIC hotspot recurrence and CIViC therapy associations for somatic interpretation",
+			MimeType:    "application/json",
+			Tags:        []string{"evidence", "somatic", "cosmic", "civic", "therapy"},
+			LastModified: time.Now().Add(-time.Hour),
+			Metadata: map[string]interface{}{
+				"template":  true,
+				"parameter": "variant_id",
+				"sources":   []string{"COSMIC", "CIViC"},
+			},
+		},
 	}
 
-	result := &ResourceList{
-		Resources: resources,
-		Total:     len(resources),
+	result, err := Paginate(ctx, resources, cursor)
+	if err != nil {
+		return nil, err
 	}
 
-	p.logger.WithField("count", len(resources)).Info("Listed evidence resources")
+	p.logger.WithField("count", len(result.Resources)).Info("Listed evidence resources")
 	return result, nil
 }
 
@@ -803,7 +898,9 @@ func (p *EvidenceResourceProvider) GetProviderInfo() ProviderInfo {
 			"/evidence/{variant_id}/functional",
 			"/evidence/{variant_id}/computational",
 			"/evidence/{variant_id}/literature",
+			"/evidence/{variant_id}/literature/page/{page}",
 			"/evidence/{variant_id}/quality",
+			"/evidence/{variant_id}/somatic",
 		},
 	}
 }
@@ -818,9 +915,11 @@ func (p *EvidenceResourceProvider) generateFullEvidenceData(variantID string) *E
 		FunctionalEvidence: p.generateFunctionalEvidence(),
 		ComputationalEvidence: p.generateComputationalEvidence(),
 		LiteratureEvidence: p.generateLiteratureEvidence(),
+		SomaticEvidence: p.generateSomaticEvidence(),
 		EvidenceQuality: p.generateEvidenceQuality(),
 		LastUpdated: time.Now(),
 		DataSources: p.generateDataSources(),
+		Origin:      EvidenceOriginMock,
 	}
 }
 
@@ -1026,6 +1125,41 @@ func (p *EvidenceResourceProvider) generateComputationalEvidence() Computational
 	}
 }
 
+func (p *EvidenceResourceProvider) generateSomaticEvidence() SomaticEvidenceData {
+	return SomaticEvidenceData{
+		COSMIC: COSMICRecurrenceData{
+			CosmicID:      "COSV56056643",
+			SampleCount:   52,
+			MutationCount: 54,
+			TumorTypes:    []string{"breast", "ovary"},
+			Hotspot:       true,
+			Pathogenicity: "Pathogenic",
+		},
+		ClinicalEvidence: []domain.SomaticEvidence{
+			{
+				Source:       "CIViC",
+				SourceLevel:  "B",
+				Level:        domain.LEVEL_B,
+				Type:         domain.SomaticTherapeutic,
+				Significance: "sensitivityresponse",
+				TumorType:    "Ovarian Cancer",
+				Therapies:    []string{"Olaparib"},
+				References:   []string{"25366685"},
+			},
+		},
+		TherapyAssociations: []TherapyAssociationData{
+			{
+				Therapy:       "Olaparib",
+				Response:      "sensitivityresponse",
+				TumorType:     "Ovarian Cancer",
+				EvidenceLevel: "B",
+				Source:        "CIViC",
+				References:    []string{"25366685"},
+			},
+		},
+	}
+}
+
 func (p *EvidenceResourceProvider) generateLiteratureEvidence() LiteratureEvidenceData {
 	return LiteratureEvidenceData{
 		PubMedArticles: []LiteratureArticleData{
@@ -1065,6 +1199,38 @@ func (p *EvidenceResourceProvider) generateLiteratureEvidence() LiteratureEviden
 	}
 }
 
+// paginateLiterature returns the requested page of literature articles
+func paginateLiterature(variantID string, literature LiteratureEvidenceData, page int) LiteraturePageData {
+	total := len(literature.PubMedArticles)
+	totalPages := (total + LiteraturePageSize - 1) / LiteraturePageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	result := LiteraturePageData{
+		Page:          page,
+		PageSize:      LiteraturePageSize,
+		TotalPages:    totalPages,
+		TotalArticles: total,
+		Articles:      []LiteratureArticleData{},
+	}
+
+	start := (page - 1) * LiteraturePageSize
+	if start < total {
+		end := start + LiteraturePageSize
+		if end > total {
+			end = total
+		}
+		result.Articles = literature.PubMedArticles[start:end]
+	}
+
+	if page < totalPages {
+		result.NextPageURI = fmt.Sprintf("/evidence/%s/literature/page/%d", url.PathEscape(variantID), page+1)
+	}
+
+	return result
+}
+
 func (p *EvidenceResourceProvider) generateEvidenceQuality() EvidenceQualityMetrics {
 	return EvidenceQualityMetrics{
 		OverallQuality: "High",
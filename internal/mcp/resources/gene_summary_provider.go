@@ -0,0 +1,324 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// Limits of a gene summary
+const (
+	maxGeneSummaryRecords = 1000 // Newest classifications aggregated per gene
+	maxSummaryCriteria    = 10
+	maxSummaryHotspots    = 10
+)
+
+// summaryEvidenceCategories maps each germline evidence category to the
+// stored evidence fields that carry it
+var summaryEvidenceCategories = map[string][]string{
+	domain.EvidenceCategoryClinical:      {"clinvar_data", "lovd_data", "hgmd_data", "residue_variants"},
+	domain.EvidenceCategoryPopulation:    {"population_data"},
+	domain.EvidenceCategoryComputational: {"computational_data", "splicing_predictions"},
+	domain.EvidenceCategoryLiterature:    {"literature_data"},
+	domain.EvidenceCategoryGene:          {"gene_curation", "gene_constraint"},
+}
+
+// Positions of protein and coding changes, e.g. p.Arg1699Gln and c.5096+1G>A
+var (
+	proteinPositionPattern = regexp.MustCompile(`p\.\(?(?:[A-Z][a-z]{2}|[A-Z*])(\d+)`)
+	codingPositionPattern  = regexp.MustCompile(`c\.(\*?-?\d+(?:[+-]\d+)?)`)
+)
+
+// GeneSummary aggregates the stored classifications of a gene. Each variant
+// counts once, with its latest classification.
+type GeneSummary struct {
+	Gene                     string           `json:"gene"`
+	Variants                 int              `json:"variants"`
+	Classifications          int              `json:"classifications"` // Including reclassifications
+	ClassDistribution        map[string]int   `json:"class_distribution"`
+	CommonCriteria           []CriterionUsage `json:"common_criteria"`
+	Hotspots                 []Hotspot        `json:"hotspots"`
+	MeanEvidenceCompleteness *float64         `json:"mean_evidence_completeness,omitempty"` // Nil when no evidence was recorded
+	FirstClassifiedAt        time.Time        `json:"first_classified_at"`
+	LastClassifiedAt         time.Time        `json:"last_classified_at"`
+	Truncated                bool             `json:"truncated,omitempty"` // Only the newest classifications were aggregated
+}
+
+// CriterionUsage is how often a criterion was applied across a gene's variants
+type CriterionUsage struct {
+	Code     string  `json:"code"`
+	Variants int     `json:"variants"`
+	Share    float64 `json:"share"` // Fraction of the gene's variants
+}
+
+// Hotspot is a position where several of a gene's variants were classified
+type Hotspot struct {
+	Position          string         `json:"position"` // Protein residue, e.g. p.1699, else coding position, e.g. c.5096
+	Variants          int            `json:"variants"`
+	ClassDistribution map[string]int `json:"class_distribution"`
+}
+
+// GeneSummaryResourceProvider summarizes the classifications stored in the
+// audit trail per gene for quality review and curation prioritization
+type GeneSummaryResourceProvider struct {
+	logger    *logrus.Logger
+	store     audit.Store
+	searcher  audit.Searcher
+	uriParser *URIParser
+}
+
+// NewGeneSummaryResourceProvider creates a new gene summary resource
+// provider. Classifications are found by gene through searcher and read
+// from store.
+func NewGeneSummaryResourceProvider(logger *logrus.Logger, store audit.Store, searcher audit.Searcher) *GeneSummaryResourceProvider {
+	provider := &GeneSummaryResourceProvider{
+		logger:    logger,
+		store:     store,
+		searcher:  searcher,
+		uriParser: NewURIParser(),
+	}
+
+	provider.uriParser.AddPattern("gene_summary", `^/genes/(?P<symbol>[A-Za-z0-9-]+)/summary$`)
+
+	return provider
+}
+
+// GetResource returns the classification summary of a gene
+func (gp *GeneSummaryResourceProvider) GetResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	gp.logger.WithField("uri", uri).Debug("Getting gene summary resource")
+
+	symbol, err := gp.parseSymbol(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := gp.searcher.Search(ctx, audit.SearchQuery{Gene: symbol, Limit: maxGeneSummaryRecords})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search classifications of %s: %w", symbol, err)
+	}
+	if len(hits) == 0 {
+		return nil, fmt.Errorf("no classifications recorded for gene %s", symbol)
+	}
+
+	summary, err := gp.summarize(ctx, symbol, hits)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResourceContent{
+		URI:          uri,
+		Name:         fmt.Sprintf("%s Classification Summary", symbol),
+		Description:  "Class distribution, common criteria, hotspot positions and evidence completeness of the gene's classified variants",
+		MimeType:     "application/json",
+		Content:      summary,
+		LastModified: summary.LastClassifiedAt,
+		ETag:         fmt.Sprintf("gene-summary-%s-%d", symbol, hits[0].RecordID),
+		Metadata: map[string]interface{}{
+			"provider": "gene_summary",
+			"gene":     symbol,
+			"variants": summary.Variants,
+		},
+	}, nil
+}
+
+// summarize aggregates the search hits, newest first, of a gene
+func (gp *GeneSummaryResourceProvider) summarize(ctx context.Context, symbol string, hits []*audit.SearchHit) (*GeneSummary, error) {
+	summary := &GeneSummary{
+		Gene:              symbol,
+		Classifications:   len(hits),
+		ClassDistribution: make(map[string]int),
+		CommonCriteria:    []CriterionUsage{},
+		Hotspots:          []Hotspot{},
+		FirstClassifiedAt: hits[len(hits)-1].CreatedAt,
+		LastClassifiedAt:  hits[0].CreatedAt,
+		Truncated:         len(hits) == maxGeneSummaryRecords,
+	}
+
+	criteria := make(map[string]int)
+	hotspots := make(map[string]*Hotspot)
+	var completeness float64
+	var withEvidence int
+	seen := make(map[string]bool)
+	for _, hit := range hits {
+		if seen[hit.HGVSNotation] {
+			continue // An earlier classification of a variant already counted
+		}
+		seen[hit.HGVSNotation] = true
+		summary.Variants++
+		summary.ClassDistribution[hit.Classification]++
+		for _, code := range hit.Criteria {
+			criteria[code]++
+		}
+
+		record, err := gp.store.Get(ctx, hit.RecordID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load classification %d: %w", hit.RecordID, err)
+		}
+		if position := variantPosition(record); position != "" {
+			hotspot, ok := hotspots[position]
+			if !ok {
+				hotspot = &Hotspot{Position: position, ClassDistribution: make(map[string]int)}
+				hotspots[position] = hotspot
+			}
+			hotspot.Variants++
+			hotspot.ClassDistribution[hit.Classification]++
+		}
+		if score, ok := evidenceCompleteness(record.Evidence); ok {
+			completeness += score
+			withEvidence++
+		}
+	}
+
+	for code, count := range criteria {
+		summary.CommonCriteria = append(summary.CommonCriteria, CriterionUsage{
+			Code:     code,
+			Variants: count,
+			Share:    float64(count) / float64(summary.Variants),
+		})
+	}
+	sort.Slice(summary.CommonCriteria, func(i, j int) bool {
+		a, b := summary.CommonCriteria[i], summary.CommonCriteria[j]
+		if a.Variants != b.Variants {
+			return a.Variants > b.Variants
+		}
+		return a.Code < b.Code
+	})
+	if len(summary.CommonCriteria) > maxSummaryCriteria {
+		summary.CommonCriteria = summary.CommonCriteria[:maxSummaryCriteria]
+	}
+
+	for _, hotspot := range hotspots {
+		if hotspot.Variants > 1 {
+			summary.Hotspots = append(summary.Hotspots, *hotspot)
+		}
+	}
+	sort.Slice(summary.Hotspots, func(i, j int) bool {
+		a, b := summary.Hotspots[i], summary.Hotspots[j]
+		if a.Variants != b.Variants {
+			return a.Variants > b.Variants
+		}
+		return a.Position < b.Position
+	})
+	if len(summary.Hotspots) > maxSummaryHotspots {
+		summary.Hotspots = summary.Hotspots[:maxSummaryHotspots]
+	}
+
+	if withEvidence > 0 {
+		mean := completeness / float64(withEvidence)
+		summary.MeanEvidenceCompleteness = &mean
+	}
+	return summary, nil
+}
+
+// variantPosition returns the protein residue of a classified variant when
+// the request named a protein change, else its coding position
+func variantPosition(record *audit.Record) string {
+	var request struct {
+		GeneSymbolNotation string `json:"gene_symbol_notation"`
+		HGVSNotation       string `json:"hgvs_notation"`
+	}
+	json.Unmarshal(record.Request, &request)
+	for _, notation := range []string{request.GeneSymbolNotation, request.HGVSNotation, record.HGVSNotation} {
+		if match := proteinPositionPattern.FindStringSubmatch(notation); match != nil {
+			return "p." + match[1]
+		}
+	}
+	for _, notation := range []string{request.HGVSNotation, record.HGVSNotation, request.GeneSymbolNotation} {
+		if match := codingPositionPattern.FindStringSubmatch(notation); match != nil {
+			return "c." + match[1]
+		}
+	}
+	return ""
+}
+
+// evidenceCompleteness returns the fraction of germline evidence categories
+// with data in the recorded evidence, false when none was recorded
+func evidenceCompleteness(raw json.RawMessage) (float64, bool) {
+	if len(raw) == 0 {
+		return 0, false
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return 0, false
+	}
+	present := 0
+	for _, names := range summaryEvidenceCategories {
+		for _, name := range names {
+			if value, ok := fields[name]; ok && string(value) != "null" {
+				present++
+				break
+			}
+		}
+	}
+	return float64(present) / float64(len(summaryEvidenceCategories)), true
+}
+
+// ListResources lists the gene summary URI template; genes are not enumerated
+func (gp *GeneSummaryResourceProvider) ListResources(ctx context.Context, cursor string) (*ResourceList, error) {
+	resources := []ResourceInfo{
+		{
+			URI:         "/genes/{symbol}/summary",
+			Name:        "Gene Classification Summary",
+			Description: "Aggregate statistics of the classifications stored for a gene",
+			MimeType:    "application/json",
+			Tags:        []string{"gene", "classification", "quality"},
+		},
+	}
+
+	return Paginate(ctx, resources, cursor)
+}
+
+// GetResourceInfo returns metadata about a gene summary resource
+func (gp *GeneSummaryResourceProvider) GetResourceInfo(ctx context.Context, uri string) (*ResourceInfo, error) {
+	symbol, err := gp.parseSymbol(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResourceInfo{
+		URI:         uri,
+		Name:        fmt.Sprintf("%s Classification Summary", symbol),
+		Description: "Aggregate statistics of the gene's stored classifications",
+		MimeType:    "application/json",
+		Tags:        []string{"gene", "classification", "quality"},
+		Metadata: map[string]interface{}{
+			"gene": symbol,
+		},
+	}, nil
+}
+
+// SupportsURI checks if this provider supports the given URI
+func (gp *GeneSummaryResourceProvider) SupportsURI(uri string) bool {
+	_, _, err := gp.uriParser.ParseURI(uri)
+	return err == nil
+}
+
+// GetProviderInfo returns information about this provider
+func (gp *GeneSummaryResourceProvider) GetProviderInfo() ProviderInfo {
+	return ProviderInfo{
+		Name:        "gene_summary",
+		Description: "Per-gene statistics of stored classifications",
+		Version:     "1.0.0",
+		URIPatterns: []string{
+			"/genes/{symbol}/summary",
+		},
+	}
+}
+
+// parseSymbol extracts the upper-cased gene symbol from the URI
+func (gp *GeneSummaryResourceProvider) parseSymbol(uri string) (string, error) {
+	_, params, err := gp.uriParser.ParseURI(uri)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse gene summary URI: %w", err)
+	}
+	return strings.ToUpper(params["symbol"]), nil
+}
@@ -0,0 +1,90 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+)
+
+func TestGeneSummaryResourceProvider_GetResource(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store, err := audit.NewSQLiteStore(filepath.Join(t.TempDir(), "audit.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	ctx := context.Background()
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	classify := func(notation, classification, evidence string, criteria ...string) {
+		rules := make([]map[string]interface{}, len(criteria))
+		for i, code := range criteria {
+			rules[i] = map[string]interface{}{"rule_code": code, "applied": true}
+		}
+		rulesJSON, _ := json.Marshal(rules)
+		day = day.Add(time.Hour)
+		require.NoError(t, store.Append(ctx, &audit.Record{
+			HGVSNotation:   notation,
+			Request:        json.RawMessage(fmt.Sprintf(`{"gene_symbol_notation": %q}`, notation)),
+			Evidence:       json.RawMessage(evidence),
+			AppliedRules:   rulesJSON,
+			Classification: classification,
+			EngineVersion:  "v0.1.0",
+			CreatedAt:      day,
+		}))
+	}
+	classify("BRCA1:p.Arg1699Trp", "Uncertain significance", `{"population_data": {}}`, "PM2")
+	classify("BRCA1:p.Arg1699Trp", "Pathogenic", `{"population_data": {}, "clinvar_data": {}, "computational_data": {}, "literature_data": {}, "gene_constraint": {}}`, "PM2", "PP3", "PS3")
+	classify("BRCA1:p.Arg1699Gln", "Likely pathogenic", `{"population_data": {}, "clinvar_data": {}, "computational_data": null}`, "PM2", "PP3")
+	classify("BRCA1:p.Cys61Gly", "Pathogenic", ``, "PS3")
+	classify("BRCA2:p.Arg1699Trp", "Benign", `{}`, "BA1")
+
+	provider := NewGeneSummaryResourceProvider(logger, store, store)
+	content, err := provider.GetResource(ctx, "/genes/brca1/summary")
+	require.NoError(t, err)
+
+	summary := content.Content.(*GeneSummary)
+	assert.Equal(t, "BRCA1", summary.Gene)
+	assert.Equal(t, 3, summary.Variants)
+	assert.Equal(t, 4, summary.Classifications)
+	assert.Equal(t, map[string]int{"Pathogenic": 2, "Likely pathogenic": 1}, summary.ClassDistribution,
+		"only the latest classification of a variant counts")
+	assert.Equal(t, []CriterionUsage{
+		{Code: "PM2", Variants: 2, Share: 2.0 / 3},
+		{Code: "PP3", Variants: 2, Share: 2.0 / 3},
+		{Code: "PS3", Variants: 2, Share: 2.0 / 3},
+	}, summary.CommonCriteria)
+	assert.Equal(t, []Hotspot{
+		{Position: "p.1699", Variants: 2, ClassDistribution: map[string]int{"Pathogenic": 1, "Likely pathogenic": 1}},
+	}, summary.Hotspots)
+	require.NotNil(t, summary.MeanEvidenceCompleteness)
+	assert.InDelta(t, (1.0+0.4)/2, *summary.MeanEvidenceCompleteness, 1e-9, "records without evidence are skipped")
+	assert.Equal(t, day.Add(-time.Hour), summary.LastClassifiedAt)
+	assert.False(t, summary.Truncated)
+
+	_, err = provider.GetResource(ctx, "/genes/TP53/summary")
+	assert.Error(t, err)
+	assert.True(t, provider.SupportsURI("/genes/BRCA1/summary"))
+	assert.False(t, provider.SupportsURI("/genes/BRCA1/diseases"))
+}
+
+func TestVariantPosition(t *testing.T) {
+	for notation, position := range map[string]string{
+		`{"gene_symbol_notation": "BRCA1:p.(Arg1699Trp)"}`:   "p.1699",
+		`{"hgvs_notation": "NM_007294.4:c.5096G>A"}`:         "c.5096",
+		`{"hgvs_notation": "NM_007294.4:c.5152+1G>T"}`:       "c.5152+1",
+		`{"hgvs_notation": "NC_000017.11:g.43057051del"}`:    "",
+		`{"gene_symbol_notation": "CFTR:p.Phe508del"}`:       "p.508",
+		`{"gene_symbol_notation": "BRCA2:c.-26G>A"}`:         "c.-26",
+		`{"gene_symbol_notation": "BRCA2:c.*103_*104delAA"}`: "c.*103",
+	} {
+		assert.Equal(t, position, variantPosition(&audit.Record{Request: json.RawMessage(notation)}), notation)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
 )
 
 // InterpretationResourceProvider provides interpretation/{id} resources
@@ -103,8 +105,8 @@ type ACMGRuleApplicationData struct {
 type AppliedACMGRule struct {
 	RuleCode      string                 `json:"rule_code"`
 	RuleName      string                 `json:"rule_name"`
-	Category      string                 `json:"category"` // "pathogenic", "benign"
-	Strength      string                 `json:"strength"` // "very_strong", "strong", "moderate", "supporting"
+	Category      string                 `json:"category"` // "PATHOGENIC", "BENIGN"
+	Strength      string                 `json:"strength"` // "VERY_STRONG", "STRONG", "MODERATE", "SUPPORTING"
 	Evidence      string                 `json:"evidence"`
 	Rationale     string                 `json:"rationale"`
 	Confidence    float64                `json:"confidence"`
@@ -357,10 +359,7 @@ func (ip *InterpretationResourceProvider) ListResources(ctx context.Context, cur
 		},
 	}
 	
-	return &ResourceList{
-		Resources: resources,
-		Total:     len(resources),
-	}, nil
+	return Paginate(ctx, resources, cursor)
 }
 
 // GetResourceInfo returns metadata about an interpretation resource
@@ -607,11 +606,12 @@ func (ip *InterpretationResourceProvider) generateMockInterpretationData(id stri
 
 func (ip *InterpretationResourceProvider) generateMockClassificationData(id string) ClassificationData {
 	hash := ip.hashString(id)
-	classifications := []string{"Pathogenic", "Likely pathogenic", "VUS", "Likely benign", "Benign"}
+	classifications := domain.ClassificationEnum()
+	confidenceLevels := domain.ConfidenceLevelEnum()
 	
 	return ClassificationData{
 		PrimaryClassification:   classifications[hash%len(classifications)],
-		ConfidenceLevel:        []string{"high", "moderate", "low"}[hash%3],
+		ConfidenceLevel:        confidenceLevels[hash%len(confidenceLevels)],
 		ConfidenceScore:        0.3 + (float64(hash%700) / 1000.0),
 		ClassificationRationale: "Based on ACMG/AMP guidelines and available evidence",
 		GuidelinesUsed:         []string{"ACMG/AMP 2015"},
@@ -655,8 +655,8 @@ func (ip *InterpretationResourceProvider) generateMockACMGRules(id string) ACMGR
 			{
 				RuleCode:     "PVS1",
 				RuleName:     "Null variant",
-				Category:     "pathogenic",
-				Strength:     "very_strong",
+				Category:     string(domain.PATHOGENIC_RULE),
+				Strength:     string(domain.VERY_STRONG),
 				Evidence:     "Nonsense variant in gene where LOF is pathogenic mechanism",
 				Rationale:    "Variant introduces premature stop codon",
 				Confidence:   0.95,
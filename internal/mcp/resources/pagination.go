@@ -0,0 +1,152 @@
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Page size limits for resource listings
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// ErrInvalidCursor is returned when a listing cursor was not issued by this
+// server or was issued for different filters
+var ErrInvalidCursor = errors.New("invalid resource cursor")
+
+// ListOptions limits and filters resource listings. Cursors are bound to the
+// filters they were issued for; the page size may change between pages.
+type ListOptions struct {
+	PageSize int      // Defaults to DefaultPageSize, capped at MaxPageSize
+	Tags     []string // Resources must carry every tag, compared case-insensitively
+	MimeType string   // Exact MIME type, or a wildcard such as application/*
+}
+
+type listOptionsKey struct{}
+
+// WithListOptions returns a context carrying options for ListResources
+func WithListOptions(ctx context.Context, opts ListOptions) context.Context {
+	return context.WithValue(ctx, listOptionsKey{}, opts)
+}
+
+// ListOptionsFrom returns the context's listing options with the page size
+// defaulted and capped
+func ListOptionsFrom(ctx context.Context) ListOptions {
+	opts, _ := ctx.Value(listOptionsKey{}).(ListOptions)
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if opts.PageSize > MaxPageSize {
+		opts.PageSize = MaxPageSize
+	}
+	return opts
+}
+
+// Matches reports whether a resource passes the filters
+func (o ListOptions) Matches(info ResourceInfo) bool {
+	if o.MimeType != "" {
+		if prefix, ok := strings.CutSuffix(o.MimeType, "/*"); ok {
+			if !strings.HasPrefix(strings.ToLower(info.MimeType), strings.ToLower(prefix)+"/") {
+				return false
+			}
+		} else if !strings.EqualFold(info.MimeType, o.MimeType) {
+			return false
+		}
+	}
+	for _, tag := range o.Tags {
+		found := false
+		for _, have := range info.Tags {
+			if strings.EqualFold(have, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// filterKey identifies the filters a cursor was issued for
+func (o ListOptions) filterKey() string {
+	tags := make([]string, 0, len(o.Tags))
+	for _, tag := range o.Tags {
+		tags = append(tags, strings.ToLower(tag))
+	}
+	sort.Strings(tags)
+	sum := sha256.Sum256([]byte(strings.ToLower(o.MimeType) + "\x00" + strings.Join(tags, "\x00")))
+	return hex.EncodeToString(sum[:4])
+}
+
+// pageCursor is the position of the next page in a provider's listing
+type pageCursor struct {
+	Offset int    `json:"o"`
+	Filter string `json:"f"`
+}
+
+// Paginate returns the page after cursor of the resources matching the
+// context's list options. Providers list their resources in a stable order
+// and page them with Paginate; Total counts the matches across all pages.
+func Paginate(ctx context.Context, resources []ResourceInfo, cursor string) (*ResourceList, error) {
+	opts := ListOptionsFrom(ctx)
+	key := opts.filterKey()
+	offset := 0
+	if cursor != "" {
+		var position pageCursor
+		if err := decodeCursor(cursor, &position); err != nil {
+			return nil, err
+		}
+		if position.Filter != key || position.Offset < 0 {
+			return nil, fmt.Errorf("%w: cursor was issued for different filters", ErrInvalidCursor)
+		}
+		offset = position.Offset
+	}
+
+	matching := make([]ResourceInfo, 0, len(resources))
+	for _, resource := range resources {
+		if opts.Matches(resource) {
+			matching = append(matching, resource)
+		}
+	}
+	// Resources may have been removed since the cursor was issued
+	if offset > len(matching) {
+		offset = len(matching)
+	}
+	end := offset + opts.PageSize
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	list := &ResourceList{Resources: matching[offset:end], Total: len(matching)}
+	if end < len(matching) {
+		list.NextCursor = encodeCursor(pageCursor{Offset: end, Filter: key})
+	}
+	return list, nil
+}
+
+// encodeCursor encodes a cursor as opaque URL-safe text
+func encodeCursor(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor decodes a cursor issued by encodeCursor
+func decodeCursor(cursor string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return nil
+}
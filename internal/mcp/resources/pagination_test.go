@@ -0,0 +1,185 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listProvider lists a fixed set of resources
+type listProvider struct {
+	resources []ResourceInfo
+}
+
+func (p *listProvider) GetResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	return nil, fmt.Errorf("not found: %s", uri)
+}
+
+func (p *listProvider) ListResources(ctx context.Context, cursor string) (*ResourceList, error) {
+	return Paginate(ctx, p.resources, cursor)
+}
+
+func (p *listProvider) GetResourceInfo(ctx context.Context, uri string) (*ResourceInfo, error) {
+	return nil, fmt.Errorf("not found: %s", uri)
+}
+
+func (p *listProvider) SupportsURI(uri string) bool { return false }
+
+func (p *listProvider) GetProviderInfo() ProviderInfo { return ProviderInfo{Name: "list"} }
+
+// variantResources returns n stored variant resources
+func variantResources(n int) []ResourceInfo {
+	resources := make([]ResourceInfo, n)
+	for i := range resources {
+		resources[i] = ResourceInfo{
+			URI:      fmt.Sprintf("/variant/%04d", i),
+			Name:     fmt.Sprintf("Variant %d", i),
+			MimeType: "application/json",
+			Tags:     []string{"variant"},
+		}
+		if i%2 == 0 {
+			resources[i].Tags = append(resources[i].Tags, "Clinical")
+		}
+	}
+	return resources
+}
+
+// listAll follows cursors until the last page, returning the URIs listed
+func listAll(t *testing.T, ctx context.Context, list func(context.Context, string) (*ResourceList, error)) ([]string, int) {
+	t.Helper()
+	var uris []string
+	cursor := ""
+	for pages := 1; ; pages++ {
+		page, err := list(ctx, cursor)
+		require.NoError(t, err)
+		for _, resource := range page.Resources {
+			uris = append(uris, resource.URI)
+		}
+		if page.NextCursor == "" {
+			return uris, pages
+		}
+		require.Less(t, pages, 1000, "pagination does not terminate")
+		cursor = page.NextCursor
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	resources := variantResources(5)
+
+	t.Run("pages in order", func(t *testing.T) {
+		ctx := WithListOptions(context.Background(), ListOptions{PageSize: 2})
+		page, err := Paginate(ctx, resources, "")
+		require.NoError(t, err)
+		assert.Equal(t, 5, page.Total)
+		assert.Len(t, page.Resources, 2)
+
+		uris, pages := listAll(t, ctx, func(ctx context.Context, cursor string) (*ResourceList, error) {
+			return Paginate(ctx, resources, cursor)
+		})
+		assert.Equal(t, []string{"/variant/0000", "/variant/0001", "/variant/0002", "/variant/0003", "/variant/0004"}, uris)
+		assert.Equal(t, 3, pages)
+	})
+
+	t.Run("page size defaults and limits", func(t *testing.T) {
+		assert.Equal(t, DefaultPageSize, ListOptionsFrom(context.Background()).PageSize)
+		assert.Equal(t, MaxPageSize, ListOptionsFrom(WithListOptions(context.Background(), ListOptions{PageSize: 100000})).PageSize)
+
+		page, err := Paginate(context.Background(), variantResources(DefaultPageSize+1), "")
+		require.NoError(t, err)
+		assert.Len(t, page.Resources, DefaultPageSize)
+		assert.NotEmpty(t, page.NextCursor)
+	})
+
+	t.Run("filters by tag and MIME type", func(t *testing.T) {
+		mixed := append(variantResources(5), ResourceInfo{URI: "/report", MimeType: "text/markdown", Tags: []string{"clinical"}})
+
+		page, err := Paginate(WithListOptions(context.Background(), ListOptions{Tags: []string{"clinical"}}), mixed, "")
+		require.NoError(t, err)
+		assert.Equal(t, 4, page.Total, "tags match case-insensitively")
+
+		page, err = Paginate(WithListOptions(context.Background(), ListOptions{Tags: []string{"clinical", "variant"}, MimeType: "application/*"}), mixed, "")
+		require.NoError(t, err)
+		assert.Equal(t, 3, page.Total, "every tag is required")
+
+		page, err = Paginate(WithListOptions(context.Background(), ListOptions{MimeType: "text/markdown"}), mixed, "")
+		require.NoError(t, err)
+		require.Len(t, page.Resources, 1)
+		assert.Equal(t, "/report", page.Resources[0].URI)
+	})
+
+	t.Run("invalid cursors", func(t *testing.T) {
+		ctx := WithListOptions(context.Background(), ListOptions{PageSize: 2})
+		page, err := Paginate(ctx, resources, "")
+		require.NoError(t, err)
+
+		_, err = Paginate(ctx, resources, "not-a-cursor")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+
+		_, err = Paginate(WithListOptions(ctx, ListOptions{PageSize: 2, Tags: []string{"clinical"}}), resources, page.NextCursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor, "cursors are bound to their filters")
+
+		page, err = Paginate(WithListOptions(ctx, ListOptions{PageSize: 10}), resources, page.NextCursor)
+		require.NoError(t, err, "the page size may change between pages")
+		assert.Len(t, page.Resources, 3)
+	})
+}
+
+func TestResourceManager_ListResourcesPagination(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	manager := NewResourceManager(logger)
+	manager.RegisterProvider("a_variants", &listProvider{resources: variantResources(1203)})
+	manager.RegisterProvider("b_empty", &listProvider{})
+	manager.RegisterProvider("c_audit", NewAuditResourceProvider(logger, nil))
+
+	t.Run("pages across providers", func(t *testing.T) {
+		ctx := WithListOptions(context.Background(), ListOptions{PageSize: 100})
+		first, err := manager.ListResources(ctx, "")
+		require.NoError(t, err)
+		assert.Len(t, first.Resources, 100)
+		assert.Equal(t, 1204, first.Total)
+
+		uris, pages := listAll(t, ctx, manager.ListResources)
+		assert.Equal(t, 13, pages)
+		require.Len(t, uris, 1204)
+		assert.Equal(t, "/variant/1202", uris[1202])
+		assert.Equal(t, "/audit/{variant_id}", uris[1203])
+	})
+
+	t.Run("a page ending with a provider continues with the next", func(t *testing.T) {
+		ctx := WithListOptions(context.Background(), ListOptions{PageSize: 401})
+		page := &ResourceList{}
+		for i := 0; i < 3; i++ {
+			var err error
+			page, err = manager.ListResources(ctx, page.NextCursor)
+			require.NoError(t, err)
+			assert.Len(t, page.Resources, 401)
+			require.NotEmpty(t, page.NextCursor)
+		}
+
+		last, err := manager.ListResources(ctx, page.NextCursor)
+		require.NoError(t, err)
+		require.Len(t, last.Resources, 1)
+		assert.Equal(t, "/audit/{variant_id}", last.Resources[0].URI)
+		assert.Empty(t, last.NextCursor)
+	})
+
+	t.Run("filters apply across providers", func(t *testing.T) {
+		ctx := WithListOptions(context.Background(), ListOptions{PageSize: 500, Tags: []string{"clinical"}})
+		uris, _ := listAll(t, ctx, manager.ListResources)
+		assert.Len(t, uris, 602)
+
+		page, err := manager.ListResources(WithListOptions(context.Background(), ListOptions{Tags: []string{"audit"}}), "")
+		require.NoError(t, err)
+		assert.Equal(t, 1, page.Total)
+		assert.Equal(t, "/audit/{variant_id}", page.Resources[0].URI)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := manager.ListResources(context.Background(), "bm9wZQ")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
@@ -0,0 +1,124 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/playbook"
+)
+
+// PlaybookResourceProvider provides access to curated per-gene interpretation playbooks
+type PlaybookResourceProvider struct {
+	logger    *logrus.Logger
+	store     playbook.Store
+	uriParser *URIParser
+}
+
+// NewPlaybookResourceProvider creates a new playbook resource provider
+func NewPlaybookResourceProvider(logger *logrus.Logger, store playbook.Store) *PlaybookResourceProvider {
+	provider := &PlaybookResourceProvider{
+		logger:    logger,
+		store:     store,
+		uriParser: NewURIParser(),
+	}
+
+	provider.uriParser.AddPattern("gene_playbook", `^/genes/(?P<symbol>[A-Za-z0-9-]+)/playbook$`)
+
+	return provider
+}
+
+// GetResource retrieves the playbook for a gene
+func (pp *PlaybookResourceProvider) GetResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	pp.logger.WithField("uri", uri).Debug("Getting playbook resource")
+
+	_, params, err := pp.uriParser.ParseURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse playbook URI: %w", err)
+	}
+
+	symbol := playbook.NormalizeGeneSymbol(params["symbol"])
+	pb, err := pp.store.Get(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load playbook for %s: %w", symbol, err)
+	}
+	if pb == nil {
+		return nil, fmt.Errorf("no playbook found for gene %s", symbol)
+	}
+
+	return &ResourceContent{
+		URI:          fmt.Sprintf("/genes/%s/playbook", symbol),
+		Name:         fmt.Sprintf("%s Interpretation Playbook", symbol),
+		Description:  "VCEP highlights, common pitfalls, frequent benign variants and lab notes",
+		MimeType:     "application/json",
+		Content:      pb,
+		LastModified: pb.UpdatedAt,
+		ETag:         fmt.Sprintf("playbook-%s-v%d", symbol, pb.Version),
+		Metadata: map[string]interface{}{
+			"provider":   "playbook",
+			"gene":       symbol,
+			"version":    pb.Version,
+			"updated_by": pb.UpdatedBy,
+		},
+	}, nil
+}
+
+// ListResources lists the genes that have playbooks
+func (pp *PlaybookResourceProvider) ListResources(ctx context.Context, cursor string) (*ResourceList, error) {
+	genes, err := pp.store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list playbooks: %w", err)
+	}
+
+	resources := make([]ResourceInfo, 0, len(genes))
+	for _, gene := range genes {
+		resources = append(resources, ResourceInfo{
+			URI:         fmt.Sprintf("/genes/%s/playbook", gene),
+			Name:        fmt.Sprintf("%s Interpretation Playbook", gene),
+			Description: "Curated interpretation playbook",
+			MimeType:    "application/json",
+			Tags:        []string{"gene", "playbook", "curation"},
+		})
+	}
+
+	return Paginate(ctx, resources, cursor)
+}
+
+// GetResourceInfo returns metadata about a playbook resource
+func (pp *PlaybookResourceProvider) GetResourceInfo(ctx context.Context, uri string) (*ResourceInfo, error) {
+	_, params, err := pp.uriParser.ParseURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse playbook URI: %w", err)
+	}
+
+	symbol := playbook.NormalizeGeneSymbol(params["symbol"])
+	return &ResourceInfo{
+		URI:         uri,
+		Name:        fmt.Sprintf("%s Interpretation Playbook", symbol),
+		Description: "Curated interpretation playbook",
+		MimeType:    "application/json",
+		Tags:        []string{"gene", "playbook", "curation"},
+		Metadata: map[string]interface{}{
+			"gene": symbol,
+		},
+	}, nil
+}
+
+// SupportsURI checks if this provider supports the given URI
+func (pp *PlaybookResourceProvider) SupportsURI(uri string) bool {
+	_, _, err := pp.uriParser.ParseURI(uri)
+	return err == nil
+}
+
+// GetProviderInfo returns information about this provider
+func (pp *PlaybookResourceProvider) GetProviderInfo() ProviderInfo {
+	return ProviderInfo{
+		Name:        "playbook",
+		Description: "Curated per-gene interpretation playbooks maintained by senior curators",
+		Version:     "1.0.0",
+		URIPatterns: []string{
+			"/genes/{symbol}/playbook",
+		},
+	}
+}
@@ -2,9 +2,11 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -25,7 +27,8 @@ type ResourceProvider interface {
 	// GetResource retrieves a resource by URI
 	GetResource(ctx context.Context, uri string) (*ResourceContent, error)
 	
-	// ListResources lists available resources with optional filtering
+	// ListResources lists the page of available resources after cursor,
+	// filtered by the context's ListOptions (see Paginate)
 	ListResources(ctx context.Context, cursor string) (*ResourceList, error)
 	
 	// GetResourceInfo returns metadata about a resource
@@ -50,11 +53,11 @@ type ResourceContent struct {
 	ETag        string                 `json:"etag,omitempty"`
 }
 
-// ResourceList represents a list of available resources
+// ResourceList represents a page of available resources
 type ResourceList struct {
 	Resources []ResourceInfo `json:"resources"`
-	NextCursor string        `json:"nextCursor,omitempty"`
-	Total      int           `json:"total"`
+	NextCursor string        `json:"nextCursor,omitempty"` // Empty on the last page
+	Total      int           `json:"total"`                // Matching resources across all pages
 }
 
 // ResourceInfo provides metadata about a resource
@@ -164,34 +167,81 @@ func (rm *ResourceManager) GetResource(ctx context.Context, uri string) (*Resour
 	return content, nil
 }
 
-// ListResources lists all available resources
+// ListResources lists a page of the resources of every provider, filtered
+// by the context's ListOptions. Providers are listed in name order and the
+// cursor records the provider and its own cursor, so each call only pages
+// through the providers it needs; the others are asked for their totals.
 func (rm *ResourceManager) ListResources(ctx context.Context, cursor string) (*ResourceList, error) {
 	rm.logger.WithField("cursor", cursor).Debug("Listing resources")
-	
-	allResources := make([]ResourceInfo, 0)
-	
+
+	opts := ListOptionsFrom(ctx)
+	var start managerCursor
+	if cursor != "" {
+		if err := decodeCursor(cursor, &start); err != nil {
+			return nil, err
+		}
+	}
+
 	rm.mutex.RLock()
-	for _, provider := range rm.providers {
-		list, err := provider.ListResources(ctx, cursor)
+	defer rm.mutex.RUnlock()
+	names := make([]string, 0, len(rm.providers))
+	for name := range rm.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := &ResourceList{Resources: make([]ResourceInfo, 0)}
+	for _, name := range names {
+		remaining := opts.PageSize - len(result.Resources)
+		collect := name >= start.Provider && remaining > 0 && result.NextCursor == ""
+
+		// Providers outside the page are only counted
+		pageOpts, providerCursor := opts, ""
+		if collect {
+			pageOpts.PageSize = remaining
+			if name == start.Provider {
+				providerCursor = start.Cursor
+			}
+		} else {
+			pageOpts.PageSize = 1
+		}
+
+		list, err := rm.providers[name].ListResources(WithListOptions(ctx, pageOpts), providerCursor)
+		if errors.Is(err, ErrInvalidCursor) {
+			return nil, err
+		}
 		if err != nil {
-			rm.logger.WithError(err).WithField("provider", provider.GetProviderInfo().Name).
+			rm.logger.WithError(err).WithField("provider", rm.providers[name].GetProviderInfo().Name).
 				Warn("Failed to list resources from provider")
 			continue
 		}
-		
-		allResources = append(allResources, list.Resources...)
-	}
-	rm.mutex.RUnlock()
-	
-	result := &ResourceList{
-		Resources: allResources,
-		Total:     len(allResources),
+
+		result.Total += list.Total
+		switch {
+		case collect:
+			result.Resources = append(result.Resources, list.Resources...)
+			if list.NextCursor != "" {
+				result.NextCursor = encodeCursor(managerCursor{Provider: name, Cursor: list.NextCursor})
+			}
+		case name > start.Provider && result.NextCursor == "" && list.Total > 0:
+			// The page filled up at the end of the previous provider
+			result.NextCursor = encodeCursor(managerCursor{Provider: name})
+		}
 	}
-	
-	rm.logger.WithField("count", len(allResources)).Info("Listed resources")
+
+	rm.logger.WithFields(logrus.Fields{
+		"count": len(result.Resources),
+		"total": result.Total,
+	}).Info("Listed resources")
 	return result, nil
 }
 
+// managerCursor is the position of the next page across providers
+type managerCursor struct {
+	Provider string `json:"p"`
+	Cursor   string `json:"c,omitempty"` // The provider's own cursor
+}
+
 // GetResourceInfo returns metadata about a resource
 func (rm *ResourceManager) GetResourceInfo(ctx context.Context, uri string) (*ResourceInfo, error) {
 	provider := rm.findProvider(uri)
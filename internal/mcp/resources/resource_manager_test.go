@@ -37,7 +37,7 @@ func TestResourceManager_GetResource(t *testing.T) {
 	// Register providers
 	manager.RegisterProvider("variant", NewVariantResourceProvider(logger))
 	manager.RegisterProvider("interpretation", NewInterpretationResourceProvider(logger))
-	manager.RegisterProvider("evidence", NewEvidenceResourceProvider(logger))
+	manager.RegisterProvider("evidence", mockEvidenceProvider(logger))
 	manager.RegisterProvider("acmg_rules", NewACMGRulesResourceProvider(logger))
 	
 	tests := []struct {
@@ -131,7 +131,7 @@ func TestResourceManager_ListResources(t *testing.T) {
 	// Register all providers
 	manager.RegisterProvider("variant", NewVariantResourceProvider(logger))
 	manager.RegisterProvider("interpretation", NewInterpretationResourceProvider(logger))
-	manager.RegisterProvider("evidence", NewEvidenceResourceProvider(logger))
+	manager.RegisterProvider("evidence", mockEvidenceProvider(logger))
 	manager.RegisterProvider("acmg_rules", NewACMGRulesResourceProvider(logger))
 	
 	ctx := context.Background()
@@ -417,7 +417,7 @@ func TestAllResourceProviders_Integration(t *testing.T) {
 	providers := map[string]ResourceProvider{
 		"variant":      NewVariantResourceProvider(logger),
 		"interpretation": NewInterpretationResourceProvider(logger),
-		"evidence":     NewEvidenceResourceProvider(logger),
+		"evidence":     mockEvidenceProvider(logger),
 		"acmg_rules":   NewACMGRulesResourceProvider(logger),
 	}
 	
@@ -546,7 +546,7 @@ func TestResourceProviders_ErrorHandling(t *testing.T) {
 	providers := []ResourceProvider{
 		NewVariantResourceProvider(logger),
 		NewInterpretationResourceProvider(logger),
-		NewEvidenceResourceProvider(logger),
+		mockEvidenceProvider(logger),
 		NewACMGRulesResourceProvider(logger),
 	}
 	
@@ -575,4 +575,10 @@ func TestResourceProviders_ErrorHandling(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+// mockEvidenceProvider serves mock evidence, as no knowledge base is configured
+func mockEvidenceProvider(logger *logrus.Logger) *EvidenceResourceProvider {
+	provider := NewEvidenceResourceProvider(logger)
+	provider.SetFallbackToMock(true)
+	return provider
+}
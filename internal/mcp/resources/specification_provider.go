@@ -0,0 +1,197 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/vcep"
+)
+
+// SpecificationResourceProvider provides access to the loaded VCEP rule specifications
+type SpecificationResourceProvider struct {
+	logger    *logrus.Logger
+	registry  *vcep.Registry
+	uriParser *URIParser
+	loadedAt  time.Time
+}
+
+// SpecificationSummary describes a loaded specification in the index resource
+type SpecificationSummary struct {
+	ID       string   `json:"id,omitempty"`
+	Gene     string   `json:"gene"`
+	Disease  string   `json:"disease"`
+	Version  string   `json:"version"`
+	Source   string   `json:"source,omitempty"`
+	File     string   `json:"file,omitempty"`
+	Criteria []string `json:"criteria"` // Criteria overridden by the specification
+	URI      string   `json:"uri"`
+}
+
+// NewSpecificationResourceProvider creates a new VCEP specification resource provider
+func NewSpecificationResourceProvider(logger *logrus.Logger, registry *vcep.Registry) *SpecificationResourceProvider {
+	provider := &SpecificationResourceProvider{
+		logger:    logger,
+		registry:  registry,
+		uriParser: NewURIParser(),
+		loadedAt:  time.Now(),
+	}
+
+	provider.uriParser.AddPattern("specifications", `^/acmg/specifications$`)
+	provider.uriParser.AddPattern("gene_specification", `^/acmg/specifications/(?P<gene>[A-Za-z0-9-]+)$`)
+
+	return provider
+}
+
+// GetResource returns the specification index or a single gene's specification
+func (sp *SpecificationResourceProvider) GetResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	sp.logger.WithField("uri", uri).Debug("Getting VCEP specification resource")
+
+	pattern, params, err := sp.uriParser.ParseURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse specification URI: %w", err)
+	}
+
+	if pattern == "specifications" {
+		specs := sp.registry.List()
+		summaries := make([]SpecificationSummary, 0, len(specs))
+		for _, spec := range specs {
+			summaries = append(summaries, summarizeSpecification(spec))
+		}
+		return &ResourceContent{
+			URI:          "/acmg/specifications",
+			Name:         "VCEP Rule Specifications",
+			Description:  "Gene-specific ClinGen VCEP specifications that override the generic ACMG/AMP rules",
+			MimeType:     "application/json",
+			Content:      map[string]interface{}{"specifications": summaries, "total": len(summaries)},
+			LastModified: sp.loadedAt,
+			Metadata: map[string]interface{}{
+				"provider": "specification",
+				"count":    len(summaries),
+			},
+		}, nil
+	}
+
+	spec := sp.registry.Lookup(params["gene"])
+	if spec == nil {
+		return nil, fmt.Errorf("no VCEP specification loaded for gene %s", params["gene"])
+	}
+
+	return &ResourceContent{
+		URI:          fmt.Sprintf("/acmg/specifications/%s", spec.Gene),
+		Name:         fmt.Sprintf("%s VCEP Specification", spec.Gene),
+		Description:  fmt.Sprintf("%s rule specification for %s", spec.Label(), spec.Disease),
+		MimeType:     "application/json",
+		Content:      spec,
+		LastModified: sp.loadedAt,
+		ETag:         fmt.Sprintf("vcep-%s-%s", spec.Gene, spec.Version),
+		Metadata: map[string]interface{}{
+			"provider": "specification",
+			"gene":     spec.Gene,
+			"version":  spec.Version,
+		},
+	}, nil
+}
+
+// ListResources lists the index and every loaded specification
+func (sp *SpecificationResourceProvider) ListResources(ctx context.Context, cursor string) (*ResourceList, error) {
+	specs := sp.registry.List()
+
+	resources := make([]ResourceInfo, 0, len(specs)+1)
+	resources = append(resources, ResourceInfo{
+		URI:         "/acmg/specifications",
+		Name:        "VCEP Rule Specifications",
+		Description: "Index of loaded gene-specific rule specifications",
+		MimeType:    "application/json",
+		Tags:        []string{"acmg", "vcep", "specifications"},
+	})
+	for _, spec := range specs {
+		resources = append(resources, ResourceInfo{
+			URI:         fmt.Sprintf("/acmg/specifications/%s", spec.Gene),
+			Name:        fmt.Sprintf("%s VCEP Specification", spec.Gene),
+			Description: spec.Disease,
+			MimeType:    "application/json",
+			Tags:        []string{"acmg", "vcep", "gene"},
+		})
+	}
+
+	return Paginate(ctx, resources, cursor)
+}
+
+// GetResourceInfo returns metadata about a specification resource
+func (sp *SpecificationResourceProvider) GetResourceInfo(ctx context.Context, uri string) (*ResourceInfo, error) {
+	pattern, params, err := sp.uriParser.ParseURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse specification URI: %w", err)
+	}
+
+	if pattern == "specifications" {
+		return &ResourceInfo{
+			URI:         uri,
+			Name:        "VCEP Rule Specifications",
+			Description: "Index of loaded gene-specific rule specifications",
+			MimeType:    "application/json",
+			Tags:        []string{"acmg", "vcep", "specifications"},
+		}, nil
+	}
+
+	return &ResourceInfo{
+		URI:         uri,
+		Name:        fmt.Sprintf("%s VCEP Specification", params["gene"]),
+		Description: "Gene-specific ACMG/AMP rule specification",
+		MimeType:    "application/json",
+		Tags:        []string{"acmg", "vcep", "gene"},
+		Metadata: map[string]interface{}{
+			"gene": params["gene"],
+		},
+	}, nil
+}
+
+// SupportsURI checks if this provider supports the given URI
+func (sp *SpecificationResourceProvider) SupportsURI(uri string) bool {
+	_, _, err := sp.uriParser.ParseURI(uri)
+	return err == nil
+}
+
+// GetProviderInfo returns information about this provider
+func (sp *SpecificationResourceProvider) GetProviderInfo() ProviderInfo {
+	return ProviderInfo{
+		Name:        "specification",
+		Description: "Gene-specific ClinGen VCEP rule specifications",
+		Version:     "1.0.0",
+		URIPatterns: []string{
+			"/acmg/specifications",
+			"/acmg/specifications/{gene}",
+		},
+	}
+}
+
+func summarizeSpecification(spec *vcep.Specification) SpecificationSummary {
+	criteria := make([]string, 0, len(spec.Rules))
+	for _, code := range ruleOrder {
+		if spec.Rule(code) != nil {
+			criteria = append(criteria, code)
+		}
+	}
+	return SpecificationSummary{
+		ID:       spec.ID,
+		Gene:     spec.Gene,
+		Disease:  spec.Disease,
+		Version:  spec.Version,
+		Source:   spec.Source,
+		File:     spec.File,
+		Criteria: criteria,
+		URI:      fmt.Sprintf("/acmg/specifications/%s", spec.Gene),
+	}
+}
+
+// ruleOrder lists criteria in the order they appear in the guidelines
+var ruleOrder = []string{
+	"PVS1", "PS1", "PS2", "PS3", "PS4",
+	"PM1", "PM2", "PM3", "PM4", "PM5", "PM6",
+	"PP1", "PP2", "PP3", "PP4", "PP5",
+	"BA1", "BS1", "BS2", "BS3", "BS4",
+	"BP1", "BP2", "BP3", "BP4", "BP5", "BP6", "BP7",
+}
@@ -0,0 +1,174 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/transcriptset"
+)
+
+// TranscriptSetResourceProvider provides access to the loaded per-specialty transcript sets
+type TranscriptSetResourceProvider struct {
+	logger    *logrus.Logger
+	registry  *transcriptset.Registry
+	uriParser *URIParser
+	loadedAt  time.Time
+}
+
+// TranscriptSetSummary describes a loaded transcript set in the index resource
+type TranscriptSetSummary struct {
+	Specialty   string `json:"specialty"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version,omitempty"`
+	File        string `json:"file,omitempty"`
+	Genes       int    `json:"genes"`
+	URI         string `json:"uri"`
+}
+
+// NewTranscriptSetResourceProvider creates a new transcript set resource provider
+func NewTranscriptSetResourceProvider(logger *logrus.Logger, registry *transcriptset.Registry) *TranscriptSetResourceProvider {
+	provider := &TranscriptSetResourceProvider{
+		logger:    logger,
+		registry:  registry,
+		uriParser: NewURIParser(),
+		loadedAt:  time.Now(),
+	}
+
+	provider.uriParser.AddPattern("transcript_sets", `^/acmg/transcript-sets$`)
+	provider.uriParser.AddPattern("specialty_transcript_set", `^/acmg/transcript-sets/(?P<specialty>[A-Za-z0-9_-]+)$`)
+
+	return provider
+}
+
+// GetResource returns the transcript set index or a single specialty's set
+func (tp *TranscriptSetResourceProvider) GetResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	tp.logger.WithField("uri", uri).Debug("Getting transcript set resource")
+
+	pattern, params, err := tp.uriParser.ParseURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transcript set URI: %w", err)
+	}
+
+	if pattern == "transcript_sets" {
+		sets := tp.registry.List()
+		summaries := make([]TranscriptSetSummary, 0, len(sets))
+		for _, set := range sets {
+			summaries = append(summaries, TranscriptSetSummary{
+				Specialty:   set.Specialty,
+				Description: set.Description,
+				Version:     set.Version,
+				File:        set.File,
+				Genes:       len(set.Transcripts),
+				URI:         fmt.Sprintf("/acmg/transcript-sets/%s", set.Specialty),
+			})
+		}
+		return &ResourceContent{
+			URI:          "/acmg/transcript-sets",
+			Name:         "Specialty Transcript Sets",
+			Description:  "Clinically mandated transcripts per ordering specialty",
+			MimeType:     "application/json",
+			Content:      map[string]interface{}{"transcript_sets": summaries, "total": len(summaries)},
+			LastModified: tp.loadedAt,
+			Metadata: map[string]interface{}{
+				"provider": "transcript_set",
+				"count":    len(summaries),
+			},
+		}, nil
+	}
+
+	set := tp.registry.Get(params["specialty"])
+	if set == nil {
+		return nil, fmt.Errorf("no transcript set loaded for specialty %s", params["specialty"])
+	}
+
+	return &ResourceContent{
+		URI:          fmt.Sprintf("/acmg/transcript-sets/%s", set.Specialty),
+		Name:         fmt.Sprintf("%s Transcript Set", set.Specialty),
+		Description:  set.Description,
+		MimeType:     "application/json",
+		Content:      set,
+		LastModified: tp.loadedAt,
+		ETag:         fmt.Sprintf("transcripts-%s-%s", set.Specialty, set.Version),
+		Metadata: map[string]interface{}{
+			"provider":  "transcript_set",
+			"specialty": set.Specialty,
+			"version":   set.Version,
+		},
+	}, nil
+}
+
+// ListResources lists the index and every loaded transcript set
+func (tp *TranscriptSetResourceProvider) ListResources(ctx context.Context, cursor string) (*ResourceList, error) {
+	sets := tp.registry.List()
+
+	resources := make([]ResourceInfo, 0, len(sets)+1)
+	resources = append(resources, ResourceInfo{
+		URI:         "/acmg/transcript-sets",
+		Name:        "Specialty Transcript Sets",
+		Description: "Index of loaded per-specialty transcript sets",
+		MimeType:    "application/json",
+		Tags:        []string{"acmg", "transcripts", "specialty"},
+	})
+	for _, set := range sets {
+		resources = append(resources, ResourceInfo{
+			URI:         fmt.Sprintf("/acmg/transcript-sets/%s", set.Specialty),
+			Name:        fmt.Sprintf("%s Transcript Set", set.Specialty),
+			Description: set.Description,
+			MimeType:    "application/json",
+			Tags:        []string{"acmg", "transcripts", "specialty"},
+		})
+	}
+
+	return Paginate(ctx, resources, cursor)
+}
+
+// GetResourceInfo returns metadata about a transcript set resource
+func (tp *TranscriptSetResourceProvider) GetResourceInfo(ctx context.Context, uri string) (*ResourceInfo, error) {
+	pattern, params, err := tp.uriParser.ParseURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transcript set URI: %w", err)
+	}
+
+	if pattern == "transcript_sets" {
+		return &ResourceInfo{
+			URI:         uri,
+			Name:        "Specialty Transcript Sets",
+			Description: "Index of loaded per-specialty transcript sets",
+			MimeType:    "application/json",
+			Tags:        []string{"acmg", "transcripts", "specialty"},
+		}, nil
+	}
+
+	return &ResourceInfo{
+		URI:         uri,
+		Name:        fmt.Sprintf("%s Transcript Set", params["specialty"]),
+		Description: "Clinically mandated transcripts for an ordering specialty",
+		MimeType:    "application/json",
+		Tags:        []string{"acmg", "transcripts", "specialty"},
+		Metadata: map[string]interface{}{
+			"specialty": params["specialty"],
+		},
+	}, nil
+}
+
+// SupportsURI checks if this provider supports the given URI
+func (tp *TranscriptSetResourceProvider) SupportsURI(uri string) bool {
+	_, _, err := tp.uriParser.ParseURI(uri)
+	return err == nil
+}
+
+// GetProviderInfo returns information about this provider
+func (tp *TranscriptSetResourceProvider) GetProviderInfo() ProviderInfo {
+	return ProviderInfo{
+		Name:        "transcript_set",
+		Description: "Clinically mandated transcript sets per ordering specialty",
+		Version:     "1.0.0",
+		URIPatterns: []string{
+			"/acmg/transcript-sets",
+			"/acmg/transcript-sets/{specialty}",
+		},
+	}
+}
@@ -237,10 +237,7 @@ func (vp *VariantResourceProvider) ListResources(ctx context.Context, cursor str
 		},
 	}
 	
-	return &ResourceList{
-		Resources: resources,
-		Total:     len(resources),
-	}, nil
+	return Paginate(ctx, resources, cursor)
 }
 
 // GetResourceInfo returns metadata about a variant resource
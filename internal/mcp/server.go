@@ -3,6 +3,7 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"time"
@@ -10,15 +11,24 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sirupsen/logrus"
 
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/bulk"
 	"github.com/acmg-amp-mcp-server/internal/config"
 	"github.com/acmg-amp-mcp-server/internal/domain"
 	"github.com/acmg-amp-mcp-server/internal/feedback"
+	"github.com/acmg-amp-mcp-server/internal/i18n"
 	"github.com/acmg-amp-mcp-server/internal/mcp/caching"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
 	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
 	"github.com/acmg-amp-mcp-server/internal/mcp/transport"
+	"github.com/acmg-amp-mcp-server/internal/metrics"
+	"github.com/acmg-amp-mcp-server/internal/phi"
+	"github.com/acmg-amp-mcp-server/internal/readiness"
 	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/tracing"
 	"github.com/acmg-amp-mcp-server/pkg/external"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
 )
 
 // Server represents the ACMG-AMP MCP Server implementation
@@ -30,6 +40,7 @@ type Server struct {
 	protocolCore    *protocol.ProtocolCore
 	toolRegistry    *tools.ToolRegistry
 	feedbackStore   feedback.Store
+	auditStore      audit.Store
 	logger          *logrus.Logger
 }
 
@@ -49,8 +60,29 @@ func NewServer(configManager *config.Manager) (*Server, error) {
 	cfg := configManager.GetConfig()
 	mcpConfig := &cfg.MCP
 
-	// Create transport manager
+	// Hide patient identifiers and clinical free text in log fields
+	var redactor *phi.Redactor
+	if cfg.Logging.PHISafeLogging {
+		var err error
+		redactor, err = phi.NewRedactor(phi.Config{
+			Mode:             phi.Mode(cfg.Logging.PHIRedactionMode),
+			TokenKey:         cfg.Logging.PHITokenKey,
+			IdentifierFields: cfg.Logging.PHIIdentifierFields,
+			FreeTextFields:   cfg.Logging.PHIFreeTextFields,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid logging.phi_redaction_mode: %w", err)
+		}
+		logger.AddHook(phi.NewHook(redactor))
+	}
+
+	// Create transport manager; HTTP clients authenticate by API key or JWT
 	transportMgr := transport.NewManager(logger, mcpConfig)
+	authenticator, err := newAuthenticator(cfg.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authentication configuration: %w", err)
+	}
+	transportMgr.SetAuthenticator(authenticator, tools.RequiredRole)
 
 	// Create protocol core
 	protocolCore := protocol.NewProtocolCore(logger)
@@ -61,6 +93,9 @@ func NewServer(configManager *config.Manager) (*Server, error) {
 	// The protocol core will route messages through its built-in system handlers
 	// and the message router handles tool-specific routing
 
+	// External API clients share one pooled transport
+	external.ConfigureTransport(configManager.GetExternalAPIConfig().HTTP)
+
 	// Create external services for evidence gathering
 	// TODO: Extract individual configs from mcpConfig once available
 	// For now, create with sensible default configs
@@ -106,6 +141,49 @@ func NewServer(configManager *config.Manager) (*Server, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create knowledge base service: %w", err)
 	}
+	// The full server keeps no local SQLite databases to probe
+	registerReadinessProbes(readiness.Default, clinVarEUtilsURL, gnomADAPIURL, nil, knowledgeBaseService)
+
+	// Enable SpliceAI/Pangolin predictions when a lookup API or scores file is configured
+	if splicingConfig := configManager.GetExternalAPIConfig().Splicing; splicingConfig.BaseURL != "" || splicingConfig.ScoresFile != "" {
+		predictor, err := external.NewSplicingPredictor(splicingConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create splicing predictor: %w", err)
+		}
+		knowledgeBaseService.SetSplicingPredictor(predictor)
+	}
+
+	// Look up REVEL, CADD and AlphaMissense scores in a local dbNSFP copy when configured
+	if path := configManager.GetExternalAPIConfig().DbNSFP.File; path != "" {
+		annotator, err := external.NewDbNSFPAnnotator(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open dbNSFP: %w", err)
+		}
+		knowledgeBaseService.SetComputationalPredictor(annotator)
+	}
+
+	// Find pathogenic ClinVar variants at the same residue for PS1 and PM5
+	knowledgeBaseService.SetResidueLookup(external.NewClinVarResidueLookup(domain.ClinVarConfig{
+		BaseURL:   "https://eutils.ncbi.nlm.nih.gov/entrez/eutils/",
+		RateLimit: 3, // 3 requests per second (NCBI guideline)
+		Timeout:   30 * time.Second,
+	}, external.DefaultResidueMinStars))
+
+	// Look up ClinGen gene-disease validity and dosage curations for PVS1
+	knowledgeBaseService.SetGeneCurationClient(external.NewClinGenClient(configManager.GetExternalAPIConfig().ClinGen))
+
+	// Look up gnomAD gene constraint for PVS1, PP2 and BP1 when configured
+	if path := configManager.GetExternalAPIConfig().Constraint.File; path != "" {
+		table, err := external.LoadConstraintTable(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load gene constraint: %w", err)
+		}
+		knowledgeBaseService.SetConstraintClient(table)
+	}
+
+	// Sources missing their deadline leave the classification provisional
+	externalAPIConfig := configManager.GetExternalAPIConfig()
+	knowledgeBaseService.SetSourceDeadlines(externalAPIConfig.SourceDeadline, externalAPIConfig.SourceDeadlines)
 
 	// Create input parser for HGVS notation
 	inputParser := domain.NewStandardInputParser()
@@ -161,21 +239,138 @@ func NewServer(configManager *config.Manager) (*Server, error) {
 	// Create classifier service with transcript resolver
 	classifierService := service.NewClassifierService(logger, knowledgeBaseService, inputParser, transcriptResolver)
 
+	// Apply per-gene and per-condition frequency thresholds from the configuration
+	frequencyOverrides, err := configManager.GetFrequencyOverrides()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load frequency thresholds: %w", err)
+	}
+	classifierService.SetFrequencyOverrides(frequencyOverrides)
+	logger.WithField("count", frequencyOverrides.Count()).Info("Loaded frequency threshold overrides")
+
+	// Resolve conflicting criteria with the configured policies
+	conflictPolicies, err := configManager.GetConflictPolicies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load conflict policies: %w", err)
+	}
+	classifierService.SetConflictPolicies(conflictPolicies)
+	logger.WithField("default", conflictPolicies.Default).Info("Loaded conflict resolution policies")
+
+	// Reweight criteria with the laboratory's policy, if one is configured
+	weightingPolicy, err := configManager.GetWeightingPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load weighting policy: %w", err)
+	}
+	if weightingPolicy != nil {
+		classifierService.SetWeightingPolicy(weightingPolicy)
+		logger.WithFields(logrus.Fields{"policy": weightingPolicy.Name, "overrides": len(weightingPolicy.Overrides)}).Info("Loaded criterion weighting policy")
+	}
+
+	// Assign PP3/BP4 strength from calibrated predictor intervals, if configured
+	predictorCalibration, err := configManager.GetPredictorCalibration()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load predictor calibration: %w", err)
+	}
+	if predictorCalibration != nil {
+		classifierService.SetCalibration(predictorCalibration)
+		logger.WithFields(logrus.Fields{"calibration": predictorCalibration.Name, "tables": len(predictorCalibration.Tables)}).Info("Loaded predictor calibration")
+	}
+
+	// Annotate protein domains and hotspots for PM1
+	proteinDomains, err := configManager.GetProteinDomains()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load protein domains: %w", err)
+	}
+	classifierService.SetDomainSource(proteinDomains)
+	logger.WithField("count", proteinDomains.Count()).Info("Loaded protein domain annotations")
+
+	// Match patient HPO terms against gene phenotypes for PP4
+	phenotypeMatcher, err := configManager.GetPhenotypeMatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load HPO annotations: %w", err)
+	}
+	classifierService.SetPhenotypeSource(phenotypeMatcher)
+	logger.WithField("count", phenotypeMatcher.Count()).Info("Loaded HPO gene annotations")
+
+	// Annotate transcript consequences with Ensembl VEP when selected
+	if configManager.GetConsequenceAnnotator() == config.ConsequenceAnnotatorVEP {
+		vepConfig := configManager.GetExternalAPIConfig().VEP
+		classifierService.SetConsequenceAnnotator(external.NewVEPClient(vepConfig))
+		logger.WithFields(logrus.Fields{"url": vepConfig.BaseURL, "transcripts": vepConfig.Transcripts}).Info("Annotating transcript consequences with Ensembl VEP")
+	}
+
+	// Query CIViC, and OncoKB when a token is configured, for somatic tiering
+	var somaticSources []external.SomaticEvidenceClient
+	if civicConfig := configManager.GetExternalAPIConfig().CIViC; civicConfig.BaseURL != "" {
+		somaticSources = append(somaticSources, external.NewCIViCClient(civicConfig))
+	}
+	if oncoKBConfig := configManager.GetExternalAPIConfig().OncoKB; oncoKBConfig.BaseURL != "" && oncoKBConfig.Token != "" {
+		somaticSources = append(somaticSources, external.NewOncoKBClient(oncoKBConfig))
+	}
+	classifierService.SetSomaticEvidenceSources(somaticSources...)
+
+	// Memoize classification results of hot variants
+	if classificationConfig := configManager.GetConfig().Classification; classificationConfig.ResultCacheSize > 0 {
+		resultCache, err := service.NewResultCache(classificationConfig.ResultCacheSize, classificationConfig.ResultCacheTTL)
+		if err != nil {
+			return nil, err
+		}
+		classifierService.SetResultCache(resultCache)
+		logger.WithFields(logrus.Fields{"size": classificationConfig.ResultCacheSize, "ttl": classificationConfig.ResultCacheTTL}).Info("Memoizing classification results")
+	}
+
 	// Validate service initialization and connectivity
 	if err := validateServiceConnectivity(logger, transcriptResolver, knowledgeBaseService); err != nil {
 		return nil, fmt.Errorf("service connectivity validation failed: %w", err)
 	}
 
+	// Initialize classification audit trail store (PostgreSQL-based, using same database as main app)
+	dbConnStr := configManager.GetDatabaseConnectionString()
+	auditStore, err := audit.NewPostgresStoreFromURL(dbConnStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit store: %w", err)
+	}
+
+	locale, err := i18n.ParseLocale(mcpConfig.Locale)
+	if err != nil {
+		auditStore.Close()
+		return nil, fmt.Errorf("invalid mcp.locale: %w", err)
+	}
+
 	// Create tool registry and register tools
 	toolRegistry := tools.NewToolRegistry(logger, router, classifierService)
+	toolRegistry.SetBatchClassificationLimits(mcpConfig.BatchClassifyLimit, mcpConfig.BatchClassifyWorkers)
+	toolRegistry.SetAuditStore(auditStore)
+	toolRegistry.SetLocale(locale)
 	if err := toolRegistry.RegisterAllTools(); err != nil {
+		auditStore.Close()
 		return nil, fmt.Errorf("failed to register tools: %w", err)
 	}
+	var auditSigner *audit.Signer
+	if mcpConfig.AuditSigningKey != "" {
+		if auditSigner, err = audit.NewSigner(mcpConfig.AuditSigningKey); err != nil {
+			auditStore.Close()
+			return nil, fmt.Errorf("invalid mcp.audit_signing_key: %w", err)
+		}
+	}
+	if err := registerAuditTools(toolRegistry, logger, auditStore, auditSigner, redactor); err != nil {
+		auditStore.Close()
+		return nil, fmt.Errorf("failed to register audit tools: %w", err)
+	}
+
+	// Serve the prompt templates, including case review from the audit trail
+	registerPrompts(router, logger, auditStore, locale)
+
+	// Limit response sizes per transport
+	toolRegistry.SetResponseLimiter(tools.NewResponseLimiter(logger, map[string]int{
+		tools.TransportStdio:     mcpConfig.MaxResponseBytesStdio,
+		tools.TransportHTTPSSE:   mcpConfig.MaxResponseBytesHTTP,
+		tools.TransportWebSocket: mcpConfig.MaxResponseBytesHTTP,
+	}))
 
 	// Initialize feedback store (PostgreSQL-based, using same database as main app)
-	dbConnStr := configManager.GetDatabaseConnectionString()
 	feedbackStore, err := feedback.NewPostgresStoreFromURL(dbConnStr)
 	if err != nil {
+		auditStore.Close()
 		return nil, fmt.Errorf("failed to create feedback store: %w", err)
 	}
 
@@ -184,16 +379,19 @@ func NewServer(configManager *config.Manager) (*Server, error) {
 	exportDir := filepath.Join(getFeedbackDataDir(), "exports")
 	if err := os.MkdirAll(exportDir, 0755); err != nil {
 		feedbackStore.Close()
+		auditStore.Close()
 		return nil, fmt.Errorf("failed to create export directory: %w", err)
 	}
-	if err := registerFeedbackTools(toolRegistry, logger, feedbackStore, exportDir); err != nil {
+	if err := registerFeedbackTools(toolRegistry, logger, feedbackStore, nil, exportDir); err != nil {
 		feedbackStore.Close()
+		auditStore.Close()
 		return nil, fmt.Errorf("failed to register feedback tools: %w", err)
 	}
 
 	// Validate all tools
 	if err := toolRegistry.ValidateAllTools(); err != nil {
 		feedbackStore.Close()
+		auditStore.Close()
 		return nil, fmt.Errorf("tool validation failed: %w", err)
 	}
 
@@ -205,6 +403,9 @@ func NewServer(configManager *config.Manager) (*Server, error) {
 
 	// Create MCP server with tool handlers
 	mcpServer := mcp.NewServer(serverInfo, nil)
+	// Page and filter resources/list
+	mcpServer.AddReceivingMiddleware(listResourcesMiddleware)
+
 	
 	// Create server instance first
 	server := &Server{
@@ -214,14 +415,41 @@ func NewServer(configManager *config.Manager) (*Server, error) {
 		protocolCore:  protocolCore,
 		toolRegistry:  toolRegistry,
 		feedbackStore: feedbackStore,
+		auditStore:    auditStore,
 		logger:        logger,
 	}
 
+	// Classify uploaded variant tables on the HTTP and WebSocket transports
+	transportMgr.AddRoute(transport.Route{
+		Method:  http.MethodPost,
+		Path:    "/api/v1/classify/table",
+		Role:    tools.RequiredRole("classify_variants_batch"),
+		Handler: bulk.Handler(logger, server, hgvs.AssemblyGRCh38, bulk.Options{ChunkSize: mcpConfig.BatchClassifyLimit}),
+	})
+
 	// Register MCP tools from our tool registry
 	if err := server.registerMCPTools(mcpServer, toolRegistry); err != nil {
 		return nil, fmt.Errorf("failed to register MCP tools: %w", err)
 	}
 
+	// Register the gene-disease resource when OMIM or Orphanet is configured
+	var omim, orphanet external.GeneDiseaseClient
+	if omimConfig := configManager.GetExternalAPIConfig().OMIM; omimConfig.APIKey != "" {
+		omim = external.NewOMIMClient(omimConfig)
+	}
+	if dir := configManager.GetExternalAPIConfig().Orphanet.Dir; dir != "" {
+		data, err := external.LoadOrphanetDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Orphanet data: %w", err)
+		}
+		logger.WithField("count", data.Count()).Info("Loaded Orphanet gene-disease associations")
+		orphanet = data
+	}
+	registerDiseaseResources(mcpServer, logger, omim, orphanet)
+	registerEvidenceResources(mcpServer, logger, knowledgeBaseService, somaticSources, configManager.GetConfig().MCP.EvidenceMockFallback)
+	registerAuditResource(mcpServer, logger, auditStore)
+	registerCacheStatsResource(mcpServer, logger, knowledgeBaseService)
+
 	// Register capabilities
 	if err := server.registerCapabilities(); err != nil {
 		return nil, fmt.Errorf("failed to register capabilities: %w", err)
@@ -230,6 +458,15 @@ func NewServer(configManager *config.Manager) (*Server, error) {
 	return server, nil
 }
 
+// CallTool runs a tool in-process, without a transport. Results are not summarized.
+func (s *Server) CallTool(ctx context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response {
+	return s.toolRegistry.ExecuteTool(tools.WithInProcess(ctx), &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  name,
+		Params:  arguments,
+	})
+}
+
 // registerMCPTools registers our tools with the MCP SDK
 func (s *Server) registerMCPTools(mcpServer *mcp.Server, toolRegistry *tools.ToolRegistry) error {
 	s.logger.Info("Registering tools with MCP SDK...")
@@ -239,10 +476,14 @@ func (s *Server) registerMCPTools(mcpServer *mcp.Server, toolRegistry *tools.Too
 	
 	for _, toolInfo := range toolsInfo {
 		// Create MCP tool definition
+		inputSchema, err := toolInputSchema(toolInfo)
+		if err != nil {
+			return err
+		}
 		toolDef := &mcp.Tool{
 			Name:        toolInfo.Name,
 			Description: toolInfo.Description,
-			// TODO: Add proper input schema from tool info
+			InputSchema: inputSchema,
 		}
 		
 		// Create handler bridge
@@ -269,8 +510,41 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 
 	s.activeTransport = activeTransport
+	s.toolRegistry.SetActiveTransport(activeTransport.GetType())
 	s.logger.WithField("transport_type", activeTransport.GetType()).Info("Transport initialized")
 
+	// Serve Prometheus metrics on their own address
+	if addr := s.config.GetConfig().MCP.MetricsAddr; addr != "" {
+		metrics.Serve(ctx, addr, s.logger)
+	}
+
+	// Probe dependencies for /healthz and /readyz
+	readiness.Default.Run(ctx, s.logger)
+
+	// Export traces of tool calls to an OpenTelemetry collector
+	if mcpConfig := s.config.GetConfig().MCP; mcpConfig.TracingEndpoint != "" {
+		sampleRatio := mcpConfig.TraceSampleRatio
+		if sampleRatio == 0 {
+			sampleRatio = 1
+		}
+		if err := tracing.Install(ctx, tracing.Config{
+			Endpoint:    mcpConfig.TracingEndpoint,
+			ServiceName: mcpConfig.ServerName,
+			SampleRatio: sampleRatio,
+		}, s.logger); err != nil {
+			s.logger.WithError(err).Warn("Failed to install tracing")
+		}
+	}
+
+	// Session transports run one MCP session per connection
+	if sessions, ok := activeTransport.(transport.SessionTransport); ok {
+		if err := serveSessions(ctx, s.mcpServer, sessions, s.logger); err != nil {
+			s.activeTransport.Close()
+			return fmt.Errorf("MCP server failed: %w", err)
+		}
+		return nil
+	}
+
 	// Create bridge between our transport and MCP SDK
 	mcpTransport := NewMCPTransportBridge(activeTransport, s.logger)
 	
@@ -356,8 +630,8 @@ func (s *Server) registerResources() error {
 
 // registerPrompts registers MCP prompts
 func (s *Server) registerPrompts() error {
-	// For now, we'll register placeholder prompts
-	s.logger.Debug("Prompt registration placeholder - to be implemented")
+	// Prompt templates are registered with the message router in NewServer
+	s.logger.Debug("Prompts served by the message router")
 	return nil
 }
 
@@ -394,6 +668,11 @@ func (s *Server) Close() error {
 			s.logger.WithError(err).Error("Failed to close feedback store")
 		}
 	}
+	if s.auditStore != nil {
+		if err := s.auditStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close audit store")
+		}
+	}
 	if s.activeTransport != nil {
 		s.activeTransport.Close()
 	}
@@ -411,3 +690,26 @@ func getFeedbackDataDir() string {
 	return filepath.Join(homeDir, ".acmg-amp-mcp")
 }
 
+// newAuthenticator creates the authenticator for HTTP clients from the auth configuration
+func newAuthenticator(cfg domain.AuthConfig) (*auth.Authenticator, error) {
+	authConfig := auth.Config{
+		JWTSecret:   cfg.JWTSecret,
+		JWTIssuer:   cfg.JWTIssuer,
+		JWTAudience: cfg.JWTAudience,
+	}
+	for _, key := range cfg.APIKeys {
+		role, err := auth.ParseRole(key.Role)
+		if err != nil {
+			return nil, fmt.Errorf("API key %s: %w", key.Name, err)
+		}
+		authConfig.APIKeys = append(authConfig.APIKeys, auth.APIKey{Name: key.Name, Role: role, Key: key.Key})
+	}
+	if cfg.AnonymousRole != "" {
+		role, err := auth.ParseRole(cfg.AnonymousRole)
+		if err != nil {
+			return nil, fmt.Errorf("anonymous role: %w", err)
+		}
+		authConfig.AnonymousRole = role
+	}
+	return auth.NewAuthenticator(authConfig)
+}
@@ -4,21 +4,70 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sirupsen/logrus"
 
+	"github.com/acmg-amp-mcp-server/internal/admin"
+	"github.com/acmg-amp-mcp-server/internal/artifact"
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/beacon"
+	"github.com/acmg-amp-mcp-server/internal/benign"
+	"github.com/acmg-amp-mcp-server/internal/bulk"
 	"github.com/acmg-amp-mcp-server/internal/cache"
+	"github.com/acmg-amp-mcp-server/internal/calibration"
+	"github.com/acmg-amp-mcp-server/internal/cnv"
+	"github.com/acmg-amp-mcp-server/internal/cohort"
 	litecfg "github.com/acmg-amp-mcp-server/internal/config"
+	"github.com/acmg-amp-mcp-server/internal/configrepo"
+	"github.com/acmg-amp-mcp-server/internal/conflicts"
+	"github.com/acmg-amp-mcp-server/internal/digest"
 	"github.com/acmg-amp-mcp-server/internal/domain"
 	"github.com/acmg-amp-mcp-server/internal/feedback"
+	"github.com/acmg-amp-mcp-server/internal/followup"
+	"github.com/acmg-amp-mcp-server/internal/graphqlapi"
+	"github.com/acmg-amp-mcp-server/internal/grpcapi"
+	"github.com/acmg-amp-mcp-server/internal/i18n"
+	"github.com/acmg-amp-mcp-server/internal/jobs"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+	"github.com/acmg-amp-mcp-server/internal/literature"
+	"github.com/acmg-amp-mcp-server/internal/matchmaker"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
 	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
 	"github.com/acmg-amp-mcp-server/internal/mcp/transport"
+	"github.com/acmg-amp-mcp-server/internal/metrics"
+	"github.com/acmg-amp-mcp-server/internal/pgx"
+	"github.com/acmg-amp-mcp-server/internal/phenotype"
+	"github.com/acmg-amp-mcp-server/internal/phi"
+	"github.com/acmg-amp-mcp-server/internal/playbook"
+	"github.com/acmg-amp-mcp-server/internal/proteindomains"
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+	"github.com/acmg-amp-mcp-server/internal/readiness"
+	"github.com/acmg-amp-mcp-server/internal/regions"
+	"github.com/acmg-amp-mcp-server/internal/review"
 	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/shutdown"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+	"github.com/acmg-amp-mcp-server/internal/surveillance"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+	"github.com/acmg-amp-mcp-server/internal/tracing"
+	"github.com/acmg-amp-mcp-server/internal/transcriptset"
+	"github.com/acmg-amp-mcp-server/internal/variantid"
+	"github.com/acmg-amp-mcp-server/internal/vcep"
+	"github.com/acmg-amp-mcp-server/internal/vrs"
+	"github.com/acmg-amp-mcp-server/internal/webhook"
+	"github.com/acmg-amp-mcp-server/internal/weighting"
+	"github.com/acmg-amp-mcp-server/internal/wizard"
 	"github.com/acmg-amp-mcp-server/pkg/external"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
 )
 
 // LiteServer is a lightweight MCP server that requires no external databases.
@@ -30,7 +79,58 @@ type LiteServer struct {
 	activeTransport transport.Transport
 	toolRegistry    *tools.ToolRegistry
 	feedbackStore   feedback.Store
+	snapshotStore   snapshot.Store
+	playbookStore   playbook.Store
+	followUpStore   followup.Store
+	literatureStore literature.Store
+	literatureMonitor *literature.Monitor
+	refresher       *surveillance.Refresher
+	refreshSchedule *surveillance.Schedule
+	jobStore        jobs.Store
+	sessionStore    wizard.Store
+	reviewStore     review.Store
+	jobRunner       *jobs.Runner
+	cohortStore     cohort.Store
+	artifactStore   artifact.Store
+	auditStore      audit.Store
+	knownBenign     benign.Store
+	labKnowledge    labkb.Store
+	webhookStore    webhook.Store
+	webhooks        *webhook.Dispatcher
+	identifierStore variantid.Store
+	thresholdStore  thresholds.Store
+	specifications  *vcep.Registry
+	frequencyOverrides *thresholds.FrequencyOverrides
+	conflictPolicies *conflicts.Config
+	weightingPolicy  *weighting.Policy
+	calibration      *calibration.Calibration
+	splicingPredictor external.SplicingPredictionClient
+	computationalPredictor external.ComputationalPredictionClient
+	residueLookup   external.ResidueVariantClient
+	geneCurations   external.GeneCurationClient
+	geneConstraint  external.GeneConstraintClient
+	omim            external.GeneDiseaseClient
+	orphanet        *external.OrphanetData
+	localClinVar    *external.LocalClinVar
+	localGnomAD     *external.GnomADAnnotator
+	datasetVersions *provenance.Registry
+	normalizer      service.VariantNormalizer
+	vrsReference    *hgvs.IndexedFasta
+	consequenceAnnotator service.ConsequenceAnnotator
+	regionTracks    *regions.Tracks
+	cnvAnnotations  *cnv.Annotations
+	pgxKnowledge    *pgx.Knowledge
+	transcriptSets  *transcriptset.Registry
+	proteinDomains  *proteindomains.Registry
+	phenotypes      *phenotype.Matcher
+	configRepo      *configrepo.Syncer
+	adminServer     *admin.Server
+	grpcServer      *grpcapi.Server
+	digestGenerator *digest.Generator
+	digestNotifiers []digest.Notifier
 	cache           *cache.MemoryCache
+	shutdown        *shutdown.Coordinator
+	redactor        *phi.Redactor // Set when PHI-safe logging is enabled
 	logger          *logrus.Logger
 }
 
@@ -45,6 +145,298 @@ func WithFeedbackStore(store feedback.Store) LiteServerOption {
 	}
 }
 
+// WithSnapshotStore sets a custom evidence snapshot store.
+func WithSnapshotStore(store snapshot.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.snapshotStore = store
+		return nil
+	}
+}
+
+// WithPlaybookStore sets a custom gene playbook store.
+func WithPlaybookStore(store playbook.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.playbookStore = store
+		return nil
+	}
+}
+
+// WithFollowUpStore sets a custom follow-up flag store.
+func WithFollowUpStore(store followup.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.followUpStore = store
+		return nil
+	}
+}
+
+// WithLiteratureStore sets a custom literature notice store.
+func WithLiteratureStore(store literature.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.literatureStore = store
+		return nil
+	}
+}
+
+// WithCohortStore sets a custom in-house cohort observation store.
+func WithCohortStore(store cohort.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.cohortStore = store
+		return nil
+	}
+}
+
+// WithArtifactStore sets a custom artifact blacklist store.
+func WithArtifactStore(store artifact.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.artifactStore = store
+		return nil
+	}
+}
+
+// WithKnownBenignStore sets a custom known benign list store.
+func WithKnownBenignStore(store benign.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.knownBenign = store
+		return nil
+	}
+}
+
+// WithLabKnowledgeStore sets a custom lab knowledge base store.
+func WithLabKnowledgeStore(store labkb.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.labKnowledge = store
+		return nil
+	}
+}
+
+// WithWebhookStore sets a custom webhook store.
+func WithWebhookStore(store webhook.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.webhookStore = store
+		return nil
+	}
+}
+
+// WithJobStore sets a custom classification job store.
+func WithJobStore(store jobs.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.jobStore = store
+		return nil
+	}
+}
+
+// WithClassificationSessionStore sets a custom guided classification session store.
+func WithClassificationSessionStore(store wizard.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.sessionStore = store
+		return nil
+	}
+}
+
+// WithReviewStore sets a custom classification review store.
+func WithReviewStore(store review.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.reviewStore = store
+		return nil
+	}
+}
+
+// WithIdentifierStore sets a custom rsID and ClinVar accession mapping cache.
+func WithIdentifierStore(store variantid.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.identifierStore = store
+		return nil
+	}
+}
+
+// WithAuditStore sets a custom classification audit trail store.
+func WithAuditStore(store audit.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.auditStore = store
+		return nil
+	}
+}
+
+// WithThresholdStore sets a custom rule threshold revision store.
+func WithThresholdStore(store thresholds.Store) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.thresholdStore = store
+		return nil
+	}
+}
+
+// WithSpecifications sets a custom VCEP rule specification registry.
+func WithSpecifications(registry *vcep.Registry) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.specifications = registry
+		return nil
+	}
+}
+
+// WithFrequencyOverrides sets custom per-gene and per-condition frequency thresholds.
+func WithFrequencyOverrides(overrides *thresholds.FrequencyOverrides) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.frequencyOverrides = overrides
+		return nil
+	}
+}
+
+// WithConflictPolicies sets custom resolution policies for conflicting criteria.
+func WithConflictPolicies(policies *conflicts.Config) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.conflictPolicies = policies
+		return nil
+	}
+}
+
+// WithWeightingPolicy sets a custom laboratory criterion weighting policy.
+func WithWeightingPolicy(policy *weighting.Policy) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.weightingPolicy = policy
+		return nil
+	}
+}
+
+// WithCalibration sets custom calibrated predictor intervals.
+func WithCalibration(cal *calibration.Calibration) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.calibration = cal
+		return nil
+	}
+}
+
+// WithConfigRepo sets a custom Git-backed clinical configuration syncer.
+// It must already be started; the server keeps it up to date.
+func WithConfigRepo(syncer *configrepo.Syncer) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.configRepo = syncer
+		return nil
+	}
+}
+
+// WithSplicingPredictor sets a custom SpliceAI/Pangolin splicing predictor.
+func WithSplicingPredictor(predictor external.SplicingPredictionClient) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.splicingPredictor = predictor
+		return nil
+	}
+}
+
+// WithResidueLookup sets a custom source of pathogenic variants at the same
+// protein residue, used by PS1 and PM5.
+func WithResidueLookup(lookup external.ResidueVariantClient) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.residueLookup = lookup
+		return nil
+	}
+}
+
+// WithGeneCurationClient sets a custom source of ClinGen gene-disease
+// validity and dosage curations, used by PVS1.
+func WithGeneCurationClient(client external.GeneCurationClient) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.geneCurations = client
+		return nil
+	}
+}
+
+// WithOMIMClient sets a custom source of OMIM gene-disease associations.
+func WithOMIMClient(client external.GeneDiseaseClient) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.omim = client
+		return nil
+	}
+}
+
+// WithOrphanetData sets custom Orphanet gene-disease associations.
+func WithOrphanetData(data *external.OrphanetData) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.orphanet = data
+		return nil
+	}
+}
+
+// WithConstraintClient sets a custom source of gene constraint metrics,
+// used by PVS1, PP2 and BP1.
+func WithConstraintClient(client external.GeneConstraintClient) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.geneConstraint = client
+		return nil
+	}
+}
+
+// WithComputationalPredictor sets a custom in silico score source, such as a dbNSFP annotator.
+func WithComputationalPredictor(predictor external.ComputationalPredictionClient) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.computationalPredictor = predictor
+		return nil
+	}
+}
+
+// WithNormalizer sets a custom HGVS normalizer.
+func WithNormalizer(normalizer service.VariantNormalizer) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.normalizer = normalizer
+		return nil
+	}
+}
+
+// WithConsequenceAnnotator sets a custom transcript consequence annotator.
+func WithConsequenceAnnotator(annotator service.ConsequenceAnnotator) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.consequenceAnnotator = annotator
+		return nil
+	}
+}
+
+// WithRegionTracks sets custom problematic region tracks.
+func WithRegionTracks(tracks *regions.Tracks) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.regionTracks = tracks
+		return nil
+	}
+}
+
+// WithPGxKnowledge sets custom star allele definitions and CPIC tables.
+func WithPGxKnowledge(knowledge *pgx.Knowledge) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.pgxKnowledge = knowledge
+		return nil
+	}
+}
+
+// WithCNVAnnotations sets custom gene and dosage annotations for CNV classification.
+func WithCNVAnnotations(annotations *cnv.Annotations) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.cnvAnnotations = annotations
+		return nil
+	}
+}
+
+// WithPhenotypeMatcher sets a custom HPO phenotype matcher for PP4.
+func WithPhenotypeMatcher(matcher *phenotype.Matcher) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.phenotypes = matcher
+		return nil
+	}
+}
+
+// WithProteinDomains sets custom protein domain and hotspot annotations.
+func WithProteinDomains(registry *proteindomains.Registry) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.proteinDomains = registry
+		return nil
+	}
+}
+
+// WithTranscriptSets sets a custom per-specialty transcript set registry.
+func WithTranscriptSets(registry *transcriptset.Registry) LiteServerOption {
+	return func(s *LiteServer) error {
+		s.transcriptSets = registry
+		return nil
+	}
+}
+
 // WithLogger sets a custom logger.
 func WithLogger(logger *logrus.Logger) LiteServerOption {
 	return func(s *LiteServer) error {
@@ -78,11 +470,34 @@ func NewLiteServer(cfg *litecfg.LiteConfig, opts ...LiteServerOption) (*LiteServ
 		}
 	}
 
+	// Hide patient identifiers and clinical free text in log fields
+	if cfg.PHISafeLogging {
+		redactor, err := phi.NewRedactor(phi.Config{
+			Mode:             phi.Mode(cfg.PHIRedactionMode),
+			TokenKey:         cfg.PHITokenKey,
+			IdentifierFields: cfg.PHIIdentifierFields,
+			FreeTextFields:   cfg.PHIFreeTextFields,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid ACMG_PHI_REDACTION_MODE: %w", err)
+		}
+		server.redactor = redactor
+		server.logger.AddHook(phi.NewHook(redactor))
+		server.logger.WithField("mode", redactor.Mode()).Info("PHI-safe logging enabled")
+	}
+
 	// Ensure data directory exists
 	if err := cfg.EnsureDataDir(); err != nil {
 		return nil, fmt.Errorf("failed to create data directory: %w", err)
 	}
 
+	// External API clients share one pooled transport
+	external.ConfigureTransport(domain.HTTPConfig{
+		MaxConnsPerHost: cfg.ExternalMaxConnsPerHost,
+		MaxRetries:      cfg.ExternalMaxRetries,
+		HostTimeouts:    cfg.ExternalHostTimeouts,
+	})
+
 	// Initialize memory cache
 	memCache, err := cache.NewMemoryCache(cfg.CacheMaxItems, cfg.CacheTTL)
 	if err != nil {
@@ -90,23 +505,418 @@ func NewLiteServer(cfg *litecfg.LiteConfig, opts ...LiteServerOption) (*LiteServ
 	}
 	server.cache = memCache
 
+	// Local SQLite databases, probed for integrity by /readyz
+	databases := make(map[string]string)
+
 	// Initialize feedback store if not provided
 	if server.feedbackStore == nil {
 		store, err := feedback.NewSQLiteStore(cfg.FeedbackDBPath())
 		if err != nil {
 			return nil, fmt.Errorf("failed to create feedback store: %w", err)
 		}
+		databases["feedback"] = cfg.FeedbackDBPath()
 		server.feedbackStore = store
 	}
 
+	// Initialize evidence snapshot store with compressed archive tier if not provided
+	if server.snapshotStore == nil {
+		archive, err := snapshot.NewFileArchive(cfg.ArchiveDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create evidence archive: %w", err)
+		}
+		store, err := snapshot.NewSQLiteStore(cfg.SnapshotDBPath(), archive)
+		if err != nil {
+			archive.Close()
+			return nil, fmt.Errorf("failed to create snapshot store: %w", err)
+		}
+		databases["snapshot"] = cfg.SnapshotDBPath()
+		server.snapshotStore = store
+	}
+
+	// Initialize gene playbook store if not provided
+	if server.playbookStore == nil {
+		store, err := playbook.NewSQLiteStore(cfg.PlaybookDBPath(), cfg.SeniorCurators)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create playbook store: %w", err)
+		}
+		databases["playbook"] = cfg.PlaybookDBPath()
+		server.playbookStore = store
+	}
+
+	// Initialize follow-up flag store if not provided
+	if server.followUpStore == nil {
+		store, err := followup.NewSQLiteStore(cfg.FollowUpDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create follow-up store: %w", err)
+		}
+		databases["follow_up"] = cfg.FollowUpDBPath()
+		server.followUpStore = store
+	}
+
+	// Initialize literature notice store if not provided
+	if server.literatureStore == nil {
+		store, err := literature.NewSQLiteStore(cfg.LiteratureDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create literature store: %w", err)
+		}
+		databases["literature"] = cfg.LiteratureDBPath()
+		server.literatureStore = store
+	}
+
+	// Initialize in-house cohort store if not provided
+	if server.cohortStore == nil {
+		store, err := cohort.NewSQLiteStore(cfg.CohortDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cohort store: %w", err)
+		}
+		databases["cohort"] = cfg.CohortDBPath()
+		server.cohortStore = store
+	}
+	artifactCriteria := cohort.DefaultArtifactCriteria()
+	artifactCriteria.MinCohortSize = cfg.CohortMinSize
+	artifactCriteria.MinCarrierFraction = cfg.CohortArtifactFraction
+
+	// Initialize artifact blacklist store if not provided
+	if server.artifactStore == nil {
+		store, err := artifact.NewSQLiteStore(cfg.ArtifactsDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create artifact store: %w", err)
+		}
+		databases["artifacts"] = cfg.ArtifactsDBPath()
+		server.artifactStore = store
+	}
+
+	// Initialize known benign list store if not provided
+	if server.knownBenign == nil {
+		store, err := benign.NewSQLiteStore(cfg.KnownBenignDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create known benign store: %w", err)
+		}
+		databases["known_benign"] = cfg.KnownBenignDBPath()
+		server.knownBenign = store
+	}
+
+	// Initialize lab knowledge base store if not provided
+	if server.labKnowledge == nil {
+		store, err := labkb.NewSQLiteStore(cfg.LabKnowledgeDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create lab knowledge store: %w", err)
+		}
+		databases["lab_knowledge"] = cfg.LabKnowledgeDBPath()
+		server.labKnowledge = store
+	}
+
+	// Initialize webhook store if not provided
+	if server.webhookStore == nil {
+		store, err := webhook.NewSQLiteStore(cfg.WebhooksDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create webhook store: %w", err)
+		}
+		databases["webhooks"] = cfg.WebhooksDBPath()
+		server.webhookStore = store
+	}
+	server.webhooks = webhook.NewDispatcher(server.logger, server.webhookStore)
+
+	// Initialize classification job store if not provided
+	if server.jobStore == nil {
+		store, err := jobs.NewSQLiteStore(cfg.JobsDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create job store: %w", err)
+		}
+		databases["jobs"] = cfg.JobsDBPath()
+		server.jobStore = store
+	}
+
+	// Initialize guided classification session store if not provided
+	if server.sessionStore == nil {
+		store, err := wizard.NewSQLiteStore(cfg.ClassificationSessionsDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create classification session store: %w", err)
+		}
+		databases["classification_sessions"] = cfg.ClassificationSessionsDBPath()
+		server.sessionStore = store
+	}
+
+	// Initialize classification review store if not provided
+	if server.reviewStore == nil {
+		store, err := review.NewSQLiteStore(cfg.ReviewsDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create review store: %w", err)
+		}
+		databases["reviews"] = cfg.ReviewsDBPath()
+		server.reviewStore = store
+	}
+
+	// Initialize classification audit trail store if not provided
+	if server.auditStore == nil {
+		store, err := audit.NewSQLiteStore(cfg.AuditDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create audit store: %w", err)
+		}
+		databases["audit"] = cfg.AuditDBPath()
+		server.auditStore = store
+	}
+
+	// Initialize identifier mapping cache if not provided
+	if server.identifierStore == nil {
+		store, err := variantid.NewSQLiteStore(cfg.IdentifiersDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create identifier store: %w", err)
+		}
+		databases["identifiers"] = cfg.IdentifiersDBPath()
+		server.identifierStore = store
+	}
+
+	// Initialize rule threshold store if not provided
+	if server.thresholdStore == nil {
+		store, err := thresholds.NewSQLiteStore(cfg.ThresholdsDBPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create threshold store: %w", err)
+		}
+		databases["thresholds"] = cfg.ThresholdsDBPath()
+		server.thresholdStore = store
+	}
+
+	// Load clinical configuration from the config repository when configured;
+	// it then replaces the local specification, threshold, region and transcript set files
+	if server.configRepo == nil && cfg.ConfigRepoEnabled() {
+		syncer, err := configrepo.NewSyncer(configrepo.Options{
+			URL:                cfg.ConfigRepoURL,
+			Branch:             cfg.ConfigRepoBranch,
+			Dir:                cfg.ConfigRepoDir(),
+			Interval:           cfg.ConfigRepoInterval,
+			VerifySignatures:   cfg.ConfigRepoVerifySignatures,
+			AllowedSignersFile: cfg.ConfigRepoAllowedSigners,
+		}, server.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create config repository: %w", err)
+		}
+		if err := syncer.Start(context.Background()); err != nil {
+			return nil, fmt.Errorf("failed to load config repository: %w", err)
+		}
+		server.configRepo = syncer
+	}
+	if server.configRepo != nil {
+		if snapshot := server.configRepo.Current(); snapshot != nil {
+			server.specifications = snapshot.Specifications
+			server.frequencyOverrides = snapshot.FrequencyOverrides
+			server.regionTracks = snapshot.RegionTracks
+			server.transcriptSets = snapshot.TranscriptSets
+		}
+	}
+
+	// Load gene-specific VCEP rule specifications if not provided
+	if server.specifications == nil {
+		registry, err := vcep.LoadDir(cfg.SpecificationsDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load VCEP specifications: %w", err)
+		}
+		server.specifications = registry
+	}
+	server.logger.WithField("count", len(server.specifications.List())).Info("Loaded VCEP rule specifications")
+
+	// Load per-gene and per-condition frequency thresholds if not provided
+	if server.frequencyOverrides == nil {
+		overrides, err := thresholds.LoadFrequencyOverrides(cfg.FrequencyThresholdsPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load frequency thresholds: %w", err)
+		}
+		server.frequencyOverrides = overrides
+	}
+	server.logger.WithField("count", server.frequencyOverrides.Count()).Info("Loaded frequency threshold overrides")
+
+	// Load conflicting-criteria resolution policies if not provided
+	if server.conflictPolicies == nil {
+		policies, err := conflicts.Load(cfg.ConflictPoliciesPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load conflict policies: %w", err)
+		}
+		server.conflictPolicies = policies
+	}
+	server.logger.WithFields(logrus.Fields{
+		"default":    server.conflictPolicies.Default,
+		"configured": server.conflictPolicies.Configured(),
+	}).Info("Loaded conflict resolution policies")
+
+	// Load the laboratory's criterion weighting policy if not provided
+	if server.weightingPolicy == nil {
+		policy, err := weighting.Load(cfg.WeightingPolicyPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load weighting policy: %w", err)
+		}
+		server.weightingPolicy = policy
+	}
+	if server.weightingPolicy != nil {
+		server.logger.WithFields(logrus.Fields{
+			"policy":    server.weightingPolicy.Name,
+			"overrides": len(server.weightingPolicy.Overrides),
+		}).Info("Loaded criterion weighting policy")
+	}
+
+	// Load calibrated predictor intervals if not provided
+	if server.calibration == nil {
+		cal, err := calibration.Load(cfg.CalibrationPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load predictor calibration: %w", err)
+		}
+		server.calibration = cal
+	}
+	if server.calibration != nil {
+		server.logger.WithFields(logrus.Fields{
+			"calibration": server.calibration.Name,
+			"tables":      len(server.calibration.Tables),
+		}).Info("Loaded predictor calibration")
+	}
+
+	// Load problematic region tracks if not provided
+	if server.regionTracks == nil {
+		tracks, err := regions.LoadDir(cfg.RegionTracksDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load region tracks: %w", err)
+		}
+		server.regionTracks = tracks
+	}
+	server.logger.WithField("count", server.regionTracks.Count()).Info("Loaded problematic region tracks")
+
+	// Load CNV gene and dosage annotations if not provided
+	if server.cnvAnnotations == nil {
+		annotations, err := cnv.LoadDir(cfg.CNVAnnotationsDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CNV annotations: %w", err)
+		}
+		server.cnvAnnotations = annotations
+	}
+	server.logger.WithField("count", server.cnvAnnotations.Count()).Info("Loaded CNV annotations")
+
+	// Load star allele definitions and CPIC tables if not provided
+	if server.pgxKnowledge == nil {
+		knowledge, err := pgx.LoadDir(cfg.PGxTablesDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load pharmacogenomics tables: %w", err)
+		}
+		server.pgxKnowledge = knowledge
+	}
+	server.logger.WithField("count", server.pgxKnowledge.Count()).Info("Loaded pharmacogenomics tables")
+
+	// Load per-specialty transcript sets if not provided
+	if server.transcriptSets == nil {
+		registry, err := transcriptset.LoadDir(cfg.TranscriptSetsDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load transcript sets: %w", err)
+		}
+		server.transcriptSets = registry
+	}
+	server.logger.WithField("specialties", server.transcriptSets.Specialties()).Info("Loaded specialty transcript sets")
+
+	// Load protein domain and hotspot annotations for PM1 if not provided
+	if server.proteinDomains == nil {
+		registry, err := proteindomains.LoadDir(cfg.ProteinDomainsDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load protein domains: %w", err)
+		}
+		server.proteinDomains = registry
+	}
+	server.logger.WithField("count", server.proteinDomains.Count()).Info("Loaded protein domain annotations")
+
+	// Load the HPO ontology and gene annotations for PP4 if not provided
+	if server.phenotypes == nil {
+		matcher, err := phenotype.LoadDir(cfg.HPODir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load HPO annotations: %w", err)
+		}
+		server.phenotypes = matcher
+	}
+	server.logger.WithField("count", server.phenotypes.Count()).Info("Loaded HPO gene annotations")
+
+	// Authenticate HTTP and admin API clients by API key or JWT
+	authenticator, err := newAuthenticator(domain.AuthConfig{
+		APIKeys:       cfg.AuthAPIKeys,
+		JWTSecret:     cfg.AuthJWTSecret,
+		JWTIssuer:     cfg.AuthJWTIssuer,
+		JWTAudience:   cfg.AuthJWTAudience,
+		AnonymousRole: cfg.AuthAnonymousRole,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid authentication configuration: %w", err)
+	}
+
+	// Create the threshold admin API when configured
+	if cfg.AdminEnabled() {
+		adminServer, err := admin.NewServer(server.logger, server.thresholdStore, cfg.AdminToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create admin API: %w", err)
+		}
+		adminServer.SetAuthenticator(authenticator)
+		server.adminServer = adminServer
+	}
+
+	// Build the weekly review digest from the feedback, snapshot and threshold stores
+	server.digestGenerator = digest.NewGenerator(server.logger, server.feedbackStore)
+	server.digestGenerator.SetSnapshotStore(server.snapshotStore)
+	server.digestGenerator.SetThresholdStore(server.thresholdStore)
+	if cfg.DigestSlackWebhook != "" {
+		server.digestNotifiers = append(server.digestNotifiers, digest.NewSlackNotifier(cfg.DigestSlackWebhook))
+	}
+	if cfg.DigestEmailEnabled() {
+		server.digestNotifiers = append(server.digestNotifiers, digest.NewEmailNotifier(
+			cfg.DigestSMTPAddr, cfg.DigestSMTPUser, cfg.DigestSMTPPassword, cfg.DigestEmailFrom, cfg.DigestEmailTo))
+	}
+
+	// Check the literature cited by signed-out classifications against PubMed,
+	// raising follow-up flags for classifications citing retracted articles
+	pubMed := external.NewPubMedClient(external.PubMedConfig{
+		BaseURL: "https://eutils.ncbi.nlm.nih.gov/entrez/eutils/",
+		APIKey:  cfg.ClinVarAPIKey, // PubMed uses same NCBI API key
+		Timeout: 60 * time.Second,
+	})
+	server.literatureMonitor = literature.NewMonitor(server.logger, server.literatureStore, pubMed, server.feedbackStore, server.snapshotStore)
+	server.literatureMonitor.SetFollowUpStore(server.followUpStore)
+
+	// Re-evaluate stored variants with fresh evidence, in-process through
+	// classify_variant, on the configured schedule or on demand
+	server.refresher = surveillance.NewRefresher(server.logger, server, server.auditStore)
+	server.refresher.SetLabKnowledgeBase(server.labKnowledge)
+	server.refresher.SetFollowUpStore(server.followUpStore)
+	server.refresher.SetReportDir(cfg.SurveillanceReportDir())
+	server.refresher.SetMaxVariants(cfg.SurveillanceMaxVariants)
+	if cfg.SurveillanceSchedule != "" {
+		schedule, err := surveillance.ParseSchedule(cfg.SurveillanceSchedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid surveillance schedule: %w", err)
+		}
+		server.refreshSchedule = schedule
+	}
+
+	// Classify submitted jobs in the background, in-process through
+	// classify_variants_batch, a batch-sized chunk at a time
+	server.jobRunner = jobs.NewRunner(server.logger, server.jobStore, server, cfg.JobWorkers)
+	server.jobRunner.SetChunkSize(min(jobs.DefaultChunkSize, cfg.BatchClassifyLimit))
+
+	// Variant tables, uploaded or submitted as jobs, are read on the configured assembly
+	tableAssembly, err := hgvs.ParseAssembly(cfg.GenomeAssembly)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACMG_GENOME_ASSEMBLY: %w", err)
+	}
+
 	// Create MCP configuration for transport
 	mcpConfig := &domain.MCPConfig{
-		TransportType: cfg.Transport,
-		HTTPPort:      cfg.HTTPPort,
+		TransportType:         cfg.Transport,
+		HTTPPort:              cfg.HTTPPort,
+		RateLimitRPS:          cfg.RateLimitRPS,
+		RateLimitBurst:        cfg.RateLimitBurst,
+		DailyQuota:            cfg.DailyQuota,
+		APIKeys:               cfg.APIKeys,
+		MaxMessageBytes:       cfg.MaxMessageBytes,
+		WebSocketPingInterval: cfg.WebSocketPingInterval,
+		WebSocketIdleTimeout:  cfg.WebSocketIdleTimeout,
 	}
 
 	// Create transport manager and message router
 	transportMgr := transport.NewManager(server.logger, mcpConfig)
+	transportMgr.SetAuthenticator(authenticator, tools.RequiredRole)
+	if server.adminServer != nil && transportMgr.RateLimiter() != nil {
+		server.adminServer.SetRateLimiter(transportMgr.RateLimiter())
+	}
 	router := protocol.NewMessageRouter(server.logger)
 
 	// Create external services for evidence gathering (no Redis cache)
@@ -114,6 +924,161 @@ func NewLiteServer(cfg *litecfg.LiteConfig, opts ...LiteServerOption) (*LiteServ
 	if err != nil {
 		return nil, fmt.Errorf("failed to create knowledge base service: %w", err)
 	}
+	knowledgeBaseService.SetSourceDeadlines(cfg.EvidenceSourceDeadline, cfg.EvidenceSourceDeadlines)
+
+	// Look up ClinVar in a local release when one has been set up, so
+	// classification makes no calls to NCBI for ClinVar
+	clinVarURL := clinVarEUtilsURL
+	if path := cfg.ClinVarDBPath(); pathExists(path) {
+		localClinVar, err := external.OpenLocalClinVar(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open local ClinVar: %w", err)
+		}
+		server.localClinVar = localClinVar
+		knowledgeBaseService.SetClinVarClient(localClinVar)
+		clinVarURL = ""
+		release := localClinVar.Release()
+		server.logger.WithFields(logrus.Fields{
+			"variants":    release.Variants,
+			"assembly":    release.Assembly,
+			"imported_at": release.ImportedAt,
+		}).Info("Using local ClinVar release")
+	}
+
+	// Look up allele frequencies in a local gnomAD copy when one has been
+	// set up, so BA1, BS1 and PM2 make no calls to the gnomAD API
+	gnomADURL := gnomADAPIURL
+	if path := cfg.GnomADPath(); pathExists(path) {
+		annotator, err := external.NewGnomADAnnotator(path, external.GnomADDatasetV4)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open local gnomAD: %w", err)
+		}
+		server.localGnomAD = annotator
+		knowledgeBaseService.SetPopulationFrequencyClient(annotator)
+		gnomADURL = ""
+		server.logger.WithField("path", path).Info("Using local gnomAD frequencies")
+	}
+
+	registerReadinessProbes(readiness.Default, clinVarURL, gnomADURL, databases, knowledgeBaseService)
+	if server.localClinVar != nil {
+		readiness.Default.Register("clinvar", false, server.localClinVar.Ping)
+	}
+	if server.localGnomAD != nil {
+		readiness.Default.Register("gnomad", false, server.localGnomAD.Ping)
+	}
+
+	// Enable SpliceAI/Pangolin predictions when configured or provided
+	if server.splicingPredictor == nil && cfg.SplicingEnabled() {
+		predictor, err := external.NewSplicingPredictor(domain.SplicingConfig{
+			BaseURL:    cfg.SplicingLookupURL,
+			ScoresFile: cfg.SplicingScoresFile,
+			Timeout:    60 * time.Second,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create splicing predictor: %w", err)
+		}
+		server.splicingPredictor = predictor
+	}
+	if server.splicingPredictor != nil {
+		knowledgeBaseService.SetSplicingPredictor(server.splicingPredictor)
+		server.logger.Info("Enabled SpliceAI/Pangolin splicing predictions")
+	}
+
+	// Look up in silico scores in a local dbNSFP copy when one has been set up
+	if server.computationalPredictor == nil {
+		if path := cfg.DbNSFPPath(); pathExists(path) {
+			annotator, err := external.NewDbNSFPAnnotator(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open dbNSFP: %w", err)
+			}
+			server.computationalPredictor = annotator
+		}
+	}
+	if server.computationalPredictor != nil {
+		knowledgeBaseService.SetComputationalPredictor(server.computationalPredictor)
+		server.logger.Info("Enabled dbNSFP in silico scores")
+	}
+
+	// Record the dataset versions evidence is drawn from and hold them to
+	// the configured pins, so pinned deployments classify on the same data
+	server.datasetVersions = provenance.NewRegistry(cfg.DatasetPins)
+	if server.localClinVar != nil {
+		server.datasetVersions.Record(provenance.ClinVar, server.localClinVar.Release().Version())
+	} else {
+		server.datasetVersions.Record(provenance.ClinVar, provenance.Queried(""))
+	}
+	if server.localGnomAD != nil {
+		server.datasetVersions.Record(provenance.GnomAD, server.localGnomAD.Dataset())
+	} else {
+		server.datasetVersions.Record(provenance.GnomAD, provenance.Queried(external.GnomADDatasetV4))
+	}
+	if dbNSFP, ok := server.computationalPredictor.(*external.DbNSFPAnnotator); ok {
+		server.datasetVersions.Record(provenance.DbNSFP, dbNSFP.Version())
+	}
+	if err := server.datasetVersions.Check(); err != nil {
+		return nil, fmt.Errorf("dataset versions do not match ACMG_DATASET_PINS: %w", err)
+	}
+	server.logger.WithField("dataset_versions", server.datasetVersions.DatasetVersions().String()).Info("Recorded dataset versions")
+
+	// Find pathogenic ClinVar variants at the same residue for PS1 and PM5
+	if server.residueLookup == nil && server.localClinVar != nil {
+		server.residueLookup = server.localClinVar
+	}
+	if server.residueLookup == nil {
+		server.residueLookup = external.NewClinVarResidueLookup(domain.ClinVarConfig{
+			BaseURL:   "https://eutils.ncbi.nlm.nih.gov/entrez/eutils/",
+			RateLimit: 3,
+			Timeout:   30 * time.Second,
+			APIKey:    cfg.ClinVarAPIKey,
+		}, external.DefaultResidueMinStars)
+	}
+	knowledgeBaseService.SetResidueLookup(server.residueLookup)
+
+	// Look up ClinGen gene-disease validity and dosage curations for PVS1
+	if server.geneCurations == nil {
+		server.geneCurations = external.NewClinGenClient(domain.ClinGenConfig{
+			BaseURL:   "https://genegraph.clinicalgenome.org/api",
+			RateLimit: 5,
+			Timeout:   30 * time.Second,
+		})
+	}
+	knowledgeBaseService.SetGeneCurationClient(server.geneCurations)
+
+	// Look up gnomAD gene constraint for PVS1, PP2 and BP1 when a table is present
+	if server.geneConstraint == nil {
+		if path := cfg.ConstraintPath(); pathExists(path) {
+			table, err := external.LoadConstraintTable(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load gene constraint: %w", err)
+			}
+			server.geneConstraint = table
+			server.logger.WithField("count", table.Len()).Info("Loaded " + table.Source() + " gene constraint")
+		}
+	}
+	if server.geneConstraint != nil {
+		knowledgeBaseService.SetConstraintClient(server.geneConstraint)
+	}
+
+	// Gene-disease associations for the /genes/{symbol}/diseases resource
+	if server.omim == nil && cfg.OMIMAPIKey != "" {
+		server.omim = external.NewOMIMClient(domain.OMIMConfig{
+			BaseURL:   "https://api.omim.org/api",
+			APIKey:    cfg.OMIMAPIKey,
+			RateLimit: 4,
+			Timeout:   30 * time.Second,
+		})
+	}
+	if server.orphanet == nil {
+		data, err := external.LoadOrphanetDir(cfg.OrphanetPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Orphanet data: %w", err)
+		}
+		server.orphanet = data
+	}
+	server.logger.WithField("count", server.orphanet.Count()).Info("Loaded Orphanet gene-disease associations")
+
+	// Mark citations of retracted or corrected articles in gathered evidence
+	knowledgeBaseService.SetCitationNotices(server.literatureStore)
 
 	// Create input parser for HGVS notation
 	inputParser := domain.NewStandardInputParser()
@@ -131,18 +1096,214 @@ func NewLiteServer(cfg *litecfg.LiteConfig, opts ...LiteServerOption) (*LiteServ
 
 	// Create classifier service
 	classifierService := service.NewClassifierService(server.logger, knowledgeBaseService, inputParser, transcriptResolver)
+	if server.configRepo != nil {
+		// Read the config repository's current snapshot on each lookup so reloads apply live
+		classifierService.SetThresholdSource(server.configRepo.Thresholds(server.thresholdStore))
+		classifierService.SetSpecificationSource(server.configRepo.Specifications())
+		classifierService.SetFrequencyOverrides(server.configRepo.FrequencyOverrides())
+		classifierService.SetRegionSource(server.configRepo.RegionTracks())
+		classifierService.SetTranscriptSetSource(server.configRepo.TranscriptSets())
+		classifierService.SetConfigVersionSource(server.configRepo)
+	} else {
+		classifierService.SetThresholdSource(server.thresholdStore)
+		classifierService.SetSpecificationSource(server.specifications)
+		classifierService.SetFrequencyOverrides(server.frequencyOverrides)
+		classifierService.SetRegionSource(server.regionTracks)
+		classifierService.SetTranscriptSetSource(server.transcriptSets)
+	}
+	classifierService.SetDomainSource(server.proteinDomains)
+	classifierService.SetPhenotypeSource(server.phenotypes)
+	classifierService.SetLabKnowledgeSource(server.labKnowledge)
+	classifierService.SetConflictPolicies(server.conflictPolicies)
+	classifierService.SetWeightingPolicy(server.weightingPolicy)
+	classifierService.SetCalibration(server.calibration)
+	classifierService.SetDatasetVersionSource(server.datasetVersions)
+
+	// Memoize classification results; each surveillance run drops them
+	if cfg.ResultCacheSize > 0 {
+		resultCache, err := service.NewResultCache(cfg.ResultCacheSize, cfg.ResultCacheTTL)
+		if err != nil {
+			return nil, err
+		}
+		classifierService.SetResultCache(resultCache)
+		server.refresher.SetResultCache(resultCache)
+	}
+
+	// Normalize HGVS notations when a reference genome has been set up
+	if server.normalizer == nil && pathExists(cfg.ReferenceFastaPath()) {
+		normalizer, err := createLiteNormalizer(cfg, server.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HGVS normalizer: %w", err)
+		}
+		server.normalizer = normalizer
+	}
+	if server.normalizer != nil {
+		classifierService.SetNormalizer(server.normalizer)
+	}
+
+	// Compute GA4GH VRS identifiers of normalized variants on the same reference genome
+	if pathExists(cfg.ReferenceFastaPath()) {
+		reference, err := hgvs.OpenIndexedFasta(cfg.ReferenceFastaPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to open reference genome for VRS identifiers: %w", err)
+		}
+		server.vrsReference = reference
+		classifierService.SetVRSTranslator(vrs.NewTranslator(reference))
+	}
+
+	// Annotate transcript consequences with Ensembl VEP when selected; the
+	// normalizer's own annotation is the fallback
+	if server.consequenceAnnotator == nil && cfg.VEPEnabled() {
+		server.consequenceAnnotator = external.NewVEPClient(domain.VEPConfig{
+			BaseURL:     cfg.VEPURL,
+			Transcripts: cfg.VEPTranscripts,
+			Plugins:     cfg.VEPPlugins,
+		})
+		server.logger.WithFields(logrus.Fields{"url": cfg.VEPURL, "transcripts": cfg.VEPTranscripts}).Info("Annotating transcript consequences with Ensembl VEP")
+	}
+	if server.consequenceAnnotator != nil {
+		classifierService.SetConsequenceAnnotator(server.consequenceAnnotator)
+	}
+
+	// Query OncoKB and CIViC for somatic tiering
+	somaticSources := createSomaticEvidenceSources(cfg)
+	classifierService.SetSomaticEvidenceSources(somaticSources...)
+
+	scoringMode, err := service.ParseScoringMode(cfg.ScoringMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACMG_SCORING_MODE: %w", err)
+	}
+	classifierService.SetScoringMode(scoringMode)
+
+	locale, err := i18n.ParseLocale(cfg.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACMG_LOCALE: %w", err)
+	}
 
 	// Create tool registry and register tools
 	toolRegistry := tools.NewToolRegistry(server.logger, router, classifierService)
+	toolRegistry.SetSnapshotStore(server.snapshotStore)
+	toolRegistry.SetEvidenceBundles(cfg.EvidenceBundles)
+	toolRegistry.SetPlaybookStore(server.playbookStore)
+	toolRegistry.SetFollowUpStore(server.followUpStore)
+	toolRegistry.SetCohortStore(server.cohortStore, artifactCriteria)
+	toolRegistry.SetArtifactStore(server.artifactStore)
+	toolRegistry.SetKnownBenignStore(server.knownBenign)
+	toolRegistry.SetAuditStore(server.auditStore)
+	toolRegistry.SetIdentifierResolver(variantid.NewResolver(server.identifierLookup(cfg), server.identifierStore, server.logger))
+	toolRegistry.SetBatchClassificationLimits(cfg.BatchClassifyLimit, cfg.BatchClassifyWorkers)
+	toolRegistry.SetWebhookPublisher(server.webhooks)
+	toolRegistry.SetClassificationSessionStore(server.sessionStore)
+	toolRegistry.SetReviewStore(server.reviewStore, cfg.RequireReview)
+	toolRegistry.SetAuthenticationRequired(authenticator.Enabled())
+	toolRegistry.SetReportExportDir(cfg.ExportDir())
+	toolRegistry.SetLocale(locale)
 	if err := toolRegistry.RegisterAllTools(); err != nil {
 		return nil, fmt.Errorf("failed to register tools: %w", err)
 	}
 
+	// Track transport calls so a shutdown drains them
+	server.shutdown = shutdown.NewCoordinator()
+	toolRegistry.SetShutdownCoordinator(server.shutdown)
+
+	// Limit response sizes per transport
+	toolRegistry.SetResponseLimiter(tools.NewResponseLimiter(server.logger, map[string]int{
+		tools.TransportStdio:     cfg.MaxResponseBytesStdio,
+		tools.TransportHTTPSSE:   cfg.MaxResponseBytesHTTP,
+		tools.TransportWebSocket: cfg.MaxResponseBytesHTTP,
+	}))
+
 	// Register feedback tools
-	if err := registerFeedbackTools(toolRegistry, server.logger, server.feedbackStore, cfg.ExportDir()); err != nil {
+	if err := registerFeedbackTools(toolRegistry, server.logger, server.feedbackStore, server.followUpStore, cfg.ExportDir()); err != nil {
 		return nil, fmt.Errorf("failed to register feedback tools: %w", err)
 	}
 
+	// Register follow-up flag tools
+	if err := registerFollowUpTools(toolRegistry, server.logger, server.followUpStore); err != nil {
+		return nil, fmt.Errorf("failed to register follow-up tools: %w", err)
+	}
+
+	// Register playbook tools
+	if err := registerPlaybookTools(toolRegistry, server.logger, server.playbookStore, authenticator.Enabled()); err != nil {
+		return nil, fmt.Errorf("failed to register playbook tools: %w", err)
+	}
+
+	// Register in-house cohort tools
+	caseMatcher := matchmaker.NewMatchmaker(server.cohortStore, server.phenotypes)
+	if err := registerCohortTools(toolRegistry, server.logger, server.cohortStore, artifactCriteria, caseMatcher); err != nil {
+		return nil, fmt.Errorf("failed to register cohort tools: %w", err)
+	}
+
+	// Register artifact blacklist tools
+	if err := registerArtifactTools(toolRegistry, server.logger, server.artifactStore, cfg.ExportDir()); err != nil {
+		return nil, fmt.Errorf("failed to register artifact tools: %w", err)
+	}
+
+	// Register known benign list tools
+	if err := registerKnownBenignTools(toolRegistry, server.logger, server.knownBenign); err != nil {
+		return nil, fmt.Errorf("failed to register known benign tools: %w", err)
+	}
+
+	// Register lab knowledge base tools
+	if err := registerLabKnowledgeTools(toolRegistry, server.logger, server.labKnowledge, server.auditStore); err != nil {
+		return nil, fmt.Errorf("failed to register lab knowledge tools: %w", err)
+	}
+
+	// Register webhook tools
+	if err := registerWebhookTools(toolRegistry, server.logger, server.webhookStore, server.webhooks); err != nil {
+		return nil, fmt.Errorf("failed to register webhook tools: %w", err)
+	}
+
+	// Register background classification job tools
+	if err := registerJobTools(toolRegistry, server.logger, server.jobRunner, server.jobStore, cfg.JobMaxVariants, tableAssembly); err != nil {
+		return nil, fmt.Errorf("failed to register job tools: %w", err)
+	}
+
+	// Register classification audit trail tools, signing export bundles when a key is configured
+	var auditSigner *audit.Signer
+	if cfg.AuditSigningKey != "" {
+		if auditSigner, err = audit.NewSigner(cfg.AuditSigningKey); err != nil {
+			return nil, fmt.Errorf("invalid ACMG_AUDIT_SIGNING_KEY: %w", err)
+		}
+		server.logger.WithField("key_id", audit.KeyID(auditSigner.PublicKey())).Info("Signing audit export bundles")
+	}
+	if err := registerAuditTools(toolRegistry, server.logger, server.auditStore, auditSigner, server.redactor); err != nil {
+		return nil, fmt.Errorf("failed to register audit tools: %w", err)
+	}
+
+	// Register weekly digest tools
+	if err := registerDigestTools(toolRegistry, server.logger, server.digestGenerator); err != nil {
+		return nil, fmt.Errorf("failed to register digest tools: %w", err)
+	}
+
+	// Register literature retraction tools
+	if err := registerLiteratureTools(toolRegistry, server.logger, server.literatureMonitor, server.literatureStore); err != nil {
+		return nil, fmt.Errorf("failed to register literature tools: %w", err)
+	}
+
+	// Register evidence surveillance tools
+	if err := registerSurveillanceTools(toolRegistry, server.logger, server.refresher); err != nil {
+		return nil, fmt.Errorf("failed to register surveillance tools: %w", err)
+	}
+
+	// Serve the prompt templates, including case review from the audit trail
+	registerPrompts(router, server.logger, server.auditStore, locale)
+
+	// Register circuit breaker override tools
+	if err := registerCircuitBreakerTools(toolRegistry, server.logger, external.CircuitBreakers); err != nil {
+		return nil, fmt.Errorf("failed to register circuit breaker tools: %w", err)
+	}
+
+	// Register CNV classification tool
+	if err := registerCNVTools(toolRegistry, server.logger, server.cnvAnnotations); err != nil {
+		return nil, fmt.Errorf("failed to register CNV tools: %w", err)
+	}
+
+	// Register pharmacogenomic annotation tool
+	if err := registerPGxTools(toolRegistry, server.logger, server.pgxKnowledge); err != nil {
+		return nil, fmt.Errorf("failed to register pharmacogenomics tools: %w", err)
+	}
+
 	// Validate all tools
 	if err := toolRegistry.ValidateAllTools(); err != nil {
 		return nil, fmt.Errorf("tool validation failed: %w", err)
@@ -156,17 +1317,114 @@ func NewLiteServer(cfg *litecfg.LiteConfig, opts ...LiteServerOption) (*LiteServ
 
 	// Create MCP server
 	mcpServer := mcp.NewServer(serverInfo, nil)
+	// Page and filter resources/list
+	mcpServer.AddReceivingMiddleware(listResourcesMiddleware)
+
 
 	// Complete server setup
 	server.mcpServer = mcpServer
 	server.transportMgr = transportMgr
 	server.toolRegistry = toolRegistry
 
+	// Classify uploaded variant tables on the HTTP and WebSocket transports
+	transportMgr.AddRoute(transport.Route{
+		Method:  http.MethodPost,
+		Path:    "/api/v1/classify/table",
+		Role:    tools.RequiredRole("classify_variants_batch"),
+		Handler: server.shutdown.Handler(bulk.Handler(server.logger, server, tableAssembly, bulk.Options{ChunkSize: cfg.BatchClassifyLimit})),
+		Summary: "Classify an uploaded VCF or TSV variant table",
+	})
+
+	// Match cases against the in-house cohort
+	transportMgr.AddRoute(transport.Route{
+		Method:  http.MethodPost,
+		Path:     matchmaker.Path,
+		Role:     tools.RequiredRole("match_internal_cases"),
+		Handler:  matchmaker.NewHandler(server.logger, caseMatcher),
+		Summary:  "Match a case against internal cases by gene and phenotype",
+		Request:  matchmaker.MatchRequest{},
+		Response: matchmaker.MatchResponse{},
+	})
+
+	// Serve evidence and stored classifications over GraphQL
+	graphqlHandler, err := graphqlapi.NewHandler(server.logger, server, server.auditStore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GraphQL API: %w", err)
+	}
+	transportMgr.AddRoute(transport.Route{
+		Method:   http.MethodPost,
+		Path:     graphqlapi.Path,
+		Role:     tools.RequiredRole("query_evidence"),
+		Handler:  graphqlHandler,
+		Summary:  "Query evidence and stored classifications with GraphQL",
+		Request:  graphqlapi.Request{},
+		Response: graphqlapi.Response{},
+	})
+
+	// Answer GA4GH Beacon v2 queries from the lab knowledge base
+	if cfg.BeaconEnabled {
+		tiers, err := beacon.ParseTiers(cfg.BeaconGranularity, cfg.BeaconRoleGranularity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ACMG_BEACON_GRANULARITY or ACMG_BEACON_ROLE_GRANULARITY: %w", err)
+		}
+		beaconConfig := beacon.Config{
+			ID:           cfg.BeaconID,
+			Name:         cfg.BeaconName,
+			Organization: cfg.BeaconOrganization,
+			Assembly:     tableAssembly,
+			Version:      serverInfo.Version,
+			Tiers:        tiers,
+		}
+		beaconHandler := beacon.NewHandler(server.logger, server.labKnowledge, beaconConfig)
+		for _, endpoint := range beacon.Endpoints {
+			transportMgr.AddRoute(transport.Route{
+				Method:   endpoint.Method,
+				Path:     endpoint.Path,
+				Role:     auth.RoleReadOnly,
+				Public:   beaconConfig.Public(),
+				Handler:  beaconHandler,
+				Summary:  endpoint.Summary,
+				Response: endpoint.Response,
+			})
+		}
+		server.logger.WithFields(logrus.Fields{
+			"beacon_id": cfg.BeaconID,
+			"public":    beaconConfig.Public(),
+		}).Info("Serving GA4GH Beacon v2 API")
+	}
+
+	// Serve classify, validate and evidence calls over gRPC for pipelines
+	if cfg.GRPCAddr != "" {
+		grpcServer, err := grpcapi.NewServer(server.logger, server, authenticator)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gRPC API: %w", err)
+		}
+		grpcServer.SetRateLimiter(transportMgr.RateLimiter())
+		grpcServer.SetShutdown(server.shutdown)
+		server.grpcServer = grpcServer
+	}
+
 	// Register MCP tools
 	if err := server.registerMCPTools(mcpServer, toolRegistry); err != nil {
 		return nil, fmt.Errorf("failed to register MCP tools: %w", err)
 	}
 
+	// Register MCP resources
+	var orphanet external.GeneDiseaseClient
+	if server.orphanet.Count() > 0 {
+		orphanet = server.orphanet
+	}
+	registerDiseaseResources(mcpServer, server.logger, server.omim, orphanet)
+	registerGeneSummaryResource(mcpServer, server.logger, server.auditStore)
+	registerPlaybookResource(mcpServer, server.logger, server.playbookStore)
+	registerAuditResource(mcpServer, server.logger, server.auditStore)
+	registerEvidenceResources(mcpServer, server.logger, knowledgeBaseService, somaticSources, cfg.EvidenceMockFallback)
+	registerCircuitBreakerResource(mcpServer, server.logger, external.CircuitBreakers)
+	registerCacheStatsResource(mcpServer, server.logger, knowledgeBaseService)
+	registerSpecificationResources(mcpServer, server.logger, server.specifications)
+	registerTranscriptSetResources(mcpServer, server.logger, server.transcriptSets)
+	registerDigestResources(mcpServer, server.logger, server.digestGenerator)
+
 	server.logger.Info("Lite server initialized successfully")
 	return server, nil
 }
@@ -178,9 +1436,14 @@ func (s *LiteServer) registerMCPTools(mcpServer *mcp.Server, toolRegistry *tools
 	toolsInfo := toolRegistry.GetRegisteredToolsInfo()
 
 	for _, toolInfo := range toolsInfo {
+		inputSchema, err := toolInputSchema(toolInfo)
+		if err != nil {
+			return err
+		}
 		toolDef := &mcp.Tool{
 			Name:        toolInfo.Name,
 			Description: toolInfo.Description,
+			InputSchema: inputSchema,
 		}
 
 		handler := NewMCPToolHandler(toolRegistry, toolInfo.Name, s.logger)
@@ -204,8 +1467,71 @@ func (s *LiteServer) Start(ctx context.Context) error {
 	}
 
 	s.activeTransport = activeTransport
+	s.toolRegistry.SetActiveTransport(activeTransport.GetType())
 	s.logger.WithField("transport_type", activeTransport.GetType()).Info("Transport initialized")
 
+	// Serve the threshold admin API alongside the MCP transport
+	if s.adminServer != nil {
+		if err := s.adminServer.Start(s.config.AdminAddr); err != nil {
+			return fmt.Errorf("failed to start admin API: %w", err)
+		}
+	}
+
+	// Serve the gRPC API on its own address
+	if s.grpcServer != nil {
+		if err := s.grpcServer.Start(s.config.GRPCAddr); err != nil {
+			return fmt.Errorf("failed to start gRPC API: %w", err)
+		}
+	}
+
+	// Serve Prometheus metrics on their own address
+	if s.config.MetricsAddr != "" {
+		metrics.Serve(ctx, s.config.MetricsAddr, s.logger)
+	}
+
+	// Probe dependencies for /healthz and /readyz
+	readiness.Default.Run(ctx, s.logger)
+
+	// Export traces of tool calls to an OpenTelemetry collector
+	if s.config.TracingEndpoint != "" {
+		if err := tracing.Install(ctx, tracing.Config{
+			Endpoint:    s.config.TracingEndpoint,
+			ServiceName: "acmg-amp-mcp-server-lite",
+			SampleRatio: s.config.TraceSampleRatio,
+		}, s.logger); err != nil {
+			s.logger.WithError(err).Warn("Failed to install tracing")
+		}
+	}
+
+	// Move old evidence snapshots to the archive tier in the background
+	go s.runArchiver(ctx)
+
+	// Send the weekly digest to any configured channels
+	go s.runDigest(ctx)
+
+	// Check cited literature for retractions and errata
+	go s.runLiteratureCheck(ctx)
+
+	// Re-evaluate stored variants with fresh evidence
+	go s.runSurveillance(ctx)
+
+	// Classify queued jobs, resuming any a previous run left unfinished
+	s.jobRunner.Start(ctx)
+
+	// Reload clinical configuration as the config repository changes
+	if s.configRepo != nil {
+		go s.configRepo.Run(ctx)
+	}
+
+	// Session transports run one MCP session per connection
+	if sessions, ok := activeTransport.(transport.SessionTransport); ok {
+		if err := serveSessions(ctx, s.mcpServer, sessions, s.logger); err != nil {
+			s.activeTransport.Close()
+			return fmt.Errorf("MCP server failed: %w", err)
+		}
+		return nil
+	}
+
 	// Create bridge between transport and MCP SDK
 	mcpTransport := NewMCPTransportBridge(activeTransport, s.logger)
 
@@ -218,42 +1544,397 @@ func (s *LiteServer) Start(ctx context.Context) error {
 	return nil
 }
 
+// runArchiver periodically archives evidence snapshots older than the configured age.
+func (s *LiteServer) runArchiver(ctx context.Context) {
+	if s.snapshotStore == nil || s.config.ArchiveAfter <= 0 || s.config.ArchiveInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.ArchiveInterval)
+	defer ticker.Stop()
+
+	for {
+		cutoff := time.Now().Add(-s.config.ArchiveAfter)
+		archived, err := s.snapshotStore.ArchiveOlderThan(ctx, cutoff)
+		if err != nil && ctx.Err() == nil {
+			s.logger.WithError(err).Error("Failed to archive evidence snapshots")
+		} else if archived > 0 {
+			s.logger.WithField("archived", archived).Info("Archived old evidence snapshots")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runLiteratureCheck periodically checks cited literature for retractions and errata.
+func (s *LiteServer) runLiteratureCheck(ctx context.Context) {
+	if s.literatureMonitor == nil || s.config.LiteratureCheckInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.LiteratureCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := s.literatureMonitor.Check(ctx); err != nil && ctx.Err() == nil {
+			s.logger.WithError(err).Error("Failed to check cited literature")
+		}
+	}
+}
+
+// runSurveillance re-evaluates stored variants at each time the configured schedule matches.
+func (s *LiteServer) runSurveillance(ctx context.Context) {
+	if s.refresher == nil || s.refreshSchedule == nil {
+		return
+	}
+
+	for {
+		next := s.refreshSchedule.Next(time.Now())
+		if next.IsZero() {
+			s.logger.WithField("schedule", s.refreshSchedule.String()).Warn("Surveillance schedule never runs")
+			return
+		}
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		report, err := s.refresher.Run(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.WithError(err).Error("Failed to re-evaluate stored variants")
+			}
+			continue
+		}
+		s.logger.WithField("report", report.Path).Info("Wrote reclassification report")
+	}
+}
+
+// runDigest sends the previous week's digest at the configured weekday and hour.
+func (s *LiteServer) runDigest(ctx context.Context) {
+	if len(s.digestNotifiers) == 0 {
+		return
+	}
+
+	for {
+		next := digest.NextRun(time.Now(), s.config.DigestWeekday, s.config.DigestHour)
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		weekStart := digest.WeekStart(next).Add(-digest.Week)
+		d, err := s.digestGenerator.Generate(ctx, weekStart)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to generate weekly digest")
+			continue
+		}
+		for _, notifier := range s.digestNotifiers {
+			if err := notifier.Notify(ctx, d); err != nil {
+				s.logger.WithError(err).Error("Failed to send weekly digest")
+			}
+		}
+		s.logger.WithField("week_start", weekStart.Format("2006-01-02")).Info("Sent weekly digest")
+	}
+}
+
+// Shutdown refuses new tool calls and table uploads, reports the instance
+// unready, and waits until ctx is done for calls in flight to finish and for
+// running jobs to checkpoint after their current chunk. Calls still running
+// at the deadline are cancelled. Cancel the context passed to Start
+// afterwards to stop the transport and background tasks.
+func (s *LiteServer) Shutdown(ctx context.Context) shutdown.Report {
+	readiness.Default.SetDraining()
+	s.logger.WithField("in_flight", s.shutdown.InFlight()).Info("Draining in-flight requests")
+
+	// Jobs checkpoint while calls drain, within the same deadline
+	checkpointed := make(chan int, 1)
+	go func() {
+		checkpointed <- s.jobRunner.Shutdown(ctx)
+	}()
+	report := s.shutdown.Drain(ctx)
+	report.CheckpointedJobs = <-checkpointed
+
+	s.logger.WithFields(logrus.Fields{
+		"drained":           report.Drained,
+		"aborted":           report.Aborted,
+		"checkpointed_jobs": report.CheckpointedJobs,
+	}).Info("Shutdown drain complete")
+	return report
+}
+
 // Close cleans up server resources.
 func (s *LiteServer) Close() error {
+	if s.adminServer != nil {
+		if err := s.adminServer.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to stop admin API")
+		}
+	}
+	if s.grpcServer != nil {
+		s.grpcServer.Close()
+	}
 	if s.feedbackStore != nil {
 		if err := s.feedbackStore.Close(); err != nil {
 			s.logger.WithError(err).Error("Failed to close feedback store")
 		}
 	}
+	if s.snapshotStore != nil {
+		if err := s.snapshotStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close snapshot store")
+		}
+	}
+	if s.playbookStore != nil {
+		if err := s.playbookStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close playbook store")
+		}
+	}
+	if s.followUpStore != nil {
+		if err := s.followUpStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close follow-up store")
+		}
+	}
+	if s.literatureStore != nil {
+		if err := s.literatureStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close literature store")
+		}
+	}
+	if s.cohortStore != nil {
+		if err := s.cohortStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close cohort store")
+		}
+	}
+	if s.artifactStore != nil {
+		if err := s.artifactStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close artifact store")
+		}
+	}
+	if s.knownBenign != nil {
+		if err := s.knownBenign.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close known benign store")
+		}
+	}
+	if s.labKnowledge != nil {
+		if err := s.labKnowledge.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close lab knowledge store")
+		}
+	}
+	if s.webhooks != nil {
+		s.webhooks.Wait()
+	}
+	if s.webhookStore != nil {
+		if err := s.webhookStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close webhook store")
+		}
+	}
+	if s.jobRunner != nil {
+		s.jobRunner.Wait()
+	}
+	if s.jobStore != nil {
+		if err := s.jobStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close job store")
+		}
+	}
+	if s.sessionStore != nil {
+		if err := s.sessionStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close classification session store")
+		}
+	}
+	if s.reviewStore != nil {
+		if err := s.reviewStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close review store")
+		}
+	}
+	if s.auditStore != nil {
+		if err := s.auditStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close audit store")
+		}
+	}
+	if s.identifierStore != nil {
+		if err := s.identifierStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close identifier store")
+		}
+	}
+	if closer, ok := s.computationalPredictor.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close dbNSFP")
+		}
+	}
+	if s.localClinVar != nil {
+		if err := s.localClinVar.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close local ClinVar")
+		}
+	}
+	if s.localGnomAD != nil {
+		if err := s.localGnomAD.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close local gnomAD")
+		}
+	}
+	if closer, ok := s.normalizer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close reference genome")
+		}
+	}
+	if s.vrsReference != nil {
+		if err := s.vrsReference.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close reference genome")
+		}
+	}
+	if s.thresholdStore != nil {
+		if err := s.thresholdStore.Close(); err != nil {
+			s.logger.WithError(err).Error("Failed to close threshold store")
+		}
+	}
 	if s.activeTransport != nil {
 		s.activeTransport.Close()
 	}
 	return nil
 }
 
+// CallTool runs a tool in-process, as the offline CLI subcommands do, without
+// starting a transport. Results are not summarized.
+func (s *LiteServer) CallTool(ctx context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response {
+	return s.toolRegistry.ExecuteTool(tools.WithInProcess(ctx), &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  name,
+		Params:  arguments,
+	})
+}
+
 // GetFeedbackStore returns the feedback store for external access.
 func (s *LiteServer) GetFeedbackStore() feedback.Store {
 	return s.feedbackStore
 }
 
+// GetSnapshotStore returns the evidence snapshot store for external access.
+func (s *LiteServer) GetSnapshotStore() snapshot.Store {
+	return s.snapshotStore
+}
+
+// GetAuditStore returns the classification audit trail store for external access.
+func (s *LiteServer) GetAuditStore() audit.Store {
+	return s.auditStore
+}
+
+// GetSpecifications returns the loaded VCEP rule specifications for external access.
+func (s *LiteServer) GetSpecifications() *vcep.Registry {
+	if snapshot := s.currentConfig(); snapshot != nil {
+		return snapshot.Specifications
+	}
+	return s.specifications
+}
+
+// GetTranscriptSets returns the loaded specialty transcript sets for external access.
+func (s *LiteServer) GetTranscriptSets() *transcriptset.Registry {
+	if snapshot := s.currentConfig(); snapshot != nil {
+		return snapshot.TranscriptSets
+	}
+	return s.transcriptSets
+}
+
+// pathExists reports whether a file or directory exists
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// GetConfigRepo returns the Git-backed clinical configuration syncer, or nil when not configured.
+func (s *LiteServer) GetConfigRepo() *configrepo.Syncer {
+	return s.configRepo
+}
+
+// currentConfig returns the config repository snapshot in effect, if any
+func (s *LiteServer) currentConfig() *configrepo.Snapshot {
+	if s.configRepo == nil {
+		return nil
+	}
+	return s.configRepo.Current()
+}
+
 // GetCache returns the memory cache for external access.
 func (s *LiteServer) GetCache() *cache.MemoryCache {
 	return s.cache
 }
 
-// createKnowledgeBaseService creates the knowledge base service with no Redis cache.
+// identifierLookup resolves rsIDs and ClinVar accessions in the local
+// ClinVar release when one is open, and otherwise via E-utilities
+func (s *LiteServer) identifierLookup(cfg *litecfg.LiteConfig) variantid.Lookup {
+	if s.localClinVar != nil {
+		return s.localClinVar
+	}
+	return createIdentifierClient(cfg)
+}
+
+// createIdentifierClient creates the E-utilities client that resolves rsIDs
+// and ClinVar accessions not yet in the local mapping cache.
+func createIdentifierClient(cfg *litecfg.LiteConfig) *external.IdentifierClient {
+	return external.NewIdentifierClient(domain.ClinVarConfig{
+		BaseURL:   "https://eutils.ncbi.nlm.nih.gov/entrez/eutils",
+		RateLimit: 3,
+		Timeout:   30 * time.Second,
+		APIKey:    cfg.ClinVarAPIKey,
+	})
+}
+
+// createSomaticEvidenceSources creates the knowledge bases queried for somatic
+// tiering: CIViC, which is open, and OncoKB when an API token is configured.
+func createSomaticEvidenceSources(cfg *litecfg.LiteConfig) []external.SomaticEvidenceClient {
+	sources := []external.SomaticEvidenceClient{
+		external.NewCIViCClient(domain.CIViCConfig{
+			BaseURL:   "https://civicdb.org/api/graphql",
+			RateLimit: 5,
+			Timeout:   30 * time.Second,
+		}),
+	}
+	if cfg.OncoKBToken != "" {
+		sources = append(sources, external.NewOncoKBClient(domain.OncoKBConfig{
+			BaseURL:   "https://www.oncokb.org/api/v1",
+			Token:     cfg.OncoKBToken,
+			RateLimit: 5,
+			Timeout:   30 * time.Second,
+		}))
+	}
+	return sources
+}
+
+// ClinVar and gnomAD endpoints queried for evidence and probed for readiness
+const (
+	clinVarEUtilsURL = "https://eutils.ncbi.nlm.nih.gov/entrez/eutils"
+	gnomADAPIURL     = "https://gnomad.broadinstitute.org/api"
+)
+
+// createKnowledgeBaseService creates the knowledge base service with an
+// in-memory evidence cache in place of Redis.
 func createKnowledgeBaseService(cfg *litecfg.LiteConfig) (*external.KnowledgeBaseService, error) {
 	return external.NewKnowledgeBaseService(
 		domain.ClinVarConfig{
-			BaseURL:   "https://eutils.ncbi.nlm.nih.gov/entrez/eutils",
+			BaseURL:   clinVarEUtilsURL,
 			RateLimit: 3,
 			Timeout:   30 * time.Second,
 			APIKey:    cfg.ClinVarAPIKey,
 		},
 		domain.GnomADConfig{
-			BaseURL:   "https://gnomad.broadinstitute.org/api",
+			BaseURL:   gnomADAPIURL,
 			RateLimit: 10,
 			Timeout:   30 * time.Second,
+			Dataset:   external.GnomADDatasetV4,
 		},
 		domain.COSMICConfig{
 			BaseURL:   "https://cancer.sanger.ac.uk/cosmic/search",
@@ -278,13 +1959,74 @@ func createKnowledgeBaseService(cfg *litecfg.LiteConfig) (*external.KnowledgeBas
 			Timeout:   30 * time.Second,
 		},
 		domain.CacheConfig{
-			// Empty Redis URL - service will work without caching
-			RedisURL:   "",
-			DefaultTTL: 5 * time.Minute,
+			// Empty Redis URL - evidence is cached in an in-memory LRU
+			RedisURL:             "",
+			DefaultTTL:           cfg.CacheTTL,
+			MaxEntries:           cfg.CacheMaxItems,
+			MaxBytes:             cfg.CacheMaxBytes,
+			SpillPath:            cfg.CacheSpillFile,
+			SourceTTLs:           cfg.CacheSourceTTLs,
+			StaleWhileRevalidate: cfg.CacheStaleWindow,
 		},
 	)
 }
 
+// createLiteNormalizer opens the reference genome and loads the reference
+// transcripts and liftover chains that exist alongside it
+func createLiteNormalizer(cfg *litecfg.LiteConfig, logger *logrus.Logger) (*hgvs.Normalizer, error) {
+	assembly, err := hgvs.ParseAssembly(cfg.GenomeAssembly)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACMG_GENOME_ASSEMBLY: %w", err)
+	}
+	reference, err := hgvs.OpenIndexedFasta(cfg.ReferenceFastaPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var transcripts hgvs.TranscriptSource
+	if path := cfg.TranscriptGTFPath(); pathExists(path) {
+		index, err := hgvs.LoadGTFFile(path)
+		if err != nil {
+			reference.Close()
+			return nil, err
+		}
+		transcripts = index
+		logger.WithField("count", index.Len()).Info("Loaded reference transcripts")
+	} else {
+		logger.WithField("path", path).Warn("No reference transcripts found; only g. notations will be normalized")
+	}
+
+	normalizer, err := hgvs.NewNormalizer(assembly, transcripts, reference)
+	if err != nil {
+		reference.Close()
+		return nil, err
+	}
+
+	chains := []struct{ file, from, to string }{
+		{"hg19ToHg38.over.chain.gz", hgvs.AssemblyGRCh37, hgvs.AssemblyGRCh38},
+		{"hg38ToHg19.over.chain.gz", hgvs.AssemblyGRCh38, hgvs.AssemblyGRCh37},
+	}
+	for _, chain := range chains {
+		path := filepath.Join(cfg.LiftoverDir(), chain.file)
+		if !pathExists(path) {
+			continue
+		}
+		lift, err := hgvs.LoadChainFile(path, chain.from, chain.to)
+		if err != nil {
+			normalizer.Close()
+			return nil, err
+		}
+		if err := normalizer.SetLiftover(lift); err != nil {
+			normalizer.Close()
+			return nil, err
+		}
+		logger.WithField("chain", chain.file).Info("Loaded liftover chain")
+	}
+
+	logger.WithField("assembly", assembly).Info("Enabled HGVS normalization")
+	return normalizer, nil
+}
+
 // createLiteTranscriptResolver creates a transcript resolver with in-memory caching only.
 func createLiteTranscriptResolver(logger *logrus.Logger) (domain.GeneTranscriptResolver, error) {
 	config := service.TranscriptResolverConfig{
@@ -325,4 +2067,3 @@ func createLiteTranscriptResolver(logger *logrus.Logger) (domain.GeneTranscriptR
 
 	return service.NewTranscriptResolverAdapter(cachedResolver), nil
 }
-
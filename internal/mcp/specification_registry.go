@@ -0,0 +1,57 @@
+// Package mcp provides the MCP server implementation.
+// This file contains VCEP specification resource registration logic.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/resources"
+	"github.com/acmg-amp-mcp-server/internal/vcep"
+)
+
+// registerSpecificationResources registers the /acmg/specifications index
+// resource and the /acmg/specifications/{gene} resource template.
+func registerSpecificationResources(mcpServer *mcp.Server, logger *logrus.Logger, registry *vcep.Registry) {
+	provider := resources.NewSpecificationResourceProvider(logger, registry)
+	handler := func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		content, err := provider.GetResource(ctx, strings.TrimPrefix(uri, diseaseURIScheme))
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.MCPResourceError, err)
+		}
+		text, err := json.Marshal(content.Content)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InternalError, fmt.Errorf("failed to encode VCEP specification: %w", err))
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: content.MimeType, Text: string(text)}},
+		}, nil
+	}
+
+	resource := &mcp.Resource{
+		Name:        "vcep_specifications",
+		Title:       "VCEP Rule Specifications",
+		Description: "Index of the loaded gene-specific ClinGen VCEP specifications and the criteria each overrides",
+		MIMEType:    "application/json",
+		URI:         diseaseURIScheme + "/acmg/specifications",
+		Meta:        resourceTags(provider, "/acmg/specifications"),
+	}
+	mcpServer.AddResource(resource, handler)
+
+	template := &mcp.ResourceTemplate{
+		Name:        "vcep_specification",
+		Title:       "Gene VCEP Specification",
+		Description: "The VCEP rule specification loaded for a gene: criteria overrides, hotspots and PVS1 decision tree",
+		MIMEType:    "application/json",
+		URITemplate: diseaseURIScheme + "/acmg/specifications/{gene}",
+	}
+	mcpServer.AddResourceTemplate(template, handler)
+	logger.WithField("uri_template", template.URITemplate).Debug("Registered VCEP specification resources")
+}
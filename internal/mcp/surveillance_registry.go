@@ -0,0 +1,23 @@
+// Package mcp provides the MCP server implementation.
+// This file contains evidence surveillance tool registration logic.
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/internal/surveillance"
+)
+
+// registerSurveillanceTools registers the on-demand evidence refresh tool.
+func registerSurveillanceTools(registry *tools.ToolRegistry, logger *logrus.Logger, refresher *surveillance.Refresher) error {
+	refreshTool := tools.NewRunEvidenceRefreshTool(logger, refresher)
+	if err := registry.RegisterTool(refreshTool); err != nil {
+		return fmt.Errorf("failed to register %s: %w", refreshTool.GetToolInfo().Name, err)
+	}
+	logger.WithField("tool_name", refreshTool.GetToolInfo().Name).Debug("Registered surveillance tool")
+
+	return nil
+}
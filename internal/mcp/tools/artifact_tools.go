@@ -0,0 +1,504 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/artifact"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// artifactStoreError maps blacklist store errors to tool responses
+func artifactStoreError(logger *logrus.Logger, action string, err error) *protocol.JSONRPC2Response {
+	switch {
+	case errors.Is(err, artifact.ErrNotFound):
+		return invalidParamsError("Artifact entry not found", err.Error())
+	case errors.Is(err, artifact.ErrInvalidEntry),
+		errors.Is(err, artifact.ErrAlreadyListed),
+		errors.Is(err, artifact.ErrSelfReview),
+		errors.Is(err, artifact.ErrAlreadySignedOff):
+		return invalidParamsError(err.Error())
+	}
+	logger.WithError(err).Errorf("Failed to %s", action)
+	return internalError("Failed to "+action, err.Error())
+}
+
+// =============================================================================
+// Propose Artifact Tool
+// =============================================================================
+
+// ProposeArtifactTool implements the propose_artifact MCP tool
+type ProposeArtifactTool struct {
+	logger *logrus.Logger
+	store  artifact.Store
+}
+
+// ProposeArtifactParams defines parameters for the propose_artifact tool
+type ProposeArtifactParams struct {
+	NormalizedHGVS string `json:"normalized_hgvs"`
+	Evidence       string `json:"evidence"`
+	CuratorID      string `json:"curator_id"`
+}
+
+// NewProposeArtifactTool creates a new propose_artifact tool
+func NewProposeArtifactTool(logger *logrus.Logger, store artifact.Store) *ProposeArtifactTool {
+	return &ProposeArtifactTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for propose_artifact
+func (t *ProposeArtifactTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "propose_artifact",
+		Description: "Propose adding a known sequencing or pipeline artifact to the lab's artifact blacklist. The entry takes effect once signed off by a second reviewer.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"normalized_hgvs": map[string]interface{}{
+					"type":        "string",
+					"description": "Normalized HGVS notation of the artifact variant",
+				},
+				"evidence": map[string]interface{}{
+					"type":        "string",
+					"description": "Evidence the variant is an artifact, e.g. recurrence rate, failed orthogonal confirmation, strand bias",
+				},
+				"curator_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the curator proposing the entry",
+				},
+			},
+			"required": []string{"normalized_hgvs", "evidence", "curator_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ProposeArtifactTool) ValidateParams(params interface{}) error {
+	var p ProposeArtifactParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.NormalizedHGVS) == "" {
+		return fmt.Errorf("normalized_hgvs is required")
+	}
+	if strings.TrimSpace(p.Evidence) == "" {
+		return fmt.Errorf("evidence is required")
+	}
+	if strings.TrimSpace(p.CuratorID) == "" {
+		return fmt.Errorf("curator_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the propose_artifact tool request
+func (t *ProposeArtifactTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ProposeArtifactParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	entry := &artifact.Entry{
+		NormalizedHGVS: params.NormalizedHGVS,
+		Evidence:       params.Evidence,
+		SubmittedBy:    params.CuratorID,
+	}
+	if err := t.store.Propose(ctx, entry); err != nil {
+		return artifactStoreError(t.logger, "propose artifact", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"entry":  entry,
+			"status": entry.Status(),
+		},
+	}
+}
+
+// =============================================================================
+// Sign Off Artifact Tool
+// =============================================================================
+
+// SignOffArtifactTool implements the sign_off_artifact MCP tool
+type SignOffArtifactTool struct {
+	logger *logrus.Logger
+	store  artifact.Store
+}
+
+// SignOffArtifactParams defines parameters for the sign_off_artifact tool
+type SignOffArtifactParams struct {
+	EntryID    int64  `json:"entry_id"`
+	ReviewerID string `json:"reviewer_id"`
+}
+
+// NewSignOffArtifactTool creates a new sign_off_artifact tool
+func NewSignOffArtifactTool(logger *logrus.Logger, store artifact.Store) *SignOffArtifactTool {
+	return &SignOffArtifactTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for sign_off_artifact
+func (t *SignOffArtifactTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "sign_off_artifact",
+		Description: "Sign off a proposed artifact blacklist entry, activating it. The reviewer must differ from the curator who proposed it.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"entry_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID of the pending entry",
+				},
+				"reviewer_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the reviewer signing off",
+				},
+			},
+			"required": []string{"entry_id", "reviewer_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *SignOffArtifactTool) ValidateParams(params interface{}) error {
+	var p SignOffArtifactParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.EntryID <= 0 {
+		return fmt.Errorf("entry_id is required")
+	}
+	if strings.TrimSpace(p.ReviewerID) == "" {
+		return fmt.Errorf("reviewer_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the sign_off_artifact tool request
+func (t *SignOffArtifactTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params SignOffArtifactParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	entry, err := t.store.SignOff(ctx, params.EntryID, params.ReviewerID)
+	if err != nil {
+		return artifactStoreError(t.logger, "sign off artifact", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"entry":  entry,
+			"status": entry.Status(),
+		},
+	}
+}
+
+// =============================================================================
+// Remove Artifact Tool
+// =============================================================================
+
+// RemoveArtifactTool implements the remove_artifact MCP tool
+type RemoveArtifactTool struct {
+	logger *logrus.Logger
+	store  artifact.Store
+}
+
+// RemoveArtifactParams defines parameters for the remove_artifact tool
+type RemoveArtifactParams struct {
+	EntryID int64 `json:"entry_id"`
+}
+
+// NewRemoveArtifactTool creates a new remove_artifact tool
+func NewRemoveArtifactTool(logger *logrus.Logger, store artifact.Store) *RemoveArtifactTool {
+	return &RemoveArtifactTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for remove_artifact
+func (t *RemoveArtifactTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "remove_artifact",
+		Description: "Remove an entry from the artifact blacklist.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"entry_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID of the entry to remove",
+				},
+			},
+			"required": []string{"entry_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *RemoveArtifactTool) ValidateParams(params interface{}) error {
+	var p RemoveArtifactParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.EntryID <= 0 {
+		return fmt.Errorf("entry_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the remove_artifact tool request
+func (t *RemoveArtifactTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params RemoveArtifactParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	if err := t.store.Remove(ctx, params.EntryID); err != nil {
+		return artifactStoreError(t.logger, "remove artifact", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"success": true,
+			"message": fmt.Sprintf("Removed artifact entry %d", params.EntryID),
+		},
+	}
+}
+
+// =============================================================================
+// List Artifact Blacklist Tool
+// =============================================================================
+
+// ListArtifactsTool implements the list_artifact_blacklist MCP tool
+type ListArtifactsTool struct {
+	logger *logrus.Logger
+	store  artifact.Store
+}
+
+// ListArtifactsParams defines parameters for the list_artifact_blacklist tool
+type ListArtifactsParams struct {
+	IncludePending bool `json:"include_pending,omitempty"`
+}
+
+// NewListArtifactsTool creates a new list_artifact_blacklist tool
+func NewListArtifactsTool(logger *logrus.Logger, store artifact.Store) *ListArtifactsTool {
+	return &ListArtifactsTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for list_artifact_blacklist
+func (t *ListArtifactsTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "list_artifact_blacklist",
+		Description: "List the lab's artifact blacklist, ordered by variant.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"include_pending": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include entries awaiting sign-off",
+					"default":     false,
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ListArtifactsTool) ValidateParams(params interface{}) error {
+	return nil // No required parameters
+}
+
+// HandleTool handles the list_artifact_blacklist tool request
+func (t *ListArtifactsTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ListArtifactsParams
+	_ = ParseParams(req.Params, &params)
+
+	entries, err := t.store.List(ctx, params.IncludePending)
+	if err != nil {
+		return artifactStoreError(t.logger, "list artifact blacklist", err)
+	}
+
+	pending := 0
+	for _, e := range entries {
+		if !e.Active() {
+			pending++
+		}
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"entries": entries,
+			"total":   len(entries),
+			"pending": pending,
+		},
+	}
+}
+
+// =============================================================================
+// Export Artifact Blacklist Tool
+// =============================================================================
+
+// ExportArtifactsTool implements the export_artifact_blacklist MCP tool
+type ExportArtifactsTool struct {
+	logger    *logrus.Logger
+	store     artifact.Store
+	exportDir string
+}
+
+// NewExportArtifactsTool creates a new export_artifact_blacklist tool
+func NewExportArtifactsTool(logger *logrus.Logger, store artifact.Store, exportDir string) *ExportArtifactsTool {
+	return &ExportArtifactsTool{
+		logger:    logger,
+		store:     store,
+		exportDir: exportDir,
+	}
+}
+
+// GetToolInfo returns the tool information for export_artifact_blacklist
+func (t *ExportArtifactsTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "export_artifact_blacklist",
+		Description: "Export the artifact blacklist, including evidence and sign-off, to a JSON file for import into another environment.",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ExportArtifactsTool) ValidateParams(params interface{}) error {
+	return nil // No required parameters
+}
+
+// HandleTool handles the export_artifact_blacklist tool request
+func (t *ExportArtifactsTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	if err := os.MkdirAll(t.exportDir, 0755); err != nil {
+		return internalError("Failed to create export directory", err.Error())
+	}
+
+	filename := fmt.Sprintf("artifact_blacklist_%s.json", time.Now().Format("20060102_150405"))
+	filePath := filepath.Join(t.exportDir, filename)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return internalError("Failed to create export file", err.Error())
+	}
+	defer file.Close()
+
+	if err := t.store.ExportJSON(ctx, file); err != nil {
+		return artifactStoreError(t.logger, "export artifact blacklist", err)
+	}
+
+	entries, _ := t.store.List(ctx, true)
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"export": ExportFeedbackResult{
+				Success: true, FilePath: filePath, Count: int64(len(entries)),
+				Message: fmt.Sprintf("Exported %d artifact entries to %s", len(entries), filePath),
+			},
+		},
+	}
+}
+
+// =============================================================================
+// Import Artifact Blacklist Tool
+// =============================================================================
+
+// ImportArtifactsTool implements the import_artifact_blacklist MCP tool
+type ImportArtifactsTool struct {
+	logger *logrus.Logger
+	store  artifact.Store
+}
+
+// NewImportArtifactsTool creates a new import_artifact_blacklist tool
+func NewImportArtifactsTool(logger *logrus.Logger, store artifact.Store) *ImportArtifactsTool {
+	return &ImportArtifactsTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for import_artifact_blacklist
+func (t *ImportArtifactsTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "import_artifact_blacklist",
+		Description: "Import artifact blacklist entries exported from another environment, keeping their sign-off. Variants already listed are skipped.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"file_path": map[string]interface{}{
+					"type":        "string",
+					"description": "Path to the JSON file to import",
+				},
+			},
+			"required": []string{"file_path"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ImportArtifactsTool) ValidateParams(params interface{}) error {
+	var p ImportFeedbackParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.FilePath == "" {
+		return fmt.Errorf("file_path is required")
+	}
+	return nil
+}
+
+// HandleTool handles the import_artifact_blacklist tool request
+func (t *ImportArtifactsTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ImportFeedbackParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	file, err := os.Open(params.FilePath)
+	if err != nil {
+		return invalidParamsError("Failed to open file", err.Error())
+	}
+	defer file.Close()
+
+	imported, skipped, err := t.store.ImportJSON(ctx, file)
+	if err != nil {
+		return artifactStoreError(t.logger, "import artifact blacklist", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"import": ImportFeedbackResult{
+				Success: true, Imported: imported, Skipped: skipped,
+				Message: fmt.Sprintf("Imported %d entries, skipped %d already listed", imported, skipped),
+			},
+		},
+	}
+}
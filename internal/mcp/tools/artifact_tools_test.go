@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/artifact"
+)
+
+func createTestArtifactStore(t *testing.T) *artifact.SQLiteStore {
+	t.Helper()
+
+	store, err := artifact.NewSQLiteStore(filepath.Join(t.TempDir(), "artifacts.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestArtifactTools_ProposeAndSignOff(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestArtifactStore(t)
+	propose := NewProposeArtifactTool(logger, store)
+	signOff := NewSignOffArtifactTool(logger, store)
+
+	// Act
+	proposed := propose.HandleTool(context.Background(), toolRequest("propose_artifact", map[string]interface{}{
+		"normalized_hgvs": "NM_000059.4:c.68-7T>A",
+		"evidence":        "Recurrent in 12% of exomes; not confirmed by Sanger in 5/5",
+		"curator_id":      "curator-1",
+	}))
+	require.Nil(t, proposed.Error)
+	entry := proposed.Result.(map[string]interface{})["entry"].(*artifact.Entry)
+
+	self := signOff.HandleTool(context.Background(), toolRequest("sign_off_artifact", map[string]interface{}{
+		"entry_id":    entry.ID,
+		"reviewer_id": "curator-1",
+	}))
+	signed := signOff.HandleTool(context.Background(), toolRequest("sign_off_artifact", map[string]interface{}{
+		"entry_id":    entry.ID,
+		"reviewer_id": "director",
+	}))
+
+	// Assert
+	assert.Equal(t, artifact.StatusPending, proposed.Result.(map[string]interface{})["status"])
+	require.NotNil(t, self.Error)
+	require.Nil(t, signed.Error)
+	assert.Equal(t, artifact.StatusActive, signed.Result.(map[string]interface{})["status"])
+}
+
+func TestArtifactTools_ExportImport(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	source := createTestArtifactStore(t)
+	entry := &artifact.Entry{NormalizedHGVS: "NM_000059.4:c.68-7T>A", Evidence: "Strand bias", SubmittedBy: "curator-1"}
+	require.NoError(t, source.Propose(context.Background(), entry))
+	_, err := source.SignOff(context.Background(), entry.ID, "director")
+	require.NoError(t, err)
+
+	exported := NewExportArtifactsTool(logger, source, t.TempDir()).HandleTool(context.Background(), toolRequest("export_artifact_blacklist", nil))
+	require.Nil(t, exported.Error)
+	filePath := exported.Result.(map[string]interface{})["export"].(ExportFeedbackResult).FilePath
+
+	target := createTestArtifactStore(t)
+	imported := NewImportArtifactsTool(logger, target).HandleTool(context.Background(), toolRequest("import_artifact_blacklist", map[string]interface{}{
+		"file_path": filePath,
+	}))
+
+	require.Nil(t, imported.Error)
+	assert.Equal(t, 1, imported.Result.(map[string]interface{})["import"].(ImportFeedbackResult).Imported)
+	found, err := target.Lookup(context.Background(), "NM_000059.4:c.68-7T>A")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+}
+
+func TestClassifyVariantTool_LookupArtifactIgnoresPending(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestArtifactStore(t)
+	tool := NewClassifyVariantToolLegacy(logger, nil)
+	tool.SetArtifactStore(store)
+	entry := &artifact.Entry{NormalizedHGVS: "NM_000059.4:c.68-7T>A", Evidence: "Strand bias", SubmittedBy: "curator-1"}
+	require.NoError(t, store.Propose(context.Background(), entry))
+
+	assert.Nil(t, tool.lookupArtifact(context.Background(), entry.NormalizedHGVS))
+
+	_, err := store.SignOff(context.Background(), entry.ID, "director")
+	require.NoError(t, err)
+	found := tool.lookupArtifact(context.Background(), entry.NormalizedHGVS)
+	require.NotNil(t, found)
+	assert.Equal(t, "Strand bias", found.Evidence)
+}
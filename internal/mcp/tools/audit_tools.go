@@ -0,0 +1,648 @@
+package tools
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/phi"
+)
+
+// maxAuditQueryLimit caps the number of audit records returned by one query
+const maxAuditQueryLimit = 500
+
+// auditStoreError maps audit store errors to tool responses
+func auditStoreError(logger *logrus.Logger, action string, err error) *protocol.JSONRPC2Response {
+	if errors.Is(err, audit.ErrNotFound) {
+		return invalidParamsError("Audit record not found", err.Error())
+	}
+	logger.WithError(err).Errorf("Failed to %s", action)
+	return internalError("Failed to "+action, err.Error())
+}
+
+// =============================================================================
+// Query Audit Trail Tool
+// =============================================================================
+
+// QueryAuditTrailTool implements the query_audit_trail MCP tool
+type QueryAuditTrailTool struct {
+	logger *logrus.Logger
+	store  audit.Store
+}
+
+// QueryAuditTrailParams defines parameters for the query_audit_trail tool
+type QueryAuditTrailParams struct {
+	Variant        string `json:"variant,omitempty"`
+	Classification string `json:"classification,omitempty"`
+	Since          string `json:"since,omitempty"` // YYYY-MM-DD, inclusive
+	Until          string `json:"until,omitempty"` // YYYY-MM-DD, inclusive
+	FailedOnly     bool   `json:"failed_only,omitempty"`
+	Limit          int    `json:"limit,omitempty"`
+}
+
+// NewQueryAuditTrailTool creates a new query_audit_trail tool
+func NewQueryAuditTrailTool(logger *logrus.Logger, store audit.Store) *QueryAuditTrailTool {
+	return &QueryAuditTrailTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for query_audit_trail
+func (t *QueryAuditTrailTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "query_audit_trail",
+		Description: "Query the classification audit trail. Each record holds the request as received, the evidence retrieved, the rules applied, the final call and the engine and rule versions in effect. Newest records are returned first.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"variant": map[string]interface{}{
+					"type":        "string",
+					"description": "Variant ID or HGVS notation of the classified variant",
+				},
+				"classification": map[string]interface{}{
+					"type":        "string",
+					"description": "Only records with this final call, e.g. Pathogenic",
+				},
+				"since": map[string]interface{}{
+					"type":        "string",
+					"description": "Only records on or after this date (YYYY-MM-DD)",
+				},
+				"until": map[string]interface{}{
+					"type":        "string",
+					"description": "Only records on or before this date (YYYY-MM-DD)",
+				},
+				"failed_only": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Only classification requests that failed",
+					"default":     false,
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of records to return",
+					"minimum":     1,
+					"maximum":     maxAuditQueryLimit,
+					"default":     audit.DefaultQueryLimit,
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *QueryAuditTrailTool) ValidateParams(params interface{}) error {
+	if params == nil {
+		return nil // No required parameters
+	}
+	var p QueryAuditTrailParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	_, err := p.filter()
+	return err
+}
+
+// filter converts the parameters to a store filter
+func (p *QueryAuditTrailParams) filter() (audit.Filter, error) {
+	filter := audit.Filter{
+		Variant:        strings.TrimSpace(p.Variant),
+		Classification: strings.TrimSpace(p.Classification),
+		FailedOnly:     p.FailedOnly,
+		Limit:          p.Limit,
+	}
+	if p.Limit < 0 || p.Limit > maxAuditQueryLimit {
+		return filter, fmt.Errorf("limit must be between 1 and %d", maxAuditQueryLimit)
+	}
+	if p.Since != "" {
+		since, err := time.Parse("2006-01-02", p.Since)
+		if err != nil {
+			return filter, fmt.Errorf("since must be a date in YYYY-MM-DD format")
+		}
+		filter.Since = since
+	}
+	if p.Until != "" {
+		until, err := time.Parse("2006-01-02", p.Until)
+		if err != nil {
+			return filter, fmt.Errorf("until must be a date in YYYY-MM-DD format")
+		}
+		filter.Until = until.AddDate(0, 0, 1)
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return filter, fmt.Errorf("since must not be after until")
+	}
+	return filter, nil
+}
+
+// HandleTool handles the query_audit_trail tool request
+func (t *QueryAuditTrailTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params QueryAuditTrailParams
+	if req.Params != nil {
+		if err := ParseParams(req.Params, &params); err != nil {
+			return invalidParamsError("Invalid parameters", err.Error())
+		}
+	}
+	filter, err := params.filter()
+	if err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	records, err := t.store.Query(ctx, filter)
+	if err != nil {
+		return auditStoreError(t.logger, "query audit trail", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"records": records,
+			"total":   len(records),
+		},
+	}
+}
+
+// =============================================================================
+// Compare Classifications Tool
+// =============================================================================
+
+// CompareClassificationsTool implements the compare_classifications MCP tool
+type CompareClassificationsTool struct {
+	logger *logrus.Logger
+	store  audit.Store
+}
+
+// CompareClassificationsParams defines parameters for the compare_classifications tool
+type CompareClassificationsParams struct {
+	Variant          string `json:"variant,omitempty"`
+	PreviousRecordID int64  `json:"previous_record_id,omitempty"`
+	CurrentRecordID  int64  `json:"current_record_id,omitempty"`
+}
+
+// NewCompareClassificationsTool creates a new compare_classifications tool
+func NewCompareClassificationsTool(logger *logrus.Logger, store audit.Store) *CompareClassificationsTool {
+	return &CompareClassificationsTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for compare_classifications
+func (t *CompareClassificationsTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "compare_classifications",
+		Description: "Compare two recorded classifications of a variant and report which criteria changed, which evidence sources changed and whether the overall class moved. By default compares the variant's latest classification with the one before it. A move between the benign, uncertain and pathogenic tiers is flagged for a reclassification notice.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"variant": map[string]interface{}{
+					"type":        "string",
+					"description": "HGVS notation of the variant; required unless both record IDs are given",
+				},
+				"previous_record_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "Audit record of the earlier classification; defaults to the one before the current record",
+				},
+				"current_record_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "Audit record of the later classification; defaults to the latest",
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *CompareClassificationsTool) ValidateParams(params interface{}) error {
+	var p CompareClassificationsParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.Variant) == "" && (p.PreviousRecordID <= 0 || p.CurrentRecordID <= 0) {
+		return fmt.Errorf("variant is required unless previous_record_id and current_record_id are given")
+	}
+	if p.PreviousRecordID < 0 || p.CurrentRecordID < 0 {
+		return fmt.Errorf("record IDs must be positive")
+	}
+	return nil
+}
+
+// HandleTool handles the compare_classifications tool request
+func (t *CompareClassificationsTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params CompareClassificationsParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	current, err := t.currentRecord(ctx, &params)
+	if err != nil {
+		return auditStoreError(t.logger, "load current classification", err)
+	}
+	if current == nil {
+		return invalidParamsError("No successful classification recorded for the variant", params.Variant)
+	}
+
+	var previous *audit.Record
+	if params.PreviousRecordID > 0 {
+		previous, err = t.store.Get(ctx, params.PreviousRecordID)
+	} else {
+		previous, err = audit.Previous(ctx, t.store, current)
+	}
+	if err != nil {
+		return auditStoreError(t.logger, "load previous classification", err)
+	}
+	if previous == nil {
+		return invalidParamsError("No earlier classification recorded for the variant", current.HGVSNotation)
+	}
+
+	diff, err := audit.Compare(previous, current)
+	if err != nil {
+		if errors.Is(err, audit.ErrInvalidRecord) {
+			return invalidParamsError(err.Error())
+		}
+		return auditStoreError(t.logger, "compare classifications", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"diff":    diff,
+			"changed": diff.Changed(),
+		},
+	}
+}
+
+// currentRecord returns the requested record or the variant's latest successful classification
+func (t *CompareClassificationsTool) currentRecord(ctx context.Context, params *CompareClassificationsParams) (*audit.Record, error) {
+	if params.CurrentRecordID > 0 {
+		return t.store.Get(ctx, params.CurrentRecordID)
+	}
+	records, err := t.store.Query(ctx, audit.Filter{
+		Variant:       strings.TrimSpace(params.Variant),
+		SucceededOnly: true,
+		Limit:         1,
+	})
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	return records[0], nil
+}
+
+// =============================================================================
+// Get Audit Record Tool
+// =============================================================================
+
+// GetAuditRecordTool implements the get_audit_record MCP tool
+type GetAuditRecordTool struct {
+	logger *logrus.Logger
+	store  audit.Store
+}
+
+// GetAuditRecordParams defines parameters for the get_audit_record tool
+type GetAuditRecordParams struct {
+	RecordID int64 `json:"record_id"`
+}
+
+// NewGetAuditRecordTool creates a new get_audit_record tool
+func NewGetAuditRecordTool(logger *logrus.Logger, store audit.Store) *GetAuditRecordTool {
+	return &GetAuditRecordTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for get_audit_record
+func (t *GetAuditRecordTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "get_audit_record",
+		Description: "Get a single classification audit record by ID, including the full request, evidence and applied rules.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"record_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID of the audit record",
+				},
+			},
+			"required": []string{"record_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *GetAuditRecordTool) ValidateParams(params interface{}) error {
+	var p GetAuditRecordParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.RecordID <= 0 {
+		return fmt.Errorf("record_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the get_audit_record tool request
+func (t *GetAuditRecordTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params GetAuditRecordParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	record, err := t.store.Get(ctx, params.RecordID)
+	if err != nil {
+		return auditStoreError(t.logger, "get audit record", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"record": record,
+		},
+	}
+}
+
+// =============================================================================
+// Verify Audit Chain Tool
+// =============================================================================
+
+// VerifyAuditChainTool implements the verify_audit_chain MCP tool
+type VerifyAuditChainTool struct {
+	logger *logrus.Logger
+	store  audit.Store
+}
+
+// NewVerifyAuditChainTool creates a new verify_audit_chain tool
+func NewVerifyAuditChainTool(logger *logrus.Logger, store audit.Store) *VerifyAuditChainTool {
+	return &VerifyAuditChainTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for verify_audit_chain
+func (t *VerifyAuditChainTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "verify_audit_chain",
+		Description: "Verify the hash chain of the classification audit trail. Every record holds the hash of the record before it and a hash of its own content, so a modified, removed or reordered record is reported with the ID where the chain breaks.",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *VerifyAuditChainTool) ValidateParams(params interface{}) error {
+	return nil // No parameters
+}
+
+// HandleTool handles the verify_audit_chain tool request
+func (t *VerifyAuditChainTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	verification, err := audit.VerifyChain(ctx, t.store)
+	if err != nil {
+		return auditStoreError(t.logger, "verify audit chain", err)
+	}
+	if !verification.Verified {
+		t.logger.WithFields(logrus.Fields{
+			"broken_at": verification.BrokenAt,
+			"problem":   verification.Problem,
+		}).Warn("Audit chain verification failed")
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"verification": verification,
+		},
+	}
+}
+
+// =============================================================================
+// Export Audit Bundle Tool
+// =============================================================================
+
+// ExportAuditBundleTool implements the export_audit_bundle MCP tool
+type ExportAuditBundleTool struct {
+	logger   *logrus.Logger
+	store    audit.Store
+	signer   *audit.Signer // Nil exports unsigned bundles
+	redactor *phi.Redactor // Set when PHI-safe logging is enabled
+}
+
+// ExportAuditBundleParams defines parameters for the export_audit_bundle tool
+type ExportAuditBundleParams struct {
+	FromID int64 `json:"from_id,omitempty"`
+	ToID   int64 `json:"to_id,omitempty"`
+	Limit  int   `json:"limit,omitempty"`
+}
+
+// NewExportAuditBundleTool creates a new export_audit_bundle tool
+func NewExportAuditBundleTool(logger *logrus.Logger, store audit.Store, signer *audit.Signer) *ExportAuditBundleTool {
+	return &ExportAuditBundleTool{
+		logger: logger,
+		store:  store,
+		signer: signer,
+	}
+}
+
+// SetRedactor hides PHI in the exported requests. The records still verify
+// through the digests of the original requests.
+func (t *ExportAuditBundleTool) SetRedactor(redactor *phi.Redactor) {
+	t.redactor = redactor
+}
+
+// GetToolInfo returns the tool information for export_audit_bundle
+func (t *ExportAuditBundleTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "export_audit_bundle",
+		Description: "Export a range of classification audit records as a bundle for auditors. The bundle carries each record's chain hashes and, when the server has an audit signing key, an Ed25519 signature over the range and the head hash, so auditors can show the history was not modified after export. With PHI-safe logging enabled, patient identifiers and clinical free text in the requests are redacted or tokenized.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"from_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "First audit record ID to export; defaults to the first record",
+					"minimum":     1,
+				},
+				"to_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "Last audit record ID to export; defaults to the newest record",
+					"minimum":     1,
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of records in the bundle",
+					"minimum":     1,
+					"maximum":     audit.MaxBundleRecords,
+					"default":     audit.MaxBundleRecords,
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ExportAuditBundleTool) ValidateParams(params interface{}) error {
+	if params == nil {
+		return nil // No required parameters
+	}
+	var p ExportAuditBundleParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.FromID < 0 || p.ToID < 0 {
+		return fmt.Errorf("from_id and to_id must be positive")
+	}
+	if p.ToID != 0 && p.ToID < p.FromID {
+		return fmt.Errorf("to_id must not be before from_id")
+	}
+	if p.Limit < 0 || p.Limit > audit.MaxBundleRecords {
+		return fmt.Errorf("limit must be between 1 and %d", audit.MaxBundleRecords)
+	}
+	return nil
+}
+
+// HandleTool handles the export_audit_bundle tool request
+func (t *ExportAuditBundleTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ExportAuditBundleParams
+	if req.Params != nil {
+		if err := ParseParams(req.Params, &params); err != nil {
+			return invalidParamsError("Invalid parameters", err.Error())
+		}
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	bundle, err := audit.Export(ctx, t.store, params.FromID, params.ToID, params.Limit, nil)
+	if err != nil {
+		return auditStoreError(t.logger, "export audit bundle", err)
+	}
+	redacted := 0
+	if t.redactor != nil {
+		for _, record := range bundle.Records {
+			request, changed, err := t.redactor.JSON(record.Request)
+			if err != nil {
+				return auditStoreError(t.logger, "redact audit bundle", fmt.Errorf("record %d: %w", record.ID, err))
+			}
+			if !changed {
+				continue
+			}
+			if err := record.RedactRequest(request); err != nil {
+				return auditStoreError(t.logger, "redact audit bundle", fmt.Errorf("record %d: %w", record.ID, err))
+			}
+			redacted++
+		}
+	}
+	if t.signer != nil {
+		t.signer.Sign(bundle)
+	}
+	t.logger.WithFields(logrus.Fields{
+		"from_id":  bundle.FromID,
+		"to_id":    bundle.ToID,
+		"signed":   bundle.Signature != nil,
+		"redacted": redacted,
+	}).Info("Exported audit bundle")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"bundle": bundle,
+			"count":  len(bundle.Records),
+			"signed": bundle.Signature != nil,
+		},
+	}
+}
+
+// =============================================================================
+// Verify Audit Bundle Tool
+// =============================================================================
+
+// VerifyAuditBundleTool implements the verify_audit_bundle MCP tool
+type VerifyAuditBundleTool struct {
+	logger *logrus.Logger
+}
+
+// VerifyAuditBundleParams defines parameters for the verify_audit_bundle tool
+type VerifyAuditBundleParams struct {
+	Bundle    *audit.Bundle `json:"bundle"`
+	PublicKey string        `json:"public_key,omitempty"`
+}
+
+// NewVerifyAuditBundleTool creates a new verify_audit_bundle tool
+func NewVerifyAuditBundleTool(logger *logrus.Logger) *VerifyAuditBundleTool {
+	return &VerifyAuditBundleTool{logger: logger}
+}
+
+// GetToolInfo returns the tool information for verify_audit_bundle
+func (t *VerifyAuditBundleTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "verify_audit_bundle",
+		Description: "Verify an audit bundle produced by export_audit_bundle: its signature and the hash of every record in it. Pass the lab's published public key to also check who signed the bundle.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"bundle": map[string]interface{}{
+					"type":        "object",
+					"description": "Bundle as returned by export_audit_bundle",
+				},
+				"public_key": map[string]interface{}{
+					"type":        "string",
+					"description": "Trusted base64 Ed25519 public key; defaults to the key embedded in the bundle",
+				},
+			},
+			"required": []string{"bundle"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *VerifyAuditBundleTool) ValidateParams(params interface{}) error {
+	var p VerifyAuditBundleParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.Bundle == nil {
+		return fmt.Errorf("bundle is required")
+	}
+	return nil
+}
+
+// HandleTool handles the verify_audit_bundle tool request
+func (t *VerifyAuditBundleTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params VerifyAuditBundleParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	var trusted ed25519.PublicKey
+	if params.PublicKey != "" {
+		key, err := audit.ParsePublicKey(params.PublicKey)
+		if err != nil {
+			return invalidParamsError(err.Error())
+		}
+		trusted = key
+	}
+
+	verification, err := audit.VerifyBundle(params.Bundle, trusted)
+	if err != nil {
+		// A bundle that fails its signature check is a finding, not a request error
+		verification = &audit.Verification{Problem: err.Error()}
+	}
+	result := map[string]interface{}{
+		"verification": verification,
+	}
+	if params.Bundle.Signature != nil {
+		result["key_id"] = params.Bundle.Signature.KeyID
+	}
+	return &protocol.JSONRPC2Response{Result: result}
+}
@@ -0,0 +1,276 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/phi"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+func createTestAuditStore(t *testing.T) *audit.SQLiteStore {
+	t.Helper()
+
+	store, err := audit.NewSQLiteStore(filepath.Join(t.TempDir(), "audit.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestClassifyVariantTool_RecordAudit(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestAuditStore(t)
+	tool := NewClassifyVariantToolLegacy(logger, nil)
+	tool.SetAuditStore(store)
+	params := &ClassifyVariantParams{HGVSNotation: "NM_000492.4:c.1521_1523del", ScoringMode: "bayesian"}
+
+	// Act
+	tool.recordAudit(context.Background(), params, params.HGVSNotation, &service.ClassifyVariantResult{
+		VariantID:      "VAR_1",
+		Classification: "Pathogenic",
+		Confidence:     "High",
+		ScoringMode:    "bayesian",
+		AppliedRules:   []service.ACMGAMPRuleResult{{RuleCode: "PM2", Applied: true}},
+		Evidence:       &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleFrequency: 0.0001}},
+	}, nil)
+	tool.recordAudit(context.Background(), params, params.HGVSNotation, nil, errors.New("classification service failed"))
+
+	// Assert
+	records, err := store.Query(context.Background(), audit.Filter{Variant: params.HGVSNotation})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	failed, classified := records[0], records[1]
+	assert.Equal(t, "classification service failed", failed.Error)
+	assert.Empty(t, failed.Classification)
+	assert.Equal(t, "VAR_1", classified.VariantID)
+	assert.Equal(t, "Pathogenic", classified.Classification)
+	assert.Equal(t, service.EngineVersion, classified.EngineVersion)
+	assert.Contains(t, string(classified.Request), "NM_000492.4:c.1521_1523del")
+	assert.Contains(t, string(classified.AppliedRules), "PM2")
+	assert.Contains(t, string(classified.Evidence), "0.0001")
+}
+
+func TestQueryAuditTrailTool(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestAuditStore(t)
+	for _, hgvs := range []string{"NM_000492.4:c.1521_1523del", "NM_007294.4:c.5266dupC"} {
+		require.NoError(t, store.Append(context.Background(), &audit.Record{
+			HGVSNotation:   hgvs,
+			Request:        []byte(`{}`),
+			Classification: "Pathogenic",
+			EngineVersion:  service.EngineVersion,
+		}))
+	}
+	tool := NewQueryAuditTrailTool(logger, store)
+
+	// Act
+	all := tool.HandleTool(context.Background(), toolRequest("query_audit_trail", nil))
+	one := tool.HandleTool(context.Background(), toolRequest("query_audit_trail", map[string]interface{}{
+		"variant": "NM_007294.4:c.5266dupC",
+	}))
+	badDate := tool.HandleTool(context.Background(), toolRequest("query_audit_trail", map[string]interface{}{
+		"since": "03/01/2026",
+	}))
+
+	// Assert
+	require.Nil(t, all.Error)
+	assert.Equal(t, 2, all.Result.(map[string]interface{})["total"])
+	require.Nil(t, one.Error)
+	assert.Equal(t, 1, one.Result.(map[string]interface{})["total"])
+	require.NotNil(t, badDate.Error)
+}
+
+func TestGetAuditRecordTool_NotFound(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewGetAuditRecordTool(logger, createTestAuditStore(t))
+
+	resp := tool.HandleTool(context.Background(), toolRequest("get_audit_record", map[string]interface{}{
+		"record_id": 42,
+	}))
+
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "Audit record not found", resp.Error.Message)
+}
+
+func TestClassifyVariantTool_ReclassificationDiff(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewClassifyVariantToolLegacy(logger, nil)
+	tool.SetAuditStore(createTestAuditStore(t))
+	params := &ClassifyVariantParams{HGVSNotation: "NM_000492.4:c.350G>A"}
+
+	first := tool.recordAudit(context.Background(), params, params.HGVSNotation, &service.ClassifyVariantResult{
+		Classification: "VUS",
+		AppliedRules:   []service.ACMGAMPRuleResult{{RuleCode: "PM2", Strength: "moderate", Applied: true}},
+	}, nil)
+	second := tool.recordAudit(context.Background(), params, params.HGVSNotation, &service.ClassifyVariantResult{
+		Classification: "LIKELY_PATHOGENIC",
+		AppliedRules: []service.ACMGAMPRuleResult{
+			{RuleCode: "PM2", Strength: "moderate", Applied: true},
+			{RuleCode: "PS3", Strength: "strong", Applied: true},
+		},
+	}, nil)
+
+	// Assert
+	assert.Nil(t, tool.reclassificationDiff(context.Background(), first))
+	diff := tool.reclassificationDiff(context.Background(), second)
+	require.NotNil(t, diff)
+	assert.Equal(t, audit.DirectionUpgraded, diff.Direction)
+	assert.True(t, diff.NotificationRecommended)
+	require.Len(t, diff.CriteriaAdded, 1)
+	assert.Equal(t, "PS3", diff.CriteriaAdded[0].Code)
+}
+
+func TestClassifyVariantTool_ReclassificationDiffAcrossContexts(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewClassifyVariantToolLegacy(logger, nil)
+	tool.SetAuditStore(createTestAuditStore(t))
+	params := &ClassifyVariantParams{HGVSNotation: "NM_004333.6:c.1799T>A"}
+
+	tool.recordAudit(context.Background(), params, params.HGVSNotation, &service.ClassifyVariantResult{Classification: "VUS"}, nil)
+	somatic := tool.recordAudit(context.Background(), params, params.HGVSNotation, &service.ClassifyVariantResult{Classification: "TIER_I"}, nil)
+
+	// Assert
+	assert.Nil(t, tool.reclassificationDiff(context.Background(), somatic), "a tier is not a reclassification of a germline call")
+}
+
+func TestCompareClassificationsTool(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestAuditStore(t)
+	for _, classification := range []string{"LIKELY_PATHOGENIC", "PATHOGENIC"} {
+		require.NoError(t, store.Append(context.Background(), &audit.Record{
+			HGVSNotation:   "NM_007294.4:c.5266dupC",
+			Request:        []byte(`{}`),
+			Classification: classification,
+			AppliedRules:   []byte(`[]`),
+			EngineVersion:  service.EngineVersion,
+		}))
+	}
+	tool := NewCompareClassificationsTool(logger, store)
+
+	// Act
+	latest := tool.HandleTool(context.Background(), toolRequest("compare_classifications", map[string]interface{}{
+		"variant": "NM_007294.4:c.5266dupC",
+	}))
+	unknown := tool.HandleTool(context.Background(), toolRequest("compare_classifications", map[string]interface{}{
+		"variant": "NM_000492.4:c.350G>A",
+	}))
+	missing := tool.HandleTool(context.Background(), toolRequest("compare_classifications", map[string]interface{}{}))
+
+	// Assert
+	require.Nil(t, latest.Error)
+	diff := latest.Result.(map[string]interface{})["diff"].(*audit.Diff)
+	assert.Equal(t, "LIKELY_PATHOGENIC", diff.PreviousClassification)
+	assert.Equal(t, "PATHOGENIC", diff.CurrentClassification)
+	assert.False(t, diff.NotificationRecommended)
+	assert.Equal(t, true, latest.Result.(map[string]interface{})["changed"])
+	require.NotNil(t, unknown.Error)
+	require.NotNil(t, missing.Error)
+}
+
+func TestAuditBundleTools_ExportAndVerify(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	store := createTestAuditStore(t)
+	for _, hgvs := range []string{"NM_000492.4:c.1521_1523del", "NM_007294.4:c.5266dupC", "NM_000546.6:c.743G>A"} {
+		require.NoError(t, store.Append(ctx, &audit.Record{
+			HGVSNotation:   hgvs,
+			Request:        []byte(`{"hgvs_notation":"` + hgvs + `"}`),
+			Classification: "Pathogenic",
+			EngineVersion:  service.EngineVersion,
+		}))
+	}
+	signer, err := audit.NewSigner("AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=")
+	require.NoError(t, err)
+	publicKey := base64.StdEncoding.EncodeToString(signer.PublicKey())
+
+	// Act
+	chain := NewVerifyAuditChainTool(logger, store).HandleTool(ctx, toolRequest("verify_audit_chain", nil))
+	export := NewExportAuditBundleTool(logger, store, signer).HandleTool(ctx, toolRequest("export_audit_bundle", map[string]interface{}{
+		"from_id": 2,
+	}))
+
+	// Assert
+	require.Nil(t, chain.Error)
+	assert.True(t, chain.Result.(map[string]interface{})["verification"].(*audit.Verification).Verified)
+	require.Nil(t, export.Error)
+	assert.Equal(t, 2, export.Result.(map[string]interface{})["count"])
+	assert.Equal(t, true, export.Result.(map[string]interface{})["signed"])
+
+	// The bundle is verified as an auditor receives it, as JSON
+	raw, err := json.Marshal(export.Result.(map[string]interface{})["bundle"])
+	require.NoError(t, err)
+	var bundle map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &bundle))
+	verify := NewVerifyAuditBundleTool(logger)
+	response := verify.HandleTool(ctx, toolRequest("verify_audit_bundle", map[string]interface{}{
+		"bundle": bundle, "public_key": publicKey,
+	}))
+	require.Nil(t, response.Error)
+	assert.True(t, response.Result.(map[string]interface{})["verification"].(*audit.Verification).Verified)
+	assert.Equal(t, audit.KeyID(signer.PublicKey()), response.Result.(map[string]interface{})["key_id"])
+
+	bundle["records"].([]interface{})[0].(map[string]interface{})["classification"] = "Benign"
+	response = verify.HandleTool(ctx, toolRequest("verify_audit_bundle", map[string]interface{}{"bundle": bundle}))
+	require.Nil(t, response.Error)
+	tampered := response.Result.(map[string]interface{})["verification"].(*audit.Verification)
+	assert.False(t, tampered.Verified)
+	assert.Contains(t, tampered.Problem, "modified")
+
+	// Unsigned bundles and malformed requests
+	unsigned := NewExportAuditBundleTool(logger, store, nil).HandleTool(ctx, toolRequest("export_audit_bundle", nil))
+	require.Nil(t, unsigned.Error)
+	assert.Equal(t, false, unsigned.Result.(map[string]interface{})["signed"])
+	require.NotNil(t, NewExportAuditBundleTool(logger, store, nil).HandleTool(ctx, toolRequest("export_audit_bundle", map[string]interface{}{
+		"from_id": 3, "to_id": 2,
+	})).Error)
+	require.NotNil(t, verify.HandleTool(ctx, toolRequest("verify_audit_bundle", map[string]interface{}{})).Error)
+	require.NotNil(t, verify.HandleTool(ctx, toolRequest("verify_audit_bundle", map[string]interface{}{
+		"bundle": bundle, "public_key": "not-a-key",
+	})).Error)
+}
+
+func TestExportAuditBundleTool_RedactsPHI(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	store := createTestAuditStore(t)
+	require.NoError(t, store.Append(ctx, &audit.Record{
+		HGVSNotation:  "NM_000492.4:c.1521_1523del",
+		Request:       []byte(`{"hgvs_notation":"NM_000492.4:c.1521_1523del","proband_id":"P-0042","clinical_context":"Recurrent pancreatitis"}`),
+		EngineVersion: service.EngineVersion,
+	}))
+	signer, err := audit.NewSigner("AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=")
+	require.NoError(t, err)
+	redactor, err := phi.NewRedactor(phi.Config{Mode: phi.ModeRedact})
+	require.NoError(t, err)
+	tool := NewExportAuditBundleTool(logger, store, signer)
+	tool.SetRedactor(redactor)
+
+	// Act
+	response := tool.HandleTool(ctx, toolRequest("export_audit_bundle", nil))
+
+	// Assert: patient data is gone from the export, and the bundle still verifies
+	require.Nil(t, response.Error)
+	bundle := response.Result.(map[string]interface{})["bundle"].(*audit.Bundle)
+	require.Len(t, bundle.Records, 1)
+	assert.True(t, bundle.Records[0].RequestRedacted)
+	assert.NotContains(t, string(bundle.Records[0].Request), "P-0042")
+	assert.NotContains(t, string(bundle.Records[0].Request), "pancreatitis")
+	assert.Contains(t, string(bundle.Records[0].Request), "NM_000492.4:c.1521_1523del")
+	verification, err := audit.VerifyBundle(bundle, signer.PublicKey())
+	require.NoError(t, err)
+	assert.True(t, verification.Verified)
+
+	stored, err := store.Get(ctx, bundle.Records[0].ID)
+	require.NoError(t, err)
+	assert.Contains(t, string(stored.Request), "P-0042", "the stored record is not changed")
+}
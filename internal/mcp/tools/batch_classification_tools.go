@@ -0,0 +1,320 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/webhook"
+)
+
+// Defaults for classify_variants_batch
+const (
+	DefaultBatchClassifyLimit   = 500
+	DefaultBatchClassifyWorkers = 8
+)
+
+// ClassifyVariantsBatchTool implements the classify_variants_batch MCP tool
+type ClassifyVariantsBatchTool struct {
+	logger       *logrus.Logger
+	classifyTool *ClassifyVariantTool
+	maxBatchSize int
+	workers      int
+	webhooks     webhook.Publisher
+}
+
+// ClassifyVariantsBatchParams defines parameters for the classify_variants_batch tool
+type ClassifyVariantsBatchParams struct {
+	HGVSNotations     []string `json:"hgvs_notations"`
+	ClinicalContext   string   `json:"clinical_context,omitempty"`
+	OrderingSpecialty string   `json:"ordering_specialty,omitempty"`
+	Condition         string   `json:"condition,omitempty"`
+	MaxConcurrent     int      `json:"max_concurrent,omitempty"`
+	BypassKnownBenign bool     `json:"bypass_known_benign,omitempty"` // Fully classify variants on the known benign list
+}
+
+// BatchClassificationItem is the outcome for a single variant in a batch
+type BatchClassificationItem struct {
+	Index          int                    `json:"index"`
+	HGVSNotation   string                 `json:"hgvs_notation"`
+	Result         *ClassifyVariantResult `json:"result,omitempty"`
+	Error          string                 `json:"error,omitempty"`
+	ProcessingTime string                 `json:"processing_time"`
+}
+
+// ClassifyVariantsBatchResult contains per-variant results in input order
+type ClassifyVariantsBatchResult struct {
+	TotalVariants         int                       `json:"total_variants"`
+	SucceededVariants     int                       `json:"succeeded_variants"`
+	FailedVariants        int                       `json:"failed_variants"`
+	ArtifactVariants      int                       `json:"artifact_variants"`     // Blacklisted probable artifacts, excluded from classification counts
+	KnownBenignVariants   int                       `json:"known_benign_variants"` // Returned from the known benign list without evidence lookups
+	ReclassifiedVariants  int                       `json:"reclassified_variants"` // Moved between benign, uncertain and pathogenic tiers since their previous classification
+	Cancelled             bool                      `json:"cancelled,omitempty"`   // Request cancelled mid-flight; unstarted variants report the cancellation as their error
+	Workers               int                       `json:"workers"`
+	TotalProcessingTime   string                    `json:"total_processing_time"`
+	AverageProcessingTime string                    `json:"average_processing_time"`
+	ClassificationCounts  map[string]int            `json:"classification_counts"`
+	Results               []BatchClassificationItem `json:"results"`
+}
+
+// NewClassifyVariantsBatchTool creates a new classify_variants_batch tool.
+// Non-positive limits fall back to the defaults.
+func NewClassifyVariantsBatchTool(logger *logrus.Logger, classifyTool *ClassifyVariantTool, maxBatchSize, workers int) *ClassifyVariantsBatchTool {
+	if maxBatchSize <= 0 {
+		maxBatchSize = DefaultBatchClassifyLimit
+	}
+	if workers <= 0 {
+		workers = DefaultBatchClassifyWorkers
+	}
+	return &ClassifyVariantsBatchTool{
+		logger:       logger,
+		classifyTool: classifyTool,
+		maxBatchSize: maxBatchSize,
+		workers:      workers,
+	}
+}
+
+// SetWebhookPublisher enables batch.completed events when a batch finishes
+func (t *ClassifyVariantsBatchTool) SetWebhookPublisher(publisher webhook.Publisher) {
+	t.webhooks = publisher
+}
+
+// GetToolInfo returns the tool information for classify_variants_batch
+func (t *ClassifyVariantsBatchTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "classify_variants_batch",
+		Description: fmt.Sprintf("Classify up to %d variants concurrently using ACMG/AMP guidelines. Returns per-variant results in input order; failures for individual variants do not fail the batch. Variants on the curator-confirmed known benign list return the confirmed call without evidence lookups. Sends progress notifications when the request carries a progress token.", t.maxBatchSize),
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"hgvs_notations": map[string]interface{}{
+					"type":        "array",
+					"description": "HGVS notations of the variants to classify",
+					"items":       map[string]interface{}{"type": "string"},
+					"minItems":    1,
+					"maxItems":    t.maxBatchSize,
+				},
+				"clinical_context": map[string]interface{}{
+					"type":        "string",
+					"description": "Clinical context applied to every variant in the batch",
+				},
+				"ordering_specialty": map[string]interface{}{
+					"type":        "string",
+					"description": "Ordering specialty applied to every variant; selects the specialty's mandated transcript set",
+				},
+				"condition": map[string]interface{}{
+					"type":        "string",
+					"description": "Condition applied to every variant; selects configured frequency thresholds",
+				},
+				"max_concurrent": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of variants classified concurrently",
+					"default":     t.workers,
+					"maximum":     t.workers,
+				},
+				"bypass_known_benign": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Fully classify variants on the known benign list instead of returning the confirmed call",
+					"default":     false,
+				},
+			},
+			"required": []string{"hgvs_notations"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ClassifyVariantsBatchTool) ValidateParams(params interface{}) error {
+	var p ClassifyVariantsBatchParams
+	return t.parseAndValidateParams(params, &p)
+}
+
+func (t *ClassifyVariantsBatchTool) parseAndValidateParams(params interface{}, target *ClassifyVariantsBatchParams) error {
+	if err := ParseParams(params, target); err != nil {
+		return err
+	}
+	if len(target.HGVSNotations) == 0 {
+		return fmt.Errorf("hgvs_notations cannot be empty")
+	}
+	if len(target.HGVSNotations) > t.maxBatchSize {
+		return fmt.Errorf("maximum batch size is %d, received %d", t.maxBatchSize, len(target.HGVSNotations))
+	}
+	if target.MaxConcurrent <= 0 || target.MaxConcurrent > t.workers {
+		target.MaxConcurrent = t.workers
+	}
+	return nil
+}
+
+// HandleTool handles the classify_variants_batch tool request
+func (t *ClassifyVariantsBatchTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	startTime := time.Now()
+	t.logger.WithField("tool", "classify_variants_batch").Info("Processing batch classification request")
+
+	var params ClassifyVariantsBatchParams
+	if err := t.parseAndValidateParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	result := t.classifyBatch(ctx, &params)
+	result.TotalProcessingTime = time.Since(startTime).String()
+
+	t.logger.WithFields(logrus.Fields{
+		"total_variants":  result.TotalVariants,
+		"succeeded":       result.SucceededVariants,
+		"failed":          result.FailedVariants,
+		"workers":         result.Workers,
+		"cancelled":       result.Cancelled,
+		"processing_time": result.TotalProcessingTime,
+	}).Info("Batch classification completed")
+
+	if t.webhooks != nil {
+		t.webhooks.Publish(ctx, &webhook.Event{Type: webhook.EventBatchCompleted, Data: batchCompletedData(result)})
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"batch_classification": result,
+		},
+	}
+}
+
+// batchCompletedData summarizes a batch for the batch.completed event.
+// Per-variant results are left out to keep deliveries small; reclassified
+// variants are listed since they usually need a notice.
+func batchCompletedData(result *ClassifyVariantsBatchResult) map[string]interface{} {
+	reclassified := []string{}
+	for _, item := range result.Results {
+		if item.Result != nil && item.Result.Reclassification != nil && item.Result.Reclassification.NotificationRecommended {
+			reclassified = append(reclassified, item.HGVSNotation)
+		}
+	}
+	return map[string]interface{}{
+		"total_variants":        result.TotalVariants,
+		"succeeded_variants":    result.SucceededVariants,
+		"failed_variants":       result.FailedVariants,
+		"artifact_variants":     result.ArtifactVariants,
+		"known_benign_variants": result.KnownBenignVariants,
+		"reclassified_variants": reclassified,
+		"classification_counts": result.ClassificationCounts,
+		"cancelled":             result.Cancelled,
+		"total_processing_time": result.TotalProcessingTime,
+	}
+}
+
+// classifyBatch classifies variants with a bounded worker pool, preserving input order
+func (t *ClassifyVariantsBatchTool) classifyBatch(ctx context.Context, params *ClassifyVariantsBatchParams) *ClassifyVariantsBatchResult {
+	items := make([]BatchClassificationItem, len(params.HGVSNotations))
+	jobs := make(chan int)
+	total := float64(len(items))
+
+	// Progress is reported under a lock so notifications are strictly increasing
+	var progressMu sync.Mutex
+	completed := 0
+
+	var wg sync.WaitGroup
+	for w := 0; w < params.MaxConcurrent; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				items[i] = t.classifyOne(ctx, i, params.HGVSNotations[i], params)
+
+				progressMu.Lock()
+				completed++
+				reportProgress(ctx, float64(completed), total, fmt.Sprintf("Classified %d of %d variants", completed, len(items)))
+				progressMu.Unlock()
+			}
+		}()
+	}
+
+	for i := range params.HGVSNotations {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	result := &ClassifyVariantsBatchResult{
+		TotalVariants:        len(items),
+		Workers:              params.MaxConcurrent,
+		ClassificationCounts: make(map[string]int),
+		Results:              items,
+		Cancelled:            ctx.Err() != nil,
+	}
+
+	var busy time.Duration
+	for _, item := range items {
+		if d, err := time.ParseDuration(item.ProcessingTime); err == nil {
+			busy += d
+		}
+		if item.Error != "" {
+			result.FailedVariants++
+			continue
+		}
+		result.SucceededVariants++
+		if item.Result.KnownBenign != nil && !params.BypassKnownBenign {
+			result.KnownBenignVariants++
+		}
+		if diff := item.Result.Reclassification; diff != nil && diff.NotificationRecommended {
+			result.ReclassifiedVariants++
+		}
+		if item.Result.ProbableArtifact != nil {
+			result.ArtifactVariants++
+			continue
+		}
+		result.ClassificationCounts[item.Result.Classification]++
+	}
+	if len(items) > 0 {
+		result.AverageProcessingTime = (busy / time.Duration(len(items))).String()
+	}
+
+	return result
+}
+
+// classifyOne classifies a single variant; errors are captured rather than returned
+func (t *ClassifyVariantsBatchTool) classifyOne(ctx context.Context, index int, notation string, batch *ClassifyVariantsBatchParams) BatchClassificationItem {
+	start := time.Now()
+	item := BatchClassificationItem{Index: index, HGVSNotation: notation}
+
+	if err := ctx.Err(); err != nil {
+		item.Error = err.Error()
+	} else {
+		params := &ClassifyVariantParams{
+			HGVSNotation:      strings.TrimSpace(notation),
+			ClinicalContext:   batch.ClinicalContext,
+			OrderingSpecialty: batch.OrderingSpecialty,
+			Condition:         batch.Condition,
+		}
+		if err := t.classifyTool.validateNotationParameters(params); err != nil {
+			item.Error = err.Error()
+		} else if err := t.classifyTool.validateNotationFormats(params); err != nil {
+			item.Error = err.Error()
+		} else if cached := t.knownBenignResult(ctx, params, batch); cached != nil {
+			item.Result = cached
+		} else if result, err := t.classifyTool.classifyVariant(ctx, params); err != nil {
+			item.Error = err.Error()
+		} else {
+			item.Result = result
+		}
+	}
+
+	elapsed := time.Since(start)
+	item.ProcessingTime = elapsed.String()
+	if item.Result != nil {
+		item.Result.ProcessingTime = item.ProcessingTime
+	}
+	return item
+}
+
+// knownBenignResult returns the curator-endorsed call for a listed variant unless the batch bypasses the list
+func (t *ClassifyVariantsBatchTool) knownBenignResult(ctx context.Context, params *ClassifyVariantParams, batch *ClassifyVariantsBatchParams) *ClassifyVariantResult {
+	if batch.BypassKnownBenign {
+		return nil
+	}
+	return t.classifyTool.knownBenignResult(ctx, params)
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+func TestClassifyVariantsBatchTool_PartialFailures(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewClassifyVariantsBatchTool(logger, NewClassifyVariantToolLegacy(logger, nil), 10, 3)
+
+	req := &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "classify_variants_batch",
+		Params: map[string]interface{}{
+			"hgvs_notations": []string{"NM_000492.3:c.1521_1523delCTT", "not-hgvs", "NM_007294.4:c.5266dupC"},
+		},
+		ID: 1,
+	}
+
+	// Act
+	response := tool.HandleTool(context.Background(), req)
+
+	// Assert
+	require.Nil(t, response.Error)
+	result := response.Result.(map[string]interface{})["batch_classification"].(*ClassifyVariantsBatchResult)
+	assert.Equal(t, 3, result.TotalVariants)
+	assert.Equal(t, 3, result.Workers)
+	assert.Equal(t, 3, result.FailedVariants)
+	require.Len(t, result.Results, 3)
+	for i, item := range result.Results {
+		assert.Equal(t, i, item.Index)
+		assert.NotEmpty(t, item.Error)
+	}
+	assert.Equal(t, "not-hgvs", result.Results[1].HGVSNotation)
+	assert.Contains(t, result.Results[1].Error, "invalid HGVS notation format")
+	assert.Contains(t, result.Results[0].Error, "classification service not configured")
+	assert.NotEmpty(t, result.TotalProcessingTime)
+}
+
+func TestClassifyVariantsBatchTool_BatchLimit(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewClassifyVariantsBatchTool(logger, NewClassifyVariantToolLegacy(logger, nil), 2, 0)
+
+	err := tool.ValidateParams(map[string]interface{}{
+		"hgvs_notations": []string{"NM_000492.3:c.1A>G", "NM_000492.3:c.2A>G", "NM_000492.3:c.3A>G"},
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, DefaultBatchClassifyWorkers, tool.workers)
+}
+
+func TestClassifyVariantsBatchTool_Progress(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewClassifyVariantsBatchTool(logger, NewClassifyVariantToolLegacy(logger, nil), 10, 3)
+
+	var progress []float64
+	var totals []float64
+	ctx := WithProgress(context.Background(), func(p, total float64, message string) {
+		progress = append(progress, p)
+		totals = append(totals, total)
+		assert.Contains(t, message, "variants")
+	})
+
+	req := &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "classify_variants_batch",
+		Params: map[string]interface{}{
+			"hgvs_notations": []string{"NM_000492.3:c.1A>G", "NM_000492.3:c.2A>G", "NM_000492.3:c.3A>G", "NM_000492.3:c.4A>G"},
+		},
+		ID: 1,
+	}
+	response := tool.HandleTool(ctx, req)
+
+	require.Nil(t, response.Error)
+	assert.Equal(t, []float64{1, 2, 3, 4}, progress)
+	assert.Equal(t, []float64{4, 4, 4, 4}, totals)
+}
+
+func TestClassifyVariantsBatchTool_Cancelled(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewClassifyVariantsBatchTool(logger, NewClassifyVariantToolLegacy(logger, nil), 10, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "classify_variants_batch",
+		Params: map[string]interface{}{
+			"hgvs_notations": []string{"NM_000492.3:c.1A>G", "NM_000492.3:c.2A>G"},
+		},
+		ID: 1,
+	}
+	response := tool.HandleTool(ctx, req)
+
+	require.Nil(t, response.Error)
+	result := response.Result.(map[string]interface{})["batch_classification"].(*ClassifyVariantsBatchResult)
+	assert.True(t, result.Cancelled)
+	assert.Equal(t, 2, result.FailedVariants)
+	assert.Contains(t, result.Results[0].Error, context.Canceled.Error())
+}
@@ -0,0 +1,275 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/benign"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// benignStoreError maps known benign store errors to tool responses
+func benignStoreError(logger *logrus.Logger, action string, err error) *protocol.JSONRPC2Response {
+	switch {
+	case errors.Is(err, benign.ErrNotFound):
+		return invalidParamsError("Known benign entry not found", err.Error())
+	case errors.Is(err, benign.ErrInvalidEntry),
+		errors.Is(err, benign.ErrAlreadyListed):
+		return invalidParamsError(err.Error())
+	}
+	logger.WithError(err).Errorf("Failed to %s", action)
+	return internalError("Failed to "+action, err.Error())
+}
+
+// =============================================================================
+// Add Known Benign Tool
+// =============================================================================
+
+// AddKnownBenignTool implements the add_known_benign MCP tool
+type AddKnownBenignTool struct {
+	logger *logrus.Logger
+	store  benign.Store
+}
+
+// AddKnownBenignParams defines parameters for the add_known_benign tool
+type AddKnownBenignParams struct {
+	NormalizedHGVS string `json:"normalized_hgvs"`
+	GeneSymbol     string `json:"gene_symbol,omitempty"`
+	Classification string `json:"classification"`
+	Evidence       string `json:"evidence"`
+	CuratorID      string `json:"curator_id"`
+}
+
+// NewAddKnownBenignTool creates a new add_known_benign tool
+func NewAddKnownBenignTool(logger *logrus.Logger, store benign.Store) *AddKnownBenignTool {
+	return &AddKnownBenignTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for add_known_benign
+func (t *AddKnownBenignTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "add_known_benign",
+		Description: "Add a curator-confirmed benign or likely benign variant to the lab's known benign list. Batch classification returns the confirmed call for listed variants without repeating evidence lookups.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"normalized_hgvs": map[string]interface{}{
+					"type":        "string",
+					"description": "Normalized HGVS notation of the variant, as submitted for classification",
+				},
+				"gene_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Gene symbol, used to list entries by gene",
+				},
+				"classification": map[string]interface{}{
+					"type":        "string",
+					"description": "Confirmed classification",
+					"enum":        []string{benign.Benign, benign.LikelyBenign},
+				},
+				"evidence": map[string]interface{}{
+					"type":        "string",
+					"description": "Basis for the call, e.g. BA1 with the gnomAD popmax frequency",
+				},
+				"curator_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the curator confirming the call",
+				},
+			},
+			"required": []string{"normalized_hgvs", "classification", "evidence", "curator_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *AddKnownBenignTool) ValidateParams(params interface{}) error {
+	var p AddKnownBenignParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.NormalizedHGVS) == "" {
+		return fmt.Errorf("normalized_hgvs is required")
+	}
+	if _, ok := benign.NormalizeClassification(p.Classification); !ok {
+		return fmt.Errorf("classification must be %s or %s", benign.Benign, benign.LikelyBenign)
+	}
+	if strings.TrimSpace(p.Evidence) == "" {
+		return fmt.Errorf("evidence is required")
+	}
+	if strings.TrimSpace(p.CuratorID) == "" {
+		return fmt.Errorf("curator_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the add_known_benign tool request
+func (t *AddKnownBenignTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params AddKnownBenignParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	entry := &benign.Entry{
+		NormalizedHGVS: params.NormalizedHGVS,
+		GeneSymbol:     params.GeneSymbol,
+		Classification: params.Classification,
+		Evidence:       params.Evidence,
+		ConfirmedBy:    params.CuratorID,
+	}
+	if err := t.store.Add(ctx, entry); err != nil {
+		return benignStoreError(t.logger, "add known benign variant", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"entry": entry,
+		},
+	}
+}
+
+// =============================================================================
+// Remove Known Benign Tool
+// =============================================================================
+
+// RemoveKnownBenignTool implements the remove_known_benign MCP tool
+type RemoveKnownBenignTool struct {
+	logger *logrus.Logger
+	store  benign.Store
+}
+
+// RemoveKnownBenignParams defines parameters for the remove_known_benign tool
+type RemoveKnownBenignParams struct {
+	EntryID int64 `json:"entry_id"`
+}
+
+// NewRemoveKnownBenignTool creates a new remove_known_benign tool
+func NewRemoveKnownBenignTool(logger *logrus.Logger, store benign.Store) *RemoveKnownBenignTool {
+	return &RemoveKnownBenignTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for remove_known_benign
+func (t *RemoveKnownBenignTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "remove_known_benign",
+		Description: "Remove a variant from the known benign list so it is fully classified again.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"entry_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID of the known benign entry",
+				},
+			},
+			"required": []string{"entry_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *RemoveKnownBenignTool) ValidateParams(params interface{}) error {
+	var p RemoveKnownBenignParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.EntryID <= 0 {
+		return fmt.Errorf("entry_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the remove_known_benign tool request
+func (t *RemoveKnownBenignTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params RemoveKnownBenignParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	if err := t.store.Remove(ctx, params.EntryID); err != nil {
+		return benignStoreError(t.logger, "remove known benign variant", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"success": true,
+			"message": fmt.Sprintf("Removed known benign entry %d", params.EntryID),
+		},
+	}
+}
+
+// =============================================================================
+// List Known Benign Tool
+// =============================================================================
+
+// ListKnownBenignTool implements the list_known_benign MCP tool
+type ListKnownBenignTool struct {
+	logger *logrus.Logger
+	store  benign.Store
+}
+
+// ListKnownBenignParams defines parameters for the list_known_benign tool
+type ListKnownBenignParams struct {
+	GeneSymbol string `json:"gene_symbol,omitempty"`
+}
+
+// NewListKnownBenignTool creates a new list_known_benign tool
+func NewListKnownBenignTool(logger *logrus.Logger, store benign.Store) *ListKnownBenignTool {
+	return &ListKnownBenignTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for list_known_benign
+func (t *ListKnownBenignTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "list_known_benign",
+		Description: "List the lab's curator-confirmed known benign variants, ordered by variant.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gene_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Only list entries for this gene",
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ListKnownBenignTool) ValidateParams(params interface{}) error {
+	return nil // No required parameters
+}
+
+// HandleTool handles the list_known_benign tool request
+func (t *ListKnownBenignTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ListKnownBenignParams
+	_ = ParseParams(req.Params, &params)
+
+	entries, err := t.store.List(ctx, params.GeneSymbol)
+	if err != nil {
+		return benignStoreError(t.logger, "list known benign variants", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"entries": entries,
+			"total":   len(entries),
+		},
+	}
+}
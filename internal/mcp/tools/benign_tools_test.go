@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/benign"
+)
+
+func createTestBenignStore(t *testing.T) *benign.SQLiteStore {
+	t.Helper()
+
+	store, err := benign.NewSQLiteStore(filepath.Join(t.TempDir(), "known_benign.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestKnownBenignTools_AddAndList(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestBenignStore(t)
+	add := NewAddKnownBenignTool(logger, store)
+	list := NewListKnownBenignTool(logger, store)
+
+	// Act
+	added := add.HandleTool(context.Background(), toolRequest("add_known_benign", map[string]interface{}{
+		"normalized_hgvs": "NM_007294.4:c.4308T>C",
+		"gene_symbol":     "BRCA1",
+		"classification":  "benign",
+		"evidence":        "BA1: gnomAD popmax AF 0.21",
+		"curator_id":      "curator-1",
+	}))
+	duplicate := add.HandleTool(context.Background(), toolRequest("add_known_benign", map[string]interface{}{
+		"normalized_hgvs": "NM_007294.4:c.4308T>C",
+		"classification":  "benign",
+		"evidence":        "BA1",
+		"curator_id":      "curator-2",
+	}))
+	pathogenic := add.HandleTool(context.Background(), toolRequest("add_known_benign", map[string]interface{}{
+		"normalized_hgvs": "NM_007294.4:c.5266dup",
+		"classification":  "pathogenic",
+		"evidence":        "PVS1",
+		"curator_id":      "curator-1",
+	}))
+	listed := list.HandleTool(context.Background(), toolRequest("list_known_benign", map[string]interface{}{"gene_symbol": "brca1"}))
+
+	// Assert
+	require.Nil(t, added.Error)
+	assert.Equal(t, benign.Benign, added.Result.(map[string]interface{})["entry"].(*benign.Entry).Classification)
+	require.NotNil(t, duplicate.Error)
+	require.NotNil(t, pathogenic.Error)
+	require.Nil(t, listed.Error)
+	assert.Equal(t, 1, listed.Result.(map[string]interface{})["total"])
+}
+
+func TestClassifyVariantsBatchTool_KnownBenignShortCircuit(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestBenignStore(t)
+	require.NoError(t, store.Add(context.Background(), &benign.Entry{
+		NormalizedHGVS: "NM_007294.4:c.4308T>C",
+		Classification: "likely benign",
+		Evidence:       "BS1: observed in 40 unaffected controls",
+		ConfirmedBy:    "curator-1",
+	}))
+	auditStore := createTestAuditStore(t)
+	classifyTool := NewClassifyVariantToolLegacy(logger, nil)
+	classifyTool.SetKnownBenignStore(store)
+	classifyTool.SetAuditStore(auditStore)
+	tool := NewClassifyVariantsBatchTool(logger, classifyTool, 10, 2)
+	notations := []string{"NM_007294.4:c.4308T>C", "NM_000492.3:c.1521_1523delCTT"}
+
+	// Act
+	response := tool.HandleTool(context.Background(), toolRequest("classify_variants_batch", map[string]interface{}{
+		"hgvs_notations": notations,
+	}))
+	bypassed := tool.HandleTool(context.Background(), toolRequest("classify_variants_batch", map[string]interface{}{
+		"hgvs_notations":      notations[:1],
+		"bypass_known_benign": true,
+	}))
+
+	// Assert: the listed variant needs no classifier service; the other fails without one
+	require.Nil(t, response.Error)
+	result := response.Result.(map[string]interface{})["batch_classification"].(*ClassifyVariantsBatchResult)
+	assert.Equal(t, 1, result.SucceededVariants)
+	assert.Equal(t, 1, result.FailedVariants)
+	assert.Equal(t, 1, result.KnownBenignVariants)
+	assert.Equal(t, 1, result.ClassificationCounts[benign.LikelyBenign])
+	cached := result.Results[0].Result
+	require.NotNil(t, cached)
+	require.NotNil(t, cached.KnownBenign)
+	assert.Equal(t, benign.LikelyBenign, cached.Classification)
+	assert.Contains(t, cached.EvidenceSummary, "curator-1")
+
+	records, err := auditStore.Query(context.Background(), audit.Filter{Variant: notations[0]})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, benign.LikelyBenign, records[0].Classification)
+
+	bypassResult := bypassed.Result.(map[string]interface{})["batch_classification"].(*ClassifyVariantsBatchResult)
+	assert.Equal(t, 0, bypassResult.KnownBenignVariants)
+	assert.Equal(t, 1, bypassResult.FailedVariants)
+}
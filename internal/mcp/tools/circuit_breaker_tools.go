@@ -0,0 +1,195 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// CircuitBreakerParams defines parameters for the circuit breaker override tools
+type CircuitBreakerParams struct {
+	Breaker  string `json:"breaker"`
+	Reason   string `json:"reason,omitempty"`
+	Operator string `json:"operator,omitempty"` // Recorded when the caller is not authenticated, e.g. over stdio
+}
+
+// breakerSchema returns the input schema of a circuit breaker override tool
+func breakerSchema(breakers *external.BreakerRegistry, required ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"breaker": map[string]interface{}{
+				"type":        "string",
+				"description": "Breaker name, case-insensitive: " + strings.Join(breakers.Names(), ", "),
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"description": "Why the override is needed, shown on the circuit breaker dashboard",
+			},
+			"operator": map[string]interface{}{
+				"type":        "string",
+				"description": "Who made the override; the authenticated caller is recorded instead when there is one",
+			},
+		},
+		"required": append([]string{"breaker"}, required...),
+	}
+}
+
+// operatorFor returns the authenticated caller or, without one, the operator parameter
+func operatorFor(ctx context.Context, params CircuitBreakerParams) string {
+	if principal := auth.PrincipalFrom(ctx); principal != nil {
+		return principal.Subject
+	}
+	return strings.TrimSpace(params.Operator)
+}
+
+// =============================================================================
+// Force Open Circuit Breaker Tool
+// =============================================================================
+
+// ForceOpenCircuitBreakerTool implements the force_open_circuit_breaker MCP tool
+type ForceOpenCircuitBreakerTool struct {
+	logger   *logrus.Logger
+	breakers *external.BreakerRegistry
+}
+
+// NewForceOpenCircuitBreakerTool creates a new force_open_circuit_breaker tool
+func NewForceOpenCircuitBreakerTool(logger *logrus.Logger, breakers *external.BreakerRegistry) *ForceOpenCircuitBreakerTool {
+	return &ForceOpenCircuitBreakerTool{
+		logger:   logger,
+		breakers: breakers,
+	}
+}
+
+// GetToolInfo returns the tool information for force_open_circuit_breaker
+func (t *ForceOpenCircuitBreakerTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name: "force_open_circuit_breaker",
+		Description: "Force an external API's circuit breaker open so no requests reach a degraded upstream. Classification continues " +
+			"with cached and other sources' evidence. The breaker stays open until reset_circuit_breaker.",
+		InputSchema: breakerSchema(t.breakers, "reason"),
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ForceOpenCircuitBreakerTool) ValidateParams(params interface{}) error {
+	var p CircuitBreakerParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.Breaker) == "" {
+		return fmt.Errorf("breaker is required")
+	}
+	if strings.TrimSpace(p.Reason) == "" {
+		return fmt.Errorf("reason is required")
+	}
+	return nil
+}
+
+// HandleTool handles the force_open_circuit_breaker tool request
+func (t *ForceOpenCircuitBreakerTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params CircuitBreakerParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	breaker, err := t.breakers.Get(params.Breaker)
+	if err != nil {
+		return invalidParamsError(err.Error())
+	}
+	operator := operatorFor(ctx, params)
+	breaker.ForceOpen(operator, strings.TrimSpace(params.Reason))
+
+	t.logger.WithFields(logrus.Fields{
+		"circuit_breaker": breaker.Name(),
+		"operator":        operator,
+		"reason":          params.Reason,
+	}).Warn("Circuit breaker forced open")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"circuit_breaker": breaker.Status(),
+		},
+	}
+}
+
+// =============================================================================
+// Reset Circuit Breaker Tool
+// =============================================================================
+
+// ResetCircuitBreakerTool implements the reset_circuit_breaker MCP tool
+type ResetCircuitBreakerTool struct {
+	logger   *logrus.Logger
+	breakers *external.BreakerRegistry
+}
+
+// NewResetCircuitBreakerTool creates a new reset_circuit_breaker tool
+func NewResetCircuitBreakerTool(logger *logrus.Logger, breakers *external.BreakerRegistry) *ResetCircuitBreakerTool {
+	return &ResetCircuitBreakerTool{
+		logger:   logger,
+		breakers: breakers,
+	}
+}
+
+// GetToolInfo returns the tool information for reset_circuit_breaker
+func (t *ResetCircuitBreakerTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name: "reset_circuit_breaker",
+		Description: "Close an external API's circuit breaker and clear its failure counts, whether it was forced open or tripped " +
+			"by failures, so requests reach the upstream again at once instead of after the open timeout.",
+		InputSchema: breakerSchema(t.breakers),
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ResetCircuitBreakerTool) ValidateParams(params interface{}) error {
+	var p CircuitBreakerParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.Breaker) == "" {
+		return fmt.Errorf("breaker is required")
+	}
+	return nil
+}
+
+// HandleTool handles the reset_circuit_breaker tool request
+func (t *ResetCircuitBreakerTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params CircuitBreakerParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	breaker, err := t.breakers.Get(params.Breaker)
+	if err != nil {
+		return invalidParamsError(err.Error())
+	}
+	previous := breaker.Status()
+	breaker.Reset()
+
+	t.logger.WithFields(logrus.Fields{
+		"circuit_breaker": breaker.Name(),
+		"operator":        operatorFor(ctx, params),
+		"previous_state":  previous.State,
+		"reason":          params.Reason,
+	}).Warn("Circuit breaker reset")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"previous_state":  previous.State,
+			"circuit_breaker": breaker.Status(),
+		},
+	}
+}
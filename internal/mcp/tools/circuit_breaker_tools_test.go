@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+func TestCircuitBreakerTools_ForceOpenAndReset(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	breaker := external.NewBreaker(gobreaker.Settings{Name: "ToolTestSource"})
+	forceOpen := NewForceOpenCircuitBreakerTool(logger, external.CircuitBreakers)
+	reset := NewResetCircuitBreakerTool(logger, external.CircuitBreakers)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "oncall", Role: auth.RoleAdmin})
+
+	// Act
+	opened := forceOpen.HandleTool(ctx, toolRequest("force_open_circuit_breaker", map[string]interface{}{
+		"breaker":  "tooltestsource",
+		"reason":   "upstream returning stale data",
+		"operator": "ignored",
+	}))
+
+	// Assert
+	require.Nil(t, opened.Error)
+	status := opened.Result.(map[string]interface{})["circuit_breaker"].(external.BreakerStatus)
+	assert.Equal(t, "open", status.State)
+	assert.True(t, status.ForcedOpen)
+	assert.Equal(t, "oncall", status.ForcedBy, "the authenticated caller is recorded")
+	_, err := breaker.Execute(func() (interface{}, error) { return nil, nil })
+	assert.True(t, errors.Is(err, gobreaker.ErrOpenState))
+
+	reopened := reset.HandleTool(context.Background(), toolRequest("reset_circuit_breaker", map[string]interface{}{
+		"breaker": "ToolTestSource",
+	}))
+	require.Nil(t, reopened.Error)
+	result := reopened.Result.(map[string]interface{})
+	assert.Equal(t, "open", result["previous_state"])
+	assert.Equal(t, "closed", result["circuit_breaker"].(external.BreakerStatus).State)
+	assert.Equal(t, gobreaker.StateClosed, breaker.State())
+}
+
+func TestCircuitBreakerTools_Invalid(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	forceOpen := NewForceOpenCircuitBreakerTool(logger, external.CircuitBreakers)
+	reset := NewResetCircuitBreakerTool(logger, external.CircuitBreakers)
+
+	noReason := forceOpen.HandleTool(context.Background(), toolRequest("force_open_circuit_breaker", map[string]interface{}{
+		"breaker": "ClinVar",
+	}))
+	require.NotNil(t, noReason.Error)
+	assert.Contains(t, noReason.Error.Message, "reason is required")
+
+	unknown := reset.HandleTool(context.Background(), toolRequest("reset_circuit_breaker", map[string]interface{}{
+		"breaker": "NoSuchSource",
+	}))
+	require.NotNil(t, unknown.Error)
+	assert.Contains(t, unknown.Error.Message, "unknown circuit breaker")
+}
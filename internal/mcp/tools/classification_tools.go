@@ -2,15 +2,38 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/acmg-amp-mcp-server/internal/artifact"
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/benign"
+	"github.com/acmg-amp-mcp-server/internal/cohort"
+	"github.com/acmg-amp-mcp-server/internal/conflicts"
 	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/fhir"
+	"github.com/acmg-amp-mcp-server/internal/followup"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/playbook"
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+	"github.com/acmg-amp-mcp-server/internal/regions"
+	"github.com/acmg-amp-mcp-server/internal/ruleversions"
 	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+	"github.com/acmg-amp-mcp-server/internal/variantid"
+	"github.com/acmg-amp-mcp-server/internal/vrs"
+	"github.com/acmg-amp-mcp-server/internal/webhook"
+)
+
+// Output formats of classify_variant
+const (
+	OutputFormatStandard = "standard"
+	OutputFormatVASpec   = "va_spec" // Adds a GA4GH VA-Spec pathogenicity statement
+	OutputFormatFHIR     = "fhir"    // Adds an HL7 FHIR R4 Genomics Reporting bundle
 )
 
 // ClassifyVariantTool implements the classify_variant MCP tool
@@ -18,6 +41,16 @@ type ClassifyVariantTool struct {
 	logger            *logrus.Logger
 	classifierService *service.ClassifierService
 	inputParser       domain.InputParser
+	playbooks         playbook.Store
+	flags             followup.Store
+	cohort            cohort.Store
+	artifactCriteria  cohort.ArtifactCriteria
+	artifacts         artifact.Store
+	audit             audit.Store
+	knownBenign       benign.Store
+	identifiers       *variantid.Resolver
+	webhooks          webhook.Publisher
+	bundles           snapshot.Store
 }
 
 // ClassifyVariantParams defines parameters for the classify_variant tool
@@ -33,6 +66,19 @@ type ClassifyVariantParams struct {
 	PreferredIsoform   string `json:"preferred_isoform,omitempty"`   // Override transcript selection
 	ClinicalContext    string `json:"clinical_context,omitempty"`
 	IncludeEvidence    bool   `json:"include_evidence,omitempty"`
+	ScoringMode        string `json:"scoring_mode,omitempty"`
+	OrderingSpecialty  string `json:"ordering_specialty,omitempty"` // Selects the specialty's mandated transcript set
+	Condition          string `json:"condition,omitempty"` // Selects gene/condition-specific frequency thresholds
+	ProbandID          string `json:"proband_id,omitempty"` // Records the observation in the in-house cohort
+	Zygosity           string `json:"zygosity,omitempty"`
+	ClassificationContext string `json:"classification_context,omitempty"` // germline (default) or somatic
+	TumorType          string `json:"tumor_type,omitempty"` // Patient's tumor type, for somatic tiering
+	PatientContext     *service.PatientContext `json:"patient_context,omitempty"` // De novo evidence for PS2 and PM6, segregation for PP1 and BS4, phase for PM3 and BP2
+	OutputFormat       string `json:"output_format,omitempty"` // standard (default), va_spec or fhir
+	RefreshEvidence    bool   `json:"refresh_evidence,omitempty"` // Bypass the evidence cache
+	RulesVersion       string `json:"rules_version,omitempty"` // Rule set version, e.g. acmg2015 (default) or clingen-svi-2023
+
+	TranscriptConsequences []domain.TranscriptConsequence `json:"transcript_consequences,omitempty"`
 }
 
 // ClassifyVariantResult defines the result structure for classify_variant tool
@@ -44,18 +90,63 @@ type ClassifyVariantResult struct {
 	EvidenceSummary string                 `json:"evidence_summary"`
 	Recommendations []string               `json:"recommendations"`
 	ProcessingTime  string                 `json:"processing_time"`
+	GenePlaybook    *playbook.Playbook     `json:"gene_playbook,omitempty"`
+	MultiTranscript *service.MultiTranscriptAssessment `json:"multi_transcript,omitempty"`
+	Transcript      string                 `json:"transcript,omitempty"` // Transcript the criteria were evaluated on
+	TranscriptConsequences []domain.TranscriptConsequence `json:"transcript_consequences,omitempty"` // Consequence on each overlapping transcript, MANE Select first
+	FollowUpFlags   []*followup.Flag       `json:"followup_flags,omitempty"`
+	ReclassificationBlocked bool           `json:"reclassification_blocked,omitempty"`
+	ThresholdRevision int64                `json:"threshold_revision,omitempty"`
+	ConfigCommit    string                 `json:"config_commit,omitempty"`
+	DatasetVersions provenance.Versions    `json:"dataset_versions,omitempty"` // ClinVar release, gnomAD dataset and dbNSFP build used
+	FrequencyThresholds *service.FrequencyThresholds `json:"frequency_thresholds,omitempty"` // BA1, BS1 and PM2 cutoffs applied and their source
+	ConflictingEvidence []conflicts.Decision `json:"conflicting_evidence,omitempty"` // Conflicting applied criteria and how each was resolved
+	ScoringMode     string                 `json:"scoring_mode"`
+	RulesVersion    string                 `json:"rules_version,omitempty"` // Rule set version the criteria were evaluated under
+	WeightingPolicy *service.WeightingPolicyResult `json:"weighting_policy,omitempty"` // Laboratory weighting policy in effect and the overrides it applied
+	PointTotal      int                    `json:"point_total"`
+	ConfidenceScore float64                `json:"confidence_score,omitempty"`
+	PosteriorProbability float64           `json:"posterior_probability,omitempty"`
+	VUSTier         service.VUSTier        `json:"vus_tier,omitempty"` // hot, warm or cool, for a VUS
+	Specification   string                 `json:"vcep_specification,omitempty"`
+	CohortFrequency *cohort.Frequency      `json:"cohort_frequency,omitempty"`
+	ProbableArtifact *artifact.Entry       `json:"probable_artifact,omitempty"`
+	RegionCaveats   []regions.Caveat       `json:"region_caveats,omitempty"`
+	SpecialtyTranscript *service.SpecialtyTranscript `json:"specialty_transcript,omitempty"`
+	KnownBenign     *benign.Entry          `json:"known_benign,omitempty"` // Curator-confirmed entry on the known benign list
+	Reclassification *audit.Diff           `json:"reclassification,omitempty"` // Changes since the variant's previous recorded classification
+	ResolvedFrom    *domain.IdentifierMapping `json:"resolved_from,omitempty"` // rsID or ClinVar accession the variant was given as
+	VRSID           string                 `json:"vrs_id,omitempty"` // GA4GH VRS computed identifier, if a reference genome is configured
+	VRSAllele       *vrs.Allele            `json:"vrs_allele,omitempty"`
+	Normalized      *domain.NormalizedVariant `json:"normalized,omitempty"` // Normalized genomic and transcript notation, if a reference genome is configured
+	ClassificationContext string           `json:"classification_context,omitempty"`
+	Somatic         *service.SomaticAssessment `json:"somatic,omitempty"` // AMP/ASCO/CAP tier and the evidence it rests on
+	Structural      *service.StructuralAssessment `json:"structural,omitempty"` // Repeat expansion or balanced structural variant, left for manual review
+	EvidenceSnapshotID int64               `json:"evidence_snapshot_id,omitempty"` // Stored evidence bundle, for replay_classification
+	Provisional     bool                   `json:"provisional,omitempty"` // Made without a source that failed or missed its deadline
+	IncompleteEvidence []string            `json:"incomplete_evidence,omitempty"` // Evidence categories missing a source
+	EvidenceSources []domain.SourceStatus  `json:"evidence_sources,omitempty"` // How each evidence source fared
+	Cached          bool                   `json:"cached,omitempty"` // Served from the result cache
+	Evidence        *domain.AggregatedEvidence `json:"-"` // Evidence the rules were evaluated against, for explain_classification
 }
 
 // ACMGAMPRuleResult represents a single ACMG/AMP rule evaluation result
 type ACMGAMPRuleResult struct {
-	RuleCode    string  `json:"rule_code"`
-	RuleName    string  `json:"rule_name"`
-	Category    string  `json:"category"` // "pathogenic", "benign", "other"
-	Strength    string  `json:"strength"` // "very_strong", "strong", "moderate", "supporting"
-	Applied     bool    `json:"applied"`
-	Confidence  float64 `json:"confidence"`
-	Evidence    string  `json:"evidence,omitempty"`
-	Reasoning   string  `json:"reasoning,omitempty"`
+	RuleCode        string                  `json:"rule_code"`
+	RuleName        string                  `json:"rule_name"`
+	Category        string                  `json:"category"` // "PATHOGENIC", "BENIGN"
+	Strength        string                  `json:"strength"` // "VERY_STRONG", "STRONG", "MODERATE", "SUPPORTING"
+	Applied         bool                    `json:"applied"`
+	Confidence      float64                 `json:"confidence"`
+	Evidence        string                  `json:"evidence,omitempty"`
+	Reasoning       string                  `json:"reasoning,omitempty"`
+	MatchedVariants []domain.ResidueVariant `json:"matched_variants,omitempty"` // PS1/PM5 ClinVar variants at the residue
+	MatchedDomain   *domain.ProteinDomain   `json:"matched_domain,omitempty"`   // PM1 domain or hotspot
+	Segregation     *domain.SegregationAnalysis `json:"segregation,omitempty"` // PP1/BS4 LOD computation
+	PhenotypeMatch  *domain.PhenotypeMatch      `json:"phenotype_match,omitempty"`  // PP4 HPO term similarity
+	InTrans         *domain.InTransTally        `json:"in_trans,omitempty"`         // PM3 proband tally
+	PolicyOverride  string                      `json:"policy_override,omitempty"`  // Laboratory weighting override applied
+	Override        *CriterionOverride          `json:"override,omitempty"`         // Manual override by a curator, from override_criterion
 }
 
 // NewClassifyVariantTool creates a new classify_variant tool
@@ -76,6 +167,55 @@ func NewClassifyVariantToolLegacy(logger *logrus.Logger, classifierService *serv
 	}
 }
 
+// SetPlaybookStore enables attaching curated gene playbooks to classification results
+func (t *ClassifyVariantTool) SetPlaybookStore(store playbook.Store) {
+	t.playbooks = store
+}
+
+// SetFollowUpStore enables surfacing open follow-up flags on classification results
+func (t *ClassifyVariantTool) SetFollowUpStore(store followup.Store) {
+	t.flags = store
+}
+
+// SetCohortStore enables recording observations in the in-house cohort and
+// attaching the cohort frequency to classification results
+func (t *ClassifyVariantTool) SetCohortStore(store cohort.Store, criteria cohort.ArtifactCriteria) {
+	t.cohort = store
+	t.artifactCriteria = criteria
+}
+
+// SetArtifactStore enables annotating variants on the artifact blacklist as probable artifacts
+func (t *ClassifyVariantTool) SetArtifactStore(store artifact.Store) {
+	t.artifacts = store
+}
+
+// SetAuditStore enables recording every classification request and its outcome in the audit trail
+func (t *ClassifyVariantTool) SetAuditStore(store audit.Store) {
+	t.audit = store
+}
+
+// SetKnownBenignStore enables checking classifications against the curated known benign list
+func (t *ClassifyVariantTool) SetKnownBenignStore(store benign.Store) {
+	t.knownBenign = store
+}
+
+// SetIdentifierResolver enables giving dbSNP rsIDs and ClinVar VCV/RCV accessions as hgvs_notation
+func (t *ClassifyVariantTool) SetIdentifierResolver(resolver *variantid.Resolver) {
+	t.identifiers = resolver
+}
+
+// SetWebhookPublisher enables classification.changed events when a variant is
+// classified differently from its previous recorded classification
+func (t *ClassifyVariantTool) SetWebhookPublisher(publisher webhook.Publisher) {
+	t.webhooks = publisher
+}
+
+// SetClassificationSnapshotStore enables storing the evidence bundle of each
+// germline classification as a snapshot that replay_classification can re-run
+func (t *ClassifyVariantTool) SetClassificationSnapshotStore(store snapshot.Store) {
+	t.bundles = store
+}
+
 // HandleTool implements the ToolHandler interface for classify_variant
 func (t *ClassifyVariantTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
 	startTime := time.Now()
@@ -113,13 +253,48 @@ func (t *ClassifyVariantTool) HandleTool(ctx context.Context, req *protocol.JSON
 		"processing_time": result.ProcessingTime,
 	}).Info("Variant classification completed")
 
+	response := map[string]interface{}{
+		"classification": result,
+	}
+	switch params.OutputFormat {
+	case OutputFormatVASpec:
+		statement, err := vaSpecStatement(result)
+		if err != nil {
+			result.Recommendations = append(result.Recommendations, "No VA-Spec statement: "+err.Error())
+		} else {
+			response["va_spec"] = statement
+		}
+	case OutputFormatFHIR:
+		response["fhir"] = fhir.NewReportBundle([]fhir.Variant{FHIRVariant(params.HGVSNotation, result)}, time.Now())
+	}
+
 	return &protocol.JSONRPC2Response{
-		Result: map[string]interface{}{
-			"classification": result,
-		},
+		Result: response,
 	}
 }
 
+// vaSpecStatement describes a germline classification of the variant's VRS
+// allele as a GA4GH VA-Spec pathogenicity statement
+func vaSpecStatement(result *ClassifyVariantResult) (*vrs.Statement, error) {
+	if result.VRSAllele == nil {
+		return nil, fmt.Errorf("the variant has no VRS allele; a reference genome is needed to compute one")
+	}
+	if result.Somatic != nil {
+		return nil, fmt.Errorf("somatic tiers are not pathogenicity classifications")
+	}
+	classification, err := domain.ParseClassification(result.Classification)
+	if err != nil {
+		return nil, err
+	}
+	var criteria []vrs.Criterion
+	for _, rule := range result.AppliedRules {
+		if rule.Applied {
+			criteria = append(criteria, vrs.Criterion{Code: rule.RuleCode, Strength: rule.Strength})
+		}
+	}
+	return vrs.NewPathogenicityStatement(result.VRSAllele, classification, criteria), nil
+}
+
 // GetToolInfo returns tool metadata
 func (t *ClassifyVariantTool) GetToolInfo() protocol.ToolInfo {
 	return protocol.ToolInfo{
@@ -130,9 +305,9 @@ func (t *ClassifyVariantTool) GetToolInfo() protocol.ToolInfo {
 			"properties": map[string]interface{}{
 				"hgvs_notation": map[string]interface{}{
 					"type":        "string",
-					"description": "HGVS notation of the variant (e.g., 'NM_000492.3:c.1521_1523delCTT', 'NC_000017.11:g.43104261G>T')",
-					"pattern":     "^(NC_|NM_|NP_|NG_|NR_|XM_|XR_).*",
-					"examples":    []string{"NM_000492.3:c.1521_1523delCTT", "NC_000017.11:g.43104261G>T", "NP_000483.3:p.Phe508del"},
+					"description": "HGVS notation of the variant (e.g., 'NM_000492.3:c.1521_1523delCTT', 'NC_000017.11:g.43104261G>T'), or a dbSNP rsID or ClinVar VCV/RCV accession to resolve to one",
+					"pattern":     "^(NC_|NM_|NP_|NG_|NR_|XM_|XR_|[Rr][Ss][0-9]|VCV[0-9]|RCV[0-9]).*",
+					"examples":    []string{"NM_000492.3:c.1521_1523delCTT", "NC_000017.11:g.43104261G>T", "NP_000483.3:p.Phe508del", "rs80357906", "VCV000017661"},
 				},
 				"gene_symbol_notation": map[string]interface{}{
 					"type":        "string",
@@ -162,6 +337,21 @@ func (t *ClassifyVariantTool) GetToolInfo() protocol.ToolInfo {
 					"pattern":     "^(NM_|NR_|XM_|XR_).*",
 					"examples":    []string{"NM_000492.3", "NM_007294.4"},
 				},
+				"transcript_consequences": map[string]interface{}{
+					"type":        "array",
+					"description": "Per-transcript annotations (e.g. from VEP). When consequences differ across clinically relevant transcripts, each is evaluated and the most conservative call is reported",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"transcript_id":       map[string]interface{}{"type": "string"},
+							"hgvs_coding":         map[string]interface{}{"type": "string"},
+							"hgvs_protein":        map[string]interface{}{"type": "string"},
+							"consequence":         map[string]interface{}{"type": "string", "description": "Sequence Ontology term, e.g. stop_gained"},
+							"clinically_relevant": map[string]interface{}{"type": "boolean", "description": "MANE Select, MANE Plus Clinical or lab-designated transcript"},
+						},
+						"required": []string{"transcript_id"},
+					},
+				},
 				"clinical_context": map[string]interface{}{
 					"type":        "string",
 					"description": "Clinical context or phenotype information for enhanced interpretation",
@@ -172,6 +362,191 @@ func (t *ClassifyVariantTool) GetToolInfo() protocol.ToolInfo {
 					"description": "Whether to include detailed evidence summary in the response",
 					"default":     false,
 				},
+				"refresh_evidence": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Drop the variant's cached database responses and fetch current ones, e.g. to re-evaluate a stored classification",
+					"default":     false,
+				},
+				"scoring_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "How criteria are combined: 'combining_rules' (ACMG/AMP 2015) or 'points' (ClinGen SVI Tavtigian Bayesian framework). Defaults to the server setting. The point total is reported in both modes",
+					"enum":        []string{"combining_rules", "points"},
+				},
+				"rules_version": map[string]interface{}{
+					"type":        "string",
+					"description": "Rule set version to evaluate the criteria under: 'acmg2015' (default, the 2015 guidelines as published) or 'clingen-svi-2023' (PM2 at supporting, PP5 and BP6 retired, points scoring unless scoring_mode is given)",
+					"enum":        ruleversions.Names(),
+				},
+				"output_format": map[string]interface{}{
+					"type":        "string",
+					"description": "'standard' (default); 'va_spec' adds the classification as a GA4GH VA-Spec variant pathogenicity statement about the variant's VRS allele (germline classifications only; needs a reference genome); 'fhir' adds an HL7 FHIR R4 Genomics Reporting bundle with the DiagnosticReport, variant Observation and diagnostic implication Observation",
+					"enum":        []string{OutputFormatStandard, OutputFormatVASpec, OutputFormatFHIR},
+					"default":     OutputFormatStandard,
+				},
+				"ordering_specialty": map[string]interface{}{
+					"type":        "string",
+					"description": "Ordering specialty (e.g. cardiology, oncology, neurology). Interprets the gene on the transcript that specialty mandates; an explicit transcript_id or preferred_isoform takes precedence",
+					"examples":    []string{"cardiology", "oncology", "neurology"},
+				},
+				"condition": map[string]interface{}{
+					"type":        "string",
+					"description": "Condition under evaluation (name or MONDO ID). Selects configured gene/condition-specific BA1, BS1 and PM2 thresholds; defaults to the disease in the gene's threshold model",
+					"examples":    []string{"Hereditary hemochromatosis", "MONDO:0007576"},
+				},
+				"proband_id": map[string]interface{}{
+					"type":        "string",
+					"description": "De-identified proband identifier. When given, the variant is recorded in the in-house cohort; repeat cases for the same proband count once",
+				},
+				"zygosity": map[string]interface{}{
+					"type":        "string",
+					"description": "Zygosity of the variant in the proband, recorded with proband_id",
+					"enum":        []string{"heterozygous", "homozygous", "hemizygous"},
+					"default":     "heterozygous",
+				},
+				"classification_context": map[string]interface{}{
+					"type":        "string",
+					"description": "'germline' classifies with the ACMG/AMP 2015 criteria; 'somatic' assigns an AMP/ASCO/CAP 2017 tier (Tier I-IV) from OncoKB and CIViC evidence levels A-D",
+					"enum":        []string{"germline", "somatic"},
+					"default":     "germline",
+				},
+				"tumor_type": map[string]interface{}{
+					"type":        "string",
+					"description": "Patient's tumor type for somatic tiering. Level A and B evidence counts only in a matching tumor type; without one it is assessed as level C",
+					"examples":    []string{"Melanoma", "Lung Adenocarcinoma", "Colorectal Cancer"},
+				},
+				"patient_context": map[string]interface{}{
+					"type":        "object",
+					"description": "Case-level evidence about the proband. A de novo occurrence is scored on the ClinGen SVI point scale and applies PS2 when maternity and paternity are confirmed, PM6 otherwise, at the strength the points reach. Segregation counts are scored as a LOD for PP1 and BS4, zygosity and phased variants in the gene give PM3 and BP2, and HPO terms are matched against the gene's phenotypes for PP4",
+					"properties": map[string]interface{}{
+						"de_novo_status": map[string]interface{}{
+							"type": "string",
+							"enum": []string{"de_novo", "inherited", "unknown"},
+						},
+						"parental_confirmation": map[string]interface{}{
+							"type":        "boolean",
+							"description": "Maternity and paternity confirmed, e.g. by trio sequencing",
+						},
+						"phenotype_match": map[string]interface{}{
+							"type":        "string",
+							"description": "How well the proband's phenotype fits the gene: highly specific, consistent, or consistent but genetically heterogeneous",
+							"enum":        []string{"highly_specific", "consistent", "consistent_heterogeneous", "not_consistent"},
+						},
+						"hpo_terms": map[string]interface{}{
+							"type":        "array",
+							"description": "The proband's Human Phenotype Ontology terms. PP4 applies when their semantic similarity to the gene's HPO annotations reaches the configured cutoff",
+							"items": map[string]interface{}{
+								"type":    "string",
+								"pattern": "^HP:\\d{7}$",
+							},
+							"examples": [][]string{{"HP:0001250", "HP:0001263"}},
+						},
+						"family_history": map[string]interface{}{
+							"type":        "string",
+							"description": "Disease in the proband's family; a positive history precludes PS2 and PM6",
+							"enum":        []string{"negative", "positive", "unknown"},
+						},
+						"segregation": map[string]interface{}{
+							"type":        "object",
+							"description": "Genotyped relatives of the proband, summed across families, excluding the proband. Each relative counts as one informative meiosis",
+							"properties": map[string]interface{}{
+								"families": map[string]interface{}{
+									"type":    "integer",
+									"minimum": 0,
+								},
+								"affected_carriers": map[string]interface{}{
+									"type":    "integer",
+									"minimum": 0,
+								},
+								"affected_noncarriers": map[string]interface{}{
+									"type":        "integer",
+									"minimum":     0,
+									"description": "Affected relatives without the variant (nonsegregations)",
+								},
+								"unaffected_carriers": map[string]interface{}{
+									"type":    "integer",
+									"minimum": 0,
+								},
+								"unaffected_noncarriers": map[string]interface{}{
+									"type":    "integer",
+									"minimum": 0,
+								},
+								"penetrance": map[string]interface{}{
+									"type":             "number",
+									"description":      "Probability a carrier is affected (default 0.9)",
+									"exclusiveMinimum": 0,
+									"exclusiveMaximum": 1,
+								},
+								"phenocopy_rate": map[string]interface{}{
+									"type":             "number",
+									"description":      "Probability a noncarrier is affected (default 0.001)",
+									"exclusiveMinimum": 0,
+									"exclusiveMaximum": 1,
+								},
+							},
+							"additionalProperties": false,
+						},
+						"zygosity": map[string]interface{}{
+							"type":        "string",
+							"description": "Zygosity of the variant in the proband; homozygosity counts toward PM3",
+							"enum":        []string{"heterozygous", "homozygous", "hemizygous"},
+						},
+						"inheritance": map[string]interface{}{
+							"type":        "string",
+							"description": "Mode of inheritance of the disorder. PM3 applies to recessive disorders; BP2 applies to a variant in trans with a pathogenic variant in a dominant disorder; BS2 counts homozygotes, hemizygotes or carriers by the mode. Falls back to the gene model for BS2",
+							"enum":        []string{"autosomal_recessive", "autosomal_dominant", "x_linked"},
+						},
+						"phased_variants": map[string]interface{}{
+							"type":        "array",
+							"description": "Other variants the proband carries in the gene and their phase. A pathogenic or likely pathogenic variant in trans scores PM3 on the ClinGen SVI point scale; one in cis applies BP2",
+							"items": map[string]interface{}{
+								"type": "object",
+								"properties": map[string]interface{}{
+									"variant":        map[string]interface{}{"type": "string"},
+									"classification": map[string]interface{}{"type": "string", "description": "Classification of the other variant, e.g. Pathogenic"},
+									"phase":          map[string]interface{}{"type": "string", "enum": []string{"trans", "cis", "unknown"}},
+									"phase_basis":    map[string]interface{}{"type": "string", "description": "How the phase was determined"},
+								},
+								"required":             []string{"variant", "classification"},
+								"additionalProperties": false,
+							},
+						},
+						"in_trans_cases": map[string]interface{}{
+							"type":        "array",
+							"description": "Other unrelated probands with the variant, from the lab's cases or the literature, tallied with the proband for PM3 on the ClinGen SVI point scale. Each proband counts once with its best observation: in trans with a pathogenic variant 1 point, likely pathogenic 0.5, halved when the phase is unknown; homozygous 0.5, at most 1 point across homozygous probands. The phase is inferred from the parental origin of both variants when not given",
+							"items": map[string]interface{}{
+								"type": "object",
+								"properties": map[string]interface{}{
+									"proband_id":           map[string]interface{}{"type": "string"},
+									"zygosity":             map[string]interface{}{"type": "string", "enum": []string{"heterozygous", "homozygous", "hemizygous"}},
+									"other_variant":        map[string]interface{}{"type": "string"},
+									"other_classification": map[string]interface{}{"type": "string", "description": "e.g. Pathogenic"},
+									"phase":                map[string]interface{}{"type": "string", "enum": []string{"trans", "cis", "unknown"}},
+									"variant_origin":       map[string]interface{}{"type": "string", "enum": []string{"maternal", "paternal", "de_novo", "unknown"}},
+									"other_origin":         map[string]interface{}{"type": "string", "enum": []string{"maternal", "paternal", "de_novo", "unknown"}},
+									"phase_basis":          map[string]interface{}{"type": "string"},
+								},
+								"additionalProperties": false,
+							},
+						},
+						"penetrance": map[string]interface{}{
+							"type":        "string",
+							"enum":        []string{"complete", "reduced", "age_dependent", "unknown"},
+							"description": "Penetrance of the disorder, for BS2; falls back to the gene model. Reduced or age-dependent penetrance withholds BS2, and a dominant disorder needs complete penetrance",
+						},
+						"healthy_adults": map[string]interface{}{
+							"type":        "object",
+							"description": "Unaffected adults carrying the variant in the lab's own data, such as unaffected parents or internal controls, added to the gnomAD counts for BS2",
+							"properties": map[string]interface{}{
+								"homozygotes":   map[string]interface{}{"type": "integer", "minimum": 0},
+								"hemizygotes":   map[string]interface{}{"type": "integer", "minimum": 0},
+								"heterozygotes": map[string]interface{}{"type": "integer", "minimum": 0},
+								"source":        map[string]interface{}{"type": "string", "description": "e.g. internal controls"},
+							},
+							"additionalProperties": false,
+						},
+					},
+					"additionalProperties": false,
+				},
 			},
 			"oneOf": []map[string]interface{}{
 				{
@@ -251,8 +626,8 @@ func (t *ClassifyVariantTool) validateNotationParameters(params *ClassifyVariant
 func (t *ClassifyVariantTool) validateNotationFormats(params *ClassifyVariantParams) error {
 	// Validate HGVS notation if provided
 	if params.HGVSNotation != "" {
-		if !t.isValidHGVSFormat(params.HGVSNotation) {
-			return fmt.Errorf("invalid HGVS notation format: %s. Expected format like 'NM_000492.3:c.1521_1523delCTT'", params.HGVSNotation)
+		if !t.isValidHGVSFormat(params.HGVSNotation) && !variantid.IsIdentifier(params.HGVSNotation) {
+			return fmt.Errorf("invalid HGVS notation format: %s. Expected format like 'NM_000492.3:c.1521_1523delCTT', or an rsID or ClinVar accession like 'rs80357906' or 'VCV000017661'", params.HGVSNotation)
 		}
 	}
 
@@ -273,6 +648,12 @@ func (t *ClassifyVariantTool) validateNotationFormats(params *ClassifyVariantPar
 
 // validateAdditionalParameters validates other optional parameters
 func (t *ClassifyVariantTool) validateAdditionalParameters(params *ClassifyVariantParams) error {
+	if params.PatientContext != nil {
+		if err := params.PatientContext.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// Validate preferred isoform if provided
 	if params.PreferredIsoform != "" {
 		if !t.isValidTranscriptFormat(params.PreferredIsoform) {
@@ -288,6 +669,44 @@ func (t *ClassifyVariantTool) validateAdditionalParameters(params *ClassifyVaria
 		}
 	}
 
+	// Validate scoring mode if provided
+	if params.ScoringMode != "" {
+		if _, err := service.ParseScoringMode(params.ScoringMode); err != nil {
+			return err
+		}
+	}
+
+	// Validate rule set version if provided
+	if params.RulesVersion != "" {
+		if _, err := ruleversions.Lookup(params.RulesVersion); err != nil {
+			return err
+		}
+	}
+
+	// Validate output format if provided
+	switch params.OutputFormat {
+	case "", OutputFormatStandard, OutputFormatVASpec, OutputFormatFHIR:
+	default:
+		return fmt.Errorf("invalid output_format: %s. Valid formats: %s, %s, %s", params.OutputFormat, OutputFormatStandard, OutputFormatVASpec, OutputFormatFHIR)
+	}
+
+	// Validate classification context if provided
+	if params.ClassificationContext != "" {
+		if _, err := service.ParseClassificationContext(params.ClassificationContext); err != nil {
+			return err
+		}
+	}
+
+	// Validate zygosity if provided
+	if params.Zygosity != "" {
+		if !cohort.Zygosity(strings.ToLower(params.Zygosity)).IsValid() {
+			return fmt.Errorf("invalid zygosity: %s. Valid values: heterozygous, homozygous, hemizygous", params.Zygosity)
+		}
+		if params.ProbandID == "" {
+			return fmt.Errorf("zygosity requires proband_id")
+		}
+	}
+
 	return nil
 }
 
@@ -410,10 +829,19 @@ func (t *ClassifyVariantTool) classifyVariant(ctx context.Context, params *Class
 		return nil, fmt.Errorf("classification service not configured")
 	}
 
+	// Resolve an rsID or ClinVar accession to the variant it describes
+	notationParams, resolvedFrom, err := t.resolveIdentifier(ctx, params)
+	if err != nil {
+		t.recordAudit(ctx, params, params.HGVSNotation, nil, err)
+		return nil, err
+	}
+
 	// Determine the input notation and prepare for classification
-	hgvsNotation, geneSymbol, err := t.prepareNotationForClassification(ctx, params)
+	hgvsNotation, geneSymbol, err := t.prepareNotationForClassification(ctx, notationParams)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare notation for classification: %w", err)
+		err = fmt.Errorf("failed to prepare notation for classification: %w", err)
+		t.recordAudit(ctx, params, params.HGVSNotation, nil, err)
+		return nil, err
 	}
 
 	t.logger.WithFields(logrus.Fields{
@@ -421,26 +849,19 @@ func (t *ClassifyVariantTool) classifyVariant(ctx context.Context, params *Class
 		"gene_symbol":   geneSymbol,
 	}).Debug("Starting variant classification")
 
-	// Convert MCP tool params to service params
-	serviceParams := &service.ClassifyVariantParams{
-		HGVSNotation:    hgvsNotation,
-		VariantType:     params.VariantType,
-		GeneSymbol:      geneSymbol,
-		TranscriptID:    params.TranscriptID,
-		ClinicalContext: params.ClinicalContext,
-		IncludeEvidence: params.IncludeEvidence,
-	}
-
-	// Add preferred isoform if specified
-	if params.PreferredIsoform != "" {
-		serviceParams.TranscriptID = params.PreferredIsoform
-	}
-
 	// Call the real classification service
+	serviceParams := toServiceParams(params, hgvsNotation, geneSymbol)
 	serviceResult, err := t.classifierService.ClassifyVariant(ctx, serviceParams)
+	if ctx.Err() != nil {
+		// A cancelled classification is neither audited nor recorded in the cohort
+		return nil, fmt.Errorf("classification cancelled: %w", ctx.Err())
+	}
 	if err != nil {
-		return nil, fmt.Errorf("classification service failed: %w", err)
+		err = fmt.Errorf("classification service failed: %w", err)
+		t.recordAudit(ctx, params, hgvsNotation, nil, err)
+		return nil, err
 	}
+	record := t.recordAudit(ctx, params, hgvsNotation, serviceResult, nil)
 
 	// Convert service result to MCP tool result
 	result := &ClassifyVariantResult{
@@ -451,11 +872,360 @@ func (t *ClassifyVariantTool) classifyVariant(ctx context.Context, params *Class
 		EvidenceSummary: serviceResult.EvidenceSummary,
 		Recommendations: serviceResult.Recommendations,
 		ProcessingTime:  serviceResult.ProcessingTime.String(),
+		MultiTranscript: serviceResult.MultiTranscript,
+		Transcript:      serviceResult.Transcript,
+		TranscriptConsequences: serviceResult.TranscriptConsequences,
+		ThresholdRevision: serviceResult.ThresholdRevision,
+		ConfigCommit:    serviceResult.ConfigCommit,
+		DatasetVersions: serviceResult.DatasetVersions,
+		FrequencyThresholds: serviceResult.FrequencyThresholds,
+		ConflictingEvidence: serviceResult.ConflictingEvidence,
+		ScoringMode:     serviceResult.ScoringMode,
+		RulesVersion:    serviceResult.RulesVersion,
+		WeightingPolicy: serviceResult.WeightingPolicy,
+		PointTotal:      serviceResult.PointTotal,
+		ConfidenceScore: serviceResult.ConfidenceScore,
+		PosteriorProbability: serviceResult.PosteriorProbability,
+		VUSTier:         serviceResult.VUSTier,
+		Specification:   serviceResult.Specification,
+		RegionCaveats:   serviceResult.RegionCaveats,
+		SpecialtyTranscript: serviceResult.SpecialtyTranscript,
+		ResolvedFrom:    resolvedFrom,
+		VRSAllele:       serviceResult.VRS,
+		Normalized:      serviceResult.Normalized,
+		ClassificationContext: serviceResult.ClassificationContext,
+		Somatic:         serviceResult.Somatic,
+		Structural:      serviceResult.Structural,
+		Provisional:     serviceResult.Provisional,
+		IncompleteEvidence: serviceResult.IncompleteEvidence,
+		EvidenceSources: serviceResult.EvidenceSources,
+		Cached:          serviceResult.Cached,
+		Evidence:        serviceResult.Evidence,
+	}
+
+	if result.VRSAllele != nil {
+		result.VRSID = result.VRSAllele.ID
+	}
+	result.EvidenceSnapshotID = t.saveEvidenceBundle(ctx, hgvsNotation, serviceResult.Bundle)
+
+	// Attach the curated playbook for the gene, if any
+	result.GenePlaybook = t.lookupPlaybook(ctx, geneSymbol)
+
+	// Surface open follow-up flags; blocking flags put reclassification on hold
+	if flags := t.lookupFlags(ctx, hgvsNotation); len(flags) > 0 {
+		result.FollowUpFlags = flags
+		result.ReclassificationBlocked = followup.HasBlocking(flags)
+		if result.ReclassificationBlocked {
+			result.Recommendations = append(result.Recommendations,
+				"Reclassification on hold: resolve blocking follow-up flags before signing out a changed classification")
+		}
+	}
+
+	// Annotate variants on the signed-off artifact blacklist
+	if entry := t.lookupArtifact(ctx, hgvsNotation); entry != nil {
+		result.ProbableArtifact = entry
+		result.Recommendations = append(result.Recommendations,
+			"Probable sequencing artifact (on the lab artifact blacklist): "+entry.Evidence+". Confirm with an orthogonal method before reporting")
+	}
+
+	// Flag a computed call that disagrees with the curator-confirmed known
+	// benign list; the list holds germline calls, which tiers do not compare to
+	if entry := t.lookupKnownBenign(ctx, hgvsNotation); entry != nil && serviceResult.Somatic == nil {
+		result.KnownBenign = entry
+		if result.Classification != entry.Classification {
+			result.Recommendations = append(result.Recommendations,
+				fmt.Sprintf("Differs from the curator-confirmed %s call on the known benign list (%s); review before sign-out", entry.Classification, entry.Evidence))
+		}
+	}
+
+	// Compare with the previous recorded classification of the variant
+	if diff := t.reclassificationDiff(ctx, record); diff != nil {
+		result.Reclassification = diff
+		if diff.NotificationRecommended {
+			result.Recommendations = append(result.Recommendations,
+				fmt.Sprintf("Reclassified from %s to %s since %s: issue a reclassification notice",
+					diff.PreviousClassification, diff.CurrentClassification, diff.PreviousAt.Format("2006-01-02")))
+		}
+		if diff.ClassChanged && t.webhooks != nil {
+			t.webhooks.Publish(ctx, &webhook.Event{
+				Type:    webhook.EventClassificationChanged,
+				Variant: hgvsNotation,
+				Data:    diff,
+			})
+		}
+	}
+
+	// Record the case in the in-house cohort and report the cohort frequency
+	if freq := t.observeInCohort(ctx, hgvsNotation, geneSymbol, result.Classification, params); freq != nil {
+		result.CohortFrequency = freq
+		if freq.SuspectArtifact {
+			result.Recommendations = append(result.Recommendations,
+				"Suspected recurrent sequencing artifact: "+freq.ArtifactRationale)
+		}
 	}
 
 	return result, nil
 }
 
+// lookupArtifact returns the variant's active blacklist entry; lookup failures are logged and ignored
+func (t *ClassifyVariantTool) lookupArtifact(ctx context.Context, normalizedHGVS string) *artifact.Entry {
+	if t.artifacts == nil || normalizedHGVS == "" {
+		return nil
+	}
+
+	entry, err := t.artifacts.Lookup(ctx, normalizedHGVS)
+	if err != nil {
+		t.logger.WithError(err).WithField("variant", normalizedHGVS).Warn("Failed to look up artifact blacklist")
+		return nil
+	}
+	return entry
+}
+
+// lookupKnownBenign returns the variant's known benign entry; lookup failures are logged and ignored
+func (t *ClassifyVariantTool) lookupKnownBenign(ctx context.Context, normalizedHGVS string) *benign.Entry {
+	if t.knownBenign == nil || normalizedHGVS == "" {
+		return nil
+	}
+
+	entry, err := t.knownBenign.Lookup(ctx, normalizedHGVS)
+	if err != nil {
+		t.logger.WithError(err).WithField("variant", normalizedHGVS).Warn("Failed to look up known benign list")
+		return nil
+	}
+	return entry
+}
+
+// knownBenignResult returns the curator-endorsed result for a listed variant
+// without gathering evidence, or nil if the variant is not listed. The
+// shortcut is recorded in the audit trail like any other classification.
+func (t *ClassifyVariantTool) knownBenignResult(ctx context.Context, params *ClassifyVariantParams) *ClassifyVariantResult {
+	if strings.EqualFold(strings.TrimSpace(params.ClassificationContext), service.ContextSomatic) {
+		return nil
+	}
+	entry := t.lookupKnownBenign(ctx, strings.TrimSpace(params.HGVSNotation))
+	if entry == nil {
+		return nil
+	}
+
+	result := &ClassifyVariantResult{
+		Classification:  entry.Classification,
+		Confidence:      domain.HIGH.String(),
+		AppliedRules:    []ACMGAMPRuleResult{},
+		EvidenceSummary: fmt.Sprintf("Curator-confirmed %s on the known benign list (confirmed by %s): %s", entry.Classification, entry.ConfirmedBy, entry.Evidence),
+		Recommendations: []string{"Returned from the known benign list without evidence lookups; classify individually to re-evaluate"},
+		KnownBenign:     entry,
+	}
+	t.recordAudit(ctx, params, entry.NormalizedHGVS, &service.ClassifyVariantResult{
+		Classification: result.Classification,
+		Confidence:     result.Confidence,
+	}, nil)
+	return result
+}
+
+// observeInCohort records the proband's observation, if given, with the gene,
+// classification and phenotype for case matching, and returns the variant's
+// cohort frequency; store failures are logged and ignored
+func (t *ClassifyVariantTool) observeInCohort(ctx context.Context, normalizedHGVS, geneSymbol, classification string, params *ClassifyVariantParams) *cohort.Frequency {
+	if t.cohort == nil || normalizedHGVS == "" {
+		return nil
+	}
+
+	if params.ProbandID != "" {
+		obs := &cohort.Observation{
+			ProbandID:      params.ProbandID,
+			NormalizedHGVS: normalizedHGVS,
+			Zygosity:       cohort.Zygosity(params.Zygosity),
+			GeneSymbol:     geneSymbol,
+			Classification: classification,
+		}
+		if params.PatientContext != nil {
+			obs.HPOTerms = params.PatientContext.HPOTerms
+		}
+		if _, err := t.cohort.Record(ctx, obs); err != nil {
+			t.logger.WithError(err).WithField("variant", normalizedHGVS).Warn("Failed to record cohort observation")
+		}
+	}
+
+	freq, err := t.cohort.Frequency(ctx, normalizedHGVS)
+	if err != nil {
+		t.logger.WithError(err).WithField("variant", normalizedHGVS).Warn("Failed to compute cohort frequency")
+		return nil
+	}
+	if freq.Carriers == 0 {
+		return nil
+	}
+	t.artifactCriteria.Assess(freq, 0)
+	return freq
+}
+
+// lookupPlaybook returns the gene's playbook; lookup failures are logged and ignored
+func (t *ClassifyVariantTool) lookupPlaybook(ctx context.Context, geneSymbol string) *playbook.Playbook {
+	if t.playbooks == nil || geneSymbol == "" {
+		return nil
+	}
+
+	pb, err := t.playbooks.Get(ctx, geneSymbol)
+	if err != nil {
+		t.logger.WithError(err).WithField("gene", geneSymbol).Warn("Failed to load gene playbook")
+		return nil
+	}
+	return pb
+}
+
+// lookupFlags returns open follow-up flags for the variant; lookup failures are logged and ignored
+func (t *ClassifyVariantTool) lookupFlags(ctx context.Context, normalizedHGVS string) []*followup.Flag {
+	if t.flags == nil || normalizedHGVS == "" {
+		return nil
+	}
+
+	flags, err := t.flags.Open(ctx, normalizedHGVS, "")
+	if err != nil {
+		t.logger.WithError(err).WithField("variant", normalizedHGVS).Warn("Failed to look up follow-up flags")
+		return nil
+	}
+	return flags
+}
+
+// recordAudit appends the request and its outcome to the audit trail and
+// returns the record; store failures are logged and ignored
+func (t *ClassifyVariantTool) recordAudit(ctx context.Context, params *ClassifyVariantParams, hgvsNotation string, result *service.ClassifyVariantResult, classifyErr error) *audit.Record {
+	if t.audit == nil {
+		return nil
+	}
+
+	record := &audit.Record{
+		HGVSNotation:  hgvsNotation,
+		EngineVersion: service.EngineVersion,
+	}
+	if record.HGVSNotation == "" {
+		record.HGVSNotation = params.GeneSymbolNotation
+	}
+	record.Request, _ = json.Marshal(params)
+	if classifyErr != nil {
+		record.Error = classifyErr.Error()
+	}
+	if result != nil {
+		record.VariantID = result.VariantID
+		record.Classification = result.Classification
+		record.Confidence = result.Confidence
+		record.ScoringMode = result.ScoringMode
+		record.ThresholdRevision = result.ThresholdRevision
+		record.ConfigCommit = result.ConfigCommit
+		record.DatasetVersions = result.DatasetVersions
+		record.Specification = result.Specification
+		record.AppliedRules, _ = json.Marshal(result.AppliedRules)
+		if result.Evidence != nil {
+			record.Evidence, _ = json.Marshal(result.Evidence)
+		}
+	}
+
+	if err := t.audit.Append(ctx, record); err != nil {
+		t.logger.WithError(err).WithField("variant", record.HGVSNotation).Warn("Failed to record classification audit trail")
+		return nil
+	}
+	return record
+}
+
+// saveEvidenceBundle stores the evidence bundle as a classification snapshot
+// and returns its ID; store failures are logged and ignored
+func (t *ClassifyVariantTool) saveEvidenceBundle(ctx context.Context, hgvsNotation string, bundle *service.EvidenceBundle) int64 {
+	if t.bundles == nil || bundle == nil || hgvsNotation == "" {
+		return 0
+	}
+
+	payload, err := json.Marshal(bundle)
+	if err != nil {
+		t.logger.WithError(err).WithField("variant", hgvsNotation).Warn("Failed to marshal evidence bundle")
+		return 0
+	}
+	snap := &snapshot.Snapshot{
+		Kind:           snapshot.KindClassification,
+		NormalizedHGVS: hgvsNotation,
+		Payload:        payload,
+	}
+	if err := t.bundles.Save(ctx, snap); err != nil {
+		t.logger.WithError(err).WithField("variant", hgvsNotation).Warn("Failed to save evidence bundle")
+		return 0
+	}
+	return snap.ID
+}
+
+// reclassificationDiff compares a recorded classification with the variant's
+// previous one; lookup failures are logged and ignored
+func (t *ClassifyVariantTool) reclassificationDiff(ctx context.Context, record *audit.Record) *audit.Diff {
+	if t.audit == nil || record == nil {
+		return nil
+	}
+
+	previous, err := audit.Previous(ctx, t.audit, record)
+	if err != nil {
+		t.logger.WithError(err).WithField("variant", record.HGVSNotation).Warn("Failed to load previous classification")
+		return nil
+	}
+	if previous == nil {
+		return nil
+	}
+	// Germline classifications and somatic tiers are not comparable
+	if domain.SomaticTier(previous.Classification).IsValid() != domain.SomaticTier(record.Classification).IsValid() {
+		return nil
+	}
+
+	diff, err := audit.Compare(previous, record)
+	if err != nil {
+		t.logger.WithError(err).WithField("variant", record.HGVSNotation).Warn("Failed to compare with previous classification")
+		return nil
+	}
+	return diff
+}
+
+// toServiceParams converts classify_variant tool params to classification
+// service params for the prepared notation
+func toServiceParams(params *ClassifyVariantParams, hgvsNotation, geneSymbol string) *service.ClassifyVariantParams {
+	serviceParams := &service.ClassifyVariantParams{
+		HGVSNotation:           hgvsNotation,
+		VariantType:            params.VariantType,
+		GeneSymbol:             geneSymbol,
+		TranscriptID:           params.TranscriptID,
+		ClinicalContext:        params.ClinicalContext,
+		IncludeEvidence:        params.IncludeEvidence,
+		ScoringMode:            params.ScoringMode,
+		OrderingSpecialty:      params.OrderingSpecialty,
+		Condition:              params.Condition,
+		ClassificationContext:  params.ClassificationContext,
+		TumorType:              params.TumorType,
+		PatientContext:         params.PatientContext,
+		TranscriptConsequences: params.TranscriptConsequences,
+		RefreshEvidence:        params.RefreshEvidence,
+		RulesVersion:           params.RulesVersion,
+	}
+
+	// Add preferred isoform if specified
+	if params.PreferredIsoform != "" {
+		serviceParams.TranscriptID = params.PreferredIsoform
+	}
+	return serviceParams
+}
+
+// resolveIdentifier resolves an rsID or ClinVar accession given as hgvs_notation,
+// returning params with the resolved notation; other params are returned as is
+func (t *ClassifyVariantTool) resolveIdentifier(ctx context.Context, params *ClassifyVariantParams) (*ClassifyVariantParams, *domain.IdentifierMapping, error) {
+	variant, mapping, err := resolveNotation(ctx, t.identifiers, params.HGVSNotation)
+	if err != nil || variant == nil {
+		return params, nil, err
+	}
+
+	resolved := *params
+	resolved.HGVSNotation = variantid.Notation(variant)
+	if resolved.GeneSymbol == "" {
+		resolved.GeneSymbol = variant.GeneSymbol
+	}
+	t.logger.WithFields(logrus.Fields{
+		"identifier":    mapping.Identifier,
+		"hgvs_notation": resolved.HGVSNotation,
+	}).Debug("Resolved variant identifier")
+	return &resolved, mapping, nil
+}
+
 // prepareNotationForClassification determines the appropriate notation to use for classification
 func (t *ClassifyVariantTool) prepareNotationForClassification(ctx context.Context, params *ClassifyVariantParams) (hgvs, geneSymbol string, err error) {
 	// HGVS takes priority when both are provided
@@ -554,14 +1324,20 @@ func (t *ClassifyVariantTool) convertRuleResults(serviceRules []service.ACMGAMPR
 	results := make([]ACMGAMPRuleResult, len(serviceRules))
 	for i, rule := range serviceRules {
 		results[i] = ACMGAMPRuleResult{
-			RuleCode:   rule.RuleCode,
-			RuleName:   rule.RuleName,
-			Category:   rule.Category,
-			Strength:   rule.Strength,
-			Applied:    rule.Applied,
-			Confidence: rule.Confidence,
-			Evidence:   rule.Evidence,
-			Reasoning:  rule.Reasoning,
+			RuleCode:        rule.RuleCode,
+			RuleName:        rule.RuleName,
+			Category:        rule.Category,
+			Strength:        rule.Strength,
+			Applied:         rule.Applied,
+			Confidence:      rule.Confidence,
+			Evidence:        rule.Evidence,
+			Reasoning:       rule.Reasoning,
+			MatchedVariants: rule.MatchedVariants,
+			MatchedDomain:   rule.MatchedDomain,
+			Segregation:     rule.Segregation,
+			PhenotypeMatch:  rule.PhenotypeMatch,
+			InTrans:         rule.InTrans,
+			PolicyOverride:  rule.PolicyOverride,
 		}
 	}
 	return results
@@ -0,0 +1,327 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// maxSubmissionRecords caps the classifications prepared in one request
+const maxSubmissionRecords = 500
+
+// PrepareClinVarSubmissionTool implements the prepare_clinvar_submission MCP tool
+type PrepareClinVarSubmissionTool struct {
+	logger       *logrus.Logger
+	auditStore   audit.Store
+	labKnowledge labkb.Store
+}
+
+// PrepareClinVarSubmissionParams defines parameters for the prepare_clinvar_submission tool
+type PrepareClinVarSubmissionParams struct {
+	Records                 []SubmissionRecordParams `json:"records"`
+	Format                  string                   `json:"format,omitempty"` // json (default) or tsv
+	OrgID                   string                   `json:"org_id,omitempty"`
+	AssertionMethod         string                   `json:"assertion_method,omitempty"`
+	AssertionMethodCitation string                   `json:"assertion_method_citation,omitempty"`
+}
+
+// SubmissionRecordParams selects a stored classification and supplies the
+// fields ClinVar needs that the classification does not record
+type SubmissionRecordParams struct {
+	ClassificationID  int64    `json:"classification_id"`
+	Condition         string   `json:"condition,omitempty"`
+	ConditionID       string   `json:"condition_id,omitempty"`
+	ModeOfInheritance string   `json:"mode_of_inheritance,omitempty"`
+	Citations         []string `json:"citations,omitempty"`
+	AffectedProbands  int      `json:"affected_probands,omitempty"`
+}
+
+// PreparedSubmissionRecord reports how a classification was converted
+type PreparedSubmissionRecord struct {
+	ClassificationID int64       `json:"classification_id"`
+	HGVSNotation     string      `json:"hgvs_notation"`
+	Classification   string      `json:"classification,omitempty"`
+	CriteriaMet      []string    `json:"criteria_met,omitempty"`
+	LabAssertionID   int64       `json:"lab_assertion_id,omitempty"` // Lab knowledge base assertion that filled missing fields
+	Included         bool        `json:"included"`
+	Gaps             []labkb.Gap `json:"gaps,omitempty"`
+}
+
+// PrepareClinVarSubmissionResult is the result of the prepare_clinvar_submission tool
+type PrepareClinVarSubmissionResult struct {
+	Format     string                     `json:"format"`
+	Ready      bool                       `json:"ready"` // Every classification is complete and included
+	Included   int                        `json:"included"`
+	Total      int                        `json:"total"`
+	Records    []PreparedSubmissionRecord `json:"records"`
+	Submission string                     `json:"submission,omitempty"` // Complete classifications only
+	Summary    string                     `json:"summary"`
+}
+
+// NewPrepareClinVarSubmissionTool creates a new prepare_clinvar_submission
+// tool. The lab knowledge base is optional.
+func NewPrepareClinVarSubmissionTool(logger *logrus.Logger, auditStore audit.Store, labKnowledge labkb.Store) *PrepareClinVarSubmissionTool {
+	return &PrepareClinVarSubmissionTool{
+		logger:       logger,
+		auditStore:   auditStore,
+		labKnowledge: labKnowledge,
+	}
+}
+
+// GetToolInfo returns the tool information for prepare_clinvar_submission
+func (t *PrepareClinVarSubmissionTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "prepare_clinvar_submission",
+		Description: "Convert stored classifications from the audit trail into a ClinVar submission: Submission API JSON or rows for the legacy submission spreadsheet. Condition, mode of inheritance and citations come from the request or the lab knowledge base; classifications missing any of them are reported with their gaps and left out of the submission.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"records": map[string]interface{}{
+					"type":        "array",
+					"description": "Classifications to submit",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"classification_id": map[string]interface{}{
+								"type":        "integer",
+								"description": "Audit record ID of the classification",
+							},
+							"condition": map[string]interface{}{
+								"type":        "string",
+								"description": "Preferred condition name",
+							},
+							"condition_id": map[string]interface{}{
+								"type":        "string",
+								"description": "Condition ID with its source prefix: " + strings.Join(labkb.ConditionSources, ", "),
+							},
+							"mode_of_inheritance": map[string]interface{}{
+								"type":        "string",
+								"description": "Mode of inheritance, e.g. Autosomal dominant inheritance",
+							},
+							"citations": map[string]interface{}{
+								"type":        "array",
+								"items":       map[string]interface{}{"type": "string"},
+								"description": "Supporting PubMed IDs",
+							},
+							"affected_probands": map[string]interface{}{
+								"type":        "integer",
+								"description": "Unrelated affected individuals observed with the variant",
+								"minimum":     0,
+							},
+						},
+						"required": []string{"classification_id"},
+					},
+					"minItems": 1,
+					"maxItems": maxSubmissionRecords,
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "ClinVar Submission API JSON, or tab-separated rows for the legacy submission spreadsheet",
+					"enum":        []string{labkb.FormatJSON, labkb.FormatTSV},
+					"default":     labkb.FormatJSON,
+				},
+				"org_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ClinVar organization ID of the submitting lab",
+				},
+				"assertion_method": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the lab's classification method; defaults to " + labkb.DefaultAssertionMethod,
+				},
+				"assertion_method_citation": map[string]interface{}{
+					"type":        "string",
+					"description": "PubMed ID or URL describing the method; defaults to " + labkb.DefaultAssertionMethodCitation,
+				},
+			},
+			"required": []string{"records"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *PrepareClinVarSubmissionTool) ValidateParams(params interface{}) error {
+	var p PrepareClinVarSubmissionParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if len(p.Records) == 0 {
+		return fmt.Errorf("records is required")
+	}
+	if len(p.Records) > maxSubmissionRecords {
+		return fmt.Errorf("at most %d records can be prepared at once", maxSubmissionRecords)
+	}
+	if err := validateSubmissionFormat(p.Format, labkb.FormatJSON, labkb.FormatTSV); err != nil {
+		return err
+	}
+	for i, r := range p.Records {
+		if r.ClassificationID <= 0 {
+			return fmt.Errorf("records[%d]: classification_id is required", i)
+		}
+		if r.ConditionID != "" {
+			if _, _, ok := labkb.ParseConditionID(r.ConditionID); !ok {
+				return fmt.Errorf("records[%d]: condition_id must be PREFIX:value with a prefix of %s", i, strings.Join(labkb.ConditionSources, ", "))
+			}
+		}
+		for _, c := range r.Citations {
+			if _, ok := labkb.ParsePMID(c); !ok {
+				return fmt.Errorf("records[%d]: citation %q is not a PubMed ID", i, c)
+			}
+		}
+		if r.AffectedProbands < 0 {
+			return fmt.Errorf("records[%d]: affected_probands must not be negative", i)
+		}
+	}
+	return nil
+}
+
+// HandleTool handles the prepare_clinvar_submission tool request
+func (t *PrepareClinVarSubmissionTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params PrepareClinVarSubmissionParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+	format := strings.ToLower(strings.TrimSpace(params.Format))
+	if format == "" {
+		format = labkb.FormatJSON
+	}
+
+	result := &PrepareClinVarSubmissionResult{Format: format, Total: len(params.Records)}
+	var complete []*labkb.Assertion
+	for _, r := range params.Records {
+		record, err := t.auditStore.Get(ctx, r.ClassificationID)
+		if err != nil {
+			return auditStoreError(t.logger, "get classification", err)
+		}
+
+		assertion, prepared := t.prepare(ctx, record, r)
+		result.Records = append(result.Records, prepared)
+		if prepared.Included {
+			complete = append(complete, assertion)
+		}
+	}
+	result.Included = len(complete)
+	result.Ready = result.Included == result.Total
+
+	if len(complete) > 0 {
+		var content bytes.Buffer
+		options := labkb.SubmissionOptions{
+			OrgID:                   strings.TrimSpace(params.OrgID),
+			AssertionMethod:         strings.TrimSpace(params.AssertionMethod),
+			AssertionMethodCitation: strings.TrimSpace(params.AssertionMethodCitation),
+		}
+		if err := labkb.WriteSubmission(&content, format, complete, options); err != nil {
+			t.logger.WithError(err).Error("Failed to write ClinVar submission")
+			return internalError("Failed to write ClinVar submission", err.Error())
+		}
+		result.Submission = content.String()
+	}
+
+	switch {
+	case result.Ready:
+		result.Summary = fmt.Sprintf("All %d classification(s) are ready for ClinVar submission", result.Total)
+	case result.Included == 0:
+		result.Summary = fmt.Sprintf("None of the %d classification(s) can be submitted; fill the gaps listed for each", result.Total)
+	default:
+		result.Summary = fmt.Sprintf("%d of %d classification(s) are ready for ClinVar submission; fill the gaps listed for the others", result.Included, result.Total)
+	}
+
+	return &protocol.JSONRPC2Response{Result: result}
+}
+
+// prepare converts a stored classification to a lab assertion, filling the
+// fields it does not record from the request and then the lab knowledge base
+func (t *PrepareClinVarSubmissionTool) prepare(ctx context.Context, record *audit.Record, r SubmissionRecordParams) (*labkb.Assertion, PreparedSubmissionRecord) {
+	prepared := PreparedSubmissionRecord{ClassificationID: record.ID, HGVSNotation: record.HGVSNotation}
+	assertion := &labkb.Assertion{
+		NormalizedHGVS: record.HGVSNotation,
+		Classification: record.Classification,
+		EvaluatedAt:    record.CreatedAt,
+	}
+	if classification, err := domain.ParseClassification(record.Classification); err == nil {
+		assertion.Classification = string(classification)
+	}
+
+	var request ClassifyVariantParams
+	if len(record.Request) > 0 {
+		_ = json.Unmarshal(record.Request, &request)
+	}
+	assertion.GeneSymbol = strings.ToUpper(strings.TrimSpace(request.GeneSymbol))
+
+	var rules []ACMGAMPRuleResult
+	if len(record.AppliedRules) > 0 {
+		if err := json.Unmarshal(record.AppliedRules, &rules); err != nil {
+			t.logger.WithError(err).WithField("classification_id", record.ID).Warn("Failed to decode recorded rules")
+		}
+	}
+	for _, rule := range rules {
+		if rule.Applied {
+			assertion.CriteriaMet = append(assertion.CriteriaMet, labkb.CriterionLabel(rule.RuleCode, rule.Strength))
+		}
+	}
+
+	if lab := t.lookupLabAssertion(ctx, record.HGVSNotation); lab != nil {
+		prepared.LabAssertionID = lab.ID
+		assertion.ID = lab.ID
+		assertion.Condition, assertion.ConditionID = lab.Condition, lab.ConditionID
+		assertion.ModeOfInheritance = lab.ModeOfInheritance
+		assertion.Citations = lab.Citations
+		assertion.AffectedProbands = lab.AffectedProbands
+		assertion.Evidence = lab.Evidence
+		if assertion.GeneSymbol == "" {
+			assertion.GeneSymbol = lab.GeneSymbol
+		}
+	}
+	if r.Condition != "" || r.ConditionID != "" {
+		assertion.Condition, assertion.ConditionID = strings.TrimSpace(r.Condition), strings.TrimSpace(r.ConditionID)
+	}
+	if r.ModeOfInheritance != "" {
+		assertion.ModeOfInheritance = strings.TrimSpace(r.ModeOfInheritance)
+	}
+	if len(r.Citations) > 0 {
+		assertion.Citations = nil
+		for _, c := range r.Citations {
+			pmid, _ := labkb.ParsePMID(c)
+			assertion.Citations = append(assertion.Citations, pmid)
+		}
+	}
+	if r.AffectedProbands > 0 {
+		assertion.AffectedProbands = r.AffectedProbands
+	}
+
+	prepared.Classification = assertion.Classification
+	prepared.CriteriaMet = assertion.CriteriaMet
+	if record.Error != "" {
+		prepared.Gaps = append(prepared.Gaps, labkb.Gap{Field: "classification", Message: "the classification failed: " + record.Error})
+	} else if strings.EqualFold(request.ClassificationContext, "somatic") {
+		prepared.Gaps = append(prepared.Gaps, labkb.Gap{Field: "classification", Message: "somatic classifications are not germline submissions"})
+	}
+	prepared.Gaps = append(prepared.Gaps, assertion.SubmissionGaps()...)
+	prepared.Included = len(prepared.Gaps) == 0
+	return assertion, prepared
+}
+
+// lookupLabAssertion returns the lab knowledge base assertion for a variant;
+// lookup failures are logged and ignored
+func (t *PrepareClinVarSubmissionTool) lookupLabAssertion(ctx context.Context, hgvsNotation string) *labkb.Assertion {
+	if t.labKnowledge == nil {
+		return nil
+	}
+	assertion, err := t.labKnowledge.Lookup(ctx, hgvsNotation)
+	if err != nil {
+		t.logger.WithError(err).WithField("variant", hgvsNotation).Warn("Failed to look up lab knowledge base")
+		return nil
+	}
+	return assertion
+}
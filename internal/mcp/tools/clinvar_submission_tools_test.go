@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+)
+
+func appendTestClassification(t *testing.T, store audit.Store, hgvs, classification string) int64 {
+	t.Helper()
+	record := &audit.Record{
+		HGVSNotation:   hgvs,
+		Request:        json.RawMessage(`{"hgvs_notation":"` + hgvs + `","gene_symbol":"brca1"}`),
+		AppliedRules:   json.RawMessage(`[{"rule_code":"PS3","strength":"STRONG","applied":true},{"rule_code":"PM2","strength":"SUPPORTING","applied":true},{"rule_code":"PP3","strength":"SUPPORTING","applied":false}]`),
+		Classification: classification,
+		EngineVersion:  "test",
+	}
+	require.NoError(t, store.Append(context.Background(), record))
+	return record.ID
+}
+
+func TestPrepareClinVarSubmissionTool(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	auditStore := createTestAuditStore(t)
+	labStore := createTestLabStore(t)
+	require.NoError(t, labStore.Save(context.Background(), &labkb.Assertion{
+		NormalizedHGVS:    "NM_007294.4:c.5095C>T",
+		Classification:    "PATHOGENIC",
+		ConditionID:       "MONDO:0011450",
+		ModeOfInheritance: "Autosomal dominant inheritance",
+		Citations:         []string{"20104584"},
+		AffectedProbands:  4,
+		EvaluatedAt:       time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		EvaluatedBy:       "curator-1",
+	}))
+	fromLab := appendTestClassification(t, auditStore, "NM_007294.4:c.5095C>T", "Pathogenic")
+	fromRequest := appendTestClassification(t, auditStore, "NM_007294.4:c.5096G>A", "Likely Pathogenic")
+	incomplete := appendTestClassification(t, auditStore, "NM_007294.4:c.5097G>A", "Uncertain Significance")
+	tool := NewPrepareClinVarSubmissionTool(logger, auditStore, labStore)
+
+	// Act
+	response := tool.HandleTool(context.Background(), toolRequest("prepare_clinvar_submission", map[string]interface{}{
+		"records": []map[string]interface{}{
+			{"classification_id": fromLab},
+			{"classification_id": fromRequest, "condition": "Hereditary breast ovarian cancer syndrome", "mode_of_inheritance": "Autosomal dominant inheritance", "citations": []string{"PMID:25741868"}},
+			{"classification_id": incomplete},
+		},
+	}))
+	missing := tool.HandleTool(context.Background(), toolRequest("prepare_clinvar_submission", map[string]interface{}{
+		"records": []map[string]interface{}{{"classification_id": 999}},
+	}))
+	badCondition := tool.HandleTool(context.Background(), toolRequest("prepare_clinvar_submission", map[string]interface{}{
+		"records": []map[string]interface{}{{"classification_id": fromLab, "condition_id": "DOID:1612"}},
+	}))
+
+	// Assert
+	require.Nil(t, response.Error)
+	result := response.Result.(*PrepareClinVarSubmissionResult)
+	assert.False(t, result.Ready)
+	assert.Equal(t, 2, result.Included)
+	assert.Equal(t, 3, result.Total)
+	require.Len(t, result.Records, 3)
+	assert.NotZero(t, result.Records[0].LabAssertionID)
+	assert.Equal(t, []string{"PS3", "PM2_Supporting"}, result.Records[0].CriteriaMet)
+	assert.True(t, result.Records[1].Included)
+	assert.False(t, result.Records[2].Included)
+	var fields []string
+	for _, gap := range result.Records[2].Gaps {
+		fields = append(fields, gap.Field)
+	}
+	assert.Equal(t, []string{"condition", "mode_of_inheritance", "citations"}, fields)
+
+	var submission struct {
+		Actions []struct {
+			Data struct {
+				Content struct {
+					ClinvarSubmission []json.RawMessage `json:"clinvarSubmission"`
+				} `json:"content"`
+			} `json:"data"`
+		} `json:"actions"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Submission), &submission))
+	require.Len(t, submission.Actions, 1)
+	assert.Len(t, submission.Actions[0].Data.Content.ClinvarSubmission, 2)
+	assert.Contains(t, result.Submission, `"germlineClassificationDescription": "Likely pathogenic"`)
+	assert.Contains(t, result.Submission, `"id": "20104584"`)
+	assert.Contains(t, result.Submission, "ACMG/AMP criteria met: PS3, PM2_Supporting.")
+
+	require.NotNil(t, missing.Error)
+	require.NotNil(t, badCondition.Error)
+}
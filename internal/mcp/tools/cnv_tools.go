@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/cnv"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// ClassifyCNVTool implements the classify_cnv MCP tool
+type ClassifyCNVTool struct {
+	logger     *logrus.Logger
+	classifier *cnv.Classifier
+}
+
+// ClassifyCNVParams defines parameters for the classify_cnv tool
+type ClassifyCNVParams struct {
+	CNV      string         `json:"cnv"`
+	Type     string         `json:"type,omitempty"`
+	Assembly string         `json:"assembly,omitempty"`
+	Evidence []cnv.Evidence `json:"evidence,omitempty"`
+}
+
+// NewClassifyCNVTool creates a new classify_cnv tool
+func NewClassifyCNVTool(logger *logrus.Logger, classifier *cnv.Classifier) *ClassifyCNVTool {
+	return &ClassifyCNVTool{
+		logger:     logger,
+		classifier: classifier,
+	}
+}
+
+// GetToolInfo returns the tool information for classify_cnv
+func (t *ClassifyCNVTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "classify_cnv",
+		Description: "Classify a copy-number deletion or duplication with the ACMG/ClinGen 2019 CNV scoring scheme. Sections 1-3 are scored from the bundled gene and ClinGen dosage annotations; curator evidence for sections 4-5 (and overrides for 1-3) is submitted by code. Returns the point breakdown per criterion and section with the final classification.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"cnv": map[string]interface{}{
+					"type":        "string",
+					"description": "CNV in ISCN, e.g. arr[GRCh38] 17q12(36459258_37856255)x1, or as a genomic interval, e.g. chr17:36459258-37856255",
+				},
+				"type": map[string]interface{}{
+					"type":        "string",
+					"description": "deletion or duplication; required for an interval, taken from the copy number for ISCN",
+					"enum":        []string{string(cnv.Loss), string(cnv.Gain)},
+				},
+				"assembly": map[string]interface{}{
+					"type":        "string",
+					"description": "Reference assembly of an interval (default GRCh38)",
+					"enum":        []string{cnv.GRCh37, cnv.GRCh38},
+				},
+				"evidence": map[string]interface{}{
+					"type":        "array",
+					"description": "Curator evidence categories, e.g. 4A or 5H; points default to the suggested points",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"code":   map[string]interface{}{"type": "string", "description": "Evidence category, e.g. 2C-1, 4A"},
+							"points": map[string]interface{}{"type": "number", "description": "Points within the range allowed for the category"},
+							"note":   map[string]interface{}{"type": "string", "description": "Supporting detail, e.g. PMIDs"},
+						},
+						"required": []string{"code"},
+					},
+				},
+			},
+			"required": []string{"cnv"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ClassifyCNVTool) ValidateParams(params interface{}) error {
+	var p ClassifyCNVParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.CNV) == "" {
+		return fmt.Errorf("cnv is required")
+	}
+	for _, e := range p.Evidence {
+		if strings.TrimSpace(e.Code) == "" {
+			return fmt.Errorf("evidence code is required")
+		}
+	}
+	return nil
+}
+
+// HandleTool handles the classify_cnv tool request
+func (t *ClassifyCNVTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ClassifyCNVParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	call, err := cnv.Parse(params.CNV, params.Type, params.Assembly)
+	if err != nil {
+		return invalidParamsError(err.Error())
+	}
+	result, err := t.classifier.Evaluate(call, params.Evidence)
+	if err != nil {
+		if errors.Is(err, cnv.ErrInvalidCNV) {
+			return invalidParamsError(err.Error())
+		}
+		t.logger.WithError(err).Error("Failed to classify CNV")
+		return internalError("Failed to classify CNV", err.Error())
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"cnv_classification": result,
+		},
+	}
+}
@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/cnv"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+func createTestCNVTool(t *testing.T) *ClassifyCNVTool {
+	t.Helper()
+	logger, _ := test.NewNullLogger()
+	annotations, err := cnv.LoadDir(filepath.Join("..", "..", "..", "examples", "cnv"))
+	require.NoError(t, err)
+	return NewClassifyCNVTool(logger, cnv.NewClassifier(annotations))
+}
+
+func TestClassifyCNVTool_HandleTool(t *testing.T) {
+	tool := createTestCNVTool(t)
+
+	// Act
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "classify_cnv",
+		Params: map[string]interface{}{
+			"cnv":      "arr[GRCh38] 17q12(36459258_37856255)x1",
+			"evidence": []interface{}{map[string]interface{}{"code": "5C", "points": -0.15, "note": "Inherited from an unaffected mother"}},
+		},
+		ID: 1,
+	})
+
+	// Assert
+	require.Nil(t, response.Error)
+	result := response.Result.(map[string]interface{})["cnv_classification"].(*cnv.Result)
+	assert.Equal(t, cnv.Loss, result.Type)
+	assert.Equal(t, 1, result.CopyNumber)
+	assert.InDelta(t, 0.85, result.TotalPoints, 0.001)
+	assert.Equal(t, domain.VUS, result.Classification)
+}
+
+func TestClassifyCNVTool_HandleTool_Interval(t *testing.T) {
+	tool := createTestCNVTool(t)
+
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "classify_cnv",
+		Params:  map[string]interface{}{"cnv": "chr17:37600000-37800000", "type": "deletion"},
+		ID:      1,
+	})
+
+	require.Nil(t, response.Error)
+	result := response.Result.(map[string]interface{})["cnv_classification"].(*cnv.Result)
+	assert.Equal(t, []string{"HNF1B"}, result.ProteinCodingGenes)
+	assert.Equal(t, domain.PATHOGENIC, result.Classification)
+}
+
+func TestClassifyCNVTool_HandleTool_InvalidParams(t *testing.T) {
+	tool := createTestCNVTool(t)
+
+	for _, params := range []map[string]interface{}{
+		{},
+		{"cnv": "chr17:37600000-37800000"},
+		{"cnv": "chr17:37600000-37800000", "type": "deletion", "evidence": []interface{}{map[string]interface{}{"code": "2K"}}},
+		{"cnv": "chr17:37600000-37800000", "type": "deletion", "evidence": []interface{}{map[string]interface{}{"code": "4A", "points": 2}}},
+	} {
+		response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+			JSONRPC: "2.0",
+			Method:  "classify_cnv",
+			Params:  params,
+			ID:      1,
+		})
+
+		require.NotNil(t, response.Error, "params %v", params)
+		assert.Equal(t, protocol.InvalidParams, response.Error.Code)
+	}
+}
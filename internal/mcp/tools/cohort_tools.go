@@ -0,0 +1,285 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/cohort"
+	"github.com/acmg-amp-mcp-server/internal/matchmaker"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// =============================================================================
+// Cohort Frequency Tool
+// =============================================================================
+
+// CohortFrequencyTool implements the query_cohort_frequency MCP tool
+type CohortFrequencyTool struct {
+	logger   *logrus.Logger
+	store    cohort.Store
+	criteria cohort.ArtifactCriteria
+}
+
+// CohortFrequencyParams defines parameters for the query_cohort_frequency tool
+type CohortFrequencyParams struct {
+	NormalizedHGVS string  `json:"normalized_hgvs"`
+	PopulationAF   float64 `json:"population_af,omitempty"` // e.g. gnomAD popmax, for the artifact check
+}
+
+// NewCohortFrequencyTool creates a new query_cohort_frequency tool
+func NewCohortFrequencyTool(logger *logrus.Logger, store cohort.Store, criteria cohort.ArtifactCriteria) *CohortFrequencyTool {
+	return &CohortFrequencyTool{
+		logger:   logger,
+		store:    store,
+		criteria: criteria,
+	}
+}
+
+// GetToolInfo returns the tool information for query_cohort_frequency
+func (t *CohortFrequencyTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "query_cohort_frequency",
+		Description: "Get the in-house cohort allele frequency of a variant, computed from this deployment's classified cases and deduplicated by proband. Supplementary to population databases; flags suspected recurrent sequencing artifacts.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"normalized_hgvs": map[string]interface{}{
+					"type":        "string",
+					"description": "Normalized HGVS notation of the variant",
+				},
+				"population_af": map[string]interface{}{
+					"type":        "number",
+					"description": "Population allele frequency (e.g. gnomAD) to compare against; an artifact is only suspected when the cohort frequency far exceeds it",
+					"minimum":     0,
+					"maximum":     1,
+				},
+			},
+			"required": []string{"normalized_hgvs"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *CohortFrequencyTool) ValidateParams(params interface{}) error {
+	var p CohortFrequencyParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.NormalizedHGVS) == "" {
+		return fmt.Errorf("normalized_hgvs is required")
+	}
+	if p.PopulationAF < 0 || p.PopulationAF > 1 {
+		return fmt.Errorf("population_af must be between 0 and 1")
+	}
+	return nil
+}
+
+// HandleTool handles the query_cohort_frequency tool request
+func (t *CohortFrequencyTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params CohortFrequencyParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	freq, err := t.store.Frequency(ctx, strings.TrimSpace(params.NormalizedHGVS))
+	if err != nil {
+		return internalError("Failed to compute cohort frequency", err.Error())
+	}
+	t.criteria.Assess(freq, params.PopulationAF)
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"cohort_frequency": freq,
+		},
+	}
+}
+
+// =============================================================================
+// Cohort Artifacts Tool
+// =============================================================================
+
+// CohortArtifactsTool implements the list_cohort_artifacts MCP tool
+type CohortArtifactsTool struct {
+	logger   *logrus.Logger
+	store    cohort.Store
+	criteria cohort.ArtifactCriteria
+}
+
+// CohortArtifactsParams defines parameters for the list_cohort_artifacts tool
+type CohortArtifactsParams struct {
+	Limit int `json:"limit,omitempty"`
+}
+
+// NewCohortArtifactsTool creates a new list_cohort_artifacts tool
+func NewCohortArtifactsTool(logger *logrus.Logger, store cohort.Store, criteria cohort.ArtifactCriteria) *CohortArtifactsTool {
+	return &CohortArtifactsTool{
+		logger:   logger,
+		store:    store,
+		criteria: criteria,
+	}
+}
+
+// GetToolInfo returns the tool information for list_cohort_artifacts
+func (t *CohortArtifactsTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "list_cohort_artifacts",
+		Description: "List variants recurring across the in-house cohort often enough to be suspected artifacts of the lab's sequencing pipeline, most frequent first.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of variants to return",
+					"minimum":     1,
+					"maximum":     500,
+					"default":     50,
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *CohortArtifactsTool) ValidateParams(params interface{}) error {
+	_, err := t.parseParams(params)
+	return err
+}
+
+// HandleTool handles the list_cohort_artifacts tool request
+func (t *CohortArtifactsTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	params, err := t.parseParams(req.Params)
+	if err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	// Fetch generously: carrier count alone doesn't decide, the carrier fraction does
+	recurrent, err := t.store.Recurrent(ctx, 2, params.Limit*4)
+	if err != nil {
+		return internalError("Failed to list recurrent variants", err.Error())
+	}
+
+	artifacts := make([]*cohort.Frequency, 0)
+	for _, freq := range recurrent {
+		t.criteria.Assess(freq, 0)
+		if freq.SuspectArtifact {
+			artifacts = append(artifacts, freq)
+		}
+		if len(artifacts) == params.Limit {
+			break
+		}
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"artifacts": artifacts,
+			"total":     len(artifacts),
+		},
+	}
+}
+
+// parseParams parses the optional parameters, applying the default limit
+func (t *CohortArtifactsTool) parseParams(params interface{}) (CohortArtifactsParams, error) {
+	p := CohortArtifactsParams{}
+	if params != nil {
+		if err := ParseParams(params, &p); err != nil {
+			return p, err
+		}
+	}
+	if p.Limit == 0 {
+		p.Limit = 50
+	}
+	if p.Limit < 1 || p.Limit > 500 {
+		return p, fmt.Errorf("limit must be between 1 and 500")
+	}
+	return p, nil
+}
+
+// =============================================================================
+// Internal Case Match Tool
+// =============================================================================
+
+// MatchInternalCasesTool implements the match_internal_cases MCP tool
+type MatchInternalCasesTool struct {
+	logger     *logrus.Logger
+	matchmaker *matchmaker.Matchmaker
+}
+
+// NewMatchInternalCasesTool creates a new match_internal_cases tool
+func NewMatchInternalCasesTool(logger *logrus.Logger, mm *matchmaker.Matchmaker) *MatchInternalCasesTool {
+	return &MatchInternalCasesTool{
+		logger:     logger,
+		matchmaker: mm,
+	}
+}
+
+// GetToolInfo returns the tool information for match_internal_cases
+func (t *MatchInternalCasesTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "match_internal_cases",
+		Description: "Match a case against this deployment's own cases, Matchmaker Exchange style: anonymized counts of cases carrying candidate (not benign) variants in a gene and how many have a similar phenotype, per variant. Supports PS4 case counting and finding families for PP1 segregation; proband IDs are never returned.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gene_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "HGNC symbol of the gene carrying the candidate variant",
+				},
+				"hpo_terms": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "HPO terms of the patient being matched, e.g. HP:0001250",
+				},
+				"proband_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Proband being matched, excluded from the counts",
+				},
+				"min_similarity": map[string]interface{}{
+					"type":        "number",
+					"description": "Phenotype similarity a case needs to match",
+					"minimum":     0,
+					"maximum":     1,
+					"default":     matchmaker.DefaultMinSimilarity,
+				},
+			},
+			"required": []string{"gene_symbol"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *MatchInternalCasesTool) ValidateParams(params interface{}) error {
+	var q matchmaker.Query
+	if err := ParseParams(params, &q); err != nil {
+		return err
+	}
+	return q.Validate()
+}
+
+// HandleTool handles the match_internal_cases tool request
+func (t *MatchInternalCasesTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var q matchmaker.Query
+	if err := ParseParams(req.Params, &q); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	result, err := t.matchmaker.Match(ctx, q)
+	if errors.Is(err, matchmaker.ErrInvalidQuery) {
+		return invalidParamsError(err.Error())
+	}
+	if err != nil {
+		return internalError("Failed to match internal cases", err.Error())
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"match": result,
+		},
+	}
+}
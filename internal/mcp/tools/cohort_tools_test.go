@@ -0,0 +1,163 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/cohort"
+	"github.com/acmg-amp-mcp-server/internal/matchmaker"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+func createTestCohortStore(t *testing.T) *cohort.SQLiteStore {
+	t.Helper()
+
+	store, err := cohort.NewSQLiteStore(filepath.Join(t.TempDir(), "cohort.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// seedCohort records ten probands, six of whom carry the given variant
+func seedCohort(t *testing.T, store cohort.Store, recurrent string) {
+	t.Helper()
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		proband := fmt.Sprintf("P%d", i)
+		_, err := store.Record(ctx, &cohort.Observation{ProbandID: proband, NormalizedHGVS: fmt.Sprintf("NM_000001.1:c.%dA>G", i+1)})
+		require.NoError(t, err)
+		if i < 6 {
+			_, err = store.Record(ctx, &cohort.Observation{ProbandID: proband, NormalizedHGVS: recurrent})
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestCohortFrequencyTool_FlagsRecurrentVariant(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestCohortStore(t)
+	recurrent := "NM_000059.4:c.68-7T>A"
+	seedCohort(t, store, recurrent)
+	criteria := cohort.ArtifactCriteria{MinCohortSize: 10, MinCarrierFraction: 0.2, PopulationRatio: 10}
+	tool := NewCohortFrequencyTool(logger, store, criteria)
+
+	// Act
+	rare := tool.HandleTool(context.Background(), toolRequest("query_cohort_frequency", map[string]interface{}{
+		"normalized_hgvs": recurrent,
+		"population_af":   0.0001,
+	}))
+	common := tool.HandleTool(context.Background(), toolRequest("query_cohort_frequency", map[string]interface{}{
+		"normalized_hgvs": recurrent,
+		"population_af":   0.2,
+	}))
+
+	// Assert
+	require.Nil(t, rare.Error)
+	require.Nil(t, common.Error)
+	freq := rare.Result.(map[string]interface{})["cohort_frequency"].(*cohort.Frequency)
+	assert.Equal(t, 6, freq.Carriers)
+	assert.Equal(t, 10, freq.CohortSize)
+	assert.InDelta(t, 0.3, freq.AlleleFrequency, 1e-9)
+	assert.True(t, freq.SuspectArtifact)
+	assert.False(t, common.Result.(map[string]interface{})["cohort_frequency"].(*cohort.Frequency).SuspectArtifact)
+}
+
+func TestCohortFrequencyTool_RequiresVariant(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewCohortFrequencyTool(logger, createTestCohortStore(t), cohort.DefaultArtifactCriteria())
+
+	response := tool.HandleTool(context.Background(), toolRequest("query_cohort_frequency", map[string]interface{}{
+		"population_af": 0.01,
+	}))
+
+	require.NotNil(t, response.Error)
+}
+
+func TestCohortArtifactsTool_ListsOnlySuspectedArtifacts(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestCohortStore(t)
+	recurrent := "NM_000059.4:c.68-7T>A"
+	seedCohort(t, store, recurrent)
+	// A second variant shared by two probands stays below the carrier fraction
+	for _, proband := range []string{"P8", "P9"} {
+		_, err := store.Record(context.Background(), &cohort.Observation{ProbandID: proband, NormalizedHGVS: "NM_000546.6:c.215C>G"})
+		require.NoError(t, err)
+	}
+	criteria := cohort.ArtifactCriteria{MinCohortSize: 10, MinCarrierFraction: 0.3, PopulationRatio: 10}
+	tool := NewCohortArtifactsTool(logger, store, criteria)
+
+	response := tool.HandleTool(context.Background(), toolRequest("list_cohort_artifacts", nil))
+
+	require.Nil(t, response.Error)
+	artifacts := response.Result.(map[string]interface{})["artifacts"].([]*cohort.Frequency)
+	require.Len(t, artifacts, 1)
+	assert.Equal(t, recurrent, artifacts[0].NormalizedHGVS)
+}
+
+func TestClassifyVariantTool_ObserveInCohortDeduplicatesProband(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestCohortStore(t)
+	tool := NewClassifyVariantToolLegacy(logger, nil)
+	tool.SetCohortStore(store, cohort.DefaultArtifactCriteria())
+	hgvs := "NM_000492.4:c.1521_1523del"
+
+	// Act: the same proband classified twice, then a second proband
+	tool.observeInCohort(context.Background(), hgvs, "CFTR", "PATHOGENIC", &ClassifyVariantParams{ProbandID: "P1"})
+	tool.observeInCohort(context.Background(), hgvs, "CFTR", "PATHOGENIC", &ClassifyVariantParams{ProbandID: "P1", Zygosity: "homozygous"})
+	freq := tool.observeInCohort(context.Background(), hgvs, "CFTR", "PATHOGENIC", &ClassifyVariantParams{ProbandID: "P2"})
+
+	// Assert
+	require.NotNil(t, freq)
+	assert.Equal(t, 2, freq.Carriers)
+	assert.Equal(t, 3, freq.AlleleCount)
+	assert.False(t, freq.SuspectArtifact)
+}
+
+func TestMatchInternalCasesTool_CountsMatchingCases(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestCohortStore(t)
+	tool := NewClassifyVariantToolLegacy(logger, nil)
+	tool.SetCohortStore(store, cohort.DefaultArtifactCriteria())
+	hgvs := "NM_001165963.4:c.2836C>T"
+	for _, proband := range []string{"P1", "P2"} {
+		tool.observeInCohort(context.Background(), hgvs, "SCN1A", "Uncertain significance", &ClassifyVariantParams{
+			ProbandID:      proband,
+			PatientContext: &service.PatientContext{HPOTerms: []string{"HP:0001250"}},
+		})
+	}
+	matchTool := NewMatchInternalCasesTool(logger, matchmaker.NewMatchmaker(store, nil))
+
+	// Act
+	response := matchTool.HandleTool(context.Background(), toolRequest("match_internal_cases", map[string]interface{}{
+		"gene_symbol": "SCN1A",
+		"hpo_terms":   []string{"HP:0001250"},
+		"proband_id":  "P1",
+	}))
+
+	// Assert
+	require.Nil(t, response.Error)
+	result := response.Result.(map[string]interface{})["match"].(*matchmaker.Result)
+	assert.Equal(t, 1, result.Cases)
+	assert.Equal(t, 1, result.PhenotypeMatchedCases)
+	require.Len(t, result.Variants, 1)
+	assert.Equal(t, hgvs, result.Variants[0].NormalizedHGVS)
+
+	invalid := matchTool.HandleTool(context.Background(), toolRequest("match_internal_cases", map[string]interface{}{
+		"hpo_terms": []string{"HP:0001250"},
+	}))
+	require.NotNil(t, invalid.Error)
+}
+
+func TestClassifyVariantTool_ObserveInCohortWithoutStore(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewClassifyVariantToolLegacy(logger, nil)
+
+	assert.Nil(t, tool.observeInCohort(context.Background(), "NM_000492.4:c.1521_1523del", "CFTR", "PATHOGENIC", &ClassifyVariantParams{ProbandID: "P1"}))
+}
@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/digest"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// GetWeeklyDigestTool implements the get_weekly_digest MCP tool
+type GetWeeklyDigestTool struct {
+	logger    *logrus.Logger
+	generator *digest.Generator
+}
+
+// GetWeeklyDigestParams defines parameters for the get_weekly_digest tool
+type GetWeeklyDigestParams struct {
+	WeekOf string `json:"week_of,omitempty"` // Any date in the week, YYYY-MM-DD; defaults to the current week
+}
+
+// NewGetWeeklyDigestTool creates a new get_weekly_digest tool
+func NewGetWeeklyDigestTool(logger *logrus.Logger, generator *digest.Generator) *GetWeeklyDigestTool {
+	return &GetWeeklyDigestTool{
+		logger:    logger,
+		generator: generator,
+	}
+}
+
+// GetToolInfo returns the tool information for get_weekly_digest
+func (t *GetWeeklyDigestTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "get_weekly_digest",
+		Description: "Generate the weekly variant review digest: classifications signed out, reclassifications, discordances with ClinVar and data source updates. Weeks run Monday to Sunday (UTC).",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"week_of": map[string]interface{}{
+					"type":        "string",
+					"description": "Any date in the week to report (YYYY-MM-DD). Defaults to the current week.",
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *GetWeeklyDigestTool) ValidateParams(params interface{}) error {
+	_, err := t.parseWeek(params)
+	return err
+}
+
+// HandleTool handles the get_weekly_digest tool request
+func (t *GetWeeklyDigestTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	weekOf, err := t.parseWeek(req.Params)
+	if err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	d, err := t.generator.Generate(ctx, weekOf)
+	if err != nil {
+		t.logger.WithError(err).Error("Failed to generate weekly digest")
+		return internalError("Failed to generate weekly digest", err.Error())
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"digest": d,
+			"text":   d.Text(),
+		},
+	}
+}
+
+// parseWeek returns the requested date, or now when no week is given
+func (t *GetWeeklyDigestTool) parseWeek(params interface{}) (time.Time, error) {
+	var p GetWeeklyDigestParams
+	if params != nil {
+		if err := ParseParams(params, &p); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if p.WeekOf == "" {
+		return time.Now(), nil
+	}
+	weekOf, err := time.Parse("2006-01-02", p.WeekOf)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("week_of must be a date in YYYY-MM-DD format")
+	}
+	return weekOf, nil
+}
@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/digest"
+	"github.com/acmg-amp-mcp-server/internal/feedback"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+func createTestDigestTool(t *testing.T) (*GetWeeklyDigestTool, *feedback.SQLiteStore) {
+	t.Helper()
+	logger, _ := test.NewNullLogger()
+	store, err := feedback.NewSQLiteStore(filepath.Join(t.TempDir(), "feedback.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return NewGetWeeklyDigestTool(logger, digest.NewGenerator(logger, store)), store
+}
+
+func TestGetWeeklyDigestTool_HandleTool_CurrentWeek(t *testing.T) {
+	tool, store := createTestDigestTool(t)
+	require.NoError(t, store.Save(context.Background(), &feedback.Feedback{
+		Variant:                 "BRCA1:c.5266dupC",
+		NormalizedHGVS:          "NM_007294.4:c.5266dupC",
+		SuggestedClassification: "Pathogenic",
+		UserClassification:      "Pathogenic",
+		UserAgreed:              true,
+	}))
+
+	// Act
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{JSONRPC: "2.0", Method: "get_weekly_digest", ID: 1})
+
+	// Assert
+	require.Nil(t, response.Error)
+	result := response.Result.(map[string]interface{})
+	d := result["digest"].(*digest.Digest)
+	assert.Equal(t, digest.WeekStart(time.Now()), d.WeekStart)
+	assert.Equal(t, 1, d.Classifications.Total)
+	assert.Contains(t, result["text"], "Classifications signed out: 1")
+}
+
+func TestGetWeeklyDigestTool_HandleTool_WeekOf(t *testing.T) {
+	tool, _ := createTestDigestTool(t)
+
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "get_weekly_digest",
+		Params:  map[string]interface{}{"week_of": "2026-10-15"},
+		ID:      1,
+	})
+
+	require.Nil(t, response.Error)
+	d := response.Result.(map[string]interface{})["digest"].(*digest.Digest)
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), d.WeekStart)
+	assert.Zero(t, d.Classifications.Total)
+}
+
+func TestGetWeeklyDigestTool_HandleTool_InvalidDate(t *testing.T) {
+	tool, _ := createTestDigestTool(t)
+
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "get_weekly_digest",
+		Params:  map[string]interface{}{"week_of": "15/10/2026"},
+		ID:      1,
+	})
+
+	require.NotNil(t, response.Error)
+	assert.Equal(t, protocol.InvalidParams, response.Error.Code)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/metrics"
 )
 
 // EvidenceCache provides caching for external database queries
@@ -46,6 +47,7 @@ func (c *EvidenceCache) Get(key string, maxAge string) *QueryEvidenceResult {
 
 	entry, exists := c.cache[key]
 	if !exists {
+		metrics.ObserveCache("query_evidence", metrics.CacheMiss)
 		return nil
 	}
 
@@ -59,8 +61,10 @@ func (c *EvidenceCache) Get(key string, maxAge string) *QueryEvidenceResult {
 	// Check if entry is expired
 	if time.Since(entry.Timestamp) > maxAgeDuration {
 		c.logger.WithField("key", key).Debug("Cache entry expired")
+		metrics.ObserveCache("query_evidence", metrics.CacheMiss)
 		return nil
 	}
+	metrics.ObserveCache("query_evidence", metrics.CacheHit)
 
 	// Update access statistics
 	entry.AccessCount++
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/service"
 )
 
 // TestEvidenceToolsIntegration tests the full evidence gathering workflow
@@ -291,6 +292,57 @@ func TestErrorHandling(t *testing.T) {
 
 // Helper functions
 
+// stubFrequencyThresholds returns fixed cutoffs and records what was asked for
+type stubFrequencyThresholds struct {
+	gene, condition string
+}
+
+func (s *stubFrequencyThresholds) FrequencyThresholds(ctx context.Context, gene, condition string) *service.FrequencyThresholds {
+	s.gene, s.condition = gene, condition
+	return &service.FrequencyThresholds{
+		BA1AlleleFrequency: 0.1,
+		BS1AlleleFrequency: 0.08,
+		PM2AlleleFrequency: 0.0000001,
+		Source:             service.FrequencySourceOverride,
+		Gene:               gene,
+		Condition:          condition,
+		Override:           gene,
+	}
+}
+
+func TestQueryEvidenceTool_FrequencyAssessmentUsesConfiguredThresholds(t *testing.T) {
+	source := &stubFrequencyThresholds{}
+	tool := NewQueryEvidenceTool(logrus.New())
+	tool.SetFrequencyThresholdSource(source)
+
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		Method: "query_evidence",
+		Params: QueryEvidenceParams{
+			HGVSNotation: "NM_000410.4:c.845G>A",
+			GeneSymbol:   "HFE",
+			Condition:    "Hereditary hemochromatosis",
+			Databases:    []string{"gnomad"},
+		},
+	})
+	require.Nil(t, response.Error)
+
+	evidenceBytes, err := json.Marshal(response.Result.(map[string]interface{})["evidence"])
+	require.NoError(t, err)
+	var result QueryEvidenceResult
+	require.NoError(t, json.Unmarshal(evidenceBytes, &result))
+
+	assert.Equal(t, "HFE", source.gene)
+	assert.Equal(t, "Hereditary hemochromatosis", source.condition)
+
+	// The mock gnomAD frequency clears the lowered PM2 cutoff, and the cutoffs are echoed back
+	frequency := result.AggregatedEvidence.PopulationFrequency
+	assert.Equal(t, "rare - compatible with pathogenicity", frequency.FrequencyAssessment)
+	require.NotNil(t, frequency.Thresholds)
+	assert.Equal(t, service.FrequencySourceOverride, frequency.Thresholds.Source)
+	assert.Equal(t, 0.1, frequency.Thresholds.BA1AlleleFrequency)
+	assert.False(t, result.ACMGCriteriaHints["PM2"].Applicable)
+}
+
 func testQueryEvidenceTool(t *testing.T, tool *QueryEvidenceTool, hgvs string) *QueryEvidenceResult {
 	params := QueryEvidenceParams{
 		HGVSNotation: hgvs,
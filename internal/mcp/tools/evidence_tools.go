@@ -2,19 +2,34 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/acmg-amp-mcp-server/internal/domain"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+	"github.com/acmg-amp-mcp-server/internal/webhook"
 )
 
 // QueryEvidenceTool implements the query_evidence MCP tool for comprehensive evidence gathering
 type QueryEvidenceTool struct {
-	logger *logrus.Logger
-	cache  *EvidenceCache
+	logger     *logrus.Logger
+	cache      *EvidenceCache
+	snapshots  snapshot.Store
+	thresholds FrequencyThresholdSource
+	webhooks   webhook.Publisher
+}
+
+// FrequencyThresholdSource resolves the BA1, BS1 and PM2 cutoffs for a gene and condition
+type FrequencyThresholdSource interface {
+	FrequencyThresholds(ctx context.Context, gene, condition string) *service.FrequencyThresholds
 }
 
 // QueryEvidenceParams defines parameters for the query_evidence tool
@@ -23,6 +38,7 @@ type QueryEvidenceParams struct {
 	HGVSNotation    string   `json:"hgvs_notation" validate:"required"`
 	GeneSymbol      string   `json:"gene_symbol,omitempty"`
 	GenomicPosition string   `json:"genomic_position,omitempty"`
+	Condition       string   `json:"condition,omitempty"` // selects configured frequency thresholds
 	Databases       []string `json:"databases,omitempty"` // specific databases to query
 	IncludeRaw      bool     `json:"include_raw,omitempty"`
 	MaxAge          string   `json:"max_age,omitempty"` // cache max age (e.g., "24h")
@@ -76,6 +92,7 @@ type PopulationFrequencyData struct {
 	HomozygoteCount     int                `json:"homozygote_count"`
 	QualityMetrics      map[string]float64 `json:"quality_metrics"`
 	FrequencyAssessment string             `json:"frequency_assessment"`
+	Thresholds          *service.FrequencyThresholds `json:"thresholds,omitempty"` // BA1, BS1 and PM2 cutoffs the assessment used
 }
 
 // ClinicalEvidenceData contains clinical significance information
@@ -167,6 +184,39 @@ func NewQueryEvidenceTool(logger *logrus.Logger) *QueryEvidenceTool {
 	}
 }
 
+// SetSnapshotStore enables recording of gathered evidence as point-in-time snapshots
+func (t *QueryEvidenceTool) SetSnapshotStore(store snapshot.Store) {
+	t.snapshots = store
+}
+
+// SetWebhookPublisher enables evidence.updated events when a database's
+// results for a variant differ from its previous snapshot
+func (t *QueryEvidenceTool) SetWebhookPublisher(publisher webhook.Publisher) {
+	t.webhooks = publisher
+}
+
+// SetFrequencyThresholdSource makes the frequency assessment use the gene's
+// configured cutoffs instead of the defaults
+func (t *QueryEvidenceTool) SetFrequencyThresholdSource(source FrequencyThresholdSource) {
+	t.thresholds = source
+}
+
+// frequencyThresholds resolves the cutoffs for the queried gene and condition
+func (t *QueryEvidenceTool) frequencyThresholds(ctx context.Context, params *QueryEvidenceParams) *service.FrequencyThresholds {
+	if t.thresholds != nil {
+		return t.thresholds.FrequencyThresholds(ctx, params.GeneSymbol, params.Condition)
+	}
+	defaults := thresholds.Defaults()
+	return &service.FrequencyThresholds{
+		BA1AlleleFrequency: defaults.BA1AlleleFrequency,
+		BS1AlleleFrequency: defaults.BS1AlleleFrequency,
+		PM2AlleleFrequency: defaults.PM2AlleleFrequency,
+		Source:             service.FrequencySourceDefault,
+		Gene:               params.GeneSymbol,
+		Condition:          params.Condition,
+	}
+}
+
 // HandleTool implements the ToolHandler interface for query_evidence
 func (t *QueryEvidenceTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
 	startTime := time.Now()
@@ -212,6 +262,9 @@ func (t *QueryEvidenceTool) HandleTool(ctx context.Context, req *protocol.JSONRP
 	// Cache the result
 	t.cacheResult(&params, result)
 
+	// Record an evidence snapshot for later audit
+	t.recordSnapshot(ctx, result)
+
 	t.logger.WithFields(logrus.Fields{
 		"hgvs":            params.HGVSNotation,
 		"databases":       len(result.DatabaseResults),
@@ -242,6 +295,10 @@ func (t *QueryEvidenceTool) GetToolInfo() protocol.ToolInfo {
 					"type":        "string",
 					"description": "HGNC gene symbol",
 				},
+				"condition": map[string]interface{}{
+					"type":        "string",
+					"description": "Condition under evaluation (name or MONDO ID); selects gene/condition-specific frequency thresholds",
+				},
 				"genomic_position": map[string]interface{}{
 					"type":        "string",
 					"description": "Genomic position (chr:pos format)",
@@ -303,7 +360,7 @@ func (t *QueryEvidenceTool) parseAndValidateParams(params interface{}, target *Q
 // checkCache checks for cached results
 func (t *QueryEvidenceTool) checkCache(params *QueryEvidenceParams) *QueryEvidenceResult {
 	if t.cache != nil {
-		return t.cache.Get(params.HGVSNotation, params.MaxAge)
+		return t.cache.Get(evidenceCacheKey(params), params.MaxAge)
 	}
 	return nil
 }
@@ -311,10 +368,87 @@ func (t *QueryEvidenceTool) checkCache(params *QueryEvidenceParams) *QueryEviden
 // cacheResult caches the evidence result
 func (t *QueryEvidenceTool) cacheResult(params *QueryEvidenceParams, result *QueryEvidenceResult) {
 	if t.cache != nil {
-		t.cache.Set(params.HGVSNotation, result)
+		t.cache.Set(evidenceCacheKey(params), result)
+	}
+}
+
+// evidenceCacheKey keys cached results by variant and, because the frequency
+// assessment depends on it, by condition
+func evidenceCacheKey(params *QueryEvidenceParams) string {
+	if params.Condition == "" {
+		return params.HGVSNotation
+	}
+	return params.HGVSNotation + "|" + strings.ToLower(params.Condition)
+}
+
+// recordSnapshot persists the evidence result; failures are logged and do not fail the query
+func (t *QueryEvidenceTool) recordSnapshot(ctx context.Context, result *QueryEvidenceResult) {
+	if t.snapshots == nil {
+		return
+	}
+
+	payload, err := json.Marshal(result)
+	if err != nil {
+		t.logger.WithError(err).Warn("Failed to marshal evidence snapshot")
+		return
+	}
+
+	// Compare with the previous snapshot before this one becomes the latest
+	if t.webhooks != nil {
+		if changed := t.changedSources(ctx, result.HGVSNotation, payload); len(changed) > 0 {
+			t.webhooks.Publish(ctx, &webhook.Event{
+				Type:    webhook.EventEvidenceUpdated,
+				Variant: result.HGVSNotation,
+				Data: map[string]interface{}{
+					"changed_sources":  changed,
+					"database_results": result.DatabaseResults,
+				},
+			})
+		}
+	}
+
+	if err := t.snapshots.Save(ctx, &snapshot.Snapshot{
+		NormalizedHGVS: result.HGVSNotation,
+		Payload:        payload,
+	}); err != nil {
+		t.logger.WithError(err).WithField("hgvs", result.HGVSNotation).Warn("Failed to save evidence snapshot")
 	}
 }
 
+// changedSources returns the databases whose results differ from the
+// variant's previous snapshot. Databases missing from either snapshot are
+// not compared; lookup failures are logged and ignored.
+func (t *QueryEvidenceTool) changedSources(ctx context.Context, hgvsNotation string, payload []byte) []string {
+	history, err := t.snapshots.ListByVariant(ctx, hgvsNotation)
+	if err != nil {
+		t.logger.WithError(err).WithField("hgvs", hgvsNotation).Warn("Failed to list evidence snapshots")
+		return nil
+	}
+	if len(history) == 0 {
+		return nil
+	}
+	previous, err := t.snapshots.Get(ctx, history[0].ID)
+	if err != nil {
+		t.logger.WithError(err).WithField("hgvs", hgvsNotation).Warn("Failed to load previous evidence snapshot")
+		return nil
+	}
+
+	var before, after struct {
+		DatabaseResults map[string]json.RawMessage `json:"database_results"`
+	}
+	if json.Unmarshal(previous.Payload, &before) != nil || json.Unmarshal(payload, &after) != nil {
+		return nil
+	}
+	var changed []string
+	for database, current := range after.DatabaseResults {
+		if earlier, ok := before.DatabaseResults[database]; ok && string(earlier) != string(current) {
+			changed = append(changed, database)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
 // gatherEvidence orchestrates evidence gathering from multiple sources
 // Enhanced per REQ-MCP-002 to return self-sufficient results with quality assessment
 func (t *QueryEvidenceTool) gatherEvidence(ctx context.Context, params *QueryEvidenceParams) (*QueryEvidenceResult, error) {
@@ -329,6 +463,10 @@ func (t *QueryEvidenceTool) gatherEvidence(ctx context.Context, params *QueryEvi
 
 	// Query each requested database
 	for _, database := range params.Databases {
+		// A cancelled query must not be cached or snapshotted as partial evidence
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		dbResult, err := t.queryDatabase(ctx, database, params)
 		if err != nil {
 			t.logger.WithFields(logrus.Fields{
@@ -346,8 +484,12 @@ func (t *QueryEvidenceTool) gatherEvidence(ctx context.Context, params *QueryEvi
 		result.SourceQuality[database] = t.assessSourceQuality(database, dbResult)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Aggregate evidence across databases
-	result.AggregatedEvidence = t.aggregateEvidence(result.DatabaseResults)
+	result.AggregatedEvidence = t.aggregateEvidence(result.DatabaseResults, t.frequencyThresholds(ctx, params))
 
 	// Calculate quality scores
 	result.QualityScores = t.calculateQualityScores(result.DatabaseResults, result.AggregatedEvidence)
@@ -521,11 +663,11 @@ func (t *QueryEvidenceTool) generateVariantID(hgvs string) string {
 }
 
 // aggregateEvidence aggregates evidence across all database sources
-func (t *QueryEvidenceTool) aggregateEvidence(dbResults map[string]interface{}) AggregatedEvidence {
+func (t *QueryEvidenceTool) aggregateEvidence(dbResults map[string]interface{}, cutoffs *service.FrequencyThresholds) AggregatedEvidence {
 	aggregated := AggregatedEvidence{}
 
 	// Aggregate population frequency data
-	aggregated.PopulationFrequency = t.aggregatePopulationFrequency(dbResults)
+	aggregated.PopulationFrequency = t.aggregatePopulationFrequency(dbResults, cutoffs)
 
 	// Aggregate clinical evidence
 	aggregated.ClinicalEvidence = t.aggregateClinicalEvidence(dbResults)
@@ -543,10 +685,11 @@ func (t *QueryEvidenceTool) aggregateEvidence(dbResults map[string]interface{})
 }
 
 // aggregatePopulationFrequency aggregates frequency data from population databases
-func (t *QueryEvidenceTool) aggregatePopulationFrequency(dbResults map[string]interface{}) PopulationFrequencyData {
+func (t *QueryEvidenceTool) aggregatePopulationFrequency(dbResults map[string]interface{}, cutoffs *service.FrequencyThresholds) PopulationFrequencyData {
 	frequency := PopulationFrequencyData{
 		PopulationFreqs: make(map[string]float64),
 		QualityMetrics:  make(map[string]float64),
+		Thresholds:      cutoffs,
 	}
 
 	// Extract frequency data from gnomAD
@@ -564,12 +707,12 @@ func (t *QueryEvidenceTool) aggregatePopulationFrequency(dbResults map[string]in
 		}
 	}
 
-	// Assess frequency for pathogenicity
-	if frequency.MaxFrequency > 0.05 {
+	// Assess frequency for pathogenicity against the gene's cutoffs
+	if frequency.MaxFrequency > cutoffs.BA1AlleleFrequency {
 		frequency.FrequencyAssessment = "common - likely benign"
-	} else if frequency.MaxFrequency > 0.01 {
+	} else if frequency.MaxFrequency > cutoffs.BS1AlleleFrequency {
 		frequency.FrequencyAssessment = "intermediate frequency - uncertain"
-	} else if frequency.MaxFrequency > 0.0001 {
+	} else if frequency.MaxFrequency > cutoffs.PM2AlleleFrequency {
 		frequency.FrequencyAssessment = "rare - compatible with pathogenicity"
 	} else {
 		frequency.FrequencyAssessment = "absent/very rare - supports pathogenicity"
@@ -1024,30 +1167,31 @@ func (t *QueryEvidenceTool) assessSourceQuality(database string, dbResult interf
 // generateACMGCriteriaHints generates ACMG criteria mapping hints (REQ-MCP-002)
 func (t *QueryEvidenceTool) generateACMGCriteriaHints(dbResults map[string]interface{}, evidence AggregatedEvidence) map[string]*CriteriaHint {
 	hints := make(map[string]*CriteriaHint)
+	cutoffs := evidence.PopulationFrequency.Thresholds
 
 	// PM2: Absent from controls or at extremely low frequency
-	if evidence.PopulationFrequency.MaxFrequency < 0.0001 {
+	if evidence.PopulationFrequency.MaxFrequency < cutoffs.PM2AlleleFrequency {
 		hints["PM2"] = &CriteriaHint{
 			Applicable: true,
-			Note:       "Absent or extremely low frequency in gnomAD (<0.01%)",
+			Note:       fmt.Sprintf("Absent or extremely low frequency in gnomAD (<%g%%)", cutoffs.PM2AlleleFrequency*100),
 		}
-	} else if evidence.PopulationFrequency.MaxFrequency < 0.01 {
+	} else if evidence.PopulationFrequency.MaxFrequency < cutoffs.BS1AlleleFrequency {
 		hints["PM2"] = &CriteriaHint{
 			Applicable: false,
 			Note:       fmt.Sprintf("Low but not absent from population databases (%.4f%%)", evidence.PopulationFrequency.MaxFrequency*100),
 		}
 	}
 
-	// BA1: Allele frequency > 5%
-	if evidence.PopulationFrequency.MaxFrequency > 0.05 {
+	// BA1: Allele frequency above the stand-alone cutoff (5% by default)
+	if evidence.PopulationFrequency.MaxFrequency > cutoffs.BA1AlleleFrequency {
 		hints["BA1"] = &CriteriaHint{
 			Applicable: true,
-			Note:       fmt.Sprintf("Allele frequency >5%% (%.2f%%) - stand-alone benign", evidence.PopulationFrequency.MaxFrequency*100),
+			Note:       fmt.Sprintf("Allele frequency >%g%% (%.2f%%) - stand-alone benign", cutoffs.BA1AlleleFrequency*100, evidence.PopulationFrequency.MaxFrequency*100),
 		}
 	}
 
 	// BS1: Allele frequency greater than expected for disorder
-	if evidence.PopulationFrequency.MaxFrequency > 0.01 && evidence.PopulationFrequency.MaxFrequency <= 0.05 {
+	if evidence.PopulationFrequency.MaxFrequency > cutoffs.BS1AlleleFrequency && evidence.PopulationFrequency.MaxFrequency <= cutoffs.BA1AlleleFrequency {
 		hints["BS1"] = &CriteriaHint{
 			Applicable: true,
 			Note:       fmt.Sprintf("Allele frequency greater than expected (%.2f%%)", evidence.PopulationFrequency.MaxFrequency*100),
@@ -1055,14 +1199,14 @@ func (t *QueryEvidenceTool) generateACMGCriteriaHints(dbResults map[string]inter
 	}
 
 	// PP5/BP6: Clinical assertions
-	if evidence.ClinicalEvidence.OverallSignificance != "" {
-		switch evidence.ClinicalEvidence.OverallSignificance {
-		case "Pathogenic", "Likely pathogenic":
+	if significance, err := domain.ParseClassification(evidence.ClinicalEvidence.OverallSignificance); err == nil {
+		switch significance {
+		case domain.PATHOGENIC, domain.LIKELY_PATHOGENIC:
 			hints["PP5"] = &CriteriaHint{
 				Applicable: true,
 				Note:       fmt.Sprintf("ClinVar: %s with %s", evidence.ClinicalEvidence.OverallSignificance, evidence.ClinicalEvidence.ReviewStatus),
 			}
-		case "Benign", "Likely benign":
+		case domain.BENIGN, domain.LIKELY_BENIGN:
 			hints["BP6"] = &CriteriaHint{
 				Applicable: true,
 				Note:       fmt.Sprintf("ClinVar: %s with %s", evidence.ClinicalEvidence.OverallSignificance, evidence.ClinicalEvidence.ReviewStatus),
@@ -0,0 +1,628 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+// Criterion statuses reported by explain_classification
+const (
+	CriterionApplied          = "applied"
+	CriterionNotMet           = "not_met"
+	CriterionInsufficientData = "insufficient_data"
+	CriterionNotEvaluated     = "not_evaluated"
+)
+
+// evidenceCategory is a row of the ACMG/AMP evidence framework (Richards et
+// al. 2015, Table 5) and the criteria it holds
+type evidenceCategory struct {
+	name     string
+	label    string
+	criteria []string
+}
+
+// evidenceCategories lists the framework rows in the order of the table
+var evidenceCategories = []evidenceCategory{
+	{"population", "Population data", []string{"BA1", "BS1", "BS2", "PM2", "PS4"}},
+	{"computational", "Computational and predictive data", []string{"PVS1", "PS1", "PM4", "PM5", "PP3", "BP1", "BP3", "BP4", "BP7"}},
+	{"functional", "Functional data", []string{"PS3", "PM1", "PP2", "BS3"}},
+	{"segregation", "Segregation data", []string{"PP1", "BS4"}},
+	{"de_novo", "De novo data", []string{"PS2", "PM6"}},
+	{"allelic", "Allelic data", []string{"PM3", "BP2"}},
+	{"other_database", "Other database", []string{"PP5", "BP6"}},
+	{"other", "Other data", []string{"PP4", "BP5"}},
+}
+
+// ExplainClassificationTool implements the explain_classification MCP tool
+type ExplainClassificationTool struct {
+	logger       *logrus.Logger
+	classifyTool *ClassifyVariantTool
+	audit        audit.Store
+}
+
+// ExplainClassificationParams defines parameters for the explain_classification
+// tool: a prior classification's audit record ID, or the classify_variant
+// parameters of a variant to classify and explain
+type ExplainClassificationParams struct {
+	ClassificationID int64 `json:"classification_id,omitempty"`
+	ClassifyVariantParams
+}
+
+// ExplainClassificationResult explains every criterion considered in a
+// classification, grouped by evidence category
+type ExplainClassificationResult struct {
+	ClassificationID  int64                 `json:"classification_id,omitempty"` // Audit record explained; 0 for a fresh classification
+	VariantID         string                `json:"variant_id,omitempty"`
+	HGVSNotation      string                `json:"hgvs_notation,omitempty"`
+	Classification    string                `json:"classification"`
+	Confidence        string                `json:"confidence"`
+	ScoringMode       string                `json:"scoring_mode,omitempty"`
+	Specification     string                `json:"vcep_specification,omitempty"`
+	ThresholdRevision int64                 `json:"threshold_revision"` // 0 when the built-in thresholds were used
+	EngineVersion     string                `json:"engine_version,omitempty"`
+	ClassifiedAt      time.Time             `json:"classified_at"`
+	Summary           string                `json:"summary"`
+	Categories        []CategoryExplanation `json:"categories"`
+	Notes             []string              `json:"notes,omitempty"` // Limits of the explanation, e.g. cutoffs that could not be recovered
+}
+
+// CategoryExplanation holds the criteria of one evidence category
+type CategoryExplanation struct {
+	Category string                 `json:"category"`
+	Label    string                 `json:"label"`
+	Applied  []string               `json:"applied"` // Codes applied in this category
+	Criteria []CriterionExplanation `json:"criteria"`
+}
+
+// CriterionExplanation is the rationale for one criterion, met or not
+type CriterionExplanation struct {
+	Code            string             `json:"code"`
+	Name            string             `json:"name"`
+	Description     string             `json:"description,omitempty"`
+	Direction       string             `json:"direction"` // pathogenic or benign
+	Strength        string             `json:"strength"`
+	Applied         bool               `json:"applied"`
+	Status          string             `json:"status"` // applied, not_met, insufficient_data or not_evaluated
+	Rationale       string             `json:"rationale"`
+	Thresholds      map[string]float64 `json:"thresholds,omitempty"`       // Cutoffs the criterion was evaluated against
+	ThresholdSource string             `json:"threshold_source,omitempty"` // Where the frequency cutoffs came from
+	SourceData      []string           `json:"source_data,omitempty"`      // Evidence the criterion was evaluated on
+}
+
+// NewExplainClassificationTool creates a new explain_classification tool.
+// Without an audit store only fresh classifications can be explained.
+func NewExplainClassificationTool(logger *logrus.Logger, classifyTool *ClassifyVariantTool, store audit.Store) *ExplainClassificationTool {
+	return &ExplainClassificationTool{
+		logger:       logger,
+		classifyTool: classifyTool,
+		audit:        store,
+	}
+}
+
+// GetToolInfo returns the tool information for explain_classification
+func (t *ExplainClassificationTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "explain_classification",
+		Description: "Explain a classification criterion by criterion. For every ACMG/AMP criterion considered, reports whether it was applied and why or why not, the cutoffs it was evaluated against and the evidence it was evaluated on, grouped by evidence category (population, computational, functional, segregation, de novo, allelic, other database, other). Give the audit record ID of a prior classification, or a variant with any classify_variant parameters to classify and explain it.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"classification_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "Audit record ID of a prior classification, as listed by query_audit_trail",
+				},
+				"hgvs_notation": map[string]interface{}{
+					"type":        "string",
+					"description": "HGVS notation, rsID or ClinVar accession of a variant to classify and explain",
+				},
+				"gene_symbol_notation": map[string]interface{}{
+					"type":        "string",
+					"description": "Gene symbol notation of a variant to classify and explain, e.g. 'TP53:c.273G>A'",
+				},
+				"condition": map[string]interface{}{
+					"type":        "string",
+					"description": "Condition under evaluation; selects gene/condition-specific frequency thresholds",
+				},
+				"scoring_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Combine evidence with the ACMG/AMP combining rules or ClinGen SVI points",
+					"enum":        []string{"combining_rules", "points"},
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ExplainClassificationTool) ValidateParams(params interface{}) error {
+	var p ExplainClassificationParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	hasVariant := strings.TrimSpace(p.HGVSNotation) != "" || strings.TrimSpace(p.GeneSymbolNotation) != ""
+	switch {
+	case p.ClassificationID < 0:
+		return fmt.Errorf("classification_id must be positive")
+	case p.ClassificationID > 0 && hasVariant:
+		return fmt.Errorf("give either classification_id or a variant, not both")
+	case p.ClassificationID > 0:
+		if t.audit == nil {
+			return fmt.Errorf("classification_id requires the audit trail, which is not configured")
+		}
+		return nil
+	case !hasVariant:
+		return fmt.Errorf("classification_id, hgvs_notation or gene_symbol_notation is required")
+	}
+	return t.classifyTool.ValidateParams(params)
+}
+
+// HandleTool handles the explain_classification tool request
+func (t *ExplainClassificationTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ExplainClassificationParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	var result *ExplainClassificationResult
+	if params.ClassificationID > 0 {
+		record, err := t.audit.Get(ctx, params.ClassificationID)
+		if err != nil {
+			return auditStoreError(t.logger, "get audit record", err)
+		}
+		if record.Error != "" {
+			return invalidParamsError("Classification failed; there is nothing to explain", record.Error)
+		}
+		if result, err = t.explainRecord(ctx, record); err != nil {
+			return internalError("Failed to explain classification", err.Error())
+		}
+	} else {
+		classified, err := t.classifyTool.classifyVariant(ctx, &params.ClassifyVariantParams)
+		if err != nil {
+			return &protocol.JSONRPC2Response{
+				Error: &protocol.RPCError{
+					Code:    protocol.MCPToolError,
+					Message: "Classification failed",
+					Data:    err.Error(),
+				},
+			}
+		}
+		result = t.explainResult(ctx, classified)
+		result.HGVSNotation = params.HGVSNotation
+		if result.HGVSNotation == "" {
+			result.HGVSNotation = params.GeneSymbolNotation
+		}
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"classification_id": result.ClassificationID,
+		"variant_id":        result.VariantID,
+		"classification":    result.Classification,
+	}).Info("Explained classification")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"explanation": result,
+		},
+	}
+}
+
+// explainResult explains a classification made for this request
+func (t *ExplainClassificationTool) explainResult(ctx context.Context, classified *ClassifyVariantResult) *ExplainClassificationResult {
+	result := &ExplainClassificationResult{
+		VariantID:         classified.VariantID,
+		Classification:    classified.Classification,
+		Confidence:        classified.Confidence,
+		ScoringMode:       classified.ScoringMode,
+		Specification:     classified.Specification,
+		ThresholdRevision: classified.ThresholdRevision,
+		EngineVersion:     service.EngineVersion,
+		ClassifiedAt:      time.Now().UTC(),
+	}
+	if classified.Somatic != nil {
+		result.Notes = append(result.Notes, "Somatic variants are tiered with AMP/ASCO/CAP evidence levels rather than ACMG/AMP criteria; see the somatic assessment of classify_variant")
+	}
+
+	cutoffs := t.thresholdsFor(ctx, result)
+	result.explain(classified.AppliedRules, classified.Evidence, cutoffs, classified.FrequencyThresholds)
+	return result
+}
+
+// explainRecord explains a classification recorded in the audit trail
+func (t *ExplainClassificationTool) explainRecord(ctx context.Context, record *audit.Record) (*ExplainClassificationResult, error) {
+	result := &ExplainClassificationResult{
+		ClassificationID:  record.ID,
+		VariantID:         record.VariantID,
+		HGVSNotation:      record.HGVSNotation,
+		Classification:    record.Classification,
+		Confidence:        record.Confidence,
+		ScoringMode:       record.ScoringMode,
+		Specification:     record.Specification,
+		ThresholdRevision: record.ThresholdRevision,
+		EngineVersion:     record.EngineVersion,
+		ClassifiedAt:      record.CreatedAt,
+	}
+
+	var rules []ACMGAMPRuleResult
+	if len(record.AppliedRules) > 0 {
+		if err := json.Unmarshal(record.AppliedRules, &rules); err != nil {
+			return nil, fmt.Errorf("failed to decode recorded rules: %w", err)
+		}
+	}
+	var evidence *domain.AggregatedEvidence
+	if len(record.Evidence) > 0 {
+		evidence = &domain.AggregatedEvidence{}
+		if err := json.Unmarshal(record.Evidence, evidence); err != nil {
+			return nil, fmt.Errorf("failed to decode recorded evidence: %w", err)
+		}
+	}
+	if len(rules) == 0 {
+		result.Notes = append(result.Notes, "No ACMG/AMP criteria were recorded for this classification")
+	}
+	if record.Specification != "" {
+		result.Notes = append(result.Notes, fmt.Sprintf("Frequency cutoffs shown are the threshold revision's; the %s specification or a configured override may have replaced them", record.Specification))
+	}
+
+	cutoffs := t.thresholdsFor(ctx, result)
+	result.explain(rules, evidence, cutoffs, nil)
+	return result, nil
+}
+
+// thresholdsFor returns the thresholds the classification was evaluated
+// against, or nil with a note when they cannot be recovered
+func (t *ExplainClassificationTool) thresholdsFor(ctx context.Context, result *ExplainClassificationResult) *thresholds.Thresholds {
+	classifier := t.classifyTool.classifierService
+	if classifier == nil {
+		if result.ThresholdRevision == 0 {
+			defaults := thresholds.Defaults()
+			return &defaults
+		}
+		result.Notes = append(result.Notes, fmt.Sprintf("Cutoffs of threshold revision %d are not available; thresholds are omitted", result.ThresholdRevision))
+		return nil
+	}
+
+	values, revision, err := classifier.ThresholdsAt(ctx, result.ClassifiedAt)
+	if err != nil {
+		t.logger.WithError(err).WithField("revision", result.ThresholdRevision).Warn("Failed to load threshold revision")
+		result.Notes = append(result.Notes, fmt.Sprintf("Could not load threshold revision %d; thresholds are omitted", result.ThresholdRevision))
+		return nil
+	}
+	if revision != result.ThresholdRevision {
+		result.Notes = append(result.Notes, fmt.Sprintf("Threshold revision %d is no longer the one in effect at the time of classification; thresholds are omitted", result.ThresholdRevision))
+		return nil
+	}
+	return &values
+}
+
+// explain fills in the per-category explanations and the summary
+func (r *ExplainClassificationResult) explain(rules []ACMGAMPRuleResult, evidence *domain.AggregatedEvidence, cutoffs *thresholds.Thresholds, frequency *service.FrequencyThresholds) {
+	byCode := make(map[string]CriterionExplanation, len(rules))
+	for _, rule := range rules {
+		byCode[rule.RuleCode] = explainCriterion(rule, evidence, cutoffs, frequency)
+	}
+
+	var applied []string
+	counts := make(map[string]int)
+	placed := make(map[string]bool, len(rules))
+	for _, category := range evidenceCategories {
+		explanation := CategoryExplanation{Category: category.name, Label: category.label, Applied: []string{}}
+		for _, code := range category.criteria {
+			criterion, ok := byCode[code]
+			if !ok {
+				continue
+			}
+			placed[code] = true
+			explanation.Criteria = append(explanation.Criteria, criterion)
+		}
+		// Criteria a specification adds land in the last category
+		if category.name == "other" {
+			for _, rule := range rules {
+				if !placed[rule.RuleCode] {
+					placed[rule.RuleCode] = true
+					explanation.Criteria = append(explanation.Criteria, byCode[rule.RuleCode])
+				}
+			}
+		}
+		for _, criterion := range explanation.Criteria {
+			counts[criterion.Status]++
+			if criterion.Applied {
+				explanation.Applied = append(explanation.Applied, criterion.Code)
+				applied = append(applied, criterion.Code)
+			}
+		}
+		if len(explanation.Criteria) > 0 {
+			r.Categories = append(r.Categories, explanation)
+		}
+	}
+	if r.Categories == nil {
+		r.Categories = []CategoryExplanation{}
+	}
+
+	r.Summary = fmt.Sprintf("%s (%s confidence): %d of %d criteria applied", r.Classification, r.Confidence, len(applied), len(rules))
+	if len(applied) > 0 {
+		r.Summary += " (" + strings.Join(applied, ", ") + ")"
+	}
+	if n := counts[CriterionInsufficientData]; n > 0 {
+		r.Summary += fmt.Sprintf("; %d could not be assessed for lack of data", n)
+	}
+	if n := counts[CriterionNotEvaluated]; n > 0 {
+		r.Summary += fmt.Sprintf("; %d not evaluated automatically", n)
+	}
+}
+
+// explainCriterion builds the rationale for one criterion
+func explainCriterion(rule ACMGAMPRuleResult, evidence *domain.AggregatedEvidence, cutoffs *thresholds.Thresholds, frequency *service.FrequencyThresholds) CriterionExplanation {
+	criterion := CriterionExplanation{
+		Code:      rule.RuleCode,
+		Name:      rule.RuleName,
+		Direction: strings.ToLower(rule.Category),
+		Strength:  strings.ToLower(rule.Strength),
+		Applied:   rule.Applied,
+		Status:    criterionStatus(rule),
+	}
+	if definition, ok := ACMGAMPRules[rule.RuleCode]; ok {
+		criterion.Description = definition.Description
+	}
+
+	reasoning := strings.TrimSuffix(strings.TrimSpace(rule.Reasoning), ".")
+	strength := strings.ReplaceAll(criterion.Strength, "_", " ")
+	switch criterion.Status {
+	case CriterionApplied:
+		criterion.Rationale = fmt.Sprintf("Applied as %s %s evidence: %s", strength, criterion.Direction, reasoning)
+	case CriterionInsufficientData:
+		criterion.Rationale = "Not applied, could not be assessed: " + reasoning
+	case CriterionNotEvaluated:
+		criterion.Rationale = "Not applied: " + reasoning + "; assess manually"
+	default:
+		criterion.Rationale = "Not applied: " + reasoning
+	}
+	if rule.Evidence != "" {
+		criterion.Rationale += ". Evidence: " + rule.Evidence
+	}
+
+	criterion.Thresholds, criterion.ThresholdSource = criterionThresholds(rule.RuleCode, cutoffs, frequency)
+	criterion.SourceData = criterionSourceData(rule, evidence)
+	return criterion
+}
+
+// criterionStatus classifies why a criterion was or was not applied
+func criterionStatus(rule ACMGAMPRuleResult) string {
+	reasoning := strings.ToLower(rule.Reasoning)
+	switch {
+	case rule.Applied:
+		return CriterionApplied
+	case strings.Contains(reasoning, "not yet implemented"), strings.HasPrefix(reasoning, "rule evaluation failed"):
+		return CriterionNotEvaluated
+	case strings.HasPrefix(reasoning, "no ") && (strings.Contains(reasoning, "available") || strings.Contains(reasoning, "no lookup")),
+		strings.Contains(reasoning, "not provided"), strings.Contains(reasoning, "unknown; cannot assess"):
+		return CriterionInsufficientData
+	default:
+		return CriterionNotMet
+	}
+}
+
+// criterionThresholds returns the cutoffs a criterion is evaluated against and,
+// for the frequency criteria, where they came from
+func criterionThresholds(code string, cutoffs *thresholds.Thresholds, frequency *service.FrequencyThresholds) (map[string]float64, string) {
+	if cutoffs == nil && frequency == nil {
+		return nil, ""
+	}
+	var t thresholds.Thresholds
+	source := service.FrequencySourceDefault
+	if cutoffs != nil {
+		t = *cutoffs
+	}
+	if frequency != nil {
+		t.BA1AlleleFrequency = frequency.BA1AlleleFrequency
+		t.BS1AlleleFrequency = frequency.BS1AlleleFrequency
+		t.PM2AlleleFrequency = frequency.PM2AlleleFrequency
+		source = frequency.Source
+		if frequency.Override != "" {
+			source += " (" + frequency.Override + ")"
+		}
+	}
+
+	switch code {
+	case "BA1":
+		return map[string]float64{"ba1_allele_frequency": t.BA1AlleleFrequency}, source
+	case "BS1":
+		return map[string]float64{"bs1_allele_frequency": t.BS1AlleleFrequency, "ba1_allele_frequency": t.BA1AlleleFrequency}, source
+	case "PM2":
+		return map[string]float64{"pm2_allele_frequency": t.PM2AlleleFrequency}, source
+	}
+	if cutoffs == nil {
+		return nil, ""
+	}
+
+	p := t.Predictors
+	spliceDeleterious, spliceBenign := p.SpliceCutoffs()
+	revelDeleterious, revelBenign := p.REVELCutoffs()
+	amDeleterious, amBenign := p.AlphaMissenseCutoffs()
+	switch code {
+	case "PP3":
+		return map[string]float64{
+			"cadd_deleterious":          p.CADDDeleterious,
+			"sift_deleterious":          p.SIFTDeleterious,
+			"polyphen_deleterious":      p.PolyPhenDeleterious,
+			"revel_deleterious":         revelDeleterious,
+			"alphamissense_deleterious": amDeleterious,
+			"splice_deleterious":        spliceDeleterious,
+		}, ""
+	case "BP4":
+		return map[string]float64{
+			"cadd_benign":          p.CADDBenign,
+			"polyphen_benign":      p.PolyPhenBenign,
+			"revel_benign":         revelBenign,
+			"alphamissense_benign": amBenign,
+			"splice_benign":        spliceBenign,
+		}, ""
+	case "BP7":
+		return map[string]float64{"splice_benign": spliceBenign}, ""
+	case "PM1":
+		maxBenignDensity, minPathogenic := t.Domains.Cutoffs()
+		return map[string]float64{"max_benign_density": maxBenignDensity, "min_pathogenic_missense": float64(minPathogenic)}, ""
+	case "PP1", "BS4":
+		supporting, moderate, strong, bs4 := t.Segregation.Cutoffs()
+		if code == "BS4" {
+			return map[string]float64{"bs4_lod": bs4}, ""
+		}
+		return map[string]float64{"supporting_lod": supporting, "moderate_lod": moderate, "strong_lod": strong}, ""
+	case "PVS1":
+		pli, loeuf, _ := t.Constraint.Cutoffs()
+		return map[string]float64{"pli_intolerant": pli, "loeuf_intolerant": loeuf}, ""
+	case "PP2", "BP1":
+		_, _, missenseZ := t.Constraint.Cutoffs()
+		return map[string]float64{"missense_z_constrained": missenseZ}, ""
+	case "PP4":
+		return map[string]float64{"pp4_similarity": t.Phenotype.Cutoff()}, ""
+	case "PS4":
+		supporting, moderate, strong := t.CaseCounts.Cutoffs()
+		return map[string]float64{"ps4_supporting_probands": float64(supporting), "ps4_moderate_probands": float64(moderate), "ps4_strong_probands": float64(strong)}, ""
+	case "BS2":
+		homozygotes, heterozygotes := t.HealthyAdults.Cutoffs()
+		return map[string]float64{"bs2_homozygotes": float64(homozygotes), "bs2_heterozygotes": float64(heterozygotes)}, ""
+	}
+	return nil, ""
+}
+
+// criterionSourceData describes the evidence a criterion was evaluated on
+func criterionSourceData(rule ACMGAMPRuleResult, evidence *domain.AggregatedEvidence) []string {
+	var data []string
+	for _, v := range rule.MatchedVariants {
+		if v.Source == domain.ResidueSourceLab {
+			data = append(data, fmt.Sprintf("Lab assertion %s %s: %s, %s", v.VariationID, v.Name, v.ClinicalSignificance, v.ReviewStatus))
+			continue
+		}
+		data = append(data, fmt.Sprintf("ClinVar %s %s: %s, %s (%d stars)", v.VariationID, v.Name, v.ClinicalSignificance, v.ReviewStatus, v.Stars))
+	}
+	if d := rule.MatchedDomain; d != nil {
+		data = append(data, fmt.Sprintf("%s domain %s (residues %d-%d): %d pathogenic and %d benign missense variants, benign density %.4f",
+			d.Source, d.Name, d.Start, d.End, d.PathogenicMissense, d.BenignMissense, d.BenignDensity))
+	}
+	if s := rule.Segregation; s != nil {
+		data = append(data, fmt.Sprintf("Segregation: LOD %.2f over %d informative meioses (%d affected carriers, %d unaffected carriers)",
+			s.LOD, s.Meioses, s.AffectedCarriers, s.UnaffectedCarriers))
+	}
+	if p := rule.PhenotypeMatch; p != nil {
+		data = append(data, fmt.Sprintf("HPO phenotype similarity to %s: %.2f over %d patient terms", p.Gene, p.Score, len(p.Terms)))
+	}
+	if tally := rule.InTrans; tally != nil {
+		data = append(data, fmt.Sprintf("PM3 tally: %.3g points over %d probands", tally.Points, tally.Probands))
+	}
+	if evidence == nil {
+		return data
+	}
+
+	switch rule.RuleCode {
+	case "BA1", "BS1", "BS2", "PM2", "PS4":
+		if p := evidence.PopulationData; p != nil {
+			dataset := "gnomAD"
+			if p.Dataset != "" {
+				dataset += " " + p.Dataset
+			}
+			line := fmt.Sprintf("%s: allele frequency %g (%d/%d), %d homozygotes", dataset, p.AlleleFrequency, p.AlleleCount, p.AlleleNumber, p.HomozygoteCount)
+			if p.HemizygoteCount > 0 {
+				line += fmt.Sprintf(", %d hemizygotes", p.HemizygoteCount)
+			}
+			if p.FAF95 > 0 {
+				line += fmt.Sprintf(", FAF95 %g", p.FAF95)
+				if p.FAFPopulation != "" {
+					line += " in " + p.FAFPopulation
+				}
+			}
+			data = append(data, line)
+		}
+	case "PP3", "BP4":
+		if c := evidence.ComputationalData; c != nil {
+			data = append(data, predictorScores(c))
+		}
+		data = append(data, splicingScores(evidence.SplicingPredictions)...)
+	case "BP7":
+		data = append(data, splicingScores(evidence.SplicingPredictions)...)
+	case "PP5", "BP6":
+		if c := evidence.ClinVarData; c != nil && c.ClinicalSignificance != "" {
+			data = append(data, fmt.Sprintf("ClinVar %s: %s, %s (%d submissions)", c.VariationID, c.ClinicalSignificance, c.ReviewStatus, len(c.Submissions)))
+		}
+	case "PS3", "BS3":
+		if l := evidence.LiteratureData; l != nil {
+			data = append(data, fmt.Sprintf("Literature: %d of %d citations retrieved for %q", l.RetrievedCitations, l.TotalCitations, l.SearchQuery))
+		}
+	case "PVS1", "PP2", "BP1":
+		if c := evidence.GeneConstraint; c != nil {
+			data = append(data, geneConstraintMetrics(c))
+		}
+	}
+	return data
+}
+
+// geneConstraintMetrics lists the constraint metrics a gene has
+func geneConstraintMetrics(c *domain.GeneConstraint) string {
+	var parts []string
+	for _, metric := range []struct {
+		name  string
+		value *float64
+	}{{"pLI", c.PLI}, {"LOEUF", c.LOEUF}, {"missense Z", c.MissenseZ}} {
+		if metric.value != nil {
+			parts = append(parts, fmt.Sprintf("%s %g", metric.name, *metric.value))
+		}
+	}
+	line := c.Source + " constraint for " + c.Gene
+	if c.Transcript != "" {
+		line += " (" + c.Transcript + ")"
+	}
+	if len(parts) == 0 {
+		return line + ": no metrics"
+	}
+	return line + ": " + strings.Join(parts, ", ")
+}
+
+// predictorScores lists the in silico scores the variant has
+func predictorScores(c *domain.ComputationalData) string {
+	scores := map[string]float64{
+		domain.PredictorSIFT:          c.SIFTScore,
+		domain.PredictorPolyPhen:      c.PolyPhenScore,
+		domain.PredictorCADD:          c.CADDScore,
+		domain.PredictorREVEL:         c.REVELScore,
+		domain.PredictorAlphaMissense: c.AlphaMissenseScore,
+	}
+	var parts []string
+	for _, name := range []string{domain.PredictorREVEL, domain.PredictorAlphaMissense, domain.PredictorCADD, domain.PredictorSIFT, domain.PredictorPolyPhen} {
+		if c.Has(name) {
+			parts = append(parts, fmt.Sprintf("%s %g", name, scores[name]))
+		}
+	}
+	source := c.Source
+	if source == "" {
+		source = "In silico predictors"
+	}
+	if len(parts) == 0 {
+		return source + ": no scores"
+	}
+	return source + ": " + strings.Join(parts, ", ")
+}
+
+// splicingScores lists the strongest splicing prediction per tool
+func splicingScores(predictions []domain.SplicingPrediction) []string {
+	var data []string
+	for _, p := range predictions {
+		line := fmt.Sprintf("%s: delta score %g", p.Tool, p.Score)
+		if p.Event != "" {
+			line += " (" + p.Event + ")"
+		}
+		data = append(data, line)
+	}
+	return data
+}
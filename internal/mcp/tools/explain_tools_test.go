@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+func TestExplainClassificationTool_Record(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestAuditStore(t)
+	tool := NewExplainClassificationTool(logger, NewClassifyVariantToolLegacy(logger, nil), store)
+
+	rules, _ := json.Marshal([]service.ACMGAMPRuleResult{
+		{RuleCode: "PM2", RuleName: "Absent from controls", Category: "PATHOGENIC", Strength: "MODERATE", Applied: true,
+			Evidence: "gnomAD AF 0.00001", Reasoning: "Variant absent or extremely rare in population databases"},
+		{RuleCode: "BA1", RuleName: "Allele frequency >5%", Category: "BENIGN", Strength: "VERY_STRONG",
+			Reasoning: "Population frequency below threshold: 0.000010"},
+		{RuleCode: "PP3", RuleName: "Computational evidence", Category: "PATHOGENIC", Strength: "SUPPORTING",
+			Reasoning: "No computational prediction data available"},
+		{RuleCode: "PM3", RuleName: "In trans", Category: "PATHOGENIC", Strength: "MODERATE",
+			Reasoning: "Rule evaluation not yet implemented"},
+	})
+	evidence, _ := json.Marshal(&domain.AggregatedEvidence{
+		PopulationData: &domain.PopulationData{AlleleFrequency: 0.00001, AlleleCount: 1, AlleleNumber: 100000, Dataset: "gnomad_r4"},
+	})
+	record := &audit.Record{
+		VariantID:      "VAR_1",
+		HGVSNotation:   "NM_000492.4:c.1521_1523del",
+		Request:        json.RawMessage(`{"hgvs_notation":"NM_000492.4:c.1521_1523del"}`),
+		AppliedRules:   rules,
+		Evidence:       evidence,
+		Classification: "Likely pathogenic",
+		Confidence:     "Moderate",
+		EngineVersion:  service.EngineVersion,
+	}
+	require.NoError(t, store.Append(context.Background(), record))
+
+	// Act
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		Method: "explain_classification",
+		Params: map[string]interface{}{"classification_id": record.ID},
+	})
+
+	// Assert
+	require.Nil(t, response.Error)
+	result := response.Result.(map[string]interface{})["explanation"].(*ExplainClassificationResult)
+	assert.Equal(t, record.ID, result.ClassificationID)
+	assert.Equal(t, "Likely pathogenic (Moderate confidence): 1 of 4 criteria applied (PM2); 1 could not be assessed for lack of data; 1 not evaluated automatically", result.Summary)
+	require.Len(t, result.Categories, 3)
+
+	population := result.Categories[0]
+	assert.Equal(t, "population", population.Category)
+	assert.Equal(t, []string{"PM2"}, population.Applied)
+	require.Len(t, population.Criteria, 2)
+	ba1, pm2 := population.Criteria[0], population.Criteria[1]
+	assert.Equal(t, CriterionNotMet, ba1.Status)
+	assert.Equal(t, map[string]float64{"ba1_allele_frequency": 0.05}, ba1.Thresholds)
+	assert.Equal(t, service.FrequencySourceDefault, ba1.ThresholdSource)
+	assert.Equal(t, CriterionApplied, pm2.Status)
+	assert.Equal(t, "Applied as moderate pathogenic evidence: Variant absent or extremely rare in population databases. Evidence: gnomAD AF 0.00001", pm2.Rationale)
+	assert.Equal(t, []string{"gnomAD gnomad_r4: allele frequency 1e-05 (1/100000), 0 homozygotes"}, pm2.SourceData)
+
+	pp3 := result.Categories[1].Criteria[0]
+	assert.Equal(t, CriterionInsufficientData, pp3.Status)
+	assert.Contains(t, pp3.Thresholds, "revel_deleterious")
+	assert.Equal(t, CriterionNotEvaluated, result.Categories[2].Criteria[0].Status)
+}
+
+func TestExplainClassificationTool_Validation(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestAuditStore(t)
+	tool := NewExplainClassificationTool(logger, NewClassifyVariantToolLegacy(logger, nil), store)
+
+	for name, params := range map[string]map[string]interface{}{
+		"empty":        {},
+		"both":         {"classification_id": 1, "hgvs_notation": "NM_000492.3:c.1521_1523delCTT"},
+		"negative ID":  {"classification_id": -1},
+		"invalid HGVS": {"hgvs_notation": "not-hgvs"},
+	} {
+		assert.Error(t, tool.ValidateParams(params), name)
+	}
+	assert.NoError(t, tool.ValidateParams(map[string]interface{}{"hgvs_notation": "NM_000492.3:c.1521_1523delCTT"}))
+
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{Params: map[string]interface{}{"classification_id": 42}})
+	require.NotNil(t, response.Error)
+	assert.Equal(t, "Audit record not found", response.Error.Message)
+
+	withoutAudit := NewExplainClassificationTool(logger, NewClassifyVariantToolLegacy(logger, nil), nil)
+	assert.Error(t, withoutAudit.ValidateParams(map[string]interface{}{"classification_id": 1}))
+
+	response = withoutAudit.HandleTool(context.Background(), &protocol.JSONRPC2Request{Params: map[string]interface{}{"hgvs_notation": "NM_000492.3:c.1521_1523delCTT"}})
+	require.NotNil(t, response.Error)
+	assert.Contains(t, response.Error.Data, "classification service not configured")
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/acmg-amp-mcp-server/internal/feedback"
+	"github.com/acmg-amp-mcp-server/internal/followup"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
 )
 
@@ -45,6 +47,7 @@ func internalError(msg string, data string) *protocol.JSONRPC2Response {
 type SubmitFeedbackTool struct {
 	logger *logrus.Logger
 	store  feedback.Store
+	flags  followup.Store
 }
 
 // SubmitFeedbackParams defines parameters for the submit_feedback tool
@@ -60,9 +63,10 @@ type SubmitFeedbackParams struct {
 
 // SubmitFeedbackResult defines the result of submit_feedback
 type SubmitFeedbackResult struct {
-	Success  bool               `json:"success"`
-	Message  string             `json:"message"`
-	Feedback *feedback.Feedback `json:"feedback,omitempty"`
+	Success       bool               `json:"success"`
+	Message       string             `json:"message"`
+	Feedback      *feedback.Feedback `json:"feedback,omitempty"`
+	FollowUpFlags []*followup.Flag   `json:"followup_flags,omitempty"`
 }
 
 // NewSubmitFeedbackTool creates a new submit_feedback tool
@@ -73,6 +77,12 @@ func NewSubmitFeedbackTool(logger *logrus.Logger, store feedback.Store) *SubmitF
 	}
 }
 
+// SetFollowUpStore enables follow-up flag checks; blocking flags prevent
+// changing a signed-out classification until they are resolved
+func (t *SubmitFeedbackTool) SetFollowUpStore(store followup.Store) {
+	t.flags = store
+}
+
 // GetToolInfo returns the tool information for submit_feedback
 func (t *SubmitFeedbackTool) GetToolInfo() protocol.ToolInfo {
 	return protocol.ToolInfo{
@@ -162,6 +172,30 @@ func (t *SubmitFeedbackTool) HandleTool(ctx context.Context, req *protocol.JSONR
 		Notes:                   params.Notes,
 	}
 
+	var openFlags []*followup.Flag
+	if t.flags != nil {
+		flags, err := t.flags.Open(ctx, normalizedHGVS, params.CancerType)
+		if err != nil {
+			return internalError("Failed to check follow-up flags", err.Error())
+		}
+		if followup.HasBlocking(flags) {
+			existing, err := t.store.Get(ctx, normalizedHGVS, params.CancerType)
+			if err != nil {
+				return internalError("Failed to load existing classification", err.Error())
+			}
+			if existing != nil && existing.UserClassification != fb.UserClassification {
+				return &protocol.JSONRPC2Response{
+					Error: &protocol.RPCError{
+						Code:    protocol.MCPToolError,
+						Message: "Reclassification blocked by open follow-up flags",
+						Data:    fmt.Sprintf("%s is signed out as %s; resolve blocking flags (%s) before changing it", normalizedHGVS, existing.UserClassification, describeFlags(flags)),
+					},
+				}
+			}
+		}
+		openFlags = flags
+	}
+
 	if err := t.store.Save(ctx, fb); err != nil {
 		t.logger.WithError(err).Error("Failed to save feedback")
 		return internalError("Failed to save feedback", err.Error())
@@ -172,14 +206,26 @@ func (t *SubmitFeedbackTool) HandleTool(ctx context.Context, req *protocol.JSONR
 		msg = fmt.Sprintf("Feedback saved: Classification corrected from %s to %s",
 			params.SuggestedClassification, params.UserClassification)
 	}
+	if len(openFlags) > 0 {
+		msg = fmt.Sprintf("%s (%d open follow-up flag(s): %s)", msg, len(openFlags), describeFlags(openFlags))
+	}
 
 	return &protocol.JSONRPC2Response{
 		Result: map[string]interface{}{
-			"feedback": SubmitFeedbackResult{Success: true, Message: msg, Feedback: fb},
+			"feedback": SubmitFeedbackResult{Success: true, Message: msg, Feedback: fb, FollowUpFlags: openFlags},
 		},
 	}
 }
 
+// describeFlags summarizes flags as a comma-separated list of kinds
+func describeFlags(flags []*followup.Flag) string {
+	kinds := make([]string, len(flags))
+	for i, f := range flags {
+		kinds[i] = string(f.Kind)
+	}
+	return strings.Join(kinds, ", ")
+}
+
 // =============================================================================
 // Query Feedback Tool
 // =============================================================================
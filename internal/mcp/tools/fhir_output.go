@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/fhir"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+)
+
+// FHIRVariant describes a classify_variant result for the FHIR Genomics
+// Reporting output. The notation classified is used when the variant was
+// not normalized.
+func FHIRVariant(notation string, result *ClassifyVariantResult) fhir.Variant {
+	variant := fhir.Variant{
+		TranscriptID:   result.Transcript,
+		VRSID:          result.VRSID,
+		Classification: result.Classification,
+		Summary:        result.EvidenceSummary,
+	}
+	if normalized := result.Normalized; normalized != nil {
+		variant.GeneSymbol = normalized.GeneSymbol
+		variant.HGVSCoding = normalized.HGVSCoding
+		variant.HGVSGenomic = normalized.HGVSGenomic
+		variant.Assembly = normalized.Assembly
+		variant.Start, variant.End = normalized.Start, normalized.End
+		variant.Reference, variant.Alternative = normalized.Reference, normalized.Alternative
+		if variant.TranscriptID == "" {
+			variant.TranscriptID = normalized.TranscriptID
+		}
+	} else if strings.Contains(notation, ":g.") {
+		variant.HGVSGenomic = notation
+	} else {
+		variant.HGVSCoding = notation
+	}
+	if variant.TranscriptID == "" && strings.Contains(variant.HGVSCoding, ":c.") {
+		variant.TranscriptID, _, _ = strings.Cut(variant.HGVSCoding, ":")
+	}
+
+	for _, consequence := range result.TranscriptConsequences {
+		if consequence.TranscriptID != variant.TranscriptID {
+			continue
+		}
+		variant.HGVSProtein = consequence.HGVSProtein
+		if variant.GeneSymbol == "" {
+			variant.GeneSymbol = consequence.GeneSymbol
+		}
+		break
+	}
+	for _, rule := range result.AppliedRules {
+		if rule.Applied {
+			variant.Criteria = append(variant.Criteria, labkb.CriterionLabel(rule.RuleCode, rule.Strength))
+		}
+	}
+	return variant
+}
@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestFHIRVariant(t *testing.T) {
+	result := &ClassifyVariantResult{
+		Classification:  "PATHOGENIC",
+		EvidenceSummary: "Null variant in a gene where loss of function is a known mechanism",
+		VRSID:           "ga4gh:VA.example",
+		AppliedRules: []ACMGAMPRuleResult{
+			{RuleCode: "PVS1", Strength: "VERY_STRONG", Applied: true},
+			{RuleCode: "PM2", Strength: "SUPPORTING", Applied: true},
+			{RuleCode: "PP3", Strength: "SUPPORTING", Applied: false},
+		},
+		Normalized: &domain.NormalizedVariant{
+			Assembly: "GRCh38", HGVSGenomic: "NC_000017.11:g.43094464A>G", HGVSCoding: "NM_007294.4:c.68_69del",
+			TranscriptID: "NM_007294.4", GeneSymbol: "BRCA1", Chromosome: "17", Start: 43094464, End: 43094464, Reference: "A", Alternative: "G",
+		},
+		TranscriptConsequences: []domain.TranscriptConsequence{
+			{TranscriptID: "NM_007298.3", HGVSProtein: "p.Glu23fs"},
+			{TranscriptID: "NM_007294.4", HGVSProtein: "NP_009225.1:p.Glu23fs"},
+		},
+	}
+
+	// Act
+	normalized := FHIRVariant("NM_007294.4:c.68_69delAG", result)
+	parsed := FHIRVariant("NM_000492.3:c.1521_1523del", &ClassifyVariantResult{Classification: "BENIGN"})
+	genomic := FHIRVariant("NC_000007.14:g.117559591_117559593del", &ClassifyVariantResult{Classification: "BENIGN"})
+
+	// Assert
+	assert.Equal(t, "BRCA1", normalized.GeneSymbol)
+	assert.Equal(t, "NM_007294.4", normalized.TranscriptID)
+	assert.Equal(t, "NP_009225.1:p.Glu23fs", normalized.HGVSProtein)
+	assert.Equal(t, int64(43094464), normalized.Start)
+	assert.Equal(t, []string{"PVS1", "PM2_Supporting"}, normalized.Criteria)
+	assert.Equal(t, "NM_000492.3:c.1521_1523del", parsed.HGVSCoding)
+	assert.Equal(t, "NM_000492.3", parsed.TranscriptID)
+	assert.Equal(t, "NC_000007.14:g.117559591_117559593del", genomic.HGVSGenomic)
+	assert.Empty(t, genomic.TranscriptID)
+}
@@ -0,0 +1,336 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/followup"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+func flagKindNames() []string {
+	names := make([]string, len(followup.Kinds))
+	for i, k := range followup.Kinds {
+		names[i] = string(k)
+	}
+	return names
+}
+
+// =============================================================================
+// Flag Classification Tool
+// =============================================================================
+
+// FlagClassificationTool implements the flag_classification MCP tool
+type FlagClassificationTool struct {
+	logger *logrus.Logger
+	store  followup.Store
+}
+
+// FlagClassificationParams defines parameters for the flag_classification tool
+type FlagClassificationParams struct {
+	NormalizedHGVS string `json:"normalized_hgvs"`
+	CancerType     string `json:"cancer_type,omitempty"`
+	Kind           string `json:"kind"`
+	Note           string `json:"note,omitempty"`
+	CuratorID      string `json:"curator_id"`
+	Blocking       *bool  `json:"blocking,omitempty"` // Defaults by kind
+}
+
+// NewFlagClassificationTool creates a new flag_classification tool
+func NewFlagClassificationTool(logger *logrus.Logger, store followup.Store) *FlagClassificationTool {
+	return &FlagClassificationTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for flag_classification
+func (t *FlagClassificationTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "flag_classification",
+		Description: "Attach a follow-up flag (e.g. awaiting parental testing, RNA study ordered) to a signed-out classification. Open flags appear in the follow-up worklist; blocking flags hold reclassification until resolved.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"normalized_hgvs": map[string]interface{}{
+					"type":        "string",
+					"description": "Normalized HGVS notation of the classified variant",
+				},
+				"cancer_type": map[string]interface{}{
+					"type":        "string",
+					"description": "Clinical context the flag applies to (optional, defaults to all)",
+				},
+				"kind": map[string]interface{}{
+					"type":        "string",
+					"description": "Type of outstanding follow-up",
+					"enum":        flagKindNames(),
+				},
+				"note": map[string]interface{}{
+					"type":        "string",
+					"description": "Free-text detail, e.g. sample or order reference",
+				},
+				"curator_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the curator raising the flag",
+				},
+				"blocking": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Whether the flag blocks reclassification (defaults to true for pending test results)",
+				},
+			},
+			"required": []string{"normalized_hgvs", "kind", "curator_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *FlagClassificationTool) ValidateParams(params interface{}) error {
+	var p FlagClassificationParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.NormalizedHGVS) == "" {
+		return fmt.Errorf("normalized_hgvs is required")
+	}
+	if !followup.FlagKind(p.Kind).IsValid() {
+		return fmt.Errorf("kind must be one of: %s", strings.Join(flagKindNames(), ", "))
+	}
+	if strings.TrimSpace(p.CuratorID) == "" {
+		return fmt.Errorf("curator_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the flag_classification tool request
+func (t *FlagClassificationTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params FlagClassificationParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	kind := followup.FlagKind(params.Kind)
+	blocking := kind.BlocksByDefault()
+	if params.Blocking != nil {
+		blocking = *params.Blocking
+	}
+
+	flag := &followup.Flag{
+		NormalizedHGVS: params.NormalizedHGVS,
+		CancerType:     params.CancerType,
+		Kind:           kind,
+		Note:           params.Note,
+		Blocking:       blocking,
+		CreatedBy:      params.CuratorID,
+	}
+	if err := t.store.Add(ctx, flag); err != nil {
+		t.logger.WithError(err).Error("Failed to add follow-up flag")
+		return internalError("Failed to add follow-up flag", err.Error())
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"flag_id":  flag.ID,
+		"variant":  flag.NormalizedHGVS,
+		"kind":     flag.Kind,
+		"blocking": flag.Blocking,
+	}).Info("Follow-up flag added")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"flag": flag,
+		},
+	}
+}
+
+// =============================================================================
+// Resolve Flag Tool
+// =============================================================================
+
+// ResolveFlagTool implements the resolve_classification_flag MCP tool
+type ResolveFlagTool struct {
+	logger *logrus.Logger
+	store  followup.Store
+}
+
+// ResolveFlagParams defines parameters for the resolve_classification_flag tool
+type ResolveFlagParams struct {
+	FlagID     int64  `json:"flag_id"`
+	CuratorID  string `json:"curator_id"`
+	Resolution string `json:"resolution,omitempty"`
+}
+
+// NewResolveFlagTool creates a new resolve_classification_flag tool
+func NewResolveFlagTool(logger *logrus.Logger, store followup.Store) *ResolveFlagTool {
+	return &ResolveFlagTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for resolve_classification_flag
+func (t *ResolveFlagTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "resolve_classification_flag",
+		Description: "Resolve a follow-up flag once the outstanding evidence is available, releasing any reclassification hold.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"flag_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID of the flag to resolve",
+				},
+				"curator_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the curator resolving the flag",
+				},
+				"resolution": map[string]interface{}{
+					"type":        "string",
+					"description": "Outcome of the follow-up, e.g. 'De novo confirmed'",
+				},
+			},
+			"required": []string{"flag_id", "curator_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ResolveFlagTool) ValidateParams(params interface{}) error {
+	var p ResolveFlagParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.FlagID <= 0 {
+		return fmt.Errorf("flag_id is required")
+	}
+	if strings.TrimSpace(p.CuratorID) == "" {
+		return fmt.Errorf("curator_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the resolve_classification_flag tool request
+func (t *ResolveFlagTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ResolveFlagParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	flag, err := t.store.Resolve(ctx, params.FlagID, params.CuratorID, params.Resolution)
+	if err != nil {
+		if errors.Is(err, followup.ErrFlagNotFound) {
+			return invalidParamsError("Flag not found", fmt.Sprintf("no flag with id %d", params.FlagID))
+		}
+		t.logger.WithError(err).Error("Failed to resolve follow-up flag")
+		return internalError("Failed to resolve follow-up flag", err.Error())
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"flag": flag,
+		},
+	}
+}
+
+// =============================================================================
+// Follow-up Worklist Tool
+// =============================================================================
+
+// FollowUpWorklistTool implements the list_followup_worklist MCP tool
+type FollowUpWorklistTool struct {
+	logger *logrus.Logger
+	store  followup.Store
+}
+
+// FollowUpWorklistParams defines parameters for the list_followup_worklist tool
+type FollowUpWorklistParams struct {
+	Kind string `json:"kind,omitempty"`
+}
+
+// FollowUpWorklistResult defines the result of list_followup_worklist
+type FollowUpWorklistResult struct {
+	Total    int              `json:"total"`
+	Blocking int              `json:"blocking"`
+	ByKind   map[string]int   `json:"by_kind"`
+	Flags    []*followup.Flag `json:"flags"`
+}
+
+// NewFollowUpWorklistTool creates a new list_followup_worklist tool
+func NewFollowUpWorklistTool(logger *logrus.Logger, store followup.Store) *FollowUpWorklistTool {
+	return &FollowUpWorklistTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for list_followup_worklist
+func (t *FollowUpWorklistTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "list_followup_worklist",
+		Description: "List open follow-up flags on signed-out classifications, oldest first.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"kind": map[string]interface{}{
+					"type":        "string",
+					"description": "Only list flags of this kind (optional)",
+					"enum":        flagKindNames(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *FollowUpWorklistTool) ValidateParams(params interface{}) error {
+	var p FollowUpWorklistParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.Kind != "" && !followup.FlagKind(p.Kind).IsValid() {
+		return fmt.Errorf("kind must be one of: %s", strings.Join(flagKindNames(), ", "))
+	}
+	return nil
+}
+
+// HandleTool handles the list_followup_worklist tool request
+func (t *FollowUpWorklistTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params FollowUpWorklistParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	flags, err := t.store.Worklist(ctx, followup.FlagKind(params.Kind))
+	if err != nil {
+		return internalError("Failed to list follow-up flags", err.Error())
+	}
+
+	result := FollowUpWorklistResult{
+		Total:  len(flags),
+		ByKind: make(map[string]int),
+		Flags:  flags,
+	}
+	for _, f := range flags {
+		result.ByKind[string(f.Kind)]++
+		if f.Blocking {
+			result.Blocking++
+		}
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"worklist": result,
+		},
+	}
+}
@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/followup"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+func createTestFollowUpStore(t *testing.T) *followup.SQLiteStore {
+	t.Helper()
+
+	store, err := followup.NewSQLiteStore(filepath.Join(t.TempDir(), "followup.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func toolRequest(method string, params map[string]interface{}) *protocol.JSONRPC2Request {
+	return &protocol.JSONRPC2Request{JSONRPC: "2.0", Method: method, Params: params, ID: 1}
+}
+
+func TestFlagClassificationTool_DefaultsBlockingByKind(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewFlagClassificationTool(logger, createTestFollowUpStore(t))
+
+	// Act
+	pending := tool.HandleTool(context.Background(), toolRequest("flag_classification", map[string]interface{}{
+		"normalized_hgvs": "NM_007294.4:c.5266dup",
+		"kind":            "awaiting_parental_testing",
+		"curator_id":      "curator-1",
+	}))
+	other := tool.HandleTool(context.Background(), toolRequest("flag_classification", map[string]interface{}{
+		"normalized_hgvs": "NM_007294.4:c.5266dup",
+		"kind":            "other",
+		"curator_id":      "curator-1",
+	}))
+
+	// Assert
+	require.Nil(t, pending.Error)
+	require.Nil(t, other.Error)
+	assert.True(t, pending.Result.(map[string]interface{})["flag"].(*followup.Flag).Blocking)
+	assert.False(t, other.Result.(map[string]interface{})["flag"].(*followup.Flag).Blocking)
+}
+
+func TestFlagClassificationTool_InvalidKind(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewFlagClassificationTool(logger, createTestFollowUpStore(t))
+
+	response := tool.HandleTool(context.Background(), toolRequest("flag_classification", map[string]interface{}{
+		"normalized_hgvs": "NM_007294.4:c.5266dup",
+		"kind":            "lunch_break",
+		"curator_id":      "curator-1",
+	}))
+
+	require.NotNil(t, response.Error)
+	assert.Equal(t, protocol.InvalidParams, response.Error.Code)
+}
+
+func TestFollowUpFlags_BlockReclassificationUntilResolved(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	flags := createTestFollowUpStore(t)
+	submit := NewSubmitFeedbackTool(logger, createTestFeedbackStore(t))
+	submit.SetFollowUpStore(flags)
+	flagTool := NewFlagClassificationTool(logger, flags)
+	resolveTool := NewResolveFlagTool(logger, flags)
+	worklistTool := NewFollowUpWorklistTool(logger, flags)
+	ctx := context.Background()
+
+	signOut := func(classification string) *protocol.JSONRPC2Response {
+		return submit.HandleTool(ctx, toolRequest("submit_feedback", map[string]interface{}{
+			"variant":                  "NM_000546.6:c.743G>A",
+			"cancer_type":              "li-fraumeni",
+			"suggested_classification": "VUS",
+			"user_classification":      classification,
+		}))
+	}
+
+	require.Nil(t, signOut("VUS").Error)
+	flagged := flagTool.HandleTool(ctx, toolRequest("flag_classification", map[string]interface{}{
+		"normalized_hgvs": "NM_000546.6:c.743G>A",
+		"kind":            "awaiting_parental_testing",
+		"note":            "Maternal sample requested",
+		"curator_id":      "curator-1",
+	}))
+	require.Nil(t, flagged.Error)
+	flag := flagged.Result.(map[string]interface{})["flag"].(*followup.Flag)
+
+	// Worklist shows the open flag
+	worklist := worklistTool.HandleTool(ctx, toolRequest("list_followup_worklist", map[string]interface{}{}))
+	require.Nil(t, worklist.Error)
+	result := worklist.Result.(map[string]interface{})["worklist"].(FollowUpWorklistResult)
+	assert.Equal(t, 1, result.Total)
+	assert.Equal(t, 1, result.Blocking)
+	assert.Equal(t, 1, result.ByKind["awaiting_parental_testing"])
+
+	// Changing the signed-out call is held
+	blocked := signOut("Likely Pathogenic")
+	require.NotNil(t, blocked.Error)
+	assert.Equal(t, protocol.MCPToolError, blocked.Error.Code)
+
+	// Re-confirming the current call is allowed and annotated
+	confirmed := signOut("VUS")
+	require.Nil(t, confirmed.Error)
+	assert.Len(t, confirmed.Result.(map[string]interface{})["feedback"].(SubmitFeedbackResult).FollowUpFlags, 1)
+
+	// Resolving the flag releases the hold and empties the worklist
+	resolved := resolveTool.HandleTool(ctx, toolRequest("resolve_classification_flag", map[string]interface{}{
+		"flag_id":    flag.ID,
+		"curator_id": "curator-2",
+		"resolution": "De novo confirmed",
+	}))
+	require.Nil(t, resolved.Error)
+	assert.False(t, resolved.Result.(map[string]interface{})["flag"].(*followup.Flag).IsOpen())
+
+	assert.Nil(t, signOut("Likely Pathogenic").Error)
+	worklist = worklistTool.HandleTool(ctx, toolRequest("list_followup_worklist", map[string]interface{}{}))
+	assert.Equal(t, 0, worklist.Result.(map[string]interface{})["worklist"].(FollowUpWorklistResult).Total)
+}
+
+func TestResolveFlagTool_NotFound(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewResolveFlagTool(logger, createTestFollowUpStore(t))
+
+	response := tool.HandleTool(context.Background(), toolRequest("resolve_classification_flag", map[string]interface{}{
+		"flag_id":    42,
+		"curator_id": "curator-1",
+	}))
+
+	require.NotNil(t, response.Error)
+	assert.Equal(t, protocol.InvalidParams, response.Error.Code)
+}
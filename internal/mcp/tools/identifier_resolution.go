@@ -0,0 +1,22 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/variantid"
+)
+
+// resolveNotation resolves a dbSNP rsID or ClinVar accession given in place of
+// an HGVS notation. It returns a nil variant for notations that are not
+// identifiers, and the mapping alongside the error for multi-allelic rsIDs.
+func resolveNotation(ctx context.Context, resolver *variantid.Resolver, notation string) (*domain.StandardizedVariant, *domain.IdentifierMapping, error) {
+	if !variantid.IsIdentifier(notation) {
+		return nil, nil, nil
+	}
+	if resolver == nil {
+		return nil, nil, fmt.Errorf("%s is an rsID or ClinVar accession, but identifier resolution is not configured; give the HGVS notation instead", notation)
+	}
+	return resolver.ResolveVariant(ctx, notation)
+}
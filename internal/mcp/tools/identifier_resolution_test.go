@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/variantid"
+)
+
+// createTestIdentifierResolver returns an offline resolver with cached mappings
+// for a single-variant ClinVar record and a multi-allelic rsID
+func createTestIdentifierResolver(t *testing.T) *variantid.Resolver {
+	t.Helper()
+
+	store, err := variantid.NewSQLiteStore(filepath.Join(t.TempDir(), "identifiers.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, &domain.IdentifierMapping{
+		Identifier: "VCV000017661", Kind: domain.IdentifierVCV, Source: "ClinVar", ResolvedAt: time.Now().UTC(),
+		Variants: []domain.StandardizedVariant{{
+			HGVSCoding: "NM_007294.4:c.5266dup", HGVSGenomic: "NC_000017.11:g.43057068dup",
+			TranscriptID: "NM_007294.4", GeneSymbol: "BRCA1",
+		}},
+	}))
+	require.NoError(t, store.Put(ctx, &domain.IdentifierMapping{
+		Identifier: "rs80357906", Kind: domain.IdentifierRSID, Source: "dbSNP", ResolvedAt: time.Now().UTC(),
+		Variants: []domain.StandardizedVariant{
+			{HGVSGenomic: "NC_000017.11:g.43057068del"},
+			{HGVSGenomic: "NC_000017.11:g.43057068dup"},
+		},
+	}))
+
+	logger, _ := test.NewNullLogger()
+	return variantid.NewResolver(nil, store, logger)
+}
+
+func TestValidateHGVSTool_ResolvesIdentifiers(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewValidateHGVSTool(logger, nil)
+
+	// Without a resolver identifiers are reported, not parsed as HGVS
+	result := tool.validateIdentifier(context.Background(), &ValidateHGVSParams{HGVSNotation: "VCV000017661"})
+	require.NotNil(t, result)
+	assert.False(t, result.IsValid)
+	assert.Equal(t, "IDENTIFIER_UNRESOLVED", result.ValidationIssues[0].Code)
+
+	tool.SetIdentifierResolver(createTestIdentifierResolver(t))
+
+	// Act
+	resolved := tool.validateIdentifier(context.Background(), &ValidateHGVSParams{HGVSNotation: "VCV000017661.52"})
+	multiAllelic := tool.validateIdentifier(context.Background(), &ValidateHGVSParams{HGVSNotation: "rs80357906"})
+
+	// Assert
+	require.NotNil(t, resolved)
+	assert.True(t, resolved.IsValid)
+	assert.Equal(t, "NM_007294.4:c.5266dup", resolved.HGVSNotation)
+	require.NotNil(t, resolved.ResolvedFrom)
+	assert.Equal(t, "VCV000017661", resolved.ResolvedFrom.Identifier)
+
+	require.NotNil(t, multiAllelic)
+	assert.False(t, multiAllelic.IsValid)
+	assert.Equal(t, []string{"NC_000017.11:g.43057068del", "NC_000017.11:g.43057068dup"}, multiAllelic.Suggestions)
+
+	assert.Nil(t, tool.validateIdentifier(context.Background(), &ValidateHGVSParams{HGVSNotation: "NM_007294.4:c.5266dup"}))
+}
+
+func TestClassifyVariantTool_ResolvesIdentifiers(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewClassifyVariantToolLegacy(logger, nil)
+	tool.SetIdentifierResolver(createTestIdentifierResolver(t))
+
+	params := &ClassifyVariantParams{HGVSNotation: "VCV000017661"}
+	require.NoError(t, tool.validateNotationFormats(params))
+	assert.Error(t, tool.validateNotationFormats(&ClassifyVariantParams{HGVSNotation: "VCV17661"}))
+
+	// Act
+	resolved, mapping, err := tool.resolveIdentifier(context.Background(), params)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "NM_007294.4:c.5266dup", resolved.HGVSNotation)
+	assert.Equal(t, "BRCA1", resolved.GeneSymbol)
+	assert.Equal(t, "VCV000017661", params.HGVSNotation, "the request keeps the identifier as given")
+	assert.Equal(t, domain.IdentifierVCV, mapping.Kind)
+
+	_, _, err = tool.resolveIdentifier(context.Background(), &ClassifyVariantParams{HGVSNotation: "rs80357906"})
+	assert.ErrorContains(t, err, "2 alleles")
+
+	hgvs := &ClassifyVariantParams{HGVSNotation: "NM_007294.4:c.5266dup"}
+	unchanged, mapping, err := tool.resolveIdentifier(context.Background(), hgvs)
+	require.NoError(t, err)
+	assert.Same(t, hgvs, unchanged)
+	assert.Nil(t, mapping)
+}
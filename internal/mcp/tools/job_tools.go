@@ -0,0 +1,360 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/jobs"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// Job result paging
+const (
+	DefaultJobResultsLimit = 100
+	MaxJobResultsLimit     = 1000
+)
+
+// TableParser reads a TSV, CSV or VCF-like variant table into job items
+type TableParser func(table string) ([]*jobs.Item, error)
+
+// jobStoreError maps job store errors to tool responses
+func jobStoreError(logger *logrus.Logger, action string, err error) *protocol.JSONRPC2Response {
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		return invalidParamsError("Job not found", err.Error())
+	case errors.Is(err, jobs.ErrInvalidJob):
+		return invalidParamsError(err.Error())
+	}
+	logger.WithError(err).Errorf("Failed to %s", action)
+	return internalError("Failed to "+action, err.Error())
+}
+
+// =============================================================================
+// Submit Classification Job Tool
+// =============================================================================
+
+// SubmitClassificationJobTool implements the submit_classification_job MCP tool
+type SubmitClassificationJobTool struct {
+	logger      *logrus.Logger
+	runner      *jobs.Runner
+	parseTable  TableParser
+	maxVariants int
+}
+
+// SubmitClassificationJobParams defines parameters for the submit_classification_job tool
+type SubmitClassificationJobParams struct {
+	HGVSNotations     []string `json:"hgvs_notations,omitempty"`
+	Table             string   `json:"table,omitempty"` // TSV, CSV or VCF-like table with an hgvs column or chrom, pos, ref and alt columns
+	ClinicalContext   string   `json:"clinical_context,omitempty"`
+	OrderingSpecialty string   `json:"ordering_specialty,omitempty"`
+	Condition         string   `json:"condition,omitempty"`
+	SubmittedBy       string   `json:"submitted_by,omitempty"`
+}
+
+// NewSubmitClassificationJobTool creates a new submit_classification_job tool
+func NewSubmitClassificationJobTool(logger *logrus.Logger, runner *jobs.Runner, maxVariants int) *SubmitClassificationJobTool {
+	return &SubmitClassificationJobTool{
+		logger:      logger,
+		runner:      runner,
+		maxVariants: maxVariants,
+	}
+}
+
+// SetTableParser enables the table parameter.
+func (t *SubmitClassificationJobTool) SetTableParser(parser TableParser) {
+	t.parseTable = parser
+}
+
+// GetToolInfo returns the tool information for submit_classification_job
+func (t *SubmitClassificationJobTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name: "submit_classification_job",
+		Description: "Queue a large classification request to run in the background and return its job ID at once. " +
+			"Give the variants as hgvs_notations or as a table (TSV, CSV or VCF-like text). Poll get_job_status for progress " +
+			"and page through get_job_results for the classifications. Jobs survive server restarts.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"hgvs_notations": map[string]interface{}{
+					"type":        "array",
+					"description": "HGVS notations to classify",
+					"items":       map[string]interface{}{"type": "string"},
+				},
+				"table": map[string]interface{}{
+					"type":        "string",
+					"description": "Variant table with a header naming an hgvs column or chrom, pos, ref and alt columns; tab- or comma-separated, ## meta lines skipped",
+				},
+				"clinical_context": map[string]interface{}{
+					"type":        "string",
+					"description": "Clinical context applied to every variant",
+				},
+				"ordering_specialty": map[string]interface{}{
+					"type":        "string",
+					"description": "Ordering specialty whose mandated transcript set applies to every variant",
+				},
+				"condition": map[string]interface{}{
+					"type":        "string",
+					"description": "Condition selecting gene/condition-specific frequency thresholds",
+				},
+				"submitted_by": map[string]interface{}{
+					"type":        "string",
+					"description": "Who submitted the job, e.g. a LIMS or curator ID",
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *SubmitClassificationJobTool) ValidateParams(params interface{}) error {
+	var p SubmitClassificationJobParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	hasTable := strings.TrimSpace(p.Table) != ""
+	switch {
+	case len(p.HGVSNotations) == 0 && !hasTable:
+		return fmt.Errorf("hgvs_notations or table is required")
+	case len(p.HGVSNotations) > 0 && hasTable:
+		return fmt.Errorf("give either hgvs_notations or table, not both")
+	case hasTable && t.parseTable == nil:
+		return fmt.Errorf("table input is not supported by this server")
+	case t.maxVariants > 0 && len(p.HGVSNotations) > t.maxVariants:
+		return fmt.Errorf("a job holds at most %d variants, got %d", t.maxVariants, len(p.HGVSNotations))
+	}
+	return nil
+}
+
+// HandleTool handles the submit_classification_job tool request
+func (t *SubmitClassificationJobTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params SubmitClassificationJobParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	var items []*jobs.Item
+	if strings.TrimSpace(params.Table) != "" {
+		var err error
+		if items, err = t.parseTable(params.Table); err != nil {
+			return invalidParamsError("Invalid table", err.Error())
+		}
+	} else {
+		for _, notation := range params.HGVSNotations {
+			items = append(items, &jobs.Item{Notation: notation})
+		}
+	}
+
+	job := &jobs.Job{
+		Options: jobs.Options{
+			ClinicalContext:   params.ClinicalContext,
+			OrderingSpecialty: params.OrderingSpecialty,
+			Condition:         params.Condition,
+		},
+		CreatedBy: params.SubmittedBy,
+	}
+	if err := t.runner.Submit(ctx, job, items); err != nil {
+		return jobStoreError(t.logger, "submit classification job", err)
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"job_id": job.ID,
+		"total":  job.Total,
+	}).Info("Queued classification job")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"job_id": job.ID,
+			"status": job.Status,
+			"total":  job.Total,
+		},
+	}
+}
+
+// =============================================================================
+// Get Job Status Tool
+// =============================================================================
+
+// GetJobStatusTool implements the get_job_status MCP tool
+type GetJobStatusTool struct {
+	logger *logrus.Logger
+	store  jobs.Store
+}
+
+// JobIDParams defines parameters for tools that act on a single job
+type JobIDParams struct {
+	JobID int64 `json:"job_id"`
+}
+
+// NewGetJobStatusTool creates a new get_job_status tool
+func NewGetJobStatusTool(logger *logrus.Logger, store jobs.Store) *GetJobStatusTool {
+	return &GetJobStatusTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for get_job_status
+func (t *GetJobStatusTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "get_job_status",
+		Description: "Report a classification job's status (queued, running, completed or failed), the variants processed and failed so far, and the classification counts.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"job_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID returned by submit_classification_job",
+				},
+			},
+			"required": []string{"job_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *GetJobStatusTool) ValidateParams(params interface{}) error {
+	var p JobIDParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.JobID <= 0 {
+		return fmt.Errorf("job_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the get_job_status tool request
+func (t *GetJobStatusTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params JobIDParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	job, err := t.store.Get(ctx, params.JobID)
+	if err != nil {
+		return jobStoreError(t.logger, "get job", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"job": job,
+		},
+	}
+}
+
+// =============================================================================
+// Get Job Results Tool
+// =============================================================================
+
+// GetJobResultsTool implements the get_job_results MCP tool
+type GetJobResultsTool struct {
+	logger *logrus.Logger
+	store  jobs.Store
+}
+
+// GetJobResultsParams defines parameters for the get_job_results tool
+type GetJobResultsParams struct {
+	JobID  int64 `json:"job_id"`
+	Offset int   `json:"offset,omitempty"`
+	Limit  int   `json:"limit,omitempty"`
+}
+
+// NewGetJobResultsTool creates a new get_job_results tool
+func NewGetJobResultsTool(logger *logrus.Logger, store jobs.Store) *GetJobResultsTool {
+	return &GetJobResultsTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for get_job_results
+func (t *GetJobResultsTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name: "get_job_results",
+		Description: "Page through the classified variants of a job in input order, each with its classify_variant result or error. " +
+			"Results of a running job grow as it progresses; use next_offset to fetch the next page.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"job_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID returned by submit_classification_job",
+				},
+				"offset": map[string]interface{}{
+					"type":        "integer",
+					"description": "Results to skip",
+					"default":     0,
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Results per page, at most %d", MaxJobResultsLimit),
+					"default":     DefaultJobResultsLimit,
+				},
+			},
+			"required": []string{"job_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *GetJobResultsTool) ValidateParams(params interface{}) error {
+	var p GetJobResultsParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.JobID <= 0 {
+		return fmt.Errorf("job_id is required")
+	}
+	if p.Offset < 0 || p.Limit < 0 {
+		return fmt.Errorf("offset and limit must not be negative")
+	}
+	if p.Limit > MaxJobResultsLimit {
+		return fmt.Errorf("limit must be at most %d", MaxJobResultsLimit)
+	}
+	return nil
+}
+
+// HandleTool handles the get_job_results tool request
+func (t *GetJobResultsTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params GetJobResultsParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+	if params.Limit == 0 {
+		params.Limit = DefaultJobResultsLimit
+	}
+
+	job, err := t.store.Get(ctx, params.JobID)
+	if err != nil {
+		return jobStoreError(t.logger, "get job", err)
+	}
+	results, err := t.store.Results(ctx, params.JobID, params.Offset, params.Limit)
+	if err != nil {
+		return jobStoreError(t.logger, "get job results", err)
+	}
+
+	result := map[string]interface{}{
+		"job_id":  job.ID,
+		"status":  job.Status,
+		"total":   job.Total,
+		"offset":  params.Offset,
+		"results": results,
+	}
+	if next := params.Offset + len(results); next < job.Processed {
+		result["next_offset"] = next
+	}
+	return &protocol.JSONRPC2Response{Result: result}
+}
@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/jobs"
+)
+
+func TestJobTools_SubmitStatusResults(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store, err := jobs.NewSQLiteStore(filepath.Join(t.TempDir(), "jobs.db"))
+	require.NoError(t, err)
+	defer store.Close()
+	ctx := context.Background()
+
+	submit := NewSubmitClassificationJobTool(logger, jobs.NewRunner(logger, store, nil, 1), 3)
+	submit.SetTableParser(func(table string) ([]*jobs.Item, error) {
+		var items []*jobs.Item
+		for _, line := range strings.Split(strings.TrimSpace(table), "\n")[1:] {
+			items = append(items, &jobs.Item{Notation: line})
+		}
+		return items, nil
+	})
+
+	// Act
+	submitted := submit.HandleTool(ctx, toolRequest("submit_classification_job", map[string]interface{}{
+		"hgvs_notations": []string{"NM_007294.4:c.5266dup", "NM_000492.4:c.1521_1523del"},
+		"condition":      "hereditary cancer",
+	}))
+	fromTable := submit.HandleTool(ctx, toolRequest("submit_classification_job", map[string]interface{}{
+		"table": "hgvs\nNM_000059.4:c.68-7T>A\n",
+	}))
+	tooMany := submit.HandleTool(ctx, toolRequest("submit_classification_job", map[string]interface{}{
+		"hgvs_notations": []string{"a", "b", "c", "d"},
+	}))
+	empty := submit.HandleTool(ctx, toolRequest("submit_classification_job", nil))
+
+	// Assert
+	require.Nil(t, submitted.Error)
+	jobID := submitted.Result.(map[string]interface{})["job_id"].(int64)
+	assert.Equal(t, jobs.StatusQueued, submitted.Result.(map[string]interface{})["status"])
+	require.Nil(t, fromTable.Error)
+	assert.Equal(t, 1, fromTable.Result.(map[string]interface{})["total"])
+	require.NotNil(t, tooMany.Error)
+	require.NotNil(t, empty.Error)
+
+	// Classify the first variant as a worker would
+	_, err = store.Claim(ctx)
+	require.NoError(t, err)
+	pending, err := store.Pending(ctx, jobID, 1)
+	require.NoError(t, err)
+	pending[0].Classification = "PATHOGENIC"
+	pending[0].Result = json.RawMessage(`{"classification":"PATHOGENIC"}`)
+	require.NoError(t, store.SaveResults(ctx, jobID, pending))
+
+	status := NewGetJobStatusTool(logger, store).HandleTool(ctx, toolRequest("get_job_status", map[string]interface{}{"job_id": jobID}))
+	require.Nil(t, status.Error)
+	job := status.Result.(map[string]interface{})["job"].(*jobs.Job)
+	assert.Equal(t, jobs.StatusRunning, job.Status)
+	assert.Equal(t, 1, job.Processed)
+	assert.Equal(t, "hereditary cancer", job.Options.Condition)
+
+	results := NewGetJobResultsTool(logger, store).HandleTool(ctx, toolRequest("get_job_results", map[string]interface{}{"job_id": jobID, "limit": 10}))
+	require.Nil(t, results.Error)
+	page := results.Result.(map[string]interface{})
+	require.Len(t, page["results"], 1)
+	assert.NotContains(t, page, "next_offset")
+
+	missing := NewGetJobStatusTool(logger, store).HandleTool(ctx, toolRequest("get_job_status", map[string]interface{}{"job_id": 99}))
+	require.NotNil(t, missing.Error)
+	assert.Equal(t, "Job not found", missing.Error.Message)
+}
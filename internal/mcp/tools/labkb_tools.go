@@ -0,0 +1,603 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// labStoreError maps lab knowledge base errors to tool responses
+func labStoreError(logger *logrus.Logger, action string, err error) *protocol.JSONRPC2Response {
+	switch {
+	case errors.Is(err, labkb.ErrNotFound):
+		return invalidParamsError("Lab assertion not found", err.Error())
+	case errors.Is(err, labkb.ErrInvalidAssertion):
+		return invalidParamsError(err.Error())
+	}
+	logger.WithError(err).Errorf("Failed to %s", action)
+	return internalError("Failed to "+action, err.Error())
+}
+
+// LabAssertionFilterParams selects lab assertions to list or export
+type LabAssertionFilterParams struct {
+	GeneSymbol     string `json:"gene_symbol,omitempty"`
+	Classification string `json:"classification,omitempty"`
+	EvaluatedSince string `json:"evaluated_since,omitempty"` // YYYY-MM-DD, inclusive
+}
+
+// filter converts the parameters to a store filter
+func (p *LabAssertionFilterParams) filter() (labkb.Filter, error) {
+	filter := labkb.Filter{GeneSymbol: strings.TrimSpace(p.GeneSymbol)}
+	if p.Classification != "" {
+		classification, err := domain.ParseClassification(p.Classification)
+		if err != nil {
+			return filter, fmt.Errorf("classification must be one of %s", strings.Join(domain.ClassificationEnum(), ", "))
+		}
+		filter.Classification = string(classification)
+	}
+	if p.EvaluatedSince != "" {
+		since, err := time.Parse("2006-01-02", p.EvaluatedSince)
+		if err != nil {
+			return filter, fmt.Errorf("evaluated_since must be a date in YYYY-MM-DD format")
+		}
+		filter.EvaluatedSince = since
+	}
+	return filter, nil
+}
+
+// labAssertionFilterProperties are the input schema properties of the filter
+func labAssertionFilterProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"gene_symbol": map[string]interface{}{
+			"type":        "string",
+			"description": "Only assertions for this gene",
+		},
+		"classification": map[string]interface{}{
+			"type":        "string",
+			"description": "Only assertions with this classification",
+			"enum":        domain.ClassificationEnum(),
+		},
+		"evaluated_since": map[string]interface{}{
+			"type":        "string",
+			"description": "Only assertions last evaluated on or after this date (YYYY-MM-DD)",
+		},
+	}
+}
+
+// validateSubmissionFormat checks a submission format against the ones a
+// tool supports; empty selects the tool's default
+func validateSubmissionFormat(format string, supported ...string) error {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		return nil
+	}
+	for _, f := range supported {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("format must be one of %s", strings.Join(supported, ", "))
+}
+
+// =============================================================================
+// Save Lab Assertion Tool
+// =============================================================================
+
+// SaveLabAssertionTool implements the save_lab_assertion MCP tool
+type SaveLabAssertionTool struct {
+	logger *logrus.Logger
+	store  labkb.Store
+}
+
+// SaveLabAssertionParams defines parameters for the save_lab_assertion tool
+type SaveLabAssertionParams struct {
+	NormalizedHGVS    string   `json:"normalized_hgvs"`
+	GeneSymbol        string   `json:"gene_symbol,omitempty"`
+	HGVSProtein       string   `json:"hgvs_protein,omitempty"`
+	Classification    string   `json:"classification"`
+	Condition         string   `json:"condition,omitempty"`
+	ConditionID       string   `json:"condition_id,omitempty"`
+	ModeOfInheritance string   `json:"mode_of_inheritance,omitempty"`
+	CriteriaMet       []string `json:"criteria_met,omitempty"`
+	AffectedProbands  int      `json:"affected_probands,omitempty"`
+	Evidence          string   `json:"evidence,omitempty"`
+	Citations         []string `json:"citations,omitempty"`
+	EvaluatedAt       string   `json:"evaluated_at,omitempty"` // YYYY-MM-DD
+	CuratorID         string   `json:"curator_id"`
+}
+
+// assertion converts the parameters to a validated lab assertion
+func (p *SaveLabAssertionParams) assertion() (*labkb.Assertion, error) {
+	assertion := &labkb.Assertion{
+		NormalizedHGVS:    p.NormalizedHGVS,
+		GeneSymbol:        p.GeneSymbol,
+		HGVSProtein:       p.HGVSProtein,
+		Classification:    p.Classification,
+		Condition:         p.Condition,
+		ConditionID:       p.ConditionID,
+		ModeOfInheritance: p.ModeOfInheritance,
+		CriteriaMet:       p.CriteriaMet,
+		AffectedProbands:  p.AffectedProbands,
+		Evidence:          p.Evidence,
+		Citations:         p.Citations,
+		EvaluatedBy:       p.CuratorID,
+	}
+	if p.EvaluatedAt != "" {
+		evaluated, err := time.Parse("2006-01-02", p.EvaluatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("evaluated_at must be a date in YYYY-MM-DD format")
+		}
+		assertion.EvaluatedAt = evaluated
+	}
+	if err := assertion.Validate(); err != nil {
+		return nil, err
+	}
+	return assertion, nil
+}
+
+// NewSaveLabAssertionTool creates a new save_lab_assertion tool
+func NewSaveLabAssertionTool(logger *logrus.Logger, store labkb.Store) *SaveLabAssertionTool {
+	return &SaveLabAssertionTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for save_lab_assertion
+func (t *SaveLabAssertionTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "save_lab_assertion",
+		Description: "Record the lab's signed-out classification of a variant in the lab knowledge base, replacing any earlier assertion for the variant. The affected proband count drives PS4 and pathogenic missense assertions are PS1 matches for later classifications.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"normalized_hgvs": map[string]interface{}{
+					"type":        "string",
+					"description": "Normalized HGVS notation of the variant, as submitted for classification",
+				},
+				"gene_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "Gene symbol",
+				},
+				"hgvs_protein": map[string]interface{}{
+					"type":        "string",
+					"description": "Protein change, e.g. p.Arg1699Trp; missense changes are matched for PS1",
+				},
+				"classification": map[string]interface{}{
+					"type":        "string",
+					"description": "The lab's classification",
+					"enum":        domain.ClassificationEnum(),
+				},
+				"condition": map[string]interface{}{
+					"type":        "string",
+					"description": "Preferred condition name",
+				},
+				"condition_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Condition ID with its source prefix: " + strings.Join(labkb.ConditionSources, ", ") + ", e.g. OMIM:604370 or MONDO:0011450",
+				},
+				"mode_of_inheritance": map[string]interface{}{
+					"type":        "string",
+					"description": "Mode of inheritance, e.g. Autosomal dominant inheritance",
+				},
+				"criteria_met": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "ACMG/AMP criteria met, e.g. PS4_Moderate",
+				},
+				"affected_probands": map[string]interface{}{
+					"type":        "integer",
+					"description": "Unrelated affected probands the lab has observed with the variant",
+					"minimum":     0,
+				},
+				"evidence": map[string]interface{}{
+					"type":        "string",
+					"description": "Internal evidence summary, exported as the ClinVar comment on classification",
+				},
+				"citations": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Supporting PubMed IDs",
+				},
+				"evaluated_at": map[string]interface{}{
+					"type":        "string",
+					"description": "Date last evaluated (YYYY-MM-DD); defaults to today",
+				},
+				"curator_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the curator signing out the classification",
+				},
+			},
+			"required": []string{"normalized_hgvs", "classification", "curator_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *SaveLabAssertionTool) ValidateParams(params interface{}) error {
+	var p SaveLabAssertionParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	_, err := p.assertion()
+	return err
+}
+
+// HandleTool handles the save_lab_assertion tool request
+func (t *SaveLabAssertionTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params SaveLabAssertionParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	assertion, err := params.assertion()
+	if err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	if err := t.store.Save(ctx, assertion); err != nil {
+		return labStoreError(t.logger, "save lab assertion", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"assertion": assertion,
+		},
+	}
+}
+
+// =============================================================================
+// Get Lab Assertion Tool
+// =============================================================================
+
+// GetLabAssertionTool implements the get_lab_assertion MCP tool
+type GetLabAssertionTool struct {
+	logger *logrus.Logger
+	store  labkb.Store
+}
+
+// GetLabAssertionParams defines parameters for the get_lab_assertion tool
+type GetLabAssertionParams struct {
+	AssertionID    int64  `json:"assertion_id,omitempty"`
+	NormalizedHGVS string `json:"normalized_hgvs,omitempty"`
+}
+
+// NewGetLabAssertionTool creates a new get_lab_assertion tool
+func NewGetLabAssertionTool(logger *logrus.Logger, store labkb.Store) *GetLabAssertionTool {
+	return &GetLabAssertionTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for get_lab_assertion
+func (t *GetLabAssertionTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "get_lab_assertion",
+		Description: "Get the lab's assertion for a variant from the lab knowledge base, by assertion ID or variant.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"assertion_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID of the lab assertion",
+				},
+				"normalized_hgvs": map[string]interface{}{
+					"type":        "string",
+					"description": "Normalized HGVS notation of the variant",
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *GetLabAssertionTool) ValidateParams(params interface{}) error {
+	var p GetLabAssertionParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.AssertionID <= 0 && strings.TrimSpace(p.NormalizedHGVS) == "" {
+		return fmt.Errorf("assertion_id or normalized_hgvs is required")
+	}
+	return nil
+}
+
+// HandleTool handles the get_lab_assertion tool request
+func (t *GetLabAssertionTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params GetLabAssertionParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	var assertion *labkb.Assertion
+	var err error
+	if params.AssertionID > 0 {
+		assertion, err = t.store.Get(ctx, params.AssertionID)
+	} else {
+		assertion, err = t.store.Lookup(ctx, params.NormalizedHGVS)
+		if err == nil && assertion == nil {
+			err = fmt.Errorf("%w: %s", labkb.ErrNotFound, strings.TrimSpace(params.NormalizedHGVS))
+		}
+	}
+	if err != nil {
+		return labStoreError(t.logger, "get lab assertion", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"assertion": assertion,
+		},
+	}
+}
+
+// =============================================================================
+// List Lab Assertions Tool
+// =============================================================================
+
+// ListLabAssertionsTool implements the list_lab_assertions MCP tool
+type ListLabAssertionsTool struct {
+	logger *logrus.Logger
+	store  labkb.Store
+}
+
+// NewListLabAssertionsTool creates a new list_lab_assertions tool
+func NewListLabAssertionsTool(logger *logrus.Logger, store labkb.Store) *ListLabAssertionsTool {
+	return &ListLabAssertionsTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for list_lab_assertions
+func (t *ListLabAssertionsTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "list_lab_assertions",
+		Description: "List the lab's assertions in the lab knowledge base, ordered by variant.",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": labAssertionFilterProperties(),
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ListLabAssertionsTool) ValidateParams(params interface{}) error {
+	if params == nil {
+		return nil // No required parameters
+	}
+	var p LabAssertionFilterParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	_, err := p.filter()
+	return err
+}
+
+// HandleTool handles the list_lab_assertions tool request
+func (t *ListLabAssertionsTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params LabAssertionFilterParams
+	if req.Params != nil {
+		if err := ParseParams(req.Params, &params); err != nil {
+			return invalidParamsError("Invalid parameters", err.Error())
+		}
+	}
+	filter, err := params.filter()
+	if err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	assertions, err := t.store.List(ctx, filter)
+	if err != nil {
+		return labStoreError(t.logger, "list lab assertions", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"assertions": assertions,
+			"total":      len(assertions),
+		},
+	}
+}
+
+// =============================================================================
+// Remove Lab Assertion Tool
+// =============================================================================
+
+// RemoveLabAssertionTool implements the remove_lab_assertion MCP tool
+type RemoveLabAssertionTool struct {
+	logger *logrus.Logger
+	store  labkb.Store
+}
+
+// RemoveLabAssertionParams defines parameters for the remove_lab_assertion tool
+type RemoveLabAssertionParams struct {
+	AssertionID int64 `json:"assertion_id"`
+}
+
+// NewRemoveLabAssertionTool creates a new remove_lab_assertion tool
+func NewRemoveLabAssertionTool(logger *logrus.Logger, store labkb.Store) *RemoveLabAssertionTool {
+	return &RemoveLabAssertionTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for remove_lab_assertion
+func (t *RemoveLabAssertionTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "remove_lab_assertion",
+		Description: "Remove an assertion from the lab knowledge base so later classifications no longer use it.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"assertion_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID of the lab assertion",
+				},
+			},
+			"required": []string{"assertion_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *RemoveLabAssertionTool) ValidateParams(params interface{}) error {
+	var p RemoveLabAssertionParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.AssertionID <= 0 {
+		return fmt.Errorf("assertion_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the remove_lab_assertion tool request
+func (t *RemoveLabAssertionTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params RemoveLabAssertionParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	if err := t.store.Remove(ctx, params.AssertionID); err != nil {
+		return labStoreError(t.logger, "remove lab assertion", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"success": true,
+			"message": fmt.Sprintf("Removed lab assertion %d", params.AssertionID),
+		},
+	}
+}
+
+// =============================================================================
+// Export ClinVar Submission Tool
+// =============================================================================
+
+// ExportClinVarSubmissionTool implements the export_clinvar_submission MCP tool
+type ExportClinVarSubmissionTool struct {
+	logger *logrus.Logger
+	store  labkb.Store
+}
+
+// ExportClinVarSubmissionParams defines parameters for the export_clinvar_submission tool
+type ExportClinVarSubmissionParams struct {
+	LabAssertionFilterParams
+	Format                  string `json:"format,omitempty"`
+	OrgID                   string `json:"org_id,omitempty"`
+	AssertionMethod         string `json:"assertion_method,omitempty"`
+	AssertionMethodCitation string `json:"assertion_method_citation,omitempty"`
+}
+
+// NewExportClinVarSubmissionTool creates a new export_clinvar_submission tool
+func NewExportClinVarSubmissionTool(logger *logrus.Logger, store labkb.Store) *ExportClinVarSubmissionTool {
+	return &ExportClinVarSubmissionTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for export_clinvar_submission
+func (t *ExportClinVarSubmissionTool) GetToolInfo() protocol.ToolInfo {
+	properties := labAssertionFilterProperties()
+	properties["format"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Submission format: ClinVar Submission API JSON, submission XML or rows for the submission spreadsheet",
+		"enum":        []string{labkb.FormatJSON, labkb.FormatXML, labkb.FormatTSV},
+		"default":     labkb.FormatTSV,
+	}
+	properties["org_id"] = map[string]interface{}{
+		"type":        "string",
+		"description": "ClinVar organization ID of the submitting lab",
+	}
+	properties["assertion_method"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Name of the lab's classification method; defaults to " + labkb.DefaultAssertionMethod,
+	}
+	properties["assertion_method_citation"] = map[string]interface{}{
+		"type":        "string",
+		"description": "PubMed ID or URL describing the method; defaults to " + labkb.DefaultAssertionMethodCitation,
+	}
+
+	return protocol.ToolInfo{
+		Name:        "export_clinvar_submission",
+		Description: "Export lab assertions in ClinVar submission format: Submission API JSON, XML, or tab-separated rows for the variant submission spreadsheet.",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ExportClinVarSubmissionTool) ValidateParams(params interface{}) error {
+	if params == nil {
+		return nil // No required parameters
+	}
+	var p ExportClinVarSubmissionParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if err := validateSubmissionFormat(p.Format, labkb.FormatJSON, labkb.FormatXML, labkb.FormatTSV); err != nil {
+		return err
+	}
+	_, err := p.filter()
+	return err
+}
+
+// HandleTool handles the export_clinvar_submission tool request
+func (t *ExportClinVarSubmissionTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ExportClinVarSubmissionParams
+	if req.Params != nil {
+		if err := ParseParams(req.Params, &params); err != nil {
+			return invalidParamsError("Invalid parameters", err.Error())
+		}
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+	filter, _ := params.filter()
+	format := strings.ToLower(strings.TrimSpace(params.Format))
+	if format == "" {
+		format = labkb.FormatTSV
+	}
+
+	assertions, err := t.store.List(ctx, filter)
+	if err != nil {
+		return labStoreError(t.logger, "list lab assertions", err)
+	}
+
+	var content bytes.Buffer
+	options := labkb.SubmissionOptions{
+		OrgID:                   strings.TrimSpace(params.OrgID),
+		AssertionMethod:         strings.TrimSpace(params.AssertionMethod),
+		AssertionMethodCitation: strings.TrimSpace(params.AssertionMethodCitation),
+	}
+	if err := labkb.WriteSubmission(&content, format, assertions, options); err != nil {
+		return labStoreError(t.logger, "export ClinVar submission", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"format":  format,
+			"content": content.String(),
+			"total":   len(assertions),
+		},
+	}
+}
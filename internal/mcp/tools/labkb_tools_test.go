@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+)
+
+func createTestLabStore(t *testing.T) *labkb.SQLiteStore {
+	t.Helper()
+
+	store, err := labkb.NewSQLiteStore(filepath.Join(t.TempDir(), "lab_knowledge.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestLabAssertionTools_SaveGetAndList(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestLabStore(t)
+	save := NewSaveLabAssertionTool(logger, store)
+	get := NewGetLabAssertionTool(logger, store)
+	list := NewListLabAssertionsTool(logger, store)
+
+	// Act
+	saved := save.HandleTool(context.Background(), toolRequest("save_lab_assertion", map[string]interface{}{
+		"normalized_hgvs":   "NM_007294.4:c.5095C>T",
+		"gene_symbol":       "BRCA1",
+		"hgvs_protein":      "p.Arg1699Trp",
+		"classification":    "likely pathogenic",
+		"condition_id":      "MONDO:0011450",
+		"criteria_met":      []string{"PS4_Moderate", "PM2_Supporting"},
+		"affected_probands": 6,
+		"evaluated_at":      "2026-03-02",
+		"curator_id":        "curator-1",
+	}))
+	badDate := save.HandleTool(context.Background(), toolRequest("save_lab_assertion", map[string]interface{}{
+		"normalized_hgvs": "NM_007294.4:c.5096G>A",
+		"classification":  "VUS",
+		"evaluated_at":    "March 2026",
+		"curator_id":      "curator-1",
+	}))
+	noCurator := save.HandleTool(context.Background(), toolRequest("save_lab_assertion", map[string]interface{}{
+		"normalized_hgvs": "NM_007294.4:c.5096G>A",
+		"classification":  "VUS",
+	}))
+	byVariant := get.HandleTool(context.Background(), toolRequest("get_lab_assertion", map[string]interface{}{"normalized_hgvs": "NM_007294.4:c.5095C>T"}))
+	missing := get.HandleTool(context.Background(), toolRequest("get_lab_assertion", map[string]interface{}{"normalized_hgvs": "NM_007294.4:c.1A>G"}))
+	listed := list.HandleTool(context.Background(), toolRequest("list_lab_assertions", map[string]interface{}{"classification": "LP", "evaluated_since": "2026-03-01"}))
+	badFilter := list.HandleTool(context.Background(), toolRequest("list_lab_assertions", map[string]interface{}{"classification": "damaging"}))
+
+	// Assert
+	require.Nil(t, saved.Error)
+	assertion := saved.Result.(map[string]interface{})["assertion"].(*labkb.Assertion)
+	assert.Equal(t, "R1699W", assertion.ProteinChange)
+	assert.Equal(t, 6, assertion.AffectedProbands)
+	require.NotNil(t, badDate.Error)
+	require.NotNil(t, noCurator.Error)
+	require.Nil(t, byVariant.Error)
+	assert.Equal(t, assertion.ID, byVariant.Result.(map[string]interface{})["assertion"].(*labkb.Assertion).ID)
+	require.NotNil(t, missing.Error)
+	require.Nil(t, listed.Error)
+	assert.Equal(t, 1, listed.Result.(map[string]interface{})["total"])
+	require.NotNil(t, badFilter.Error)
+}
+
+func TestExportClinVarSubmissionTool(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestLabStore(t)
+	require.NoError(t, store.Save(context.Background(), &labkb.Assertion{
+		NormalizedHGVS: "NM_007294.4:c.5095C>T",
+		GeneSymbol:     "BRCA1",
+		Classification: "pathogenic",
+		Condition:      "Hereditary breast ovarian cancer syndrome",
+		EvaluatedBy:    "curator-1",
+	}))
+	tool := NewExportClinVarSubmissionTool(logger, store)
+
+	// Act
+	tsv := tool.HandleTool(context.Background(), toolRequest("export_clinvar_submission", map[string]interface{}{"gene_symbol": "BRCA1"}))
+	xml := tool.HandleTool(context.Background(), toolRequest("export_clinvar_submission", map[string]interface{}{"format": "XML", "org_id": "500031"}))
+	unsupported := tool.HandleTool(context.Background(), toolRequest("export_clinvar_submission", map[string]interface{}{"format": "vcf"}))
+
+	// Assert
+	require.Nil(t, tsv.Error)
+	result := tsv.Result.(map[string]interface{})
+	assert.Equal(t, labkb.FormatTSV, result["format"])
+	assert.Equal(t, 1, result["total"])
+	assert.Contains(t, result["content"], "NM_007294.4\tc.5095C>T")
+	require.Nil(t, xml.Error)
+	content := xml.Result.(map[string]interface{})["content"].(string)
+	assert.Contains(t, content, "<OrgID>500031</OrgID>")
+	assert.Contains(t, content, "<Description>Pathogenic</Description>")
+	require.NotNil(t, unsupported.Error)
+}
@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/literature"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// CheckCitedLiteratureTool implements the check_cited_literature MCP tool
+type CheckCitedLiteratureTool struct {
+	logger  *logrus.Logger
+	monitor *literature.Monitor
+	store   literature.Store
+}
+
+// CheckCitedLiteratureParams defines parameters for the check_cited_literature tool
+type CheckCitedLiteratureParams struct {
+	RecordedOnly bool `json:"recorded_only,omitempty"` // List recorded notices without querying PubMed
+}
+
+// NewCheckCitedLiteratureTool creates a new check_cited_literature tool
+func NewCheckCitedLiteratureTool(logger *logrus.Logger, monitor *literature.Monitor, store literature.Store) *CheckCitedLiteratureTool {
+	return &CheckCitedLiteratureTool{
+		logger:  logger,
+		monitor: monitor,
+		store:   store,
+	}
+}
+
+// GetToolInfo returns the tool information for check_cited_literature
+func (t *CheckCitedLiteratureTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "check_cited_literature",
+		Description: "Check the PubMed articles cited by signed-out classifications for retraction and erratum notices. Each affected classification gets a literature_retracted or literature_erratum follow-up flag for review; the check also runs periodically in the background.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"recorded_only": map[string]interface{}{
+					"type":        "boolean",
+					"description": "List the notices already recorded without querying PubMed",
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *CheckCitedLiteratureTool) ValidateParams(params interface{}) error {
+	_, err := t.parseParams(params)
+	return err
+}
+
+// HandleTool handles the check_cited_literature tool request
+func (t *CheckCitedLiteratureTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	params, err := t.parseParams(req.Params)
+	if err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	result := map[string]interface{}{}
+	if !params.RecordedOnly {
+		report, err := t.monitor.Check(ctx)
+		if err != nil {
+			t.logger.WithError(err).Error("Failed to check cited literature")
+			return internalError("Failed to check cited literature", err.Error())
+		}
+		result["report"] = report
+	}
+
+	notices, err := t.store.List(ctx)
+	if err != nil {
+		return internalError("Failed to list literature notices", err.Error())
+	}
+	if notices == nil {
+		notices = []*literature.Notice{}
+	}
+	result["notices"] = notices
+
+	return &protocol.JSONRPC2Response{Result: result}
+}
+
+func (t *CheckCitedLiteratureTool) parseParams(params interface{}) (*CheckCitedLiteratureParams, error) {
+	var p CheckCitedLiteratureParams
+	if params != nil {
+		if err := ParseParams(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	return &p, nil
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/feedback"
+	"github.com/acmg-amp-mcp-server/internal/literature"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+)
+
+// staticNoticeChecker reports the same notices for every check
+type staticNoticeChecker map[string][]domain.CitationNotice
+
+func (c staticNoticeChecker) QueryNotices(ctx context.Context, pmids []string) (map[string][]domain.CitationNotice, error) {
+	return c, nil
+}
+
+func TestCheckCitedLiteratureTool_HandleTool(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	logger, _ := test.NewNullLogger()
+
+	fbStore, err := feedback.NewSQLiteStore(filepath.Join(dir, "feedback.db"))
+	require.NoError(t, err)
+	defer fbStore.Close()
+	archive, err := snapshot.NewFileArchive(filepath.Join(dir, "archive"))
+	require.NoError(t, err)
+	snapStore, err := snapshot.NewSQLiteStore(filepath.Join(dir, "evidence.db"), archive)
+	require.NoError(t, err)
+	defer snapStore.Close()
+	store, err := literature.NewSQLiteStore(filepath.Join(dir, "literature.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.NoError(t, fbStore.Save(ctx, &feedback.Feedback{
+		Variant:                 "TP53:c.743G>A",
+		NormalizedHGVS:          "NM_000546.6:c.743G>A",
+		SuggestedClassification: "Pathogenic",
+		UserClassification:      "Pathogenic",
+		UserAgreed:              true,
+	}))
+	payload, _ := json.Marshal(map[string]interface{}{
+		"database_results": map[string]interface{}{
+			"pubmed": map[string]interface{}{"citations": []map[string]string{{"pmid": "20301425"}}},
+		},
+	})
+	require.NoError(t, snapStore.Save(ctx, &snapshot.Snapshot{NormalizedHGVS: "NM_000546.6:c.743G>A", Payload: payload}))
+
+	checker := staticNoticeChecker{"20301425": {{Kind: domain.NoticeRetraction}}}
+	tool := NewCheckCitedLiteratureTool(logger, literature.NewMonitor(logger, store, checker, fbStore, snapStore), store)
+
+	// Act
+	checked := tool.HandleTool(ctx, &protocol.JSONRPC2Request{JSONRPC: "2.0", Method: "check_cited_literature", ID: 1})
+	recorded := tool.HandleTool(ctx, &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "check_cited_literature",
+		Params:  map[string]interface{}{"recorded_only": true},
+		ID:      2,
+	})
+
+	// Assert
+	require.Nil(t, checked.Error)
+	report := checked.Result.(map[string]interface{})["report"].(*literature.Report)
+	assert.Len(t, report.NewNotices, 1)
+	require.Len(t, report.Flagged, 1)
+	assert.Equal(t, "Pathogenic", report.Flagged[0].Classification)
+
+	require.Nil(t, recorded.Error)
+	result := recorded.Result.(map[string]interface{})
+	assert.NotContains(t, result, "report")
+	assert.Len(t, result["notices"], 1)
+}
@@ -0,0 +1,391 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+// Actions of a manual criterion override
+const (
+	OverrideApply          = "apply"
+	OverrideRemove         = "remove"
+	OverrideChangeStrength = "change_strength"
+)
+
+// CriterionOverride records a curator's manual override of an automated
+// criterion call. The original call is kept from the first override, so a
+// criterion overridden again still shows what the engine decided.
+type CriterionOverride struct {
+	Action            string    `json:"action"` // apply, remove or change_strength
+	Justification     string    `json:"justification"`
+	OverriddenBy      string    `json:"overridden_by"`
+	OverriddenAt      time.Time `json:"overridden_at"`
+	Version           int       `json:"version"`    // 1 for the first override of the criterion
+	Supersedes        int64     `json:"supersedes"` // Audit record the override was made against
+	OriginalApplied   bool      `json:"original_applied"`
+	OriginalStrength  string    `json:"original_strength,omitempty"`
+	OriginalReasoning string    `json:"original_reasoning,omitempty"`
+}
+
+// =============================================================================
+// Override Criterion Tool
+// =============================================================================
+
+// OverrideCriterionTool implements the override_criterion MCP tool
+type OverrideCriterionTool struct {
+	logger     *logrus.Logger
+	audit      audit.Store
+	classifier *service.ClassifierService
+}
+
+// OverrideCriterionParams defines parameters for the override_criterion tool
+type OverrideCriterionParams struct {
+	ClassificationID int64  `json:"classification_id"`
+	Criterion        string `json:"criterion"`
+	Action           string `json:"action"`
+	Strength         string `json:"strength,omitempty"` // Required for change_strength; optional for apply
+	Justification    string `json:"justification"`
+	CuratorID        string `json:"curator_id,omitempty"` // Used when the request is not authenticated
+}
+
+// OverrideCriterionResult is the classification after an override, recorded
+// as a new audit record that supersedes the one overridden
+type OverrideCriterionResult struct {
+	AuditRecordID          int64                  `json:"audit_record_id"`
+	Supersedes             int64                  `json:"supersedes"`
+	Criterion              ACMGAMPRuleResult      `json:"criterion"`
+	PreviousClassification string                 `json:"previous_classification"`
+	ClassificationChanged  bool                   `json:"classification_changed"`
+	Classification         *ClassifyVariantResult `json:"classification"` // For generate_report
+	Summary                string                 `json:"summary"`
+}
+
+// NewOverrideCriterionTool creates a new override_criterion tool
+func NewOverrideCriterionTool(logger *logrus.Logger, store audit.Store, classifier *service.ClassifierService) *OverrideCriterionTool {
+	return &OverrideCriterionTool{
+		logger:     logger,
+		audit:      store,
+		classifier: classifier,
+	}
+}
+
+// GetToolInfo returns the tool information for override_criterion
+func (t *OverrideCriterionTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "override_criterion",
+		Description: "Manually override one automated criterion call of a recorded classification: apply a criterion, remove it, or change its strength, with a required justification. The criteria are recombined in the scoring mode of the original call and the result is appended to the audit trail as a new record superseding the one overridden; earlier records are never modified. Overridden criteria are marked in reports generated from the result.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"classification_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "Audit record ID of the classification to override, e.g. from query_audit_trail or an earlier override",
+				},
+				"criterion": map[string]interface{}{
+					"type":        "string",
+					"description": "ACMG/AMP criterion code, e.g. PS3",
+				},
+				"action": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{OverrideApply, OverrideRemove, OverrideChangeStrength},
+					"description": "apply a criterion that was not met, remove one that was, or change the strength of an applied criterion",
+				},
+				"strength": map[string]interface{}{
+					"type":        "string",
+					"enum":        domain.RuleStrengthEnum(),
+					"description": "Strength to apply the criterion at. Required for change_strength; apply defaults to the criterion's current or guideline strength",
+				},
+				"justification": map[string]interface{}{
+					"type":        "string",
+					"description": "Why the automated call is overridden; recorded in the audit trail and shown in the report",
+				},
+				"curator_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the curator making the override. Ignored when the request is authenticated; the authenticated user is recorded instead",
+				},
+			},
+			"required": []string{"classification_id", "criterion", "action", "justification"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *OverrideCriterionTool) ValidateParams(params interface{}) error {
+	var p OverrideCriterionParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.ClassificationID <= 0 {
+		return fmt.Errorf("classification_id is required")
+	}
+	code := strings.ToUpper(strings.TrimSpace(p.Criterion))
+	if code == "" {
+		return fmt.Errorf("criterion is required")
+	}
+	if _, ok := ACMGAMPRules[code]; !ok {
+		return fmt.Errorf("unknown criterion: %s", p.Criterion)
+	}
+	switch p.Action {
+	case OverrideApply, OverrideRemove:
+	case OverrideChangeStrength:
+		if p.Strength == "" {
+			return fmt.Errorf("strength is required to change the strength of a criterion")
+		}
+	default:
+		return fmt.Errorf("action must be one of %s, %s or %s", OverrideApply, OverrideRemove, OverrideChangeStrength)
+	}
+	if p.Strength != "" {
+		if _, err := domain.ParseRuleStrength(p.Strength); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(p.Justification) == "" {
+		return fmt.Errorf("justification is required")
+	}
+	return nil
+}
+
+// curatorFor returns who made the override: the authenticated user when
+// the request is authenticated, otherwise the curator_id parameter
+func curatorFor(ctx context.Context, params OverrideCriterionParams) string {
+	if principal := auth.PrincipalFrom(ctx); principal != nil {
+		return principal.Subject
+	}
+	return strings.TrimSpace(params.CuratorID)
+}
+
+// HandleTool handles the override_criterion tool request
+func (t *OverrideCriterionTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params OverrideCriterionParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	curator := curatorFor(ctx, params)
+	if curator == "" {
+		return invalidParamsError("Invalid parameters", "curator_id is required when the request is not authenticated")
+	}
+
+	record, err := t.audit.Get(ctx, params.ClassificationID)
+	if err != nil {
+		return auditStoreError(t.logger, "get audit record", err)
+	}
+	if record.Error != "" {
+		return invalidParamsError("Classification failed; there is nothing to override", record.Error)
+	}
+	var request ClassifyVariantParams
+	_ = json.Unmarshal(record.Request, &request)
+	if strings.EqualFold(strings.TrimSpace(request.ClassificationContext), service.ContextSomatic) {
+		return invalidParamsError("Somatic classifications have no ACMG/AMP criteria to override", "classification_context is somatic")
+	}
+	var rules []ACMGAMPRuleResult
+	if len(record.AppliedRules) > 0 {
+		if err := json.Unmarshal(record.AppliedRules, &rules); err != nil {
+			return internalError("Failed to decode recorded criteria", err.Error())
+		}
+	}
+
+	criterion, err := overrideCriterion(rules, params, curator, record.ID)
+	if err != nil {
+		return invalidParamsError("Invalid override", err.Error())
+	}
+	if i := ruleIndex(rules, criterion.RuleCode); i >= 0 {
+		rules[i] = *criterion
+	} else {
+		rules = append(rules, *criterion)
+	}
+
+	mode := service.ScoringModeCombiningRules
+	if record.ScoringMode != "" {
+		if parsed, err := service.ParseScoringMode(record.ScoringMode); err == nil {
+			mode = parsed
+		}
+	}
+	combined, err := t.combine(rules, mode)
+	if err != nil {
+		return internalError("Failed to combine evidence", err.Error())
+	}
+
+	overridden := &audit.Record{
+		VariantID:         record.VariantID,
+		HGVSNotation:      record.HGVSNotation,
+		Evidence:          record.Evidence,
+		Classification:    combined.Classification,
+		Confidence:        combined.Confidence,
+		EngineVersion:     service.EngineVersion,
+		ScoringMode:       string(mode),
+		ThresholdRevision: record.ThresholdRevision,
+		Specification:     record.Specification,
+		ConfigCommit:      record.ConfigCommit,
+		DatasetVersions:   record.DatasetVersions,
+	}
+	overridden.Request, _ = json.Marshal(map[string]interface{}{
+		"supersedes": record.ID,
+		"override":   params,
+		"curator_id": curator,
+	})
+	overridden.AppliedRules, _ = json.Marshal(rules)
+	if err := t.audit.Append(ctx, overridden); err != nil {
+		return auditStoreError(t.logger, "record override", err)
+	}
+
+	result := &OverrideCriterionResult{
+		AuditRecordID:          overridden.ID,
+		Supersedes:             record.ID,
+		Criterion:              *criterion,
+		PreviousClassification: record.Classification,
+		ClassificationChanged:  !sameClassification(record.Classification, combined.Classification),
+		Classification: &ClassifyVariantResult{
+			VariantID:         record.VariantID,
+			Classification:    combined.Classification,
+			Confidence:        combined.Confidence,
+			AppliedRules:      rules,
+			EvidenceSummary:   combined.Summary,
+			Recommendations:   []string{},
+			ScoringMode:       string(mode),
+			ThresholdRevision: record.ThresholdRevision,
+			ConfigCommit:      record.ConfigCommit,
+			DatasetVersions:   record.DatasetVersions,
+			Specification:     record.Specification,
+		},
+	}
+	result.Summary = fmt.Sprintf("%s overridden by %s (%s); classification %s", criterion.RuleCode, curator, params.Action, combined.Classification)
+	if result.ClassificationChanged {
+		result.Summary += fmt.Sprintf(", was %s", record.Classification)
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"classification_id": record.ID,
+		"audit_record_id":   overridden.ID,
+		"criterion":         criterion.RuleCode,
+		"action":            params.Action,
+		"curator":           curator,
+	}).Info("Criterion overridden")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"override": result,
+		},
+	}
+}
+
+// combine recombines the criteria after an override
+func (t *OverrideCriterionTool) combine(rules []ACMGAMPRuleResult, mode service.ScoringMode) (*service.EvidenceCombinationResult, error) {
+	if t.classifier == nil {
+		return nil, fmt.Errorf("classification service not configured")
+	}
+	results := make([]service.RuleResult, len(rules))
+	for i, rule := range rules {
+		results[i] = service.RuleResult{
+			RuleCode:   rule.RuleCode,
+			RuleName:   rule.RuleName,
+			Category:   rule.Category,
+			Strength:   rule.Strength,
+			Applied:    rule.Applied,
+			Confidence: rule.Confidence,
+			Evidence:   rule.Evidence,
+			Reasoning:  rule.Reasoning,
+		}
+	}
+	return t.classifier.CombineEvidenceWithMode(results, mode)
+}
+
+// overrideCriterion applies an override to the recorded call of a criterion
+// and returns the overridden criterion
+func overrideCriterion(rules []ACMGAMPRuleResult, params OverrideCriterionParams, curator string, recordID int64) (*ACMGAMPRuleResult, error) {
+	code := strings.ToUpper(strings.TrimSpace(params.Criterion))
+	var criterion ACMGAMPRuleResult
+	if i := ruleIndex(rules, code); i >= 0 {
+		criterion = rules[i]
+	} else {
+		category, strength := criterionDefaults(code)
+		criterion = ACMGAMPRuleResult{RuleCode: code, Category: category, Strength: strength}
+		if definition, ok := ACMGAMPRules[code]; ok {
+			criterion.RuleName = definition.Name
+		}
+	}
+
+	strength := ""
+	if params.Strength != "" {
+		parsed, _ := domain.ParseRuleStrength(params.Strength)
+		strength = string(parsed)
+	}
+	switch params.Action {
+	case OverrideApply:
+		if criterion.Applied && (strength == "" || strings.EqualFold(strength, criterion.Strength)) {
+			return nil, fmt.Errorf("%s is already applied", code)
+		}
+	case OverrideRemove:
+		if !criterion.Applied {
+			return nil, fmt.Errorf("%s is not applied", code)
+		}
+	case OverrideChangeStrength:
+		if !criterion.Applied {
+			return nil, fmt.Errorf("%s is not applied; apply it at the strength instead", code)
+		}
+		if strings.EqualFold(strength, criterion.Strength) {
+			return nil, fmt.Errorf("%s is already applied at %s", code, strings.ToLower(strength))
+		}
+	}
+
+	override := &CriterionOverride{
+		Action:            params.Action,
+		Justification:     strings.TrimSpace(params.Justification),
+		OverriddenBy:      curator,
+		OverriddenAt:      time.Now().UTC(),
+		Version:           1,
+		Supersedes:        recordID,
+		OriginalApplied:   criterion.Applied,
+		OriginalStrength:  criterion.Strength,
+		OriginalReasoning: criterion.Reasoning,
+	}
+	if previous := criterion.Override; previous != nil {
+		override.Version = previous.Version + 1
+		override.OriginalApplied = previous.OriginalApplied
+		override.OriginalStrength = previous.OriginalStrength
+		override.OriginalReasoning = previous.OriginalReasoning
+	}
+
+	criterion.Applied = params.Action != OverrideRemove
+	if strength != "" {
+		criterion.Strength = strength
+	}
+	criterion.Confidence = 1
+	criterion.Reasoning = fmt.Sprintf("Manual override by %s: %s", curator, override.Justification)
+	criterion.Override = override
+	return &criterion, nil
+}
+
+// ruleIndex returns the position of a criterion in the rules, or -1
+func ruleIndex(rules []ACMGAMPRuleResult, code string) int {
+	for i, rule := range rules {
+		if strings.EqualFold(rule.RuleCode, code) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameClassification reports whether two classifications are the same call,
+// whether given as canonical values or display labels
+func sameClassification(a, b string) bool {
+	parsedA, errA := domain.ParseClassification(a)
+	parsedB, errB := domain.ParseClassification(b)
+	if errA == nil && errB == nil {
+		return parsedA == parsedB
+	}
+	return strings.EqualFold(a, b)
+}
@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+func TestOverrideCriterionTool(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	auditStore := createTestAuditStore(t)
+	tool := NewOverrideCriterionTool(logger, auditStore, service.NewClassifierService(logger, nil, nil, nil))
+
+	rules, _ := json.Marshal([]ACMGAMPRuleResult{
+		{RuleCode: "PVS1", RuleName: "Null variant", Category: "PATHOGENIC", Strength: "VERY_STRONG", Applied: true, Confidence: 0.9,
+			Reasoning: "Frameshift in a gene where LOF is a known mechanism of disease"},
+		{RuleCode: "PM2", RuleName: "Absent from controls", Category: "PATHOGENIC", Strength: "MODERATE", Applied: true, Confidence: 0.8,
+			Reasoning: "Absent from gnomAD"},
+		{RuleCode: "PS3", RuleName: "Functional studies", Category: "PATHOGENIC", Strength: "STRONG", Reasoning: "No functional data"},
+	})
+	record := &audit.Record{
+		VariantID:      "VAR_1",
+		HGVSNotation:   "NM_007294.4:c.5266dup",
+		Request:        json.RawMessage(`{"hgvs_notation":"NM_007294.4:c.5266dup"}`),
+		AppliedRules:   rules,
+		Classification: string(domain.LIKELY_PATHOGENIC),
+		Confidence:     "High",
+		EngineVersion:  service.EngineVersion,
+		ScoringMode:    string(service.ScoringModeCombiningRules),
+	}
+	require.NoError(t, auditStore.Append(ctx, record))
+
+	// Act: an authenticated curator applies PS3; the principal wins over curator_id
+	authenticated := auth.WithPrincipal(ctx, &auth.Principal{Subject: "curator-1", Role: auth.RoleClassify})
+	response := tool.HandleTool(authenticated, toolRequest("override_criterion", map[string]interface{}{
+		"classification_id": record.ID,
+		"criterion":         "ps3",
+		"action":            OverrideApply,
+		"justification":     "Patch clamp shows loss of function",
+		"curator_id":        "someone-else",
+	}))
+
+	// Assert
+	require.Nil(t, response.Error)
+	applied := response.Result.(map[string]interface{})["override"].(*OverrideCriterionResult)
+	assert.Equal(t, record.ID, applied.Supersedes)
+	assert.NotEqual(t, record.ID, applied.AuditRecordID)
+	assert.True(t, applied.ClassificationChanged)
+	assert.Equal(t, string(domain.PATHOGENIC), applied.Classification.Classification)
+	assert.True(t, applied.Criterion.Applied)
+	assert.Equal(t, "STRONG", applied.Criterion.Strength)
+	assert.Equal(t, "Manual override by curator-1: Patch clamp shows loss of function", applied.Criterion.Reasoning)
+	require.NotNil(t, applied.Criterion.Override)
+	assert.Equal(t, "curator-1", applied.Criterion.Override.OverriddenBy)
+	assert.Equal(t, 1, applied.Criterion.Override.Version)
+	assert.False(t, applied.Criterion.Override.OriginalApplied)
+	assert.Equal(t, "No functional data", applied.Criterion.Override.OriginalReasoning)
+
+	// The earlier record is untouched; the override is a new record
+	original, err := auditStore.Get(ctx, record.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(domain.LIKELY_PATHOGENIC), original.Classification)
+	recorded, err := auditStore.Get(ctx, applied.AuditRecordID)
+	require.NoError(t, err)
+	assert.Equal(t, applied.Classification.Classification, recorded.Classification)
+	assert.Contains(t, string(recorded.Request), `"supersedes":`)
+	assert.Contains(t, string(recorded.Request), `"curator_id":"curator-1"`)
+
+	// Overriding the same criterion again bumps the version and keeps the original call
+	response = tool.HandleTool(ctx, toolRequest("override_criterion", map[string]interface{}{
+		"classification_id": applied.AuditRecordID,
+		"criterion":         "PS3",
+		"action":            OverrideChangeStrength,
+		"strength":          "moderate",
+		"justification":     "Assay not yet validated in our lab",
+		"curator_id":        "curator-2",
+	}))
+	require.Nil(t, response.Error)
+	changed := response.Result.(map[string]interface{})["override"].(*OverrideCriterionResult)
+	assert.Equal(t, applied.AuditRecordID, changed.Supersedes)
+	assert.Equal(t, "MODERATE", changed.Criterion.Strength)
+	assert.Equal(t, 2, changed.Criterion.Override.Version)
+	assert.Equal(t, "curator-2", changed.Criterion.Override.OverriddenBy)
+	assert.False(t, changed.Criterion.Override.OriginalApplied)
+	assert.Equal(t, string(domain.PATHOGENIC), changed.PreviousClassification)
+}
+
+func TestOverrideCriterionTool_Rejects(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	auditStore := createTestAuditStore(t)
+	tool := NewOverrideCriterionTool(logger, auditStore, service.NewClassifierService(logger, nil, nil, nil))
+
+	rules, _ := json.Marshal([]ACMGAMPRuleResult{
+		{RuleCode: "PM2", Category: "PATHOGENIC", Strength: "MODERATE", Applied: true},
+	})
+	record := &audit.Record{
+		HGVSNotation:  "NM_007294.4:c.5266dup",
+		Request:       json.RawMessage(`{"hgvs_notation":"NM_007294.4:c.5266dup"}`),
+		AppliedRules:  rules,
+		EngineVersion: service.EngineVersion,
+	}
+	require.NoError(t, auditStore.Append(ctx, record))
+	somatic := &audit.Record{
+		HGVSNotation:  "NM_004333.6:c.1799T>A",
+		Request:       json.RawMessage(`{"hgvs_notation":"NM_004333.6:c.1799T>A","classification_context":"somatic"}`),
+		EngineVersion: service.EngineVersion,
+	}
+	require.NoError(t, auditStore.Append(ctx, somatic))
+
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+	}{
+		{"missing justification", map[string]interface{}{"classification_id": record.ID, "criterion": "PS3", "action": OverrideApply, "curator_id": "c"}},
+		{"missing curator", map[string]interface{}{"classification_id": record.ID, "criterion": "PS3", "action": OverrideApply, "justification": "j"}},
+		{"unknown criterion", map[string]interface{}{"classification_id": record.ID, "criterion": "PX9", "action": OverrideApply, "justification": "j", "curator_id": "c"}},
+		{"unknown action", map[string]interface{}{"classification_id": record.ID, "criterion": "PS3", "action": "flip", "justification": "j", "curator_id": "c"}},
+		{"strength change without strength", map[string]interface{}{"classification_id": record.ID, "criterion": "PM2", "action": OverrideChangeStrength, "justification": "j", "curator_id": "c"}},
+		{"already applied", map[string]interface{}{"classification_id": record.ID, "criterion": "PM2", "action": OverrideApply, "justification": "j", "curator_id": "c"}},
+		{"remove unapplied", map[string]interface{}{"classification_id": record.ID, "criterion": "PS3", "action": OverrideRemove, "justification": "j", "curator_id": "c"}},
+		{"missing record", map[string]interface{}{"classification_id": record.ID + 100, "criterion": "PS3", "action": OverrideApply, "justification": "j", "curator_id": "c"}},
+		{"somatic record", map[string]interface{}{"classification_id": somatic.ID, "criterion": "PS3", "action": OverrideApply, "justification": "j", "curator_id": "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := tool.HandleTool(ctx, toolRequest("override_criterion", tt.params))
+			require.NotNil(t, response.Error)
+		})
+	}
+}
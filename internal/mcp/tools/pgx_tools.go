@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/pgx"
+)
+
+// AnnotatePGxTool implements the annotate_pgx MCP tool
+type AnnotatePGxTool struct {
+	logger    *logrus.Logger
+	annotator *pgx.Annotator
+}
+
+// AnnotatePGxParams defines parameters for the annotate_pgx tool
+type AnnotatePGxParams struct {
+	Variants []pgx.Observation `json:"variants,omitempty"`
+	Genes    []string          `json:"genes,omitempty"`
+	Drug     string            `json:"drug,omitempty"`
+}
+
+// NewAnnotatePGxTool creates a new annotate_pgx tool
+func NewAnnotatePGxTool(logger *logrus.Logger, annotator *pgx.Annotator) *AnnotatePGxTool {
+	return &AnnotatePGxTool{
+		logger:    logger,
+		annotator: annotator,
+	}
+}
+
+// GetToolInfo returns the tool information for annotate_pgx
+func (t *AnnotatePGxTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "annotate_pgx",
+		Description: "Map observed variants to PharmVar star alleles and call an unphased diplotype per pharmacogene, translate it to a CPIC phenotype and list the CPIC dosing recommendations, optionally for one drug. Genes requested without observed variants are reported as reference.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"variants": map[string]interface{}{
+					"type":        "array",
+					"description": "Variants observed in the patient, as genomic HGVS on GRCh38",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"hgvs":     map[string]interface{}{"type": "string", "description": "Genomic HGVS, e.g. NC_000010.11:g.94781859G>A"},
+							"zygosity": map[string]interface{}{"type": "string", "enum": []string{pgx.Heterozygous, pgx.Homozygous}, "description": "Default heterozygous"},
+						},
+						"required": []string{"hgvs"},
+					},
+				},
+				"genes": map[string]interface{}{
+					"type":        "array",
+					"description": "Pharmacogenes to report even without observed variants, e.g. CYP2C19",
+					"items":       map[string]interface{}{"type": "string"},
+				},
+				"drug": map[string]interface{}{
+					"type":        "string",
+					"description": "Limit recommendations to one drug, e.g. clopidogrel",
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *AnnotatePGxTool) ValidateParams(params interface{}) error {
+	var p AnnotatePGxParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if len(p.Variants) == 0 && len(p.Genes) == 0 {
+		return fmt.Errorf("variants or genes are required")
+	}
+	for _, v := range p.Variants {
+		if strings.TrimSpace(v.HGVS) == "" {
+			return fmt.Errorf("variant hgvs is required")
+		}
+	}
+	return nil
+}
+
+// HandleTool handles the annotate_pgx tool request
+func (t *AnnotatePGxTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params AnnotatePGxParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	result, err := t.annotator.Annotate(params.Variants, params.Genes, params.Drug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrInvalidRequest) {
+			return invalidParamsError(err.Error())
+		}
+		t.logger.WithError(err).Error("Failed to annotate pharmacogenomic variants")
+		return internalError("Failed to annotate pharmacogenomic variants", err.Error())
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"pgx_annotation": result,
+		},
+	}
+}
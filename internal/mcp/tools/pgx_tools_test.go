@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/pgx"
+)
+
+func createTestPGxTool(t *testing.T) *AnnotatePGxTool {
+	t.Helper()
+	logger, _ := test.NewNullLogger()
+	knowledge, err := pgx.LoadDir(filepath.Join("..", "..", "..", "examples", "pgx"))
+	require.NoError(t, err)
+	return NewAnnotatePGxTool(logger, pgx.NewAnnotator(knowledge))
+}
+
+func TestAnnotatePGxTool_HandleTool(t *testing.T) {
+	tool := createTestPGxTool(t)
+
+	// Act
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "annotate_pgx",
+		Params: map[string]interface{}{
+			"variants": []interface{}{map[string]interface{}{"hgvs": "NC_000010.11:g.94781859G>A", "zygosity": "homozygous"}},
+			"drug":     "clopidogrel",
+		},
+		ID: 1,
+	})
+
+	// Assert
+	require.Nil(t, response.Error)
+	result := response.Result.(map[string]interface{})["pgx_annotation"].(*pgx.Result)
+	require.Len(t, result.Genes, 1)
+	assert.Equal(t, "CYP2C19*2/*2", result.Genes[0].Diplotype)
+	assert.Equal(t, "Poor Metabolizer", result.Genes[0].Phenotype)
+	require.Len(t, result.Genes[0].Recommendations, 1)
+	assert.Equal(t, "Strong", result.Genes[0].Recommendations[0].Strength)
+}
+
+func TestAnnotatePGxTool_HandleTool_InvalidParams(t *testing.T) {
+	tool := createTestPGxTool(t)
+
+	for _, params := range []map[string]interface{}{
+		{},
+		{"variants": []interface{}{map[string]interface{}{"zygosity": "homozygous"}}},
+		{"variants": []interface{}{map[string]interface{}{"hgvs": "NC_000010.11:g.94781859G>A", "zygosity": "triploid"}}},
+		{"genes": []interface{}{"NOTAGENE"}},
+	} {
+		response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+			JSONRPC: "2.0",
+			Method:  "annotate_pgx",
+			Params:  params,
+			ID:      1,
+		})
+
+		require.NotNil(t, response.Error, "params %v", params)
+		assert.Equal(t, protocol.InvalidParams, response.Error.Code)
+	}
+}
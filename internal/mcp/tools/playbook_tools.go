@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/playbook"
+)
+
+// UpdateGenePlaybookTool implements the update_gene_playbook MCP tool
+type UpdateGenePlaybookTool struct {
+	logger       *logrus.Logger
+	store        playbook.Store
+	authRequired bool
+}
+
+// UpdateGenePlaybookParams defines parameters for the update_gene_playbook tool.
+// Provided sections replace the existing ones; a lab note is appended.
+type UpdateGenePlaybookParams struct {
+	GeneSymbol      string                   `json:"gene_symbol"`
+	CuratorID       string                   `json:"curator_id,omitempty"` // Used when authentication is not required
+	VCEPHighlights  []string                 `json:"vcep_highlights,omitempty"`
+	CommonPitfalls  []string                 `json:"common_pitfalls,omitempty"`
+	FrequentBenigns []playbook.BenignVariant `json:"frequent_benigns,omitempty"`
+	LabNote         string                   `json:"lab_note,omitempty"`
+}
+
+// NewUpdateGenePlaybookTool creates a new update_gene_playbook tool
+func NewUpdateGenePlaybookTool(logger *logrus.Logger, store playbook.Store) *UpdateGenePlaybookTool {
+	return &UpdateGenePlaybookTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// SetAuthenticationRequired refuses edits without an authenticated principal
+// instead of trusting curator_id
+func (t *UpdateGenePlaybookTool) SetAuthenticationRequired(required bool) {
+	t.authRequired = required
+}
+
+// GetToolInfo returns the tool information for update_gene_playbook
+func (t *UpdateGenePlaybookTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "update_gene_playbook",
+		Description: "Create or update the curated interpretation playbook for a gene. Restricted to senior curators. Playbooks are served at /genes/{symbol}/playbook and attached to classification results.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gene_symbol": map[string]interface{}{
+					"type":        "string",
+					"description": "HGNC gene symbol",
+				},
+				"curator_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the senior curator making the edit; authenticated requests are attributed to the authenticated user",
+				},
+				"vcep_highlights": map[string]interface{}{
+					"type":        "array",
+					"description": "Key points from the VCEP specification (replaces existing)",
+					"items":       map[string]interface{}{"type": "string"},
+				},
+				"common_pitfalls": map[string]interface{}{
+					"type":        "array",
+					"description": "Common interpretation pitfalls (replaces existing)",
+					"items":       map[string]interface{}{"type": "string"},
+				},
+				"frequent_benigns": map[string]interface{}{
+					"type":        "array",
+					"description": "Frequently seen benign variants (replaces existing)",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"hgvs": map[string]interface{}{"type": "string"},
+							"note": map[string]interface{}{"type": "string"},
+						},
+						"required": []string{"hgvs"},
+					},
+				},
+				"lab_note": map[string]interface{}{
+					"type":        "string",
+					"description": "Lab-specific note to append",
+				},
+			},
+			"required": []string{"gene_symbol"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *UpdateGenePlaybookTool) ValidateParams(params interface{}) error {
+	var p UpdateGenePlaybookParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.GeneSymbol) == "" {
+		return fmt.Errorf("gene_symbol is required")
+	}
+	return nil
+}
+
+// HandleTool handles the update_gene_playbook tool request
+func (t *UpdateGenePlaybookTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params UpdateGenePlaybookParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+	curator, err := actingUser(ctx, params.CuratorID, t.authRequired)
+	if err != nil {
+		return unauthenticatedError(err)
+	}
+	if curator == "" {
+		return invalidParamsError("curator_id is required when the request is not authenticated")
+	}
+
+	pb, err := t.store.Get(ctx, params.GeneSymbol)
+	if err != nil {
+		return internalError("Failed to load playbook", err.Error())
+	}
+	if pb == nil {
+		pb = &playbook.Playbook{GeneSymbol: params.GeneSymbol}
+	}
+
+	if params.VCEPHighlights != nil {
+		pb.VCEPHighlights = params.VCEPHighlights
+	}
+	if params.CommonPitfalls != nil {
+		pb.CommonPitfalls = params.CommonPitfalls
+	}
+	if params.FrequentBenigns != nil {
+		pb.FrequentBenigns = params.FrequentBenigns
+	}
+	if params.LabNote != "" {
+		pb.LabNotes = append(pb.LabNotes, playbook.LabNote{Author: curator, Note: params.LabNote})
+	}
+
+	if err := t.store.Save(ctx, pb, curator); err != nil {
+		if errors.Is(err, playbook.ErrNotAuthorized) {
+			return &protocol.JSONRPC2Response{
+				Error: &protocol.RPCError{
+					Code:    protocol.MCPUnauthorized,
+					Message: "Not authorized",
+					Data:    err.Error(),
+				},
+			}
+		}
+		t.logger.WithError(err).Error("Failed to save playbook")
+		return internalError("Failed to save playbook", err.Error())
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"gene":    pb.GeneSymbol,
+		"curator": curator,
+		"version": pb.Version,
+	}).Info("Gene playbook updated")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"playbook": pb,
+		},
+	}
+}
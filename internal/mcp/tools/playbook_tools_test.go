@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/playbook"
+)
+
+func createTestPlaybookStore(t *testing.T) *playbook.SQLiteStore {
+	t.Helper()
+	store, err := playbook.NewSQLiteStore(filepath.Join(t.TempDir(), "playbooks.db"), []string{"senior1"})
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestUpdateGenePlaybookTool_HandleTool_Success(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestPlaybookStore(t)
+	tool := NewUpdateGenePlaybookTool(logger, store)
+
+	req := &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "update_gene_playbook",
+		Params: map[string]interface{}{
+			"gene_symbol":     "tp53",
+			"curator_id":      "senior1",
+			"common_pitfalls": []string{"Somatic hotspots are not germline evidence"},
+			"lab_note":        "Check for clonal hematopoiesis in low VAF calls",
+		},
+		ID: 1,
+	}
+
+	// Act
+	response := tool.HandleTool(context.Background(), req)
+
+	// Assert
+	require.Nil(t, response.Error)
+	pb := response.Result.(map[string]interface{})["playbook"].(*playbook.Playbook)
+	assert.Equal(t, "TP53", pb.GeneSymbol)
+	assert.Equal(t, 1, pb.Version)
+	require.Len(t, pb.LabNotes, 1)
+	assert.Equal(t, "senior1", pb.LabNotes[0].Author)
+
+	stored, err := store.Get(context.Background(), "TP53")
+	require.NoError(t, err)
+	assert.Equal(t, pb.CommonPitfalls, stored.CommonPitfalls)
+}
+
+func TestUpdateGenePlaybookTool_HandleTool_Unauthorized(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewUpdateGenePlaybookTool(logger, createTestPlaybookStore(t))
+
+	req := &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "update_gene_playbook",
+		Params: map[string]interface{}{
+			"gene_symbol": "TP53",
+			"curator_id":  "junior",
+			"lab_note":    "Unauthorized edit",
+		},
+		ID: 1,
+	}
+
+	response := tool.HandleTool(context.Background(), req)
+
+	require.NotNil(t, response.Error)
+	assert.Equal(t, protocol.MCPUnauthorized, response.Error.Code)
+}
+
+func TestClassifyVariantTool_LookupPlaybook(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestPlaybookStore(t)
+	require.NoError(t, store.Save(context.Background(), &playbook.Playbook{
+		GeneSymbol:     "BRCA1",
+		VCEPHighlights: []string{"Use ENIGMA BRCA1 specifications"},
+	}, "senior1"))
+
+	tool := NewClassifyVariantToolLegacy(logger, nil)
+	tool.SetPlaybookStore(store)
+
+	pb := tool.lookupPlaybook(context.Background(), "BRCA1")
+	require.NotNil(t, pb)
+	assert.Equal(t, "BRCA1", pb.GeneSymbol)
+
+	assert.Nil(t, tool.lookupPlaybook(context.Background(), "CFTR"))
+}
+
+func TestUpdateGenePlaybookTool_HandleTool_AuthenticatedCurator(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewUpdateGenePlaybookTool(logger, createTestPlaybookStore(t))
+	tool.SetAuthenticationRequired(true)
+	request := func(curatorID string) *protocol.JSONRPC2Request {
+		return &protocol.JSONRPC2Request{
+			JSONRPC: "2.0",
+			Method:  "update_gene_playbook",
+			Params: map[string]interface{}{
+				"gene_symbol": "TP53",
+				"curator_id":  curatorID,
+				"lab_note":    "Check for clonal hematopoiesis in low VAF calls",
+			},
+			ID: 1,
+		}
+	}
+
+	// Act: a junior curator names a senior one
+	junior := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "junior", Role: auth.RoleAdmin})
+	spoofed := tool.HandleTool(junior, request("senior1"))
+	unauthenticated := tool.HandleTool(context.Background(), request("senior1"))
+	senior := tool.HandleTool(auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "senior1", Role: auth.RoleAdmin}), request(""))
+
+	// Assert
+	require.NotNil(t, spoofed.Error)
+	assert.Equal(t, protocol.MCPUnauthorized, spoofed.Error.Code)
+	require.NotNil(t, unauthenticated.Error)
+	assert.Equal(t, protocol.MCPUnauthorized, unauthenticated.Error.Code)
+	require.Nil(t, senior.Error)
+	pb := senior.Result.(map[string]interface{})["playbook"].(*playbook.Playbook)
+	assert.Equal(t, "senior1", pb.LabNotes[0].Author)
+}
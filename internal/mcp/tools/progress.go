@@ -0,0 +1,22 @@
+package tools
+
+import "context"
+
+// ProgressFunc receives progress updates from a long-running tool.
+// Progress increases with every call; total is zero when unknown.
+type ProgressFunc func(progress, total float64, message string)
+
+type progressKey struct{}
+
+// WithProgress attaches a progress callback to a tool call context.
+// Transports set it when the client supplied a progress token.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// reportProgress forwards a progress update to the caller, if it asked for one
+func reportProgress(ctx context.Context, progress, total float64, message string) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		fn(progress, total, message)
+	}
+}
@@ -6,19 +6,63 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/acmg-amp-mcp-server/internal/artifact"
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/benign"
+	"github.com/acmg-amp-mcp-server/internal/cohort"
+	"github.com/acmg-amp-mcp-server/internal/followup"
+	"github.com/acmg-amp-mcp-server/internal/i18n"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/playbook"
 	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/shutdown"
+	"github.com/acmg-amp-mcp-server/internal/review"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+	"github.com/acmg-amp-mcp-server/internal/variantid"
+	"github.com/acmg-amp-mcp-server/internal/webhook"
+	"github.com/acmg-amp-mcp-server/internal/wizard"
 )
 
 // Tool is an alias for protocol.ToolHandler for use within the tools package.
 type Tool = protocol.ToolHandler
 
+// inProcessKey marks calls made in-process rather than over a transport
+type inProcessKey struct{}
+
+// WithInProcess marks ctx as an in-process call. Its results are consumed
+// directly rather than sent over a transport, so they are not summarized.
+func WithInProcess(ctx context.Context) context.Context {
+	return context.WithValue(ctx, inProcessKey{}, true)
+}
+
 // ToolRegistry manages registration of all MCP tools
 type ToolRegistry struct {
 	logger            *logrus.Logger
 	router            *protocol.MessageRouter
 	classifierService *service.ClassifierService
 	inputParser       *service.InputParserService
+	responseLimiter   *ResponseLimiter
+	snapshotStore     snapshot.Store
+	evidenceBundles   bool
+	playbookStore     playbook.Store
+	followUpStore     followup.Store
+	cohortStore       cohort.Store
+	artifactCriteria  cohort.ArtifactCriteria
+	artifactStore     artifact.Store
+	auditStore        audit.Store
+	knownBenignStore  benign.Store
+	identifiers       *variantid.Resolver
+	webhooks          webhook.Publisher
+	sessionStore      wizard.Store
+	reviewStore       review.Store
+	reviewRequired    bool
+	authRequired      bool
+	reportExportDir   string
+	batchLimit        int
+	batchWorkers      int
+	shutdown          *shutdown.Coordinator
+	locale            i18n.Locale
 }
 
 // NewToolRegistry creates a new tool registry
@@ -37,10 +81,102 @@ func (tr *ToolRegistry) RegisterAllTools() error {
 
 	// Register classification tools
 	classifyTool := NewClassifyVariantTool(tr.logger, tr.classifierService, tr.inputParser)
+	if tr.playbookStore != nil {
+		classifyTool.SetPlaybookStore(tr.playbookStore)
+	}
+	if tr.followUpStore != nil {
+		classifyTool.SetFollowUpStore(tr.followUpStore)
+	}
+	if tr.cohortStore != nil {
+		classifyTool.SetCohortStore(tr.cohortStore, tr.artifactCriteria)
+	}
+	if tr.artifactStore != nil {
+		classifyTool.SetArtifactStore(tr.artifactStore)
+	}
+	if tr.auditStore != nil {
+		classifyTool.SetAuditStore(tr.auditStore)
+	}
+	if tr.knownBenignStore != nil {
+		classifyTool.SetKnownBenignStore(tr.knownBenignStore)
+	}
+	if tr.identifiers != nil {
+		classifyTool.SetIdentifierResolver(tr.identifiers)
+	}
+	if tr.webhooks != nil {
+		classifyTool.SetWebhookPublisher(tr.webhooks)
+	}
+	if tr.snapshotStore != nil && tr.evidenceBundles {
+		classifyTool.SetClassificationSnapshotStore(tr.snapshotStore)
+	}
 	tr.router.RegisterToolHandler("classify_variant", classifyTool)
 	tr.logger.Debug("Registered classify_variant tool")
 
+	batchClassifyTool := NewClassifyVariantsBatchTool(tr.logger, classifyTool, tr.batchLimit, tr.batchWorkers)
+	if tr.webhooks != nil {
+		batchClassifyTool.SetWebhookPublisher(tr.webhooks)
+	}
+	tr.router.RegisterToolHandler("classify_variants_batch", batchClassifyTool)
+	tr.logger.Debug("Registered classify_variants_batch tool")
+
+	explainTool := NewExplainClassificationTool(tr.logger, classifyTool, tr.auditStore)
+	tr.router.RegisterToolHandler("explain_classification", explainTool)
+	tr.logger.Debug("Registered explain_classification tool")
+
+	replayTool := NewReplayClassificationTool(tr.logger, tr.classifierService, tr.snapshotStore)
+	tr.router.RegisterToolHandler("replay_classification", replayTool)
+	tr.logger.Debug("Registered replay_classification tool")
+
+	compareVersionsTool := NewCompareRuleVersionsTool(tr.logger, classifyTool)
+	tr.router.RegisterToolHandler("compare_rule_versions", compareVersionsTool)
+	tr.logger.Debug("Registered compare_rule_versions tool")
+
+	trioTool := NewClassifyTrioTool(tr.logger, classifyTool)
+	tr.router.RegisterToolHandler("classify_trio", trioTool)
+	tr.logger.Debug("Registered classify_trio tool")
+
+	// Register guided classification session tools
+	if tr.sessionStore != nil {
+		startSessionTool := NewStartClassificationSessionTool(tr.logger, tr.sessionStore, classifyTool, tr.auditStore)
+		tr.router.RegisterToolHandler("start_classification_session", startSessionTool)
+		tr.logger.Debug("Registered start_classification_session tool")
+
+		provideEvidenceTool := NewProvideEvidenceTool(tr.logger, tr.sessionStore, tr.classifierService)
+		tr.router.RegisterToolHandler("provide_evidence", provideEvidenceTool)
+		tr.logger.Debug("Registered provide_evidence tool")
+
+		finalizeTool := NewFinalizeClassificationTool(tr.logger, tr.sessionStore, tr.classifierService, tr.auditStore)
+		tr.router.RegisterToolHandler("finalize_classification", finalizeTool)
+		tr.logger.Debug("Registered finalize_classification tool")
+	}
+
+	// Register manual criterion overrides, recorded in the audit trail
+	if tr.auditStore != nil {
+		overrideTool := NewOverrideCriterionTool(tr.logger, tr.auditStore, tr.classifierService)
+		tr.router.RegisterToolHandler("override_criterion", overrideTool)
+		tr.logger.Debug("Registered override_criterion tool")
+	}
+
+	// Register dual-review sign-out tools
+	if tr.reviewStore != nil && tr.auditStore != nil {
+		submitReviewTool := NewSubmitForReviewTool(tr.logger, tr.reviewStore, tr.auditStore)
+		submitReviewTool.SetAuthenticationRequired(tr.authRequired)
+		tr.router.RegisterToolHandler("submit_for_review", submitReviewTool)
+		tr.logger.Debug("Registered submit_for_review tool")
+
+		reviewTool := NewReviewClassificationTool(tr.logger, tr.reviewStore)
+		reviewTool.SetAuthenticationRequired(tr.authRequired)
+		tr.router.RegisterToolHandler("review_classification", reviewTool)
+		tr.logger.Debug("Registered review_classification tool")
+
+		listReviewsTool := NewListReviewsTool(tr.logger, tr.reviewStore)
+		tr.router.RegisterToolHandler("list_reviews", listReviewsTool)
+		tr.logger.Debug("Registered list_reviews tool")
+	}
+
 	validateTool := NewValidateHGVSTool(tr.logger, tr.classifierService)
+	if tr.identifiers != nil {
+		validateTool.SetIdentifierResolver(tr.identifiers)
+	}
 	tr.router.RegisterToolHandler("validate_hgvs", validateTool)
 	tr.logger.Debug("Registered validate_hgvs tool")
 
@@ -54,6 +190,15 @@ func (tr *ToolRegistry) RegisterAllTools() error {
 
 	// Register evidence gathering tools
 	queryEvidenceTool := NewQueryEvidenceTool(tr.logger)
+	if tr.snapshotStore != nil {
+		queryEvidenceTool.SetSnapshotStore(tr.snapshotStore)
+	}
+	if tr.classifierService != nil {
+		queryEvidenceTool.SetFrequencyThresholdSource(tr.classifierService)
+	}
+	if tr.webhooks != nil {
+		queryEvidenceTool.SetWebhookPublisher(tr.webhooks)
+	}
 	tr.router.RegisterToolHandler("query_evidence", queryEvidenceTool)
 	tr.logger.Debug("Registered query_evidence tool")
 
@@ -76,6 +221,10 @@ func (tr *ToolRegistry) RegisterAllTools() error {
 
 	// Register report generation tools
 	generateReportTool := NewGenerateReportTool(tr.logger)
+	generateReportTool.SetExportDir(tr.reportExportDir)
+	if tr.reviewStore != nil && tr.reviewRequired {
+		generateReportTool.SetReviewStore(tr.reviewStore, tr.auditStore)
+	}
 	tr.router.RegisterToolHandler("generate_report", generateReportTool)
 	tr.logger.Debug("Registered generate_report tool")
 
@@ -102,6 +251,144 @@ func (tr *ToolRegistry) RegisterTool(tool Tool) error {
 	return nil
 }
 
+// SetSnapshotStore sets the store used to record evidence snapshots.
+// It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetSnapshotStore(store snapshot.Store) {
+	tr.snapshotStore = store
+}
+
+// SetEvidenceBundles enables storing the evidence bundle of each classification
+// in the snapshot store, for replay_classification.
+// It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetEvidenceBundles(enabled bool) {
+	tr.evidenceBundles = enabled
+}
+
+// SetPlaybookStore sets the store used to attach gene playbooks to classifications.
+// It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetPlaybookStore(store playbook.Store) {
+	tr.playbookStore = store
+}
+
+// SetFollowUpStore sets the store used to surface follow-up flags on classifications.
+// It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetFollowUpStore(store followup.Store) {
+	tr.followUpStore = store
+}
+
+// SetCohortStore sets the store used to record in-house cohort observations
+// and the criteria for flagging recurrent artifacts. It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetCohortStore(store cohort.Store, criteria cohort.ArtifactCriteria) {
+	tr.cohortStore = store
+	tr.artifactCriteria = criteria
+}
+
+// SetArtifactStore sets the artifact blacklist used to annotate probable artifacts.
+// It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetArtifactStore(store artifact.Store) {
+	tr.artifactStore = store
+}
+
+// SetAuditStore sets the store that records every classification in the audit trail.
+// It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetAuditStore(store audit.Store) {
+	tr.auditStore = store
+}
+
+// SetKnownBenignStore sets the curated known benign list that batch classification
+// short-circuits. It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetKnownBenignStore(store benign.Store) {
+	tr.knownBenignStore = store
+}
+
+// SetIdentifierResolver sets the resolver that lets classify_variant and
+// validate_hgvs accept rsIDs and ClinVar accessions. It must be called before
+// RegisterAllTools.
+func (tr *ToolRegistry) SetIdentifierResolver(resolver *variantid.Resolver) {
+	tr.identifiers = resolver
+}
+
+// SetWebhookPublisher sets the publisher that notifies registered webhooks of
+// finished batches, reclassifications and evidence updates. It must be called
+// before RegisterAllTools.
+func (tr *ToolRegistry) SetWebhookPublisher(publisher webhook.Publisher) {
+	tr.webhooks = publisher
+}
+
+// SetClassificationSessionStore sets the store of guided classification
+// sessions and enables the session tools. It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetClassificationSessionStore(store wizard.Store) {
+	tr.sessionStore = store
+}
+
+// SetReviewStore sets the store of classification reviews and enables the
+// dual-review tools. When required, generate_report only reports
+// classifications approved in review. It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetReviewStore(store review.Store, required bool) {
+	tr.reviewStore = store
+	tr.reviewRequired = required
+}
+
+// SetAuthenticationRequired makes the tools that act on behalf of a user
+// refuse calls without an authenticated principal instead of trusting the
+// user ID in their parameters. It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetAuthenticationRequired(required bool) {
+	tr.authRequired = required
+}
+
+// SetReportExportDir sets the directory generate_report saves rendered report
+// documents to. It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetReportExportDir(dir string) {
+	tr.reportExportDir = dir
+}
+
+// SetBatchClassificationLimits sets the maximum batch size and worker count for
+// classify_variants_batch. It must be called before RegisterAllTools.
+func (tr *ToolRegistry) SetBatchClassificationLimits(maxBatchSize, workers int) {
+	tr.batchLimit = maxBatchSize
+	tr.batchWorkers = workers
+}
+
+// SetResponseLimiter sets the limiter applied to tool results before they are returned
+func (tr *ToolRegistry) SetResponseLimiter(limiter *ResponseLimiter) {
+	tr.responseLimiter = limiter
+}
+
+// SetShutdownCoordinator tracks transport calls so a shutdown can drain them.
+// In-process calls are not tracked; their callers stop on their own.
+func (tr *ToolRegistry) SetShutdownCoordinator(coordinator *shutdown.Coordinator) {
+	tr.shutdown = coordinator
+}
+
+// SetLocale sets the locale of reports and error messages for requests that
+// do not give one
+func (tr *ToolRegistry) SetLocale(locale i18n.Locale) {
+	tr.locale = locale
+}
+
+// requestLocale returns the locale named by a request's locale parameter, or
+// else the configured locale
+func (tr *ToolRegistry) requestLocale(req *protocol.JSONRPC2Request) i18n.Locale {
+	if params, ok := req.Params.(map[string]interface{}); ok {
+		if tag, ok := params["locale"].(string); ok && tag != "" {
+			if locale, err := i18n.ParseLocale(tag); err == nil {
+				return locale
+			}
+		}
+	}
+	if tr.locale != "" {
+		return tr.locale
+	}
+	return i18n.English
+}
+
+// SetActiveTransport sets the transport type used to select the response size limit
+func (tr *ToolRegistry) SetActiveTransport(transportType string) {
+	if tr.responseLimiter != nil {
+		tr.responseLimiter.SetTransport(transportType)
+	}
+}
+
 // GetRegisteredToolsInfo returns information about all registered tools
 func (tr *ToolRegistry) GetRegisteredToolsInfo() []protocol.ToolInfo {
 	toolHandlers := tr.router.GetToolHandlers()
@@ -147,21 +434,122 @@ func (tr *ToolRegistry) ValidateAllTools() error {
 
 // ExecuteTool executes a tool by name using the registered handler
 func (tr *ToolRegistry) ExecuteTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
-	tr.logger.WithField("tool", req.Method).Debug("Executing tool")
+	ctx, correlationID := protocol.EnsureCorrelationID(ctx)
+	locale := tr.requestLocale(req)
+	ctx = i18n.WithLocale(ctx, locale)
+	tr.logger.WithFields(logrus.Fields{
+		"tool":           req.Method,
+		"correlation_id": correlationID,
+	}).Debug("Executing tool")
 	
 	// Get the tool handler from the router
 	handler, exists := tr.router.GetToolHandler(req.Method)
 	if !exists {
-		return &protocol.JSONRPC2Response{
+		return tr.envelopeError(&protocol.JSONRPC2Response{
 			JSONRPC: "2.0",
 			ID:      req.ID,
 			Error: &protocol.RPCError{
 				Code:    protocol.MethodNotFound,
 				Message: fmt.Sprintf("Tool '%s' not found", req.Method),
 			},
-		}
+		}, req.Method, correlationID, locale)
 	}
 	
+	// HTTP clients carry a principal; stdio clients are local and trusted
+	if principal := auth.PrincipalFrom(ctx); principal != nil {
+		if err := auth.Authorize(principal, RequiredRole(req.Method)); err != nil {
+			return tr.envelopeError(&protocol.JSONRPC2Response{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error: &protocol.RPCError{
+					Code:    protocol.MCPForbidden,
+					Message: fmt.Sprintf("Tool '%s' requires the %s role", req.Method, RequiredRole(req.Method)),
+					Data:    err.Error(),
+				},
+			}, req.Method, correlationID, locale)
+		}
+	}
+
+	// Refuse new calls once shutdown begins; calls in flight are drained
+	inProcess, _ := ctx.Value(inProcessKey{}).(bool)
+	if tr.shutdown != nil && !inProcess {
+		var done func()
+		var err error
+		if ctx, done, err = tr.shutdown.Begin(ctx); err != nil {
+			return tr.envelopeError(&protocol.JSONRPC2Response{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error: &protocol.RPCError{
+					Code:    protocol.MCPResourceError,
+					Message: "Server is shutting down; retry on another instance or after restart",
+					Data:    err.Error(),
+				},
+			}, req.Method, correlationID, locale)
+		}
+		defer done()
+	}
+
 	// Execute the tool using its handler
-	return handler.HandleTool(ctx, req)
-}
\ No newline at end of file
+	response := handler.HandleTool(ctx, req)
+
+	// A cancelled request's results may be partial, so they are discarded
+	if err := ctx.Err(); err != nil {
+		message := "Tool call cancelled by the client"
+		if tr.shutdown != nil && tr.shutdown.Draining() {
+			message = "Tool call aborted at the shutdown deadline"
+		}
+		tr.logger.WithFields(logrus.Fields{
+			"tool":           req.Method,
+			"correlation_id": correlationID,
+		}).Info(message)
+		cancelled := &protocol.RPCError{
+			Code:    protocol.RequestCancelled,
+			Message: fmt.Sprintf("Tool '%s' was cancelled", req.Method),
+			Data:    protocol.NewErrorEnvelope(protocol.ErrorCodeCancelled, err.Error(), "tool:"+req.Method, correlationID, nil),
+		}
+		localizeError(cancelled, protocol.ErrorCodeCancelled, locale)
+		return &protocol.JSONRPC2Response{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   cancelled,
+		}
+	}
+
+	if response != nil && response.Error != nil {
+		return tr.envelopeError(response, req.Method, correlationID, locale)
+	}
+
+	// Summarize results that exceed the active transport's size limit
+	if tr.responseLimiter != nil && response != nil && response.Error == nil && !inProcess {
+		response.Result = tr.responseLimiter.Apply(req.Method, response.Result)
+	}
+
+	return response
+}
+
+// envelopeError wraps a tool failure in the standard error envelope and logs
+// it with the correlation ID returned to the client
+func (tr *ToolRegistry) envelopeError(response *protocol.JSONRPC2Response, toolName, correlationID string, locale i18n.Locale) *protocol.JSONRPC2Response {
+	envelope := response.Error.Envelope("tool:"+toolName, correlationID)
+	tr.logger.WithFields(logrus.Fields{
+		"tool":           toolName,
+		"correlation_id": envelope.CorrelationID,
+		"code":           envelope.Code,
+		"retryable":      envelope.Retryable,
+	}).Warn("Tool execution failed: " + envelope.Message)
+	localizeError(response.Error, envelope.Code, locale)
+	return response
+}
+
+// localizeError replaces the message of an error with the locale's message
+// for its envelope code. The envelope keeps the English message, so logs and
+// support requests read the same in every locale.
+func localizeError(rpcError *protocol.RPCError, code string, locale i18n.Locale) {
+	if locale == i18n.English || locale == "" {
+		return
+	}
+	key := "error." + code
+	if message := locale.T(key); message != key {
+		rpcError.Message = message
+	}
+}
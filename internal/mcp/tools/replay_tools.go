@@ -0,0 +1,272 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+)
+
+// ReplayClassificationTool implements the replay_classification MCP tool
+type ReplayClassificationTool struct {
+	logger            *logrus.Logger
+	classifierService *service.ClassifierService
+	snapshots         snapshot.Store
+}
+
+// ReplayClassificationParams defines parameters for the replay_classification tool
+type ReplayClassificationParams struct {
+	SnapshotID  int64  `json:"snapshot_id"`
+	Rules       string `json:"rules,omitempty"`       // recorded (default) or current
+	RulesAsOf   string `json:"rules_as_of,omitempty"` // YYYY-MM-DD; the thresholds in effect at the end of that day
+	ScoringMode string `json:"scoring_mode,omitempty"`
+}
+
+// ReplayOutcome is the outcome of a classification and the rules it was made with
+type ReplayOutcome struct {
+	Classification    string `json:"classification"`
+	Confidence        string `json:"confidence"`
+	PointTotal        int    `json:"point_total"`
+	ScoringMode       string `json:"scoring_mode"`
+	ThresholdRevision int64  `json:"threshold_revision"` // 0 when the built-in thresholds were used
+	Specification     string `json:"vcep_specification,omitempty"`
+	EngineVersion     string `json:"engine_version"`
+	ConfigCommit      string `json:"config_commit,omitempty"`
+}
+
+// ReplayClassificationResult compares a replayed classification with the recorded one
+type ReplayClassificationResult struct {
+	SnapshotID      int64                       `json:"snapshot_id"`
+	HGVSNotation    string                      `json:"hgvs_notation"`
+	ClassifiedAt    time.Time                   `json:"classified_at"`
+	Rules           string                      `json:"rules"` // recorded, current or "as of YYYY-MM-DD"
+	Recorded        ReplayOutcome               `json:"recorded"`
+	Replayed        ReplayOutcome               `json:"replayed"`
+	Reproduced      bool                        `json:"reproduced"` // Same classification from the same criteria
+	Diff            *audit.Diff                 `json:"diff"`
+	AppliedRules    []service.ACMGAMPRuleResult `json:"applied_rules"`
+	EvidenceSummary string                      `json:"evidence_summary"`
+	Recommendations []string                    `json:"recommendations,omitempty"`
+}
+
+// NewReplayClassificationTool creates a new replay_classification tool
+func NewReplayClassificationTool(logger *logrus.Logger, classifierService *service.ClassifierService, snapshots snapshot.Store) *ReplayClassificationTool {
+	return &ReplayClassificationTool{
+		logger:            logger,
+		classifierService: classifierService,
+		snapshots:         snapshots,
+	}
+}
+
+// GetToolInfo returns the tool information for replay_classification
+func (t *ReplayClassificationTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "replay_classification",
+		Description: "Re-run the ACMG/AMP rule engine against the evidence bundle stored with a prior classification, without querying any external database. With the recorded rules the original call is reproduced, for validation and debugging; with the current rules or those in effect on a date, the effect of a threshold change on the call is shown. Reports both outcomes and the criteria that differ. Bundles are stored when ACMG_EVIDENCE_BUNDLES is enabled; classify_variant returns the bundle's evidence_snapshot_id.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"snapshot_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "Evidence bundle to replay, the evidence_snapshot_id returned by classify_variant",
+				},
+				"rules": map[string]interface{}{
+					"type":        "string",
+					"description": "Thresholds to replay with: those the classification was made with (default) or those in effect now",
+					"enum":        []string{service.ReplayRulesRecorded, service.ReplayRulesCurrent},
+				},
+				"rules_as_of": map[string]interface{}{
+					"type":        "string",
+					"description": "Replay with the thresholds in effect on this date (YYYY-MM-DD) instead",
+				},
+				"scoring_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Combine evidence with the ACMG/AMP combining rules or ClinGen SVI points; defaults to the recorded mode",
+					"enum":        []string{"combining_rules", "points"},
+				},
+			},
+			"required": []string{"snapshot_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ReplayClassificationTool) ValidateParams(params interface{}) error {
+	var p ReplayClassificationParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.SnapshotID <= 0 {
+		return fmt.Errorf("snapshot_id is required")
+	}
+	switch p.Rules {
+	case "", service.ReplayRulesRecorded, service.ReplayRulesCurrent:
+	default:
+		return fmt.Errorf("rules must be %s or %s", service.ReplayRulesRecorded, service.ReplayRulesCurrent)
+	}
+	if p.RulesAsOf != "" {
+		if p.Rules != "" {
+			return fmt.Errorf("give either rules or rules_as_of, not both")
+		}
+		if _, err := time.Parse("2006-01-02", p.RulesAsOf); err != nil {
+			return fmt.Errorf("rules_as_of must be a date (YYYY-MM-DD)")
+		}
+	}
+	if p.ScoringMode != "" {
+		if _, err := service.ParseScoringMode(p.ScoringMode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleTool handles the replay_classification tool request
+func (t *ReplayClassificationTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ReplayClassificationParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+	if t.classifierService == nil || t.snapshots == nil {
+		return internalError("Classification replay not configured", "the classifier service and snapshot store are required")
+	}
+
+	snap, err := t.snapshots.Get(ctx, params.SnapshotID)
+	if err != nil {
+		t.logger.WithError(err).WithField("snapshot_id", params.SnapshotID).Error("Failed to load evidence bundle")
+		return internalError("Failed to load evidence bundle", err.Error())
+	}
+	if snap == nil {
+		return invalidParamsError("Evidence bundle not found", fmt.Sprintf("no snapshot with ID %d", params.SnapshotID))
+	}
+	if snap.Kind != snapshot.KindClassification {
+		return invalidParamsError("Not an evidence bundle", fmt.Sprintf("snapshot %d holds query_evidence results, which cannot be replayed", params.SnapshotID))
+	}
+
+	var bundle service.EvidenceBundle
+	if err := json.Unmarshal(snap.Payload, &bundle); err != nil {
+		return internalError("Failed to decode evidence bundle", err.Error())
+	}
+
+	opts := service.ReplayOptions{Rules: params.Rules, ScoringMode: params.ScoringMode}
+	rules := params.Rules
+	if rules == "" {
+		rules = service.ReplayRulesRecorded
+	}
+	if params.RulesAsOf != "" {
+		day, _ := time.Parse("2006-01-02", params.RulesAsOf)
+		opts.RulesAsOf = day.Add(24*time.Hour - time.Nanosecond)
+		rules = "as of " + params.RulesAsOf
+	}
+
+	replayed, err := t.classifierService.ReplayClassification(ctx, &bundle, opts)
+	if err != nil {
+		t.logger.WithError(err).WithField("snapshot_id", params.SnapshotID).Warn("Failed to replay classification")
+		return &protocol.JSONRPC2Response{
+			Error: &protocol.RPCError{
+				Code:    protocol.MCPToolError,
+				Message: "Replay failed",
+				Data:    err.Error(),
+			},
+		}
+	}
+
+	result, err := t.compare(snap, &bundle, replayed)
+	if err != nil {
+		return internalError("Failed to compare classifications", err.Error())
+	}
+	result.Rules = rules
+
+	return &protocol.JSONRPC2Response{Result: result}
+}
+
+// compare builds the replay result, diffing the replayed classification
+// against the recorded one as two audit records of the same evidence
+func (t *ReplayClassificationTool) compare(snap *snapshot.Snapshot, bundle *service.EvidenceBundle, replayed *service.ClassifyVariantResult) (*ReplayClassificationResult, error) {
+	evidence, err := json.Marshal(bundle.Evidence)
+	if err != nil {
+		return nil, err
+	}
+	recordedRules, err := json.Marshal(bundle.AppliedRules)
+	if err != nil {
+		return nil, err
+	}
+	replayedRules, err := json.Marshal(replayed.AppliedRules)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := &audit.Record{
+		HGVSNotation:      bundle.HGVSNotation,
+		Evidence:          evidence,
+		AppliedRules:      recordedRules,
+		Classification:    bundle.Classification,
+		Confidence:        bundle.Confidence,
+		EngineVersion:     bundle.EngineVersion,
+		ScoringMode:       bundle.ScoringMode,
+		ThresholdRevision: bundle.ThresholdRevision,
+		Specification:     bundle.Specification,
+		ConfigCommit:      bundle.ConfigCommit,
+		DatasetVersions:   bundle.DatasetVersions,
+		CreatedAt:         snap.CapturedAt,
+	}
+	current := &audit.Record{
+		HGVSNotation:      bundle.HGVSNotation,
+		Evidence:          evidence,
+		AppliedRules:      replayedRules,
+		Classification:    replayed.Classification,
+		Confidence:        replayed.Confidence,
+		EngineVersion:     service.EngineVersion,
+		ScoringMode:       replayed.ScoringMode,
+		ThresholdRevision: replayed.ThresholdRevision,
+		Specification:     replayed.Specification,
+		ConfigCommit:      replayed.ConfigCommit,
+		DatasetVersions:   replayed.DatasetVersions,
+		CreatedAt:         time.Now().UTC(),
+	}
+	diff, err := audit.Compare(recorded, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplayClassificationResult{
+		SnapshotID:   snap.ID,
+		HGVSNotation: bundle.HGVSNotation,
+		ClassifiedAt: snap.CapturedAt,
+		Recorded: ReplayOutcome{
+			Classification:    bundle.Classification,
+			Confidence:        bundle.Confidence,
+			PointTotal:        bundle.PointTotal,
+			ScoringMode:       bundle.ScoringMode,
+			ThresholdRevision: bundle.ThresholdRevision,
+			Specification:     bundle.Specification,
+			EngineVersion:     bundle.EngineVersion,
+			ConfigCommit:      bundle.ConfigCommit,
+		},
+		Replayed: ReplayOutcome{
+			Classification:    replayed.Classification,
+			Confidence:        replayed.Confidence,
+			PointTotal:        replayed.PointTotal,
+			ScoringMode:       replayed.ScoringMode,
+			ThresholdRevision: replayed.ThresholdRevision,
+			Specification:     replayed.Specification,
+			EngineVersion:     service.EngineVersion,
+			ConfigCommit:      replayed.ConfigCommit,
+		},
+		Reproduced: !diff.ClassChanged && len(diff.CriteriaAdded) == 0 &&
+			len(diff.CriteriaRemoved) == 0 && len(diff.CriteriaStrengthChanged) == 0,
+		Diff:            diff,
+		AppliedRules:    replayed.AppliedRules,
+		EvidenceSummary: replayed.EvidenceSummary,
+		Recommendations: replayed.Recommendations,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+type fixedThresholdSource struct {
+	revision *thresholds.Revision
+}
+
+func (s fixedThresholdSource) Effective(ctx context.Context, at time.Time) (*thresholds.Revision, error) {
+	return s.revision, nil
+}
+
+func createTestSnapshotStore(t *testing.T) snapshot.Store {
+	t.Helper()
+	dir := t.TempDir()
+	archive, err := snapshot.NewFileArchive(filepath.Join(dir, "archive"))
+	require.NoError(t, err)
+	store, err := snapshot.NewSQLiteStore(filepath.Join(dir, "evidence.db"), archive)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// saveTestBundle stores the evidence bundle of a classification made with the
+// default thresholds and returns its snapshot ID
+func saveTestBundle(t *testing.T, classifier *service.ClassifierService, store snapshot.Store) int64 {
+	t.Helper()
+	hgvs := "NC_000017.11:g.43045712G>A"
+	bundle := &service.EvidenceBundle{
+		Params:        &service.ClassifyVariantParams{HGVSNotation: hgvs},
+		HGVSNotation:  hgvs,
+		Variant:       &domain.StandardizedVariant{ID: "var-1", GeneSymbol: "BRCA1", HGVSGenomic: hgvs},
+		Evidence:      &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleFrequency: 0.02}},
+		ScoringMode:   "combining_rules",
+		Thresholds:    thresholds.Defaults(),
+		EngineVersion: service.EngineVersion,
+	}
+	original, err := classifier.ReplayClassification(context.Background(), bundle, service.ReplayOptions{})
+	require.NoError(t, err)
+	bundle.Classification = original.Classification
+	bundle.Confidence = original.Confidence
+	bundle.PointTotal = original.PointTotal
+	bundle.AppliedRules = original.AppliedRules
+
+	payload, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	snap := &snapshot.Snapshot{Kind: snapshot.KindClassification, NormalizedHGVS: hgvs, Payload: payload}
+	require.NoError(t, store.Save(context.Background(), snap))
+	return snap.ID
+}
+
+func TestReplayClassificationTool_Rules(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	classifier := service.NewClassifierService(logger, nil, nil, nil)
+	store := createTestSnapshotStore(t)
+	snapshotID := saveTestBundle(t, classifier, store)
+	tool := NewReplayClassificationTool(logger, classifier, store)
+
+	custom := thresholds.Defaults()
+	custom.BA1AlleleFrequency = 0.01
+	custom.BS1AlleleFrequency = 0.005
+	classifier.SetThresholdSource(fixedThresholdSource{revision: &thresholds.Revision{ID: 3, Thresholds: custom}})
+
+	// The recorded rules reproduce the call, whatever is in effect now
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		Method: "replay_classification",
+		Params: map[string]interface{}{"snapshot_id": snapshotID},
+	})
+	require.Nil(t, response.Error)
+	result := response.Result.(*ReplayClassificationResult)
+	assert.Equal(t, service.ReplayRulesRecorded, result.Rules)
+	assert.True(t, result.Reproduced)
+	assert.Equal(t, result.Recorded.Classification, result.Replayed.Classification)
+	assert.False(t, result.Diff.Changed())
+
+	// The current rules make 2% stand-alone benign
+	response = tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		Method: "replay_classification",
+		Params: map[string]interface{}{"snapshot_id": snapshotID, "rules": "current"},
+	})
+	require.Nil(t, response.Error)
+	result = response.Result.(*ReplayClassificationResult)
+	assert.False(t, result.Reproduced)
+	assert.Equal(t, string(domain.BENIGN), result.Replayed.Classification)
+	assert.Equal(t, int64(3), result.Replayed.ThresholdRevision)
+	assert.Contains(t, result.Diff.CriteriaAdded, audit.CriterionChange{Code: "BA1", CurrentStrength: "VERY_STRONG"})
+	assert.Contains(t, result.Diff.ConfigurationChanges, "threshold_revision: none -> 3")
+
+	response = tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		Method: "replay_classification",
+		Params: map[string]interface{}{"snapshot_id": snapshotID, "rules_as_of": "2024-06-01"},
+	})
+	require.Nil(t, response.Error)
+	assert.Equal(t, "as of 2024-06-01", response.Result.(*ReplayClassificationResult).Rules)
+}
+
+func TestReplayClassificationTool_Invalid(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	classifier := service.NewClassifierService(logger, nil, nil, nil)
+	store := createTestSnapshotStore(t)
+	tool := NewReplayClassificationTool(logger, classifier, store)
+
+	evidence := &snapshot.Snapshot{NormalizedHGVS: "NM_007294.4:c.5266dup", Payload: json.RawMessage(`{}`)}
+	require.NoError(t, store.Save(context.Background(), evidence))
+
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		want   string
+	}{
+		{"missing snapshot_id", map[string]interface{}{}, "snapshot_id is required"},
+		{"unknown rules", map[string]interface{}{"snapshot_id": 1, "rules": "latest"}, "rules must be recorded or current"},
+		{"rules and date", map[string]interface{}{"snapshot_id": 1, "rules": "current", "rules_as_of": "2024-06-01"}, "not both"},
+		{"bad date", map[string]interface{}{"snapshot_id": 1, "rules_as_of": "June 2024"}, "YYYY-MM-DD"},
+		{"not found", map[string]interface{}{"snapshot_id": 99}, "Evidence bundle not found"},
+		{"query_evidence snapshot", map[string]interface{}{"snapshot_id": evidence.ID}, "Not an evidence bundle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+				Method: "replay_classification",
+				Params: tt.params,
+			})
+			require.NotNil(t, response.Error)
+			assert.Equal(t, protocol.InvalidParams, response.Error.Code)
+			assert.Contains(t, response.Error.Message, tt.want)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package tools
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/i18n"
+	"github.com/acmg-amp-mcp-server/internal/reportdoc"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+// reportFormatJSON is the default output format of generate_report: the
+// structured report only, without a rendered document
+const reportFormatJSON = "json"
+
+// ReportDocument is a report rendered as a document. Text formats are
+// returned as Content, binary formats (PDF, DOCX) base64-encoded.
+type ReportDocument struct {
+	Format        string `json:"format"`
+	MimeType      string `json:"mime_type"`
+	Content       string `json:"content,omitempty"`
+	ContentBase64 string `json:"content_base64,omitempty"`
+	Size          int    `json:"size"`
+	FilePath      string `json:"file_path,omitempty"` // Copy written to the export directory, if configured
+}
+
+// reportOutputFormats lists the output_format values generate_report accepts
+func reportOutputFormats() []string {
+	formats := []string{reportFormatJSON}
+	for _, format := range reportdoc.Formats {
+		formats = append(formats, string(format))
+	}
+	return formats
+}
+
+// renderDocument renders the report in the requested document format and,
+// when an export directory is configured, saves a copy named after the report
+func (t *GenerateReportTool) renderDocument(params *GenerateReportParams, report *ReportResult) (*ReportDocument, error) {
+	format, err := reportdoc.ParseFormat(params.OutputFormat)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := reportdoc.Render(t.buildDocument(params, report), format)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &ReportDocument{
+		Format:   string(rendered.Format),
+		MimeType: rendered.MimeType,
+		Size:     len(rendered.Content),
+	}
+	if format.Binary() {
+		document.ContentBase64 = base64.StdEncoding.EncodeToString(rendered.Content)
+	} else {
+		document.Content = string(rendered.Content)
+	}
+
+	if t.exportDir != "" {
+		if err := os.MkdirAll(t.exportDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create export directory: %w", err)
+		}
+		filePath := filepath.Join(t.exportDir, report.ReportID+rendered.Extension)
+		if err := os.WriteFile(filePath, rendered.Content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write report document: %w", err)
+		}
+		document.FilePath = filePath
+	}
+	return document, nil
+}
+
+// buildDocument lays out the clinical report: the result, evidence summary,
+// criteria or tier rationale, limitations and recommendations, followed by
+// the disclaimers. Headings and labels are in the report's locale; text
+// carried over from the classification is not translated.
+func (t *GenerateReportTool) buildDocument(params *GenerateReportParams, report *ReportResult) *reportdoc.Document {
+	classification := params.Classification
+	somatic := classification.Somatic
+	locale := i18n.Locale(params.Locale)
+
+	doc := &reportdoc.Document{
+		Title:    locale.T("report.title.germline"),
+		Subtitle: params.HGVSNotation,
+		Footer:   report.Disclaimers,
+	}
+	if somatic != nil {
+		doc.Title = locale.T("report.title.somatic")
+	}
+
+	reportDate := report.GenerationDate
+	clinical := params.ClinicalContext
+	if clinical == nil {
+		clinical = &ClinicalContext{}
+	}
+	if clinical.ReportDate != "" {
+		reportDate = clinical.ReportDate
+	}
+	doc.Fields = documentFields(
+		locale.T("report.field.report_id"), report.ReportID,
+		locale.T("report.field.report_date"), reportDate,
+		locale.T("report.field.gene"), params.GeneSymbol,
+		locale.T("report.field.variant_id"), params.VariantID,
+		locale.T("report.field.patient_id"), clinical.PatientID,
+		locale.T("report.field.clinical_indication"), clinical.ClinicalIndication,
+		locale.T("report.field.referring_physician"), clinical.ReferringPhysician,
+		locale.T("report.field.test_date"), clinical.TestDate,
+	)
+	if approved := report.Review; approved != nil && approved.ReviewedAt != nil {
+		doc.Fields = append(doc.Fields, documentFields(
+			locale.T("report.field.analyst"), approved.SubmittedBy,
+			locale.T("report.field.reviewed_by"), approved.ReviewedBy,
+			locale.T("report.field.review_date"), approved.ReviewedAt.Format("2006-01-02"),
+		)...)
+	}
+
+	if somatic != nil {
+		doc.Sections = append(doc.Sections, reportdoc.Section{
+			Heading:    locale.T("report.section.result"),
+			Paragraphs: []string{somatic.TierLabel},
+			Fields: documentFields(
+				locale.T("report.field.tumor_type"), somatic.TumorType,
+				locale.T("report.field.evidence_level"), somatic.EvidenceLevel.Label(),
+				locale.T("report.field.confidence"), classification.Confidence,
+			),
+		})
+	} else {
+		doc.Sections = append(doc.Sections, reportdoc.Section{
+			Heading:    locale.T("report.section.result"),
+			Paragraphs: []string{classificationLabel(locale, classification.Classification)},
+			Fields: documentFields(
+				locale.T("report.field.confidence"), classification.Confidence,
+				locale.T("report.field.scoring"), classification.ScoringMode,
+				locale.T("report.field.vcep_specification"), classification.Specification,
+			),
+		})
+	}
+
+	evidence := reportdoc.Section{Heading: locale.T("report.section.evidence_summary")}
+	if classification.EvidenceSummary != "" {
+		evidence.Paragraphs = append(evidence.Paragraphs, classification.EvidenceSummary)
+	}
+	if params.Evidence != nil {
+		if params.Evidence.Synthesis != "" {
+			evidence.Paragraphs = append(evidence.Paragraphs, params.Evidence.Synthesis)
+		}
+		sources := make([]string, 0, len(params.Evidence.DatabaseResults))
+		for source := range params.Evidence.DatabaseResults {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		evidence.Fields = documentFields(locale.T("report.field.data_sources"), strings.Join(sources, ", "))
+	}
+	if len(evidence.Paragraphs) > 0 || len(evidence.Fields) > 0 {
+		doc.Sections = append(doc.Sections, evidence)
+	}
+
+	if somatic != nil {
+		doc.Sections = append(doc.Sections, somaticSection(locale, somatic))
+	} else {
+		doc.Sections = append(doc.Sections, criteriaSection(locale, locale.T("report.section.criteria_met"), classification.AppliedRules, true))
+		if params.DetailLevel == "comprehensive" {
+			doc.Sections = append(doc.Sections, criteriaSection(locale, locale.T("report.section.criteria_not_met"), classification.AppliedRules, false))
+		}
+		if overrides := overridesSection(locale, classification.AppliedRules); overrides != nil {
+			doc.Sections = append(doc.Sections, *overrides)
+		}
+	}
+
+	limitations, _ := t.generateLimitationsSection(params)["limitations"].([]string)
+	doc.Sections = append(doc.Sections, reportdoc.Section{Heading: locale.T("report.section.limitations"), Items: limitations})
+
+	recommendations := mergeRecommendations(classification.Recommendations, report.Recommendations)
+	if len(recommendations) > 0 {
+		doc.Sections = append(doc.Sections, reportdoc.Section{Heading: locale.T("report.section.recommendations"), Items: recommendations})
+	}
+	return doc
+}
+
+// criteriaSection tabulates the criteria that were, or were not, met with
+// their strength and rationale
+func criteriaSection(locale i18n.Locale, heading string, rules []ACMGAMPRuleResult, applied bool) reportdoc.Section {
+	section := reportdoc.Section{Heading: heading}
+	table := &reportdoc.Table{Header: []string{locale.T("report.column.criterion"), locale.T("report.column.strength"), locale.T("report.column.rationale")}}
+	for _, rule := range rules {
+		if rule.Applied != applied {
+			continue
+		}
+		code := rule.RuleCode
+		if rule.Override != nil {
+			code += " " + locale.T("report.override.marker")
+		}
+		rationale := rule.Reasoning
+		if rationale == "" {
+			rationale = rule.Evidence
+		}
+		if rationale == "" {
+			rationale = rule.RuleName
+		}
+		table.Rows = append(table.Rows, []string{code, strengthLabel(locale, rule.Strength), rationale})
+	}
+
+	if len(table.Rows) == 0 {
+		section.Paragraphs = []string{locale.T("report.none")}
+		return section
+	}
+	section.Table = table
+	return section
+}
+
+// overridesSection lists the criteria a curator overrode, what changed from
+// the automated call and why, or nil when nothing was overridden
+func overridesSection(locale i18n.Locale, rules []ACMGAMPRuleResult) *reportdoc.Section {
+	table := &reportdoc.Table{Header: []string{
+		locale.T("report.column.criterion"),
+		locale.T("report.column.change"),
+		locale.T("report.column.justification"),
+		locale.T("report.column.overridden_by"),
+		locale.T("report.column.date"),
+	}}
+	for _, rule := range rules {
+		override := rule.Override
+		if override == nil {
+			continue
+		}
+		var change string
+		switch {
+		case !rule.Applied:
+			change = locale.T("report.override.remove", strengthLabel(locale, override.OriginalStrength))
+		case !override.OriginalApplied:
+			change = locale.T("report.override.apply", strengthLabel(locale, rule.Strength))
+		default:
+			change = locale.T("report.override.strength", strengthLabel(locale, override.OriginalStrength), strengthLabel(locale, rule.Strength))
+		}
+		table.Rows = append(table.Rows, []string{
+			rule.RuleCode,
+			change,
+			override.Justification,
+			override.OverriddenBy,
+			override.OverriddenAt.Format("2006-01-02"),
+		})
+	}
+	if len(table.Rows) == 0 {
+		return nil
+	}
+	return &reportdoc.Section{Heading: locale.T("report.section.overrides"), Table: table}
+}
+
+// strengthLabel returns the display label of a criterion strength in the
+// locale, or the strength as given if it is not a canonical value
+func strengthLabel(locale i18n.Locale, strength string) string {
+	if parsed, err := domain.ParseRuleStrength(strength); err == nil {
+		return locale.T("strength." + string(parsed))
+	}
+	return strength
+}
+
+// somaticSection gives the rationale for the AMP/ASCO/CAP tier and the
+// evidence it rests on
+func somaticSection(locale i18n.Locale, somatic *service.SomaticAssessment) reportdoc.Section {
+	section := reportdoc.Section{
+		Heading:    locale.T("report.section.tier_rationale"),
+		Paragraphs: []string{somatic.Rationale},
+		Fields:     documentFields(locale.T("report.field.sources"), strings.Join(somatic.Sources, ", ")),
+	}
+	if len(somatic.Evidence) == 0 {
+		return section
+	}
+
+	table := &reportdoc.Table{Header: []string{
+		locale.T("report.column.level"),
+		locale.T("report.column.type"),
+		locale.T("report.column.tumor_type"),
+		locale.T("report.column.significance"),
+		locale.T("report.column.therapies"),
+		locale.T("report.column.source"),
+	}}
+	for _, item := range somatic.Evidence {
+		table.Rows = append(table.Rows, []string{
+			string(item.Level),
+			item.Type,
+			item.TumorType,
+			item.Significance,
+			strings.Join(item.Therapies, ", "),
+			strings.TrimSpace(item.Source + " " + item.SourceLevel),
+		})
+	}
+	section.Table = table
+	return section
+}
+
+// classificationLabel returns the display label of a classification in the
+// locale, or the classification as given if it is not a canonical value
+func classificationLabel(locale i18n.Locale, classification string) string {
+	if parsed, err := domain.ParseClassification(classification); err == nil {
+		return locale.T("classification." + string(parsed))
+	}
+	return classification
+}
+
+// documentFields builds fields from label, value pairs, leaving out empty values
+func documentFields(pairs ...string) []reportdoc.Field {
+	var fields []reportdoc.Field
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			fields = append(fields, reportdoc.Field{Label: pairs[i], Value: pairs[i+1]})
+		}
+	}
+	return fields
+}
+
+// mergeRecommendations combines recommendation lists, dropping duplicates
+func mergeRecommendations(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	var merged []string
+	for _, list := range lists {
+		for _, recommendation := range list {
+			if !seen[recommendation] {
+				seen[recommendation] = true
+				merged = append(merged, recommendation)
+			}
+		}
+	}
+	return merged
+}
@@ -2,23 +2,33 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/i18n"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/reportdoc"
+	"github.com/acmg-amp-mcp-server/internal/review"
 )
 
 // GenerateReportTool implements the generate_report MCP tool
 type GenerateReportTool struct {
-	logger *logrus.Logger
+	logger    *logrus.Logger
+	exportDir string       // Directory rendered documents are saved to; empty to return them only
+	reviews   review.Store // When set, only classifications approved in dual review are reported
+	audit     audit.Store  // Audited classifications approved reports are built from
 }
 
 // GenerateReportParams defines parameters for the generate_report tool
 type GenerateReportParams struct {
 	VariantID          string                 `json:"variant_id,omitempty"`
+	ClassificationID   int64                  `json:"classification_id,omitempty"` // Audit record of the classification; required when review is required
 	HGVSNotation       string                 `json:"hgvs_notation" validate:"required"`
 	GeneSymbol         string                 `json:"gene_symbol,omitempty"`
 	Classification     ClassifyVariantResult  `json:"classification" validate:"required"`
@@ -30,6 +40,8 @@ type GenerateReportParams struct {
 	DetailLevel        string                 `json:"detail_level,omitempty"`
 	IncludeRawData     bool                   `json:"include_raw_data,omitempty"`
 	CustomMetadata     map[string]interface{} `json:"custom_metadata,omitempty"`
+	OutputFormat       string                 `json:"output_format,omitempty"` // json (default), markdown, html, pdf or docx
+	Locale             string                 `json:"locale,omitempty"`        // Language of the report text; the request's or configured locale when empty
 }
 
 // ClinicalContext provides patient and clinical context for personalized reports
@@ -60,6 +72,7 @@ type ReportResult struct {
 	Recommendations    []string               `json:"recommendations"`
 	Disclaimers        []string               `json:"disclaimers"`
 	Appendices         map[string]interface{} `json:"appendices,omitempty"`
+	Review             *review.Review         `json:"review,omitempty"` // Approved review, when review is required
 }
 
 // ReportSummary provides executive summary of the clinical interpretation
@@ -91,6 +104,89 @@ func NewGenerateReportTool(logger *logrus.Logger) *GenerateReportTool {
 	}
 }
 
+// SetExportDir sets the directory rendered report documents are saved to
+func (t *GenerateReportTool) SetExportDir(dir string) {
+	t.exportDir = dir
+}
+
+// SetReviewStore requires classifications to be approved in dual review
+// before they are reported. Approved reports are built from the audited
+// classification in auditStore rather than the classification sent by the client.
+func (t *GenerateReportTool) SetReviewStore(store review.Store, auditStore audit.Store) {
+	t.reviews = store
+	t.audit = auditStore
+}
+
+// approvedReview returns the approved review of the classification being
+// reported. The variant and classification must match the call that was approved.
+func (t *GenerateReportTool) approvedReview(ctx context.Context, params *GenerateReportParams) (*review.Review, *protocol.JSONRPC2Response) {
+	if params.ClassificationID <= 0 {
+		return nil, invalidParamsError("Classification not approved for reporting", "classification_id is required: only classifications approved in review can be reported")
+	}
+	approved, err := t.reviews.Approved(ctx, params.ClassificationID)
+	if errors.Is(err, review.ErrNotFound) {
+		return nil, invalidParamsError("Classification not approved for reporting",
+			fmt.Sprintf("classification %d has not been approved in review; submit it with submit_for_review", params.ClassificationID))
+	}
+	if err != nil {
+		return nil, reviewStoreError(t.logger, "check classification review", err)
+	}
+	if variant, approvedVariant, ok := sameVariant(params, approved); !ok {
+		return nil, invalidParamsError("Classification not approved for reporting",
+			fmt.Sprintf("variant %s differs from the approved variant %s", variant, approvedVariant))
+	}
+	if !sameClassification(params.Classification.Classification, approved.Classification) {
+		return nil, invalidParamsError("Classification not approved for reporting",
+			fmt.Sprintf("classification %s differs from the approved call %s", params.Classification.Classification, approved.Classification))
+	}
+	return approved, nil
+}
+
+// sameVariant reports whether the variant being reported is the one that was
+// approved, returning the mismatched identifiers when it is not
+func sameVariant(params *GenerateReportParams, approved *review.Review) (string, string, bool) {
+	if approved.HGVSNotation != "" && strings.TrimSpace(params.HGVSNotation) != approved.HGVSNotation {
+		return params.HGVSNotation, approved.HGVSNotation, false
+	}
+	if approved.VariantID == "" {
+		return "", "", true
+	}
+	for _, id := range []string{params.VariantID, params.Classification.VariantID} {
+		if id = strings.TrimSpace(id); id != "" && id != approved.VariantID {
+			return id, approved.VariantID, false
+		}
+	}
+	return "", "", true
+}
+
+// useAuditedClassification replaces the classification sent by the client with
+// the audited one the review approved, so the report states the recorded
+// variant, call and criteria
+func (t *GenerateReportTool) useAuditedClassification(ctx context.Context, params *GenerateReportParams) *protocol.JSONRPC2Response {
+	record, err := t.audit.Get(ctx, params.ClassificationID)
+	if err != nil {
+		return auditStoreError(t.logger, "get audit record", err)
+	}
+	var rules []ACMGAMPRuleResult
+	if len(record.AppliedRules) > 0 {
+		if err := json.Unmarshal(record.AppliedRules, &rules); err != nil {
+			return internalError("Failed to decode recorded criteria", err.Error())
+		}
+	}
+
+	params.HGVSNotation = record.HGVSNotation
+	params.VariantID = record.VariantID
+	params.Classification.VariantID = record.VariantID
+	params.Classification.Classification = record.Classification
+	params.Classification.Confidence = record.Confidence
+	params.Classification.AppliedRules = rules
+	params.Classification.ScoringMode = record.ScoringMode
+	params.Classification.ThresholdRevision = record.ThresholdRevision
+	params.Classification.ConfigCommit = record.ConfigCommit
+	params.Classification.DatasetVersions = record.DatasetVersions
+	return nil
+}
+
 // HandleTool implements the ToolHandler interface for generate_report
 func (t *GenerateReportTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
 	t.logger.WithField("tool", "generate_report").Info("Processing report generation request")
@@ -107,6 +203,23 @@ func (t *GenerateReportTool) HandleTool(ctx context.Context, req *protocol.JSONR
 		}
 	}
 
+	if params.Locale == "" {
+		params.Locale = string(i18n.FromContext(ctx))
+	}
+
+	var approved *review.Review
+	if t.reviews != nil {
+		var response *protocol.JSONRPC2Response
+		if approved, response = t.approvedReview(ctx, &params); response != nil {
+			return response
+		}
+		if t.audit != nil {
+			if response := t.useAuditedClassification(ctx, &params); response != nil {
+				return response
+			}
+		}
+	}
+
 	// Generate the report
 	report, err := t.generateReport(ctx, &params)
 	if err != nil {
@@ -118,18 +231,38 @@ func (t *GenerateReportTool) HandleTool(ctx context.Context, req *protocol.JSONR
 			},
 		}
 	}
+	if approved != nil {
+		report.Review = approved
+		report.QualityMetrics.ReviewStatus = string(approved.Status)
+	}
+
+	result := map[string]interface{}{
+		"report": report,
+	}
+	if params.OutputFormat != reportFormatJSON {
+		document, err := t.renderDocument(&params, report)
+		if err != nil {
+			return &protocol.JSONRPC2Response{
+				Error: &protocol.RPCError{
+					Code:    protocol.InternalError,
+					Message: "Report rendering failed",
+					Data:    err.Error(),
+				},
+			}
+		}
+		result["document"] = document
+	}
 
 	t.logger.WithFields(logrus.Fields{
 		"report_id":      report.ReportID,
 		"hgvs":           params.HGVSNotation,
 		"classification": report.Summary.Classification,
 		"sections":       len(report.Sections),
+		"format":         params.OutputFormat,
 	}).Info("Report generation completed")
 
 	return &protocol.JSONRPC2Response{
-		Result: map[string]interface{}{
-			"report": report,
-		},
+		Result: result,
 	}
 }
 
@@ -153,6 +286,10 @@ func (t *GenerateReportTool) GetToolInfo() protocol.ToolInfo {
 					"type":        "object",
 					"description": "Classification result from classify_variant tool",
 				},
+				"classification_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "Audit record ID of the classification. Required when the server requires dual review: only approved classifications are reported",
+				},
 				"evidence": map[string]interface{}{
 					"type":        "object",
 					"description": "Evidence data from query_evidence tool",
@@ -196,6 +333,17 @@ func (t *GenerateReportTool) GetToolInfo() protocol.ToolInfo {
 					"default":     "standard",
 					"description": "Level of detail for the report",
 				},
+				"output_format": map[string]interface{}{
+					"type":        "string",
+					"enum":        reportOutputFormats(),
+					"default":     reportFormatJSON,
+					"description": "Also render the report as a clinical document: markdown or html as text, pdf or docx base64-encoded",
+				},
+				"locale": map[string]interface{}{
+					"type":        "string",
+					"enum":        i18n.Strings(),
+					"description": "Language of the report text: headings, labels, limitations, recommendations and disclaimers. Defaults to the server's locale",
+				},
 			},
 			"required": []string{"hgvs_notation", "classification"},
 		},
@@ -228,6 +376,24 @@ func (t *GenerateReportTool) parseAndValidateParams(params interface{}, target *
 		target.DetailLevel = "standard"
 	}
 
+	target.OutputFormat = strings.ToLower(strings.TrimSpace(target.OutputFormat))
+	if target.OutputFormat == "" {
+		target.OutputFormat = reportFormatJSON
+	}
+	if target.OutputFormat != reportFormatJSON {
+		if _, err := reportdoc.ParseFormat(target.OutputFormat); err != nil {
+			return fmt.Errorf("invalid output format: %s", target.OutputFormat)
+		}
+	}
+
+	if target.Locale != "" {
+		locale, err := i18n.ParseLocale(target.Locale)
+		if err != nil {
+			return err
+		}
+		target.Locale = string(locale)
+	}
+
 	// Validate template
 	validTemplates := []string{"clinical", "research", "summary", "detailed", "custom"}
 	if !t.isValidTemplate(target.ReportTemplate, validTemplates) {
@@ -596,10 +762,14 @@ func (t *GenerateReportTool) generateACMGRuleAssessmentSection(params *GenerateR
 }
 
 func (t *GenerateReportTool) generateLimitationsSection(params *GenerateReportParams) map[string]interface{} {
+	locale := i18n.Locale(params.Locale)
 	limitations := []string{
-		"Classification is based on currently available evidence and may change as new data becomes available",
-		"Variant interpretation follows ACMG/AMP guidelines which have inherent limitations",
-		"Population frequency data may not be representative of all ethnic groups",
+		locale.T("report.limitation.evidence"),
+		locale.T("report.limitation.guidelines"),
+		locale.T("report.limitation.population"),
+	}
+	for _, caveat := range params.Classification.RegionCaveats {
+		limitations = append(limitations, caveat.Message)
 	}
 	
 	return map[string]interface{}{
@@ -637,6 +807,9 @@ func (t *GenerateReportTool) generateSummary(params *GenerateReportParams) Repor
 	if len(params.Classification.AppliedRules) > 0 {
 		summary.CriticalEvidence = t.convertACMGRulesToStrings(params.Classification.AppliedRules)
 	}
+	for _, caveat := range params.Classification.RegionCaveats {
+		summary.LimitationsNoted = append(summary.LimitationsNoted, caveat.Message)
+	}
 
 	return summary
 }
@@ -658,31 +831,36 @@ func (t *GenerateReportTool) generateQualityMetrics(params *GenerateReportParams
 		metrics.EvidenceQuality = params.Evidence.QualityScores.OverallQuality
 		metrics.DataSources = len(params.Evidence.DatabaseResults)
 	}
+	for _, caveat := range params.Classification.RegionCaveats {
+		metrics.QualityFlags = append(metrics.QualityFlags, "problematic_region:"+string(caveat.Category))
+	}
 
 	return metrics
 }
 
 func (t *GenerateReportTool) generateRecommendations(params *GenerateReportParams) []string {
+	locale := i18n.Locale(params.Locale)
 	recommendations := []string{
-		"Follow ACMG/AMP guidelines for variant interpretation",
-		"Consider family testing if clinically indicated",
+		locale.T("report.recommendation.guidelines"),
+		locale.T("report.recommendation.family_testing"),
 	}
 
 	if params.Classification.Classification == "VUS" {
 		recommendations = append(recommendations, 
-			"Periodic re-evaluation of variant as new evidence becomes available",
-			"Consider functional studies if clinically warranted")
+			locale.T("report.recommendation.reevaluation"),
+			locale.T("report.recommendation.functional_studies"))
 	}
 
 	return recommendations
 }
 
 func (t *GenerateReportTool) generateDisclaimers(params *GenerateReportParams) []string {
+	locale := i18n.Locale(params.Locale)
 	return []string{
-		"This report is for research/clinical decision support purposes only",
-		"Classification may change as new evidence becomes available",
-		"Clinical decisions should consider additional patient-specific factors",
-		"Report generated using automated ACMG/AMP classification algorithms",
+		locale.T("report.disclaimer.purpose"),
+		locale.T("report.disclaimer.may_change"),
+		locale.T("report.disclaimer.patient_factors"),
+		locale.T("report.disclaimer.automated"),
 	}
 }
 
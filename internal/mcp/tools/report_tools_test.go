@@ -2,14 +2,22 @@ package tools
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/i18n"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/regions"
+	"github.com/acmg-amp-mcp-server/internal/service"
 )
 
 // TestGenerateReportTool tests the generate_report tool functionality
@@ -152,6 +160,235 @@ func TestGenerateReportTool(t *testing.T) {
 	}
 }
 
+// TestGenerateReportTool_RegionCaveats tests that problematic region caveats reach the report
+func TestGenerateReportTool_RegionCaveats(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	tool := NewGenerateReportTool(logger)
+
+	caveat := regions.Caveat{
+		Category: regions.CategoryENCODEBlacklist,
+		Track:    "encode_blacklist",
+		Assembly: regions.GRCh38,
+		Region:   "chr1:1001-2000",
+		Message:  "Variant lies in an ENCODE blacklist region with anomalous, artifactual signal (chr1:1001-2000)",
+	}
+	params := GenerateReportParams{
+		HGVSNotation: "NC_000001.11:g.1500A>G",
+		Classification: ClassifyVariantResult{
+			Classification: "VUS",
+			Confidence:     "Low",
+			RegionCaveats:  []regions.Caveat{caveat},
+		},
+		ReportTemplate: "clinical",
+	}
+
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{Method: "generate_report", Params: params})
+	require.Nil(t, response.Error)
+
+	resultMap := response.Result.(map[string]interface{})
+	report := resultMap["report"].(*ReportResult)
+	assert.Contains(t, report.Summary.LimitationsNoted, caveat.Message)
+	assert.Contains(t, report.QualityMetrics.QualityFlags, "problematic_region:encode_blacklist")
+}
+
+// TestGenerateReportTool_OutputFormat tests rendering reports as documents
+func TestGenerateReportTool_OutputFormat(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	tool := NewGenerateReportTool(logger)
+	exportDir := t.TempDir()
+	tool.SetExportDir(exportDir)
+
+	params := GenerateReportParams{
+		HGVSNotation: "NM_007294.4:c.5266dup",
+		GeneSymbol:   "BRCA1",
+		Classification: ClassifyVariantResult{
+			Classification:  "LIKELY_PATHOGENIC",
+			Confidence:      "High",
+			EvidenceSummary: "Frameshift predicted to cause loss of function",
+			AppliedRules: []ACMGAMPRuleResult{
+				{RuleCode: "PVS1", Strength: "VERY_STRONG", Applied: true, Reasoning: "Null variant in a gene where LOF is a known mechanism of disease"},
+				{RuleCode: "PS3", Strength: "STRONG", Applied: false},
+			},
+		},
+		ClinicalContext: &ClinicalContext{PatientID: "P-001", ClinicalIndication: "Family history of breast cancer"},
+		DetailLevel:     "comprehensive",
+	}
+
+	t.Run("markdown", func(t *testing.T) {
+		params.OutputFormat = "markdown"
+		response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{Method: "generate_report", Params: params})
+		require.Nil(t, response.Error)
+
+		resultMap := response.Result.(map[string]interface{})
+		document := resultMap["document"].(*ReportDocument)
+		assert.Equal(t, "markdown", document.Format)
+		assert.Contains(t, document.Content, "# Germline Variant Interpretation Report")
+		assert.Contains(t, document.Content, "Likely pathogenic")
+		assert.Contains(t, document.Content, "- **Patient ID:** P-001")
+		assert.Contains(t, document.Content, "| PVS1 | Very strong | Null variant in a gene where LOF is a known mechanism of disease |")
+		assert.Contains(t, document.Content, "## ACMG/AMP Criteria Not Met")
+		assert.FileExists(t, document.FilePath)
+	})
+
+	t.Run("pdf", func(t *testing.T) {
+		params.OutputFormat = "PDF"
+		response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{Method: "generate_report", Params: params})
+		require.Nil(t, response.Error)
+
+		document := response.Result.(map[string]interface{})["document"].(*ReportDocument)
+		assert.Equal(t, "application/pdf", document.MimeType)
+		assert.Empty(t, document.Content)
+		content, err := base64.StdEncoding.DecodeString(document.ContentBase64)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(content), "%PDF-"))
+		assert.Equal(t, ".pdf", filepath.Ext(document.FilePath))
+	})
+
+	t.Run("json by default", func(t *testing.T) {
+		params.OutputFormat = ""
+		response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{Method: "generate_report", Params: params})
+		require.Nil(t, response.Error)
+		assert.NotContains(t, response.Result.(map[string]interface{}), "document")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		params.OutputFormat = "rtf"
+		response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{Method: "generate_report", Params: params})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, protocol.InvalidParams, response.Error.Code)
+	})
+}
+
+// TestGenerateReportTool_Locale tests that report text follows the requested
+// or configured locale while classification text is kept as given
+func TestGenerateReportTool_Locale(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	tool := NewGenerateReportTool(logger)
+
+	params := GenerateReportParams{
+		HGVSNotation: "NM_007294.4:c.5266dup",
+		Classification: ClassifyVariantResult{
+			Classification: "VUS",
+			AppliedRules: []ACMGAMPRuleResult{
+				{RuleCode: "PM2", Strength: "SUPPORTING", Applied: true, Reasoning: "Absent from gnomAD"},
+			},
+		},
+		ClinicalContext: &ClinicalContext{PatientID: "P-001"},
+		OutputFormat:    "markdown",
+		Locale:          "ja-JP",
+	}
+
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{Method: "generate_report", Params: params})
+	require.Nil(t, response.Error)
+	report := response.Result.(map[string]interface{})["report"].(*ReportResult)
+	document := response.Result.(map[string]interface{})["document"].(*ReportDocument)
+	assert.Contains(t, document.Content, "# 生殖細胞系列バリアント解釈報告書")
+	assert.Contains(t, document.Content, "- **患者ID:** P-001")
+	assert.Contains(t, document.Content, "意義不明")
+	assert.Contains(t, document.Content, "| PM2 | 支持的 | Absent from gnomAD |")
+	assert.Contains(t, report.Disclaimers, "新しいエビデンスが得られた場合、分類が変更されることがあります")
+	assert.Contains(t, report.Recommendations, "臨床的に妥当な場合は機能解析を検討してください")
+
+	// Without a locale parameter the request's locale applies
+	params.Locale = ""
+	ctx := i18n.WithLocale(context.Background(), i18n.TraditionalChinese)
+	response = tool.HandleTool(ctx, &protocol.JSONRPC2Request{Method: "generate_report", Params: params})
+	require.Nil(t, response.Error)
+	document = response.Result.(map[string]interface{})["document"].(*ReportDocument)
+	assert.Contains(t, document.Content, "# 生殖細胞系變異解讀報告")
+	assert.Contains(t, document.Content, "## 限制")
+
+	params.Locale = "zh-CN"
+	response = tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{Method: "generate_report", Params: params})
+	require.NotNil(t, response.Error)
+	assert.Equal(t, protocol.InvalidParams, response.Error.Code)
+}
+
+// TestGenerateReportTool_SomaticDocument tests that somatic reports give the tier and its evidence
+func TestGenerateReportTool_SomaticDocument(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	tool := NewGenerateReportTool(logger)
+
+	params := GenerateReportParams{
+		HGVSNotation: "NM_004333.6:c.1799T>A",
+		GeneSymbol:   "BRAF",
+		Classification: ClassifyVariantResult{
+			Classification:        "TIER_I",
+			Confidence:            "High",
+			ClassificationContext: "somatic",
+			Somatic: &service.SomaticAssessment{
+				Tier:          domain.TIER_I,
+				TierLabel:     domain.TIER_I.Label(),
+				EvidenceLevel: domain.LEVEL_A,
+				TumorType:     "Melanoma",
+				Rationale:     "Level A therapeutic evidence in Melanoma",
+				Sources:       []string{"OncoKB"},
+				Evidence: []domain.SomaticEvidence{{
+					Source: "OncoKB", SourceLevel: "LEVEL_1", Level: domain.LEVEL_A, Type: domain.SomaticTherapeutic,
+					Significance: "sensitivity", TumorType: "Melanoma", Therapies: []string{"Dabrafenib", "Trametinib"},
+				}},
+			},
+		},
+		OutputFormat: "html",
+	}
+
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{Method: "generate_report", Params: params})
+	require.Nil(t, response.Error)
+
+	document := response.Result.(map[string]interface{})["document"].(*ReportDocument)
+	assert.Contains(t, document.Content, "<h1>Somatic Variant Interpretation Report</h1>")
+	assert.Contains(t, document.Content, "<h2>AMP/ASCO/CAP Tier Rationale</h2>")
+	assert.Contains(t, document.Content, "<td>Dabrafenib, Trametinib</td>")
+	assert.NotContains(t, document.Content, "ACMG/AMP Criteria Met")
+	assert.Empty(t, document.FilePath, "no export directory configured")
+}
+
+// TestGenerateReportTool_OverriddenCriteria tests that manually overridden
+// criteria are marked and listed with their justification
+func TestGenerateReportTool_OverriddenCriteria(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	tool := NewGenerateReportTool(logger)
+	overriddenAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	params := GenerateReportParams{
+		HGVSNotation: "NM_007294.4:c.5266dup",
+		Classification: ClassifyVariantResult{
+			Classification: "LIKELY_PATHOGENIC",
+			AppliedRules: []ACMGAMPRuleResult{
+				{RuleCode: "PVS1", Strength: "VERY_STRONG", Applied: true, Reasoning: "Null variant"},
+				{RuleCode: "PS3", Strength: "MODERATE", Applied: true, Reasoning: "Manual override by curator-1: Assay not validated",
+					Override: &CriterionOverride{Action: OverrideChangeStrength, Justification: "Assay not validated", OverriddenBy: "curator-1",
+						OverriddenAt: overriddenAt, Version: 1, OriginalApplied: true, OriginalStrength: "STRONG"}},
+				{RuleCode: "PP3", Strength: "SUPPORTING", Applied: false,
+					Override: &CriterionOverride{Action: OverrideRemove, Justification: "Predictors discordant", OverriddenBy: "curator-1",
+						OverriddenAt: overriddenAt, Version: 1, OriginalApplied: true, OriginalStrength: "SUPPORTING"}},
+			},
+		},
+		OutputFormat: "markdown",
+	}
+
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{Method: "generate_report", Params: params})
+	require.Nil(t, response.Error)
+	document := response.Result.(map[string]interface{})["document"].(*ReportDocument)
+	assert.Contains(t, document.Content, "| PS3 (manual override) | Moderate | Manual override by curator-1: Assay not validated |")
+	assert.Contains(t, document.Content, "| PVS1 | Very strong | Null variant |")
+	assert.Contains(t, document.Content, "## Manual Criterion Overrides")
+	assert.Contains(t, document.Content, "| PS3 | Strength changed from Strong to Moderate | Assay not validated | curator-1 | 2026-03-02 |")
+	assert.Contains(t, document.Content, "| PP3 | Removed (was Supporting) | Predictors discordant | curator-1 | 2026-03-02 |")
+
+	// Without overrides there is no overrides section
+	params.Classification.AppliedRules = params.Classification.AppliedRules[:1]
+	response = tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{Method: "generate_report", Params: params})
+	require.Nil(t, response.Error)
+	document = response.Result.(map[string]interface{})["document"].(*ReportDocument)
+	assert.NotContains(t, document.Content, "Manual Criterion Overrides")
+}
+
 // TestFormatReportTool tests the format_report tool functionality
 func TestFormatReportTool(t *testing.T) {
 	logger := logrus.New()
@@ -0,0 +1,187 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Transport type identifiers used for response size limits
+const (
+	TransportStdio     = "stdio"
+	TransportHTTPSSE   = "http-sse"
+	TransportWebSocket = "websocket"
+)
+
+// maxSummarizedClinVarEntries caps the ClinVar entries kept in a summarized evidence result
+const maxSummarizedClinVarEntries = 5
+
+// ResponseTruncation describes how an oversized tool result was reduced
+type ResponseTruncation struct {
+	Truncated     bool     `json:"truncated"`
+	Transport     string   `json:"transport"`
+	OriginalBytes int      `json:"original_bytes"`
+	MaxBytes      int      `json:"max_bytes"`
+	Message       string   `json:"message"`
+	OmittedFields []string `json:"omitted_fields,omitempty"`
+	ResourceLinks []string `json:"resource_links,omitempty"`
+}
+
+// ResponseLimiter enforces per-transport size limits on tool results.
+// Results exceeding the limit for the active transport are replaced with a
+// summary that links to paginated sub-resources holding the full data.
+type ResponseLimiter struct {
+	logger    *logrus.Logger
+	limits    map[string]int
+	transport string
+	mu        sync.RWMutex
+}
+
+// NewResponseLimiter creates a response limiter with byte limits keyed by transport type.
+// A limit of zero or less disables summarization for that transport.
+func NewResponseLimiter(logger *logrus.Logger, limits map[string]int) *ResponseLimiter {
+	copied := make(map[string]int, len(limits))
+	for transport, limit := range limits {
+		copied[transport] = limit
+	}
+	return &ResponseLimiter{
+		logger:    logger,
+		limits:    copied,
+		transport: TransportStdio,
+	}
+}
+
+// SetTransport sets the transport whose limit applies to subsequent responses
+func (rl *ResponseLimiter) SetTransport(transport string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.transport = transport
+}
+
+// MaxBytes returns the size limit for the active transport
+func (rl *ResponseLimiter) MaxBytes() int {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	return rl.limits[rl.transport]
+}
+
+// Apply returns the result unchanged if it fits within the active transport's
+// limit, otherwise a summarized version of it.
+func (rl *ResponseLimiter) Apply(toolName string, result interface{}) interface{} {
+	rl.mu.RLock()
+	transport := rl.transport
+	limit := rl.limits[transport]
+	rl.mu.RUnlock()
+
+	if limit <= 0 || result == nil {
+		return result
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil || len(data) <= limit {
+		return result
+	}
+
+	truncation := &ResponseTruncation{
+		Truncated:     true,
+		Transport:     transport,
+		OriginalBytes: len(data),
+		MaxBytes:      limit,
+	}
+
+	summarized := rl.summarize(result, truncation)
+	if summarizedData, err := json.Marshal(summarized); err != nil || len(summarizedData) > limit {
+		// Summary still too large; fall back to listing the omitted fields only
+		summarized = rl.summarizeGeneric(result, truncation)
+	}
+
+	rl.logger.WithFields(logrus.Fields{
+		"tool":           toolName,
+		"transport":      transport,
+		"original_bytes": len(data),
+		"max_bytes":      limit,
+	}).Warn("Tool response exceeded transport size limit, returning summary")
+
+	return summarized
+}
+
+// summarize dispatches to a result-specific summarizer
+func (rl *ResponseLimiter) summarize(result interface{}, truncation *ResponseTruncation) interface{} {
+	if resultMap, ok := result.(map[string]interface{}); ok {
+		if evidence, ok := resultMap["evidence"].(*QueryEvidenceResult); ok && evidence != nil {
+			return map[string]interface{}{
+				"evidence":   summarizeEvidence(evidence, truncation),
+				"truncation": truncation,
+			}
+		}
+	}
+	return rl.summarizeGeneric(result, truncation)
+}
+
+// summarizeGeneric replaces the result with its top-level field names
+func (rl *ResponseLimiter) summarizeGeneric(result interface{}, truncation *ResponseTruncation) interface{} {
+	truncation.OmittedFields = topLevelFields(result)
+	truncation.ResourceLinks = nil
+	truncation.Message = fmt.Sprintf("Response of %d bytes exceeds the %d byte limit for the %s transport; narrow the request to retrieve the full result",
+		truncation.OriginalBytes, truncation.MaxBytes, truncation.Transport)
+	return map[string]interface{}{
+		"truncation": truncation,
+	}
+}
+
+// summarizeEvidence drops bulky raw data from an evidence result while keeping
+// the synthesis, criteria hints and quality scores
+func summarizeEvidence(evidence *QueryEvidenceResult, truncation *ResponseTruncation) *QueryEvidenceResult {
+	summary := *evidence
+	summary.DatabaseResults = nil
+	summary.AggregatedEvidence.FunctionalEvidence.FunctionalStudies = nil
+	summary.AggregatedEvidence.LiteratureEvidence.PubMedCitations = nil
+	if len(summary.AggregatedEvidence.ClinicalEvidence.ClinVarEntries) > maxSummarizedClinVarEntries {
+		summary.AggregatedEvidence.ClinicalEvidence.ClinVarEntries =
+			summary.AggregatedEvidence.ClinicalEvidence.ClinVarEntries[:maxSummarizedClinVarEntries]
+	}
+
+	variantID := url.PathEscape(evidence.HGVSNotation)
+	if variantID == "" {
+		variantID = url.PathEscape(evidence.VariantID)
+	}
+
+	truncation.OmittedFields = []string{
+		"database_results",
+		"aggregated_evidence.functional_evidence.functional_studies",
+		"aggregated_evidence.literature_evidence.pubmed_citations",
+	}
+	truncation.ResourceLinks = []string{
+		fmt.Sprintf("/evidence/%s/clinical", variantID),
+		fmt.Sprintf("/evidence/%s/functional", variantID),
+		fmt.Sprintf("/evidence/%s/literature/page/1", variantID),
+	}
+	truncation.Message = fmt.Sprintf("Evidence response of %d bytes exceeds the %d byte limit for the %s transport; raw data was omitted and is available from the linked resources",
+		truncation.OriginalBytes, truncation.MaxBytes, truncation.Transport)
+
+	return &summary
+}
+
+// topLevelFields returns the sorted top-level JSON field names of a result
+func topLevelFields(result interface{}) []string {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func newLargeEvidenceResult() *QueryEvidenceResult {
+	citations := make([]PubMedCitation, 200)
+	for i := range citations {
+		citations[i] = PubMedCitation{
+			PMID:  "12345678",
+			Title: strings.Repeat("Functional characterization of variant ", 10),
+		}
+	}
+
+	return &QueryEvidenceResult{
+		VariantID:    "VAR_1",
+		HGVSNotation: "NM_000492.3:c.1521_1523delCTT",
+		DatabaseResults: map[string]interface{}{
+			"clinvar": strings.Repeat("x", 10000),
+		},
+		AggregatedEvidence: AggregatedEvidence{
+			LiteratureEvidence: LiteratureEvidenceData{
+				PubMedCitations: citations,
+				TotalCitations:  len(citations),
+			},
+		},
+		Synthesis: "Evidence supports pathogenicity",
+	}
+}
+
+// TestResponseLimiter_UnderLimit tests that small results pass through unchanged
+func TestResponseLimiter_UnderLimit(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	limiter := NewResponseLimiter(logger, map[string]int{TransportStdio: 1024})
+
+	result := map[string]interface{}{"status": "ok"}
+	limited := limiter.Apply("validate_hgvs", result)
+
+	resultMap, ok := limited.(map[string]interface{})
+	if !ok || resultMap["status"] != "ok" {
+		t.Errorf("Expected result unchanged, got: %v", limited)
+	}
+}
+
+// TestResponseLimiter_SummarizesEvidence tests evidence summarization with resource links
+func TestResponseLimiter_SummarizesEvidence(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	limiter := NewResponseLimiter(logger, map[string]int{
+		TransportStdio:   8 * 1024,
+		TransportHTTPSSE: 1024 * 1024,
+	})
+
+	evidence := newLargeEvidenceResult()
+	limited := limiter.Apply("query_evidence", map[string]interface{}{"evidence": evidence})
+
+	resultMap := limited.(map[string]interface{})
+	summary, ok := resultMap["evidence"].(*QueryEvidenceResult)
+	if !ok {
+		t.Fatalf("Expected summarized evidence, got: %T", resultMap["evidence"])
+	}
+	if summary.DatabaseResults != nil {
+		t.Error("Expected database results to be omitted")
+	}
+	if len(summary.AggregatedEvidence.LiteratureEvidence.PubMedCitations) != 0 {
+		t.Error("Expected citations to be omitted")
+	}
+	if summary.AggregatedEvidence.LiteratureEvidence.TotalCitations != 200 {
+		t.Errorf("Expected citation count to be kept, got: %d", summary.AggregatedEvidence.LiteratureEvidence.TotalCitations)
+	}
+	if summary.Synthesis == "" {
+		t.Error("Expected synthesis to be kept")
+	}
+	if len(evidence.AggregatedEvidence.LiteratureEvidence.PubMedCitations) != 200 {
+		t.Error("Expected original evidence result to be left intact")
+	}
+
+	truncation := resultMap["truncation"].(*ResponseTruncation)
+	if !truncation.Truncated || truncation.Transport != TransportStdio {
+		t.Errorf("Unexpected truncation info: %+v", truncation)
+	}
+	expectedLink := "/evidence/NM_000492.3:c.1521_1523delCTT/literature/page/1"
+	found := false
+	for _, link := range truncation.ResourceLinks {
+		if link == expectedLink {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected link %s, got: %v", expectedLink, truncation.ResourceLinks)
+	}
+}
+
+// TestResponseLimiter_PerTransport tests that the active transport selects the limit
+func TestResponseLimiter_PerTransport(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	limiter := NewResponseLimiter(logger, map[string]int{
+		TransportStdio:   8 * 1024,
+		TransportHTTPSSE: 1024 * 1024,
+	})
+	limiter.SetTransport(TransportHTTPSSE)
+
+	result := map[string]interface{}{"evidence": newLargeEvidenceResult()}
+	limited := limiter.Apply("query_evidence", result).(map[string]interface{})
+
+	if _, truncated := limited["truncation"]; truncated {
+		t.Error("Expected no truncation under the http-sse limit")
+	}
+}
+
+// TestResponseLimiter_GenericFallback tests summarization of unknown result shapes
+func TestResponseLimiter_GenericFallback(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	limiter := NewResponseLimiter(logger, map[string]int{TransportStdio: 256})
+
+	result := map[string]interface{}{
+		"report":   strings.Repeat("a", 1000),
+		"metadata": "info",
+	}
+	limited := limiter.Apply("generate_report", result).(map[string]interface{})
+
+	truncation, ok := limited["truncation"].(*ResponseTruncation)
+	if !ok {
+		t.Fatalf("Expected truncation info, got: %v", limited)
+	}
+	if len(truncation.OmittedFields) != 2 || truncation.OmittedFields[0] != "metadata" {
+		t.Errorf("Expected omitted fields [metadata report], got: %v", truncation.OmittedFields)
+	}
+}
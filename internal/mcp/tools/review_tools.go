@@ -0,0 +1,425 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/review"
+)
+
+// Review decisions accepted by review_classification
+const (
+	ReviewApprove = "approve"
+	ReviewReturn  = "return"
+)
+
+// reviewStoreError maps review store errors to tool responses
+func reviewStoreError(logger *logrus.Logger, action string, err error) *protocol.JSONRPC2Response {
+	switch {
+	case errors.Is(err, review.ErrNotFound):
+		return invalidParamsError("Review not found", err.Error())
+	case errors.Is(err, review.ErrInvalidReview),
+		errors.Is(err, review.ErrAlreadyInReview),
+		errors.Is(err, review.ErrSelfReview),
+		errors.Is(err, review.ErrAlreadyReviewed):
+		return invalidParamsError(err.Error())
+	}
+	logger.WithError(err).Errorf("Failed to %s", action)
+	return internalError("Failed to "+action, err.Error())
+}
+
+// actingUser returns who a call acts for, such as a review's submitter or a
+// playbook's editor: the authenticated user when the request is
+// authenticated, otherwise the ID given in the parameters. The ID is not
+// trusted when authentication is required.
+func actingUser(ctx context.Context, id string, authRequired bool) (string, error) {
+	if principal := auth.PrincipalFrom(ctx); principal != nil {
+		return principal.Subject, nil
+	}
+	if authRequired {
+		return "", auth.ErrUnauthenticated
+	}
+	return strings.TrimSpace(id), nil
+}
+
+// unauthenticatedError answers a call that must come from an authenticated user
+func unauthenticatedError(err error) *protocol.JSONRPC2Response {
+	return &protocol.JSONRPC2Response{
+		Error: &protocol.RPCError{
+			Code:    protocol.MCPUnauthorized,
+			Message: "Authentication required",
+			Data:    err.Error(),
+		},
+	}
+}
+
+// =============================================================================
+// Submit For Review Tool
+// =============================================================================
+
+// SubmitForReviewTool implements the submit_for_review MCP tool
+type SubmitForReviewTool struct {
+	logger       *logrus.Logger
+	store        review.Store
+	audit        audit.Store
+	authRequired bool
+}
+
+// SubmitForReviewParams defines parameters for the submit_for_review tool
+type SubmitForReviewParams struct {
+	ClassificationID int64  `json:"classification_id"`
+	SubmittedBy      string `json:"submitted_by,omitempty"` // Used when authentication is not required
+	Note             string `json:"note,omitempty"`
+}
+
+// NewSubmitForReviewTool creates a new submit_for_review tool
+func NewSubmitForReviewTool(logger *logrus.Logger, store review.Store, auditStore audit.Store) *SubmitForReviewTool {
+	return &SubmitForReviewTool{
+		logger: logger,
+		store:  store,
+		audit:  auditStore,
+	}
+}
+
+// SetAuthenticationRequired refuses submissions without an authenticated
+// principal instead of trusting submitted_by
+func (t *SubmitForReviewTool) SetAuthenticationRequired(required bool) {
+	t.authRequired = required
+}
+
+// GetToolInfo returns the tool information for submit_for_review
+func (t *SubmitForReviewTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "submit_for_review",
+		Description: "Place a recorded classification in pending review for dual-review sign-out. A second user with the reviewer role approves it or returns it with comments; when review is required only approved classifications can be exported as reports.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"classification_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "Audit record ID of the classification, e.g. from query_audit_trail, override_criterion or finalize_classification",
+				},
+				"submitted_by": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the analyst submitting the classification. Ignored when the request is authenticated; the authenticated user is recorded instead",
+				},
+				"note": map[string]interface{}{
+					"type":        "string",
+					"description": "Note for the reviewer",
+				},
+			},
+			"required": []string{"classification_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *SubmitForReviewTool) ValidateParams(params interface{}) error {
+	var p SubmitForReviewParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.ClassificationID <= 0 {
+		return fmt.Errorf("classification_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the submit_for_review tool request
+func (t *SubmitForReviewTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params SubmitForReviewParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	submitter, err := actingUser(ctx, params.SubmittedBy, t.authRequired)
+	if err != nil {
+		return unauthenticatedError(err)
+	}
+	if submitter == "" {
+		return invalidParamsError("Invalid parameters", "submitted_by is required when the request is not authenticated")
+	}
+
+	record, err := t.audit.Get(ctx, params.ClassificationID)
+	if err != nil {
+		return auditStoreError(t.logger, "get audit record", err)
+	}
+	if record.Error != "" {
+		return invalidParamsError("Classification failed; there is nothing to review", record.Error)
+	}
+
+	submission := &review.Review{
+		ClassificationID: record.ID,
+		VariantID:        record.VariantID,
+		HGVSNotation:     record.HGVSNotation,
+		Classification:   record.Classification,
+		Confidence:       record.Confidence,
+		SubmittedBy:      submitter,
+		SubmissionNote:   params.Note,
+	}
+	if err := t.store.Submit(ctx, submission); err != nil {
+		return reviewStoreError(t.logger, "submit classification for review", err)
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"review_id":         submission.ID,
+		"classification_id": record.ID,
+		"submitted_by":      submitter,
+	}).Info("Classification submitted for review")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"review": submission,
+		},
+	}
+}
+
+// =============================================================================
+// Review Classification Tool
+// =============================================================================
+
+// ReviewClassificationTool implements the review_classification MCP tool
+type ReviewClassificationTool struct {
+	logger       *logrus.Logger
+	store        review.Store
+	authRequired bool
+}
+
+// ReviewClassificationParams defines parameters for the review_classification tool
+type ReviewClassificationParams struct {
+	ReviewID   int64  `json:"review_id"`
+	Decision   string `json:"decision"` // approve or return
+	Comments   string `json:"comments,omitempty"`
+	ReviewerID string `json:"reviewer_id,omitempty"` // Used when authentication is not required
+}
+
+// NewReviewClassificationTool creates a new review_classification tool
+func NewReviewClassificationTool(logger *logrus.Logger, store review.Store) *ReviewClassificationTool {
+	return &ReviewClassificationTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// SetAuthenticationRequired refuses decisions without an authenticated
+// principal instead of trusting reviewer_id
+func (t *ReviewClassificationTool) SetAuthenticationRequired(required bool) {
+	t.authRequired = required
+}
+
+// GetToolInfo returns the tool information for review_classification
+func (t *ReviewClassificationTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "review_classification",
+		Description: "Approve a classification pending review, signing it out for reporting, or return it to the submitter with comments. The reviewer must differ from the analyst who submitted it. Over HTTP this requires the reviewer role.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"review_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID of the review, from submit_for_review or list_reviews",
+				},
+				"decision": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{ReviewApprove, ReviewReturn},
+					"description": "approve to sign out the classification, or return it for correction",
+				},
+				"comments": map[string]interface{}{
+					"type":        "string",
+					"description": "Reviewer comments; required to return a classification",
+				},
+				"reviewer_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the reviewer. Ignored when the request is authenticated; the authenticated user is recorded instead",
+				},
+			},
+			"required": []string{"review_id", "decision"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ReviewClassificationTool) ValidateParams(params interface{}) error {
+	var p ReviewClassificationParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.ReviewID <= 0 {
+		return fmt.Errorf("review_id is required")
+	}
+	switch p.Decision {
+	case ReviewApprove:
+	case ReviewReturn:
+		if strings.TrimSpace(p.Comments) == "" {
+			return fmt.Errorf("comments are required to return a classification")
+		}
+	default:
+		return fmt.Errorf("decision must be %s or %s", ReviewApprove, ReviewReturn)
+	}
+	return nil
+}
+
+// HandleTool handles the review_classification tool request
+func (t *ReviewClassificationTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ReviewClassificationParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	reviewer, err := actingUser(ctx, params.ReviewerID, t.authRequired)
+	if err != nil {
+		return unauthenticatedError(err)
+	}
+	if reviewer == "" {
+		return invalidParamsError("Invalid parameters", "reviewer_id is required when the request is not authenticated")
+	}
+
+	status := review.StatusApproved
+	if params.Decision == ReviewReturn {
+		status = review.StatusReturned
+	}
+	decided, err := t.store.Decide(ctx, params.ReviewID, status, reviewer, params.Comments)
+	if err != nil {
+		return reviewStoreError(t.logger, "review classification", err)
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"review_id":         decided.ID,
+		"classification_id": decided.ClassificationID,
+		"status":            decided.Status,
+		"reviewed_by":       reviewer,
+	}).Info("Classification reviewed")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"review": decided,
+		},
+	}
+}
+
+// =============================================================================
+// List Reviews Tool
+// =============================================================================
+
+// ListReviewsTool implements the list_reviews MCP tool
+type ListReviewsTool struct {
+	logger *logrus.Logger
+	store  review.Store
+}
+
+// ListReviewsParams defines parameters for the list_reviews tool
+type ListReviewsParams struct {
+	Status           string `json:"status,omitempty"` // Defaults to pending_review; "all" lists every status
+	ClassificationID int64  `json:"classification_id,omitempty"`
+	Variant          string `json:"variant,omitempty"`
+	Limit            int    `json:"limit,omitempty"`
+}
+
+// NewListReviewsTool creates a new list_reviews tool
+func NewListReviewsTool(logger *logrus.Logger, store review.Store) *ListReviewsTool {
+	return &ListReviewsTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for list_reviews
+func (t *ListReviewsTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "list_reviews",
+		Description: "List classification reviews, oldest first. By default lists the review queue: classifications pending review.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"status": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{string(review.StatusPending), string(review.StatusApproved), string(review.StatusReturned), "all"},
+					"default":     string(review.StatusPending),
+					"description": "Reviews to list",
+				},
+				"classification_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "Only reviews of this audit record",
+				},
+				"variant": map[string]interface{}{
+					"type":        "string",
+					"description": "Only reviews of this variant ID or HGVS notation",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Maximum reviews to return (default %d)", review.DefaultListLimit),
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ListReviewsTool) ValidateParams(params interface{}) error {
+	var p ListReviewsParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	_, err := p.filter()
+	return err
+}
+
+// filter converts the parameters to a review filter
+func (p *ListReviewsParams) filter() (review.Filter, error) {
+	filter := review.Filter{
+		Status:           review.StatusPending,
+		ClassificationID: p.ClassificationID,
+		Variant:          p.Variant,
+		Limit:            p.Limit,
+	}
+	switch strings.ToLower(strings.TrimSpace(p.Status)) {
+	case "":
+	case "all":
+		filter.Status = ""
+	default:
+		status, err := review.ParseStatus(p.Status)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = status
+	}
+	if p.Limit < 0 {
+		return filter, fmt.Errorf("limit must not be negative")
+	}
+	return filter, nil
+}
+
+// HandleTool handles the list_reviews tool request
+func (t *ListReviewsTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ListReviewsParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	filter, err := params.filter()
+	if err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	reviews, err := t.store.List(ctx, filter)
+	if err != nil {
+		return reviewStoreError(t.logger, "list reviews", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"reviews": reviews,
+			"count":   len(reviews),
+		},
+	}
+}
@@ -0,0 +1,293 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/review"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+func createTestReviewStore(t *testing.T) *review.SQLiteStore {
+	t.Helper()
+
+	store, err := review.NewSQLiteStore(filepath.Join(t.TempDir(), "reviews.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestReviewTools_DualReviewSignOut(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	auditStore := createTestAuditStore(t)
+	reviews := createTestReviewStore(t)
+	submit := NewSubmitForReviewTool(logger, reviews, auditStore)
+	decide := NewReviewClassificationTool(logger, reviews)
+	list := NewListReviewsTool(logger, reviews)
+	report := NewGenerateReportTool(logger)
+	report.SetReviewStore(reviews, auditStore)
+
+	record := &audit.Record{
+		VariantID:      "VAR_1",
+		HGVSNotation:   "NM_007294.4:c.5266dup",
+		Request:        json.RawMessage(`{"hgvs_notation":"NM_007294.4:c.5266dup"}`),
+		Classification: "PATHOGENIC",
+		Confidence:     "High",
+		EngineVersion:  service.EngineVersion,
+	}
+	require.NoError(t, auditStore.Append(ctx, record))
+	reportParams := map[string]interface{}{
+		"hgvs_notation":     "NM_007294.4:c.5266dup",
+		"classification_id": record.ID,
+		"classification":    map[string]interface{}{"classification": "PATHOGENIC", "confidence": "High"},
+		"output_format":     "markdown",
+	}
+
+	// Act: an authenticated analyst submits the classification
+	analyst := auth.WithPrincipal(ctx, &auth.Principal{Subject: "analyst-1", Role: auth.RoleClassify})
+	response := submit.HandleTool(analyst, toolRequest("submit_for_review", map[string]interface{}{
+		"classification_id": record.ID,
+		"note":              "PVS1 and PS4 met",
+	}))
+
+	// Assert: it waits in the review queue and cannot be reported yet
+	require.Nil(t, response.Error)
+	pending := response.Result.(map[string]interface{})["review"].(*review.Review)
+	assert.Equal(t, review.StatusPending, pending.Status)
+	assert.Equal(t, "analyst-1", pending.SubmittedBy)
+	assert.Equal(t, "PATHOGENIC", pending.Classification)
+
+	queue := list.HandleTool(ctx, toolRequest("list_reviews", map[string]interface{}{}))
+	require.Nil(t, queue.Error)
+	assert.Equal(t, 1, queue.Result.(map[string]interface{})["count"])
+
+	unapproved := report.HandleTool(ctx, toolRequest("generate_report", reportParams))
+	require.NotNil(t, unapproved.Error)
+	assert.Equal(t, "Classification not approved for reporting", unapproved.Error.Message)
+
+	again := submit.HandleTool(analyst, toolRequest("submit_for_review", map[string]interface{}{"classification_id": record.ID}))
+	require.NotNil(t, again.Error, "already pending review")
+
+	// The submitter cannot approve their own classification
+	self := decide.HandleTool(analyst, toolRequest("review_classification", map[string]interface{}{
+		"review_id": pending.ID, "decision": ReviewApprove,
+	}))
+	require.NotNil(t, self.Error)
+
+	reviewer := auth.WithPrincipal(ctx, &auth.Principal{Subject: "reviewer-1", Role: auth.RoleReviewer})
+	response = decide.HandleTool(reviewer, toolRequest("review_classification", map[string]interface{}{
+		"review_id": pending.ID, "decision": ReviewApprove, "reviewer_id": "ignored",
+	}))
+	require.Nil(t, response.Error)
+	approved := response.Result.(map[string]interface{})["review"].(*review.Review)
+	assert.Equal(t, review.StatusApproved, approved.Status)
+	assert.Equal(t, "reviewer-1", approved.ReviewedBy)
+
+	// Only the approved call is reported
+	mismatched := map[string]interface{}{}
+	for key, value := range reportParams {
+		mismatched[key] = value
+	}
+	mismatched["classification"] = map[string]interface{}{"classification": "VUS"}
+	response = report.HandleTool(ctx, toolRequest("generate_report", mismatched))
+	require.NotNil(t, response.Error)
+
+	response = report.HandleTool(ctx, toolRequest("generate_report", reportParams))
+	require.Nil(t, response.Error)
+	generated := response.Result.(map[string]interface{})["report"].(*ReportResult)
+	require.NotNil(t, generated.Review)
+	assert.Equal(t, "approved", generated.QualityMetrics.ReviewStatus)
+	document := response.Result.(map[string]interface{})["document"].(*ReportDocument)
+	assert.Contains(t, document.Content, "- **Analyst:** analyst-1")
+	assert.Contains(t, document.Content, "- **Reviewed by:** reviewer-1")
+}
+
+func TestReviewTools_ReportOnlyTheApprovedVariant(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	auditStore := createTestAuditStore(t)
+	reviews := createTestReviewStore(t)
+	submit := NewSubmitForReviewTool(logger, reviews, auditStore)
+	decide := NewReviewClassificationTool(logger, reviews)
+	report := NewGenerateReportTool(logger)
+	report.SetReviewStore(reviews, auditStore)
+
+	record := &audit.Record{
+		VariantID:      "VAR_1",
+		HGVSNotation:   "NM_007294.4:c.5266dup",
+		Request:        json.RawMessage(`{"hgvs_notation":"NM_007294.4:c.5266dup"}`),
+		AppliedRules:   json.RawMessage(`[{"rule_code":"PVS1","rule_name":"Null variant","applied":true}]`),
+		Classification: "PATHOGENIC",
+		Confidence:     "High",
+		EngineVersion:  service.EngineVersion,
+	}
+	require.NoError(t, auditStore.Append(ctx, record))
+	analyst := auth.WithPrincipal(ctx, &auth.Principal{Subject: "analyst-1", Role: auth.RoleClassify})
+	response := submit.HandleTool(analyst, toolRequest("submit_for_review", map[string]interface{}{"classification_id": record.ID}))
+	require.Nil(t, response.Error)
+	pending := response.Result.(map[string]interface{})["review"].(*review.Review)
+	reviewer := auth.WithPrincipal(ctx, &auth.Principal{Subject: "reviewer-1", Role: auth.RoleReviewer})
+	response = decide.HandleTool(reviewer, toolRequest("review_classification", map[string]interface{}{
+		"review_id": pending.ID, "decision": ReviewApprove,
+	}))
+	require.Nil(t, response.Error)
+
+	// Act: the approved ID is used to sign off a different variant with the same call
+	otherHGVS := report.HandleTool(ctx, toolRequest("generate_report", map[string]interface{}{
+		"hgvs_notation":     "NM_000059.4:c.1813dup",
+		"classification_id": record.ID,
+		"classification":    map[string]interface{}{"classification": "PATHOGENIC"},
+	}))
+	otherID := report.HandleTool(ctx, toolRequest("generate_report", map[string]interface{}{
+		"hgvs_notation":     "NM_007294.4:c.5266dup",
+		"classification_id": record.ID,
+		"classification":    map[string]interface{}{"variant_id": "VAR_2", "classification": "PATHOGENIC"},
+	}))
+
+	// Assert: neither is reported
+	require.NotNil(t, otherHGVS.Error)
+	assert.Equal(t, "Classification not approved for reporting", otherHGVS.Error.Message)
+	require.NotNil(t, otherID.Error)
+	assert.Equal(t, "Classification not approved for reporting", otherID.Error.Message)
+
+	// Criteria sent by the client are replaced with the audited ones
+	response = report.HandleTool(ctx, toolRequest("generate_report", map[string]interface{}{
+		"hgvs_notation":     "NM_007294.4:c.5266dup",
+		"classification_id": record.ID,
+		"classification": map[string]interface{}{
+			"classification": "PATHOGENIC",
+			"applied_rules":  []map[string]interface{}{{"rule_code": "PS3", "rule_name": "Functional studies", "applied": true}},
+		},
+	}))
+	require.Nil(t, response.Error)
+	generated := response.Result.(map[string]interface{})["report"].(*ReportResult)
+	assert.Equal(t, "VAR_1", generated.VariantID)
+	assert.Equal(t, []string{"PVS1 (Null variant)"}, generated.Summary.CriticalEvidence)
+}
+
+func TestReviewTools_ReturnWithComments(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	auditStore := createTestAuditStore(t)
+	reviews := createTestReviewStore(t)
+	submit := NewSubmitForReviewTool(logger, reviews, auditStore)
+	decide := NewReviewClassificationTool(logger, reviews)
+	list := NewListReviewsTool(logger, reviews)
+
+	record := &audit.Record{
+		HGVSNotation:   "NM_000546.6:c.743G>A",
+		Request:        json.RawMessage(`{"hgvs_notation":"NM_000546.6:c.743G>A"}`),
+		Classification: "LIKELY_PATHOGENIC",
+		EngineVersion:  service.EngineVersion,
+	}
+	require.NoError(t, auditStore.Append(ctx, record))
+	failed := &audit.Record{
+		HGVSNotation:  "NM_000546.6:c.743G>A",
+		Request:       json.RawMessage(`{}`),
+		Error:         "evidence gathering failed",
+		EngineVersion: service.EngineVersion,
+	}
+	require.NoError(t, auditStore.Append(ctx, failed))
+
+	// Unauthenticated requests name the submitter; failed classifications are not reviewed
+	require.NotNil(t, submit.HandleTool(ctx, toolRequest("submit_for_review", map[string]interface{}{"classification_id": record.ID})).Error)
+	require.NotNil(t, submit.HandleTool(ctx, toolRequest("submit_for_review", map[string]interface{}{
+		"classification_id": failed.ID, "submitted_by": "analyst-1",
+	})).Error)
+	response := submit.HandleTool(ctx, toolRequest("submit_for_review", map[string]interface{}{
+		"classification_id": record.ID, "submitted_by": "analyst-1",
+	}))
+	require.Nil(t, response.Error)
+	pending := response.Result.(map[string]interface{})["review"].(*review.Review)
+
+	// Act: returning needs comments
+	uncommented := decide.HandleTool(ctx, toolRequest("review_classification", map[string]interface{}{
+		"review_id": pending.ID, "decision": ReviewReturn, "reviewer_id": "reviewer-1",
+	}))
+	require.NotNil(t, uncommented.Error)
+	response = decide.HandleTool(ctx, toolRequest("review_classification", map[string]interface{}{
+		"review_id": pending.ID, "decision": ReviewReturn, "reviewer_id": "reviewer-1",
+		"comments": "PM1 hotspot evidence is not documented",
+	}))
+
+	// Assert
+	require.Nil(t, response.Error)
+	returned := response.Result.(map[string]interface{})["review"].(*review.Review)
+	assert.Equal(t, review.StatusReturned, returned.Status)
+	assert.Equal(t, "PM1 hotspot evidence is not documented", returned.Comments)
+
+	decided := decide.HandleTool(ctx, toolRequest("review_classification", map[string]interface{}{
+		"review_id": pending.ID, "decision": ReviewApprove, "reviewer_id": "reviewer-2",
+	}))
+	require.NotNil(t, decided.Error, "a returned review is closed")
+
+	response = list.HandleTool(ctx, toolRequest("list_reviews", map[string]interface{}{"status": "returned"}))
+	require.Nil(t, response.Error)
+	assert.Equal(t, 1, response.Result.(map[string]interface{})["count"])
+	response = list.HandleTool(ctx, toolRequest("list_reviews", map[string]interface{}{}))
+	require.Nil(t, response.Error)
+	assert.Equal(t, 0, response.Result.(map[string]interface{})["count"])
+	require.NotNil(t, list.HandleTool(ctx, toolRequest("list_reviews", map[string]interface{}{"status": "signed"})).Error)
+
+	// The corrected classification can be submitted again
+	resubmitted := submit.HandleTool(ctx, toolRequest("submit_for_review", map[string]interface{}{
+		"classification_id": record.ID, "submitted_by": "analyst-1",
+	}))
+	require.Nil(t, resubmitted.Error)
+}
+
+func TestReviewTools_AuthenticationRequired(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	auditStore := createTestAuditStore(t)
+	reviews := createTestReviewStore(t)
+	submit := NewSubmitForReviewTool(logger, reviews, auditStore)
+	submit.SetAuthenticationRequired(true)
+	decide := NewReviewClassificationTool(logger, reviews)
+	decide.SetAuthenticationRequired(true)
+
+	record := &audit.Record{
+		HGVSNotation:   "NM_007294.4:c.5266dup",
+		Request:        json.RawMessage(`{"hgvs_notation":"NM_007294.4:c.5266dup"}`),
+		Classification: "PATHOGENIC",
+		EngineVersion:  service.EngineVersion,
+	}
+	require.NoError(t, auditStore.Append(ctx, record))
+
+	// Act: an unauthenticated caller names the submitter
+	response := submit.HandleTool(ctx, toolRequest("submit_for_review", map[string]interface{}{
+		"classification_id": record.ID, "submitted_by": "analyst-1",
+	}))
+
+	// Assert
+	require.NotNil(t, response.Error)
+	assert.Equal(t, protocol.MCPUnauthorized, response.Error.Code)
+
+	// The submitter cannot approve their own classification by naming another reviewer
+	analyst := auth.WithPrincipal(ctx, &auth.Principal{Subject: "analyst-1", Role: auth.RoleClassify})
+	response = submit.HandleTool(analyst, toolRequest("submit_for_review", map[string]interface{}{"classification_id": record.ID}))
+	require.Nil(t, response.Error)
+	pending := response.Result.(map[string]interface{})["review"].(*review.Review)
+
+	response = decide.HandleTool(ctx, toolRequest("review_classification", map[string]interface{}{
+		"review_id": pending.ID, "decision": ReviewApprove, "reviewer_id": "reviewer-1",
+	}))
+	require.NotNil(t, response.Error)
+	assert.Equal(t, protocol.MCPUnauthorized, response.Error.Code)
+
+	response = decide.HandleTool(analyst, toolRequest("review_classification", map[string]interface{}{
+		"review_id": pending.ID, "decision": ReviewApprove, "reviewer_id": "reviewer-1",
+	}))
+	require.NotNil(t, response.Error)
+	assert.Contains(t, response.Error.Message, review.ErrSelfReview.Error())
+}
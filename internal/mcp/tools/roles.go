@@ -0,0 +1,95 @@
+package tools
+
+import "github.com/acmg-amp-mcp-server/internal/auth"
+
+// toolRoles is the role each tool requires of HTTP clients. Queries need
+// read_only; tools that classify variants or record curation need classify;
+// signing out classifications others submitted needs reviewer; tools that
+// change lab-wide data used in later classifications need admin.
+var toolRoles = map[string]auth.Role{
+	"query_evidence":             auth.RoleReadOnly,
+	"batch_query_evidence":       auth.RoleReadOnly,
+	"query_clinvar":              auth.RoleReadOnly,
+	"query_gnomad":               auth.RoleReadOnly,
+	"query_cosmic":               auth.RoleReadOnly,
+	"validate_hgvs":              auth.RoleReadOnly,
+	"validate_report":            auth.RoleReadOnly,
+	"format_report":              auth.RoleReadOnly,
+	"query_audit_trail":          auth.RoleReadOnly,
+	"search_variants":            auth.RoleReadOnly,
+	"get_audit_record":           auth.RoleReadOnly,
+	"compare_classifications":    auth.RoleReadOnly,
+	"verify_audit_chain":         auth.RoleReadOnly,
+	"export_audit_bundle":        auth.RoleReadOnly,
+	"verify_audit_bundle":        auth.RoleReadOnly,
+	"list_known_benign":          auth.RoleReadOnly,
+	"query_cohort_frequency":     auth.RoleReadOnly,
+	"list_cohort_artifacts":      auth.RoleReadOnly,
+	"match_internal_cases":       auth.RoleReadOnly,
+	"get_weekly_digest":          auth.RoleReadOnly,
+	"query_feedback":             auth.RoleReadOnly,
+	"export_feedback":            auth.RoleReadOnly,
+	"list_feedback":              auth.RoleReadOnly,
+	"list_followup_worklist":     auth.RoleReadOnly,
+	"check_cited_literature":     auth.RoleReadOnly,
+	"list_artifact_blacklist":    auth.RoleReadOnly,
+	"export_artifact_blacklist":  auth.RoleReadOnly,
+	"annotate_pgx":               auth.RoleReadOnly,
+	"get_lab_assertion":          auth.RoleReadOnly,
+	"list_lab_assertions":        auth.RoleReadOnly,
+	"export_clinvar_submission":  auth.RoleReadOnly,
+	"prepare_clinvar_submission": auth.RoleReadOnly,
+	"get_job_status":             auth.RoleReadOnly,
+	"get_job_results":            auth.RoleReadOnly,
+	"list_reviews":               auth.RoleReadOnly,
+
+	"classify_variant":             auth.RoleClassify,
+	"classify_variants_batch":      auth.RoleClassify,
+	"explain_classification":       auth.RoleClassify,
+	"replay_classification":        auth.RoleClassify,
+	"compare_rule_versions":        auth.RoleClassify,
+	"classify_trio":                auth.RoleClassify,
+	"classify_cnv":                 auth.RoleClassify,
+	"apply_rule":                   auth.RoleClassify,
+	"combine_evidence":             auth.RoleClassify,
+	"generate_report":              auth.RoleClassify,
+	"submit_feedback":              auth.RoleClassify,
+	"flag_classification":          auth.RoleClassify,
+	"resolve_classification_flag":  auth.RoleClassify,
+	"propose_artifact":             auth.RoleClassify,
+	"submit_classification_job":    auth.RoleClassify,
+	"start_classification_session": auth.RoleClassify,
+	"provide_evidence":             auth.RoleClassify,
+	"finalize_classification":      auth.RoleClassify,
+	"override_criterion":           auth.RoleClassify,
+	"submit_for_review":            auth.RoleClassify,
+
+	"review_classification": auth.RoleReviewer,
+
+	"sign_off_artifact":          auth.RoleAdmin,
+	"remove_artifact":            auth.RoleAdmin,
+	"import_artifact_blacklist":  auth.RoleAdmin,
+	"add_known_benign":           auth.RoleAdmin,
+	"remove_known_benign":        auth.RoleAdmin,
+	"save_lab_assertion":         auth.RoleAdmin,
+	"remove_lab_assertion":       auth.RoleAdmin,
+	"import_feedback":            auth.RoleAdmin,
+	"update_gene_playbook":       auth.RoleAdmin,
+	"register_webhook":           auth.RoleAdmin,
+	"list_webhooks":              auth.RoleAdmin,
+	"remove_webhook":             auth.RoleAdmin,
+	"test_webhook":               auth.RoleAdmin,
+	"run_evidence_refresh":       auth.RoleAdmin,
+	"force_open_circuit_breaker": auth.RoleAdmin,
+	"reset_circuit_breaker":      auth.RoleAdmin,
+}
+
+// RequiredRole returns the role a client needs to call a tool. Tools
+// without an assigned role require admin, so a newly added tool is not
+// exposed to every client by omission.
+func RequiredRole(tool string) auth.Role {
+	if role, ok := toolRoles[tool]; ok {
+		return role
+	}
+	return auth.RoleAdmin
+}
@@ -335,6 +335,21 @@ func (t *ApplyRuleTool) applyRule(ctx context.Context, params *ApplyRuleParams)
 		Requirements:    []string{}, // Could be enhanced with specific requirements
 		Recommendations: []string{}, // Could be enhanced with specific recommendations
 	}
+	if len(serviceResult.MatchedVariants) > 0 {
+		result.Evidence["matched_variants"] = serviceResult.MatchedVariants
+	}
+	if serviceResult.MatchedDomain != nil {
+		result.Evidence["matched_domain"] = serviceResult.MatchedDomain
+	}
+	if serviceResult.Segregation != nil {
+		result.Evidence["segregation"] = serviceResult.Segregation
+	}
+	if serviceResult.PhenotypeMatch != nil {
+		result.Evidence["phenotype_match"] = serviceResult.PhenotypeMatch
+	}
+	if serviceResult.InTrans != nil {
+		result.Evidence["in_trans"] = serviceResult.InTrans
+	}
 
 	return result, nil
 }
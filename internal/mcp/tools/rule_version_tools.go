@@ -0,0 +1,187 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/ruleversions"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+// CompareRuleVersionsTool implements the compare_rule_versions MCP tool
+type CompareRuleVersionsTool struct {
+	logger       *logrus.Logger
+	classifyTool *ClassifyVariantTool
+}
+
+// CompareRuleVersionsParams defines parameters for the compare_rule_versions
+// tool: the two rule set versions and the classify_variant parameters of the
+// variant to classify under both
+type CompareRuleVersionsParams struct {
+	Baseline  string `json:"baseline_version,omitempty"`  // Defaults to acmg2015
+	Candidate string `json:"candidate_version,omitempty"` // Defaults to clingen-svi-2023
+	ClassifyVariantParams
+}
+
+// CompareRuleVersionsResult reports how a variant's classification differs
+// between two rule set versions
+type CompareRuleVersionsResult struct {
+	*service.RuleVersionComparison
+	Versions []*ruleversions.Version `json:"versions"` // The baseline and candidate definitions
+	Summary  string                  `json:"summary"`
+}
+
+// NewCompareRuleVersionsTool creates a new compare_rule_versions tool
+func NewCompareRuleVersionsTool(logger *logrus.Logger, classifyTool *ClassifyVariantTool) *CompareRuleVersionsTool {
+	return &CompareRuleVersionsTool{
+		logger:       logger,
+		classifyTool: classifyTool,
+	}
+}
+
+// GetToolInfo returns the tool information for compare_rule_versions
+func (t *CompareRuleVersionsTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "compare_rule_versions",
+		Description: "Classify the same variant under two versions of the ACMG/AMP rule set and report the criteria and outcomes that differ, to evaluate a guideline migration. Evidence is gathered once and both versions are evaluated from it with the same thresholds. Versions: 'acmg2015' (the 2015 guidelines as published, combining rules) and 'clingen-svi-2023' (ClinGen SVI recommendations: PM2 at supporting, PP5 and BP6 retired, Bayesian points). Accepts the classify_variant parameters of the variant; a scoring_mode applies to both versions.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"hgvs_notation": map[string]interface{}{
+					"type":        "string",
+					"description": "HGVS notation, rsID or ClinVar accession of the variant",
+				},
+				"gene_symbol_notation": map[string]interface{}{
+					"type":        "string",
+					"description": "Gene symbol notation of the variant, e.g. 'TP53:c.273G>A'",
+				},
+				"baseline_version": map[string]interface{}{
+					"type":        "string",
+					"description": "Rule set version to compare from",
+					"enum":        ruleversions.Names(),
+					"default":     ruleversions.ACMG2015,
+				},
+				"candidate_version": map[string]interface{}{
+					"type":        "string",
+					"description": "Rule set version to compare to",
+					"enum":        ruleversions.Names(),
+					"default":     ruleversions.ClinGenSVI2023,
+				},
+				"condition": map[string]interface{}{
+					"type":        "string",
+					"description": "Condition under evaluation; selects gene/condition-specific frequency thresholds",
+				},
+				"scoring_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Combine evidence the same way under both versions; by default each version uses its own",
+					"enum":        []string{"combining_rules", "points"},
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *CompareRuleVersionsTool) ValidateParams(params interface{}) error {
+	var p CompareRuleVersionsParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	for _, version := range []string{p.Baseline, p.Candidate} {
+		if _, err := ruleversions.Lookup(version); err != nil {
+			return err
+		}
+	}
+	if p.RulesVersion != "" {
+		return fmt.Errorf("give baseline_version and candidate_version instead of rules_version")
+	}
+	if p.ClassificationContext == service.ContextSomatic {
+		return fmt.Errorf("rule set versions apply to germline classification")
+	}
+	return t.classifyTool.ValidateParams(params)
+}
+
+// HandleTool handles the compare_rule_versions tool request
+func (t *CompareRuleVersionsTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params CompareRuleVersionsParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if t.classifyTool.classifierService == nil {
+		return internalError("Classification service not configured", "compare_rule_versions requires the classifier service")
+	}
+	if params.Baseline == "" {
+		params.Baseline = ruleversions.ACMG2015
+	}
+	if params.Candidate == "" {
+		params.Candidate = ruleversions.ClinGenSVI2023
+	}
+
+	comparison, err := t.compare(ctx, &params)
+	if err != nil {
+		t.logger.WithError(err).WithField("hgvs_notation", params.HGVSNotation).Warn("Failed to compare rule set versions")
+		return &protocol.JSONRPC2Response{
+			Error: &protocol.RPCError{
+				Code:    protocol.MCPToolError,
+				Message: "Rule version comparison failed",
+				Data:    err.Error(),
+			},
+		}
+	}
+
+	baseline, _ := ruleversions.Lookup(params.Baseline)
+	candidate, _ := ruleversions.Lookup(params.Candidate)
+	return &protocol.JSONRPC2Response{
+		Result: &CompareRuleVersionsResult{
+			RuleVersionComparison: comparison,
+			Versions:              []*ruleversions.Version{baseline, candidate},
+			Summary:               summarizeRuleVersions(comparison),
+		},
+	}
+}
+
+// compare resolves the variant as classify_variant does and classifies it
+// under both versions
+func (t *CompareRuleVersionsTool) compare(ctx context.Context, params *CompareRuleVersionsParams) (*service.RuleVersionComparison, error) {
+	notationParams, _, err := t.classifyTool.resolveIdentifier(ctx, &params.ClassifyVariantParams)
+	if err != nil {
+		return nil, err
+	}
+	hgvsNotation, geneSymbol, err := t.classifyTool.prepareNotationForClassification(ctx, notationParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare notation for classification: %w", err)
+	}
+	serviceParams := toServiceParams(notationParams, hgvsNotation, geneSymbol)
+	return t.classifyTool.classifierService.CompareRuleVersions(ctx, serviceParams, params.Baseline, params.Candidate)
+}
+
+// summarizeRuleVersions describes the comparison in a sentence or two
+func summarizeRuleVersions(c *service.RuleVersionComparison) string {
+	outcome := fmt.Sprintf("%s under %s and %s", c.Baseline.Classification, c.Baseline.Title, c.Candidate.Title)
+	if c.ClassificationChanged {
+		outcome = fmt.Sprintf("%s under %s, %s under %s", c.Baseline.Classification, c.Baseline.Title, c.Candidate.Classification, c.Candidate.Title)
+	}
+	if len(c.DifferingCriteria) == 0 {
+		return outcome + "; the same criteria are met under both."
+	}
+
+	changes := make([]string, 0, len(c.DifferingCriteria))
+	for _, d := range c.DifferingCriteria {
+		switch {
+		case d.BaselineStrength == "":
+			changes = append(changes, d.Code+" added")
+		case d.CandidateStrength == "":
+			changes = append(changes, d.Code+" dropped")
+		default:
+			changes = append(changes, fmt.Sprintf("%s %s -> %s", d.Code, strings.ToLower(d.BaselineStrength), strings.ToLower(d.CandidateStrength)))
+		}
+	}
+	return fmt.Sprintf("%s; criteria differing: %s.", outcome, strings.Join(changes, ", "))
+}
@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+func TestCompareRuleVersionsTool_Invalid(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewCompareRuleVersionsTool(logger, NewClassifyVariantToolLegacy(logger, nil))
+
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		want   string
+	}{
+		{"missing variant", map[string]interface{}{}, "hgvs_notation"},
+		{"unknown version", map[string]interface{}{"hgvs_notation": "NM_000546.6:c.273G>A", "candidate_version": "acmg2025"}, "unknown rule set version"},
+		{"rules_version", map[string]interface{}{"hgvs_notation": "NM_000546.6:c.273G>A", "rules_version": "acmg2015"}, "baseline_version and candidate_version"},
+		{"somatic", map[string]interface{}{"hgvs_notation": "NM_000546.6:c.273G>A", "classification_context": "somatic"}, "germline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+				Method: "compare_rule_versions",
+				Params: tt.params,
+			})
+			require.NotNil(t, response.Error)
+			assert.Equal(t, protocol.InvalidParams, response.Error.Code)
+			assert.Contains(t, response.Error.Data, tt.want)
+		})
+	}
+}
+
+func TestClassifyVariantTool_RulesVersion(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewClassifyVariantToolLegacy(logger, nil)
+
+	assert.NoError(t, tool.ValidateParams(map[string]interface{}{"hgvs_notation": "NM_000546.6:c.273G>A", "rules_version": "clingen-svi-2023"}))
+	assert.ErrorContains(t, tool.ValidateParams(map[string]interface{}{"hgvs_notation": "NM_000546.6:c.273G>A", "rules_version": "acmg2025"}), "unknown rule set version")
+}
+
+func TestSummarizeRuleVersions(t *testing.T) {
+	comparison := &service.RuleVersionComparison{
+		Baseline:              service.RuleVersionOutcome{Title: "ACMG/AMP 2015", Classification: "LIKELY_PATHOGENIC"},
+		Candidate:             service.RuleVersionOutcome{Title: "ClinGen SVI 2023", Classification: "VUS"},
+		ClassificationChanged: true,
+		DifferingCriteria: []service.CriterionDifference{
+			{Code: "PM2", BaselineStrength: "MODERATE", CandidateStrength: "SUPPORTING"},
+			{Code: "PP5", BaselineStrength: "SUPPORTING"},
+		},
+	}
+	assert.Equal(t, "LIKELY_PATHOGENIC under ACMG/AMP 2015, VUS under ClinGen SVI 2023; criteria differing: PM2 moderate -> supporting, PP5 dropped.", summarizeRuleVersions(comparison))
+
+	comparison.Candidate.Classification = "LIKELY_PATHOGENIC"
+	comparison.ClassificationChanged = false
+	comparison.DifferingCriteria = nil
+	assert.Equal(t, "LIKELY_PATHOGENIC under ACMG/AMP 2015 and ClinGen SVI 2023; the same criteria are met under both.", summarizeRuleVersions(comparison))
+}
@@ -0,0 +1,164 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// SearchVariantsTool implements the search_variants MCP tool
+type SearchVariantsTool struct {
+	logger   *logrus.Logger
+	searcher audit.Searcher
+}
+
+// SearchVariantsParams defines parameters for the search_variants tool
+type SearchVariantsParams struct {
+	Gene           string   `json:"gene,omitempty"`
+	Classification string   `json:"classification,omitempty"`
+	Criteria       []string `json:"criteria,omitempty"`
+	Text           string   `json:"text,omitempty"`
+	Since          string   `json:"since,omitempty"` // YYYY-MM-DD, inclusive
+	Until          string   `json:"until,omitempty"` // YYYY-MM-DD, inclusive
+	Limit          int      `json:"limit,omitempty"`
+}
+
+// NewSearchVariantsTool creates a new search_variants tool
+func NewSearchVariantsTool(logger *logrus.Logger, searcher audit.Searcher) *SearchVariantsTool {
+	return &SearchVariantsTool{
+		logger:   logger,
+		searcher: searcher,
+	}
+}
+
+// GetToolInfo returns the tool information for search_variants
+func (t *SearchVariantsTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "search_variants",
+		Description: "Search stored classifications by gene, final call, date range, the criteria applied (e.g. every variant where PM2 and PP3 were both met) and free text over the justifications of the applied criteria. Text matches are ranked by relevance and return a snippet of the matching justification; other searches return the newest classifications first. Failed classification requests are not searched.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"gene": map[string]interface{}{
+					"type":        "string",
+					"description": "HGNC gene symbol, e.g. BRCA1",
+				},
+				"classification": map[string]interface{}{
+					"type":        "string",
+					"description": "Only classifications with this final call, e.g. Likely pathogenic",
+				},
+				"criteria": map[string]interface{}{
+					"type":        "array",
+					"description": "ACMG/AMP criteria that must all have been applied, e.g. [\"PM2\", \"PP3\"]",
+					"items":       map[string]interface{}{"type": "string"},
+				},
+				"text": map[string]interface{}{
+					"type":        "string",
+					"description": "Words that must all appear in the justifications of the applied criteria; end a word with * to match it as a prefix",
+				},
+				"since": map[string]interface{}{
+					"type":        "string",
+					"description": "Only classifications on or after this date (YYYY-MM-DD)",
+				},
+				"until": map[string]interface{}{
+					"type":        "string",
+					"description": "Only classifications on or before this date (YYYY-MM-DD)",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of classifications to return",
+					"minimum":     1,
+					"maximum":     maxAuditQueryLimit,
+					"default":     audit.DefaultQueryLimit,
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *SearchVariantsTool) ValidateParams(params interface{}) error {
+	if params == nil {
+		return nil // No required parameters
+	}
+	var p SearchVariantsParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	_, err := p.query()
+	return err
+}
+
+// query converts the parameters to a search query
+func (p *SearchVariantsParams) query() (audit.SearchQuery, error) {
+	query := audit.SearchQuery{
+		Gene:           strings.TrimSpace(p.Gene),
+		Classification: strings.TrimSpace(p.Classification),
+		Text:           strings.TrimSpace(p.Text),
+		Limit:          p.Limit,
+	}
+	if p.Limit < 0 || p.Limit > maxAuditQueryLimit {
+		return query, fmt.Errorf("limit must be between 1 and %d", maxAuditQueryLimit)
+	}
+	for _, criterion := range p.Criteria {
+		criterion = strings.ToUpper(strings.TrimSpace(criterion))
+		if criterion == "" {
+			return query, fmt.Errorf("criteria must not be empty")
+		}
+		query.Criteria = append(query.Criteria, criterion)
+	}
+	if p.Since != "" {
+		since, err := time.Parse("2006-01-02", p.Since)
+		if err != nil {
+			return query, fmt.Errorf("since must be a date in YYYY-MM-DD format")
+		}
+		query.Since = since
+	}
+	if p.Until != "" {
+		until, err := time.Parse("2006-01-02", p.Until)
+		if err != nil {
+			return query, fmt.Errorf("until must be a date in YYYY-MM-DD format")
+		}
+		query.Until = until.AddDate(0, 0, 1)
+	}
+	if !query.Since.IsZero() && !query.Until.IsZero() && !query.Since.Before(query.Until) {
+		return query, fmt.Errorf("since must not be after until")
+	}
+	return query, nil
+}
+
+// HandleTool handles the search_variants tool request
+func (t *SearchVariantsTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params SearchVariantsParams
+	if req.Params != nil {
+		if err := ParseParams(req.Params, &params); err != nil {
+			return invalidParamsError("Invalid parameters", err.Error())
+		}
+	}
+	query, err := params.query()
+	if err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	hits, err := t.searcher.Search(ctx, query)
+	if err != nil {
+		if errors.Is(err, audit.ErrInvalidQuery) {
+			return invalidParamsError(err.Error())
+		}
+		return auditStoreError(t.logger, "search classifications", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"results": hits,
+			"total":   len(hits),
+		},
+	}
+}
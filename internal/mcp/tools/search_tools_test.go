@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/service"
+)
+
+func TestSearchVariantsTool(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestAuditStore(t)
+	tool := NewClassifyVariantToolLegacy(logger, nil)
+	tool.SetAuditStore(store)
+	for _, call := range []struct {
+		notation string
+		rules    []service.ACMGAMPRuleResult
+	}{
+		{"BRCA1:p.Arg1699Gln", []service.ACMGAMPRuleResult{
+			{RuleCode: "PM2", Applied: true, Evidence: "Absent from gnomAD"},
+			{RuleCode: "PP3", Applied: true, Reasoning: "REVEL 0.93 predicts a damaging effect"},
+		}},
+		{"BRCA2:p.Gly2336Asp", []service.ACMGAMPRuleResult{
+			{RuleCode: "PM2", Applied: true, Evidence: "Absent from gnomAD"},
+			{RuleCode: "PP3", Applied: false, Reasoning: "Benign in silico predictions"},
+		}},
+	} {
+		params := &ClassifyVariantParams{GeneSymbolNotation: call.notation}
+		tool.recordAudit(context.Background(), params, "", &service.ClassifyVariantResult{
+			Classification: "Uncertain significance",
+			AppliedRules:   call.rules,
+		}, nil)
+	}
+	search := NewSearchVariantsTool(logger, store)
+
+	// Act
+	both := search.HandleTool(context.Background(), toolRequest("search_variants", map[string]interface{}{
+		"criteria": []string{"pm2", "PP3"},
+		"since":    time.Now().UTC().Format("2006-01-02"),
+	}))
+	text := search.HandleTool(context.Background(), toolRequest("search_variants", map[string]interface{}{
+		"gene": "BRCA1",
+		"text": "damag*",
+	}))
+	badDate := search.HandleTool(context.Background(), toolRequest("search_variants", map[string]interface{}{
+		"until": "03/01/2026",
+	}))
+	badCriterion := search.HandleTool(context.Background(), toolRequest("search_variants", map[string]interface{}{
+		"criteria": []string{""},
+	}))
+
+	// Assert
+	require.Nil(t, both.Error)
+	hits := both.Result.(map[string]interface{})["results"].([]*audit.SearchHit)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "BRCA1", hits[0].Gene)
+	assert.Equal(t, []string{"PM2", "PP3"}, hits[0].Criteria)
+
+	require.Nil(t, text.Error)
+	result, err := json.Marshal(text.Result)
+	require.NoError(t, err)
+	assert.Contains(t, string(result), `[damaging]`)
+	assert.Equal(t, 1, text.Result.(map[string]interface{})["total"])
+
+	require.NotNil(t, badDate.Error)
+	require.NotNil(t, badCriterion.Error)
+}
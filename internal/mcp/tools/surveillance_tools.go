@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/surveillance"
+)
+
+// RunEvidenceRefreshTool implements the run_evidence_refresh MCP tool
+type RunEvidenceRefreshTool struct {
+	logger    *logrus.Logger
+	refresher *surveillance.Refresher
+}
+
+// NewRunEvidenceRefreshTool creates a new run_evidence_refresh tool
+func NewRunEvidenceRefreshTool(logger *logrus.Logger, refresher *surveillance.Refresher) *RunEvidenceRefreshTool {
+	return &RunEvidenceRefreshTool{
+		logger:    logger,
+		refresher: refresher,
+	}
+}
+
+// GetToolInfo returns the tool information for run_evidence_refresh
+func (t *RunEvidenceRefreshTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name: "run_evidence_refresh",
+		Description: "Re-fetch external evidence for every variant in the audit trail and the lab knowledge base, re-run the rule engine " +
+			"and report classifications that changed. Each change gets a reclassification_detected follow-up flag and the report is " +
+			"written to the surveillance report directory. The same run happens on the configured schedule; this runs it now.",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *RunEvidenceRefreshTool) ValidateParams(params interface{}) error {
+	return nil // No parameters
+}
+
+// HandleTool handles the run_evidence_refresh tool request
+func (t *RunEvidenceRefreshTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	report, err := t.refresher.Run(ctx)
+	if err != nil {
+		t.logger.WithError(err).Error("Failed to re-evaluate stored variants")
+		return internalError("Failed to re-evaluate stored variants", err.Error())
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"report": report,
+		},
+	}
+}
@@ -6,7 +6,11 @@ import (
 
 	"github.com/sirupsen/logrus/hooks/test"
 
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/i18n"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/shutdown"
+	"github.com/acmg-amp-mcp-server/internal/vrs"
 )
 
 // TestClassifyVariantTool tests the classify_variant tool
@@ -64,6 +68,13 @@ func TestClassifyVariantTool_InvalidParams(t *testing.T) {
 				"hgvs_notation": "invalid",
 			},
 		},
+		{
+			name: "invalid_output_format",
+			params: map[string]interface{}{
+				"hgvs_notation": "NM_000492.3:c.1521_1523delCTT",
+				"output_format": "phenopacket",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -89,6 +100,38 @@ func TestClassifyVariantTool_InvalidParams(t *testing.T) {
 	}
 }
 
+// TestVASpecStatement tests the VA-Spec statement of a classification
+func TestVASpecStatement(t *testing.T) {
+	allele, err := vrs.NewAllele("SQ.IIB53T8CNeJJdUqzn9V_JnRtQadwWCbl", 44908821, 44908822, vrs.LiteralSequence("T"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := &ClassifyVariantResult{
+		Classification: "LIKELY_PATHOGENIC",
+		VRSAllele:      allele,
+		AppliedRules: []ACMGAMPRuleResult{
+			{RuleCode: "PS3", Strength: "STRONG", Applied: true},
+			{RuleCode: "PM2", Strength: "SUPPORTING", Applied: true},
+			{RuleCode: "PP3", Strength: "SUPPORTING", Applied: false},
+		},
+	}
+
+	statement, err := vaSpecStatement(result)
+	if err != nil {
+		t.Fatalf("Expected a statement, got: %v", err)
+	}
+	if statement.Proposition.SubjectVariant.ID != "ga4gh:VA.0AePZIWZUNsUlQTamyLrjm2HWUw2opLt" {
+		t.Errorf("Unexpected subject variant: %s", statement.Proposition.SubjectVariant.ID)
+	}
+	if len(statement.HasEvidenceLines) != 2 {
+		t.Errorf("Expected an evidence line per applied criterion, got %d", len(statement.HasEvidenceLines))
+	}
+
+	if _, err := vaSpecStatement(&ClassifyVariantResult{Classification: "PATHOGENIC"}); err == nil {
+		t.Error("Expected an error without a VRS allele")
+	}
+}
+
 // TestValidateHGVSTool tests the validate_hgvs tool
 func TestValidateHGVSTool(t *testing.T) {
 	logger, _ := test.NewNullLogger()
@@ -407,7 +450,7 @@ func TestToolRegistry(t *testing.T) {
 	// Test getting tool info
 	toolsInfo := registry.GetRegisteredToolsInfo()
 	expectedTools := []string{
-		"classify_variant", "validate_hgvs", "apply_rule", "combine_evidence",
+		"classify_variant", "classify_variants_batch", "explain_classification", "replay_classification", "compare_rule_versions", "classify_trio", "validate_hgvs", "apply_rule", "combine_evidence",
 		"query_evidence", "batch_query_evidence", "query_clinvar", "query_gnomad", "query_cosmic",
 		"generate_report", "format_report", "validate_report",
 	}
@@ -435,6 +478,175 @@ func TestToolRegistry(t *testing.T) {
 	}
 }
 
+// TestToolRegistry_ErrorEnvelope tests that tool failures carry the standard error envelope
+func TestToolRegistry_ErrorEnvelope(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	router := protocol.NewMessageRouter(logger)
+	registry := NewToolRegistry(logger, router, nil)
+	if err := registry.RegisterAllTools(); err != nil {
+		t.Fatalf("Failed to register tools: %v", err)
+	}
+
+	ctx := protocol.WithCorrelationID(context.Background(), "ticket-42")
+	response := registry.ExecuteTool(ctx, &protocol.JSONRPC2Request{
+		Method: "validate_hgvs",
+		Params: map[string]interface{}{},
+	})
+	if response.Error == nil {
+		t.Fatal("Expected error for missing parameters")
+	}
+
+	envelope, ok := response.Error.Data.(*protocol.ErrorEnvelope)
+	if !ok {
+		t.Fatalf("Expected error envelope, got %T", response.Error.Data)
+	}
+	if envelope.Code != protocol.ErrorCodeInvalidInput || envelope.Source != "tool:validate_hgvs" {
+		t.Errorf("Unexpected envelope: %+v", envelope)
+	}
+	if envelope.CorrelationID != "ticket-42" || envelope.Retryable {
+		t.Errorf("Unexpected correlation or retryability: %+v", envelope)
+	}
+	if entry := hook.LastEntry(); entry == nil || entry.Data["correlation_id"] != "ticket-42" {
+		t.Error("Expected the failure to be logged with its correlation ID")
+	}
+
+	// Unknown tools are enveloped with a fresh correlation ID
+	response = registry.ExecuteTool(context.Background(), &protocol.JSONRPC2Request{Method: "no_such_tool"})
+	envelope, ok = response.Error.Data.(*protocol.ErrorEnvelope)
+	if !ok || envelope.Code != protocol.ErrorCodeNotFound || envelope.CorrelationID == "" {
+		t.Errorf("Unexpected envelope for unknown tool: %+v", response.Error.Data)
+	}
+}
+
+// TestToolRegistry_LocalizedErrors tests that error messages follow the
+// request's or configured locale while the envelope keeps the English message
+func TestToolRegistry_LocalizedErrors(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	router := protocol.NewMessageRouter(logger)
+	registry := NewToolRegistry(logger, router, nil)
+	registry.SetLocale(i18n.TraditionalChinese)
+	if err := registry.RegisterAllTools(); err != nil {
+		t.Fatalf("Failed to register tools: %v", err)
+	}
+
+	response := registry.ExecuteTool(context.Background(), &protocol.JSONRPC2Request{
+		Method: "validate_hgvs",
+		Params: map[string]interface{}{},
+	})
+	if response.Error == nil || response.Error.Message != "輸入無效" {
+		t.Fatalf("Expected the configured locale's message, got %+v", response.Error)
+	}
+	envelope, ok := response.Error.Data.(*protocol.ErrorEnvelope)
+	if !ok || envelope.Message == response.Error.Message {
+		t.Errorf("Expected the envelope to keep the English message: %+v", response.Error.Data)
+	}
+
+	response = registry.ExecuteTool(context.Background(), &protocol.JSONRPC2Request{
+		Method: "validate_hgvs",
+		Params: map[string]interface{}{"locale": "ja"},
+	})
+	if response.Error == nil || response.Error.Message != "入力が無効です" {
+		t.Errorf("Expected the requested locale's message, got %+v", response.Error)
+	}
+}
+
+// TestToolRegistry_Cancelled tests that a cancelled call reports the
+// cancellation instead of its partial result
+func TestToolRegistry_Cancelled(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	router := protocol.NewMessageRouter(logger)
+	registry := NewToolRegistry(logger, router, nil)
+	if err := registry.RegisterAllTools(); err != nil {
+		t.Fatalf("Failed to register tools: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(protocol.WithCorrelationID(context.Background(), "ticket-43"))
+	cancel()
+	response := registry.ExecuteTool(ctx, &protocol.JSONRPC2Request{
+		Method: "classify_variants_batch",
+		Params: map[string]interface{}{"hgvs_notations": []string{"NM_000492.3:c.1A>G"}},
+	})
+	if response.Error == nil || response.Error.Code != protocol.RequestCancelled {
+		t.Fatalf("Expected a cancellation error, got %+v", response)
+	}
+	if response.Result != nil {
+		t.Error("Expected the partial result to be discarded")
+	}
+	envelope, ok := response.Error.Data.(*protocol.ErrorEnvelope)
+	if !ok || envelope.Code != protocol.ErrorCodeCancelled || envelope.CorrelationID != "ticket-43" || envelope.Retryable {
+		t.Errorf("Unexpected envelope: %+v", response.Error.Data)
+	}
+}
+
+// TestToolRegistry_ShuttingDown tests that transport calls are refused once a
+// shutdown drain begins, while in-process calls still run
+func TestToolRegistry_ShuttingDown(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	router := protocol.NewMessageRouter(logger)
+	registry := NewToolRegistry(logger, router, nil)
+	if err := registry.RegisterAllTools(); err != nil {
+		t.Fatalf("Failed to register tools: %v", err)
+	}
+	coordinator := shutdown.NewCoordinator()
+	registry.SetShutdownCoordinator(coordinator)
+	coordinator.Drain(context.Background())
+
+	req := &protocol.JSONRPC2Request{
+		Method: "validate_hgvs",
+		Params: map[string]interface{}{"hgvs_notation": "NM_000492.3:c.1521_1523del"},
+	}
+	response := registry.ExecuteTool(context.Background(), req)
+	if response.Error == nil || response.Error.Code != protocol.MCPResourceError {
+		t.Fatalf("Expected a shutdown error, got %+v", response)
+	}
+	envelope, ok := response.Error.Data.(*protocol.ErrorEnvelope)
+	if !ok || !envelope.Retryable {
+		t.Errorf("Expected a retryable envelope, got %+v", response.Error.Data)
+	}
+
+	if response := registry.ExecuteTool(WithInProcess(context.Background()), req); response.Error != nil {
+		t.Errorf("Expected in-process calls to run, got %+v", response.Error)
+	}
+}
+
+// TestToolRegistry_Roles tests that tool calls from HTTP clients are authorized by role
+func TestToolRegistry_Roles(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	router := protocol.NewMessageRouter(logger)
+	registry := NewToolRegistry(logger, router, nil)
+	if err := registry.RegisterAllTools(); err != nil {
+		t.Fatalf("Failed to register tools: %v", err)
+	}
+
+	for _, info := range registry.GetRegisteredToolsInfo() {
+		if _, ok := toolRoles[info.Name]; !ok {
+			t.Errorf("Tool %s has no assigned role", info.Name)
+		}
+	}
+	if RequiredRole("no_such_tool") != auth.RoleAdmin {
+		t.Error("Expected unassigned tools to require admin")
+	}
+
+	reader := &auth.Principal{Subject: "viewer", Role: auth.RoleReadOnly, Method: auth.MethodAPIKey}
+	ctx := auth.WithPrincipal(context.Background(), reader)
+	response := registry.ExecuteTool(ctx, &protocol.JSONRPC2Request{
+		Method: "classify_variant",
+		Params: map[string]interface{}{"hgvs_notation": "NM_000492.3:c.1521_1523delCTT"},
+	})
+	if response.Error == nil || response.Error.Code != protocol.MCPForbidden {
+		t.Fatalf("Expected read_only principal to be forbidden from classifying, got %+v", response.Error)
+	}
+	if envelope, ok := response.Error.Data.(*protocol.ErrorEnvelope); !ok || envelope.Code != protocol.ErrorCodeForbidden {
+		t.Errorf("Expected FORBIDDEN envelope, got %+v", response.Error.Data)
+	}
+
+	// Read-only tools run; the missing parameter is reported rather than the role
+	response = registry.ExecuteTool(ctx, &protocol.JSONRPC2Request{Method: "validate_hgvs", Params: map[string]interface{}{}})
+	if response.Error == nil || response.Error.Code == protocol.MCPForbidden {
+		t.Errorf("Expected validate_hgvs to run for a read_only principal, got %+v", response.Error)
+	}
+}
+
 // TestToolInfo tests that all tools provide complete metadata
 func TestToolInfo(t *testing.T) {
 	logger, _ := test.NewNullLogger()
@@ -0,0 +1,358 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/trio"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// ClassifyTrioTool implements the classify_trio MCP tool
+type ClassifyTrioTool struct {
+	logger       *logrus.Logger
+	classifyTool *ClassifyVariantTool
+}
+
+// ClassifyTrioParams defines parameters for the classify_trio tool: the
+// trio's genotypes, given per variant or as a multi-sample VCF, and the
+// classify_variant parameters of the variant to classify. With a VCF the
+// variant defaults to the target record.
+type ClassifyTrioParams struct {
+	Genotypes              *TrioGenotypes       `json:"genotypes,omitempty"`
+	PartnerVariants        []TrioPartnerVariant `json:"partner_variants,omitempty"`
+	VCF                    string               `json:"vcf,omitempty"`
+	VCFSamples             trio.Samples         `json:"vcf_samples,omitempty"`
+	Target                 string               `json:"target,omitempty"`                  // chrom:pos:ref:alt of the VCF record to classify
+	PartnerClassifications map[string]string    `json:"partner_classifications,omitempty"` // chrom:pos:ref:alt of other VCF records in the gene -> classification
+	Assembly               string               `json:"assembly,omitempty"`                // Of the VCF; defaults to GRCh38
+	ClassifyVariantParams
+}
+
+// TrioGenotypes are the trio's calls at a variant, as VCF GT values (0/1,
+// 1|0, 1/1, 0/0, ./.) or zygosity names
+type TrioGenotypes struct {
+	Proband string `json:"proband"`
+	Mother  string `json:"mother,omitempty"`
+	Father  string `json:"father,omitempty"`
+}
+
+// TrioPartnerVariant is another variant of the proband in the same gene
+type TrioPartnerVariant struct {
+	Variant        string        `json:"variant"`
+	Classification string        `json:"classification"`
+	Genotypes      TrioGenotypes `json:"genotypes"`
+}
+
+// NewClassifyTrioTool creates a new classify_trio tool
+func NewClassifyTrioTool(logger *logrus.Logger, classifyTool *ClassifyVariantTool) *ClassifyTrioTool {
+	return &ClassifyTrioTool{
+		logger:       logger,
+		classifyTool: classifyTool,
+	}
+}
+
+// GetToolInfo returns the tool information for classify_trio
+func (t *ClassifyTrioTool) GetToolInfo() protocol.ToolInfo {
+	genotypes := map[string]interface{}{
+		"type":        "object",
+		"description": "Calls as VCF GT values (0/1, 1|0, 1/1, 0/0, 1, ./.) or names (heterozygous, homozygous, hemizygous, absent). Omit a parent who was not sequenced",
+		"properties": map[string]interface{}{
+			"proband": map[string]interface{}{"type": "string"},
+			"mother":  map[string]interface{}{"type": "string"},
+			"father":  map[string]interface{}{"type": "string"},
+		},
+		"required":             []string{"proband"},
+		"additionalProperties": false,
+	}
+	return protocol.ToolInfo{
+		Name:        "classify_trio",
+		Description: "Classify a variant from proband and parental genotypes. Inheritance is inferred from the trio: absent from both parents is de novo (PS2 when patient_context.parental_confirmation is set, PM6 otherwise, scored with patient_context.phenotype_match), carried by one parent is inherited from that parent. The variant is phased with the proband's other variants in the gene: in trans when they came from different parents or read-backed phasing (GT with |, within one PS phase set) puts them on different haplotypes, in cis when they came from the same parent or share a haplotype. A pathogenic variant in trans applies PM3 for a recessive disorder and BP2 for a dominant one; one in cis applies BP2. Set patient_context.inheritance to the disorder's mode. Give genotypes and partner_variants, or a multi-sample VCF with the target record and partner_classifications. The result lists the assumptions the inference relies on. Accepts the classify_variant parameters.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"hgvs_notation": map[string]interface{}{
+					"type":        "string",
+					"description": "HGVS notation of the variant; with a VCF, defaults to the target record",
+				},
+				"gene_symbol_notation": map[string]interface{}{
+					"type":        "string",
+					"description": "Gene symbol notation of the variant, e.g. 'CFTR:p.Arg117His'",
+				},
+				"genotypes": genotypes,
+				"partner_variants": map[string]interface{}{
+					"type":        "array",
+					"description": "The proband's other variants in the same gene, with their classification and trio genotypes",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"variant":        map[string]interface{}{"type": "string"},
+							"classification": map[string]interface{}{"type": "string", "description": "e.g. Pathogenic"},
+							"genotypes":      genotypes,
+						},
+						"required":             []string{"variant", "classification", "genotypes"},
+						"additionalProperties": false,
+					},
+				},
+				"vcf": map[string]interface{}{
+					"type":        "string",
+					"description": "Multi-sample VCF text with GT and optionally PS FORMAT fields, instead of genotypes. Multi-allelic records must be split",
+				},
+				"vcf_samples": map[string]interface{}{
+					"type":        "object",
+					"description": "Sample columns of the trio; by default the first three samples are the proband, mother and father",
+					"properties": map[string]interface{}{
+						"proband": map[string]interface{}{"type": "string"},
+						"mother":  map[string]interface{}{"type": "string"},
+						"father":  map[string]interface{}{"type": "string"},
+					},
+					"additionalProperties": false,
+				},
+				"target": map[string]interface{}{
+					"type":        "string",
+					"description": "VCF record to classify as chrom:pos:ref:alt, e.g. 7:117530975:G:A",
+				},
+				"partner_classifications": map[string]interface{}{
+					"type":                 "object",
+					"description":          "Other VCF records in the gene to phase with the target, as chrom:pos:ref:alt -> classification. Records not listed are ignored",
+					"additionalProperties": map[string]interface{}{"type": "string"},
+				},
+				"assembly": map[string]interface{}{
+					"type":        "string",
+					"description": "Assembly of the VCF coordinates",
+					"enum":        []string{hgvs.AssemblyGRCh38, hgvs.AssemblyGRCh37},
+					"default":     hgvs.AssemblyGRCh38,
+				},
+				"patient_context": map[string]interface{}{
+					"type":        "object",
+					"description": "Case-level evidence as for classify_variant, e.g. parental_confirmation, phenotype_match, family_history and inheritance. De novo status, zygosity and phased variants are derived from the genotypes",
+				},
+			},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ClassifyTrioTool) ValidateParams(params interface{}) error {
+	var p ClassifyTrioParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if _, err := p.analyze(); err != nil {
+		return err
+	}
+	return t.classifyTool.ValidateParams(&p.ClassifyVariantParams)
+}
+
+// HandleTool handles the classify_trio tool request
+func (t *ClassifyTrioTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ClassifyTrioParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	analysis, err := params.analyze()
+	if err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.classifyTool.ValidateParams(&params.ClassifyVariantParams); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	result, err := t.classifyTool.classifyVariant(ctx, &params.ClassifyVariantParams)
+	if err != nil {
+		return &protocol.JSONRPC2Response{
+			Error: &protocol.RPCError{
+				Code:    protocol.MCPToolError,
+				Message: "Classification failed",
+				Data:    err.Error(),
+			},
+		}
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"variant_id":     result.VariantID,
+		"inheritance":    analysis.Inheritance,
+		"classification": result.Classification,
+	}).Info("Trio classification completed")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"classification": result,
+			"trio":           analysis,
+		},
+	}
+}
+
+// analyze infers the inheritance and phase of the variant from the trio's
+// genotypes and records them in the patient context for classification
+func (p *ClassifyTrioParams) analyze() (*trio.Analysis, error) {
+	if p.PatientContext != nil && (p.PatientContext.DeNovoStatus != "" || p.PatientContext.Zygosity != "" || len(p.PatientContext.PhasedVariants) > 0) {
+		return nil, fmt.Errorf("patient_context de_novo_status, zygosity and phased_variants are derived from the genotypes; do not set them")
+	}
+
+	var variant trio.Variant
+	var partners []trio.Partner
+	var err error
+	switch {
+	case p.VCF != "" && (p.Genotypes != nil || len(p.PartnerVariants) > 0):
+		return nil, fmt.Errorf("give either vcf or genotypes and partner_variants, not both")
+	case p.VCF != "":
+		variant, partners, err = p.vcfVariants()
+	case p.Genotypes != nil:
+		variant, partners, err = p.structuredVariants()
+	default:
+		return nil, fmt.Errorf("genotypes or vcf is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	analysis, err := trio.Analyze(variant, partners)
+	if err != nil {
+		return nil, err
+	}
+
+	patient := &service.PatientContext{}
+	if p.PatientContext != nil {
+		copied := *p.PatientContext
+		patient = &copied
+	}
+	patient.DeNovoStatus = deNovoStatus(analysis.Inheritance)
+	patient.Zygosity = analysis.Zygosity
+	for _, partner := range analysis.Partners {
+		patient.PhasedVariants = append(patient.PhasedVariants, service.PhasedVariant{
+			Variant:        partner.Variant,
+			Classification: partner.Classification,
+			Phase:          partner.Phase,
+			PhaseBasis:     partner.Basis,
+		})
+	}
+	if err := patient.Validate(); err != nil {
+		return nil, err
+	}
+	p.PatientContext = patient
+	if p.Zygosity == "" {
+		p.Zygosity = analysis.Zygosity
+	}
+	return analysis, nil
+}
+
+// structuredVariants reads the variant and partners from the genotypes given per variant
+func (p *ClassifyTrioParams) structuredVariants() (trio.Variant, []trio.Partner, error) {
+	name := strings.TrimSpace(p.HGVSNotation)
+	if name == "" {
+		name = strings.TrimSpace(p.GeneSymbolNotation)
+	}
+	variant, err := trioVariant(name, *p.Genotypes)
+	if err != nil {
+		return trio.Variant{}, nil, err
+	}
+	partners := make([]trio.Partner, 0, len(p.PartnerVariants))
+	for _, partner := range p.PartnerVariants {
+		if strings.TrimSpace(partner.Variant) == "" {
+			return trio.Variant{}, nil, fmt.Errorf("partner_variants: variant is required")
+		}
+		v, err := trioVariant(strings.TrimSpace(partner.Variant), partner.Genotypes)
+		if err != nil {
+			return trio.Variant{}, nil, fmt.Errorf("partner_variants: %w", err)
+		}
+		partners = append(partners, trio.Partner{Variant: v, Classification: partner.Classification})
+	}
+	return variant, partners, nil
+}
+
+// trioVariant parses the trio's genotypes at a variant
+func trioVariant(name string, genotypes TrioGenotypes) (trio.Variant, error) {
+	variant := trio.Variant{Name: name}
+	for _, call := range []struct {
+		role     string
+		value    string
+		genotype *trio.Genotype
+	}{
+		{"proband", genotypes.Proband, &variant.Proband},
+		{"mother", genotypes.Mother, &variant.Mother},
+		{"father", genotypes.Father, &variant.Father},
+	} {
+		genotype, err := trio.ParseGenotype(call.value)
+		if err != nil {
+			return variant, fmt.Errorf("%s genotype of %s: %w", call.role, name, err)
+		}
+		*call.genotype = genotype
+	}
+	return variant, nil
+}
+
+// vcfVariants reads the target record and the listed partner records from
+// the VCF, filling in hgvs_notation from the target when no variant is given
+func (p *ClassifyTrioParams) vcfVariants() (trio.Variant, []trio.Partner, error) {
+	if p.Target == "" {
+		return trio.Variant{}, nil, fmt.Errorf("target is required with vcf")
+	}
+	target, err := trio.NormalizeKey(p.Target)
+	if err != nil {
+		return trio.Variant{}, nil, err
+	}
+	classifications := make(map[string]string, len(p.PartnerClassifications))
+	for key, classification := range p.PartnerClassifications {
+		normalized, err := trio.NormalizeKey(key)
+		if err != nil {
+			return trio.Variant{}, nil, fmt.Errorf("partner_classifications: %w", err)
+		}
+		classifications[normalized] = classification
+	}
+
+	assembly := hgvs.AssemblyGRCh38
+	if p.Assembly != "" {
+		if assembly, err = hgvs.ParseAssembly(p.Assembly); err != nil {
+			return trio.Variant{}, nil, err
+		}
+	}
+	records, err := trio.ReadVCF(strings.NewReader(p.VCF), assembly, p.VCFSamples)
+	if err != nil {
+		return trio.Variant{}, nil, fmt.Errorf("invalid vcf: %w", err)
+	}
+
+	var variant *trio.Variant
+	var partners []trio.Partner
+	for i := range records {
+		record := &records[i]
+		if record.Key == target {
+			variant = &record.Variant
+		} else if classification, ok := classifications[record.Key]; ok {
+			partners = append(partners, trio.Partner{Variant: record.Variant, Classification: classification})
+			delete(classifications, record.Key)
+		}
+	}
+	if variant == nil {
+		return trio.Variant{}, nil, fmt.Errorf("target %s not found in the vcf", p.Target)
+	}
+	if len(classifications) > 0 {
+		missing := make([]string, 0, len(classifications))
+		for key := range classifications {
+			missing = append(missing, key)
+		}
+		sort.Strings(missing)
+		return trio.Variant{}, nil, fmt.Errorf("partners not found in the vcf: %s", strings.Join(missing, ", "))
+	}
+	if p.HGVSNotation == "" && p.GeneSymbolNotation == "" {
+		p.HGVSNotation = variant.Name
+	}
+	return *variant, partners, nil
+}
+
+// deNovoStatus maps the inferred inheritance to the patient context's de novo status
+func deNovoStatus(inheritance string) string {
+	switch inheritance {
+	case trio.DeNovo:
+		return service.DeNovoStatusDeNovo
+	case trio.Maternal, trio.Paternal, trio.Inherited, trio.Biparental:
+		return service.DeNovoStatusInherited
+	}
+	return service.DeNovoStatusUnknown
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/trio"
+)
+
+func TestClassifyTrioParams_Genotypes(t *testing.T) {
+	params := &ClassifyTrioParams{
+		Genotypes: &TrioGenotypes{Proband: "0/1", Mother: "0/1", Father: "0/0"},
+		PartnerVariants: []TrioPartnerVariant{
+			{Variant: "CFTR:p.Phe508del", Classification: "pathogenic", Genotypes: TrioGenotypes{Proband: "0/1", Mother: "0/0", Father: "0/1"}},
+		},
+		ClassifyVariantParams: ClassifyVariantParams{
+			GeneSymbolNotation: "CFTR:p.Arg117His",
+			PatientContext:     &service.PatientContext{Inheritance: "autosomal_recessive", PhenotypeMatch: "consistent"},
+		},
+	}
+
+	analysis, err := params.analyze()
+	require.NoError(t, err)
+	assert.Equal(t, trio.Maternal, analysis.Inheritance)
+	assert.Equal(t, service.DeNovoStatusInherited, params.PatientContext.DeNovoStatus)
+	assert.Equal(t, service.ZygosityHeterozygous, params.PatientContext.Zygosity)
+	assert.Equal(t, service.PhenotypeConsistent, params.PatientContext.PhenotypeMatch, "the given patient context is kept")
+	assert.Equal(t, []service.PhasedVariant{
+		{Variant: "CFTR:p.Phe508del", Classification: "Pathogenic", Phase: service.PhaseTrans, PhaseBasis: "inherited from different parents"},
+	}, params.PatientContext.PhasedVariants)
+	assert.Equal(t, service.ZygosityHeterozygous, params.Zygosity)
+}
+
+func TestClassifyTrioParams_VCF(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tkid\tmom\tdad\n" +
+		"chr7\t117530975\t.\tG\tA\t50\tPASS\t.\tGT:PS\t0|1:117530975\t0/0\t0/0\n" +
+		"chr7\t117559590\t.\tATCT\tA\t50\tPASS\t.\tGT:PS\t1|0:117530975\t0/1\t0/0\n" +
+		"chr7\t117611649\t.\tC\tT\t50\tPASS\t.\tGT\t0/1\t0/0\t0/1\n"
+	params := &ClassifyTrioParams{
+		VCF:                    vcf,
+		Target:                 "7-117530975-G-A",
+		PartnerClassifications: map[string]string{"7:117559590:ATCT:A": "Pathogenic"},
+	}
+
+	analysis, err := params.analyze()
+	require.NoError(t, err)
+	assert.Equal(t, "NC_000007.14:g.117530975G>A", params.HGVSNotation, "the target record is classified")
+	assert.Equal(t, trio.DeNovo, analysis.Inheritance)
+	assert.Equal(t, service.DeNovoStatusDeNovo, params.PatientContext.DeNovoStatus)
+	require.Len(t, analysis.Partners, 1, "records not listed in partner_classifications are ignored")
+	assert.Equal(t, trio.Trans, analysis.Partners[0].Phase, "read-backed phasing resolves a de novo variant")
+	assert.Contains(t, analysis.Partners[0].Basis, "phase set 117530975")
+
+	params = &ClassifyTrioParams{VCF: vcf, Target: "7:117530975:G:A", PartnerClassifications: map[string]string{"7:1:A:G": "Pathogenic"}}
+	_, err = params.analyze()
+	assert.ErrorContains(t, err, "not found in the vcf")
+}
+
+func TestClassifyTrioTool_Invalid(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewClassifyTrioTool(logger, NewClassifyVariantToolLegacy(logger, nil))
+
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		want   string
+	}{
+		{"no genotypes", map[string]interface{}{"hgvs_notation": "NM_000492.4:c.350G>A"}, "genotypes or vcf is required"},
+		{"not carried", map[string]interface{}{"hgvs_notation": "NM_000492.4:c.350G>A", "genotypes": map[string]interface{}{"proband": "0/0"}}, "proband does not carry"},
+		{"bad genotype", map[string]interface{}{"hgvs_notation": "NM_000492.4:c.350G>A", "genotypes": map[string]interface{}{"proband": "0/2"}}, "multi-allelic"},
+		{"derived field", map[string]interface{}{
+			"hgvs_notation":   "NM_000492.4:c.350G>A",
+			"genotypes":       map[string]interface{}{"proband": "0/1"},
+			"patient_context": map[string]interface{}{"de_novo_status": "de_novo"},
+		}, "derived from the genotypes"},
+		{"bad partner classification", map[string]interface{}{
+			"hgvs_notation":    "NM_000492.4:c.350G>A",
+			"genotypes":        map[string]interface{}{"proband": "0/1"},
+			"partner_variants": []map[string]interface{}{{"variant": "CFTR:p.Phe508del", "classification": "bad", "genotypes": map[string]interface{}{"proband": "0/1"}}},
+		}, "phased_variants"},
+		{"vcf without target", map[string]interface{}{"vcf": "#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tkid\n"}, "target is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := tool.HandleTool(context.Background(), toolRequest("classify_trio", tt.params))
+			require.NotNil(t, response.Error)
+			assert.Equal(t, protocol.InvalidParams, response.Error.Code)
+			assert.Contains(t, response.Error.Data, tt.want)
+			assert.Error(t, tool.ValidateParams(tt.params))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/acmg-amp-mcp-server/internal/domain"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
 )
 
@@ -336,8 +337,7 @@ func (t *ValidateReportTool) performStandardValidation(params *ValidateReportPar
 	result.ChecksPerformed = append(result.ChecksPerformed, "standard_validation")
 
 	// Validate classification values
-	validClassifications := []string{"Pathogenic", "Likely pathogenic", "VUS", "Likely benign", "Benign"}
-	if !t.isValidClassification(params.Report.Summary.Classification, validClassifications) {
+	if _, err := domain.ParseClassification(params.Report.Summary.Classification); err != nil {
 		result.ValidationIssues = append(result.ValidationIssues, ValidationIssue{
 			Severity: "warning",
 			Code:     "INVALID_CLASSIFICATION",
@@ -723,12 +723,3 @@ func (t *ValidateReportTool) isValidLevel(level string, validLevels []string) bo
 	}
 	return false
 }
-
-func (t *ValidateReportTool) isValidClassification(classification string, validClassifications []string) bool {
-	for _, valid := range validClassifications {
-		if strings.EqualFold(classification, valid) {
-			return true
-		}
-	}
-	return false
-}
\ No newline at end of file
@@ -8,14 +8,18 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/acmg-amp-mcp-server/internal/domain"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
 	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/variantid"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
 )
 
 // ValidateHGVSTool implements the validate_hgvs MCP tool
 type ValidateHGVSTool struct {
 	logger            *logrus.Logger
 	classifierService *service.ClassifierService
+	identifiers       *variantid.Resolver
 }
 
 // ValidateHGVSParams defines parameters for the validate_hgvs tool
@@ -36,6 +40,12 @@ type ValidateHGVSResult struct {
 	GeneInfo         *GeneInfo         `json:"gene_info,omitempty"`
 	TranscriptInfo   *TranscriptInfo   `json:"transcript_info,omitempty"`
 	Suggestions      []string          `json:"suggestions,omitempty"`
+	// Normalized notation on the genome and transcript, when reference data is configured
+	Normalized       *domain.NormalizedVariant `json:"normalized,omitempty"`
+	// rsID or ClinVar accession the notation was resolved from
+	ResolvedFrom     *domain.IdentifierMapping `json:"resolved_from,omitempty"`
+	// Repeat expansion or balanced structural variant, which classify_variant does not evaluate with the criteria
+	Structural       *domain.StructuralVariant `json:"structural,omitempty"`
 }
 
 // GeneInfo contains gene-related information (REQ-MCP-001)
@@ -81,6 +91,11 @@ func NewValidateHGVSTool(logger *logrus.Logger, classifierService *service.Class
 	}
 }
 
+// SetIdentifierResolver enables validating the variant a dbSNP rsID or ClinVar VCV/RCV accession resolves to
+func (t *ValidateHGVSTool) SetIdentifierResolver(resolver *variantid.Resolver) {
+	t.identifiers = resolver
+}
+
 // HandleTool implements the ToolHandler interface for validate_hgvs
 func (t *ValidateHGVSTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
 	t.logger.WithField("tool", "validate_hgvs").Info("Processing HGVS validation request")
@@ -97,8 +112,11 @@ func (t *ValidateHGVSTool) HandleTool(ctx context.Context, req *protocol.JSONRPC
 		}
 	}
 
-	// Perform HGVS validation
-	result := t.validateHGVS(&params)
+	// Perform HGVS validation, resolving rsIDs and ClinVar accessions first
+	result := t.validateIdentifier(ctx, &params)
+	if result == nil {
+		result = t.validateHGVS(&params)
+	}
 
 	t.logger.WithFields(logrus.Fields{
 		"hgvs":      params.HGVSNotation,
@@ -123,8 +141,8 @@ func (t *ValidateHGVSTool) GetToolInfo() protocol.ToolInfo {
 			"properties": map[string]interface{}{
 				"hgvs_notation": map[string]interface{}{
 					"type":        "string",
-					"description": "HGVS notation string to validate",
-					"examples":    []string{"NM_000492.3:c.1521_1523delCTT", "NC_000007.14:g.117199644_117199645insA"},
+					"description": "HGVS notation string to validate, or a dbSNP rsID or ClinVar VCV/RCV accession to resolve and validate",
+					"examples":    []string{"NM_000492.3:c.1521_1523delCTT", "NC_000007.14:g.117199644_117199645insA", "rs80357906", "RCV000019241"},
 				},
 				"strict_mode": map[string]interface{}{
 					"type":        "boolean",
@@ -164,6 +182,9 @@ func (t *ValidateHGVSTool) validateHGVS(params *ValidateHGVSParams) *ValidateHGV
 
 	// Check if classifier service is available
 	if t.classifierService == nil {
+		if result := t.validateStructural(hgvs); result != nil {
+			return result
+		}
 		// Fall back to basic parsing for enhanced output
 		return t.validateHGVSBasic(hgvs)
 	}
@@ -196,6 +217,18 @@ func (t *ValidateHGVSTool) validateHGVS(params *ValidateHGVSParams) *ValidateHGV
 			Description: serviceResult.PredictedProtein,
 		},
 		Suggestions: make([]string, 0),
+		Normalized:  serviceResult.Normalized,
+		Structural:  serviceResult.Structural,
+	}
+
+	// A notation that parses but cannot be placed on the reference is reported, not rejected
+	if serviceResult.NormalizationError != "" {
+		result.ValidationIssues = append(result.ValidationIssues, ValidationIssue{
+			Severity: "warning",
+			Code:     "NORMALIZATION_FAILED",
+			Message:  serviceResult.NormalizationError,
+			Position: 0,
+		})
 	}
 
 	// Populate enhanced GeneInfo (REQ-MCP-001)
@@ -231,6 +264,42 @@ func (t *ValidateHGVSTool) validateHGVS(params *ValidateHGVSParams) *ValidateHGV
 	return result
 }
 
+// validateIdentifier validates the notation an rsID or ClinVar accession
+// resolves to. It returns nil when the input is not an identifier.
+func (t *ValidateHGVSTool) validateIdentifier(ctx context.Context, params *ValidateHGVSParams) *ValidateHGVSResult {
+	variant, mapping, err := resolveNotation(ctx, t.identifiers, strings.TrimSpace(params.HGVSNotation))
+	if err != nil {
+		result := &ValidateHGVSResult{
+			IsValid:      false,
+			HGVSNotation: strings.TrimSpace(params.HGVSNotation),
+			ValidationIssues: []ValidationIssue{{
+				Severity: "error",
+				Code:     "IDENTIFIER_UNRESOLVED",
+				Message:  err.Error(),
+				Position: 0,
+			}},
+			Suggestions:  make([]string, 0),
+			ResolvedFrom: mapping,
+		}
+		// Offer each allele of a multi-allelic rsID as a notation to validate
+		if mapping != nil {
+			for i := range mapping.Variants {
+				result.Suggestions = append(result.Suggestions, variantid.Notation(&mapping.Variants[i]))
+			}
+		}
+		return result
+	}
+	if variant == nil {
+		return nil
+	}
+
+	resolved := *params
+	resolved.HGVSNotation = variantid.Notation(variant)
+	result := t.validateHGVS(&resolved)
+	result.ResolvedFrom = mapping
+	return result
+}
+
 // validateHGVSBasic performs basic HGVS validation without the classifier service
 // Used as fallback when service is not available, still provides enhanced output
 func (t *ValidateHGVSTool) validateHGVSBasic(hgvs string) *ValidateHGVSResult {
@@ -289,6 +358,47 @@ func (t *ValidateHGVSTool) validateHGVSBasic(hgvs string) *ValidateHGVSResult {
 	return result
 }
 
+// validateStructural validates a repeat expansion, inversion or translocation
+// junction. It returns nil when the notation is not structural.
+func (t *ValidateHGVSTool) validateStructural(notation string) *ValidateHGVSResult {
+	if !hgvs.IsStructural(notation) {
+		return nil
+	}
+
+	result := &ValidateHGVSResult{
+		HGVSNotation:     notation,
+		ValidationIssues: make([]ValidationIssue, 0),
+		Suggestions:      make([]string, 0),
+	}
+
+	structural, err := hgvs.ParseStructural(notation)
+	if err != nil {
+		result.ValidationIssues = append(result.ValidationIssues, ValidationIssue{
+			Severity: "error",
+			Code:     "HGVS_INVALID",
+			Message:  err.Error(),
+			Position: 0,
+		})
+		return result
+	}
+
+	result.IsValid = true
+	result.NormalizedHGVS = structural.Notation
+	result.Structural = structural
+	result.ParsedComponents = HGVSComponents{
+		Reference:   extractReference(structural.Notation),
+		VariantType: string(structural.Kind),
+		Description: structural.Notation,
+	}
+	result.ValidationIssues = append(result.ValidationIssues, ValidationIssue{
+		Severity: "info",
+		Code:     "STRUCTURAL_VARIANT",
+		Message:  fmt.Sprintf("%s is outside the ACMG/AMP sequence variant criteria; classify_variant returns it for manual review", structural.Kind),
+		Position: 0,
+	})
+	return result
+}
+
 // getGeneName returns the full gene name for a symbol
 // In production, this would query a gene database
 func (t *ValidateHGVSTool) getGeneName(symbol string) string {
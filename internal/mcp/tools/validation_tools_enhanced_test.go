@@ -229,3 +229,32 @@ func TestValidateHGVS_EnhancedOutput_GeneSymbolInput(t *testing.T) {
 		t.Logf("Suggestions provided: %v", validation.Suggestions)
 	}
 }
+
+// TestValidateHGVS_StructuralVariant tests that repeat expansions and balanced
+// structural variants validate without a classifier service
+func TestValidateHGVS_StructuralVariant(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tool := NewValidateHGVSTool(logger, nil)
+
+	response := tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "validate_hgvs",
+		Params:  map[string]interface{}{"hgvs_notation": "NC_000023.11:g.154000000_154500000inv"},
+		ID:      1,
+	})
+
+	assert.Nil(t, response.Error)
+	validation := response.Result.(map[string]interface{})["validation"].(*ValidateHGVSResult)
+	assert.True(t, validation.IsValid)
+	assert.NotNil(t, validation.Structural)
+	assert.Equal(t, "inversion", validation.ParsedComponents.VariantType)
+
+	response = tool.HandleTool(context.Background(), &protocol.JSONRPC2Request{
+		JSONRPC: "2.0",
+		Method:  "validate_hgvs",
+		Params:  map[string]interface{}{"hgvs_notation": "NC_000001.11:g.200_100inv"},
+		ID:      1,
+	})
+	validation = response.Result.(map[string]interface{})["validation"].(*ValidateHGVSResult)
+	assert.False(t, validation.IsValid)
+}
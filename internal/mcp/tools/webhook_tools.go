@@ -0,0 +1,347 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/webhook"
+)
+
+// webhookStoreError maps webhook store errors to tool responses
+func webhookStoreError(logger *logrus.Logger, action string, err error) *protocol.JSONRPC2Response {
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		return invalidParamsError("Webhook not found", err.Error())
+	case errors.Is(err, webhook.ErrInvalidWebhook):
+		return invalidParamsError(err.Error())
+	}
+	logger.WithError(err).Errorf("Failed to %s", action)
+	return internalError("Failed to "+action, err.Error())
+}
+
+// =============================================================================
+// Register Webhook Tool
+// =============================================================================
+
+// RegisterWebhookTool implements the register_webhook MCP tool
+type RegisterWebhookTool struct {
+	logger *logrus.Logger
+	store  webhook.Store
+}
+
+// RegisterWebhookParams defines parameters for the register_webhook tool
+type RegisterWebhookParams struct {
+	URL       string   `json:"url"`
+	Secret    string   `json:"secret"`
+	Events    []string `json:"events,omitempty"`
+	Variants  []string `json:"variants,omitempty"`
+	CuratorID string   `json:"curator_id"`
+}
+
+// NewRegisterWebhookTool creates a new register_webhook tool
+func NewRegisterWebhookTool(logger *logrus.Logger, store webhook.Store) *RegisterWebhookTool {
+	return &RegisterWebhookTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for register_webhook
+func (t *RegisterWebhookTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name: "register_webhook",
+		Description: "Register a webhook that receives signed JSON POSTs when a batch classification finishes (batch.completed), " +
+			"a variant is classified differently from its previous classification (classification.changed), or " +
+			"external database evidence for a watched variant changes (evidence.updated). Each delivery carries an " +
+			"X-ACMG-Signature header: sha256= and the hex HMAC-SHA256 of the X-ACMG-Timestamp header, a dot and the body, keyed by the secret.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"url": map[string]interface{}{
+					"type":        "string",
+					"description": "HTTP or HTTPS URL events are posted to",
+				},
+				"secret": map[string]interface{}{
+					"type":        "string",
+					"description": "Shared secret used to sign deliveries; it is not returned by list_webhooks",
+				},
+				"events": map[string]interface{}{
+					"type":        "array",
+					"description": "Event types to deliver; all when omitted",
+					"items": map[string]interface{}{
+						"type": "string",
+						"enum": webhook.EventTypes,
+					},
+				},
+				"variants": map[string]interface{}{
+					"type":        "array",
+					"description": "Normalized HGVS of watched variants. Variant events are limited to these when given; evidence.updated is only sent for watched variants",
+					"items":       map[string]interface{}{"type": "string"},
+				},
+				"curator_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the administrator registering the webhook",
+				},
+			},
+			"required": []string{"url", "secret", "curator_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *RegisterWebhookTool) ValidateParams(params interface{}) error {
+	var p RegisterWebhookParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.URL) == "" {
+		return fmt.Errorf("url is required")
+	}
+	if strings.TrimSpace(p.Secret) == "" {
+		return fmt.Errorf("secret is required")
+	}
+	if strings.TrimSpace(p.CuratorID) == "" {
+		return fmt.Errorf("curator_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the register_webhook tool request
+func (t *RegisterWebhookTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params RegisterWebhookParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError(err.Error())
+	}
+
+	hook := &webhook.Webhook{
+		URL:       params.URL,
+		Secret:    params.Secret,
+		Events:    params.Events,
+		Variants:  params.Variants,
+		CreatedBy: params.CuratorID,
+	}
+	if err := t.store.Create(ctx, hook); err != nil {
+		return webhookStoreError(t.logger, "register webhook", err)
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"webhook_id": hook.ID,
+		"url":        hook.URL,
+		"events":     hook.Events,
+	}).Info("Registered webhook")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"webhook": hook,
+		},
+	}
+}
+
+// =============================================================================
+// List Webhooks Tool
+// =============================================================================
+
+// ListWebhooksTool implements the list_webhooks MCP tool
+type ListWebhooksTool struct {
+	logger *logrus.Logger
+	store  webhook.Store
+}
+
+// NewListWebhooksTool creates a new list_webhooks tool
+func NewListWebhooksTool(logger *logrus.Logger, store webhook.Store) *ListWebhooksTool {
+	return &ListWebhooksTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for list_webhooks
+func (t *ListWebhooksTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "list_webhooks",
+		Description: "List registered webhooks with their event filters and watched variants. Secrets are not returned.",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ListWebhooksTool) ValidateParams(params interface{}) error {
+	return nil // No parameters
+}
+
+// HandleTool handles the list_webhooks tool request
+func (t *ListWebhooksTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	webhooks, err := t.store.List(ctx)
+	if err != nil {
+		return webhookStoreError(t.logger, "list webhooks", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"webhooks": webhooks,
+			"total":    len(webhooks),
+		},
+	}
+}
+
+// =============================================================================
+// Remove Webhook Tool
+// =============================================================================
+
+// RemoveWebhookTool implements the remove_webhook MCP tool
+type RemoveWebhookTool struct {
+	logger *logrus.Logger
+	store  webhook.Store
+}
+
+// WebhookIDParams defines parameters for tools that act on a single webhook
+type WebhookIDParams struct {
+	WebhookID int64 `json:"webhook_id"`
+}
+
+// NewRemoveWebhookTool creates a new remove_webhook tool
+func NewRemoveWebhookTool(logger *logrus.Logger, store webhook.Store) *RemoveWebhookTool {
+	return &RemoveWebhookTool{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GetToolInfo returns the tool information for remove_webhook
+func (t *RemoveWebhookTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "remove_webhook",
+		Description: "Remove a registered webhook so it no longer receives events.",
+		InputSchema: webhookIDSchema(),
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *RemoveWebhookTool) ValidateParams(params interface{}) error {
+	var p WebhookIDParams
+	return parseWebhookID(params, &p)
+}
+
+// HandleTool handles the remove_webhook tool request
+func (t *RemoveWebhookTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params WebhookIDParams
+	if err := parseWebhookID(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	if err := t.store.Remove(ctx, params.WebhookID); err != nil {
+		return webhookStoreError(t.logger, "remove webhook", err)
+	}
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"success": true,
+			"message": fmt.Sprintf("Removed webhook %d", params.WebhookID),
+		},
+	}
+}
+
+// =============================================================================
+// Test Webhook Tool
+// =============================================================================
+
+// TestWebhookTool implements the test_webhook MCP tool
+type TestWebhookTool struct {
+	logger     *logrus.Logger
+	store      webhook.Store
+	dispatcher *webhook.Dispatcher
+}
+
+// NewTestWebhookTool creates a new test_webhook tool
+func NewTestWebhookTool(logger *logrus.Logger, store webhook.Store, dispatcher *webhook.Dispatcher) *TestWebhookTool {
+	return &TestWebhookTool{
+		logger:     logger,
+		store:      store,
+		dispatcher: dispatcher,
+	}
+}
+
+// GetToolInfo returns the tool information for test_webhook
+func (t *TestWebhookTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "test_webhook",
+		Description: "Send a signed ping event to a registered webhook and report whether it was accepted, to check the URL and signature verification.",
+		InputSchema: webhookIDSchema(),
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *TestWebhookTool) ValidateParams(params interface{}) error {
+	var p WebhookIDParams
+	return parseWebhookID(params, &p)
+}
+
+// HandleTool handles the test_webhook tool request
+func (t *TestWebhookTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params WebhookIDParams
+	if err := parseWebhookID(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	webhooks, err := t.store.List(ctx)
+	if err != nil {
+		return webhookStoreError(t.logger, "list webhooks", err)
+	}
+	var hook *webhook.Webhook
+	for _, w := range webhooks {
+		if w.ID == params.WebhookID {
+			hook = w
+		}
+	}
+	if hook == nil {
+		return webhookStoreError(t.logger, "test webhook", fmt.Errorf("%w: %d", webhook.ErrNotFound, params.WebhookID))
+	}
+
+	event := &webhook.Event{Type: webhook.EventPing, Data: map[string]interface{}{"webhook_id": hook.ID}}
+	err = t.dispatcher.Deliver(ctx, hook, event)
+	result := map[string]interface{}{
+		"webhook_id": hook.ID,
+		"event_id":   event.ID,
+		"delivered":  err == nil,
+	}
+	if err != nil {
+		result["error"] = err.Error()
+	}
+
+	return &protocol.JSONRPC2Response{Result: result}
+}
+
+// webhookIDSchema is the input schema of tools that act on a single webhook
+func webhookIDSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"webhook_id": map[string]interface{}{
+				"type":        "integer",
+				"description": "ID of the webhook",
+			},
+		},
+		"required": []string{"webhook_id"},
+	}
+}
+
+func parseWebhookID(params interface{}, target *WebhookIDParams) error {
+	if err := ParseParams(params, target); err != nil {
+		return err
+	}
+	if target.WebhookID <= 0 {
+		return fmt.Errorf("webhook_id is required")
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/benign"
+	"github.com/acmg-amp-mcp-server/internal/snapshot"
+	"github.com/acmg-amp-mcp-server/internal/webhook"
+)
+
+// recordingPublisher collects published webhook events
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []*webhook.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event *webhook.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func createTestWebhookStore(t *testing.T) *webhook.SQLiteStore {
+	t.Helper()
+
+	store, err := webhook.NewSQLiteStore(filepath.Join(t.TempDir(), "webhooks.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestWebhookTools_RegisterListTestRemove(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestWebhookStore(t)
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(webhook.HeaderSignature)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	// Act
+	registered := NewRegisterWebhookTool(logger, store).HandleTool(ctx, toolRequest("register_webhook", map[string]interface{}{
+		"url":        server.URL,
+		"secret":     "s3cret",
+		"events":     []string{webhook.EventBatchCompleted},
+		"curator_id": "admin-1",
+	}))
+	invalid := NewRegisterWebhookTool(logger, store).HandleTool(ctx, toolRequest("register_webhook", map[string]interface{}{
+		"url":        server.URL,
+		"secret":     "s3cret",
+		"events":     []string{"variant.deleted"},
+		"curator_id": "admin-1",
+	}))
+	listed := NewListWebhooksTool(logger, store).HandleTool(ctx, toolRequest("list_webhooks", nil))
+	tested := NewTestWebhookTool(logger, store, webhook.NewDispatcher(logger, store)).HandleTool(ctx, toolRequest("test_webhook", map[string]interface{}{"webhook_id": 1}))
+	removed := NewRemoveWebhookTool(logger, store).HandleTool(ctx, toolRequest("remove_webhook", map[string]interface{}{"webhook_id": 1}))
+	missing := NewRemoveWebhookTool(logger, store).HandleTool(ctx, toolRequest("remove_webhook", map[string]interface{}{"webhook_id": 1}))
+
+	// Assert
+	require.Nil(t, registered.Error)
+	assert.Equal(t, int64(1), registered.Result.(map[string]interface{})["webhook"].(*webhook.Webhook).ID)
+	require.NotNil(t, invalid.Error)
+	require.Nil(t, listed.Error)
+	assert.Equal(t, 1, listed.Result.(map[string]interface{})["total"])
+	require.Nil(t, tested.Error)
+	assert.Equal(t, true, tested.Result.(map[string]interface{})["delivered"])
+	assert.Contains(t, signature, "sha256=")
+	require.Nil(t, removed.Error)
+	require.NotNil(t, missing.Error)
+}
+
+func TestClassifyVariantsBatchTool_PublishesBatchCompleted(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := createTestBenignStore(t)
+	require.NoError(t, store.Add(context.Background(), &benign.Entry{
+		NormalizedHGVS: "NM_007294.4:c.4308T>C",
+		Classification: "benign",
+		Evidence:       "BA1",
+		ConfirmedBy:    "curator-1",
+	}))
+	classifyTool := NewClassifyVariantToolLegacy(logger, nil)
+	classifyTool.SetKnownBenignStore(store)
+	tool := NewClassifyVariantsBatchTool(logger, classifyTool, 10, 2)
+	publisher := &recordingPublisher{}
+	tool.SetWebhookPublisher(publisher)
+
+	// Act
+	response := tool.HandleTool(context.Background(), toolRequest("classify_variants_batch", map[string]interface{}{
+		"hgvs_notations": []string{"NM_007294.4:c.4308T>C"},
+	}))
+
+	// Assert
+	require.Nil(t, response.Error)
+	require.Len(t, publisher.events, 1)
+	event := publisher.events[0]
+	assert.Equal(t, webhook.EventBatchCompleted, event.Type)
+	data := event.Data.(map[string]interface{})
+	assert.Equal(t, 1, data["succeeded_variants"])
+	assert.Equal(t, 1, data["known_benign_variants"])
+	assert.Empty(t, data["reclassified_variants"])
+}
+
+func TestQueryEvidenceTool_PublishesEvidenceUpdated(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	archive, err := snapshot.NewFileArchive(filepath.Join(t.TempDir(), "archive"))
+	require.NoError(t, err)
+	snapshots, err := snapshot.NewSQLiteStore(filepath.Join(t.TempDir(), "evidence.db"), archive)
+	require.NoError(t, err)
+	defer snapshots.Close()
+
+	tool := NewQueryEvidenceTool(logger)
+	tool.SetSnapshotStore(snapshots)
+	publisher := &recordingPublisher{}
+	tool.SetWebhookPublisher(publisher)
+	ctx := context.Background()
+	hgvs := "NM_007294.4:c.5266dup"
+
+	// Act: the first query has nothing to compare with; the second changes ClinVar only
+	tool.recordSnapshot(ctx, &QueryEvidenceResult{HGVSNotation: hgvs, DatabaseResults: map[string]interface{}{
+		"clinvar": map[string]string{"significance": "Likely pathogenic"},
+		"gnomad":  map[string]float64{"af": 0.0001},
+	}})
+	tool.recordSnapshot(ctx, &QueryEvidenceResult{HGVSNotation: hgvs, DatabaseResults: map[string]interface{}{
+		"clinvar": map[string]string{"significance": "Pathogenic"},
+		"gnomad":  map[string]float64{"af": 0.0001},
+		"lovd":    map[string]int{"entries": 3},
+	}})
+
+	// Assert
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, webhook.EventEvidenceUpdated, publisher.events[0].Type)
+	assert.Equal(t, hgvs, publisher.events[0].Variant)
+	assert.Equal(t, []string{"clinvar"}, publisher.events[0].Data.(map[string]interface{})["changed_sources"])
+}
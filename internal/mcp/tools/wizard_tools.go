@@ -0,0 +1,884 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/wizard"
+)
+
+// Sources of a criterion's result in a guided classification
+const (
+	CriterionSourceAutomated = "automated"
+	CriterionSourceAnalyst   = "analyst"
+)
+
+// SessionCriterion is a criterion under review: its automated result and the
+// analyst's assertion on it, if any
+type SessionCriterion struct {
+	CriterionExplanation
+	Assertion *wizard.Assertion `json:"assertion,omitempty"`
+}
+
+// SessionStep is the evidence category an analyst reviews next
+type SessionStep struct {
+	Category string             `json:"category"`
+	Label    string             `json:"label"`
+	Position int                `json:"position"` // 1-based position in the walk through the categories
+	Of       int                `json:"of"`
+	Criteria []SessionCriterion `json:"criteria"`
+}
+
+// MergedCriterion is a criterion of a session's call and where its result came from
+type MergedCriterion struct {
+	ACMGAMPRuleResult
+	Source     string `json:"source"` // automated or analyst
+	AssertedBy string `json:"asserted_by,omitempty"`
+}
+
+// SessionCall is the classification the automated evidence and the analyst's
+// assertions combine to under the ACMG/AMP combining rules
+type SessionCall struct {
+	Classification  string            `json:"classification"`
+	Confidence      string            `json:"confidence"`
+	CombinationRule string            `json:"combination_rule,omitempty"`
+	AppliedCriteria []string          `json:"applied_criteria"` // ClinGen labels, e.g. PS3_Moderate
+	Criteria        []MergedCriterion `json:"criteria,omitempty"`
+}
+
+// ClassificationSessionResult reports a session's progress and what to do next
+type ClassificationSessionResult struct {
+	Session     *wizard.Session `json:"session"`
+	NextStep    *SessionStep    `json:"next_step,omitempty"` // nil once every category is reviewed
+	Remaining   []string        `json:"remaining_categories"`
+	Provisional *SessionCall    `json:"provisional_call,omitempty"` // Call from the evidence merged so far
+	NextAction  string          `json:"next_action"`
+}
+
+// FinalClassificationResult is the analyst-attributed call of a finalized session
+type FinalClassificationResult struct {
+	SessionID               int64     `json:"session_id"`
+	VariantID               string    `json:"variant_id,omitempty"`
+	HGVSNotation            string    `json:"hgvs_notation"`
+	AnalystID               string    `json:"analyst_id"` // Analyst the final call is attributed to
+	AutomatedClassification string    `json:"automated_classification"`
+	ChangedFromAutomated    bool      `json:"changed_from_automated"`
+	AnalystAssertions       int       `json:"analyst_assertions"`
+	AuditRecordID           int64     `json:"audit_record_id,omitempty"` // Audit trail record of the final call
+	FinalizedAt             time.Time `json:"finalized_at"`
+	Summary                 string    `json:"summary"`
+	SessionCall
+}
+
+func sessionCategoryNames() []string {
+	names := make([]string, len(evidenceCategories))
+	for i, category := range evidenceCategories {
+		names[i] = category.name
+	}
+	return names
+}
+
+// sessionCategoryIndex returns the position of a category in the walk, or -1
+func sessionCategoryIndex(name string) int {
+	for i, category := range evidenceCategories {
+		if category.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// remainingCategories lists the categories not yet reviewed, the current one first
+func remainingCategories(session *wizard.Session) []string {
+	i := sessionCategoryIndex(session.CurrentCategory)
+	if i < 0 {
+		return []string{}
+	}
+	return sessionCategoryNames()[i:]
+}
+
+// categoryCriteria returns the criteria reviewed in a category. Criteria a
+// specification adds are reviewed with the last category, as in
+// explain_classification.
+func categoryCriteria(category evidenceCategory, rules []ACMGAMPRuleResult) []string {
+	codes := append([]string(nil), category.criteria...)
+	if category.name != "other" {
+		return codes
+	}
+	listed := make(map[string]bool)
+	for _, c := range evidenceCategories {
+		for _, code := range c.criteria {
+			listed[code] = true
+		}
+	}
+	for _, rule := range rules {
+		if !listed[rule.RuleCode] {
+			listed[rule.RuleCode] = true
+			codes = append(codes, rule.RuleCode)
+		}
+	}
+	return codes
+}
+
+// criterionDefaults returns the direction and strength a criterion has in
+// the 2015 guidelines, from its prefix
+func criterionDefaults(code string) (string, string) {
+	category := domain.PATHOGENIC_RULE
+	if strings.HasPrefix(code, "B") {
+		category = domain.BENIGN_RULE
+	}
+	switch {
+	case strings.HasPrefix(code, "PVS"), strings.HasPrefix(code, "BA"):
+		return string(category), string(domain.VERY_STRONG)
+	case strings.HasPrefix(code, "PS"), strings.HasPrefix(code, "BS"):
+		return string(category), string(domain.STRONG)
+	case strings.HasPrefix(code, "PM"):
+		return string(category), string(domain.MODERATE)
+	}
+	return string(category), string(domain.SUPPORTING)
+}
+
+// sessionRules decodes the criteria evaluated automatically when the session started
+func sessionRules(session *wizard.Session) ([]ACMGAMPRuleResult, error) {
+	var rules []ACMGAMPRuleResult
+	if len(session.AutomatedRules) > 0 {
+		if err := json.Unmarshal(session.AutomatedRules, &rules); err != nil {
+			return nil, fmt.Errorf("failed to decode automated criteria: %w", err)
+		}
+	}
+	return rules, nil
+}
+
+// sessionStep builds the review of a category: each criterion's automated
+// result and any assertion the analyst has made on it
+func sessionStep(session *wizard.Session, rules []ACMGAMPRuleResult, index int) *SessionStep {
+	category := evidenceCategories[index]
+	byCode := make(map[string]ACMGAMPRuleResult, len(rules))
+	for _, rule := range rules {
+		byCode[rule.RuleCode] = rule
+	}
+
+	step := &SessionStep{
+		Category: category.name,
+		Label:    category.label,
+		Position: index + 1,
+		Of:       len(evidenceCategories),
+		Criteria: []SessionCriterion{},
+	}
+	for _, code := range categoryCriteria(category, rules) {
+		var criterion SessionCriterion
+		if rule, ok := byCode[code]; ok {
+			criterion.CriterionExplanation = explainCriterion(rule, nil, nil, nil)
+		} else {
+			direction, strength := criterionDefaults(code)
+			criterion.CriterionExplanation = CriterionExplanation{
+				Code:      code,
+				Direction: strings.ToLower(direction),
+				Strength:  strings.ToLower(strength),
+				Status:    CriterionNotEvaluated,
+				Rationale: "Not evaluated automatically; assess manually",
+			}
+			if definition, ok := ACMGAMPRules[code]; ok {
+				criterion.Name = definition.Name
+				criterion.Description = definition.Description
+			}
+		}
+		if assertion, ok := session.AssertionFor(code); ok {
+			criterion.Assertion = &assertion
+		}
+		step.Criteria = append(step.Criteria, criterion)
+	}
+	return step
+}
+
+// mergeEvidence overlays the analyst's assertions on the automated criteria
+func mergeEvidence(rules []ACMGAMPRuleResult, assertions []wizard.Assertion) []MergedCriterion {
+	merged := make([]MergedCriterion, 0, len(rules)+len(assertions))
+	index := make(map[string]int, len(rules))
+	for _, rule := range rules {
+		index[rule.RuleCode] = len(merged)
+		merged = append(merged, MergedCriterion{ACMGAMPRuleResult: rule, Source: CriterionSourceAutomated})
+	}
+
+	for _, assertion := range assertions {
+		i, ok := index[assertion.Code]
+		if !ok {
+			category, strength := criterionDefaults(assertion.Code)
+			rule := ACMGAMPRuleResult{RuleCode: assertion.Code, Category: category, Strength: strength}
+			if definition, ok := ACMGAMPRules[assertion.Code]; ok {
+				rule.RuleName = definition.Name
+			}
+			i = len(merged)
+			index[assertion.Code] = i
+			merged = append(merged, MergedCriterion{ACMGAMPRuleResult: rule})
+		}
+		criterion := &merged[i]
+		criterion.Applied = assertion.Applied
+		if assertion.Strength != "" {
+			criterion.Strength = assertion.Strength
+		}
+		criterion.Confidence = 1
+		criterion.Reasoning = fmt.Sprintf("Asserted by %s: %s", assertion.AssertedBy, assertion.Justification)
+		criterion.Source = CriterionSourceAnalyst
+		criterion.AssertedBy = assertion.AssertedBy
+	}
+	return merged
+}
+
+// combineSession combines the merged criteria of a session into a call
+func combineSession(classifier *service.ClassifierService, session *wizard.Session, rules []ACMGAMPRuleResult) (*SessionCall, error) {
+	if classifier == nil {
+		return nil, fmt.Errorf("classification service not configured")
+	}
+
+	merged := mergeEvidence(rules, session.Assertions)
+	results := make([]service.RuleResult, len(merged))
+	for i, criterion := range merged {
+		results[i] = service.RuleResult{
+			RuleCode:   criterion.RuleCode,
+			RuleName:   criterion.RuleName,
+			Category:   criterion.Category,
+			Strength:   criterion.Strength,
+			Applied:    criterion.Applied,
+			Confidence: criterion.Confidence,
+			Evidence:   criterion.Evidence,
+			Reasoning:  criterion.Reasoning,
+		}
+	}
+	combined, err := classifier.CombineEvidence(results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to combine evidence: %w", err)
+	}
+
+	call := &SessionCall{
+		Classification:  combined.Classification,
+		Confidence:      combined.Confidence,
+		CombinationRule: combined.CombinationRule,
+		AppliedCriteria: []string{},
+		Criteria:        merged,
+	}
+	for _, criterion := range merged {
+		if criterion.Applied {
+			call.AppliedCriteria = append(call.AppliedCriteria, labkb.CriterionLabel(criterion.RuleCode, criterion.Strength))
+		}
+	}
+	return call, nil
+}
+
+// sessionProgress reports where a session stands. The provisional call is
+// left out when no classifier service is configured.
+func sessionProgress(classifier *service.ClassifierService, session *wizard.Session) (*ClassificationSessionResult, error) {
+	rules, err := sessionRules(session)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ClassificationSessionResult{Session: session, Remaining: remainingCategories(session)}
+	if i := sessionCategoryIndex(session.CurrentCategory); i >= 0 {
+		result.NextStep = sessionStep(session, rules, i)
+		result.NextAction = fmt.Sprintf("Review the %s criteria, then call provide_evidence with session_id %d and category %q, asserting any criterion to apply or reject with a justification. Give no assertions to accept the automated results.",
+			strings.ToLower(result.NextStep.Label), session.ID, session.CurrentCategory)
+	} else {
+		result.NextAction = fmt.Sprintf("Every category is reviewed. Call finalize_classification with session_id %d to sign out the call.", session.ID)
+	}
+	if classifier != nil {
+		call, err := combineSession(classifier, session, rules)
+		if err != nil {
+			return nil, err
+		}
+		call.Criteria = nil
+		result.Provisional = call
+	}
+	return result, nil
+}
+
+// sessionStoreError maps a session store error to a tool error response
+func sessionStoreError(logger *logrus.Logger, action string, err error) *protocol.JSONRPC2Response {
+	switch {
+	case errors.Is(err, wizard.ErrSessionNotFound):
+		return invalidParamsError("Classification session not found", err.Error())
+	case errors.Is(err, wizard.ErrSessionFinalized):
+		return invalidParamsError("Classification session already finalized", err.Error())
+	}
+	logger.WithError(err).Errorf("Failed to %s", action)
+	return internalError("Failed to "+action, err.Error())
+}
+
+// =============================================================================
+// Start Classification Session Tool
+// =============================================================================
+
+// StartClassificationSessionTool implements the start_classification_session MCP tool
+type StartClassificationSessionTool struct {
+	logger       *logrus.Logger
+	store        wizard.Store
+	classifyTool *ClassifyVariantTool
+	audit        audit.Store
+}
+
+// StartClassificationSessionParams defines parameters for the
+// start_classification_session tool: the analyst, and a prior
+// classification's audit record ID or a variant to classify
+type StartClassificationSessionParams struct {
+	AnalystID        string `json:"analyst_id"`
+	ClassificationID int64  `json:"classification_id,omitempty"`
+	ClassifyVariantParams
+}
+
+// NewStartClassificationSessionTool creates a new start_classification_session
+// tool. Without an audit store sessions start from fresh classifications only.
+func NewStartClassificationSessionTool(logger *logrus.Logger, store wizard.Store, classifyTool *ClassifyVariantTool, auditStore audit.Store) *StartClassificationSessionTool {
+	return &StartClassificationSessionTool{
+		logger:       logger,
+		store:        store,
+		classifyTool: classifyTool,
+		audit:        auditStore,
+	}
+}
+
+// GetToolInfo returns the tool information for start_classification_session
+func (t *StartClassificationSessionTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "start_classification_session",
+		Description: "Start a guided classification of a variant. The variant is classified automatically, or a prior classification is taken from the audit trail, and the analyst is walked through the ACMG/AMP criteria one evidence category at a time (population, computational, functional, segregation, de novo, allelic, other database, other). Review each category with provide_evidence, then sign out the call with finalize_classification.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"analyst_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the analyst running the session",
+				},
+				"classification_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "Audit record ID of a prior classification to start from, as listed by query_audit_trail",
+				},
+				"hgvs_notation": map[string]interface{}{
+					"type":        "string",
+					"description": "HGVS notation, rsID or ClinVar accession of a variant to classify",
+				},
+				"gene_symbol_notation": map[string]interface{}{
+					"type":        "string",
+					"description": "Gene symbol notation of a variant to classify, e.g. 'TP53:c.273G>A'",
+				},
+				"condition": map[string]interface{}{
+					"type":        "string",
+					"description": "Condition under evaluation; selects gene/condition-specific frequency thresholds",
+				},
+				"patient_context": map[string]interface{}{
+					"type":        "object",
+					"description": "De novo and segregation evidence, as for classify_variant",
+				},
+			},
+			"required": []string{"analyst_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *StartClassificationSessionTool) ValidateParams(params interface{}) error {
+	var p StartClassificationSessionParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.AnalystID) == "" {
+		return fmt.Errorf("analyst_id is required")
+	}
+	hasVariant := strings.TrimSpace(p.HGVSNotation) != "" || strings.TrimSpace(p.GeneSymbolNotation) != ""
+	switch {
+	case p.ClassificationID < 0:
+		return fmt.Errorf("classification_id must be positive")
+	case p.ClassificationID > 0 && hasVariant:
+		return fmt.Errorf("give either classification_id or a variant, not both")
+	case p.ClassificationID > 0:
+		if t.audit == nil {
+			return fmt.Errorf("classification_id requires the audit trail, which is not configured")
+		}
+		return nil
+	case !hasVariant:
+		return fmt.Errorf("classification_id, hgvs_notation or gene_symbol_notation is required")
+	case strings.EqualFold(strings.TrimSpace(p.ClassificationContext), service.ContextSomatic):
+		return fmt.Errorf("guided sessions cover ACMG/AMP germline criteria; somatic variants are tiered by classify_variant")
+	}
+	return t.classifyTool.ValidateParams(params)
+}
+
+// HandleTool handles the start_classification_session tool request
+func (t *StartClassificationSessionTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params StartClassificationSessionParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	session := &wizard.Session{
+		AnalystID:       params.AnalystID,
+		CurrentCategory: evidenceCategories[0].name,
+	}
+	var rules []ACMGAMPRuleResult
+	if params.ClassificationID > 0 {
+		record, err := t.audit.Get(ctx, params.ClassificationID)
+		if err != nil {
+			return auditStoreError(t.logger, "get audit record", err)
+		}
+		if record.Error != "" {
+			return invalidParamsError("Classification failed; there is nothing to review", record.Error)
+		}
+		if len(record.AppliedRules) > 0 {
+			if err := json.Unmarshal(record.AppliedRules, &rules); err != nil {
+				return internalError("Failed to decode recorded criteria", err.Error())
+			}
+		}
+		session.ClassificationID = record.ID
+		session.VariantID = record.VariantID
+		session.HGVSNotation = record.HGVSNotation
+		session.AutomatedClassification = record.Classification
+	} else {
+		classified, err := t.classifyTool.classifyVariant(ctx, &params.ClassifyVariantParams)
+		if err != nil {
+			return &protocol.JSONRPC2Response{
+				Error: &protocol.RPCError{
+					Code:    protocol.MCPToolError,
+					Message: "Classification failed",
+					Data:    err.Error(),
+				},
+			}
+		}
+		rules = classified.AppliedRules
+		session.VariantID = classified.VariantID
+		session.HGVSNotation = params.HGVSNotation
+		if session.HGVSNotation == "" {
+			session.HGVSNotation = params.GeneSymbolNotation
+		}
+		session.AutomatedClassification = classified.Classification
+	}
+
+	automated, err := json.Marshal(rules)
+	if err != nil {
+		return internalError("Failed to encode automated criteria", err.Error())
+	}
+	session.AutomatedRules = automated
+	if err := t.store.Create(ctx, session); err != nil {
+		return sessionStoreError(t.logger, "create classification session", err)
+	}
+
+	result, err := sessionProgress(t.classifyTool.classifierService, session)
+	if err != nil {
+		return internalError("Failed to report session progress", err.Error())
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"session_id": session.ID,
+		"variant":    session.HGVSNotation,
+		"analyst_id": session.AnalystID,
+	}).Info("Classification session started")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"classification_session": result,
+		},
+	}
+}
+
+// =============================================================================
+// Provide Evidence Tool
+// =============================================================================
+
+// ProvideEvidenceTool implements the provide_evidence MCP tool
+type ProvideEvidenceTool struct {
+	logger     *logrus.Logger
+	store      wizard.Store
+	classifier *service.ClassifierService
+}
+
+// EvidenceAssertion is an analyst's call on one criterion of a category
+type EvidenceAssertion struct {
+	Code          string `json:"code"`
+	Applied       *bool  `json:"applied,omitempty"`  // Defaults to true
+	Strength      string `json:"strength,omitempty"` // Defaults to the automated or guideline strength
+	Justification string `json:"justification"`
+}
+
+// ProvideEvidenceParams defines parameters for the provide_evidence tool
+type ProvideEvidenceParams struct {
+	SessionID  int64               `json:"session_id"`
+	AnalystID  string              `json:"analyst_id"`
+	Category   string              `json:"category,omitempty"` // Defaults to the category under review
+	Assertions []EvidenceAssertion `json:"assertions,omitempty"`
+}
+
+// NewProvideEvidenceTool creates a new provide_evidence tool
+func NewProvideEvidenceTool(logger *logrus.Logger, store wizard.Store, classifier *service.ClassifierService) *ProvideEvidenceTool {
+	return &ProvideEvidenceTool{
+		logger:     logger,
+		store:      store,
+		classifier: classifier,
+	}
+}
+
+// GetToolInfo returns the tool information for provide_evidence
+func (t *ProvideEvidenceTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "provide_evidence",
+		Description: "Review an evidence category of a guided classification session. Assert criteria the automated evaluation missed or got wrong, each with a justification; the assertions replace the automated results in the final call. Reviewing the category under review moves the session on to the next; naming an earlier category revises it. Give no assertions to accept the automated results.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"session_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID of the session, from start_classification_session",
+				},
+				"analyst_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the analyst making the assertions",
+				},
+				"category": map[string]interface{}{
+					"type":        "string",
+					"description": "Evidence category reviewed (defaults to the category under review)",
+					"enum":        sessionCategoryNames(),
+				},
+				"assertions": map[string]interface{}{
+					"type":        "array",
+					"description": "Criteria to apply or reject, overriding the automated results",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"code": map[string]interface{}{
+								"type":        "string",
+								"description": "Criterion code in the category, e.g. PS3",
+							},
+							"applied": map[string]interface{}{
+								"type":        "boolean",
+								"description": "Whether the criterion is met (default true)",
+							},
+							"strength": map[string]interface{}{
+								"type":        "string",
+								"description": "Strength to apply the criterion at, e.g. moderate for PS3_Moderate",
+								"enum":        []string{"very_strong", "strong", "moderate", "supporting"},
+							},
+							"justification": map[string]interface{}{
+								"type":        "string",
+								"description": "Why the criterion is or is not met, e.g. the study or family data relied on",
+							},
+						},
+						"required": []string{"code", "justification"},
+					},
+				},
+			},
+			"required": []string{"session_id", "analyst_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *ProvideEvidenceTool) ValidateParams(params interface{}) error {
+	var p ProvideEvidenceParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.SessionID <= 0 {
+		return fmt.Errorf("session_id is required")
+	}
+	if strings.TrimSpace(p.AnalystID) == "" {
+		return fmt.Errorf("analyst_id is required")
+	}
+	if p.Category != "" && sessionCategoryIndex(p.Category) < 0 {
+		return fmt.Errorf("category must be one of: %s", strings.Join(sessionCategoryNames(), ", "))
+	}
+	seen := make(map[string]bool, len(p.Assertions))
+	for i, assertion := range p.Assertions {
+		code := strings.ToUpper(strings.TrimSpace(assertion.Code))
+		if code == "" {
+			return fmt.Errorf("assertions[%d]: code is required", i)
+		}
+		if seen[code] {
+			return fmt.Errorf("assertions[%d]: %s is asserted more than once", i, code)
+		}
+		seen[code] = true
+		if strings.TrimSpace(assertion.Justification) == "" {
+			return fmt.Errorf("assertions[%d]: a justification is required for %s", i, code)
+		}
+		if assertion.Strength != "" {
+			if _, err := domain.ParseRuleStrength(assertion.Strength); err != nil {
+				return fmt.Errorf("assertions[%d]: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// HandleTool handles the provide_evidence tool request
+func (t *ProvideEvidenceTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params ProvideEvidenceParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	session, err := t.store.Get(ctx, params.SessionID)
+	if err != nil {
+		return sessionStoreError(t.logger, "get classification session", err)
+	}
+	if !session.IsOpen() {
+		return invalidParamsError("Classification session already finalized", wizard.ErrSessionFinalized.Error())
+	}
+
+	// Categories are reviewed in order; any reviewed category may be revised
+	current := sessionCategoryIndex(session.CurrentCategory)
+	category := params.Category
+	if category == "" {
+		if current < 0 {
+			return invalidParamsError("Every category is reviewed; give the category to revise")
+		}
+		category = session.CurrentCategory
+	}
+	index := sessionCategoryIndex(category)
+	if current >= 0 && index > current {
+		return invalidParamsError(fmt.Sprintf("Review the %s category before %s", session.CurrentCategory, category))
+	}
+
+	rules, err := sessionRules(session)
+	if err != nil {
+		return internalError("Failed to read classification session", err.Error())
+	}
+	allowed := make(map[string]bool)
+	for _, code := range categoryCriteria(evidenceCategories[index], rules) {
+		allowed[code] = true
+	}
+	now := time.Now().UTC()
+	for _, given := range params.Assertions {
+		code := strings.ToUpper(strings.TrimSpace(given.Code))
+		if !allowed[code] {
+			return invalidParamsError(fmt.Sprintf("%s is not a %s criterion", code, category))
+		}
+		assertion := wizard.Assertion{
+			Code:          code,
+			Category:      category,
+			Applied:       given.Applied == nil || *given.Applied,
+			Justification: strings.TrimSpace(given.Justification),
+			AssertedBy:    params.AnalystID,
+			AssertedAt:    now,
+		}
+		if given.Strength != "" {
+			strength, _ := domain.ParseRuleStrength(given.Strength)
+			assertion.Strength = string(strength)
+		}
+		session.Assert(assertion)
+	}
+
+	if index == current {
+		session.CurrentCategory = ""
+		if index+1 < len(evidenceCategories) {
+			session.CurrentCategory = evidenceCategories[index+1].name
+		}
+	}
+	if err := t.store.Update(ctx, session); err != nil {
+		return sessionStoreError(t.logger, "update classification session", err)
+	}
+
+	result, err := sessionProgress(t.classifier, session)
+	if err != nil {
+		return internalError("Failed to report session progress", err.Error())
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"session_id": session.ID,
+		"category":   category,
+		"assertions": len(params.Assertions),
+		"analyst_id": params.AnalystID,
+	}).Info("Classification session evidence provided")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"classification_session": result,
+		},
+	}
+}
+
+// =============================================================================
+// Finalize Classification Tool
+// =============================================================================
+
+// FinalizeClassificationTool implements the finalize_classification MCP tool
+type FinalizeClassificationTool struct {
+	logger     *logrus.Logger
+	store      wizard.Store
+	classifier *service.ClassifierService
+	audit      audit.Store
+}
+
+// FinalizeClassificationParams defines parameters for the finalize_classification tool
+type FinalizeClassificationParams struct {
+	SessionID int64  `json:"session_id"`
+	AnalystID string `json:"analyst_id"`
+}
+
+// NewFinalizeClassificationTool creates a new finalize_classification tool.
+// With an audit store the final call is recorded in the audit trail.
+func NewFinalizeClassificationTool(logger *logrus.Logger, store wizard.Store, classifier *service.ClassifierService, auditStore audit.Store) *FinalizeClassificationTool {
+	return &FinalizeClassificationTool{
+		logger:     logger,
+		store:      store,
+		classifier: classifier,
+		audit:      auditStore,
+	}
+}
+
+// GetToolInfo returns the tool information for finalize_classification
+func (t *FinalizeClassificationTool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{
+		Name:        "finalize_classification",
+		Description: "Sign out the call of a guided classification session once every evidence category is reviewed. The automated criteria and the analyst's assertions are merged and combined under the ACMG/AMP combining rules; the call is attributed to the analyst, recorded in the audit trail and the session is closed to further evidence.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"session_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID of the session, from start_classification_session",
+				},
+				"analyst_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the analyst signing out the call",
+				},
+			},
+			"required": []string{"session_id", "analyst_id"},
+		},
+	}
+}
+
+// ValidateParams validates the input parameters
+func (t *FinalizeClassificationTool) ValidateParams(params interface{}) error {
+	var p FinalizeClassificationParams
+	if err := ParseParams(params, &p); err != nil {
+		return err
+	}
+	if p.SessionID <= 0 {
+		return fmt.Errorf("session_id is required")
+	}
+	if strings.TrimSpace(p.AnalystID) == "" {
+		return fmt.Errorf("analyst_id is required")
+	}
+	return nil
+}
+
+// HandleTool handles the finalize_classification tool request
+func (t *FinalizeClassificationTool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	var params FinalizeClassificationParams
+	if err := ParseParams(req.Params, &params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+	if err := t.ValidateParams(req.Params); err != nil {
+		return invalidParamsError("Invalid parameters", err.Error())
+	}
+
+	session, err := t.store.Get(ctx, params.SessionID)
+	if err != nil {
+		return sessionStoreError(t.logger, "get classification session", err)
+	}
+	if !session.IsOpen() {
+		return invalidParamsError("Classification session already finalized", wizard.ErrSessionFinalized.Error())
+	}
+	if remaining := remainingCategories(session); len(remaining) > 0 {
+		return invalidParamsError("Review every evidence category before finalizing", "not yet reviewed: "+strings.Join(remaining, ", "))
+	}
+
+	rules, err := sessionRules(session)
+	if err != nil {
+		return internalError("Failed to read classification session", err.Error())
+	}
+	call, err := combineSession(t.classifier, session, rules)
+	if err != nil {
+		return internalError("Failed to combine evidence", err.Error())
+	}
+
+	finalizedAt := time.Now().UTC()
+	session.Status = wizard.StatusFinalized
+	session.FinalClassification = call.Classification
+	session.FinalConfidence = call.Confidence
+	session.FinalizedBy = params.AnalystID
+	session.FinalizedAt = &finalizedAt
+	session.FinalRecordID = t.recordAudit(ctx, session, call)
+	if err := t.store.Update(ctx, session); err != nil {
+		return sessionStoreError(t.logger, "finalize classification session", err)
+	}
+
+	result := &FinalClassificationResult{
+		SessionID:               session.ID,
+		VariantID:               session.VariantID,
+		HGVSNotation:            session.HGVSNotation,
+		AnalystID:               params.AnalystID,
+		AutomatedClassification: session.AutomatedClassification,
+		ChangedFromAutomated:    !strings.EqualFold(call.Classification, session.AutomatedClassification),
+		AnalystAssertions:       len(session.Assertions),
+		AuditRecordID:           session.FinalRecordID,
+		FinalizedAt:             finalizedAt,
+		SessionCall:             *call,
+	}
+	result.Summary = fmt.Sprintf("%s (%s confidence), signed out by %s", call.Classification, call.Confidence, params.AnalystID)
+	if len(call.AppliedCriteria) > 0 {
+		result.Summary += ": " + strings.Join(call.AppliedCriteria, ", ")
+	}
+	if result.ChangedFromAutomated {
+		result.Summary += fmt.Sprintf("; automated classification was %s", session.AutomatedClassification)
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"session_id":     session.ID,
+		"variant":        session.HGVSNotation,
+		"classification": call.Classification,
+		"analyst_id":     params.AnalystID,
+	}).Info("Classification session finalized")
+
+	return &protocol.JSONRPC2Response{
+		Result: map[string]interface{}{
+			"final_classification": result,
+		},
+	}
+}
+
+// recordAudit appends the final call to the audit trail and returns the
+// record ID; store failures are logged and ignored
+func (t *FinalizeClassificationTool) recordAudit(ctx context.Context, session *wizard.Session, call *SessionCall) int64 {
+	if t.audit == nil {
+		return 0
+	}
+
+	rules := make([]ACMGAMPRuleResult, len(call.Criteria))
+	for i, criterion := range call.Criteria {
+		rules[i] = criterion.ACMGAMPRuleResult
+	}
+	record := &audit.Record{
+		VariantID:      session.VariantID,
+		HGVSNotation:   session.HGVSNotation,
+		Classification: call.Classification,
+		Confidence:     call.Confidence,
+		EngineVersion:  service.EngineVersion,
+		ScoringMode:    string(service.ScoringModeCombiningRules),
+	}
+	record.Request, _ = json.Marshal(map[string]interface{}{
+		"session_id": session.ID,
+		"analyst_id": session.FinalizedBy,
+		"assertions": session.Assertions,
+	})
+	record.AppliedRules, _ = json.Marshal(rules)
+
+	if err := t.audit.Append(ctx, record); err != nil {
+		t.logger.WithError(err).WithField("session_id", session.ID).Warn("Failed to record final classification in the audit trail")
+		return 0
+	}
+	return record.ID
+}
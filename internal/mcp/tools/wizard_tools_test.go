@@ -0,0 +1,198 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/service"
+	"github.com/acmg-amp-mcp-server/internal/wizard"
+)
+
+func createTestSessionStore(t *testing.T) *wizard.SQLiteStore {
+	t.Helper()
+
+	store, err := wizard.NewSQLiteStore(filepath.Join(t.TempDir(), "classification_sessions.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestClassificationSessionTools_GuidedWorkflow(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	auditStore := createTestAuditStore(t)
+	sessions := createTestSessionStore(t)
+	classifier := service.NewClassifierService(logger, nil, nil, nil)
+	start := NewStartClassificationSessionTool(logger, sessions, NewClassifyVariantToolLegacy(logger, classifier), auditStore)
+	provide := NewProvideEvidenceTool(logger, sessions, classifier)
+	finalize := NewFinalizeClassificationTool(logger, sessions, classifier, auditStore)
+
+	rules, _ := json.Marshal([]service.ACMGAMPRuleResult{
+		{RuleCode: "PM2", RuleName: "Absent from controls", Category: "PATHOGENIC", Strength: "MODERATE", Applied: true,
+			Reasoning: "Variant absent or extremely rare in population databases"},
+		{RuleCode: "BA1", RuleName: "Allele frequency >5%", Category: "BENIGN", Strength: "VERY_STRONG",
+			Reasoning: "Population frequency below threshold: 0.000010"},
+	})
+	record := &audit.Record{
+		VariantID:      "VAR_1",
+		HGVSNotation:   "NM_000492.4:c.1521_1523del",
+		Request:        json.RawMessage(`{"hgvs_notation":"NM_000492.4:c.1521_1523del"}`),
+		AppliedRules:   rules,
+		Classification: "Uncertain significance",
+		Confidence:     "Low",
+		EngineVersion:  service.EngineVersion,
+	}
+	require.NoError(t, auditStore.Append(ctx, record))
+
+	// Act: start from the recorded classification
+	response := start.HandleTool(ctx, toolRequest("start_classification_session", map[string]interface{}{
+		"analyst_id":        "analyst-1",
+		"classification_id": record.ID,
+	}))
+
+	// Assert: the walk starts with population data
+	require.Nil(t, response.Error)
+	started := response.Result.(map[string]interface{})["classification_session"].(*ClassificationSessionResult)
+	sessionID := started.Session.ID
+	assert.Equal(t, record.ID, started.Session.ClassificationID)
+	assert.Equal(t, "analyst-1", started.Session.AnalystID)
+	require.NotNil(t, started.NextStep)
+	assert.Equal(t, "population", started.NextStep.Category)
+	assert.Equal(t, 1, started.NextStep.Position)
+	assert.Len(t, started.Remaining, len(evidenceCategories))
+	codes := make([]string, len(started.NextStep.Criteria))
+	for i, criterion := range started.NextStep.Criteria {
+		codes[i] = criterion.Code
+	}
+	assert.Equal(t, []string{"BA1", "BS1", "BS2", "PM2", "PS4"}, codes)
+	assert.Equal(t, CriterionApplied, started.NextStep.Criteria[3].Status)
+	assert.Equal(t, CriterionNotEvaluated, started.NextStep.Criteria[1].Status, "BS1 was not evaluated automatically")
+	require.NotNil(t, started.Provisional)
+	assert.Equal(t, []string{"PM2"}, started.Provisional.AppliedCriteria)
+
+	// Categories cannot be skipped
+	skipped := provide.HandleTool(ctx, toolRequest("provide_evidence", map[string]interface{}{
+		"session_id": sessionID, "analyst_id": "analyst-1", "category": "functional",
+	}))
+	require.NotNil(t, skipped.Error)
+
+	// Accept the automated population and computational results
+	for range []string{"population", "computational"} {
+		accepted := provide.HandleTool(ctx, toolRequest("provide_evidence", map[string]interface{}{
+			"session_id": sessionID, "analyst_id": "analyst-1",
+		}))
+		require.Nil(t, accepted.Error)
+	}
+
+	// Assertions must belong to the category and be justified
+	wrongCategory := provide.HandleTool(ctx, toolRequest("provide_evidence", map[string]interface{}{
+		"session_id": sessionID, "analyst_id": "analyst-1",
+		"assertions": []interface{}{map[string]interface{}{"code": "PS2", "justification": "Trio confirmed"}},
+	}))
+	require.NotNil(t, wrongCategory.Error)
+	unjustified := provide.HandleTool(ctx, toolRequest("provide_evidence", map[string]interface{}{
+		"session_id": sessionID, "analyst_id": "analyst-1",
+		"assertions": []interface{}{map[string]interface{}{"code": "PS3"}},
+	}))
+	require.NotNil(t, unjustified.Error)
+
+	functional := provide.HandleTool(ctx, toolRequest("provide_evidence", map[string]interface{}{
+		"session_id": sessionID, "analyst_id": "analyst-2",
+		"assertions": []interface{}{map[string]interface{}{"code": "ps3", "justification": "Patch clamp shows loss of channel function"}},
+	}))
+	require.Nil(t, functional.Error)
+	progress := functional.Result.(map[string]interface{})["classification_session"].(*ClassificationSessionResult)
+	assert.Equal(t, "segregation", progress.NextStep.Category)
+	assert.Equal(t, []string{"PM2", "PS3"}, progress.Provisional.AppliedCriteria)
+
+	// Finalizing needs every category reviewed
+	early := finalize.HandleTool(ctx, toolRequest("finalize_classification", map[string]interface{}{
+		"session_id": sessionID, "analyst_id": "analyst-2",
+	}))
+	require.NotNil(t, early.Error)
+	for _, category := range []string{"segregation", "de_novo", "allelic", "other_database", "other"} {
+		reviewed := provide.HandleTool(ctx, toolRequest("provide_evidence", map[string]interface{}{
+			"session_id": sessionID, "analyst_id": "analyst-2", "category": category,
+		}))
+		require.Nil(t, reviewed.Error, category)
+	}
+
+	// Revise an earlier category: PS3 at moderate
+	revised := provide.HandleTool(ctx, toolRequest("provide_evidence", map[string]interface{}{
+		"session_id": sessionID, "analyst_id": "analyst-2", "category": "functional",
+		"assertions": []interface{}{map[string]interface{}{"code": "PS3", "strength": "moderate", "justification": "Assay not yet validated"}},
+	}))
+	require.Nil(t, revised.Error)
+	assert.Nil(t, revised.Result.(map[string]interface{})["classification_session"].(*ClassificationSessionResult).NextStep)
+
+	final := finalize.HandleTool(ctx, toolRequest("finalize_classification", map[string]interface{}{
+		"session_id": sessionID, "analyst_id": "analyst-2",
+	}))
+
+	// Assert: the call merges the assertion and is attributed to the analyst
+	require.Nil(t, final.Error)
+	result := final.Result.(map[string]interface{})["final_classification"].(*FinalClassificationResult)
+	assert.Equal(t, "analyst-2", result.AnalystID)
+	assert.Equal(t, []string{"PM2", "PS3_Moderate"}, result.AppliedCriteria)
+	assert.Equal(t, 1, result.AnalystAssertions)
+	assert.Contains(t, result.Summary, "signed out by analyst-2")
+	var ps3 MergedCriterion
+	for _, criterion := range result.Criteria {
+		if criterion.RuleCode == "PS3" {
+			ps3 = criterion
+		}
+	}
+	assert.Equal(t, CriterionSourceAnalyst, ps3.Source)
+	assert.Equal(t, "Asserted by analyst-2: Assay not yet validated", ps3.Reasoning)
+
+	recorded, err := auditStore.Get(ctx, result.AuditRecordID)
+	require.NoError(t, err)
+	assert.Equal(t, result.Classification, recorded.Classification)
+	assert.Contains(t, string(recorded.Request), `"analyst_id":"analyst-2"`)
+
+	session, err := sessions.Get(ctx, sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, wizard.StatusFinalized, session.Status)
+	assert.Equal(t, "analyst-2", session.FinalizedBy)
+
+	// A finalized session takes no more evidence
+	again := finalize.HandleTool(ctx, toolRequest("finalize_classification", map[string]interface{}{
+		"session_id": sessionID, "analyst_id": "analyst-2",
+	}))
+	require.NotNil(t, again.Error)
+	late := provide.HandleTool(ctx, toolRequest("provide_evidence", map[string]interface{}{
+		"session_id": sessionID, "analyst_id": "analyst-2", "category": "functional",
+	}))
+	require.NotNil(t, late.Error)
+}
+
+func TestMergeEvidence(t *testing.T) {
+	rules := []ACMGAMPRuleResult{
+		{RuleCode: "PP3", Category: "PATHOGENIC", Strength: "SUPPORTING", Applied: true, Reasoning: "REVEL 0.8"},
+		{RuleCode: "PM2", Category: "PATHOGENIC", Strength: "MODERATE", Applied: true},
+	}
+	assertions := []wizard.Assertion{
+		{Code: "PP3", Applied: false, Justification: "Splice predictor disagrees", AssertedBy: "analyst-1"},
+		{Code: "BS2", Applied: true, Justification: "Homozygous in healthy adult", AssertedBy: "analyst-1"},
+	}
+
+	// Act
+	merged := mergeEvidence(rules, assertions)
+
+	// Assert
+	require.Len(t, merged, 3)
+	assert.False(t, merged[0].Applied, "the analyst rejected PP3")
+	assert.Equal(t, CriterionSourceAnalyst, merged[0].Source)
+	assert.Equal(t, CriterionSourceAutomated, merged[1].Source)
+	assert.Equal(t, "BS2", merged[2].RuleCode)
+	assert.Equal(t, "BENIGN", merged[2].Category)
+	assert.Equal(t, "STRONG", merged[2].Strength)
+	assert.Equal(t, "analyst-1", merged[2].AssertedBy)
+}
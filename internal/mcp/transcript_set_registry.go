@@ -0,0 +1,57 @@
+// Package mcp provides the MCP server implementation.
+// This file contains specialty transcript set resource registration logic.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/resources"
+	"github.com/acmg-amp-mcp-server/internal/transcriptset"
+)
+
+// registerTranscriptSetResources registers the /acmg/transcript-sets index
+// resource and the /acmg/transcript-sets/{specialty} resource template.
+func registerTranscriptSetResources(mcpServer *mcp.Server, logger *logrus.Logger, registry *transcriptset.Registry) {
+	provider := resources.NewTranscriptSetResourceProvider(logger, registry)
+	handler := func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		content, err := provider.GetResource(ctx, strings.TrimPrefix(uri, diseaseURIScheme))
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.MCPResourceError, err)
+		}
+		text, err := json.Marshal(content.Content)
+		if err != nil {
+			return nil, resourceError(ctx, logger, uri, protocol.InternalError, fmt.Errorf("failed to encode transcript set: %w", err))
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: content.MimeType, Text: string(text)}},
+		}, nil
+	}
+
+	resource := &mcp.Resource{
+		Name:        "transcript_sets",
+		Title:       "Specialty Transcript Sets",
+		Description: "Index of the loaded per-specialty transcript sets selected by ordering_specialty",
+		MIMEType:    "application/json",
+		URI:         diseaseURIScheme + "/acmg/transcript-sets",
+		Meta:        resourceTags(provider, "/acmg/transcript-sets"),
+	}
+	mcpServer.AddResource(resource, handler)
+
+	template := &mcp.ResourceTemplate{
+		Name:        "specialty_transcript_set",
+		Title:       "Specialty Transcript Set",
+		Description: "The RefSeq transcript an ordering specialty mandates for each gene",
+		MIMEType:    "application/json",
+		URITemplate: diseaseURIScheme + "/acmg/transcript-sets/{specialty}",
+	}
+	mcpServer.AddResourceTemplate(template, handler)
+	logger.WithField("uri_template", template.URITemplate).Debug("Registered transcript set resources")
+}
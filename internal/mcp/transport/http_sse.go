@@ -10,6 +10,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/metrics"
+	"github.com/acmg-amp-mcp-server/internal/middleware"
+	"github.com/acmg-amp-mcp-server/internal/readiness"
 )
 
 // HTTPSSETransport implements MCP communication over HTTP with Server-Sent Events
@@ -24,6 +29,9 @@ type HTTPSSETransport struct {
 	messagesCh  chan HTTPMessage
 	closed      bool
 	mu          sync.RWMutex
+	limiter     *middleware.RateLimiter
+	authn       *auth.Authenticator
+	toolRole    func(tool string) auth.Role
 }
 
 // SSEClient represents a connected MCP client via SSE
@@ -38,8 +46,9 @@ type SSEClient struct {
 
 // HTTPMessage represents a message received via HTTP
 type HTTPMessage struct {
-	ClientID string
-	Data     []byte
+	ClientID  string
+	Data      []byte
+	Principal *auth.Principal // Sender, when an authenticator is set
 }
 
 // NewHTTPSSETransport creates a new HTTP SSE transport for remote AI agents
@@ -66,10 +75,10 @@ func NewHTTPSSETransport(logger *logrus.Logger, host string, port int) *HTTPSSET
 // setupRoutes configures HTTP routes for MCP communication
 func (h *HTTPSSETransport) setupRoutes() {
 	// SSE endpoint for receiving messages from server
-	h.router.GET("/mcp/sse", h.handleSSEConnection)
+	h.router.GET("/mcp/sse", h.authenticate, h.rateLimit, h.handleSSEConnection)
 	
 	// HTTP endpoint for sending messages to server
-	h.router.POST("/mcp/message", h.handleMessage)
+	h.router.POST("/mcp/message", h.authenticate, h.rateLimit, h.handleMessage)
 	
 	// Health check endpoint
 	h.router.GET("/health", func(c *gin.Context) {
@@ -79,6 +88,75 @@ func (h *HTTPSSETransport) setupRoutes() {
 			"clients":   len(h.clients),
 		})
 	})
+
+	// Dependency status for liveness and readiness probes
+	h.router.GET("/healthz", gin.WrapH(readiness.Default.LivenessHandler()))
+	h.router.GET("/readyz", gin.WrapH(readiness.Default.ReadinessHandler()))
+
+	// Prometheus metrics; like the health check, not authenticated
+	h.router.GET("/metrics", gin.WrapH(metrics.Handler()))
+}
+
+// SetRateLimiter throttles MCP requests per client; the health check is exempt
+func (h *HTTPSSETransport) SetRateLimiter(limiter *middleware.RateLimiter) {
+	h.limiter = limiter
+}
+
+// SetAuthorization requires MCP requests to authenticate, and tools/call
+// requests to hold the role toolRole returns for the tool; the health check
+// is exempt
+func (h *HTTPSSETransport) SetAuthorization(authenticator *auth.Authenticator, toolRole func(tool string) auth.Role) {
+	h.authn = authenticator
+	h.toolRole = toolRole
+}
+
+// AddRoute serves a REST endpoint beside MCP, authenticated and rate limited
+// like MCP requests
+func (h *HTTPSSETransport) AddRoute(route Route) {
+	if route.Public {
+		h.router.Handle(route.Method, route.Path, h.authenticateOptional, h.rateLimit, gin.WrapH(route.Handler))
+		return
+	}
+	h.router.Handle(route.Method, route.Path, h.authenticate, h.rateLimit, h.requireRole(route.Role), gin.WrapH(route.Handler))
+}
+
+// requireRole checks the principal's role, if an authenticator is set
+func (h *HTTPSSETransport) requireRole(role auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.authn == nil {
+			c.Next()
+			return
+		}
+		middleware.RequireRole(role)(c)
+	}
+}
+
+// authenticateOptional authenticates requests carrying credentials, if an
+// authenticator is set
+func (h *HTTPSSETransport) authenticateOptional(c *gin.Context) {
+	if h.authn == nil {
+		c.Next()
+		return
+	}
+	middleware.AuthenticateOptional(h.authn)(c)
+}
+
+// authenticate applies the authenticator, if one is set
+func (h *HTTPSSETransport) authenticate(c *gin.Context) {
+	if h.authn == nil {
+		c.Next()
+		return
+	}
+	middleware.Authenticate(h.authn)(c)
+}
+
+// rateLimit applies the rate limiter, if one is set
+func (h *HTTPSSETransport) rateLimit(c *gin.Context) {
+	if h.limiter == nil {
+		c.Next()
+		return
+	}
+	middleware.RateLimit(h.limiter)(c)
 }
 
 // Start initializes the HTTP SSE transport
@@ -143,12 +221,14 @@ func (h *HTTPSSETransport) handleSSEConnection(c *gin.Context) {
 	h.clientsMu.Lock()
 	h.clients[clientID] = client
 	h.clientsMu.Unlock()
+	metrics.ActiveConnections.Inc("http-sse")
 
 	defer func() {
 		// Unregister client
 		h.clientsMu.Lock()
 		delete(h.clients, clientID)
 		h.clientsMu.Unlock()
+		metrics.ActiveConnections.Dec("http-sse")
 		close(client.Done)
 		h.logger.WithField("client_id", clientID).Info("SSE client disconnected")
 	}()
@@ -190,9 +270,22 @@ func (h *HTTPSSETransport) handleMessage(c *gin.Context) {
 		return
 	}
 
+	principal := auth.PrincipalFrom(c.Request.Context())
+	if h.authn != nil {
+		if err := authorizeMessage(principal, h.toolRole, message); err != nil {
+			h.logger.WithFields(logrus.Fields{
+				"client_id": clientID,
+				"subject":   principal.Subject,
+				"role":      principal.Role,
+			}).WithError(err).Warn("Rejected unauthorized MCP request")
+			middleware.Forbid(c, err)
+			return
+		}
+	}
+
 	// Queue message for processing
 	select {
-	case h.messagesCh <- HTTPMessage{ClientID: clientID, Data: message}:
+	case h.messagesCh <- HTTPMessage{ClientID: clientID, Data: message, Principal: principal}:
 		c.JSON(http.StatusOK, gin.H{"status": "received"})
 	default:
 		h.logger.Error("Message queue full")
@@ -210,11 +303,18 @@ func (h *HTTPSSETransport) processMessages(ctx context.Context) {
 
 // ReadMessage reads a message from the HTTP transport
 func (h *HTTPSSETransport) ReadMessage() ([]byte, error) {
+	data, _, err := h.ReadMessageWithPrincipal()
+	return data, err
+}
+
+// ReadMessageWithPrincipal reads a message from the HTTP transport with the
+// principal that posted it
+func (h *HTTPSSETransport) ReadMessageWithPrincipal() ([]byte, *auth.Principal, error) {
 	select {
 	case msg := <-h.messagesCh:
-		return msg.Data, nil
+		return msg.Data, msg.Principal, nil
 	case <-time.After(30 * time.Second):
-		return nil, fmt.Errorf("read timeout")
+		return nil, nil, fmt.Errorf("read timeout")
 	}
 }
 
@@ -300,4 +400,36 @@ func (h *HTTPSSETransport) GetConnectedClients() int {
 	h.clientsMu.RLock()
 	defer h.clientsMu.RUnlock()
 	return len(h.clients)
-}
\ No newline at end of file
+}
+
+// authorizeMessage checks that the principal may send a JSON-RPC message or
+// batch. Every method is open to an authenticated principal except
+// tools/call, which requires the role toolRole returns for the tool.
+func authorizeMessage(principal *auth.Principal, toolRole func(tool string) auth.Role, message json.RawMessage) error {
+	type call struct {
+		Method string `json:"method"`
+		Params struct {
+			Name string `json:"name"`
+		} `json:"params"`
+	}
+
+	var calls []call
+	if err := json.Unmarshal(message, &calls); err != nil {
+		var single call
+		if err := json.Unmarshal(message, &single); err != nil {
+			// Malformed messages are rejected by the protocol layer
+			return nil
+		}
+		calls = []call{single}
+	}
+
+	for _, c := range calls {
+		if c.Method != "tools/call" || toolRole == nil {
+			continue
+		}
+		if err := auth.Authorize(principal, toolRole(c.Params.Name)); err != nil {
+			return fmt.Errorf("tool %s: %w", c.Params.Name, err)
+		}
+	}
+	return nil
+}
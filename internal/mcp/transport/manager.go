@@ -3,6 +3,7 @@ package transport
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 	"sync"
@@ -10,9 +11,15 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/acmg-amp-mcp-server/internal/auth"
 	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/middleware"
+	"github.com/acmg-amp-mcp-server/internal/openapi"
 )
 
+// APIVersion is the version of the REST API given in its OpenAPI document
+const APIVersion = "1.0.0"
+
 // Manager handles transport creation, auto-detection, and lifecycle management
 type Manager struct {
 	logger    *logrus.Logger
@@ -21,15 +28,100 @@ type Manager struct {
 	clients   map[string]*ClientInfo
 	clientsMu sync.RWMutex
 	mu        sync.RWMutex
+	limiter   *middleware.RateLimiter
+	authn     *auth.Authenticator
+	toolRole  func(tool string) auth.Role
+	routes    []Route
 }
 
-// NewManager creates a new transport manager
+// NewManager creates a new transport manager. HTTP requests are rate limited
+// when the configuration sets a request rate or daily quota.
 func NewManager(logger *logrus.Logger, config *domain.MCPConfig) *Manager {
-	return &Manager{
+	m := &Manager{
 		logger:  logger,
 		config:  config,
 		clients: make(map[string]*ClientInfo),
 	}
+	if config != nil {
+		limits := middleware.RateLimitConfig{
+			RequestsPerSecond: config.RateLimitRPS,
+			Burst:             config.RateLimitBurst,
+			DailyQuota:        config.DailyQuota,
+			APIKeys:           config.APIKeys,
+		}
+		if limits.Enabled() {
+			m.limiter = middleware.NewRateLimiter(limits)
+		}
+	}
+	return m
+}
+
+// RateLimiter returns the HTTP rate limiter, or nil if requests are not limited
+func (m *Manager) RateLimiter() *middleware.RateLimiter {
+	return m.limiter
+}
+
+// SetAuthenticator requires clients of the HTTP transport to authenticate,
+// and their tool calls to hold the role toolRole returns for each tool. The
+// HTTP transport is not created without one; stdio clients are local and
+// trusted.
+func (m *Manager) SetAuthenticator(authenticator *auth.Authenticator, toolRole func(tool string) auth.Role) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.authn = authenticator
+	m.toolRole = toolRole
+}
+
+// AddRoute serves a REST endpoint on the HTTP and WebSocket transports
+// created afterwards; stdio has no HTTP listener to serve it on.
+func (m *Manager) AddRoute(route Route) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if route.Request != nil {
+		route.Handler = openapi.ValidateRequest(openapi.SchemaOf(route.Request), route.Handler)
+	}
+	m.routes = append(m.routes, route)
+}
+
+// OpenAPI generates the OpenAPI document of the routes added so far
+func (m *Manager) OpenAPI() *openapi.Document {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.openAPI()
+}
+
+// openAPI generates the OpenAPI document; m.mu must be held
+func (m *Manager) openAPI() *openapi.Document {
+	document := openapi.NewDocument(openapi.Info{
+		Title:       "ACMG-AMP MCP Server REST API",
+		Version:     APIVersion,
+		Description: "REST endpoints served beside MCP by the HTTP and WebSocket transports. Generated from the server's Go types.",
+	})
+	for _, route := range m.routes {
+		role := string(route.Role)
+		if route.Public {
+			role = ""
+		}
+		document.Add(openapi.Endpoint{
+			Method:   route.Method,
+			Path:     route.Path,
+			Summary:  route.Summary,
+			Request:  route.Request,
+			Response: route.Response,
+			Role:     role,
+			Public:   route.Public,
+		})
+	}
+	document.Add(openapi.Endpoint{Method: http.MethodGet, Path: openapi.Path, Summary: "This OpenAPI document", Public: true})
+	return document
+}
+
+// restRoutes returns the added routes and the route serving their OpenAPI
+// document; m.mu must be held
+func (m *Manager) restRoutes() []Route {
+	document := m.openAPI()
+	routes := append([]Route(nil), m.routes...)
+	return append(routes, Route{Method: http.MethodGet, Path: openapi.Path, Public: true, Handler: openapi.Handler(document)})
 }
 
 // AutoDetectTransport automatically detects the appropriate transport type
@@ -46,6 +138,9 @@ func (m *Manager) AutoDetectTransport() (TransportType, error) {
 			case "--http", "-http":
 				m.logger.Info("Detected HTTP transport via command line argument")
 				return TransportHTTPSSE, nil
+			case "--websocket", "-websocket", "--ws", "-ws":
+				m.logger.Info("Detected WebSocket transport via command line argument")
+				return TransportWebSocket, nil
 			}
 		}
 	}
@@ -59,6 +154,9 @@ func (m *Manager) AutoDetectTransport() (TransportType, error) {
 		case "http", "http-sse":
 			m.logger.Info("Detected HTTP SSE transport via MCP_TRANSPORT environment variable")
 			return TransportHTTPSSE, nil
+		case "websocket", "ws":
+			m.logger.Info("Detected WebSocket transport via MCP_TRANSPORT environment variable")
+			return TransportWebSocket, nil
 		default:
 			m.logger.WithField("transport_type", transportType).Warn("Unknown transport type in MCP_TRANSPORT")
 		}
@@ -73,6 +171,9 @@ func (m *Manager) AutoDetectTransport() (TransportType, error) {
 		case "http", "http-sse":
 			m.logger.Info("Using HTTP SSE transport from configuration")
 			return TransportHTTPSSE, nil
+		case "websocket", "ws":
+			m.logger.Info("Using WebSocket transport from configuration")
+			return TransportWebSocket, nil
 		default:
 			m.logger.WithField("transport_type", m.config.TransportType).Warn("Unknown transport type in configuration")
 		}
@@ -97,44 +198,92 @@ func (m *Manager) CreateTransport(transportType TransportType) (Transport, error
 	switch transportType {
 	case TransportStdio:
 		m.logger.Info("Creating stdio transport")
-		return NewStdioTransport(m.logger), nil
-	
-	case TransportHTTPSSE:
-		host := "localhost"
-		port := 8080
-		
-		// Get host/port from config or environment
+		stdio := NewStdioTransport(m.logger)
 		if m.config != nil {
-			if m.config.HTTPHost != "" {
-				host = m.config.HTTPHost
-			}
-			if m.config.HTTPPort > 0 {
-				port = m.config.HTTPPort
-			}
+			stdio.SetMaxMessageBytes(m.config.MaxMessageBytes)
 		}
-		
-		if envPort := os.Getenv("MCP_HTTP_PORT"); envPort != "" {
-			if p, err := strconv.Atoi(envPort); err == nil {
-				port = p
-			}
-		}
-		
-		if envHost := os.Getenv("MCP_HTTP_HOST"); envHost != "" {
-			host = envHost
+		return stdio, nil
+
+	case TransportHTTPSSE:
+		if m.authn == nil || !m.authn.Enabled() {
+			return nil, fmt.Errorf("HTTP transport requires authentication: configure API keys, a JWT secret or an anonymous role")
 		}
-		
+
+		host, port := m.listenAddress()
+
 		m.logger.WithFields(logrus.Fields{
 			"host": host,
 			"port": port,
 		}).Info("Creating HTTP SSE transport")
-		
-		return NewHTTPSSETransport(m.logger, host, port), nil
-	
+
+		httpTransport := NewHTTPSSETransport(m.logger, host, port)
+		if m.limiter != nil {
+			httpTransport.SetRateLimiter(m.limiter)
+		}
+		httpTransport.SetAuthorization(m.authn, m.toolRole)
+		for _, route := range m.restRoutes() {
+			httpTransport.AddRoute(route)
+		}
+		return httpTransport, nil
+
+	case TransportWebSocket:
+		if m.authn == nil || !m.authn.Enabled() {
+			return nil, fmt.Errorf("WebSocket transport requires authentication: configure API keys, a JWT secret or an anonymous role")
+		}
+
+		host, port := m.listenAddress()
+		m.logger.WithFields(logrus.Fields{
+			"host": host,
+			"port": port,
+		}).Info("Creating WebSocket transport")
+
+		wsTransport := NewWebSocketTransport(m.logger, host, port)
+		if m.config != nil {
+			wsTransport.SetKeepalive(m.config.WebSocketPingInterval, m.config.WebSocketIdleTimeout)
+			wsTransport.SetMaxMessageBytes(m.config.MaxMessageBytes)
+		}
+		if m.limiter != nil {
+			wsTransport.SetRateLimiter(m.limiter)
+		}
+		wsTransport.SetAuthorization(m.authn, m.toolRole)
+		for _, route := range m.restRoutes() {
+			wsTransport.AddRoute(route)
+		}
+		return wsTransport, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported transport type: %s", transportType)
 	}
 }
 
+// listenAddress returns the host and port of the network transports, from
+// the configuration or the MCP_HTTP_HOST and MCP_HTTP_PORT variables
+func (m *Manager) listenAddress() (string, int) {
+	host := "localhost"
+	port := 8080
+
+	// Get host/port from config or environment
+	if m.config != nil {
+		if m.config.HTTPHost != "" {
+			host = m.config.HTTPHost
+		}
+		if m.config.HTTPPort > 0 {
+			port = m.config.HTTPPort
+		}
+	}
+
+	if envPort := os.Getenv("MCP_HTTP_PORT"); envPort != "" {
+		if p, err := strconv.Atoi(envPort); err == nil {
+			port = p
+		}
+	}
+
+	if envHost := os.Getenv("MCP_HTTP_HOST"); envHost != "" {
+		host = envHost
+	}
+	return host, port
+}
+
 // StartTransport auto-detects and starts the appropriate transport
 func (m *Manager) StartTransport(ctx context.Context) (Transport, error) {
 	// Auto-detect transport type
@@ -157,7 +306,7 @@ func (m *Manager) StartTransport(ctx context.Context) (Transport, error) {
 
 	m.transport = transport
 	m.logger.WithField("transport_type", transport.GetType()).Info("Transport started successfully")
-	
+
 	return transport, nil
 }
 
@@ -257,7 +406,7 @@ func isTerminal() bool {
 	if err != nil {
 		return false
 	}
-	
+
 	// If stdin is a character device (terminal), not a pipe or regular file
 	return (stat.Mode() & os.ModeCharDevice) != 0
 }
@@ -269,4 +418,4 @@ func parseTime(timeStr string) time.Time {
 		return time.Now()
 	}
 	return t
-}
\ No newline at end of file
+}
@@ -0,0 +1,78 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+type echoRequest struct {
+	Gene string `json:"gene" validate:"required"`
+}
+
+// TestManager_OpenAPI tests that routes are documented at /openapi.json and
+// that routes with a request type have their bodies validated
+func TestManager_OpenAPI(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	authenticator, err := auth.NewAuthenticator(auth.Config{APIKeys: []auth.APIKey{{Name: "lims", Role: auth.RoleClassify, Key: "classify-key"}}})
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+	manager := NewManager(logger, &domain.MCPConfig{})
+	manager.SetAuthenticator(authenticator, func(string) auth.Role { return auth.RoleClassify })
+	manager.AddRoute(Route{
+		Method:  http.MethodPost,
+		Path:    "/api/v1/echo",
+		Role:    auth.RoleClassify,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+		Summary: "Echo",
+		Request: echoRequest{},
+	})
+
+	created, err := manager.CreateTransport(TransportWebSocket)
+	if err != nil {
+		t.Fatalf("Failed to create transport: %v", err)
+	}
+	server := httptest.NewServer(created.(*WebSocketTransport).Handler())
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/openapi.json")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	var document struct {
+		OpenAPI string                                       `json:"openapi"`
+		Paths   map[string]map[string]map[string]interface{} `json:"paths"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&document)
+	resp.Body.Close()
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected the document without credentials, got %d: %v", resp.StatusCode, err)
+	}
+	if document.OpenAPI != "3.1.0" || document.Paths["/api/v1/echo"]["post"]["summary"] != "Echo" {
+		t.Errorf("Expected the echo route in an OpenAPI 3.1 document, got %+v", document)
+	}
+
+	for body, want := range map[string]int{
+		`{"gene": "SCN1A"}`: http.StatusNoContent,
+		`{"gene": 7}`:       http.StatusBadRequest,
+	} {
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/api/v1/echo", strings.NewReader(body))
+		req.Header.Set(auth.APIKeyHeader, "classify-key")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("Body %s: expected %d, got %d", body, want, resp.StatusCode)
+		}
+	}
+}
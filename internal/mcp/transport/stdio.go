@@ -2,32 +2,69 @@ package transport
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/metrics"
 )
 
-// StdioTransport implements MCP communication over stdin/stdout
+// DefaultMaxMessageBytes is the largest stdio message accepted when no limit
+// is configured. It leaves room for VCF payloads of a few thousand variants.
+const DefaultMaxMessageBytes = 64 * 1024 * 1024
+
+// invalidRequestCode is the JSON-RPC error code for oversized messages
+const invalidRequestCode = -32600
+
+// requestIDPrefix bounds how much of an oversized message is kept to find its id
+const requestIDPrefix = 4096
+
+// requestIDPattern finds the id of a JSON-RPC request in the start of a message
+var requestIDPattern = regexp.MustCompile(`"id"\s*:\s*(-?\d+|"(?:[^"\\]|\\.)*")`)
+
+// StdioTransport implements MCP communication over stdin/stdout. Messages
+// are newline-delimited JSON, or framed with a Content-Length header as in
+// LSP; once a client sends a framed message, replies are framed too.
 type StdioTransport struct {
-	logger   *logrus.Logger
-	reader   *bufio.Scanner
-	writer   io.Writer
-	mu       sync.RWMutex
-	closed   bool
-	cancelFn context.CancelFunc
+	logger          *logrus.Logger
+	reader          *bufio.Reader
+	writer          io.Writer
+	writeMu         sync.Mutex
+	maxMessageBytes int
+	framed          bool
+	mu              sync.RWMutex
+	closed          bool
+	cancelFn        context.CancelFunc
 }
 
 // NewStdioTransport creates a new stdio transport for local AI agent connections
 func NewStdioTransport(logger *logrus.Logger) *StdioTransport {
+	return newStdioTransport(logger, os.Stdin, os.Stdout)
+}
+
+func newStdioTransport(logger *logrus.Logger, r io.Reader, w io.Writer) *StdioTransport {
 	return &StdioTransport{
-		logger: logger,
-		reader: bufio.NewScanner(os.Stdin),
-		writer: os.Stdout,
+		logger:          logger,
+		reader:          bufio.NewReaderSize(r, 64*1024),
+		writer:          w,
+		maxMessageBytes: DefaultMaxMessageBytes,
+	}
+}
+
+// SetMaxMessageBytes sets the largest message accepted. Larger messages are
+// answered with a -32600 error and skipped; the connection stays open.
+func (s *StdioTransport) SetMaxMessageBytes(n int) {
+	if n > 0 {
+		s.maxMessageBytes = n
 	}
 }
 
@@ -35,7 +72,7 @@ func NewStdioTransport(logger *logrus.Logger) *StdioTransport {
 func (s *StdioTransport) Start(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	
+
 	if s.closed {
 		return fmt.Errorf("transport is closed")
 	}
@@ -43,9 +80,10 @@ func (s *StdioTransport) Start(ctx context.Context) error {
 	// Create cancellable context for this transport
 	ctx, cancel := context.WithCancel(ctx)
 	s.cancelFn = cancel
+	metrics.ActiveConnections.Inc("stdio")
+
+	s.logger.WithField("max_message_bytes", s.maxMessageBytes).Info("Starting stdio transport for MCP communication")
 
-	s.logger.Info("Starting stdio transport for MCP communication")
-	
 	// Stdio transport is ready immediately
 	return nil
 }
@@ -54,45 +92,195 @@ func (s *StdioTransport) Start(ctx context.Context) error {
 func (s *StdioTransport) ReadMessage() ([]byte, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	
+
 	if s.closed {
 		return nil, fmt.Errorf("transport is closed")
 	}
 
-	if !s.reader.Scan() {
-		if err := s.reader.Err(); err != nil {
+	for {
+		message, err := s.readFrame()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if oversized, ok := err.(*oversizedMessageError); ok {
+			s.logger.WithFields(logrus.Fields{
+				"message_length": oversized.size,
+				"limit":          s.maxMessageBytes,
+			}).Warn("Rejected oversized message via stdio")
+			if err := s.rejectOversized(oversized); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
 			s.logger.WithError(err).Error("Failed to read from stdin")
 			return nil, fmt.Errorf("failed to read message: %w", err)
 		}
-		// EOF
-		return nil, io.EOF
+		if len(bytes.TrimSpace(message)) == 0 {
+			continue
+		}
+
+		s.logger.WithField("message_length", len(message)).Debug("Received message via stdio")
+		return message, nil
 	}
+}
+
+// oversizedMessageError reports a message over the size limit. The start of
+// the message is kept so the error reply can quote the request id.
+type oversizedMessageError struct {
+	size   int // Bytes read, or the declared Content-Length
+	prefix []byte
+}
+
+func (e *oversizedMessageError) Error() string {
+	return fmt.Sprintf("message of %d bytes exceeds the size limit", e.size)
+}
 
-	message := s.reader.Bytes()
-	s.logger.WithField("message_length", len(message)).Debug("Received message via stdio")
-	
+// readFrame reads one message, detecting Content-Length framing by its header
+func (s *StdioTransport) readFrame() ([]byte, error) {
+	for {
+		peek, err := s.reader.Peek(1)
+		if err != nil {
+			return nil, err
+		}
+		// Skip blank lines between messages
+		if peek[0] == '\n' || peek[0] == '\r' {
+			s.reader.ReadByte()
+			continue
+		}
+		if peek[0] == 'C' || peek[0] == 'c' {
+			return s.readFramedMessage()
+		}
+		return s.readLine()
+	}
+}
+
+// readLine reads a newline-delimited message without buffering more than
+// the size limit
+func (s *StdioTransport) readLine() ([]byte, error) {
+	var message []byte
+	size := 0
+	oversized := false
+read:
+	for {
+		chunk, err := s.reader.ReadSlice('\n')
+		size += len(chunk)
+		if !oversized {
+			if size > s.maxMessageBytes {
+				oversized = true
+				message = append(message, chunk...)
+				if len(message) > requestIDPrefix {
+					message = message[:requestIDPrefix]
+				}
+			} else {
+				message = append(message, chunk...)
+			}
+		}
+		switch {
+		case err == bufio.ErrBufferFull:
+			continue
+		case err == io.EOF && size > 0:
+			// Last message without a trailing newline
+		case err != nil:
+			return nil, err
+		}
+		break read
+	}
+	if oversized {
+		return nil, &oversizedMessageError{size: size, prefix: message}
+	}
+	return bytes.TrimRight(message, "\r\n"), nil
+}
+
+// readFramedMessage reads a Content-Length framed message
+func (s *StdioTransport) readFramedMessage() ([]byte, error) {
+	length := -1
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("malformed header %q", line)
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil || length < 0 {
+				return nil, fmt.Errorf("invalid Content-Length %q", value)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("framed message without Content-Length header")
+	}
+
+	s.writeMu.Lock()
+	s.framed = true
+	s.writeMu.Unlock()
+
+	if length > s.maxMessageBytes {
+		prefix := make([]byte, min(length, requestIDPrefix))
+		n, err := io.ReadFull(s.reader, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.CopyN(io.Discard, s.reader, int64(length-n)); err != nil {
+			return nil, err
+		}
+		return nil, &oversizedMessageError{size: length, prefix: prefix}
+	}
+
+	message := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, message); err != nil {
+		return nil, err
+	}
 	return message, nil
 }
 
+// rejectOversized answers an oversized request with an Invalid Request error
+func (s *StdioTransport) rejectOversized(oversized *oversizedMessageError) error {
+	id := json.RawMessage("null")
+	if match := requestIDPattern.FindSubmatch(oversized.prefix); match != nil {
+		id = json.RawMessage(match[1])
+	}
+	return s.WriteJSONMessage(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error": map[string]interface{}{
+			"code":    invalidRequestCode,
+			"message": fmt.Sprintf("Message of %d bytes exceeds the %d byte limit", oversized.size, s.maxMessageBytes),
+		},
+	})
+}
+
 // WriteMessage writes a JSON-RPC message to stdout
 func (s *StdioTransport) WriteMessage(message []byte) error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	
+
 	if s.closed {
 		return fmt.Errorf("transport is closed")
 	}
 
-	// Write message followed by newline (MCP protocol requirement)
-	if _, err := s.writer.Write(message); err != nil {
+	// Messages are written whole so concurrent replies cannot interleave
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	var frame []byte
+	if s.framed {
+		frame = append([]byte(fmt.Sprintf("Content-Length: %d\r\n\r\n", len(message))), message...)
+	} else {
+		// Newline-delimited (MCP stdio transport)
+		frame = append(append(make([]byte, 0, len(message)+1), message...), '\n')
+	}
+	if _, err := s.writer.Write(frame); err != nil {
 		s.logger.WithError(err).Error("Failed to write message to stdout")
 		return fmt.Errorf("failed to write message: %w", err)
 	}
-	
-	if _, err := s.writer.Write([]byte("\n")); err != nil {
-		s.logger.WithError(err).Error("Failed to write newline to stdout")
-		return fmt.Errorf("failed to write newline: %w", err)
-	}
 
 	s.logger.WithField("message_length", len(message)).Debug("Sent message via stdio")
 	return nil
@@ -104,7 +292,7 @@ func (s *StdioTransport) WriteJSONMessage(obj interface{}) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
-	
+
 	return s.WriteMessage(data)
 }
 
@@ -112,16 +300,17 @@ func (s *StdioTransport) WriteJSONMessage(obj interface{}) error {
 func (s *StdioTransport) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	
+
 	if s.closed {
 		return nil
 	}
-	
+
 	s.closed = true
 	if s.cancelFn != nil {
 		s.cancelFn()
+		metrics.ActiveConnections.Dec("stdio")
 	}
-	
+
 	s.logger.Info("Stdio transport closed")
 	return nil
 }
@@ -136,4 +325,4 @@ func (s *StdioTransport) IsClosed() bool {
 // GetType returns the transport type
 func (s *StdioTransport) GetType() string {
 	return "stdio"
-}
\ No newline at end of file
+}
@@ -0,0 +1,123 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func framedMessage(body string) string {
+	return "Content-Length: " + strconv.Itoa(len(body)) + "\r\n\r\n" + body
+}
+
+// TestStdioTransport_NewlineDelimited tests reading messages longer than the
+// old 64 KB line limit
+func TestStdioTransport_NewlineDelimited(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	large := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"vcf":"` + strings.Repeat("A", 200*1024) + `"}}`
+	input := large + "\n\n" + `{"jsonrpc":"2.0","id":2,"method":"ping"}` + "\n"
+	var output bytes.Buffer
+	stdio := newStdioTransport(logger, strings.NewReader(input), &output)
+
+	message, err := stdio.ReadMessage()
+	if err != nil {
+		t.Fatalf("Failed to read large message: %v", err)
+	}
+	if string(message) != large {
+		t.Errorf("Expected %d bytes, got %d", len(large), len(message))
+	}
+	message, err = stdio.ReadMessage()
+	if err != nil || !strings.Contains(string(message), `"ping"`) {
+		t.Errorf("Expected ping message, got %q (%v)", message, err)
+	}
+	if _, err := stdio.ReadMessage(); err != io.EOF {
+		t.Errorf("Expected EOF, got %v", err)
+	}
+
+	if err := stdio.WriteMessage([]byte(`{"jsonrpc":"2.0","id":2,"result":{}}`)); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+	if output.String() != `{"jsonrpc":"2.0","id":2,"result":{}}`+"\n" {
+		t.Errorf("Expected newline-delimited reply, got %q", output.String())
+	}
+}
+
+// TestStdioTransport_ContentLength tests Content-Length framed messages
+func TestStdioTransport_ContentLength(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"note":"line\nbreak"}}`
+	input := framedMessage(body) + framedMessage(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	var output bytes.Buffer
+	stdio := newStdioTransport(logger, strings.NewReader(input), &output)
+
+	message, err := stdio.ReadMessage()
+	if err != nil || string(message) != body {
+		t.Fatalf("Expected framed body, got %q (%v)", message, err)
+	}
+	message, err = stdio.ReadMessage()
+	if err != nil || !strings.Contains(string(message), `"ping"`) {
+		t.Errorf("Expected ping message, got %q (%v)", message, err)
+	}
+
+	reply := `{"jsonrpc":"2.0","id":1,"result":{}}`
+	if err := stdio.WriteMessage([]byte(reply)); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+	if output.String() != framedMessage(reply) {
+		t.Errorf("Expected framed reply, got %q", output.String())
+	}
+}
+
+// TestStdioTransport_Oversized tests that oversized messages are rejected
+// with -32600 and the next message is still read
+func TestStdioTransport_Oversized(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "newline",
+			input: `{"jsonrpc":"2.0","id":"big-1","method":"tools/call","params":{"vcf":"` + strings.Repeat("A", 4096) + `"}}` + "\n" + `{"jsonrpc":"2.0","id":2,"method":"ping"}` + "\n",
+		},
+		{
+			name:  "content-length",
+			input: framedMessage(`{"jsonrpc":"2.0","id":"big-1","method":"tools/call","params":{"vcf":"`+strings.Repeat("A", 4096)+`"}}`) + framedMessage(`{"jsonrpc":"2.0","id":2,"method":"ping"}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			stdio := newStdioTransport(logger, strings.NewReader(tt.input), &output)
+			stdio.SetMaxMessageBytes(1024)
+
+			message, err := stdio.ReadMessage()
+			if err != nil || !strings.Contains(string(message), `"ping"`) {
+				t.Fatalf("Expected ping after oversized message, got %q (%v)", message, err)
+			}
+
+			reply := output.String()
+			if i := strings.Index(reply, "{"); i >= 0 {
+				reply = reply[i:]
+			}
+			var response struct {
+				ID    string `json:"id"`
+				Error struct {
+					Code int `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal([]byte(strings.TrimSpace(reply)), &response); err != nil {
+				t.Fatalf("Invalid error reply %q: %v", output.String(), err)
+			}
+			if response.ID != "big-1" || response.Error.Code != -32600 {
+				t.Errorf("Expected -32600 for big-1, got %+v", response)
+			}
+		})
+	}
+}
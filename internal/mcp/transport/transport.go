@@ -2,38 +2,51 @@ package transport
 
 import (
 	"context"
+	"net/http"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
 )
 
 // Transport defines the interface for MCP transport mechanisms
 type Transport interface {
 	// Start initializes the transport
 	Start(ctx context.Context) error
-	
+
 	// ReadMessage reads a message from the transport
 	ReadMessage() ([]byte, error)
-	
+
 	// WriteMessage sends a message via the transport
 	WriteMessage(message []byte) error
-	
+
 	// WriteJSONMessage sends a JSON object as a message
 	WriteJSONMessage(obj interface{}) error
-	
+
 	// Close closes the transport and cleans up resources
 	Close() error
-	
+
 	// IsClosed returns whether the transport is closed
 	IsClosed() bool
-	
+
 	// GetType returns the transport type identifier
 	GetType() string
 }
 
+// PrincipalReader is implemented by transports that authenticate each
+// message rather than each connection, so that a message is read with the
+// principal that sent it
+type PrincipalReader interface {
+	// ReadMessageWithPrincipal reads a message and its sender, or nil when
+	// the transport does not authenticate
+	ReadMessageWithPrincipal() ([]byte, *auth.Principal, error)
+}
+
 // TransportType represents the type of transport
 type TransportType string
 
 const (
-	TransportStdio   TransportType = "stdio"
-	TransportHTTPSSE TransportType = "http-sse"
+	TransportStdio     TransportType = "stdio"
+	TransportHTTPSSE   TransportType = "http-sse"
+	TransportWebSocket TransportType = "websocket"
 )
 
 // TransportConfig holds configuration for transport creation
@@ -43,6 +56,24 @@ type TransportConfig struct {
 	HTTPPort int           `json:"http_port,omitempty"`
 }
 
+// Route is a REST endpoint served by the network transports beside MCP.
+// Requests authenticate and are rate limited like MCP requests, and must
+// hold Role. Public routes are also served without credentials, leaving the
+// handler to decide what such requests may see; credentials that are sent
+// must still be valid. Summary, Request and Response document the route in
+// the generated OpenAPI document; a route with a Request type has its JSON
+// bodies validated against that type's schema before Handler sees them.
+type Route struct {
+	Method   string
+	Path     string
+	Role     auth.Role
+	Public   bool
+	Handler  http.Handler
+	Summary  string
+	Request  interface{} // JSON request body, e.g. MatchRequest{}
+	Response interface{} // JSON response body
+}
+
 // ClientInfo represents information about a connected MCP client
 type ClientInfo struct {
 	ID            string            `json:"id"`
@@ -50,4 +81,4 @@ type ClientInfo struct {
 	ConnectedAt   string            `json:"connected_at"`
 	LastActivity  string            `json:"last_activity"`
 	Metadata      map[string]string `json:"metadata,omitempty"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,657 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/metrics"
+	"github.com/acmg-amp-mcp-server/internal/middleware"
+	"github.com/acmg-amp-mcp-server/internal/readiness"
+)
+
+// WebSocket keepalive defaults
+const (
+	DefaultWebSocketPingInterval = 30 * time.Second
+	DefaultWebSocketIdleTimeout  = 10 * time.Minute
+)
+
+// webSocketWriteTimeout bounds a single write, including control frames
+const webSocketWriteTimeout = 10 * time.Second
+
+// webSocketCloseGrace is how long Close waits for the client to answer the
+// close frame before dropping the connection
+const webSocketCloseGrace = 5 * time.Second
+
+// ErrSessionTransport is returned by the message methods of a transport that
+// carries one session per connection; use Accept instead
+var ErrSessionTransport = errors.New("transport carries one session per connection")
+
+// SessionTransport is a transport whose connections are separate MCP
+// sessions. The server runs one session for each accepted connection.
+type SessionTransport interface {
+	Transport
+	// Accept waits for the next connection
+	Accept(ctx context.Context) (Transport, error)
+}
+
+// SessionState is the per-connection state of a WebSocket session
+type SessionState struct {
+	ID              string                 `json:"id"`
+	RemoteAddr      string                 `json:"remote_addr"`
+	Subject         string                 `json:"subject,omitempty"`
+	Role            auth.Role              `json:"role,omitempty"`
+	ProtocolVersion string                 `json:"protocol_version,omitempty"`
+	ClientInfo      map[string]interface{} `json:"client_info,omitempty"`
+	Capabilities    map[string]interface{} `json:"capabilities,omitempty"`
+	ConnectedAt     time.Time              `json:"connected_at"`
+	LastActivity    time.Time              `json:"last_activity"`
+}
+
+// WebSocketTransport serves MCP over WebSocket at /mcp/ws. Unlike HTTP/SSE,
+// every connection is a bidirectional session of its own, with the
+// capabilities it negotiated and the principal it authenticated as.
+// Connections are kept alive with ping/pong and closed after a period
+// without messages.
+type WebSocketTransport struct {
+	logger          *logrus.Logger
+	server          *http.Server
+	router          *gin.Engine
+	host            string
+	port            int
+	upgrader        websocket.Upgrader
+	pingInterval    time.Duration
+	idleTimeout     time.Duration
+	maxMessageBytes int64
+	conns           map[string]*WebSocketConn
+	connsMu         sync.RWMutex
+	accepted        chan *WebSocketConn
+	closed          bool
+	done            chan struct{}
+	mu              sync.RWMutex
+	limiter         *middleware.RateLimiter
+	authn           *auth.Authenticator
+	toolRole        func(tool string) auth.Role
+}
+
+// NewWebSocketTransport creates a new WebSocket transport for remote AI agents
+func NewWebSocketTransport(logger *logrus.Logger, host string, port int) *WebSocketTransport {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	transport := &WebSocketTransport{
+		logger:          logger,
+		router:          router,
+		host:            host,
+		port:            port,
+		pingInterval:    DefaultWebSocketPingInterval,
+		idleTimeout:     DefaultWebSocketIdleTimeout,
+		maxMessageBytes: DefaultMaxMessageBytes,
+		conns:           make(map[string]*WebSocketConn),
+		accepted:        make(chan *WebSocketConn),
+		done:            make(chan struct{}),
+	}
+	transport.upgrader = websocket.Upgrader{
+		ReadBufferSize:  64 * 1024,
+		WriteBufferSize: 64 * 1024,
+		Subprotocols:    []string{"mcp"},
+	}
+
+	transport.setupRoutes()
+	return transport
+}
+
+// setupRoutes configures the WebSocket, health and metrics routes
+func (w *WebSocketTransport) setupRoutes() {
+	w.router.GET("/mcp/ws", w.authenticate, w.rateLimit, w.handleUpgrade)
+
+	w.router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status":    "healthy",
+			"transport": "websocket",
+			"clients":   w.GetConnectedClients(),
+		})
+	})
+
+	// Dependency status for liveness and readiness probes
+	w.router.GET("/healthz", gin.WrapH(readiness.Default.LivenessHandler()))
+	w.router.GET("/readyz", gin.WrapH(readiness.Default.ReadinessHandler()))
+
+	// Prometheus metrics; like the health check, not authenticated
+	w.router.GET("/metrics", gin.WrapH(metrics.Handler()))
+}
+
+// Handler returns the HTTP handler serving the transport's routes
+func (w *WebSocketTransport) Handler() http.Handler {
+	return w.router
+}
+
+// SetKeepalive sets how often connections are pinged and how long a
+// connection may go without messages before it is closed. Zero keeps the
+// default; a negative idle timeout disables idle closing.
+func (w *WebSocketTransport) SetKeepalive(pingInterval, idleTimeout time.Duration) {
+	if pingInterval > 0 {
+		w.pingInterval = pingInterval
+	}
+	if idleTimeout != 0 {
+		w.idleTimeout = idleTimeout
+	}
+}
+
+// SetMaxMessageBytes sets the largest message accepted; larger messages
+// close the connection with status 1009 (message too big)
+func (w *WebSocketTransport) SetMaxMessageBytes(n int) {
+	if n > 0 {
+		w.maxMessageBytes = int64(n)
+	}
+}
+
+// SetRateLimiter throttles connection attempts per client; the health check is exempt
+func (w *WebSocketTransport) SetRateLimiter(limiter *middleware.RateLimiter) {
+	w.limiter = limiter
+}
+
+// SetAuthorization requires connections to authenticate, and tools/call
+// requests to hold the role toolRole returns for the tool; the health check
+// is exempt
+func (w *WebSocketTransport) SetAuthorization(authenticator *auth.Authenticator, toolRole func(tool string) auth.Role) {
+	w.authn = authenticator
+	w.toolRole = toolRole
+}
+
+// AddRoute serves a REST endpoint beside MCP, authenticated and rate limited
+// like connection attempts
+func (w *WebSocketTransport) AddRoute(route Route) {
+	if route.Public {
+		w.router.Handle(route.Method, route.Path, w.authenticateOptional, w.rateLimit, gin.WrapH(route.Handler))
+		return
+	}
+	w.router.Handle(route.Method, route.Path, w.authenticate, w.rateLimit, w.requireRole(route.Role), gin.WrapH(route.Handler))
+}
+
+// requireRole checks the principal's role, if an authenticator is set
+func (w *WebSocketTransport) requireRole(role auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if w.authn == nil {
+			c.Next()
+			return
+		}
+		middleware.RequireRole(role)(c)
+	}
+}
+
+// authenticateOptional authenticates requests carrying credentials, if an
+// authenticator is set
+func (w *WebSocketTransport) authenticateOptional(c *gin.Context) {
+	if w.authn == nil {
+		c.Next()
+		return
+	}
+	middleware.AuthenticateOptional(w.authn)(c)
+}
+
+// authenticate applies the authenticator, if one is set
+func (w *WebSocketTransport) authenticate(c *gin.Context) {
+	if w.authn == nil {
+		c.Next()
+		return
+	}
+	middleware.Authenticate(w.authn)(c)
+}
+
+// rateLimit applies the rate limiter, if one is set
+func (w *WebSocketTransport) rateLimit(c *gin.Context) {
+	if w.limiter == nil {
+		c.Next()
+		return
+	}
+	middleware.RateLimit(w.limiter)(c)
+}
+
+// Start starts serving WebSocket connections
+func (w *WebSocketTransport) Start(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return fmt.Errorf("transport is closed")
+	}
+
+	addr := fmt.Sprintf("%s:%d", w.host, w.port)
+	w.server = &http.Server{
+		Addr:    addr,
+		Handler: w.router,
+	}
+
+	w.logger.WithFields(logrus.Fields{
+		"address":       addr,
+		"type":          "websocket",
+		"ping_interval": w.pingInterval.String(),
+		"idle_timeout":  w.idleTimeout.String(),
+	}).Info("Starting WebSocket transport for MCP communication")
+
+	go func() {
+		if err := w.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			w.logger.WithError(err).Error("WebSocket server failed")
+		}
+	}()
+
+	return nil
+}
+
+// handleUpgrade upgrades an authenticated request to a WebSocket session
+func (w *WebSocketTransport) handleUpgrade(c *gin.Context) {
+	ws, err := w.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already answered with an HTTP error
+		w.logger.WithError(err).Warn("WebSocket upgrade failed")
+		return
+	}
+
+	now := time.Now()
+	conn := &WebSocketConn{
+		logger:       w.logger,
+		conn:         ws,
+		transport:    w,
+		principal:    auth.PrincipalFrom(c.Request.Context()),
+		incoming:     make(chan []byte, 16),
+		readDone:     make(chan struct{}),
+		closing:      make(chan struct{}),
+		pingInterval: w.pingInterval,
+		idleTimeout:  w.idleTimeout,
+		state: SessionState{
+			ID:           uuid.New().String(),
+			RemoteAddr:   c.Request.RemoteAddr,
+			ConnectedAt:  now,
+			LastActivity: now,
+		},
+	}
+	if conn.principal != nil {
+		conn.state.Subject = conn.principal.Subject
+		conn.state.Role = conn.principal.Role
+	}
+	ws.SetReadLimit(w.maxMessageBytes)
+
+	w.connsMu.Lock()
+	w.conns[conn.state.ID] = conn
+	w.connsMu.Unlock()
+	metrics.ActiveConnections.Inc("websocket")
+
+	w.logger.WithFields(logrus.Fields{
+		"session_id": conn.state.ID,
+		"subject":    conn.state.Subject,
+		"remote":     conn.state.RemoteAddr,
+	}).Info("WebSocket client connected")
+
+	select {
+	case w.accepted <- conn:
+	case <-w.done:
+		conn.Close()
+	}
+}
+
+// Accept waits for the next WebSocket connection
+func (w *WebSocketTransport) Accept(ctx context.Context) (Transport, error) {
+	select {
+	case conn := <-w.accepted:
+		return conn, nil
+	case <-w.done:
+		return nil, io.EOF
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ReadMessage is not supported; each connection is read through Accept
+func (w *WebSocketTransport) ReadMessage() ([]byte, error) {
+	return nil, ErrSessionTransport
+}
+
+// WriteMessage is not supported; each connection is written through Accept
+func (w *WebSocketTransport) WriteMessage(message []byte) error {
+	return ErrSessionTransport
+}
+
+// WriteJSONMessage is not supported; each connection is written through Accept
+func (w *WebSocketTransport) WriteJSONMessage(obj interface{}) error {
+	return ErrSessionTransport
+}
+
+// Close closes every session with a close handshake and stops the server
+func (w *WebSocketTransport) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.done)
+	w.mu.Unlock()
+
+	w.connsMu.RLock()
+	conns := make([]*WebSocketConn, 0, len(w.conns))
+	for _, conn := range w.conns {
+		conns = append(conns, conn)
+	}
+	w.connsMu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, conn := range conns {
+		wg.Add(1)
+		go func(conn *WebSocketConn) {
+			defer wg.Done()
+			conn.closeWith(websocket.CloseGoingAway, "server shutting down")
+		}(conn)
+	}
+	wg.Wait()
+
+	if w.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := w.server.Shutdown(ctx); err != nil {
+			w.logger.WithError(err).Error("Error shutting down WebSocket server")
+			return err
+		}
+	}
+
+	w.logger.Info("WebSocket transport closed")
+	return nil
+}
+
+// IsClosed returns whether the transport is closed
+func (w *WebSocketTransport) IsClosed() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.closed
+}
+
+// GetType returns the transport type
+func (w *WebSocketTransport) GetType() string {
+	return "websocket"
+}
+
+// GetConnectedClients returns the number of open sessions
+func (w *WebSocketTransport) GetConnectedClients() int {
+	w.connsMu.RLock()
+	defer w.connsMu.RUnlock()
+	return len(w.conns)
+}
+
+// Sessions returns the state of every open session
+func (w *WebSocketTransport) Sessions() []SessionState {
+	w.connsMu.RLock()
+	defer w.connsMu.RUnlock()
+
+	sessions := make([]SessionState, 0, len(w.conns))
+	for _, conn := range w.conns {
+		sessions = append(sessions, conn.State())
+	}
+	return sessions
+}
+
+// removeConn forgets a closed connection
+func (w *WebSocketTransport) removeConn(id string) {
+	w.connsMu.Lock()
+	defer w.connsMu.Unlock()
+	delete(w.conns, id)
+}
+
+// WebSocketConn is one WebSocket session. It implements Transport, so the
+// server runs an MCP session over it like over stdio.
+type WebSocketConn struct {
+	logger       *logrus.Logger
+	conn         *websocket.Conn
+	transport    *WebSocketTransport
+	principal    *auth.Principal
+	incoming     chan []byte
+	readDone     chan struct{}
+	closing      chan struct{}
+	pingInterval time.Duration
+	idleTimeout  time.Duration
+	writeMu      sync.Mutex
+	stateMu      sync.RWMutex
+	state        SessionState
+	startOnce    sync.Once
+	closeOnce    sync.Once
+	mu           sync.RWMutex
+	closed       bool
+}
+
+// Start begins reading messages and sending keepalive pings
+func (c *WebSocketConn) Start(ctx context.Context) error {
+	c.startOnce.Do(func() {
+		go c.readLoop()
+		go c.keepalive(ctx)
+	})
+	return nil
+}
+
+// SessionID returns the identifier of the session
+func (c *WebSocketConn) SessionID() string {
+	return c.state.ID
+}
+
+// Principal returns the principal the connection authenticated as, or nil
+func (c *WebSocketConn) Principal() *auth.Principal {
+	return c.principal
+}
+
+// State returns a copy of the session state
+func (c *WebSocketConn) State() SessionState {
+	c.stateMu.RLock()
+	defer c.stateMu.RUnlock()
+	return c.state
+}
+
+// readLoop reads messages until the connection closes. The read deadline
+// is extended by every pong, so a client that stops answering pings is
+// dropped after two ping intervals.
+func (c *WebSocketConn) readLoop() {
+	defer close(c.readDone)
+	defer close(c.incoming)
+
+	pongWait := 2 * c.pingInterval
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		messageType, message, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				c.logger.WithError(err).WithField("session_id", c.state.ID).Warn("WebSocket connection lost")
+			}
+			return
+		}
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		if messageType != websocket.TextMessage && messageType != websocket.BinaryMessage {
+			continue
+		}
+
+		c.touch(message)
+		if c.principal != nil {
+			if err := authorizeMessage(c.principal, c.transport.toolRole, message); err != nil {
+				c.logger.WithFields(logrus.Fields{
+					"session_id": c.state.ID,
+					"subject":    c.principal.Subject,
+					"role":       c.principal.Role,
+				}).WithError(err).Warn("Rejected unauthorized MCP request")
+				c.reject(message, protocol.MCPForbidden, protocol.ErrorCodeForbidden, err)
+				continue
+			}
+		}
+
+		select {
+		case c.incoming <- message:
+		case <-c.closing:
+			return
+		}
+	}
+}
+
+// touch records activity and the parameters of an initialize request
+func (c *WebSocketConn) touch(message []byte) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.state.LastActivity = time.Now()
+
+	var request struct {
+		Method string `json:"method"`
+		Params struct {
+			ProtocolVersion string                 `json:"protocolVersion"`
+			ClientInfo      map[string]interface{} `json:"clientInfo"`
+			Capabilities    map[string]interface{} `json:"capabilities"`
+		} `json:"params"`
+	}
+	if json.Unmarshal(message, &request) != nil || request.Method != "initialize" {
+		return
+	}
+	c.state.ProtocolVersion = request.Params.ProtocolVersion
+	c.state.ClientInfo = request.Params.ClientInfo
+	c.state.Capabilities = request.Params.Capabilities
+}
+
+// webSocketRequestID matches the id of a single JSON-RPC request
+var webSocketRequestID = regexp.MustCompile(`^\s*\{.*?"id"\s*:\s*(-?\d+|"(?:[^"\\]|\\.)*")`)
+
+// reject answers a request the connection will not deliver
+func (c *WebSocketConn) reject(message []byte, code int, envelopeCode string, err error) {
+	id := json.RawMessage("null")
+	if match := webSocketRequestID.FindSubmatch(message); match != nil {
+		id = json.RawMessage(match[1])
+	}
+	c.WriteJSONMessage(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error": &protocol.RPCError{
+			Code:    code,
+			Message: err.Error(),
+			Data:    protocol.NewErrorEnvelope(envelopeCode, err.Error(), "transport:websocket", "", nil),
+		},
+	})
+}
+
+// keepalive pings the client and closes the session once it has been idle
+// for longer than the idle timeout
+func (c *WebSocketConn) keepalive(ctx context.Context) {
+	ticker := time.NewTicker(c.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.closeWith(websocket.CloseGoingAway, "server shutting down")
+			return
+		case <-c.readDone:
+			return
+		case <-ticker.C:
+			if c.idleTimeout > 0 && time.Since(c.State().LastActivity) > c.idleTimeout {
+				c.logger.WithField("session_id", c.state.ID).Info("Closing idle WebSocket session")
+				c.closeWith(websocket.CloseNormalClosure, "idle timeout")
+				return
+			}
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(webSocketWriteTimeout)); err != nil {
+				c.logger.WithError(err).WithField("session_id", c.state.ID).Debug("WebSocket ping failed")
+				return
+			}
+		}
+	}
+}
+
+// ReadMessage returns the next message from the client, or io.EOF once the
+// connection has closed
+func (c *WebSocketConn) ReadMessage() ([]byte, error) {
+	message, ok := <-c.incoming
+	if !ok {
+		return nil, io.EOF
+	}
+	return message, nil
+}
+
+// WriteMessage sends a message to the client
+func (c *WebSocketConn) WriteMessage(message []byte) error {
+	if c.IsClosed() {
+		return fmt.Errorf("transport is closed")
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout))
+	if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+		c.logger.WithError(err).WithField("session_id", c.state.ID).Error("Failed to write WebSocket message")
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
+
+// WriteJSONMessage writes a JSON object as a message
+func (c *WebSocketConn) WriteJSONMessage(obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return c.WriteMessage(data)
+}
+
+// Close ends the session with a normal close handshake
+func (c *WebSocketConn) Close() error {
+	c.closeWith(websocket.CloseNormalClosure, "")
+	return nil
+}
+
+// closeWith sends a close frame and waits briefly for the client to answer
+// before dropping the connection
+func (c *WebSocketConn) closeWith(code int, reason string) {
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		c.closed = true
+		c.mu.Unlock()
+		close(c.closing)
+
+		message := websocket.FormatCloseMessage(code, reason)
+		if err := c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(webSocketWriteTimeout)); err == nil {
+			select {
+			case <-c.readDone:
+			case <-time.After(webSocketCloseGrace):
+			}
+		}
+		c.conn.Close()
+		c.transport.removeConn(c.state.ID)
+		metrics.ActiveConnections.Dec("websocket")
+
+		c.logger.WithFields(logrus.Fields{
+			"session_id": c.state.ID,
+			"code":       code,
+			"reason":     reason,
+		}).Info("WebSocket session closed")
+	})
+}
+
+// IsClosed returns whether the session is closed
+func (c *WebSocketConn) IsClosed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.closed
+}
+
+// GetType returns the transport type
+func (c *WebSocketConn) GetType() string {
+	return "websocket"
+}
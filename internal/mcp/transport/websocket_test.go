@@ -0,0 +1,274 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// newTestWebSocket serves a WebSocket transport accepting the API keys
+// "read-key" (read_only) and "classify-key" (classify)
+func newTestWebSocket(t *testing.T) (*WebSocketTransport, *httptest.Server) {
+	t.Helper()
+	logger, _ := test.NewNullLogger()
+	authenticator, err := auth.NewAuthenticator(auth.Config{APIKeys: []auth.APIKey{
+		{Name: "dashboard", Role: auth.RoleReadOnly, Key: "read-key"},
+		{Name: "lims", Role: auth.RoleClassify, Key: "classify-key"},
+	}})
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+
+	ws := NewWebSocketTransport(logger, "localhost", 0)
+	ws.SetAuthorization(authenticator, func(tool string) auth.Role {
+		if tool == "classify_variant" {
+			return auth.RoleClassify
+		}
+		return auth.RoleReadOnly
+	})
+	server := httptest.NewServer(ws.Handler())
+	t.Cleanup(server.Close)
+	return ws, server
+}
+
+// dialTestWebSocket connects with an API key and accepts the session
+func dialTestWebSocket(t *testing.T, ws *WebSocketTransport, server *httptest.Server, key string) (*websocket.Conn, Transport) {
+	t.Helper()
+	header := http.Header{}
+	header.Set(auth.APIKeyHeader, key)
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/mcp/ws", header)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	conn, err := ws.Accept(ctx)
+	if err != nil {
+		t.Fatalf("Failed to accept session: %v", err)
+	}
+	if err := conn.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+	return client, conn
+}
+
+// TestWebSocketTransport_Session tests message exchange and session state
+func TestWebSocketTransport_Session(t *testing.T) {
+	ws, server := newTestWebSocket(t)
+	client, conn := dialTestWebSocket(t, ws, server, "classify-key")
+
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","clientInfo":{"name":"lims"},"capabilities":{"sampling":{}}}}`
+	if err := client.WriteMessage(websocket.TextMessage, []byte(initialize)); err != nil {
+		t.Fatalf("Failed to send: %v", err)
+	}
+	message, err := conn.ReadMessage()
+	if err != nil || string(message) != initialize {
+		t.Fatalf("Expected initialize request, got %q (%v)", message, err)
+	}
+
+	state := conn.(*WebSocketConn).State()
+	if state.Subject != "lims" || state.Role != auth.RoleClassify {
+		t.Errorf("Expected lims with classify role, got %s with %s", state.Subject, state.Role)
+	}
+	if state.ProtocolVersion != "2025-06-18" || state.ClientInfo["name"] != "lims" || state.Capabilities["sampling"] == nil {
+		t.Errorf("Initialize parameters not recorded: %+v", state)
+	}
+	if len(ws.Sessions()) != 1 {
+		t.Errorf("Expected 1 session, got %d", len(ws.Sessions()))
+	}
+
+	if err := conn.WriteMessage([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`)); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	_, reply, err := client.ReadMessage()
+	if err != nil || string(reply) != `{"jsonrpc":"2.0","id":1,"result":{}}` {
+		t.Errorf("Expected reply, got %q (%v)", reply, err)
+	}
+
+	// A second connection is a separate session
+	_, other := dialTestWebSocket(t, ws, server, "read-key")
+	if other.(*WebSocketConn).SessionID() == conn.(*WebSocketConn).SessionID() {
+		t.Error("Connections should have distinct session IDs")
+	}
+}
+
+// TestWebSocketTransport_Forbidden tests that tool calls needing a higher
+// role are answered on the connection and not delivered
+func TestWebSocketTransport_Forbidden(t *testing.T) {
+	ws, server := newTestWebSocket(t)
+	client, conn := dialTestWebSocket(t, ws, server, "read-key")
+
+	client.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"classify_variant"}}`))
+	client.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":8,"method":"tools/list"}`))
+
+	var response struct {
+		ID    int `json:"id"`
+		Error struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	_, reply, err := client.ReadMessage()
+	if err != nil || json.Unmarshal(reply, &response) != nil {
+		t.Fatalf("Expected error reply, got %q (%v)", reply, err)
+	}
+	if response.ID != 7 || response.Error.Code != protocol.MCPForbidden {
+		t.Errorf("Expected forbidden error for id 7, got %s", reply)
+	}
+
+	message, err := conn.ReadMessage()
+	if err != nil || !strings.Contains(string(message), "tools/list") {
+		t.Errorf("Expected only tools/list to be delivered, got %q (%v)", message, err)
+	}
+}
+
+// TestWebSocketTransport_Unauthenticated tests that the upgrade requires credentials
+func TestWebSocketTransport_Unauthenticated(t *testing.T) {
+	_, server := newTestWebSocket(t)
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/mcp/ws", nil)
+	if err == nil {
+		t.Fatal("Expected the upgrade to be refused")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %v", resp)
+	}
+}
+
+// TestWebSocketTransport_Route tests that REST routes require
+// authentication and the route's role
+func TestWebSocketTransport_Route(t *testing.T) {
+	ws, server := newTestWebSocket(t)
+	ws.AddRoute(Route{
+		Method:  http.MethodPost,
+		Path:    "/api/v1/echo",
+		Role:    auth.RoleClassify,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+	})
+
+	for key, want := range map[string]int{
+		"":             http.StatusUnauthorized,
+		"read-key":     http.StatusForbidden,
+		"classify-key": http.StatusNoContent,
+	} {
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/api/v1/echo", nil)
+		if key != "" {
+			req.Header.Set(auth.APIKeyHeader, key)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("Key %q: expected %d, got %d", key, want, resp.StatusCode)
+		}
+	}
+}
+
+// TestWebSocketTransport_PublicRoute tests that public routes serve requests
+// without credentials but still reject invalid ones
+func TestWebSocketTransport_PublicRoute(t *testing.T) {
+	ws, server := newTestWebSocket(t)
+	ws.AddRoute(Route{
+		Method: http.MethodGet,
+		Path:   "/api/v1/whoami",
+		Role:   auth.RoleClassify,
+		Public: true,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if principal := auth.PrincipalFrom(r.Context()); principal != nil {
+				w.Write([]byte(principal.Subject))
+			}
+		}),
+	})
+
+	for key, want := range map[string]string{
+		"":         "",
+		"read-key": "dashboard",
+	} {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v1/whoami", nil)
+		if key != "" {
+			req.Header.Set(auth.APIKeyHeader, key)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || string(body) != want {
+			t.Errorf("Key %q: expected 200 %q, got %d %q", key, want, resp.StatusCode, body)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v1/whoami", nil)
+	req.Header.Set(auth.APIKeyHeader, "wrong-key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Invalid key: expected 401, got %d", resp.StatusCode)
+	}
+}
+
+// TestWebSocketTransport_IdleTimeout tests that idle sessions are closed
+// with a close handshake
+func TestWebSocketTransport_IdleTimeout(t *testing.T) {
+	ws, server := newTestWebSocket(t)
+	ws.SetKeepalive(20*time.Millisecond, 60*time.Millisecond)
+	client, conn := dialTestWebSocket(t, ws, server, "read-key")
+
+	client.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err := client.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseNormalClosure || closeErr.Text != "idle timeout" {
+		t.Fatalf("Expected idle timeout close, got %v", err)
+	}
+	if _, err := conn.ReadMessage(); err == nil {
+		t.Error("Expected EOF after the session closed")
+	}
+	if !conn.IsClosed() {
+		t.Error("Session should be closed")
+	}
+}
+
+// TestWebSocketTransport_Close tests that closing the transport closes
+// every session with going away
+func TestWebSocketTransport_Close(t *testing.T) {
+	ws, server := newTestWebSocket(t)
+	client, _ := dialTestWebSocket(t, ws, server, "read-key")
+
+	closed := make(chan error, 1)
+	go func() {
+		client.SetReadDeadline(time.Now().Add(2 * time.Second))
+		_, _, err := client.ReadMessage()
+		closed <- err
+	}()
+
+	if err := ws.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !websocket.IsCloseError(<-closed, websocket.CloseGoingAway) {
+		t.Error("Expected going away close")
+	}
+	if ws.GetConnectedClients() != 0 {
+		t.Errorf("Expected no sessions, got %d", ws.GetConnectedClients())
+	}
+	if _, err := ws.Accept(context.Background()); err == nil {
+		t.Error("Accept should fail after Close")
+	}
+}
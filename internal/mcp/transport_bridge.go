@@ -5,16 +5,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 
+	"github.com/google/jsonschema-go/jsonschema"
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/acmg-amp-mcp-server/internal/auth"
 	"github.com/acmg-amp-mcp-server/internal/mcp/transport"
 	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
 	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/internal/tracing"
 )
 
+// tracer records a span per MCP tool call
+var tracer = otel.Tracer("github.com/acmg-amp-mcp-server/internal/mcp")
+
 // MCPTransportBridge bridges our custom transport interface with MCP SDK Transport
 type MCPTransportBridge struct {
 	customTransport transport.Transport
@@ -56,7 +69,7 @@ func (c *MCPConnectionBridge) Read(ctx context.Context) (jsonrpc.Message, error)
 	c.logger.Debug("Reading message through connection bridge")
 	
 	// Read raw bytes from our custom transport
-	data, err := c.customTransport.ReadMessage()
+	data, principal, err := c.readMessage()
 	if err != nil {
 		if err == io.EOF {
 			return nil, err // Pass through EOF as-is
@@ -75,16 +88,37 @@ func (c *MCPConnectionBridge) Read(ctx context.Context) (jsonrpc.Message, error)
 		c.logger.WithError(err).WithField("data", string(data)).Error("Failed to parse JSON-RPC message")
 		return nil, fmt.Errorf("failed to parse JSON-RPC message: %w", err)
 	}
+
+	// Requests carry their sender to the handlers
+	if req, ok := msg.(*jsonrpc.Request); ok && principal != nil {
+		req.Extra = &mcp.RequestExtra{
+			TokenInfo: &sdkauth.TokenInfo{Extra: map[string]any{principalExtraKey: principal}},
+		}
+	}
 	
 	return msg, nil
 }
 
+// readMessage reads a message and the principal that sent it, when the
+// transport authenticates its clients per message or per connection
+func (c *MCPConnectionBridge) readMessage() ([]byte, *auth.Principal, error) {
+	switch t := c.customTransport.(type) {
+	case transport.PrincipalReader:
+		return t.ReadMessageWithPrincipal()
+	case interface{ Principal() *auth.Principal }:
+		data, err := c.customTransport.ReadMessage()
+		return data, t.Principal(), err
+	}
+	data, err := c.customTransport.ReadMessage()
+	return data, nil, err
+}
+
 // Write implements the mcp.Connection interface  
 func (c *MCPConnectionBridge) Write(ctx context.Context, msg jsonrpc.Message) error {
 	c.logger.Debug("Writing message through connection bridge")
 	
-	// Encode JSON-RPC message to bytes
-	data, err := json.Marshal(msg)
+	// Encode JSON-RPC message to bytes in its wire format
+	data, err := jsonrpc.EncodeMessage(msg)
 	if err != nil {
 		c.logger.WithError(err).Error("Failed to marshal JSON-RPC message")
 		return fmt.Errorf("failed to marshal JSON-RPC message: %w", err)
@@ -102,46 +136,141 @@ func (c *MCPConnectionBridge) Close() error {
 
 // SessionID implements the mcp.Connection interface
 func (c *MCPConnectionBridge) SessionID() string {
-	// Generate or return a session ID - for now return a simple identifier
+	// Transports carrying one session per connection identify each session
+	if session, ok := c.customTransport.(interface{ SessionID() string }); ok {
+		return session.SessionID()
+	}
 	return "acmg-amp-session"
 }
 
-// parseJSONRPCMessage parses raw JSON into a jsonrpc.Message
-func parseJSONRPCMessage(raw json.RawMessage) (jsonrpc.Message, error) {
-	// First try to determine if it's a request, response, or notification
-	var base struct {
-		JSONRPC string          `json:"jsonrpc"`
-		Method  string          `json:"method,omitempty"`
-		ID      json.RawMessage `json:"id,omitempty"`
-		Result  json.RawMessage `json:"result,omitempty"`
-		Error   json.RawMessage `json:"error,omitempty"`
+// principalExtraKey names the sender's principal in the token info the
+// bridge attaches to each request
+const principalExtraKey = "principal"
+
+// requestPrincipal returns the principal the bridge attached to a request,
+// or nil for clients of unauthenticated transports such as stdio
+func requestPrincipal(extra *mcp.RequestExtra) *auth.Principal {
+	if extra == nil || extra.TokenInfo == nil {
+		return nil
 	}
-	
-	if err := json.Unmarshal(raw, &base); err != nil {
+	principal, _ := extra.TokenInfo.Extra[principalExtraKey].(*auth.Principal)
+	return principal
+}
+
+// serveSessions runs an MCP session for every connection the transport
+// accepts, until the context is cancelled or the transport closes
+func serveSessions(ctx context.Context, server *mcp.Server, sessions transport.SessionTransport, logger *logrus.Logger) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := sessions.Accept(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			session, err := server.Connect(ctx, NewMCPTransportBridge(conn, logger), nil)
+			if err != nil {
+				logger.WithError(err).Error("Failed to start MCP session")
+				conn.Close()
+				return
+			}
+			session.Wait()
+		}()
+	}
+}
+
+// cancelRequestMethod is the LSP-style cancellation notification some
+// clients send instead of MCP's notifications/cancelled
+const cancelRequestMethod = "$/cancelRequest"
+
+// translateCancelRequest rewrites a $/cancelRequest notification as
+// notifications/cancelled, which the SDK acts on by cancelling the context of
+// the in-flight request
+func translateCancelRequest(req *jsonrpc.Request) (jsonrpc.Message, error) {
+	var params struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil || len(params.ID) == 0 {
+		return nil, fmt.Errorf("invalid %s params: missing request id", cancelRequestMethod)
+	}
+	cancelled, err := json.Marshal(map[string]json.RawMessage{"requestId": params.ID})
+	if err != nil {
+		return nil, err
+	}
+	return &jsonrpc.Request{Method: "notifications/cancelled", Params: cancelled}, nil
+}
+
+// parseJSONRPCMessage parses raw JSON into a jsonrpc.Message. The SDK's
+// decoder is used because request IDs only unmarshal through it.
+func parseJSONRPCMessage(raw json.RawMessage) (jsonrpc.Message, error) {
+	msg, err := jsonrpc.DecodeMessage(raw)
+	if err != nil {
 		return nil, fmt.Errorf("invalid JSON-RPC message: %w", err)
 	}
-	
-	// If it has a method, it's a request or notification
-	if base.Method != "" {
-		var req jsonrpc.Request
-		if err := json.Unmarshal(raw, &req); err != nil {
-			return nil, fmt.Errorf("invalid JSON-RPC request: %w", err)
-		}
-		return &req, nil
+	if req, ok := msg.(*jsonrpc.Request); ok && req.Method == cancelRequestMethod {
+		return translateCancelRequest(req)
 	}
-	
-	// Otherwise, it's a response
-	var resp jsonrpc.Response
-	if err := json.Unmarshal(raw, &resp); err != nil {
-		return nil, fmt.Errorf("invalid JSON-RPC response: %w", err)
+	return msg, nil
+}
+
+// toolInputSchema converts a tool's declared input schema for the MCP SDK,
+// which requires one of type object; tools declaring none accept any object
+func toolInputSchema(info protocol.ToolInfo) (*jsonschema.Schema, error) {
+	schema := &jsonschema.Schema{Type: "object"}
+	if len(info.InputSchema) == 0 {
+		return schema, nil
+	}
+	raw, err := json.Marshal(info.InputSchema)
+	if err == nil {
+		err = json.Unmarshal(raw, schema)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid input schema for tool %s: %w", info.Name, err)
+	}
+	if schema.Type == "" {
+		schema.Type = "object"
 	}
-	return &resp, nil
+	if schema.Type != "object" {
+		return nil, fmt.Errorf("input schema for tool %s has type %q, not object", info.Name, schema.Type)
+	}
+	return schema, nil
 }
 
 // NewMCPToolHandler creates a new MCP tool handler function
 func NewMCPToolHandler(toolRegistry *tools.ToolRegistry, toolName string, logger *logrus.Logger) mcp.ToolHandler {
 	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		logger.WithField("tool", toolName).Debug("Handling MCP tool call")
+		ctx, correlationID := protocol.EnsureCorrelationID(ctx)
+		logger.WithFields(logrus.Fields{
+			"tool":           toolName,
+			"correlation_id": correlationID,
+		}).Debug("Handling MCP tool call")
+
+		// The registry checks the caller's role, and tools acting on behalf
+		// of a user identify them, from the principal in the context
+		if principal := requestPrincipal(req.Extra); principal != nil {
+			ctx = auth.WithPrincipal(ctx, principal)
+		}
+
+		// Trace the call, continuing the client's trace when it sent a
+		// traceparent in _meta
+		if traceparent, ok := req.Params.Meta["traceparent"].(string); ok {
+			ctx = propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": traceparent})
+		}
+		ctx, span := tracer.Start(ctx, "tools/call "+toolName, trace.WithAttributes(
+			attribute.String("mcp.tool", toolName), attribute.String("correlation_id", correlationID)))
+		defer span.End()
+
+		// Forward progress from long-running tools when the client asked for it
+		if token := req.Params.GetProgressToken(); token != nil && req.Session != nil {
+			ctx = tools.WithProgress(ctx, newProgressNotifier(ctx, req.Session, token, logger))
+		}
 		
 		// Convert MCP call to our internal protocol
 		internalReq := &protocol.JSONRPC2Request{
@@ -151,20 +280,32 @@ func NewMCPToolHandler(toolRegistry *tools.ToolRegistry, toolName string, logger
 		
 		// Execute through our tool registry
 		response := toolRegistry.ExecuteTool(ctx, internalReq)
+
+		// The client no longer waits for a cancelled call; report the
+		// cancellation rather than a result
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		
 		// Convert internal response to MCP CallToolResult
 		var result *mcp.CallToolResult
 		
 		if response.Error != nil {
-			// Return error as tool result with isError flag
+			// Return error as tool result with isError flag; the envelope
+			// carries the correlation ID to quote in support requests
+			envelope := response.Error.Envelope("tool:"+toolName, correlationID)
 			result = &mcp.CallToolResult{
 				Content: []mcp.Content{
 					&mcp.TextContent{
-						Text: fmt.Sprintf("Tool execution failed: %s", response.Error.Message),
+						Text: fmt.Sprintf("Tool execution failed: %s (code %s, correlation ID %s)",
+							envelope.Message, envelope.Code, envelope.CorrelationID),
 					},
 				},
-				IsError: true,
+				StructuredContent: map[string]interface{}{"error": envelope},
+				IsError:           true,
 			}
+			span.RecordError(fmt.Errorf("%s: %s", envelope.Code, envelope.Message))
+			span.SetStatus(codes.Error, envelope.Message)
 		} else {
 			// Convert successful result
 			result = &mcp.CallToolResult{
@@ -176,7 +317,24 @@ func NewMCPToolHandler(toolRegistry *tools.ToolRegistry, toolName string, logger
 				StructuredContent: response.Result,
 			}
 		}
+
+		// Return the trace ID so slow calls can be looked up in the tracing backend
+		result.Meta = tracing.Metadata(span)
 		
 		return result, nil
 	}
-}
\ No newline at end of file
+}
+// newProgressNotifier sends notifications/progress for a tool call's progress token
+func newProgressNotifier(ctx context.Context, session *mcp.ServerSession, token any, logger *logrus.Logger) tools.ProgressFunc {
+	return func(progress, total float64, message string) {
+		err := session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Total:         total,
+			Message:       message,
+		})
+		if err != nil {
+			logger.WithError(err).Debug("Failed to send progress notification")
+		}
+	}
+}
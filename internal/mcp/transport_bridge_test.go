@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+)
+
+// principalMessage is a message posted to the stub transport by a principal
+type principalMessage struct {
+	data      string
+	principal *auth.Principal
+}
+
+// stubPrincipalTransport authenticates each message, like the HTTP transport
+type stubPrincipalTransport struct {
+	incoming  chan principalMessage
+	outgoing  chan []byte
+	closeOnce sync.Once
+}
+
+func newStubPrincipalTransport() *stubPrincipalTransport {
+	return &stubPrincipalTransport{
+		incoming: make(chan principalMessage, 8),
+		outgoing: make(chan []byte, 8),
+	}
+}
+
+func (s *stubPrincipalTransport) Start(ctx context.Context) error { return nil }
+
+func (s *stubPrincipalTransport) ReadMessage() ([]byte, error) {
+	data, _, err := s.ReadMessageWithPrincipal()
+	return data, err
+}
+
+func (s *stubPrincipalTransport) ReadMessageWithPrincipal() ([]byte, *auth.Principal, error) {
+	msg, ok := <-s.incoming
+	if !ok {
+		return nil, nil, io.EOF
+	}
+	return []byte(msg.data), msg.principal, nil
+}
+
+func (s *stubPrincipalTransport) WriteMessage(message []byte) error {
+	s.outgoing <- message
+	return nil
+}
+
+func (s *stubPrincipalTransport) WriteJSONMessage(obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return s.WriteMessage(data)
+}
+
+func (s *stubPrincipalTransport) Close() error {
+	s.closeOnce.Do(func() { close(s.incoming) })
+	return nil
+}
+
+func (s *stubPrincipalTransport) IsClosed() bool { return false }
+
+func (s *stubPrincipalTransport) GetType() string { return "stub" }
+
+// whoAmITool reports the principal it was called by
+type whoAmITool struct{}
+
+func (whoAmITool) HandleTool(ctx context.Context, req *protocol.JSONRPC2Request) *protocol.JSONRPC2Response {
+	subject := ""
+	if principal := auth.PrincipalFrom(ctx); principal != nil {
+		subject = principal.Subject
+	}
+	return &protocol.JSONRPC2Response{JSONRPC: "2.0", ID: req.ID, Result: map[string]interface{}{"subject": subject}}
+}
+
+func (whoAmITool) GetToolInfo() protocol.ToolInfo {
+	return protocol.ToolInfo{Name: "whoami", Description: "Reports the caller"}
+}
+
+func (whoAmITool) ValidateParams(params interface{}) error { return nil }
+
+func TestTransportBridge_PropagatesPrincipal(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	router := protocol.NewMessageRouter(logger)
+	router.RegisterToolHandler("whoami", whoAmITool{})
+	registry := tools.NewToolRegistry(logger, router, nil)
+
+	inputSchema, err := toolInputSchema(whoAmITool{}.GetToolInfo())
+	require.NoError(t, err)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.1"}, nil)
+	server.AddTool(&mcp.Tool{Name: "whoami", InputSchema: inputSchema}, NewMCPToolHandler(registry, "whoami", logger))
+
+	stub := newStubPrincipalTransport()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	session, err := server.Connect(ctx, NewMCPTransportBridge(stub, logger), nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	admin := &auth.Principal{Subject: "lab-admin", Role: auth.RoleAdmin, Method: auth.MethodAPIKey}
+	reader := &auth.Principal{Subject: "dashboard", Role: auth.RoleReadOnly, Method: auth.MethodAPIKey}
+
+	// call posts a request and waits for the response with its ID
+	call := func(id int, principal *auth.Principal, method string, params string) map[string]interface{} {
+		t.Helper()
+		stub.incoming <- principalMessage{
+			data:      fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":%q,"params":%s}`, id, method, params),
+			principal: principal,
+		}
+		select {
+		case data := <-stub.outgoing:
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(data, &response))
+			return response
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no response to %s", method)
+			return nil
+		}
+	}
+
+	call(1, admin, "initialize", `{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"v0.0.1"}}`)
+	stub.incoming <- principalMessage{data: `{"jsonrpc":"2.0","method":"notifications/initialized","params":{}}`, principal: admin}
+
+	// Act
+	allowed := call(2, admin, "tools/call", `{"name":"whoami","arguments":{}}`)
+	forbidden := call(3, reader, "tools/call", `{"name":"whoami","arguments":{}}`)
+
+	// Assert
+	assert.Equal(t, float64(2), allowed["id"])
+	result := allowed["result"].(map[string]interface{})
+	assert.Nil(t, result["isError"])
+	assert.Equal(t, "lab-admin", result["structuredContent"].(map[string]interface{})["subject"])
+
+	result = forbidden["result"].(map[string]interface{})
+	assert.Equal(t, true, result["isError"])
+	envelope := result["structuredContent"].(map[string]interface{})["error"].(map[string]interface{})
+	assert.Contains(t, envelope["message"], "requires the admin role")
+}
@@ -0,0 +1,31 @@
+// Package mcp provides the MCP server implementation.
+// This file contains webhook tool registration logic.
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/tools"
+	"github.com/acmg-amp-mcp-server/internal/webhook"
+)
+
+// registerWebhookTools registers tools for managing webhooks.
+func registerWebhookTools(registry *tools.ToolRegistry, logger *logrus.Logger, store webhook.Store, dispatcher *webhook.Dispatcher) error {
+	webhookTools := []tools.Tool{
+		tools.NewRegisterWebhookTool(logger, store),
+		tools.NewListWebhooksTool(logger, store),
+		tools.NewRemoveWebhookTool(logger, store),
+		tools.NewTestWebhookTool(logger, store, dispatcher),
+	}
+
+	for _, tool := range webhookTools {
+		if err := registry.RegisterTool(tool); err != nil {
+			return fmt.Errorf("failed to register %s: %w", tool.GetToolInfo().Name, err)
+		}
+		logger.WithField("tool_name", tool.GetToolInfo().Name).Debug("Registered webhook tool")
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Default is the registry the server's instruments are registered with
+var Default = NewRegistry()
+
+// Server instruments. Ratios such as the external API error rate or the
+// cache hit ratio are computed from the counters at query time, e.g.
+// rate(acmg_external_api_errors_total[5m]) / rate(acmg_external_api_requests_total[5m]).
+var (
+	ClassificationDuration = Default.NewHistogramVec("acmg_classification_duration_seconds",
+		"Time to classify a variant, including evidence gathering", DefaultBuckets, "context")
+	Classifications = Default.NewCounterVec("acmg_classifications_total",
+		"Completed classifications by outcome", "classification")
+	RuleEvaluations = Default.NewCounterVec("acmg_rule_evaluations_total",
+		"ACMG/AMP criteria evaluated in completed classifications, by whether they were met", "rule", "applied")
+	ExternalRequests = Default.NewCounterVec("acmg_external_api_requests_total",
+		"Requests to external evidence sources", "source")
+	ExternalErrors = Default.NewCounterVec("acmg_external_api_errors_total",
+		"Failed requests to external evidence sources, including those refused by an open circuit breaker", "source")
+	EvidenceSourceTimeouts = Default.NewCounterVec("acmg_evidence_source_timeouts_total",
+		"Evidence sources that missed their deadline, leaving a classification provisional", "source")
+	CacheRequests = Default.NewCounterVec("acmg_cache_requests_total",
+		"Cache lookups by result: hit, stale (served while refreshed) or miss", "cache", "result")
+	CacheEvictions = Default.NewCounterVec("acmg_cache_evictions_total",
+		"Entries evicted from an in-memory cache to stay within its entry and byte limits", "cache")
+	ActiveConnections = Default.NewGaugeVec("acmg_mcp_active_connections",
+		"Open MCP client connections", "transport")
+)
+
+// Cache lookup results
+const (
+	CacheHit   = "hit"
+	CacheStale = "stale"
+	CacheMiss  = "miss"
+)
+
+// ObserveClassification records a completed classification
+func ObserveClassification(classificationContext, classification string, elapsed time.Duration) {
+	ClassificationDuration.Observe(elapsed.Seconds(), classificationContext)
+	Classifications.Inc(classification)
+}
+
+// ObserveRule records one criterion of a completed classification
+func ObserveRule(rule string, applied bool) {
+	RuleEvaluations.Inc(rule, strconv.FormatBool(applied))
+}
+
+// ObserveExternalRequest records a request to an external source
+func ObserveExternalRequest(source string, err error) {
+	ExternalRequests.Inc(source)
+	if err != nil {
+		ExternalErrors.Inc(source)
+	}
+}
+
+// ObserveEvidenceSourceTimeout records an evidence source that missed its
+// deadline
+func ObserveEvidenceSourceTimeout(source string) {
+	EvidenceSourceTimeouts.Inc(source)
+}
+
+// ObserveCache records a cache lookup
+func ObserveCache(cache, result string) {
+	CacheRequests.Inc(cache, result)
+}
+
+// ObserveCacheEviction records an entry evicted from an in-memory cache
+func ObserveCacheEviction(cache string) {
+	CacheEvictions.Inc(cache)
+}
+
+// Handler serves the default registry's metrics
+func Handler() http.Handler {
+	return Default.Handler()
+}
+
+// Serve serves /metrics on addr until ctx is cancelled
+func Serve(ctx context.Context, addr string, logger *logrus.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	logger.WithField("address", addr).Info("Serving Prometheus metrics")
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.WithError(err).Error("Metrics server failed")
+		}
+	}()
+}
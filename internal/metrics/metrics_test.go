@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	r := NewRegistry()
+	requests := r.NewCounterVec("test_requests_total", "Requests by source", "source")
+	connections := r.NewGaugeVec("test_connections", "Open connections", "transport")
+
+	requests.Inc("pubmed")
+	requests.Add(2, "clinvar")
+	requests.Inc("clinvar")
+	connections.Inc("http")
+	connections.Inc("http")
+	connections.Dec("http")
+
+	var out strings.Builder
+	require.NoError(t, r.WriteText(&out))
+	assert.Equal(t, `# HELP test_requests_total Requests by source
+# TYPE test_requests_total counter
+test_requests_total{source="clinvar"} 3
+test_requests_total{source="pubmed"} 1
+# HELP test_connections Open connections
+# TYPE test_connections gauge
+test_connections{transport="http"} 1
+`, out.String())
+
+	assert.Equal(t, float64(3), requests.Value("clinvar"))
+	assert.Equal(t, float64(0), requests.Value("gnomad"))
+	assert.Panics(t, func() { requests.Add(-1, "clinvar") })
+	assert.Panics(t, func() { requests.Inc() })
+	assert.Panics(t, func() { r.NewCounterVec("test_requests_total", "Duplicate") })
+}
+
+func TestHistogramVec_Buckets(t *testing.T) {
+	r := NewRegistry()
+	duration := r.NewHistogramVec("test_duration_seconds", "Durations", []float64{0.1, 1}, "context")
+
+	duration.Observe(0.05, "germline")
+	duration.Observe(0.1, "germline")
+	duration.Observe(0.5, "germline")
+	duration.Observe(3, "germline")
+
+	var out strings.Builder
+	require.NoError(t, r.WriteText(&out))
+	assert.Equal(t, `# HELP test_duration_seconds Durations
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{context="germline",le="0.1"} 2
+test_duration_seconds_bucket{context="germline",le="1"} 3
+test_duration_seconds_bucket{context="germline",le="+Inf"} 4
+test_duration_seconds_sum{context="germline"} 3.65
+test_duration_seconds_count{context="germline"} 4
+`, out.String())
+	assert.Equal(t, uint64(4), duration.Count("germline"))
+}
+
+func TestEscaping(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("test_total", "Help with \\ and\nnewline", "label")
+	c.Inc("quote \" backslash \\ newline \n")
+
+	var out strings.Builder
+	require.NoError(t, r.WriteText(&out))
+	assert.Contains(t, out.String(), `# HELP test_total Help with \\ and\nnewline`)
+	assert.Contains(t, out.String(), `test_total{label="quote \" backslash \\ newline \n"} 1`)
+}
+
+func TestHandler(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("test_total", "Test").Inc()
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, contentType, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "test_total 1\n")
+}
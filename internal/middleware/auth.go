@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// PrincipalKey is the gin context key holding the authenticated *auth.Principal
+const PrincipalKey = "principal"
+
+// Authenticate rejects requests without a valid API key or bearer token with
+// 401 Unauthorized. The principal of an accepted request is stored in the gin
+// context and in the request context, where auth.PrincipalFrom finds it.
+func Authenticate(authenticator *auth.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := authenticator.Authenticate(c.Request)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="acmg-amp-mcp"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, protocol.NewErrorEnvelope(protocol.ErrorCodeUnauthorized, "Valid API key or bearer token required",
+				"rest:"+c.Request.Method+" "+c.FullPath(), c.GetString("correlation_id"), err.Error()))
+			return
+		}
+		c.Set(PrincipalKey, principal)
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// AuthenticateOptional authenticates requests to endpoints that are also
+// served without credentials. Requests without an API key or bearer token
+// pass without a principal; invalid credentials are rejected as by
+// Authenticate.
+func AuthenticateOptional(authenticator *auth.Authenticator) gin.HandlerFunc {
+	authenticate := Authenticate(authenticator)
+	return func(c *gin.Context) {
+		if c.GetHeader(auth.APIKeyHeader) == "" && c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		authenticate(c)
+	}
+}
+
+// RequireRole rejects requests whose principal lacks the role with 403
+// Forbidden. It must follow Authenticate.
+func RequireRole(role auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := auth.PrincipalFrom(c.Request.Context())
+		if err := auth.Authorize(principal, role); err != nil {
+			Forbid(c, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+// Forbid aborts a request with 403 Forbidden and the authorization failure
+func Forbid(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(http.StatusForbidden, protocol.NewErrorEnvelope(protocol.ErrorCodeForbidden, "Insufficient role for this operation",
+		"rest:"+c.Request.Method+" "+c.FullPath(), c.GetString("correlation_id"), err.Error()))
+}
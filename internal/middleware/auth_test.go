@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+func TestAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authenticator, err := auth.NewAuthenticator(auth.Config{APIKeys: []auth.APIKey{
+		{Name: "lims", Role: auth.RoleClassify, Key: "classify-key"},
+		{Name: "dashboard", Role: auth.RoleReadOnly, Key: "read-key"},
+	}})
+	require.NoError(t, err)
+
+	limiter := NewRateLimiter(RateLimitConfig{DailyQuota: 10})
+	router := gin.New()
+	router.POST("/classify", Authenticate(authenticator), RequireRole(auth.RoleClassify), func(c *gin.Context) {
+		c.String(http.StatusOK, limiter.ClientID(c))
+	})
+
+	do := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/classify", nil)
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("classify-key")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user:lims", rec.Body.String(), "authenticated clients are rate limited by subject")
+
+	rec = do("")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+	var envelope protocol.ErrorEnvelope
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	assert.Equal(t, protocol.ErrorCodeUnauthorized, envelope.Code)
+
+	rec = do("read-key")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	assert.Equal(t, protocol.ErrorCodeForbidden, envelope.Code)
+	assert.False(t, envelope.Retryable)
+}
@@ -0,0 +1,263 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+
+	"github.com/acmg-amp-mcp-server/internal/auth"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// APIKeyHeader carries the API key that identifies a client for rate limiting
+const APIKeyHeader = auth.APIKeyHeader
+
+// clientIdleTimeout is how long a client is kept after its last request
+const clientIdleTimeout = 24 * time.Hour
+
+// RateLimitConfig configures per-client request throttling
+type RateLimitConfig struct {
+	RequestsPerSecond float64  // Sustained request rate per client; zero disables the token bucket
+	Burst             int      // Requests a client may make at once
+	DailyQuota        int      // Requests per client per UTC day; zero means unlimited
+	APIKeys           []string // Keys that identify clients; requests without a listed key are limited per IP
+}
+
+// Enabled reports whether the configuration limits anything
+func (c RateLimitConfig) Enabled() bool {
+	return c.RequestsPerSecond > 0 || c.DailyQuota > 0
+}
+
+// QuotaStatus is a client's usage of its daily quota
+type QuotaStatus struct {
+	Client    string    `json:"client"`
+	Day       string    `json:"day"` // UTC date the usage counts towards
+	Used      int       `json:"used"`
+	Limit     int       `json:"limit"`     // Zero means unlimited
+	Remaining int       `json:"remaining"` // -1 when unlimited
+	Throttled int64     `json:"throttled"` // Requests rejected today by the rate limit or quota
+	ResetsAt  time.Time `json:"resets_at"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// RateLimiter throttles clients with a token bucket and counts their
+// requests against a daily quota. Clients are identified by API key or,
+// failing that, by IP address.
+type RateLimiter struct {
+	config  RateLimitConfig
+	apiKeys map[string]bool
+	now     func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*clientUsage
+	lastPrune time.Time
+}
+
+// clientUsage tracks one client's token bucket and quota usage
+type clientUsage struct {
+	bucket    *rate.Limiter
+	day       time.Time
+	used      int
+	throttled int64
+	lastSeen  time.Time
+}
+
+// NewRateLimiter creates a rate limiter
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	if config.Burst <= 0 {
+		config.Burst = int(math.Max(1, math.Ceil(config.RequestsPerSecond)))
+	}
+	apiKeys := make(map[string]bool, len(config.APIKeys))
+	for _, key := range config.APIKeys {
+		if key = strings.TrimSpace(key); key != "" {
+			apiKeys[key] = true
+		}
+	}
+	return &RateLimiter{
+		config:  config,
+		apiKeys: apiKeys,
+		now:     time.Now,
+		clients: make(map[string]*clientUsage),
+	}
+}
+
+// Config returns the limiter's configuration
+func (l *RateLimiter) Config() RateLimitConfig {
+	return l.config
+}
+
+// Allow records a request from client. If the request is rejected, it
+// returns how long the client should wait before retrying.
+func (l *RateLimiter) Allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+	usage := l.usage(client, now)
+	usage.lastSeen = now
+
+	if l.config.DailyQuota > 0 && usage.used >= l.config.DailyQuota {
+		usage.throttled++
+		return false, usage.day.AddDate(0, 0, 1).Sub(now)
+	}
+	if usage.bucket != nil {
+		reservation := usage.bucket.ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			usage.throttled++
+			return false, delay
+		}
+	}
+	usage.used++
+	return true, 0
+}
+
+// usage returns a client's usage, starting a new day's count when the UTC
+// day has changed
+func (l *RateLimiter) usage(client string, now time.Time) *clientUsage {
+	day := utcDay(now)
+	usage, ok := l.clients[client]
+	if !ok {
+		usage = &clientUsage{day: day}
+		if l.config.RequestsPerSecond > 0 {
+			usage.bucket = rate.NewLimiter(rate.Limit(l.config.RequestsPerSecond), l.config.Burst)
+		}
+		l.clients[client] = usage
+	}
+	if !usage.day.Equal(day) {
+		usage.day = day
+		usage.used = 0
+		usage.throttled = 0
+	}
+	return usage
+}
+
+// prune forgets clients idle for a day, at most once an hour
+func (l *RateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Hour {
+		return
+	}
+	l.lastPrune = now
+	for client, usage := range l.clients {
+		if now.Sub(usage.lastSeen) > clientIdleTimeout {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// Quotas returns the quota usage of every known client, ordered by client
+func (l *RateLimiter) Quotas() []QuotaStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	statuses := make([]QuotaStatus, 0, len(l.clients))
+	for client := range l.clients {
+		statuses = append(statuses, l.status(client, now))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Client < statuses[j].Client })
+	return statuses
+}
+
+// Quota returns a client's quota usage, if the client is known
+func (l *RateLimiter) Quota(client string) (QuotaStatus, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.clients[client]; !ok {
+		return QuotaStatus{}, false
+	}
+	return l.status(client, l.now()), true
+}
+
+// Reset clears a client's quota usage and refills its token bucket,
+// reporting whether the client was known
+func (l *RateLimiter) Reset(client string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.clients[client]; !ok {
+		return false
+	}
+	delete(l.clients, client)
+	return true
+}
+
+// status reports the usage of a known client
+func (l *RateLimiter) status(client string, now time.Time) QuotaStatus {
+	usage := l.usage(client, now)
+	status := QuotaStatus{
+		Client:    client,
+		Day:       usage.day.Format("2006-01-02"),
+		Used:      usage.used,
+		Limit:     l.config.DailyQuota,
+		Remaining: -1,
+		Throttled: usage.throttled,
+		ResetsAt:  usage.day.AddDate(0, 0, 1),
+		LastSeen:  usage.lastSeen,
+	}
+	if l.config.DailyQuota > 0 {
+		status.Remaining = max(0, l.config.DailyQuota-usage.used)
+	}
+	return status
+}
+
+// ClientID identifies the client of a request: "user:" and the subject of
+// an authenticated principal, "key:" and a digest of a configured API key,
+// or "ip:" and the client IP. Keys are digested so they do not appear in
+// quota listings or logs.
+func (l *RateLimiter) ClientID(c *gin.Context) string {
+	if principal := auth.PrincipalFrom(c.Request.Context()); principal != nil && principal.Method != auth.MethodAnonymous {
+		return "user:" + principal.Subject
+	}
+	if key := strings.TrimSpace(c.GetHeader(APIKeyHeader)); key != "" && l.apiKeys[key] {
+		digest := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(digest[:6])
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// RateLimit rejects requests over a client's rate limit or daily quota with
+// 429 Too Many Requests and a Retry-After header. With a daily quota, the
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers
+// report the client's usage.
+func RateLimit(limiter *RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client := limiter.ClientID(c)
+		allowed, retryAfter := limiter.Allow(client)
+
+		if quota := limiter.config.DailyQuota; quota > 0 {
+			if status, ok := limiter.Quota(client); ok {
+				c.Header("X-RateLimit-Limit", strconv.Itoa(quota))
+				c.Header("X-RateLimit-Remaining", strconv.Itoa(status.Remaining))
+				c.Header("X-RateLimit-Reset", strconv.FormatInt(status.ResetsAt.Unix(), 10))
+			}
+		}
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, protocol.NewErrorEnvelope(protocol.ErrorCodeRateLimited, "Rate limit exceeded",
+				"rest:"+c.Request.Method+" "+c.FullPath(), c.GetString("correlation_id"), map[string]interface{}{
+					"client":              client,
+					"retry_after_seconds": math.Ceil(retryAfter.Seconds()),
+				}))
+			return
+		}
+		c.Next()
+	}
+}
+
+// utcDay returns the start of the UTC day containing t
+func utcDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(RateLimitConfig{RequestsPerSecond: 1, Burst: 2})
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		allowed, _ := limiter.Allow("ip:192.0.2.1")
+		require.True(t, allowed, "request %d is within the burst", i+1)
+	}
+	allowed, retryAfter := limiter.Allow("ip:192.0.2.1")
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+
+	allowed, _ = limiter.Allow("ip:192.0.2.2")
+	assert.True(t, allowed, "clients are limited separately")
+
+	now = now.Add(time.Second)
+	allowed, _ = limiter.Allow("ip:192.0.2.1")
+	assert.True(t, allowed, "the bucket refills")
+}
+
+func TestRateLimiter_DailyQuota(t *testing.T) {
+	now := time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(RateLimitConfig{DailyQuota: 2})
+	limiter.now = func() time.Time { return now }
+
+	limiter.Allow("key:abc")
+	limiter.Allow("key:abc")
+	allowed, retryAfter := limiter.Allow("key:abc")
+	assert.False(t, allowed)
+	assert.Equal(t, 6*time.Hour, retryAfter, "the quota resets at midnight UTC")
+
+	status, ok := limiter.Quota("key:abc")
+	require.True(t, ok)
+	assert.Equal(t, "2024-03-01", status.Day)
+	assert.Equal(t, 0, status.Remaining)
+	assert.Equal(t, int64(1), status.Throttled)
+
+	now = now.Add(6 * time.Hour)
+	allowed, _ = limiter.Allow("key:abc")
+	assert.True(t, allowed)
+	status, _ = limiter.Quota("key:abc")
+	assert.Equal(t, 1, status.Used)
+	assert.Equal(t, int64(0), status.Throttled)
+}
+
+func TestRateLimit_Middleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := NewRateLimiter(RateLimitConfig{RequestsPerSecond: 0.001, Burst: 1, DailyQuota: 10, APIKeys: []string{"lab-key"}})
+	router := gin.New()
+	router.Use(CorrelationID())
+	router.POST("/mcp/message", RateLimit(limiter), func(c *gin.Context) { c.Status(http.StatusAccepted) })
+
+	send := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/mcp/message", nil)
+		req.RemoteAddr = "192.0.2.1:50000"
+		if apiKey != "" {
+			req.Header.Set(APIKeyHeader, apiKey)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := send("lab-key")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "9", rec.Header().Get("X-RateLimit-Remaining"))
+
+	rec = send("lab-key")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+	var envelope protocol.ErrorEnvelope
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	assert.Equal(t, protocol.ErrorCodeRateLimited, envelope.Code)
+
+	// Unlisted keys do not get a bucket of their own
+	assert.Equal(t, http.StatusAccepted, send("made-up-key").Code)
+	assert.Equal(t, http.StatusTooManyRequests, send("").Code)
+
+	quotas := limiter.Quotas()
+	require.Len(t, quotas, 2)
+	assert.Contains(t, quotas[0].Client, "ip:192.0.2.1")
+	assert.Regexp(t, `^key:[0-9a-f]{12}$`, quotas[1].Client)
+}
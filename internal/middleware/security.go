@@ -7,6 +7,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
 )
 
 // SecurityHeaders adds security headers to all responses
@@ -43,14 +45,15 @@ func SecurityHeaders() gin.HandlerFunc {
 func CorrelationID() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Check if correlation ID already exists in headers
-		correlationID := c.GetHeader("X-Correlation-ID")
+		correlationID := c.GetHeader(protocol.CorrelationHeader)
 		if correlationID == "" {
 			correlationID = uuid.New().String()
 		}
 
 		// Set correlation ID in context and response header
 		c.Set("correlation_id", correlationID)
-		c.Header("X-Correlation-ID", correlationID)
+		c.Request = c.Request.WithContext(protocol.WithCorrelationID(c.Request.Context(), correlationID))
+		c.Header(protocol.CorrelationHeader, correlationID)
 
 		c.Next()
 	}
@@ -79,11 +82,8 @@ func RequestTimeout(timeout time.Duration) gin.HandlerFunc {
 			// Request completed normally
 		case <-ctx.Done():
 			// Timeout occurred
-			c.AbortWithStatusJSON(408, gin.H{
-				"error":          "Request timeout",
-				"correlation_id": c.GetString("correlation_id"),
-				"timestamp":      time.Now().UTC().Format(time.RFC3339),
-			})
+			c.AbortWithStatusJSON(408, protocol.NewErrorEnvelope(protocol.ErrorCodeTimeout, "Request timeout",
+				"rest:"+c.Request.Method+" "+c.FullPath(), c.GetString("correlation_id"), nil))
 		}
 	}
 }
@@ -0,0 +1,189 @@
+package mocksources
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidDataset is returned when a mock dataset fails validation.
+var ErrInvalidDataset = errors.New("invalid mock source dataset")
+
+//go:embed dataset.yaml
+var defaultDataset []byte
+
+// Variant is a variant served by the mock sources, with the identifiers the
+// clients search by and the record each source returns for it. A source
+// without a record answers as the real service does for an unknown variant.
+type Variant struct {
+	Gene        string `json:"gene" yaml:"gene"`
+	Chromosome  string `json:"chromosome" yaml:"chromosome"` // With or without the chr prefix
+	Position    int64  `json:"position" yaml:"position"`     // 1-based GRCh38 position
+	Reference   string `json:"reference" yaml:"reference"`
+	Alternative string `json:"alternative" yaml:"alternative"`
+	Transcript  string `json:"transcript,omitempty" yaml:"transcript,omitempty"`
+	HGVSGenomic string `json:"hgvs_genomic,omitempty" yaml:"hgvs_genomic,omitempty"`
+	HGVSCoding  string `json:"hgvs_coding,omitempty" yaml:"hgvs_coding,omitempty"`
+	HGVSProtein string `json:"hgvs_protein,omitempty" yaml:"hgvs_protein,omitempty"`
+
+	ClinVar *ClinVarRecord `json:"clinvar,omitempty" yaml:"clinvar,omitempty"`
+	GnomAD  *GnomADRecord  `json:"gnomad,omitempty" yaml:"gnomad,omitempty"`
+	COSMIC  []COSMICRecord `json:"cosmic,omitempty" yaml:"cosmic,omitempty"`
+}
+
+// ClinVarRecord is the E-utilities summary of a ClinVar variation
+type ClinVarRecord struct {
+	VariationID          string              `json:"variation_id" yaml:"variation_id"`
+	Title                string              `json:"title,omitempty" yaml:"title,omitempty"`
+	VariationType        string              `json:"variation_type,omitempty" yaml:"variation_type,omitempty"`
+	ClinicalSignificance string              `json:"clinical_significance" yaml:"clinical_significance"`
+	ReviewStatus         string              `json:"review_status,omitempty" yaml:"review_status,omitempty"`
+	LastEvaluated        string              `json:"last_evaluated,omitempty" yaml:"last_evaluated,omitempty"` // YYYY/MM/DD
+	Conditions           []string            `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	Submissions          []ClinVarSubmission `json:"submissions,omitempty" yaml:"submissions,omitempty"`
+}
+
+// ClinVarSubmission is a submitted interpretation in a ClinVar summary
+type ClinVarSubmission struct {
+	Submitter            string `json:"submitter" yaml:"submitter"`
+	ClinicalSignificance string `json:"clinical_significance" yaml:"clinical_significance"`
+	ReviewStatus         string `json:"review_status,omitempty" yaml:"review_status,omitempty"`
+	DateCreated          string `json:"date_created,omitempty" yaml:"date_created,omitempty"` // YYYY-MM-DD
+}
+
+// GnomADRecord holds joint exome and genome allele counts. gnomAD v4 queries
+// return them as is; v3 queries return them as genome frequencies.
+type GnomADRecord struct {
+	AC              int                `json:"ac" yaml:"ac"`
+	AN              int                `json:"an" yaml:"an"`
+	HomozygoteCount int                `json:"homozygote_count,omitempty" yaml:"homozygote_count,omitempty"`
+	Filters         []string           `json:"filters,omitempty" yaml:"filters,omitempty"`
+	Populations     []GnomADPopulation `json:"populations,omitempty" yaml:"populations,omitempty"`
+	FAF95           *FilteringAF       `json:"faf95,omitempty" yaml:"faf95,omitempty"`
+	FAF99           *FilteringAF       `json:"faf99,omitempty" yaml:"faf99,omitempty"`
+}
+
+// GnomADPopulation is a genetic ancestry group's allele counts
+type GnomADPopulation struct {
+	ID              string `json:"id" yaml:"id"`
+	AC              int    `json:"ac" yaml:"ac"`
+	AN              int    `json:"an" yaml:"an"`
+	HomozygoteCount int    `json:"homozygote_count,omitempty" yaml:"homozygote_count,omitempty"`
+}
+
+// FilteringAF is a popmax filtering allele frequency
+type FilteringAF struct {
+	Popmax           float64 `json:"popmax" yaml:"popmax"`
+	PopmaxPopulation string  `json:"popmax_population,omitempty" yaml:"popmax_population,omitempty"`
+}
+
+// COSMICRecord is one COSMIC observation of a variant, typically per tissue
+type COSMICRecord struct {
+	CosmicID         string `json:"cosmic_id" yaml:"cosmic_id"`
+	PrimaryTissue    string `json:"primary_tissue,omitempty" yaml:"primary_tissue,omitempty"`
+	PrimaryHistology string `json:"primary_histology,omitempty" yaml:"primary_histology,omitempty"`
+	SampleCount      int    `json:"sample_count,omitempty" yaml:"sample_count,omitempty"`
+	MutationCount    int    `json:"mutation_count,omitempty" yaml:"mutation_count,omitempty"`
+	FathmmScore      string `json:"fathmm_score,omitempty" yaml:"fathmm_score,omitempty"`
+	FathmmPrediction string `json:"fathmm_prediction,omitempty" yaml:"fathmm_prediction,omitempty"`
+	Tier             string `json:"tier,omitempty" yaml:"tier,omitempty"`
+}
+
+// Dataset is the set of variants served by the mock sources
+type Dataset struct {
+	Variants []Variant `json:"variants" yaml:"variants"`
+}
+
+// DefaultDataset returns the built-in dataset: a handful of well-known
+// variants covering pathogenic, common and somatic hotspot cases. The values
+// are illustrative and not a copy of the upstream records.
+func DefaultDataset() (*Dataset, error) {
+	var dataset Dataset
+	if err := yaml.Unmarshal(defaultDataset, &dataset); err != nil {
+		return nil, fmt.Errorf("failed to parse default dataset: %w", err)
+	}
+	if err := dataset.Validate(); err != nil {
+		return nil, err
+	}
+	return &dataset, nil
+}
+
+// LoadDataset reads a dataset from a JSON or YAML file with a "variants" list.
+func LoadDataset(path string) (*Dataset, error) {
+	unmarshal := yaml.Unmarshal
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		unmarshal = json.Unmarshal
+	case ".yaml", ".yml":
+	default:
+		return nil, fmt.Errorf("unsupported dataset format: %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+
+	var dataset Dataset
+	if err := unmarshal(data, &dataset); err != nil {
+		return nil, fmt.Errorf("failed to parse dataset %s: %w", filepath.Base(path), err)
+	}
+	if err := dataset.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return &dataset, nil
+}
+
+// Validate checks that every variant can be found by the clients: a gene and
+// genomic coordinates, and unique ClinVar variation IDs.
+func (d *Dataset) Validate() error {
+	variationIDs := make(map[string]bool)
+	for i, v := range d.Variants {
+		label := v.Label()
+		if label == "" {
+			label = "#" + strconv.Itoa(i+1)
+		}
+		if strings.TrimSpace(v.Gene) == "" {
+			return fmt.Errorf("%w: variant %s: gene is required", ErrInvalidDataset, label)
+		}
+		if v.Chromosome == "" || v.Position <= 0 || v.Reference == "" || v.Alternative == "" {
+			return fmt.Errorf("%w: variant %s: chromosome, position, reference and alternative are required", ErrInvalidDataset, label)
+		}
+		if v.ClinVar != nil {
+			if v.ClinVar.VariationID == "" {
+				return fmt.Errorf("%w: variant %s: clinvar variation_id is required", ErrInvalidDataset, label)
+			}
+			if variationIDs[v.ClinVar.VariationID] {
+				return fmt.Errorf("%w: duplicate clinvar variation_id %s", ErrInvalidDataset, v.ClinVar.VariationID)
+			}
+			variationIDs[v.ClinVar.VariationID] = true
+		}
+		if v.GnomAD != nil && (v.GnomAD.AN <= 0 || v.GnomAD.AC < 0 || v.GnomAD.AC > v.GnomAD.AN) {
+			return fmt.Errorf("%w: variant %s: gnomad ac must be between 0 and an, and an positive", ErrInvalidDataset, label)
+		}
+	}
+	return nil
+}
+
+// GnomADID returns the gnomAD variant ID, chrom-pos-ref-alt.
+func (v Variant) GnomADID() string {
+	return fmt.Sprintf("%s-%d-%s-%s", strings.TrimPrefix(v.Chromosome, "chr"), v.Position, v.Reference, v.Alternative)
+}
+
+// Label identifies the variant in messages, e.g. "CFTR NM_000492.4:c.1521_1523del".
+func (v Variant) Label() string {
+	switch {
+	case v.HGVSCoding != "":
+		return strings.TrimSpace(v.Gene + " " + v.HGVSCoding)
+	case v.Chromosome != "":
+		return strings.TrimSpace(v.Gene + " " + v.GnomADID())
+	}
+	return v.Gene
+}
@@ -0,0 +1,74 @@
+package mocksources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultDataset(t *testing.T) {
+	dataset, err := DefaultDataset()
+	require.NoError(t, err)
+	require.NotEmpty(t, dataset.Variants)
+
+	genes := make(map[string]bool)
+	for _, v := range dataset.Variants {
+		genes[v.Gene] = true
+	}
+	for _, gene := range []string{"CFTR", "HFE", "F5", "BRCA1", "TP53"} {
+		assert.True(t, genes[gene], gene)
+	}
+	assert.Equal(t, "2017-01-12", dataset.Variants[0].ClinVar.Submissions[0].DateCreated)
+}
+
+func TestLoadDataset(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("JSON", func(t *testing.T) {
+		path := filepath.Join(dir, "dataset.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"variants": [
+			{"gene": "MYH7", "chromosome": "14", "position": 23415165, "reference": "G", "alternative": "A",
+			 "hgvs_coding": "NM_000257.4:c.1208G>A",
+			 "clinvar": {"variation_id": "14089", "clinical_significance": "Pathogenic"},
+			 "gnomad": {"ac": 2, "an": 1461000}}
+		]}`), 0o644))
+
+		dataset, err := LoadDataset(path)
+		require.NoError(t, err)
+		require.Len(t, dataset.Variants, 1)
+		assert.Equal(t, "14-23415165-G-A", dataset.Variants[0].GnomADID())
+		assert.Equal(t, "MYH7 NM_000257.4:c.1208G>A", dataset.Variants[0].Label())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"missing gene":        `variants: [{chromosome: "1", position: 1, reference: A, alternative: G}]`,
+			"missing coordinates": `variants: [{gene: MYH7}]`,
+			"missing clinvar id":  `variants: [{gene: MYH7, chromosome: "1", position: 1, reference: A, alternative: G, clinvar: {clinical_significance: Benign}}]`,
+			"allele count":        `variants: [{gene: MYH7, chromosome: "1", position: 1, reference: A, alternative: G, gnomad: {ac: 5, an: 2}}]`,
+			"duplicate clinvar id": `variants:
+  - {gene: MYH7, chromosome: "1", position: 1, reference: A, alternative: G, clinvar: {variation_id: "1"}}
+  - {gene: MYH7, chromosome: "1", position: 2, reference: A, alternative: G, clinvar: {variation_id: "1"}}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				path := filepath.Join(dir, "invalid.yaml")
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+				_, err := LoadDataset(path)
+				assert.ErrorIs(t, err, ErrInvalidDataset)
+			})
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := LoadDataset(filepath.Join(dir, "dataset.csv"))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadDataset(filepath.Join(dir, "absent.yaml"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,542 @@
+// Package mocksources emulates the ClinVar E-utilities, gnomAD GraphQL and
+// COSMIC APIs from a local dataset, so the full server can be tested end to
+// end in environments without internet access.
+package mocksources
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Source names, used for per-source latency and in the route prefixes
+const (
+	SourceClinVar = "clinvar"
+	SourceGnomAD  = "gnomad"
+	SourceCOSMIC  = "cosmic"
+)
+
+// Options configures the simulated network behaviour
+type Options struct {
+	Latency       time.Duration            // Delay before every response
+	Jitter        time.Duration            // Random extra delay, up to this duration
+	SourceLatency map[string]time.Duration // Replaces Latency for the named source
+}
+
+// Server serves the mock sources. Routes are mounted under /clinvar/,
+// /gnomad/ and /cosmic/, so each client's base URL points at its prefix.
+// Server is safe for concurrent use.
+type Server struct {
+	dataset *Dataset
+	options Options
+
+	byVariationID map[string]*Variant
+	byHGVS        map[string][]*Variant // Lowercased HGVS, with and without the reference sequence
+	byPosition    map[string][]*Variant // chrom:pos
+	byGnomADID    map[string]*Variant
+	byGene        map[string][]*Variant // Uppercased symbol
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewServer indexes a dataset for serving.
+func NewServer(dataset *Dataset, options Options) *Server {
+	s := &Server{
+		dataset:       dataset,
+		options:       options,
+		byVariationID: make(map[string]*Variant),
+		byHGVS:        make(map[string][]*Variant),
+		byPosition:    make(map[string][]*Variant),
+		byGnomADID:    make(map[string]*Variant),
+		byGene:        make(map[string][]*Variant),
+		rng:           rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	for i := range dataset.Variants {
+		v := &dataset.Variants[i]
+		if v.ClinVar != nil {
+			s.byVariationID[v.ClinVar.VariationID] = v
+		}
+		for _, hgvs := range []string{v.HGVSGenomic, v.HGVSCoding, v.HGVSProtein} {
+			for _, key := range hgvsKeys(hgvs) {
+				s.byHGVS[key] = append(s.byHGVS[key], v)
+			}
+		}
+		s.byPosition[positionKey(v.Chromosome, v.Position)] = append(s.byPosition[positionKey(v.Chromosome, v.Position)], v)
+		s.byGnomADID[v.GnomADID()] = v
+		gene := strings.ToUpper(v.Gene)
+		s.byGene[gene] = append(s.byGene[gene], v)
+	}
+	return s
+}
+
+// Handler returns the HTTP handler for all mock sources.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ok", "variants": len(s.dataset.Variants)})
+	})
+
+	mux.HandleFunc("GET /clinvar/esearch.fcgi", s.delayed(SourceClinVar, s.handleClinVarSearch))
+	mux.HandleFunc("GET /clinvar/esummary.fcgi", s.delayed(SourceClinVar, s.handleClinVarSummary))
+
+	mux.HandleFunc("POST /gnomad/graphql", s.delayed(SourceGnomAD, s.handleGnomAD))
+
+	mux.HandleFunc("GET /cosmic/api/variants/search", s.delayed(SourceCOSMIC, s.requireCOSMICKey(s.handleCOSMICSearch)))
+	mux.HandleFunc("GET /cosmic/api/variants/genomic", s.delayed(SourceCOSMIC, s.requireCOSMICKey(s.handleCOSMICGenomic)))
+	mux.HandleFunc("GET /cosmic/api/genes/{gene}/mutations/count", s.delayed(SourceCOSMIC, s.requireCOSMICKey(s.handleCOSMICCount)))
+	return mux
+}
+
+// delayed wraps a handler with the configured latency for the source. A
+// cancelled request returns without a response, as a timed out client would see.
+func (s *Server) delayed(source string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if delay := s.delay(source); delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+// delay returns the latency for one request to the source
+func (s *Server) delay(source string) time.Duration {
+	delay := s.options.Latency
+	if d, ok := s.options.SourceLatency[source]; ok {
+		delay = d
+	}
+	if s.options.Jitter > 0 {
+		s.mu.Lock()
+		delay += time.Duration(s.rng.Int63n(int64(s.options.Jitter) + 1))
+		s.mu.Unlock()
+	}
+	return delay
+}
+
+// ClinVar E-utilities
+
+var (
+	clinVarNameTerm     = regexp.MustCompile(`^(.+)\[variant name\]$`)
+	clinVarPositionTerm = regexp.MustCompile(`^(\S+)\[chr\] AND (\d+)\[chrpos\]$`)
+	clinVarGeneTerm     = regexp.MustCompile(`^(\S+)\[gene\]$`)
+)
+
+// eSearchResult is the E-search XML response
+type eSearchResult struct {
+	XMLName xml.Name `xml:"eSearchResult"`
+	Count   int      `xml:"Count"`
+	RetMax  int      `xml:"RetMax"`
+	IDs     []string `xml:"IdList>Id"`
+}
+
+// eSummaryResult is the E-summary XML response for db=clinvar
+type eSummaryResult struct {
+	XMLName   xml.Name          `xml:"eSummaryResult"`
+	Summaries []documentSummary `xml:"DocumentSummary"`
+}
+
+type documentSummary struct {
+	UID           string              `xml:"uid,attr"`
+	Title         string              `xml:"title"`
+	ReviewStatus  string              `xml:"clinical_significance>ReviewStatus"`
+	Description   string              `xml:"clinical_significance>Description"`
+	LastEvaluated string              `xml:"clinical_significance>LastEvaluated"`
+	VariationName string              `xml:"variation_set>variation>Name"`
+	VariationType string              `xml:"variation_set>variation>VariationType"`
+	Traits        []trait             `xml:"trait_set>trait"`
+	Assertions    []clinicalAssertion `xml:"clinical_assertion_list>clinical_assertion"`
+}
+
+type trait struct {
+	Name string `xml:"Name"`
+}
+
+type clinicalAssertion struct {
+	SubmitterName string `xml:"ClinVarAccession>SubmitterName"`
+	Description   string `xml:"ClinVarAccession>Description"`
+	DateCreated   string `xml:"ClinVarAccession>DateCreated"`
+	ReviewStatus  string `xml:"ClinVarAccession>ReviewStatus"`
+}
+
+func (s *Server) handleClinVarSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("db") != "clinvar" {
+		http.Error(w, "unsupported database: "+query.Get("db"), http.StatusBadRequest)
+		return
+	}
+	retMax := 20
+	if n, err := strconv.Atoi(query.Get("retmax")); err == nil && n >= 0 {
+		retMax = n
+	}
+
+	var ids []string
+	for _, v := range s.searchClinVar(strings.TrimSpace(query.Get("term"))) {
+		if v.ClinVar != nil {
+			ids = append(ids, v.ClinVar.VariationID)
+		}
+	}
+	result := eSearchResult{Count: len(ids), RetMax: retMax, IDs: ids}
+	if len(result.IDs) > retMax {
+		result.IDs = result.IDs[:retMax]
+	}
+	writeXML(w, result)
+}
+
+// searchClinVar resolves the search terms the ClinVar client builds
+func (s *Server) searchClinVar(term string) []*Variant {
+	if m := clinVarNameTerm.FindStringSubmatch(term); m != nil {
+		return s.byHGVS[strings.ToLower(strings.TrimSpace(m[1]))]
+	}
+	if m := clinVarPositionTerm.FindStringSubmatch(term); m != nil {
+		position, _ := strconv.ParseInt(m[2], 10, 64)
+		return s.byPosition[positionKey(m[1], position)]
+	}
+	if m := clinVarGeneTerm.FindStringSubmatch(term); m != nil {
+		return s.byGene[strings.ToUpper(m[1])]
+	}
+	return nil
+}
+
+func (s *Server) handleClinVarSummary(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("db") != "clinvar" {
+		http.Error(w, "unsupported database: "+query.Get("db"), http.StatusBadRequest)
+		return
+	}
+
+	var result eSummaryResult
+	for _, id := range strings.Split(query.Get("id"), ",") {
+		v, ok := s.byVariationID[strings.TrimSpace(id)]
+		if !ok {
+			continue
+		}
+		record := v.ClinVar
+		summary := documentSummary{
+			UID:           record.VariationID,
+			Title:         record.Title,
+			ReviewStatus:  record.ReviewStatus,
+			Description:   record.ClinicalSignificance,
+			LastEvaluated: record.LastEvaluated,
+			VariationName: v.HGVSCoding,
+			VariationType: record.VariationType,
+		}
+		for _, condition := range record.Conditions {
+			summary.Traits = append(summary.Traits, trait{Name: condition})
+		}
+		for _, submission := range record.Submissions {
+			summary.Assertions = append(summary.Assertions, clinicalAssertion{
+				SubmitterName: submission.Submitter,
+				Description:   submission.ClinicalSignificance,
+				DateCreated:   submission.DateCreated,
+				ReviewStatus:  submission.ReviewStatus,
+			})
+		}
+		result.Summaries = append(result.Summaries, summary)
+	}
+	writeXML(w, result)
+}
+
+// gnomAD GraphQL
+
+// graphQLRequest is a gnomAD variant query. The v4 client passes the dataset
+// as a variable; the v3 client names gnomad_r3 in the query text.
+type graphQLRequest struct {
+	Query     string `json:"query"`
+	Variables struct {
+		VariantID string `json:"variantId"`
+		Dataset   string `json:"dataset"`
+	} `json:"variables"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+// v4Variant is the gnomAD v4 variant payload
+type v4Variant struct {
+	VariantID string        `json:"variant_id"`
+	Joint     *GnomADRecord `json:"joint"`
+}
+
+// v3Variant is the gnomAD v3 variant payload
+type v3Variant struct {
+	VariantID string        `json:"variantId"`
+	Genome    v3Frequencies `json:"genome"`
+	Exome     v3Frequencies `json:"exome"`
+}
+
+type v3Frequencies struct {
+	AC             int            `json:"ac"`
+	AN             int            `json:"an"`
+	AF             float64        `json:"af"`
+	Hom            int            `json:"hom"`
+	Populations    []v3Population `json:"populations"`
+	QualityMetrics struct {
+		MeanDP float64 `json:"mean_dp"`
+		MeanGQ float64 `json:"mean_gq"`
+		Pass   bool    `json:"pass"`
+	} `json:"qualityMetrics"`
+}
+
+type v3Population struct {
+	ID string  `json:"id"`
+	AC int     `json:"ac"`
+	AN int     `json:"an"`
+	AF float64 `json:"af"`
+}
+
+func (s *Server) handleGnomAD(w http.ResponseWriter, r *http.Request) {
+	var request graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"errors": []graphQLError{{Message: "invalid GraphQL request: " + err.Error()}},
+		})
+		return
+	}
+
+	v, ok := s.byGnomADID[request.Variables.VariantID]
+	if !ok || v.GnomAD == nil {
+		// gnomAD reports unknown variants as a GraphQL error with HTTP 200
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data":   map[string]interface{}{"variant": nil},
+			"errors": []graphQLError{{Message: "Variant not found"}},
+		})
+		return
+	}
+
+	var variant interface{}
+	if request.Variables.Dataset != "" || strings.Contains(request.Query, "joint") {
+		variant = v4Variant{VariantID: v.GnomADID(), Joint: v.GnomAD}
+	} else {
+		variant = v3Variant{VariantID: v.GnomADID(), Genome: genomeFrequencies(v.GnomAD)}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{"variant": variant},
+	})
+}
+
+// genomeFrequencies presents joint counts in the v3 genome layout
+func genomeFrequencies(record *GnomADRecord) v3Frequencies {
+	genome := v3Frequencies{
+		AC:  record.AC,
+		AN:  record.AN,
+		AF:  frequency(record.AC, record.AN),
+		Hom: record.HomozygoteCount,
+	}
+	genome.QualityMetrics.Pass = len(record.Filters) == 0
+	for _, pop := range record.Populations {
+		genome.Populations = append(genome.Populations, v3Population{
+			ID: pop.ID,
+			AC: pop.AC,
+			AN: pop.AN,
+			AF: frequency(pop.AC, pop.AN),
+		})
+	}
+	return genome
+}
+
+// COSMIC
+
+// cosmicEntry is one row of a COSMIC variant response
+type cosmicEntry struct {
+	CosmicID         string `json:"cosmic_id"`
+	GeneName         string `json:"gene_name"`
+	Transcript       string `json:"transcript"`
+	CDSMutation      string `json:"cds_mutation"`
+	AAMutation       string `json:"aa_mutation"`
+	PrimaryTissue    string `json:"primary_tissue"`
+	PrimaryHistology string `json:"primary_histology"`
+	SampleCount      int    `json:"sample_count"`
+	MutationCount    int    `json:"mutation_count"`
+	FathmmScore      string `json:"fathmm_score"`
+	FathmmPrediction string `json:"fathmm_prediction"`
+	SomaticStatus    string `json:"mutation_somatic_status"`
+	Tier             string `json:"tier"`
+}
+
+type cosmicResponse struct {
+	Data  []cosmicEntry `json:"data"`
+	Count int           `json:"count"`
+	Error string        `json:"error,omitempty"`
+}
+
+// requireCOSMICKey rejects requests without an api_key, as COSMIC does
+func (s *Server) requireCOSMICKey(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("api_key") == "" {
+			writeJSON(w, http.StatusUnauthorized, cosmicResponse{Data: []cosmicEntry{}, Error: "api_key is required"})
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleCOSMICSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	gene := strings.ToUpper(query.Get("gene_name"))
+	cds := query.Get("cds_mutation")
+	aa := query.Get("aa_mutation")
+	if gene == "" && cds == "" && aa == "" {
+		writeJSON(w, http.StatusBadRequest, cosmicResponse{Data: []cosmicEntry{}, Error: "gene_name, cds_mutation or aa_mutation is required"})
+		return
+	}
+
+	var matches []*Variant
+	for i := range s.dataset.Variants {
+		v := &s.dataset.Variants[i]
+		if gene != "" && strings.ToUpper(v.Gene) != gene {
+			continue
+		}
+		if cds != "" && !hgvsMatches(cds, v.HGVSCoding) {
+			continue
+		}
+		if aa != "" && !hgvsMatches(aa, v.HGVSProtein) {
+			continue
+		}
+		matches = append(matches, v)
+	}
+	s.writeCOSMIC(w, matches)
+}
+
+func (s *Server) handleCOSMICGenomic(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	position, err := strconv.ParseInt(query.Get("position"), 10, 64)
+	if query.Get("chromosome") == "" || err != nil {
+		writeJSON(w, http.StatusBadRequest, cosmicResponse{Data: []cosmicEntry{}, Error: "chromosome and position are required"})
+		return
+	}
+
+	var matches []*Variant
+	for _, v := range s.byPosition[positionKey(query.Get("chromosome"), position)] {
+		if ref := query.Get("ref_allele"); ref != "" && !strings.EqualFold(ref, v.Reference) {
+			continue
+		}
+		if alt := query.Get("alt_allele"); alt != "" && !strings.EqualFold(alt, v.Alternative) {
+			continue
+		}
+		matches = append(matches, v)
+	}
+	s.writeCOSMIC(w, matches)
+}
+
+func (s *Server) handleCOSMICCount(w http.ResponseWriter, r *http.Request) {
+	total := 0
+	tumorTypes := make(map[string]int)
+	for _, v := range s.byGene[strings.ToUpper(r.PathValue("gene"))] {
+		for _, record := range v.COSMIC {
+			total += record.MutationCount
+			if record.PrimaryTissue != "" {
+				tumorTypes[record.PrimaryTissue] += record.MutationCount
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"total_mutations": total, "tumor_types": tumorTypes})
+}
+
+// writeCOSMIC writes the COSMIC rows of the matched variants. COSMIC answers
+// 404 when nothing matches.
+func (s *Server) writeCOSMIC(w http.ResponseWriter, variants []*Variant) {
+	response := cosmicResponse{Data: []cosmicEntry{}}
+	for _, v := range variants {
+		for _, record := range v.COSMIC {
+			response.Data = append(response.Data, cosmicEntry{
+				CosmicID:         record.CosmicID,
+				GeneName:         v.Gene,
+				Transcript:       v.Transcript,
+				CDSMutation:      hgvsChange(v.HGVSCoding),
+				AAMutation:       hgvsChange(v.HGVSProtein),
+				PrimaryTissue:    record.PrimaryTissue,
+				PrimaryHistology: record.PrimaryHistology,
+				SampleCount:      record.SampleCount,
+				MutationCount:    record.MutationCount,
+				FathmmScore:      record.FathmmScore,
+				FathmmPrediction: record.FathmmPrediction,
+				SomaticStatus:    "Confirmed somatic variant",
+				Tier:             record.Tier,
+			})
+		}
+	}
+	response.Count = len(response.Data)
+
+	status := http.StatusOK
+	if response.Count == 0 {
+		status = http.StatusNotFound
+	}
+	writeJSON(w, status, response)
+}
+
+// hgvsMatches compares a query against a dataset HGVS expression. COSMIC
+// wildcard queries (*c.68_69del*) match by substring; either side may omit
+// the reference sequence.
+func hgvsMatches(query, hgvs string) bool {
+	if hgvs == "" {
+		return false
+	}
+	query = strings.ToLower(query)
+	if strings.HasPrefix(query, "*") || strings.HasSuffix(query, "*") {
+		return strings.Contains(strings.ToLower(hgvs), strings.Trim(query, "*"))
+	}
+	for _, key := range hgvsKeys(hgvs) {
+		if key == query || key == strings.ToLower(hgvsChange(query)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hgvsKeys returns the lookup keys of an HGVS expression: the full expression
+// and the change without its reference sequence
+func hgvsKeys(hgvs string) []string {
+	hgvs = strings.ToLower(strings.TrimSpace(hgvs))
+	if hgvs == "" {
+		return nil
+	}
+	if change := hgvsChange(hgvs); change != hgvs {
+		return []string{hgvs, change}
+	}
+	return []string{hgvs}
+}
+
+// hgvsChange strips the reference sequence, "NM_000492.4:c.1521_1523del" -> "c.1521_1523del"
+func hgvsChange(hgvs string) string {
+	if i := strings.LastIndex(hgvs, ":"); i >= 0 {
+		return hgvs[i+1:]
+	}
+	return hgvs
+}
+
+func positionKey(chromosome string, position int64) string {
+	return strings.TrimPrefix(strings.ToLower(chromosome), "chr") + ":" + strconv.FormatInt(position, 10)
+}
+
+func frequency(ac, an int) float64 {
+	if an == 0 {
+		return 0
+	}
+	return float64(ac) / float64(an)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeXML(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "text/xml; charset=UTF-8")
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,173 @@
+package mocksources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+func newTestServer(t *testing.T, options Options) *httptest.Server {
+	t.Helper()
+	dataset, err := DefaultDataset()
+	require.NoError(t, err)
+	server := httptest.NewServer(NewServer(dataset, options).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClinVarClientAgainstMock(t *testing.T) {
+	server := newTestServer(t, Options{})
+	client := external.NewClinVarClient(domain.ClinVarConfig{
+		BaseURL:   server.URL + "/clinvar/",
+		Timeout:   5 * time.Second,
+		RateLimit: 1000,
+	})
+
+	t.Run("coding HGVS", func(t *testing.T) {
+		data, err := client.QueryVariant(context.Background(), &domain.StandardizedVariant{
+			HGVSCoding: "NM_000492.4:c.1521_1523del",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "7105", data.VariationID)
+		assert.Equal(t, "Pathogenic", data.ClinicalSignificance)
+		assert.Equal(t, "reviewed by expert panel", data.ReviewStatus)
+		assert.Contains(t, data.Conditions, "Cystic fibrosis")
+		assert.Len(t, data.Submissions, 2)
+		assert.Equal(t, 2017, data.LastEvaluated.Year())
+	})
+
+	t.Run("coordinates", func(t *testing.T) {
+		data, err := client.QueryVariant(context.Background(), &domain.StandardizedVariant{
+			Chromosome: "chr6",
+			Position:   26092913,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "9", data.VariationID)
+	})
+
+	t.Run("unknown variant", func(t *testing.T) {
+		data, err := client.QueryVariant(context.Background(), &domain.StandardizedVariant{
+			HGVSCoding: "NM_000492.4:c.1A>G",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, data.VariationID)
+	})
+}
+
+func TestGnomADClientsAgainstMock(t *testing.T) {
+	server := newTestServer(t, Options{})
+	hfe := &domain.StandardizedVariant{Chromosome: "chr6", Position: 26092913, Reference: "G", Alternative: "A"}
+
+	t.Run("v4 joint frequencies", func(t *testing.T) {
+		client := external.NewGnomADV4Client(domain.GnomADConfig{BaseURL: server.URL + "/gnomad", Timeout: 5 * time.Second, RateLimit: 1000})
+		data, err := client.QueryVariant(context.Background(), hfe)
+		require.NoError(t, err)
+		assert.Equal(t, external.GnomADDatasetV4, data.Dataset)
+		assert.InDelta(t, 55830.0/1461870.0, data.AlleleFrequency, 1e-9)
+		assert.Equal(t, 0.0438, data.FAF95)
+		assert.Equal(t, "nfe", data.FAFPopulation)
+		assert.True(t, data.QualityMetrics.FilterPass)
+	})
+
+	t.Run("v3 genome frequencies", func(t *testing.T) {
+		client := external.NewGnomADClient(domain.GnomADConfig{BaseURL: server.URL + "/gnomad", Timeout: 5 * time.Second, RateLimit: 1000})
+		data, err := client.QueryVariant(context.Background(), hfe)
+		require.NoError(t, err)
+		assert.Equal(t, external.GnomADDatasetV3, data.Dataset)
+		assert.Equal(t, 55830, data.AlleleCount)
+		assert.Contains(t, data.PopulationFrequencies, "nfe")
+	})
+
+	t.Run("variant absent from gnomAD", func(t *testing.T) {
+		client := external.NewGnomADV4Client(domain.GnomADConfig{BaseURL: server.URL + "/gnomad", Timeout: 5 * time.Second, RateLimit: 1000})
+		data, err := client.QueryVariant(context.Background(), &domain.StandardizedVariant{
+			Chromosome: "17", Position: 7675088, Reference: "C", Alternative: "T",
+		})
+		require.NoError(t, err)
+		assert.Zero(t, data.AlleleNumber)
+	})
+}
+
+func TestCOSMICClientAgainstMock(t *testing.T) {
+	server := newTestServer(t, Options{})
+	client := external.NewCOSMICClient(domain.COSMICConfig{
+		BaseURL:   server.URL + "/cosmic",
+		APIKey:    "mock",
+		Timeout:   5 * time.Second,
+		RateLimit: 1000,
+	})
+
+	data, err := client.QueryVariant(context.Background(), &domain.StandardizedVariant{
+		GeneSymbol: "TP53",
+		HGVSCoding: "NM_000546.6:c.524G>A",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, data.CosmicID, "COSV52661038")
+	assert.Equal(t, 1840, data.SampleCount)
+	assert.Contains(t, data.TumorTypes, "breast")
+
+	t.Run("coordinates", func(t *testing.T) {
+		data, err := client.QueryVariant(context.Background(), &domain.StandardizedVariant{
+			Chromosome: "chr17", Position: 43124027, Reference: "ACT", Alternative: "A",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, data.CosmicID, "COSV58786436")
+	})
+
+	t.Run("germline variant without somatic records", func(t *testing.T) {
+		data, err := client.QueryVariant(context.Background(), &domain.StandardizedVariant{
+			GeneSymbol: "CFTR",
+			HGVSCoding: "NM_000492.4:c.1521_1523del",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, data.CosmicID)
+	})
+}
+
+func TestCOSMICRequiresAPIKey(t *testing.T) {
+	server := newTestServer(t, Options{})
+
+	resp, err := http.Get(server.URL + "/cosmic/api/variants/search?gene_name=TP53")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestLatency(t *testing.T) {
+	server := newTestServer(t, Options{
+		Latency:       time.Millisecond,
+		SourceLatency: map[string]time.Duration{SourceClinVar: 150 * time.Millisecond},
+	})
+
+	start := time.Now()
+	resp, err := http.Get(server.URL + "/clinvar/esearch.fcgi?db=clinvar&term=BRCA1%5Bgene%5D")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	// The client gives up first, as it would against a slow upstream
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/clinvar/esearch.fcgi?db=clinvar&term=BRCA1%5Bgene%5D", nil)
+	require.NoError(t, err)
+	_, err = http.DefaultClient.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestHGVSMatches(t *testing.T) {
+	assert.True(t, hgvsMatches("NM_000546.6:c.524G>A", "NM_000546.6:c.524G>A"))
+	assert.True(t, hgvsMatches("c.524G>A", "NM_000546.6:c.524G>A"))
+	assert.True(t, hgvsMatches("NM_000546.6:c.524G>A", "c.524G>A"))
+	assert.True(t, hgvsMatches("*c.524G>A*", "NM_000546.6:c.524G>A"))
+	assert.False(t, hgvsMatches("c.524G>T", "NM_000546.6:c.524G>A"))
+	assert.False(t, hgvsMatches("c.524G>A", ""))
+	assert.Equal(t, "c.1521_1523del", hgvsChange("NM_000492.4:c.1521_1523del"))
+}
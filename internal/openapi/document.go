@@ -0,0 +1,202 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// Path is where the generated document is served.
+const Path = "/openapi.json"
+
+// Version is the OpenAPI version of generated documents
+const Version = "3.1.0"
+
+// Security scheme names
+const (
+	APIKeyScheme = "apiKey"
+	BearerScheme = "bearerAuth"
+)
+
+// Document is an OpenAPI 3.1 document
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"` // Path -> lower-case method -> operation
+	Components Components                       `json:"components"`
+	Security   []map[string][]string            `json:"security,omitempty"`
+
+	types map[string]reflect.Type // Go type of each component schema
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components holds the schemas and security schemes operations refer to
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme is a way clients authenticate
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+	In     string `json:"in,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// Operation is one method on a path
+type Operation struct {
+	Summary      string                `json:"summary,omitempty"`
+	OperationID  string                `json:"operationId,omitempty"`
+	RequestBody  *RequestBody          `json:"requestBody,omitempty"`
+	Responses    map[string]*Response  `json:"responses"`
+	Security     []map[string][]string `json:"security,omitempty"`
+	RequiredRole string                `json:"x-required-role,omitempty"`
+}
+
+// RequestBody is an operation's JSON request body
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is one of an operation's responses
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType gives the schema of a body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Endpoint describes a REST endpoint by the Go types of its bodies
+type Endpoint struct {
+	Method   string
+	Path     string
+	Summary  string
+	Request  interface{} // Decoded JSON request body; nil when the endpoint takes none
+	Response interface{} // Encoded JSON response body; nil when not JSON
+	Role     string      // Role required; empty for public endpoints
+	Public   bool        // Credentials optional
+}
+
+// NewDocument creates a document with the API key and bearer token schemes
+// and the error envelope schema
+func NewDocument(info Info) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]map[string]*Operation),
+		Components: Components{
+			Schemas: map[string]*Schema{"ErrorEnvelope": SchemaOf(protocol.ErrorEnvelope{})},
+			SecuritySchemes: map[string]*SecurityScheme{
+				APIKeyScheme: {Type: "apiKey", In: "header", Name: "X-API-Key"},
+				BearerScheme: {Type: "http", Scheme: "bearer"},
+			},
+		},
+		Security: []map[string][]string{{APIKeyScheme: {}}, {BearerScheme: {}}},
+		types:    map[string]reflect.Type{"ErrorEnvelope": reflect.TypeOf(protocol.ErrorEnvelope{})},
+	}
+}
+
+// pathParam matches gin path parameters such as :id
+var pathParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// Add documents an endpoint. Request and response types are added to the
+// component schemas under their Go type names.
+func (d *Document) Add(endpoint Endpoint) {
+	path := pathParam.ReplaceAllString(endpoint.Path, "{$1}")
+	operation := &Operation{
+		Summary:      endpoint.Summary,
+		OperationID:  operationID(endpoint.Method, path),
+		RequiredRole: endpoint.Role,
+		Responses: map[string]*Response{
+			"200": {Description: "OK"},
+			"401": d.errorResponse("Missing or invalid credentials"),
+			"403": d.errorResponse("The client's role does not permit this request"),
+			"429": d.errorResponse("Rate limit or daily quota exceeded"),
+		},
+	}
+	if endpoint.Public {
+		// Credentials are optional but raise the access granted
+		operation.Security = append([]map[string][]string{{}}, d.Security...)
+		delete(operation.Responses, "401")
+		delete(operation.Responses, "403")
+	}
+	if endpoint.Request != nil {
+		operation.RequestBody = &RequestBody{Required: true, Content: jsonContent(d.component(endpoint.Request))}
+		operation.Responses["400"] = d.errorResponse("The request body is not valid JSON or does not match the schema; details.errors lists each offending field")
+	}
+	if endpoint.Response != nil {
+		operation.Responses["200"].Content = jsonContent(d.component(endpoint.Response))
+	}
+
+	if d.Paths[path] == nil {
+		d.Paths[path] = make(map[string]*Operation)
+	}
+	d.Paths[path][strings.ToLower(endpoint.Method)] = operation
+}
+
+// component adds the schema of v's type to the components, returning a
+// reference to it
+func (d *Document) component(v interface{}) *Schema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if name == "" {
+		return SchemaOf(v)
+	}
+	if existing, ok := d.types[name]; ok && existing != t {
+		// Types of the same name in different packages are qualified
+		if pkg := t.PkgPath(); pkg != "" {
+			name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+		}
+	}
+	d.types[name] = t
+	d.Components.Schemas[name] = SchemaOf(v)
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// errorResponse is a response with the error envelope
+func (d *Document) errorResponse(description string) *Response {
+	return &Response{Description: description, Content: jsonContent(&Schema{Ref: "#/components/schemas/ErrorEnvelope"})}
+}
+
+// jsonContent is an application/json body of the schema
+func jsonContent(schema *Schema) map[string]*MediaType {
+	return map[string]*MediaType{"application/json": {Schema: schema}}
+}
+
+// operationID derives an operation ID such as postApiV1Match
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, word := range strings.FieldsFunc(path, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// Handler serves the document as JSON
+func Handler(document *Document) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(document)
+	})
+}
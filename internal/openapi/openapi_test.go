@@ -0,0 +1,153 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+type testBase struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testRequest struct {
+	testBase
+	Gene       string            `json:"gene" validate:"required,min=1"`
+	Similarity float64           `json:"similarity,omitempty" validate:"min=0,max=1"`
+	Mode       string            `json:"mode,omitempty" validate:"oneof=fast thorough"`
+	Terms      []testTerm        `json:"terms,omitempty" validate:"max=2"`
+	Count      int               `json:"count,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Next       *testRequest      `json:"next,omitempty"`
+	Secret     string            `json:"-"`
+	internal   string
+}
+
+type testTerm struct {
+	ID string `json:"id" validate:"required"`
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema := SchemaOf(testRequest{})
+
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []string{"gene"}, schema.Required)
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, schema.Properties["created_at"], "embedded fields are flattened")
+	assert.Equal(t, 1, *schema.Properties["gene"].MinLength)
+	assert.Equal(t, 1.0, *schema.Properties["similarity"].Maximum)
+	assert.Equal(t, []interface{}{"fast", "thorough"}, schema.Properties["mode"].Enum)
+	assert.Equal(t, 2, *schema.Properties["terms"].MaxItems)
+	assert.Equal(t, []string{"id"}, schema.Properties["terms"].Items.Required)
+	assert.Equal(t, "integer", schema.Properties["count"].Type)
+	assert.Equal(t, "string", schema.Properties["labels"].AdditionalProperties.Type)
+	assert.Equal(t, &Schema{}, schema.Properties["next"], "recursive types are left unconstrained")
+	assert.NotContains(t, schema.Properties, "Secret")
+	assert.NotContains(t, schema.Properties, "internal")
+}
+
+func TestSchema_Validate(t *testing.T) {
+	schema := SchemaOf(testRequest{})
+	decode := func(body string) interface{} {
+		var value interface{}
+		require.NoError(t, json.Unmarshal([]byte(body), &value))
+		return value
+	}
+
+	assert.Empty(t, schema.Validate(decode(`{"gene": "SCN1A", "similarity": 0.5, "terms": [{"id": "HP:0001250"}], "unknown": true}`)))
+	assert.Equal(t, []FieldError{{Field: "gene", Message: "is required"}}, schema.Validate(decode(`{"gene": null}`)))
+	assert.Equal(t, []FieldError{{Message: "must be an object"}}, schema.Validate(decode(`[]`)))
+	assert.Equal(t, []FieldError{
+		{Field: "count", Message: "must be an integer"},
+		{Field: "gene", Message: "must be at least 1 character(s)"},
+		{Field: "labels.a", Message: "must be a string"},
+		{Field: "mode", Message: "must be one of [fast thorough]"},
+		{Field: "similarity", Message: "must be at most 1"},
+		{Field: "terms", Message: "must have at most 2 item(s)"},
+		{Field: "terms[1].id", Message: "is required"},
+		{Field: "terms[2]", Message: "must be an object"},
+	}, schema.Validate(decode(`{"gene": "", "similarity": 2, "mode": "slow", "count": 1.5, "labels": {"a": 1}, "terms": [{"id": "x"}, {}, "y"]}`)))
+}
+
+func TestValidateRequest(t *testing.T) {
+	var received string
+	handler := ValidateRequest(SchemaOf(testRequest{}), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+	}))
+
+	t.Run("valid bodies reach the handler unchanged", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/test", strings.NewReader(`{"gene": "SCN1A"}`)))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `{"gene": "SCN1A"}`, received)
+	})
+
+	t.Run("schema violations list each field", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/test", strings.NewReader(`{"similarity": "high"}`))
+		req.Header.Set(protocol.CorrelationHeader, "corr-1")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		var envelope struct {
+			protocol.ErrorEnvelope
+			Details struct {
+				Errors []FieldError `json:"errors"`
+			} `json:"details"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+		assert.Equal(t, protocol.ErrorCodeInvalidInput, envelope.Code)
+		assert.Equal(t, "corr-1", envelope.CorrelationID)
+		assert.Equal(t, "rest:POST /api/v1/test", envelope.Source)
+		assert.Equal(t, []FieldError{
+			{Field: "gene", Message: "is required"},
+			{Field: "similarity", Message: "must be a number"},
+		}, envelope.Details.Errors)
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/test", strings.NewReader(`{"gene":`)))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), protocol.ErrorCodeParseError)
+	})
+}
+
+func TestDocument(t *testing.T) {
+	document := NewDocument(Info{Title: "Test API", Version: "1.0.0"})
+	document.Add(Endpoint{Method: http.MethodPost, Path: "/api/v1/test", Summary: "Test", Request: testRequest{}, Response: &testTerm{}, Role: "classify"})
+	document.Add(Endpoint{Method: http.MethodGet, Path: "/api/v1/items/:id", Public: true})
+
+	rec := httptest.NewRecorder()
+	Handler(document).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var served map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	assert.Equal(t, Version, served["openapi"])
+
+	operation := document.Paths["/api/v1/test"]["post"]
+	require.NotNil(t, operation)
+	assert.Equal(t, "postApiV1Test", operation.OperationID)
+	assert.Equal(t, "classify", operation.RequiredRole)
+	assert.Equal(t, "#/components/schemas/testRequest", operation.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/testTerm", operation.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Contains(t, operation.Responses, "400")
+	assert.Contains(t, document.Components.Schemas, "ErrorEnvelope")
+
+	public := document.Paths["/api/v1/items/{id}"]["get"]
+	require.NotNil(t, public, "path parameters use OpenAPI syntax")
+	assert.Equal(t, map[string][]string{}, public.Security[0], "credentials are optional")
+	assert.NotContains(t, public.Responses, "401")
+}
@@ -0,0 +1,179 @@
+// Package openapi generates the OpenAPI 3.1 document of the REST API from
+// the Go types its endpoints decode and encode, and validates request bodies
+// against the same schemas so malformed requests are rejected with the path
+// of each offending field. Schemas follow struct json tags; constraints come
+// from validate tags in the style used across the repository: required,
+// min=, max= and oneof=.
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is a JSON Schema 2020-12 schema, the dialect of OpenAPI 3.1
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	durationType   = reflect.TypeOf(time.Duration(0))
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// SchemaOf returns the schema of the JSON encoding of v's type
+func SchemaOf(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	return schemaFor(reflect.TypeOf(v), make(map[reflect.Type]bool))
+}
+
+// schemaFor builds the schema of t; types already being built are recursive
+// and left unconstrained
+func schemaFor(t reflect.Type, building map[reflect.Type]bool) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case durationType:
+		return &Schema{Type: "integer", Format: "int64"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: schemaFor(t.Elem(), building)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaFor(t.Elem(), building)}
+	case reflect.Struct:
+		if building[t] {
+			return &Schema{}
+		}
+		building[t] = true
+		defer delete(building, t)
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		addFields(schema, t, building)
+		return schema
+	}
+	return &Schema{}
+}
+
+// addFields adds the properties of a struct's fields, flattening embedded
+// structs as encoding/json does
+func addFields(schema *Schema, t reflect.Type, building map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addFields(schema, embedded, building)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := schemaFor(field.Type, building)
+		if strings.Contains(options, "string") && property.Type != "string" {
+			property = &Schema{Type: "string"}
+		}
+		if applyConstraints(property, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// applyConstraints applies a validate tag to a property, reporting whether
+// the property is required
+func applyConstraints(property *Schema, tag string) bool {
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch key {
+		case "required":
+			required = true
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			setBound(property, key == "min", n)
+		case "oneof":
+			for _, option := range strings.Fields(value) {
+				property.Enum = append(property.Enum, option)
+			}
+		}
+	}
+	return required
+}
+
+// setBound sets a lower or upper bound on a length, item count or value
+func setBound(property *Schema, lower bool, n float64) {
+	count := int(n)
+	switch property.Type {
+	case "string":
+		if lower {
+			property.MinLength = &count
+		} else {
+			property.MaxLength = &count
+		}
+	case "array":
+		if lower {
+			property.MinItems = &count
+		} else {
+			property.MaxItems = &count
+		}
+	case "integer", "number":
+		if lower {
+			property.Minimum = &n
+		} else {
+			property.Maximum = &n
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// maxRequestBytes bounds the request bodies read for validation
+const maxRequestBytes = 1 << 20
+
+// FieldError is a request field that does not match the schema
+type FieldError struct {
+	Field   string `json:"field"` // JSON path, e.g. patient.features[0].id; empty for the whole body
+	Message string `json:"message"`
+}
+
+// Validate checks a decoded JSON value against the schema, returning every
+// mismatch. Properties the schema does not declare are ignored, as they are
+// when the body is decoded into its Go type.
+func (s *Schema) Validate(value interface{}) []FieldError {
+	var errs []FieldError
+	s.validate("", value, &errs)
+	return errs
+}
+
+func (s *Schema) validate(path string, value interface{}, errs *[]FieldError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+	if value == nil {
+		if s.Type != "" {
+			fail("must be %s, not null", article(s.Type))
+		}
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		for _, name := range s.Required {
+			if v, present := object[name]; !present || v == nil {
+				*errs = append(*errs, FieldError{Field: join(path, name), Message: "is required"})
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := s.Properties[name]; ok {
+				if object[name] != nil {
+					property.validate(join(path, name), object[name], errs)
+				}
+			} else if s.AdditionalProperties != nil {
+				s.AdditionalProperties.validate(join(path, name), object[name], errs)
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		if s.MinItems != nil && len(array) < *s.MinItems {
+			fail("must have at least %d item(s)", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(array) > *s.MaxItems {
+			fail("must have at most %d item(s)", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range array {
+				s.Items.validate(path+"["+strconv.Itoa(i)+"]", item, errs)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		length := len([]rune(str))
+		if s.MinLength != nil && length < *s.MinLength {
+			fail("must be at least %d character(s)", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail("must be at most %d character(s)", *s.MaxLength)
+		}
+		if len(s.Enum) > 0 && !s.allows(str) {
+			fail("must be one of %v", s.Enum)
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok {
+			fail("must be %s", article(s.Type))
+			return
+		}
+		if s.Type == "integer" && n != math.Trunc(n) {
+			fail("must be an integer")
+		}
+		if s.Minimum != nil && n < *s.Minimum {
+			fail("must be at least %g", *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			fail("must be at most %g", *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("must be a boolean")
+		}
+	}
+}
+
+// allows reports whether a string is one of the schema's enum values
+func (s *Schema) allows(str string) bool {
+	for _, option := range s.Enum {
+		if option == str {
+			return true
+		}
+	}
+	return false
+}
+
+// join appends a property name to a JSON path
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// article prefixes a JSON type with its indefinite article
+func article(jsonType string) string {
+	switch jsonType {
+	case "array", "object", "integer":
+		return "an " + jsonType
+	}
+	return "a " + jsonType
+}
+
+// ValidateRequest rejects request bodies that are not JSON matching the
+// schema with 400 Bad Request and the standard error envelope, listing each
+// offending field in details.errors. Valid bodies are passed on unchanged.
+func ValidateRequest(schema *Schema, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+		if err != nil {
+			writeError(w, r, protocol.ErrorCodeInvalidRequest, "Request body could not be read", err.Error())
+			return
+		}
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			writeError(w, r, protocol.ErrorCodeParseError, "Request body is not valid JSON", err.Error())
+			return
+		}
+		if errs := schema.Validate(value); len(errs) > 0 {
+			writeError(w, r, protocol.ErrorCodeInvalidInput, "Request does not match the schema", map[string]interface{}{"errors": errs})
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeError responds with 400 and the standard error envelope
+func writeError(w http.ResponseWriter, r *http.Request, code, message string, details interface{}) {
+	envelope := protocol.NewErrorEnvelope(code, message, "rest:"+r.Method+" "+r.URL.Path, r.Header.Get(protocol.CorrelationHeader), details)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(envelope)
+}
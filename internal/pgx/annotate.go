@@ -0,0 +1,268 @@
+package pgx
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidRequest is returned for observations or genes that cannot be annotated
+var ErrInvalidRequest = errors.New("invalid pharmacogenomic request")
+
+// Zygosities of an observed variant
+const (
+	Heterozygous = "heterozygous"
+	Homozygous   = "homozygous"
+)
+
+// PhenotypeIndeterminate is reported when the diplotype's functions have no
+// phenotype translation
+const PhenotypeIndeterminate = "Indeterminate"
+
+// Observation is a variant observed in the patient
+type Observation struct {
+	HGVS     string `json:"hgvs"`
+	Zygosity string `json:"zygosity,omitempty"` // heterozygous (default) or homozygous
+}
+
+// ObservedVariant is an observation and the star alleles it helps define
+type ObservedVariant struct {
+	HGVS     string   `json:"hgvs"`
+	Zygosity string   `json:"zygosity"`
+	Alleles  []string `json:"alleles"`
+}
+
+// GeneResult is the diplotype, phenotype and recommendations for one gene
+type GeneResult struct {
+	Gene            string            `json:"gene"`
+	Diplotype       string            `json:"diplotype"` // e.g. CYP2C19*1/*2
+	Alleles         []Allele          `json:"alleles"`
+	ActivityScore   *float64          `json:"activity_score,omitempty"` // Sum of the allele activity values, when both have one
+	Phenotype       string            `json:"phenotype"`
+	Variants        []ObservedVariant `json:"observed_variants,omitempty"`
+	Recommendations []Recommendation  `json:"recommendations,omitempty"`
+	Notes           []string          `json:"notes,omitempty"`
+}
+
+// Result is the pharmacogenomic annotation of a set of observations
+type Result struct {
+	Genes     []GeneResult `json:"genes"`
+	Unmatched []string     `json:"unmatched_variants,omitempty"` // Observations defining no star allele
+}
+
+// Annotator calls star allele diplotypes and looks up CPIC recommendations
+type Annotator struct {
+	knowledge *Knowledge
+}
+
+// NewAnnotator creates an annotator. Without knowledge every observation is
+// unmatched.
+func NewAnnotator(knowledge *Knowledge) *Annotator {
+	if knowledge == nil {
+		knowledge = NewKnowledge()
+	}
+	return &Annotator{knowledge: knowledge}
+}
+
+// Annotate calls a diplotype for each gene with observed defining variants
+// and for each requested gene, and lists the CPIC recommendations for the
+// resulting phenotype, limited to one drug when drug is set. Genotypes are
+// unphased: defining variants are assigned to the most specific alleles
+// first, and positions without an observation are assumed to be reference.
+func (a *Annotator) Annotate(observations []Observation, genes []string, drug string) (*Result, error) {
+	dosage := make(map[string]int)
+	zygosity := make(map[string]string)
+	byGene := make(map[string][]string)
+	result := &Result{Genes: []GeneResult{}}
+
+	for _, obs := range observations {
+		key := variantKey(obs.HGVS)
+		if key == "" {
+			return nil, fmt.Errorf("%w: observation without hgvs", ErrInvalidRequest)
+		}
+		copies, z, err := parseZygosity(obs.Zygosity)
+		if err != nil {
+			return nil, err
+		}
+		if _, seen := dosage[key]; seen {
+			return nil, fmt.Errorf("%w: %s observed twice", ErrInvalidRequest, obs.HGVS)
+		}
+		dosage[key], zygosity[key] = copies, z
+
+		gene, ok := a.knowledge.variantGenes[key]
+		if !ok {
+			result.Unmatched = append(result.Unmatched, strings.TrimSpace(obs.HGVS))
+			continue
+		}
+		byGene[gene] = append(byGene[gene], strings.TrimSpace(obs.HGVS))
+	}
+
+	for _, gene := range genes {
+		canonical, ok := a.canonicalGene(gene)
+		if !ok {
+			return nil, fmt.Errorf("%w: no star alleles for %q (known genes: %s)", ErrInvalidRequest, gene, strings.Join(a.knowledge.Genes(), ", "))
+		}
+		if _, ok := byGene[canonical]; !ok {
+			byGene[canonical] = nil
+		}
+	}
+
+	names := make([]string, 0, len(byGene))
+	for gene := range byGene {
+		names = append(names, gene)
+	}
+	sort.Strings(names)
+	for _, gene := range names {
+		geneResult := a.callGene(gene, byGene[gene], dosage, zygosity)
+		geneResult.Recommendations = a.recommendationsFor(gene, geneResult.Phenotype, drug)
+		if drug != "" && len(geneResult.Recommendations) == 0 {
+			geneResult.Notes = append(geneResult.Notes, fmt.Sprintf("No CPIC recommendation for %s and %s %s", strings.TrimSpace(drug), gene, geneResult.Phenotype))
+		}
+		result.Genes = append(result.Genes, geneResult)
+	}
+	return result, nil
+}
+
+// callGene assigns the observed variants of a gene to at most two alleles
+func (a *Annotator) callGene(gene string, variants []string, dosage map[string]int, zygosity map[string]string) GeneResult {
+	geneResult := GeneResult{Gene: gene}
+	remaining := make(map[string]int)
+	for _, variant := range variants {
+		remaining[variantKey(variant)] = dosage[variantKey(variant)]
+	}
+
+	var reference *Allele
+	for i, allele := range a.knowledge.alleles[gene] {
+		if len(allele.Variants) == 0 {
+			if reference == nil {
+				reference = &a.knowledge.alleles[gene][i]
+			}
+			continue
+		}
+		for len(geneResult.Alleles) < 2 && covers(allele, remaining) {
+			for _, variant := range allele.Variants {
+				remaining[variantKey(variant)]--
+			}
+			geneResult.Alleles = append(geneResult.Alleles, allele)
+		}
+	}
+
+	var unassigned []string
+	for _, variant := range variants {
+		if remaining[variantKey(variant)] > 0 {
+			unassigned = append(unassigned, variant)
+		}
+	}
+	if len(unassigned) > 0 {
+		geneResult.Notes = append(geneResult.Notes, fmt.Sprintf("Variants not assigned to a called allele: %s; the diplotype may be a novel or rarer allele", strings.Join(unassigned, ", ")))
+	}
+
+	for len(geneResult.Alleles) < 2 {
+		if reference == nil {
+			geneResult.Notes = append(geneResult.Notes, "No reference allele defined for "+gene)
+			break
+		}
+		geneResult.Alleles = append(geneResult.Alleles, *reference)
+	}
+	if len(variants) == 0 {
+		geneResult.Notes = append(geneResult.Notes, "No defining variants observed; assumes every defining position was genotyped")
+	} else if len(variants) > 1 {
+		geneResult.Notes = append(geneResult.Notes, "Phase unknown; variants are assigned to the most specific alleles that explain them")
+	}
+
+	for _, variant := range variants {
+		observed := ObservedVariant{HGVS: variant, Zygosity: zygosity[variantKey(variant)], Alleles: []string{}}
+		for _, allele := range a.knowledge.alleles[gene] {
+			for _, defining := range allele.Variants {
+				if variantKey(defining) == variantKey(variant) {
+					observed.Alleles = append(observed.Alleles, allele.Name)
+				}
+			}
+		}
+		geneResult.Variants = append(geneResult.Variants, observed)
+	}
+
+	if len(geneResult.Alleles) < 2 {
+		geneResult.Phenotype = PhenotypeIndeterminate
+		return geneResult
+	}
+	geneResult.Diplotype = diplotype(gene, geneResult.Alleles[0].Name, geneResult.Alleles[1].Name)
+	geneResult.Phenotype = PhenotypeIndeterminate
+	if phenotype, ok := a.knowledge.phenotypes[gene][functionPair(geneResult.Alleles[0].Function, geneResult.Alleles[1].Function)]; ok {
+		geneResult.Phenotype = phenotype
+	}
+	if first, second := geneResult.Alleles[0].ActivityValue, geneResult.Alleles[1].ActivityValue; first != nil && second != nil {
+		score := *first + *second
+		geneResult.ActivityScore = &score
+	}
+	return geneResult
+}
+
+// recommendationsFor returns the recommendations for a phenotype, for one
+// drug when drug is set
+func (a *Annotator) recommendationsFor(gene, phenotype, drug string) []Recommendation {
+	var recommendations []Recommendation
+	for _, rec := range a.knowledge.recommendations[gene] {
+		if !strings.EqualFold(rec.Phenotype, phenotype) {
+			continue
+		}
+		if drug != "" && !strings.EqualFold(rec.Drug, strings.TrimSpace(drug)) {
+			continue
+		}
+		recommendations = append(recommendations, rec)
+	}
+	return recommendations
+}
+
+// canonicalGene matches a requested gene to a loaded gene in any case
+func (a *Annotator) canonicalGene(gene string) (string, bool) {
+	for name := range a.knowledge.alleles {
+		if strings.EqualFold(name, strings.TrimSpace(gene)) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// covers reports whether every defining variant of an allele is still unassigned
+func covers(allele Allele, remaining map[string]int) bool {
+	for _, variant := range allele.Variants {
+		if remaining[variantKey(variant)] < 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// diplotype formats two alleles as GENE*1/*2, lower star number first
+func diplotype(gene, first, second string) string {
+	first, second = strings.TrimPrefix(first, gene), strings.TrimPrefix(second, gene)
+	if starLess(second, first) {
+		first, second = second, first
+	}
+	return gene + first + "/" + second
+}
+
+// starLess orders star alleles numerically, e.g. *2 before *17 and *3A before *3B
+func starLess(a, b string) bool {
+	var na, nb int
+	var sa, sb string
+	fmt.Sscanf(strings.TrimPrefix(a, "*"), "%d%s", &na, &sa)
+	fmt.Sscanf(strings.TrimPrefix(b, "*"), "%d%s", &nb, &sb)
+	if na != nb {
+		return na < nb
+	}
+	return sa < sb
+}
+
+// parseZygosity returns the copies of a variant for a zygosity
+func parseZygosity(s string) (int, string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "heterozygous", "het", "0/1":
+		return 1, Heterozygous, nil
+	case "homozygous", "hom", "1/1":
+		return 2, Homozygous, nil
+	}
+	return 0, "", fmt.Errorf("%w: unknown zygosity %q (use heterozygous or homozygous)", ErrInvalidRequest, s)
+}
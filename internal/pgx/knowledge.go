@@ -0,0 +1,240 @@
+// Package pgx annotates variants with pharmacogenomic star alleles and the
+// CPIC guideline recommendations for the resulting phenotype. Star allele
+// definitions (PharmVar), the diplotype to phenotype translation and the
+// dosing recommendations (CPIC) are loaded from local tables.
+package pgx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Allele is a PharmVar star allele and the variants that define it. The
+// reference allele of a gene, usually *1, has no defining variants.
+type Allele struct {
+	Gene          string   `json:"gene"`
+	Name          string   `json:"name"`     // e.g. CYP2C19*2
+	Function      string   `json:"function"` // CPIC clinical function, e.g. No function
+	ActivityValue *float64 `json:"activity_value,omitempty"`
+	Variants      []string `json:"defining_variants,omitempty"` // Genomic HGVS
+}
+
+// Recommendation is a CPIC dosing recommendation for a drug and phenotype
+type Recommendation struct {
+	Gene           string `json:"gene"`
+	Drug           string `json:"drug"`
+	Phenotype      string `json:"phenotype"`
+	Recommendation string `json:"recommendation"`
+	Strength       string `json:"strength,omitempty"`  // CPIC classification of the recommendation, e.g. Strong
+	Guideline      string `json:"guideline,omitempty"` // Guideline citation, e.g. a PMID
+}
+
+// Knowledge holds star allele definitions, phenotype translations and CPIC
+// recommendations by gene. Knowledge is read-only after loading and safe
+// for concurrent use.
+type Knowledge struct {
+	alleles         map[string][]Allele
+	phenotypes      map[string]map[string]string // Gene -> function pair -> phenotype
+	recommendations map[string][]Recommendation
+	variantGenes    map[string]string // Defining variant key -> gene
+	count           int
+}
+
+// NewKnowledge creates an empty knowledge base
+func NewKnowledge() *Knowledge {
+	return &Knowledge{
+		alleles:         make(map[string][]Allele),
+		phenotypes:      make(map[string]map[string]string),
+		recommendations: make(map[string][]Recommendation),
+		variantGenes:    make(map[string]string),
+	}
+}
+
+// LoadDir loads tab-separated tables from a directory: star alleles from
+// files with "alleles" in their name (gene, allele, function, activity
+// value, defining variants separated by ";"), phenotypes from files with
+// "phenotype" in their name (gene, allele 1 function, allele 2 function,
+// phenotype) and recommendations from files with "recommendation" in their
+// name (gene, drug, phenotype, recommendation, strength, guideline). A
+// missing directory yields an empty knowledge base.
+func LoadDir(dir string) (*Knowledge, error) {
+	knowledge := NewKnowledge()
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return knowledge, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pharmacogenomics directory: %w", err)
+	}
+
+	// Alleles first: phenotypes and recommendations refer to their genes
+	sort.SliceStable(entries, func(i, j int) bool {
+		return strings.Contains(strings.ToLower(entries[i].Name()), "alleles") &&
+			!strings.Contains(strings.ToLower(entries[j].Name()), "alleles")
+	})
+	for _, entry := range entries {
+		name := strings.ToLower(entry.Name())
+		if entry.IsDir() || !strings.HasSuffix(name, ".tsv") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case strings.Contains(name, "alleles"):
+			err = readTable(path, 4, knowledge.addAllele)
+		case strings.Contains(name, "phenotype"):
+			err = readTable(path, 4, knowledge.addPhenotype)
+		case strings.Contains(name, "recommendation"):
+			err = readTable(path, 4, knowledge.addRecommendation)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for gene := range knowledge.alleles {
+		// Most specific alleles first, as diplotypes are called greedily
+		sort.SliceStable(knowledge.alleles[gene], func(i, j int) bool {
+			return len(knowledge.alleles[gene][i].Variants) > len(knowledge.alleles[gene][j].Variants)
+		})
+	}
+	return knowledge, nil
+}
+
+// Count returns the number of loaded alleles, phenotype translations and
+// recommendations
+func (k *Knowledge) Count() int {
+	if k == nil {
+		return 0
+	}
+	return k.count
+}
+
+// Genes returns the genes with star allele definitions, sorted
+func (k *Knowledge) Genes() []string {
+	genes := make([]string, 0, len(k.alleles))
+	for gene := range k.alleles {
+		genes = append(genes, gene)
+	}
+	sort.Strings(genes)
+	return genes
+}
+
+func (k *Knowledge) addAllele(fields []string) error {
+	allele := Allele{Gene: fields[0], Name: fields[1], Function: fields[2]}
+	if fields[3] != "" && fields[3] != "n/a" {
+		value, err := strconv.ParseFloat(fields[3], 64)
+		if err != nil || value < 0 {
+			return fmt.Errorf("invalid activity value %q", fields[3])
+		}
+		allele.ActivityValue = &value
+	}
+	if len(fields) > 4 {
+		for _, variant := range strings.Split(fields[4], ";") {
+			if variant = strings.TrimSpace(variant); variant != "" {
+				allele.Variants = append(allele.Variants, variant)
+			}
+		}
+	}
+	if allele.Gene == "" || allele.Name == "" || allele.Function == "" {
+		return fmt.Errorf("gene, allele and function are required")
+	}
+
+	for _, variant := range allele.Variants {
+		key := variantKey(variant)
+		if gene, ok := k.variantGenes[key]; ok && gene != allele.Gene {
+			return fmt.Errorf("variant %s defines alleles of both %s and %s", variant, gene, allele.Gene)
+		}
+		k.variantGenes[key] = allele.Gene
+	}
+	k.alleles[allele.Gene] = append(k.alleles[allele.Gene], allele)
+	k.count++
+	return nil
+}
+
+func (k *Knowledge) addPhenotype(fields []string) error {
+	gene := fields[0]
+	if _, ok := k.alleles[gene]; !ok {
+		return fmt.Errorf("no alleles defined for %s", gene)
+	}
+	if k.phenotypes[gene] == nil {
+		k.phenotypes[gene] = make(map[string]string)
+	}
+	k.phenotypes[gene][functionPair(fields[1], fields[2])] = fields[3]
+	k.count++
+	return nil
+}
+
+func (k *Knowledge) addRecommendation(fields []string) error {
+	rec := Recommendation{Gene: fields[0], Drug: fields[1], Phenotype: fields[2], Recommendation: fields[3]}
+	if len(fields) > 4 {
+		rec.Strength = fields[4]
+	}
+	if len(fields) > 5 {
+		rec.Guideline = fields[5]
+	}
+	if _, ok := k.alleles[rec.Gene]; !ok {
+		return fmt.Errorf("no alleles defined for %s", rec.Gene)
+	}
+	if rec.Drug == "" || rec.Phenotype == "" || rec.Recommendation == "" {
+		return fmt.Errorf("drug, phenotype and recommendation are required")
+	}
+	k.recommendations[rec.Gene] = append(k.recommendations[rec.Gene], rec)
+	k.count++
+	return nil
+}
+
+// readTable reads a tab-separated table, skipping blank lines and "#"
+// comments, and passes each row with at least minFields fields to add
+func readTable(path string, minFields int, add func([]string) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open pharmacogenomics table: %w", err)
+	}
+	defer file.Close()
+
+	base := filepath.Base(path)
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if len(fields) < minFields {
+			return fmt.Errorf("%s line %d: expected at least %d tab-separated fields", base, line, minFields)
+		}
+		if err := add(fields); err != nil {
+			return fmt.Errorf("%s line %d: %w", base, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", base, err)
+	}
+	return nil
+}
+
+// functionPair is the unordered key of two allele functions
+func functionPair(a, b string) string {
+	a, b = strings.ToLower(strings.TrimSpace(a)), strings.ToLower(strings.TrimSpace(b))
+	if a > b {
+		a, b = b, a
+	}
+	return a + "/" + b
+}
+
+// variantKey normalizes a defining variant for matching
+func variantKey(hgvs string) string {
+	return strings.ToUpper(strings.TrimSpace(hgvs))
+}
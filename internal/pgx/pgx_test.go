@@ -0,0 +1,129 @@
+package pgx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exampleAnnotator(t *testing.T) *Annotator {
+	t.Helper()
+	knowledge, err := LoadDir(filepath.Join("..", "..", "examples", "pgx"))
+	require.NoError(t, err)
+	return NewAnnotator(knowledge)
+}
+
+func TestLoadDir(t *testing.T) {
+	knowledge, err := LoadDir(filepath.Join("..", "..", "examples", "pgx"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CYP2C19", "TPMT"}, knowledge.Genes())
+	assert.Equal(t, 29, knowledge.Count())
+
+	empty, err := LoadDir(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Zero(t, empty.Count())
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "alleles.tsv"), []byte("GENE1\tGENE1*1\tNormal function\t1\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "phenotypes.tsv"), []byte("GENE2\tNormal function\tNormal function\tNormal Metabolizer\n"), 0644))
+	_, err = LoadDir(dir)
+	assert.ErrorContains(t, err, "phenotypes.tsv line 1: no alleles defined for GENE2")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "phenotypes.tsv"), nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "alleles.tsv"), []byte("GENE1\tGENE1*2\tNo function\tlow\tNC_000001.11:g.100A>G\n"), 0644))
+	_, err = LoadDir(dir)
+	assert.ErrorContains(t, err, "invalid activity value")
+}
+
+func TestAnnotate(t *testing.T) {
+	annotator := exampleAnnotator(t)
+
+	tests := []struct {
+		name         string
+		observations []Observation
+		diplotype    string
+		phenotype    string
+	}{
+		{"heterozygous no function", []Observation{{HGVS: "NC_000010.11:g.94781859G>A"}}, "CYP2C19*1/*2", "Intermediate Metabolizer"},
+		{"homozygous no function", []Observation{{HGVS: "nc_000010.11:g.94781859g>a", Zygosity: "hom"}}, "CYP2C19*2/*2", "Poor Metabolizer"},
+		{"no and increased function", []Observation{
+			{HGVS: "NC_000010.11:g.94761900C>T"}, {HGVS: "NC_000010.11:g.94780653G>A"}}, "CYP2C19*3/*17", "Intermediate Metabolizer"},
+		{"multi-variant allele preferred", []Observation{
+			{HGVS: "NC_000006.12:g.18138997C>T"}, {HGVS: "NC_000006.12:g.18130687T>C"}}, "TPMT*1/*3A", "Intermediate Metabolizer"},
+		{"multi-variant allele and its component", []Observation{
+			{HGVS: "NC_000006.12:g.18138997C>T", Zygosity: "homozygous"}, {HGVS: "NC_000006.12:g.18130687T>C"}}, "TPMT*3A/*3B", "Poor Metabolizer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := annotator.Annotate(tt.observations, nil, "")
+			require.NoError(t, err)
+			require.Len(t, result.Genes, 1)
+			assert.Equal(t, tt.diplotype, result.Genes[0].Diplotype)
+			assert.Equal(t, tt.phenotype, result.Genes[0].Phenotype)
+			assert.NotEmpty(t, result.Genes[0].Recommendations)
+			assert.Empty(t, result.Unmatched)
+		})
+	}
+}
+
+func TestAnnotate_GenesAndDrug(t *testing.T) {
+	annotator := exampleAnnotator(t)
+
+	result, err := annotator.Annotate([]Observation{
+		{HGVS: "NC_000010.11:g.94781859G>A", Zygosity: "homozygous"},
+		{HGVS: "NC_000017.11:g.43094464A>G"},
+	}, []string{"tpmt"}, "Clopidogrel")
+	require.NoError(t, err)
+
+	require.Len(t, result.Genes, 2)
+	assert.Equal(t, []string{"NC_000017.11:g.43094464A>G"}, result.Unmatched)
+
+	cyp2c19 := result.Genes[0]
+	require.Len(t, cyp2c19.Recommendations, 1)
+	assert.Equal(t, "clopidogrel", cyp2c19.Recommendations[0].Drug)
+	assert.Contains(t, cyp2c19.Recommendations[0].Recommendation, "Avoid clopidogrel")
+	assert.Equal(t, []ObservedVariant{{HGVS: "NC_000010.11:g.94781859G>A", Zygosity: Homozygous, Alleles: []string{"CYP2C19*2"}}}, cyp2c19.Variants)
+
+	tpmt := result.Genes[1]
+	assert.Equal(t, "TPMT*1/*1", tpmt.Diplotype)
+	assert.Equal(t, "Normal Metabolizer", tpmt.Phenotype)
+	assert.Empty(t, tpmt.Recommendations)
+	assert.Len(t, tpmt.Notes, 2, "untested positions and missing drug recommendation are noted")
+}
+
+func TestAnnotate_Unassigned(t *testing.T) {
+	annotator := exampleAnnotator(t)
+
+	// Three no function alleles cannot all be on two chromosomes
+	result, err := annotator.Annotate([]Observation{
+		{HGVS: "NC_000010.11:g.94781859G>A"},
+		{HGVS: "NC_000010.11:g.94780653G>A", Zygosity: "homozygous"},
+	}, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, "CYP2C19*2/*3", result.Genes[0].Diplotype)
+	assert.Contains(t, result.Genes[0].Notes[0], "not assigned to a called allele: NC_000010.11:g.94780653G>A")
+}
+
+func TestAnnotate_Invalid(t *testing.T) {
+	annotator := exampleAnnotator(t)
+
+	for _, call := range []func() error{
+		func() error { _, err := annotator.Annotate([]Observation{{HGVS: " "}}, nil, ""); return err },
+		func() error {
+			_, err := annotator.Annotate([]Observation{{HGVS: "NC_000010.11:g.94781859G>A", Zygosity: "mosaic"}}, nil, "")
+			return err
+		},
+		func() error {
+			_, err := annotator.Annotate([]Observation{{HGVS: "NC_000010.11:g.94781859G>A"}, {HGVS: "NC_000010.11:g.94781859G>A"}}, nil, "")
+			return err
+		},
+		func() error { _, err := annotator.Annotate(nil, []string{"CYP2D6"}, ""); return err },
+	} {
+		assert.True(t, errors.Is(call(), ErrInvalidRequest))
+	}
+}
@@ -0,0 +1,381 @@
+package phenotype
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// MatchMethod describes how Match scores a patient against a gene
+const MatchMethod = "Resnik best match per patient term, weighted by information content"
+
+// Matcher scores patient HPO terms against gene phenotype associations.
+// Matcher is read-only after loading and safe for concurrent use.
+type Matcher struct {
+	ontology *Ontology
+	genes    map[string]*geneAnnotation // Upper-case gene symbol -> annotations
+	ic       map[string]float64         // Term -> information content
+	maxIC    float64                    // Information content of a term annotated to one gene
+	count    int
+}
+
+// geneAnnotation holds the phenotypes associated with a gene
+type geneAnnotation struct {
+	symbol    string
+	terms     []string
+	ancestors map[string]bool // Annotated terms and all their ancestors
+}
+
+// NewMatcher creates a matcher without ontology or annotations; every gene
+// is unannotated
+func NewMatcher() *Matcher {
+	return &Matcher{genes: make(map[string]*geneAnnotation), ic: make(map[string]float64)}
+}
+
+// LoadDir loads hp.obo and the gene to phenotype associations, a file with
+// "genes_to_phenotype" in its name, from a directory. A missing directory
+// yields an empty matcher.
+func LoadDir(dir string) (*Matcher, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewMatcher(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HPO directory: %w", err)
+	}
+
+	var annotations []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.Contains(strings.ToLower(entry.Name()), "genes_to_phenotype") {
+			annotations = append(annotations, filepath.Join(dir, entry.Name()))
+		}
+	}
+	ontologyPath := filepath.Join(dir, "hp.obo")
+	if _, err := os.Stat(ontologyPath); errors.Is(err, os.ErrNotExist) {
+		if len(annotations) > 0 {
+			return nil, fmt.Errorf("HPO directory %s has gene annotations but no hp.obo", dir)
+		}
+		return NewMatcher(), nil
+	}
+
+	ontology, err := loadOntology(ontologyPath)
+	if err != nil {
+		return nil, err
+	}
+	matcher := NewMatcher()
+	matcher.ontology = ontology
+	for _, path := range annotations {
+		if err := matcher.loadAnnotations(path); err != nil {
+			return nil, err
+		}
+	}
+	matcher.computeInformationContent()
+	return matcher, nil
+}
+
+// loadAnnotations reads a genes_to_phenotype file. The current release names
+// its columns in a header row (gene_symbol, hpo_id); older releases describe
+// them in a "#Format:" comment (entrez-gene-symbol, HPO-Term-ID).
+func (m *Matcher) loadAnnotations(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open HPO annotations: %w", err)
+	}
+	defer file.Close()
+
+	name := filepath.Base(path)
+	scanner := bufio.NewScanner(file)
+	symbolColumn, termColumn := -1, -1
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if symbolColumn < 0 {
+			header := strings.TrimPrefix(strings.TrimPrefix(text, "#Format:"), "#")
+			columns := strings.Split(strings.ReplaceAll(header, "<tab>", "\t"), "\t")
+			for i, column := range columns {
+				switch strings.ToLower(strings.TrimSpace(column)) {
+				case "gene_symbol", "entrez-gene-symbol":
+					symbolColumn = i
+				case "hpo_id", "hpo-term-id", "hpo-id":
+					termColumn = i
+				}
+			}
+			if symbolColumn >= 0 && termColumn >= 0 {
+				continue
+			}
+			if strings.HasPrefix(text, "#") {
+				symbolColumn = -1
+				continue
+			}
+			return fmt.Errorf("%s: header is missing the gene_symbol or hpo_id column", name)
+		}
+		if strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if symbolColumn >= len(fields) || termColumn >= len(fields) {
+			return fmt.Errorf("%s line %d: expected at least %d tab-separated fields", name, line, max(symbolColumn, termColumn)+1)
+		}
+		symbol := strings.TrimSpace(fields[symbolColumn])
+		id, ok := m.ontology.Resolve(strings.TrimSpace(fields[termColumn]))
+		if symbol == "" || !ok {
+			continue
+		}
+		m.annotate(symbol, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read HPO annotations %s: %w", name, err)
+	}
+	return nil
+}
+
+// annotate associates a term with a gene
+func (m *Matcher) annotate(symbol, id string) {
+	key := strings.ToUpper(symbol)
+	gene, ok := m.genes[key]
+	if !ok {
+		gene = &geneAnnotation{symbol: symbol, ancestors: make(map[string]bool)}
+		m.genes[key] = gene
+	}
+	if gene.ancestors[id] && containsTerm(gene.terms, id) {
+		return
+	}
+	gene.terms = append(gene.terms, id)
+	for ancestor := range m.ontology.ancestors(id) {
+		gene.ancestors[ancestor] = true
+	}
+	m.count++
+}
+
+// computeInformationContent sets the information content of each term to
+// -ln of the fraction of annotated genes it or a descendant is annotated to
+func (m *Matcher) computeInformationContent() {
+	total := float64(len(m.genes))
+	if total == 0 {
+		return
+	}
+	counts := make(map[string]int)
+	for _, gene := range m.genes {
+		for ancestor := range gene.ancestors {
+			counts[ancestor]++
+		}
+	}
+	for id, n := range counts {
+		m.ic[id] = -math.Log(float64(n) / total)
+	}
+	m.maxIC = math.Log(total)
+}
+
+// informationContent returns the information content of a term; a term no
+// gene is annotated to is as informative as one annotated to a single gene
+func (m *Matcher) informationContent(id string) float64 {
+	if ic, ok := m.ic[id]; ok {
+		return ic
+	}
+	return m.maxIC
+}
+
+// Count returns the number of loaded gene to phenotype associations
+func (m *Matcher) Count() int {
+	if m == nil {
+		return 0
+	}
+	return m.count
+}
+
+// Match scores patient HPO terms against the phenotypes associated with a
+// gene. Each patient term is matched to the gene term sharing its most
+// informative ancestor; the score is the information those ancestors carry
+// as a fraction of the information in the patient terms, from 0 (nothing
+// more specific than the root in common) to 1 (every term annotated to the
+// gene or a descendant of one). Match returns nil when the gene has no
+// associations or no patient term is informative.
+func (m *Matcher) Match(gene string, terms []string) *domain.PhenotypeMatch {
+	annotation, ok := m.genes[strings.ToUpper(strings.TrimSpace(gene))]
+	if !ok || m.ontology == nil {
+		return nil
+	}
+
+	match := &domain.PhenotypeMatch{Gene: annotation.symbol, Method: MatchMethod, Terms: []domain.PhenotypeTermMatch{}}
+	var shared, possible float64
+	seen := make(map[string]bool)
+	for _, raw := range terms {
+		normalized, err := NormalizeTerm(raw)
+		if err != nil {
+			match.UnknownTerms = append(match.UnknownTerms, raw)
+			continue
+		}
+		id, ok := m.ontology.Resolve(normalized)
+		if !ok {
+			match.UnknownTerms = append(match.UnknownTerms, normalized)
+			continue
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		ic := m.informationContent(id)
+		if ic == 0 {
+			continue // Annotated to every gene: carries no information
+		}
+		common := m.mostInformativeCommonAncestor(id, annotation)
+		commonIC := 0.0
+		if common != "" {
+			commonIC = m.informationContent(common)
+		}
+		shared += commonIC
+		possible += ic
+
+		termMatch := domain.PhenotypeTermMatch{
+			PatientTerm:        id,
+			PatientLabel:       m.ontology.Name(id),
+			InformationContent: ic,
+			Similarity:         commonIC / ic,
+		}
+		if commonIC > 0 {
+			termMatch.CommonAncestor = common
+			termMatch.CommonAncestorLabel = m.ontology.Name(common)
+			termMatch.GeneTerm = m.geneTermUnder(common, id, annotation)
+			termMatch.GeneLabel = m.ontology.Name(termMatch.GeneTerm)
+		}
+		match.Terms = append(match.Terms, termMatch)
+	}
+	if possible == 0 {
+		return nil
+	}
+	match.Score = shared / possible
+
+	// Best matches first
+	sort.SliceStable(match.Terms, func(i, j int) bool {
+		return match.Terms[i].Similarity > match.Terms[j].Similarity
+	})
+	return match
+}
+
+// SimilarityMethod describes how Similarity scores two patients
+const SimilarityMethod = "Resnik best match per term, weighted by information content, averaged over both patients"
+
+// Similarity scores how alike two patients' phenotypes are, from 0 to 1.
+// Each patient's terms are matched against the other's as in Match and the
+// two scores averaged, so the result is symmetric. Without the ontology and
+// gene annotations only identical terms match, scored by their overlap.
+// Unknown terms are ignored; a patient with no known term scores 0.
+func (m *Matcher) Similarity(a, b []string) float64 {
+	left, right := m.resolveTerms(a), m.resolveTerms(b)
+	if len(left) == 0 || len(right) == 0 {
+		return 0
+	}
+	if m.ontology == nil || m.maxIC == 0 {
+		shared := 0
+		for _, id := range left {
+			if containsTerm(right, id) {
+				shared++
+			}
+		}
+		return float64(shared) / float64(len(left)+len(right)-shared)
+	}
+	return (m.termSimilarity(left, right) + m.termSimilarity(right, left)) / 2
+}
+
+// termSimilarity scores how well the terms of one patient are explained by
+// another's: the information in the most informative ancestors they share,
+// as a fraction of the information in the first patient's terms
+func (m *Matcher) termSimilarity(from, to []string) float64 {
+	covered := make(map[string]bool)
+	for _, id := range to {
+		for ancestor := range m.ontology.ancestors(id) {
+			covered[ancestor] = true
+		}
+	}
+	var shared, possible float64
+	for _, id := range from {
+		best := 0.0
+		for ancestor := range m.ontology.ancestors(id) {
+			if ic := m.informationContent(ancestor); covered[ancestor] && ic > best {
+				best = ic
+			}
+		}
+		shared += best
+		possible += m.informationContent(id)
+	}
+	if possible == 0 {
+		return 0
+	}
+	return shared / possible
+}
+
+// resolveTerms normalizes terms to their current IDs, dropping unknown and
+// duplicate terms
+func (m *Matcher) resolveTerms(terms []string) []string {
+	var resolved []string
+	for _, raw := range terms {
+		id, err := NormalizeTerm(raw)
+		if err != nil {
+			continue
+		}
+		if m.ontology != nil {
+			if id, ok := m.ontology.Resolve(id); ok && !containsTerm(resolved, id) {
+				resolved = append(resolved, id)
+			}
+			continue
+		}
+		if !containsTerm(resolved, id) {
+			resolved = append(resolved, id)
+		}
+	}
+	return resolved
+}
+
+// mostInformativeCommonAncestor returns the most informative ancestor a
+// patient term shares with any of the gene's terms
+func (m *Matcher) mostInformativeCommonAncestor(id string, gene *geneAnnotation) string {
+	best, bestIC, bestDepth := "", -1.0, 0
+	for ancestor := range m.ontology.ancestors(id) {
+		if !gene.ancestors[ancestor] {
+			continue
+		}
+		// Ties go to the more specific term, then the lower ID, so the
+		// result does not depend on map order
+		ic, depth := m.informationContent(ancestor), len(m.ontology.ancestors(ancestor))
+		if ic > bestIC || (ic == bestIC && (depth > bestDepth || (depth == bestDepth && ancestor < best))) {
+			best, bestIC, bestDepth = ancestor, ic, depth
+		}
+	}
+	return best
+}
+
+// geneTermUnder returns the gene term the common ancestor was reached from,
+// preferring the patient term itself and then the first annotated
+func (m *Matcher) geneTermUnder(common, patientTerm string, gene *geneAnnotation) string {
+	if containsTerm(gene.terms, patientTerm) {
+		return patientTerm
+	}
+	for _, id := range gene.terms {
+		if m.ontology.ancestors(id)[common] {
+			return id
+		}
+	}
+	return ""
+}
+
+func containsTerm(terms []string, id string) bool {
+	for _, t := range terms {
+		if t == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+// Package phenotype matches a patient's Human Phenotype Ontology terms to the
+// phenotypes associated with a gene, for PP4. The ontology (hp.obo) and the
+// gene to phenotype associations derived from the HPO annotations
+// (genes_to_phenotype.txt) are loaded from local files. Terms are compared by
+// the information content of their most informative common ancestor
+// (Resnik), with information content computed from the gene annotations.
+package phenotype
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidTerm is returned for a term that is not an HPO term ID
+var ErrInvalidTerm = errors.New("invalid HPO term")
+
+// RootTerm is the root of the ontology, "All"
+const RootTerm = "HP:0000001"
+
+// termPattern is the form of an HPO term ID
+var termPattern = regexp.MustCompile(`^HP:\d{7}$`)
+
+// NormalizeTerm returns an HPO term ID in canonical form, e.g. "hp:0001250"
+// becomes "HP:0001250"
+func NormalizeTerm(term string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(term))
+	if strings.HasPrefix(normalized, "HP_") {
+		normalized = "HP:" + strings.TrimPrefix(normalized, "HP_")
+	}
+	if !termPattern.MatchString(normalized) {
+		return "", fmt.Errorf("%w %q (expected HP:0000000)", ErrInvalidTerm, term)
+	}
+	return normalized, nil
+}
+
+// term is an ontology term and its is_a parents
+type term struct {
+	name    string
+	parents []string
+}
+
+// Ontology holds the is_a hierarchy of the HPO. Obsolete terms and alternate
+// IDs resolve to the term that replaces them.
+type Ontology struct {
+	terms    map[string]*term
+	aliases  map[string]string // Alternate or obsolete ID -> current ID
+	ancestor map[string]map[string]bool
+}
+
+// readOntology parses the [Term] stanzas of an OBO file
+func readOntology(reader io.Reader) (*Ontology, error) {
+	ontology := &Ontology{
+		terms:    make(map[string]*term),
+		aliases:  make(map[string]string),
+		ancestor: make(map[string]map[string]bool),
+	}
+
+	var id string
+	var current *term
+	var obsolete bool
+	var replacedBy string
+	var altIDs []string
+	flush := func() {
+		if id == "" {
+			return
+		}
+		if obsolete {
+			if replacedBy != "" {
+				ontology.aliases[id] = replacedBy
+			}
+		} else {
+			ontology.terms[id] = current
+			for _, alt := range altIDs {
+				ontology.aliases[alt] = id
+			}
+		}
+		id, current, obsolete, replacedBy, altIDs = "", nil, false, "", nil
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	inTerm := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			flush()
+			inTerm = line == "[Term]"
+			continue
+		}
+		if !inTerm {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		// Drop trailing comments such as "HP:0000118 ! Phenotypic abnormality"
+		if i := strings.Index(value, " !"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		switch key {
+		case "id":
+			id, current = value, &term{}
+		case "name":
+			if current != nil {
+				current.name = value
+			}
+		case "is_a":
+			if current != nil {
+				current.parents = append(current.parents, value)
+			}
+		case "alt_id":
+			altIDs = append(altIDs, value)
+		case "is_obsolete":
+			obsolete = value == "true"
+		case "replaced_by":
+			replacedBy = value
+		}
+	}
+	flush()
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if _, ok := ontology.terms[RootTerm]; !ok {
+		return nil, fmt.Errorf("ontology has no root term %s", RootTerm)
+	}
+	return ontology, nil
+}
+
+// Resolve returns the current ID of a term, following alternate IDs and
+// replacements of obsolete terms
+func (o *Ontology) Resolve(id string) (string, bool) {
+	if _, ok := o.terms[id]; ok {
+		return id, true
+	}
+	if current, ok := o.aliases[id]; ok {
+		if _, ok := o.terms[current]; ok {
+			return current, true
+		}
+	}
+	return "", false
+}
+
+// Name returns the label of a term
+func (o *Ontology) Name(id string) string {
+	if t, ok := o.terms[id]; ok {
+		return t.name
+	}
+	return ""
+}
+
+// Len returns the number of current terms
+func (o *Ontology) Len() int {
+	return len(o.terms)
+}
+
+// ancestors returns a term and all its ancestors. Ancestor sets are computed
+// once at load time, so lookups are safe for concurrent use.
+func (o *Ontology) ancestors(id string) map[string]bool {
+	return o.ancestor[id]
+}
+
+// computeAncestors fills the ancestor set of every term
+func (o *Ontology) computeAncestors() {
+	var visit func(id string) map[string]bool
+	visit = func(id string) map[string]bool {
+		if set, ok := o.ancestor[id]; ok {
+			return set
+		}
+		set := map[string]bool{id: true}
+		o.ancestor[id] = set // Guards against cycles in a malformed file
+		if t, ok := o.terms[id]; ok {
+			for _, parent := range t.parents {
+				for ancestor := range visit(parent) {
+					set[ancestor] = true
+				}
+			}
+		}
+		return set
+	}
+	for id := range o.terms {
+		visit(id)
+	}
+}
+
+// loadOntology reads an OBO file
+func loadOntology(path string) (*Ontology, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open HPO ontology: %w", err)
+	}
+	defer file.Close()
+	ontology, err := readOntology(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HPO ontology %s: %w", path, err)
+	}
+	ontology.computeAncestors()
+	return ontology, nil
+}
@@ -0,0 +1,216 @@
+package phenotype
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOntology = `format-version: 1.2
+ontology: hp
+
+[Term]
+id: HP:0000001
+name: All
+
+[Term]
+id: HP:0000118
+name: Phenotypic abnormality
+is_a: HP:0000001 ! All
+
+[Term]
+id: HP:0000707
+name: Abnormality of the nervous system
+is_a: HP:0000118 ! Phenotypic abnormality
+
+[Term]
+id: HP:0001250
+name: Seizure
+alt_id: HP:0002279
+is_a: HP:0000707 ! Abnormality of the nervous system
+
+[Term]
+id: HP:0007359
+name: Focal-onset seizure
+is_a: HP:0001250 ! Seizure
+
+[Term]
+id: HP:0002266
+name: Focal clonic seizure
+is_a: HP:0007359 ! Focal-onset seizure
+
+[Term]
+id: HP:0001263
+name: Global developmental delay
+is_a: HP:0000707 ! Abnormality of the nervous system
+
+[Term]
+id: HP:0000478
+name: Abnormality of the eye
+is_a: HP:0000118 ! Phenotypic abnormality
+
+[Term]
+id: HP:0000505
+name: Visual impairment
+is_a: HP:0000478 ! Abnormality of the eye
+
+[Term]
+id: HP:0000003
+name: Obsolete term
+is_obsolete: true
+replaced_by: HP:0001263
+
+[Typedef]
+id: part_of
+name: part of
+`
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func loadTestMatcher(t *testing.T) *Matcher {
+	t.Helper()
+	dir := t.TempDir()
+	writeFile(t, dir, "hp.obo", testOntology)
+	writeFile(t, dir, "genes_to_phenotype.txt", "ncbi_gene_id\tgene_symbol\thpo_id\thpo_name\tfrequency\tdisease_id\n"+
+		"6323\tSCN1A\tHP:0002266\tFocal clonic seizure\t-\tOMIM:607208\n"+
+		"6323\tSCN1A\tHP:0000003\tObsolete term\t-\tOMIM:607208\n"+
+		"6323\tSCN1A\tHP:0002266\tFocal clonic seizure\t-\tORPHA:33069\n"+
+		"7249\tTSC2\tHP:0001250\tSeizure\t-\tOMIM:613254\n"+
+		"7249\tTSC2\tHP:9999999\tNot in the ontology\t-\tOMIM:613254\n")
+	// Releases before 2023 describe their columns in a comment
+	writeFile(t, dir, "legacy_genes_to_phenotype.txt", "#Format: entrez-gene-id<tab>entrez-gene-symbol<tab>HPO-Term-ID<tab>HPO-Term-Name\n"+
+		"6010\tRHO\tHP:0000505\tVisual impairment\n"+
+		"5080\tPAX6\tHP:0000505\tVisual impairment\n")
+
+	matcher, err := LoadDir(dir)
+	require.NoError(t, err)
+	return matcher
+}
+
+func TestNormalizeTerm(t *testing.T) {
+	for input, expected := range map[string]string{
+		"HP:0001250":   "HP:0001250",
+		" hp:0001250 ": "HP:0001250",
+		"HP_0001250":   "HP:0001250",
+	} {
+		normalized, err := NormalizeTerm(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, normalized)
+	}
+	for _, input := range []string{"", "HP:123", "Seizure", "MONDO:0000001"} {
+		_, err := NormalizeTerm(input)
+		assert.ErrorIs(t, err, ErrInvalidTerm, input)
+	}
+}
+
+func TestReadOntology(t *testing.T) {
+	ontology, err := readOntology(strings.NewReader(testOntology))
+	require.NoError(t, err)
+	ontology.computeAncestors()
+
+	assert.Equal(t, 9, ontology.Len(), "obsolete terms and typedefs are not counted")
+	assert.Equal(t, "Focal clonic seizure", ontology.Name("HP:0002266"))
+
+	id, ok := ontology.Resolve("HP:0002279")
+	assert.True(t, ok)
+	assert.Equal(t, "HP:0001250", id, "alternate IDs resolve to the current term")
+	id, ok = ontology.Resolve("HP:0000003")
+	assert.True(t, ok)
+	assert.Equal(t, "HP:0001263", id, "obsolete terms resolve to their replacement")
+	_, ok = ontology.Resolve("HP:9999999")
+	assert.False(t, ok)
+
+	ancestors := ontology.ancestors("HP:0002266")
+	for _, id := range []string{"HP:0002266", "HP:0007359", "HP:0001250", "HP:0000707", "HP:0000118", RootTerm} {
+		assert.True(t, ancestors[id], id)
+	}
+	assert.False(t, ancestors["HP:0001263"])
+
+	_, err = readOntology(strings.NewReader("[Term]\nid: HP:0000118\nname: Phenotypic abnormality\n"))
+	assert.Error(t, err, "an ontology needs its root")
+}
+
+func TestLoadDir(t *testing.T) {
+	matcher := loadTestMatcher(t)
+	assert.Equal(t, 5, matcher.Count(), "duplicate and unknown annotations are skipped")
+
+	// Four annotated genes: seizures in two, focal clonic seizures in one
+	assert.InDelta(t, 0, matcher.informationContent("HP:0000118"), 1e-9)
+	assert.InDelta(t, math.Log(2), matcher.informationContent("HP:0001250"), 1e-9)
+	assert.InDelta(t, math.Log(4), matcher.informationContent("HP:0002266"), 1e-9)
+
+	empty, err := LoadDir(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, empty.Count())
+	assert.Nil(t, empty.Match("SCN1A", []string{"HP:0001250"}))
+
+	dir := t.TempDir()
+	writeFile(t, dir, "genes_to_phenotype.txt", "gene_symbol\thpo_id\nSCN1A\tHP:0001250\n")
+	_, err = LoadDir(dir)
+	assert.Error(t, err, "annotations need the ontology")
+
+	writeFile(t, dir, "hp.obo", testOntology)
+	writeFile(t, dir, "genes_to_phenotype.txt", "gene\tterm\nSCN1A\tHP:0001250\n")
+	_, err = LoadDir(dir)
+	assert.Error(t, err, "annotations need the gene_symbol and hpo_id columns")
+}
+
+func TestMatch(t *testing.T) {
+	matcher := loadTestMatcher(t)
+
+	exact := matcher.Match("scn1a", []string{"HP:0002266", "HP:0000003"})
+	require.NotNil(t, exact)
+	assert.Equal(t, "SCN1A", exact.Gene)
+	assert.InDelta(t, 1, exact.Score, 1e-9)
+	require.Len(t, exact.Terms, 2)
+	assert.Equal(t, "HP:0002266", exact.Terms[0].GeneTerm)
+
+	// A focal clonic seizure shares only "Seizure" with TSC2
+	partial := matcher.Match("TSC2", []string{"HP:0002266"})
+	require.NotNil(t, partial)
+	assert.InDelta(t, 0.5, partial.Score, 1e-9)
+	require.Len(t, partial.Terms, 1)
+	term := partial.Terms[0]
+	assert.Equal(t, "HP:0001250", term.CommonAncestor)
+	assert.Equal(t, "Seizure", term.CommonAncestorLabel)
+	assert.Equal(t, "HP:0001250", term.GeneTerm)
+	assert.Equal(t, "Focal clonic seizure", term.PatientLabel)
+
+	// Nothing more specific than "Phenotypic abnormality" in common
+	unrelated := matcher.Match("SCN1A", []string{"HP:0000505", "HP:9999999", "seizure"})
+	require.NotNil(t, unrelated)
+	assert.InDelta(t, 0, unrelated.Score, 1e-9)
+	assert.Empty(t, unrelated.Terms[0].CommonAncestor)
+	assert.Equal(t, []string{"HP:9999999", "seizure"}, unrelated.UnknownTerms)
+
+	mixed := matcher.Match("SCN1A", []string{"HP:0002266", "HP:0000505"})
+	require.NotNil(t, mixed)
+	assert.InDelta(t, math.Log(4)/(math.Log(4)+math.Log(2)), mixed.Score, 1e-9)
+	assert.Equal(t, "HP:0002266", mixed.Terms[0].PatientTerm, "best matches first")
+
+	assert.Nil(t, matcher.Match("BRCA1", []string{"HP:0001250"}), "unannotated gene")
+	assert.Nil(t, matcher.Match("SCN1A", []string{"HP:0000118"}), "no informative term")
+}
+
+func TestSimilarity(t *testing.T) {
+	matcher := loadTestMatcher(t)
+
+	assert.InDelta(t, 1, matcher.Similarity([]string{"HP:0002266"}, []string{"hp_0002266"}), 1e-9)
+	// Seizure fully explains itself but half of a focal clonic seizure
+	assert.InDelta(t, 0.75, matcher.Similarity([]string{"HP:0002266"}, []string{"HP:0001250"}), 1e-9)
+	assert.InDelta(t, 0.75, matcher.Similarity([]string{"HP:0001250"}, []string{"HP:0002266"}), 1e-9, "symmetric")
+	assert.InDelta(t, 0, matcher.Similarity([]string{"HP:0002266"}, []string{"HP:0000505"}), 1e-9)
+	assert.Zero(t, matcher.Similarity([]string{"HP:9999999"}, []string{"HP:0002266"}), "no known term")
+
+	// Without an ontology only identical terms match
+	bare := NewMatcher()
+	assert.InDelta(t, 1.0/3.0, bare.Similarity([]string{"HP:0002266", "HP:0001250"}, []string{"HP:0002266", "HP:0000505"}), 1e-9)
+}
@@ -0,0 +1,28 @@
+package phi
+
+import "github.com/sirupsen/logrus"
+
+// Hook is a logrus hook that hides sensitive fields of every log entry
+// before it is formatted. Messages are not inspected; log sensitive values
+// as fields, never in the message.
+type Hook struct {
+	redactor *Redactor
+}
+
+// NewHook creates a hook that redacts with redactor.
+func NewHook(redactor *Redactor) *Hook {
+	return &Hook{redactor: redactor}
+}
+
+// Levels returns the levels the hook fires on: all of them.
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire hides the sensitive fields of an entry.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	if fields, changed := h.redactor.Fields(entry.Data); changed {
+		entry.Data = fields
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+// Package phi keeps protected health information out of logs and audit
+// exports. Request fields are classified by name as patient identifiers,
+// such as proband_id, or free text describing the patient, such as
+// clinical_context or a phenotype. With PHI-safe logging enabled, a Redactor
+// replaces their values wherever they appear in structured log fields and in
+// exported requests: free text is always redacted, and identifiers are
+// either redacted or tokenized with a keyed hash, so log lines about the same
+// patient can be correlated without revealing who it is.
+package phi
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Redacted replaces the value of a redacted field.
+const Redacted = "[REDACTED]"
+
+// tokenPrefix marks tokenized identifiers
+const tokenPrefix = "tok_"
+
+// Class is the kind of sensitive data a field holds.
+type Class int
+
+const (
+	// NotSensitive fields are logged and exported as they are.
+	NotSensitive Class = iota
+	// Identifier fields identify a patient, a sample or a family.
+	Identifier
+	// FreeText fields describe the patient in clinical terms.
+	FreeText
+)
+
+// Mode is how identifiers are hidden.
+type Mode string
+
+const (
+	// ModeRedact replaces identifiers with Redacted.
+	ModeRedact Mode = "redact"
+	// ModeTokenize replaces identifiers with a stable keyed token.
+	ModeTokenize Mode = "tokenize"
+)
+
+// ParseMode parses a redaction mode; empty selects ModeRedact.
+func ParseMode(s string) (Mode, error) {
+	switch mode := Mode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return ModeRedact, nil
+	case ModeRedact, ModeTokenize:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown PHI redaction mode %q: must be redact or tokenize", s)
+}
+
+// DefaultIdentifierFields are the request fields that identify a patient.
+var DefaultIdentifierFields = []string{
+	"patient_id", "proband_id", "family_id", "sample_id", "individual_id",
+	"mrn", "patient_name", "patient_label", "date_of_birth",
+}
+
+// DefaultFreeTextFields are the request fields that describe a patient.
+var DefaultFreeTextFields = []string{
+	"clinical_context", "clinical_indication", "testing_indication",
+	"phenotype", "patient_phenotype", "hpo_terms", "patient_context",
+	"family_history", "family_history_notes", "clinical_notes",
+}
+
+// Config configures a Redactor. Fields are added to the defaults.
+type Config struct {
+	Mode             Mode
+	TokenKey         string // Keys identifier tokens; a random key is used when empty
+	IdentifierFields []string
+	FreeTextFields   []string
+}
+
+// Redactor hides the values of sensitive fields.
+type Redactor struct {
+	mode   Mode
+	key    []byte
+	fields map[string]Class
+}
+
+// NewRedactor creates a redactor. Without a token key, tokens are stable
+// only until the process restarts.
+func NewRedactor(cfg Config) (*Redactor, error) {
+	mode, err := ParseMode(string(cfg.Mode))
+	if err != nil {
+		return nil, err
+	}
+	r := &Redactor{mode: mode, key: []byte(cfg.TokenKey), fields: make(map[string]Class)}
+	if len(r.key) == 0 {
+		r.key = make([]byte, 32)
+		if _, err := rand.Read(r.key); err != nil {
+			return nil, fmt.Errorf("failed to generate token key: %w", err)
+		}
+	}
+	for _, field := range append(DefaultIdentifierFields, cfg.IdentifierFields...) {
+		r.fields[normalizeField(field)] = Identifier
+	}
+	for _, field := range append(DefaultFreeTextFields, cfg.FreeTextFields...) {
+		r.fields[normalizeField(field)] = FreeText
+	}
+	delete(r.fields, "")
+	return r, nil
+}
+
+// normalizeField lets camelCase and kebab-case names match snake_case ones
+func normalizeField(field string) string {
+	field = strings.ToLower(strings.TrimSpace(field))
+	return strings.NewReplacer("_", "", "-", "").Replace(field)
+}
+
+// Mode returns how identifiers are hidden.
+func (r *Redactor) Mode() Mode {
+	return r.mode
+}
+
+// Classify returns the class of a field name.
+func (r *Redactor) Classify(field string) Class {
+	return r.fields[normalizeField(field)]
+}
+
+// Token returns the token an identifier is replaced with in tokenize mode.
+func (r *Redactor) Token(value string) string {
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(value))
+	return tokenPrefix + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// hide returns the replacement of a sensitive value
+func (r *Redactor) hide(class Class, value interface{}) interface{} {
+	if class == Identifier && r.mode == ModeTokenize {
+		switch v := value.(type) {
+		case nil:
+			return nil
+		case string:
+			if v == "" {
+				return v
+			}
+			return r.Token(v)
+		default:
+			return r.Token(fmt.Sprint(v))
+		}
+	}
+	return Redacted
+}
+
+// Value returns value with sensitive fields hidden. A field is hidden
+// whole, whatever its type; other values are searched for nested fields.
+func (r *Redactor) Value(field string, value interface{}) (interface{}, bool) {
+	if class := r.Classify(field); class != NotSensitive {
+		return r.hide(class, value), true
+	}
+	return r.nested(value)
+}
+
+// nested hides sensitive fields inside maps, slices, JSON documents and structs
+func (r *Redactor) nested(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case nil, string, bool, int, int64, float64, error:
+		return value, false
+	case map[string]interface{}:
+		return r.Fields(v)
+	case map[string]string:
+		out := make(map[string]interface{}, len(v))
+		changed := false
+		for key, item := range v {
+			redacted, hidden := r.Value(key, item)
+			out[key] = redacted
+			changed = changed || hidden
+		}
+		if !changed {
+			return value, false
+		}
+		return out, true
+	case []interface{}:
+		var out []interface{}
+		for i, item := range v {
+			redacted, hidden := r.nested(item)
+			if hidden && out == nil {
+				out = append([]interface{}{}, v...)
+			}
+			if out != nil {
+				out[i] = redacted
+			}
+		}
+		if out == nil {
+			return value, false
+		}
+		return out, true
+	case json.RawMessage:
+		redacted, changed, err := r.JSON(v)
+		if err != nil || !changed {
+			return value, false
+		}
+		return redacted, true
+	}
+
+	// Structs, pointers to them and other containers are searched through their JSON form
+	kind := reflect.Indirect(reflect.ValueOf(value)).Kind()
+	if kind != reflect.Struct && kind != reflect.Map && kind != reflect.Slice {
+		return value, false
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return value, false
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return value, false
+	}
+	redacted, changed := r.nested(doc)
+	if !changed {
+		return value, false
+	}
+	return redacted, true
+}
+
+// Fields returns a copy of fields with sensitive values hidden, or fields
+// itself when nothing is sensitive.
+func (r *Redactor) Fields(fields map[string]interface{}) (map[string]interface{}, bool) {
+	var out map[string]interface{}
+	for key, value := range fields {
+		redacted, hidden := r.Value(key, value)
+		if !hidden {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]interface{}, len(fields))
+			for k, v := range fields {
+				out[k] = v
+			}
+		}
+		out[key] = redacted
+	}
+	if out == nil {
+		return fields, false
+	}
+	return out, true
+}
+
+// JSON returns a JSON document with sensitive fields hidden, and whether any were.
+func (r *Redactor) JSON(raw json.RawMessage) (json.RawMessage, bool, error) {
+	if len(raw) == 0 {
+		return raw, false, nil
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, false, err
+	}
+	redacted, changed := r.nested(doc)
+	if !changed {
+		return raw, false, nil
+	}
+	out, err := json.Marshal(redacted)
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
@@ -0,0 +1,134 @@
+package phi
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestRedactor(t *testing.T, mode Mode) *Redactor {
+	t.Helper()
+	redactor, err := NewRedactor(Config{
+		Mode:             mode,
+		TokenKey:         "test-key",
+		IdentifierFields: []string{"lims_accession"},
+		FreeTextFields:   []string{"referral_notes"},
+	})
+	require.NoError(t, err)
+	return redactor
+}
+
+func TestRedactor_Classify(t *testing.T) {
+	redactor := createTestRedactor(t, ModeRedact)
+
+	assert.Equal(t, Identifier, redactor.Classify("proband_id"))
+	assert.Equal(t, Identifier, redactor.Classify("ProbandID"), "camelCase names match")
+	assert.Equal(t, Identifier, redactor.Classify("lims_accession"))
+	assert.Equal(t, FreeText, redactor.Classify("clinical_context"))
+	assert.Equal(t, FreeText, redactor.Classify("Referral-Notes"))
+	assert.Equal(t, NotSensitive, redactor.Classify("hgvs_notation"))
+}
+
+func TestRedactor_JSON(t *testing.T) {
+	request := json.RawMessage(`{
+		"hgvs_notation": "NM_000492.4:c.1521_1523del",
+		"proband_id": "P-0042",
+		"clinical_context": "Recurrent pancreatitis since age 3",
+		"patient_context": {"de_novo_status": "de_novo", "hpo_terms": ["HP:0001733"]},
+		"variants": [{"hgvs_notation": "NM_007294.4:c.5266dupC", "sample_id": "S-17"}]
+	}`)
+
+	t.Run("redact", func(t *testing.T) {
+		redactor := createTestRedactor(t, ModeRedact)
+
+		// Act
+		redacted, changed, err := redactor.JSON(request)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.JSONEq(t, `{
+			"hgvs_notation": "NM_000492.4:c.1521_1523del",
+			"proband_id": "[REDACTED]",
+			"clinical_context": "[REDACTED]",
+			"patient_context": "[REDACTED]",
+			"variants": [{"hgvs_notation": "NM_007294.4:c.5266dupC", "sample_id": "[REDACTED]"}]
+		}`, string(redacted))
+	})
+
+	t.Run("tokenize", func(t *testing.T) {
+		redactor := createTestRedactor(t, ModeTokenize)
+
+		redacted, changed, err := redactor.JSON(request)
+
+		require.NoError(t, err)
+		assert.True(t, changed)
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal(redacted, &doc))
+		assert.Equal(t, redactor.Token("P-0042"), doc["proband_id"], "identifiers become stable tokens")
+		assert.Regexp(t, `^tok_[0-9a-f]{16}$`, doc["proband_id"])
+		assert.Equal(t, Redacted, doc["clinical_context"], "free text is always redacted")
+
+		other, err := NewRedactor(Config{Mode: ModeTokenize, TokenKey: "other-key"})
+		require.NoError(t, err)
+		assert.NotEqual(t, redactor.Token("P-0042"), other.Token("P-0042"), "tokens depend on the key")
+	})
+
+	t.Run("nothing sensitive", func(t *testing.T) {
+		redactor := createTestRedactor(t, ModeRedact)
+		plain := json.RawMessage(`{"hgvs_notation": "NM_000492.4:c.1521_1523del"}`)
+
+		redacted, changed, err := redactor.JSON(plain)
+
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, plain, redacted)
+		_, _, err = redactor.JSON(json.RawMessage(`{`))
+		assert.Error(t, err)
+	})
+}
+
+func TestHook_RedactsLogFields(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(NewHook(createTestRedactor(t, ModeTokenize)))
+	hook := test.NewLocal(logger) // Records entries after redaction
+	type promptArgs struct {
+		Gene             string `json:"gene"`
+		PatientPhenotype string `json:"patient_phenotype"`
+	}
+
+	// Act
+	logger.WithFields(logrus.Fields{
+		"hgvs":       "NM_000492.4:c.1521_1523del",
+		"proband_id": "P-0042",
+		"args":       map[string]string{"variant": "NM_000492.4:c.1521_1523del", "family_history": "Mother affected"},
+		"params":     &promptArgs{Gene: "CFTR", PatientPhenotype: "Meconium ileus"},
+	}).Info("Classifying variant")
+
+	// Assert
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, "NM_000492.4:c.1521_1523del", entry.Data["hgvs"])
+	assert.Regexp(t, `^tok_`, entry.Data["proband_id"])
+	assert.Equal(t, map[string]interface{}{"variant": "NM_000492.4:c.1521_1523del", "family_history": Redacted}, entry.Data["args"])
+	assert.Equal(t, map[string]interface{}{"gene": "CFTR", "patient_phenotype": Redacted}, entry.Data["params"])
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("")
+	require.NoError(t, err)
+	assert.Equal(t, ModeRedact, mode)
+	mode, err = ParseMode(" Tokenize ")
+	require.NoError(t, err)
+	assert.Equal(t, ModeTokenize, mode)
+	_, err = ParseMode("hash")
+	assert.Error(t, err)
+	_, err = NewRedactor(Config{Mode: "hash"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,187 @@
+package playbook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+// Playbooks are stored as JSON documents keyed by gene symbol.
+type SQLiteStore struct {
+	db             *sql.DB
+	dbPath         string
+	seniorCurators map[string]bool
+}
+
+// NewSQLiteStore creates a new SQLite playbook store.
+// seniorCurators lists the curator IDs allowed to edit playbooks.
+func NewSQLiteStore(dbPath string, seniorCurators []string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	curators := make(map[string]bool, len(seniorCurators))
+	for _, c := range seniorCurators {
+		if c = strings.TrimSpace(c); c != "" {
+			curators[c] = true
+		}
+	}
+
+	return &SQLiteStore{
+		db:             db,
+		dbPath:         dbPath,
+		seniorCurators: curators,
+	}, nil
+}
+
+// createSchema creates the database tables.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS gene_playbooks (
+		gene_symbol TEXT PRIMARY KEY,
+		document TEXT NOT NULL,
+		version INTEGER NOT NULL DEFAULT 1,
+		updated_by TEXT NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+// IsSeniorCurator reports whether the curator may edit playbooks.
+func (s *SQLiteStore) IsSeniorCurator(curator string) bool {
+	return s.seniorCurators[strings.TrimSpace(curator)]
+}
+
+// Get retrieves the playbook for a gene. Returns nil if none exists.
+func (s *SQLiteStore) Get(ctx context.Context, geneSymbol string) (*Playbook, error) {
+	var document string
+	err := s.db.QueryRowContext(ctx,
+		"SELECT document FROM gene_playbooks WHERE gene_symbol = ?",
+		NormalizeGeneSymbol(geneSymbol),
+	).Scan(&document)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query playbook: %w", err)
+	}
+
+	var pb Playbook
+	if err := json.Unmarshal([]byte(document), &pb); err != nil {
+		return nil, fmt.Errorf("failed to decode playbook: %w", err)
+	}
+	return &pb, nil
+}
+
+// Save creates or replaces the playbook for a gene, incrementing its version.
+func (s *SQLiteStore) Save(ctx context.Context, playbook *Playbook, editor string) error {
+	if !s.IsSeniorCurator(editor) {
+		return ErrNotAuthorized
+	}
+
+	playbook.GeneSymbol = NormalizeGeneSymbol(playbook.GeneSymbol)
+	if playbook.GeneSymbol == "" {
+		return fmt.Errorf("gene symbol is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var version int
+	err = tx.QueryRowContext(ctx,
+		"SELECT version FROM gene_playbooks WHERE gene_symbol = ?", playbook.GeneSymbol,
+	).Scan(&version)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check existing: %w", err)
+	}
+
+	now := time.Now().UTC()
+	playbook.Version = version + 1
+	playbook.UpdatedBy = strings.TrimSpace(editor)
+	playbook.UpdatedAt = now
+	for i := range playbook.LabNotes {
+		if playbook.LabNotes[i].CreatedAt.IsZero() {
+			playbook.LabNotes[i].CreatedAt = now
+		}
+		if playbook.LabNotes[i].Author == "" {
+			playbook.LabNotes[i].Author = playbook.UpdatedBy
+		}
+	}
+
+	document, err := json.Marshal(playbook)
+	if err != nil {
+		return fmt.Errorf("failed to encode playbook: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO gene_playbooks (gene_symbol, document, version, updated_by, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(gene_symbol) DO UPDATE SET
+			document = excluded.document,
+			version = excluded.version,
+			updated_by = excluded.updated_by,
+			updated_at = excluded.updated_at
+	`, playbook.GeneSymbol, string(document), playbook.Version, playbook.UpdatedBy, now); err != nil {
+		return fmt.Errorf("failed to save playbook: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// List returns the gene symbols that have playbooks.
+func (s *SQLiteStore) List(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT gene_symbol FROM gene_playbooks ORDER BY gene_symbol")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var genes []string
+	for rows.Next() {
+		var gene string
+		if err := rows.Scan(&gene); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		genes = append(genes, gene)
+	}
+	return genes, rows.Err()
+}
+
+// Close closes the store and releases resources.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,75 @@
+package playbook
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "playbooks.db"), []string{"senior1"})
+	require.NoError(t, err)
+	return store
+}
+
+func TestSQLiteStore_SaveAndGet(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	pb := &Playbook{
+		GeneSymbol:     "brca1",
+		VCEPHighlights: []string{"PM2_Supporting applies at gnomAD FAF < 0.00002"},
+		CommonPitfalls: []string{"Do not apply PVS1 to last-exon truncations without NMD review"},
+		FrequentBenigns: []BenignVariant{
+			{HGVS: "NM_007294.4:c.4837A>G", Note: "Common polymorphism"},
+		},
+		LabNotes: []LabNote{{Note: "Confirm large deletions by MLPA"}},
+	}
+
+	// Act
+	require.NoError(t, store.Save(ctx, pb, "senior1"))
+
+	// Assert
+	got, err := store.Get(ctx, "BRCA1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "BRCA1", got.GeneSymbol)
+	assert.Equal(t, 1, got.Version)
+	assert.Equal(t, "senior1", got.UpdatedBy)
+	assert.Equal(t, "senior1", got.LabNotes[0].Author)
+	assert.Len(t, got.FrequentBenigns, 1)
+
+	// Second save increments version
+	require.NoError(t, store.Save(ctx, got, "senior1"))
+	got, err = store.Get(ctx, "BRCA1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Version)
+
+	genes, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BRCA1"}, genes)
+}
+
+func TestSQLiteStore_SaveRequiresSeniorCurator(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	err := store.Save(context.Background(), &Playbook{GeneSymbol: "TP53"}, "junior")
+
+	assert.ErrorIs(t, err, ErrNotAuthorized)
+}
+
+func TestSQLiteStore_GetMissing(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	got, err := store.Get(context.Background(), "CFTR")
+
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
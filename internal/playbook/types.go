@@ -0,0 +1,60 @@
+// Package playbook provides curated per-gene interpretation playbooks.
+// A playbook combines VCEP specification highlights, common pitfalls,
+// frequent benign variants and lab-specific notes for a single gene.
+package playbook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrNotAuthorized is returned when a non-senior curator attempts an edit.
+var ErrNotAuthorized = errors.New("only senior curators may edit playbooks")
+
+// Playbook is the curated interpretation guidance for a gene.
+type Playbook struct {
+	GeneSymbol      string          `json:"gene_symbol"`
+	VCEPHighlights  []string        `json:"vcep_highlights,omitempty"`  // Key points from the VCEP specification
+	CommonPitfalls  []string        `json:"common_pitfalls,omitempty"`  // Known interpretation mistakes
+	FrequentBenigns []BenignVariant `json:"frequent_benigns,omitempty"` // Variants frequently seen and classified benign
+	LabNotes        []LabNote       `json:"lab_notes,omitempty"`        // Lab-specific guidance
+	Version         int             `json:"version"`
+	UpdatedBy       string          `json:"updated_by"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// BenignVariant is a variant frequently encountered in a gene and classified benign.
+type BenignVariant struct {
+	HGVS string `json:"hgvs"`
+	Note string `json:"note,omitempty"`
+}
+
+// LabNote is a lab-specific note attached to a playbook.
+type LabNote struct {
+	Author    string    `json:"author"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store defines the interface for playbook storage operations.
+type Store interface {
+	// Get retrieves the playbook for a gene. Returns nil if none exists.
+	Get(ctx context.Context, geneSymbol string) (*Playbook, error)
+
+	// Save creates or replaces the playbook for a gene, incrementing its version.
+	// The editor must be a senior curator.
+	Save(ctx context.Context, playbook *Playbook, editor string) error
+
+	// List returns the gene symbols that have playbooks.
+	List(ctx context.Context) ([]string, error)
+
+	// Close closes the store and releases resources.
+	Close() error
+}
+
+// NormalizeGeneSymbol returns the canonical upper-case form of a gene symbol.
+func NormalizeGeneSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
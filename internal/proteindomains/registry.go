@@ -0,0 +1,202 @@
+// Package proteindomains maps protein positions to UniProt domains, Pfam
+// families and published mutational hotspots for PM1. Annotations come from
+// locally bundled tables, each row carrying the benign and pathogenic ClinVar
+// missense counts across the region, so the rule engine can tell a critical
+// domain from one that tolerates variation.
+package proteindomains
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// ErrInvalidTable is returned when a domain table fails validation.
+var ErrInvalidTable = errors.New("invalid protein domain table")
+
+// columns are the required header columns of a domain table
+var columns = []string{"gene", "source", "accession", "name", "start", "end", "benign_missense", "pathogenic_missense"}
+
+// sourceRank orders overlapping annotations: hotspots are the most specific
+// evidence, then curated UniProt features, then Pfam families
+var sourceRank = map[string]int{
+	domain.DomainSourceHotspot: 0,
+	domain.DomainSourceUniProt: 1,
+	domain.DomainSourcePfam:    2,
+}
+
+// Registry holds protein domain annotations by gene.
+// Registry is read-only after loading and safe for concurrent use.
+type Registry struct {
+	domains map[string][]domain.ProteinDomain // upper-case gene symbol -> domains
+	count   int
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{domains: make(map[string][]domain.ProteinDomain)}
+}
+
+// LoadDir loads every .tsv domain table in dir, e.g. uniprot.tsv, pfam.tsv
+// and hotspots.tsv. A missing directory yields an empty registry.
+func LoadDir(dir string) (*Registry, error) {
+	registry := NewRegistry()
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read protein domain directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".tsv") {
+			continue
+		}
+		if err := registry.LoadFile(filepath.Join(dir, entry.Name())); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// LoadFile loads a tab-separated domain table with a header row naming the
+// gene, source, accession, name, start, end, benign_missense and
+// pathogenic_missense columns, in any order.
+func (r *Registry) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open protein domain table: %w", err)
+	}
+	defer file.Close()
+
+	name := filepath.Base(path)
+	scanner := bufio.NewScanner(file)
+	var index map[string]int
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+
+		if index == nil {
+			index, err = headerIndex(fields)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			continue
+		}
+
+		d, err := parseRow(fields, index)
+		if err != nil {
+			return fmt.Errorf("%w: %s line %d: %v", ErrInvalidTable, name, line, err)
+		}
+		r.add(d)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read protein domain table %s: %w", name, err)
+	}
+	return nil
+}
+
+// headerIndex maps each required column to its position in the header
+func headerIndex(header []string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		index[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range columns {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("%w: missing %s column", ErrInvalidTable, column)
+		}
+	}
+	return index, nil
+}
+
+// parseRow validates one table row
+func parseRow(fields []string, index map[string]int) (domain.ProteinDomain, error) {
+	value := func(column string) string {
+		if i := index[column]; i < len(fields) {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+	integer := func(column string) (int, error) {
+		n, err := strconv.Atoi(value(column))
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("%s must be a non-negative integer", column)
+		}
+		return n, nil
+	}
+
+	d := domain.ProteinDomain{
+		Gene:      strings.ToUpper(value("gene")),
+		Source:    strings.ToLower(value("source")),
+		Accession: value("accession"),
+		Name:      value("name"),
+	}
+	if d.Gene == "" || d.Name == "" {
+		return d, fmt.Errorf("gene and name are required")
+	}
+	if _, ok := sourceRank[d.Source]; !ok {
+		return d, fmt.Errorf("source must be one of %s, %s or %s", domain.DomainSourceUniProt, domain.DomainSourcePfam, domain.DomainSourceHotspot)
+	}
+
+	var err error
+	if d.Start, err = integer("start"); err != nil {
+		return d, err
+	}
+	if d.End, err = integer("end"); err != nil {
+		return d, err
+	}
+	if d.Start < 1 || d.End < d.Start {
+		return d, fmt.Errorf("invalid residue range %d-%d", d.Start, d.End)
+	}
+	if d.BenignMissense, err = integer("benign_missense"); err != nil {
+		return d, err
+	}
+	if d.PathogenicMissense, err = integer("pathogenic_missense"); err != nil {
+		return d, err
+	}
+	d.BenignDensity = float64(d.BenignMissense) / float64(d.End-d.Start+1)
+	return d, nil
+}
+
+func (r *Registry) add(d domain.ProteinDomain) {
+	r.domains[d.Gene] = append(r.domains[d.Gene], d)
+	r.count++
+}
+
+// Count returns the number of loaded annotations.
+func (r *Registry) Count() int {
+	return r.count
+}
+
+// Lookup returns the annotations of gene covering a residue, hotspots first,
+// then UniProt features and Pfam families, the narrowest first within each.
+func (r *Registry) Lookup(gene string, position int) []domain.ProteinDomain {
+	var matches []domain.ProteinDomain
+	for _, d := range r.domains[strings.ToUpper(strings.TrimSpace(gene))] {
+		if position >= d.Start && position <= d.End {
+			matches = append(matches, d)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if sourceRank[matches[i].Source] != sourceRank[matches[j].Source] {
+			return sourceRank[matches[i].Source] < sourceRank[matches[j].Source]
+		}
+		return matches[i].End-matches[i].Start < matches[j].End-matches[j].Start
+	})
+	return matches
+}
@@ -0,0 +1,72 @@
+package proteindomains
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+const header = "gene\tsource\taccession\tname\tstart\tend\tbenign_missense\tpathogenic_missense\n"
+
+func writeTable(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTable(t, dir, "domains.tsv", "# UniProt and Pfam\n"+header+
+		"TP53\tuniprot\tP04637\tDNA-binding domain\t102\t292\t1\t231\n"+
+		"tp53\tPfam\tPF00870\tP53\t95\t288\t1\t220\n")
+	writeTable(t, dir, "hotspots.tsv", header+"TP53\thotspot\tPMID:26619011\tL3 loop\t237\t250\t0\t31\n")
+	writeTable(t, dir, "README.md", "not a table")
+
+	registry, err := LoadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 3, registry.Count())
+
+	matches := registry.Lookup("TP53", 248)
+	require.Len(t, matches, 3)
+	assert.Equal(t, []string{domain.DomainSourceHotspot, domain.DomainSourceUniProt, domain.DomainSourcePfam},
+		[]string{matches[0].Source, matches[1].Source, matches[2].Source}, "hotspots first, then UniProt, then Pfam")
+	assert.InDelta(t, 1.0/191, matches[1].BenignDensity, 1e-9)
+	assert.Equal(t, "TP53", matches[2].Gene)
+
+	assert.Len(t, registry.Lookup("tp53", 100), 1, "gene symbols are case-insensitive")
+	assert.Empty(t, registry.Lookup("TP53", 300))
+	assert.Empty(t, registry.Lookup("BRCA1", 248))
+
+	empty, err := LoadDir(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, empty.Count())
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"missing column": "gene\tsource\tname\tstart\tend\n",
+		"unknown source": header + "TP53\tinterpro\tIPR011615\tDNA-binding\t102\t292\t1\t231\n",
+		"reversed range": header + "TP53\tuniprot\tP04637\tDNA-binding\t292\t102\t1\t231\n",
+		"zero start":     header + "TP53\tuniprot\tP04637\tDNA-binding\t0\t102\t1\t231\n",
+		"negative count": header + "TP53\tuniprot\tP04637\tDNA-binding\t102\t292\t-1\t231\n",
+		"missing count":  header + "TP53\tuniprot\tP04637\tDNA-binding\t102\t292\n",
+		"missing gene":   header + "\tuniprot\tP04637\tDNA-binding\t102\t292\t1\t231\n",
+	} {
+		path := writeTable(t, dir, "table.tsv", content)
+		err := NewRegistry().LoadFile(path)
+		assert.ErrorIs(t, err, ErrInvalidTable, name)
+	}
+}
+
+func TestLoadDir_Examples(t *testing.T) {
+	registry, err := LoadDir(filepath.Join("..", "..", "examples", "protein_domains"))
+	require.NoError(t, err)
+	assert.NotZero(t, registry.Count())
+}
@@ -0,0 +1,163 @@
+// Package provenance records the versions of the datasets evidence is drawn
+// from, such as the ClinVar release, the gnomAD dataset and the dbNSFP
+// build, so each classification carries the version set it was made with.
+// Deployments can pin versions: a dataset that does not match its pin fails
+// the check made at startup, so two labs pinned alike classify against the
+// same data and can reproduce each other's calls.
+package provenance
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Dataset sources
+const (
+	ClinVar = "clinvar"
+	GnomAD  = "gnomad"
+	DbNSFP  = "dbnsfp"
+)
+
+// Live is the version of a source queried through its public API, whose
+// data changes without notice; it never matches a pin
+const Live = "live"
+
+// Unknown is recorded for a local copy whose version could not be read
+const Unknown = "unknown"
+
+// Queried returns the version of a dataset queried through its public API:
+// Live, qualified by the API's dataset when there is one, e.g.
+// "live (gnomad_r4)"
+func Queried(dataset string) string {
+	if dataset == "" {
+		return Live
+	}
+	return Live + " (" + dataset + ")"
+}
+
+// ErrPinMismatch is returned when a dataset in use is not the pinned version.
+var ErrPinMismatch = errors.New("dataset version does not match pin")
+
+// Versions maps a dataset source to its version, e.g. clinvar to 2024-05-01.
+type Versions map[string]string
+
+// Clone returns a copy, or nil for an empty set.
+func (v Versions) Clone() Versions {
+	if len(v) == 0 {
+		return nil
+	}
+	clone := make(Versions, len(v))
+	for source, version := range v {
+		clone[source] = version
+	}
+	return clone
+}
+
+// Sources returns the sources in order.
+func (v Versions) Sources() []string {
+	sources := make([]string, 0, len(v))
+	for source := range v {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// String formats the set as "clinvar=2024-05-01, gnomad=gnomad_r4".
+func (v Versions) String() string {
+	parts := make([]string, 0, len(v))
+	for _, source := range v.Sources() {
+		parts = append(parts, source+"="+v[source])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Changes lists the sources whose version differs between two sets, as
+// "clinvar: 2024-04-01 -> 2024-05-01", ordered by source.
+func Changes(previous, current Versions) []string {
+	all := make(Versions, len(previous)+len(current))
+	for source := range previous {
+		all[source] = ""
+	}
+	for source := range current {
+		all[source] = ""
+	}
+
+	var changes []string
+	for _, source := range all.Sources() {
+		if prev, cur := previous[source], current[source]; prev != cur {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", source, orNone(prev), orNone(cur)))
+		}
+	}
+	return changes
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// Registry holds the versions of the datasets in use and the pinned ones.
+type Registry struct {
+	mu       sync.RWMutex
+	versions Versions
+	pins     Versions
+}
+
+// NewRegistry creates a registry enforcing pins; empty pins are ignored.
+func NewRegistry(pins Versions) *Registry {
+	r := &Registry{versions: make(Versions), pins: make(Versions)}
+	for source, version := range pins {
+		if version = strings.TrimSpace(version); version != "" {
+			r.pins[strings.ToLower(source)] = version
+		}
+	}
+	return r
+}
+
+// Record sets the version of a source in use, Unknown when it is empty.
+func (r *Registry) Record(source, version string) {
+	if version = strings.TrimSpace(version); version == "" {
+		version = Unknown
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.versions[strings.ToLower(source)] = version
+}
+
+// DatasetVersions returns the versions in use.
+func (r *Registry) DatasetVersions() Versions {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.versions.Clone()
+}
+
+// Pins returns the pinned versions.
+func (r *Registry) Pins() Versions {
+	return r.pins.Clone()
+}
+
+// Check verifies every pinned source is in use at its pinned version,
+// ignoring case, and reports each one that is not.
+func (r *Registry) Check() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var errs []error
+	for _, source := range r.pins.Sources() {
+		pin := r.pins[source]
+		version, ok := r.versions[source]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%w: %s is pinned to %s but not in use", ErrPinMismatch, source, pin))
+		case !strings.EqualFold(version, pin):
+			errs = append(errs, fmt.Errorf("%w: %s is %s, pinned to %s", ErrPinMismatch, source, version, pin))
+		}
+	}
+	return errors.Join(errs...)
+}
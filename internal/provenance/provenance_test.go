@@ -0,0 +1,56 @@
+package provenance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Check(t *testing.T) {
+	registry := NewRegistry(Versions{ClinVar: "2024-05-01", GnomAD: "gnomad_r4", DbNSFP: ""})
+	assert.Equal(t, Versions{ClinVar: "2024-05-01", GnomAD: "gnomad_r4"}, registry.Pins())
+
+	registry.Record(ClinVar, "2024-05-01")
+	registry.Record(GnomAD, "GNOMAD_R4")
+	registry.Record(DbNSFP, "")
+	require.NoError(t, registry.Check())
+	assert.Equal(t, Versions{ClinVar: "2024-05-01", GnomAD: "GNOMAD_R4", DbNSFP: Unknown}, registry.DatasetVersions())
+
+	registry.Record(ClinVar, Queried(""))
+	registry.Record(GnomAD, Queried("gnomad_r4"))
+	err := registry.Check()
+	assert.ErrorIs(t, err, ErrPinMismatch)
+	assert.ErrorContains(t, err, "clinvar is live, pinned to 2024-05-01")
+	assert.ErrorContains(t, err, "gnomad is live (gnomad_r4), pinned to gnomad_r4")
+}
+
+func TestRegistry_CheckMissingSource(t *testing.T) {
+	registry := NewRegistry(Versions{DbNSFP: "4.9a"})
+	registry.Record(ClinVar, "2024-05-01")
+
+	err := registry.Check()
+	assert.ErrorIs(t, err, ErrPinMismatch)
+	assert.ErrorContains(t, err, "dbnsfp is pinned to 4.9a but not in use")
+
+	assert.NoError(t, NewRegistry(nil).Check())
+}
+
+func TestRegistry_DatasetVersionsIsACopy(t *testing.T) {
+	registry := NewRegistry(nil)
+	assert.Nil(t, registry.DatasetVersions())
+
+	registry.Record(ClinVar, "2024-05-01")
+	versions := registry.DatasetVersions()
+	versions[ClinVar] = "changed"
+	assert.Equal(t, "2024-05-01", registry.DatasetVersions()[ClinVar])
+}
+
+func TestVersions_StringAndChanges(t *testing.T) {
+	previous := Versions{ClinVar: "2024-04-01", GnomAD: "gnomad_r4"}
+	current := Versions{ClinVar: "2024-05-01", GnomAD: "gnomad_r4", DbNSFP: "4.9a"}
+
+	assert.Equal(t, "clinvar=2024-05-01, dbnsfp=4.9a, gnomad=gnomad_r4", current.String())
+	assert.Equal(t, []string{"clinvar: 2024-04-01 -> 2024-05-01", "dbnsfp: none -> 4.9a"}, Changes(previous, current))
+	assert.Empty(t, Changes(current, current.Clone()))
+}
@@ -0,0 +1,75 @@
+package readiness
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	_ "modernc.org/sqlite"
+)
+
+// HTTPProbe checks that a service answers at endpoint. Any response below 500
+// counts as reachable, since APIs often reject a bare GET with a 4xx.
+func HTTPProbe(client *http.Client, endpoint string) Probe {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("unreachable: %w", err)
+		}
+		defer resp.Body.Close()
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+		if resp.StatusCode >= 500 {
+			return fmt.Errorf("answered %s", resp.Status)
+		}
+		return nil
+	}
+}
+
+// SQLiteProbe checks a local SQLite database with PRAGMA quick_check,
+// opening it read-only so the probe never creates or changes it
+func SQLiteProbe(path string) Probe {
+	return func(ctx context.Context) error {
+		if _, err := os.Stat(path); err != nil {
+			return err
+		}
+		db, err := sql.Open("sqlite", "file:"+(&url.URL{Path: path}).EscapedPath()+"?mode=ro")
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		rows, err := db.QueryContext(ctx, "PRAGMA quick_check")
+		if err != nil {
+			return fmt.Errorf("integrity check failed: %w", err)
+		}
+		defer rows.Close()
+		var problems []string
+		for rows.Next() {
+			var result string
+			if err := rows.Scan(&result); err != nil {
+				return err
+			}
+			if result != "ok" {
+				problems = append(problems, result)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("integrity check failed: %w", err)
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("integrity check found %d problems, first: %s", len(problems), problems[0])
+		}
+		return nil
+	}
+}
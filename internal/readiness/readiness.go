@@ -0,0 +1,254 @@
+// Package readiness probes the server's dependencies in the background and
+// reports their status at /healthz and /readyz.
+//
+// /healthz answers 200 while the process serves requests and lists every
+// dependency; /readyz answers 503 until each critical dependency has
+// passed its latest probe, so a load balancer only routes to instances
+// that can classify. External evidence sources are usually registered as
+// non-critical: classification continues with partial evidence while one
+// is unreachable, and the instance reports itself degraded.
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultInterval is how often dependencies are probed
+	DefaultInterval = 30 * time.Second
+	// DefaultTimeout bounds a single probe
+	DefaultTimeout = 5 * time.Second
+)
+
+// Status is a dependency's state after its latest probe
+type Status string
+
+const (
+	StatusUp      Status = "up"
+	StatusDown    Status = "down"
+	StatusUnknown Status = "unknown" // Not probed yet
+)
+
+// Overall states reported for the instance
+const (
+	OverallOK          = "ok"          // Every dependency is up
+	OverallDegraded    = "degraded"    // A non-critical dependency is down
+	OverallUnavailable = "unavailable" // A critical dependency is down or not probed yet
+	OverallDraining    = "draining"    // Shutting down; new requests are refused
+)
+
+// Probe checks a dependency, returning an error when it is unusable
+type Probe func(ctx context.Context) error
+
+// DependencyStatus reports a dependency's latest probe
+type DependencyStatus struct {
+	Status      Status     `json:"status"`
+	Critical    bool       `json:"critical"`
+	LastChecked *time.Time `json:"last_checked,omitempty"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	LatencyMs   int64      `json:"latency_ms"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// Report is the body of /healthz and /readyz
+type Report struct {
+	Status       string                      `json:"status"`
+	Timestamp    time.Time                   `json:"timestamp"`
+	Uptime       string                      `json:"uptime"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+type dependency struct {
+	probe  Probe
+	status DependencyStatus
+}
+
+// Checker probes registered dependencies and reports their status
+type Checker struct {
+	interval time.Duration
+	timeout  time.Duration
+	started  time.Time
+	now      func() time.Time
+
+	mu       sync.RWMutex
+	deps     map[string]*dependency
+	draining bool
+}
+
+// Default is the checker served by the HTTP and WebSocket transports
+var Default = NewChecker(DefaultInterval, DefaultTimeout)
+
+// NewChecker creates a checker probing every interval, each probe bounded
+// by timeout
+func NewChecker(interval, timeout time.Duration) *Checker {
+	return &Checker{
+		interval: interval,
+		timeout:  timeout,
+		started:  time.Now(),
+		now:      time.Now,
+		deps:     make(map[string]*dependency),
+	}
+}
+
+// Register adds a dependency. Critical dependencies must be up for the
+// instance to be ready; registering a name again replaces its probe.
+func (c *Checker) Register(name string, critical bool, probe Probe) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deps[name] = &dependency{
+		probe:  probe,
+		status: DependencyStatus{Status: StatusUnknown, Critical: critical},
+	}
+}
+
+// SetDraining reports the instance unready from now on, so load balancers
+// stop routing to it while in-flight requests finish.
+func (c *Checker) SetDraining() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.draining = true
+}
+
+// CheckNow probes every dependency concurrently and records the results
+func (c *Checker) CheckNow(ctx context.Context) {
+	c.mu.RLock()
+	probes := make(map[string]Probe, len(c.deps))
+	for name, dep := range c.deps {
+		probes[name] = dep.probe
+	}
+	c.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for name, probe := range probes {
+		wg.Add(1)
+		go func(name string, probe Probe) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			start := c.now()
+			err := probe(probeCtx)
+			c.record(name, start, c.now().Sub(start), err)
+		}(name, probe)
+	}
+	wg.Wait()
+}
+
+func (c *Checker) record(name string, checked time.Time, latency time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dep, ok := c.deps[name]
+	if !ok {
+		return
+	}
+	dep.status.LastChecked = &checked
+	dep.status.LatencyMs = latency.Milliseconds()
+	if err != nil {
+		dep.status.Status = StatusDown
+		dep.status.Error = err.Error()
+		return
+	}
+	dep.status.Status = StatusUp
+	dep.status.Error = ""
+	dep.status.LastSuccess = &checked
+}
+
+// Run probes immediately and then every interval until ctx is cancelled
+func (c *Checker) Run(ctx context.Context, logger *logrus.Logger) {
+	c.mu.RLock()
+	names := make([]string, 0, len(c.deps))
+	for name := range c.deps {
+		names = append(names, name)
+	}
+	c.mu.RUnlock()
+	sort.Strings(names)
+	logger.WithField("dependencies", names).Info("Probing dependencies for readiness")
+
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			c.CheckNow(ctx)
+			c.logFailures(logger)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (c *Checker) logFailures(logger *logrus.Logger) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for name, dep := range c.deps {
+		if dep.status.Status == StatusDown {
+			logger.WithFields(logrus.Fields{
+				"dependency": name,
+				"critical":   dep.status.Critical,
+				"error":      dep.status.Error,
+			}).Warn("Dependency probe failed")
+		}
+	}
+}
+
+// Report returns every dependency's status and the overall state
+func (c *Checker) Report() Report {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	report := Report{
+		Status:       OverallOK,
+		Timestamp:    c.now().UTC(),
+		Uptime:       c.now().Sub(c.started).Round(time.Second).String(),
+		Dependencies: make(map[string]DependencyStatus, len(c.deps)),
+	}
+	for name, dep := range c.deps {
+		report.Dependencies[name] = dep.status
+		switch {
+		case dep.status.Status == StatusUp:
+		case dep.status.Critical:
+			report.Status = OverallUnavailable
+		case report.Status == OverallOK:
+			report.Status = OverallDegraded
+		}
+	}
+	if c.draining {
+		report.Status = OverallDraining
+	}
+	return report
+}
+
+// LivenessHandler serves /healthz: 200 with the report while the process runs
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, c.Report())
+	})
+}
+
+// ReadinessHandler serves /readyz: 200 when every critical dependency is up,
+// otherwise 503, as while draining
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Report()
+		status := http.StatusOK
+		if report.Status == OverallUnavailable || report.Status == OverallDraining {
+			status = http.StatusServiceUnavailable
+		}
+		writeReport(w, status, report)
+	})
+}
+
+func writeReport(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,118 @@
+package readiness
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serve(t *testing.T, handler http.Handler) (int, Report) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	var report Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	return rec.Code, report
+}
+
+func TestChecker_Readiness(t *testing.T) {
+	checker := NewChecker(time.Minute, time.Second)
+	cacheErr := errors.New("connection refused")
+	clinvarErr := error(nil)
+	checker.Register("cache", true, func(context.Context) error { return cacheErr })
+	checker.Register("clinvar", false, func(context.Context) error { return clinvarErr })
+
+	// Not probed yet: live but not ready
+	code, report := serve(t, checker.ReadinessHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusUnknown, report.Dependencies["cache"].Status)
+	code, _ = serve(t, checker.LivenessHandler())
+	assert.Equal(t, http.StatusOK, code)
+
+	// A critical dependency down
+	checker.CheckNow(context.Background())
+	code, report = serve(t, checker.ReadinessHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, OverallUnavailable, report.Status)
+	assert.Equal(t, StatusDown, report.Dependencies["cache"].Status)
+	assert.Equal(t, "connection refused", report.Dependencies["cache"].Error)
+	assert.Nil(t, report.Dependencies["cache"].LastSuccess)
+	assert.NotNil(t, report.Dependencies["clinvar"].LastSuccess)
+
+	// Only a non-critical dependency down: ready but degraded
+	cacheErr, clinvarErr = nil, errors.New("timeout")
+	checker.CheckNow(context.Background())
+	code, report = serve(t, checker.ReadinessHandler())
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, OverallDegraded, report.Status)
+	assert.Equal(t, StatusDown, report.Dependencies["clinvar"].Status)
+	assert.NotNil(t, report.Dependencies["clinvar"].LastSuccess, "last success is kept while down")
+
+	clinvarErr = nil
+	checker.CheckNow(context.Background())
+	_, report = serve(t, checker.LivenessHandler())
+	assert.Equal(t, OverallOK, report.Status)
+
+	// Draining: live but no longer ready
+	checker.SetDraining()
+	code, report = serve(t, checker.ReadinessHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, OverallDraining, report.Status)
+	code, _ = serve(t, checker.LivenessHandler())
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	checker := NewChecker(time.Minute, 20*time.Millisecond)
+	checker.Register("slow", true, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	checker.CheckNow(context.Background())
+	assert.Equal(t, StatusDown, checker.Report().Dependencies["slow"].Status)
+}
+
+func TestHTTPProbe(t *testing.T) {
+	status := http.StatusBadRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	probe := HTTPProbe(server.Client(), server.URL)
+	assert.NoError(t, probe(context.Background()), "a 4xx still shows the service is reachable")
+
+	status = http.StatusBadGateway
+	assert.Error(t, probe(context.Background()))
+
+	server.Close()
+	assert.Error(t, probe(context.Background()))
+}
+
+func TestSQLiteProbe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feedback.db")
+	probe := SQLiteProbe(path)
+	assert.Error(t, probe(context.Background()), "missing database")
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "probe must not create the database")
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE feedback (id INTEGER PRIMARY KEY, note TEXT)")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	assert.NoError(t, probe(context.Background()))
+
+	require.NoError(t, os.WriteFile(path, []byte("not a database, just some text padded out to look like a file"), 0o600))
+	assert.Error(t, probe(context.Background()))
+}
@@ -0,0 +1,163 @@
+package regions
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// interval is a 0-based half-open BED interval
+type interval struct {
+	start, end int64
+	name       string
+	track      string
+}
+
+// Tracks holds problematic region intervals by assembly and chromosome.
+// Tracks is read-only after loading and safe for concurrent use.
+type Tracks struct {
+	intervals map[string]map[string][]interval // assembly -> chromosome -> sorted by start
+	maxLength map[string]map[string]int64      // longest interval per chromosome, bounds the overlap scan
+	count     int
+}
+
+// NewTracks creates an empty track set.
+func NewTracks() *Tracks {
+	return &Tracks{
+		intervals: make(map[string]map[string][]interval),
+		maxLength: make(map[string]map[string]int64),
+	}
+}
+
+// LoadDir loads BED tracks from per-assembly subdirectories, e.g.
+// dir/GRCh38/encode_blacklist.bed. The file name selects the category.
+// A missing directory yields an empty track set.
+func LoadDir(dir string) (*Tracks, error) {
+	tracks := NewTracks()
+
+	for _, assembly := range []string{GRCh37, GRCh38} {
+		assemblyDir := filepath.Join(dir, assembly)
+		entries, err := os.ReadDir(assemblyDir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read region track directory: %w", err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".bed") {
+				continue
+			}
+			if err := tracks.LoadFile(assembly, filepath.Join(assemblyDir, entry.Name())); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	tracks.sort()
+	return tracks, nil
+}
+
+// LoadFile loads a single BED file for an assembly.
+func (t *Tracks) LoadFile(assembly, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open region track: %w", err)
+	}
+	defer file.Close()
+
+	track := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "track") || strings.HasPrefix(text, "browser") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < 3 {
+			return fmt.Errorf("%s line %d: expected chrom, start and end", filepath.Base(path), line)
+		}
+		start, err1 := strconv.ParseInt(fields[1], 10, 64)
+		end, err2 := strconv.ParseInt(fields[2], 10, 64)
+		if err1 != nil || err2 != nil || start < 0 || end <= start {
+			return fmt.Errorf("%s line %d: invalid interval", filepath.Base(path), line)
+		}
+
+		r := interval{start: start, end: end, track: track}
+		if len(fields) > 3 {
+			r.name = strings.Join(fields[3:], " ")
+		}
+		t.add(assembly, normalizeChromosome(fields[0]), r)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read region track %s: %w", filepath.Base(path), err)
+	}
+
+	t.sort()
+	return nil
+}
+
+func (t *Tracks) add(assembly, chrom string, r interval) {
+	if t.intervals[assembly] == nil {
+		t.intervals[assembly] = make(map[string][]interval)
+		t.maxLength[assembly] = make(map[string]int64)
+	}
+	t.intervals[assembly][chrom] = append(t.intervals[assembly][chrom], r)
+	if length := r.end - r.start; length > t.maxLength[assembly][chrom] {
+		t.maxLength[assembly][chrom] = length
+	}
+	t.count++
+}
+
+func (t *Tracks) sort() {
+	for _, chroms := range t.intervals {
+		for _, list := range chroms {
+			sort.Slice(list, func(i, j int) bool { return list[i].start < list[j].start })
+		}
+	}
+}
+
+// Count returns the number of loaded regions.
+func (t *Tracks) Count() int {
+	return t.count
+}
+
+// Annotate returns a caveat for every region overlapping the locus.
+func (t *Tracks) Annotate(locus Locus) []Caveat {
+	list := t.intervals[locus.Assembly][locus.Chromosome]
+	if len(list) == 0 {
+		return nil
+	}
+
+	// BED is 0-based half-open; the locus is 1-based inclusive
+	start, end := locus.Start-1, locus.End
+	lowest := start - t.maxLength[locus.Assembly][locus.Chromosome]
+	i := sort.Search(len(list), func(i int) bool { return list[i].start >= lowest })
+
+	var caveats []Caveat
+	for ; i < len(list) && list[i].start < end; i++ {
+		if list[i].end > start {
+			caveats = append(caveats, newCaveat(locus.Assembly, locus.Chromosome, list[i]))
+		}
+	}
+	return caveats
+}
+
+// AnnotateHGVS annotates a variant given in chromosomal genomic HGVS notation.
+// Other notations cannot be placed on the assembly and yield no caveats.
+func (t *Tracks) AnnotateHGVS(hgvs string) []Caveat {
+	locus, ok := ParseLocus(hgvs)
+	if !ok {
+		return nil
+	}
+	return t.Annotate(locus)
+}
@@ -0,0 +1,98 @@
+package regions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTrack(t *testing.T, dir, assembly, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, assembly), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, assembly, name), []byte(content), 0644))
+}
+
+func TestParseLocus(t *testing.T) {
+	tests := []struct {
+		hgvs  string
+		ok    bool
+		locus Locus
+	}{
+		{"NC_000017.11:g.43104261G>T", true, Locus{GRCh38, "17", 43104261, 43104261}},
+		{"NC_000017.10:g.41256277G>T", true, Locus{GRCh37, "17", 41256277, 41256277}},
+		{"NC_000023.11:g.100_200del", true, Locus{GRCh38, "X", 100, 200}},
+		{"NC_012920.1:g.3243A>G", true, Locus{GRCh38, "MT", 3243, 3243}},
+		{"NC_000017.9:g.100A>G", false, Locus{}},
+		{"NM_007294.4:c.5266dupC", false, Locus{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hgvs, func(t *testing.T) {
+			locus, ok := ParseLocus(tt.hgvs)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.locus, locus)
+		})
+	}
+}
+
+func TestLoadDir_Annotate(t *testing.T) {
+	dir := t.TempDir()
+	writeTrack(t, dir, GRCh38, "encode_blacklist.v2.bed", "track name=blacklist\nchr17\t1000\t2000\tHigh Signal Region\n")
+	writeTrack(t, dir, GRCh38, "assembly_gaps.bed", "# UCSC gap table\nchr1\t0\t10000\ttelomere\n")
+	writeTrack(t, dir, GRCh38, "giab_alldifficult.bed", "chr17\t1500\t50000\n")
+	writeTrack(t, dir, GRCh37, "encode_blacklist.bed", "17\t5000000\t5001000\n")
+
+	tracks, err := LoadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 4, tracks.Count())
+
+	// BED start 1000 is 1-based position 1001; position 1000 is outside
+	assert.Empty(t, tracks.AnnotateHGVS("NC_000017.11:g.1000A>G"))
+
+	caveats := tracks.AnnotateHGVS("NC_000017.11:g.1800A>G")
+	require.Len(t, caveats, 2)
+	assert.Equal(t, CategoryENCODEBlacklist, caveats[0].Category)
+	assert.Equal(t, "High Signal Region", caveats[0].Name)
+	assert.Equal(t, "chr17:1001-2000", caveats[0].Region)
+	assert.Equal(t, CategoryGIABDifficult, caveats[1].Category)
+
+	// A deletion starting before a long region still overlaps it
+	caveats = tracks.AnnotateHGVS("NC_000017.11:g.40000_40010del")
+	require.Len(t, caveats, 1)
+	assert.Equal(t, "giab_alldifficult", caveats[0].Track)
+
+	gap := tracks.AnnotateHGVS("NC_000001.11:g.5000A>G")
+	require.Len(t, gap, 1)
+	assert.Equal(t, CategoryAssemblyGap, gap[0].Category)
+	assert.Contains(t, gap[0].Message, "reference assembly gap")
+
+	// Tracks only apply to their own assembly
+	assert.Empty(t, tracks.AnnotateHGVS("NC_000017.10:g.1800A>G"))
+	assert.Len(t, tracks.AnnotateHGVS("NC_000017.10:g.5000500A>G"), 1)
+}
+
+func TestLoadDir_Missing(t *testing.T) {
+	tracks, err := LoadDir(filepath.Join(t.TempDir(), "missing"))
+
+	require.NoError(t, err)
+	assert.Zero(t, tracks.Count())
+}
+
+func TestLoadDir_InvalidInterval(t *testing.T) {
+	dir := t.TempDir()
+	writeTrack(t, dir, GRCh38, "encode_blacklist.bed", "chr1\t2000\t1000\n")
+
+	_, err := LoadDir(dir)
+
+	assert.Error(t, err)
+}
+
+func TestLoadDir_Example(t *testing.T) {
+	tracks, err := LoadDir(filepath.Join("..", "..", "examples", "regions"))
+
+	require.NoError(t, err)
+	assert.NotZero(t, tracks.Count())
+}
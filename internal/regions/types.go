@@ -0,0 +1,164 @@
+// Package regions annotates variants that fall in regions where short-read
+// calls are unreliable: ENCODE blacklist regions, reference assembly gaps and
+// GIAB difficult-to-map regions. Regions come from locally bundled BED tracks
+// so no lookup leaves the deployment.
+package regions
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Category of a problematic region track
+type Category string
+
+const (
+	CategoryENCODEBlacklist Category = "encode_blacklist"
+	CategoryAssemblyGap     Category = "assembly_gap"
+	CategoryGIABDifficult   Category = "giab_difficult"
+	CategoryOther           Category = "problematic_region"
+)
+
+// Supported reference assemblies
+const (
+	GRCh37 = "GRCh37"
+	GRCh38 = "GRCh38"
+)
+
+// categoryPrefixes maps BED file name prefixes to categories
+var categoryPrefixes = []struct {
+	prefix   string
+	category Category
+}{
+	{"encode_blacklist", CategoryENCODEBlacklist},
+	{"assembly_gap", CategoryAssemblyGap},
+	{"giab", CategoryGIABDifficult},
+}
+
+// CategoryForTrack derives the category from a track (BED file) name.
+func CategoryForTrack(track string) Category {
+	name := strings.ToLower(track)
+	for _, p := range categoryPrefixes {
+		if strings.HasPrefix(name, p.prefix) {
+			return p.category
+		}
+	}
+	return CategoryOther
+}
+
+// Description returns a reader-facing description of the category.
+func (c Category) Description() string {
+	switch c {
+	case CategoryENCODEBlacklist:
+		return "an ENCODE blacklist region with anomalous, artifactual signal"
+	case CategoryAssemblyGap:
+		return "a reference assembly gap"
+	case CategoryGIABDifficult:
+		return "a GIAB difficult-to-map region"
+	default:
+		return "a known problematic region"
+	}
+}
+
+// Caveat is a data-quality caveat for a variant overlapping a problematic region.
+type Caveat struct {
+	Category Category `json:"category"`
+	Track    string   `json:"track"`          // BED file the region came from
+	Name     string   `json:"name,omitempty"` // BED name column, if present
+	Assembly string   `json:"assembly"`
+	Region   string   `json:"region"` // chr:start-end, 1-based inclusive
+	Message  string   `json:"message"`
+}
+
+// Locus is a 1-based inclusive genomic interval.
+type Locus struct {
+	Assembly   string
+	Chromosome string // Without "chr" prefix, e.g. 17, X, MT
+	Start      int64
+	End        int64
+}
+
+// grch37Versions holds the GRCh37 RefSeq version of each chromosome accession.
+// GRCh38 accessions are one version higher; the mitochondrial genome is shared.
+var grch37Versions = map[string]int{
+	"01": 10, "02": 11, "03": 11, "04": 11, "05": 9, "06": 11, "07": 13, "08": 10,
+	"09": 11, "10": 10, "11": 9, "12": 11, "13": 10, "14": 8, "15": 9, "16": 9,
+	"17": 10, "18": 9, "19": 9, "20": 10, "21": 8, "22": 10, "23": 10, "24": 9,
+}
+
+var genomicLocusPattern = regexp.MustCompile(`^(NC_0000(\d{2})|NC_012920)\.(\d+):g\.(\d+)(?:_(\d+))?`)
+
+// ParseLocus extracts the locus from chromosomal genomic HGVS notation,
+// e.g. NC_000017.11:g.43104261G>T. Other notations yield false.
+func ParseLocus(hgvs string) (Locus, bool) {
+	m := genomicLocusPattern.FindStringSubmatch(strings.TrimSpace(hgvs))
+	if m == nil {
+		return Locus{}, false
+	}
+
+	version, _ := strconv.Atoi(m[3])
+	locus := Locus{}
+	if m[1] == "NC_012920" {
+		locus.Chromosome = "MT"
+		locus.Assembly = GRCh38
+	} else {
+		base, ok := grch37Versions[m[2]]
+		if !ok {
+			return Locus{}, false
+		}
+		switch version {
+		case base:
+			locus.Assembly = GRCh37
+		case base + 1:
+			locus.Assembly = GRCh38
+		default:
+			return Locus{}, false
+		}
+		locus.Chromosome = normalizeChromosome(strings.TrimLeft(m[2], "0"))
+	}
+
+	locus.Start, _ = strconv.ParseInt(m[4], 10, 64)
+	locus.End = locus.Start
+	if m[5] != "" {
+		locus.End, _ = strconv.ParseInt(m[5], 10, 64)
+	}
+	if locus.Start <= 0 || locus.End < locus.Start {
+		return Locus{}, false
+	}
+	return locus, true
+}
+
+// normalizeChromosome strips the chr prefix and maps numeric sex chromosomes
+func normalizeChromosome(chrom string) string {
+	chrom = strings.TrimPrefix(strings.TrimPrefix(chrom, "chr"), "Chr")
+	switch strings.ToUpper(chrom) {
+	case "23":
+		return "X"
+	case "24":
+		return "Y"
+	case "M", "MT":
+		return "MT"
+	}
+	return strings.ToUpper(chrom)
+}
+
+// newCaveat builds the caveat for a locus overlapping a region
+func newCaveat(assembly, chrom string, r interval) Caveat {
+	category := CategoryForTrack(r.track)
+	region := fmt.Sprintf("chr%s:%d-%d", chrom, r.start+1, r.end)
+	message := fmt.Sprintf("Variant lies in %s (%s)", category.Description(), region)
+	if r.name != "" {
+		message += ": " + r.name
+	}
+	message += "; call accuracy may be reduced, confirm with an orthogonal method"
+	return Caveat{
+		Category: category,
+		Track:    r.track,
+		Name:     r.name,
+		Assembly: assembly,
+		Region:   region,
+		Message:  message,
+	}
+}
@@ -0,0 +1,172 @@
+package reportdoc
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// docxParts are the fixed parts of a minimal WordprocessingML package
+var docxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`},
+}
+
+// docxTextWidth is the width between the margins of an A4 page, in twips
+const docxTextWidth = 11906 - 2*1000
+
+// Font sizes in half-points
+const (
+	docxTitleSize   = 32
+	docxHeadingSize = 24
+	docxFooterSize  = 16
+)
+
+// renderDOCX writes the document as a Word document. Formatting is applied
+// directly to runs so the package needs no styles part.
+func renderDOCX(doc *Document) ([]byte, error) {
+	var body strings.Builder
+	docxParagraph(&body, doc.Title, true, docxTitleSize)
+	if doc.Subtitle != "" {
+		docxParagraph(&body, doc.Subtitle, false, 0)
+	}
+	docxFields(&body, doc.Fields)
+
+	for _, section := range doc.Sections {
+		docxParagraph(&body, section.Heading, true, docxHeadingSize)
+		for _, paragraph := range section.Paragraphs {
+			docxParagraph(&body, paragraph, false, 0)
+		}
+		docxFields(&body, section.Fields)
+		for _, item := range section.Items {
+			docxParagraph(&body, "• "+item, false, 0)
+		}
+		if section.Table != nil && len(section.Table.Rows) > 0 {
+			docxTable(&body, section.Table)
+		}
+	}
+	for _, line := range doc.Footer {
+		docxParagraph(&body, line, false, docxFooterSize)
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, part := range docxParts {
+		if err := docxWrite(archive, part.name, part.content); err != nil {
+			return nil, err
+		}
+	}
+	document := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		body.String() +
+		`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1000" w:right="1000" w:bottom="1000" w:left="1000" w:header="500" w:footer="500" w:gutter="0"/></w:sectPr></w:body></w:document>`
+	if err := docxWrite(archive, "word/document.xml", document); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// docxWrite adds a part to the package
+func docxWrite(archive *zip.Writer, name, content string) error {
+	part, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = part.Write([]byte(content))
+	return err
+}
+
+// docxParagraph writes a paragraph of a single run; size zero keeps the
+// default size
+func docxParagraph(sb *strings.Builder, text string, bold bool, size int) {
+	sb.WriteString("<w:p>")
+	docxRun(sb, text, bold, size)
+	sb.WriteString("</w:p>")
+}
+
+// docxRun writes a run of text, turning line breaks into w:br
+func docxRun(sb *strings.Builder, text string, bold bool, size int) {
+	sb.WriteString("<w:r>")
+	if bold || size > 0 {
+		sb.WriteString("<w:rPr>")
+		if bold {
+			sb.WriteString("<w:b/>")
+		}
+		if size > 0 {
+			sb.WriteString("<w:sz w:val=\"" + strconv.Itoa(size) + "\"/>")
+		}
+		sb.WriteString("</w:rPr>")
+	}
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			sb.WriteString("<w:br/>")
+		}
+		sb.WriteString(`<w:t xml:space="preserve">`)
+		xml.EscapeText(sb, []byte(line))
+		sb.WriteString("</w:t>")
+	}
+	sb.WriteString("</w:r>")
+}
+
+// docxFields writes labeled values as paragraphs with a bold label
+func docxFields(sb *strings.Builder, fields []Field) {
+	for _, field := range fields {
+		sb.WriteString("<w:p>")
+		docxRun(sb, field.Label+": ", true, 0)
+		docxRun(sb, field.Value, false, 0)
+		sb.WriteString("</w:p>")
+	}
+}
+
+// docxTable writes a full-width bordered table with a bold header row
+func docxTable(sb *strings.Builder, table *Table) {
+	sb.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="5000" w:type="pct"/><w:tblBorders>`)
+	for _, border := range []string{"top", "left", "bottom", "right", "insideH", "insideV"} {
+		sb.WriteString("<w:" + border + ` w:val="single" w:sz="4" w:space="0" w:color="999999"/>`)
+	}
+	sb.WriteString("</w:tblBorders></w:tblPr><w:tblGrid>")
+	for range table.Header {
+		sb.WriteString(`<w:gridCol w:w="` + strconv.Itoa(docxTextWidth/len(table.Header)) + `"/>`)
+	}
+	sb.WriteString("</w:tblGrid>")
+
+	row := func(cells []string, header bool) {
+		sb.WriteString("<w:tr>")
+		if header {
+			sb.WriteString("<w:trPr><w:tblHeader/></w:trPr>")
+		}
+		for i := range table.Header {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			sb.WriteString("<w:tc>")
+			docxParagraph(sb, cell, header, 0)
+			sb.WriteString("</w:tc>")
+		}
+		sb.WriteString("</w:tr>")
+	}
+	row(table.Header, true)
+	for _, cells := range table.Rows {
+		row(cells, false)
+	}
+	sb.WriteString("</w:tbl>")
+	// Word requires a paragraph between a table and what follows
+	sb.WriteString("<w:p/>")
+}
@@ -0,0 +1,84 @@
+package reportdoc
+
+import (
+	"bytes"
+	"html/template"
+)
+
+// htmlTemplate lays out a document as a standalone, printable HTML page
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 11pt; color: #222; max-width: 800px; margin: 2em auto; }
+h1 { font-size: 18pt; margin-bottom: 0.2em; }
+h2 { font-size: 13pt; border-bottom: 1px solid #999; padding-bottom: 0.2em; margin-top: 1.5em; }
+.subtitle { color: #555; margin-top: 0; }
+dl { display: grid; grid-template-columns: max-content auto; gap: 0.2em 1em; }
+dt { font-weight: bold; }
+dd { margin: 0; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #999; padding: 4px 6px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+footer { margin-top: 2em; border-top: 1px solid #999; font-size: 9pt; color: #555; }
+@media print { body { margin: 0; max-width: none; } h2 { page-break-after: avoid; } tr { page-break-inside: avoid; } }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .Subtitle}}
+<p class="subtitle">{{.Subtitle}}</p>
+{{- end}}
+{{- template "fields" .Fields}}
+{{- range .Sections}}
+<section>
+<h2>{{.Heading}}</h2>
+{{- range .Paragraphs}}
+<p>{{.}}</p>
+{{- end}}
+{{- template "fields" .Fields}}
+{{- if .Items}}
+<ul>
+{{- range .Items}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if and .Table .Table.Rows}}
+<table>
+<thead><tr>{{range .Table.Header}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Table.Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</section>
+{{- end}}
+{{- if .Footer}}
+<footer>
+{{- range .Footer}}
+<p>{{.}}</p>
+{{- end}}
+</footer>
+{{- end}}
+</body>
+</html>
+{{define "fields"}}{{if .}}
+<dl>
+{{- range .}}
+<dt>{{.Label}}</dt><dd>{{.Value}}</dd>
+{{- end}}
+</dl>{{end}}{{end}}`))
+
+// renderHTML writes the document as an HTML page with inline print styles
+func renderHTML(doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,72 @@
+package reportdoc
+
+import (
+	"strings"
+)
+
+// renderMarkdown writes the document as GitHub-flavored Markdown
+func renderMarkdown(doc *Document) []byte {
+	var sb strings.Builder
+
+	sb.WriteString("# " + doc.Title + "\n\n")
+	if doc.Subtitle != "" {
+		sb.WriteString("_" + doc.Subtitle + "_\n\n")
+	}
+	writeMarkdownFields(&sb, doc.Fields)
+
+	for _, section := range doc.Sections {
+		sb.WriteString("## " + section.Heading + "\n\n")
+		for _, paragraph := range section.Paragraphs {
+			sb.WriteString(paragraph + "\n\n")
+		}
+		writeMarkdownFields(&sb, section.Fields)
+		if len(section.Items) > 0 {
+			for _, item := range section.Items {
+				sb.WriteString("- " + item + "\n")
+			}
+			sb.WriteString("\n")
+		}
+		if table := section.Table; table != nil && len(table.Rows) > 0 {
+			writeMarkdownRow(&sb, table.Header)
+			separators := make([]string, len(table.Header))
+			for i := range separators {
+				separators[i] = "---"
+			}
+			sb.WriteString("| " + strings.Join(separators, " | ") + " |\n")
+			for _, row := range table.Rows {
+				writeMarkdownRow(&sb, row)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(doc.Footer) > 0 {
+		sb.WriteString("---\n\n")
+		for _, line := range doc.Footer {
+			sb.WriteString("_" + line + "_\n\n")
+		}
+	}
+	return []byte(strings.TrimRight(sb.String(), "\n") + "\n")
+}
+
+// writeMarkdownFields writes labeled values as a bold-labeled list
+func writeMarkdownFields(sb *strings.Builder, fields []Field) {
+	if len(fields) == 0 {
+		return
+	}
+	for _, field := range fields {
+		sb.WriteString("- **" + field.Label + ":** " + field.Value + "\n")
+	}
+	sb.WriteString("\n")
+}
+
+// writeMarkdownRow writes a table row; pipes and line breaks in cells would
+// end the cell or row and are escaped
+func writeMarkdownRow(sb *strings.Builder, cells []string) {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		escaped[i] = strings.ReplaceAll(cell, "\n", " ")
+	}
+	sb.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+}
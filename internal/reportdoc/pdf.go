@@ -0,0 +1,366 @@
+package reportdoc
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page geometry in points
+const (
+	pdfPageWidth    = 595.0
+	pdfPageHeight   = 842.0
+	pdfMargin       = 50.0
+	pdfContentWidth = pdfPageWidth - 2*pdfMargin
+	pdfFieldLabel   = 130.0 // Width of the label column of fields
+	pdfCellPadding  = 3.0
+)
+
+// Font resource names of the two standard fonts used; standard fonts need
+// no embedding and every PDF reader provides them
+const (
+	pdfRegular = "F1"
+	pdfBold    = "F2"
+)
+
+// helveticaWidths are the advance widths of printable ASCII (32-126) in
+// Helvetica, in thousandths of the font size, from the Adobe font metrics
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// winAnsiSubstitutes maps characters outside Latin-1 onto WinAnsiEncoding
+// codes or ASCII equivalents
+var winAnsiSubstitutes = map[rune]string{
+	'–': "\x96",
+	'—': "\x97",
+	'‘': "\x91",
+	'’': "\x92",
+	'“': "\x93",
+	'”': "\x94",
+	'•': "\x95",
+	'…': "\x85",
+	'≥': ">=",
+	'≤': "<=",
+	'→': "->",
+}
+
+// pdfWriter lays a document out on pages of PDF content stream operators
+type pdfWriter struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	y     float64 // Baseline of the last line written on the page
+}
+
+// renderPDF writes the document as a PDF 1.4 file. Text is set in Helvetica
+// with WinAnsiEncoding; characters it cannot encode are replaced.
+func renderPDF(doc *Document) []byte {
+	w := &pdfWriter{}
+	w.newPage()
+
+	w.lines(pdfMargin, pdfContentWidth, pdfBold, 18, 22, doc.Title)
+	if doc.Subtitle != "" {
+		w.lines(pdfMargin, pdfContentWidth, pdfRegular, 11, 15, doc.Subtitle)
+	}
+	w.y -= 8
+	w.fields(doc.Fields)
+
+	for _, section := range doc.Sections {
+		w.ensure(50) // Keep a heading with the start of its section
+		w.y -= 10
+		w.lines(pdfMargin, pdfContentWidth, pdfBold, 13, 17, section.Heading)
+		fmt.Fprintf(w.page, "%.2f %.2f m %.2f %.2f l S\n", pdfMargin, w.y-4, pdfPageWidth-pdfMargin, w.y-4)
+		w.y -= 8
+
+		for _, paragraph := range section.Paragraphs {
+			w.lines(pdfMargin, pdfContentWidth, pdfRegular, 10, 14, paragraph)
+			w.y -= 4
+		}
+		w.fields(section.Fields)
+		for _, item := range section.Items {
+			w.ensure(14)
+			w.text(pdfMargin+4, w.y-14, pdfRegular, 10, "•")
+			w.lines(pdfMargin+16, pdfContentWidth-16, pdfRegular, 10, 14, item)
+		}
+		if section.Table != nil && len(section.Table.Rows) > 0 {
+			w.y -= 4
+			w.table(section.Table)
+		}
+	}
+
+	if len(doc.Footer) > 0 {
+		w.ensure(30)
+		w.y -= 16
+		fmt.Fprintf(w.page, "%.2f %.2f m %.2f %.2f l S\n", pdfMargin, w.y, pdfPageWidth-pdfMargin, w.y)
+		w.y -= 4
+		for _, line := range doc.Footer {
+			w.lines(pdfMargin, pdfContentWidth, pdfRegular, 8, 11, line)
+		}
+	}
+
+	for i, page := range w.pages {
+		number := fmt.Sprintf("Page %d of %d", i+1, len(w.pages))
+		fmt.Fprintf(page, "BT /%s 8 Tf %.2f %.2f Td (%s) Tj ET\n",
+			pdfRegular, (pdfPageWidth-textWidth(number, pdfRegular, 8))/2, pdfMargin/2, pdfEscape(number))
+	}
+	return w.file(doc.Title)
+}
+
+// newPage starts a page
+func (w *pdfWriter) newPage() {
+	w.page = &bytes.Buffer{}
+	w.page.WriteString("0.5 w\n")
+	w.pages = append(w.pages, w.page)
+	w.y = pdfPageHeight - pdfMargin
+}
+
+// ensure starts a new page unless height points remain above the bottom margin
+func (w *pdfWriter) ensure(height float64) {
+	if w.y-height < pdfMargin {
+		w.newPage()
+	}
+}
+
+// text draws a single line with its baseline at y
+func (w *pdfWriter) text(x, y float64, font string, size float64, s string) {
+	fmt.Fprintf(w.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(s))
+}
+
+// lines draws text wrapped to width, breaking pages as needed
+func (w *pdfWriter) lines(x, width float64, font string, size, leading float64, s string) {
+	for _, line := range wrapText(s, font, size, width) {
+		w.ensure(leading)
+		w.y -= leading
+		w.text(x, w.y, font, size, line)
+	}
+}
+
+// fields draws labeled values in two columns
+func (w *pdfWriter) fields(fields []Field) {
+	for _, field := range fields {
+		values := wrapText(field.Value, pdfRegular, 10, pdfContentWidth-pdfFieldLabel)
+		w.ensure(14)
+		w.y -= 14
+		w.text(pdfMargin, w.y, pdfBold, 10, field.Label)
+		for i, value := range values {
+			if i > 0 {
+				w.ensure(14)
+				w.y -= 14
+			}
+			w.text(pdfMargin+pdfFieldLabel, w.y, pdfRegular, 10, value)
+		}
+	}
+	if len(fields) > 0 {
+		w.y -= 6
+	}
+}
+
+// table draws a bordered table, wrapping cells within their columns and
+// repeating the header on each page the table spans
+func (w *pdfWriter) table(table *Table) {
+	const size, leading = 9.0, 11.0
+	widths := columnWidths(table, size)
+
+	row := func(cells []string, font string, shaded bool) {
+		wrapped := make([][]string, len(widths))
+		lines := 1
+		for i := range widths {
+			if i < len(cells) {
+				wrapped[i] = wrapText(cells[i], font, size, widths[i]-2*pdfCellPadding)
+			}
+			if len(wrapped[i]) > lines {
+				lines = len(wrapped[i])
+			}
+		}
+		height := float64(lines)*leading + 2*pdfCellPadding
+
+		x := pdfMargin
+		for i, width := range widths {
+			if shaded {
+				fmt.Fprintf(w.page, "q 0.92 g %.2f %.2f %.2f %.2f re f Q\n", x, w.y-height, width, height)
+			}
+			fmt.Fprintf(w.page, "%.2f %.2f %.2f %.2f re S\n", x, w.y-height, width, height)
+			for j, line := range wrapped[i] {
+				w.text(x+pdfCellPadding, w.y-pdfCellPadding-size-float64(j)*leading, font, size, line)
+			}
+			x += width
+		}
+		w.y -= height
+	}
+	rowHeight := func(cells []string) float64 {
+		lines := 1
+		for i, width := range widths {
+			if i < len(cells) {
+				if n := len(wrapText(cells[i], pdfRegular, size, width-2*pdfCellPadding)); n > lines {
+					lines = n
+				}
+			}
+		}
+		return float64(lines)*leading + 2*pdfCellPadding
+	}
+
+	header := func() { row(table.Header, pdfBold, true) }
+	w.ensure(rowHeight(table.Header) + rowHeight(table.Rows[0]))
+	header()
+	for _, cells := range table.Rows {
+		if w.y-rowHeight(cells) < pdfMargin {
+			w.newPage()
+			header()
+		}
+		row(cells, pdfRegular, false)
+	}
+	w.y -= 6
+}
+
+// columnWidths shares the content width among the columns of a table in
+// proportion to their widest cell, so short columns are not wrapped
+func columnWidths(table *Table, size float64) []float64 {
+	natural := make([]float64, len(table.Header))
+	for i, cell := range table.Header {
+		natural[i] = textWidth(cell, pdfBold, size)
+	}
+	for _, row := range table.Rows {
+		for i, cell := range row {
+			if i < len(natural) {
+				natural[i] = max(natural[i], textWidth(cell, pdfRegular, size))
+			}
+		}
+	}
+
+	total := 0.0
+	for i := range natural {
+		// Bound each column so one long cell does not squeeze the others
+		natural[i] = min(max(natural[i]+2*pdfCellPadding, 40), pdfContentWidth*0.6)
+		total += natural[i]
+	}
+	widths := make([]float64, len(natural))
+	for i := range natural {
+		widths[i] = natural[i] / total * pdfContentWidth
+	}
+	return widths
+}
+
+// wrapText breaks text into lines no wider than width, at spaces where
+// possible and within words that are wider than a line
+func wrapText(s, font string, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if textWidth(candidate, font, size) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = word
+			for textWidth(line, font, size) > width {
+				runes := []rune(line)
+				cut := len(runes) - 1
+				for cut > 1 && textWidth(string(runes[:cut]), font, size) > width {
+					cut--
+				}
+				lines = append(lines, string(runes[:cut]))
+				line = string(runes[cut:])
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// textWidth measures text set in a font, in points. Bold text is measured as
+// ten percent wider than regular, which is close for Helvetica-Bold.
+func textWidth(s, font string, size float64) float64 {
+	units := 0
+	for _, c := range []byte(winAnsi(s)) {
+		if c >= 32 && c <= 126 {
+			units += helveticaWidths[c-32]
+		} else {
+			units += 556
+		}
+	}
+	width := float64(units) / 1000 * size
+	if font == pdfBold {
+		width *= 1.1
+	}
+	return width
+}
+
+// winAnsi encodes text in WinAnsiEncoding, which agrees with Latin-1 above 0xA0
+func winAnsi(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			sb.WriteByte(' ')
+		case r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+			sb.WriteByte(byte(r))
+		case winAnsiSubstitutes[r] != "":
+			sb.WriteString(winAnsiSubstitutes[r])
+		default:
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
+
+// pdfEscape encodes text as the body of a PDF literal string
+func pdfEscape(s string) string {
+	s = winAnsi(s)
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "(", `\(`)
+	return strings.ReplaceAll(s, ")", `\)`)
+}
+
+// file assembles the pages into a PDF file with a cross-reference table
+func (w *pdfWriter) file(title string) []byte {
+	var objects []string
+	add := func(object string) int {
+		objects = append(objects, object)
+		return len(objects)
+	}
+
+	add("<< /Type /Catalog /Pages 2 0 R >>")
+	add("") // Pages, written once the page objects are numbered
+	add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	info := add(fmt.Sprintf("<< /Title (%s) /Producer (ACMG/AMP MCP Server) >>", pdfEscape(title)))
+
+	kids := make([]string, 0, len(w.pages))
+	for _, page := range w.pages {
+		contents := add(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+		pageObject := add(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, pdfRegular, pdfBold, contents))
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObject))
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))
+
+	var buf bytes.Buffer
+	// The comment of high-bit bytes marks the file as binary to transfer tools
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, info, xref)
+	return buf.Bytes()
+}
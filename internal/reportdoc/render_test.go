@@ -0,0 +1,159 @@
+package reportdoc
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDocument() *Document {
+	return &Document{
+		Title:    "Germline Variant Report",
+		Subtitle: "NM_007294.4:c.5266dup",
+		Fields:   []Field{{Label: "Gene", Value: "BRCA1"}, {Label: "Patient", Value: "P-001"}},
+		Sections: []Section{
+			{
+				Heading:    "Result",
+				Paragraphs: []string{"Pathogenic (confidence: high)"},
+			},
+			{
+				Heading: "ACMG/AMP Criteria Met",
+				Table: &Table{
+					Header: []string{"Criterion", "Strength", "Rationale"},
+					Rows: [][]string{
+						{"PVS1", "Very strong", "Frameshift in a gene where loss of function is a known mechanism"},
+						{"PM2", "Supporting", "Absent from gnomAD | frequency ≤ 0.0001 <rare>"},
+					},
+				},
+			},
+			{
+				Heading: "Recommendations",
+				Items:   []string{"Cascade testing of first-degree relatives"},
+			},
+		},
+		Footer: []string{"Classification may change as new evidence becomes available"},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat(" PDF ")
+	require.NoError(t, err)
+	assert.Equal(t, FormatPDF, format)
+	assert.True(t, format.Binary())
+	assert.False(t, FormatMarkdown.Binary())
+
+	_, err = ParseFormat("rtf")
+	assert.Error(t, err)
+}
+
+func TestRender_Markdown(t *testing.T) {
+	rendered, err := Render(testDocument(), FormatMarkdown)
+	require.NoError(t, err)
+	assert.Equal(t, ".md", rendered.Extension)
+
+	content := string(rendered.Content)
+	assert.True(t, strings.HasPrefix(content, "# Germline Variant Report\n"))
+	assert.Contains(t, content, "- **Gene:** BRCA1")
+	assert.Contains(t, content, "| Criterion | Strength | Rationale |\n| --- | --- | --- |")
+	assert.Contains(t, content, `Absent from gnomAD \| frequency`)
+	assert.Contains(t, content, "- Cascade testing of first-degree relatives")
+}
+
+func TestRender_HTML(t *testing.T) {
+	rendered, err := Render(testDocument(), FormatHTML)
+	require.NoError(t, err)
+
+	content := string(rendered.Content)
+	assert.Contains(t, content, "<h1>Germline Variant Report</h1>")
+	assert.Contains(t, content, "<th>Criterion</th>")
+	assert.Contains(t, content, "&lt;rare&gt;", "cell text is escaped")
+	assert.Contains(t, content, "<footer>")
+}
+
+func TestRender_PDF(t *testing.T) {
+	doc := testDocument()
+	// Enough rows to run onto a second page
+	for i := 0; i < 40; i++ {
+		doc.Sections[1].Table.Rows = append(doc.Sections[1].Table.Rows, []string{fmt.Sprintf("BS%d", i), "Strong", "Observed in healthy adults"})
+	}
+
+	rendered, err := Render(doc, FormatPDF)
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", rendered.MimeType)
+
+	content := rendered.Content
+	require.True(t, bytes.HasPrefix(content, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(content, []byte("%%EOF\n")))
+	assert.True(t, bytes.Contains(content, []byte("(Germline Variant Report) Tj")))
+	assert.True(t, bytes.Contains(content, []byte("/Count 2")))
+	assert.True(t, bytes.Contains(content, []byte("(Page 2 of 2) Tj")))
+	assert.True(t, bytes.Contains(content, []byte("(\x95) Tj")), "bullets are set in WinAnsiEncoding")
+
+	// The cross-reference table points at each object
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(content)
+	require.NotNil(t, startxref)
+	offset, _ := strconv.Atoi(string(startxref[1]))
+	require.True(t, bytes.HasPrefix(content[offset:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(content[offset:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		objectOffset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(content[objectOffset:], []byte(fmt.Sprintf("%d 0 obj", i+1))), "object %d", i+1)
+	}
+
+	// Rendering is deterministic
+	again, err := Render(doc, FormatPDF)
+	require.NoError(t, err)
+	assert.Equal(t, content, again.Content)
+}
+
+func TestRender_DOCX(t *testing.T) {
+	rendered, err := Render(testDocument(), FormatDOCX)
+	require.NoError(t, err)
+	assert.Equal(t, ".docx", rendered.Extension)
+
+	archive, err := zip.NewReader(bytes.NewReader(rendered.Content), int64(len(rendered.Content)))
+	require.NoError(t, err)
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		parts[file.Name] = string(data)
+	}
+
+	require.Contains(t, parts, "[Content_Types].xml")
+	require.Contains(t, parts, "_rels/.rels")
+	document := parts["word/document.xml"]
+	assert.Contains(t, document, `<w:sz w:val="32"/></w:rPr><w:t xml:space="preserve">Germline Variant Report</w:t>`)
+	assert.Contains(t, document, "<w:tbl>")
+	assert.Contains(t, document, "&lt;rare&gt;")
+	assert.Contains(t, document, "• Cascade testing")
+}
+
+func TestWinAnsi(t *testing.T) {
+	assert.Equal(t, "caf\xe9 \x96 AF <= 0.01 ?", winAnsi("café – AF ≤ 0.01 α"))
+	assert.Equal(t, `BRCA1 \(17q21\) \\`, pdfEscape(`BRCA1 (17q21) \`))
+}
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("The quick brown fox jumps over the lazy dog", pdfRegular, 10, 100)
+	require.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		assert.LessOrEqual(t, textWidth(line, pdfRegular, 10), 100.0)
+	}
+	assert.Equal(t, "The quick brown fox jumps over the lazy dog", strings.Join(lines, " "))
+
+	// Words wider than a line are broken
+	lines = wrapText(strings.Repeat("A", 50), pdfRegular, 10, 100)
+	assert.Equal(t, strings.Repeat("A", 50), strings.Join(lines, ""))
+}
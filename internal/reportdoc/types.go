@@ -0,0 +1,110 @@
+// Package reportdoc renders clinical variant reports as documents for sign-out
+// and for the patient record: Markdown, HTML, PDF and DOCX. A report is first
+// laid out as a Document of titled sections, fields, lists and tables; each
+// renderer then writes that layout in its format using only the standard
+// library.
+package reportdoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format is an output document format
+type Format string
+
+// Supported document formats
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+	FormatPDF      Format = "pdf"
+	FormatDOCX     Format = "docx"
+)
+
+// Formats lists the supported formats in the order offered to clients
+var Formats = []Format{FormatMarkdown, FormatHTML, FormatPDF, FormatDOCX}
+
+// ParseFormat validates a document format name, ignoring case
+func ParseFormat(s string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range Formats {
+		if format == known {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported document format %q", s)
+}
+
+// Binary reports whether documents in the format are binary rather than text
+func (f Format) Binary() bool {
+	return f == FormatPDF || f == FormatDOCX
+}
+
+// Document is the layout of a report, independent of the output format
+type Document struct {
+	Title    string
+	Subtitle string
+	Fields   []Field // Header fields, e.g. report ID and patient
+	Sections []Section
+	Footer   []string // Disclaimers, printed at the end of the document
+}
+
+// Field is a labeled value
+type Field struct {
+	Label string
+	Value string
+}
+
+// Section is a headed part of a document. Its parts are rendered in the
+// order paragraphs, fields, items and table; empty parts are skipped.
+type Section struct {
+	Heading    string
+	Paragraphs []string
+	Fields     []Field
+	Items      []string // Bulleted list
+	Table      *Table
+}
+
+// Table is a table with a header row
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// Rendered is a document rendered in one format
+type Rendered struct {
+	Format    Format
+	MimeType  string
+	Extension string
+	Content   []byte
+}
+
+// Render writes the document in the given format
+func Render(doc *Document, format Format) (*Rendered, error) {
+	var (
+		content []byte
+		err     error
+	)
+	rendered := &Rendered{Format: format}
+	switch format {
+	case FormatMarkdown:
+		rendered.MimeType, rendered.Extension = "text/markdown; charset=utf-8", ".md"
+		content = renderMarkdown(doc)
+	case FormatHTML:
+		rendered.MimeType, rendered.Extension = "text/html; charset=utf-8", ".html"
+		content, err = renderHTML(doc)
+	case FormatPDF:
+		rendered.MimeType, rendered.Extension = "application/pdf", ".pdf"
+		content = renderPDF(doc)
+	case FormatDOCX:
+		rendered.MimeType, rendered.Extension = "application/vnd.openxmlformats-officedocument.wordprocessingml.document", ".docx"
+		content, err = renderDOCX(doc)
+	default:
+		return nil, fmt.Errorf("unsupported document format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s document: %w", format, err)
+	}
+	rendered.Content = content
+	return rendered, nil
+}
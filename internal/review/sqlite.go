@@ -0,0 +1,270 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite review store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables and indexes.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS classification_reviews (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		classification_id INTEGER NOT NULL,
+		variant_id TEXT DEFAULT '',
+		hgvs_notation TEXT NOT NULL,
+		classification TEXT DEFAULT '',
+		confidence TEXT DEFAULT '',
+		status TEXT NOT NULL,
+		submitted_by TEXT NOT NULL,
+		submission_note TEXT DEFAULT '',
+		reviewed_by TEXT DEFAULT '',
+		comments TEXT DEFAULT '',
+		created_at DATETIME NOT NULL,
+		reviewed_at DATETIME
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_reviews_classification ON classification_reviews(classification_id, status);
+	CREATE INDEX IF NOT EXISTS idx_reviews_status ON classification_reviews(status, created_at);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+const reviewColumns = `id, classification_id, variant_id, hgvs_notation, classification, confidence, status,
+	submitted_by, submission_note, reviewed_by, comments, created_at, reviewed_at`
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanReview scans a row into a Review struct.
+func scanReview(row rowScanner) (*Review, error) {
+	r := &Review{}
+	var status string
+	var reviewedAt sql.NullTime
+
+	err := row.Scan(
+		&r.ID, &r.ClassificationID, &r.VariantID, &r.HGVSNotation, &r.Classification, &r.Confidence, &status,
+		&r.SubmittedBy, &r.SubmissionNote, &r.ReviewedBy, &r.Comments, &r.CreatedAt, &reviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Status = Status(status)
+	if reviewedAt.Valid {
+		t := reviewedAt.Time
+		r.ReviewedAt = &t
+	}
+	return r, nil
+}
+
+// Submit saves a pending review, setting its ID and creation time.
+func (s *SQLiteStore) Submit(ctx context.Context, review *Review) error {
+	if err := review.Validate(); err != nil {
+		return err
+	}
+
+	var open int
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM classification_reviews WHERE classification_id = ? AND status IN (?, ?)`,
+		review.ClassificationID, string(StatusPending), string(StatusApproved)).Scan(&open); err != nil {
+		return fmt.Errorf("failed to check existing reviews: %w", err)
+	}
+	if open > 0 {
+		return fmt.Errorf("%w: classification %d", ErrAlreadyInReview, review.ClassificationID)
+	}
+
+	review.Status = StatusPending
+	review.ReviewedBy = ""
+	review.Comments = ""
+	review.ReviewedAt = nil
+	review.CreatedAt = time.Now().UTC()
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO classification_reviews (classification_id, variant_id, hgvs_notation, classification, confidence,
+			status, submitted_by, submission_note, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, review.ClassificationID, review.VariantID, review.HGVSNotation, review.Classification, review.Confidence,
+		string(review.Status), review.SubmittedBy, review.SubmissionNote, review.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert review: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get review ID: %w", err)
+	}
+	review.ID = id
+	return nil
+}
+
+// Decide approves or returns a pending review.
+func (s *SQLiteStore) Decide(ctx context.Context, id int64, status Status, reviewer, comments string) (*Review, error) {
+	reviewer = strings.TrimSpace(reviewer)
+	comments = strings.TrimSpace(comments)
+	if status != StatusApproved && status != StatusReturned {
+		return nil, fmt.Errorf("%w: a review is decided as approved or returned", ErrInvalidReview)
+	}
+	if reviewer == "" {
+		return nil, fmt.Errorf("%w: reviewer is required", ErrInvalidReview)
+	}
+	if status == StatusReturned && comments == "" {
+		return nil, fmt.Errorf("%w: comments are required to return a classification", ErrInvalidReview)
+	}
+
+	review, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status != StatusPending {
+		return nil, fmt.Errorf("%w: review %d is %s", ErrAlreadyReviewed, id, review.Status)
+	}
+	if strings.EqualFold(review.SubmittedBy, reviewer) {
+		return nil, ErrSelfReview
+	}
+
+	now := time.Now().UTC()
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE classification_reviews SET status = ?, reviewed_by = ?, comments = ?, reviewed_at = ?
+		WHERE id = ? AND status = ?
+	`, string(status), reviewer, comments, now, id, string(StatusPending))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update review: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update review: %w", err)
+	}
+	if n == 0 {
+		// Decided by a concurrent call
+		return nil, fmt.Errorf("%w: review %d", ErrAlreadyReviewed, id)
+	}
+
+	review.Status = status
+	review.ReviewedBy = reviewer
+	review.Comments = comments
+	review.ReviewedAt = &now
+	return review, nil
+}
+
+// Get returns a review.
+func (s *SQLiteStore) Get(ctx context.Context, id int64) (*Review, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+reviewColumns+" FROM classification_reviews WHERE id = ?", id)
+	review, err := scanReview(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review: %w", err)
+	}
+	return review, nil
+}
+
+// Approved returns the approved review of a classification.
+func (s *SQLiteStore) Approved(ctx context.Context, classificationID int64) (*Review, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+reviewColumns+` FROM classification_reviews
+		WHERE classification_id = ? AND status = ? ORDER BY id DESC LIMIT 1`,
+		classificationID, string(StatusApproved))
+	review, err := scanReview(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review: %w", err)
+	}
+	return review, nil
+}
+
+// List returns reviews matching the filter, oldest first.
+func (s *SQLiteStore) List(ctx context.Context, filter Filter) ([]*Review, error) {
+	query := "SELECT " + reviewColumns + " FROM classification_reviews WHERE 1=1"
+	var args []interface{}
+	if filter.Status != "" {
+		query += " AND status = ?"
+		args = append(args, string(filter.Status))
+	}
+	if filter.ClassificationID > 0 {
+		query += " AND classification_id = ?"
+		args = append(args, filter.ClassificationID)
+	}
+	if variant := strings.TrimSpace(filter.Variant); variant != "" {
+		query += " AND (variant_id = ? OR hgvs_notation = ?)"
+		args = append(args, variant, variant)
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	query += " ORDER BY created_at, id LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*Review{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, rows.Err()
+}
+
+// Close closes the store and releases resources.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,95 @@
+package review
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "reviews.db"))
+	require.NoError(t, err)
+	return store
+}
+
+func TestSQLiteStore_SubmitAndApprove(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	review := &Review{
+		ClassificationID: 7,
+		VariantID:        "VAR_1",
+		HGVSNotation:     "NM_007294.4:c.5266dup",
+		Classification:   "PATHOGENIC",
+		SubmittedBy:      "analyst1",
+		SubmissionNote:   "Ready for sign-out",
+	}
+
+	// Act
+	require.NoError(t, store.Submit(ctx, review))
+
+	// Assert
+	assert.NotZero(t, review.ID)
+	assert.Equal(t, StatusPending, review.Status)
+	assert.ErrorIs(t, store.Submit(ctx, &Review{ClassificationID: 7, HGVSNotation: "NM_007294.4:c.5266dup", SubmittedBy: "analyst1"}), ErrAlreadyInReview)
+	_, err := store.Approved(ctx, 7)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// The submitter cannot sign out their own classification
+	_, err = store.Decide(ctx, review.ID, StatusApproved, "ANALYST1", "")
+	assert.ErrorIs(t, err, ErrSelfReview)
+
+	approved, err := store.Decide(ctx, review.ID, StatusApproved, "reviewer1", "")
+	require.NoError(t, err)
+	assert.Equal(t, StatusApproved, approved.Status)
+	assert.Equal(t, "reviewer1", approved.ReviewedBy)
+	require.NotNil(t, approved.ReviewedAt)
+
+	got, err := store.Approved(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, review.ID, got.ID)
+	assert.Equal(t, "Ready for sign-out", got.SubmissionNote)
+
+	_, err = store.Decide(ctx, review.ID, StatusReturned, "reviewer2", "Second thoughts")
+	assert.ErrorIs(t, err, ErrAlreadyReviewed)
+	assert.ErrorIs(t, store.Submit(ctx, &Review{ClassificationID: 7, HGVSNotation: "NM_007294.4:c.5266dup", SubmittedBy: "analyst1"}), ErrAlreadyInReview)
+}
+
+func TestSQLiteStore_ReturnAndResubmit(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	review := &Review{ClassificationID: 3, HGVSNotation: "NM_000546.6:c.743G>A", SubmittedBy: "analyst1"}
+	require.NoError(t, store.Submit(ctx, review))
+
+	// Act: comments are required to return a classification
+	_, err := store.Decide(ctx, review.ID, StatusReturned, "reviewer1", " ")
+	assert.ErrorIs(t, err, ErrInvalidReview)
+	returned, err := store.Decide(ctx, review.ID, StatusReturned, "reviewer1", "PS3 assay is not validated")
+	require.NoError(t, err)
+
+	// Assert: a returned classification can be submitted again
+	assert.Equal(t, "PS3 assay is not validated", returned.Comments)
+	resubmitted := &Review{ClassificationID: 3, HGVSNotation: "NM_000546.6:c.743G>A", SubmittedBy: "analyst1"}
+	require.NoError(t, store.Submit(ctx, resubmitted))
+
+	pending, err := store.List(ctx, Filter{Status: StatusPending})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, resubmitted.ID, pending[0].ID)
+
+	all, err := store.List(ctx, Filter{Variant: "NM_000546.6:c.743G>A"})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, StatusReturned, all[0].Status)
+
+	_, err = store.Get(ctx, resubmitted.ID+1)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, store.Submit(ctx, &Review{HGVSNotation: "NM_000546.6:c.743G>A", SubmittedBy: "analyst1"}), ErrInvalidReview)
+}
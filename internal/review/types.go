@@ -0,0 +1,118 @@
+// Package review implements the dual-review sign-out of classifications.
+// A recorded classification is submitted for review, a second user with the
+// reviewer role approves it or returns it with comments, and only approved
+// classifications are exported as reports. A returned classification is
+// corrected and submitted again as a new review.
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when a review does not exist.
+	ErrNotFound = errors.New("review not found")
+	// ErrInvalidReview is returned when a review is missing required fields.
+	ErrInvalidReview = errors.New("invalid review")
+	// ErrAlreadyInReview is returned when a classification is submitted while
+	// a review of it is pending or approved.
+	ErrAlreadyInReview = errors.New("classification already pending review or approved")
+	// ErrSelfReview is returned when the submitter tries to review their own classification.
+	ErrSelfReview = errors.New("classification must be reviewed by a different user")
+	// ErrAlreadyReviewed is returned when a review that was decided is decided again.
+	ErrAlreadyReviewed = errors.New("review already decided")
+)
+
+// Status is the state of a review.
+type Status string
+
+const (
+	StatusPending  Status = "pending_review"
+	StatusApproved Status = "approved"
+	StatusReturned Status = "returned"
+)
+
+// ParseStatus parses a review status.
+func ParseStatus(s string) (Status, error) {
+	status := Status(strings.ToLower(strings.TrimSpace(s)))
+	switch status {
+	case StatusPending, StatusApproved, StatusReturned:
+		return status, nil
+	}
+	return "", fmt.Errorf("unknown review status %q: must be pending_review, approved or returned", s)
+}
+
+// Review is one submission of a recorded classification for sign-out.
+type Review struct {
+	ID               int64      `json:"id,omitempty"`
+	ClassificationID int64      `json:"classification_id"` // Audit record of the classification under review
+	VariantID        string     `json:"variant_id,omitempty"`
+	HGVSNotation     string     `json:"hgvs_notation"`
+	Classification   string     `json:"classification"`
+	Confidence       string     `json:"confidence,omitempty"`
+	Status           Status     `json:"status"`
+	SubmittedBy      string     `json:"submitted_by"`
+	SubmissionNote   string     `json:"submission_note,omitempty"`
+	ReviewedBy       string     `json:"reviewed_by,omitempty"`
+	Comments         string     `json:"comments,omitempty"` // Required when returned
+	CreatedAt        time.Time  `json:"created_at"`
+	ReviewedAt       *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// Validate normalizes the review and checks required fields.
+func (r *Review) Validate() error {
+	r.HGVSNotation = strings.TrimSpace(r.HGVSNotation)
+	r.SubmittedBy = strings.TrimSpace(r.SubmittedBy)
+	r.SubmissionNote = strings.TrimSpace(r.SubmissionNote)
+
+	if r.ClassificationID <= 0 {
+		return fmt.Errorf("%w: classification ID is required", ErrInvalidReview)
+	}
+	if r.HGVSNotation == "" && r.VariantID == "" {
+		return fmt.Errorf("%w: variant ID or HGVS notation is required", ErrInvalidReview)
+	}
+	if r.SubmittedBy == "" {
+		return fmt.Errorf("%w: submitter is required", ErrInvalidReview)
+	}
+	return nil
+}
+
+// Filter selects reviews. Zero values match everything.
+type Filter struct {
+	Status           Status
+	ClassificationID int64
+	Variant          string // Matches the variant ID or the HGVS notation
+	Limit            int    // Defaults to DefaultListLimit
+}
+
+// DefaultListLimit caps list results when no limit is given.
+const DefaultListLimit = 100
+
+// Store defines the interface for review storage.
+type Store interface {
+	// Submit saves a pending review, setting its ID and creation time.
+	// Returns ErrAlreadyInReview if the classification has a pending or
+	// approved review.
+	Submit(ctx context.Context, review *Review) error
+
+	// Decide approves or returns a pending review. The reviewer must differ
+	// from the submitter and comments are required to return a review.
+	Decide(ctx context.Context, id int64, status Status, reviewer, comments string) (*Review, error)
+
+	// Get returns a review. Returns ErrNotFound if it does not exist.
+	Get(ctx context.Context, id int64) (*Review, error)
+
+	// Approved returns the approved review of a classification, or
+	// ErrNotFound if it has not been approved.
+	Approved(ctx context.Context, classificationID int64) (*Review, error)
+
+	// List returns reviews matching the filter, oldest first.
+	List(ctx context.Context, filter Filter) ([]*Review, error)
+
+	// Close closes the store and releases resources.
+	Close() error
+}
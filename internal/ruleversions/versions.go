@@ -0,0 +1,116 @@
+// Package ruleversions defines versions of the ACMG/AMP rule set: the 2015
+// guidelines as published and later revisions such as the ClinGen Sequence
+// Variant Interpretation (SVI) working group recommendations. A version
+// adjusts criteria across all genes, marking them retired or changing their
+// strength, and selects how criteria are combined, so the same variant can
+// be classified under two versions when evaluating a guideline migration.
+package ruleversions
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/vcep"
+)
+
+// ErrUnknownVersion is returned for a rule set version that is not defined.
+var ErrUnknownVersion = errors.New("unknown rule set version")
+
+// Built-in versions
+const (
+	ACMG2015       = "acmg2015"
+	ClinGenSVI2023 = "clingen-svi-2023"
+)
+
+// Default is the version used when none is selected; the rule engine
+// implements it without overrides.
+const Default = ACMG2015
+
+// Version is a version of the ACMG/AMP rule set.
+type Version struct {
+	Name        string                    `json:"name"`
+	Title       string                    `json:"title"`
+	Published   string                    `json:"published"`        // Year of the publication or recommendation
+	Source      string                    `json:"source,omitempty"` // Citation of the publication
+	ScoringMode string                    `json:"scoring_mode"`     // combining_rules or points
+	Rules       map[string]*vcep.RuleSpec `json:"rules,omitempty"`  // Keyed by criterion code
+	Notes       string                    `json:"notes,omitempty"`
+}
+
+// Rule returns the version's override for a criterion, or nil.
+func (v *Version) Rule(code string) *vcep.RuleSpec {
+	if v == nil {
+		return nil
+	}
+	return v.Rules[code]
+}
+
+// Label identifies the version in rule reasoning.
+func (v *Version) Label() string {
+	return v.Title
+}
+
+func notApplicable(notes string) *vcep.RuleSpec {
+	applicable := false
+	return &vcep.RuleSpec{Applicable: &applicable, Notes: notes}
+}
+
+var versions = map[string]*Version{
+	ACMG2015: {
+		Name:        ACMG2015,
+		Title:       "ACMG/AMP 2015",
+		Published:   "2015",
+		Source:      "Richards et al. 2015, Genet Med 17:405-424",
+		ScoringMode: "combining_rules",
+		Notes:       "The criteria and combining rules as published",
+	},
+	ClinGenSVI2023: {
+		Name:        ClinGenSVI2023,
+		Title:       "ClinGen SVI 2023",
+		Published:   "2023",
+		Source:      "ClinGen SVI working group recommendations, as of 2023",
+		ScoringMode: "points",
+		Rules: map[string]*vcep.RuleSpec{
+			"PM2": {Strength: domain.SUPPORTING, Notes: "PM2 is applied at supporting strength (SVI 2020)"},
+			"PP5": notApplicable("PP5 is retired; assess the evidence behind the assertion instead (Biesecker and Harrison 2018)"),
+			"BP6": notApplicable("BP6 is retired; assess the evidence behind the assertion instead (Biesecker and Harrison 2018)"),
+		},
+		Notes: "PM2 at supporting, PP5 and BP6 retired, criteria combined with the Bayesian point system (Tavtigian et al. 2020)",
+	},
+}
+
+// Lookup returns a version by name, ignoring case and surrounding space. An
+// empty name selects the default version.
+func Lookup(name string) (*Version, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = Default
+	}
+	version, ok := versions[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q: use one of %s", ErrUnknownVersion, name, strings.Join(Names(), ", "))
+	}
+	return version, nil
+}
+
+// Names returns the names of the defined versions in order.
+func Names() []string {
+	names := make([]string, 0, len(versions))
+	for name := range versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// List returns the defined versions ordered by name.
+func List() []*Version {
+	list := make([]*Version, 0, len(versions))
+	for _, name := range Names() {
+		list = append(list, versions[name])
+	}
+	return list
+}
@@ -0,0 +1,37 @@
+package ruleversions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestLookup(t *testing.T) {
+	version, err := Lookup("")
+	require.NoError(t, err)
+	assert.Equal(t, Default, version.Name)
+	assert.Empty(t, version.Rules)
+
+	version, err = Lookup(" ClinGen-SVI-2023 ")
+	require.NoError(t, err)
+	assert.Equal(t, ClinGenSVI2023, version.Name)
+	assert.Equal(t, domain.SUPPORTING, version.Rule("PM2").Strength)
+	assert.True(t, version.Rule("PP5").NotApplicable())
+	assert.True(t, version.Rule("BP6").NotApplicable())
+	assert.Nil(t, version.Rule("PS3"))
+
+	_, err = Lookup("acmg2025")
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+	assert.ErrorContains(t, err, "acmg2015, clingen-svi-2023")
+}
+
+func TestList(t *testing.T) {
+	assert.Equal(t, []string{ACMG2015, ClinGenSVI2023}, Names())
+	for _, version := range List() {
+		assert.Contains(t, []string{"combining_rules", "points"}, version.ScoringMode, version.Name)
+		assert.NotEmpty(t, version.Title)
+	}
+}
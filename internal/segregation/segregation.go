@@ -0,0 +1,112 @@
+// Package segregation scores how a variant segregates with disease in a
+// family for PP1 and BS4. Each relative counted contributes one informative
+// meiosis: a relative with a 1 in 2 prior chance of carrying the variant
+// (Jarvik and Browning 2016). The likelihood of the observed genotypes given
+// the phenotypes is compared between a causal variant, with the given
+// penetrance and phenocopy rate, and a neutral one, to give a Bayes factor
+// and LOD score.
+package segregation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// ErrInvalidObservations is returned when segregation counts fail validation.
+var ErrInvalidObservations = errors.New("invalid segregation data")
+
+// Default model parameters: a highly penetrant dominant disease with rare
+// phenocopies, under which each cosegregating meiosis has a Bayes factor of
+// about 2, as in meiosis counting
+const (
+	DefaultPenetrance    = 0.9
+	DefaultPhenocopyRate = 0.001
+)
+
+// Observations are the genotyped relatives of the proband, summed across
+// families. The proband is not counted.
+type Observations struct {
+	Families              int     `json:"families,omitempty"` // Families contributing, for reporting
+	AffectedCarriers      int     `json:"affected_carriers"`
+	AffectedNoncarriers   int     `json:"affected_noncarriers"` // Nonsegregations
+	UnaffectedCarriers    int     `json:"unaffected_carriers"`
+	UnaffectedNoncarriers int     `json:"unaffected_noncarriers"`
+	Penetrance            float64 `json:"penetrance,omitempty"`     // Probability a carrier is affected; defaults to DefaultPenetrance
+	PhenocopyRate         float64 `json:"phenocopy_rate,omitempty"` // Probability a noncarrier is affected; defaults to DefaultPhenocopyRate
+}
+
+// Validate checks the counts and model parameters, filling in the defaults
+func (o *Observations) Validate() error {
+	if o.Families < 0 || o.AffectedCarriers < 0 || o.AffectedNoncarriers < 0 || o.UnaffectedCarriers < 0 || o.UnaffectedNoncarriers < 0 {
+		return fmt.Errorf("%w: counts must not be negative", ErrInvalidObservations)
+	}
+	if o.Meioses() == 0 {
+		return fmt.Errorf("%w: at least one genotyped relative is required", ErrInvalidObservations)
+	}
+	if o.Penetrance == 0 {
+		o.Penetrance = DefaultPenetrance
+	}
+	if o.PhenocopyRate == 0 {
+		o.PhenocopyRate = DefaultPhenocopyRate
+	}
+	if o.Penetrance <= 0 || o.Penetrance >= 1 || o.PhenocopyRate <= 0 || o.PhenocopyRate >= o.Penetrance {
+		return fmt.Errorf("%w: need 0 < phenocopy_rate < penetrance < 1", ErrInvalidObservations)
+	}
+	return nil
+}
+
+// Meioses returns the number of informative meioses observed
+func (o Observations) Meioses() int {
+	return o.AffectedCarriers + o.AffectedNoncarriers + o.UnaffectedCarriers + o.UnaffectedNoncarriers
+}
+
+// Analyze computes the Bayes factor and LOD score of the observations.
+// Under the causal model a relative's chance of carrying the variant given
+// their phenotype follows from the penetrance f1 and phenocopy rate f0; under
+// the neutral model it stays 1 in 2. Each relative contributes the ratio:
+// affected carriers 2f1/(f1+f0), affected noncarriers 2f0/(f1+f0),
+// unaffected carriers 2(1-f1)/(2-f1-f0) and unaffected noncarriers
+// 2(1-f0)/(2-f1-f0).
+func Analyze(o Observations) (domain.SegregationAnalysis, error) {
+	if err := o.Validate(); err != nil {
+		return domain.SegregationAnalysis{}, err
+	}
+
+	f1, f0 := o.Penetrance, o.PhenocopyRate
+	affected := f1 + f0
+	unaffected := 2 - f1 - f0
+	ratios := domain.SegregationRatios{
+		AffectedCarrier:      2 * f1 / affected,
+		AffectedNoncarrier:   2 * f0 / affected,
+		UnaffectedCarrier:    2 * (1 - f1) / unaffected,
+		UnaffectedNoncarrier: 2 * (1 - f0) / unaffected,
+	}
+
+	lod := float64(o.AffectedCarriers)*math.Log10(ratios.AffectedCarrier) +
+		float64(o.AffectedNoncarriers)*math.Log10(ratios.AffectedNoncarrier) +
+		float64(o.UnaffectedCarriers)*math.Log10(ratios.UnaffectedCarrier) +
+		float64(o.UnaffectedNoncarriers)*math.Log10(ratios.UnaffectedNoncarrier)
+
+	return domain.SegregationAnalysis{
+		Families:              o.Families,
+		Meioses:               o.Meioses(),
+		AffectedCarriers:      o.AffectedCarriers,
+		AffectedNoncarriers:   o.AffectedNoncarriers,
+		UnaffectedCarriers:    o.UnaffectedCarriers,
+		UnaffectedNoncarriers: o.UnaffectedNoncarriers,
+		Penetrance:            f1,
+		PhenocopyRate:         f0,
+		Ratios:                ratios,
+		LOD:                   round(lod, 3),
+		BayesFactor:           math.Pow(10, lod),
+	}, nil
+}
+
+// round rounds the LOD score for reporting
+func round(x float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(x*scale) / scale
+}
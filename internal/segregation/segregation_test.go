@@ -0,0 +1,71 @@
+package segregation
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_AffectedCarriers(t *testing.T) {
+	tests := []struct {
+		carriers int
+		minLOD   float64
+	}{
+		{3, 0.9},
+		{4, 1.2},
+		{5, 1.5},
+	}
+	for _, tt := range tests {
+		analysis, err := Analyze(Observations{AffectedCarriers: tt.carriers})
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, analysis.LOD, tt.minLOD, "%d affected carriers", tt.carriers)
+		assert.Less(t, analysis.LOD, tt.minLOD+0.3, "%d affected carriers", tt.carriers)
+		assert.Equal(t, tt.carriers, analysis.Meioses)
+		assert.InEpsilon(t, math.Pow(10, analysis.LOD), analysis.BayesFactor, 0.01)
+	}
+}
+
+func TestAnalyze_Defaults(t *testing.T) {
+	analysis, err := Analyze(Observations{Families: 2, AffectedCarriers: 1})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPenetrance, analysis.Penetrance)
+	assert.Equal(t, DefaultPhenocopyRate, analysis.PhenocopyRate)
+	assert.Equal(t, 2, analysis.Families)
+	assert.InDelta(t, 2.0, analysis.Ratios.AffectedCarrier, 0.01)
+}
+
+func TestAnalyze_Nonsegregation(t *testing.T) {
+	analysis, err := Analyze(Observations{AffectedNoncarriers: 1})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, analysis.LOD, -2.0)
+
+	analysis, err = Analyze(Observations{AffectedCarriers: 3, AffectedNoncarriers: 1})
+	require.NoError(t, err)
+	assert.Less(t, analysis.LOD, 0.0)
+	assert.Greater(t, analysis.LOD, -2.0)
+}
+
+func TestAnalyze_UnaffectedRelatives(t *testing.T) {
+	// Unaffected carriers count against segregation under incomplete penetrance
+	analysis, err := Analyze(Observations{UnaffectedCarriers: 1, UnaffectedNoncarriers: 1})
+	require.NoError(t, err)
+	assert.Less(t, analysis.Ratios.UnaffectedCarrier, 1.0)
+	assert.Greater(t, analysis.Ratios.UnaffectedNoncarrier, 1.0)
+}
+
+func TestAnalyze_Invalid(t *testing.T) {
+	invalid := []Observations{
+		{},
+		{AffectedCarriers: -1, UnaffectedNoncarriers: 2},
+		{AffectedCarriers: 2, Penetrance: 1},
+		{AffectedCarriers: 2, Penetrance: 0.5, PhenocopyRate: 0.6},
+		{AffectedCarriers: 2, PhenocopyRate: -0.1},
+	}
+	for _, o := range invalid {
+		_, err := Analyze(o)
+		assert.True(t, errors.Is(err, ErrInvalidObservations), "%+v", o)
+	}
+}
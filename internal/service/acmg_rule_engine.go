@@ -6,15 +6,30 @@ import (
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/acmg-amp-mcp-server/internal/calibration"
+	"github.com/acmg-amp-mcp-server/internal/conflicts"
 	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+	"github.com/acmg-amp-mcp-server/internal/weighting"
 )
 
 // ACMGAMPRuleEngine implements ACMG/AMP variant classification rules
 // Following the 2015 ACMG/AMP guidelines for sequence variant interpretation
 type ACMGAMPRuleEngine struct {
-	logger *logrus.Logger
-	rules  map[string]*ACMGRule
+	logger         *logrus.Logger
+	rules          map[string]*ACMGRule
+	thresholds         ThresholdSource
+	specifications     SpecificationSource
+	frequencyOverrides FrequencyOverrideSource
+	domains            DomainSource
+	phenotypes         PhenotypeSource
+	labKnowledge       LabKnowledgeSource
+	conflictPolicies   *conflicts.Config
+	weighting          *weighting.Policy
+	calibration        *calibration.Calibration
 }
 
 // ACMGRule represents an individual ACMG/AMP rule implementation
@@ -42,11 +57,27 @@ func NewACMGAMPRuleEngine(logger *logrus.Logger) *ACMGAMPRuleEngine {
 
 // EvaluateAllRules evaluates all ACMG/AMP rules against the variant and evidence
 func (e *ACMGAMPRuleEngine) EvaluateAllRules(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) ([]domain.ACMGAMPRuleResult, error) {
+	results, _, err := e.evaluateRules(ctx, variant, evidence)
+	return results, err
+}
+
+// evaluateRules evaluates all rules, then resolves conflicting applied
+// criteria with the configured policies and returns the decisions made
+func (e *ACMGAMPRuleEngine) evaluateRules(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) ([]domain.ACMGAMPRuleResult, []conflicts.Decision, error) {
 	e.logger.WithField("variant_id", variant.ID).Debug("Evaluating all ACMG/AMP rules")
 
+	ctx, span := tracer.Start(ctx, "rules.evaluate", trace.WithAttributes(attribute.String("variant.id", variant.ID)))
+	defer span.End()
+
+	ctx, _ = e.withThresholds(ctx)
+	spec := e.specificationFor(variant)
+	ctx, _ = e.withFrequencyThresholds(ctx, variant, spec)
 	results := make([]domain.ACMGAMPRuleResult, 0, len(e.rules))
 
 	for _, rule := range e.rules {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		result, err := rule.Evaluator(ctx, variant, evidence)
 		if err != nil {
 			e.logger.WithError(err).WithField("rule", rule.Code).Warn("Failed to evaluate rule")
@@ -61,17 +92,34 @@ func (e *ACMGAMPRuleEngine) EvaluateAllRules(ctx context.Context, variant *domai
 				Evidence:   "",
 				Reasoning:  fmt.Sprintf("Rule evaluation failed: %v", err),
 			}
+		} else {
+			if e.calibration != nil {
+				applyCalibration(e.calibration, variant, result, evidence)
+			}
+			if version := ruleVersionFrom(ctx); version != nil {
+				applyRuleVersion(version, result)
+			}
+			if spec != nil {
+				e.applySpecification(spec, variant, result)
+			}
+			if e.weighting != nil {
+				applyWeighting(e.weighting, result, evidence)
+			}
 		}
 		results = append(results, *result)
 	}
 
+	decisions := conflicts.Resolve(results, evidence, thresholdsFrom(ctx).PM2AlleleFrequency, e.conflictPolicies)
+
 	e.logger.WithFields(logrus.Fields{
 		"variant_id":    variant.ID,
 		"total_rules":   len(results),
 		"applied_rules": countAppliedRules(results),
+		"conflicts":     len(decisions),
 	}).Info("Completed ACMG/AMP rule evaluation")
+	span.SetAttributes(attribute.Int("rules.total", len(results)), attribute.Int("rules.applied", countAppliedRules(results)), attribute.Int("rules.conflicts", len(decisions)))
 
-	return results, nil
+	return results, decisions, nil
 }
 
 // EvaluateRule evaluates a specific ACMG/AMP rule
@@ -86,10 +134,25 @@ func (e *ACMGAMPRuleEngine) EvaluateRule(ctx context.Context, ruleCode string, v
 		return nil, fmt.Errorf("unknown ACMG/AMP rule: %s", ruleCode)
 	}
 
+	ctx, _ = e.withThresholds(ctx)
+	spec := e.specificationFor(variant)
+	ctx, _ = e.withFrequencyThresholds(ctx, variant, spec)
 	result, err := rule.Evaluator(ctx, variant, evidence)
 	if err != nil {
 		return nil, fmt.Errorf("failed to evaluate rule %s: %w", ruleCode, err)
 	}
+	if e.calibration != nil {
+		applyCalibration(e.calibration, variant, result, evidence)
+	}
+	if version := ruleVersionFrom(ctx); version != nil {
+		applyRuleVersion(version, result)
+	}
+	if spec != nil {
+		e.applySpecification(spec, variant, result)
+	}
+	if e.weighting != nil {
+		applyWeighting(e.weighting, result, evidence)
+	}
 
 	return result, nil
 }
@@ -301,13 +364,55 @@ func (e *ACMGAMPRuleEngine) evaluatePVS1(ctx context.Context, variant *domain.St
 	isNullVariant := strings.Contains(strings.ToLower(variant.HGVSCoding), "nonsense") ||
 		strings.Contains(strings.ToLower(variant.HGVSCoding), "frameshift") ||
 		strings.Contains(strings.ToLower(variant.HGVSCoding), "splice") ||
-		strings.Contains(strings.ToLower(variant.HGVSProtein), "*")
+		strings.Contains(strings.ToLower(variant.HGVSProtein), "*") ||
+		ClassifyConsequence(variant.Consequence, variant.HGVSCoding, variant.HGVSProtein) == ConsequenceLossOfFunction
+
+	model, hasModel := thresholdsFrom(ctx).GeneModel(variant.GeneSymbol)
+	lofNotMechanism := hasModel && model.Mechanism != thresholds.MechanismLossOfFunction && model.Mechanism != thresholds.MechanismUnknown
 
-	if isNullVariant {
+	// The laboratory's gene model takes precedence over the ClinGen curation
+	var curation *domain.GeneCuration
+	if !hasModel && evidence != nil {
+		curation = evidence.GeneCuration
+	}
+	established, curated := curation.LossOfFunctionMechanism()
+
+	if isNullVariant && lofNotMechanism {
+		result.Applied = false
+		result.Confidence = 0.0
+		result.Reasoning = fmt.Sprintf("Null variant, but loss of function is not the disease mechanism for %s (%s)", variant.GeneSymbol, model.Mechanism)
+	} else if isNullVariant && curated && !established {
+		if curation.Haploinsufficiency == domain.DosageEmergingEvidence {
+			// Limited evidence for the mechanism: reduce the strength (ClinGen SVI)
+			result.Applied = true
+			result.Strength = domain.STRONG
+			result.Confidence = 0.7
+			result.Evidence = "Variant predicted to result in loss of function"
+			result.Reasoning = fmt.Sprintf("Null variant; applied at strong as ClinGen finds emerging evidence for haploinsufficiency of %s (score 2)", variant.GeneSymbol)
+		} else {
+			result.Applied = false
+			result.Confidence = 0.0
+			result.Reasoning = fmt.Sprintf("Null variant, but ClinGen does not establish loss of function as a mechanism for %s (haploinsufficiency score %d)", variant.GeneSymbol, curation.Haploinsufficiency)
+		}
+	} else if isNullVariant && established {
+		result.Applied = true
+		result.Confidence = 0.95
+		result.Evidence = "Variant predicted to result in loss of function"
+		result.Reasoning = fmt.Sprintf("Null variant (nonsense/frameshift/splice) detected; ClinGen establishes loss of function for %s (haploinsufficiency score %d)", variant.GeneSymbol, curation.Haploinsufficiency)
+	} else if isNullVariant {
 		result.Applied = true
 		result.Confidence = 0.9
 		result.Evidence = "Variant predicted to result in loss of function"
 		result.Reasoning = "Null variant (nonsense/frameshift/splice) detected"
+		// Without a gene model or curation, gnomAD constraint informs the mechanism
+		if !hasModel && !curated && evidence != nil {
+			if intolerant, known, metrics := lofIntolerance(ctx, evidence.GeneConstraint); known && intolerant {
+				result.Reasoning += fmt.Sprintf("; gnomAD constraint supports loss-of-function intolerance of %s (%s)", variant.GeneSymbol, metrics)
+			} else if known {
+				result.Confidence = 0.7
+				result.Reasoning += fmt.Sprintf("; %s tolerates heterozygous loss of function in gnomAD (%s), confirm loss of function is the disease mechanism, e.g. for recessive disease", variant.GeneSymbol, metrics)
+			}
+		}
 	} else {
 		result.Applied = false
 		result.Confidence = 0.0
@@ -325,41 +430,53 @@ func (e *ACMGAMPRuleEngine) evaluatePS1(ctx context.Context, variant *domain.Sta
 		Category: domain.PATHOGENIC_RULE,
 		Strength: domain.STRONG,
 	}
-
-	// Check ClinVar for same amino acid change
-	if evidence.ClinVarData != nil {
-		if strings.Contains(strings.ToLower(evidence.ClinVarData.ClinicalSignificance), "pathogenic") {
-			result.Applied = true
-			result.Confidence = 0.8
-			result.Evidence = fmt.Sprintf("ClinVar reports pathogenic variant: %s", evidence.ClinVarData.ClinicalSignificance)
-			result.Reasoning = "Same amino acid change found in ClinVar as pathogenic"
-		}
-	}
-
-	if !result.Applied {
-		result.Applied = false
-		result.Confidence = 0.0
-		result.Reasoning = "No established pathogenic variant at same amino acid position found"
-	}
-
+	evaluateResidueRule(result, variant, e.withLabResidueVariants(ctx, variant, evidence), true)
 	return result, nil
 }
 
 // Placeholder implementations for remaining rules (PM2 is key for population frequency)
 func (e *ACMGAMPRuleEngine) evaluatePS2(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PS2", "De novo in patient with disease and no family history", domain.PATHOGENIC_RULE, domain.STRONG), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PS2",
+		Name:     "De novo in patient with disease and no family history",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.STRONG,
+	}
+	evaluateDeNovo(ctx, result, true)
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluatePS3(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PS3", "Well-established functional studies supportive of damaging effect", domain.PATHOGENIC_RULE, domain.STRONG), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PS3",
+		Name:     "Well-established functional studies supportive of damaging effect",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.STRONG,
+	}
+	evaluateLiterature(result, evidence, domain.SignalFunctionalDamaging, "a damaging effect in functional studies")
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluatePS4(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PS4", "Variant prevalence in affecteds significantly higher than controls", domain.PATHOGENIC_RULE, domain.STRONG), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PS4",
+		Name:     "Variant prevalence in affecteds significantly higher than controls",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.STRONG,
+	}
+	e.evaluateCaseCount(ctx, result, variant, evidence)
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluatePM1(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PM1", "Located in mutational hot spot or functional domain", domain.PATHOGENIC_RULE, domain.MODERATE), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PM1",
+		Name:     "Located in mutational hot spot or functional domain",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.MODERATE,
+	}
+	e.evaluateDomains(ctx, result, variant)
+	return result, nil
 }
 
 // evaluatePM2 - Key rule for population frequency analysis
@@ -373,12 +490,12 @@ func (e *ACMGAMPRuleEngine) evaluatePM2(ctx context.Context, variant *domain.Sta
 
 	// Check population frequency data
 	if evidence.PopulationData != nil {
-		frequency := evidence.PopulationData.AlleleFrequency
+		frequency, description := rarityFrequency(evidence.PopulationData)
 		// PM2 typically applies if frequency < 0.0001 (1 in 10,000)
-		if frequency < 0.0001 {
+		if frequency < thresholdsFrom(ctx).PM2AlleleFrequency {
 			result.Applied = true
 			result.Confidence = 0.7
-			result.Evidence = fmt.Sprintf("Population frequency: %.6f", frequency)
+			result.Evidence = description
 			result.Reasoning = "Variant absent or extremely rare in population databases"
 		} else {
 			result.Applied = false
@@ -403,14 +520,15 @@ func (e *ACMGAMPRuleEngine) evaluateBA1(ctx context.Context, variant *domain.Sta
 		Strength: domain.VERY_STRONG,
 	}
 
-	// Check if variant frequency exceeds 5% threshold
+	// Check if variant frequency exceeds the stand-alone threshold (5% by default)
 	if evidence.PopulationData != nil {
-		frequency := evidence.PopulationData.AlleleFrequency
-		if frequency > 0.05 {
+		frequency, description := benignFrequency(evidence.PopulationData)
+		threshold := thresholdsFrom(ctx).BA1AlleleFrequency
+		if frequency > threshold {
 			result.Applied = true
 			result.Confidence = 0.95
-			result.Evidence = fmt.Sprintf("Population frequency: %.4f", frequency)
-			result.Reasoning = "Variant frequency exceeds 5% threshold in population"
+			result.Evidence = description
+			result.Reasoning = fmt.Sprintf("Variant frequency exceeds %g threshold in population", threshold)
 		} else {
 			result.Applied = false
 			result.Confidence = 0.0
@@ -427,71 +545,323 @@ func (e *ACMGAMPRuleEngine) evaluateBA1(ctx context.Context, variant *domain.Sta
 
 // Placeholder implementations for remaining rules
 func (e *ACMGAMPRuleEngine) evaluatePM3(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PM3", "For recessive disorders, detected in trans with pathogenic variant", domain.PATHOGENIC_RULE, domain.MODERATE), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PM3",
+		Name:     "For recessive disorders, detected in trans with pathogenic variant",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.MODERATE,
+	}
+	evaluateInTrans(ctx, result)
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluatePM4(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
 	return e.createPlaceholderResult("PM4", "Protein length changes as a result of in-frame deletions/insertions", domain.PATHOGENIC_RULE, domain.MODERATE), nil
 }
 
+// evaluatePM5 - Novel missense change at a residue where a different pathogenic missense change has been seen
 func (e *ACMGAMPRuleEngine) evaluatePM5(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PM5", "Novel missense change at amino acid residue where different pathogenic change has been seen", domain.PATHOGENIC_RULE, domain.MODERATE), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PM5",
+		Name:     "Novel missense change at amino acid residue where different pathogenic change has been seen",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.MODERATE,
+	}
+	evaluateResidueRule(result, variant, e.withLabResidueVariants(ctx, variant, evidence), false)
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluatePM6(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PM6", "Assumed de novo, but without confirmation of paternity and maternity", domain.PATHOGENIC_RULE, domain.MODERATE), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PM6",
+		Name:     "Assumed de novo, but without confirmation of paternity and maternity",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.MODERATE,
+	}
+	evaluateDeNovo(ctx, result, false)
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluatePP1(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PP1", "Cosegregation with disease in multiple affected family members", domain.PATHOGENIC_RULE, domain.SUPPORTING), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PP1",
+		Name:     "Cosegregation with disease in multiple affected family members",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.SUPPORTING,
+	}
+	evaluateSegregation(ctx, result, true)
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluatePP2(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PP2", "Missense variant in gene with low rate of benign missense variation", domain.PATHOGENIC_RULE, domain.SUPPORTING), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PP2",
+		Name:     "Missense variant in gene with low rate of benign missense variation",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.SUPPORTING,
+	}
+	evaluateMissenseConstraint(ctx, result, variant, evidence, true)
+	return result, nil
 }
 
+// evaluatePP3 - At least two predictors deleterious and none benign
 func (e *ACMGAMPRuleEngine) evaluatePP3(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PP3", "Multiple lines of computational evidence support deleterious effect", domain.PATHOGENIC_RULE, domain.SUPPORTING), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PP3",
+		Name:     "Multiple lines of computational evidence support deleterious effect",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.SUPPORTING,
+	}
+
+	// A predicted splicing effect supports PP3 on its own, except for null
+	// variants where PVS1 already accounts for the splice site
+	predictors := thresholdsFrom(ctx).Predictors
+	class := ClassifyConsequence(variant.Consequence, variant.HGVSCoding, variant.HGVSProtein)
+	altering, _ := splicingCalls(evidence.SplicingPredictions, predictors)
+	if len(altering) > 0 && class != ConsequenceLossOfFunction {
+		result.Applied = true
+		result.Confidence = 0.6
+		result.Evidence = "Splice-altering: " + strings.Join(altering, ", ")
+		result.Reasoning = "Splicing predictors support an effect on splicing"
+		return result, nil
+	}
+	if isSplicingOnlyVariant(variant, class) {
+		if len(evidence.SplicingPredictions) == 0 {
+			result.Reasoning = fmt.Sprintf("No splicing predictions available for %s variant", variantRegion(variant, class))
+		} else {
+			result.Reasoning = fmt.Sprintf("No splice-altering prediction for %s variant", variantRegion(variant, class))
+		}
+		return result, nil
+	}
+
+	if evidence.ComputationalData == nil {
+		result.Reasoning = "No computational prediction data available"
+		return result, nil
+	}
+
+	deleterious, benign := predictorCalls(evidence.ComputationalData, predictors)
+	if len(deleterious) >= 2 && len(benign) == 0 {
+		result.Applied = true
+		result.Confidence = 0.6
+		result.Evidence = "Deleterious: " + strings.Join(deleterious, ", ")
+		result.Reasoning = "Multiple in silico predictors support a deleterious effect"
+	} else {
+		result.Reasoning = fmt.Sprintf("%d deleterious and %d benign predictions; PP3 requires at least two concordant deleterious predictions", len(deleterious), len(benign))
+	}
+
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluatePP4(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PP4", "Patient's phenotype or family history highly specific for disease", domain.PATHOGENIC_RULE, domain.SUPPORTING), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PP4",
+		Name:     "Patient's phenotype or family history highly specific for disease",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.SUPPORTING,
+	}
+	e.evaluatePhenotype(ctx, result, variant)
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluatePP5(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("PP5", "Reputable source recently reports variant as pathogenic", domain.PATHOGENIC_RULE, domain.SUPPORTING), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "PP5",
+		Name:     "Reputable source recently reports variant as pathogenic",
+		Category: domain.PATHOGENIC_RULE,
+		Strength: domain.SUPPORTING,
+	}
+	evaluateLiterature(result, evidence, domain.SignalReportedPathogenic, "the variant as pathogenic")
+	return result, nil
 }
 
+// evaluateBS1 - Frequency above the disorder threshold but below BA1
 func (e *ACMGAMPRuleEngine) evaluateBS1(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("BS1", "Allele frequency greater than expected for disorder", domain.BENIGN_RULE, domain.STRONG), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "BS1",
+		Name:     "Allele frequency greater than expected for disorder",
+		Category: domain.BENIGN_RULE,
+		Strength: domain.STRONG,
+	}
+
+	if evidence.PopulationData == nil {
+		result.Reasoning = "No population frequency data available"
+		return result, nil
+	}
+
+	t := thresholdsFrom(ctx)
+	frequency, description := benignFrequency(evidence.PopulationData)
+	switch {
+	case frequency > t.BA1AlleleFrequency:
+		result.Reasoning = "Frequency meets BA1; BS1 not applied separately"
+	case frequency > t.BS1AlleleFrequency:
+		result.Applied = true
+		result.Confidence = 0.8
+		result.Evidence = description
+		result.Reasoning = fmt.Sprintf("Frequency exceeds %g expected for the disorder", t.BS1AlleleFrequency)
+	default:
+		result.Reasoning = fmt.Sprintf("Population frequency below threshold: %.6f", frequency)
+	}
+
+	return result, nil
 }
 
+// evaluateBS2 - Homozygotes, hemizygotes or carriers unaffected by a fully penetrant disorder
 func (e *ACMGAMPRuleEngine) evaluateBS2(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("BS2", "Observed in healthy adult individual for recessive disorder", domain.BENIGN_RULE, domain.STRONG), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "BS2",
+		Name:     "Observed in healthy adult individual for recessive disorder",
+		Category: domain.BENIGN_RULE,
+		Strength: domain.STRONG,
+	}
+	evaluateHealthyAdults(ctx, result, variant, evidence)
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluateBS3(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("BS3", "Well-established functional studies show no damaging effect", domain.BENIGN_RULE, domain.STRONG), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "BS3",
+		Name:     "Well-established functional studies show no damaging effect",
+		Category: domain.BENIGN_RULE,
+		Strength: domain.STRONG,
+	}
+	evaluateLiterature(result, evidence, domain.SignalFunctionalNormal, "no damaging effect in functional studies")
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluateBS4(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("BS4", "Lack of segregation in affected members of a family", domain.BENIGN_RULE, domain.STRONG), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "BS4",
+		Name:     "Lack of segregation in affected members of a family",
+		Category: domain.BENIGN_RULE,
+		Strength: domain.STRONG,
+	}
+	evaluateSegregation(ctx, result, false)
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluateBP1(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("BP1", "Missense variant in gene for which truncating variants cause disease", domain.BENIGN_RULE, domain.SUPPORTING), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "BP1",
+		Name:     "Missense variant in gene for which truncating variants cause disease",
+		Category: domain.BENIGN_RULE,
+		Strength: domain.SUPPORTING,
+	}
+	evaluateMissenseConstraint(ctx, result, variant, evidence, false)
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluateBP2(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("BP2", "Observed in trans with pathogenic variant for fully penetrant dominant gene", domain.BENIGN_RULE, domain.SUPPORTING), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "BP2",
+		Name:     "Observed in trans with pathogenic variant for fully penetrant dominant gene",
+		Category: domain.BENIGN_RULE,
+		Strength: domain.SUPPORTING,
+	}
+	evaluateCisOrDominantTrans(ctx, result)
+	return result, nil
 }
 
 func (e *ACMGAMPRuleEngine) evaluateBP3(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
 	return e.createPlaceholderResult("BP3", "In-frame deletions/insertions in repetitive region", domain.BENIGN_RULE, domain.SUPPORTING), nil
 }
 
+// evaluateBP4 - At least two predictors benign and none deleterious
 func (e *ACMGAMPRuleEngine) evaluateBP4(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("BP4", "Multiple lines of computational evidence suggest no impact", domain.BENIGN_RULE, domain.SUPPORTING), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "BP4",
+		Name:     "Multiple lines of computational evidence suggest no impact",
+		Category: domain.BENIGN_RULE,
+		Strength: domain.SUPPORTING,
+	}
+
+	predictors := thresholdsFrom(ctx).Predictors
+	class := ClassifyConsequence(variant.Consequence, variant.HGVSCoding, variant.HGVSProtein)
+	altering, noImpact := splicingCalls(evidence.SplicingPredictions, predictors)
+	if len(altering) > 0 {
+		result.Reasoning = "Splicing predictors predict an effect on splicing: " + strings.Join(altering, ", ")
+		return result, nil
+	}
+	// Synonymous and intronic variants are judged on splicing predictions alone
+	if isSplicingOnlyVariant(variant, class) {
+		if len(noImpact) > 0 && len(noImpact) == len(evidence.SplicingPredictions) {
+			result.Applied = true
+			result.Confidence = 0.6
+			result.Evidence = "No splicing impact: " + strings.Join(noImpact, ", ")
+			result.Reasoning = fmt.Sprintf("Splicing predictors suggest no impact of %s variant", variantRegion(variant, class))
+		} else {
+			result.Reasoning = fmt.Sprintf("%d of %d splicing predictions suggest no impact; BP4 requires all to be below the splice cutoff", len(noImpact), len(evidence.SplicingPredictions))
+		}
+		return result, nil
+	}
+
+	if evidence.ComputationalData == nil {
+		result.Reasoning = "No computational prediction data available"
+		return result, nil
+	}
+
+	deleterious, benign := predictorCalls(evidence.ComputationalData, predictors)
+	if len(benign) >= 2 && len(deleterious) == 0 {
+		result.Applied = true
+		result.Confidence = 0.6
+		result.Evidence = "Benign: " + strings.Join(benign, ", ")
+		result.Reasoning = "Multiple in silico predictors suggest no impact"
+	} else {
+		result.Reasoning = fmt.Sprintf("%d benign and %d deleterious predictions; BP4 requires at least two concordant benign predictions", len(benign), len(deleterious))
+	}
+
+	return result, nil
+}
+
+// predictorCalls splits in silico scores into deleterious and benign calls,
+// skipping predictors that did not score the variant
+func predictorCalls(data *domain.ComputationalData, t thresholds.PredictorThresholds) (deleterious, benign []string) {
+	if data.Has(domain.PredictorCADD) {
+		switch {
+		case data.CADDScore >= t.CADDDeleterious:
+			deleterious = append(deleterious, fmt.Sprintf("CADD %.1f", data.CADDScore))
+		case data.CADDScore > 0 && data.CADDScore <= t.CADDBenign:
+			benign = append(benign, fmt.Sprintf("CADD %.1f", data.CADDScore))
+		}
+	}
+
+	if data.Has(domain.PredictorSIFT) {
+		if data.SIFTScore <= t.SIFTDeleterious {
+			deleterious = append(deleterious, fmt.Sprintf("SIFT %.2f", data.SIFTScore))
+		} else {
+			benign = append(benign, fmt.Sprintf("SIFT %.2f", data.SIFTScore))
+		}
+	}
+
+	if data.Has(domain.PredictorPolyPhen) {
+		switch {
+		case data.PolyPhenScore >= t.PolyPhenDeleterious:
+			deleterious = append(deleterious, fmt.Sprintf("PolyPhen %.3f", data.PolyPhenScore))
+		case data.PolyPhenScore <= t.PolyPhenBenign:
+			benign = append(benign, fmt.Sprintf("PolyPhen %.3f", data.PolyPhenScore))
+		}
+	}
+
+	if data.Has(domain.PredictorREVEL) {
+		revelDeleterious, revelBenign := t.REVELCutoffs()
+		switch {
+		case data.REVELScore >= revelDeleterious:
+			deleterious = append(deleterious, fmt.Sprintf("REVEL %.3f", data.REVELScore))
+		case data.REVELScore <= revelBenign:
+			benign = append(benign, fmt.Sprintf("REVEL %.3f", data.REVELScore))
+		}
+	}
+
+	if data.Has(domain.PredictorAlphaMissense) {
+		amDeleterious, amBenign := t.AlphaMissenseCutoffs()
+		switch {
+		case data.AlphaMissenseScore >= amDeleterious:
+			deleterious = append(deleterious, fmt.Sprintf("AlphaMissense %.3f", data.AlphaMissenseScore))
+		case data.AlphaMissenseScore < amBenign:
+			benign = append(benign, fmt.Sprintf("AlphaMissense %.3f", data.AlphaMissenseScore))
+		}
+	}
+
+	return deleterious, benign
 }
 
 func (e *ACMGAMPRuleEngine) evaluateBP5(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
@@ -502,8 +872,36 @@ func (e *ACMGAMPRuleEngine) evaluateBP6(ctx context.Context, variant *domain.Sta
 	return e.createPlaceholderResult("BP6", "Reputable source recently reports variant as benign", domain.BENIGN_RULE, domain.SUPPORTING), nil
 }
 
+// evaluateBP7 - Synonymous or deep intronic variant with no predicted splicing impact
 func (e *ACMGAMPRuleEngine) evaluateBP7(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*domain.ACMGAMPRuleResult, error) {
-	return e.createPlaceholderResult("BP7", "Synonymous variant with no predicted impact on splicing", domain.BENIGN_RULE, domain.SUPPORTING), nil
+	result := &domain.ACMGAMPRuleResult{
+		Code:     "BP7",
+		Name:     "Synonymous variant with no predicted impact on splicing",
+		Category: domain.BENIGN_RULE,
+		Strength: domain.SUPPORTING,
+	}
+
+	class := ClassifyConsequence(variant.Consequence, variant.HGVSCoding, variant.HGVSProtein)
+	if class != ConsequenceSynonymous && !isDeepIntronic(variant) {
+		result.Reasoning = "BP7 applies only to synonymous and deep intronic (+7 or beyond, -21 or beyond) variants"
+		return result, nil
+	}
+	if len(evidence.SplicingPredictions) == 0 {
+		result.Reasoning = "No splicing predictions available"
+		return result, nil
+	}
+
+	altering, noImpact := splicingCalls(evidence.SplicingPredictions, thresholdsFrom(ctx).Predictors)
+	if len(noImpact) == len(evidence.SplicingPredictions) {
+		result.Applied = true
+		result.Confidence = 0.6
+		result.Evidence = "No splicing impact: " + strings.Join(noImpact, ", ")
+		result.Reasoning = fmt.Sprintf("Splicing predictors suggest no impact of %s variant", variantRegion(variant, class))
+	} else {
+		result.Reasoning = fmt.Sprintf("%d splice-altering and %d no-impact predictions; BP7 requires all to be below the splice cutoff", len(altering), len(noImpact))
+	}
+
+	return result, nil
 }
 
 // createPlaceholderResult creates a default non-applied result for rules not yet implemented
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestRuleEngine_EvaluateAllRulesCancelled(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{GeneSymbol: "CFTR", HGVSCoding: "NM_000492.3:c.1521_1523delCTT"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := engine.EvaluateAllRules(ctx, variant, &domain.AggregatedEvidence{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, results, "no partial rule results")
+
+	results, err = engine.EvaluateAllRules(context.Background(), variant, &domain.AggregatedEvidence{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, results)
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/trio"
+)
+
+// Phases of another variant relative to the classified one, for
+// PhasedVariant.Phase
+const (
+	PhaseTrans   = "trans"
+	PhaseCis     = "cis"
+	PhaseUnknown = "unknown"
+)
+
+// Zygosities of the classified variant in the proband, for
+// PatientContext.Zygosity
+const (
+	ZygosityHeterozygous = "heterozygous"
+	ZygosityHomozygous   = "homozygous"
+	ZygosityHemizygous   = "hemizygous"
+)
+
+// Modes of inheritance of the disorder, for PatientContext.Inheritance
+const (
+	InheritanceAutosomalRecessive = "autosomal_recessive"
+	InheritanceAutosomalDominant  = "autosomal_dominant"
+	InheritanceXLinked            = "x_linked"
+)
+
+// PhasedVariant is another variant the proband carries in the same gene and
+// its phase relative to the classified variant, for PM3 and BP2
+type PhasedVariant struct {
+	Variant        string `json:"variant"`
+	Classification string `json:"classification"` // Classification of the other variant, e.g. Pathogenic
+	Phase          string `json:"phase"`          // trans, cis or unknown
+	PhaseBasis     string `json:"phase_basis,omitempty"`
+}
+
+// validate normalizes the phased variant and checks its values
+func (v *PhasedVariant) validate() error {
+	v.Variant = strings.TrimSpace(v.Variant)
+	if v.Variant == "" {
+		return fmt.Errorf("variant is required")
+	}
+	classification, err := domain.ParseClassification(v.Classification)
+	if err != nil {
+		return fmt.Errorf("variant %s: %w", v.Variant, err)
+	}
+	v.Classification = classification.Label()
+	v.Phase = strings.ToLower(strings.TrimSpace(v.Phase))
+	switch v.Phase {
+	case "":
+		v.Phase = PhaseUnknown
+	case PhaseTrans, PhaseCis, PhaseUnknown:
+	default:
+		return fmt.Errorf("variant %s: invalid phase %q (expected one of: trans, cis, unknown)", v.Variant, v.Phase)
+	}
+	return nil
+}
+
+// pathogenic reports whether the other variant is pathogenic or likely pathogenic
+func (v *PhasedVariant) pathogenic() bool {
+	return v.Classification == domain.PATHOGENIC.Label() || v.Classification == domain.LIKELY_PATHOGENIC.Label()
+}
+
+// inTransPoints scores the other variant on the ClinGen SVI PM3 point scale
+// (v1.0): in trans with a pathogenic variant scores 1, likely pathogenic
+// 0.5; with phase unknown they score 0.5 and 0.25. Variants in cis or not
+// pathogenic score 0.
+func (v *PhasedVariant) inTransPoints() float64 {
+	var points float64
+	switch v.Classification {
+	case domain.PATHOGENIC.Label():
+		points = 1
+	case domain.LIKELY_PATHOGENIC.Label():
+		points = 0.5
+	default:
+		return 0
+	}
+	switch v.Phase {
+	case PhaseTrans:
+		return points
+	case PhaseUnknown:
+		return points / 2
+	}
+	return 0
+}
+
+// PM3 point scale limits (ClinGen SVI v1.0)
+const (
+	homozygousPoints    = 0.5 // Per homozygous occurrence
+	maxHomozygousPoints = 1.0 // Homozygous occurrences together
+)
+
+// Kinds of PM3 observation, for domain.InTransObservation.Kind
+const (
+	ObservationInTrans      = "in_trans"
+	ObservationPhaseUnknown = "phase_unknown"
+	ObservationHomozygous   = "homozygous"
+	ObservationNone         = "none"
+)
+
+// Parental origins of a variant, for InTransCase
+const (
+	OriginMaternal = trio.Maternal
+	OriginPaternal = trio.Paternal
+	OriginDeNovo   = trio.DeNovo
+	OriginUnknown  = trio.Unknown
+)
+
+// InTransCase is another unrelated proband with the variant, from the lab's
+// cases or the literature, tallied for PM3. The phase with the other variant
+// is given, or inferred from the parents that transmitted the two variants.
+type InTransCase struct {
+	ProbandID           string `json:"proband_id,omitempty"`
+	Zygosity            string `json:"zygosity,omitempty"` // homozygous for a homozygous occurrence
+	OtherVariant        string `json:"other_variant,omitempty"`
+	OtherClassification string `json:"other_classification,omitempty"`
+	Phase               string `json:"phase,omitempty"`          // trans, cis or unknown
+	VariantOrigin       string `json:"variant_origin,omitempty"` // maternal, paternal, de_novo or unknown
+	OtherOrigin         string `json:"other_origin,omitempty"`
+	PhaseBasis          string `json:"phase_basis,omitempty"` // How the phase was determined
+}
+
+// validate normalizes the case, inferring the phase from parental origin
+// when it is not given
+func (c *InTransCase) validate() error {
+	c.ProbandID = strings.TrimSpace(c.ProbandID)
+	c.Zygosity = strings.ToLower(strings.TrimSpace(c.Zygosity))
+	switch c.Zygosity {
+	case "", ZygosityHeterozygous, ZygosityHomozygous, ZygosityHemizygous:
+	default:
+		return fmt.Errorf("invalid zygosity %q (expected one of: heterozygous, homozygous, hemizygous)", c.Zygosity)
+	}
+	for _, origin := range []*string{&c.VariantOrigin, &c.OtherOrigin} {
+		*origin = strings.ToLower(strings.TrimSpace(*origin))
+		switch *origin {
+		case "", OriginMaternal, OriginPaternal, OriginDeNovo, OriginUnknown:
+		default:
+			return fmt.Errorf("invalid origin %q (expected one of: maternal, paternal, de_novo, unknown)", *origin)
+		}
+	}
+	if strings.TrimSpace(c.OtherVariant) == "" {
+		if c.Zygosity != ZygosityHomozygous {
+			return fmt.Errorf("a case needs other_variant or zygosity homozygous")
+		}
+		return nil
+	}
+
+	phase := strings.TrimSpace(c.Phase)
+	if phase == "" && (c.VariantOrigin != "" || c.OtherOrigin != "") {
+		phase, c.PhaseBasis = trio.PhaseFromOrigin(c.VariantOrigin, c.OtherOrigin)
+	}
+	other := PhasedVariant{Variant: c.OtherVariant, Classification: c.OtherClassification, Phase: phase}
+	if err := other.validate(); err != nil {
+		return err
+	}
+	c.OtherVariant, c.OtherClassification, c.Phase = other.Variant, other.Classification, other.Phase
+	return nil
+}
+
+// bestObservation picks the highest scoring PM3 observation of a proband
+// with the given zygosity and other variants in the gene
+func bestObservation(probandID, zygosity string, others []PhasedVariant) domain.InTransObservation {
+	best := domain.InTransObservation{ProbandID: probandID, Kind: ObservationNone}
+	if zygosity == ZygosityHomozygous {
+		best.Kind, best.Points = ObservationHomozygous, homozygousPoints
+	}
+	for i := range others {
+		other := &others[i]
+		if points := other.inTransPoints(); points > best.Points {
+			best = domain.InTransObservation{
+				ProbandID:           probandID,
+				Kind:                ObservationInTrans,
+				OtherVariant:        other.Variant,
+				OtherClassification: other.Classification,
+				PhaseBasis:          other.PhaseBasis,
+				Points:              points,
+			}
+			if other.Phase == PhaseUnknown {
+				best.Kind = ObservationPhaseUnknown
+			}
+		}
+	}
+	return best
+}
+
+// InTransTally tallies the proband under classification and the other
+// cases on the ClinGen SVI PM3 point scale. A proband ID seen twice counts
+// once, with its best observation.
+func (p *PatientContext) InTransTally() *domain.InTransTally {
+	tally := &domain.InTransTally{Observations: []domain.InTransObservation{}}
+	if p.Zygosity != "" || len(p.PhasedVariants) > 0 {
+		tally.Observations = append(tally.Observations, bestObservation("", p.Zygosity, p.PhasedVariants))
+	}
+	seen := make(map[string]int)
+	for _, c := range p.InTransCases {
+		var others []PhasedVariant
+		if c.OtherVariant != "" {
+			others = []PhasedVariant{{Variant: c.OtherVariant, Classification: c.OtherClassification, Phase: c.Phase, PhaseBasis: c.PhaseBasis}}
+		}
+		observation := bestObservation(c.ProbandID, c.Zygosity, others)
+		if i, ok := seen[c.ProbandID]; ok && c.ProbandID != "" {
+			if observation.Points > tally.Observations[i].Points {
+				tally.Observations[i] = observation
+			}
+			continue
+		}
+		seen[c.ProbandID] = len(tally.Observations)
+		tally.Observations = append(tally.Observations, observation)
+	}
+
+	tally.Probands = len(tally.Observations)
+	for _, observation := range tally.Observations {
+		if observation.Kind == ObservationHomozygous {
+			tally.HomozygousPoints += observation.Points
+		} else {
+			tally.Points += observation.Points
+		}
+	}
+	tally.HomozygousPoints = math.Min(tally.HomozygousPoints, maxHomozygousPoints)
+	tally.Points += tally.HomozygousPoints
+	return tally
+}
+
+// evaluateInTrans applies PM3 for a recessive disorder from the tally of
+// probands carrying the variant homozygously or with a pathogenic variant
+// in trans, at the strength the ClinGen SVI point scale gives: 0.5
+// supporting, 1 moderate, 2 strong and 4 very strong
+func evaluateInTrans(ctx context.Context, result *domain.ACMGAMPRuleResult) {
+	patient := patientContextFrom(ctx)
+	if patient == nil || (patient.Zygosity == "" && len(patient.PhasedVariants) == 0 && len(patient.InTransCases) == 0) {
+		result.Reasoning = "Zygosity and other variants in the gene not provided; pass patient_context.zygosity, patient_context.phased_variants or patient_context.in_trans_cases to assess PM3"
+		return
+	}
+	if patient.Inheritance != InheritanceAutosomalRecessive {
+		if patient.Inheritance == "" {
+			result.Reasoning = "Mode of inheritance not provided; PM3 applies to recessive disorders only"
+		} else {
+			result.Reasoning = fmt.Sprintf("PM3 applies to recessive disorders; the disorder is %s", strings.ReplaceAll(patient.Inheritance, "_", " "))
+		}
+		return
+	}
+
+	tally := patient.InTransTally()
+	result.InTrans = tally
+	result.Evidence = fmt.Sprintf("%s: %.3g points", summarizeInTrans(tally), tally.Points)
+	strength := deNovoStrength(tally.Points)
+	if strength == "" {
+		result.Reasoning = fmt.Sprintf("Tally scores %.3g points on the ClinGen SVI PM3 scale, below supporting", tally.Points)
+		return
+	}
+	result.Applied = true
+	result.Strength = strength
+	result.Confidence = 0.8
+	result.Reasoning = fmt.Sprintf("Tally scores %.3g points on the ClinGen SVI PM3 scale (%s)", tally.Points, strings.ToLower(strength.Label()))
+	if tally.HomozygousPoints == maxHomozygousPoints {
+		result.Reasoning += "; homozygous occurrences capped at 1 point"
+	}
+}
+
+// summarizeInTrans describes the observations of a tally, e.g. "3 probands:
+// 2 in trans with a pathogenic variant, 1 homozygous"
+func summarizeInTrans(tally *domain.InTransTally) string {
+	counts := make(map[string]int)
+	for _, observation := range tally.Observations {
+		key := observation.Kind
+		if observation.Kind == ObservationInTrans || observation.Kind == ObservationPhaseUnknown {
+			key += " " + strings.ToLower(observation.OtherClassification)
+		}
+		counts[key]++
+	}
+	var parts []string
+	for _, kind := range []struct{ key, label string }{
+		{ObservationInTrans + " pathogenic", "in trans with a pathogenic variant"},
+		{ObservationInTrans + " likely pathogenic", "in trans with a likely pathogenic variant"},
+		{ObservationPhaseUnknown + " pathogenic", "with a pathogenic variant, phase unknown"},
+		{ObservationPhaseUnknown + " likely pathogenic", "with a likely pathogenic variant, phase unknown"},
+		{ObservationHomozygous, "homozygous"},
+		{ObservationNone, "not scoring"},
+	} {
+		if n := counts[kind.key]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, kind.label))
+		}
+	}
+	noun := "probands"
+	if tally.Probands == 1 {
+		noun = "proband"
+	}
+	return fmt.Sprintf("%d %s: %s", tally.Probands, noun, strings.Join(parts, ", "))
+}
+
+// evaluateCisOrDominantTrans applies BP2 when the variant is in cis with a
+// pathogenic variant, or in trans with one in a dominant disorder, where a
+// second pathogenic allele is not expected to be tolerated. Full penetrance
+// of the dominant disorder is taken as given.
+func evaluateCisOrDominantTrans(ctx context.Context, result *domain.ACMGAMPRuleResult) {
+	patient := patientContextFrom(ctx)
+	if patient == nil || len(patient.PhasedVariants) == 0 {
+		result.Reasoning = "Other variants in the gene not provided; pass patient_context.phased_variants to assess BP2"
+		return
+	}
+
+	for i := range patient.PhasedVariants {
+		other := &patient.PhasedVariants[i]
+		if !other.pathogenic() {
+			continue
+		}
+		switch {
+		case other.Phase == PhaseCis:
+			result.Evidence = fmt.Sprintf("In cis with %s variant %s", strings.ToLower(other.Classification), other.Variant)
+			result.Reasoning = "Observed in cis with a pathogenic variant"
+		case other.Phase == PhaseTrans && patient.Inheritance == InheritanceAutosomalDominant:
+			result.Evidence = fmt.Sprintf("In trans with %s variant %s in a dominant disorder", strings.ToLower(other.Classification), other.Variant)
+			result.Reasoning = "Observed in trans with a pathogenic variant in a dominant disorder, assumed fully penetrant"
+		default:
+			continue
+		}
+		if other.PhaseBasis != "" {
+			result.Evidence += " (" + other.PhaseBasis + ")"
+		}
+		result.Applied = true
+		result.Confidence = 0.7
+		return
+	}
+	result.Reasoning = "No pathogenic variant in cis, or in trans in a dominant disorder"
+}
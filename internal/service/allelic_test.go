@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestPhasedVariant_InTransPoints(t *testing.T) {
+	tests := []struct {
+		classification string
+		phase          string
+		points         float64
+	}{
+		{"Pathogenic", PhaseTrans, 1},
+		{"Likely pathogenic", PhaseTrans, 0.5},
+		{"Pathogenic", PhaseUnknown, 0.5},
+		{"Likely pathogenic", PhaseUnknown, 0.25},
+		{"Pathogenic", PhaseCis, 0},
+		{"Uncertain significance", PhaseTrans, 0},
+	}
+	for _, tt := range tests {
+		other := &PhasedVariant{Variant: "CFTR:p.Phe508del", Classification: tt.classification, Phase: tt.phase}
+		assert.Equal(t, tt.points, other.inTransPoints(), tt.classification+" "+tt.phase)
+	}
+}
+
+func TestPatientContext_ValidatePhasedVariants(t *testing.T) {
+	patient := &PatientContext{
+		Zygosity:       " Heterozygous",
+		Inheritance:    "AUTOSOMAL_RECESSIVE",
+		PhasedVariants: []PhasedVariant{{Variant: " CFTR:p.Phe508del ", Classification: "lp"}},
+	}
+	require.NoError(t, patient.Validate())
+	assert.Equal(t, ZygosityHeterozygous, patient.Zygosity)
+	assert.Equal(t, InheritanceAutosomalRecessive, patient.Inheritance)
+	assert.Equal(t, PhasedVariant{Variant: "CFTR:p.Phe508del", Classification: "Likely pathogenic", Phase: PhaseUnknown}, patient.PhasedVariants[0])
+
+	assert.Error(t, (&PatientContext{Zygosity: "compound"}).Validate())
+	assert.Error(t, (&PatientContext{Inheritance: "mitochondrial"}).Validate())
+	assert.Error(t, (&PatientContext{PhasedVariants: []PhasedVariant{{Classification: "Pathogenic"}}}).Validate())
+	assert.Error(t, (&PatientContext{PhasedVariants: []PhasedVariant{{Variant: "CFTR:p.Gly551Asp", Classification: "bad"}}}).Validate())
+	assert.Error(t, (&PatientContext{PhasedVariants: []PhasedVariant{{Variant: "CFTR:p.Gly551Asp", Classification: "Pathogenic", Phase: "both"}}}).Validate())
+}
+
+func TestPatientContext_InTransTally(t *testing.T) {
+	patient := &PatientContext{
+		Zygosity:       ZygosityHeterozygous,
+		PhasedVariants: []PhasedVariant{{Variant: "CFTR:p.Phe508del", Classification: "Pathogenic", Phase: PhaseUnknown}},
+		InTransCases: []InTransCase{
+			{ProbandID: "P1", OtherVariant: "CFTR:p.Gly551Asp", OtherClassification: "Pathogenic", VariantOrigin: "maternal", OtherOrigin: "paternal"},
+			{ProbandID: "P1", OtherVariant: "CFTR:p.Gly551Asp", OtherClassification: "Pathogenic", Phase: PhaseUnknown},
+			{ProbandID: "P2", OtherVariant: "CFTR:p.Asn1303Lys", OtherClassification: "likely pathogenic", Phase: PhaseTrans},
+			{ProbandID: "P3", OtherVariant: "CFTR:p.Arg553Ter", OtherClassification: "Pathogenic", VariantOrigin: "maternal", OtherOrigin: "maternal"},
+			{ProbandID: "P4", Zygosity: "homozygous"},
+			{ProbandID: "P5", Zygosity: "homozygous"},
+			{ProbandID: "P6", Zygosity: "homozygous"},
+		},
+	}
+	require.NoError(t, patient.Validate())
+	assert.Equal(t, PhaseTrans, patient.InTransCases[0].Phase, "phase is inferred from parental origin")
+	assert.Equal(t, "inherited from different parents", patient.InTransCases[0].PhaseBasis)
+	assert.Equal(t, PhaseCis, patient.InTransCases[3].Phase)
+
+	tally := patient.InTransTally()
+	assert.Equal(t, 7, tally.Probands, "P1 counts once")
+	assert.Equal(t, domain.InTransObservation{Kind: ObservationPhaseUnknown, OtherVariant: "CFTR:p.Phe508del", OtherClassification: "Pathogenic", Points: 0.5}, tally.Observations[0])
+	assert.Equal(t, 1.0, tally.Observations[1].Points, "P1 keeps its best observation")
+	assert.Equal(t, ObservationNone, tally.Observations[3].Kind, "in cis does not score")
+	assert.Equal(t, 1.0, tally.HomozygousPoints, "three homozygous occurrences are capped at 1 point")
+	assert.Equal(t, 0.5+1+0.5+0+1, tally.Points)
+	assert.Equal(t, domain.STRONG, deNovoStrength(tally.Points))
+	assert.Equal(t, "7 probands: 1 in trans with a pathogenic variant, 1 in trans with a likely pathogenic variant, 1 with a pathogenic variant, phase unknown, 3 homozygous, 1 not scoring", summarizeInTrans(tally))
+
+	assert.Error(t, (&PatientContext{InTransCases: []InTransCase{{ProbandID: "P1"}}}).Validate(), "a case needs a second variant or homozygosity")
+	assert.Error(t, (&PatientContext{InTransCases: []InTransCase{{OtherVariant: "CFTR:p.Gly551Asp", OtherClassification: "Pathogenic", VariantOrigin: "mother"}}}).Validate())
+}
+
+func TestRuleEngine_Allelic(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{GeneSymbol: "CFTR", HGVSProtein: "p.Arg117His"}
+	evaluate := func(code string, patient *PatientContext) *domain.ACMGAMPRuleResult {
+		t.Helper()
+		result, err := engine.EvaluateRule(withPatientContext(context.Background(), patient), code, variant, &domain.AggregatedEvidence{})
+		require.NoError(t, err)
+		return result
+	}
+	partner := func(classification, phase string) []PhasedVariant {
+		return []PhasedVariant{{Variant: "CFTR:p.Phe508del", Classification: classification, Phase: phase}}
+	}
+
+	result := evaluate("PM3", nil)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "patient_context.phased_variants")
+
+	inTrans := &PatientContext{Inheritance: InheritanceAutosomalRecessive, PhasedVariants: partner("Pathogenic", PhaseTrans)}
+	result = evaluate("PM3", inTrans)
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.MODERATE, result.Strength)
+	assert.Equal(t, "1 proband: 1 in trans with a pathogenic variant: 1 points", result.Evidence)
+	require.NotNil(t, result.InTrans)
+	assert.Equal(t, 1.0, result.InTrans.Points)
+	assert.False(t, evaluate("BP2", inTrans).Applied, "trans in a recessive disorder is not benign evidence")
+
+	unphased := &PatientContext{Inheritance: InheritanceAutosomalRecessive, PhasedVariants: partner("Pathogenic", PhaseUnknown)}
+	result = evaluate("PM3", unphased)
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.SUPPORTING, result.Strength)
+
+	homozygous := &PatientContext{Zygosity: ZygosityHomozygous, Inheritance: InheritanceAutosomalRecessive}
+	result = evaluate("PM3", homozygous)
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.SUPPORTING, result.Strength)
+
+	assert.False(t, evaluate("PM3", &PatientContext{Inheritance: InheritanceAutosomalRecessive, PhasedVariants: partner("Likely pathogenic", PhaseUnknown)}).Applied)
+	assert.False(t, evaluate("PM3", &PatientContext{Inheritance: InheritanceAutosomalDominant, PhasedVariants: partner("Pathogenic", PhaseTrans)}).Applied)
+	result = evaluate("PM3", &PatientContext{PhasedVariants: partner("Pathogenic", PhaseTrans)})
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "Mode of inheritance not provided")
+
+	result = evaluate("BP2", &PatientContext{Inheritance: InheritanceAutosomalRecessive, PhasedVariants: partner("Pathogenic", PhaseCis)})
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.SUPPORTING, result.Strength)
+	assert.Contains(t, result.Evidence, "In cis")
+	result = evaluate("BP2", &PatientContext{Inheritance: InheritanceAutosomalDominant, PhasedVariants: partner("Likely pathogenic", PhaseTrans)})
+	assert.True(t, result.Applied)
+	assert.Contains(t, result.Evidence, "dominant disorder")
+	assert.False(t, evaluate("BP2", &PatientContext{Inheritance: InheritanceAutosomalDominant, PhasedVariants: partner("Pathogenic", PhaseUnknown)}).Applied)
+	assert.False(t, evaluate("BP2", &PatientContext{PhasedVariants: partner("Uncertain significance", PhaseCis)}).Applied)
+
+	cases := &PatientContext{Inheritance: InheritanceAutosomalRecessive, InTransCases: make([]InTransCase, 4)}
+	for i := range cases.InTransCases {
+		cases.InTransCases[i] = InTransCase{OtherVariant: "CFTR:p.Phe508del", OtherClassification: "Pathogenic", Phase: PhaseTrans}
+	}
+	result = evaluate("PM3", cases)
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.VERY_STRONG, result.Strength, "four probands in trans with a pathogenic variant")
+	assert.Equal(t, 4, result.InTrans.Probands)
+}
@@ -7,11 +7,28 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/acmg-amp-mcp-server/internal/conflicts"
 	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/metrics"
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+	"github.com/acmg-amp-mcp-server/internal/regions"
+	"github.com/acmg-amp-mcp-server/internal/vrs"
 	"github.com/acmg-amp-mcp-server/pkg/external"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
 )
 
+// EngineVersion identifies the classification engine release. It is recorded
+// with every audited classification alongside the rule configuration used.
+const EngineVersion = "v0.1.0"
+
+// tracer records the classification pipeline's spans
+var tracer = otel.Tracer("github.com/acmg-amp-mcp-server/internal/service")
+
 // ClassifierService implements ACMG/AMP variant classification
 type ClassifierService struct {
 	logger              *logrus.Logger
@@ -19,6 +36,16 @@ type ClassifierService struct {
 	inputParser         domain.InputParser
 	transcriptResolver  domain.GeneTranscriptResolver
 	ruleEngine          *ACMGAMPRuleEngine
+	scoringMode         ScoringMode
+	regions             RegionSource
+	transcriptSets      TranscriptSetSource
+	configVersion       ConfigVersionSource
+	datasetVersions     DatasetVersionSource
+	normalizer          VariantNormalizer
+	consequenceAnnotator ConsequenceAnnotator
+	somaticSources      []external.SomaticEvidenceClient
+	vrs                 VRSTranslator
+	resultCache         *ResultCache
 }
 
 // NewClassifierService creates a new classifier service
@@ -34,6 +61,7 @@ func NewClassifierService(
 		inputParser:         inputParser,
 		transcriptResolver:  transcriptResolver,
 		ruleEngine:          NewACMGAMPRuleEngine(logger),
+		scoringMode:         ScoringModeCombiningRules,
 	}
 }
 
@@ -45,6 +73,15 @@ func (c *ClassifierService) ClassifyVariant(ctx context.Context, params *Classif
 	if err := c.validateNotationInput(params); err != nil {
 		return nil, fmt.Errorf("invalid input parameters: %w", err)
 	}
+	classificationContext, err := ParseClassificationContext(params.ClassificationContext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input parameters: %w", err)
+	}
+	if params.PatientContext != nil {
+		if err := params.PatientContext.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid input parameters: %w", err)
+		}
+	}
 	
 	// Determine input type and log accordingly
 	inputType, inputValue := c.determineInputType(params)
@@ -59,28 +96,240 @@ func (c *ClassifierService) ClassifyVariant(ctx context.Context, params *Classif
 		return nil, fmt.Errorf("failed to prepare variant for classification: %w", err)
 	}
 
+	// Repeat expansions and balanced structural variants are outside the
+	// sequence variant criteria and are returned for manual review
+	if variant.Structural != nil {
+		return c.classifyStructural(variant, hgvsNotation, startTime), nil
+	}
+
+	variant.TranscriptConsequences = params.TranscriptConsequences
+
+	// Step 1a: Normalize against reference transcripts and the genome
+	_, normalizeSpan := tracer.Start(ctx, "hgvs.normalize", trace.WithAttributes(attribute.String("hgvs", hgvsNotation)))
+	normalized, normalizeErr := c.normalizeVariant(variant, hgvsNotation)
+	if normalizeErr != nil {
+		normalizeSpan.RecordError(normalizeErr)
+		normalizeSpan.SetStatus(codes.Error, normalizeErr.Error())
+	}
+	normalizeSpan.End()
+	if normalizeErr != nil {
+		c.logger.WithError(normalizeErr).WithField("hgvs_notation", hgvsNotation).Warn("Failed to normalize HGVS notation")
+	}
+
+	// Select the rule set version and scoring mode: per request, falling back
+	// to the version's own mode and then the service default
+	ruleVersion, scoringMode, err := resolveRuleVersion(params.RulesVersion, params.ScoringMode, c.scoringMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input parameters: %w", err)
+	}
+	ctx = withRuleVersion(ctx, ruleVersion)
+	ctx = withScoringMode(ctx, scoringMode)
+	ctx = withCondition(ctx, params.Condition)
+	ctx = withPatientContext(ctx, params.PatientContext)
+	ctx, thresholdRevision := c.ruleEngine.withThresholds(ctx)
+	datasetVersions := c.datasetVersionSet()
+
+	// Serve a memoized result of the same variant under the same rules and
+	// dataset versions, unless fresh evidence was asked for
+	var memoKey string
+	if c.resultCache != nil && memoizable(params) {
+		key, err := c.memoKey(ctx, resultKey{
+			Variant:                hgvsNotation,
+			RulesVersion:           ruleVersion.Name,
+			DatasetVersions:        datasetVersions,
+			EngineVersion:          EngineVersion,
+			ScoringMode:            scoringMode,
+			ThresholdRevision:      thresholdRevision,
+			ConfigCommit:           c.configCommit(),
+			ClassificationContext:  classificationContext,
+			TranscriptID:           params.TranscriptID,
+			PreferredIsoform:       params.PreferredIsoform,
+			OrderingSpecialty:      params.OrderingSpecialty,
+			Condition:              params.Condition,
+			TumorType:              params.TumorType,
+			TranscriptConsequences: params.TranscriptConsequences,
+		}, normalized)
+		if err != nil {
+			c.logger.WithError(err).Warn("Failed to get lab knowledge base revision; the classification is not memoized")
+		}
+		memoKey = key
+	}
+	if memoKey != "" && !params.RefreshEvidence {
+		if memoized, ok := c.resultCache.get(memoKey); ok {
+			result := servedResult(memoized, params, hgvsNotation, startTime)
+			metrics.ObserveClassification(classificationContext, result.Classification, result.ProcessingTime)
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String("classification", result.Classification))
+			c.logger.WithFields(logrus.Fields{
+				"variant_id":     result.VariantID,
+				"classification": result.Classification,
+			}).Debug("Served memoized classification")
+			return result, nil
+		}
+	}
+
+	// Step 1b: Annotate on the overlapping transcripts, MANE Select first
+	if err := c.annotateTranscripts(ctx, variant, normalized); err != nil {
+		c.logger.WithError(err).WithField("hgvs_notation", hgvsNotation).Warn("Failed to annotate transcript consequences")
+	}
+	transcriptConsequences := variant.TranscriptConsequences
+
+	// Step 1c: Interpret on the transcript mandated by the ordering specialty
+	specialtyTranscript, err := c.applySpecialtyTranscript(params, variant)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input parameters: %w", err)
+	}
+
 	// Step 2: Gather evidence from external databases
-	evidence, err := c.knowledgeBaseService.GatherEvidence(ctx, variant)
+	if params.RefreshEvidence {
+		if err := c.knowledgeBaseService.InvalidateCache(ctx, variant); err != nil {
+			c.logger.WithError(err).WithField("hgvs_notation", hgvsNotation).Warn("Failed to drop cached evidence")
+		}
+	}
+	gatherCtx, gatherSpan := tracer.Start(ctx, "evidence.gather")
+	evidence, err := c.knowledgeBaseService.GatherEvidence(gatherCtx, variant)
+	if err != nil {
+		gatherSpan.RecordError(err)
+		gatherSpan.SetStatus(codes.Error, err.Error())
+	}
+	gatherSpan.End()
+	if ctx.Err() != nil {
+		// Cancelled: whatever evidence arrived is incomplete
+		return nil, fmt.Errorf("classification cancelled: %w", ctx.Err())
+	}
 	if err != nil {
 		c.logger.WithError(err).Warn("Failed to gather complete evidence, proceeding with available data")
+	}
+	if evidence == nil {
 		// Continue with partial evidence
 		evidence = &domain.AggregatedEvidence{}
 	}
 
-	// Step 3: Apply ACMG/AMP rules
-	ruleResults, err := c.ruleEngine.EvaluateAllRules(ctx, variant, evidence)
+	// Somatic variants are tiered on their clinical significance in cancer
+	// rather than classified with the germline criteria
+	if classificationContext == ContextSomatic {
+		result := c.classifySomatic(ctx, params, variant, hgvsNotation, evidence, normalized, startTime)
+		result.DatasetVersions = datasetVersions
+		markProvisional(result, evidence)
+		c.memoize(memoKey, result)
+		metrics.ObserveClassification(ContextSomatic, result.Classification, time.Since(startTime))
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("classification", result.Classification))
+		return result, nil
+	}
+
+	// Steps 3-6: Apply the rules, classify and summarize
+	result, ruleResults, err := c.evaluateGermline(ctx, germlineEvaluation{
+		params:                 params,
+		variant:                variant,
+		hgvsNotation:           hgvsNotation,
+		evidence:               evidence,
+		normalized:             normalized,
+		normalizeErr:           normalizeErr,
+		transcriptConsequences: transcriptConsequences,
+		specialtyTranscript:    specialtyTranscript,
+		scoringMode:            scoringMode,
+		datasetVersions:        datasetVersions,
+		startTime:              startTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.memoize(memoKey, result)
+
+	metrics.ObserveClassification(ContextGermline, result.Classification, result.ProcessingTime)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("classification", result.Classification))
+	for _, rule := range ruleResults {
+		metrics.ObserveRule(rule.Code, rule.Applied)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"variant_id":      result.VariantID,
+		"classification":  result.Classification,
+		"confidence":      result.Confidence,
+		"processing_time": result.ProcessingTime,
+		"rules_applied":   len(result.AppliedRules),
+		"input_type":      inputType,
+	}).Info("Variant classification completed")
+
+	return result, nil
+}
+
+// germlineEvaluation is the variant and evidence the germline criteria are
+// evaluated against, gathered live by ClassifyVariant or read back from an
+// evidence bundle by ReplayClassification
+type germlineEvaluation struct {
+	params                 *ClassifyVariantParams
+	variant                *domain.StandardizedVariant
+	hgvsNotation           string
+	evidence               *domain.AggregatedEvidence
+	normalized             *domain.NormalizedVariant
+	normalizeErr           error
+	transcriptConsequences []domain.TranscriptConsequence
+	specialtyTranscript    *SpecialtyTranscript
+	scoringMode            ScoringMode
+	datasetVersions        provenance.Versions
+	startTime              time.Time
+}
+
+// evaluateGermline applies the ACMG/AMP criteria to gathered evidence and
+// builds the classification result, with the evidence bundle it was made from
+func (c *ClassifierService) evaluateGermline(ctx context.Context, eval germlineEvaluation) (*ClassifyVariantResult, []domain.ACMGAMPRuleResult, error) {
+	variant, hgvsNotation, evidence := eval.variant, eval.hgvsNotation, eval.evidence
+	normalized, normalizeErr := eval.normalized, eval.normalizeErr
+	transcriptConsequences, specialtyTranscript := eval.transcriptConsequences, eval.specialtyTranscript
+	scoringMode, datasetVersions, startTime := eval.scoringMode, eval.datasetVersions, eval.startTime
+
+	// Step 3: Apply ACMG/AMP rules with the thresholds in effect now
+	ctx, thresholdRevision := c.ruleEngine.withThresholds(ctx)
+	baseThresholds := thresholdsFrom(ctx)
+	configCommit := c.configCommit()
+	_, frequencyThresholds := c.ruleEngine.withFrequencyThresholds(ctx, variant, c.ruleEngine.specificationFor(variant))
+	ruleResults, conflictDecisions, err := c.ruleEngine.evaluateRules(ctx, variant, evidence)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to evaluate ACMG/AMP rules: %w", err)
+	}
+
+	// Step 4: Combine evidence with the combining rules or point-based scoring
+	classification, confidence := c.ruleEngine.classify(ctx, ruleResults)
+
+	// Step 4b: Re-evaluate per transcript when consequences are discordant
+	multiTranscript, err := c.assessTranscripts(ctx, variant, evidence)
 	if err != nil {
-		return nil, fmt.Errorf("failed to evaluate ACMG/AMP rules: %w", err)
+		return nil, nil, fmt.Errorf("failed to evaluate transcript consequences: %w", err)
+	}
+	if multiTranscript != nil {
+		ruleResults = multiTranscript.ruleResults
+		conflictDecisions = multiTranscript.conflicts
+		classification, confidence = multiTranscript.classification, multiTranscript.confidence
 	}
 
-	// Step 4: Combine evidence according to ACMG/AMP guidelines
-	classification, confidence := c.ruleEngine.CombineEvidence(ruleResults)
+	// Step 4c: Lower confidence for variants in problematic regions
+	regionCaveats := c.regionCaveats(variant)
+	if len(regionCaveats) > 0 {
+		confidence = downgradeConfidence(confidence)
+	}
 
 	// Step 5: Generate recommendations
 	recommendations := c.generateRecommendations(classification, confidence, evidence)
+	if VUSTierFor(classification, PointTotal(ruleResults)) == VUSTierHot {
+		recommendations = append(recommendations, "Hot VUS: one moderate pathogenic criterion short of likely pathogenic; prioritize segregation, functional or de novo evidence")
+	}
+	recommendations = append(recommendations, conflictRecommendations(conflictDecisions)...)
+	if multiTranscript != nil {
+		recommendations = append(recommendations, "Consequence is discordant across clinically relevant transcripts; confirm the transcript of clinical relevance for the indication")
+	}
+	for _, caveat := range regionCaveats {
+		recommendations = append(recommendations, caveat.Message)
+	}
+	if rec := specialtyTranscript.Recommendation(); rec != "" {
+		recommendations = append(recommendations, rec)
+	}
+	if normalizeErr != nil {
+		recommendations = append(recommendations, fmt.Sprintf("Could not normalize %s (%v); verify the notation against the reference sequence", hgvsNotation, normalizeErr))
+	}
 
 	// Step 6: Create evidence summary
 	evidenceSummary := c.generateEvidenceSummary(ruleResults, evidence)
+	points := PointTotal(ruleResults)
 
 	result := &ClassifyVariantResult{
 		VariantID:       variant.ID,
@@ -91,24 +340,89 @@ func (c *ClassifierService) ClassifyVariant(ctx context.Context, params *Classif
 		Recommendations: recommendations,
 		ProcessingTime:  time.Since(startTime),
 		InputNotation:   hgvsNotation, // Store the final HGVS notation used
+		MultiTranscript: multiTranscript,
+		ThresholdRevision: thresholdRevision,
+		ConfigCommit:    configCommit,
+		DatasetVersions: datasetVersions,
+		FrequencyThresholds: frequencyThresholds,
+		ConflictingEvidence: conflictDecisions,
+		ScoringMode:     string(scoringMode),
+		PointTotal:      points,
+		ConfidenceScore: ConfidenceScore(classification, points),
+		PosteriorProbability: PosteriorProbability(points),
+		VUSTier:         VUSTierFor(classification, points),
+		RegionCaveats:   regionCaveats,
+		SpecialtyTranscript: specialtyTranscript,
+		Normalized:      normalized,
+		VRS:             c.vrsAllele(normalized),
+		Transcript:      variant.TranscriptID,
+		TranscriptConsequences: transcriptConsequences,
+		Evidence:        evidence,
+		ClassificationContext: ContextGermline,
 	}
+	if spec := c.ruleEngine.specificationFor(variant); spec != nil {
+		result.Specification = spec.Label()
+	}
+	if version := ruleVersionFrom(ctx); version != nil {
+		result.RulesVersion = version.Name
+	}
+	result.WeightingPolicy = c.ruleEngine.weightingPolicyResult(ruleResults)
+	result.Bundle = &EvidenceBundle{
+		Params:                 eval.params,
+		HGVSNotation:           hgvsNotation,
+		Variant:                variant,
+		Evidence:               evidence,
+		ResidueLookup:          evidence.ResidueVariants != nil,
+		Normalized:             normalized,
+		TranscriptConsequences: transcriptConsequences,
+		SpecialtyTranscript:    specialtyTranscript,
+		ScoringMode:            string(scoringMode),
+		RulesVersion:           result.RulesVersion,
+		Thresholds:             baseThresholds,
+		ThresholdRevision:      thresholdRevision,
+		EngineVersion:          EngineVersion,
+		Specification:          result.Specification,
+		ConfigCommit:           configCommit,
+		DatasetVersions:        datasetVersions,
+		Classification:         result.Classification,
+		Confidence:             result.Confidence,
+		PointTotal:             points,
+		AppliedRules:           result.AppliedRules,
+	}
+	if normalizeErr != nil {
+		result.Bundle.NormalizationError = normalizeErr.Error()
+	}
+	markProvisional(result, evidence)
 
-	c.logger.WithFields(logrus.Fields{
-		"variant_id":      result.VariantID,
-		"classification":  result.Classification,
-		"confidence":      result.Confidence,
-		"processing_time": result.ProcessingTime,
-		"rules_applied":   len(result.AppliedRules),
-		"input_type":      inputType,
-	}).Info("Variant classification completed")
-
-	return result, nil
+	return result, ruleResults, nil
 }
 
 // ValidateHGVS validates HGVS notation and returns normalized form
 func (c *ClassifierService) ValidateHGVS(hgvsNotation string) (*HGVSValidationResult, error) {
 	c.logger.WithField("hgvs_notation", hgvsNotation).Debug("Validating HGVS notation")
 
+	if hgvs.IsStructural(hgvsNotation) {
+		variant, err := parseStructuralVariant(hgvsNotation)
+		if err != nil {
+			return &HGVSValidationResult{
+				IsValid:      false,
+				ErrorMessage: err.Error(),
+			}, nil
+		}
+		result := &HGVSValidationResult{
+			IsValid:        true,
+			NormalizedHGVS: variant.Structural.Notation,
+			VariantType:    string(variant.Structural.Kind),
+			GeneSymbol:     variant.GeneSymbol,
+			TranscriptID:   variant.TranscriptID,
+			Structural:     variant.Structural,
+		}
+		if variant.Chromosome != "" {
+			result.GenomicPosition = fmt.Sprintf("chr%s:g.%d", variant.Chromosome, variant.Position)
+		}
+		return result, nil
+	}
+
 	// Parse the variant
 	variant, err := c.inputParser.ParseVariant(hgvsNotation)
 	if err != nil {
@@ -118,15 +432,31 @@ func (c *ClassifierService) ValidateHGVS(hgvsNotation string) (*HGVSValidationRe
 		}, nil
 	}
 
-	return &HGVSValidationResult{
+	result := &HGVSValidationResult{
 		IsValid:           true,
 		NormalizedHGVS:    variant.HGVSCoding, // Use the parsed/normalized version
 		VariantType:       variant.VariantType.String(),
 		GeneSymbol:        variant.GeneSymbol,
 		TranscriptID:      variant.TranscriptID,
-		GenomicPosition:   fmt.Sprintf("chr%s:g.%d", variant.Chromosome, variant.Position),
 		PredictedProtein:  variant.HGVSProtein,
-	}, nil
+	}
+
+	// Normalize against reference transcripts and the genome when configured
+	normalized, err := c.normalizeVariant(variant, hgvsNotation)
+	if err != nil {
+		result.NormalizationError = err.Error()
+	} else if normalized != nil {
+		result.Normalized = normalized
+		result.NormalizedHGVS = normalized.HGVSCoding
+		if result.NormalizedHGVS == "" {
+			result.NormalizedHGVS = normalized.HGVSGenomic
+		}
+		result.TranscriptID = variant.TranscriptID
+		result.GeneSymbol = variant.GeneSymbol
+	}
+	result.GenomicPosition = fmt.Sprintf("chr%s:g.%d", variant.Chromosome, variant.Position)
+
+	return result, nil
 }
 
 // ApplyRule applies a specific ACMG/AMP rule to a variant
@@ -148,8 +478,13 @@ func (c *ClassifierService) ApplyRule(ctx context.Context, params *ApplyRulePara
 		evidence = params.Evidence
 	} else {
 		evidence, err = c.knowledgeBaseService.GatherEvidence(ctx, variant)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("rule evaluation cancelled: %w", ctx.Err())
+		}
 		if err != nil {
 			c.logger.WithError(err).Warn("Failed to gather evidence for rule evaluation")
+		}
+		if evidence == nil {
 			evidence = &domain.AggregatedEvidence{}
 		}
 	}
@@ -161,21 +496,36 @@ func (c *ClassifierService) ApplyRule(ctx context.Context, params *ApplyRulePara
 	}
 
 	return &RuleEvaluationResult{
-		RuleCode:    ruleResult.Code,
-		RuleName:    ruleResult.Name,
-		Category:    ruleResult.Category.String(),
-		Strength:    ruleResult.Strength.String(),
-		Applied:     ruleResult.Applied,
-		Confidence:  ruleResult.Confidence,
-		Evidence:    ruleResult.Evidence,
-		Reasoning:   ruleResult.Reasoning,
-		MetCriteria: ruleResult.MetCriteria,
+		RuleCode:        ruleResult.Code,
+		RuleName:        ruleResult.Name,
+		Category:        ruleResult.Category.String(),
+		Strength:        ruleResult.Strength.String(),
+		Applied:         ruleResult.Applied,
+		Confidence:      ruleResult.Confidence,
+		Evidence:        ruleResult.Evidence,
+		Reasoning:       ruleResult.Reasoning,
+		MetCriteria:     ruleResult.MetCriteria,
+		MatchedVariants: ruleResult.MatchedVariants,
+		MatchedDomain:   ruleResult.MatchedDomain,
+		Segregation:     ruleResult.Segregation,
+		PhenotypeMatch:  ruleResult.PhenotypeMatch,
+		InTrans:         ruleResult.InTrans,
 	}, nil
 }
 
 // CombineEvidence combines evidence according to ACMG/AMP guidelines
 func (c *ClassifierService) CombineEvidence(ruleResults []RuleResult) (*EvidenceCombinationResult, error) {
-	c.logger.WithField("rule_count", len(ruleResults)).Debug("Combining evidence")
+	return c.CombineEvidenceWithMode(ruleResults, ScoringModeCombiningRules)
+}
+
+// CombineEvidenceWithMode combines evidence with the combining rules or
+// ClinGen SVI points, e.g. to recombine a recorded classification in the
+// mode it was made in after a criterion is overridden
+func (c *ClassifierService) CombineEvidenceWithMode(ruleResults []RuleResult, mode ScoringMode) (*EvidenceCombinationResult, error) {
+	c.logger.WithFields(logrus.Fields{
+		"rule_count":   len(ruleResults),
+		"scoring_mode": mode,
+	}).Debug("Combining evidence")
 
 	// Convert to internal format
 	internalRuleResults := make([]domain.ACMGAMPRuleResult, len(ruleResults))
@@ -183,8 +533,8 @@ func (c *ClassifierService) CombineEvidence(ruleResults []RuleResult) (*Evidence
 		internalRuleResults[i] = domain.ACMGAMPRuleResult{
 			Code:        rr.RuleCode,
 			Name:        rr.RuleName,
-			Category:    domain.RuleCategory(rr.Category),
-			Strength:    domain.RuleStrength(rr.Strength),
+			Category:    parseRuleCategory(rr.Category),
+			Strength:    parseRuleStrength(rr.Strength),
 			Applied:     rr.Applied,
 			Confidence:  rr.Confidence,
 			Evidence:    rr.Evidence,
@@ -193,7 +543,7 @@ func (c *ClassifierService) CombineEvidence(ruleResults []RuleResult) (*Evidence
 	}
 
 	// Use rule engine to combine evidence
-	classification, confidence := c.ruleEngine.CombineEvidence(internalRuleResults)
+	classification, confidence := c.ruleEngine.classify(withScoringMode(context.Background(), mode), internalRuleResults)
 
 	return &EvidenceCombinationResult{
 		Classification:  classification.String(),
@@ -203,6 +553,23 @@ func (c *ClassifierService) CombineEvidence(ruleResults []RuleResult) (*Evidence
 	}, nil
 }
 
+// parseRuleCategory accepts any registered spelling, e.g. "pathogenic" from
+// MCP clients, and keeps unknown values as-is so they are ignored downstream
+func parseRuleCategory(s string) domain.RuleCategory {
+	if category, err := domain.ParseRuleCategory(s); err == nil {
+		return category
+	}
+	return domain.RuleCategory(s)
+}
+
+// parseRuleStrength accepts any registered spelling, e.g. "very_strong"
+func parseRuleStrength(s string) domain.RuleStrength {
+	if strength, err := domain.ParseRuleStrength(s); err == nil {
+		return strength
+	}
+	return domain.RuleStrength(s)
+}
+
 // generateRecommendations creates actionable recommendations based on classification
 func (c *ClassifierService) generateRecommendations(classification domain.Classification, confidence domain.ConfidenceLevel, evidence *domain.AggregatedEvidence) []string {
 	recommendations := make([]string, 0)
@@ -255,6 +622,21 @@ func (c *ClassifierService) generateEvidenceSummary(ruleResults []domain.ACMGAMP
 	
 	if evidence.PopulationData != nil {
 		summary += fmt.Sprintf(". Population frequency: %.6f", evidence.PopulationData.AlleleFrequency)
+		if evidence.PopulationData.FAF95 > 0 {
+			summary += fmt.Sprintf(" (faf95 %.6f)", evidence.PopulationData.FAF95)
+		}
+	}
+
+	if supported := evidence.GeneCuration.SupportedDiseases(); len(supported) > 0 {
+		diseases := make([]string, 0, len(supported))
+		for _, validity := range supported {
+			disease := fmt.Sprintf("%s (%s", validity.Disease, validity.Classification)
+			if validity.ModeOfInheritance != "" {
+				disease += ", " + validity.ModeOfInheritance
+			}
+			diseases = append(diseases, disease+")")
+		}
+		summary += fmt.Sprintf(". ClinGen gene-disease validity: %s", strings.Join(diseases, "; "))
 	}
 
 	return summary
@@ -319,14 +701,20 @@ func convertRuleResults(results []domain.ACMGAMPRuleResult) []ACMGAMPRuleResult
 	converted := make([]ACMGAMPRuleResult, len(results))
 	for i, r := range results {
 		converted[i] = ACMGAMPRuleResult{
-			RuleCode:    r.Code,
-			RuleName:    r.Name,
-			Category:    r.Category.String(),
-			Strength:    r.Strength.String(),
-			Applied:     r.Applied,
-			Confidence:  r.Confidence,
-			Evidence:    r.Evidence,
-			Reasoning:   r.Reasoning,
+			RuleCode:        r.Code,
+			RuleName:        r.Name,
+			Category:        r.Category.String(),
+			Strength:        r.Strength.String(),
+			Applied:         r.Applied,
+			Confidence:      r.Confidence,
+			Evidence:        r.Evidence,
+			Reasoning:       r.Reasoning,
+			MatchedVariants: r.MatchedVariants,
+			MatchedDomain:   r.MatchedDomain,
+			Segregation:     r.Segregation,
+			PhenotypeMatch:  r.PhenotypeMatch,
+			InTrans:         r.InTrans,
+			PolicyOverride:  r.PolicyOverride,
 		}
 	}
 	return converted
@@ -347,6 +735,17 @@ type ClassifyVariantParams struct {
 	PreferredIsoform   string `json:"preferred_isoform,omitempty"`   // Override transcript selection
 	ClinicalContext    string `json:"clinical_context,omitempty"`
 	IncludeEvidence    bool   `json:"include_evidence,omitempty"`
+	ScoringMode        string `json:"scoring_mode,omitempty"`        // combining_rules or points; defaults to the service setting
+	OrderingSpecialty  string `json:"ordering_specialty,omitempty"`  // Selects the specialty's mandated transcript set, e.g. cardiology
+	Condition          string `json:"condition,omitempty"`           // Condition under evaluation; selects configured frequency thresholds
+	ClassificationContext string `json:"classification_context,omitempty"` // germline (ACMG/AMP, default) or somatic (AMP/ASCO/CAP tiers)
+	TumorType          string `json:"tumor_type,omitempty"`          // Patient's tumor type, for somatic tiering
+	PatientContext     *PatientContext `json:"patient_context,omitempty"` // Case-level de novo evidence for PS2 and PM6
+	RefreshEvidence    bool   `json:"refresh_evidence,omitempty"`    // Drop cached database responses and fetch them again
+	RulesVersion       string `json:"rules_version,omitempty"`       // Rule set version, e.g. acmg2015 (default) or clingen-svi-2023
+
+	// Per-transcript annotations; discordant consequences trigger multi-transcript evaluation
+	TranscriptConsequences []domain.TranscriptConsequence `json:"transcript_consequences,omitempty"`
 }
 
 // ClassifyVariantResult result of variant classification
@@ -359,6 +758,35 @@ type ClassifyVariantResult struct {
 	Recommendations []string               `json:"recommendations"`
 	ProcessingTime  time.Duration          `json:"processing_time"`
 	InputNotation   string                 `json:"input_notation,omitempty"` // Final HGVS notation used
+	MultiTranscript *MultiTranscriptAssessment `json:"multi_transcript,omitempty"`
+	ThresholdRevision int64                  `json:"threshold_revision,omitempty"` // 0 when default thresholds applied
+	ConfigCommit    string                 `json:"config_commit,omitempty"` // Config repository commit in effect, if one is configured
+	DatasetVersions provenance.Versions    `json:"dataset_versions,omitempty"` // Version of each dataset evidence was drawn from, e.g. the ClinVar release
+	FrequencyThresholds *FrequencyThresholds `json:"frequency_thresholds,omitempty"` // BA1, BS1 and PM2 cutoffs applied and their source
+	ConflictingEvidence []conflicts.Decision `json:"conflicting_evidence,omitempty"` // Conflicting applied criteria and how each was resolved
+	ScoringMode     string                 `json:"scoring_mode"`
+	RulesVersion    string                 `json:"rules_version,omitempty"` // Rule set version the criteria were evaluated under
+	WeightingPolicy *WeightingPolicyResult `json:"weighting_policy,omitempty"` // Laboratory weighting policy in effect and the overrides it applied
+	PointTotal      int                    `json:"point_total"` // ClinGen SVI points of the applied criteria, reported in both modes
+	ConfidenceScore float64                `json:"confidence_score,omitempty"` // 0-1 support of the point total for the classification
+	PosteriorProbability float64           `json:"posterior_probability,omitempty"` // Probability of pathogenicity implied by the point total
+	VUSTier         VUSTier                `json:"vus_tier,omitempty"` // hot, warm or cool, for a VUS
+	Specification   string                 `json:"vcep_specification,omitempty"` // Gene-specific VCEP specification applied, if any
+	RegionCaveats   []regions.Caveat       `json:"region_caveats,omitempty"`     // Problematic regions the variant overlaps
+	SpecialtyTranscript *SpecialtyTranscript `json:"specialty_transcript,omitempty"` // Transcript mandated by the ordering specialty
+	Normalized      *domain.NormalizedVariant `json:"normalized,omitempty"` // Normalized genomic and transcript notation, if a normalizer is configured
+	VRS             *vrs.Allele            `json:"vrs,omitempty"` // GA4GH VRS allele of the normalized variant, if a reference genome is configured
+	Transcript      string                 `json:"transcript,omitempty"` // Transcript the criteria were evaluated on
+	TranscriptConsequences []domain.TranscriptConsequence `json:"transcript_consequences,omitempty"` // Consequence on each overlapping transcript, MANE Select first
+	Evidence        *domain.AggregatedEvidence `json:"-"` // Evidence the rules were evaluated against, kept for the audit trail
+	Bundle          *EvidenceBundle        `json:"-"` // Everything the germline call was made from, for replay_classification
+	ClassificationContext string             `json:"classification_context"`
+	Somatic         *SomaticAssessment     `json:"somatic,omitempty"` // AMP/ASCO/CAP tiering, in the somatic context
+	Structural      *StructuralAssessment  `json:"structural,omitempty"` // Repeat expansion or balanced structural variant, not evaluated with the criteria
+	Provisional     bool                   `json:"provisional,omitempty"` // Made without a source that failed or missed its deadline
+	IncompleteEvidence []string            `json:"incomplete_evidence,omitempty"` // Evidence categories missing a source
+	EvidenceSources []domain.SourceStatus  `json:"evidence_sources,omitempty"` // How each evidence source fared
+	Cached          bool                   `json:"cached,omitempty"` // Served from the result cache
 }
 
 // HGVSValidationResult result of HGVS validation
@@ -371,6 +799,9 @@ type HGVSValidationResult struct {
 	GenomicPosition   string `json:"genomic_position,omitempty"`
 	PredictedProtein  string `json:"predicted_protein,omitempty"`
 	ErrorMessage      string `json:"error_message,omitempty"`
+	Normalized        *domain.NormalizedVariant `json:"normalized,omitempty"`
+	NormalizationError string `json:"normalization_error,omitempty"` // Why the notation could not be normalized, if a normalizer is configured
+	Structural        *domain.StructuralVariant `json:"structural,omitempty"` // Repeat expansion or balanced structural variant
 }
 
 // ApplyRuleParams parameters for applying specific rule
@@ -382,15 +813,20 @@ type ApplyRuleParams struct {
 
 // RuleEvaluationResult result of rule evaluation
 type RuleEvaluationResult struct {
-	RuleCode    string  `json:"rule_code"`
-	RuleName    string  `json:"rule_name"`
-	Category    string  `json:"category"`
-	Strength    string  `json:"strength"`
-	Applied     bool    `json:"applied"`
-	Confidence  float64 `json:"confidence"`
-	Evidence    string  `json:"evidence,omitempty"`
-	Reasoning   string  `json:"reasoning,omitempty"`
-	MetCriteria []string `json:"met_criteria,omitempty"`
+	RuleCode        string                  `json:"rule_code"`
+	RuleName        string                  `json:"rule_name"`
+	Category        string                  `json:"category"`
+	Strength        string                  `json:"strength"`
+	Applied         bool                    `json:"applied"`
+	Confidence      float64                 `json:"confidence"`
+	Evidence        string                  `json:"evidence,omitempty"`
+	Reasoning       string                  `json:"reasoning,omitempty"`
+	MetCriteria     []string                `json:"met_criteria,omitempty"`
+	MatchedVariants []domain.ResidueVariant `json:"matched_variants,omitempty"`
+	MatchedDomain   *domain.ProteinDomain   `json:"matched_domain,omitempty"`
+	Segregation     *domain.SegregationAnalysis `json:"segregation,omitempty"`
+	PhenotypeMatch  *domain.PhenotypeMatch      `json:"phenotype_match,omitempty"`
+	InTrans         *domain.InTransTally        `json:"in_trans,omitempty"`
 }
 
 // RuleResult for evidence combination
@@ -415,14 +851,20 @@ type EvidenceCombinationResult struct {
 
 // ACMGAMPRuleResult represents a single ACMG/AMP rule evaluation result for API
 type ACMGAMPRuleResult struct {
-	RuleCode    string  `json:"rule_code"`
-	RuleName    string  `json:"rule_name"`
-	Category    string  `json:"category"`
-	Strength    string  `json:"strength"`
-	Applied     bool    `json:"applied"`
-	Confidence  float64 `json:"confidence"`
-	Evidence    string  `json:"evidence,omitempty"`
-	Reasoning   string  `json:"reasoning,omitempty"`
+	RuleCode        string                  `json:"rule_code"`
+	RuleName        string                  `json:"rule_name"`
+	Category        string                  `json:"category"`
+	Strength        string                  `json:"strength"`
+	Applied         bool                    `json:"applied"`
+	Confidence      float64                 `json:"confidence"`
+	Evidence        string                  `json:"evidence,omitempty"`
+	Reasoning       string                  `json:"reasoning,omitempty"`
+	MatchedVariants []domain.ResidueVariant `json:"matched_variants,omitempty"` // PS1/PM5 ClinVar variants at the residue
+	MatchedDomain   *domain.ProteinDomain   `json:"matched_domain,omitempty"`   // PM1 domain or hotspot
+	Segregation     *domain.SegregationAnalysis `json:"segregation,omitempty"` // PP1/BS4 LOD computation
+	PhenotypeMatch  *domain.PhenotypeMatch      `json:"phenotype_match,omitempty"`  // PP4 HPO term similarity
+	InTrans         *domain.InTransTally        `json:"in_trans,omitempty"`         // PM3 proband tally
+	PolicyOverride  string                      `json:"policy_override,omitempty"`  // Laboratory weighting override applied
 }
 
 // Helper methods for enhanced ClassifyVariant functionality
@@ -459,6 +901,14 @@ func (c *ClassifierService) prepareVariantForClassification(ctx context.Context,
 	// If HGVS notation is provided, use it directly (takes priority)
 	if params.HGVSNotation != "" {
 		c.logger.WithField("hgvs_notation", params.HGVSNotation).Debug("Processing HGVS notation input")
+
+		if hgvs.IsStructural(params.HGVSNotation) {
+			variant, err := parseStructuralVariant(params.HGVSNotation)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to parse HGVS notation: %w", err)
+			}
+			return variant, variant.Structural.Notation, nil
+		}
 		
 		variant, err := c.inputParser.ParseVariant(params.HGVSNotation)
 		if err != nil {
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestClassifierService_CombineEvidenceNormalizesSpellings(t *testing.T) {
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+
+	// Lowercase and display spellings from MCP clients must count like the canonical values
+	result, err := classifier.CombineEvidence([]RuleResult{
+		{RuleCode: "PVS1", Category: "pathogenic", Strength: "very_strong", Applied: true},
+		{RuleCode: "PS3", Category: "Pathogenic", Strength: "Strong", Applied: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.PATHOGENIC.String(), result.Classification)
+
+	// Unknown values are kept and contribute nothing
+	result, err = classifier.CombineEvidence([]RuleResult{
+		{RuleCode: "XX1", Category: "other", Strength: "huge", Applied: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.VUS.String(), result.Classification)
+}
+
+func TestClassifierService_CombineEvidenceWithMode(t *testing.T) {
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+	rules := []RuleResult{
+		{RuleCode: "PVS1", Category: "pathogenic", Strength: "very_strong", Applied: true},
+		{RuleCode: "PP3", Category: "pathogenic", Strength: "supporting", Applied: true},
+	}
+
+	// Act
+	combined, err := classifier.CombineEvidenceWithMode(rules, ScoringModeCombiningRules)
+	require.NoError(t, err)
+	scored, err := classifier.CombineEvidenceWithMode(rules, ScoringModePoints)
+	require.NoError(t, err)
+
+	// Assert: nine points are likely pathogenic; the combining rules need more
+	assert.Equal(t, domain.LIKELY_PATHOGENIC.String(), scored.Classification)
+	assert.NotEqual(t, scored.Classification, combined.Classification)
+}
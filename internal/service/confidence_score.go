@@ -0,0 +1,77 @@
+package service
+
+import (
+	"math"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// VUSTier sub-tiers a variant of uncertain significance by how close its
+// accumulated evidence points come to likely pathogenic
+type VUSTier string
+
+const (
+	// VUSTierHot is 4 or more points: one moderate criterion from likely pathogenic
+	VUSTierHot VUSTier = "hot"
+	// VUSTierWarm is 2 or 3 points: some pathogenic evidence, well short of likely pathogenic
+	VUSTierWarm VUSTier = "warm"
+	// VUSTierCool is 1 point or fewer: little or conflicting evidence
+	VUSTierCool VUSTier = "cool"
+)
+
+// Point totals at which a VUS is hot or warm
+const (
+	hotVUSMinPoints  = 4
+	warmVUSMinPoints = 2
+)
+
+// Bayesian framework the point scale derives from (Tavtigian et al. 2018):
+// a prior probability of pathogenicity of 0.10 and odds of pathogenicity of
+// 350 for very strong evidence, with each point worth an eighth of that
+const (
+	priorProbability   = 0.10
+	veryStrongOddsPath = 350.0
+)
+
+// VUSTierFor sub-tiers a VUS by its point total. Other classifications are
+// not tiered and return an empty tier.
+func VUSTierFor(classification domain.Classification, points int) VUSTier {
+	if classification != domain.VUS {
+		return ""
+	}
+	switch {
+	case points >= hotVUSMinPoints:
+		return VUSTierHot
+	case points >= warmVUSMinPoints:
+		return VUSTierWarm
+	default:
+		return VUSTierCool
+	}
+}
+
+// PosteriorProbability converts a point total to the posterior probability
+// of pathogenicity under the Bayesian framework
+func PosteriorProbability(points int) float64 {
+	odds := math.Pow(veryStrongOddsPath, float64(points)/8)
+	return round3(odds * priorProbability / ((odds-1)*priorProbability + 1))
+}
+
+// ConfidenceScore quantifies, from 0 to 1, how strongly the point total
+// supports the classification made: the posterior probability of
+// pathogenicity for pathogenic calls, of benignity for benign calls, and for
+// a VUS how evenly balanced the evidence is (1 at a posterior of 0.5)
+func ConfidenceScore(classification domain.Classification, points int) float64 {
+	posterior := PosteriorProbability(points)
+	switch classification {
+	case domain.PATHOGENIC, domain.LIKELY_PATHOGENIC:
+		return posterior
+	case domain.BENIGN, domain.LIKELY_BENIGN:
+		return round3(1 - posterior)
+	default:
+		return round3(1 - math.Abs(2*posterior-1))
+	}
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestVUSTierFor(t *testing.T) {
+	assert.Equal(t, VUSTierHot, VUSTierFor(domain.VUS, 5))
+	assert.Equal(t, VUSTierHot, VUSTierFor(domain.VUS, 4))
+	assert.Equal(t, VUSTierWarm, VUSTierFor(domain.VUS, 3))
+	assert.Equal(t, VUSTierWarm, VUSTierFor(domain.VUS, 2))
+	assert.Equal(t, VUSTierCool, VUSTierFor(domain.VUS, 1))
+	assert.Equal(t, VUSTierCool, VUSTierFor(domain.VUS, -3))
+
+	// A VUS under the combining rules can exceed the points VUS range
+	assert.Equal(t, VUSTierHot, VUSTierFor(domain.VUS, 8))
+
+	assert.Empty(t, VUSTierFor(domain.LIKELY_PATHOGENIC, 5))
+	assert.Empty(t, VUSTierFor(domain.BENIGN, 0))
+}
+
+func TestPosteriorProbability(t *testing.T) {
+	// Classification boundaries of the point scale fall on the Bayesian posteriors
+	assert.Equal(t, 0.1, PosteriorProbability(0))
+	assert.Equal(t, 0.9, PosteriorProbability(likelyPathogenicMinPoints))
+	assert.Equal(t, 0.994, PosteriorProbability(pathogenicMinPoints))
+	assert.Equal(t, 0.051, PosteriorProbability(likelyBenignMaxPoints))
+	assert.Equal(t, 0.0, PosteriorProbability(-8))
+}
+
+func TestConfidenceScore(t *testing.T) {
+	assert.Equal(t, 0.994, ConfidenceScore(domain.PATHOGENIC, 10))
+	assert.Equal(t, 0.9, ConfidenceScore(domain.LIKELY_PATHOGENIC, 6))
+	assert.Equal(t, 0.949, ConfidenceScore(domain.LIKELY_BENIGN, -1))
+	assert.Equal(t, 1.0, ConfidenceScore(domain.BENIGN, -8))
+
+	// A VUS scores highest when the evidence is evenly balanced
+	assert.Equal(t, 0.2, ConfidenceScore(domain.VUS, 0))
+	assert.Equal(t, 0.65, ConfidenceScore(domain.VUS, 2))
+	assert.Equal(t, 0.376, ConfidenceScore(domain.VUS, 5))
+}
@@ -0,0 +1,21 @@
+package service
+
+// ConfigVersionSource reports the version of the clinical configuration in
+// effect, such as the commit of a Git-backed config repository
+type ConfigVersionSource interface {
+	ConfigCommit() string
+}
+
+// SetConfigVersionSource configures the clinical configuration version
+// recorded with each classification. Without a source none is recorded.
+func (c *ClassifierService) SetConfigVersionSource(source ConfigVersionSource) {
+	c.configVersion = source
+}
+
+// configCommit returns the version of the clinical configuration in effect
+func (c *ClassifierService) configCommit() string {
+	if c.configVersion == nil {
+		return ""
+	}
+	return c.configVersion.ConfigCommit()
+}
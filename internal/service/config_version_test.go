@@ -0,0 +1,22 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubConfigVersion string
+
+func (s stubConfigVersion) ConfigCommit() string {
+	return string(s)
+}
+
+func TestConfigCommit(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	assert.Empty(t, service.configCommit(), "no source configured")
+
+	service.SetConfigVersionSource(stubConfigVersion("3f2c9a1e"))
+	assert.Equal(t, "3f2c9a1e", service.configCommit())
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/acmg-amp-mcp-server/internal/conflicts"
+)
+
+// SetConflictPolicies configures how conflicting applied criteria, such as
+// PS3 with BS3, are resolved. Without policies every conflict is flagged
+// for review and the criteria are combined unchanged.
+func (e *ACMGAMPRuleEngine) SetConflictPolicies(config *conflicts.Config) {
+	e.conflictPolicies = config
+}
+
+// SetConflictPolicies configures the conflict resolution policies used by the rule engine
+func (c *ClassifierService) SetConflictPolicies(config *conflicts.Config) {
+	c.ruleEngine.SetConflictPolicies(config)
+}
+
+// conflictRecommendations asks for curator review of conflicts no policy resolved
+func conflictRecommendations(decisions []conflicts.Decision) []string {
+	var recommendations []string
+	for _, decision := range decisions {
+		if decision.NeedsReview {
+			recommendations = append(recommendations, fmt.Sprintf("Conflicting evidence (%s vs %s): %s; review before reporting", decision.Source1, decision.Source2, decision.Conflict))
+		}
+	}
+	return recommendations
+}
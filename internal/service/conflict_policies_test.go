@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/conflicts"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestRuleEngine_ConflictPolicies(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{ID: "var-1", GeneSymbol: "BRCA1"}
+	evidence := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{
+		AlleleFrequency:       0.00005,
+		AlleleCount:           5,
+		AlleleNumber:          100000,
+		PopulationFrequencies: map[string]float64{"afr": 0.0006, "nfe": 0.00001},
+	}}
+
+	// Flagged by default: PM2 stays applied and the conflict is reported
+	results, decisions, err := engine.evaluateRules(context.Background(), variant, evidence)
+	require.NoError(t, err)
+	require.True(t, findRule(t, results, "PM2").Applied)
+	require.Len(t, decisions, 1)
+	assert.Equal(t, conflicts.KindSubpopulation, decisions[0].Kind)
+	assert.Equal(t, "afr", decisions[0].Source2)
+	assert.True(t, decisions[0].NeedsReview)
+	assert.Len(t, conflictRecommendations(decisions), 1)
+
+	// Downgrading drops PM2 to supporting
+	engine.SetConflictPolicies(&conflicts.Config{Kinds: map[string]string{conflicts.KindSubpopulation: conflicts.PolicyDowngradeBoth}})
+	results, err = engine.EvaluateAllRules(context.Background(), variant, evidence)
+	require.NoError(t, err)
+	pm2 := findRule(t, results, "PM2")
+	assert.True(t, pm2.Applied)
+	assert.Equal(t, domain.SUPPORTING, pm2.Strength)
+	assert.Contains(t, pm2.Reasoning, "[Conflict: downgraded from moderate to supporting")
+}
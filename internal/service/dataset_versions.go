@@ -0,0 +1,23 @@
+package service
+
+import "github.com/acmg-amp-mcp-server/internal/provenance"
+
+// DatasetVersionSource reports the versions of the datasets evidence is
+// drawn from, such as a provenance.Registry
+type DatasetVersionSource interface {
+	DatasetVersions() provenance.Versions
+}
+
+// SetDatasetVersionSource configures the dataset versions recorded with each
+// classification. Without a source none are recorded.
+func (c *ClassifierService) SetDatasetVersionSource(source DatasetVersionSource) {
+	c.datasetVersions = source
+}
+
+// datasetVersionSet returns the versions of the datasets in use
+func (c *ClassifierService) datasetVersionSet() provenance.Versions {
+	if c.datasetVersions == nil {
+		return nil
+	}
+	return c.datasetVersions.DatasetVersions()
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+)
+
+func TestDatasetVersionSet(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	assert.Nil(t, service.datasetVersionSet(), "no source configured")
+
+	registry := provenance.NewRegistry(nil)
+	registry.Record(provenance.ClinVar, "2024-05-01")
+	registry.Record(provenance.GnomAD, "gnomad_r4")
+	service.SetDatasetVersionSource(registry)
+	assert.Equal(t, provenance.Versions{provenance.ClinVar: "2024-05-01", provenance.GnomAD: "gnomad_r4"}, service.datasetVersionSet())
+}
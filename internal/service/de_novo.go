@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/phenotype"
+	"github.com/acmg-amp-mcp-server/internal/segregation"
+)
+
+// De novo statuses for PatientContext.DeNovoStatus
+const (
+	DeNovoStatusDeNovo    = "de_novo"
+	DeNovoStatusInherited = "inherited"
+	DeNovoStatusUnknown   = "unknown"
+)
+
+// Phenotype matches for PatientContext.PhenotypeMatch, as graded by the
+// ClinGen SVI de novo recommendation
+const (
+	PhenotypeHighlySpecific          = "highly_specific"          // Highly specific for the gene
+	PhenotypeConsistent              = "consistent"               // Consistent with the gene, not highly specific
+	PhenotypeConsistentHeterogeneous = "consistent_heterogeneous" // Consistent, but the phenotype has high genetic heterogeneity
+	PhenotypeNotConsistent           = "not_consistent"
+)
+
+// Family histories for PatientContext.FamilyHistory
+const (
+	FamilyHistoryNegative = "negative"
+	FamilyHistoryPositive = "positive"
+	FamilyHistoryUnknown  = "unknown"
+)
+
+// PatientContext is case-level evidence about the proband and family, used
+// for PS2, PM3, PM6, PP1, PP4, BP2, BS2 and BS4
+type PatientContext struct {
+	DeNovoStatus         string                    `json:"de_novo_status,omitempty"`        // de_novo, inherited or unknown
+	ParentalConfirmation bool                      `json:"parental_confirmation,omitempty"` // Maternity and paternity confirmed
+	PhenotypeMatch       string                    `json:"phenotype_match,omitempty"`       // highly_specific, consistent, consistent_heterogeneous or not_consistent
+	FamilyHistory        string                    `json:"family_history,omitempty"`        // negative, positive or unknown
+	Segregation          *segregation.Observations `json:"segregation,omitempty"`           // Genotyped relatives, for PP1 and BS4
+	HPOTerms             []string                  `json:"hpo_terms,omitempty"`             // Proband phenotype, for PP4
+	Zygosity             string                    `json:"zygosity,omitempty"`              // heterozygous, homozygous or hemizygous, for PM3
+	Inheritance          string                    `json:"inheritance,omitempty"`           // Disorder's mode: autosomal_recessive, autosomal_dominant or x_linked, for PM3, BP2 and BS2
+	Penetrance           string                    `json:"penetrance,omitempty"`            // Disorder's penetrance: complete, reduced, age_dependent or unknown, for BS2
+	PhasedVariants       []PhasedVariant           `json:"phased_variants,omitempty"`       // Other variants in the gene, for PM3 and BP2
+	InTransCases         []InTransCase             `json:"in_trans_cases,omitempty"`        // Other unrelated probands with the variant, tallied for PM3
+	HealthyAdults        *HealthyAdults            `json:"healthy_adults,omitempty"`        // Unaffected adults with the variant in the lab's own data, for BS2
+}
+
+// Validate normalizes the patient context and checks its values
+func (p *PatientContext) Validate() error {
+	fields := []struct {
+		name    string
+		value   *string
+		allowed []string
+	}{
+		{"de_novo_status", &p.DeNovoStatus, []string{DeNovoStatusDeNovo, DeNovoStatusInherited, DeNovoStatusUnknown}},
+		{"phenotype_match", &p.PhenotypeMatch, []string{PhenotypeHighlySpecific, PhenotypeConsistent, PhenotypeConsistentHeterogeneous, PhenotypeNotConsistent}},
+		{"family_history", &p.FamilyHistory, []string{FamilyHistoryNegative, FamilyHistoryPositive, FamilyHistoryUnknown}},
+		{"zygosity", &p.Zygosity, []string{ZygosityHeterozygous, ZygosityHomozygous, ZygosityHemizygous}},
+		{"inheritance", &p.Inheritance, []string{InheritanceAutosomalRecessive, InheritanceAutosomalDominant, InheritanceXLinked}},
+		{"penetrance", &p.Penetrance, []string{PenetranceComplete, PenetranceReduced, PenetranceAgeDependent, PenetranceUnknown}},
+	}
+	for _, f := range fields {
+		*f.value = strings.ToLower(strings.TrimSpace(*f.value))
+		if *f.value == "" {
+			continue
+		}
+		valid := false
+		for _, allowed := range f.allowed {
+			if *f.value == allowed {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid patient_context.%s %q (expected one of: %s)", f.name, *f.value, strings.Join(f.allowed, ", "))
+		}
+	}
+	if p.Segregation != nil {
+		if err := p.Segregation.Validate(); err != nil {
+			return fmt.Errorf("invalid patient_context.segregation: %w", err)
+		}
+	}
+	for i, term := range p.HPOTerms {
+		normalized, err := phenotype.NormalizeTerm(term)
+		if err != nil {
+			return fmt.Errorf("invalid patient_context.hpo_terms: %w", err)
+		}
+		p.HPOTerms[i] = normalized
+	}
+	for i := range p.PhasedVariants {
+		if err := p.PhasedVariants[i].validate(); err != nil {
+			return fmt.Errorf("invalid patient_context.phased_variants: %w", err)
+		}
+	}
+	for i := range p.InTransCases {
+		if err := p.InTransCases[i].validate(); err != nil {
+			return fmt.Errorf("invalid patient_context.in_trans_cases[%d]: %w", i, err)
+		}
+	}
+	if p.HealthyAdults != nil {
+		if err := p.HealthyAdults.validate(); err != nil {
+			return fmt.Errorf("invalid patient_context.healthy_adults: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeNovoPoints scores the de novo occurrence on the ClinGen SVI point scale
+// (v1.1): confirmed de novo in a phenotype highly specific for the gene
+// scores 2, consistent 1 and consistent with high genetic heterogeneity 0.5.
+// Assumed de novo scores half as much, and other phenotypes score 0.
+func (p *PatientContext) DeNovoPoints() float64 {
+	var points float64
+	switch p.PhenotypeMatch {
+	case PhenotypeHighlySpecific:
+		points = 2
+	case PhenotypeConsistent:
+		points = 1
+	case PhenotypeConsistentHeterogeneous:
+		points = 0.5
+	default:
+		return 0
+	}
+	if !p.ParentalConfirmation {
+		points /= 2
+	}
+	return points
+}
+
+// deNovoStrength maps de novo points to the criterion strength, or "" when
+// the points do not reach supporting
+func deNovoStrength(points float64) domain.RuleStrength {
+	switch {
+	case points >= 4:
+		return domain.VERY_STRONG
+	case points >= 2:
+		return domain.STRONG
+	case points >= 1:
+		return domain.MODERATE
+	case points >= 0.5:
+		return domain.SUPPORTING
+	}
+	return ""
+}
+
+type patientContextKey struct{}
+
+// withPatientContext attaches the case-level evidence to the context
+func withPatientContext(ctx context.Context, patient *PatientContext) context.Context {
+	if patient == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, patientContextKey{}, patient)
+}
+
+// patientContextFrom returns the case-level evidence attached to the context, or nil
+func patientContextFrom(ctx context.Context) *PatientContext {
+	patient, _ := ctx.Value(patientContextKey{}).(*PatientContext)
+	return patient
+}
+
+// evaluateDeNovo applies PS2 (confirmed) or PM6 (assumed de novo) from the
+// patient context, at the strength the ClinGen SVI point scale gives. Only
+// the rule matching the parental confirmation is applied, so one de novo
+// observation is not counted twice.
+func evaluateDeNovo(ctx context.Context, result *domain.ACMGAMPRuleResult, confirmed bool) {
+	patient := patientContextFrom(ctx)
+	if patient == nil || patient.DeNovoStatus == "" || patient.DeNovoStatus == DeNovoStatusUnknown {
+		result.Reasoning = "De novo status not provided; pass patient_context.de_novo_status to assess " + result.Code
+		return
+	}
+	if patient.DeNovoStatus == DeNovoStatusInherited {
+		result.Reasoning = "Variant is inherited"
+		return
+	}
+	if patient.FamilyHistory == FamilyHistoryPositive {
+		result.Reasoning = "Positive family history is inconsistent with a de novo origin of the disease"
+		return
+	}
+	if patient.ParentalConfirmation != confirmed {
+		if confirmed {
+			result.Reasoning = "Maternity and paternity not confirmed; PM6 applies instead"
+		} else {
+			result.Reasoning = "Maternity and paternity confirmed; PS2 applies instead"
+		}
+		return
+	}
+
+	points := patient.DeNovoPoints()
+	strength := deNovoStrength(points)
+	if patient.PhenotypeMatch == "" {
+		result.Reasoning = "Phenotype match not provided; pass patient_context.phenotype_match to score the de novo occurrence"
+		return
+	}
+	if strength == "" {
+		result.Reasoning = fmt.Sprintf("De novo occurrence scores %.2g points with phenotype %s, below supporting", points, patient.PhenotypeMatch)
+		return
+	}
+
+	result.Applied = true
+	result.Strength = strength
+	result.Confidence = 0.7
+	origin := "Assumed de novo"
+	if confirmed {
+		result.Confidence = 0.9
+		origin = "Confirmed de novo"
+	}
+	result.Evidence = fmt.Sprintf("%s, phenotype %s, family history %s: %.2g points", origin, patient.PhenotypeMatch, familyHistoryLabel(patient.FamilyHistory), points)
+	result.Reasoning = fmt.Sprintf("%s occurrence scores %.2g points on the ClinGen SVI de novo scale (%s)", origin, points, strings.ToLower(strength.Label()))
+	if patient.FamilyHistory != FamilyHistoryNegative {
+		result.Reasoning += "; family history not reported as negative"
+	}
+}
+
+// familyHistoryLabel describes the family history in rule evidence
+func familyHistoryLabel(history string) string {
+	if history == "" {
+		return FamilyHistoryUnknown
+	}
+	return history
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestPatientContext_DeNovoPoints(t *testing.T) {
+	tests := []struct {
+		phenotype string
+		confirmed bool
+		points    float64
+		strength  domain.RuleStrength
+	}{
+		{PhenotypeHighlySpecific, true, 2, domain.STRONG},
+		{PhenotypeHighlySpecific, false, 1, domain.MODERATE},
+		{PhenotypeConsistent, true, 1, domain.MODERATE},
+		{PhenotypeConsistent, false, 0.5, domain.SUPPORTING},
+		{PhenotypeConsistentHeterogeneous, true, 0.5, domain.SUPPORTING},
+		{PhenotypeConsistentHeterogeneous, false, 0.25, ""},
+		{PhenotypeNotConsistent, true, 0, ""},
+	}
+	for _, tt := range tests {
+		patient := &PatientContext{DeNovoStatus: DeNovoStatusDeNovo, PhenotypeMatch: tt.phenotype, ParentalConfirmation: tt.confirmed}
+		assert.Equal(t, tt.points, patient.DeNovoPoints(), tt.phenotype)
+		assert.Equal(t, tt.strength, deNovoStrength(tt.points), tt.phenotype)
+	}
+}
+
+func TestPatientContext_Validate(t *testing.T) {
+	patient := &PatientContext{DeNovoStatus: " De_Novo ", PhenotypeMatch: "HIGHLY_SPECIFIC", FamilyHistory: "negative"}
+	require.NoError(t, patient.Validate())
+	assert.Equal(t, DeNovoStatusDeNovo, patient.DeNovoStatus)
+	assert.Equal(t, PhenotypeHighlySpecific, patient.PhenotypeMatch)
+
+	terms := &PatientContext{HPOTerms: []string{"hp:0001250", "HP_0001263"}}
+	require.NoError(t, terms.Validate())
+	assert.Equal(t, []string{"HP:0001250", "HP:0001263"}, terms.HPOTerms)
+
+	assert.Error(t, (&PatientContext{DeNovoStatus: "maybe"}).Validate())
+	assert.Error(t, (&PatientContext{PhenotypeMatch: "partial"}).Validate())
+	assert.Error(t, (&PatientContext{FamilyHistory: "yes"}).Validate())
+	assert.Error(t, (&PatientContext{HPOTerms: []string{"Seizure"}}).Validate())
+	assert.NoError(t, (&PatientContext{}).Validate())
+}
+
+func TestRuleEngine_DeNovo(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{GeneSymbol: "SCN1A", HGVSProtein: "p.Arg1648His"}
+	evaluate := func(code string, patient *PatientContext) *domain.ACMGAMPRuleResult {
+		t.Helper()
+		result, err := engine.EvaluateRule(withPatientContext(context.Background(), patient), code, variant, &domain.AggregatedEvidence{})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := evaluate("PS2", nil)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "patient_context.de_novo_status")
+
+	confirmed := &PatientContext{DeNovoStatus: DeNovoStatusDeNovo, ParentalConfirmation: true, PhenotypeMatch: PhenotypeHighlySpecific, FamilyHistory: FamilyHistoryNegative}
+	result = evaluate("PS2", confirmed)
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.STRONG, result.Strength)
+	assert.Contains(t, result.Evidence, "Confirmed de novo")
+	assert.False(t, evaluate("PM6", confirmed).Applied, "PM6 is not applied alongside PS2")
+
+	assumed := &PatientContext{DeNovoStatus: DeNovoStatusDeNovo, PhenotypeMatch: PhenotypeConsistent}
+	result = evaluate("PM6", assumed)
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.SUPPORTING, result.Strength)
+	assert.Contains(t, result.Reasoning, "family history not reported as negative")
+	assert.False(t, evaluate("PS2", assumed).Applied)
+
+	heterogeneous := &PatientContext{DeNovoStatus: DeNovoStatusDeNovo, PhenotypeMatch: PhenotypeConsistentHeterogeneous}
+	assert.False(t, evaluate("PM6", heterogeneous).Applied, "0.25 points do not reach supporting")
+
+	familial := &PatientContext{DeNovoStatus: DeNovoStatusDeNovo, ParentalConfirmation: true, PhenotypeMatch: PhenotypeHighlySpecific, FamilyHistory: FamilyHistoryPositive}
+	assert.False(t, evaluate("PS2", familial).Applied)
+
+	assert.False(t, evaluate("PS2", &PatientContext{DeNovoStatus: DeNovoStatusInherited, ParentalConfirmation: true, PhenotypeMatch: PhenotypeHighlySpecific}).Applied)
+	result = evaluate("PS2", &PatientContext{DeNovoStatus: DeNovoStatusDeNovo, ParentalConfirmation: true})
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "phenotype_match")
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+	"github.com/acmg-amp-mcp-server/internal/vcep"
+)
+
+// FrequencyOverrideSource supplies configured per-gene and per-condition
+// population frequency cutoffs
+type FrequencyOverrideSource interface {
+	Lookup(gene, condition string) *thresholds.FrequencyOverride
+}
+
+// Sources of the frequency cutoffs applied to a variant, in increasing precedence
+const (
+	FrequencySourceDefault       = "default"
+	FrequencySourceRevision      = "threshold_revision"
+	FrequencySourceSpecification = "vcep_specification"
+	FrequencySourceOverride      = "configured_override"
+)
+
+// FrequencyThresholds are the BA1, BS1 and PM2 cutoffs applied to a variant
+// and where they came from
+type FrequencyThresholds struct {
+	BA1AlleleFrequency float64 `json:"ba1_allele_frequency"`
+	BS1AlleleFrequency float64 `json:"bs1_allele_frequency"`
+	PM2AlleleFrequency float64 `json:"pm2_allele_frequency"`
+	Source             string  `json:"source"`
+	Gene               string  `json:"gene,omitempty"`
+	Condition          string  `json:"condition,omitempty"`
+	Override           string  `json:"override,omitempty"` // Configured override applied, e.g. HFE
+	Reason             string  `json:"reason,omitempty"`   // Why the override exists
+}
+
+type conditionKey struct{}
+
+// withCondition attaches the condition under evaluation to the context
+func withCondition(ctx context.Context, condition string) context.Context {
+	if condition == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, conditionKey{}, condition)
+}
+
+// SetFrequencyOverrides configures per-gene and per-condition frequency
+// cutoffs. They take precedence over threshold revisions and VCEP specifications.
+func (e *ACMGAMPRuleEngine) SetFrequencyOverrides(source FrequencyOverrideSource) {
+	e.frequencyOverrides = source
+}
+
+// SetFrequencyOverrides configures the frequency overrides used by the rule engine
+func (c *ClassifierService) SetFrequencyOverrides(source FrequencyOverrideSource) {
+	c.ruleEngine.SetFrequencyOverrides(source)
+}
+
+// FrequencyThresholds returns the cutoffs that would be applied to a variant
+// in gene, evaluated for condition, with the thresholds in effect now
+func (c *ClassifierService) FrequencyThresholds(ctx context.Context, gene, condition string) *FrequencyThresholds {
+	variant := &domain.StandardizedVariant{GeneSymbol: gene}
+	ctx, _ = c.ruleEngine.withThresholds(withCondition(ctx, condition))
+	_, applied := c.ruleEngine.withFrequencyThresholds(ctx, variant, c.ruleEngine.specificationFor(variant))
+	return applied
+}
+
+// withFrequencyThresholds replaces the frequency cutoffs in the context with
+// those of the variant's VCEP specification and then any configured override,
+// and reports the cutoffs that result. The context must already carry thresholds.
+func (e *ACMGAMPRuleEngine) withFrequencyThresholds(ctx context.Context, variant *domain.StandardizedVariant, spec *vcep.Specification) (context.Context, *FrequencyThresholds) {
+	active, _ := ctx.Value(thresholdsKey{}).(activeThresholds)
+	applied := &FrequencyThresholds{Source: FrequencySourceDefault, Gene: variant.GeneSymbol}
+	if active.revision != 0 {
+		applied.Source = FrequencySourceRevision
+	}
+
+	if spec != nil {
+		if next := withSpecificationThresholds(ctx, spec); next != ctx {
+			ctx = next
+			applied.Source = FrequencySourceSpecification
+		}
+	}
+
+	applied.Condition, _ = ctx.Value(conditionKey{}).(string)
+	if applied.Condition == "" {
+		if model, ok := thresholdsFrom(ctx).GeneModel(variant.GeneSymbol); ok {
+			applied.Condition = model.Disease
+		}
+	}
+	if e.frequencyOverrides != nil {
+		if override := e.frequencyOverrides.Lookup(variant.GeneSymbol, applied.Condition); override != nil {
+			if active, ok := ctx.Value(thresholdsKey{}).(activeThresholds); ok {
+				active.values = override.Apply(active.values)
+				ctx = context.WithValue(ctx, thresholdsKey{}, active)
+			}
+			applied.Source = FrequencySourceOverride
+			applied.Override = override.Label()
+			applied.Reason = override.Reason
+		}
+	}
+
+	t := thresholdsFrom(ctx)
+	applied.BA1AlleleFrequency = t.BA1AlleleFrequency
+	applied.BS1AlleleFrequency = t.BS1AlleleFrequency
+	applied.PM2AlleleFrequency = t.PM2AlleleFrequency
+	return ctx, applied
+}
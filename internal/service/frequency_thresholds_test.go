@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+func testFrequencyOverrides(t *testing.T) *thresholds.FrequencyOverrides {
+	t.Helper()
+	ba1 := 0.1
+	bs1 := 0.08
+	cdh1 := 0.5
+	pm2 := 0.00005
+	overrides, err := thresholds.NewFrequencyOverrides([]thresholds.FrequencyOverride{
+		{Gene: "HFE", BA1AlleleFrequency: &ba1, BS1AlleleFrequency: &bs1, Reason: "Low-penetrance hemochromatosis alleles"},
+		{Gene: "CDH1", BA1AlleleFrequency: &cdh1},
+		{Condition: "Nonsyndromic genetic hearing loss", PM2AlleleFrequency: &pm2},
+	})
+	require.NoError(t, err)
+	return overrides
+}
+
+func TestRuleEngine_FrequencyOverrideRaisesBA1(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	evidence := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleFrequency: 0.06}}
+	variant := &domain.StandardizedVariant{ID: "var-1", GeneSymbol: "HFE"}
+
+	results, err := engine.EvaluateAllRules(context.Background(), variant, evidence)
+	require.NoError(t, err)
+	assert.True(t, findRule(t, results, "BA1").Applied)
+
+	// The HFE exception keeps a 6% allele below BA1 and BS1
+	engine.SetFrequencyOverrides(testFrequencyOverrides(t))
+	results, err = engine.EvaluateAllRules(context.Background(), variant, evidence)
+	require.NoError(t, err)
+	assert.False(t, findRule(t, results, "BA1").Applied)
+	assert.False(t, findRule(t, results, "BS1").Applied)
+
+	// Other genes keep the defaults
+	variant.GeneSymbol = "BRCA1"
+	results, err = engine.EvaluateAllRules(context.Background(), variant, evidence)
+	require.NoError(t, err)
+	assert.True(t, findRule(t, results, "BA1").Applied)
+}
+
+func TestClassifierService_FrequencyThresholdsSource(t *testing.T) {
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+	ctx := context.Background()
+
+	applied := classifier.FrequencyThresholds(ctx, "BRCA1", "")
+	assert.Equal(t, FrequencySourceDefault, applied.Source)
+	assert.Equal(t, thresholds.Defaults().BA1AlleleFrequency, applied.BA1AlleleFrequency)
+
+	// A VCEP specification replaces the revision's cutoffs
+	classifier.SetSpecificationSource(testSpecifications(t))
+	applied = classifier.FrequencyThresholds(ctx, "CDH1", "")
+	assert.Equal(t, FrequencySourceSpecification, applied.Source)
+	assert.Equal(t, 0.002, applied.BA1AlleleFrequency)
+	assert.Equal(t, 0.00001, applied.PM2AlleleFrequency)
+
+	// A configured override takes precedence over the specification, cutoff by cutoff
+	classifier.SetFrequencyOverrides(testFrequencyOverrides(t))
+	applied = classifier.FrequencyThresholds(ctx, "CDH1", "")
+	assert.Equal(t, FrequencySourceOverride, applied.Source)
+	assert.Equal(t, "CDH1", applied.Override)
+	assert.Equal(t, 0.5, applied.BA1AlleleFrequency)
+	assert.Equal(t, 0.00001, applied.PM2AlleleFrequency)
+
+	applied = classifier.FrequencyThresholds(ctx, "GJB2", "nonsyndromic genetic hearing loss")
+	assert.Equal(t, FrequencySourceOverride, applied.Source)
+	assert.Equal(t, 0.00005, applied.PM2AlleleFrequency)
+	assert.Equal(t, "nonsyndromic genetic hearing loss", applied.Condition)
+}
+
+func TestClassifierService_FrequencyThresholdsConditionFromGeneModel(t *testing.T) {
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+	classifier.SetFrequencyOverrides(testFrequencyOverrides(t))
+	custom := thresholds.Defaults()
+	custom.GeneModels = map[string]thresholds.GeneDiseaseModel{
+		"GJB2": {Disease: "Nonsyndromic genetic hearing loss", Inheritance: "AR", Mechanism: thresholds.MechanismLossOfFunction},
+	}
+	classifier.SetThresholdSource(&stubThresholdSource{revision: &thresholds.Revision{ID: 4, Thresholds: custom}})
+
+	applied := classifier.FrequencyThresholds(context.Background(), "GJB2", "")
+	assert.Equal(t, FrequencySourceOverride, applied.Source)
+	assert.Equal(t, "Nonsyndromic genetic hearing loss", applied.Condition)
+
+	applied = classifier.FrequencyThresholds(context.Background(), "MYH7", "")
+	assert.Equal(t, FrequencySourceRevision, applied.Source)
+}
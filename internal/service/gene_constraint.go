@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+// lofIntolerance reports whether gnomAD constraint marks a gene as loss of
+// function intolerant, and describes the metrics it was judged on. known is
+// false without a pLI or LOEUF.
+func lofIntolerance(ctx context.Context, constraint *domain.GeneConstraint) (intolerant, known bool, metrics string) {
+	if constraint == nil || (constraint.PLI == nil && constraint.LOEUF == nil) {
+		return false, false, ""
+	}
+	pliCutoff, loeufCutoff, _ := thresholdsFrom(ctx).Constraint.Cutoffs()
+	var parts []string
+	if constraint.PLI != nil {
+		parts = append(parts, fmt.Sprintf("pLI %.2f", *constraint.PLI))
+		intolerant = intolerant || *constraint.PLI >= pliCutoff
+	}
+	if constraint.LOEUF != nil {
+		parts = append(parts, fmt.Sprintf("LOEUF %.2f", *constraint.LOEUF))
+		intolerant = intolerant || *constraint.LOEUF < loeufCutoff
+	}
+	return intolerant, true, strings.Join(parts, ", ")
+}
+
+// lofMechanismEstablished reports whether loss of function is the gene's
+// established disease mechanism, from the laboratory's gene model or else
+// the ClinGen haploinsufficiency curation
+func lofMechanismEstablished(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (established bool, source string) {
+	if model, ok := thresholdsFrom(ctx).GeneModel(variant.GeneSymbol); ok {
+		return model.Mechanism == thresholds.MechanismLossOfFunction, "gene model"
+	}
+	if evidence != nil {
+		if established, curated := evidence.GeneCuration.LossOfFunctionMechanism(); curated {
+			return established, "ClinGen"
+		}
+	}
+	return false, ""
+}
+
+// evaluateMissenseConstraint applies PP2 (pathogenic) to a missense variant
+// in a gene constrained against missense variation, or BP1 to a missense
+// variant in a gene where loss of function is the established mechanism and
+// missense variation is not constrained
+func evaluateMissenseConstraint(ctx context.Context, result *domain.ACMGAMPRuleResult, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence, pathogenic bool) {
+	if ClassifyConsequence(variant.Consequence, variant.HGVSCoding, variant.HGVSProtein) != ConsequenceMissense {
+		result.Reasoning = result.Code + " applies to missense variants only"
+		return
+	}
+	var constraint *domain.GeneConstraint
+	if evidence != nil {
+		constraint = evidence.GeneConstraint
+	}
+	if constraint == nil || constraint.MissenseZ == nil {
+		result.Reasoning = fmt.Sprintf("No missense constraint data available for %s", variant.GeneSymbol)
+		return
+	}
+
+	_, _, cutoff := thresholdsFrom(ctx).Constraint.Cutoffs()
+	missenseZ := *constraint.MissenseZ
+	result.Evidence = fmt.Sprintf("%s missense Z %.2f (%s)", constraint.Gene, missenseZ, constraint.Source)
+
+	if pathogenic {
+		if missenseZ < cutoff {
+			result.Reasoning = fmt.Sprintf("Missense Z %.2f is below the constraint cutoff of %.2f", missenseZ, cutoff)
+			return
+		}
+		result.Applied = true
+		result.Confidence = 0.6
+		result.Reasoning = fmt.Sprintf("%s is constrained against missense variation (missense Z %.2f at or above %.2f)", variant.GeneSymbol, missenseZ, cutoff)
+		return
+	}
+
+	established, source := lofMechanismEstablished(ctx, variant, evidence)
+	if !established {
+		result.Reasoning = fmt.Sprintf("Loss of function is not an established disease mechanism for %s", variant.GeneSymbol)
+		return
+	}
+	if missenseZ >= cutoff {
+		result.Reasoning = fmt.Sprintf("%s is constrained against missense variation (missense Z %.2f); missense variants may cause disease", variant.GeneSymbol, missenseZ)
+		return
+	}
+	result.Applied = true
+	result.Confidence = 0.6
+	result.Reasoning = fmt.Sprintf("Loss of function is the disease mechanism for %s (%s) and missense variation is not constrained (missense Z %.2f below %.2f)",
+		variant.GeneSymbol, source, missenseZ, cutoff)
+}
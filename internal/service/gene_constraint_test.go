@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+func constraintMetric(value float64) *float64 {
+	return &value
+}
+
+func TestRuleEngine_GeneConstraint(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	missense := &domain.StandardizedVariant{GeneSymbol: "SCN1A", HGVSCoding: "NM_001165963.4:c.5347G>A", HGVSProtein: "p.Ala1783Thr"}
+	nonsense := &domain.StandardizedVariant{GeneSymbol: "SCN1A", HGVSCoding: "NM_001165963.4:c.664C>T", HGVSProtein: "p.Arg222Ter"}
+
+	constrained := &domain.GeneConstraint{Gene: "SCN1A", PLI: constraintMetric(1), LOEUF: constraintMetric(0.15), MissenseZ: constraintMetric(6.02), Source: "gnomAD v4"}
+	tolerant := &domain.GeneConstraint{Gene: "SCN1A", PLI: constraintMetric(0), LOEUF: constraintMetric(1.3), MissenseZ: constraintMetric(0.4), Source: "gnomAD v4"}
+
+	evaluate := func(engine *ACMGAMPRuleEngine, code string, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) *domain.ACMGAMPRuleResult {
+		t.Helper()
+		result, err := engine.EvaluateRule(context.Background(), code, variant, evidence)
+		require.NoError(t, err)
+		return result
+	}
+
+	pp2 := evaluate(engine, "PP2", missense, &domain.AggregatedEvidence{GeneConstraint: constrained})
+	assert.True(t, pp2.Applied)
+	assert.Contains(t, pp2.Evidence, "missense Z 6.02")
+	assert.False(t, evaluate(engine, "PP2", missense, &domain.AggregatedEvidence{GeneConstraint: tolerant}).Applied)
+	assert.False(t, evaluate(engine, "PP2", nonsense, &domain.AggregatedEvidence{GeneConstraint: constrained}).Applied)
+	noData := evaluate(engine, "PP2", missense, &domain.AggregatedEvidence{})
+	assert.False(t, noData.Applied)
+	assert.Contains(t, noData.Reasoning, "No missense constraint data available")
+
+	// BP1 needs loss of function established by a gene model or ClinGen
+	assert.False(t, evaluate(engine, "BP1", missense, &domain.AggregatedEvidence{GeneConstraint: tolerant}).Applied)
+	lofCurated := &domain.GeneCuration{Gene: "SCN1A", Haploinsufficiency: domain.DosageSufficientEvidence, Triplosensitivity: domain.DosageNotCurated}
+	bp1 := evaluate(engine, "BP1", missense, &domain.AggregatedEvidence{GeneConstraint: tolerant, GeneCuration: lofCurated})
+	assert.True(t, bp1.Applied)
+	assert.Contains(t, bp1.Reasoning, "ClinGen")
+	assert.False(t, evaluate(engine, "BP1", missense, &domain.AggregatedEvidence{GeneConstraint: constrained, GeneCuration: lofCurated}).Applied,
+		"missense constrained genes do not get BP1")
+
+	models := thresholds.Defaults()
+	models.GeneModels = map[string]thresholds.GeneDiseaseModel{"SCN1A": {Inheritance: "AD", Mechanism: thresholds.MechanismLossOfFunction}}
+	modelled := NewACMGAMPRuleEngine(logrus.New())
+	modelled.SetThresholdSource(&stubThresholdSource{revision: &thresholds.Revision{ID: 1, Thresholds: models}})
+	bp1 = evaluate(modelled, "BP1", missense, &domain.AggregatedEvidence{GeneConstraint: tolerant})
+	assert.True(t, bp1.Applied)
+	assert.Contains(t, bp1.Reasoning, "gene model")
+
+	// A stricter missense cutoff from the thresholds
+	strict := thresholds.Defaults()
+	strict.Constraint.MissenseZConstrained = 7
+	strictEngine := NewACMGAMPRuleEngine(logrus.New())
+	strictEngine.SetThresholdSource(&stubThresholdSource{revision: &thresholds.Revision{ID: 2, Thresholds: strict}})
+	assert.False(t, evaluate(strictEngine, "PP2", missense, &domain.AggregatedEvidence{GeneConstraint: constrained}).Applied)
+
+	// PVS1 notes the constraint when no gene model or curation covers the gene
+	pvs1 := evaluate(engine, "PVS1", nonsense, &domain.AggregatedEvidence{GeneConstraint: constrained})
+	assert.True(t, pvs1.Applied)
+	assert.Equal(t, 0.9, pvs1.Confidence)
+	assert.Contains(t, pvs1.Reasoning, "loss-of-function intolerance of SCN1A (pLI 1.00, LOEUF 0.15)")
+	pvs1 = evaluate(engine, "PVS1", nonsense, &domain.AggregatedEvidence{GeneConstraint: tolerant})
+	assert.True(t, pvs1.Applied)
+	assert.Equal(t, 0.7, pvs1.Confidence)
+	assert.Contains(t, pvs1.Reasoning, "tolerates heterozygous loss of function")
+}
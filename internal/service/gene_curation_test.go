@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestRuleEngine_PVS1GeneCuration(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	nonsense := &domain.StandardizedVariant{GeneSymbol: "GENE1", HGVSCoding: "NM_000001.1:c.100C>T", HGVSProtein: "p.Arg34Ter"}
+
+	evaluate := func(haploinsufficiency int) *domain.ACMGAMPRuleResult {
+		t.Helper()
+		evidence := &domain.AggregatedEvidence{GeneCuration: &domain.GeneCuration{
+			Gene: "GENE1", Haploinsufficiency: haploinsufficiency, Triplosensitivity: domain.DosageNotCurated, Source: "ClinGen",
+		}}
+		result, err := engine.EvaluateRule(context.Background(), "PVS1", nonsense, evidence)
+		require.NoError(t, err)
+		return result
+	}
+
+	established := evaluate(domain.DosageSufficientEvidence)
+	assert.True(t, established.Applied)
+	assert.Equal(t, domain.VERY_STRONG, established.Strength)
+	assert.Contains(t, established.Reasoning, "ClinGen establishes loss of function")
+
+	recessive := evaluate(domain.DosageAutosomalRecessive)
+	assert.True(t, recessive.Applied)
+
+	emerging := evaluate(domain.DosageEmergingEvidence)
+	assert.True(t, emerging.Applied)
+	assert.Equal(t, domain.STRONG, emerging.Strength)
+
+	unlikely := evaluate(domain.DosageUnlikely)
+	assert.False(t, unlikely.Applied)
+	assert.Contains(t, unlikely.Reasoning, "haploinsufficiency score 40")
+	assert.False(t, evaluate(domain.DosageNoEvidence).Applied)
+
+	uncurated := evaluate(domain.DosageNotCurated)
+	assert.True(t, uncurated.Applied, "without a curation PVS1 is not withheld")
+	assert.Equal(t, domain.VERY_STRONG, uncurated.Strength)
+}
+
+func TestGenerateEvidenceSummary_GeneCuration(t *testing.T) {
+	service := &ClassifierService{}
+	summary := service.generateEvidenceSummary(nil, &domain.AggregatedEvidence{GeneCuration: &domain.GeneCuration{
+		Gene: "BRCA1",
+		Validity: []domain.GeneDiseaseValidity{
+			{Disease: "hereditary breast ovarian cancer syndrome", Classification: "Definitive", ModeOfInheritance: "Autosomal dominant inheritance"},
+			{Disease: "Fanconi anemia", Classification: "Limited"},
+		},
+	}})
+	assert.Contains(t, summary, "ClinGen gene-disease validity: hereditary breast ovarian cancer syndrome (Definitive, Autosomal dominant inheritance)")
+	assert.NotContains(t, summary, "Fanconi")
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+// Penetrances of the disorder, for PatientContext.Penetrance
+const (
+	PenetranceComplete     = thresholds.PenetranceComplete
+	PenetranceReduced      = thresholds.PenetranceReduced
+	PenetranceAgeDependent = thresholds.PenetranceAgeDependent
+	PenetranceUnknown      = thresholds.PenetranceUnknown
+)
+
+// HealthyAdults counts unaffected adults in the lab's own data, such as
+// unaffected parents or internal controls, carrying the variant, for BS2
+type HealthyAdults struct {
+	Homozygotes   int    `json:"homozygotes,omitempty"`
+	Hemizygotes   int    `json:"hemizygotes,omitempty"`
+	Heterozygotes int    `json:"heterozygotes,omitempty"`
+	Source        string `json:"source,omitempty"` // Where the counts come from, e.g. internal controls
+}
+
+// validate normalizes the counts and checks they are not negative
+func (h *HealthyAdults) validate() error {
+	h.Source = strings.TrimSpace(h.Source)
+	if h.Homozygotes < 0 || h.Hemizygotes < 0 || h.Heterozygotes < 0 {
+		return fmt.Errorf("counts must not be negative")
+	}
+	return nil
+}
+
+// genotypeCounts are the individuals observed with each genotype in one source
+type genotypeCounts struct {
+	source                                  string
+	homozygotes, hemizygotes, heterozygotes int
+}
+
+// populationGenotypeCounts returns the gnomAD genotype counts. Heterozygotes
+// are the alleles not in homozygotes or hemizygotes.
+func populationGenotypeCounts(p *domain.PopulationData) genotypeCounts {
+	counts := genotypeCounts{source: "gnomAD", homozygotes: p.HomozygoteCount, hemizygotes: p.HemizygoteCount}
+	if p.Dataset != "" {
+		counts.source += " " + p.Dataset
+	}
+	counts.heterozygotes = max(p.AlleleCount-2*p.HomozygoteCount-p.HemizygoteCount, 0)
+	return counts
+}
+
+// String describes the counts, e.g. "gnomAD gnomad_r4: 3 homozygotes, 0
+// hemizygotes, 120 heterozygotes"
+func (c genotypeCounts) String() string {
+	return fmt.Sprintf("%s: %s, %s, %s", c.source, countOf(c.homozygotes, "homozygote"), countOf(c.hemizygotes, "hemizygote"), countOf(c.heterozygotes, "heterozygote"))
+}
+
+// countOf formats a count with its noun, pluralized unless the count is one
+func countOf(n int, noun string) string {
+	if n != 1 {
+		noun += "s"
+	}
+	return fmt.Sprintf("%d %s", n, noun)
+}
+
+// disorderModel returns the disorder's mode of inheritance and penetrance
+// from the patient context, falling back to the gene model for either
+func disorderModel(ctx context.Context, variant *domain.StandardizedVariant) (inheritance, penetrance string) {
+	if patient := patientContextFrom(ctx); patient != nil {
+		inheritance, penetrance = patient.Inheritance, patient.Penetrance
+	}
+	model, ok := thresholdsFrom(ctx).GeneModel(variant.GeneSymbol)
+	if !ok {
+		return inheritance, penetrance
+	}
+	if inheritance == "" {
+		switch model.Inheritance {
+		case "AR":
+			inheritance = InheritanceAutosomalRecessive
+		case "AD":
+			inheritance = InheritanceAutosomalDominant
+		case "XLR", "XLD":
+			inheritance = InheritanceXLinked
+		}
+	}
+	if penetrance == "" {
+		penetrance = model.Penetrance
+	}
+	return inheritance, penetrance
+}
+
+// evaluateHealthyAdults applies BS2 when at least the configured number of
+// unaffected individuals carry a genotype that would cause the disorder:
+// homozygotes for a recessive disorder, homozygotes and hemizygotes for an
+// X-linked one, and carriers for a dominant one. gnomAD counts are added to
+// the lab's own counts from patient_context.healthy_adults. Unaffected
+// carriers only argue against pathogenicity when the disorder is fully
+// penetrant by adulthood: reduced or age-dependent penetrance withholds BS2,
+// and a dominant disorder must be known to be completely penetrant.
+func evaluateHealthyAdults(ctx context.Context, result *domain.ACMGAMPRuleResult, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) {
+	inheritance, penetrance := disorderModel(ctx, variant)
+	if inheritance == "" {
+		result.Reasoning = "Mode of inheritance not provided; pass patient_context.inheritance or configure a gene model to assess BS2"
+		return
+	}
+
+	var sources []genotypeCounts
+	var notes []string
+	if evidence != nil && evidence.PopulationData != nil {
+		p := evidence.PopulationData
+		if p.QualityMetrics != nil && !p.QualityMetrics.FilterPass {
+			notes = append(notes, "gnomAD counts excluded: the variant fails gnomAD quality filters")
+		} else {
+			sources = append(sources, populationGenotypeCounts(p))
+		}
+	}
+	if patient := patientContextFrom(ctx); patient != nil && patient.HealthyAdults != nil {
+		h := patient.HealthyAdults
+		source := h.Source
+		if source == "" {
+			source = "internal"
+		}
+		sources = append(sources, genotypeCounts{source: source, homozygotes: h.Homozygotes, hemizygotes: h.Hemizygotes, heterozygotes: h.Heterozygotes})
+	}
+	if len(sources) == 0 {
+		result.Reasoning = "No genotype counts available; BS2 needs gnomAD data or patient_context.healthy_adults"
+		if len(notes) > 0 {
+			result.Reasoning = notes[0]
+		}
+		return
+	}
+
+	var observed int
+	homozygotes, heterozygotes := thresholdsFrom(ctx).HealthyAdults.Cutoffs()
+	cutoff, genotype := homozygotes, "Homozygotes"
+	parts := make([]string, 0, len(sources))
+	for _, s := range sources {
+		parts = append(parts, s.String())
+		switch inheritance {
+		case InheritanceAutosomalRecessive:
+			observed += s.homozygotes
+		case InheritanceXLinked:
+			observed += s.homozygotes + s.hemizygotes
+		case InheritanceAutosomalDominant:
+			observed += s.heterozygotes + s.homozygotes + s.hemizygotes
+		}
+	}
+	switch inheritance {
+	case InheritanceXLinked:
+		genotype = "Homozygotes and hemizygotes"
+	case InheritanceAutosomalDominant:
+		cutoff, genotype = heterozygotes, "Carriers"
+	}
+	disorder := strings.ReplaceAll(inheritance, "_", " ")
+	if inheritance == InheritanceXLinked {
+		disorder = "X-linked"
+	}
+	result.Evidence = strings.Join(append(parts, notes...), "; ")
+
+	switch {
+	case penetrance == PenetranceReduced || penetrance == PenetranceAgeDependent:
+		result.Reasoning = fmt.Sprintf("Penetrance of the %s disorder is %s; unaffected carriers are expected", disorder, strings.ReplaceAll(penetrance, "_", "-"))
+		return
+	case inheritance == InheritanceAutosomalDominant && penetrance != PenetranceComplete:
+		if penetrance == "" {
+			penetrance = "not provided"
+		}
+		result.Reasoning = fmt.Sprintf("BS2 for a dominant disorder needs complete penetrance; penetrance is %s", penetrance)
+		return
+	case observed < cutoff:
+		result.Reasoning = fmt.Sprintf("%s: %d, below the BS2 cutoff of %d for an %s disorder", genotype, observed, cutoff, disorder)
+		return
+	}
+
+	result.Applied = true
+	result.Confidence = 0.7
+	result.Reasoning = fmt.Sprintf("%s: %d, at or above the BS2 cutoff of %d for an %s disorder", genotype, observed, cutoff, disorder)
+	if penetrance == PenetranceComplete {
+		result.Confidence = 0.8
+		result.Reasoning += " with complete penetrance"
+	}
+}
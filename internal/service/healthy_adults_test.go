@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+func TestPatientContext_ValidateHealthyAdults(t *testing.T) {
+	patient := &PatientContext{Penetrance: " Age_Dependent", HealthyAdults: &HealthyAdults{Homozygotes: 1, Source: " parents "}}
+	require.NoError(t, patient.Validate())
+	assert.Equal(t, PenetranceAgeDependent, patient.Penetrance)
+	assert.Equal(t, "parents", patient.HealthyAdults.Source)
+
+	assert.Error(t, (&PatientContext{Penetrance: "partial"}).Validate())
+	assert.Error(t, (&PatientContext{HealthyAdults: &HealthyAdults{Homozygotes: -1}}).Validate())
+}
+
+func TestRuleEngine_BS2(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{GeneSymbol: "GJB2", HGVSProtein: "p.Val37Ile"}
+	gnomad := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{
+		AlleleCount: 130, HomozygoteCount: 3, Dataset: "gnomad_r4", QualityMetrics: &domain.QualityMetrics{FilterPass: true},
+	}}
+	evaluate := func(patient *PatientContext, evidence *domain.AggregatedEvidence) *domain.ACMGAMPRuleResult {
+		t.Helper()
+		result, err := engine.EvaluateRule(withPatientContext(context.Background(), patient), "BS2", variant, evidence)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := evaluate(nil, gnomad)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "Mode of inheritance not provided")
+
+	recessive := &PatientContext{Inheritance: InheritanceAutosomalRecessive}
+	result = evaluate(recessive, gnomad)
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.STRONG, result.Strength)
+	assert.Equal(t, "gnomAD gnomad_r4: 3 homozygotes, 0 hemizygotes, 124 heterozygotes", result.Evidence)
+	assert.Equal(t, "Homozygotes: 3, at or above the BS2 cutoff of 2 for an autosomal recessive disorder", result.Reasoning)
+
+	result = evaluate(&PatientContext{Inheritance: InheritanceAutosomalRecessive, Penetrance: PenetranceReduced}, gnomad)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "unaffected carriers are expected")
+	assert.NotEmpty(t, result.Evidence, "the counts are reported even when BS2 is withheld")
+
+	// Internal counts are added to gnomAD's
+	single := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleCount: 2, HomozygoteCount: 1}}
+	assert.False(t, evaluate(recessive, single).Applied)
+	result = evaluate(&PatientContext{Inheritance: InheritanceAutosomalRecessive, HealthyAdults: &HealthyAdults{Homozygotes: 1, Source: "unaffected parents"}}, single)
+	assert.True(t, result.Applied)
+	assert.Equal(t, "gnomAD: 1 homozygote, 0 hemizygotes, 0 heterozygotes; unaffected parents: 1 homozygote, 0 hemizygotes, 0 heterozygotes", result.Evidence)
+
+	filtered := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{HomozygoteCount: 10, QualityMetrics: &domain.QualityMetrics{FilterPass: false}}}
+	result = evaluate(recessive, filtered)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "fails gnomAD quality filters")
+
+	xLinked := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleCount: 3, HemizygoteCount: 2}}
+	result = evaluate(&PatientContext{Inheritance: InheritanceXLinked}, xLinked)
+	assert.True(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "Homozygotes and hemizygotes: 2")
+
+	dominant := &PatientContext{Inheritance: InheritanceAutosomalDominant}
+	result = evaluate(dominant, gnomad)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "needs complete penetrance")
+	dominant.Penetrance = PenetranceComplete
+	result = evaluate(dominant, gnomad)
+	assert.True(t, result.Applied)
+	assert.Equal(t, "Carriers: 127, at or above the BS2 cutoff of 3 for an autosomal dominant disorder with complete penetrance", result.Reasoning)
+}
+
+func TestRuleEngine_BS2FromGeneModel(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	custom := thresholds.Defaults()
+	custom.HealthyAdults.BS2Homozygotes = 5
+	custom.GeneModels = map[string]thresholds.GeneDiseaseModel{
+		"CFTR": {Inheritance: "AR", Mechanism: thresholds.MechanismLossOfFunction, Penetrance: thresholds.PenetranceComplete},
+	}
+	engine.SetThresholdSource(&stubThresholdSource{revision: &thresholds.Revision{ID: 4, Thresholds: custom}})
+
+	variant := &domain.StandardizedVariant{GeneSymbol: "CFTR", HGVSProtein: "p.Arg117His"}
+	evidence := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleCount: 400, HomozygoteCount: 4}}
+	result, err := engine.EvaluateRule(context.Background(), "BS2", variant, evidence)
+	require.NoError(t, err)
+	assert.False(t, result.Applied)
+	assert.Equal(t, "Homozygotes: 4, below the BS2 cutoff of 5 for an autosomal recessive disorder", result.Reasoning)
+
+	// The patient context takes precedence over the gene model
+	result, err = engine.EvaluateRule(withPatientContext(context.Background(), &PatientContext{Penetrance: PenetranceAgeDependent}), "BS2", variant, evidence)
+	require.NoError(t, err)
+	assert.Contains(t, result.Reasoning, "age-dependent")
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/transcriptset"
+)
+
+// VariantNormalizer normalizes HGVS notations against reference transcripts
+// and the reference genome
+type VariantNormalizer interface {
+	Normalize(hgvs, transcript string) (*domain.NormalizedVariant, error)
+}
+
+// SetNormalizer configures HGVS normalization. Without a normalizer
+// variants are classified on their coordinates as parsed.
+func (c *ClassifierService) SetNormalizer(normalizer VariantNormalizer) {
+	c.normalizer = normalizer
+}
+
+// normalizeVariant normalizes a notation and places the variant on the
+// genome with its normalized coordinates. The transcript describes g.
+// notations on the transcript too; if it is not in the transcript database
+// the notation is normalized on the genome alone.
+func (c *ClassifierService) normalizeVariant(variant *domain.StandardizedVariant, hgvs string) (*domain.NormalizedVariant, error) {
+	if c.normalizer == nil || hgvs == "" {
+		return nil, nil
+	}
+	transcript := ""
+	if variant != nil && strings.Contains(hgvs, ":g.") {
+		transcript = variant.TranscriptID
+	}
+	normalized, err := c.normalizer.Normalize(hgvs, transcript)
+	if err != nil && transcript != "" {
+		normalized, err = c.normalizer.Normalize(hgvs, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if variant == nil {
+		return normalized, nil
+	}
+
+	variant.Chromosome = normalized.Chromosome
+	variant.Position = normalized.Start
+	variant.HGVSGenomic = normalized.HGVSGenomic
+	if normalized.Reference != "" && normalized.Alternative != "" {
+		variant.Reference, variant.Alternative = normalized.Reference, normalized.Alternative
+	}
+	if normalized.HGVSCoding != "" {
+		variant.HGVSCoding = normalized.HGVSCoding
+		variant.TranscriptID = normalized.TranscriptID
+	}
+	if variant.GeneSymbol == "" {
+		variant.GeneSymbol = normalized.GeneSymbol
+	}
+	return normalized, nil
+}
+
+// TranscriptAnnotator describes a normalized variant on each transcript
+// overlapping it. Normalizers implementing it annotate variants for which the
+// caller supplied no transcript consequences.
+type TranscriptAnnotator interface {
+	Annotate(variant *domain.NormalizedVariant) ([]domain.TranscriptConsequence, error)
+}
+
+// ConsequenceAnnotator is an alternative source of transcript consequences,
+// such as the Ensembl VEP REST API. Consequences are listed MANE Select first.
+type ConsequenceAnnotator interface {
+	Annotate(ctx context.Context, variant *domain.NormalizedVariant) ([]domain.TranscriptConsequence, error)
+}
+
+// SetConsequenceAnnotator configures an external consequence annotator. It is
+// consulted before the normalizer's own annotation, which remains the
+// fallback when the annotator fails or finds no transcripts.
+func (c *ClassifierService) SetConsequenceAnnotator(annotator ConsequenceAnnotator) {
+	c.consequenceAnnotator = annotator
+}
+
+// annotateTranscripts fills in the consequences of a variant on its
+// overlapping transcripts, listed MANE Select first. A variant not yet on a
+// transcript is placed on the first one so that transcript-level criteria
+// such as PVS1 are evaluated on the clinically relevant transcript; a variant
+// already on a transcript takes its consequence from it.
+func (c *ClassifierService) annotateTranscripts(ctx context.Context, variant *domain.StandardizedVariant, normalized *domain.NormalizedVariant) error {
+	if variant == nil || len(variant.TranscriptConsequences) > 0 {
+		return nil
+	}
+	consequences, err := c.annotate(ctx, variant, normalized)
+	if err != nil || len(consequences) == 0 {
+		return err
+	}
+	variant.TranscriptConsequences = consequences
+
+	selected := consequences[0]
+	if variant.TranscriptID != "" {
+		found := false
+		for _, tc := range consequences {
+			if transcriptset.SameTranscript(tc.TranscriptID, variant.TranscriptID) {
+				selected, found = tc, true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	}
+	variant.TranscriptID = selected.TranscriptID
+	if variant.HGVSCoding == "" {
+		variant.HGVSCoding = selected.HGVSCoding
+	}
+	if variant.HGVSProtein == "" {
+		variant.HGVSProtein = selected.HGVSProtein
+	}
+	if variant.Consequence == "" {
+		variant.Consequence = selected.Consequence
+	}
+	if variant.GeneSymbol == "" {
+		variant.GeneSymbol = selected.GeneSymbol
+	}
+	return nil
+}
+
+// annotate asks the consequence annotator, if configured, and falls back to
+// the normalizer's annotation. Without a normalized form the external
+// annotator is given the variant's parsed genomic coordinates.
+func (c *ClassifierService) annotate(ctx context.Context, variant *domain.StandardizedVariant, normalized *domain.NormalizedVariant) ([]domain.TranscriptConsequence, error) {
+	if c.consequenceAnnotator != nil {
+		query := normalized
+		if query == nil && variant.Chromosome != "" && variant.Position > 0 {
+			query = &domain.NormalizedVariant{
+				HGVSGenomic: variant.HGVSGenomic,
+				Chromosome:  variant.Chromosome,
+				Start:       variant.Position,
+				End:         variant.Position + int64(len(variant.Reference)) - 1,
+				Reference:   variant.Reference,
+				Alternative: variant.Alternative,
+			}
+		}
+		if query != nil {
+			consequences, err := c.consequenceAnnotator.Annotate(ctx, query)
+			if err == nil && len(consequences) > 0 {
+				return consequences, nil
+			}
+			if err != nil {
+				c.logger.WithError(err).WithField("hgvs_genomic", query.HGVSGenomic).Warn("Consequence annotator failed, falling back to internal annotation")
+			}
+		}
+	}
+
+	annotator, ok := c.normalizer.(TranscriptAnnotator)
+	if !ok || normalized == nil {
+		return nil, nil
+	}
+	return annotator.Annotate(normalized)
+}
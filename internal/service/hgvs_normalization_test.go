@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// stubNormalizer normalizes known notations and records the transcripts it was given
+type stubNormalizer struct {
+	results     map[string]*domain.NormalizedVariant
+	transcripts []string
+}
+
+func (s *stubNormalizer) Normalize(hgvs, transcript string) (*domain.NormalizedVariant, error) {
+	s.transcripts = append(s.transcripts, transcript)
+	if transcript != "" && transcript != "NM_000546.6" {
+		return nil, fmt.Errorf("transcript %s not in transcript database", transcript)
+	}
+	if result, ok := s.results[hgvs]; ok {
+		return result, nil
+	}
+	return nil, fmt.Errorf("reference allele does not match the genome")
+}
+
+func TestNormalizeVariant(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	variant := &domain.StandardizedVariant{HGVSCoding: "NM_000546.6:c.743G>A"}
+
+	normalized, err := service.normalizeVariant(variant, "NM_000546.6:c.743G>A")
+	require.NoError(t, err)
+	assert.Nil(t, normalized, "no normalizer configured")
+
+	normalizer := &stubNormalizer{results: map[string]*domain.NormalizedVariant{
+		"NM_000546.6:c.743G>A": {
+			HGVSGenomic: "NC_000017.11:g.7674220C>T", HGVSCoding: "NM_000546.6:c.743G>A", TranscriptID: "NM_000546.6",
+			GeneSymbol: "TP53", Chromosome: "17", Start: 7674220, End: 7674220, Reference: "C", Alternative: "T",
+		},
+		"NC_000017.11:g.7674220C>T": {HGVSGenomic: "NC_000017.11:g.7674220C>T", Chromosome: "17", Start: 7674220},
+	}}
+	service.SetNormalizer(normalizer)
+
+	// Act
+	normalized, err = service.normalizeVariant(variant, "NM_000546.6:c.743G>A")
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, normalized)
+	assert.Equal(t, "17", variant.Chromosome)
+	assert.Equal(t, int64(7674220), variant.Position)
+	assert.Equal(t, "C", variant.Reference)
+	assert.Equal(t, "T", variant.Alternative)
+	assert.Equal(t, "NC_000017.11:g.7674220C>T", variant.HGVSGenomic)
+	assert.Equal(t, "TP53", variant.GeneSymbol)
+
+	genomic := &domain.StandardizedVariant{TranscriptID: "NM_000059.4"}
+	_, err = service.normalizeVariant(genomic, "NC_000017.11:g.7674220C>T")
+	require.NoError(t, err, "an unknown transcript falls back to the genome alone")
+	assert.Equal(t, []string{"", "NM_000059.4", ""}, normalizer.transcripts)
+
+	_, err = service.normalizeVariant(&domain.StandardizedVariant{}, "NM_000546.6:c.743A>G")
+	assert.Error(t, err)
+}
+
+// annotatingNormalizer also describes variants on overlapping transcripts
+type annotatingNormalizer struct {
+	stubNormalizer
+	consequences []domain.TranscriptConsequence
+}
+
+func (a *annotatingNormalizer) Annotate(*domain.NormalizedVariant) ([]domain.TranscriptConsequence, error) {
+	return a.consequences, nil
+}
+
+func TestAnnotateTranscripts(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	normalized := &domain.NormalizedVariant{HGVSGenomic: "NC_000017.11:g.7674220C>T", Chromosome: "17", Start: 7674220}
+	consequences := []domain.TranscriptConsequence{
+		{TranscriptID: "NM_000546.6", GeneSymbol: "TP53", HGVSCoding: "NM_000546.6:c.743G>A", HGVSProtein: "p.Arg248Gln",
+			Consequence: "missense_variant", MANE: "MANE Select", ClinicallyRelevant: true},
+		{TranscriptID: "NM_001126114.3", GeneSymbol: "TP53", HGVSCoding: "NM_001126114.3:c.743G>A", Consequence: "splice_region_variant"},
+	}
+
+	service.SetNormalizer(&stubNormalizer{})
+	variant := &domain.StandardizedVariant{}
+	require.NoError(t, service.annotateTranscripts(context.Background(), variant, normalized))
+	assert.Empty(t, variant.TranscriptConsequences, "the normalizer cannot annotate")
+
+	service.SetNormalizer(&annotatingNormalizer{consequences: consequences})
+
+	// A genomic variant is placed on the MANE Select transcript
+	require.NoError(t, service.annotateTranscripts(context.Background(), variant, normalized))
+	assert.Equal(t, consequences, variant.TranscriptConsequences)
+	assert.Equal(t, "NM_000546.6", variant.TranscriptID)
+	assert.Equal(t, "NM_000546.6:c.743G>A", variant.HGVSCoding)
+	assert.Equal(t, "p.Arg248Gln", variant.HGVSProtein)
+	assert.Equal(t, "missense_variant", variant.Consequence)
+	assert.Equal(t, "TP53", variant.GeneSymbol)
+
+	// A variant described on a transcript keeps it
+	variant = &domain.StandardizedVariant{TranscriptID: "NM_001126114", HGVSCoding: "NM_001126114.3:c.743G>A"}
+	require.NoError(t, service.annotateTranscripts(context.Background(), variant, normalized))
+	assert.Equal(t, "NM_001126114.3", variant.TranscriptID)
+	assert.Equal(t, "splice_region_variant", variant.Consequence)
+
+	// Caller-supplied consequences are kept
+	supplied := []domain.TranscriptConsequence{{TranscriptID: "NM_000546.5", Consequence: "stop_gained"}}
+	variant = &domain.StandardizedVariant{TranscriptConsequences: supplied}
+	require.NoError(t, service.annotateTranscripts(context.Background(), variant, normalized))
+	assert.Equal(t, supplied, variant.TranscriptConsequences)
+	assert.Empty(t, variant.TranscriptID)
+}
+
+// stubAnnotator stands in for an external consequence annotator
+type stubAnnotator struct {
+	consequences []domain.TranscriptConsequence
+	err          error
+	queried      *domain.NormalizedVariant
+}
+
+func (s *stubAnnotator) Annotate(_ context.Context, variant *domain.NormalizedVariant) ([]domain.TranscriptConsequence, error) {
+	s.queried = variant
+	return s.consequences, s.err
+}
+
+func TestAnnotateTranscripts_ConsequenceAnnotator(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	internal := []domain.TranscriptConsequence{{TranscriptID: "NM_000546.6", Consequence: "missense_variant", ClinicallyRelevant: true}}
+	vep := []domain.TranscriptConsequence{{TranscriptID: "ENST00000269305.9", Consequence: "missense_variant&splice_region_variant", ClinicallyRelevant: true}}
+
+	// Without a normalizer the annotator is given the parsed coordinates
+	annotator := &stubAnnotator{consequences: vep}
+	service.SetConsequenceAnnotator(annotator)
+	variant := &domain.StandardizedVariant{Chromosome: "17", Position: 7674220, Reference: "C", Alternative: "T"}
+	require.NoError(t, service.annotateTranscripts(context.Background(), variant, nil))
+	assert.Equal(t, vep, variant.TranscriptConsequences)
+	assert.Equal(t, int64(7674220), annotator.queried.End)
+
+	// The normalizer's annotation is the fallback when the annotator fails
+	service.SetNormalizer(&annotatingNormalizer{consequences: internal})
+	normalized := &domain.NormalizedVariant{Chromosome: "17", Start: 7674220, End: 7674220, Reference: "C", Alternative: "T"}
+	annotator.err = fmt.Errorf("VEP returned status 503")
+	variant = &domain.StandardizedVariant{}
+	require.NoError(t, service.annotateTranscripts(context.Background(), variant, normalized))
+	assert.Equal(t, internal, variant.TranscriptConsequences)
+	assert.Same(t, normalized, annotator.queried)
+
+	annotator.err, annotator.consequences = nil, nil
+	variant = &domain.StandardizedVariant{}
+	require.NoError(t, service.annotateTranscripts(context.Background(), variant, normalized))
+	assert.Equal(t, internal, variant.TranscriptConsequences, "or finds no transcripts")
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// LabKnowledgeSource looks up the lab's own prior assertions
+type LabKnowledgeSource interface {
+	Lookup(ctx context.Context, normalizedHGVS string) (*labkb.Assertion, error)
+	FindProteinChange(ctx context.Context, geneSymbol, proteinChange string) ([]*labkb.Assertion, error)
+	Revision(ctx context.Context) (int64, error)
+}
+
+// SetLabKnowledgeSource configures the lab knowledge base consulted for PS4
+// proband counts and PS1 matches. Without a source PS4 is not assessed and
+// PS1 relies on ClinVar alone.
+func (e *ACMGAMPRuleEngine) SetLabKnowledgeSource(source LabKnowledgeSource) {
+	e.labKnowledge = source
+}
+
+// SetLabKnowledgeSource configures the lab knowledge base used by the rule engine
+func (c *ClassifierService) SetLabKnowledgeSource(source LabKnowledgeSource) {
+	c.ruleEngine.SetLabKnowledgeSource(source)
+}
+
+// labKnowledgeRevision returns the revision of the lab knowledge base, 0
+// without one, so memoized results made before a lab assertion or case count
+// changed are not served
+func (e *ACMGAMPRuleEngine) labKnowledgeRevision(ctx context.Context) (int64, error) {
+	if e.labKnowledge == nil {
+		return 0, nil
+	}
+	return e.labKnowledge.Revision(ctx)
+}
+
+// lookupLabAssertion returns the lab's assertion for the variant under its
+// coding or genomic notation; lookup failures are logged and ignored
+func (e *ACMGAMPRuleEngine) lookupLabAssertion(ctx context.Context, variant *domain.StandardizedVariant) *labkb.Assertion {
+	if e.labKnowledge == nil {
+		return nil
+	}
+	for _, notation := range []string{variant.HGVSCoding, variant.HGVSGenomic} {
+		if notation == "" {
+			continue
+		}
+		assertion, err := e.labKnowledge.Lookup(ctx, notation)
+		if err != nil {
+			e.logger.WithError(err).WithField("variant", notation).Warn("Failed to look up lab knowledge base")
+			return nil
+		}
+		if assertion != nil {
+			return assertion
+		}
+	}
+	return nil
+}
+
+// evaluateCaseCount applies PS4 from the unrelated affected probands the lab
+// has observed with a variant rare enough for PM2, at the strength the case
+// count thresholds give
+func (e *ACMGAMPRuleEngine) evaluateCaseCount(ctx context.Context, result *domain.ACMGAMPRuleResult, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) {
+	if e.labKnowledge == nil {
+		result.Reasoning = "No lab knowledge base available for proband counts"
+		return
+	}
+	assertion := e.lookupLabAssertion(ctx, variant)
+	if assertion == nil || assertion.AffectedProbands == 0 {
+		result.Reasoning = "No affected probands with this variant recorded in the lab knowledge base"
+		return
+	}
+
+	thresholds := thresholdsFrom(ctx)
+	result.Evidence = fmt.Sprintf("Lab knowledge base: %d unrelated affected proband(s); lab classification %s, evaluated %s by %s",
+		assertion.AffectedProbands, assertion.Classification, assertion.EvaluatedAt.Format("2006-01-02"), assertion.EvaluatedBy)
+	if evidence != nil && evidence.PopulationData != nil {
+		if frequency, description := rarityFrequency(evidence.PopulationData); frequency >= thresholds.PM2AlleleFrequency {
+			result.Reasoning = fmt.Sprintf("Proband counting needs a variant rare enough for PM2; %s", strings.ToLower(description[:1])+description[1:])
+			return
+		}
+	}
+
+	supporting, moderate, strong := thresholds.CaseCounts.Cutoffs()
+	probands := assertion.AffectedProbands
+	switch {
+	case probands >= strong:
+		result.Strength = domain.STRONG
+	case probands >= moderate:
+		result.Strength = domain.MODERATE
+	case probands >= supporting:
+		result.Strength = domain.SUPPORTING
+	default:
+		result.Reasoning = fmt.Sprintf("%d unrelated affected proband(s) is below the PS4 supporting count of %d", probands, supporting)
+		return
+	}
+	result.Applied = true
+	result.Confidence = 0.7
+	result.Reasoning = fmt.Sprintf("Rare variant observed in %d unrelated affected probands by the lab, PS4 at %s strength", probands, strings.ToLower(result.Strength.Label()))
+	if evidence == nil || evidence.PopulationData == nil {
+		result.Confidence = 0.5
+		result.Reasoning += "; population frequency not checked"
+	}
+}
+
+// labResidueVariants returns the lab's pathogenic and likely pathogenic
+// assertions for other nucleotide changes causing the variant's missense
+// change, as PS1 matches
+func (e *ACMGAMPRuleEngine) labResidueVariants(ctx context.Context, variant *domain.StandardizedVariant) []domain.ResidueVariant {
+	change, ok := hgvs.ParseMissense(variant.HGVSProtein)
+	if e.labKnowledge == nil || !ok {
+		return nil
+	}
+	assertions, err := e.labKnowledge.FindProteinChange(ctx, variant.GeneSymbol, change.String())
+	if err != nil {
+		e.logger.WithError(err).WithField("gene", variant.GeneSymbol).Warn("Failed to look up lab knowledge base")
+		return nil
+	}
+
+	var matches []domain.ResidueVariant
+	for _, a := range assertions {
+		if !a.IsPathogenic() || a.NormalizedHGVS == variant.HGVSCoding || a.NormalizedHGVS == variant.HGVSGenomic {
+			continue
+		}
+		matches = append(matches, domain.ResidueVariant{
+			Source:               domain.ResidueSourceLab,
+			VariationID:          fmt.Sprintf("%d", a.ID),
+			Name:                 a.NormalizedHGVS,
+			ProteinChange:        a.ProteinChange,
+			ClinicalSignificance: domain.Classification(a.Classification).Label(),
+			ReviewStatus:         "lab assertion by " + a.EvaluatedBy,
+			SameChange:           true,
+		})
+	}
+	return matches
+}
+
+// withLabResidueVariants returns the evidence with the lab's PS1 matches
+// added to the ClinVar residue variants, skipping notations ClinVar already
+// lists. The gathered evidence is not modified.
+func (e *ACMGAMPRuleEngine) withLabResidueVariants(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) *domain.AggregatedEvidence {
+	lab := e.labResidueVariants(ctx, variant)
+	if len(lab) == 0 {
+		return evidence
+	}
+	merged := &domain.AggregatedEvidence{}
+	if evidence != nil {
+		*merged = *evidence
+	}
+	merged.ResidueVariants = append([]domain.ResidueVariant{}, merged.ResidueVariants...)
+	for _, match := range lab {
+		if !listedInClinVar(match, merged.ResidueVariants) {
+			merged.ResidueVariants = append(merged.ResidueVariants, match)
+		}
+	}
+	return merged
+}
+
+// listedInClinVar reports whether a lab match's nucleotide change appears in
+// the name of a ClinVar residue variant
+func listedInClinVar(match domain.ResidueVariant, variants []domain.ResidueVariant) bool {
+	_, change, found := strings.Cut(match.Name, ":")
+	if !found {
+		return false
+	}
+	for _, v := range variants {
+		if v.Source == "" && strings.Contains(v.Name, ":"+change) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+)
+
+type stubLabKnowledge struct {
+	assertions []*labkb.Assertion
+	revision   int64
+}
+
+// save adds an assertion and advances the revision, as a store's Save does
+func (s *stubLabKnowledge) save(assertion *labkb.Assertion) {
+	s.assertions = append(s.assertions, assertion)
+	s.revision++
+}
+
+func (s *stubLabKnowledge) Revision(ctx context.Context) (int64, error) {
+	return s.revision, nil
+}
+
+func (s *stubLabKnowledge) Lookup(ctx context.Context, normalizedHGVS string) (*labkb.Assertion, error) {
+	for _, a := range s.assertions {
+		if a.NormalizedHGVS == normalizedHGVS {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubLabKnowledge) FindProteinChange(ctx context.Context, geneSymbol, proteinChange string) ([]*labkb.Assertion, error) {
+	var matches []*labkb.Assertion
+	for _, a := range s.assertions {
+		if a.GeneSymbol == geneSymbol && a.ProteinChange == proteinChange {
+			matches = append(matches, a)
+		}
+	}
+	return matches, nil
+}
+
+func TestRuleEngine_CaseCount(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	ctx := context.Background()
+	variant := &domain.StandardizedVariant{GeneSymbol: "MYH7", HGVSCoding: "NM_000257.4:c.1208G>A", HGVSProtein: "p.Arg403Gln"}
+	rare := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleFrequency: 0.000004}}
+
+	result, err := engine.EvaluateRule(ctx, "PS4", variant, rare)
+	require.NoError(t, err)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "No lab knowledge base")
+
+	source := &stubLabKnowledge{}
+	engine.SetLabKnowledgeSource(source)
+	tests := []struct {
+		probands int
+		applied  bool
+		strength domain.RuleStrength
+	}{
+		{0, false, domain.STRONG},
+		{1, false, domain.STRONG},
+		{2, true, domain.SUPPORTING},
+		{6, true, domain.MODERATE},
+		{15, true, domain.STRONG},
+	}
+	for _, tt := range tests {
+		source.assertions = []*labkb.Assertion{{NormalizedHGVS: variant.HGVSCoding, Classification: "LIKELY_PATHOGENIC", AffectedProbands: tt.probands, EvaluatedBy: "curator1"}}
+		result, err = engine.EvaluateRule(ctx, "PS4", variant, rare)
+		require.NoError(t, err)
+		assert.Equal(t, tt.applied, result.Applied, "%d probands", tt.probands)
+		assert.Equal(t, tt.strength, result.Strength, "%d probands", tt.probands)
+	}
+	assert.Equal(t, 0.7, result.Confidence)
+	assert.Contains(t, result.Evidence, "15 unrelated affected proband(s)")
+
+	common := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleFrequency: 0.01}}
+	result, err = engine.EvaluateRule(ctx, "PS4", variant, common)
+	require.NoError(t, err)
+	assert.False(t, result.Applied, "a variant too common for PM2 is not counted")
+	assert.Contains(t, result.Reasoning, "rare enough for PM2")
+
+	result, err = engine.EvaluateRule(ctx, "PS4", variant, &domain.AggregatedEvidence{})
+	require.NoError(t, err)
+	assert.True(t, result.Applied)
+	assert.Equal(t, 0.5, result.Confidence)
+	assert.Contains(t, result.Reasoning, "population frequency not checked")
+}
+
+func TestRuleEngine_LabResidueVariants(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	ctx := context.Background()
+	variant := &domain.StandardizedVariant{GeneSymbol: "BRCA1", HGVSCoding: "NM_007294.4:c.5095C>T", HGVSProtein: "p.Arg1699Trp"}
+	evaluated := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	engine.SetLabKnowledgeSource(&stubLabKnowledge{assertions: []*labkb.Assertion{
+		{ID: 3, NormalizedHGVS: "NM_007294.4:c.5095_5097delinsTGG", GeneSymbol: "BRCA1", ProteinChange: "R1699W", Classification: "PATHOGENIC", EvaluatedAt: evaluated, EvaluatedBy: "curator1"},
+		{ID: 4, NormalizedHGVS: variant.HGVSCoding, GeneSymbol: "BRCA1", ProteinChange: "R1699W", Classification: "PATHOGENIC", EvaluatedAt: evaluated, EvaluatedBy: "curator1"},
+		{ID: 5, NormalizedHGVS: "NM_007294.4:c.5095C>A", GeneSymbol: "BRCA1", ProteinChange: "R1699W", Classification: "VUS", EvaluatedAt: evaluated, EvaluatedBy: "curator1"},
+	}})
+
+	result, err := engine.EvaluateRule(ctx, "PS1", variant, &domain.AggregatedEvidence{})
+	require.NoError(t, err)
+	assert.True(t, result.Applied, "a lab match applies PS1 without a ClinVar lookup")
+	require.Len(t, result.MatchedVariants, 1, "the variant itself and VUS assertions are not matches")
+	assert.Equal(t, domain.ResidueSourceLab, result.MatchedVariants[0].Source)
+	assert.Equal(t, "3", result.MatchedVariants[0].VariationID)
+	assert.Contains(t, result.Evidence, "Lab knowledge base: R1699W (NM_007294.4:c.5095_5097delinsTGG, assertion 3): Pathogenic")
+	assert.Contains(t, result.Reasoning, "pathogenic lab variant(s)")
+
+	clinvar := domain.ResidueVariant{VariationID: "55447", Name: "NM_007294.4(BRCA1):c.5095_5097delinsTGG (p.Arg1699Trp)", ProteinChange: "R1699W", ClinicalSignificance: "Pathogenic", Stars: 2, SameChange: true}
+	evidence := &domain.AggregatedEvidence{ResidueVariants: []domain.ResidueVariant{clinvar}}
+	result, err = engine.EvaluateRule(ctx, "PS1", variant, evidence)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ResidueVariant{clinvar}, result.MatchedVariants, "a lab match ClinVar already lists is not counted twice")
+	assert.Len(t, evidence.ResidueVariants, 1, "the gathered evidence is not modified")
+
+	result, err = engine.EvaluateRule(ctx, "PM5", variant, &domain.AggregatedEvidence{})
+	require.NoError(t, err)
+	assert.False(t, result.Applied, "PM5 is not applied alongside a lab PS1 match")
+}
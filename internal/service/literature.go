@@ -0,0 +1,41 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// literatureCandidates lists the PMIDs of non-retracted citations carrying a
+// literature signal
+func literatureCandidates(evidence *domain.AggregatedEvidence, signal string) []string {
+	if evidence == nil || evidence.LiteratureData == nil {
+		return nil
+	}
+	var pmids []string
+	for _, citation := range evidence.LiteratureData.Citations {
+		if !citation.Retracted && citation.HasSignal(signal) {
+			pmids = append(pmids, citation.PMID)
+		}
+	}
+	return pmids
+}
+
+// evaluateLiterature reports the articles mined as candidate evidence for a
+// literature-based criterion. Abstract signals say nothing of assay validity
+// or source reliability, so the criterion is left for curator review and not
+// applied.
+func evaluateLiterature(result *domain.ACMGAMPRuleResult, evidence *domain.AggregatedEvidence, signal, description string) {
+	if evidence == nil || evidence.LiteratureData == nil {
+		result.Reasoning = "No literature evidence available"
+		return
+	}
+	pmids := literatureCandidates(evidence, signal)
+	if len(pmids) == 0 {
+		result.Reasoning = fmt.Sprintf("None of %d retrieved articles report %s", evidence.LiteratureData.RetrievedCitations, description)
+		return
+	}
+	result.Evidence = fmt.Sprintf("Articles reporting %s: PMID %s", description, strings.Join(pmids, ", "))
+	result.Reasoning = fmt.Sprintf("%d candidate articles found by literature mining; %s requires curator review of the publications before it is applied", len(pmids), result.Code)
+}
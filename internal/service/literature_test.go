@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestRuleEngine_LiteratureCandidates(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{GeneSymbol: "BRCA1", HGVSCoding: "NM_007294.4:c.5266dupC"}
+	evidence := &domain.AggregatedEvidence{LiteratureData: &domain.LiteratureData{
+		RetrievedCitations: 3,
+		Citations: []domain.Citation{
+			{PMID: "20301425", Signals: []string{domain.SignalFunctionalDamaging, domain.SignalReportedPathogenic}},
+			{PMID: "15920490", Signals: []string{domain.SignalFunctionalDamaging}, Retracted: true},
+			{PMID: "11111111", Signals: []string{domain.SignalCaseReport}},
+		},
+	}}
+
+	ps3, err := engine.EvaluateRule(context.Background(), "PS3", variant, evidence)
+	require.NoError(t, err)
+	assert.False(t, ps3.Applied, "literature signals need curator review")
+	assert.Equal(t, "Articles reporting a damaging effect in functional studies: PMID 20301425", ps3.Evidence, "retracted articles are left out")
+
+	bs3, err := engine.EvaluateRule(context.Background(), "BS3", variant, evidence)
+	require.NoError(t, err)
+	assert.Empty(t, bs3.Evidence)
+	assert.Contains(t, bs3.Reasoning, "None of 3 retrieved articles")
+
+	pp5, err := engine.EvaluateRule(context.Background(), "PP5", variant, &domain.AggregatedEvidence{})
+	require.NoError(t, err)
+	assert.Equal(t, "No literature evidence available", pp5.Reasoning)
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/conflicts"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// ConsequenceClass groups transcript consequences by their interpretive impact
+type ConsequenceClass string
+
+const (
+	ConsequenceLossOfFunction ConsequenceClass = "loss_of_function"
+	ConsequenceMissense       ConsequenceClass = "missense"
+	ConsequenceInframe        ConsequenceClass = "inframe"
+	ConsequenceSynonymous     ConsequenceClass = "synonymous"
+	ConsequenceOther          ConsequenceClass = "other"
+)
+
+// consequenceSeverity orders classes from least to most severe
+var consequenceSeverity = map[ConsequenceClass]int{
+	ConsequenceOther:          0,
+	ConsequenceSynonymous:     1,
+	ConsequenceInframe:        2,
+	ConsequenceMissense:       3,
+	ConsequenceLossOfFunction: 4,
+}
+
+// consequenceTerms maps Sequence Ontology terms to consequence classes
+var consequenceTerms = map[string]ConsequenceClass{
+	"transcript_ablation":      ConsequenceLossOfFunction,
+	"stop_gained":              ConsequenceLossOfFunction,
+	"nonsense":                 ConsequenceLossOfFunction,
+	"frameshift_variant":       ConsequenceLossOfFunction,
+	"frameshift":               ConsequenceLossOfFunction,
+	"splice_donor_variant":     ConsequenceLossOfFunction,
+	"splice_acceptor_variant":  ConsequenceLossOfFunction,
+	"start_lost":               ConsequenceLossOfFunction,
+	"missense_variant":         ConsequenceMissense,
+	"missense":                 ConsequenceMissense,
+	"inframe_insertion":        ConsequenceInframe,
+	"inframe_deletion":         ConsequenceInframe,
+	"protein_altering_variant": ConsequenceInframe,
+	"stop_lost":                ConsequenceInframe,
+	"synonymous_variant":       ConsequenceSynonymous,
+	"synonymous":               ConsequenceSynonymous,
+}
+
+var (
+	canonicalSplicePattern = regexp.MustCompile(`c\.-?\*?\d+[+-][12](?:[^0-9]|$)`)
+	missenseProteinPattern = regexp.MustCompile(`^p\.\(?[A-Z][a-z]{2}\d+[A-Z][a-z]{2}\)?$`)
+)
+
+// ClassifyConsequence determines the consequence class from an annotated term,
+// falling back to HGVS coding and protein notation when no term is available
+func ClassifyConsequence(term, hgvsCoding, hgvsProtein string) ConsequenceClass {
+	if term != "" {
+		class := ConsequenceOther
+		// VEP joins multiple terms with '&'; keep the most severe
+		for _, t := range strings.FieldsFunc(strings.ToLower(term), func(r rune) bool { return r == '&' || r == ',' }) {
+			if c, ok := consequenceTerms[strings.TrimSpace(t)]; ok && consequenceSeverity[c] > consequenceSeverity[class] {
+				class = c
+			}
+		}
+		if class != ConsequenceOther {
+			return class
+		}
+	}
+
+	protein := hgvsProtein
+	if idx := strings.Index(protein, ":"); idx >= 0 {
+		protein = protein[idx+1:]
+	}
+	core := strings.TrimLeft(strings.TrimPrefix(protein, "p."), "(")
+	stopLost := strings.HasPrefix(core, "Ter") || strings.HasPrefix(core, "*")
+
+	switch {
+	case strings.Contains(protein, "fs"),
+		!stopLost && (strings.Contains(protein, "Ter") || strings.Contains(protein, "*")),
+		strings.HasPrefix(core, "Met1") && !strings.HasPrefix(core, "Met1="),
+		canonicalSplicePattern.MatchString(hgvsCoding):
+		return ConsequenceLossOfFunction
+	case stopLost:
+		return ConsequenceInframe
+	case strings.Contains(protein, "="):
+		return ConsequenceSynonymous
+	case strings.Contains(protein, "del") || strings.Contains(protein, "ins") || strings.Contains(protein, "dup"):
+		return ConsequenceInframe
+	case missenseProteinPattern.MatchString(protein):
+		return ConsequenceMissense
+	}
+	return ConsequenceOther
+}
+
+// TranscriptOutcome is the classification outcome on a single transcript
+type TranscriptOutcome struct {
+	TranscriptID     string           `json:"transcript_id"`
+	HGVSCoding       string           `json:"hgvs_coding,omitempty"`
+	HGVSProtein      string           `json:"hgvs_protein,omitempty"`
+	ConsequenceClass ConsequenceClass `json:"consequence_class"`
+	Classification   string           `json:"classification"`
+	Confidence       string           `json:"confidence"`
+	MetCriteria      []string         `json:"met_criteria"`
+	PointTotal       int              `json:"point_total"`
+	Selected         bool             `json:"selected"`
+}
+
+// MultiTranscriptAssessment describes how a discordant multi-transcript call was made
+type MultiTranscriptAssessment struct {
+	Outcomes           []TranscriptOutcome `json:"outcomes"`
+	SelectedTranscript string              `json:"selected_transcript,omitempty"` // Empty when transcript calls conflict in direction
+	Rationale          string              `json:"rationale"`
+
+	ruleResults    []domain.ACMGAMPRuleResult
+	conflicts      []conflicts.Decision
+	classification domain.Classification
+	confidence     domain.ConfidenceLevel
+}
+
+// assessTranscripts evaluates every clinically relevant transcript when their
+// consequences differ materially and selects the most conservative call.
+// Returns nil when fewer than two transcripts are relevant or consequences agree.
+func (c *ClassifierService) assessTranscripts(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) (*MultiTranscriptAssessment, error) {
+	transcripts := relevantTranscripts(variant.TranscriptConsequences)
+	if len(transcripts) < 2 {
+		return nil, nil
+	}
+
+	classes := make([]ConsequenceClass, len(transcripts))
+	discordant := false
+	for i, tc := range transcripts {
+		classes[i] = ClassifyConsequence(tc.Consequence, tc.HGVSCoding, tc.HGVSProtein)
+		if classes[i] != classes[0] {
+			discordant = true
+		}
+	}
+	if !discordant {
+		return nil, nil
+	}
+
+	assessment := &MultiTranscriptAssessment{Outcomes: make([]TranscriptOutcome, len(transcripts))}
+	results := make([][]domain.ACMGAMPRuleResult, len(transcripts))
+	decisions := make([][]conflicts.Decision, len(transcripts))
+	calls := make([]domain.Classification, len(transcripts))
+	confidences := make([]domain.ConfidenceLevel, len(transcripts))
+
+	for i, tc := range transcripts {
+		tv := *variant
+		tv.TranscriptID = tc.TranscriptID
+		tv.HGVSCoding = tc.HGVSCoding
+		tv.HGVSProtein = tc.HGVSProtein
+		tv.Consequence = tc.Consequence
+		tv.TranscriptConsequences = nil
+
+		ruleResults, conflictDecisions, err := c.ruleEngine.evaluateRules(ctx, &tv, evidence)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate rules for transcript %s: %w", tc.TranscriptID, err)
+		}
+		results[i], decisions[i] = ruleResults, conflictDecisions
+		calls[i], confidences[i] = c.ruleEngine.classify(ctx, ruleResults)
+
+		assessment.Outcomes[i] = TranscriptOutcome{
+			TranscriptID:     tc.TranscriptID,
+			HGVSCoding:       tc.HGVSCoding,
+			HGVSProtein:      tc.HGVSProtein,
+			ConsequenceClass: classes[i],
+			Classification:   calls[i].String(),
+			Confidence:       confidences[i].String(),
+			MetCriteria:      metCriteria(ruleResults),
+			PointTotal:       PointTotal(ruleResults),
+		}
+	}
+
+	// Most conservative transcript: closest to VUS, first listed wins ties
+	selected := 0
+	hasPathogenic, hasBenign := false, false
+	for i, call := range calls {
+		switch call {
+		case domain.PATHOGENIC, domain.LIKELY_PATHOGENIC:
+			hasPathogenic = true
+		case domain.BENIGN, domain.LIKELY_BENIGN:
+			hasBenign = true
+		}
+		if conservatismRank(call) < conservatismRank(calls[selected]) {
+			selected = i
+		}
+	}
+
+	assessment.ruleResults, assessment.conflicts = results[selected], decisions[selected]
+	summary := make([]string, len(transcripts))
+	for i, o := range assessment.Outcomes {
+		summary[i] = fmt.Sprintf("%s: %s -> %s", o.TranscriptID, o.ConsequenceClass, o.Classification)
+	}
+	rationale := fmt.Sprintf("Consequence differs across clinically relevant transcripts (%s).", strings.Join(summary, "; "))
+
+	if hasPathogenic && hasBenign {
+		assessment.classification = domain.VUS
+		assessment.confidence = domain.LOW
+		assessment.Rationale = rationale + " Transcript-level calls conflict in direction; reporting VUS until the transcript of clinical relevance is confirmed."
+	} else {
+		assessment.Outcomes[selected].Selected = true
+		assessment.SelectedTranscript = transcripts[selected].TranscriptID
+		assessment.classification = calls[selected]
+		assessment.confidence = confidences[selected]
+		assessment.Rationale = fmt.Sprintf("%s Reporting %s from %s as the most clinically conservative call.",
+			rationale, calls[selected], transcripts[selected].TranscriptID)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"variant_id":          variant.ID,
+		"transcripts":         len(transcripts),
+		"selected_transcript": assessment.SelectedTranscript,
+		"classification":      assessment.classification,
+	}).Info("Discordant transcript consequences detected")
+
+	return assessment, nil
+}
+
+// relevantTranscripts returns the clinically relevant transcripts, or all
+// transcripts when none are flagged, dropping duplicates
+func relevantTranscripts(consequences []domain.TranscriptConsequence) []domain.TranscriptConsequence {
+	flagged := false
+	for _, tc := range consequences {
+		if tc.ClinicallyRelevant {
+			flagged = true
+			break
+		}
+	}
+
+	seen := make(map[string]bool)
+	relevant := make([]domain.TranscriptConsequence, 0, len(consequences))
+	for _, tc := range consequences {
+		if (flagged && !tc.ClinicallyRelevant) || tc.TranscriptID == "" || seen[tc.TranscriptID] {
+			continue
+		}
+		seen[tc.TranscriptID] = true
+		relevant = append(relevant, tc)
+	}
+	return relevant
+}
+
+// conservatismRank orders classifications by distance from VUS
+func conservatismRank(c domain.Classification) int {
+	switch c {
+	case domain.VUS:
+		return 0
+	case domain.LIKELY_PATHOGENIC, domain.LIKELY_BENIGN:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func metCriteria(results []domain.ACMGAMPRuleResult) []string {
+	met := make([]string, 0)
+	for _, r := range results {
+		if r.Applied {
+			met = append(met, r.Code)
+		}
+	}
+	return met
+}
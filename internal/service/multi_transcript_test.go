@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestClassifyConsequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		term     string
+		coding   string
+		protein  string
+		expected ConsequenceClass
+	}{
+		{"SO term", "stop_gained", "", "", ConsequenceLossOfFunction},
+		{"Most severe of joined terms", "splice_region_variant&missense_variant", "", "", ConsequenceMissense},
+		{"Nonsense protein", "", "c.1234C>T", "p.Arg412Ter", ConsequenceLossOfFunction},
+		{"Frameshift protein", "", "c.68_69del", "p.(Glu23ValfsTer17)", ConsequenceLossOfFunction},
+		{"Canonical splice", "", "NM_007294.4:c.5277+1G>A", "", ConsequenceLossOfFunction},
+		{"Deep intronic", "", "c.5277+15G>A", "", ConsequenceOther},
+		{"Missense", "", "c.1234C>G", "p.Arg412Gly", ConsequenceMissense},
+		{"Synonymous", "", "c.1236G>A", "p.Arg412=", ConsequenceSynonymous},
+		{"Stop lost", "", "c.1864T>C", "p.Ter622GlnextTer?", ConsequenceInframe},
+		{"Inframe deletion", "", "c.1521_1523del", "p.Phe508del", ConsequenceInframe},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ClassifyConsequence(tt.term, tt.coding, tt.protein))
+		})
+	}
+}
+
+func TestAssessTranscripts_Discordant(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	variant := &domain.StandardizedVariant{
+		ID:         "var-1",
+		GeneSymbol: "BRCA1",
+		TranscriptConsequences: []domain.TranscriptConsequence{
+			{TranscriptID: "NM_007294.4", HGVSProtein: "p.Arg412Ter", ClinicallyRelevant: true},
+			{TranscriptID: "NM_007300.4", HGVSProtein: "p.Arg433Gly", ClinicallyRelevant: true},
+			{TranscriptID: "NM_007297.4", HGVSProtein: "p.Arg371Ter"},
+		},
+	}
+
+	// Act
+	assessment, err := service.assessTranscripts(context.Background(), variant, &domain.AggregatedEvidence{})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, assessment)
+	require.Len(t, assessment.Outcomes, 2, "only clinically relevant transcripts are evaluated")
+	assert.Equal(t, ConsequenceLossOfFunction, assessment.Outcomes[0].ConsequenceClass)
+	assert.Contains(t, assessment.Outcomes[0].MetCriteria, "PVS1")
+	assert.Equal(t, ConsequenceMissense, assessment.Outcomes[1].ConsequenceClass)
+	assert.NotContains(t, assessment.Outcomes[1].MetCriteria, "PVS1")
+	assert.Contains(t, assessment.Rationale, "NM_007294.4")
+	assert.Contains(t, assessment.Rationale, "NM_007300.4")
+
+	for _, o := range assessment.Outcomes {
+		assert.LessOrEqual(t, conservatismRank(assessment.classification), conservatismRank(domain.Classification(o.Classification)))
+	}
+}
+
+func TestAssessTranscripts_Concordant(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	variant := &domain.StandardizedVariant{
+		TranscriptConsequences: []domain.TranscriptConsequence{
+			{TranscriptID: "NM_000546.6", Consequence: "missense_variant"},
+			{TranscriptID: "NM_001126112.3", Consequence: "missense_variant"},
+		},
+	}
+
+	assessment, err := service.assessTranscripts(context.Background(), variant, &domain.AggregatedEvidence{})
+
+	require.NoError(t, err)
+	assert.Nil(t, assessment)
+}
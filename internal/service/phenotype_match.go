@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// PhenotypeSource scores patient HPO terms against the phenotypes associated
+// with a gene. Match returns nil when the gene has no associations.
+type PhenotypeSource interface {
+	Match(gene string, terms []string) *domain.PhenotypeMatch
+}
+
+// SetPhenotypeSource configures HPO phenotype matching for PP4.
+// Without a source PP4 is not assessed.
+func (e *ACMGAMPRuleEngine) SetPhenotypeSource(source PhenotypeSource) {
+	e.phenotypes = source
+}
+
+// SetPhenotypeSource configures the phenotype matcher used by the rule engine
+func (c *ClassifierService) SetPhenotypeSource(source PhenotypeSource) {
+	c.ruleEngine.SetPhenotypeSource(source)
+}
+
+// maxListedTerms bounds the matched terms named in the PP4 reasoning
+const maxListedTerms = 5
+
+// evaluatePhenotype applies PP4 when the patient's HPO terms are similar
+// enough to the phenotypes associated with the gene
+func (e *ACMGAMPRuleEngine) evaluatePhenotype(ctx context.Context, result *domain.ACMGAMPRuleResult, variant *domain.StandardizedVariant) {
+	patient := patientContextFrom(ctx)
+	if patient == nil || len(patient.HPOTerms) == 0 {
+		result.Reasoning = "HPO terms not provided; pass patient_context.hpo_terms to assess PP4"
+		return
+	}
+	var match *domain.PhenotypeMatch
+	if e.phenotypes != nil {
+		match = e.phenotypes.Match(variant.GeneSymbol, patient.HPOTerms)
+	}
+	if match == nil {
+		result.Reasoning = fmt.Sprintf("No HPO annotations available for %s", variant.GeneSymbol)
+		return
+	}
+
+	cutoff := thresholdsFrom(ctx).Phenotype.Cutoff()
+	result.PhenotypeMatch = match
+	result.Evidence = fmt.Sprintf("HPO similarity %.2f to %s phenotypes over %d patient terms", match.Score, match.Gene, len(match.Terms))
+	if len(match.UnknownTerms) > 0 {
+		result.Evidence += fmt.Sprintf("; not in the ontology: %s", strings.Join(match.UnknownTerms, ", "))
+	}
+
+	matched := matchedTermList(match.Terms)
+	if match.Score < cutoff {
+		result.Reasoning = fmt.Sprintf("Phenotype similarity %.2f is below the PP4 cutoff of %.2f", match.Score, cutoff)
+		if matched != "" {
+			result.Reasoning += "; closest matches: " + matched
+		}
+		return
+	}
+	result.Applied = true
+	result.Confidence = 0.5 + match.Score/2
+	result.Reasoning = fmt.Sprintf("Patient phenotype matches %s (similarity %.2f at or above %.2f): %s",
+		match.Gene, match.Score, cutoff, matched)
+}
+
+// matchedTermList names the best patient term matches, e.g.
+// "Seizure (HP:0001250) annotated to the gene" or
+// "Focal clonic seizure (HP:0002266) ~ Focal seizure (HP:0007359) via Focal seizure"
+func matchedTermList(terms []domain.PhenotypeTermMatch) string {
+	var parts []string
+	for _, t := range terms {
+		if t.CommonAncestor == "" {
+			continue
+		}
+		if len(parts) == maxListedTerms {
+			parts = append(parts, fmt.Sprintf("and %d more", countMatched(terms)-maxListedTerms))
+			break
+		}
+		part := fmt.Sprintf("%s (%s)", t.PatientLabel, t.PatientTerm)
+		if t.GeneTerm == t.PatientTerm {
+			part += " annotated to the gene"
+		} else {
+			part += fmt.Sprintf(" ~ %s (%s) via %s", t.GeneLabel, t.GeneTerm, t.CommonAncestorLabel)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func countMatched(terms []domain.PhenotypeTermMatch) int {
+	n := 0
+	for _, t := range terms {
+		if t.CommonAncestor != "" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+type stubPhenotypeSource struct {
+	matches map[string]*domain.PhenotypeMatch
+}
+
+func (s *stubPhenotypeSource) Match(gene string, terms []string) *domain.PhenotypeMatch {
+	return s.matches[gene]
+}
+
+func TestRuleEngine_PhenotypeMatch(t *testing.T) {
+	source := &stubPhenotypeSource{matches: map[string]*domain.PhenotypeMatch{
+		"SCN1A": {Gene: "SCN1A", Score: 0.8, Terms: []domain.PhenotypeTermMatch{
+			{PatientTerm: "HP:0002266", PatientLabel: "Focal clonic seizure", GeneTerm: "HP:0002266", GeneLabel: "Focal clonic seizure",
+				CommonAncestor: "HP:0002266", CommonAncestorLabel: "Focal clonic seizure", Similarity: 1},
+			{PatientTerm: "HP:0001263", PatientLabel: "Global developmental delay", GeneTerm: "HP:0001249", GeneLabel: "Intellectual disability",
+				CommonAncestor: "HP:0012759", CommonAncestorLabel: "Neurodevelopmental abnormality", Similarity: 0.6},
+		}},
+		"TSC2": {Gene: "TSC2", Score: 0.3, Terms: []domain.PhenotypeTermMatch{
+			{PatientTerm: "HP:0002266", PatientLabel: "Focal clonic seizure", GeneTerm: "HP:0001250", GeneLabel: "Seizure",
+				CommonAncestor: "HP:0001250", CommonAncestorLabel: "Seizure", Similarity: 0.3},
+		}},
+	}}
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	engine.SetPhenotypeSource(source)
+	patient := &PatientContext{HPOTerms: []string{"HP:0002266", "HP:0001263"}}
+
+	evaluate := func(engine *ACMGAMPRuleEngine, gene string, patient *PatientContext) *domain.ACMGAMPRuleResult {
+		t.Helper()
+		variant := &domain.StandardizedVariant{GeneSymbol: gene}
+		result, err := engine.EvaluateRule(withPatientContext(context.Background(), patient), "PP4", variant, &domain.AggregatedEvidence{})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := evaluate(engine, "SCN1A", patient)
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.SUPPORTING, result.Strength)
+	require.NotNil(t, result.PhenotypeMatch)
+	assert.Contains(t, result.Reasoning, "Focal clonic seizure (HP:0002266) annotated to the gene")
+	assert.Contains(t, result.Reasoning, "Intellectual disability (HP:0001249) via Neurodevelopmental abnormality")
+
+	result = evaluate(engine, "TSC2", patient)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "below the PP4 cutoff of 0.50")
+	assert.Contains(t, result.Reasoning, "Seizure (HP:0001250)")
+
+	result = evaluate(engine, "BRCA1", patient)
+	assert.False(t, result.Applied)
+	assert.Equal(t, "No HPO annotations available for BRCA1", result.Reasoning)
+
+	result = evaluate(engine, "SCN1A", nil)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "patient_context.hpo_terms")
+
+	unconfigured := NewACMGAMPRuleEngine(logrus.New())
+	assert.False(t, evaluate(unconfigured, "SCN1A", patient).Applied)
+
+	// A lower cutoff from the thresholds
+	lenient := thresholds.Defaults()
+	lenient.Phenotype.PP4Similarity = 0.25
+	unconfigured.SetPhenotypeSource(source)
+	unconfigured.SetThresholdSource(&stubThresholdSource{revision: &thresholds.Revision{ID: 1, Thresholds: lenient}})
+	assert.True(t, evaluate(unconfigured, "TSC2", patient).Applied)
+}
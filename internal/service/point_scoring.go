@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// ScoringMode selects how applied criteria are combined into a classification
+type ScoringMode string
+
+const (
+	// ScoringModeCombiningRules uses the ACMG/AMP 2015 combining rules (Table 5)
+	ScoringModeCombiningRules ScoringMode = "combining_rules"
+	// ScoringModePoints uses the ClinGen SVI point-based Bayesian framework
+	// (Tavtigian et al. 2018, 2020)
+	ScoringModePoints ScoringMode = "points"
+)
+
+// Point values per evidence strength; benign criteria count negatively
+var criterionPoints = map[domain.RuleStrength]int{
+	domain.VERY_STRONG: 8,
+	domain.STRONG:      4,
+	domain.MODERATE:    2,
+	domain.SUPPORTING:  1,
+}
+
+// Point total boundaries for each classification
+const (
+	pathogenicMinPoints       = 10
+	likelyPathogenicMinPoints = 6
+	likelyBenignMaxPoints     = -1
+	benignMaxPoints           = -7
+)
+
+// ParseScoringMode parses a scoring mode name. An empty name selects the combining rules.
+func ParseScoringMode(name string) (ScoringMode, error) {
+	switch ScoringMode(strings.ToLower(strings.TrimSpace(name))) {
+	case "", ScoringModeCombiningRules:
+		return ScoringModeCombiningRules, nil
+	case ScoringModePoints:
+		return ScoringModePoints, nil
+	}
+	return "", fmt.Errorf("unknown scoring mode %q: use %s or %s", name, ScoringModeCombiningRules, ScoringModePoints)
+}
+
+type scoringModeKey struct{}
+
+// withScoringMode attaches the scoring mode to an evaluation context
+func withScoringMode(ctx context.Context, mode ScoringMode) context.Context {
+	return context.WithValue(ctx, scoringModeKey{}, mode)
+}
+
+// scoringModeFrom returns the scoring mode attached to the context, or the combining rules
+func scoringModeFrom(ctx context.Context) ScoringMode {
+	if mode, ok := ctx.Value(scoringModeKey{}).(ScoringMode); ok {
+		return mode
+	}
+	return ScoringModeCombiningRules
+}
+
+// SetScoringMode sets the default scoring mode used when a request does not select one
+func (c *ClassifierService) SetScoringMode(mode ScoringMode) {
+	c.scoringMode = mode
+}
+
+// PointTotal sums the points of the applied criteria
+func PointTotal(ruleResults []domain.ACMGAMPRuleResult) int {
+	total := 0
+	for _, result := range ruleResults {
+		if !result.Applied {
+			continue
+		}
+		switch result.Category {
+		case domain.PATHOGENIC_RULE:
+			total += criterionPoints[result.Strength]
+		case domain.BENIGN_RULE:
+			total -= criterionPoints[result.Strength]
+		}
+	}
+	return total
+}
+
+// ScoreEvidence classifies applied criteria with the point-based framework.
+// BA1 remains stand-alone benign regardless of the point total.
+func (e *ACMGAMPRuleEngine) ScoreEvidence(ruleResults []domain.ACMGAMPRuleResult) (domain.Classification, domain.ConfidenceLevel, int) {
+	points := PointTotal(ruleResults)
+
+	var classification domain.Classification
+	switch {
+	case standaloneBenign(ruleResults):
+		classification = domain.BENIGN
+	case points >= pathogenicMinPoints:
+		classification = domain.PATHOGENIC
+	case points >= likelyPathogenicMinPoints:
+		classification = domain.LIKELY_PATHOGENIC
+	case points <= benignMaxPoints:
+		classification = domain.BENIGN
+	case points <= likelyBenignMaxPoints:
+		classification = domain.LIKELY_BENIGN
+	default:
+		classification = domain.VUS
+	}
+	confidence := e.determineConfidence(ruleResults, classification)
+
+	e.logger.WithFields(logrus.Fields{
+		"classification": classification.String(),
+		"confidence":     confidence.String(),
+		"points":         points,
+	}).Info("Completed point-based evidence scoring")
+
+	return classification, confidence, points
+}
+
+// classify combines applied criteria using the scoring mode attached to the context
+func (e *ACMGAMPRuleEngine) classify(ctx context.Context, ruleResults []domain.ACMGAMPRuleResult) (domain.Classification, domain.ConfidenceLevel) {
+	if scoringModeFrom(ctx) == ScoringModePoints {
+		classification, confidence, _ := e.ScoreEvidence(ruleResults)
+		return classification, confidence
+	}
+	return e.CombineEvidence(ruleResults)
+}
+
+// standaloneBenign reports whether BA1 (stand-alone benign) was applied
+func standaloneBenign(ruleResults []domain.ACMGAMPRuleResult) bool {
+	for _, result := range ruleResults {
+		if result.Applied && result.Category == domain.BENIGN_RULE && result.Strength == domain.VERY_STRONG {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func applied(code string, category domain.RuleCategory, strength domain.RuleStrength) domain.ACMGAMPRuleResult {
+	return domain.ACMGAMPRuleResult{Code: code, Category: category, Strength: strength, Applied: true, Confidence: 0.9}
+}
+
+func TestPointTotal(t *testing.T) {
+	results := []domain.ACMGAMPRuleResult{
+		applied("PVS1", domain.PATHOGENIC_RULE, domain.VERY_STRONG),
+		applied("PM2", domain.PATHOGENIC_RULE, domain.MODERATE),
+		applied("BP4", domain.BENIGN_RULE, domain.SUPPORTING),
+		{Code: "PS3", Category: domain.PATHOGENIC_RULE, Strength: domain.STRONG, Applied: false},
+	}
+
+	assert.Equal(t, 9, PointTotal(results))
+	assert.Zero(t, PointTotal(nil))
+}
+
+func TestRuleEngine_ScoreEvidence(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	pvs := applied("PVS1", domain.PATHOGENIC_RULE, domain.VERY_STRONG)
+	ps := applied("PS3", domain.PATHOGENIC_RULE, domain.STRONG)
+	pm := applied("PM2", domain.PATHOGENIC_RULE, domain.MODERATE)
+	pp := applied("PP3", domain.PATHOGENIC_RULE, domain.SUPPORTING)
+	bs := applied("BS1", domain.BENIGN_RULE, domain.STRONG)
+	bp := applied("BP4", domain.BENIGN_RULE, domain.SUPPORTING)
+	ba := applied("BA1", domain.BENIGN_RULE, domain.VERY_STRONG)
+
+	tests := []struct {
+		name           string
+		results        []domain.ACMGAMPRuleResult
+		classification domain.Classification
+		points         int
+	}{
+		{"PVS1 and PM2 reach pathogenic", []domain.ACMGAMPRuleResult{pvs, pm}, domain.PATHOGENIC, 10},
+		{"PVS1 alone is likely pathogenic", []domain.ACMGAMPRuleResult{pvs}, domain.LIKELY_PATHOGENIC, 8},
+		{"PS3 and PM2 reach likely pathogenic", []domain.ACMGAMPRuleResult{ps, pm}, domain.LIKELY_PATHOGENIC, 6},
+		{"Five points stay VUS", []domain.ACMGAMPRuleResult{ps, pp}, domain.VUS, 5},
+		{"Conflicting evidence nets out", []domain.ACMGAMPRuleResult{pm, pp, bp}, domain.VUS, 2},
+		{"One benign supporting is likely benign", []domain.ACMGAMPRuleResult{bp}, domain.LIKELY_BENIGN, -1},
+		{"BS1 and three BP reach benign", []domain.ACMGAMPRuleResult{bs, bp, bp, bp}, domain.BENIGN, -7},
+		{"BA1 is stand-alone benign", []domain.ACMGAMPRuleResult{ba, pvs, pm}, domain.BENIGN, 2},
+		{"No evidence is VUS", nil, domain.VUS, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classification, _, points := engine.ScoreEvidence(tt.results)
+			assert.Equal(t, tt.classification, classification)
+			assert.Equal(t, tt.points, points)
+		})
+	}
+}
+
+func TestRuleEngine_ClassifyUsesScoringMode(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	// PVS1 + PM2: likely pathogenic under the 2015 rules, but 10 points
+	results := []domain.ACMGAMPRuleResult{
+		applied("PVS1", domain.PATHOGENIC_RULE, domain.VERY_STRONG),
+		applied("PM2", domain.PATHOGENIC_RULE, domain.MODERATE),
+	}
+
+	classification, _ := engine.classify(context.Background(), results)
+	assert.Equal(t, domain.LIKELY_PATHOGENIC, classification)
+
+	classification, _ = engine.classify(withScoringMode(context.Background(), ScoringModePoints), results)
+	assert.Equal(t, domain.PATHOGENIC, classification)
+}
+
+func TestParseScoringMode(t *testing.T) {
+	mode, err := ParseScoringMode("")
+	require.NoError(t, err)
+	assert.Equal(t, ScoringModeCombiningRules, mode)
+
+	mode, err = ParseScoringMode(" Points ")
+	require.NoError(t, err)
+	assert.Equal(t, ScoringModePoints, mode)
+
+	_, err = ParseScoringMode("bayes")
+	assert.Error(t, err)
+}
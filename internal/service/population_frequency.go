@@ -0,0 +1,42 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// benignFrequency returns the frequency compared against the BA1 and BS1
+// thresholds. When gnomAD v4 reports a popmax filtering allele frequency it is
+// used instead of the point estimate, as recommended by ClinGen SVI: the FAF95
+// is the lower 95% confidence bound in the most frequent ancestry group, so a
+// benign call does not rest on a handful of observations.
+func benignFrequency(data *domain.PopulationData) (float64, string) {
+	if data.FAF95 > 0 {
+		return data.FAF95, describeFAF(data)
+	}
+	return data.AlleleFrequency, fmt.Sprintf("Population frequency: %.6f", data.AlleleFrequency)
+}
+
+// rarityFrequency returns the frequency compared against the PM2 threshold.
+// A variant must be rare overall and in its most frequent ancestry group, so
+// the larger of the joint allele frequency and the FAF95 is used.
+func rarityFrequency(data *domain.PopulationData) (float64, string) {
+	if data.FAF95 > data.AlleleFrequency {
+		return data.FAF95, describeFAF(data)
+	}
+	description := fmt.Sprintf("Population frequency: %.6f", data.AlleleFrequency)
+	if data.FAF95 > 0 {
+		description += fmt.Sprintf(" (faf95 %.6f, faf99 %.6f)", data.FAF95, data.FAF99)
+	}
+	return data.AlleleFrequency, description
+}
+
+// describeFAF formats the filtering allele frequencies for rule evidence
+func describeFAF(data *domain.PopulationData) string {
+	description := fmt.Sprintf("Filtering allele frequency (faf95): %.6f", data.FAF95)
+	if data.FAFPopulation != "" {
+		description += fmt.Sprintf(" in %s", data.FAFPopulation)
+	}
+	return description + fmt.Sprintf(", faf99 %.6f, joint AF %.6f", data.FAF99, data.AlleleFrequency)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestRuleEngine_BenignRulesUseFilteringAlleleFrequency(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{ID: "var-1"}
+
+	// Joint AF above BA1, but the FAF95 lower bound only clears BS1
+	evidence := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{
+		AlleleFrequency: 0.06,
+		FAF95:           0.03,
+		FAF99:           0.025,
+		FAFPopulation:   "nfe",
+	}}
+
+	results, err := engine.EvaluateAllRules(context.Background(), variant, evidence)
+	require.NoError(t, err)
+	assert.False(t, findRule(t, results, "BA1").Applied)
+	bs1 := findRule(t, results, "BS1")
+	assert.True(t, bs1.Applied)
+	assert.Contains(t, bs1.Evidence, "faf95")
+	assert.Contains(t, bs1.Evidence, "nfe")
+
+	// Without v4 filtering frequencies the point estimate applies
+	evidence.PopulationData = &domain.PopulationData{AlleleFrequency: 0.06}
+	results, err = engine.EvaluateAllRules(context.Background(), variant, evidence)
+	require.NoError(t, err)
+	assert.True(t, findRule(t, results, "BA1").Applied)
+}
+
+func TestRuleEngine_PM2RequiresRarityInEveryAncestryGroup(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{ID: "var-1"}
+
+	// Rare overall but common in one ancestry group
+	evidence := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{
+		AlleleFrequency: 0.00005,
+		FAF95:           0.0004,
+		FAFPopulation:   "afr",
+	}}
+	results, err := engine.EvaluateAllRules(context.Background(), variant, evidence)
+	require.NoError(t, err)
+	assert.False(t, findRule(t, results, "PM2").Applied)
+
+	evidence.PopulationData.FAF95 = 0.00001
+	results, err = engine.EvaluateAllRules(context.Background(), variant, evidence)
+	require.NoError(t, err)
+	pm2 := findRule(t, results, "PM2")
+	assert.True(t, pm2.Applied)
+	assert.Contains(t, pm2.Evidence, "faf95")
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// DomainSource maps protein residues to functional domains and hotspots
+type DomainSource interface {
+	Lookup(gene string, position int) []domain.ProteinDomain
+}
+
+// SetDomainSource configures protein domain annotation for PM1.
+// Without a source PM1 is only assessed from VCEP hotspot specifications.
+func (e *ACMGAMPRuleEngine) SetDomainSource(source DomainSource) {
+	e.domains = source
+}
+
+// SetDomainSource configures the protein domains used by the rule engine
+func (c *ClassifierService) SetDomainSource(source DomainSource) {
+	c.ruleEngine.SetDomainSource(source)
+}
+
+// evaluateDomains applies PM1 to a missense variant in a hotspot or domain
+// that passes the benign variation density checks. Annotations are tried
+// most specific first and the first that passes is reported.
+func (e *ACMGAMPRuleEngine) evaluateDomains(ctx context.Context, result *domain.ACMGAMPRuleResult, variant *domain.StandardizedVariant) {
+	if ClassifyConsequence(variant.Consequence, variant.HGVSCoding, variant.HGVSProtein) != ConsequenceMissense {
+		result.Reasoning = "PM1 applies to missense variants only"
+		return
+	}
+	position := proteinPosition(variant.HGVSProtein)
+	if position == 0 {
+		result.Reasoning = "Protein position unknown; cannot assess PM1"
+		return
+	}
+	if e.domains == nil {
+		result.Reasoning = "No protein domain annotations available"
+		return
+	}
+
+	annotations := e.domains.Lookup(variant.GeneSymbol, position)
+	if len(annotations) == 0 {
+		result.Reasoning = fmt.Sprintf("Residue %d is not in an annotated domain or hotspot of %s", position, variant.GeneSymbol)
+		return
+	}
+
+	maxDensity, minPathogenic := thresholdsFrom(ctx).Domains.Cutoffs()
+	rejected := make([]string, 0, len(annotations))
+	for i := range annotations {
+		d := annotations[i]
+		switch {
+		case d.BenignDensity > maxDensity:
+			rejected = append(rejected, fmt.Sprintf("%s tolerates variation (%d benign missense over %d residues)", d.Name, d.BenignMissense, d.End-d.Start+1))
+		case d.PathogenicMissense < minPathogenic:
+			rejected = append(rejected, fmt.Sprintf("%s has %d pathogenic missense variants, fewer than %d", d.Name, d.PathogenicMissense, minPathogenic))
+		default:
+			result.Applied = true
+			result.Confidence = 0.7
+			if d.Source == domain.DomainSourceHotspot {
+				result.Confidence = 0.8
+			}
+			result.Evidence = fmt.Sprintf("Residue %d in %s %s (%d-%d): %d pathogenic, %d benign missense", position, domainSourceLabel(d.Source), d.Name, d.Start, d.End, d.PathogenicMissense, d.BenignMissense)
+			result.Reasoning = fmt.Sprintf("Missense variant in critical domain %s with little benign variation", d.Name)
+			if d.Source == domain.DomainSourceHotspot {
+				result.Reasoning = fmt.Sprintf("Missense variant in mutational hotspot %s", d.Name)
+			}
+			result.MatchedDomain = &d
+			return
+		}
+	}
+	result.Reasoning = "Domain not critical for PM1: " + strings.Join(rejected, "; ")
+}
+
+// domainSourceLabel names an annotation source in rule evidence
+func domainSourceLabel(source string) string {
+	switch source {
+	case domain.DomainSourceUniProt:
+		return "UniProt domain"
+	case domain.DomainSourcePfam:
+		return "Pfam family"
+	default:
+		return "hotspot"
+	}
+}
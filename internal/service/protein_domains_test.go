@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+// staticDomains serves fixed annotations for any residue they cover
+type staticDomains []domain.ProteinDomain
+
+func (s staticDomains) Lookup(gene string, position int) []domain.ProteinDomain {
+	var matches []domain.ProteinDomain
+	for _, d := range s {
+		if d.Gene == gene && position >= d.Start && position <= d.End {
+			matches = append(matches, d)
+		}
+	}
+	return matches
+}
+
+func TestRuleEngine_PM1Domains(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	ctx := context.Background()
+	evaluate := func(variant *domain.StandardizedVariant) *domain.ACMGAMPRuleResult {
+		t.Helper()
+		result, err := engine.EvaluateRule(ctx, "PM1", variant, &domain.AggregatedEvidence{})
+		require.NoError(t, err)
+		return result
+	}
+	missense := func(gene, protein string) *domain.StandardizedVariant {
+		return &domain.StandardizedVariant{GeneSymbol: gene, HGVSProtein: protein, Consequence: "missense_variant"}
+	}
+
+	assert.Contains(t, evaluate(missense("TP53", "p.Arg248Gln")).Reasoning, "No protein domain annotations")
+
+	hotspot := domain.ProteinDomain{Gene: "TP53", Source: domain.DomainSourceHotspot, Name: "L3 loop", Start: 237, End: 250, PathogenicMissense: 31}
+	dbd := domain.ProteinDomain{Gene: "TP53", Source: domain.DomainSourceUniProt, Name: "DNA-binding domain", Start: 102, End: 292, BenignMissense: 1, PathogenicMissense: 231, BenignDensity: 1.0 / 191}
+	tad := domain.ProteinDomain{Gene: "TP53", Source: domain.DomainSourceUniProt, Name: "Transactivation domain 1", Start: 1, End: 40, BenignMissense: 6, PathogenicMissense: 3, BenignDensity: 6.0 / 40}
+	sparse := domain.ProteinDomain{Gene: "BRCA1", Source: domain.DomainSourcePfam, Name: "BRCT", Start: 1646, End: 1736, PathogenicMissense: 1}
+	engine.SetDomainSource(staticDomains{hotspot, dbd, tad, sparse})
+
+	result := evaluate(missense("TP53", "p.Arg248Gln"))
+	assert.True(t, result.Applied)
+	assert.Equal(t, 0.8, result.Confidence)
+	require.NotNil(t, result.MatchedDomain)
+	assert.Equal(t, "L3 loop", result.MatchedDomain.Name)
+	assert.Contains(t, result.Reasoning, "hotspot")
+
+	result = evaluate(missense("TP53", "p.Arg175His"))
+	assert.True(t, result.Applied)
+	assert.Equal(t, 0.7, result.Confidence)
+	assert.Equal(t, "DNA-binding domain", result.MatchedDomain.Name)
+
+	result = evaluate(missense("TP53", "p.Pro20Ser"))
+	assert.False(t, result.Applied, "a domain with dense benign variation is not critical")
+	assert.Nil(t, result.MatchedDomain)
+	assert.Contains(t, result.Reasoning, "tolerates variation")
+
+	result = evaluate(missense("BRCA1", "p.Met1689Arg"))
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "fewer than 2")
+
+	assert.False(t, evaluate(missense("TP53", "p.Gln331His")).Applied)
+	assert.Contains(t, evaluate(&domain.StandardizedVariant{GeneSymbol: "TP53", HGVSProtein: "p.Arg248Ter", Consequence: "stop_gained"}).Reasoning, "missense variants only")
+
+	// Threshold revisions can relax the density check
+	custom := thresholds.Defaults()
+	custom.Domains.MaxBenignDensity = 0.2
+	engine.SetThresholdSource(&stubThresholdSource{revision: &thresholds.Revision{ID: 3, Thresholds: custom}})
+	result = evaluate(missense("TP53", "p.Pro20Ser"))
+	assert.True(t, result.Applied)
+	assert.Equal(t, "Transactivation domain 1", result.MatchedDomain.Name)
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// markProvisional records how the evidence sources fared and, when a source
+// failed or missed its deadline, flags the classification as provisional
+// with a recommendation naming the missing evidence
+func markProvisional(result *ClassifyVariantResult, evidence *domain.AggregatedEvidence) {
+	if evidence == nil {
+		return
+	}
+	result.EvidenceSources = evidence.Sources
+	if !evidence.Incomplete() {
+		return
+	}
+	result.Provisional = true
+	result.IncompleteEvidence = evidence.IncompleteCategories
+
+	var missing []string
+	for _, source := range evidence.Sources {
+		switch source.Status {
+		case domain.SourceStatusTimeout:
+			missing = append(missing, source.Source+" timed out")
+		case domain.SourceStatusFailed:
+			missing = append(missing, source.Source+" failed")
+		}
+	}
+	result.Recommendations = append(result.Recommendations, fmt.Sprintf(
+		"Provisional classification: %s evidence incomplete (%s); reclassify once the sources respond",
+		strings.Join(evidence.IncompleteCategories, ", "), strings.Join(missing, ", ")))
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestMarkProvisional(t *testing.T) {
+	complete := &domain.AggregatedEvidence{Sources: []domain.SourceStatus{
+		{Source: "clinvar", Category: domain.EvidenceCategoryClinical, Status: domain.SourceStatusOK},
+	}}
+	result := &ClassifyVariantResult{}
+	markProvisional(result, complete)
+	assert.False(t, result.Provisional)
+	assert.Len(t, result.EvidenceSources, 1)
+	assert.Empty(t, result.Recommendations)
+
+	incomplete := &domain.AggregatedEvidence{
+		Sources: []domain.SourceStatus{
+			{Source: "clinvar", Category: domain.EvidenceCategoryClinical, Status: domain.SourceStatusOK},
+			{Source: "gnomad", Category: domain.EvidenceCategoryPopulation, Status: domain.SourceStatusTimeout},
+			{Source: "cosmic", Category: domain.EvidenceCategorySomatic, Status: domain.SourceStatusFailed},
+		},
+		IncompleteCategories: []string{domain.EvidenceCategoryPopulation, domain.EvidenceCategorySomatic},
+	}
+	result = &ClassifyVariantResult{}
+	markProvisional(result, incomplete)
+	assert.True(t, result.Provisional)
+	assert.Equal(t, []string{"population", "somatic"}, result.IncompleteEvidence)
+	assert.Equal(t, []string{"Provisional classification: population, somatic evidence incomplete (gnomad timed out, cosmic failed); reclassify once the sources respond"}, result.Recommendations)
+
+	result = &ClassifyVariantResult{}
+	markProvisional(result, nil)
+	assert.False(t, result.Provisional)
+}
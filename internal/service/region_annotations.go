@@ -0,0 +1,36 @@
+package service
+
+import (
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/regions"
+)
+
+// RegionSource annotates variants in regions where calls are unreliable
+type RegionSource interface {
+	AnnotateHGVS(hgvs string) []regions.Caveat
+}
+
+// SetRegionSource configures problematic region annotation.
+// Without a source no region caveats are reported.
+func (c *ClassifierService) SetRegionSource(source RegionSource) {
+	c.regions = source
+}
+
+// regionCaveats returns the problematic region caveats for a variant
+func (c *ClassifierService) regionCaveats(variant *domain.StandardizedVariant) []regions.Caveat {
+	if c.regions == nil || variant.HGVSGenomic == "" {
+		return nil
+	}
+	return c.regions.AnnotateHGVS(variant.HGVSGenomic)
+}
+
+// downgradeConfidence lowers confidence one level. The classification itself
+// is unchanged; only how far the call can be trusted is.
+func downgradeConfidence(confidence domain.ConfidenceLevel) domain.ConfidenceLevel {
+	switch confidence {
+	case domain.HIGH:
+		return domain.MEDIUM
+	default:
+		return domain.LOW
+	}
+}
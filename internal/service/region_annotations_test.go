@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/regions"
+)
+
+type stubRegionSource map[string][]regions.Caveat
+
+func (s stubRegionSource) AnnotateHGVS(hgvs string) []regions.Caveat {
+	return s[hgvs]
+}
+
+func TestRegionCaveats(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	variant := &domain.StandardizedVariant{HGVSGenomic: "NC_000001.11:g.5000A>G"}
+
+	assert.Empty(t, service.regionCaveats(variant), "no source configured")
+
+	service.SetRegionSource(stubRegionSource{
+		"NC_000001.11:g.5000A>G": {{Category: regions.CategoryAssemblyGap, Message: "Variant lies in a reference assembly gap"}},
+	})
+	caveats := service.regionCaveats(variant)
+	require.Len(t, caveats, 1)
+	assert.Equal(t, regions.CategoryAssemblyGap, caveats[0].Category)
+
+	assert.Empty(t, service.regionCaveats(&domain.StandardizedVariant{HGVSGenomic: "NC_000001.11:g.900000A>G"}))
+	assert.Empty(t, service.regionCaveats(&domain.StandardizedVariant{}))
+}
+
+func TestDowngradeConfidence(t *testing.T) {
+	assert.Equal(t, domain.MEDIUM, downgradeConfidence(domain.HIGH))
+	assert.Equal(t, domain.LOW, downgradeConfidence(domain.MEDIUM))
+	assert.Equal(t, domain.LOW, downgradeConfidence(domain.LOW))
+}
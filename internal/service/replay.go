@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+// Rules a classification is replayed with
+const (
+	ReplayRulesRecorded = "recorded" // The thresholds the classification was made with
+	ReplayRulesCurrent  = "current"  // The thresholds in effect now
+)
+
+// EvidenceBundle is everything a germline classification was made from: the
+// request, the standardized variant, the evidence gathered from external
+// databases and the thresholds in effect. Stored as a classification
+// snapshot, it lets the rule engine be re-run without the network.
+type EvidenceBundle struct {
+	Params                 *ClassifyVariantParams         `json:"params"`
+	HGVSNotation           string                         `json:"hgvs_notation"`
+	Variant                *domain.StandardizedVariant    `json:"variant"`
+	Evidence               *domain.AggregatedEvidence     `json:"evidence"`
+	ResidueLookup          bool                           `json:"residue_lookup,omitempty"` // Residue variants were looked up, even if none were found
+	Normalized             *domain.NormalizedVariant      `json:"normalized,omitempty"`
+	NormalizationError     string                         `json:"normalization_error,omitempty"`
+	TranscriptConsequences []domain.TranscriptConsequence `json:"transcript_consequences,omitempty"`
+	SpecialtyTranscript    *SpecialtyTranscript           `json:"specialty_transcript,omitempty"`
+	ScoringMode            string                         `json:"scoring_mode"`
+	RulesVersion           string                         `json:"rules_version,omitempty"` // Empty in bundles recorded before rule set versions, which used the default
+	Thresholds             thresholds.Thresholds          `json:"thresholds"`              // Before VCEP and gene/condition overrides, which are applied again on replay
+	ThresholdRevision      int64                          `json:"threshold_revision,omitempty"`
+	EngineVersion          string                         `json:"engine_version"`
+	Specification          string                         `json:"vcep_specification,omitempty"`
+	ConfigCommit           string                         `json:"config_commit,omitempty"`
+	DatasetVersions        provenance.Versions            `json:"dataset_versions,omitempty"`
+
+	// Outcome of the original classification
+	Classification string              `json:"classification"`
+	Confidence     string              `json:"confidence"`
+	PointTotal     int                 `json:"point_total"`
+	AppliedRules   []ACMGAMPRuleResult `json:"applied_rules"`
+}
+
+// ReplayOptions selects the rules a classification is replayed with
+type ReplayOptions struct {
+	Rules        string    // ReplayRulesRecorded (default) or ReplayRulesCurrent
+	RulesAsOf    time.Time // Thresholds in effect at this time; overrides Rules when set
+	RulesVersion string    // Rule set version; defaults to the recorded version
+	ScoringMode  string    // Defaults to the selected version's mode, or the recorded mode
+}
+
+// ReplayClassification re-runs the rule engine against the evidence bundle of
+// a recorded classification, without querying any external database. With the
+// recorded rules the original call is reproduced; with other rules, the
+// effect of a threshold change or another rule set version on the call can be
+// checked. VCEP specifications, frequency overrides and region tracks are
+// those loaded now.
+func (c *ClassifierService) ReplayClassification(ctx context.Context, bundle *EvidenceBundle, opts ReplayOptions) (*ClassifyVariantResult, error) {
+	startTime := time.Now()
+
+	if bundle == nil || bundle.Variant == nil {
+		return nil, fmt.Errorf("invalid evidence bundle: the variant is missing")
+	}
+	if bundle.Variant.Structural != nil {
+		return nil, fmt.Errorf("invalid evidence bundle: structural variants are not evaluated with the criteria")
+	}
+	params := bundle.Params
+	if params == nil {
+		params = &ClassifyVariantParams{HGVSNotation: bundle.HGVSNotation}
+	}
+
+	rulesVersion, scoringModeName := opts.RulesVersion, opts.ScoringMode
+	if rulesVersion == "" {
+		rulesVersion = bundle.RulesVersion
+		if scoringModeName == "" {
+			scoringModeName = bundle.ScoringMode
+		}
+	}
+	ruleVersion, scoringMode, err := resolveRuleVersion(rulesVersion, scoringModeName, c.scoringMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid replay options: %w", err)
+	}
+	ctx = withRuleVersion(ctx, ruleVersion)
+	ctx = withScoringMode(ctx, scoringMode)
+	ctx = withCondition(ctx, params.Condition)
+	ctx = withPatientContext(ctx, params.PatientContext)
+
+	// Attach the thresholds to replay with; without any, the rule engine
+	// resolves the ones in effect now
+	switch {
+	case !opts.RulesAsOf.IsZero():
+		values, revision, err := c.ThresholdsAt(ctx, opts.RulesAsOf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load thresholds in effect at %s: %w", opts.RulesAsOf.Format(time.RFC3339), err)
+		}
+		ctx = context.WithValue(ctx, thresholdsKey{}, activeThresholds{values: values, revision: revision})
+	case opts.Rules == "" || opts.Rules == ReplayRulesRecorded:
+		ctx = context.WithValue(ctx, thresholdsKey{}, activeThresholds{values: bundle.Thresholds, revision: bundle.ThresholdRevision})
+	case opts.Rules != ReplayRulesCurrent:
+		return nil, fmt.Errorf("invalid replay options: unknown rules %q (use %s or %s)", opts.Rules, ReplayRulesRecorded, ReplayRulesCurrent)
+	}
+
+	// Evaluate copies, so the bundle is left as recorded
+	variant := *bundle.Variant
+	evidence := &domain.AggregatedEvidence{}
+	if bundle.Evidence != nil {
+		*evidence = *bundle.Evidence
+	}
+	if bundle.ResidueLookup && evidence.ResidueVariants == nil {
+		evidence.ResidueVariants = []domain.ResidueVariant{}
+	}
+	var normalizeErr error
+	if bundle.NormalizationError != "" {
+		normalizeErr = errors.New(bundle.NormalizationError)
+	}
+
+	result, _, err := c.evaluateGermline(ctx, germlineEvaluation{
+		params:                 params,
+		variant:                &variant,
+		hgvsNotation:           bundle.HGVSNotation,
+		evidence:               evidence,
+		normalized:             bundle.Normalized,
+		normalizeErr:           normalizeErr,
+		transcriptConsequences: bundle.TranscriptConsequences,
+		specialtyTranscript:    bundle.SpecialtyTranscript,
+		scoringMode:            scoringMode,
+		datasetVersions:        bundle.DatasetVersions,
+		startTime:              startTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"variant_id":              result.VariantID,
+		"classification":          result.Classification,
+		"recorded_classification": bundle.Classification,
+		"threshold_revision":      result.ThresholdRevision,
+	}).Info("Variant classification replayed")
+
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+func testEvidenceBundle(t *testing.T, classifier *ClassifierService) *EvidenceBundle {
+	t.Helper()
+	variant := &domain.StandardizedVariant{ID: "var-1", GeneSymbol: "BRCA1", HGVSGenomic: "NC_000017.11:g.43045712G>A"}
+	evidence := &domain.AggregatedEvidence{
+		PopulationData:  &domain.PopulationData{AlleleFrequency: 0.02},
+		ResidueVariants: []domain.ResidueVariant{},
+	}
+
+	result, _, err := classifier.evaluateGermline(context.Background(), germlineEvaluation{
+		params:       &ClassifyVariantParams{HGVSNotation: variant.HGVSGenomic},
+		variant:      variant,
+		hgvsNotation: variant.HGVSGenomic,
+		evidence:     evidence,
+		scoringMode:  ScoringModeCombiningRules,
+		startTime:    time.Now(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Bundle)
+
+	// Replays read the bundle back from its stored JSON
+	payload, err := json.Marshal(result.Bundle)
+	require.NoError(t, err)
+	var bundle EvidenceBundle
+	require.NoError(t, json.Unmarshal(payload, &bundle))
+	return &bundle
+}
+
+func TestReplayClassification_RecordedRules(t *testing.T) {
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+	bundle := testEvidenceBundle(t, classifier)
+	assert.True(t, bundle.ResidueLookup)
+	assert.Equal(t, thresholds.Defaults(), bundle.Thresholds)
+
+	// A revision taking effect later does not change a replay with the recorded rules
+	custom := thresholds.Defaults()
+	custom.BA1AlleleFrequency = 0.01
+	custom.BS1AlleleFrequency = 0.005
+	classifier.SetThresholdSource(&stubThresholdSource{revision: &thresholds.Revision{ID: 7, Thresholds: custom}})
+
+	replayed, err := classifier.ReplayClassification(context.Background(), bundle, ReplayOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, bundle.Classification, replayed.Classification)
+	assert.ElementsMatch(t, bundle.AppliedRules, replayed.AppliedRules)
+	assert.Zero(t, replayed.ThresholdRevision)
+
+	// With the current rules, 2% becomes stand-alone benign
+	replayed, err = classifier.ReplayClassification(context.Background(), bundle, ReplayOptions{Rules: ReplayRulesCurrent})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), replayed.ThresholdRevision)
+	assert.Equal(t, string(domain.BENIGN), replayed.Classification)
+
+	replayed, err = classifier.ReplayClassification(context.Background(), bundle, ReplayOptions{RulesAsOf: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), replayed.ThresholdRevision)
+
+	// The bundle is left as recorded
+	assert.Equal(t, int64(0), bundle.ThresholdRevision)
+}
+
+func TestReplayClassification_Invalid(t *testing.T) {
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+	bundle := testEvidenceBundle(t, classifier)
+
+	_, err := classifier.ReplayClassification(context.Background(), bundle, ReplayOptions{Rules: "latest"})
+	assert.ErrorContains(t, err, "unknown rules")
+
+	_, err = classifier.ReplayClassification(context.Background(), bundle, ReplayOptions{ScoringMode: "bayes"})
+	assert.ErrorContains(t, err, "invalid replay options")
+
+	_, err = classifier.ReplayClassification(context.Background(), &EvidenceBundle{}, ReplayOptions{})
+	assert.ErrorContains(t, err, "variant is missing")
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// evaluateResidueRule applies PS1 (sameChange) or PM5 from the curated
+// pathogenic variants found at the variant's residue, attaching the matches
+// the decision relied on. PM5 is not applied alongside PS1, so one residue
+// is not counted twice.
+func evaluateResidueRule(result *domain.ACMGAMPRuleResult, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence, sameChange bool) {
+	change, ok := hgvs.ParseMissense(variant.HGVSProtein)
+	if !ok {
+		result.Reasoning = fmt.Sprintf("%s applies to missense variants only", result.Code)
+		return
+	}
+	if evidence == nil || evidence.ResidueVariants == nil {
+		result.Reasoning = fmt.Sprintf("No lookup of ClinVar variants at residue %s%d was available", change.Ref, change.Position)
+		return
+	}
+
+	same, different := splitResidueVariants(evidence.ResidueVariants)
+	if sameChange {
+		if len(same) == 0 {
+			result.Reasoning = fmt.Sprintf("No other nucleotide change producing %s is classified pathogenic in ClinVar", change)
+			return
+		}
+		result.Applied = true
+		result.Confidence = residueConfidence(same, 0.8)
+		result.Evidence = describeResidueVariants(same)
+		result.Reasoning = fmt.Sprintf("Same amino acid change (%s) as %d pathogenic %s variant(s) with a different nucleotide change", change, len(same), residueSources(same))
+		result.MatchedVariants = same
+		return
+	}
+
+	switch {
+	case len(same) > 0:
+		result.Reasoning = fmt.Sprintf("PS1 applies to %s; PM5 is not also applied for the same residue", change)
+		result.MatchedVariants = same
+	case len(different) == 0:
+		result.Reasoning = fmt.Sprintf("No different missense change at residue %s%d is classified pathogenic in ClinVar", change.Ref, change.Position)
+	default:
+		result.Applied = true
+		result.Confidence = residueConfidence(different, 0.7)
+		result.Evidence = describeResidueVariants(different)
+		result.Reasoning = fmt.Sprintf("Novel missense change at residue %s%d where %d different pathogenic missense change(s) are in ClinVar", change.Ref, change.Position, len(different))
+		result.MatchedVariants = different
+	}
+}
+
+// splitResidueVariants separates same amino acid changes from different
+// changes at the residue
+func splitResidueVariants(variants []domain.ResidueVariant) (same, different []domain.ResidueVariant) {
+	for _, v := range variants {
+		if v.SameChange {
+			same = append(same, v)
+		} else {
+			different = append(different, v)
+		}
+	}
+	return same, different
+}
+
+// residueConfidence raises the base confidence when an expert panel or
+// practice guideline has reviewed a match
+func residueConfidence(matches []domain.ResidueVariant, base float64) float64 {
+	for _, m := range matches {
+		if m.Stars >= 3 {
+			return base + 0.1
+		}
+	}
+	return base
+}
+
+// describeResidueVariants summarizes residue matches for the rule evidence,
+// ClinVar matches first and then the lab's own assertions
+func describeResidueVariants(matches []domain.ResidueVariant) string {
+	var clinvar, lab []string
+	for _, m := range matches {
+		if m.Source == domain.ResidueSourceLab {
+			lab = append(lab, fmt.Sprintf("%s (%s, assertion %s): %s", m.ProteinChange, m.Name, m.VariationID, m.ClinicalSignificance))
+			continue
+		}
+		clinvar = append(clinvar, fmt.Sprintf("%s (ClinVar %s): %s, %d star(s)", m.ProteinChange, m.VariationID, m.ClinicalSignificance, m.Stars))
+	}
+
+	var parts []string
+	if len(clinvar) > 0 {
+		parts = append(parts, "ClinVar: "+strings.Join(clinvar, "; "))
+	}
+	if len(lab) > 0 {
+		parts = append(parts, "Lab knowledge base: "+strings.Join(lab, "; "))
+	}
+	return strings.Join(parts, ". ")
+}
+
+// residueSources names where residue matches came from
+func residueSources(matches []domain.ResidueVariant) string {
+	var clinvar, lab bool
+	for _, m := range matches {
+		if m.Source == domain.ResidueSourceLab {
+			lab = true
+		} else {
+			clinvar = true
+		}
+	}
+	switch {
+	case clinvar && lab:
+		return "ClinVar or lab"
+	case lab:
+		return "lab"
+	}
+	return "ClinVar"
+}
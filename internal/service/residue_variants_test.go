@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestRuleEngine_ResidueVariants(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	ctx := context.Background()
+	missense := &domain.StandardizedVariant{GeneSymbol: "BRCA1", HGVSCoding: "NM_007294.4:c.5096G>A", HGVSProtein: "p.Arg1699Gln"}
+
+	sameChange := domain.ResidueVariant{VariationID: "55448", ProteinChange: "R1699Q", ClinicalSignificance: "Pathogenic", Stars: 3, SameChange: true}
+	otherChange := domain.ResidueVariant{VariationID: "55447", ProteinChange: "R1699W", ClinicalSignificance: "Pathogenic", Stars: 2}
+
+	evaluate := func(code string, variant *domain.StandardizedVariant, residues []domain.ResidueVariant) *domain.ACMGAMPRuleResult {
+		t.Helper()
+		result, err := engine.EvaluateRule(ctx, code, variant, &domain.AggregatedEvidence{ResidueVariants: residues})
+		require.NoError(t, err)
+		return result
+	}
+
+	ps1 := evaluate("PS1", missense, []domain.ResidueVariant{sameChange, otherChange})
+	assert.True(t, ps1.Applied)
+	assert.Equal(t, 0.9, ps1.Confidence, "an expert panel classification raises confidence")
+	assert.Equal(t, []domain.ResidueVariant{sameChange}, ps1.MatchedVariants)
+	assert.Contains(t, ps1.Evidence, "R1699Q (ClinVar 55448)")
+
+	pm5 := evaluate("PM5", missense, []domain.ResidueVariant{sameChange, otherChange})
+	assert.False(t, pm5.Applied, "PM5 is not applied alongside PS1")
+
+	pm5 = evaluate("PM5", missense, []domain.ResidueVariant{otherChange})
+	assert.True(t, pm5.Applied)
+	assert.Equal(t, 0.7, pm5.Confidence)
+	assert.Equal(t, []domain.ResidueVariant{otherChange}, pm5.MatchedVariants)
+	assert.False(t, evaluate("PS1", missense, []domain.ResidueVariant{otherChange}).Applied)
+
+	none := evaluate("PS1", missense, []domain.ResidueVariant{})
+	assert.False(t, none.Applied)
+	assert.Contains(t, none.Reasoning, "No other nucleotide change")
+
+	notLooked := evaluate("PM5", missense, nil)
+	assert.False(t, notLooked.Applied)
+	assert.Contains(t, notLooked.Reasoning, "No lookup")
+
+	nonsense := &domain.StandardizedVariant{GeneSymbol: "BRCA1", HGVSProtein: "p.Arg1699Ter"}
+	result := evaluate("PS1", nonsense, []domain.ResidueVariant{sameChange})
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "missense variants only")
+}
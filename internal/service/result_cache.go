@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/metrics"
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+)
+
+// resultCacheName labels the result cache in the cache metrics
+const resultCacheName = "result"
+
+// resultEntry is a memoized classification and when it expires
+type resultEntry struct {
+	result  *ClassifyVariantResult
+	expires time.Time
+}
+
+// ResultCacheStats reports the size and counters of the result cache
+type ResultCacheStats struct {
+	Entries    int   `json:"entries"`
+	MaxEntries int   `json:"max_entries"`
+	Hits       int64 `json:"hits"`
+	Misses     int64 `json:"misses"`
+	Purges     int64 `json:"purges"` // Times every entry was dropped, e.g. by an evidence refresh
+}
+
+// ResultCache memoizes classification results, so repeated requests for a
+// hot variant skip evidence gathering and rule evaluation. Results are keyed
+// on the normalized variant, the rule set version and the dataset versions
+// the evidence was drawn from and the lab knowledge base revision, together
+// with the request options and configuration that change the outcome. Entries expire after the TTL and
+// are dropped when evidence is refreshed.
+type ResultCache struct {
+	mu       sync.Mutex
+	entries  *simplelru.LRU[string, resultEntry]
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+	dropping bool // Removals are deletions, not evictions
+
+	hits, misses, purges int64
+}
+
+// NewResultCache creates a cache of at most maxEntries results, each kept
+// for ttl; a ttl of zero keeps results until evicted or purged
+func NewResultCache(maxEntries int, ttl time.Duration) (*ResultCache, error) {
+	c := &ResultCache{capacity: maxEntries, ttl: ttl, now: time.Now}
+	entries, err := simplelru.NewLRU[string, resultEntry](maxEntries, c.onRemove)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create result cache: %w", err)
+	}
+	c.entries = entries
+	return c, nil
+}
+
+// onRemove counts evictions. It is called with mu held.
+func (c *ResultCache) onRemove(string, resultEntry) {
+	if !c.dropping {
+		metrics.ObserveCacheEviction(resultCacheName)
+	}
+}
+
+// get returns the memoized result for key, if it has not expired
+func (c *ResultCache) get(key string) (*ClassifyVariantResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries.Get(key)
+	if ok && !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.dropping = true
+		c.entries.Remove(key)
+		c.dropping = false
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		metrics.ObserveCache(resultCacheName, metrics.CacheMiss)
+		return nil, false
+	}
+	c.hits++
+	metrics.ObserveCache(resultCacheName, metrics.CacheHit)
+	return entry.result, true
+}
+
+// put memoizes a result under key
+func (c *ResultCache) put(key string, result *ClassifyVariantResult) {
+	entry := resultEntry{result: result}
+	if c.ttl > 0 {
+		entry.expires = c.now().Add(c.ttl)
+	}
+	c.mu.Lock()
+	c.entries.Add(key, entry)
+	c.mu.Unlock()
+}
+
+// Purge drops every memoized result. It is called when evidence is
+// refreshed, since results made from the old evidence no longer hold.
+func (c *ResultCache) Purge() {
+	c.mu.Lock()
+	c.dropping = true
+	c.entries.Purge()
+	c.dropping = false
+	c.purges++
+	c.mu.Unlock()
+}
+
+// Stats returns the size and counters of the cache
+func (c *ResultCache) Stats() ResultCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ResultCacheStats{
+		Entries:    c.entries.Len(),
+		MaxEntries: c.capacity,
+		Hits:       c.hits,
+		Misses:     c.misses,
+		Purges:     c.purges,
+	}
+}
+
+// SetResultCache memoizes classification results in cache. Without a cache
+// every request is classified afresh.
+func (c *ClassifierService) SetResultCache(cache *ResultCache) {
+	c.resultCache = cache
+}
+
+// resultKey is everything a memoized classification depends on besides the
+// evidence itself, which the dataset versions stand for
+type resultKey struct {
+	Variant                string                         `json:"variant"` // Normalized genomic notation, or the HGVS notation without a normalizer
+	RulesVersion           string                         `json:"rules_version"`
+	DatasetVersions        provenance.Versions            `json:"dataset_versions,omitempty"`
+	EngineVersion          string                         `json:"engine_version"`
+	ScoringMode            ScoringMode                    `json:"scoring_mode"`
+	ThresholdRevision      int64                          `json:"threshold_revision,omitempty"`
+	LabKnowledgeRevision   int64                          `json:"lab_knowledge_revision,omitempty"`
+	ConfigCommit           string                         `json:"config_commit,omitempty"`
+	ClassificationContext  string                         `json:"classification_context"`
+	TranscriptID           string                         `json:"transcript_id,omitempty"`
+	PreferredIsoform       string                         `json:"preferred_isoform,omitempty"`
+	OrderingSpecialty      string                         `json:"ordering_specialty,omitempty"`
+	Condition              string                         `json:"condition,omitempty"`
+	TumorType              string                         `json:"tumor_type,omitempty"`
+	TranscriptConsequences []domain.TranscriptConsequence `json:"transcript_consequences,omitempty"`
+}
+
+// String digests the key; map keys are marshalled in sorted order, so equal
+// keys give equal digests
+func (k resultKey) String() string {
+	data, err := json.Marshal(k)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// memoKey completes key with the normalized variant and the lab knowledge
+// base revision and digests it
+func (c *ClassifierService) memoKey(ctx context.Context, key resultKey, normalized *domain.NormalizedVariant) (string, error) {
+	if normalized != nil && normalized.HGVSGenomic != "" {
+		key.Variant = normalized.HGVSGenomic
+	}
+	revision, err := c.ruleEngine.labKnowledgeRevision(ctx)
+	if err != nil {
+		return "", err
+	}
+	key.LabKnowledgeRevision = revision
+	return key.String(), nil
+}
+
+// memoizable reports whether a request's result may be memoized. Results
+// made with a patient's de novo evidence belong to that case alone.
+func memoizable(params *ClassifyVariantParams) bool {
+	return params.PatientContext == nil
+}
+
+// memoize stores a result under key unless it is provisional; results made
+// without a source are classified again once the sources respond
+func (c *ClassifierService) memoize(key string, result *ClassifyVariantResult) {
+	if key == "" || result.Provisional {
+		return
+	}
+	c.resultCache.put(key, result)
+}
+
+// servedResult is a copy of a memoized result for a new request, which may
+// have spelled the variant differently
+func servedResult(memoized *ClassifyVariantResult, params *ClassifyVariantParams, hgvsNotation string, startTime time.Time) *ClassifyVariantResult {
+	result := *memoized
+	result.Recommendations = append([]string(nil), memoized.Recommendations...)
+	result.InputNotation = hgvsNotation
+	result.ProcessingTime = time.Since(startTime)
+	result.Cached = true
+	if memoized.Bundle != nil {
+		bundle := *memoized.Bundle
+		bundle.Params = params
+		bundle.HGVSNotation = hgvsNotation
+		result.Bundle = &bundle
+	}
+	return &result
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+	"github.com/acmg-amp-mcp-server/internal/ruleversions"
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+func TestClassifyVariant_ResultCache(t *testing.T) {
+	// No knowledge base: a memoized result is served before evidence is gathered
+	classifier := NewClassifierService(logrus.New(), nil, domain.NewStandardInputParser(), nil)
+	genomic := &domain.NormalizedVariant{HGVSGenomic: "NC_000017.11:g.7674220C>T", Chromosome: "17", Start: 7674220}
+	classifier.SetNormalizer(&stubNormalizer{results: map[string]*domain.NormalizedVariant{
+		"NM_000546.6:c.743G>A":      genomic,
+		"NC_000017.11:g.7674220C>T": genomic,
+	}})
+	cache, err := NewResultCache(10, time.Hour)
+	require.NoError(t, err)
+	classifier.SetResultCache(cache)
+
+	key := resultKey{
+		Variant:               genomic.HGVSGenomic,
+		RulesVersion:          ruleversions.Default,
+		EngineVersion:         EngineVersion,
+		ScoringMode:           ScoringModeCombiningRules,
+		ClassificationContext: ContextGermline,
+	}.String()
+	memoized := &ClassifyVariantResult{
+		Classification:  domain.PATHOGENIC.String(),
+		InputNotation:   "NC_000017.11:g.7674220C>T",
+		Recommendations: []string{"Report"},
+		Bundle:          &EvidenceBundle{HGVSNotation: "NC_000017.11:g.7674220C>T"},
+	}
+	classifier.memoize(key, memoized)
+
+	// Act: the same variant spelled on the transcript
+	params := &ClassifyVariantParams{HGVSNotation: "NM_000546.6:c.743G>A"}
+	result, err := classifier.ClassifyVariant(context.Background(), params)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.Cached)
+	assert.Equal(t, domain.PATHOGENIC.String(), result.Classification)
+	assert.Equal(t, "NM_000546.6:c.743G>A", result.InputNotation)
+	assert.Equal(t, "NM_000546.6:c.743G>A", result.Bundle.HGVSNotation)
+	assert.Same(t, params, result.Bundle.Params)
+	assert.False(t, memoized.Cached, "the memoized result is not changed")
+	assert.Equal(t, "NC_000017.11:g.7674220C>T", memoized.Bundle.HGVSNotation)
+	assert.Equal(t, ResultCacheStats{Entries: 1, MaxEntries: 10, Hits: 1}, cache.Stats())
+
+	cache.Purge()
+	_, ok := cache.get(key)
+	assert.False(t, ok)
+	assert.Equal(t, int64(1), cache.Stats().Purges)
+}
+
+// unreachableKnowledgeBase returns a knowledge base whose sources all refuse
+// connections, so classifications are made without external evidence
+func unreachableKnowledgeBase(t *testing.T) *external.KnowledgeBaseService {
+	t.Helper()
+	const url = "http://127.0.0.1:1/"
+	kb, err := external.NewKnowledgeBaseService(
+		domain.ClinVarConfig{BaseURL: url, Timeout: time.Second, RateLimit: 1000},
+		domain.GnomADConfig{BaseURL: url, Timeout: time.Second, RateLimit: 1000},
+		domain.COSMICConfig{BaseURL: url, Timeout: time.Second, RateLimit: 1000},
+		domain.PubMedConfig{BaseURL: url, Timeout: time.Second, RateLimit: 1000},
+		domain.LOVDConfig{BaseURL: url, Timeout: time.Second, RateLimit: 1000},
+		domain.HGMDConfig{BaseURL: url, Timeout: time.Second, RateLimit: 1000},
+		domain.CacheConfig{},
+	)
+	require.NoError(t, err)
+	return kb
+}
+
+func TestClassifyVariant_ResultCacheFollowsLabKnowledgeBase(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	classifier := NewClassifierService(logger, unreachableKnowledgeBase(t), domain.NewStandardInputParser(), nil)
+	labKnowledge := &stubLabKnowledge{}
+	classifier.SetLabKnowledgeSource(labKnowledge)
+	cache, err := NewResultCache(10, time.Hour)
+	require.NoError(t, err)
+	classifier.SetResultCache(cache)
+
+	params := &ClassifyVariantParams{HGVSNotation: "NM_000257.4:c.1208G>A"}
+	key, err := classifier.memoKey(ctx, resultKey{
+		Variant:               params.HGVSNotation,
+		RulesVersion:          ruleversions.Default,
+		EngineVersion:         EngineVersion,
+		ScoringMode:           ScoringModeCombiningRules,
+		ClassificationContext: ContextGermline,
+	}, nil)
+	require.NoError(t, err)
+	classifier.memoize(key, &ClassifyVariantResult{Classification: domain.VUS.String()})
+	before, err := classifier.ClassifyVariant(ctx, params)
+	require.NoError(t, err)
+	require.True(t, before.Cached)
+
+	// Act: the lab records affected probands with the variant, then it is classified again
+	labKnowledge.save(&labkb.Assertion{
+		NormalizedHGVS:   "NM_000257.4:c.1208G>A",
+		GeneSymbol:       "MYH7",
+		Classification:   "LIKELY_PATHOGENIC",
+		AffectedProbands: 6,
+	})
+	after, err := classifier.ClassifyVariant(ctx, params)
+
+	// Assert: the result made before the update is not served
+	require.NoError(t, err)
+	assert.False(t, after.Cached)
+	assert.Equal(t, int64(1), cache.Stats().Hits)
+}
+
+func TestResultCache(t *testing.T) {
+	cache, err := NewResultCache(2, time.Hour)
+	require.NoError(t, err)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+	classifier.SetResultCache(cache)
+
+	// Provisional results are not memoized
+	classifier.memoize("provisional", &ClassifyVariantResult{Provisional: true})
+	_, ok := cache.get("provisional")
+	assert.False(t, ok)
+
+	classifier.memoize("complete", &ClassifyVariantResult{})
+	_, ok = cache.get("complete")
+	assert.True(t, ok)
+	now = now.Add(time.Hour)
+	_, ok = cache.get("complete")
+	assert.False(t, ok, "expired")
+
+	// Anything that changes the outcome changes the key
+	base := resultKey{Variant: "NC_000007.14:g.117559593_117559595del", RulesVersion: ruleversions.Default, ClassificationContext: ContextGermline}
+	released := base
+	released.DatasetVersions = provenance.Versions{provenance.ClinVar: "2024-06-01"}
+	revised := base
+	revised.RulesVersion = ruleversions.ClinGenSVI2023
+	assert.Equal(t, base.String(), base.String())
+	assert.NotEqual(t, base.String(), released.String())
+	assert.NotEqual(t, base.String(), revised.String())
+
+	assert.True(t, memoizable(&ClassifyVariantParams{}))
+	assert.False(t, memoizable(&ClassifyVariantParams{PatientContext: &PatientContext{}}), "case-level evidence")
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/acmg-amp-mcp-server/internal/calibration"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// SetCalibration configures calibrated predictor intervals. With a
+// calibration PP3 and BP4 are met at the strength of the interval a single
+// calibrated predictor's score falls in, instead of from a count of
+// concordant predictors; variants no calibrated predictor scored keep the
+// generic evaluation.
+func (e *ACMGAMPRuleEngine) SetCalibration(cal *calibration.Calibration) {
+	e.calibration = cal
+}
+
+// SetCalibration configures the predictor calibration used by the rule engine
+func (c *ClassifierService) SetCalibration(cal *calibration.Calibration) {
+	c.ruleEngine.SetCalibration(cal)
+}
+
+// applyCalibration re-evaluates PP3 or BP4 from the calibrated call for the
+// variant. A splice-altering call decides both criteria, except that PVS1
+// already accounts for the splice site of null variants; synonymous and
+// intronic variants are judged on splicing predictions alone and missense
+// variants on the missense tables.
+func applyCalibration(cal *calibration.Calibration, variant *domain.StandardizedVariant, result *domain.ACMGAMPRuleResult, evidence *domain.AggregatedEvidence) {
+	if result.Code != "PP3" && result.Code != "BP4" {
+		return
+	}
+
+	class := ClassifyConsequence(variant.Consequence, variant.HGVSCoding, variant.HGVSProtein)
+	splicing := cal.Splicing(evidence.SplicingPredictions)
+	var call *calibration.Call
+	switch {
+	case splicing != nil && splicing.Criterion == "PP3":
+		if result.Code == "PP3" && class == ConsequenceLossOfFunction {
+			return
+		}
+		call = splicing
+	case isSplicingOnlyVariant(variant, class):
+		call = splicing
+	case class == ConsequenceMissense:
+		call = cal.Missense(evidence.ComputationalData)
+	}
+	if call == nil {
+		return
+	}
+
+	result.Evidence = ""
+	if call.Criterion != result.Code {
+		result.Applied = false
+		result.Confidence = 0.0
+		if call.Criterion == "" {
+			result.Reasoning = fmt.Sprintf("%s score %g is in no calibrated interval (%s calibration)", call.Predictor, call.Score, cal.Name)
+		} else {
+			result.Reasoning = fmt.Sprintf("%s score %g is in the calibrated %s interval %s (%s calibration)", call.Predictor, call.Score, call.Criterion, call.Interval, cal.Name)
+		}
+		return
+	}
+
+	result.Applied = true
+	result.Confidence = 0.6
+	result.Strength = call.Strength
+	result.Evidence = call.String()
+	result.Reasoning = fmt.Sprintf("%s score %g is in the calibrated interval %s, supporting %s at %s strength (%s calibration)", call.Predictor, call.Score, call.Interval, call.Criterion, call.Strength, cal.Name)
+	if call.Source != "" {
+		result.Reasoning += "; " + call.Source
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/calibration"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestCalibration_PP3AndBP4(t *testing.T) {
+	cal, err := calibration.Load("../../examples/predictor_calibration.yaml")
+	require.NoError(t, err)
+	require.NotNil(t, cal)
+
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	engine.SetCalibration(cal)
+	ctx := context.Background()
+	missense := &domain.StandardizedVariant{ID: "var-1", GeneSymbol: "TP53", Consequence: "missense_variant", HGVSProtein: "p.Arg175His"}
+	revel := func(score float64) *domain.AggregatedEvidence {
+		// A single calibrated predictor decides, whatever the other scores
+		return &domain.AggregatedEvidence{ComputationalData: &domain.ComputationalData{
+			REVELScore: score,
+			CADDScore:  10,
+			ScoredBy:   []string{domain.PredictorREVEL, domain.PredictorCADD},
+		}}
+	}
+
+	pp3, err := engine.EvaluateRule(ctx, "PP3", missense, revel(0.95))
+	require.NoError(t, err)
+	assert.True(t, pp3.Applied)
+	assert.Equal(t, domain.STRONG, pp3.Strength)
+	assert.Contains(t, pp3.Reasoning, "clingen-2023 calibration")
+	assert.Contains(t, pp3.Evidence, "REVEL >= 0.932")
+
+	bp4, err := engine.EvaluateRule(ctx, "BP4", missense, revel(0.95))
+	require.NoError(t, err)
+	assert.False(t, bp4.Applied)
+	assert.Contains(t, bp4.Reasoning, "calibrated PP3 interval")
+
+	bp4, err = engine.EvaluateRule(ctx, "BP4", missense, revel(0.1))
+	require.NoError(t, err)
+	assert.True(t, bp4.Applied)
+	assert.Equal(t, domain.MODERATE, bp4.Strength)
+
+	pp3, err = engine.EvaluateRule(ctx, "PP3", missense, revel(0.5))
+	require.NoError(t, err)
+	assert.False(t, pp3.Applied)
+	assert.Contains(t, pp3.Reasoning, "no calibrated interval")
+
+	// A splice-altering SpliceAI call meets PP3 and rules out BP4
+	spliceAltering := revel(0.01)
+	spliceAltering.SplicingPredictions = []domain.SplicingPrediction{{Tool: "SpliceAI", Score: 0.5, Event: domain.SpliceEventDonorLoss}}
+	pp3, err = engine.EvaluateRule(ctx, "PP3", missense, spliceAltering)
+	require.NoError(t, err)
+	assert.True(t, pp3.Applied)
+	assert.Equal(t, domain.SUPPORTING, pp3.Strength)
+	bp4, err = engine.EvaluateRule(ctx, "BP4", missense, spliceAltering)
+	require.NoError(t, err)
+	assert.False(t, bp4.Applied)
+
+	// Synonymous variants are judged on splicing predictions alone
+	synonymous := &domain.StandardizedVariant{ID: "var-2", GeneSymbol: "TP53", Consequence: "synonymous_variant"}
+	noImpact := &domain.AggregatedEvidence{SplicingPredictions: []domain.SplicingPrediction{{Tool: "SpliceAI", Score: 0.02}}}
+	bp4, err = engine.EvaluateRule(ctx, "BP4", synonymous, noImpact)
+	require.NoError(t, err)
+	assert.True(t, bp4.Applied)
+	assert.Contains(t, bp4.Reasoning, "SpliceAI")
+
+	// Without a calibrated predictor the generic evaluation stands
+	uncalibrated := &domain.AggregatedEvidence{ComputationalData: &domain.ComputationalData{
+		SIFTScore: 0.01, PolyPhenScore: 0.99, ScoredBy: []string{domain.PredictorSIFT, domain.PredictorPolyPhen},
+	}}
+	withCalibration, err := engine.EvaluateRule(ctx, "PP3", missense, uncalibrated)
+	require.NoError(t, err)
+	engine.SetCalibration(nil)
+	generic, err := engine.EvaluateRule(ctx, "PP3", missense, uncalibrated)
+	require.NoError(t, err)
+	assert.Equal(t, generic, withCalibration)
+}
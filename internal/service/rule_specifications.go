@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/vcep"
+)
+
+// SpecificationSource supplies the VCEP rule specification for a gene
+type SpecificationSource interface {
+	Lookup(gene string) *vcep.Specification
+}
+
+// proteinPositionPattern captures the first residue position of a protein change
+var proteinPositionPattern = regexp.MustCompile(`^p\.\(?(?:[A-Z][a-z]{2}|[A-Z*])(\d+)`)
+
+// SetSpecificationSource configures gene-specific VCEP rule specifications.
+// Without a source every gene uses the generic rules.
+func (e *ACMGAMPRuleEngine) SetSpecificationSource(source SpecificationSource) {
+	e.specifications = source
+}
+
+// SetSpecificationSource configures the VCEP specifications used by the rule engine
+func (c *ClassifierService) SetSpecificationSource(source SpecificationSource) {
+	c.ruleEngine.SetSpecificationSource(source)
+}
+
+// specificationFor returns the VCEP specification for the variant's gene, or nil
+func (e *ACMGAMPRuleEngine) specificationFor(variant *domain.StandardizedVariant) *vcep.Specification {
+	if e.specifications == nil || variant.GeneSymbol == "" {
+		return nil
+	}
+	return e.specifications.Lookup(variant.GeneSymbol)
+}
+
+// withSpecificationThresholds replaces the frequency cutoffs in the context
+// with any gene-specific cutoffs from the specification
+func withSpecificationThresholds(ctx context.Context, spec *vcep.Specification) context.Context {
+	active, ok := ctx.Value(thresholdsKey{}).(activeThresholds)
+	if !ok {
+		return ctx
+	}
+
+	overridden := false
+	if af := spec.Rule("BA1"); af != nil && af.AlleleFrequency != nil {
+		active.values.BA1AlleleFrequency = *af.AlleleFrequency
+		overridden = true
+	}
+	if af := spec.Rule("BS1"); af != nil && af.AlleleFrequency != nil {
+		active.values.BS1AlleleFrequency = *af.AlleleFrequency
+		overridden = true
+	}
+	if af := spec.Rule("PM2"); af != nil && af.AlleleFrequency != nil {
+		active.values.PM2AlleleFrequency = *af.AlleleFrequency
+		overridden = true
+	}
+	if !overridden {
+		return ctx
+	}
+	return context.WithValue(ctx, thresholdsKey{}, active)
+}
+
+// applySpecification overrides a generic rule result with the gene's VCEP specification
+func (e *ACMGAMPRuleEngine) applySpecification(spec *vcep.Specification, variant *domain.StandardizedVariant, result *domain.ACMGAMPRuleResult) {
+	rule := spec.Rule(result.Code)
+	if rule == nil {
+		return
+	}
+
+	switch {
+	case rule.NotApplicable():
+		result.Applied = false
+		result.Confidence = 0.0
+		result.Evidence = ""
+		result.MetCriteria = nil
+		result.MatchedVariants = nil
+		result.MatchedDomain = nil
+		result.Segregation = nil
+		result.InTrans = nil
+		result.Reasoning = fmt.Sprintf("Not applicable for %s", variant.GeneSymbol)
+		if rule.Notes != "" {
+			result.Reasoning += " (" + rule.Notes + ")"
+		}
+	case result.Code == "PVS1" && len(rule.DecisionTree) > 0:
+		evaluatePVS1DecisionTree(rule.DecisionTree, variant, result)
+	case result.Code == "PM1" && len(rule.Hotspots) > 0:
+		evaluatePM1Hotspots(rule.Hotspots, variant, result)
+	}
+
+	// Strength modifiers apply to met criteria not already set by a decision tree
+	if result.Applied && rule.Strength != "" && len(rule.DecisionTree) == 0 {
+		result.Strength = rule.Strength
+	}
+
+	result.Reasoning = fmt.Sprintf("%s: %s", spec.Label(), result.Reasoning)
+}
+
+// evaluatePVS1DecisionTree applies the first matching branch of a gene-specific PVS1 tree
+func evaluatePVS1DecisionTree(tree []vcep.DecisionNode, variant *domain.StandardizedVariant, result *domain.ACMGAMPRuleResult) {
+	nullType := nullVariantType(variant)
+	result.Applied = false
+	result.Confidence = 0.0
+	result.Evidence = ""
+	if nullType == "" {
+		result.Reasoning = "Variant is not predicted to be null"
+		return
+	}
+
+	codon := proteinPosition(variant.HGVSProtein)
+	for _, node := range tree {
+		if !node.Matches(nullType, codon) {
+			continue
+		}
+		if node.Strength == "" {
+			result.Reasoning = fmt.Sprintf("Null variant (%s), PVS1 not applicable: %s", nullType, node.Description)
+			return
+		}
+		result.Applied = true
+		result.Strength = node.Strength
+		result.Confidence = 0.9
+		result.Evidence = fmt.Sprintf("Null variant (%s)", nullType)
+		result.Reasoning = fmt.Sprintf("PVS1 at %s strength: %s", strings.ToLower(string(node.Strength)), node.Description)
+		return
+	}
+
+	result.Reasoning = fmt.Sprintf("Null variant (%s) does not match any branch of the PVS1 decision tree", nullType)
+}
+
+// evaluatePM1Hotspots applies PM1 to missense variants within a defined hotspot
+func evaluatePM1Hotspots(hotspots []vcep.Region, variant *domain.StandardizedVariant, result *domain.ACMGAMPRuleResult) {
+	result.Applied = false
+	result.Confidence = 0.0
+	result.Evidence = ""
+	result.MatchedDomain = nil
+
+	if ClassifyConsequence(variant.Consequence, variant.HGVSCoding, variant.HGVSProtein) != ConsequenceMissense {
+		result.Reasoning = "PM1 hotspots apply to missense variants only"
+		return
+	}
+	position := proteinPosition(variant.HGVSProtein)
+	if position == 0 {
+		result.Reasoning = "Protein position unknown; cannot assess PM1 hotspots"
+		return
+	}
+
+	for _, region := range hotspots {
+		if region.Contains(position) {
+			result.Applied = true
+			result.Confidence = 0.8
+			result.Evidence = fmt.Sprintf("Residue %d in %d-%d", position, region.Start, region.End)
+			result.Reasoning = "Missense variant in a defined hotspot"
+			if region.Description != "" {
+				result.Reasoning += " (" + region.Description + ")"
+			}
+			return
+		}
+	}
+	result.Reasoning = fmt.Sprintf("Residue %d is outside the defined hotspots", position)
+}
+
+// nullVariantType returns the kind of null variant, or "" if the variant is not null
+func nullVariantType(variant *domain.StandardizedVariant) string {
+	if ClassifyConsequence(variant.Consequence, variant.HGVSCoding, variant.HGVSProtein) != ConsequenceLossOfFunction {
+		return ""
+	}
+
+	term := strings.ToLower(variant.Consequence)
+	protein := variant.HGVSProtein
+	if idx := strings.Index(protein, ":"); idx >= 0 {
+		protein = protein[idx+1:]
+	}
+	core := strings.TrimLeft(strings.TrimPrefix(protein, "p."), "(")
+
+	switch {
+	case strings.Contains(term, "splice") || canonicalSplicePattern.MatchString(variant.HGVSCoding):
+		return vcep.NullCanonicalSplice
+	case strings.Contains(term, "start_lost") || strings.HasPrefix(core, "Met1"):
+		return vcep.NullInitiationCodon
+	case strings.Contains(term, "frameshift") || strings.Contains(protein, "fs"):
+		return vcep.NullFrameshift
+	default:
+		return vcep.NullNonsense
+	}
+}
+
+// proteinPosition extracts the residue position from protein HGVS, or 0 if unknown
+func proteinPosition(hgvsProtein string) int {
+	protein := hgvsProtein
+	if idx := strings.Index(protein, ":"); idx >= 0 {
+		protein = protein[idx+1:]
+	}
+	match := proteinPositionPattern.FindStringSubmatch(protein)
+	if match == nil {
+		return 0
+	}
+	position, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+	return position
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/vcep"
+)
+
+func testSpecifications(t *testing.T) *vcep.Registry {
+	t.Helper()
+	notApplicable := false
+	pm2 := 0.00001
+	ba1 := 0.002
+
+	registry := vcep.NewRegistry()
+	require.NoError(t, registry.Add(&vcep.Specification{
+		Gene:    "CDH1",
+		Version: "3.1",
+		Rules: map[string]*vcep.RuleSpec{
+			"PVS1": {DecisionTree: []vcep.DecisionNode{
+				{NullType: vcep.NullCanonicalSplice, Strength: domain.VERY_STRONG, Description: "Canonical splice"},
+				{MaxCodon: 836, Strength: domain.VERY_STRONG, Description: "Predicted NMD"},
+				{MinCodon: 837, Strength: domain.MODERATE, Description: "Escapes NMD"},
+			}},
+			"PM2": {AlleleFrequency: &pm2, Strength: domain.SUPPORTING},
+			"BA1": {AlleleFrequency: &ba1},
+			"PP3": {Applicable: &notApplicable},
+		},
+	}))
+	require.NoError(t, registry.Add(&vcep.Specification{
+		Gene:    "MYH7",
+		Version: "1.0",
+		Rules: map[string]*vcep.RuleSpec{
+			"PVS1": {Applicable: &notApplicable, Notes: "LoF is not a mechanism"},
+			"PM1":  {Hotspots: []vcep.Region{{Start: 181, End: 937, Description: "Myosin head"}}},
+		},
+	}))
+	return registry
+}
+
+func TestRuleEngine_SpecificationPVS1DecisionTree(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	engine.SetSpecificationSource(testSpecifications(t))
+	evidence := &domain.AggregatedEvidence{}
+
+	upstream := &domain.StandardizedVariant{ID: "v1", GeneSymbol: "CDH1", HGVSProtein: "p.Gln23Ter", Consequence: "stop_gained"}
+	result, err := engine.EvaluateRule(context.Background(), "PVS1", upstream, evidence)
+	require.NoError(t, err)
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.VERY_STRONG, result.Strength)
+	assert.Contains(t, result.Reasoning, "CDH1 VCEP v3.1")
+
+	downstream := &domain.StandardizedVariant{ID: "v2", GeneSymbol: "CDH1", HGVSProtein: "p.Arg850fs", Consequence: "frameshift_variant"}
+	result, err = engine.EvaluateRule(context.Background(), "PVS1", downstream, evidence)
+	require.NoError(t, err)
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.MODERATE, result.Strength)
+
+	// Unknown codon only matches position-independent branches
+	unknown := &domain.StandardizedVariant{ID: "v3", GeneSymbol: "CDH1", Consequence: "stop_gained"}
+	result, err = engine.EvaluateRule(context.Background(), "PVS1", unknown, evidence)
+	require.NoError(t, err)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "does not match")
+}
+
+func TestRuleEngine_SpecificationNotApplicable(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	engine.SetSpecificationSource(testSpecifications(t))
+	variant := &domain.StandardizedVariant{ID: "v1", GeneSymbol: "MYH7", HGVSProtein: "p.Arg453Ter", Consequence: "stop_gained"}
+
+	result, err := engine.EvaluateRule(context.Background(), "PVS1", variant, &domain.AggregatedEvidence{})
+
+	require.NoError(t, err)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "Not applicable for MYH7 (LoF is not a mechanism)")
+}
+
+func TestRuleEngine_SpecificationPM1Hotspots(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	engine.SetSpecificationSource(testSpecifications(t))
+	evidence := &domain.AggregatedEvidence{}
+
+	inside := &domain.StandardizedVariant{ID: "v1", GeneSymbol: "MYH7", HGVSProtein: "NP_000248.2:p.Arg403Gln"}
+	result, err := engine.EvaluateRule(context.Background(), "PM1", inside, evidence)
+	require.NoError(t, err)
+	assert.True(t, result.Applied)
+	assert.Equal(t, "Residue 403 in 181-937", result.Evidence)
+
+	outside := &domain.StandardizedVariant{ID: "v2", GeneSymbol: "MYH7", HGVSProtein: "p.Glu1356Lys"}
+	result, err = engine.EvaluateRule(context.Background(), "PM1", outside, evidence)
+	require.NoError(t, err)
+	assert.False(t, result.Applied)
+}
+
+func TestRuleEngine_SpecificationFrequencyAndStrength(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	engine.SetSpecificationSource(testSpecifications(t))
+
+	// 0.3% is below the generic BA1 cutoff but above the CDH1 cutoff
+	common := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleFrequency: 0.003}}
+	results, err := engine.EvaluateAllRules(context.Background(), &domain.StandardizedVariant{ID: "v1", GeneSymbol: "CDH1"}, common)
+	require.NoError(t, err)
+	assert.True(t, findRule(t, results, "BA1").Applied)
+
+	results, err = engine.EvaluateAllRules(context.Background(), &domain.StandardizedVariant{ID: "v2", GeneSymbol: "BRCA1"}, common)
+	require.NoError(t, err)
+	assert.False(t, findRule(t, results, "BA1").Applied)
+
+	// PM2 uses the stricter cutoff and is downgraded to supporting
+	rare := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleFrequency: 0.000005}}
+	results, err = engine.EvaluateAllRules(context.Background(), &domain.StandardizedVariant{ID: "v3", GeneSymbol: "CDH1"}, rare)
+	require.NoError(t, err)
+	pm2 := findRule(t, results, "PM2")
+	assert.True(t, pm2.Applied)
+	assert.Equal(t, domain.SUPPORTING, pm2.Strength)
+
+	notRareEnough := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleFrequency: 0.00005}}
+	results, err = engine.EvaluateAllRules(context.Background(), &domain.StandardizedVariant{ID: "v4", GeneSymbol: "CDH1"}, notRareEnough)
+	require.NoError(t, err)
+	assert.False(t, findRule(t, results, "PM2").Applied)
+}
+
+func TestProteinPosition(t *testing.T) {
+	assert.Equal(t, 273, proteinPosition("p.Arg273His"))
+	assert.Equal(t, 273, proteinPosition("NP_000537.3:p.(Arg273His)"))
+	assert.Equal(t, 61, proteinPosition("p.C61G"))
+	assert.Equal(t, 0, proteinPosition(""))
+	assert.Equal(t, 0, proteinPosition("p.?"))
+}
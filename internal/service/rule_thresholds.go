@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+// ThresholdSource supplies the threshold revision in effect at a given time
+type ThresholdSource interface {
+	Effective(ctx context.Context, at time.Time) (*thresholds.Revision, error)
+}
+
+type thresholdsKey struct{}
+
+// activeThresholds is the threshold set attached to an evaluation context
+type activeThresholds struct {
+	values   thresholds.Thresholds
+	revision int64 // 0 when the built-in defaults are used
+}
+
+// SetThresholdSource configures where the rule engine reads its thresholds.
+// Without a source the built-in defaults are used.
+func (e *ACMGAMPRuleEngine) SetThresholdSource(source ThresholdSource) {
+	e.thresholds = source
+}
+
+// SetThresholdSource configures the thresholds used by the rule engine
+func (c *ClassifierService) SetThresholdSource(source ThresholdSource) {
+	c.ruleEngine.SetThresholdSource(source)
+}
+
+// withThresholds resolves the thresholds in effect now and attaches them to the
+// context, so every rule in an evaluation sees the same revision
+func (e *ACMGAMPRuleEngine) withThresholds(ctx context.Context) (context.Context, int64) {
+	if active, ok := ctx.Value(thresholdsKey{}).(activeThresholds); ok {
+		return ctx, active.revision
+	}
+
+	active := activeThresholds{values: thresholds.Defaults()}
+	if e.thresholds != nil {
+		revision, err := e.thresholds.Effective(ctx, time.Now())
+		if err != nil {
+			e.logger.WithError(err).Warn("Failed to load threshold revision, using defaults")
+		} else if revision != nil {
+			active = activeThresholds{values: revision.Thresholds, revision: revision.ID}
+		}
+	}
+
+	return context.WithValue(ctx, thresholdsKey{}, active), active.revision
+}
+
+// thresholdsFrom returns the thresholds attached to the context, or the defaults
+func thresholdsFrom(ctx context.Context) thresholds.Thresholds {
+	if active, ok := ctx.Value(thresholdsKey{}).(activeThresholds); ok {
+		return active.values
+	}
+	return thresholds.Defaults()
+}
+
+// ThresholdsAt returns the thresholds in effect at the given time and their
+// revision, 0 when the built-in defaults apply
+func (c *ClassifierService) ThresholdsAt(ctx context.Context, at time.Time) (thresholds.Thresholds, int64, error) {
+	if c.ruleEngine.thresholds == nil {
+		return thresholds.Defaults(), 0, nil
+	}
+	revision, err := c.ruleEngine.thresholds.Effective(ctx, at)
+	if err != nil {
+		return thresholds.Thresholds{}, 0, err
+	}
+	if revision == nil {
+		return thresholds.Defaults(), 0, nil
+	}
+	return revision.Thresholds, revision.ID, nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+type stubThresholdSource struct {
+	revision *thresholds.Revision
+	err      error
+}
+
+func (s *stubThresholdSource) Effective(ctx context.Context, at time.Time) (*thresholds.Revision, error) {
+	return s.revision, s.err
+}
+
+func findRule(t *testing.T, results []domain.ACMGAMPRuleResult, code string) domain.ACMGAMPRuleResult {
+	t.Helper()
+	for _, r := range results {
+		if r.Code == code {
+			return r
+		}
+	}
+	t.Fatalf("rule %s not evaluated", code)
+	return domain.ACMGAMPRuleResult{}
+}
+
+func TestRuleEngine_FrequencyThresholdsFromSource(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	evidence := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleFrequency: 0.02}}
+	variant := &domain.StandardizedVariant{ID: "var-1"}
+
+	// Defaults: 2% is BS1 territory
+	results, err := engine.EvaluateAllRules(context.Background(), variant, evidence)
+	require.NoError(t, err)
+	assert.True(t, findRule(t, results, "BS1").Applied)
+	assert.False(t, findRule(t, results, "BA1").Applied)
+
+	// A revision lowering BA1 to 1% makes the same frequency stand-alone benign
+	custom := thresholds.Defaults()
+	custom.BA1AlleleFrequency = 0.01
+	custom.BS1AlleleFrequency = 0.005
+	engine.SetThresholdSource(&stubThresholdSource{revision: &thresholds.Revision{ID: 7, Thresholds: custom}})
+
+	results, err = engine.EvaluateAllRules(context.Background(), variant, evidence)
+	require.NoError(t, err)
+	assert.True(t, findRule(t, results, "BA1").Applied)
+	assert.False(t, findRule(t, results, "BS1").Applied)
+}
+
+func TestRuleEngine_SourceErrorFallsBackToDefaults(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	engine.SetThresholdSource(&stubThresholdSource{err: errors.New("database locked")})
+
+	ctx, revision := engine.withThresholds(context.Background())
+
+	assert.Zero(t, revision)
+	assert.Equal(t, thresholds.Defaults(), thresholdsFrom(ctx))
+}
+
+func TestRuleEngine_PVS1RespectsGeneDiseaseModel(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	custom := thresholds.Defaults()
+	custom.GeneModels = map[string]thresholds.GeneDiseaseModel{
+		"SCN5A": {Inheritance: "AD", Mechanism: thresholds.MechanismGainOfFunction},
+	}
+	engine.SetThresholdSource(&stubThresholdSource{revision: &thresholds.Revision{ID: 3, Thresholds: custom}})
+
+	scn5a, err := engine.EvaluateRule(context.Background(), "PVS1", &domain.StandardizedVariant{GeneSymbol: "SCN5A", HGVSProtein: "p.Arg1623Ter"}, &domain.AggregatedEvidence{})
+	require.NoError(t, err)
+	brca1, err := engine.EvaluateRule(context.Background(), "PVS1", &domain.StandardizedVariant{GeneSymbol: "BRCA1", HGVSProtein: "p.Arg1443Ter"}, &domain.AggregatedEvidence{})
+	require.NoError(t, err)
+
+	assert.False(t, scn5a.Applied)
+	assert.Contains(t, scn5a.Reasoning, "gain_of_function")
+	assert.True(t, brca1.Applied, "genes without a model keep the default behaviour")
+}
+
+func TestRuleEngine_PredictorThresholds(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{}
+	damaging := &domain.AggregatedEvidence{ComputationalData: &domain.ComputationalData{CADDScore: 28, SIFTScore: 0.01, PolyPhenScore: 0.98}}
+	tolerated := &domain.AggregatedEvidence{ComputationalData: &domain.ComputationalData{CADDScore: 12, SIFTScore: 0.4, PolyPhenScore: 0.1}}
+	mixed := &domain.AggregatedEvidence{ComputationalData: &domain.ComputationalData{CADDScore: 28, SIFTScore: 0.4, PolyPhenScore: 0.95}}
+
+	pp3, err := engine.EvaluateRule(context.Background(), "PP3", variant, damaging)
+	require.NoError(t, err)
+	bp4, err := engine.EvaluateRule(context.Background(), "BP4", variant, tolerated)
+	require.NoError(t, err)
+	conflicting, err := engine.EvaluateRule(context.Background(), "PP3", variant, mixed)
+	require.NoError(t, err)
+
+	assert.True(t, pp3.Applied)
+	assert.True(t, bp4.Applied)
+	assert.False(t, conflicting.Applied, "a benign prediction blocks PP3")
+}
+
+func TestRuleEngine_DbNSFPPredictors(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{HGVSProtein: "p.Arg175His"}
+	evaluate := func(code string, data *domain.ComputationalData) *domain.ACMGAMPRuleResult {
+		t.Helper()
+		result, err := engine.EvaluateRule(context.Background(), code, variant, &domain.AggregatedEvidence{ComputationalData: data})
+		require.NoError(t, err)
+		return result
+	}
+
+	// Missense variant scored by REVEL and AlphaMissense only; SIFT and
+	// PolyPhen are missing, not zero
+	damaging := &domain.ComputationalData{REVELScore: 0.93, AlphaMissenseScore: 0.88,
+		ScoredBy: []string{domain.PredictorREVEL, domain.PredictorAlphaMissense}}
+	pp3 := evaluate("PP3", damaging)
+	assert.True(t, pp3.Applied)
+	assert.Equal(t, "Deleterious: REVEL 0.930, AlphaMissense 0.880", pp3.Evidence)
+
+	tolerated := &domain.ComputationalData{CADDScore: 8.2, REVELScore: 0.05, AlphaMissenseScore: 0.1,
+		ScoredBy: []string{domain.PredictorCADD, domain.PredictorREVEL, domain.PredictorAlphaMissense}}
+	assert.True(t, evaluate("BP4", tolerated).Applied)
+	assert.False(t, evaluate("PP3", tolerated).Applied)
+
+	// Scores between the REVEL cutoffs count neither way
+	indeterminate := &domain.ComputationalData{REVELScore: 0.5, AlphaMissenseScore: 0.9,
+		ScoredBy: []string{domain.PredictorREVEL, domain.PredictorAlphaMissense}}
+	assert.False(t, evaluate("PP3", indeterminate).Applied)
+
+	// Revisions can move the REVEL cutoffs
+	custom := thresholds.Defaults()
+	custom.Predictors.REVELDeleterious = 0.45
+	engine.SetThresholdSource(&stubThresholdSource{revision: &thresholds.Revision{ID: 3, Thresholds: custom}})
+	assert.True(t, evaluate("PP3", indeterminate).Applied)
+}
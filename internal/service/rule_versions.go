@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/provenance"
+	"github.com/acmg-amp-mcp-server/internal/ruleversions"
+)
+
+type ruleVersionKey struct{}
+
+// withRuleVersion attaches the rule set version to an evaluation context
+func withRuleVersion(ctx context.Context, version *ruleversions.Version) context.Context {
+	return context.WithValue(ctx, ruleVersionKey{}, version)
+}
+
+// ruleVersionFrom returns the rule set version attached to the context, or nil
+func ruleVersionFrom(ctx context.Context) *ruleversions.Version {
+	version, _ := ctx.Value(ruleVersionKey{}).(*ruleversions.Version)
+	return version
+}
+
+// resolveRuleVersion looks up the requested rule set version and the scoring
+// mode to use with it: the requested mode, else the version's own mode when
+// a version was named, else the fallback
+func resolveRuleVersion(name, scoringModeName string, fallback ScoringMode) (*ruleversions.Version, ScoringMode, error) {
+	version, err := ruleversions.Lookup(name)
+	if err != nil {
+		return nil, "", err
+	}
+	switch {
+	case scoringModeName != "":
+		mode, err := ParseScoringMode(scoringModeName)
+		return version, mode, err
+	case name != "":
+		mode, err := ParseScoringMode(version.ScoringMode)
+		return version, mode, err
+	}
+	return version, fallback, nil
+}
+
+// applyRuleVersion adjusts a generic rule result for the rule set version.
+// VCEP specifications are applied afterwards and take precedence.
+func applyRuleVersion(version *ruleversions.Version, result *domain.ACMGAMPRuleResult) {
+	rule := version.Rule(result.Code)
+	if rule == nil {
+		return
+	}
+
+	if rule.NotApplicable() {
+		result.Applied = false
+		result.Confidence = 0.0
+		result.Evidence = ""
+		result.MetCriteria = nil
+		result.MatchedVariants = nil
+		result.MatchedDomain = nil
+		result.Segregation = nil
+		result.InTrans = nil
+		result.Reasoning = fmt.Sprintf("%s: not applicable", version.Label())
+		if rule.Notes != "" {
+			result.Reasoning += " (" + rule.Notes + ")"
+		}
+		return
+	}
+	if result.Applied && rule.Strength != "" && rule.Strength != result.Strength {
+		result.Strength = rule.Strength
+		result.Reasoning = fmt.Sprintf("%s: %s (applied at %s strength)", version.Label(), result.Reasoning, rule.Strength)
+	}
+}
+
+// RuleVersionOutcome is the classification of a variant under one rule set version
+type RuleVersionOutcome struct {
+	Version        string              `json:"version"`
+	Title          string              `json:"title"`
+	ScoringMode    string              `json:"scoring_mode"`
+	Classification string              `json:"classification"`
+	Confidence     string              `json:"confidence"`
+	PointTotal     int                 `json:"point_total"`
+	AppliedRules   []ACMGAMPRuleResult `json:"applied_rules"`
+}
+
+// CriterionDifference is a criterion met differently under two rule set versions
+type CriterionDifference struct {
+	Code              string `json:"code"`
+	BaselineStrength  string `json:"baseline_strength,omitempty"`  // Empty when not met under the baseline
+	CandidateStrength string `json:"candidate_strength,omitempty"` // Empty when not met under the candidate
+	Reasoning         string `json:"reasoning,omitempty"`          // Why the candidate differs
+}
+
+// RuleVersionComparison compares the classification of a variant under two
+// rule set versions, made from the same evidence
+type RuleVersionComparison struct {
+	HGVSNotation          string                `json:"hgvs_notation"`
+	Baseline              RuleVersionOutcome    `json:"baseline"`
+	Candidate             RuleVersionOutcome    `json:"candidate"`
+	ClassificationChanged bool                  `json:"classification_changed"`
+	DifferingCriteria     []CriterionDifference `json:"differing_criteria"`
+	DatasetVersions       provenance.Versions   `json:"dataset_versions,omitempty"`
+}
+
+// CompareRuleVersions classifies a variant under two rule set versions and
+// reports the criteria and outcomes that differ. Evidence is gathered once,
+// under the baseline, and the candidate is evaluated from the same evidence
+// bundle with the same thresholds, so only the rule set differs. A scoring
+// mode in the parameters applies to both versions; otherwise each version
+// uses its own.
+func (c *ClassifierService) CompareRuleVersions(ctx context.Context, params *ClassifyVariantParams, baseline, candidate string) (*RuleVersionComparison, error) {
+	if params == nil {
+		return nil, fmt.Errorf("invalid input parameters: parameters are required")
+	}
+	baselineVersion, err := ruleversions.Lookup(baseline)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input parameters: %w", err)
+	}
+	candidateVersion, err := ruleversions.Lookup(candidate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input parameters: %w", err)
+	}
+	if params.ClassificationContext == ContextSomatic {
+		return nil, fmt.Errorf("invalid input parameters: rule set versions apply to germline classification")
+	}
+
+	baselineParams := *params
+	baselineParams.RulesVersion = baselineVersion.Name
+	baselineResult, err := c.ClassifyVariant(ctx, &baselineParams)
+	if err != nil {
+		return nil, err
+	}
+	if baselineResult.Bundle == nil {
+		return nil, fmt.Errorf("invalid input parameters: %s is not evaluated with the ACMG/AMP criteria", baselineResult.InputNotation)
+	}
+
+	candidateResult, err := c.ReplayClassification(ctx, baselineResult.Bundle, ReplayOptions{
+		RulesVersion: candidateVersion.Name,
+		ScoringMode:  params.ScoringMode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &RuleVersionComparison{
+		HGVSNotation:          baselineResult.InputNotation,
+		Baseline:              ruleVersionOutcome(baselineVersion, baselineResult),
+		Candidate:             ruleVersionOutcome(candidateVersion, candidateResult),
+		ClassificationChanged: baselineResult.Classification != candidateResult.Classification,
+		DifferingCriteria:     differingCriteria(baselineResult.AppliedRules, candidateResult.AppliedRules),
+		DatasetVersions:       baselineResult.DatasetVersions,
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"hgvs_notation":            comparison.HGVSNotation,
+		"baseline_version":         baselineVersion.Name,
+		"candidate_version":        candidateVersion.Name,
+		"baseline_classification":  comparison.Baseline.Classification,
+		"candidate_classification": comparison.Candidate.Classification,
+		"differing_criteria":       len(comparison.DifferingCriteria),
+	}).Info("Rule set versions compared")
+
+	return comparison, nil
+}
+
+func ruleVersionOutcome(version *ruleversions.Version, result *ClassifyVariantResult) RuleVersionOutcome {
+	return RuleVersionOutcome{
+		Version:        version.Name,
+		Title:          version.Title,
+		ScoringMode:    result.ScoringMode,
+		Classification: result.Classification,
+		Confidence:     result.Confidence,
+		PointTotal:     result.PointTotal,
+		AppliedRules:   result.AppliedRules,
+	}
+}
+
+// differingCriteria lists the criteria met under only one version or at a
+// different strength, ordered by code
+func differingCriteria(baseline, candidate []ACMGAMPRuleResult) []CriterionDifference {
+	met := func(rules []ACMGAMPRuleResult) map[string]ACMGAMPRuleResult {
+		applied := make(map[string]ACMGAMPRuleResult)
+		for _, rule := range rules {
+			if rule.Applied {
+				applied[rule.RuleCode] = rule
+			}
+		}
+		return applied
+	}
+	baselineMet, candidateMet := met(baseline), met(candidate)
+	reasoning := make(map[string]string, len(candidate))
+	for _, rule := range candidate {
+		reasoning[rule.RuleCode] = rule.Reasoning
+	}
+
+	codes := make(map[string]bool)
+	for code := range baselineMet {
+		codes[code] = true
+	}
+	for code := range candidateMet {
+		codes[code] = true
+	}
+
+	differences := []CriterionDifference{}
+	for code := range codes {
+		b, inBaseline := baselineMet[code]
+		c, inCandidate := candidateMet[code]
+		if inBaseline && inCandidate && b.Strength == c.Strength {
+			continue
+		}
+		difference := CriterionDifference{Code: code, Reasoning: reasoning[code]}
+		if inBaseline {
+			difference.BaselineStrength = b.Strength
+		}
+		if inCandidate {
+			difference.CandidateStrength = c.Strength
+		}
+		differences = append(differences, difference)
+	}
+	sort.Slice(differences, func(i, j int) bool { return differences[i].Code < differences[j].Code })
+	return differences
+}
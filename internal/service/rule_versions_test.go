@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/ruleversions"
+)
+
+func findAppliedRule(rules []ACMGAMPRuleResult, code string) ACMGAMPRuleResult {
+	for _, rule := range rules {
+		if rule.RuleCode == code {
+			return rule
+		}
+	}
+	return ACMGAMPRuleResult{}
+}
+
+func TestReplayClassification_RuleVersion(t *testing.T) {
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+	variant := &domain.StandardizedVariant{ID: "var-1", GeneSymbol: "BRCA1", HGVSGenomic: "NC_000017.11:g.43045712G>A"}
+	evidence := &domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleFrequency: 0.000001}}
+
+	baseline, _, err := classifier.evaluateGermline(withRuleVersion(context.Background(), nil), germlineEvaluation{
+		params:       &ClassifyVariantParams{HGVSNotation: variant.HGVSGenomic},
+		variant:      variant,
+		hgvsNotation: variant.HGVSGenomic,
+		evidence:     evidence,
+		scoringMode:  ScoringModeCombiningRules,
+		startTime:    time.Now(),
+	})
+	require.NoError(t, err)
+	pm2 := findAppliedRule(baseline.AppliedRules, "PM2")
+	require.True(t, pm2.Applied)
+	assert.Equal(t, "MODERATE", pm2.Strength)
+
+	// The SVI rule set applies PM2 at supporting and scores with points
+	candidate, err := classifier.ReplayClassification(context.Background(), baseline.Bundle, ReplayOptions{RulesVersion: ruleversions.ClinGenSVI2023})
+	require.NoError(t, err)
+	assert.Equal(t, ruleversions.ClinGenSVI2023, candidate.RulesVersion)
+	assert.Equal(t, string(ScoringModePoints), candidate.ScoringMode)
+	pm2 = findAppliedRule(candidate.AppliedRules, "PM2")
+	assert.True(t, pm2.Applied)
+	assert.Equal(t, "SUPPORTING", pm2.Strength)
+	assert.Contains(t, pm2.Reasoning, "ClinGen SVI 2023")
+	assert.False(t, findAppliedRule(candidate.AppliedRules, "PP5").Applied)
+
+	differences := differingCriteria(baseline.AppliedRules, candidate.AppliedRules)
+	require.Len(t, differences, 1)
+	assert.Equal(t, "PM2", differences[0].Code)
+	assert.Equal(t, "MODERATE", differences[0].BaselineStrength)
+	assert.Equal(t, "SUPPORTING", differences[0].CandidateStrength)
+
+	// An explicit scoring mode applies to either version
+	candidate, err = classifier.ReplayClassification(context.Background(), baseline.Bundle, ReplayOptions{
+		RulesVersion: ruleversions.ClinGenSVI2023,
+		ScoringMode:  string(ScoringModeCombiningRules),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(ScoringModeCombiningRules), candidate.ScoringMode)
+}
+
+func TestApplyRuleVersion_Retired(t *testing.T) {
+	version, err := ruleversions.Lookup(ruleversions.ClinGenSVI2023)
+	require.NoError(t, err)
+
+	result := &domain.ACMGAMPRuleResult{Code: "PP5", Strength: domain.SUPPORTING, Applied: true, Confidence: 0.8, Evidence: "ClinVar: Pathogenic"}
+	applyRuleVersion(version, result)
+	assert.False(t, result.Applied)
+	assert.Empty(t, result.Evidence)
+	assert.Contains(t, result.Reasoning, "not applicable")
+
+	// Criteria the version does not adjust are left alone
+	result = &domain.ACMGAMPRuleResult{Code: "PS3", Strength: domain.STRONG, Applied: true, Reasoning: "Functional studies"}
+	applyRuleVersion(version, result)
+	assert.True(t, result.Applied)
+	assert.Equal(t, "Functional studies", result.Reasoning)
+}
+
+func TestCompareRuleVersions_Invalid(t *testing.T) {
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+	params := &ClassifyVariantParams{HGVSNotation: "NC_000017.11:g.43045712G>A"}
+
+	_, err := classifier.CompareRuleVersions(context.Background(), params, ruleversions.ACMG2015, "acmg2025")
+	assert.ErrorIs(t, err, ruleversions.ErrUnknownVersion)
+
+	somatic := &ClassifyVariantParams{HGVSNotation: params.HGVSNotation, ClassificationContext: ContextSomatic}
+	_, err = classifier.CompareRuleVersions(context.Background(), somatic, ruleversions.ACMG2015, ruleversions.ClinGenSVI2023)
+	assert.ErrorContains(t, err, "germline")
+}
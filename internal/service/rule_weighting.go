@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/weighting"
+)
+
+// WeightingPolicyResult records the laboratory weighting policy in effect for
+// a classification and the overrides it applied
+type WeightingPolicyResult struct {
+	Name      string   `json:"name"`
+	Overrides []string `json:"overrides"` // e.g. "PP3 SUPPORTING -> MODERATE (REVEL >= 0.932)"
+}
+
+// SetWeightingPolicy configures the laboratory's criterion weighting policy.
+// Its overrides are applied last, after rule set versions and VCEP
+// specifications. Without a policy criteria keep their strengths.
+func (e *ACMGAMPRuleEngine) SetWeightingPolicy(policy *weighting.Policy) {
+	e.weighting = policy
+}
+
+// SetWeightingPolicy configures the weighting policy used by the rule engine
+func (c *ClassifierService) SetWeightingPolicy(policy *weighting.Policy) {
+	c.ruleEngine.SetWeightingPolicy(policy)
+}
+
+// applyWeighting sets the strength of a met criterion from the first matching
+// override of the policy, noting the override on the result
+func applyWeighting(policy *weighting.Policy, result *domain.ACMGAMPRuleResult, evidence *domain.AggregatedEvidence) {
+	if !result.Applied {
+		return
+	}
+	override := policy.Match(result.Code, evidence.ComputationalData)
+	if override == nil || override.Strength == result.Strength {
+		return
+	}
+
+	applied := weighting.Applied{
+		Criterion: result.Code,
+		From:      result.Strength.String(),
+		To:        override.Strength.String(),
+		Condition: override.When.String(),
+	}
+	result.Strength = override.Strength
+	result.PolicyOverride = applied.String()
+	result.Reasoning = fmt.Sprintf("%s (%s policy: %s)", result.Reasoning, policy.Name, applied)
+	if override.Notes != "" {
+		result.Reasoning += "; " + override.Notes
+	}
+}
+
+// weightingPolicyResult lists the overrides applied in an evaluation, or
+// returns nil without a policy
+func (e *ACMGAMPRuleEngine) weightingPolicyResult(ruleResults []domain.ACMGAMPRuleResult) *WeightingPolicyResult {
+	if e.weighting == nil {
+		return nil
+	}
+	result := &WeightingPolicyResult{Name: e.weighting.Name, Overrides: []string{}}
+	for _, rule := range ruleResults {
+		if rule.PolicyOverride != "" {
+			result.Overrides = append(result.Overrides, rule.PolicyOverride)
+		}
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/weighting"
+)
+
+func TestWeightingPolicy_RecordedInResult(t *testing.T) {
+	minScore := 0.932
+	policy := &weighting.Policy{Name: "lab-2024", Overrides: []weighting.Override{
+		{Criterion: "PP3", Strength: domain.MODERATE, When: &weighting.Condition{Predictor: domain.PredictorREVEL, MinScore: &minScore}},
+	}}
+	require.NoError(t, policy.Validate())
+
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+	classifier.SetWeightingPolicy(policy)
+	variant := &domain.StandardizedVariant{ID: "var-1", GeneSymbol: "TP53", HGVSGenomic: "NC_000017.11:g.7675088C>T"}
+	evaluate := func(revel float64) *ClassifyVariantResult {
+		evidence := &domain.AggregatedEvidence{ComputationalData: &domain.ComputationalData{
+			REVELScore: revel,
+			CADDScore:  32,
+			ScoredBy:   []string{domain.PredictorREVEL, domain.PredictorCADD},
+		}}
+		result, _, err := classifier.evaluateGermline(context.Background(), germlineEvaluation{
+			params:       &ClassifyVariantParams{HGVSNotation: variant.HGVSGenomic},
+			variant:      variant,
+			hgvsNotation: variant.HGVSGenomic,
+			evidence:     evidence,
+			scoringMode:  ScoringModeCombiningRules,
+			startTime:    time.Now(),
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := evaluate(0.95)
+	pp3 := findAppliedRule(result.AppliedRules, "PP3")
+	require.True(t, pp3.Applied)
+	assert.Equal(t, "MODERATE", pp3.Strength)
+	assert.Equal(t, "PP3 SUPPORTING -> MODERATE (REVEL >= 0.932)", pp3.PolicyOverride)
+	assert.Contains(t, pp3.Reasoning, "lab-2024 policy")
+	require.NotNil(t, result.WeightingPolicy)
+	assert.Equal(t, "lab-2024", result.WeightingPolicy.Name)
+	assert.Equal(t, []string{pp3.PolicyOverride}, result.WeightingPolicy.Overrides)
+
+	// Below the cutoff PP3 keeps its strength, and the policy is still recorded
+	result = evaluate(0.8)
+	pp3 = findAppliedRule(result.AppliedRules, "PP3")
+	require.True(t, pp3.Applied)
+	assert.Equal(t, "SUPPORTING", pp3.Strength)
+	assert.Empty(t, pp3.PolicyOverride)
+	require.NotNil(t, result.WeightingPolicy)
+	assert.Empty(t, result.WeightingPolicy.Overrides)
+
+	// Without a policy nothing is recorded
+	classifier.SetWeightingPolicy(nil)
+	assert.Nil(t, evaluate(0.95).WeightingPolicy)
+}
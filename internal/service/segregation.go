@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/segregation"
+)
+
+// evaluateSegregation applies PP1 (pathogenic) or BS4 from the segregation
+// data in the patient context. The LOD score sets the PP1 strength; BS4
+// applies when the LOD is at or below the exclusion cutoff.
+func evaluateSegregation(ctx context.Context, result *domain.ACMGAMPRuleResult, pathogenic bool) {
+	patient := patientContextFrom(ctx)
+	if patient == nil || patient.Segregation == nil {
+		result.Reasoning = "Segregation data not provided; pass patient_context.segregation to assess " + result.Code
+		return
+	}
+
+	analysis, err := segregation.Analyze(*patient.Segregation)
+	if err != nil {
+		result.Reasoning = fmt.Sprintf("Cannot assess segregation: %v", err)
+		return
+	}
+	result.Segregation = &analysis
+	result.Evidence = segregationEvidence(analysis)
+
+	supporting, moderate, strong, bs4 := thresholdsFrom(ctx).Segregation.Cutoffs()
+	if !pathogenic {
+		if analysis.LOD > bs4 {
+			result.Reasoning = fmt.Sprintf("LOD %.2f is above the BS4 cutoff of %.2f", analysis.LOD, bs4)
+			return
+		}
+		result.Applied = true
+		result.Confidence = 0.8
+		result.Reasoning = fmt.Sprintf("Variant does not segregate with disease: LOD %.2f at or below %.2f", analysis.LOD, bs4)
+		return
+	}
+
+	switch {
+	case analysis.LOD >= strong:
+		result.Strength = domain.STRONG
+	case analysis.LOD >= moderate:
+		result.Strength = domain.MODERATE
+	case analysis.LOD >= supporting:
+		result.Strength = domain.SUPPORTING
+	default:
+		result.Reasoning = fmt.Sprintf("LOD %.2f is below the PP1 supporting cutoff of %.2f", analysis.LOD, supporting)
+		return
+	}
+	result.Applied = true
+	result.Confidence = 0.8
+	result.Reasoning = fmt.Sprintf("Variant cosegregates with disease: LOD %.2f supports PP1 at %s strength", analysis.LOD, strings.ToLower(result.Strength.Label()))
+}
+
+// segregationEvidence summarizes a segregation analysis for the rule evidence
+func segregationEvidence(a domain.SegregationAnalysis) string {
+	evidence := fmt.Sprintf("%d informative meioses (%d affected carriers, %d affected noncarriers, %d unaffected carriers, %d unaffected noncarriers)",
+		a.Meioses, a.AffectedCarriers, a.AffectedNoncarriers, a.UnaffectedCarriers, a.UnaffectedNoncarriers)
+	if a.Families > 0 {
+		evidence += fmt.Sprintf(" in %d families", a.Families)
+	}
+	return evidence + fmt.Sprintf("; penetrance %g, phenocopy rate %g; Bayes factor %.3g, LOD %.2f", a.Penetrance, a.PhenocopyRate, a.BayesFactor, a.LOD)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/segregation"
+)
+
+func TestRuleEngine_Segregation(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{GeneSymbol: "MYH7", HGVSProtein: "p.Arg403Gln"}
+	evaluate := func(code string, observations *segregation.Observations) *domain.ACMGAMPRuleResult {
+		t.Helper()
+		var patient *PatientContext
+		if observations != nil {
+			patient = &PatientContext{Segregation: observations}
+		}
+		result, err := engine.EvaluateRule(withPatientContext(context.Background(), patient), code, variant, &domain.AggregatedEvidence{})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := evaluate("PP1", nil)
+	assert.False(t, result.Applied)
+	assert.Contains(t, result.Reasoning, "patient_context.segregation")
+
+	tests := []struct {
+		carriers int
+		applied  bool
+		strength domain.RuleStrength
+	}{
+		{2, false, domain.SUPPORTING},
+		{3, true, domain.SUPPORTING},
+		{4, true, domain.MODERATE},
+		{5, true, domain.STRONG},
+	}
+	for _, tt := range tests {
+		result = evaluate("PP1", &segregation.Observations{AffectedCarriers: tt.carriers})
+		assert.Equal(t, tt.applied, result.Applied, "%d affected carriers", tt.carriers)
+		assert.Equal(t, tt.strength, result.Strength, "%d affected carriers", tt.carriers)
+		require.NotNil(t, result.Segregation)
+		assert.Equal(t, tt.carriers, result.Segregation.Meioses)
+	}
+
+	result = evaluate("BS4", &segregation.Observations{AffectedCarriers: 5})
+	assert.False(t, result.Applied)
+
+	result = evaluate("BS4", &segregation.Observations{AffectedCarriers: 2, AffectedNoncarriers: 1, Families: 1})
+	assert.True(t, result.Applied)
+	assert.Equal(t, domain.STRONG, result.Strength)
+	assert.Contains(t, result.Evidence, "1 affected noncarriers")
+	assert.Contains(t, result.Evidence, "in 1 families")
+	assert.False(t, evaluate("PP1", &segregation.Observations{AffectedCarriers: 2, AffectedNoncarriers: 1}).Applied)
+}
+
+func TestPatientContext_ValidateSegregation(t *testing.T) {
+	patient := &PatientContext{Segregation: &segregation.Observations{AffectedCarriers: 3}}
+	require.NoError(t, patient.Validate())
+	assert.Equal(t, segregation.DefaultPenetrance, patient.Segregation.Penetrance)
+
+	assert.Error(t, (&PatientContext{Segregation: &segregation.Observations{}}).Validate())
+}
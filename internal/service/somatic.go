@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// Classification contexts: germline variants are classified with the
+// ACMG/AMP 2015 criteria, somatic variants are tiered with AMP/ASCO/CAP 2017
+const (
+	ContextGermline = "germline"
+	ContextSomatic  = "somatic"
+)
+
+// Population frequencies at which a somatic variant is considered a germline
+// polymorphism (Tier IV), and at which that call is made with high confidence
+const (
+	somaticPolymorphismFrequency       = 0.01
+	somaticCommonPolymorphismFrequency = 0.05
+)
+
+// panCancerTypes are tumor type names under which OncoKB and CIViC record
+// tumor-agnostic evidence; they match any tumor type
+var panCancerTypes = map[string]bool{
+	"all tumors":        true,
+	"all solid tumors":  true,
+	"all liquid tumors": true,
+	"cancer":            true,
+	"solid tumor":       true,
+}
+
+// SomaticAssessment is the AMP/ASCO/CAP tier of a somatic variant and the
+// evidence it rests on
+type SomaticAssessment struct {
+	Tier          domain.SomaticTier          `json:"tier"`
+	TierLabel     string                      `json:"tier_label"`
+	EvidenceLevel domain.SomaticEvidenceLevel `json:"evidence_level,omitempty"` // Strongest level of evidence, if any
+	TumorType     string                      `json:"tumor_type,omitempty"`
+	Confidence    domain.ConfidenceLevel      `json:"confidence"`
+	// Evidence levels are as applied to the tumor type: level A and B
+	// evidence in other tumor types counts as level C
+	Evidence     []domain.SomaticEvidence `json:"evidence,omitempty"`
+	Rationale    string                   `json:"rationale"`
+	Sources      []string                 `json:"sources"`                 // Evidence sources queried successfully
+	SourceErrors map[string]string        `json:"source_errors,omitempty"` // Sources that could not be queried
+}
+
+// SetSomaticEvidenceSources configures the knowledge bases queried for
+// somatic evidence, e.g. OncoKB and CIViC. Without sources somatic variants
+// are tiered on population and COSMIC data alone.
+func (c *ClassifierService) SetSomaticEvidenceSources(sources ...external.SomaticEvidenceClient) {
+	c.somaticSources = sources
+}
+
+// ParseClassificationContext validates a classification context, defaulting
+// to germline
+func ParseClassificationContext(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", ContextGermline:
+		return ContextGermline, nil
+	case ContextSomatic:
+		return ContextSomatic, nil
+	default:
+		return "", fmt.Errorf("unknown classification context %q (expected %s or %s)", s, ContextGermline, ContextSomatic)
+	}
+}
+
+// assessSomatic queries the somatic evidence sources and tiers the variant.
+// A failing source is recorded and the variant is tiered on the others.
+func (c *ClassifierService) assessSomatic(ctx context.Context, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence, tumorType string) *SomaticAssessment {
+	var (
+		items   []domain.SomaticEvidence
+		sources []string
+		errs    map[string]string
+	)
+	for _, source := range c.somaticSources {
+		found, err := source.QuerySomaticEvidence(ctx, variant, tumorType)
+		if err != nil {
+			c.logger.WithError(err).WithField("source", source.Name()).Warn("Failed to query somatic evidence")
+			if errs == nil {
+				errs = make(map[string]string)
+			}
+			errs[source.Name()] = err.Error()
+			continue
+		}
+		sources = append(sources, source.Name())
+		items = append(items, found...)
+	}
+
+	assessment := tierSomaticVariant(items, tumorType, evidence)
+	assessment.Sources = sources
+	assessment.SourceErrors = errs
+	return assessment
+}
+
+// tierSomaticVariant assigns the AMP/ASCO/CAP tier (Li et al. 2017): level A
+// or B evidence makes Tier I, level C or D Tier II. Without evidence a variant
+// common in the population or benign in ClinVar is Tier IV, otherwise Tier III.
+func tierSomaticVariant(items []domain.SomaticEvidence, tumorType string, evidence *domain.AggregatedEvidence) *SomaticAssessment {
+	assessment := &SomaticAssessment{TumorType: tumorType}
+
+	for _, item := range items {
+		if (item.Level == domain.LEVEL_A || item.Level == domain.LEVEL_B) && !matchesTumorType(item.TumorType, tumorType) {
+			item.Level = domain.LEVEL_C
+		}
+		assessment.Evidence = append(assessment.Evidence, item)
+	}
+	// Levels sort alphabetically from strongest to weakest
+	sort.SliceStable(assessment.Evidence, func(i, j int) bool {
+		return assessment.Evidence[i].Level < assessment.Evidence[j].Level
+	})
+
+	frequency := populationFrequency(evidence)
+	switch {
+	case len(assessment.Evidence) > 0:
+		strongest := assessment.Evidence[0]
+		assessment.EvidenceLevel = strongest.Level
+		switch strongest.Level {
+		case domain.LEVEL_A:
+			assessment.Tier, assessment.Confidence = domain.TIER_I, domain.HIGH
+		case domain.LEVEL_B:
+			assessment.Tier, assessment.Confidence = domain.TIER_I, domain.MEDIUM
+		case domain.LEVEL_C:
+			assessment.Tier, assessment.Confidence = domain.TIER_II, domain.MEDIUM
+		default:
+			assessment.Tier, assessment.Confidence = domain.TIER_II, domain.LOW
+		}
+		assessment.Rationale = fmt.Sprintf("Level %s %s evidence%s (%s)",
+			strongest.Level, strongest.Type, tumorTypeClause(strongest.TumorType), describeSomaticEvidence(strongest))
+
+	case frequency >= somaticPolymorphismFrequency:
+		assessment.Tier, assessment.Confidence = domain.TIER_IV, domain.MEDIUM
+		if frequency >= somaticCommonPolymorphismFrequency {
+			assessment.Confidence = domain.HIGH
+		}
+		assessment.Rationale = fmt.Sprintf("Population allele frequency %.4f indicates a germline polymorphism", frequency)
+
+	case clinVarBenign(evidence):
+		assessment.Tier, assessment.Confidence = domain.TIER_IV, domain.MEDIUM
+		assessment.Rationale = fmt.Sprintf("Classified %s in ClinVar with no somatic evidence of clinical significance", evidence.ClinVarData.ClinicalSignificance)
+
+	default:
+		assessment.Tier, assessment.Confidence = domain.TIER_III, domain.LOW
+		assessment.Rationale = "No evidence of clinical significance in the knowledge bases queried"
+		if evidence != nil && evidence.SomaticData != nil && evidence.SomaticData.SampleCount > 0 {
+			assessment.Rationale += fmt.Sprintf("; observed in %d COSMIC samples", evidence.SomaticData.SampleCount)
+		}
+	}
+
+	assessment.TierLabel = assessment.Tier.Label()
+	return assessment
+}
+
+// somaticRecommendations suggests follow-up for a somatic assessment
+func somaticRecommendations(assessment *SomaticAssessment) []string {
+	recommendations := make([]string, 0)
+	switch assessment.Tier {
+	case domain.TIER_I, domain.TIER_II:
+		recommendations = append(recommendations, "Report with the associated therapies, diagnoses or prognoses")
+	case domain.TIER_III:
+		recommendations = append(recommendations, "Report as a variant of unknown clinical significance")
+		recommendations = append(recommendations, "Periodic re-evaluation as new evidence becomes available")
+	case domain.TIER_IV:
+		recommendations = append(recommendations, "Not recommended for reporting")
+	}
+	if assessment.TumorType == "" {
+		recommendations = append(recommendations, "No tumor type given; level A and B evidence was assessed as level C. Provide tumor_type to tier against the patient's tumor")
+	}
+	for _, source := range sortedKeys(assessment.SourceErrors) {
+		recommendations = append(recommendations, fmt.Sprintf("%s could not be queried (%s); the tier may be incomplete", source, assessment.SourceErrors[source]))
+	}
+	return recommendations
+}
+
+// matchesTumorType reports whether evidence recorded for one tumor type
+// applies to another; names match if either contains the other
+func matchesTumorType(evidenceTumor, tumorType string) bool {
+	evidenceTumor = strings.ToLower(strings.TrimSpace(evidenceTumor))
+	tumorType = strings.ToLower(strings.TrimSpace(tumorType))
+	if tumorType == "" || evidenceTumor == "" {
+		return false
+	}
+	if panCancerTypes[evidenceTumor] {
+		return true
+	}
+	return strings.Contains(evidenceTumor, tumorType) || strings.Contains(tumorType, evidenceTumor)
+}
+
+// populationFrequency returns the highest population allele frequency known
+func populationFrequency(evidence *domain.AggregatedEvidence) float64 {
+	if evidence == nil || evidence.PopulationData == nil {
+		return 0
+	}
+	frequency := evidence.PopulationData.AlleleFrequency
+	for _, f := range evidence.PopulationData.PopulationFrequencies {
+		if f > frequency {
+			frequency = f
+		}
+	}
+	return frequency
+}
+
+// clinVarBenign reports whether ClinVar classifies the variant benign or likely benign
+func clinVarBenign(evidence *domain.AggregatedEvidence) bool {
+	if evidence == nil || evidence.ClinVarData == nil {
+		return false
+	}
+	significance := strings.ToLower(evidence.ClinVarData.ClinicalSignificance)
+	return strings.Contains(significance, "benign") && !strings.Contains(significance, "pathogenic")
+}
+
+// tumorTypeClause names the tumor type evidence was recorded in
+func tumorTypeClause(tumorType string) string {
+	if tumorType == "" {
+		return ""
+	}
+	return " in " + tumorType
+}
+
+// describeSomaticEvidence summarizes the source and substance of an evidence item
+func describeSomaticEvidence(item domain.SomaticEvidence) string {
+	parts := []string{strings.TrimSpace(item.Source + " " + item.SourceLevel)}
+	if item.Significance != "" {
+		parts = append(parts, item.Significance)
+	}
+	if len(item.Therapies) > 0 {
+		parts = append(parts, strings.Join(item.Therapies, ", "))
+	}
+	return strings.Join(parts, ": ")
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// classifySomatic builds the classification result of a somatic variant: the
+// classification is its tier and no ACMG/AMP criteria are applied
+func (c *ClassifierService) classifySomatic(ctx context.Context, params *ClassifyVariantParams, variant *domain.StandardizedVariant, hgvsNotation string, evidence *domain.AggregatedEvidence, normalized *domain.NormalizedVariant, startTime time.Time) *ClassifyVariantResult {
+	assessment := c.assessSomatic(ctx, variant, evidence, params.TumorType)
+
+	c.logger.WithFields(logrus.Fields{
+		"variant_id":     variant.ID,
+		"tier":           assessment.Tier,
+		"evidence_level": assessment.EvidenceLevel,
+		"tumor_type":     assessment.TumorType,
+	}).Info("Somatic variant tiering completed")
+
+	return &ClassifyVariantResult{
+		VariantID:             variant.ID,
+		Classification:        assessment.Tier.String(),
+		Confidence:            assessment.Confidence.String(),
+		AppliedRules:          []ACMGAMPRuleResult{},
+		EvidenceSummary:       assessment.TierLabel + ". " + assessment.Rationale,
+		Recommendations:       somaticRecommendations(assessment),
+		ProcessingTime:        time.Since(startTime),
+		InputNotation:         hgvsNotation,
+		Normalized:            normalized,
+		VRS:                   c.vrsAllele(normalized),
+		Evidence:              evidence,
+		ClassificationContext: ContextSomatic,
+		Somatic:               assessment,
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// stubSomaticSource returns fixed somatic evidence, or fails
+type stubSomaticSource struct {
+	name     string
+	evidence []domain.SomaticEvidence
+	err      error
+}
+
+func (s *stubSomaticSource) Name() string { return s.name }
+
+func (s *stubSomaticSource) QuerySomaticEvidence(ctx context.Context, variant *domain.StandardizedVariant, tumorType string) ([]domain.SomaticEvidence, error) {
+	return s.evidence, s.err
+}
+
+func TestTierSomaticVariant(t *testing.T) {
+	dabrafenib := domain.SomaticEvidence{
+		Source: "OncoKB", SourceLevel: "LEVEL_1", Level: domain.LEVEL_A, Type: domain.SomaticTherapeutic,
+		Significance: "sensitivity", TumorType: "Melanoma", Therapies: []string{"Dabrafenib"},
+	}
+	larotrectinib := domain.SomaticEvidence{
+		Source: "OncoKB", SourceLevel: "LEVEL_1", Level: domain.LEVEL_A, Type: domain.SomaticTherapeutic, TumorType: "All Solid Tumors",
+	}
+	prognostic := domain.SomaticEvidence{Source: "CIViC", Level: domain.LEVEL_D, Type: domain.SomaticPrognostic}
+
+	tests := []struct {
+		name       string
+		items      []domain.SomaticEvidence
+		tumorType  string
+		evidence   *domain.AggregatedEvidence
+		tier       domain.SomaticTier
+		level      domain.SomaticEvidenceLevel
+		confidence domain.ConfidenceLevel
+	}{
+		{"level A in the patient's tumor type", []domain.SomaticEvidence{prognostic, dabrafenib}, "cutaneous melanoma", nil, domain.TIER_I, domain.LEVEL_A, domain.HIGH},
+		{"level A in another tumor type", []domain.SomaticEvidence{dabrafenib}, "Colorectal Cancer", nil, domain.TIER_II, domain.LEVEL_C, domain.MEDIUM},
+		{"level A without a tumor type", []domain.SomaticEvidence{dabrafenib}, "", nil, domain.TIER_II, domain.LEVEL_C, domain.MEDIUM},
+		{"tumor-agnostic evidence", []domain.SomaticEvidence{larotrectinib}, "Thyroid Cancer", nil, domain.TIER_I, domain.LEVEL_A, domain.HIGH},
+		{"level D only", []domain.SomaticEvidence{prognostic}, "Melanoma", nil, domain.TIER_II, domain.LEVEL_D, domain.LOW},
+		{
+			"common polymorphism", nil, "Melanoma",
+			&domain.AggregatedEvidence{PopulationData: &domain.PopulationData{AlleleFrequency: 0.002, PopulationFrequencies: map[string]float64{"afr": 0.08}}},
+			domain.TIER_IV, "", domain.HIGH,
+		},
+		{
+			"benign in ClinVar", nil, "Melanoma",
+			&domain.AggregatedEvidence{ClinVarData: &domain.ClinVarData{ClinicalSignificance: "Likely benign"}},
+			domain.TIER_IV, "", domain.MEDIUM,
+		},
+		{
+			"no evidence", nil, "Melanoma",
+			&domain.AggregatedEvidence{ClinVarData: &domain.ClinVarData{ClinicalSignificance: "Conflicting interpretations of pathogenicity"}},
+			domain.TIER_III, "", domain.LOW,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessment := tierSomaticVariant(tt.items, tt.tumorType, tt.evidence)
+			assert.Equal(t, tt.tier, assessment.Tier)
+			assert.Equal(t, tt.level, assessment.EvidenceLevel)
+			assert.Equal(t, tt.confidence, assessment.Confidence)
+			assert.Equal(t, tt.tier.Label(), assessment.TierLabel)
+			assert.NotEmpty(t, assessment.Rationale)
+		})
+	}
+}
+
+func TestAssessSomatic(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	service.SetSomaticEvidenceSources(
+		&stubSomaticSource{name: "OncoKB", err: fmt.Errorf("OncoKB returned status 401")},
+		&stubSomaticSource{name: "CIViC", evidence: []domain.SomaticEvidence{{
+			Source: "CIViC", SourceLevel: "B", Level: domain.LEVEL_B, Type: domain.SomaticTherapeutic,
+			Significance: "sensitivityresponse", TumorType: "Lung Non-small Cell Carcinoma", Therapies: []string{"Osimertinib"},
+		}}},
+	)
+
+	assessment := service.assessSomatic(context.Background(), &domain.StandardizedVariant{GeneSymbol: "EGFR"}, &domain.AggregatedEvidence{}, "Lung Non-small Cell Carcinoma")
+
+	require.NotNil(t, assessment)
+	assert.Equal(t, domain.TIER_I, assessment.Tier)
+	assert.Equal(t, []string{"CIViC"}, assessment.Sources)
+	assert.Contains(t, assessment.SourceErrors, "OncoKB")
+	assert.Contains(t, assessment.Rationale, "Osimertinib")
+
+	recommendations := somaticRecommendations(assessment)
+	assert.Contains(t, recommendations[len(recommendations)-1], "OncoKB could not be queried")
+}
+
+func TestParseClassificationContext(t *testing.T) {
+	for input, expected := range map[string]string{"": ContextGermline, "Germline": ContextGermline, " somatic ": ContextSomatic} {
+		parsed, err := ParseClassificationContext(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, parsed)
+	}
+
+	_, err := ParseClassificationContext("tumor")
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+// intronicOffsetPattern captures the intronic offset of a coding HGVS
+// position, e.g. c.123+45 or c.-12-3
+var intronicOffsetPattern = regexp.MustCompile(`c\.[-*]?\d+([+-])(\d+)`)
+
+// Deep intronic bounds for BP7: at or beyond +7 on the donor side and -21 on
+// the acceptor side, outside the splice consensus sequences
+const (
+	deepIntronicDonorOffset    = 7
+	deepIntronicAcceptorOffset = 21
+)
+
+// intronicOffset returns the signed intronic offset of a variant's coding
+// HGVS position, or false for an exonic position
+func intronicOffset(hgvsCoding string) (int, bool) {
+	match := intronicOffsetPattern.FindStringSubmatch(hgvsCoding)
+	if match == nil {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(match[2])
+	if err != nil {
+		return 0, false
+	}
+	if match[1] == "-" {
+		offset = -offset
+	}
+	return offset, true
+}
+
+// isIntronic reports whether a variant lies in an intron, from its annotated
+// consequence or its coding HGVS position
+func isIntronic(variant *domain.StandardizedVariant) bool {
+	if strings.Contains(strings.ToLower(variant.Consequence), "intron_variant") {
+		return true
+	}
+	_, ok := intronicOffset(variant.HGVSCoding)
+	return ok
+}
+
+// isDeepIntronic reports whether a variant lies outside the splice consensus
+// region that BP7 excludes
+func isDeepIntronic(variant *domain.StandardizedVariant) bool {
+	offset, ok := intronicOffset(variant.HGVSCoding)
+	if !ok {
+		return false
+	}
+	return offset >= deepIntronicDonorOffset || offset <= -deepIntronicAcceptorOffset
+}
+
+// isSplicingOnlyVariant reports whether splicing is the only plausible
+// mechanism: synonymous and intronic variants do not change the protein
+// sequence, so missense predictors do not apply to them
+func isSplicingOnlyVariant(variant *domain.StandardizedVariant, class ConsequenceClass) bool {
+	return class == ConsequenceSynonymous || (class != ConsequenceLossOfFunction && isIntronic(variant))
+}
+
+// splicingCalls splits splicing predictions into splice-altering and no-impact
+// calls; scores between the two cutoffs are indeterminate and fall in neither
+func splicingCalls(predictions []domain.SplicingPrediction, t thresholds.PredictorThresholds) (altering, noImpact []string) {
+	deleterious, benign := t.SpliceCutoffs()
+	for _, p := range predictions {
+		switch {
+		case p.Score >= deleterious:
+			altering = append(altering, fmt.Sprintf("%s %.2f %s", p.Tool, p.Score, p.Event))
+		case p.Score <= benign:
+			noImpact = append(noImpact, fmt.Sprintf("%s %.2f", p.Tool, p.Score))
+		}
+	}
+	return altering, noImpact
+}
+
+// variantRegion describes a variant for splicing rule reasoning
+func variantRegion(variant *domain.StandardizedVariant, class ConsequenceClass) string {
+	if class == ConsequenceSynonymous {
+		return "synonymous"
+	}
+	if isIntronic(variant) {
+		return "intronic"
+	}
+	return string(class)
+}
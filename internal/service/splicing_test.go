@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/thresholds"
+)
+
+func splicingEvidence(scores ...float64) *domain.AggregatedEvidence {
+	evidence := &domain.AggregatedEvidence{}
+	for i, score := range scores {
+		tool := "SpliceAI"
+		if i > 0 {
+			tool = "Pangolin"
+		}
+		evidence.SplicingPredictions = append(evidence.SplicingPredictions, domain.SplicingPrediction{
+			Tool: tool, Score: score, Event: domain.SpliceEventDonorLoss,
+		})
+	}
+	return evidence
+}
+
+func TestIntronicOffset(t *testing.T) {
+	for hgvs, expected := range map[string]int{
+		"NM_000492.4:c.1584+18672A>G": 18672,
+		"c.1585-1G>A":                 -1,
+		"c.-12+5G>A":                  5,
+		"c.*34-25T>C":                 -25,
+	} {
+		offset, ok := intronicOffset(hgvs)
+		assert.True(t, ok, hgvs)
+		assert.Equal(t, expected, offset, hgvs)
+	}
+
+	_, ok := intronicOffset("NM_000546.6:c.524G>A")
+	assert.False(t, ok)
+
+	assert.True(t, isDeepIntronic(&domain.StandardizedVariant{HGVSCoding: "c.100+7A>G"}))
+	assert.True(t, isDeepIntronic(&domain.StandardizedVariant{HGVSCoding: "c.101-21T>C"}))
+	assert.False(t, isDeepIntronic(&domain.StandardizedVariant{HGVSCoding: "c.100+6A>G"}))
+	assert.False(t, isDeepIntronic(&domain.StandardizedVariant{HGVSCoding: "c.101-20T>C"}))
+}
+
+func TestRuleEngine_SplicingPredictions(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	ctx := context.Background()
+	synonymous := &domain.StandardizedVariant{HGVSCoding: "c.1446G>A", HGVSProtein: "p.Ala482="}
+	deepIntronic := &domain.StandardizedVariant{HGVSCoding: "c.1584+18672A>G"}
+	nearSplice := &domain.StandardizedVariant{HGVSCoding: "c.1585-5T>C"}
+	missense := &domain.StandardizedVariant{HGVSProtein: "p.Arg175His"}
+	nonsense := &domain.StandardizedVariant{HGVSProtein: "p.Arg1443Ter"}
+
+	evaluate := func(code string, variant *domain.StandardizedVariant, evidence *domain.AggregatedEvidence) *domain.ACMGAMPRuleResult {
+		t.Helper()
+		result, err := engine.EvaluateRule(ctx, code, variant, evidence)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("splice-altering prediction applies PP3", func(t *testing.T) {
+		for _, variant := range []*domain.StandardizedVariant{synonymous, deepIntronic, nearSplice, missense} {
+			result := evaluate("PP3", variant, splicingEvidence(0.45, 0.05))
+			assert.True(t, result.Applied, variant.HGVSCoding+variant.HGVSProtein)
+			assert.Contains(t, result.Evidence, "SpliceAI 0.45 donor_loss")
+		}
+		assert.False(t, evaluate("BP4", synonymous, splicingEvidence(0.45, 0.05)).Applied)
+		assert.False(t, evaluate("BP7", synonymous, splicingEvidence(0.45, 0.05)).Applied)
+	})
+
+	t.Run("null variants leave splicing to PVS1", func(t *testing.T) {
+		assert.False(t, evaluate("PP3", nonsense, splicingEvidence(0.9)).Applied)
+	})
+
+	t.Run("no-impact predictions apply BP4 and BP7", func(t *testing.T) {
+		assert.False(t, evaluate("PP3", synonymous, splicingEvidence(0.02, 0.01)).Applied)
+		assert.True(t, evaluate("BP4", synonymous, splicingEvidence(0.02, 0.01)).Applied)
+		assert.True(t, evaluate("BP7", synonymous, splicingEvidence(0.02, 0.01)).Applied)
+		assert.True(t, evaluate("BP4", nearSplice, splicingEvidence(0.02)).Applied)
+		assert.True(t, evaluate("BP7", deepIntronic, splicingEvidence(0.02)).Applied)
+	})
+
+	t.Run("BP7 excludes the splice consensus region", func(t *testing.T) {
+		result := evaluate("BP7", nearSplice, splicingEvidence(0.02))
+		assert.False(t, result.Applied)
+		assert.Contains(t, result.Reasoning, "deep intronic")
+	})
+
+	t.Run("indeterminate scores apply neither", func(t *testing.T) {
+		evidence := splicingEvidence(0.15)
+		assert.False(t, evaluate("PP3", synonymous, evidence).Applied)
+		assert.False(t, evaluate("BP4", synonymous, evidence).Applied)
+		assert.False(t, evaluate("BP7", synonymous, evidence).Applied)
+	})
+
+	t.Run("missing predictions", func(t *testing.T) {
+		result := evaluate("BP7", synonymous, &domain.AggregatedEvidence{})
+		assert.False(t, result.Applied)
+		assert.Equal(t, "No splicing predictions available", result.Reasoning)
+		assert.Contains(t, evaluate("PP3", synonymous, &domain.AggregatedEvidence{}).Reasoning, "synonymous")
+	})
+
+	t.Run("missense predictors do not apply to synonymous variants", func(t *testing.T) {
+		evidence := &domain.AggregatedEvidence{ComputationalData: &domain.ComputationalData{CADDScore: 30, SIFTScore: 0.01, PolyPhenScore: 0.99}}
+		assert.True(t, evaluate("PP3", missense, evidence).Applied)
+		assert.False(t, evaluate("PP3", synonymous, evidence).Applied)
+	})
+}
+
+func TestRuleEngine_SpliceCutoffsFromThresholds(t *testing.T) {
+	engine := NewACMGAMPRuleEngine(logrus.New())
+	variant := &domain.StandardizedVariant{HGVSCoding: "c.1446G>A", HGVSProtein: "p.Ala482="}
+
+	result, err := engine.EvaluateRule(context.Background(), "PP3", variant, splicingEvidence(0.15))
+	require.NoError(t, err)
+	assert.False(t, result.Applied)
+
+	custom := thresholds.Defaults()
+	custom.Predictors.SpliceDeleterious = 0.1
+	custom.Predictors.SpliceBenign = 0.05
+	engine.SetThresholdSource(&stubThresholdSource{revision: &thresholds.Revision{ID: 2, Thresholds: custom}})
+
+	result, err = engine.EvaluateRule(context.Background(), "PP3", variant, splicingEvidence(0.15))
+	require.NoError(t, err)
+	assert.True(t, result.Applied)
+
+	// Revisions saved before the splice cutoffs existed use the defaults
+	legacy := thresholds.PredictorThresholds{}
+	deleterious, benign := legacy.SpliceCutoffs()
+	assert.Equal(t, thresholds.DefaultSpliceDeleterious, deleterious)
+	assert.Equal(t, thresholds.DefaultSpliceBenign, benign)
+}
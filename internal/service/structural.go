@@ -0,0 +1,158 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/metrics"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// StructuralAssessment explains why a repeat expansion or balanced
+// structural variant was not classified with the ACMG/AMP criteria
+type StructuralAssessment struct {
+	Variant     *domain.StructuralVariant `json:"variant"`
+	Gene        string                    `json:"gene,omitempty"`
+	RepeatRange string                    `json:"repeat_range,omitempty"` // Locus-specific size range of the repeat count, e.g. full penetrance
+	Reason      string                    `json:"reason"`
+}
+
+// repeatRange is a repeat size range of a locus; Max 0 is unbounded
+type repeatRange struct {
+	Min, Max int
+	Label    string
+}
+
+// repeatLocus holds the size ranges of a repeat expansion disorder locus
+type repeatLocus struct {
+	Gene   string
+	Unit   string
+	Ranges []repeatRange
+}
+
+// repeatLoci are the repeat expansion loci with established size ranges,
+// keyed by the unversioned transcript accession the repeat is described on
+var repeatLoci = map[string]repeatLocus{
+	"NM_002111": {Gene: "HTT", Unit: "CAG", Ranges: []repeatRange{
+		{1, 26, "normal"}, {27, 35, "intermediate"}, {36, 39, "reduced penetrance"}, {40, 0, "full penetrance"},
+	}},
+	"NM_002024": {Gene: "FMR1", Unit: "CGG", Ranges: []repeatRange{
+		{1, 44, "normal"}, {45, 54, "intermediate"}, {55, 200, "premutation"}, {201, 0, "full mutation"},
+	}},
+	"NM_004409": {Gene: "DMPK", Unit: "CTG", Ranges: []repeatRange{
+		{1, 34, "normal"}, {35, 49, "premutation"}, {50, 0, "full mutation"},
+	}},
+}
+
+// rangeOf returns the label of the range holding a repeat count
+func (l repeatLocus) rangeOf(count int) string {
+	for _, r := range l.Ranges {
+		if count >= r.Min && (r.Max == 0 || count <= r.Max) {
+			return r.Label
+		}
+	}
+	return ""
+}
+
+// parseStructuralVariant describes a repeat expansion or balanced structural
+// variant as a standardized variant, placed at its first breakpoint
+func parseStructuralVariant(notation string) (*domain.StandardizedVariant, error) {
+	structural, err := hgvs.ParseStructural(notation)
+	if err != nil {
+		return nil, err
+	}
+
+	variant := &domain.StandardizedVariant{
+		ID:          structural.Notation,
+		VariantType: domain.GERMLINE,
+		Structural:  structural,
+	}
+	reference := strings.SplitN(structural.Notation, ":", 2)[0]
+	if strings.Contains(structural.Notation, ":g.") {
+		variant.HGVSGenomic = structural.Notation
+	} else {
+		variant.HGVSCoding = structural.Notation
+		variant.TranscriptID = reference
+	}
+	if len(structural.Breakpoints) > 0 {
+		variant.Chromosome = structural.Breakpoints[0].Chromosome
+		variant.Position = structural.Breakpoints[0].Position
+	}
+	if locus, ok := repeatLoci[strings.SplitN(reference, ".", 2)[0]]; ok {
+		variant.GeneSymbol = locus.Gene
+	}
+	return variant, nil
+}
+
+// assessStructural explains a structural variant's exclusion from the
+// criteria and places a repeat count in its locus-specific size range
+func assessStructural(variant *domain.StandardizedVariant) *StructuralAssessment {
+	structural := variant.Structural
+	assessment := &StructuralAssessment{Variant: structural, Gene: variant.GeneSymbol}
+
+	switch structural.Kind {
+	case domain.RepeatExpansion:
+		assessment.Reason = "Repeat expansions are classified by repeat size against locus-specific ranges, not with the ACMG/AMP sequence variant criteria"
+		locus, ok := repeatLoci[strings.SplitN(variant.TranscriptID, ".", 2)[0]]
+		if !ok || locus.Unit != structural.RepeatUnit {
+			break
+		}
+		low := locus.rangeOf(structural.RepeatCount)
+		assessment.RepeatRange = low
+		if structural.RepeatCountMax > 0 {
+			if high := locus.rangeOf(structural.RepeatCountMax); high != low {
+				assessment.RepeatRange = low + " to " + high
+			}
+		}
+	default:
+		assessment.Reason = fmt.Sprintf("Balanced structural variants (%s) are outside the ACMG/AMP sequence variant criteria", structural.Kind)
+	}
+	return assessment
+}
+
+// classifyStructural returns a repeat expansion or balanced structural
+// variant unclassified for manual review, without gathering evidence or
+// evaluating criteria
+func (c *ClassifierService) classifyStructural(variant *domain.StandardizedVariant, hgvsNotation string, startTime time.Time) *ClassifyVariantResult {
+	assessment := assessStructural(variant)
+	structural := variant.Structural
+
+	recommendations := []string{"Not evaluated with automated criteria: " + assessment.Reason}
+	switch structural.Kind {
+	case domain.RepeatExpansion:
+		count := fmt.Sprint(structural.RepeatCount)
+		if structural.RepeatCountMax > 0 {
+			count = fmt.Sprintf("%d-%d", structural.RepeatCount, structural.RepeatCountMax)
+		}
+		if assessment.RepeatRange != "" {
+			recommendations = append(recommendations, fmt.Sprintf("%s %s repeat of %s units is in the %s range; report against the locus-specific repeat size ranges",
+				assessment.Gene, structural.RepeatUnit, count, assessment.RepeatRange))
+		} else {
+			recommendations = append(recommendations, "Interpret the repeat size against the locus-specific normal, intermediate and pathogenic ranges")
+		}
+		recommendations = append(recommendations, "Confirm the repeat size by repeat-primed PCR or Southern blot")
+	default:
+		recommendations = append(recommendations,
+			"Review manually for disruption of a gene or regulatory region at each breakpoint",
+			"Confirm breakpoints by karyotype, FISH or long-read sequencing; classify unbalanced rearrangements with classify_cnv")
+	}
+
+	result := &ClassifyVariantResult{
+		VariantID:             variant.ID,
+		Classification:        domain.VUS.String(),
+		Confidence:            domain.LOW.String(),
+		AppliedRules:          []ACMGAMPRuleResult{},
+		EvidenceSummary:       assessment.Reason,
+		Recommendations:       recommendations,
+		ProcessingTime:        time.Since(startTime),
+		InputNotation:         hgvsNotation,
+		ScoringMode:           string(c.scoringMode),
+		Transcript:            variant.TranscriptID,
+		ClassificationContext: ContextGermline,
+		Structural:            assessment,
+	}
+	metrics.ObserveClassification(ContextGermline, result.Classification, result.ProcessingTime)
+	return result
+}
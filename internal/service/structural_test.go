@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestClassifyVariant_Structural(t *testing.T) {
+	// No knowledge base: structural variants are returned before evidence is gathered
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+
+	tests := []struct {
+		name     string
+		notation string
+		kind     domain.StructuralKind
+		gene     string
+		rng      string
+	}{
+		{"HTT full penetrance", "NM_002111.8:c.52CAG[45]", domain.RepeatExpansion, "HTT", "full penetrance"},
+		{"HTT uncertain count", "NM_002111.8:c.52cag[37_42]", domain.RepeatExpansion, "HTT", "reduced penetrance to full penetrance"},
+		{"FMR1 premutation", "NM_002024.6:c.-129CGG[80]", domain.RepeatExpansion, "FMR1", "premutation"},
+		{"unlisted repeat locus", "NC_000004.12:g.3074877CAG[45]", domain.RepeatExpansion, "", ""},
+		{"inversion", "NC_000023.11:g.154000000_154500000inv", domain.Inversion, "", ""},
+		{"translocation", "NC_000009.12:g.130714455::NC_000022.11:g.23180365", domain.Translocation, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := classifier.ClassifyVariant(context.Background(), &ClassifyVariantParams{HGVSNotation: tt.notation})
+			require.NoError(t, err)
+
+			assert.Equal(t, domain.VUS.String(), result.Classification)
+			assert.Equal(t, domain.LOW.String(), result.Confidence)
+			assert.Empty(t, result.AppliedRules)
+			require.NotNil(t, result.Structural)
+			assert.Equal(t, tt.kind, result.Structural.Variant.Kind)
+			assert.Equal(t, tt.gene, result.Structural.Gene)
+			assert.Equal(t, tt.rng, result.Structural.RepeatRange)
+			assert.Equal(t, result.Structural.Variant.Notation, result.InputNotation)
+			assert.NotEmpty(t, result.Recommendations)
+		})
+	}
+
+	_, err := classifier.ClassifyVariant(context.Background(), &ClassifyVariantParams{HGVSNotation: "NM_002111.8:c.52CAG[0]"})
+	assert.Error(t, err)
+}
+
+func TestValidateHGVS_Structural(t *testing.T) {
+	classifier := NewClassifierService(logrus.New(), nil, nil, nil)
+
+	result, err := classifier.ValidateHGVS("NM_002111.8:c.52cag[(40_45)]")
+	require.NoError(t, err)
+	assert.True(t, result.IsValid)
+	assert.Equal(t, "NM_002111.8:c.52CAG[(40_45)]", result.NormalizedHGVS)
+	assert.Equal(t, string(domain.RepeatExpansion), result.VariantType)
+	assert.Equal(t, "HTT", result.GeneSymbol)
+	require.NotNil(t, result.Structural)
+	assert.Equal(t, 45, result.Structural.RepeatCountMax)
+
+	result, err = classifier.ValidateHGVS("NC_000009.12:g.130714455::NC_000022.11:g.23180365")
+	require.NoError(t, err)
+	assert.True(t, result.IsValid)
+	assert.Equal(t, "chr9:g.130714455", result.GenomicPosition)
+
+	result, err = classifier.ValidateHGVS("NC_000009.12:g.100::NC_000009.12:g.5000")
+	require.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.NotEmpty(t, result.ErrorMessage)
+}
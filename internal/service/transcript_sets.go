@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/transcriptset"
+)
+
+// TranscriptSetSource supplies the transcript an ordering specialty mandates for a gene
+type TranscriptSetSource interface {
+	Lookup(specialty, gene string) (string, error)
+}
+
+// SpecialtyTranscript records the transcript selected for the ordering specialty
+type SpecialtyTranscript struct {
+	Specialty  string `json:"specialty"`
+	Gene       string `json:"gene"`
+	Transcript string `json:"transcript"`             // Transcript mandated by the specialty
+	Described  string `json:"described_on,omitempty"` // Transcript the input was described on, when it differs
+	Applied    bool   `json:"applied"`                // False when the input is on another transcript and could not be moved
+}
+
+// SetTranscriptSetSource configures per-specialty transcript sets.
+// Without a source the ordering specialty is ignored.
+func (c *ClassifierService) SetTranscriptSetSource(source TranscriptSetSource) {
+	c.transcriptSets = source
+}
+
+// applySpecialtyTranscript interprets the variant on the transcript mandated
+// by the ordering specialty. A matching entry in the transcript consequences
+// becomes the variant's primary annotation; an input already on another
+// transcript is left unchanged and reported as not applied, since coding
+// positions cannot be moved between transcripts here. An explicit transcript
+// in the request takes precedence over the specialty's set.
+func (c *ClassifierService) applySpecialtyTranscript(params *ClassifyVariantParams, variant *domain.StandardizedVariant) (*SpecialtyTranscript, error) {
+	if params.OrderingSpecialty == "" || c.transcriptSets == nil {
+		return nil, nil
+	}
+	if params.PreferredIsoform != "" || params.TranscriptID != "" {
+		c.logger.WithField("specialty", params.OrderingSpecialty).Debug("Explicit transcript overrides the specialty transcript set")
+		return nil, nil
+	}
+
+	gene := variant.GeneSymbol
+	if gene == "" {
+		gene = params.GeneSymbol
+	}
+	if gene == "" {
+		return nil, nil
+	}
+
+	transcript, err := c.transcriptSets.Lookup(params.OrderingSpecialty, gene)
+	if err != nil {
+		return nil, err
+	}
+	if transcript == "" {
+		return nil, nil
+	}
+
+	selection := &SpecialtyTranscript{
+		Specialty:  transcriptset.NormalizeSpecialty(params.OrderingSpecialty),
+		Gene:       strings.ToUpper(gene),
+		Transcript: transcript,
+		Applied:    true,
+	}
+
+	for _, tc := range variant.TranscriptConsequences {
+		if transcriptset.SameTranscript(tc.TranscriptID, transcript) {
+			variant.TranscriptID = tc.TranscriptID
+			variant.HGVSCoding = tc.HGVSCoding
+			variant.HGVSProtein = tc.HGVSProtein
+			variant.Consequence = tc.Consequence
+			// The mandated transcript decides; skip multi-transcript evaluation
+			variant.TranscriptConsequences = nil
+			return selection, nil
+		}
+	}
+
+	switch {
+	case variant.TranscriptID == "":
+		variant.TranscriptID = transcript
+	case !transcriptset.SameTranscript(variant.TranscriptID, transcript):
+		selection.Described = variant.TranscriptID
+		selection.Applied = false
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"specialty":  selection.Specialty,
+		"gene":       selection.Gene,
+		"transcript": transcript,
+		"applied":    selection.Applied,
+	}).Debug("Selected specialty transcript")
+
+	return selection, nil
+}
+
+// Recommendation returns the follow-up needed when the mandated transcript was not applied, or "".
+func (s *SpecialtyTranscript) Recommendation() string {
+	if s == nil || s.Applied {
+		return ""
+	}
+	return fmt.Sprintf("The %s transcript set mandates %s for %s but the variant is described on %s; confirm nomenclature and interpretation on %s before reporting",
+		s.Specialty, s.Transcript, s.Gene, s.Described, s.Transcript)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/transcriptset"
+)
+
+func testTranscriptSets(t *testing.T) *transcriptset.Registry {
+	t.Helper()
+	registry := transcriptset.NewRegistry()
+	require.NoError(t, registry.Add(&transcriptset.Set{Specialty: "cardiology", Transcripts: map[string]string{"TTN": "NM_001267550.2"}}))
+	require.NoError(t, registry.Add(&transcriptset.Set{Specialty: "neurology", Transcripts: map[string]string{"TTN": "NM_133378.4"}}))
+	return registry
+}
+
+func TestApplySpecialtyTranscript_FromConsequences(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	service.SetTranscriptSetSource(testTranscriptSets(t))
+
+	consequences := []domain.TranscriptConsequence{
+		{TranscriptID: "NM_001267550.2", HGVSCoding: "c.100C>T", HGVSProtein: "p.Arg34Ter", Consequence: "stop_gained"},
+		{TranscriptID: "NM_133378.4", HGVSCoding: "c.100+5G>A", Consequence: "intron_variant"},
+	}
+
+	for specialty, want := range map[string]string{"cardiology": "NM_001267550.2", "Neurology": "NM_133378.4"} {
+		variant := &domain.StandardizedVariant{GeneSymbol: "TTN", TranscriptConsequences: consequences}
+		selection, err := service.applySpecialtyTranscript(&ClassifyVariantParams{OrderingSpecialty: specialty}, variant)
+
+		require.NoError(t, err)
+		require.NotNil(t, selection)
+		assert.True(t, selection.Applied)
+		assert.Equal(t, want, variant.TranscriptID)
+		assert.Nil(t, variant.TranscriptConsequences)
+		assert.Empty(t, selection.Recommendation())
+	}
+}
+
+func TestApplySpecialtyTranscript_DescribedOnOtherTranscript(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	service.SetTranscriptSetSource(testTranscriptSets(t))
+
+	variant := &domain.StandardizedVariant{GeneSymbol: "TTN", TranscriptID: "NM_001267550.2", HGVSCoding: "c.100C>T"}
+	selection, err := service.applySpecialtyTranscript(&ClassifyVariantParams{OrderingSpecialty: "neurology"}, variant)
+
+	require.NoError(t, err)
+	assert.False(t, selection.Applied)
+	assert.Equal(t, "NM_001267550.2", selection.Described)
+	assert.Equal(t, "NM_001267550.2", variant.TranscriptID)
+	assert.Contains(t, selection.Recommendation(), "mandates NM_133378.4 for TTN")
+}
+
+func TestApplySpecialtyTranscript_NotApplicable(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	variant := &domain.StandardizedVariant{GeneSymbol: "TTN"}
+
+	selection, err := service.applySpecialtyTranscript(&ClassifyVariantParams{OrderingSpecialty: "cardiology"}, variant)
+	require.NoError(t, err)
+	assert.Nil(t, selection, "no source configured")
+
+	service.SetTranscriptSetSource(testTranscriptSets(t))
+
+	selection, err = service.applySpecialtyTranscript(&ClassifyVariantParams{OrderingSpecialty: "cardiology", PreferredIsoform: "NM_133378.4"}, variant)
+	require.NoError(t, err)
+	assert.Nil(t, selection, "explicit transcript wins")
+
+	selection, err = service.applySpecialtyTranscript(&ClassifyVariantParams{OrderingSpecialty: "cardiology"}, &domain.StandardizedVariant{GeneSymbol: "BRCA1"})
+	require.NoError(t, err)
+	assert.Nil(t, selection, "gene not in the set")
+
+	_, err = service.applySpecialtyTranscript(&ClassifyVariantParams{OrderingSpecialty: "dermatology"}, variant)
+	assert.True(t, errors.Is(err, transcriptset.ErrUnknownSpecialty))
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/vrs"
+)
+
+// VRSTranslator computes GA4GH VRS alleles for normalized variants
+type VRSTranslator interface {
+	Translate(variant *domain.NormalizedVariant) (*vrs.Allele, error)
+}
+
+// SetVRSTranslator configures GA4GH VRS identifiers. Without a translator,
+// or for variants that could not be normalized, results carry no VRS allele.
+func (c *ClassifierService) SetVRSTranslator(translator VRSTranslator) {
+	c.vrs = translator
+}
+
+// vrsAllele returns the VRS allele of a normalized variant; failures are
+// logged and leave the result without one
+func (c *ClassifierService) vrsAllele(normalized *domain.NormalizedVariant) *vrs.Allele {
+	if c.vrs == nil || normalized == nil {
+		return nil
+	}
+	allele, err := c.vrs.Translate(normalized)
+	if err != nil {
+		c.logger.WithError(err).WithField("variant", normalized.HGVSGenomic).Warn("Failed to compute VRS allele")
+		return nil
+	}
+	return allele
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/vrs"
+)
+
+// stubVRSTranslator places every variant on a one-base sequence
+type stubVRSTranslator struct{}
+
+func (stubVRSTranslator) Translate(variant *domain.NormalizedVariant) (*vrs.Allele, error) {
+	if variant.Chromosome == "" {
+		return nil, fmt.Errorf("variant has no genomic position")
+	}
+	return vrs.NewAllele("SQ.aKF498dAxcJAqme6QYQ7EZ07-fiw8Kw2", variant.Start-1, variant.End, vrs.LiteralSequence(variant.Alternative))
+}
+
+func TestVRSAllele(t *testing.T) {
+	service := NewClassifierService(logrus.New(), nil, nil, nil)
+	normalized := &domain.NormalizedVariant{HGVSGenomic: "NC_000017.11:g.7674220C>T", Chromosome: "17", Start: 7674220, End: 7674220, Reference: "C", Alternative: "T"}
+	assert.Nil(t, service.vrsAllele(normalized), "no translator configured")
+
+	service.SetVRSTranslator(stubVRSTranslator{})
+
+	// Act
+	allele := service.vrsAllele(normalized)
+	unplaced := service.vrsAllele(&domain.NormalizedVariant{})
+	unnormalized := service.vrsAllele(nil)
+
+	// Assert
+	require.NotNil(t, allele)
+	assert.Equal(t, int64(7674219), allele.Location.Start)
+	assert.Contains(t, allele.ID, "ga4gh:VA.")
+	assert.Nil(t, unplaced)
+	assert.Nil(t, unnormalized)
+}
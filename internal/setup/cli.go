@@ -2,6 +2,7 @@ package setup
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -37,6 +38,12 @@ func (c *CLI) Run(args []string) error {
 		return c.validate()
 	case "wizard":
 		return c.runWizard()
+	case "dbnsfp":
+		return c.setupDbNSFP(args[1:])
+	case "clinvar":
+		return c.setupClinVar(args[1:])
+	case "gnomad":
+		return c.setupGnomAD(args[1:])
 	case "help", "--help", "-h":
 		return c.showHelp()
 	default:
@@ -58,6 +65,9 @@ Commands:
   claude-desktop  Configure Claude Desktop integration
   status          Show current setup status
   validate        Validate current configuration
+  dbnsfp          Import dbNSFP in silico scores (REVEL, CADD, AlphaMissense)
+  clinvar         Download and import a ClinVar release for offline use
+  gnomad          Import gnomAD allele frequencies and coverage for offline use
 
 Examples:
   # Run interactive setup wizard
@@ -74,6 +84,18 @@ Examples:
 
   # Validate configuration
   mcp-server-lite setup validate
+
+  # Import downloaded dbNSFP files, keeping only panel genes
+  mcp-server-lite setup dbnsfp --source dbNSFP4.9a_variant.chr17.gz --genes BRCA1,TP53
+
+  # Download this month's ClinVar release and use it instead of NCBI E-utilities
+  mcp-server-lite setup clinvar --refresh
+
+  # Import a release copied into an air-gapped network
+  mcp-server-lite setup clinvar --source /media/transfer/variant_summary.txt.gz
+
+  # Import gnomAD v4.1 frequencies for the BRCA1 locus
+  mcp-server-lite setup gnomad --chrom 17 --region 17:43044295-43170245
 `
 	fmt.Println(help)
 	return nil
@@ -150,6 +172,218 @@ func (c *CLI) setupClaudeDesktop(args []string) error {
 	return nil
 }
 
+// setupDbNSFP downloads and imports dbNSFP score files.
+func (c *CLI) setupDbNSFP(args []string) error {
+	var opts DbNSFPOptions
+
+	// Parse arguments
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--source", "-s":
+			if i+1 < len(args) {
+				opts.Sources = append(opts.Sources, args[i+1])
+				i++
+			}
+		case "--data-dir", "-d":
+			if i+1 < len(args) {
+				opts.DataDir = args[i+1]
+				i++
+			}
+		case "--output", "-o":
+			if i+1 < len(args) {
+				opts.Output = args[i+1]
+				i++
+			}
+		case "--genes", "-g":
+			if i+1 < len(args) {
+				for _, gene := range strings.Split(args[i+1], ",") {
+					if gene = strings.TrimSpace(gene); gene != "" {
+						opts.Genes = append(opts.Genes, gene)
+					}
+				}
+				i++
+			}
+		case "--version", "-v":
+			if i+1 < len(args) {
+				opts.Version = args[i+1]
+				i++
+			}
+		default:
+			// Bare arguments are sources
+			opts.Sources = append(opts.Sources, args[i])
+		}
+	}
+
+	if len(opts.Sources) == 0 {
+		fmt.Println("Usage: mcp-server-lite setup dbnsfp --source <file or URL> [--source ...] [--genes GENE,...] [--version BUILD] [--data-dir DIR] [--output FILE]")
+		fmt.Println()
+		fmt.Println("Sources are dbNSFP variant files (e.g. dbNSFP4.9a_variant.chr17.gz), downloaded")
+		fmt.Println("first when given as URLs. dbNSFP is distributed by its authors under its own")
+		fmt.Println("license terms; obtain the variant files from the dbNSFP project.")
+		fmt.Println("The build (e.g. 4.9a) is read from the file names unless given with --version.")
+		return nil
+	}
+
+	fmt.Println("dbNSFP Score Setup")
+	fmt.Println("==================")
+	result, err := SetupDbNSFP(context.Background(), opts, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("failed to set up dbNSFP: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("✓ Imported %d variants into %s\n", result.Variants, result.Database)
+	fmt.Println()
+	if opts.Output != "" || opts.DataDir != "" {
+		fmt.Printf("Set ACMG_DBNSFP_FILE=%s so the lite server uses it.\n", result.Database)
+	} else {
+		fmt.Println("The lite server uses it on its next start.")
+	}
+	fmt.Println()
+	return nil
+}
+
+// setupClinVar downloads and imports a ClinVar release.
+func (c *CLI) setupClinVar(args []string) error {
+	var opts ClinVarOptions
+
+	// Parse arguments
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--source", "-s":
+			if i+1 < len(args) {
+				opts.Sources = append(opts.Sources, args[i+1])
+				i++
+			}
+		case "--data-dir", "-d":
+			if i+1 < len(args) {
+				opts.DataDir = args[i+1]
+				i++
+			}
+		case "--output", "-o":
+			if i+1 < len(args) {
+				opts.Output = args[i+1]
+				i++
+			}
+		case "--assembly", "-a":
+			if i+1 < len(args) {
+				opts.Assembly = args[i+1]
+				i++
+			}
+		case "--release":
+			if i+1 < len(args) {
+				opts.Release = args[i+1]
+				i++
+			}
+		case "--refresh", "-r":
+			opts.Refresh = true
+		case "--help", "-h":
+			fmt.Println("Usage: mcp-server-lite setup clinvar [--source <file or URL> ...] [--assembly GRCh38|GRCh37] [--release YYYY-MM-DD] [--refresh] [--data-dir DIR] [--output FILE]")
+			fmt.Println()
+			fmt.Println("Sources are ClinVar variant_summary.txt or clinvar.vcf files, plain or gzipped,")
+			fmt.Println("downloaded first when given as URLs. Without a source the current release is")
+			fmt.Println("downloaded from " + DefaultClinVarSource + ".")
+			fmt.Println("ClinVar is updated monthly; run again with --refresh to import the latest release.")
+			fmt.Println("The release date is read from a VCF's header; give it with --release for")
+			fmt.Println("variant_summary files, or the import date identifies the release.")
+			return nil
+		default:
+			// Bare arguments are sources
+			opts.Sources = append(opts.Sources, args[i])
+		}
+	}
+
+	fmt.Println("ClinVar Release Setup")
+	fmt.Println("=====================")
+	result, err := SetupClinVar(context.Background(), opts, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("failed to set up ClinVar: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("✓ Imported %d variants into %s\n", result.Variants, result.Database)
+	fmt.Println()
+	if opts.Output != "" || opts.DataDir != "" {
+		fmt.Printf("Set ACMG_CLINVAR_DB=%s so the lite server uses it.\n", result.Database)
+	} else {
+		fmt.Println("The lite server reads ClinVar from it, without calling NCBI, on its next start.")
+	}
+	fmt.Println()
+	return nil
+}
+
+// setupGnomAD downloads and imports gnomAD sites VCFs and coverage summaries.
+func (c *CLI) setupGnomAD(args []string) error {
+	var opts GnomADOptions
+
+	// Parse arguments
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--source", "-s":
+			if i+1 < len(args) {
+				opts.Sources = append(opts.Sources, args[i+1])
+				i++
+			}
+		case "--chrom", "-c":
+			if i+1 < len(args) {
+				opts.Chromosomes = append(opts.Chromosomes, strings.Split(args[i+1], ",")...)
+				i++
+			}
+		case "--region", "-r":
+			if i+1 < len(args) {
+				opts.Regions = append(opts.Regions, args[i+1])
+				i++
+			}
+		case "--dataset":
+			if i+1 < len(args) {
+				opts.Dataset = args[i+1]
+				i++
+			}
+		case "--data-dir", "-d":
+			if i+1 < len(args) {
+				opts.DataDir = args[i+1]
+				i++
+			}
+		case "--output", "-o":
+			if i+1 < len(args) {
+				opts.Output = args[i+1]
+				i++
+			}
+		default:
+			// Bare arguments are sources
+			opts.Sources = append(opts.Sources, args[i])
+		}
+	}
+
+	if len(opts.Sources) == 0 && len(opts.Chromosomes) == 0 {
+		fmt.Println("Usage: mcp-server-lite setup gnomad [--chrom N,...] [--source <file or URL> ...] [--region CHR:START-END ...] [--dataset gnomad_r4|gnomad_r3] [--data-dir DIR] [--output FILE]")
+		fmt.Println()
+		fmt.Println("Sources are gnomAD sites VCFs (joint, exome or genome) and coverage summaries,")
+		fmt.Println("plain or bgzipped, downloaded first when given as URLs; --chrom downloads the")
+		fmt.Println("gnomAD v4.1 joint sites VCF of each chromosome. --region keeps only the sites")
+		fmt.Println("and positions in a region, such as a gene panel, to save space.")
+		return nil
+	}
+
+	fmt.Println("gnomAD Frequency Setup")
+	fmt.Println("======================")
+	result, err := SetupGnomAD(context.Background(), opts, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("failed to set up gnomAD: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("✓ Imported %d variants and %d covered positions into %s\n", result.Variants, result.Positions, result.Database)
+	fmt.Println()
+	if opts.Output != "" || opts.DataDir != "" {
+		fmt.Printf("Set ACMG_GNOMAD_FILE=%s so the lite server uses it.\n", result.Database)
+	} else {
+		fmt.Println("The lite server reads gnomAD frequencies from it, without calling the gnomAD API, on its next start.")
+	}
+	fmt.Println()
+	return nil
+}
+
 // showStatus displays the current setup status.
 func (c *CLI) showStatus() error {
 	status, err := GetStatus(c.ServerType)
@@ -0,0 +1,79 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/acmg-amp-mcp-server/pkg/external"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// DefaultClinVarSource is the ClinVar tab-delimited release, updated monthly
+const DefaultClinVarSource = "https://ftp.ncbi.nlm.nih.gov/pub/clinvar/tab_delimited/variant_summary.txt.gz"
+
+// ClinVarOptions contains options for setting up a local ClinVar release.
+type ClinVarOptions struct {
+	Sources  []string // variant_summary.txt or clinvar.vcf files: local paths or http(s) URLs; DefaultClinVarSource if empty
+	DataDir  string   // Data directory; downloads go to <DataDir>/downloads
+	Output   string   // SQLite database to build; defaults to <DataDir>/clinvar.db
+	Assembly string   // Assembly whose coordinates are imported; GRCh38 if empty
+	Release  string   // Release date, e.g. 2024-05-01; read from a VCF's header when empty
+	Refresh  bool     // Download URLs again rather than reusing an earlier download
+}
+
+// ClinVarResult describes a completed ClinVar setup.
+type ClinVarResult struct {
+	Database string   // SQLite database the release was imported into
+	Files    []string // Local files imported
+	Variants int      // Variants imported
+}
+
+// SetupClinVar downloads a ClinVar release when given as URLs and imports
+// it into an indexed SQLite database the lite server reads in place of
+// NCBI E-utilities, for deployments without outbound network access. The
+// database is rebuilt on each run, so running it again with Refresh picks
+// up the next monthly release.
+func SetupClinVar(ctx context.Context, opts ClinVarOptions, progress io.Writer) (*ClinVarResult, error) {
+	if len(opts.Sources) == 0 {
+		opts.Sources = []string{DefaultClinVarSource}
+	}
+	if opts.DataDir == "" {
+		opts.DataDir = GetDefaultDataDir()
+	}
+	if opts.Output == "" {
+		opts.Output = filepath.Join(opts.DataDir, "clinvar.db")
+	}
+	if opts.Assembly != "" {
+		assembly, err := hgvs.ParseAssembly(opts.Assembly)
+		if err != nil {
+			return nil, err
+		}
+		opts.Assembly = assembly
+	}
+
+	result := &ClinVarResult{Database: opts.Output}
+	for _, source := range opts.Sources {
+		file := source
+		if isURL(source) {
+			var err error
+			if file, err = download(ctx, source, filepath.Join(opts.DataDir, "downloads"), opts.Refresh, progress); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := os.Stat(file); err != nil {
+			return nil, fmt.Errorf("ClinVar source: %w", err)
+		}
+		result.Files = append(result.Files, file)
+	}
+
+	fmt.Fprintf(progress, "Importing %d file(s) into %s...\n", len(result.Files), opts.Output)
+	n, err := external.ImportClinVar(ctx, opts.Output, result.Files, external.ClinVarImportOptions{Assembly: opts.Assembly, Release: opts.Release})
+	if err != nil {
+		return nil, err
+	}
+	result.Variants = n
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// DbNSFPOptions contains options for setting up local dbNSFP scores.
+type DbNSFPOptions struct {
+	Sources []string // dbNSFP variant files: local paths or http(s) URLs
+	DataDir string   // Data directory; downloads go to <DataDir>/downloads
+	Output  string   // SQLite database to import into; defaults to <DataDir>/dbnsfp.db
+	Genes   []string // Import only these genes; empty imports every variant
+	Version string   // dbNSFP build, e.g. 4.9a; read from the file names when empty
+}
+
+// DbNSFPResult describes a completed dbNSFP setup.
+type DbNSFPResult struct {
+	Database string   // SQLite database the scores were imported into
+	Files    []string // Local files imported
+	Variants int      // Variants imported
+}
+
+// SetupDbNSFP downloads dbNSFP variant files that are given as URLs and
+// imports their REVEL, CADD, AlphaMissense, BayesDel, SIFT, PolyPhen and
+// conservation scores into an indexed SQLite database the lite server reads.
+func SetupDbNSFP(ctx context.Context, opts DbNSFPOptions, progress io.Writer) (*DbNSFPResult, error) {
+	if len(opts.Sources) == 0 {
+		return nil, fmt.Errorf("at least one dbNSFP source file or URL is required")
+	}
+	if opts.DataDir == "" {
+		opts.DataDir = GetDefaultDataDir()
+	}
+	if opts.Output == "" {
+		opts.Output = filepath.Join(opts.DataDir, "dbnsfp.db")
+	}
+
+	result := &DbNSFPResult{Database: opts.Output}
+	for _, source := range opts.Sources {
+		file := source
+		if isURL(source) {
+			var err error
+			if file, err = download(ctx, source, filepath.Join(opts.DataDir, "downloads"), false, progress); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := os.Stat(file); err != nil {
+			return nil, fmt.Errorf("dbNSFP source: %w", err)
+		}
+		result.Files = append(result.Files, file)
+	}
+
+	for _, file := range result.Files {
+		fmt.Fprintf(progress, "Importing %s...\n", file)
+		n, err := external.ImportDbNSFP(ctx, opts.Output, []string{file}, external.DbNSFPImportOptions{Genes: opts.Genes, Version: opts.Version})
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(progress, "  %d variants\n", n)
+		result.Variants += n
+	}
+	return result, nil
+}
+
+// isURL reports whether a source is an http(s) URL rather than a local path
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// download fetches a URL into dir, reusing a completed earlier download
+// unless refresh is set
+func download(ctx context.Context, source, dir string, refresh bool, progress io.Writer) (string, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("invalid download URL: %w", err)
+	}
+	name := path.Base(u.Path)
+	if name == "" || name == "/" || name == "." {
+		return "", fmt.Errorf("download URL has no file name: %s", source)
+	}
+	target := filepath.Join(dir, name)
+	if _, err := os.Stat(target); err == nil && !refresh {
+		fmt.Fprintf(progress, "Using downloaded %s\n", target)
+		return target, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create download directory: %w", err)
+	}
+
+	fmt.Fprintf(progress, "Downloading %s...\n", source)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", source, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download %s: HTTP %d", source, resp.StatusCode)
+	}
+
+	// Download to a temporary name so an interrupted download is not reused
+	partial := target + ".partial"
+	out, err := os.Create(partial)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		os.Remove(partial)
+		return "", fmt.Errorf("failed to download %s: %w", source, err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(partial)
+		return "", err
+	}
+	if err := os.Rename(partial, target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
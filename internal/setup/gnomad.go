@@ -0,0 +1,92 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/pkg/external"
+)
+
+// GnomADJointSitesURL is the gnomAD v4.1 joint exome and genome sites VCF
+// of a chromosome
+const GnomADJointSitesURL = "https://storage.googleapis.com/gcp-public-data--gnomad/release/4.1/vcf/joint/gnomad.joint.v4.1.sites.chr%s.vcf.bgz"
+
+// GnomADOptions contains options for setting up local gnomAD frequencies.
+type GnomADOptions struct {
+	Sources     []string // Sites VCFs or coverage summaries: local paths or http(s) URLs
+	Chromosomes []string // Chromosomes whose v4.1 joint sites VCFs are downloaded
+	Regions     []string // Import only these regions, e.g. 17:43044295-43170245; empty imports every site
+	DataDir     string   // Data directory; downloads go to <DataDir>/downloads
+	Output      string   // SQLite database to import into; defaults to <DataDir>/gnomad.db
+	Dataset     string   // gnomAD dataset of the files; gnomad_r4 if empty
+}
+
+// GnomADResult describes a completed gnomAD setup.
+type GnomADResult struct {
+	Database  string   // SQLite database the frequencies were imported into
+	Files     []string // Local files imported
+	Variants  int      // Sites imported
+	Positions int      // Coverage positions imported
+}
+
+// SetupGnomAD downloads gnomAD sites VCFs and coverage summaries that are
+// given as URLs or chromosomes and imports their allele counts, filtering
+// allele frequencies and mean coverage into an indexed SQLite database the
+// lite server reads in place of the gnomAD API.
+func SetupGnomAD(ctx context.Context, opts GnomADOptions, progress io.Writer) (*GnomADResult, error) {
+	for _, chrom := range opts.Chromosomes {
+		opts.Sources = append(opts.Sources, fmt.Sprintf(GnomADJointSitesURL, strings.TrimPrefix(chrom, "chr")))
+	}
+	if len(opts.Sources) == 0 {
+		return nil, fmt.Errorf("at least one gnomAD source file, URL or chromosome is required")
+	}
+	if opts.DataDir == "" {
+		opts.DataDir = GetDefaultDataDir()
+	}
+	if opts.Output == "" {
+		opts.Output = filepath.Join(opts.DataDir, "gnomad.db")
+	}
+	var regions []external.GenomicRegion
+	for _, region := range opts.Regions {
+		parsed, err := external.ParseGenomicRegion(region)
+		if err != nil {
+			return nil, err
+		}
+		regions = append(regions, parsed)
+	}
+
+	result := &GnomADResult{Database: opts.Output}
+	for _, source := range opts.Sources {
+		file := source
+		if isURL(source) {
+			var err error
+			if file, err = download(ctx, source, filepath.Join(opts.DataDir, "downloads"), false, progress); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := os.Stat(file); err != nil {
+			return nil, fmt.Errorf("gnomAD source: %w", err)
+		}
+		result.Files = append(result.Files, file)
+	}
+
+	for _, file := range result.Files {
+		fmt.Fprintf(progress, "Importing %s...\n", file)
+		imported, err := external.ImportGnomAD(ctx, opts.Output, []string{file}, external.GnomADImportOptions{Dataset: opts.Dataset, Regions: regions})
+		if err != nil {
+			return nil, err
+		}
+		if imported.Positions > 0 {
+			fmt.Fprintf(progress, "  %d covered positions\n", imported.Positions)
+		} else {
+			fmt.Fprintf(progress, "  %d variants\n", imported.Variants)
+		}
+		result.Variants += imported.Variants
+		result.Positions += imported.Positions
+	}
+	return result, nil
+}
@@ -0,0 +1,136 @@
+// Package shutdown drains in-flight requests when the server stops. Once a
+// drain begins, new requests are refused; requests already running get until
+// the drain deadline to finish and are cancelled after it, so a restart does
+// not cut a classification off halfway without warning the caller.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// ErrShuttingDown is returned for requests that arrive during a drain.
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Report counts what happened to the requests in flight when a drain began.
+type Report struct {
+	Drained          int `json:"drained"`           // Finished before the deadline
+	Aborted          int `json:"aborted"`           // Cancelled at the deadline
+	CheckpointedJobs int `json:"checkpointed_jobs"` // Background jobs saved to resume on restart
+}
+
+// Coordinator tracks in-flight requests so a drain can wait for them.
+type Coordinator struct {
+	mu       sync.Mutex
+	draining bool
+	drained  int
+	next     uint64
+	inFlight map[uint64]context.CancelFunc
+	idle     chan struct{} // Closed when a drain has nothing left in flight
+}
+
+// NewCoordinator creates a coordinator accepting requests.
+func NewCoordinator() *Coordinator {
+	return &Coordinator{inFlight: make(map[uint64]context.CancelFunc)}
+}
+
+// Begin registers a request. The returned context is cancelled if the
+// request is still running when a drain's deadline passes; call done when
+// the request finishes. Begin fails with ErrShuttingDown during a drain.
+func (c *Coordinator) Begin(ctx context.Context) (context.Context, func(), error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.draining {
+		return ctx, func() {}, ErrShuttingDown
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	id := c.next
+	c.next++
+	c.inFlight[id] = cancel
+
+	var once sync.Once
+	return ctx, func() { once.Do(func() { c.finish(id) }) }, nil
+}
+
+// finish removes a finished request, counting it as drained during a drain
+func (c *Coordinator) finish(id uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cancel, ok := c.inFlight[id]
+	if !ok {
+		return // Aborted
+	}
+	cancel()
+	delete(c.inFlight, id)
+	if c.draining {
+		c.drained++
+		if len(c.inFlight) == 0 {
+			close(c.idle)
+		}
+	}
+}
+
+// Draining reports whether a drain has begun.
+func (c *Coordinator) Draining() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.draining
+}
+
+// InFlight returns the number of requests running.
+func (c *Coordinator) InFlight() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.inFlight)
+}
+
+// Drain refuses new requests and waits for those in flight until ctx is
+// done, then cancels the ones still running. Requests are refused from then
+// on; a second drain reports nothing.
+func (c *Coordinator) Drain(ctx context.Context) Report {
+	c.mu.Lock()
+	if c.draining {
+		c.mu.Unlock()
+		return Report{}
+	}
+	c.draining = true
+	c.idle = make(chan struct{})
+	if len(c.inFlight) == 0 {
+		close(c.idle)
+	}
+	idle := c.idle
+	c.mu.Unlock()
+
+	select {
+	case <-idle:
+	case <-ctx.Done():
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	report := Report{Drained: c.drained}
+	for id, cancel := range c.inFlight {
+		cancel()
+		delete(c.inFlight, id)
+		report.Aborted++
+	}
+	return report
+}
+
+// Handler tracks HTTP requests to next, answering 503 with Retry-After
+// during a drain.
+func (c *Coordinator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, done, err := c.Begin(r.Context())
+		if err != nil {
+			w.Header().Set("Retry-After", "30")
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer done()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,90 @@
+package shutdown
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoordinator_DrainWaitsForInFlight(t *testing.T) {
+	c := NewCoordinator()
+
+	_, done, err := c.Begin(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.InFlight())
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		done()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	report := c.Drain(ctx)
+	assert.Equal(t, Report{Drained: 1}, report)
+	assert.True(t, c.Draining())
+
+	// New requests are refused once draining
+	_, _, err = c.Begin(context.Background())
+	assert.ErrorIs(t, err, ErrShuttingDown)
+
+	// A second drain reports nothing
+	assert.Equal(t, Report{}, c.Drain(ctx))
+}
+
+func TestCoordinator_DrainAbortsAtDeadline(t *testing.T) {
+	c := NewCoordinator()
+
+	fast, fastDone, err := c.Begin(context.Background())
+	require.NoError(t, err)
+	slow, slowDone, err := c.Begin(context.Background())
+	require.NoError(t, err)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		fastDone()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	report := c.Drain(ctx)
+	assert.Equal(t, Report{Drained: 1, Aborted: 1}, report)
+
+	assert.ErrorIs(t, slow.Err(), context.Canceled)
+	assert.ErrorIs(t, fast.Err(), context.Canceled) // Released when done
+	slowDone()
+	assert.Equal(t, 0, c.InFlight())
+}
+
+func TestCoordinator_DrainWithNothingInFlight(t *testing.T) {
+	c := NewCoordinator()
+	_, done, err := c.Begin(context.Background())
+	require.NoError(t, err)
+	done()
+	done() // Idempotent
+
+	assert.Equal(t, Report{}, c.Drain(context.Background()))
+}
+
+func TestCoordinator_Handler(t *testing.T) {
+	c := NewCoordinator()
+	handler := c.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, 1, c.InFlight())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, 0, c.InFlight())
+
+	c.Drain(context.Background())
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+}
@@ -0,0 +1,102 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// FileArchive implements the Archive interface with zstd-compressed files.
+type FileArchive struct {
+	dir     string
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// NewFileArchive creates a file archive rooted at dir.
+func NewFileArchive(dir string) (*FileArchive, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		encoder.Close()
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+
+	return &FileArchive{
+		dir:     dir,
+		encoder: encoder,
+		decoder: decoder,
+	}, nil
+}
+
+// Put compresses and stores a payload under the given key.
+func (a *FileArchive) Put(ctx context.Context, key string, payload []byte) error {
+	path, err := a.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	// Write to a temp file first so a crash never leaves a partial blob
+	compressed := a.encoder.EncodeAll(payload, nil)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, compressed, 0644); err != nil {
+		return fmt.Errorf("failed to write archive blob: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to finalize archive blob: %w", err)
+	}
+
+	return nil
+}
+
+// Get retrieves and decompresses the payload stored under the given key.
+func (a *FileArchive) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := a.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive blob: %w", err)
+	}
+
+	payload, err := a.decoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress archive blob: %w", err)
+	}
+
+	return payload, nil
+}
+
+// Close releases the encoder and decoder resources.
+func (a *FileArchive) Close() error {
+	a.decoder.Close()
+	return a.encoder.Close()
+}
+
+// path resolves a key to a file path inside the archive directory.
+func (a *FileArchive) path(key string) (string, error) {
+	cleaned := filepath.Clean(key)
+	if key == "" || filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid archive key: %q", key)
+	}
+	return filepath.Join(a.dir, cleaned), nil
+}
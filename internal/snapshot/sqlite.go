@@ -0,0 +1,291 @@
+package snapshot
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements the Store interface using SQLite for recent
+// snapshots and an Archive for older ones.
+type SQLiteStore struct {
+	db      *sql.DB
+	dbPath  string
+	archive Archive
+}
+
+// NewSQLiteStore creates a new SQLite snapshot store backed by the given archive.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string, archive Archive) (*SQLiteStore, error) {
+	if archive == nil {
+		return nil, fmt.Errorf("archive is required")
+	}
+
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:      db,
+		dbPath:  dbPath,
+		archive: archive,
+	}, nil
+}
+
+// createSchema creates the database tables and indexes.
+// captured_at is stored as Unix seconds so cutoff comparisons are exact.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS evidence_snapshots (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		normalized_hgvs TEXT NOT NULL,
+		payload BLOB,
+		captured_at INTEGER NOT NULL,
+		archive_key TEXT NOT NULL DEFAULT '',
+		kind TEXT NOT NULL DEFAULT 'evidence'
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_snapshots_hgvs ON evidence_snapshots(normalized_hgvs);
+	CREATE INDEX IF NOT EXISTS idx_snapshots_captured_at ON evidence_snapshots(captured_at);
+	`
+
+	if _, err := db.Exec(schema); err != nil {
+		return err
+	}
+	return addKindColumn(db)
+}
+
+// addKindColumn upgrades a table created before snapshots had kinds; existing
+// rows are evidence snapshots
+func addKindColumn(db *sql.DB) error {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info('evidence_snapshots')`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == "kind" {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = db.Exec(`ALTER TABLE evidence_snapshots ADD COLUMN kind TEXT NOT NULL DEFAULT 'evidence'`)
+	return err
+}
+
+// Save stores a new evidence snapshot.
+func (s *SQLiteStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	if snapshot.NormalizedHGVS == "" {
+		return fmt.Errorf("normalized_hgvs is required")
+	}
+	if snapshot.CapturedAt.IsZero() {
+		snapshot.CapturedAt = time.Now()
+	}
+	if snapshot.Kind == "" {
+		snapshot.Kind = KindEvidence
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO evidence_snapshots (normalized_hgvs, payload, captured_at, kind)
+		VALUES (?, ?, ?, ?)
+	`,
+		snapshot.NormalizedHGVS,
+		[]byte(snapshot.Payload),
+		snapshot.CapturedAt.Unix(),
+		snapshot.Kind,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get insert ID: %w", err)
+	}
+	snapshot.ID = id
+
+	return nil
+}
+
+// Get retrieves a snapshot by ID, reading archived payloads transparently.
+func (s *SQLiteStore) Get(ctx context.Context, id int64) (*Snapshot, error) {
+	var (
+		snap       Snapshot
+		payload    []byte
+		capturedAt int64
+	)
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, normalized_hgvs, payload, captured_at, archive_key, kind
+		FROM evidence_snapshots
+		WHERE id = ?
+	`, id).Scan(&snap.ID, &snap.NormalizedHGVS, &payload, &capturedAt, &snap.ArchiveKey, &snap.Kind)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan: %w", err)
+	}
+
+	snap.CapturedAt = time.Unix(capturedAt, 0).UTC()
+	snap.Archived = snap.ArchiveKey != ""
+
+	if snap.Archived {
+		payload, err = s.archive.Get(ctx, snap.ArchiveKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve archived snapshot %d: %w", id, err)
+		}
+	}
+	snap.Payload = payload
+
+	return &snap, nil
+}
+
+// ListByVariant returns evidence snapshot metadata for a variant, newest first.
+func (s *SQLiteStore) ListByVariant(ctx context.Context, normalizedHGVS string) ([]*Snapshot, error) {
+	return s.ListByVariantKind(ctx, normalizedHGVS, KindEvidence)
+}
+
+// ListByVariantKind returns metadata for a variant's snapshots of one kind, newest first.
+func (s *SQLiteStore) ListByVariantKind(ctx context.Context, normalizedHGVS, kind string) ([]*Snapshot, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, normalized_hgvs, captured_at, archive_key, kind
+		FROM evidence_snapshots
+		WHERE normalized_hgvs = ? AND kind = ?
+		ORDER BY captured_at DESC, id DESC
+	`, normalizedHGVS, kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*Snapshot
+	for rows.Next() {
+		snap := &Snapshot{}
+		var capturedAt int64
+		if err := rows.Scan(&snap.ID, &snap.NormalizedHGVS, &capturedAt, &snap.ArchiveKey, &snap.Kind); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		snap.CapturedAt = time.Unix(capturedAt, 0).UTC()
+		snap.Archived = snap.ArchiveKey != ""
+		result = append(result, snap)
+	}
+	return result, rows.Err()
+}
+
+// ArchiveOlderThan moves payloads captured before the cutoff to the archive tier.
+// Each payload is written to the archive before its row is cleared, so an
+// interrupted run leaves remaining snapshots in the hot store.
+func (s *SQLiteStore) ArchiveOlderThan(ctx context.Context, cutoff time.Time) (int, error) {
+	type candidate struct {
+		id         int64
+		capturedAt int64
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, captured_at
+		FROM evidence_snapshots
+		WHERE archive_key = '' AND captured_at < ?
+		ORDER BY id
+	`, cutoff.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("failed to query archive candidates: %w", err)
+	}
+
+	var candidates []candidate
+	for rows.Next() {
+		var c candidate
+		if err := rows.Scan(&c.id, &c.capturedAt); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	archived := 0
+	for _, c := range candidates {
+		if err := ctx.Err(); err != nil {
+			return archived, err
+		}
+
+		var payload []byte
+		if err := s.db.QueryRowContext(ctx,
+			"SELECT payload FROM evidence_snapshots WHERE id = ?", c.id,
+		).Scan(&payload); err != nil {
+			return archived, fmt.Errorf("failed to load snapshot %d: %w", c.id, err)
+		}
+
+		key := archiveKey(c.id, time.Unix(c.capturedAt, 0).UTC())
+		if err := s.archive.Put(ctx, key, payload); err != nil {
+			return archived, fmt.Errorf("failed to archive snapshot %d: %w", c.id, err)
+		}
+
+		if _, err := s.db.ExecContext(ctx,
+			"UPDATE evidence_snapshots SET payload = NULL, archive_key = ? WHERE id = ?",
+			key, c.id,
+		); err != nil {
+			return archived, fmt.Errorf("failed to mark snapshot %d archived: %w", c.id, err)
+		}
+		archived++
+	}
+
+	// Reclaim the space freed by archived payloads
+	if archived > 0 {
+		if _, err := s.db.ExecContext(ctx, "VACUUM"); err != nil {
+			return archived, fmt.Errorf("failed to vacuum database: %w", err)
+		}
+	}
+
+	return archived, nil
+}
+
+// Close closes the store and releases resources, including the archive if closable.
+func (s *SQLiteStore) Close() error {
+	if closer, ok := s.archive.(io.Closer); ok {
+		closer.Close()
+	}
+	return s.db.Close()
+}
+
+// archiveKey returns the archive location for a snapshot, grouped by capture month.
+func archiveKey(id int64, capturedAt time.Time) string {
+	return filepath.Join(capturedAt.Format("2006-01"), fmt.Sprintf("%d.json.zst", id))
+}
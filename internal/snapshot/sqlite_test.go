@@ -0,0 +1,142 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) (*SQLiteStore, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	archive, err := NewFileArchive(filepath.Join(tmpDir, "archive"))
+	require.NoError(t, err)
+
+	store, err := NewSQLiteStore(filepath.Join(tmpDir, "evidence.db"), archive)
+	require.NoError(t, err)
+	return store, tmpDir
+}
+
+func TestSQLiteStore_SaveAndGet(t *testing.T) {
+	store, _ := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	snap := &Snapshot{
+		NormalizedHGVS: "NM_007294.4:c.5266dup",
+		Payload:        json.RawMessage(`{"clinvar":"Pathogenic"}`),
+	}
+
+	require.NoError(t, store.Save(ctx, snap))
+	assert.NotZero(t, snap.ID)
+	assert.False(t, snap.CapturedAt.IsZero())
+
+	got, err := store.Get(ctx, snap.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, snap.NormalizedHGVS, got.NormalizedHGVS)
+	assert.JSONEq(t, `{"clinvar":"Pathogenic"}`, string(got.Payload))
+	assert.False(t, got.Archived)
+}
+
+func TestSQLiteStore_GetNotFound(t *testing.T) {
+	store, _ := createTestStore(t)
+	defer store.Close()
+
+	got, err := store.Get(context.Background(), 42)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSQLiteStore_ArchiveOlderThan(t *testing.T) {
+	store, tmpDir := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	old := &Snapshot{
+		NormalizedHGVS: "NM_000492.3:c.1521_1523del",
+		Payload:        json.RawMessage(`{"gnomad":{"af":0.0001}}`),
+		CapturedAt:     time.Now().Add(-200 * 24 * time.Hour),
+	}
+	recent := &Snapshot{
+		NormalizedHGVS: "NM_000492.3:c.1521_1523del",
+		Payload:        json.RawMessage(`{"gnomad":{"af":0.0002}}`),
+	}
+	require.NoError(t, store.Save(ctx, old))
+	require.NoError(t, store.Save(ctx, recent))
+
+	// Act
+	archived, err := store.ArchiveOlderThan(ctx, time.Now().Add(-90*24*time.Hour))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+
+	// Archived payload lives in a compressed blob, not in SQLite
+	var payload []byte
+	require.NoError(t, store.db.QueryRow("SELECT payload FROM evidence_snapshots WHERE id = ?", old.ID).Scan(&payload))
+	assert.Nil(t, payload)
+
+	got, err := store.Get(ctx, old.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Archived)
+	assert.JSONEq(t, `{"gnomad":{"af":0.0001}}`, string(got.Payload))
+
+	_, err = os.Stat(filepath.Join(tmpDir, "archive", got.ArchiveKey))
+	assert.NoError(t, err, "Archive blob should exist")
+
+	// Running again archives nothing new
+	archived, err = store.ArchiveOlderThan(ctx, time.Now().Add(-90*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, archived)
+
+	list, err := store.ListByVariant(ctx, "NM_000492.3:c.1521_1523del")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, recent.ID, list[0].ID)
+	assert.True(t, list[1].Archived)
+}
+
+func TestSQLiteStore_ListByVariantKind(t *testing.T) {
+	store, _ := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	hgvs := "NM_007294.4:c.5266dup"
+	evidence := &Snapshot{NormalizedHGVS: hgvs, Payload: json.RawMessage(`{}`)}
+	bundle := &Snapshot{Kind: KindClassification, NormalizedHGVS: hgvs, Payload: json.RawMessage(`{"classification":"PATHOGENIC"}`)}
+	require.NoError(t, store.Save(ctx, evidence))
+	require.NoError(t, store.Save(ctx, bundle))
+	assert.Equal(t, KindEvidence, evidence.Kind)
+
+	// Evidence listings leave classification bundles out
+	list, err := store.ListByVariant(ctx, hgvs)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, evidence.ID, list[0].ID)
+
+	list, err = store.ListByVariantKind(ctx, hgvs, KindClassification)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, bundle.ID, list[0].ID)
+
+	got, err := store.Get(ctx, bundle.ID)
+	require.NoError(t, err)
+	assert.Equal(t, KindClassification, got.Kind)
+}
+
+func TestFileArchive_RejectsEscapingKeys(t *testing.T) {
+	archive, err := NewFileArchive(t.TempDir())
+	require.NoError(t, err)
+	defer archive.Close()
+
+	err = archive.Put(context.Background(), "../outside.zst", []byte("data"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,62 @@
+// Package snapshot provides storage for point-in-time evidence snapshots.
+// Recent snapshots live in SQLite; older ones are moved to a compressed
+// archive tier and retrieved transparently when accessed for audit.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Snapshot kinds
+const (
+	// KindEvidence is the evidence returned by query_evidence, the default
+	KindEvidence = "evidence"
+	// KindClassification is the full evidence bundle a classification was
+	// made from, which replay_classification re-runs the rule engine on
+	KindClassification = "classification"
+)
+
+// Snapshot is the evidence gathered for a variant at a point in time.
+type Snapshot struct {
+	ID             int64           `json:"id,omitempty"`
+	Kind           string          `json:"kind"` // KindEvidence or KindClassification
+	NormalizedHGVS string          `json:"normalized_hgvs"`
+	Payload        json.RawMessage `json:"payload"`
+	CapturedAt     time.Time       `json:"captured_at"`
+	Archived       bool            `json:"archived"`              // Payload is held in the archive tier
+	ArchiveKey     string          `json:"archive_key,omitempty"` // Location of the archived blob
+}
+
+// Store defines the interface for evidence snapshot storage operations.
+type Store interface {
+	// Save stores a new evidence snapshot.
+	Save(ctx context.Context, snapshot *Snapshot) error
+
+	// Get retrieves a snapshot by ID, reading archived payloads transparently.
+	Get(ctx context.Context, id int64) (*Snapshot, error)
+
+	// ListByVariant returns evidence snapshot metadata for a variant, newest first.
+	// Payloads are not loaded; use Get to retrieve them.
+	ListByVariant(ctx context.Context, normalizedHGVS string) ([]*Snapshot, error)
+
+	// ListByVariantKind returns metadata for a variant's snapshots of one kind, newest first.
+	ListByVariantKind(ctx context.Context, normalizedHGVS, kind string) ([]*Snapshot, error)
+
+	// ArchiveOlderThan moves payloads captured before the cutoff to the archive tier.
+	// Returns the number of snapshots archived.
+	ArchiveOlderThan(ctx context.Context, cutoff time.Time) (int, error)
+
+	// Close closes the store and releases resources.
+	Close() error
+}
+
+// Archive defines the interface for the compressed archive tier.
+type Archive interface {
+	// Put compresses and stores a payload under the given key.
+	Put(ctx context.Context, key string, payload []byte) error
+
+	// Get retrieves and decompresses the payload stored under the given key.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
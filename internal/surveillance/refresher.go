@@ -0,0 +1,410 @@
+// Package surveillance re-evaluates stored variants on a schedule. Each run
+// re-fetches external evidence for every variant in the audit trail and the
+// lab knowledge base, re-runs the rule engine through classify_variant,
+// raises a follow-up flag for each classification that changed and writes a
+// reclassification report.
+package surveillance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/followup"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// SurveillanceUser is recorded as the creator of the follow-up flags a run raises
+const SurveillanceUser = "evidence-surveillance"
+
+// auditPageSize is the page size used when scanning the audit trail
+const auditPageSize = 500
+
+// ToolCaller runs an MCP tool in-process
+type ToolCaller interface {
+	CallTool(ctx context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response
+}
+
+// Change is a variant whose re-evaluation changed its classification.
+type Change struct {
+	Variant                 string      `json:"variant"`
+	PreviousClassification  string      `json:"previous_classification"`
+	CurrentClassification   string      `json:"current_classification"`
+	LabClassification       string      `json:"lab_classification,omitempty"` // The lab's signed-out assertion, when there is one
+	Direction               string      `json:"direction,omitempty"`
+	NotificationRecommended bool        `json:"notification_recommended"`
+	Summary                 string      `json:"summary"`
+	Diff                    *audit.Diff `json:"diff,omitempty"`    // Nil when compared with the lab assertion only
+	FlagID                  int64       `json:"flag_id,omitempty"` // 0 when no flag was raised
+}
+
+// ResultCache holds memoized classifications, such as a service.ResultCache
+type ResultCache interface {
+	Purge()
+}
+
+// Failure is a variant that could not be re-evaluated.
+type Failure struct {
+	Variant string `json:"variant"`
+	Error   string `json:"error"`
+}
+
+// Report summarizes one re-evaluation run.
+type Report struct {
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+	Variants    int       `json:"variants"` // Variants selected for re-evaluation
+	Reevaluated int       `json:"reevaluated"`
+	Unchanged   int       `json:"unchanged"`
+	Changes     []Change  `json:"changes"`
+	Failures    []Failure `json:"failures"`
+	Truncated   bool      `json:"truncated,omitempty"` // More variants were stored than the run limit; the next run continues
+	Path        string    `json:"path,omitempty"`      // JSON report file; the text report sits beside it
+}
+
+// Text renders the report for reviewers.
+func (r *Report) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Reclassification report, %s\n\n", r.StartedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Variants re-evaluated: %d of %d\n", r.Reevaluated, r.Variants)
+	if r.Truncated {
+		b.WriteString("More variants are stored than the run limit; the next run continues with the rest\n")
+	}
+	fmt.Fprintf(&b, "Unchanged: %d\nChanged: %d\nFailed: %d\n", r.Unchanged, len(r.Changes), len(r.Failures))
+
+	if len(r.Changes) > 0 {
+		b.WriteString("\nChanged classifications\n")
+		for _, c := range r.Changes {
+			fmt.Fprintf(&b, "- %s: %s -> %s", c.Variant, c.PreviousClassification, c.CurrentClassification)
+			if c.NotificationRecommended {
+				b.WriteString(" (notification recommended)")
+			}
+			fmt.Fprintf(&b, "\n  %s\n", c.Summary)
+		}
+	}
+	if len(r.Failures) > 0 {
+		b.WriteString("\nFailed re-evaluations\n")
+		for _, f := range r.Failures {
+			fmt.Fprintf(&b, "- %s: %s\n", f.Variant, f.Error)
+		}
+	}
+	return b.String()
+}
+
+// candidate is a variant selected for re-evaluation
+type candidate struct {
+	variant string
+	request json.RawMessage  // Parameters of its latest classification
+	lab     *labkb.Assertion // The lab's assertion, if any
+}
+
+// Refresher re-evaluates stored variants with fresh evidence.
+type Refresher struct {
+	logger      *logrus.Logger
+	caller      ToolCaller
+	audit       audit.Store
+	lab         labkb.Store
+	flags       followup.Store
+	results     ResultCache
+	reportDir   string
+	maxVariants int
+
+	mu     sync.Mutex // Serializes runs
+	cursor int        // First variant of the next limited run
+}
+
+// NewRefresher creates a refresher that classifies through caller and
+// re-evaluates the variants in the audit trail.
+func NewRefresher(logger *logrus.Logger, caller ToolCaller, auditStore audit.Store) *Refresher {
+	return &Refresher{
+		logger: logger,
+		caller: caller,
+		audit:  auditStore,
+	}
+}
+
+// SetLabKnowledgeBase adds the lab's asserted variants to each run. Variants
+// never classified before are compared with the lab's assertion.
+func (r *Refresher) SetLabKnowledgeBase(store labkb.Store) {
+	r.lab = store
+}
+
+// SetFollowUpStore enables review tasks: each changed classification gets a
+// reclassification_detected follow-up flag on the reviewers' worklist.
+func (r *Refresher) SetFollowUpStore(store followup.Store) {
+	r.flags = store
+}
+
+// SetResultCache drops the memoized classifications at the start of each
+// run, so no classification outlives the evidence refresh.
+func (r *Refresher) SetResultCache(cache ResultCache) {
+	r.results = cache
+}
+
+// SetReportDir sets the directory JSON and text reports are written to; no
+// files are written when it is empty.
+func (r *Refresher) SetReportDir(dir string) {
+	r.reportDir = dir
+}
+
+// SetMaxVariants caps the variants re-evaluated per run; 0 re-evaluates all.
+// Limited runs continue where the previous run stopped.
+func (r *Refresher) SetMaxVariants(n int) {
+	r.maxVariants = n
+}
+
+// Run re-evaluates every stored variant. Each classification is recorded in
+// the audit trail like any other, so classification.changed webhooks and
+// later diffs see it. Failures of single variants are reported, not returned.
+func (r *Refresher) Run(ctx context.Context) (*Report, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &Report{
+		StartedAt: time.Now().UTC(),
+		Changes:   []Change{},
+		Failures:  []Failure{},
+	}
+
+	candidates, err := r.candidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.results != nil {
+		r.results.Purge()
+	}
+	if r.maxVariants > 0 && len(candidates) > r.maxVariants {
+		start := r.cursor % len(candidates)
+		candidates = append(candidates[start:], candidates[:start]...)[:r.maxVariants]
+		r.cursor = start + r.maxVariants
+		report.Truncated = true
+	}
+	report.Variants = len(candidates)
+
+	for _, c := range candidates {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		change, err := r.reevaluate(ctx, c)
+		if err != nil {
+			report.Failures = append(report.Failures, Failure{Variant: c.variant, Error: err.Error()})
+			continue
+		}
+		report.Reevaluated++
+		if change == nil {
+			report.Unchanged++
+			continue
+		}
+		change.FlagID = r.flag(ctx, change)
+		report.Changes = append(report.Changes, *change)
+	}
+	report.FinishedAt = time.Now().UTC()
+
+	if r.reportDir != "" {
+		if err := r.write(report); err != nil {
+			return nil, err
+		}
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"variants": report.Variants,
+		"changed":  len(report.Changes),
+		"failed":   len(report.Failures),
+	}).Info("Re-evaluated stored variants with fresh evidence")
+	return report, nil
+}
+
+// candidates lists the audit trail's variants and the lab's asserted variants, by notation
+func (r *Refresher) candidates(ctx context.Context) ([]*candidate, error) {
+	byVariant := make(map[string]*candidate)
+	for offset := 0; ; offset += auditPageSize {
+		page, err := r.audit.Latest(ctx, auditPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list audited variants: %w", err)
+		}
+		for _, record := range page {
+			byVariant[record.HGVSNotation] = &candidate{variant: record.HGVSNotation, request: record.Request}
+		}
+		if len(page) < auditPageSize {
+			break
+		}
+	}
+
+	if r.lab != nil {
+		assertions, err := r.lab.List(ctx, labkb.Filter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list lab assertions: %w", err)
+		}
+		for _, a := range assertions {
+			c, ok := byVariant[a.NormalizedHGVS]
+			if !ok {
+				c = &candidate{variant: a.NormalizedHGVS}
+				byVariant[a.NormalizedHGVS] = c
+			}
+			c.lab = a
+		}
+	}
+
+	candidates := make([]*candidate, 0, len(byVariant))
+	for _, c := range byVariant {
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].variant < candidates[j].variant })
+	return candidates, nil
+}
+
+// reevaluate classifies a variant with fresh evidence and returns its change, if any
+func (r *Refresher) reevaluate(ctx context.Context, c *candidate) (*Change, error) {
+	params, err := refreshParams(c)
+	if err != nil {
+		return nil, err
+	}
+
+	response := r.caller.CallTool(ctx, "classify_variant", params)
+	if response == nil {
+		return nil, fmt.Errorf("classify_variant returned no response")
+	}
+	if response.Error != nil {
+		if detail, ok := response.Error.Data.(string); ok && detail != "" {
+			return nil, fmt.Errorf("%s: %s", response.Error.Message, detail)
+		}
+		return nil, fmt.Errorf("%s", response.Error.Message)
+	}
+
+	raw, err := json.Marshal(response.Result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	var decoded struct {
+		Classification *struct {
+			Classification   string      `json:"classification"`
+			Reclassification *audit.Diff `json:"reclassification"`
+		} `json:"classification"`
+	}
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.Classification == nil {
+		return nil, fmt.Errorf("unexpected classify_variant result")
+	}
+	current, diff := decoded.Classification.Classification, decoded.Classification.Reclassification
+
+	var lab string
+	if c.lab != nil {
+		lab = c.lab.Classification
+	}
+	switch {
+	case diff != nil && diff.ClassChanged:
+		return &Change{
+			Variant:                 c.variant,
+			PreviousClassification:  diff.PreviousClassification,
+			CurrentClassification:   diff.CurrentClassification,
+			LabClassification:       lab,
+			Direction:               diff.Direction,
+			NotificationRecommended: diff.NotificationRecommended,
+			Summary:                 diff.Summary,
+			Diff:                    diff,
+		}, nil
+	case diff == nil && c.lab != nil && !sameClassification(lab, current):
+		// First classification on record: compare with what the lab signed out
+		return &Change{
+			Variant:                 c.variant,
+			PreviousClassification:  lab,
+			CurrentClassification:   current,
+			LabClassification:       lab,
+			NotificationRecommended: true,
+			Summary:                 fmt.Sprintf("Re-evaluated as %s; the lab's assertion is %s", current, lab),
+		}, nil
+	}
+	return nil, nil
+}
+
+// refreshParams are the variant's latest classification parameters with the
+// evidence cache bypassed. Proband observations are not recorded again.
+func refreshParams(c *candidate) (map[string]interface{}, error) {
+	params := map[string]interface{}{}
+	if len(c.request) > 0 {
+		if err := json.Unmarshal(c.request, &params); err != nil {
+			return nil, fmt.Errorf("failed to decode recorded request: %w", err)
+		}
+	}
+	if params["hgvs_notation"] == nil && params["gene_symbol_notation"] == nil {
+		params["hgvs_notation"] = c.variant
+	}
+	delete(params, "proband_id")
+	delete(params, "zygosity")
+	delete(params, "output_format")
+	params["refresh_evidence"] = true
+	return params, nil
+}
+
+// sameClassification compares classifications given as canonical values or labels
+func sameClassification(a, b string) bool {
+	ca, errA := domain.ParseClassification(a)
+	cb, errB := domain.ParseClassification(b)
+	if errA != nil || errB != nil {
+		return strings.EqualFold(a, b)
+	}
+	return ca == cb
+}
+
+// flag raises a review task for a change unless one is already open, returning
+// its ID; store failures are logged and ignored
+func (r *Refresher) flag(ctx context.Context, change *Change) int64 {
+	if r.flags == nil {
+		return 0
+	}
+
+	open, err := r.flags.Open(ctx, change.Variant, "")
+	if err != nil {
+		r.logger.WithError(err).WithField("variant", change.Variant).Warn("Failed to load follow-up flags")
+		return 0
+	}
+	for _, f := range open {
+		if f.Kind == followup.KindReclassificationDetected {
+			return f.ID
+		}
+	}
+
+	flag := &followup.Flag{
+		NormalizedHGVS: change.Variant,
+		Kind:           followup.KindReclassificationDetected,
+		Note:           fmt.Sprintf("Scheduled re-evaluation changed the classification from %s to %s; review and sign out", change.PreviousClassification, change.CurrentClassification),
+		Blocking:       followup.KindReclassificationDetected.BlocksByDefault(),
+		CreatedBy:      SurveillanceUser,
+	}
+	if err := r.flags.Add(ctx, flag); err != nil {
+		r.logger.WithError(err).WithField("variant", change.Variant).Warn("Failed to add follow-up flag")
+		return 0
+	}
+	return flag.ID
+}
+
+// write saves the report as JSON and text and sets its path
+func (r *Refresher) write(report *Report) error {
+	if err := os.MkdirAll(r.reportDir, 0755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	base := filepath.Join(r.reportDir, "reclassification-"+report.StartedAt.Format("20060102T150405Z"))
+	report.Path = base + ".json"
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	if err := os.WriteFile(report.Path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := os.WriteFile(base+".txt", []byte(report.Text()), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package surveillance
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/audit"
+	"github.com/acmg-amp-mcp-server/internal/followup"
+	"github.com/acmg-amp-mcp-server/internal/labkb"
+	"github.com/acmg-amp-mcp-server/internal/mcp/protocol"
+)
+
+// fakeCaller answers classify_variant from canned results by notation
+type fakeCaller struct {
+	results map[string]map[string]interface{}
+	calls   []map[string]interface{}
+}
+
+func (c *fakeCaller) CallTool(ctx context.Context, name string, arguments interface{}) *protocol.JSONRPC2Response {
+	params := arguments.(map[string]interface{})
+	c.calls = append(c.calls, params)
+	result, ok := c.results[params["hgvs_notation"].(string)]
+	if !ok {
+		return &protocol.JSONRPC2Response{Error: &protocol.RPCError{Code: protocol.InternalError, Message: "Classification failed", Data: "evidence lookup timed out"}}
+	}
+	return &protocol.JSONRPC2Response{Result: map[string]interface{}{"classification": result}}
+}
+
+// purgeCounter counts result cache purges
+type purgeCounter struct {
+	purges int
+}
+
+func (c *purgeCounter) Purge() {
+	c.purges++
+}
+
+func TestRefresher_Run(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	auditStore, err := audit.NewSQLiteStore(filepath.Join(dir, "audit.db"))
+	require.NoError(t, err)
+	defer auditStore.Close()
+	flags, err := followup.NewSQLiteStore(filepath.Join(dir, "followup.db"))
+	require.NoError(t, err)
+	defer flags.Close()
+	lab, err := labkb.NewSQLiteStore(filepath.Join(dir, "labkb.db"))
+	require.NoError(t, err)
+	defer lab.Close()
+
+	for _, hgvs := range []string{"NM_000492.4:c.1521_1523del", "NM_007294.4:c.5266dup", "NM_000059.4:c.68-7T>A"} {
+		require.NoError(t, auditStore.Append(ctx, &audit.Record{
+			HGVSNotation:   hgvs,
+			Request:        json.RawMessage(`{"hgvs_notation":"` + hgvs + `","condition":"hereditary cancer","proband_id":"P1"}`),
+			Classification: "VUS",
+			EngineVersion:  "v0.1.0",
+			CreatedAt:      time.Now(),
+		}))
+	}
+	require.NoError(t, lab.Save(ctx, &labkb.Assertion{
+		NormalizedHGVS: "NM_000251.3:c.1A>G",
+		Classification: "LIKELY_PATHOGENIC",
+		EvaluatedBy:    "curator-1",
+		EvaluatedAt:    time.Now(),
+	}))
+
+	caller := &fakeCaller{results: map[string]map[string]interface{}{
+		"NM_000492.4:c.1521_1523del": {"classification": "VUS"},
+		"NM_007294.4:c.5266dup": {
+			"classification": "LIKELY_PATHOGENIC",
+			"reclassification": &audit.Diff{
+				Variant:                 "NM_007294.4:c.5266dup",
+				PreviousClassification:  "VUS",
+				CurrentClassification:   "LIKELY_PATHOGENIC",
+				ClassChanged:            true,
+				Direction:               audit.DirectionUpgraded,
+				NotificationRecommended: true,
+				Summary:                 "VUS -> LIKELY_PATHOGENIC",
+			},
+		},
+		"NM_000251.3:c.1A>G": {"classification": "Uncertain significance"},
+	}}
+	refresher := NewRefresher(logger, caller, auditStore)
+	refresher.SetLabKnowledgeBase(lab)
+	refresher.SetFollowUpStore(flags)
+	refresher.SetReportDir(filepath.Join(dir, "reports"))
+
+	// Act
+	report, err := refresher.Run(ctx)
+	require.NoError(t, err)
+	again, err := refresher.Run(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 4, report.Variants)
+	assert.Equal(t, 3, report.Reevaluated)
+	assert.Equal(t, 1, report.Unchanged)
+	require.Len(t, report.Failures, 1)
+	assert.Equal(t, "NM_000059.4:c.68-7T>A", report.Failures[0].Variant)
+	assert.Contains(t, report.Failures[0].Error, "evidence lookup timed out")
+
+	require.Len(t, report.Changes, 2)
+	labChange, auditChange := report.Changes[0], report.Changes[1]
+	assert.Equal(t, "NM_000251.3:c.1A>G", labChange.Variant)
+	assert.Equal(t, "LIKELY_PATHOGENIC", labChange.PreviousClassification)
+	assert.Nil(t, labChange.Diff)
+	assert.Equal(t, "NM_007294.4:c.5266dup", auditChange.Variant)
+	assert.Equal(t, audit.DirectionUpgraded, auditChange.Direction)
+	assert.NotZero(t, auditChange.FlagID)
+
+	for _, params := range caller.calls {
+		assert.Equal(t, true, params["refresh_evidence"])
+		assert.Nil(t, params["proband_id"], "proband observations are not recorded again")
+	}
+	assert.Equal(t, "hereditary cancer", caller.calls[0]["condition"])
+
+	open, err := flags.Worklist(ctx, followup.KindReclassificationDetected)
+	require.NoError(t, err)
+	assert.Len(t, open, 2, "repeated runs reuse the open flag")
+	assert.Equal(t, auditChange.FlagID, again.Changes[1].FlagID)
+
+	require.FileExists(t, report.Path)
+	text, err := os.ReadFile(report.Path[:len(report.Path)-len(".json")] + ".txt")
+	require.NoError(t, err)
+	assert.Contains(t, string(text), "NM_007294.4:c.5266dup: VUS -> LIKELY_PATHOGENIC (notification recommended)")
+}
+
+func TestRefresher_MaxVariants(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	auditStore, err := audit.NewSQLiteStore(filepath.Join(t.TempDir(), "audit.db"))
+	require.NoError(t, err)
+	defer auditStore.Close()
+
+	caller := &fakeCaller{results: map[string]map[string]interface{}{}}
+	for _, hgvs := range []string{"NM_000001.1:c.1A>G", "NM_000002.1:c.1A>G", "NM_000003.1:c.1A>G"} {
+		require.NoError(t, auditStore.Append(ctx, &audit.Record{
+			HGVSNotation:   hgvs,
+			Request:        json.RawMessage(`{"hgvs_notation":"` + hgvs + `"}`),
+			Classification: "VUS",
+			EngineVersion:  "v0.1.0",
+		}))
+		caller.results[hgvs] = map[string]interface{}{"classification": "VUS"}
+	}
+	refresher := NewRefresher(logger, caller, auditStore)
+	refresher.SetMaxVariants(2)
+	results := &purgeCounter{}
+	refresher.SetResultCache(results)
+
+	// Act
+	first, err := refresher.Run(ctx)
+	require.NoError(t, err)
+	second, err := refresher.Run(ctx)
+	require.NoError(t, err)
+
+	// Assert: the second run continues with the variant the first skipped
+	assert.True(t, first.Truncated)
+	assert.Equal(t, 2, second.Reevaluated)
+	require.Len(t, caller.calls, 4)
+	assert.Equal(t, "NM_000003.1:c.1A>G", caller.calls[2]["hgvs_notation"])
+	assert.Empty(t, first.Path, "no report directory configured")
+	assert.Equal(t, 2, results.purges, "each run drops the memoized classifications")
+}
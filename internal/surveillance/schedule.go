@@ -0,0 +1,162 @@
+package surveillance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// descriptors are the shorthand schedules accepted in place of five fields
+var descriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// field bounds, in cron field order
+var fieldBounds = [5]struct{ min, max int }{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 6},  // day of week, Sunday = 0 (7 is accepted as Sunday)
+}
+
+// Schedule is a parsed five-field cron expression evaluated in UTC:
+// minute, hour, day of month, month and day of week. Fields accept *,
+// values, ranges (1-5), steps (*/15, 0-30/10) and comma-separated lists.
+// As in cron, when both day fields are restricted a day matching either runs.
+type Schedule struct {
+	expr   string
+	fields [5]map[int]bool
+	anyDOM bool
+	anyDOW bool
+}
+
+// ParseSchedule parses a cron expression or one of @hourly, @daily,
+// @weekly and @monthly.
+func ParseSchedule(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	spec := expr
+	if d, ok := descriptors[strings.ToLower(spec)]; ok {
+		spec = d
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("schedule %q must have 5 fields (minute hour day-of-month month day-of-week)", expr)
+	}
+
+	s := &Schedule{expr: expr, anyDOM: parts[2] == "*", anyDOW: parts[4] == "*"}
+	for i, part := range parts {
+		values, err := parseField(part, fieldBounds[i].min, fieldBounds[i].max, i == 4)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", expr, err)
+		}
+		s.fields[i] = values
+	}
+	return s, nil
+}
+
+// parseField expands one cron field into the set of values it matches
+func parseField(field string, min, max int, dayOfWeek bool) (map[int]bool, error) {
+	if dayOfWeek {
+		max = 7
+	}
+	values := make(map[int]bool)
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step := item, 1
+		if r, s, ok := strings.Cut(item, "/"); ok {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid step in %q", item)
+			}
+			rangePart, step = r, n
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var errA, errB error
+			lo, errA = strconv.Atoi(a)
+			hi, errB = strconv.Atoi(b)
+			if errA != nil || errB != nil || lo > hi {
+				return nil, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q", rangePart)
+			}
+			lo, hi = n, n
+			if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max {
+			return nil, fmt.Errorf("%q is outside %d-%d", item, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			values[v] = true
+		}
+	}
+	if dayOfWeek && values[7] {
+		values[0] = true
+		delete(values, 7)
+	}
+	return values, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first minute strictly after t, in UTC, that the schedule
+// matches. Expressions that never match, such as 30 February, return the
+// zero time.
+func (s *Schedule) Next(t time.Time) time.Time {
+	next := t.UTC().Truncate(time.Minute).Add(time.Minute)
+	// Every schedule repeats within a leap-year cycle
+	limit := next.AddDate(4, 0, 1)
+	for next.Before(limit) {
+		if !s.fields[3][int(next.Month())] {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.fields[1][next.Hour()] {
+			next = next.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !s.fields[0][next.Minute()] {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
+
+// matchesDay applies cron's day rule: either day field may match when both are restricted
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := s.fields[2][t.Day()]
+	dow := s.fields[4][int(t.Weekday())]
+	switch {
+	case s.anyDOM && s.anyDOW:
+		return true
+	case s.anyDOM:
+		return dow
+	case s.anyDOW:
+		return dom
+	default:
+		return dom || dow
+	}
+}
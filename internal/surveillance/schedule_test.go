@@ -0,0 +1,46 @@
+package surveillance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule_Next(t *testing.T) {
+	from := time.Date(2026, 3, 4, 10, 17, 30, 0, time.UTC) // A Wednesday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"@daily", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)},
+		{"30 2 * * 0", time.Date(2026, 3, 8, 2, 30, 0, 0, time.UTC)},
+		{"30 2 * * 7", time.Date(2026, 3, 8, 2, 30, 0, 0, time.UTC)},
+		{"0 9 1,15 * *", time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)},
+		{"0 6 * * 1-5", time.Date(2026, 3, 5, 6, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 3", time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)}, // Either day field may match
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+
+	never, err := ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, never.Next(from).IsZero())
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "@yearly", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		_, err := ParseSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+}
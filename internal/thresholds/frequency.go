@@ -0,0 +1,184 @@
+package thresholds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidFrequencyOverride is returned when a frequency override fails validation.
+var ErrInvalidFrequencyOverride = errors.New("invalid frequency threshold override")
+
+// FrequencyOverride replaces the BA1, BS1 and PM2 allele frequency cutoffs
+// for a gene, a condition, or a gene in a given condition. Unset cutoffs keep
+// the value otherwise in effect. Typical uses are BA1 exceptions for common
+// low-penetrance alleles such as HFE p.Cys282Tyr or F5 Leiden.
+type FrequencyOverride struct {
+	Gene               string   `json:"gene,omitempty" yaml:"gene,omitempty"`           // HGNC symbol
+	Condition          string   `json:"condition,omitempty" yaml:"condition,omitempty"` // Disease name or MONDO ID
+	BA1AlleleFrequency *float64 `json:"ba1_allele_frequency,omitempty" yaml:"ba1_allele_frequency,omitempty"`
+	BS1AlleleFrequency *float64 `json:"bs1_allele_frequency,omitempty" yaml:"bs1_allele_frequency,omitempty"`
+	PM2AlleleFrequency *float64 `json:"pm2_allele_frequency,omitempty" yaml:"pm2_allele_frequency,omitempty"`
+	Reason             string   `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// Validate checks that the override names a gene or condition and that its
+// cutoffs are frequencies in a consistent order.
+func (o FrequencyOverride) Validate() error {
+	if strings.TrimSpace(o.Gene) == "" && strings.TrimSpace(o.Condition) == "" {
+		return fmt.Errorf("%w: gene or condition is required", ErrInvalidFrequencyOverride)
+	}
+	if o.BA1AlleleFrequency == nil && o.BS1AlleleFrequency == nil && o.PM2AlleleFrequency == nil {
+		return fmt.Errorf("%w %s: at least one allele frequency is required", ErrInvalidFrequencyOverride, o.Label())
+	}
+	for name, f := range map[string]*float64{
+		"ba1_allele_frequency": o.BA1AlleleFrequency,
+		"bs1_allele_frequency": o.BS1AlleleFrequency,
+		"pm2_allele_frequency": o.PM2AlleleFrequency,
+	} {
+		if f != nil && (*f <= 0 || *f > 1) {
+			return fmt.Errorf("%w %s: %s must be in (0, 1], got %g", ErrInvalidFrequencyOverride, o.Label(), name, *f)
+		}
+	}
+	if o.BS1AlleleFrequency != nil && o.BA1AlleleFrequency != nil && *o.BS1AlleleFrequency > *o.BA1AlleleFrequency {
+		return fmt.Errorf("%w %s: bs1_allele_frequency must not exceed ba1_allele_frequency", ErrInvalidFrequencyOverride, o.Label())
+	}
+	if o.PM2AlleleFrequency != nil && o.BS1AlleleFrequency != nil && *o.PM2AlleleFrequency >= *o.BS1AlleleFrequency {
+		return fmt.Errorf("%w %s: pm2_allele_frequency must be below bs1_allele_frequency", ErrInvalidFrequencyOverride, o.Label())
+	}
+	return nil
+}
+
+// Apply returns t with the override's cutoffs substituted.
+func (o FrequencyOverride) Apply(t Thresholds) Thresholds {
+	if o.BA1AlleleFrequency != nil {
+		t.BA1AlleleFrequency = *o.BA1AlleleFrequency
+	}
+	if o.BS1AlleleFrequency != nil {
+		t.BS1AlleleFrequency = *o.BS1AlleleFrequency
+	}
+	if o.PM2AlleleFrequency != nil {
+		t.PM2AlleleFrequency = *o.PM2AlleleFrequency
+	}
+	return t
+}
+
+// Label identifies the override in messages, e.g. "HFE" or "HFE/hemochromatosis".
+func (o FrequencyOverride) Label() string {
+	switch {
+	case o.Gene == "":
+		return o.Condition
+	case o.Condition == "":
+		return o.Gene
+	}
+	return o.Gene + "/" + o.Condition
+}
+
+// FrequencyOverrides holds the configured overrides, indexed by gene and condition.
+type FrequencyOverrides struct {
+	overrides map[string]*FrequencyOverride
+}
+
+// frequencyOverridesFile is the on-disk layout of a frequency overrides file
+type frequencyOverridesFile struct {
+	Overrides []FrequencyOverride `json:"overrides" yaml:"overrides"`
+}
+
+// NewFrequencyOverrides validates and indexes overrides. Each gene, condition
+// or gene and condition pair may be configured once.
+func NewFrequencyOverrides(overrides []FrequencyOverride) (*FrequencyOverrides, error) {
+	index := &FrequencyOverrides{overrides: make(map[string]*FrequencyOverride, len(overrides))}
+	for i := range overrides {
+		override := overrides[i]
+		if err := override.Validate(); err != nil {
+			return nil, err
+		}
+		override.Gene = strings.ToUpper(strings.TrimSpace(override.Gene))
+		override.Condition = strings.TrimSpace(override.Condition)
+
+		key := frequencyOverrideKey(override.Gene, override.Condition)
+		if _, ok := index.overrides[key]; ok {
+			return nil, fmt.Errorf("%w: duplicate override for %s", ErrInvalidFrequencyOverride, override.Label())
+		}
+		index.overrides[key] = &override
+	}
+	return index, nil
+}
+
+// LoadFrequencyOverrides reads overrides from a JSON or YAML file with an
+// "overrides" list. A missing file yields no overrides.
+func LoadFrequencyOverrides(path string) (*FrequencyOverrides, error) {
+	unmarshal := yaml.Unmarshal
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		unmarshal = json.Unmarshal
+	case ".yaml", ".yml":
+	default:
+		return nil, fmt.Errorf("unsupported frequency overrides format: %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewFrequencyOverrides(nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read frequency overrides: %w", err)
+	}
+
+	var file frequencyOverridesFile
+	if err := unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse frequency overrides %s: %w", filepath.Base(path), err)
+	}
+
+	overrides, err := NewFrequencyOverrides(file.Overrides)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return overrides, nil
+}
+
+// Lookup returns the most specific override for a gene and condition: one for
+// both, then one for the gene, then one for the condition. It returns nil
+// when none is configured.
+func (f *FrequencyOverrides) Lookup(gene, condition string) *FrequencyOverride {
+	if f == nil || len(f.overrides) == 0 {
+		return nil
+	}
+	gene = strings.ToUpper(strings.TrimSpace(gene))
+	condition = strings.TrimSpace(condition)
+
+	var candidates []string
+	if gene != "" && condition != "" {
+		candidates = append(candidates, frequencyOverrideKey(gene, condition))
+	}
+	if gene != "" {
+		candidates = append(candidates, frequencyOverrideKey(gene, ""))
+	}
+	if condition != "" {
+		candidates = append(candidates, frequencyOverrideKey("", condition))
+	}
+	for _, key := range candidates {
+		if override, ok := f.overrides[key]; ok {
+			return override
+		}
+	}
+	return nil
+}
+
+// Count returns the number of configured overrides.
+func (f *FrequencyOverrides) Count() int {
+	if f == nil {
+		return 0
+	}
+	return len(f.overrides)
+}
+
+// frequencyOverrideKey indexes an override; conditions match case-insensitively
+func frequencyOverrideKey(gene, condition string) string {
+	return gene + "\x00" + strings.ToLower(condition)
+}
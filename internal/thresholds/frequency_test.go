@@ -0,0 +1,96 @@
+package thresholds
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func frequency(f float64) *float64 { return &f }
+
+func TestFrequencyOverride_Validate(t *testing.T) {
+	assert.NoError(t, FrequencyOverride{Gene: "HFE", BA1AlleleFrequency: frequency(0.1)}.Validate())
+
+	assert.ErrorIs(t, FrequencyOverride{BA1AlleleFrequency: frequency(0.1)}.Validate(), ErrInvalidFrequencyOverride)
+	assert.ErrorIs(t, FrequencyOverride{Gene: "HFE"}.Validate(), ErrInvalidFrequencyOverride)
+	assert.ErrorIs(t, FrequencyOverride{Gene: "HFE", BA1AlleleFrequency: frequency(1.5)}.Validate(), ErrInvalidFrequencyOverride)
+	assert.ErrorIs(t, FrequencyOverride{Gene: "HFE", BA1AlleleFrequency: frequency(0.02), BS1AlleleFrequency: frequency(0.05)}.Validate(), ErrInvalidFrequencyOverride)
+	assert.ErrorIs(t, FrequencyOverride{Gene: "HFE", BS1AlleleFrequency: frequency(0.001), PM2AlleleFrequency: frequency(0.001)}.Validate(), ErrInvalidFrequencyOverride)
+}
+
+func TestFrequencyOverrides_Lookup(t *testing.T) {
+	overrides, err := NewFrequencyOverrides([]FrequencyOverride{
+		{Gene: "hfe", BA1AlleleFrequency: frequency(0.1)},
+		{Gene: "HFE", Condition: "Hereditary hemochromatosis", BA1AlleleFrequency: frequency(0.12)},
+		{Condition: "Nonsyndromic genetic hearing loss", BS1AlleleFrequency: frequency(0.003)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, overrides.Count())
+
+	// Gene and condition beats gene alone; conditions match case-insensitively
+	assert.Equal(t, 0.12, *overrides.Lookup("HFE", "hereditary HEMOCHROMATOSIS").BA1AlleleFrequency)
+	assert.Equal(t, 0.1, *overrides.Lookup("HFE", "Porphyria").BA1AlleleFrequency)
+	assert.Equal(t, 0.1, *overrides.Lookup("hfe", "").BA1AlleleFrequency)
+
+	// Condition-wide entries apply to any gene
+	override := overrides.Lookup("GJB2", "Nonsyndromic genetic hearing loss")
+	require.NotNil(t, override)
+	assert.Equal(t, "Nonsyndromic genetic hearing loss", override.Label())
+
+	assert.Nil(t, overrides.Lookup("BRCA1", "Hereditary breast and ovarian cancer"))
+	assert.Nil(t, overrides.Lookup("", ""))
+
+	var empty *FrequencyOverrides
+	assert.Nil(t, empty.Lookup("HFE", ""))
+}
+
+func TestFrequencyOverride_Apply(t *testing.T) {
+	applied := FrequencyOverride{Gene: "F5", BA1AlleleFrequency: frequency(0.1)}.Apply(Defaults())
+	assert.Equal(t, 0.1, applied.BA1AlleleFrequency)
+	assert.Equal(t, Defaults().BS1AlleleFrequency, applied.BS1AlleleFrequency)
+	assert.Equal(t, Defaults().PM2AlleleFrequency, applied.PM2AlleleFrequency)
+}
+
+func TestNewFrequencyOverrides_RejectsDuplicates(t *testing.T) {
+	_, err := NewFrequencyOverrides([]FrequencyOverride{
+		{Gene: "HFE", BA1AlleleFrequency: frequency(0.1)},
+		{Gene: " hfe ", BA1AlleleFrequency: frequency(0.2)},
+	})
+	assert.ErrorIs(t, err, ErrInvalidFrequencyOverride)
+}
+
+func TestLoadFrequencyOverrides(t *testing.T) {
+	dir := t.TempDir()
+
+	// A missing file means no overrides
+	overrides, err := LoadFrequencyOverrides(filepath.Join(dir, "missing.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, overrides.Count())
+
+	yamlPath := filepath.Join(dir, "frequency_thresholds.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte(`overrides:
+  - gene: HFE
+    ba1_allele_frequency: 0.1
+    reason: Low-penetrance hemochromatosis allele
+`), 0o644))
+	overrides, err = LoadFrequencyOverrides(yamlPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Low-penetrance hemochromatosis allele", overrides.Lookup("HFE", "").Reason)
+
+	jsonPath := filepath.Join(dir, "frequency_thresholds.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"overrides": [{"gene": "F5", "ba1_allele_frequency": 2}]}`), 0o644))
+	_, err = LoadFrequencyOverrides(jsonPath)
+	assert.ErrorIs(t, err, ErrInvalidFrequencyOverride)
+
+	_, err = LoadFrequencyOverrides(filepath.Join(dir, "frequency_thresholds.txt"))
+	assert.Error(t, err)
+}
+
+func TestLoadFrequencyOverrides_Example(t *testing.T) {
+	overrides, err := LoadFrequencyOverrides("../../examples/frequency_thresholds.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, 3, overrides.Count())
+}
@@ -0,0 +1,259 @@
+package thresholds
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// backdateTolerance allows for clock skew between the admin client and the server.
+const backdateTolerance = time.Minute
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite threshold revision store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables and indexes.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS threshold_revisions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		thresholds TEXT NOT NULL,
+		effective_from INTEGER NOT NULL,
+		reason TEXT NOT NULL,
+		created_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		cancelled_by TEXT DEFAULT '',
+		cancelled_at DATETIME
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_threshold_effective ON threshold_revisions(cancelled_at, effective_from);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+const revisionColumns = `id, thresholds, effective_from, reason, created_by, created_at, cancelled_by, cancelled_at`
+
+// scanner is an interface for sql.Row and sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRevision scans a row into a Revision struct.
+func scanRevision(s scanner) (*Revision, error) {
+	r := &Revision{}
+	var payload string
+	var effectiveFrom int64
+	var cancelledAt sql.NullTime
+
+	err := s.Scan(&r.ID, &payload, &effectiveFrom, &r.Reason, &r.CreatedBy, &r.CreatedAt, &r.CancelledBy, &cancelledAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(payload), &r.Thresholds); err != nil {
+		return nil, fmt.Errorf("failed to decode thresholds for revision %d: %w", r.ID, err)
+	}
+	r.EffectiveFrom = time.Unix(effectiveFrom, 0).UTC()
+	if cancelledAt.Valid {
+		t := cancelledAt.Time
+		r.CancelledAt = &t
+	}
+	return r, nil
+}
+
+// Schedule validates and stores a new revision.
+func (s *SQLiteStore) Schedule(ctx context.Context, revision *Revision) error {
+	if strings.TrimSpace(revision.CreatedBy) == "" {
+		return fmt.Errorf("created_by is required")
+	}
+	if strings.TrimSpace(revision.Reason) == "" {
+		return fmt.Errorf("reason is required")
+	}
+	revision.Thresholds.normalize()
+	if err := revision.Thresholds.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if revision.EffectiveFrom.IsZero() {
+		revision.EffectiveFrom = now
+	}
+	if revision.EffectiveFrom.Before(now.Add(-backdateTolerance)) {
+		return ErrBackdated
+	}
+	revision.EffectiveFrom = revision.EffectiveFrom.UTC().Truncate(time.Second)
+	revision.CreatedAt = now
+
+	payload, err := json.Marshal(revision.Thresholds)
+	if err != nil {
+		return fmt.Errorf("failed to encode thresholds: %w", err)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO threshold_revisions (thresholds, effective_from, reason, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, string(payload), revision.EffectiveFrom.Unix(), revision.Reason, revision.CreatedBy, revision.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert revision: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get revision ID: %w", err)
+	}
+	revision.ID = id
+	return nil
+}
+
+// Effective returns the most recent uncancelled revision effective at the given time.
+func (s *SQLiteStore) Effective(ctx context.Context, at time.Time) (*Revision, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT `+revisionColumns+` FROM threshold_revisions
+		WHERE cancelled_at IS NULL AND effective_from <= ?
+		ORDER BY effective_from DESC, id DESC
+		LIMIT 1
+	`, at.Unix())
+
+	revision, err := scanRevision(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query effective revision: %w", err)
+	}
+	return revision, nil
+}
+
+// History returns revisions, most recent effective date first.
+// A non-positive limit returns all revisions.
+func (s *SQLiteStore) History(ctx context.Context, limit int) ([]*Revision, error) {
+	query := "SELECT " + revisionColumns + " FROM threshold_revisions ORDER BY effective_from DESC, id DESC"
+	var args []interface{}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*Revision
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Rows are ordered newest first, so the first uncancelled revision already
+	// in effect is the active one and every later one has been superseded
+	now := time.Now()
+	active := false
+	for _, r := range revisions {
+		switch {
+		case r.CancelledAt != nil:
+			r.Status = StatusCancelled
+		case r.EffectiveFrom.After(now):
+			r.Status = StatusPending
+		case !active:
+			r.Status = StatusActive
+			active = true
+		default:
+			r.Status = StatusSuperseded
+		}
+	}
+	return revisions, nil
+}
+
+// Cancel withdraws a pending revision.
+func (s *SQLiteStore) Cancel(ctx context.Context, id int64, cancelledBy string) (*Revision, error) {
+	if strings.TrimSpace(cancelledBy) == "" {
+		return nil, fmt.Errorf("cancelled_by is required")
+	}
+
+	row := s.db.QueryRowContext(ctx, "SELECT "+revisionColumns+" FROM threshold_revisions WHERE id = ?", id)
+	revision, err := scanRevision(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revision: %w", err)
+	}
+	if revision.CancelledAt != nil {
+		revision.Status = StatusCancelled
+		return revision, nil
+	}
+
+	now := time.Now().UTC()
+	if !revision.EffectiveFrom.After(now) {
+		return nil, ErrRevisionInEffect
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE threshold_revisions SET cancelled_by = ?, cancelled_at = ? WHERE id = ?
+	`, cancelledBy, now, id); err != nil {
+		return nil, fmt.Errorf("failed to cancel revision: %w", err)
+	}
+
+	revision.CancelledBy = cancelledBy
+	revision.CancelledAt = &now
+	revision.Status = StatusCancelled
+	return revision, nil
+}
+
+// Close closes the store and releases resources.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
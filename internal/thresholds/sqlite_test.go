@@ -0,0 +1,146 @@
+package thresholds
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "thresholds.db"))
+	require.NoError(t, err)
+	return store
+}
+
+func TestThresholds_Validate(t *testing.T) {
+	assert.NoError(t, Defaults().Validate())
+
+	inverted := Defaults()
+	inverted.PM2AlleleFrequency = 0.02
+	assert.Error(t, inverted.Validate())
+
+	badSplice := Defaults()
+	badSplice.Predictors.SpliceBenign = 0.5
+	assert.Error(t, badSplice.Validate())
+
+	badREVEL := Defaults()
+	badREVEL.Predictors.REVELBenign = 0.7
+	assert.Error(t, badREVEL.Validate())
+
+	badPhenotype := Defaults()
+	badPhenotype.Phenotype.PP4Similarity = 1.5
+	assert.Error(t, badPhenotype.Validate())
+
+	badModel := Defaults()
+	badModel.GeneModels = map[string]GeneDiseaseModel{"TP53": {Inheritance: "AD", Mechanism: "haploinsufficiency-ish"}}
+	assert.Error(t, badModel.Validate())
+
+	badPenetrance := Defaults()
+	badPenetrance.GeneModels = map[string]GeneDiseaseModel{"CFTR": {Inheritance: "AR", Mechanism: MechanismLossOfFunction, Penetrance: "partial"}}
+	assert.Error(t, badPenetrance.Validate())
+
+	badHealthyAdults := Defaults()
+	badHealthyAdults.HealthyAdults.BS2Homozygotes = -1
+	assert.Error(t, badHealthyAdults.Validate())
+}
+
+func TestSQLiteStore_ScheduleAndEffective(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	none, err := store.Effective(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	current := Defaults()
+	current.GeneModels = map[string]GeneDiseaseModel{"scn5a": {Inheritance: "AD", Mechanism: MechanismGainOfFunction}}
+	immediate := &Revision{Thresholds: current, Reason: "Add SCN5A model", CreatedBy: "admin1"}
+	require.NoError(t, store.Schedule(ctx, immediate))
+
+	next := Defaults()
+	next.BS1AlleleFrequency = 0.005
+	scheduled := &Revision{
+		Thresholds:    next,
+		EffectiveFrom: time.Now().Add(24 * time.Hour),
+		Reason:        "VCEP BS1 update",
+		CreatedBy:     "admin1",
+	}
+	require.NoError(t, store.Schedule(ctx, scheduled))
+
+	// Act
+	now, err := store.Effective(ctx, time.Now())
+	require.NoError(t, err)
+	tomorrow, err := store.Effective(ctx, time.Now().Add(25*time.Hour))
+	require.NoError(t, err)
+
+	// Assert
+	require.NotNil(t, now)
+	assert.Equal(t, immediate.ID, now.ID)
+	model, ok := now.Thresholds.GeneModel("SCN5A")
+	require.True(t, ok, "gene model keys are normalized")
+	assert.Equal(t, MechanismGainOfFunction, model.Mechanism)
+
+	require.NotNil(t, tomorrow)
+	assert.Equal(t, scheduled.ID, tomorrow.ID)
+	assert.Equal(t, 0.005, tomorrow.Thresholds.BS1AlleleFrequency)
+}
+
+func TestSQLiteStore_ScheduleRejectsInvalid(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	err := store.Schedule(ctx, &Revision{
+		Thresholds:    Defaults(),
+		EffectiveFrom: time.Now().Add(-48 * time.Hour),
+		Reason:        "Backdated",
+		CreatedBy:     "admin1",
+	})
+	assert.ErrorIs(t, err, ErrBackdated)
+
+	assert.Error(t, store.Schedule(ctx, &Revision{Thresholds: Defaults(), CreatedBy: "admin1"}), "reason is required")
+	assert.Error(t, store.Schedule(ctx, &Revision{Thresholds: Thresholds{}, Reason: "Empty", CreatedBy: "admin1"}))
+}
+
+func TestSQLiteStore_HistoryAndCancel(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	first := &Revision{Thresholds: Defaults(), Reason: "Initial", CreatedBy: "admin1"}
+	require.NoError(t, store.Schedule(ctx, first))
+	second := &Revision{Thresholds: Defaults(), Reason: "Re-baseline", CreatedBy: "admin2", EffectiveFrom: time.Now().Add(time.Hour)}
+	require.NoError(t, store.Schedule(ctx, second))
+	pending := &Revision{Thresholds: Defaults(), Reason: "Next quarter", CreatedBy: "admin1", EffectiveFrom: time.Now().Add(90 * 24 * time.Hour)}
+	require.NoError(t, store.Schedule(ctx, pending))
+
+	// Act
+	_, inEffectErr := store.Cancel(ctx, first.ID, "admin2")
+	cancelled, err := store.Cancel(ctx, pending.ID, "admin2")
+	require.NoError(t, err)
+	_, missingErr := store.Cancel(ctx, 999, "admin2")
+
+	// Assert
+	assert.ErrorIs(t, inEffectErr, ErrRevisionInEffect)
+	assert.ErrorIs(t, missingErr, ErrRevisionNotFound)
+	assert.Equal(t, StatusCancelled, cancelled.Status)
+	assert.Equal(t, "admin2", cancelled.CancelledBy)
+
+	history, err := store.History(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, pending.ID, history[0].ID)
+	assert.Equal(t, StatusCancelled, history[0].Status)
+	assert.Equal(t, StatusPending, history[1].Status)
+	assert.Equal(t, StatusActive, history[2].Status)
+
+	limited, err := store.History(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+}
@@ -0,0 +1,522 @@
+// Package thresholds manages the runtime thresholds used by the ACMG/AMP rule
+// engine: population frequency cutoffs, in silico predictor thresholds and
+// per-gene disease models. Every change is stored as a revision with an
+// effective date, so updates can be scheduled ahead of time and audited later.
+package thresholds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrRevisionNotFound is returned when a revision ID does not exist.
+	ErrRevisionNotFound = errors.New("threshold revision not found")
+
+	// ErrRevisionInEffect is returned when cancelling a revision that has already taken effect.
+	ErrRevisionInEffect = errors.New("threshold revision has already taken effect")
+
+	// ErrBackdated is returned when a revision is scheduled with an effective date in the past.
+	ErrBackdated = errors.New("effective date cannot be in the past")
+)
+
+// Disease mechanisms for GeneDiseaseModel.Mechanism.
+const (
+	MechanismLossOfFunction   = "loss_of_function"
+	MechanismGainOfFunction   = "gain_of_function"
+	MechanismDominantNegative = "dominant_negative"
+	MechanismUnknown          = "unknown"
+)
+
+// Mechanisms lists the supported disease mechanisms.
+var Mechanisms = []string{MechanismLossOfFunction, MechanismGainOfFunction, MechanismDominantNegative, MechanismUnknown}
+
+// Inheritances lists the supported modes of inheritance.
+var Inheritances = []string{"AD", "AR", "XLD", "XLR", "MT", "unknown"}
+
+// Penetrances for GeneDiseaseModel.Penetrance.
+const (
+	PenetranceComplete     = "complete"
+	PenetranceReduced      = "reduced"
+	PenetranceAgeDependent = "age_dependent"
+	PenetranceUnknown      = "unknown"
+)
+
+// Penetrances lists the supported penetrances.
+var Penetrances = []string{PenetranceComplete, PenetranceReduced, PenetranceAgeDependent, PenetranceUnknown}
+
+// Revision statuses reported by History.
+const (
+	StatusActive     = "active"
+	StatusPending    = "pending"
+	StatusSuperseded = "superseded"
+	StatusCancelled  = "cancelled"
+)
+
+// GeneDiseaseModel describes how variants in a gene cause disease.
+type GeneDiseaseModel struct {
+	Disease     string `json:"disease,omitempty"`
+	Inheritance string `json:"inheritance"`
+	Mechanism   string `json:"mechanism"`
+	Penetrance  string `json:"penetrance,omitempty"` // complete, reduced, age_dependent or unknown; BS2 needs complete for dominant disorders
+}
+
+// PredictorThresholds are the in silico score cutoffs used by PP3, BP4 and BP7.
+// The splice cutoffs are SpliceAI/Pangolin delta scores. Zero splice, REVEL and
+// AlphaMissense cutoffs mean the default.
+type PredictorThresholds struct {
+	CADDDeleterious          float64 `json:"cadd_deleterious"`                    // PP3 at or above
+	CADDBenign               float64 `json:"cadd_benign"`                         // BP4 at or below
+	SIFTDeleterious          float64 `json:"sift_deleterious"`                    // PP3 at or below
+	PolyPhenDeleterious      float64 `json:"polyphen_deleterious"`                // PP3 at or above
+	PolyPhenBenign           float64 `json:"polyphen_benign"`                     // BP4 at or below
+	SpliceDeleterious        float64 `json:"splice_deleterious,omitempty"`        // PP3 at or above
+	SpliceBenign             float64 `json:"splice_benign,omitempty"`             // BP4/BP7 at or below
+	REVELDeleterious         float64 `json:"revel_deleterious,omitempty"`         // PP3 at or above
+	REVELBenign              float64 `json:"revel_benign,omitempty"`              // BP4 at or below
+	AlphaMissenseDeleterious float64 `json:"alphamissense_deleterious,omitempty"` // PP3 at or above
+	AlphaMissenseBenign      float64 `json:"alphamissense_benign,omitempty"`      // BP4 below
+}
+
+// Default SpliceAI/Pangolin delta score cutoffs (ClinGen SVI splicing
+// subgroup, Walker et al. 2023)
+const (
+	DefaultSpliceDeleterious = 0.2
+	DefaultSpliceBenign      = 0.1
+)
+
+// Default REVEL cutoffs (ClinGen SVI calibration, Pejaver et al. 2022) and
+// AlphaMissense likely pathogenic/likely benign class cutoffs (Cheng et al. 2023)
+const (
+	DefaultREVELDeleterious         = 0.644
+	DefaultREVELBenign              = 0.290
+	DefaultAlphaMissenseDeleterious = 0.564
+	DefaultAlphaMissenseBenign      = 0.34
+)
+
+// SpliceCutoffs returns the splice-altering and no-impact delta score cutoffs,
+// falling back to the defaults for revisions saved before they existed.
+func (p PredictorThresholds) SpliceCutoffs() (deleterious, benign float64) {
+	deleterious, benign = p.SpliceDeleterious, p.SpliceBenign
+	if deleterious == 0 {
+		deleterious = DefaultSpliceDeleterious
+	}
+	if benign == 0 {
+		benign = DefaultSpliceBenign
+	}
+	return deleterious, benign
+}
+
+// REVELCutoffs returns the REVEL deleterious and benign cutoffs, falling back
+// to the defaults for revisions saved before they existed.
+func (p PredictorThresholds) REVELCutoffs() (deleterious, benign float64) {
+	deleterious, benign = p.REVELDeleterious, p.REVELBenign
+	if deleterious == 0 {
+		deleterious = DefaultREVELDeleterious
+	}
+	if benign == 0 {
+		benign = DefaultREVELBenign
+	}
+	return deleterious, benign
+}
+
+// AlphaMissenseCutoffs returns the AlphaMissense deleterious and benign
+// cutoffs, falling back to the defaults for revisions saved before they existed.
+func (p PredictorThresholds) AlphaMissenseCutoffs() (deleterious, benign float64) {
+	deleterious, benign = p.AlphaMissenseDeleterious, p.AlphaMissenseBenign
+	if deleterious == 0 {
+		deleterious = DefaultAlphaMissenseDeleterious
+	}
+	if benign == 0 {
+		benign = DefaultAlphaMissenseBenign
+	}
+	return deleterious, benign
+}
+
+// DomainThresholds are the benign variation density checks PM1 applies to
+// a protein domain or hotspot. Zero values mean the default.
+type DomainThresholds struct {
+	MaxBenignDensity      float64 `json:"max_benign_density,omitempty"`      // Benign missense variants per residue, at most
+	MinPathogenicMissense int     `json:"min_pathogenic_missense,omitempty"` // Pathogenic missense variants, at least
+}
+
+// Default PM1 domain cutoffs: at most one benign missense variant per 100
+// residues, and at least two pathogenic missense variants
+const (
+	DefaultMaxBenignDensity      = 0.01
+	DefaultMinPathogenicMissense = 2
+)
+
+// Cutoffs returns the benign density and pathogenic count cutoffs, falling
+// back to the defaults for revisions saved before they existed.
+func (d DomainThresholds) Cutoffs() (maxBenignDensity float64, minPathogenic int) {
+	maxBenignDensity, minPathogenic = d.MaxBenignDensity, d.MinPathogenicMissense
+	if maxBenignDensity == 0 {
+		maxBenignDensity = DefaultMaxBenignDensity
+	}
+	if minPathogenic == 0 {
+		minPathogenic = DefaultMinPathogenicMissense
+	}
+	return maxBenignDensity, minPathogenic
+}
+
+// SegregationThresholds are the LOD score cutoffs for PP1 and BS4. Zero
+// values mean the default.
+type SegregationThresholds struct {
+	SupportingLOD float64 `json:"supporting_lod,omitempty"` // PP1 supporting at or above
+	ModerateLOD   float64 `json:"moderate_lod,omitempty"`   // PP1 moderate at or above
+	StrongLOD     float64 `json:"strong_lod,omitempty"`     // PP1 strong at or above
+	BS4LOD        float64 `json:"bs4_lod,omitempty"`        // BS4 at or below; negative
+}
+
+// Default PP1 cutoffs (Jarvik and Browning 2016: likelihood ratios of 8, 16
+// and 32, about 3, 4 and 5 cosegregating meioses) and the BS4 cutoff, the
+// conventional LOD of -2 for excluding linkage
+const (
+	DefaultSupportingLOD = 0.9
+	DefaultModerateLOD   = 1.2
+	DefaultStrongLOD     = 1.5
+	DefaultBS4LOD        = -2.0
+)
+
+// Cutoffs returns the PP1 and BS4 LOD cutoffs, falling back to the defaults
+// for revisions saved before they existed.
+func (s SegregationThresholds) Cutoffs() (supporting, moderate, strong, bs4 float64) {
+	supporting, moderate, strong, bs4 = s.SupportingLOD, s.ModerateLOD, s.StrongLOD, s.BS4LOD
+	if supporting == 0 {
+		supporting = DefaultSupportingLOD
+	}
+	if moderate == 0 {
+		moderate = DefaultModerateLOD
+	}
+	if strong == 0 {
+		strong = DefaultStrongLOD
+	}
+	if bs4 == 0 {
+		bs4 = DefaultBS4LOD
+	}
+	return supporting, moderate, strong, bs4
+}
+
+// ConstraintThresholds are the gnomAD gene constraint cutoffs used by PVS1,
+// PP2 and BP1. Zero values mean the default.
+type ConstraintThresholds struct {
+	PLIIntolerant        float64 `json:"pli_intolerant,omitempty"`         // LoF intolerant at or above
+	LOEUFIntolerant      float64 `json:"loeuf_intolerant,omitempty"`       // LoF intolerant below
+	MissenseZConstrained float64 `json:"missense_z_constrained,omitempty"` // Missense constrained at or above (PP2); BP1 below
+}
+
+// Default constraint cutoffs: pLI 0.9 and LOEUF 0.6 (gnomAD v4 guidance for
+// LoF intolerance) and a missense Z of 3.09 (p < 0.001, Samocha et al. 2014)
+const (
+	DefaultPLIIntolerant        = 0.9
+	DefaultLOEUFIntolerant      = 0.6
+	DefaultMissenseZConstrained = 3.09
+)
+
+// Cutoffs returns the pLI, LOEUF and missense Z cutoffs, falling back to the
+// defaults for revisions saved before they existed.
+func (c ConstraintThresholds) Cutoffs() (pli, loeuf, missenseZ float64) {
+	pli, loeuf, missenseZ = c.PLIIntolerant, c.LOEUFIntolerant, c.MissenseZConstrained
+	if pli == 0 {
+		pli = DefaultPLIIntolerant
+	}
+	if loeuf == 0 {
+		loeuf = DefaultLOEUFIntolerant
+	}
+	if missenseZ == 0 {
+		missenseZ = DefaultMissenseZConstrained
+	}
+	return pli, loeuf, missenseZ
+}
+
+// PhenotypeThresholds are the HPO phenotype match cutoffs used by PP4.
+// Zero values mean the default.
+type PhenotypeThresholds struct {
+	PP4Similarity float64 `json:"pp4_similarity,omitempty"` // Apply PP4 at or above this phenotype similarity, 0 to 1
+}
+
+// DefaultPP4Similarity applies PP4 when the gene's phenotypes explain at
+// least half the information in the patient's HPO terms
+const DefaultPP4Similarity = 0.5
+
+// Cutoff returns the PP4 similarity cutoff, falling back to the default for
+// revisions saved before it existed.
+func (p PhenotypeThresholds) Cutoff() float64 {
+	if p.PP4Similarity == 0 {
+		return DefaultPP4Similarity
+	}
+	return p.PP4Similarity
+}
+
+// CaseCountThresholds are the unrelated affected proband counts at which PS4
+// applies to a rare variant, from the lab's own observations. Zero values
+// mean the default.
+type CaseCountThresholds struct {
+	PS4SupportingProbands int `json:"ps4_supporting_probands,omitempty"`
+	PS4ModerateProbands   int `json:"ps4_moderate_probands,omitempty"`
+	PS4StrongProbands     int `json:"ps4_strong_probands,omitempty"`
+}
+
+// Default proband counts, as the ClinGen cardiomyopathy expert panel counts
+// probands for PS4 (Kelly et al. 2018): 2 for supporting, 6 for moderate and
+// 15 for strong
+const (
+	DefaultPS4SupportingProbands = 2
+	DefaultPS4ModerateProbands   = 6
+	DefaultPS4StrongProbands     = 15
+)
+
+// Cutoffs returns the supporting, moderate and strong proband counts,
+// falling back to the defaults for revisions saved before they existed.
+func (c CaseCountThresholds) Cutoffs() (supporting, moderate, strong int) {
+	supporting, moderate, strong = c.PS4SupportingProbands, c.PS4ModerateProbands, c.PS4StrongProbands
+	if supporting == 0 {
+		supporting = DefaultPS4SupportingProbands
+	}
+	if moderate == 0 {
+		moderate = DefaultPS4ModerateProbands
+	}
+	if strong == 0 {
+		strong = DefaultPS4StrongProbands
+	}
+	return supporting, moderate, strong
+}
+
+// HealthyAdultThresholds are the BS2 counts of unaffected individuals with a
+// genotype that would cause a fully penetrant disorder. Zero values mean the
+// default.
+type HealthyAdultThresholds struct {
+	BS2Homozygotes   int `json:"bs2_homozygotes,omitempty"`   // Recessive: homozygotes, plus hemizygotes for X-linked disorders, at or above
+	BS2Heterozygotes int `json:"bs2_heterozygotes,omitempty"` // Dominant: heterozygotes at or above
+}
+
+// Default BS2 counts: two homozygotes for a recessive disorder, as several
+// ClinGen expert panels require (e.g. hearing loss, Oza et al. 2018), and
+// three heterozygotes for a dominant one (RASopathy, Gelb et al. 2018)
+const (
+	DefaultBS2Homozygotes   = 2
+	DefaultBS2Heterozygotes = 3
+)
+
+// Cutoffs returns the recessive and dominant counts, falling back to the
+// defaults for revisions saved before they existed.
+func (h HealthyAdultThresholds) Cutoffs() (homozygotes, heterozygotes int) {
+	homozygotes, heterozygotes = h.BS2Homozygotes, h.BS2Heterozygotes
+	if homozygotes == 0 {
+		homozygotes = DefaultBS2Homozygotes
+	}
+	if heterozygotes == 0 {
+		heterozygotes = DefaultBS2Heterozygotes
+	}
+	return homozygotes, heterozygotes
+}
+
+// Thresholds is a complete set of rule engine thresholds.
+type Thresholds struct {
+	BA1AlleleFrequency float64                     `json:"ba1_allele_frequency"` // Stand-alone benign above
+	BS1AlleleFrequency float64                     `json:"bs1_allele_frequency"` // Strong benign above
+	PM2AlleleFrequency float64                     `json:"pm2_allele_frequency"` // Moderate pathogenic below
+	Predictors         PredictorThresholds         `json:"predictors"`
+	Domains            DomainThresholds            `json:"domains"`               // PM1
+	Segregation        SegregationThresholds       `json:"segregation"`           // PP1 and BS4
+	Constraint         ConstraintThresholds        `json:"constraint"`            // PVS1, PP2 and BP1
+	Phenotype          PhenotypeThresholds         `json:"phenotype"`             // PP4
+	CaseCounts         CaseCountThresholds         `json:"case_counts"`           // PS4
+	HealthyAdults      HealthyAdultThresholds      `json:"healthy_adults"`        // BS2
+	GeneModels         map[string]GeneDiseaseModel `json:"gene_models,omitempty"` // Keyed by upper-case gene symbol
+}
+
+// Defaults returns the thresholds used when no revision is in effect.
+func Defaults() Thresholds {
+	return Thresholds{
+		BA1AlleleFrequency: 0.05,
+		BS1AlleleFrequency: 0.01,
+		PM2AlleleFrequency: 0.0001,
+		Predictors: PredictorThresholds{
+			CADDDeleterious:          25.3,
+			CADDBenign:               22.7,
+			SIFTDeleterious:          0.05,
+			PolyPhenDeleterious:      0.909,
+			PolyPhenBenign:           0.446,
+			SpliceDeleterious:        DefaultSpliceDeleterious,
+			SpliceBenign:             DefaultSpliceBenign,
+			REVELDeleterious:         DefaultREVELDeleterious,
+			REVELBenign:              DefaultREVELBenign,
+			AlphaMissenseDeleterious: DefaultAlphaMissenseDeleterious,
+			AlphaMissenseBenign:      DefaultAlphaMissenseBenign,
+		},
+		Domains: DomainThresholds{
+			MaxBenignDensity:      DefaultMaxBenignDensity,
+			MinPathogenicMissense: DefaultMinPathogenicMissense,
+		},
+		Segregation: SegregationThresholds{
+			SupportingLOD: DefaultSupportingLOD,
+			ModerateLOD:   DefaultModerateLOD,
+			StrongLOD:     DefaultStrongLOD,
+			BS4LOD:        DefaultBS4LOD,
+		},
+		Constraint: ConstraintThresholds{
+			PLIIntolerant:        DefaultPLIIntolerant,
+			LOEUFIntolerant:      DefaultLOEUFIntolerant,
+			MissenseZConstrained: DefaultMissenseZConstrained,
+		},
+		Phenotype: PhenotypeThresholds{
+			PP4Similarity: DefaultPP4Similarity,
+		},
+		CaseCounts: CaseCountThresholds{
+			PS4SupportingProbands: DefaultPS4SupportingProbands,
+			PS4ModerateProbands:   DefaultPS4ModerateProbands,
+			PS4StrongProbands:     DefaultPS4StrongProbands,
+		},
+		HealthyAdults: HealthyAdultThresholds{
+			BS2Homozygotes:   DefaultBS2Homozygotes,
+			BS2Heterozygotes: DefaultBS2Heterozygotes,
+		},
+	}
+}
+
+// Validate checks that the thresholds are internally consistent.
+func (t Thresholds) Validate() error {
+	for name, f := range map[string]float64{
+		"ba1_allele_frequency": t.BA1AlleleFrequency,
+		"bs1_allele_frequency": t.BS1AlleleFrequency,
+		"pm2_allele_frequency": t.PM2AlleleFrequency,
+	} {
+		if f <= 0 || f > 1 {
+			return fmt.Errorf("%s must be in (0, 1], got %g", name, f)
+		}
+	}
+	if t.PM2AlleleFrequency >= t.BS1AlleleFrequency {
+		return fmt.Errorf("pm2_allele_frequency must be below bs1_allele_frequency")
+	}
+	if t.BS1AlleleFrequency > t.BA1AlleleFrequency {
+		return fmt.Errorf("bs1_allele_frequency must not exceed ba1_allele_frequency")
+	}
+
+	p := t.Predictors
+	if p.CADDBenign >= p.CADDDeleterious {
+		return fmt.Errorf("predictors.cadd_benign must be below predictors.cadd_deleterious")
+	}
+	if p.SIFTDeleterious < 0 || p.SIFTDeleterious > 1 {
+		return fmt.Errorf("predictors.sift_deleterious must be in [0, 1]")
+	}
+	if p.PolyPhenBenign < 0 || p.PolyPhenDeleterious > 1 || p.PolyPhenBenign >= p.PolyPhenDeleterious {
+		return fmt.Errorf("predictors.polyphen_benign must be below predictors.polyphen_deleterious, both in [0, 1]")
+	}
+	spliceDeleterious, spliceBenign := p.SpliceCutoffs()
+	if spliceBenign < 0 || spliceDeleterious > 1 || spliceBenign >= spliceDeleterious {
+		return fmt.Errorf("predictors.splice_benign must be below predictors.splice_deleterious, both in [0, 1]")
+	}
+	revelDeleterious, revelBenign := p.REVELCutoffs()
+	if revelBenign < 0 || revelDeleterious > 1 || revelBenign >= revelDeleterious {
+		return fmt.Errorf("predictors.revel_benign must be below predictors.revel_deleterious, both in [0, 1]")
+	}
+	amDeleterious, amBenign := p.AlphaMissenseCutoffs()
+	if amBenign < 0 || amDeleterious > 1 || amBenign >= amDeleterious {
+		return fmt.Errorf("predictors.alphamissense_benign must be below predictors.alphamissense_deleterious, both in [0, 1]")
+	}
+	if t.Domains.MaxBenignDensity < 0 || t.Domains.MaxBenignDensity > 1 {
+		return fmt.Errorf("domains.max_benign_density must be in [0, 1]")
+	}
+	if t.Domains.MinPathogenicMissense < 0 {
+		return fmt.Errorf("domains.min_pathogenic_missense must not be negative")
+	}
+	supporting, moderate, strong, bs4 := t.Segregation.Cutoffs()
+	if supporting <= 0 || moderate < supporting || strong < moderate {
+		return fmt.Errorf("segregation LOD cutoffs must be positive with supporting_lod <= moderate_lod <= strong_lod")
+	}
+	if bs4 >= 0 {
+		return fmt.Errorf("segregation.bs4_lod must be negative")
+	}
+	pli, loeuf, missenseZ := t.Constraint.Cutoffs()
+	if pli < 0 || pli > 1 {
+		return fmt.Errorf("constraint.pli_intolerant must be in [0, 1]")
+	}
+	if loeuf < 0 || missenseZ < 0 {
+		return fmt.Errorf("constraint.loeuf_intolerant and constraint.missense_z_constrained must not be negative")
+	}
+	if similarity := t.Phenotype.Cutoff(); similarity < 0 || similarity > 1 {
+		return fmt.Errorf("phenotype.pp4_similarity must be in [0, 1]")
+	}
+	if supporting, moderate, strong := t.CaseCounts.Cutoffs(); supporting < 1 || moderate < supporting || strong < moderate {
+		return fmt.Errorf("case count cutoffs must be positive with ps4_supporting_probands <= ps4_moderate_probands <= ps4_strong_probands")
+	}
+	if t.HealthyAdults.BS2Homozygotes < 0 || t.HealthyAdults.BS2Heterozygotes < 0 {
+		return fmt.Errorf("healthy_adults.bs2_homozygotes and healthy_adults.bs2_heterozygotes must not be negative")
+	}
+
+	for gene, model := range t.GeneModels {
+		if !contains(Mechanisms, model.Mechanism) {
+			return fmt.Errorf("gene_models.%s: mechanism must be one of: %s", gene, strings.Join(Mechanisms, ", "))
+		}
+		if !contains(Inheritances, model.Inheritance) {
+			return fmt.Errorf("gene_models.%s: inheritance must be one of: %s", gene, strings.Join(Inheritances, ", "))
+		}
+		if model.Penetrance != "" && !contains(Penetrances, model.Penetrance) {
+			return fmt.Errorf("gene_models.%s: penetrance must be one of: %s", gene, strings.Join(Penetrances, ", "))
+		}
+	}
+	return nil
+}
+
+// GeneModel returns the disease model configured for a gene, if any.
+func (t Thresholds) GeneModel(geneSymbol string) (GeneDiseaseModel, bool) {
+	model, ok := t.GeneModels[strings.ToUpper(strings.TrimSpace(geneSymbol))]
+	return model, ok
+}
+
+// normalize upper-cases gene model keys.
+func (t *Thresholds) normalize() {
+	if len(t.GeneModels) == 0 {
+		return
+	}
+	models := make(map[string]GeneDiseaseModel, len(t.GeneModels))
+	for gene, model := range t.GeneModels {
+		models[strings.ToUpper(strings.TrimSpace(gene))] = model
+	}
+	t.GeneModels = models
+}
+
+// Revision is a scheduled or applied change to the thresholds.
+type Revision struct {
+	ID            int64      `json:"id"`
+	Thresholds    Thresholds `json:"thresholds"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	Reason        string     `json:"reason"`
+	CreatedBy     string     `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CancelledBy   string     `json:"cancelled_by,omitempty"`
+	CancelledAt   *time.Time `json:"cancelled_at,omitempty"`
+	Status        string     `json:"status,omitempty"` // Set by History
+}
+
+// Store defines the interface for threshold revision storage.
+type Store interface {
+	// Schedule validates and stores a new revision. A zero EffectiveFrom means now.
+	Schedule(ctx context.Context, revision *Revision) error
+
+	// Effective returns the revision in effect at the given time, or nil if none.
+	Effective(ctx context.Context, at time.Time) (*Revision, error)
+
+	// History returns revisions, most recent effective date first, with their status.
+	History(ctx context.Context, limit int) ([]*Revision, error)
+
+	// Cancel withdraws a revision that has not yet taken effect.
+	Cancel(ctx context.Context, id int64, cancelledBy string) (*Revision, error)
+
+	// Close closes the store and releases resources.
+	Close() error
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+// Package tracing exports the OpenTelemetry spans recorded through the
+// classification pipeline to a collector over OTLP/HTTP, and reports a
+// call's trace in MCP response metadata. Spans are started with the
+// OpenTelemetry API; span context follows W3C Trace Context, so traces join
+// those of clients passing a traceparent.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// shutdownTimeout bounds the export of queued spans once tracing stops
+const shutdownTimeout = 10 * time.Second
+
+// Config configures tracing
+type Config struct {
+	Endpoint    string  // OTLP/HTTP collector base URL, e.g. http://localhost:4318
+	ServiceName string  // Reported as the service.name resource attribute
+	SampleRatio float64 // Fraction of new traces recorded; traces continued from a caller follow its decision
+}
+
+// Install installs a tracer provider exporting over OTLP/HTTP as the global
+// OpenTelemetry provider, with the W3C Trace Context propagator. When ctx is
+// cancelled, queued spans are flushed and the provider is shut down.
+func Install(ctx context.Context, cfg Config, logger *logrus.Logger) error {
+	endpoint, err := tracesURL(cfg.Endpoint)
+	if err != nil {
+		return err
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	logger.WithFields(logrus.Fields{
+		"endpoint":     endpoint,
+		"sample_ratio": cfg.SampleRatio,
+	}).Info("Exporting traces over OTLP")
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(shutdownCtx); err != nil {
+			logger.WithError(err).Warn("Failed to flush traces")
+		}
+	}()
+	return nil
+}
+
+// tracesURL returns the collector's traces URL; spans are posted to
+// endpoint/v1/traces unless it already names that path
+func tracesURL(endpoint string) (string, error) {
+	u, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid OTLP endpoint %q", endpoint)
+	}
+	if !strings.HasSuffix(u.Path, "/v1/traces") {
+		u.Path += "/v1/traces"
+	}
+	return u.String(), nil
+}
+
+// Metadata returns the span's trace identifiers for response metadata, or
+// nil when the span has no valid span context, as while tracing is disabled
+func Metadata(span trace.Span) map[string]interface{} {
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(trace.ContextWithSpanContext(context.Background(), sc), carrier)
+	return map[string]interface{}{
+		"traceId":     sc.TraceID().String(),
+		"traceparent": carrier.Get("traceparent"),
+	}
+}
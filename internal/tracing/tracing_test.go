@@ -0,0 +1,119 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const callerTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// withTraceparent returns a context continuing the trace of a caller
+func withTraceparent(traceparent string) context.Context {
+	return propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{"traceparent": traceparent})
+}
+
+func TestMetadata_Disabled(t *testing.T) {
+	_, span := noop.NewTracerProvider().Tracer("test").Start(context.Background(), "disabled")
+	assert.Nil(t, Metadata(span))
+}
+
+func TestMetadata_ContinuesCallerTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := provider.Tracer("test")
+
+	// Act
+	ctx, root := tracer.Start(withTraceparent(callerTraceparent), "tools/call classify_variant")
+	_, child := tracer.Start(ctx, "external.clinvar")
+	child.End()
+	root.End()
+
+	// Assert
+	meta := Metadata(root)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", meta["traceId"])
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+root.SpanContext().SpanID().String()+"-01", meta["traceparent"])
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "00f067aa0ba902b7", spans[1].Parent().SpanID().String())
+	assert.Equal(t, root.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
+
+func TestMetadata_Unsampled(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(recorder),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0))),
+	)
+
+	// New traces are dropped, but their IDs still propagate
+	_, span := provider.Tracer("test").Start(context.Background(), "unsampled")
+	span.End()
+
+	assert.Empty(t, recorder.Ended())
+	meta := Metadata(span)
+	require.NotNil(t, meta)
+	assert.Equal(t, span.SpanContext().TraceID().String(), meta["traceId"])
+	assert.Contains(t, meta["traceparent"], "-00")
+}
+
+func TestInstall(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case requests <- r:
+		default:
+		}
+	}))
+	defer collector.Close()
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	logger, _ := test.NewNullLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, Install(ctx, Config{Endpoint: collector.URL, ServiceName: "acmg-test", SampleRatio: 1}, logger))
+
+	// Act: spans are flushed when the context is cancelled
+	_, span := otel.Tracer("test").Start(withTraceparent(callerTraceparent), "root")
+	span.End()
+	cancel()
+
+	// Assert
+	select {
+	case r := <-requests:
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no spans exported")
+	}
+	assert.Equal(t, trace.SpanContextFromContext(withTraceparent(callerTraceparent)).TraceID(), span.SpanContext().TraceID())
+}
+
+func TestInstall_InvalidEndpoint(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	assert.Error(t, Install(context.Background(), Config{Endpoint: "localhost"}, logger))
+}
+
+func TestTracesURL(t *testing.T) {
+	for endpoint, want := range map[string]string{
+		"http://localhost:4318":              "http://localhost:4318/v1/traces",
+		"http://localhost:4318/":             "http://localhost:4318/v1/traces",
+		"https://otel.example.org/v1/traces": "https://otel.example.org/v1/traces",
+	} {
+		got, err := tracesURL(endpoint)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, endpoint)
+	}
+}
@@ -0,0 +1,146 @@
+package transcriptset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Registry holds the loaded transcript sets, keyed by specialty.
+type Registry struct {
+	mu   sync.RWMutex
+	sets map[string]*Set
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{sets: make(map[string]*Set)}
+}
+
+// LoadDir loads every .json, .yaml and .yml transcript set in dir.
+// A missing directory yields an empty registry.
+func LoadDir(dir string) (*Registry, error) {
+	registry := NewRegistry()
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript set directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isSetFile(entry.Name()) {
+			continue
+		}
+		set, err := LoadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.Add(set); err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+	}
+
+	return registry, nil
+}
+
+// LoadFile reads and validates a single JSON or YAML transcript set.
+func LoadFile(path string) (*Set, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript set: %w", err)
+	}
+
+	var set Set
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &set)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &set)
+	default:
+		return nil, fmt.Errorf("unsupported transcript set format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transcript set %s: %w", filepath.Base(path), err)
+	}
+
+	if err := set.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	set.File = filepath.Base(path)
+	return &set, nil
+}
+
+// Add registers a transcript set. Each specialty may have only one set.
+func (r *Registry) Add(set *Set) error {
+	if err := set.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.sets[set.Specialty]; ok {
+		return fmt.Errorf("%w %s (already loaded from %s)", ErrDuplicateSpecialty, set.Specialty, existing.File)
+	}
+	r.sets[set.Specialty] = set
+	return nil
+}
+
+// Get returns the set for a specialty, or nil.
+func (r *Registry) Get(specialty string) *Set {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sets[NormalizeSpecialty(specialty)]
+}
+
+// Lookup returns the transcript a specialty mandates for a gene. It returns
+// "" when the specialty's set does not cover the gene, and
+// ErrUnknownSpecialty when no set is loaded for the specialty.
+func (r *Registry) Lookup(specialty, gene string) (string, error) {
+	set := r.Get(specialty)
+	if set == nil {
+		return "", fmt.Errorf("%w %q", ErrUnknownSpecialty, specialty)
+	}
+	return set.Transcript(gene), nil
+}
+
+// List returns all sets ordered by specialty.
+func (r *Registry) List() []*Set {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sets := make([]*Set, 0, len(r.sets))
+	for _, set := range r.sets {
+		sets = append(sets, set)
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i].Specialty < sets[j].Specialty })
+	return sets
+}
+
+// Specialties returns the configured specialties in alphabetical order.
+func (r *Registry) Specialties() []string {
+	sets := r.List()
+	specialties := make([]string, len(sets))
+	for i, set := range sets {
+		specialties[i] = set.Specialty
+	}
+	return specialties
+}
+
+func isSetFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package transcriptset
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSet(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func TestLoadDir_Examples(t *testing.T) {
+	registry, err := LoadDir(filepath.Join("..", "..", "examples", "transcript_sets"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"cardiology", "neurology", "oncology"}, registry.Specialties())
+
+	cardiology, err := registry.Lookup("Cardiology", "ttn")
+	require.NoError(t, err)
+	neurology, err := registry.Lookup("neurology", "TTN")
+	require.NoError(t, err)
+	assert.Equal(t, "NM_001267550.2", cardiology)
+	assert.Equal(t, "NM_133378.4", neurology)
+
+	brca1, err := registry.Lookup("oncology", "BRCA1")
+	require.NoError(t, err)
+	assert.Equal(t, "NM_007294.4", brca1)
+	assert.Equal(t, "oncology.json", registry.Get("oncology").File)
+}
+
+func TestLoadDir_MissingDirectory(t *testing.T) {
+	registry, err := LoadDir(filepath.Join(t.TempDir(), "missing"))
+
+	require.NoError(t, err)
+	assert.Empty(t, registry.List())
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Add(&Set{Specialty: "Cardiology", Transcripts: map[string]string{"myh7": "NM_000257.4"}}))
+
+	transcript, err := registry.Lookup("cardiology", "BRCA1")
+	require.NoError(t, err)
+	assert.Empty(t, transcript, "gene not covered by the set")
+
+	_, err = registry.Lookup("dermatology", "MYH7")
+	assert.True(t, errors.Is(err, ErrUnknownSpecialty))
+}
+
+func TestLoadDir_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    error
+	}{
+		{"missing specialty", "transcripts:\n  MYH7: NM_000257.4\n", ErrInvalidSet},
+		{"no transcripts", "specialty: cardiology\n", ErrInvalidSet},
+		{"not refseq", "specialty: cardiology\ntranscripts:\n  MYH7: ENST00000355349\n", ErrInvalidSet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeSet(t, dir, "set.yaml", tt.content)
+
+			_, err := LoadDir(dir)
+			assert.True(t, errors.Is(err, tt.want), "got %v", err)
+		})
+	}
+}
+
+func TestLoadDir_DuplicateSpecialty(t *testing.T) {
+	dir := t.TempDir()
+	writeSet(t, dir, "a.yaml", "specialty: cardiology\ntranscripts:\n  MYH7: NM_000257.4\n")
+	writeSet(t, dir, "b.json", `{"specialty": "Cardiology", "transcripts": {"TTN": "NM_001267550.2"}}`)
+
+	_, err := LoadDir(dir)
+	assert.True(t, errors.Is(err, ErrDuplicateSpecialty))
+}
+
+func TestSameTranscript(t *testing.T) {
+	assert.True(t, SameTranscript("NM_000257.4", "NM_000257.4"))
+	assert.True(t, SameTranscript("NM_000257", "NM_000257.4"))
+	assert.False(t, SameTranscript("NM_000257.3", "NM_000257.4"))
+	assert.False(t, SameTranscript("NM_001267550.2", "NM_133378.4"))
+}
+
+func TestNormalizeSpecialty(t *testing.T) {
+	assert.Equal(t, "cardiology", NormalizeSpecialty(" Cardiology "))
+	assert.Equal(t, "pediatric_neurology", NormalizeSpecialty("Pediatric-Neurology"))
+}
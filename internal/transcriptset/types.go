@@ -0,0 +1,95 @@
+// Package transcriptset loads clinically mandated transcript sets per ordering
+// specialty. Cardiology, oncology and neurology services may each mandate a
+// different transcript for the same gene; the specialty passed with a request
+// selects the set, so one gene can be interpreted on the transcript its
+// ordering context requires.
+package transcriptset
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrDuplicateSpecialty is returned when two sets cover the same specialty.
+	ErrDuplicateSpecialty = errors.New("duplicate transcript set for specialty")
+	// ErrInvalidSet is returned when a transcript set fails validation.
+	ErrInvalidSet = errors.New("invalid transcript set")
+	// ErrUnknownSpecialty is returned when no set is loaded for a specialty.
+	ErrUnknownSpecialty = errors.New("no transcript set for specialty")
+)
+
+var transcriptPattern = regexp.MustCompile(`^(NM_|NR_|XM_|XR_)\d+(\.\d+)?$`)
+
+// Set maps genes to the transcript mandated by one ordering specialty.
+type Set struct {
+	Specialty   string            `json:"specialty" yaml:"specialty"` // e.g. cardiology
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string            `json:"version,omitempty" yaml:"version,omitempty"`
+	Transcripts map[string]string `json:"transcripts" yaml:"transcripts"` // HGNC symbol -> RefSeq transcript
+	File        string            `json:"file,omitempty" yaml:"-"`        // File the set was loaded from
+}
+
+// Validate normalizes the set and checks it for errors.
+func (s *Set) Validate() error {
+	s.Specialty = NormalizeSpecialty(s.Specialty)
+	if s.Specialty == "" {
+		return fmt.Errorf("%w: specialty is required", ErrInvalidSet)
+	}
+	if len(s.Transcripts) == 0 {
+		return fmt.Errorf("%w: %s has no transcripts", ErrInvalidSet, s.Specialty)
+	}
+
+	normalized := make(map[string]string, len(s.Transcripts))
+	for gene, transcript := range s.Transcripts {
+		gene = strings.ToUpper(strings.TrimSpace(gene))
+		transcript = strings.TrimSpace(transcript)
+		if gene == "" {
+			return fmt.Errorf("%w: %s has an empty gene symbol", ErrInvalidSet, s.Specialty)
+		}
+		if !transcriptPattern.MatchString(transcript) {
+			return fmt.Errorf("%w: %s transcript %q for %s is not a RefSeq accession", ErrInvalidSet, s.Specialty, transcript, gene)
+		}
+		if _, ok := normalized[gene]; ok {
+			return fmt.Errorf("%w: %s lists %s more than once", ErrInvalidSet, s.Specialty, gene)
+		}
+		normalized[gene] = transcript
+	}
+	s.Transcripts = normalized
+	return nil
+}
+
+// Transcript returns the mandated transcript for a gene, or "".
+func (s *Set) Transcript(gene string) string {
+	return s.Transcripts[strings.ToUpper(strings.TrimSpace(gene))]
+}
+
+// Genes returns the genes covered by the set in alphabetical order.
+func (s *Set) Genes() []string {
+	genes := make([]string, 0, len(s.Transcripts))
+	for gene := range s.Transcripts {
+		genes = append(genes, gene)
+	}
+	sort.Strings(genes)
+	return genes
+}
+
+// NormalizeSpecialty lower-cases a specialty name and joins words with underscores.
+func NormalizeSpecialty(specialty string) string {
+	return strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(specialty, "-", " "))), "_")
+}
+
+// SameTranscript reports whether two RefSeq identifiers name the same
+// transcript, ignoring the version when either omits it.
+func SameTranscript(a, b string) bool {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if strings.EqualFold(a, b) {
+		return true
+	}
+	baseA, versionA, _ := strings.Cut(a, ".")
+	baseB, versionB, _ := strings.Cut(b, ".")
+	return strings.EqualFold(baseA, baseB) && (versionA == "" || versionB == "")
+}
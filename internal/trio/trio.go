@@ -0,0 +1,305 @@
+// Package trio infers the inheritance and phase of a proband's variants
+// from proband and parental genotypes. A variant absent from both parents
+// is de novo; one carried by a single parent was inherited from that
+// parent. Two variants are in trans when they came from different parents,
+// or when read-backed phasing puts them on different haplotypes of the same
+// phase set, and in cis when they came from the same parent or share a
+// haplotype. The result feeds PS2/PM6 (de novo) and PM3/BP2 (allelic data).
+package trio
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidGenotype is returned when a genotype cannot be parsed
+var ErrInvalidGenotype = errors.New("invalid genotype")
+
+// Zygosities of a genotype carrying the variant
+const (
+	Heterozygous = "heterozygous"
+	Homozygous   = "homozygous"
+	Hemizygous   = "hemizygous"
+)
+
+// Origins of the proband's variant
+const (
+	DeNovo         = "de_novo"
+	Maternal       = "maternal"
+	Paternal       = "paternal"
+	Inherited      = "inherited"  // Both parents carry it; the transmitting parent is unresolved
+	Biparental     = "biparental" // Homozygous, one copy from each parent
+	Unknown        = "unknown"    // A parental genotype needed to decide is missing
+	MendelianError = "mendelian_error"
+)
+
+// Phases of two variants in the proband
+const (
+	Trans        = "trans"
+	Cis          = "cis"
+	PhaseUnknown = "unknown"
+)
+
+// Assumptions every analysis relies on, reported with the result
+var baseAssumptions = []string{
+	"Inheritance is Mendelian and the stated mother and father are the biological parents",
+	"A parent called homozygous reference does not carry the variant; allele dropout or low coverage in a parent would make an inherited variant look de novo",
+	"Phase inferred from parental origin assumes no recombination between the variants, which holds for nearby variants in the same gene",
+}
+
+// Genotype is one sample's call at a variant
+type Genotype struct {
+	Missing      bool   // Not called, or the sample was not sequenced
+	AltCopies    int    // Copies of the variant allele: 0, 1 or 2
+	Ploidy       int    // 1 for hemizygous calls, else 2
+	Phased       bool   // Alleles are ordered, e.g. 0|1
+	AltHaplotype int    // For a phased heterozygous call, which haplotype (0 or 1) carries the variant
+	PhaseSet     string // VCF PS field; phased calls are only comparable within a phase set
+}
+
+// ParseGenotype parses a VCF GT value (0/1, 1|0, 1/1, 0/0, 1, ./.) or a
+// zygosity name (heterozygous, homozygous, hemizygous, hom_ref, absent).
+// Multi-allelic calls must be split into biallelic records first.
+func ParseGenotype(value string) (Genotype, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "", ".", "./.", ".|.", "missing", "unknown":
+		return Genotype{Missing: true}, nil
+	case "het", Heterozygous:
+		return Genotype{AltCopies: 1, Ploidy: 2}, nil
+	case "hom", "hom_alt", Homozygous:
+		return Genotype{AltCopies: 2, Ploidy: 2}, nil
+	case "hemi", Hemizygous:
+		return Genotype{AltCopies: 1, Ploidy: 1}, nil
+	case "hom_ref", "ref", "reference", "absent":
+		return Genotype{Ploidy: 2}, nil
+	}
+
+	separator := "/"
+	if strings.Contains(value, "|") {
+		separator = "|"
+	}
+	alleles := strings.Split(value, separator)
+	if len(alleles) > 2 {
+		return Genotype{}, fmt.Errorf("%w %q: only haploid and diploid calls are supported", ErrInvalidGenotype, value)
+	}
+	genotype := Genotype{Ploidy: len(alleles), Phased: separator == "|"}
+	for i, allele := range alleles {
+		if allele == "." {
+			return Genotype{Missing: true}, nil
+		}
+		index, err := strconv.Atoi(allele)
+		if err != nil || index < 0 {
+			return Genotype{}, fmt.Errorf("%w %q", ErrInvalidGenotype, value)
+		}
+		if index > 1 {
+			return Genotype{}, fmt.Errorf("%w %q: split multi-allelic records into biallelic ones", ErrInvalidGenotype, value)
+		}
+		if index == 1 {
+			genotype.AltCopies++
+			if genotype.Phased {
+				genotype.AltHaplotype = i
+			}
+		}
+	}
+	return genotype, nil
+}
+
+// Carries reports whether the sample was called with the variant
+func (g Genotype) Carries() bool {
+	return !g.Missing && g.AltCopies > 0
+}
+
+// Absent reports whether the sample was called without the variant
+func (g Genotype) Absent() bool {
+	return !g.Missing && g.AltCopies == 0
+}
+
+// Zygosity names the call, or "" when the sample does not carry the variant
+func (g Genotype) Zygosity() string {
+	switch {
+	case !g.Carries():
+		return ""
+	case g.Ploidy == 1:
+		return Hemizygous
+	case g.AltCopies == 2:
+		return Homozygous
+	}
+	return Heterozygous
+}
+
+// Variant is a variant called in the proband with the trio's genotypes
+type Variant struct {
+	Name    string // HGVS or other display name
+	Proband Genotype
+	Mother  Genotype
+	Father  Genotype
+}
+
+// Partner is another variant of the proband in the same gene
+type Partner struct {
+	Variant
+	Classification string
+}
+
+// Analysis is the inheritance of a variant and its phase with the
+// proband's other variants in the gene
+type Analysis struct {
+	Variant     string            `json:"variant"`
+	Zygosity    string            `json:"zygosity"`
+	Inheritance string            `json:"inheritance"` // de_novo, maternal, paternal, inherited, biparental, unknown or mendelian_error
+	Partners    []PartnerAnalysis `json:"partner_variants,omitempty"`
+	Assumptions []string          `json:"assumptions"`
+	Warnings    []string          `json:"warnings,omitempty"`
+}
+
+// PartnerAnalysis is the inheritance of another variant and its phase
+// relative to the analyzed one
+type PartnerAnalysis struct {
+	Variant        string `json:"variant"`
+	Classification string `json:"classification"`
+	Inheritance    string `json:"inheritance"`
+	Phase          string `json:"phase"` // trans, cis or unknown
+	Basis          string `json:"basis"` // How the phase was determined
+}
+
+// Analyze determines the inheritance of the variant and its phase with each
+// partner. The proband must carry every variant.
+func Analyze(variant Variant, partners []Partner) (*Analysis, error) {
+	if !variant.Proband.Carries() {
+		return nil, fmt.Errorf("proband does not carry %s", variant.Name)
+	}
+	analysis := &Analysis{
+		Variant:     variant.Name,
+		Zygosity:    variant.Proband.Zygosity(),
+		Assumptions: append([]string(nil), baseAssumptions...),
+	}
+	var warning string
+	analysis.Inheritance, warning = Origin(variant)
+	if warning != "" {
+		analysis.Warnings = append(analysis.Warnings, variant.Name+": "+warning)
+	}
+
+	for _, partner := range partners {
+		if !partner.Proband.Carries() {
+			return nil, fmt.Errorf("proband does not carry %s", partner.Name)
+		}
+		inheritance, warning := Origin(partner.Variant)
+		if warning != "" {
+			analysis.Warnings = append(analysis.Warnings, partner.Name+": "+warning)
+		}
+		phase, basis := Phase(variant, analysis.Inheritance, partner.Variant, inheritance)
+		analysis.Partners = append(analysis.Partners, PartnerAnalysis{
+			Variant:        partner.Name,
+			Classification: partner.Classification,
+			Inheritance:    inheritance,
+			Phase:          phase,
+			Basis:          basis,
+		})
+	}
+	if len(partners) > 0 {
+		analysis.Assumptions = append(analysis.Assumptions,
+			"Read-backed phase is only compared between calls in the same phase set; calls phased without a PS field are taken to share one phase set",
+			"A de novo variant cannot be phased with an inherited one from genotypes alone; its phase stays unknown unless read-backed phasing resolves it")
+	}
+	return analysis, nil
+}
+
+// Origin infers which parent transmitted the proband's variant, with a
+// warning when the genotypes are not consistent with Mendelian inheritance.
+// A hemizygous variant is inherited from the mother or de novo.
+func Origin(v Variant) (string, string) {
+	mother, father := v.Mother, v.Father
+	switch v.Proband.Zygosity() {
+	case Hemizygous:
+		switch {
+		case mother.Carries():
+			return Maternal, ""
+		case mother.Absent():
+			return DeNovo, ""
+		}
+		return Unknown, "maternal genotype missing"
+	case Homozygous:
+		switch {
+		case mother.Carries() && father.Carries():
+			return Biparental, ""
+		case mother.Absent() || father.Absent():
+			return MendelianError, "homozygous in the proband but absent from a parent; consider a deletion of the other allele, uniparental disomy or a sample error"
+		}
+		return Unknown, "parental genotypes missing"
+	}
+
+	switch {
+	case mother.Carries() && father.Carries():
+		return Inherited, ""
+	case mother.Carries():
+		return Maternal, ""
+	case father.Carries():
+		return Paternal, ""
+	case mother.Absent() && father.Absent():
+		return DeNovo, ""
+	}
+	return Unknown, "parental genotypes missing"
+}
+
+// Phase determines whether two heterozygous variants of the proband are in
+// trans or in cis, preferring read-backed phasing within a phase set over
+// parental origin. Hemizygous variants share the single allele, so two of
+// them are in cis. A homozygous variant is on both alleles, so any other
+// variant is in trans with one copy of it.
+func Phase(a Variant, originA string, b Variant, originB string) (string, string) {
+	zygosityA, zygosityB := a.Proband.Zygosity(), b.Proband.Zygosity()
+	switch {
+	case zygosityA == Hemizygous && zygosityB == Hemizygous:
+		return Cis, "both hemizygous on the single allele"
+	case zygosityA == Hemizygous || zygosityB == Hemizygous:
+		return PhaseUnknown, "a hemizygous variant cannot be phased with a diploid call"
+	case zygosityA == Homozygous || zygosityB == Homozygous:
+		return Trans, "a homozygous variant is on both alleles"
+	}
+	if a.Proband.Phased && b.Proband.Phased && a.Proband.PhaseSet == b.Proband.PhaseSet {
+		basis := "read-backed phasing"
+		if a.Proband.PhaseSet != "" {
+			basis += " in phase set " + a.Proband.PhaseSet
+		}
+		if a.Proband.AltHaplotype == b.Proband.AltHaplotype {
+			return Cis, basis
+		}
+		return Trans, basis
+	}
+	return PhaseFromOrigin(originA, originB)
+}
+
+// PhaseFromOrigin determines the phase of two heterozygous variants from the
+// parents that transmitted them: trans from different parents, cis from the
+// same one. A de novo or unresolved origin leaves the phase unknown.
+func PhaseFromOrigin(originA, originB string) (string, string) {
+	parentOf := func(origin string) string {
+		if origin == Maternal || origin == Paternal {
+			return origin
+		}
+		return ""
+	}
+	parentA, parentB := parentOf(originA), parentOf(originB)
+	switch {
+	case parentA != "" && parentB != "" && parentA != parentB:
+		return Trans, "inherited from different parents"
+	case parentA != "" && parentA == parentB:
+		return Cis, "both inherited from the " + parentName(parentA)
+	case originA == DeNovo && originB == DeNovo:
+		return PhaseUnknown, "both de novo; phase needs read-backed phasing"
+	case originA == DeNovo || originB == DeNovo:
+		return PhaseUnknown, "one variant is de novo; phase needs read-backed phasing"
+	}
+	return PhaseUnknown, "parental origin unresolved"
+}
+
+// parentName names the transmitting parent of an origin
+func parentName(origin string) string {
+	if origin == Maternal {
+		return "mother"
+	}
+	return "father"
+}
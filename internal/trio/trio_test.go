@@ -0,0 +1,158 @@
+package trio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+func genotype(t *testing.T, value string) Genotype {
+	t.Helper()
+	g, err := ParseGenotype(value)
+	require.NoError(t, err)
+	return g
+}
+
+func TestParseGenotype(t *testing.T) {
+	assert.Equal(t, Genotype{AltCopies: 1, Ploidy: 2}, genotype(t, "0/1"))
+	assert.Equal(t, Genotype{AltCopies: 1, Ploidy: 2, Phased: true}, genotype(t, "1|0"))
+	assert.Equal(t, Genotype{AltCopies: 1, Ploidy: 2, Phased: true, AltHaplotype: 1}, genotype(t, "0|1"))
+	assert.Equal(t, Homozygous, genotype(t, "1/1").Zygosity())
+	assert.Equal(t, Hemizygous, genotype(t, "1").Zygosity())
+	assert.Equal(t, Heterozygous, genotype(t, "Het").Zygosity())
+	assert.True(t, genotype(t, "0/0").Absent())
+	assert.True(t, genotype(t, "absent").Absent())
+	assert.True(t, genotype(t, "./.").Missing)
+	assert.True(t, genotype(t, "").Missing)
+	assert.False(t, genotype(t, "./.").Absent(), "a missing call is not an absent variant")
+
+	for _, value := range []string{"0/2", "0/1/1", "a/b", "compound"} {
+		_, err := ParseGenotype(value)
+		assert.ErrorIs(t, err, ErrInvalidGenotype, value)
+	}
+}
+
+func TestOrigin(t *testing.T) {
+	tests := []struct {
+		proband, mother, father string
+		origin                  string
+		warns                   bool
+	}{
+		{"0/1", "0/0", "0/0", DeNovo, false},
+		{"0/1", "0/1", "0/0", Maternal, false},
+		{"0/1", "./.", "0/1", Paternal, false},
+		{"0/1", "0/1", "1/1", Inherited, false},
+		{"0/1", "0/0", "./.", Unknown, true},
+		{"1/1", "0/1", "0/1", Biparental, false},
+		{"1/1", "0/1", "0/0", MendelianError, true},
+		{"1", "0/1", "0/0", Maternal, false},
+		{"1", "0/0", "1", DeNovo, false},
+	}
+	for _, tt := range tests {
+		origin, warning := Origin(Variant{Proband: genotype(t, tt.proband), Mother: genotype(t, tt.mother), Father: genotype(t, tt.father)})
+		assert.Equal(t, tt.origin, origin, "%s %s %s", tt.proband, tt.mother, tt.father)
+		assert.Equal(t, tt.warns, warning != "", "%s %s %s", tt.proband, tt.mother, tt.father)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	target := Variant{Name: "CFTR:p.Arg117His", Proband: genotype(t, "0/1"), Mother: genotype(t, "0/1"), Father: genotype(t, "0/0")}
+	partners := []Partner{
+		{Variant{Name: "CFTR:p.Phe508del", Proband: genotype(t, "0/1"), Mother: genotype(t, "0/0"), Father: genotype(t, "0/1")}, "Pathogenic"},
+		{Variant{Name: "CFTR:p.Gly551Asp", Proband: genotype(t, "0/1"), Mother: genotype(t, "0/1"), Father: genotype(t, "0/0")}, "Pathogenic"},
+		{Variant{Name: "CFTR:p.Asn1303Lys", Proband: genotype(t, "0/1"), Mother: genotype(t, "0/0"), Father: genotype(t, "0/0")}, "Likely pathogenic"},
+	}
+
+	analysis, err := Analyze(target, partners)
+	require.NoError(t, err)
+	assert.Equal(t, Heterozygous, analysis.Zygosity)
+	assert.Equal(t, Maternal, analysis.Inheritance)
+	require.Len(t, analysis.Partners, 3)
+	assert.Equal(t, PartnerAnalysis{Variant: "CFTR:p.Phe508del", Classification: "Pathogenic", Inheritance: Paternal, Phase: Trans, Basis: "inherited from different parents"}, analysis.Partners[0])
+	assert.Equal(t, Cis, analysis.Partners[1].Phase)
+	assert.Equal(t, "both inherited from the mother", analysis.Partners[1].Basis)
+	assert.Equal(t, PhaseUnknown, analysis.Partners[2].Phase)
+	assert.Equal(t, DeNovo, analysis.Partners[2].Inheritance)
+	assert.NotEmpty(t, analysis.Assumptions)
+
+	_, err = Analyze(Variant{Name: "CFTR:p.Arg117His", Proband: genotype(t, "0/0")}, nil)
+	assert.Error(t, err)
+}
+
+func TestPhase_ReadBacked(t *testing.T) {
+	phased := func(gt, phaseSet string) Variant {
+		g := genotype(t, gt)
+		g.PhaseSet = phaseSet
+		return Variant{Proband: g}
+	}
+
+	phase, basis := Phase(phased("0|1", "100"), DeNovo, phased("1|0", "100"), Maternal)
+	assert.Equal(t, Trans, phase)
+	assert.Contains(t, basis, "phase set 100")
+	phase, _ = Phase(phased("0|1", "100"), DeNovo, phased("0|1", "100"), Maternal)
+	assert.Equal(t, Cis, phase)
+	phase, _ = Phase(phased("0|1", "100"), DeNovo, phased("1|0", "200"), Maternal)
+	assert.Equal(t, PhaseUnknown, phase, "calls in different phase sets are not comparable")
+	phase, _ = Phase(Variant{Proband: genotype(t, "1/1")}, Biparental, phased("0/1", ""), Maternal)
+	assert.Equal(t, Trans, phase)
+}
+
+func TestPhase_Zygosity(t *testing.T) {
+	hemizygous := Variant{Proband: genotype(t, "1")}
+	homozygous := Variant{Proband: genotype(t, "1/1")}
+	heterozygous := Variant{Proband: genotype(t, "0/1")}
+
+	phase, basis := Phase(hemizygous, Maternal, hemizygous, DeNovo)
+	assert.Equal(t, Cis, phase, "hemizygous variants share the single allele")
+	assert.Contains(t, basis, "hemizygous")
+	phase, _ = Phase(heterozygous, Paternal, homozygous, Biparental)
+	assert.Equal(t, Trans, phase)
+	phase, _ = Phase(homozygous, Biparental, heterozygous, Maternal)
+	assert.Equal(t, Trans, phase)
+	phase, _ = Phase(hemizygous, Maternal, heterozygous, Maternal)
+	assert.Equal(t, PhaseUnknown, phase)
+}
+
+const trioVCF = "##fileformat=VCFv4.2\n" +
+	"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tdad\tkid\tmom\n" +
+	"chr7\t117530975\t.\tG\tA\t50\tPASS\t.\tGT:PS\t0/0:.\t0|1:117530975\t0/1:.\n" +
+	"chr7\t117559590\t.\tATCT\tA\t50\tPASS\t.\tGT:PS\t0/1:.\t1|0:117530975\t0/0:.\n"
+
+func TestReadVCF(t *testing.T) {
+	records, err := ReadVCF(strings.NewReader(trioVCF), hgvs.AssemblyGRCh38, Samples{Proband: "kid", Mother: "mom", Father: "dad"})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "7:117530975:G:A", records[0].Key)
+	assert.Equal(t, "NC_000007.14:g.117530975G>A", records[0].Name)
+	assert.Equal(t, Genotype{AltCopies: 1, Ploidy: 2, Phased: true, AltHaplotype: 1, PhaseSet: "117530975"}, records[0].Proband)
+	assert.True(t, records[0].Mother.Carries())
+	assert.True(t, records[0].Father.Absent())
+
+	// Without names the first sample column is taken as the proband
+	records, err = ReadVCF(strings.NewReader(trioVCF), hgvs.AssemblyGRCh38, Samples{})
+	require.NoError(t, err)
+	assert.True(t, records[0].Proband.Absent())
+
+	_, err = ReadVCF(strings.NewReader(trioVCF), hgvs.AssemblyGRCh38, Samples{Proband: "child"})
+	assert.Error(t, err)
+	_, err = ReadVCF(strings.NewReader(strings.Replace(trioVCF, "\tA\t50", "\tA,T\t50", 1)), hgvs.AssemblyGRCh38, Samples{})
+	assert.ErrorContains(t, err, "multi-allelic")
+	_, err = ReadVCF(strings.NewReader("7\t117530975\t.\tG\tA\n"), hgvs.AssemblyGRCh38, Samples{})
+	assert.Error(t, err)
+}
+
+func TestNormalizeKey(t *testing.T) {
+	for _, key := range []string{"chr7:117530975:g:a", "7-117530975-G-A", " CHR7:117530975:G:A"} {
+		normalized, err := NormalizeKey(key)
+		require.NoError(t, err, key)
+		assert.Equal(t, "7:117530975:G:A", normalized, key)
+	}
+	_, err := NormalizeKey("7:117530975:G")
+	assert.Error(t, err)
+	_, err = NormalizeKey("7:0:G:A")
+	assert.Error(t, err)
+}
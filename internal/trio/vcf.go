@@ -0,0 +1,209 @@
+package trio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// Fixed VCF columns before the samples
+const (
+	vcfChrom = iota
+	vcfPos
+	vcfID
+	vcfRef
+	vcfAlt
+	vcfQual
+	vcfFilter
+	vcfInfo
+	vcfFormat
+	vcfFirstSample
+)
+
+// maxVCFLineBytes bounds a VCF line, which grows with the number of samples
+const maxVCFLineBytes = 1 << 20
+
+// Samples names the trio's sample columns in a VCF. Empty names default to
+// the first, second and third sample columns.
+type Samples struct {
+	Proband string `json:"proband,omitempty"`
+	Mother  string `json:"mother,omitempty"`
+	Father  string `json:"father,omitempty"`
+}
+
+// Record is a VCF record with the trio's genotypes, keyed by its
+// normalized chrom:pos:ref:alt
+type Record struct {
+	Key string
+	Variant
+}
+
+// ReadVCF reads the biallelic records of a multi-sample VCF with the trio's
+// GT and, when present, PS fields. Records are named by their genomic HGVS
+// on the assembly. A parent without a sample column has missing genotypes.
+func ReadVCF(r io.Reader, assembly string, samples Samples) ([]Record, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxVCFLineBytes)
+
+	var columns map[string]int
+	var proband, mother, father int
+	var records []Record
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case text == "" || strings.HasPrefix(text, "##"):
+			continue
+		case strings.HasPrefix(text, "#"):
+			header := strings.Split(strings.TrimPrefix(text, "#"), "\t")
+			if len(header) <= vcfFirstSample || !strings.EqualFold(header[vcfFormat], "FORMAT") {
+				return nil, fmt.Errorf("line %d: VCF header has no FORMAT and sample columns", line)
+			}
+			columns = make(map[string]int)
+			for i, name := range header[vcfFirstSample:] {
+				columns[name] = vcfFirstSample + i
+			}
+			var err error
+			if proband, err = sampleColumn(columns, samples.Proband, "proband", len(header), 0); err != nil {
+				return nil, err
+			}
+			if mother, err = sampleColumn(columns, samples.Mother, "mother", len(header), 1); err != nil {
+				return nil, err
+			}
+			if father, err = sampleColumn(columns, samples.Father, "father", len(header), 2); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if columns == nil {
+			return nil, fmt.Errorf("line %d: record before the #CHROM header", line)
+		}
+
+		record, err := parseRecord(strings.Split(text, "\t"), assembly, proband, mother, father)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read VCF: %w", err)
+	}
+	if columns == nil {
+		return nil, fmt.Errorf("VCF has no #CHROM header")
+	}
+	return records, nil
+}
+
+// sampleColumn returns the column of a named sample, else the nth sample
+// column, or -1 when a parent has no column
+func sampleColumn(columns map[string]int, name, role string, width, nth int) (int, error) {
+	if name != "" {
+		column, ok := columns[name]
+		if !ok {
+			return 0, fmt.Errorf("%s sample %q not in the VCF header", role, name)
+		}
+		return column, nil
+	}
+	if column := vcfFirstSample + nth; column < width {
+		return column, nil
+	}
+	if role == "proband" {
+		return 0, fmt.Errorf("VCF has no sample columns")
+	}
+	return -1, nil
+}
+
+// parseRecord parses the position, alleles and trio genotypes of a record
+func parseRecord(fields []string, assembly string, proband, mother, father int) (Record, error) {
+	if len(fields) <= vcfFormat {
+		return Record{}, fmt.Errorf("expected at least %d columns, got %d", vcfFormat+1, len(fields))
+	}
+	chrom, ref, alt := fields[vcfChrom], fields[vcfRef], fields[vcfAlt]
+	if strings.Contains(alt, ",") {
+		return Record{}, fmt.Errorf("multi-allelic record %s:%s; split it into biallelic records, e.g. with bcftools norm -m-", chrom, fields[vcfPos])
+	}
+	pos, err := strconv.ParseInt(fields[vcfPos], 10, 64)
+	if err != nil {
+		return Record{}, fmt.Errorf("invalid position %q", fields[vcfPos])
+	}
+	notation, err := hgvs.FromVCF(assembly, chrom, pos, ref, alt)
+	if err != nil {
+		return Record{}, err
+	}
+
+	format := strings.Split(fields[vcfFormat], ":")
+	gt, ps := -1, -1
+	for i, key := range format {
+		switch key {
+		case "GT":
+			gt = i
+		case "PS":
+			ps = i
+		}
+	}
+	if gt < 0 {
+		return Record{}, fmt.Errorf("FORMAT has no GT field")
+	}
+	genotype := func(column int) (Genotype, error) {
+		if column < 0 || column >= len(fields) {
+			return Genotype{Missing: true}, nil
+		}
+		values := strings.Split(fields[column], ":")
+		if gt >= len(values) {
+			return Genotype{Missing: true}, nil
+		}
+		genotype, err := ParseGenotype(values[gt])
+		if err != nil {
+			return Genotype{}, err
+		}
+		if ps >= 0 && ps < len(values) && values[ps] != "." {
+			genotype.PhaseSet = values[ps]
+		}
+		return genotype, nil
+	}
+
+	record := Record{Key: fmt.Sprintf("%s:%d:%s:%s", normalizeChrom(chrom), pos, strings.ToUpper(ref), strings.ToUpper(alt))}
+	record.Name = notation
+	if record.Proband, err = genotype(proband); err != nil {
+		return Record{}, err
+	}
+	if record.Mother, err = genotype(mother); err != nil {
+		return Record{}, err
+	}
+	if record.Father, err = genotype(father); err != nil {
+		return Record{}, err
+	}
+	return record, nil
+}
+
+// NormalizeKey normalizes a chrom:pos:ref:alt (or chrom-pos-ref-alt) record
+// key: the chr prefix is dropped and the alleles upper-cased
+func NormalizeKey(key string) (string, error) {
+	separator := ":"
+	if !strings.Contains(key, ":") {
+		separator = "-"
+	}
+	parts := strings.Split(strings.TrimSpace(key), separator)
+	if len(parts) != 4 {
+		return "", fmt.Errorf("invalid variant key %q (expected chrom:pos:ref:alt)", key)
+	}
+	pos, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || pos < 1 {
+		return "", fmt.Errorf("invalid position in variant key %q", key)
+	}
+	return fmt.Sprintf("%s:%d:%s:%s", normalizeChrom(parts[0]), pos, strings.ToUpper(parts[2]), strings.ToUpper(parts[3])), nil
+}
+
+// normalizeChrom drops the chr prefix so 17 and chr17 match
+func normalizeChrom(chrom string) string {
+	chrom = strings.TrimSpace(chrom)
+	if len(chrom) > 3 && strings.EqualFold(chrom[:3], "chr") {
+		chrom = chrom[3:]
+	}
+	return strings.ToUpper(chrom)
+}
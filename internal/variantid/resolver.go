@@ -0,0 +1,111 @@
+package variantid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// DefaultMaxAge is how long a cached mapping is used before it is refreshed.
+// dbSNP and ClinVar merge and retire records, so mappings are not kept forever.
+const DefaultMaxAge = 30 * 24 * time.Hour
+
+// Resolver resolves identifiers through the local cache, falling back to the
+// source database for unknown or expired identifiers.
+type Resolver struct {
+	lookup Lookup
+	store  Store
+	maxAge time.Duration
+	logger *logrus.Logger
+}
+
+// NewResolver creates a resolver. Either lookup or store may be nil: without
+// a lookup only cached identifiers resolve, without a store every request
+// goes to the source database.
+func NewResolver(lookup Lookup, store Store, logger *logrus.Logger) *Resolver {
+	return &Resolver{lookup: lookup, store: store, maxAge: DefaultMaxAge, logger: logger}
+}
+
+// SetMaxAge sets how long cached mappings are used before being refreshed.
+func (r *Resolver) SetMaxAge(maxAge time.Duration) {
+	r.maxAge = maxAge
+}
+
+// Resolve returns the mapping for an rsID or ClinVar accession. A stale
+// cached mapping is returned if the source database cannot be reached.
+func (r *Resolver) Resolve(ctx context.Context, input string) (*domain.IdentifierMapping, error) {
+	identifier, kind, ok := Parse(input)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a dbSNP rsID or ClinVar VCV/RCV accession", input)
+	}
+
+	var cached *domain.IdentifierMapping
+	if r.store != nil {
+		mapping, err := r.store.Get(ctx, identifier)
+		switch {
+		case err == nil:
+			if time.Since(mapping.ResolvedAt) <= r.maxAge {
+				return mapping, nil
+			}
+			cached = mapping
+		case !errors.Is(err, ErrMappingNotFound):
+			r.logger.WithError(err).WithField("identifier", identifier).Warn("Failed to read cached identifier mapping")
+		}
+	}
+
+	if r.lookup == nil {
+		if cached != nil {
+			return cached, nil
+		}
+		return nil, fmt.Errorf("%s is not in the local identifier cache and online resolution is disabled", identifier)
+	}
+
+	mapping, err := r.lookup.ResolveIdentifier(ctx, kind, identifier)
+	if err != nil {
+		if cached != nil {
+			r.logger.WithError(err).WithField("identifier", identifier).Warn("Using stale identifier mapping")
+			return cached, nil
+		}
+		return nil, err
+	}
+	if r.store != nil {
+		if err := r.store.Put(ctx, mapping); err != nil {
+			r.logger.WithError(err).WithField("identifier", identifier).Warn("Failed to cache identifier mapping")
+		}
+	}
+	return mapping, nil
+}
+
+// ResolveVariant resolves an identifier to a single variant. Multi-allelic
+// rsIDs are rejected with the candidate alleles so the caller can pick one.
+func (r *Resolver) ResolveVariant(ctx context.Context, input string) (*domain.StandardizedVariant, *domain.IdentifierMapping, error) {
+	mapping, err := r.Resolve(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(mapping.Variants) != 1 {
+		candidates := make([]string, 0, len(mapping.Variants))
+		for i := range mapping.Variants {
+			candidates = append(candidates, Notation(&mapping.Variants[i]))
+		}
+		return nil, mapping, fmt.Errorf("%s has %d alleles; classify one of: %s",
+			mapping.Identifier, len(mapping.Variants), strings.Join(candidates, ", "))
+	}
+	variant := mapping.Variants[0]
+	return &variant, mapping, nil
+}
+
+// Notation returns the HGVS notation to classify a resolved variant by,
+// preferring the coding notation that interpretation is reported on.
+func Notation(variant *domain.StandardizedVariant) string {
+	if variant.HGVSCoding != "" {
+		return variant.HGVSCoding
+	}
+	return variant.HGVSGenomic
+}
@@ -0,0 +1,135 @@
+package variantid
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// stubLookup serves fixed mappings and counts requests
+type stubLookup struct {
+	mappings map[string]*domain.IdentifierMapping
+	calls    int
+	fail     bool
+}
+
+func (s *stubLookup) ResolveIdentifier(_ context.Context, kind domain.IdentifierKind, identifier string) (*domain.IdentifierMapping, error) {
+	s.calls++
+	if s.fail {
+		return nil, fmt.Errorf("E-utilities unavailable")
+	}
+	mapping, ok := s.mappings[identifier]
+	if !ok {
+		return nil, fmt.Errorf("no record for %s", identifier)
+	}
+	copied := *mapping
+	copied.Kind = kind
+	copied.ResolvedAt = time.Now().UTC()
+	return &copied, nil
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input      string
+		identifier string
+		kind       domain.IdentifierKind
+		ok         bool
+	}{
+		{"rs80357906", "rs80357906", domain.IdentifierRSID, true},
+		{" RS80357906 ", "rs80357906", domain.IdentifierRSID, true},
+		{"VCV000017661", "VCV000017661", domain.IdentifierVCV, true},
+		{"vcv000017661.52", "VCV000017661", domain.IdentifierVCV, true},
+		{"RCV000019241.3", "RCV000019241", domain.IdentifierRCV, true},
+		{"VCV17661", "", "", false},
+		{"NM_007294.4:c.5266dup", "", "", false},
+		{"rs", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			identifier, kind, ok := Parse(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.identifier, identifier)
+			assert.Equal(t, tt.kind, kind)
+		})
+	}
+}
+
+func TestResolver_CachesMappings(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+	lookup := &stubLookup{mappings: map[string]*domain.IdentifierMapping{
+		"VCV000017661": {Identifier: "VCV000017661", Source: "ClinVar", Variants: []domain.StandardizedVariant{
+			{HGVSCoding: "NM_007294.4:c.5266dup", HGVSGenomic: "NC_000017.11:g.43057068dup", GeneSymbol: "BRCA1"},
+		}},
+	}}
+	resolver := NewResolver(lookup, store, logrus.New())
+	ctx := context.Background()
+
+	// Act
+	variant, mapping, err := resolver.ResolveVariant(ctx, "VCV000017661.52")
+	require.NoError(t, err)
+	_, _, err = resolver.ResolveVariant(ctx, "vcv000017661")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, lookup.calls, "second lookup is served from the cache")
+	assert.Equal(t, "NM_007294.4:c.5266dup", Notation(variant))
+	assert.Equal(t, domain.IdentifierVCV, mapping.Kind)
+
+	// An expired mapping is refreshed, but still used if the refresh fails
+	resolver.SetMaxAge(0)
+	lookup.fail = true
+	variant, _, err = resolver.ResolveVariant(ctx, "VCV000017661")
+	require.NoError(t, err)
+	assert.Equal(t, 2, lookup.calls)
+	assert.Equal(t, "BRCA1", variant.GeneSymbol)
+
+	_, _, err = resolver.ResolveVariant(ctx, "RCV000019241")
+	assert.Error(t, err)
+	_, _, err = resolver.ResolveVariant(ctx, "NM_007294.4:c.5266dup")
+	assert.Error(t, err)
+}
+
+func TestResolver_MultiAllelic(t *testing.T) {
+	lookup := &stubLookup{mappings: map[string]*domain.IdentifierMapping{
+		"rs80357906": {Identifier: "rs80357906", Source: "dbSNP", Variants: []domain.StandardizedVariant{
+			{HGVSGenomic: "NC_000017.11:g.43057063del"},
+			{HGVSGenomic: "NC_000017.11:g.43057063dup"},
+		}},
+	}}
+	resolver := NewResolver(lookup, nil, logrus.New())
+
+	_, mapping, err := resolver.ResolveVariant(context.Background(), "rs80357906")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NC_000017.11:g.43057063del, NC_000017.11:g.43057063dup")
+	require.NotNil(t, mapping)
+	assert.Len(t, mapping.Variants, 2)
+}
+
+func TestResolver_OfflineUsesCacheOnly(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, &domain.IdentifierMapping{
+		Identifier: "rs28897696", Kind: domain.IdentifierRSID, Source: "dbSNP",
+		Variants:   []domain.StandardizedVariant{{HGVSGenomic: "NC_000017.11:g.43094464C>A"}},
+		ResolvedAt: time.Now().UTC().Add(-365 * 24 * time.Hour),
+	}))
+	resolver := NewResolver(nil, store, logrus.New())
+
+	variant, _, err := resolver.ResolveVariant(ctx, "rs28897696")
+	require.NoError(t, err)
+	assert.Equal(t, "NC_000017.11:g.43094464C>A", Notation(variant))
+
+	_, _, err = resolver.ResolveVariant(ctx, "rs1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package variantid
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite identifier mapping store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS identifier_mappings (
+		identifier TEXT PRIMARY KEY,
+		kind TEXT NOT NULL,
+		source TEXT NOT NULL,
+		variants TEXT NOT NULL,
+		resolved_at DATETIME NOT NULL
+	);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+// Get returns the cached mapping for a canonical identifier.
+func (s *SQLiteStore) Get(ctx context.Context, identifier string) (*domain.IdentifierMapping, error) {
+	mapping := &domain.IdentifierMapping{}
+	var kind, variants string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT identifier, kind, source, variants, resolved_at
+		FROM identifier_mappings WHERE identifier = ?
+	`, identifier).Scan(&mapping.Identifier, &kind, &mapping.Source, &variants, &mapping.ResolvedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrMappingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query mapping: %w", err)
+	}
+
+	mapping.Kind = domain.IdentifierKind(kind)
+	if err := json.Unmarshal([]byte(variants), &mapping.Variants); err != nil {
+		return nil, fmt.Errorf("failed to decode mapping variants: %w", err)
+	}
+	return mapping, nil
+}
+
+// Put stores or replaces a mapping.
+func (s *SQLiteStore) Put(ctx context.Context, mapping *domain.IdentifierMapping) error {
+	variants, err := json.Marshal(mapping.Variants)
+	if err != nil {
+		return fmt.Errorf("failed to encode mapping variants: %w", err)
+	}
+	if mapping.ResolvedAt.IsZero() {
+		mapping.ResolvedAt = time.Now().UTC()
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO identifier_mappings (identifier, kind, source, variants, resolved_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(identifier) DO UPDATE SET
+			kind = excluded.kind, source = excluded.source,
+			variants = excluded.variants, resolved_at = excluded.resolved_at
+	`, mapping.Identifier, string(mapping.Kind), mapping.Source, string(variants), mapping.ResolvedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store mapping: %w", err)
+	}
+	return nil
+}
+
+// Close closes the store and releases resources.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,62 @@
+package variantid
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "identifiers.db"))
+	require.NoError(t, err)
+	return store
+}
+
+func TestSQLiteStore_PutAndGet(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	_, err := store.Get(ctx, "rs80357906")
+	assert.ErrorIs(t, err, ErrMappingNotFound)
+
+	mapping := &domain.IdentifierMapping{
+		Identifier: "VCV000017661",
+		Kind:       domain.IdentifierVCV,
+		Source:     "ClinVar",
+		Variants: []domain.StandardizedVariant{{
+			HGVSCoding:   "NM_007294.4:c.5266dup",
+			HGVSGenomic:  "NC_000017.11:g.43057068dup",
+			TranscriptID: "NM_007294.4",
+			GeneSymbol:   "BRCA1",
+		}},
+	}
+
+	// Act
+	require.NoError(t, store.Put(ctx, mapping))
+	got, err := store.Get(ctx, "VCV000017661")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.IdentifierVCV, got.Kind)
+	assert.Equal(t, "ClinVar", got.Source)
+	assert.False(t, got.ResolvedAt.IsZero())
+	require.Len(t, got.Variants, 1)
+	assert.Equal(t, "NM_007294.4:c.5266dup", got.Variants[0].HGVSCoding)
+	assert.Equal(t, "BRCA1", got.Variants[0].GeneSymbol)
+
+	// Replacing a mapping keeps one row per identifier
+	mapping.ResolvedAt = time.Now().UTC().Add(time.Hour)
+	mapping.Variants[0].GeneSymbol = "BRCA1-DT"
+	require.NoError(t, store.Put(ctx, mapping))
+	got, err = store.Get(ctx, "VCV000017661")
+	require.NoError(t, err)
+	assert.Equal(t, "BRCA1-DT", got.Variants[0].GeneSymbol)
+}
@@ -0,0 +1,58 @@
+// Package variantid resolves dbSNP rsIDs and ClinVar VCV/RCV accessions to
+// variants, caching each mapping locally so repeat lookups stay offline.
+package variantid
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// ErrMappingNotFound is returned when an identifier has no cached mapping.
+var ErrMappingNotFound = errors.New("identifier mapping not found")
+
+var (
+	rsidPattern    = regexp.MustCompile(`(?i)^rs(\d+)$`)
+	clinVarPattern = regexp.MustCompile(`(?i)^(VCV|RCV)(\d{9})(\.\d+)?$`)
+)
+
+// Parse recognizes an rsID or ClinVar accession and returns its canonical
+// form: lower-case "rs" prefix, upper-case ClinVar prefix and no version.
+func Parse(input string) (string, domain.IdentifierKind, bool) {
+	input = strings.TrimSpace(input)
+	if m := rsidPattern.FindStringSubmatch(input); m != nil {
+		return "rs" + m[1], domain.IdentifierRSID, true
+	}
+	if m := clinVarPattern.FindStringSubmatch(input); m != nil {
+		prefix := strings.ToUpper(m[1])
+		return prefix + m[2], domain.IdentifierKind(strings.ToLower(prefix)), true
+	}
+	return "", "", false
+}
+
+// IsIdentifier reports whether the input is an rsID or ClinVar accession
+// rather than an HGVS notation.
+func IsIdentifier(input string) bool {
+	_, _, ok := Parse(input)
+	return ok
+}
+
+// Lookup resolves an identifier against its source database.
+type Lookup interface {
+	ResolveIdentifier(ctx context.Context, kind domain.IdentifierKind, identifier string) (*domain.IdentifierMapping, error)
+}
+
+// Store persists resolved identifier mappings.
+type Store interface {
+	// Get returns the cached mapping for a canonical identifier.
+	Get(ctx context.Context, identifier string) (*domain.IdentifierMapping, error)
+
+	// Put stores or replaces a mapping.
+	Put(ctx context.Context, mapping *domain.IdentifierMapping) error
+
+	// Close releases resources.
+	Close() error
+}
@@ -0,0 +1,125 @@
+package vcep
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Registry holds the loaded specifications, keyed by gene.
+type Registry struct {
+	mu    sync.RWMutex
+	specs map[string]*Specification
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{specs: make(map[string]*Specification)}
+}
+
+// LoadDir loads every .json, .yaml and .yml specification in dir.
+// A missing directory yields an empty registry.
+func LoadDir(dir string) (*Registry, error) {
+	registry := NewRegistry()
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read specification directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isSpecFile(entry.Name()) {
+			continue
+		}
+		spec, err := LoadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.Add(spec); err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+	}
+
+	return registry, nil
+}
+
+// LoadFile reads and validates a single JSON or YAML specification.
+func LoadFile(path string) (*Specification, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read specification: %w", err)
+	}
+
+	var spec Specification
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &spec)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &spec)
+	default:
+		return nil, fmt.Errorf("unsupported specification format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse specification %s: %w", filepath.Base(path), err)
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	spec.File = filepath.Base(path)
+	return &spec, nil
+}
+
+// Add registers a specification. Each gene may have only one specification.
+func (r *Registry) Add(spec *Specification) error {
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.specs[spec.Gene]; ok {
+		return fmt.Errorf("%w %s (already loaded %s)", ErrDuplicateGene, spec.Gene, existing.Label())
+	}
+	r.specs[spec.Gene] = spec
+	return nil
+}
+
+// Lookup returns the specification for a gene, or nil.
+func (r *Registry) Lookup(gene string) *Specification {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.specs[strings.ToUpper(strings.TrimSpace(gene))]
+}
+
+// List returns all specifications ordered by gene.
+func (r *Registry) List() []*Specification {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	specs := make([]*Specification, 0, len(r.specs))
+	for _, spec := range r.specs {
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Gene < specs[j].Gene })
+	return specs
+}
+
+func isSpecFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
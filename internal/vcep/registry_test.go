@@ -0,0 +1,108 @@
+package vcep
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func writeSpec(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func TestLoadDir_Examples(t *testing.T) {
+	registry, err := LoadDir(filepath.Join("..", "..", "examples", "vcep"))
+	require.NoError(t, err)
+
+	specs := registry.List()
+	require.Len(t, specs, 2)
+	assert.Equal(t, "CDH1", specs[0].Gene)
+	assert.Equal(t, "MYH7", specs[1].Gene)
+
+	cdh1 := registry.Lookup("cdh1")
+	require.NotNil(t, cdh1)
+	assert.Equal(t, "CDH1 VCEP v3.1", cdh1.Label())
+	assert.Equal(t, "CDH1.yaml", cdh1.File)
+	assert.Len(t, cdh1.Rule("PVS1").DecisionTree, 4)
+	assert.Equal(t, domain.MODERATE, cdh1.Rule("PVS1").DecisionTree[0].Strength)
+	assert.Equal(t, domain.SUPPORTING, cdh1.Rule("PM2").Strength)
+	assert.True(t, cdh1.Rule("PM1").NotApplicable())
+
+	myh7 := registry.Lookup("MYH7")
+	require.NotNil(t, myh7)
+	require.Len(t, myh7.Rule("PM1").Hotspots, 1)
+	assert.True(t, myh7.Rule("PM1").Hotspots[0].Contains(403))
+	assert.InDelta(t, 0.001, *myh7.Rule("BA1").AlleleFrequency, 1e-12)
+}
+
+func TestLoadDir_MissingDirectory(t *testing.T) {
+	registry, err := LoadDir(filepath.Join(t.TempDir(), "missing"))
+
+	require.NoError(t, err)
+	assert.Empty(t, registry.List())
+	assert.Nil(t, registry.Lookup("BRCA1"))
+}
+
+func TestLoadDir_DuplicateGene(t *testing.T) {
+	dir := t.TempDir()
+	writeSpec(t, dir, "a.json", `{"gene": "TP53", "rules": {"PM1": {"applicable": false}}}`)
+	writeSpec(t, dir, "b.yaml", "gene: tp53\nrules:\n  PP2:\n    applicable: false\n")
+
+	_, err := LoadDir(dir)
+
+	assert.True(t, errors.Is(err, ErrDuplicateGene))
+}
+
+func TestLoadDir_IgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeSpec(t, dir, "README.md", "# notes")
+	writeSpec(t, dir, "PTEN.yml", "gene: PTEN\nrules:\n  pm2:\n    allele_frequency: 0.00001\n")
+
+	registry, err := LoadDir(dir)
+
+	require.NoError(t, err)
+	require.Len(t, registry.List(), 1)
+	assert.NotNil(t, registry.Lookup("PTEN").Rule("PM2"))
+}
+
+func TestSpecification_Validate(t *testing.T) {
+	af := 0.01
+	tests := []struct {
+		name string
+		spec Specification
+	}{
+		{"missing gene", Specification{Rules: map[string]*RuleSpec{"PM1": {}}}},
+		{"no rules", Specification{Gene: "BRCA1"}},
+		{"unknown criterion", Specification{Gene: "BRCA1", Rules: map[string]*RuleSpec{"PX9": {}}}},
+		{"invalid strength", Specification{Gene: "BRCA1", Rules: map[string]*RuleSpec{"PM2": {Strength: "huge"}}}},
+		{"frequency on wrong rule", Specification{Gene: "BRCA1", Rules: map[string]*RuleSpec{"PP3": {AlleleFrequency: &af}}}},
+		{"hotspots on wrong rule", Specification{Gene: "BRCA1", Rules: map[string]*RuleSpec{"PM2": {Hotspots: []Region{{Start: 1, End: 10}}}}}},
+		{"inverted hotspot", Specification{Gene: "BRCA1", Rules: map[string]*RuleSpec{"PM1": {Hotspots: []Region{{Start: 10, End: 1}}}}}},
+		{"unknown null type", Specification{Gene: "BRCA1", Rules: map[string]*RuleSpec{"PVS1": {DecisionTree: []DecisionNode{{NullType: "missense"}}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			assert.True(t, errors.Is(err, ErrInvalidSpecification), "got %v", err)
+		})
+	}
+}
+
+func TestDecisionNode_Matches(t *testing.T) {
+	upstream := DecisionNode{MaxCodon: 836}
+	splice := DecisionNode{NullType: NullCanonicalSplice}
+
+	assert.True(t, upstream.Matches(NullNonsense, 100))
+	assert.False(t, upstream.Matches(NullNonsense, 900))
+	assert.False(t, upstream.Matches(NullNonsense, 0))
+	assert.True(t, splice.Matches(NullCanonicalSplice, 0))
+	assert.False(t, splice.Matches(NullFrameshift, 10))
+}
@@ -0,0 +1,210 @@
+// Package vcep loads gene/disease-specific rule specifications published by
+// ClinGen Variant Curation Expert Panels (VCEPs). A specification overrides
+// the generic ACMG/AMP rule engine for variants in its gene: rules can be
+// marked not applicable, given a different strength, use gene-specific
+// frequency cutoffs, PM1 hotspot regions or a modified PVS1 decision tree.
+package vcep
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+var (
+	// ErrDuplicateGene is returned when two specifications cover the same gene.
+	ErrDuplicateGene = errors.New("duplicate specification for gene")
+	// ErrInvalidSpecification is returned when a specification fails validation.
+	ErrInvalidSpecification = errors.New("invalid VCEP specification")
+)
+
+// Null variant types matched by PVS1 decision tree nodes
+const (
+	NullNonsense        = "nonsense"
+	NullFrameshift      = "frameshift"
+	NullCanonicalSplice = "canonical_splice"
+	NullInitiationCodon = "initiation_codon"
+)
+
+// NullTypes lists the recognized null variant types.
+var NullTypes = []string{NullNonsense, NullFrameshift, NullCanonicalSplice, NullInitiationCodon}
+
+// ruleCodes lists the ACMG/AMP criteria a specification may override
+var ruleCodes = map[string]bool{
+	"PVS1": true, "PS1": true, "PS2": true, "PS3": true, "PS4": true,
+	"PM1": true, "PM2": true, "PM3": true, "PM4": true, "PM5": true, "PM6": true,
+	"PP1": true, "PP2": true, "PP3": true, "PP4": true, "PP5": true,
+	"BA1": true, "BS1": true, "BS2": true, "BS3": true, "BS4": true,
+	"BP1": true, "BP2": true, "BP3": true, "BP4": true, "BP5": true, "BP6": true, "BP7": true,
+}
+
+// frequencyRules are the criteria that accept an allele frequency cutoff
+var frequencyRules = map[string]bool{"BA1": true, "BS1": true, "PM2": true}
+
+// Specification is a VCEP rule specification for one gene.
+type Specification struct {
+	ID      string               `json:"id" yaml:"id"`           // e.g. GN007 from the ClinGen CSpec registry
+	Gene    string               `json:"gene" yaml:"gene"`       // HGNC symbol
+	Disease string               `json:"disease" yaml:"disease"` // Disease the specification applies to
+	Version string               `json:"version" yaml:"version"`
+	Source  string               `json:"source,omitempty" yaml:"source,omitempty"` // URL of the published specification
+	Rules   map[string]*RuleSpec `json:"rules" yaml:"rules"`                       // Keyed by criterion code, e.g. PVS1
+	File    string               `json:"file,omitempty" yaml:"-"`                  // File the specification was loaded from
+}
+
+// RuleSpec overrides a single criterion.
+type RuleSpec struct {
+	Applicable      *bool               `json:"applicable,omitempty" yaml:"applicable,omitempty"`             // False marks the criterion not applicable
+	Strength        domain.RuleStrength `json:"strength,omitempty" yaml:"strength,omitempty"`                 // Strength used when the criterion is met
+	AlleleFrequency *float64            `json:"allele_frequency,omitempty" yaml:"allele_frequency,omitempty"` // BA1, BS1 and PM2 only
+	Hotspots        []Region            `json:"hotspots,omitempty" yaml:"hotspots,omitempty"`                 // PM1 only
+	DecisionTree    []DecisionNode      `json:"decision_tree,omitempty" yaml:"decision_tree,omitempty"`       // PVS1 only
+	Notes           string              `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// Region is an inclusive range of protein positions.
+type Region struct {
+	Start       int    `json:"start" yaml:"start"`
+	End         int    `json:"end" yaml:"end"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// DecisionNode is one branch of a PVS1 decision tree. Nodes are evaluated in
+// order and the first match decides the outcome; an empty strength means PVS1
+// does not apply. Variants matching no node do not meet PVS1.
+type DecisionNode struct {
+	NullType    string              `json:"null_type,omitempty" yaml:"null_type,omitempty"` // Empty matches any null variant
+	MinCodon    int                 `json:"min_codon,omitempty" yaml:"min_codon,omitempty"` // 0 for no lower bound
+	MaxCodon    int                 `json:"max_codon,omitempty" yaml:"max_codon,omitempty"` // 0 for no upper bound
+	Strength    domain.RuleStrength `json:"strength,omitempty" yaml:"strength,omitempty"`
+	Description string              `json:"description" yaml:"description"`
+}
+
+// Label identifies the specification in rule reasoning.
+func (s *Specification) Label() string {
+	label := s.Gene + " VCEP"
+	if s.Version != "" {
+		label += " v" + strings.TrimPrefix(s.Version, "v")
+	}
+	return label
+}
+
+// Rule returns the override for a criterion, or nil.
+func (s *Specification) Rule(code string) *RuleSpec {
+	if s == nil {
+		return nil
+	}
+	return s.Rules[code]
+}
+
+// NotApplicable reports whether the specification excludes the criterion.
+func (r *RuleSpec) NotApplicable() bool {
+	return r != nil && r.Applicable != nil && !*r.Applicable
+}
+
+// Contains reports whether a protein position falls within the region.
+func (r Region) Contains(position int) bool {
+	return position >= r.Start && position <= r.End
+}
+
+// Matches reports whether a null variant of the given type and codon falls on this branch.
+// A codon of 0 (unknown) only matches nodes without codon bounds.
+func (n DecisionNode) Matches(nullType string, codon int) bool {
+	if n.NullType != "" && n.NullType != nullType {
+		return false
+	}
+	if (n.MinCodon > 0 || n.MaxCodon > 0) && codon == 0 {
+		return false
+	}
+	if n.MinCodon > 0 && codon < n.MinCodon {
+		return false
+	}
+	if n.MaxCodon > 0 && codon > n.MaxCodon {
+		return false
+	}
+	return true
+}
+
+// Validate normalizes the specification and checks it for errors.
+func (s *Specification) Validate() error {
+	s.Gene = strings.ToUpper(strings.TrimSpace(s.Gene))
+	if s.Gene == "" {
+		return fmt.Errorf("%w: gene is required", ErrInvalidSpecification)
+	}
+	if len(s.Rules) == 0 {
+		return fmt.Errorf("%w: %s has no rule overrides", ErrInvalidSpecification, s.Gene)
+	}
+
+	normalized := make(map[string]*RuleSpec, len(s.Rules))
+	for code, rule := range s.Rules {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if !ruleCodes[code] {
+			return fmt.Errorf("%w: %s: unknown criterion %q", ErrInvalidSpecification, s.Gene, code)
+		}
+		if rule == nil {
+			return fmt.Errorf("%w: %s %s: empty override", ErrInvalidSpecification, s.Gene, code)
+		}
+		if err := rule.validate(code); err != nil {
+			return fmt.Errorf("%w: %s %s: %v", ErrInvalidSpecification, s.Gene, code, err)
+		}
+		normalized[code] = rule
+	}
+	s.Rules = normalized
+	return nil
+}
+
+func (r *RuleSpec) validate(code string) error {
+	if r.Strength != "" {
+		r.Strength = domain.RuleStrength(strings.ToUpper(string(r.Strength)))
+		if !r.Strength.IsValid() {
+			return fmt.Errorf("invalid strength %q", r.Strength)
+		}
+	}
+	if r.AlleleFrequency != nil {
+		if !frequencyRules[code] {
+			return fmt.Errorf("allele_frequency only applies to BA1, BS1 and PM2")
+		}
+		if *r.AlleleFrequency < 0 || *r.AlleleFrequency > 1 {
+			return fmt.Errorf("allele_frequency must be between 0 and 1")
+		}
+	}
+	if len(r.Hotspots) > 0 && code != "PM1" {
+		return fmt.Errorf("hotspots only apply to PM1")
+	}
+	for _, region := range r.Hotspots {
+		if region.Start <= 0 || region.End < region.Start {
+			return fmt.Errorf("invalid hotspot region %d-%d", region.Start, region.End)
+		}
+	}
+	if len(r.DecisionTree) > 0 && code != "PVS1" {
+		return fmt.Errorf("decision_tree only applies to PVS1")
+	}
+	for i := range r.DecisionTree {
+		node := &r.DecisionTree[i]
+		node.NullType = strings.ToLower(strings.TrimSpace(node.NullType))
+		if node.NullType != "" && !isNullType(node.NullType) {
+			return fmt.Errorf("decision tree node %d: unknown null_type %q", i+1, node.NullType)
+		}
+		if node.MaxCodon > 0 && node.MaxCodon < node.MinCodon {
+			return fmt.Errorf("decision tree node %d: max_codon is below min_codon", i+1)
+		}
+		if node.Strength != "" {
+			node.Strength = domain.RuleStrength(strings.ToUpper(string(node.Strength)))
+			if !node.Strength.IsValid() {
+				return fmt.Errorf("decision tree node %d: invalid strength %q", i+1, node.Strength)
+			}
+		}
+	}
+	return nil
+}
+
+func isNullType(t string) bool {
+	for _, known := range NullTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,343 @@
+package vrs
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// ErrNoChange is returned for an allele that does not change the reference
+var ErrNoChange = errors.New("allele does not change the reference sequence")
+
+// VRS object types
+const (
+	TypeAllele                    = "Allele"
+	TypeSequenceLocation          = "SequenceLocation"
+	TypeSequenceReference         = "SequenceReference"
+	TypeLiteralSequenceExpression = "LiteralSequenceExpression"
+	TypeReferenceLengthExpression = "ReferenceLengthExpression"
+)
+
+// Allele is a VRS Allele: a sequence state at a sequence location
+type Allele struct {
+	ID          string           `json:"id"`
+	Type        string           `json:"type"`
+	Digest      string           `json:"digest"`
+	Location    SequenceLocation `json:"location"`
+	State       SequenceState    `json:"state"`
+	Expressions []Expression     `json:"expressions,omitempty"` // Other notations of the allele, e.g. HGVS
+}
+
+// SequenceLocation is an interval on a reference sequence in 0-based
+// interbase coordinates
+type SequenceLocation struct {
+	ID                string            `json:"id"`
+	Type              string            `json:"type"`
+	Digest            string            `json:"digest"`
+	SequenceReference SequenceReference `json:"sequenceReference"`
+	Start             int64             `json:"start"`
+	End               int64             `json:"end"`
+}
+
+// SequenceReference names a sequence by its refget accession
+type SequenceReference struct {
+	Type            string `json:"type"`
+	RefgetAccession string `json:"refgetAccession"` // SQ.<digest>
+}
+
+// SequenceState is a LiteralSequenceExpression, or a
+// ReferenceLengthExpression for an indel within a repeat
+type SequenceState struct {
+	Type                string `json:"type"`
+	Sequence            string `json:"sequence,omitempty"`
+	Length              *int64 `json:"length,omitempty"`              // ReferenceLengthExpression only
+	RepeatSubunitLength int64  `json:"repeatSubunitLength,omitempty"` // ReferenceLengthExpression only
+}
+
+// Expression is another syntax describing the allele
+type Expression struct {
+	Syntax string `json:"syntax"`
+	Value  string `json:"value"`
+}
+
+// Reference provides reference genome sequence
+type Reference interface {
+	// Sequence returns the bases from start to end, 1-based and inclusive.
+	// The range is clipped to the chromosome.
+	Sequence(chromosome string, start, end int64) (string, error)
+	// Length returns the number of bases in a chromosome
+	Length(chromosome string) (int64, error)
+}
+
+// digestChunk is how many bases are read at a time to digest a chromosome
+const digestChunk = 1 << 20
+
+// windowFlank is how far either side of a position the reference is read
+// when rolling an indel
+const windowFlank = 512
+
+// Translator computes VRS alleles for normalized variants on the reference
+// genome they were normalized against.
+type Translator struct {
+	reference Reference
+
+	mu      sync.Mutex
+	digests map[string]string
+}
+
+// NewTranslator creates a translator for a reference genome.
+func NewTranslator(reference Reference) *Translator {
+	return &Translator{reference: reference, digests: make(map[string]string)}
+}
+
+// SetSequenceDigest records the refget accession of a chromosome, e.g. from
+// a published sequence collection, so it is not computed from the reference.
+func (t *Translator) SetSequenceDigest(chromosome, accession string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.digests[chromosome] = accession
+}
+
+// SequenceDigest returns the refget accession (SQ.<digest>) of a chromosome.
+// It is computed from the reference on first use, which reads the whole
+// chromosome, and cached afterwards.
+func (t *Translator) SequenceDigest(chromosome string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if accession, ok := t.digests[chromosome]; ok {
+		return accession, nil
+	}
+
+	length, err := t.reference.Length(chromosome)
+	if err != nil {
+		return "", err
+	}
+	hash := sha512.New()
+	for start := int64(1); start <= length; start += digestChunk {
+		seq, err := t.reference.Sequence(chromosome, start, start+digestChunk-1)
+		if err != nil {
+			return "", err
+		}
+		hash.Write([]byte(seq))
+	}
+	accession := PrefixSequence + "." + base64.URLEncoding.EncodeToString(hash.Sum(nil)[:24])
+	t.digests[chromosome] = accession
+	return accession, nil
+}
+
+// Translate returns the VRS allele of a normalized variant. The allele is
+// normalized the way VRS requires, which differs from HGVS for indels: an
+// indel within a repeat covers the whole repeat, so every placement of the
+// same indel has the same identifier.
+func (t *Translator) Translate(variant *domain.NormalizedVariant) (*Allele, error) {
+	if variant == nil || variant.Chromosome == "" {
+		return nil, fmt.Errorf("variant has no genomic position")
+	}
+	accession, err := t.SequenceDigest(variant.Chromosome)
+	if err != nil {
+		return nil, err
+	}
+
+	window := &sequenceWindow{reference: t.reference, chromosome: variant.Chromosome}
+	start, end, state, err := normalizeAllele(window, variant.Start-1, variant.End, strings.ToUpper(variant.Alternative))
+	if err != nil {
+		return nil, err
+	}
+	allele, err := NewAllele(accession, start, end, state)
+	if err != nil {
+		return nil, err
+	}
+	if variant.HGVSGenomic != "" {
+		allele.Expressions = []Expression{{Syntax: "hgvs.g", Value: variant.HGVSGenomic}}
+	}
+	return allele, nil
+}
+
+// NewAllele creates an allele with its computed identifiers. Start and end
+// are 0-based interbase coordinates on the sequence with the refget
+// accession.
+func NewAllele(accession string, start, end int64, state SequenceState) (*Allele, error) {
+	location := SequenceLocation{
+		Type:              TypeSequenceLocation,
+		SequenceReference: SequenceReference{Type: TypeSequenceReference, RefgetAccession: accession},
+		Start:             start,
+		End:               end,
+	}
+	digest, err := digestObject(map[string]interface{}{
+		"type": location.Type,
+		"sequenceReference": map[string]interface{}{
+			"type":            location.SequenceReference.Type,
+			"refgetAccession": accession,
+		},
+		"start": start,
+		"end":   end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	location.Digest = digest
+	location.ID = Identifier(PrefixLocation, digest)
+
+	allele := &Allele{Type: TypeAllele, Location: location, State: state}
+	digest, err = digestObject(map[string]interface{}{
+		"type":     allele.Type,
+		"location": location.Digest,
+		"state":    state.digestObject(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	allele.Digest = digest
+	allele.ID = Identifier(PrefixAllele, digest)
+	return allele, nil
+}
+
+// LiteralSequence returns the state of an allele spelled out in full
+func LiteralSequence(sequence string) SequenceState {
+	return SequenceState{Type: TypeLiteralSequenceExpression, Sequence: sequence}
+}
+
+// ReferenceLength returns the state of an indel within a repeat: the repeat
+// with a length of sequence, made of repeatSubunitLength-base units
+func ReferenceLength(sequence string, repeatSubunitLength int64) SequenceState {
+	length := int64(len(sequence))
+	return SequenceState{Type: TypeReferenceLengthExpression, Sequence: sequence, Length: &length, RepeatSubunitLength: repeatSubunitLength}
+}
+
+// digestObject returns the state's digest properties; the sequence of a
+// ReferenceLengthExpression follows from the reference and is left out
+func (s SequenceState) digestObject() map[string]interface{} {
+	if s.Type == TypeReferenceLengthExpression {
+		return map[string]interface{}{
+			"type":                s.Type,
+			"length":              *s.Length,
+			"repeatSubunitLength": s.RepeatSubunitLength,
+		}
+	}
+	return map[string]interface{}{"type": s.Type, "sequence": s.Sequence}
+}
+
+// normalizeAllele applies VRS normalization to the replacement of the
+// interbase interval start-end with alt. Substitutions are trimmed of bases
+// shared with the reference. Insertions and deletions are trimmed, then
+// rolled left and right as far as the reference allows and expanded over
+// the whole ambiguous region.
+func normalizeAllele(window *sequenceWindow, start, end int64, alt string) (int64, int64, SequenceState, error) {
+	ref, err := window.span(start, end)
+	if err != nil {
+		return 0, 0, SequenceState{}, err
+	}
+	for ref != "" && alt != "" && ref[0] == alt[0] {
+		ref, alt = ref[1:], alt[1:]
+		start++
+	}
+	for ref != "" && alt != "" && ref[len(ref)-1] == alt[len(alt)-1] {
+		ref, alt = ref[:len(ref)-1], alt[:len(alt)-1]
+		end--
+	}
+	if ref == "" && alt == "" {
+		return 0, 0, SequenceState{}, ErrNoChange
+	}
+	if ref != "" && alt != "" {
+		return start, end, LiteralSequence(alt), nil
+	}
+
+	// Roll the inserted or deleted bases through the reference
+	unit := ref + alt
+	k := int64(len(unit))
+	left := start
+	for i := int64(0); ; i++ {
+		base, err := window.base(left - 1)
+		if err != nil {
+			return 0, 0, SequenceState{}, err
+		}
+		if base == 0 || base != unit[k-1-i%k] {
+			break
+		}
+		left--
+	}
+	right := end
+	for i := int64(0); ; i++ {
+		base, err := window.base(right)
+		if err != nil {
+			return 0, 0, SequenceState{}, err
+		}
+		if base == 0 || base != unit[i%k] {
+			break
+		}
+		right++
+	}
+
+	extendedRef, err := window.span(left, right)
+	if err != nil {
+		return 0, 0, SequenceState{}, err
+	}
+	extendedAlt := extendedRef[:start-left] + alt + extendedRef[end-left:]
+	switch {
+	case extendedRef == "":
+		// Unambiguous insertion
+		return left, right, LiteralSequence(extendedAlt), nil
+	case len(extendedAlt) < len(extendedRef):
+		// Deletion within a repeat: what remains is reference-derived
+		return left, right, ReferenceLength(extendedAlt, k), nil
+	case int64(len(extendedRef)) >= k && isRepeat(extendedAlt, k):
+		// Insertion of further copies of a reference repeat
+		return left, right, ReferenceLength(extendedAlt, k), nil
+	}
+	return left, right, LiteralSequence(extendedAlt), nil
+}
+
+// isRepeat reports whether seq repeats with a period of k bases
+func isRepeat(seq string, k int64) bool {
+	for i := k; i < int64(len(seq)); i++ {
+		if seq[i] != seq[i-k] {
+			return false
+		}
+	}
+	return true
+}
+
+// sequenceWindow caches reference sequence around the positions an allele
+// is rolled through
+type sequenceWindow struct {
+	reference  Reference
+	chromosome string
+	start      int64 // 0-based position of the first cached base
+	seq        string
+}
+
+// base returns the reference base at a 0-based position, or 0 beyond the
+// chromosome
+func (w *sequenceWindow) base(pos int64) (byte, error) {
+	if pos < 0 {
+		return 0, nil
+	}
+	if pos < w.start || pos >= w.start+int64(len(w.seq)) {
+		from := pos - windowFlank
+		if from < 0 {
+			from = 0
+		}
+		seq, err := w.reference.Sequence(w.chromosome, from+1, pos+windowFlank+1)
+		if err != nil {
+			return 0, err
+		}
+		w.start, w.seq = from, seq
+		if pos >= w.start+int64(len(w.seq)) {
+			return 0, nil
+		}
+	}
+	return w.seq[pos-w.start], nil
+}
+
+// span returns the reference bases of the interbase interval start-end
+func (w *sequenceWindow) span(start, end int64) (string, error) {
+	if end <= start {
+		return "", nil
+	}
+	return w.reference.Sequence(w.chromosome, start+1, end)
+}
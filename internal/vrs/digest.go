@@ -0,0 +1,46 @@
+// Package vrs computes GA4GH Variation Representation Specification (VRS
+// 2.0) identifiers for classified variants and describes classifications as
+// GA4GH Variant Annotation (VA-Spec) statements, so results can be exchanged
+// with other GA4GH-compliant systems. Identifiers are computed digests: the
+// same allele gets the same ID in every implementation, with no registry.
+package vrs
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Namespace prefixes computed identifiers, e.g. ga4gh:VA.<digest>
+const Namespace = "ga4gh"
+
+// Type prefixes of computed identifiers
+const (
+	PrefixAllele   = "VA"
+	PrefixLocation = "SL"
+	PrefixSequence = "SQ"
+)
+
+// Sha512t24u is the GA4GH digest: the first 24 bytes of the SHA-512 of the
+// data, base64url encoded.
+func Sha512t24u(data []byte) string {
+	sum := sha512.Sum512(data)
+	return base64.URLEncoding.EncodeToString(sum[:24])
+}
+
+// Identifier returns the CURIE of a digest, e.g. ga4gh:VA.<digest>
+func Identifier(prefix, digest string) string {
+	return Namespace + ":" + prefix + "." + digest
+}
+
+// digestObject digests the canonical form of an object: JSON with sorted
+// keys and no whitespace, holding only the object's digest properties
+func digestObject(object map[string]interface{}) (string, error) {
+	// encoding/json sorts map keys and writes no insignificant whitespace
+	data, err := json.Marshal(object)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize VRS object: %w", err)
+	}
+	return Sha512t24u(data), nil
+}
@@ -0,0 +1,118 @@
+package vrs
+
+import (
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// ACMGSystem is the coding system of ACMG/AMP classifications and evidence
+// strengths in VA-Spec
+const ACMGSystem = "ACMG Guidelines, 2015"
+
+// Statement is a VA-Spec variant pathogenicity statement: the ACMG/AMP
+// classification of a VRS allele, with one evidence line per met criterion.
+type Statement struct {
+	Type             string         `json:"type"`
+	Proposition      Proposition    `json:"proposition"`
+	Direction        string         `json:"direction"` // supports, disputes or neutral
+	Strength         *Concept       `json:"strength,omitempty"`
+	Classification   Concept        `json:"classification"`
+	SpecifiedBy      Method         `json:"specifiedBy"`
+	HasEvidenceLines []EvidenceLine `json:"hasEvidenceLines,omitempty"`
+}
+
+// Proposition is the claim a statement evaluates
+type Proposition struct {
+	Type           string  `json:"type"`
+	SubjectVariant *Allele `json:"subjectVariant"`
+	Predicate      string  `json:"predicate"`
+}
+
+// Concept is a term from a coding system
+type Concept struct {
+	PrimaryCoding Coding `json:"primaryCoding"`
+}
+
+// Coding is a code in a coding system
+type Coding struct {
+	Code   string `json:"code"`
+	System string `json:"system"`
+}
+
+// Method names how a statement or evidence line was reached
+type Method struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// EvidenceLine is one met ACMG/AMP criterion
+type EvidenceLine struct {
+	Type                        string  `json:"type"`
+	DirectionOfEvidenceProvided string  `json:"directionOfEvidenceProvided"`
+	StrengthOfEvidenceProvided  Concept `json:"strengthOfEvidenceProvided"`
+	SpecifiedBy                 Method  `json:"specifiedBy"`
+}
+
+// Criterion is a met ACMG/AMP criterion at the strength it was applied
+type Criterion struct {
+	Code     string
+	Strength string
+}
+
+// NewPathogenicityStatement describes a germline classification of an
+// allele as a VA-Spec statement. Criteria that do not parse are left out.
+func NewPathogenicityStatement(allele *Allele, classification domain.Classification, criteria []Criterion) *Statement {
+	statement := &Statement{
+		Type: "Statement",
+		Proposition: Proposition{
+			Type:           "VariantPathogenicityProposition",
+			SubjectVariant: allele,
+			Predicate:      "isCausalFor",
+		},
+		Classification: acmgConcept(strings.ToLower(classification.Label())),
+		SpecifiedBy:    Method{Type: "Method", Name: "ACMG/AMP 2015 sequence variant interpretation guidelines"},
+	}
+	switch classification {
+	case domain.PATHOGENIC, domain.LIKELY_PATHOGENIC:
+		statement.Direction = "supports"
+	case domain.BENIGN, domain.LIKELY_BENIGN:
+		statement.Direction = "disputes"
+	default:
+		statement.Direction = "neutral"
+	}
+	switch classification {
+	case domain.PATHOGENIC, domain.BENIGN:
+		strength := acmgConcept("definitive")
+		statement.Strength = &strength
+	case domain.LIKELY_PATHOGENIC, domain.LIKELY_BENIGN:
+		strength := acmgConcept("likely")
+		statement.Strength = &strength
+	}
+
+	for _, criterion := range criteria {
+		strength, err := domain.ParseRuleStrength(criterion.Strength)
+		if err != nil {
+			continue
+		}
+		line := EvidenceLine{
+			Type:                        "EvidenceLine",
+			DirectionOfEvidenceProvided: "supports",
+			StrengthOfEvidenceProvided:  acmgConcept(strings.ToLower(strength.Label())),
+			SpecifiedBy:                 Method{Type: "Method", Name: criterion.Code},
+		}
+		if strings.HasPrefix(criterion.Code, "B") {
+			line.DirectionOfEvidenceProvided = "disputes"
+		}
+		if criterion.Code == "BA1" {
+			line.StrengthOfEvidenceProvided = acmgConcept("stand alone")
+		}
+		statement.HasEvidenceLines = append(statement.HasEvidenceLines, line)
+	}
+	return statement
+}
+
+// acmgConcept returns a concept coded in the ACMG/AMP guidelines
+func acmgConcept(code string) Concept {
+	return Concept{PrimaryCoding: Coding{Code: code, System: ACMGSystem}}
+}
@@ -0,0 +1,143 @@
+package vrs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// stubReference serves chromosomes held in memory
+type stubReference map[string]string
+
+func (r stubReference) Sequence(chromosome string, start, end int64) (string, error) {
+	seq, ok := r[chromosome]
+	if !ok {
+		return "", fmt.Errorf("chromosome %s not in reference sequence", chromosome)
+	}
+	if start < 1 {
+		start = 1
+	}
+	if end > int64(len(seq)) {
+		end = int64(len(seq))
+	}
+	if start > end {
+		return "", nil
+	}
+	return seq[start-1 : end], nil
+}
+
+func (r stubReference) Length(chromosome string) (int64, error) {
+	seq, ok := r[chromosome]
+	if !ok {
+		return 0, fmt.Errorf("chromosome %s not in reference sequence", chromosome)
+	}
+	return int64(len(seq)), nil
+}
+
+func TestSha512t24u(t *testing.T) {
+	assert.Equal(t, "z4PhNX7vuL3xVChQ1m2AB9Yg5AULVxXc", Sha512t24u([]byte("")))
+	assert.Equal(t, "aKF498dAxcJAqme6QYQ7EZ07-fiw8Kw2", Sha512t24u([]byte("ACGT")))
+}
+
+func TestNewAllele(t *testing.T) {
+	// Act: APOE rs7412, NC_000019.10:g.44908822C>T, the VRS 2.0 example
+	allele, err := NewAllele("SQ.IIB53T8CNeJJdUqzn9V_JnRtQadwWCbl", 44908821, 44908822, LiteralSequence("T"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "ga4gh:SL.wIlaGykfwHIpPY2Fcxtbx4TINbbODFVz", allele.Location.ID)
+	assert.Equal(t, "ga4gh:VA.0AePZIWZUNsUlQTamyLrjm2HWUw2opLt", allele.ID)
+	assert.Equal(t, "0AePZIWZUNsUlQTamyLrjm2HWUw2opLt", allele.Digest)
+}
+
+func TestTranslator_SequenceDigest(t *testing.T) {
+	translator := NewTranslator(stubReference{"1": "ACGT"})
+	translator.SetSequenceDigest("19", "SQ.IIB53T8CNeJJdUqzn9V_JnRtQadwWCbl")
+
+	// Act
+	computed, err := translator.SequenceDigest("1")
+	preset, presetErr := translator.SequenceDigest("19")
+	_, missingErr := translator.SequenceDigest("2")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "SQ.aKF498dAxcJAqme6QYQ7EZ07-fiw8Kw2", computed)
+	require.NoError(t, presetErr)
+	assert.Equal(t, "SQ.IIB53T8CNeJJdUqzn9V_JnRtQadwWCbl", preset)
+	require.Error(t, missingErr)
+}
+
+func TestTranslator_Translate(t *testing.T) {
+	// Positions 4-9 are a CA repeat
+	translator := NewTranslator(stubReference{"1": "TTGCACACAGTTTACGTAGG"})
+	tests := []struct {
+		name       string
+		variant    domain.NormalizedVariant
+		start, end int64
+		state      SequenceState
+	}{
+		{"substitution", domain.NormalizedVariant{Start: 11, End: 11, Reference: "T", Alternative: "C"}, 10, 11, LiteralSequence("C")},
+		{"delins trimmed", domain.NormalizedVariant{Start: 10, End: 12, Reference: "GTT", Alternative: "GAT"}, 10, 11, LiteralSequence("A")},
+		{"deletion in repeat", domain.NormalizedVariant{Start: 8, End: 9, Reference: "CA"}, 3, 9, ReferenceLength("CACA", 2)},
+		{"insertion in repeat", domain.NormalizedVariant{Start: 10, End: 9, Alternative: "CA"}, 3, 9, ReferenceLength("CACACACA", 2)},
+		{"unambiguous insertion", domain.NormalizedVariant{Start: 13, End: 12, Alternative: "G"}, 12, 12, LiteralSequence("G")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.variant.Chromosome = "1"
+
+			// Act
+			allele, err := translator.Translate(&tt.variant)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.start, allele.Location.Start)
+			assert.Equal(t, tt.end, allele.Location.End)
+			assert.Equal(t, tt.state, allele.State)
+		})
+	}
+
+	t.Run("same indel at another position", func(t *testing.T) {
+		left, err := translator.Translate(&domain.NormalizedVariant{Chromosome: "1", Start: 4, End: 5, Reference: "CA"})
+		require.NoError(t, err)
+		right, err := translator.Translate(&domain.NormalizedVariant{Chromosome: "1", Start: 8, End: 9, Reference: "CA"})
+		require.NoError(t, err)
+		assert.Equal(t, right.ID, left.ID)
+	})
+
+	t.Run("no change", func(t *testing.T) {
+		_, err := translator.Translate(&domain.NormalizedVariant{Chromosome: "1", Start: 11, End: 11, Reference: "T", Alternative: "T"})
+		assert.ErrorIs(t, err, ErrNoChange)
+	})
+}
+
+func TestNewPathogenicityStatement(t *testing.T) {
+	allele, err := NewAllele("SQ.IIB53T8CNeJJdUqzn9V_JnRtQadwWCbl", 44908821, 44908822, LiteralSequence("T"))
+	require.NoError(t, err)
+
+	// Act
+	statement := NewPathogenicityStatement(allele, domain.LIKELY_PATHOGENIC, []Criterion{
+		{Code: "PS3", Strength: "STRONG"},
+		{Code: "PM2", Strength: "SUPPORTING"},
+		{Code: "BP4", Strength: "SUPPORTING"},
+		{Code: "PP3", Strength: "unknown"},
+	})
+	uncertain := NewPathogenicityStatement(allele, domain.VUS, nil)
+
+	// Assert
+	assert.Equal(t, "VariantPathogenicityProposition", statement.Proposition.Type)
+	assert.Equal(t, allele.ID, statement.Proposition.SubjectVariant.ID)
+	assert.Equal(t, "supports", statement.Direction)
+	assert.Equal(t, "likely pathogenic", statement.Classification.PrimaryCoding.Code)
+	require.NotNil(t, statement.Strength)
+	assert.Equal(t, "likely", statement.Strength.PrimaryCoding.Code)
+	require.Len(t, statement.HasEvidenceLines, 3)
+	assert.Equal(t, "strong", statement.HasEvidenceLines[0].StrengthOfEvidenceProvided.PrimaryCoding.Code)
+	assert.Equal(t, "disputes", statement.HasEvidenceLines[2].DirectionOfEvidenceProvided)
+	assert.Equal(t, "neutral", uncertain.Direction)
+	assert.Nil(t, uncertain.Strength)
+}
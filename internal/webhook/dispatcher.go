@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Delivery headers
+const (
+	HeaderEvent     = "X-ACMG-Event"
+	HeaderDelivery  = "X-ACMG-Delivery"
+	HeaderTimestamp = "X-ACMG-Timestamp"
+	HeaderSignature = "X-ACMG-Signature"
+)
+
+// Delivery defaults
+const (
+	DefaultAttempts = 3
+	DefaultTimeout  = 10 * time.Second
+)
+
+// Sign returns the signature of a delivery: the hex HMAC-SHA256, keyed by
+// the webhook secret, of the timestamp header, a dot and the request body,
+// prefixed with "sha256=". Receivers recompute it to authenticate the
+// delivery and reject stale timestamps to prevent replays.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher delivers events to the matching webhooks in the store.
+// Deliveries run in the background so publishers are not held up by slow
+// receivers; failed deliveries are retried with backoff and then logged.
+type Dispatcher struct {
+	logger   *logrus.Logger
+	store    Store
+	client   *http.Client
+	attempts int
+	backoff  time.Duration
+	wg       sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher for the webhooks in store.
+func NewDispatcher(logger *logrus.Logger, store Store) *Dispatcher {
+	return &Dispatcher{
+		logger:   logger,
+		store:    store,
+		client:   &http.Client{Timeout: DefaultTimeout},
+		attempts: DefaultAttempts,
+		backoff:  time.Second,
+	}
+}
+
+// SetRetry sets the number of delivery attempts and the backoff before the
+// first retry, which doubles for each further retry.
+func (d *Dispatcher) SetRetry(attempts int, backoff time.Duration) {
+	if attempts < 1 {
+		attempts = 1
+	}
+	d.attempts = attempts
+	d.backoff = backoff
+}
+
+// Publish delivers the event to every matching webhook in the background.
+// The event ID and time are set when empty. Store failures are logged.
+func (d *Dispatcher) Publish(ctx context.Context, event *Event) {
+	webhooks, err := d.store.List(ctx)
+	if err != nil {
+		d.logger.WithError(err).WithField("event", event.Type).Warn("Failed to load webhooks")
+		return
+	}
+
+	body, err := encode(event)
+	if err != nil {
+		d.logger.WithError(err).WithField("event", event.Type).Warn("Failed to marshal webhook event")
+		return
+	}
+
+	// Deliveries outlive the request that published the event
+	ctx = context.WithoutCancel(ctx)
+	for _, webhook := range webhooks {
+		if !webhook.Matches(event) {
+			continue
+		}
+		d.wg.Add(1)
+		go func(webhook *Webhook) {
+			defer d.wg.Done()
+			if err := d.deliver(ctx, webhook, event, body); err != nil {
+				d.logger.WithError(err).WithFields(logrus.Fields{
+					"webhook_id": webhook.ID,
+					"event":      event.Type,
+					"event_id":   event.ID,
+				}).Warn("Failed to deliver webhook event")
+			}
+		}(webhook)
+	}
+}
+
+// Deliver sends the event to a single webhook synchronously, retrying
+// failed attempts. It is used to test a newly registered webhook.
+func (d *Dispatcher) Deliver(ctx context.Context, webhook *Webhook, event *Event) error {
+	body, err := encode(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return d.deliver(ctx, webhook, event, body)
+}
+
+// encode sets the event ID and time when empty and returns the request body
+func encode(event *Event) ([]byte, error) {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	return json.Marshal(event)
+}
+
+// Wait blocks until background deliveries have finished.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, webhook *Webhook, event *Event, body []byte) error {
+	backoff := d.backoff
+	var err error
+	for attempt := 1; attempt <= d.attempts; attempt++ {
+		if err = d.post(ctx, webhook, event, body); err == nil {
+			return nil
+		}
+		if attempt == d.attempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return fmt.Errorf("after %d attempts: %w", d.attempts, err)
+}
+
+// post makes one signed delivery attempt; any non-2xx response is a failure
+func (d *Dispatcher) post(ctx context.Context, webhook *Webhook, event *Event, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, event.Type)
+	req.Header.Set(HeaderDelivery, event.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiver records the deliveries made to a test server
+type receiver struct {
+	mu         sync.Mutex
+	deliveries []*http.Request
+	bodies     [][]byte
+	failures   int // Requests to fail before succeeding
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, req)
+	r.bodies = append(r.bodies, body)
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestWebhook_Matches(t *testing.T) {
+	batch := &Event{Type: EventBatchCompleted}
+	changed := &Event{Type: EventClassificationChanged, Variant: "NM_000492.3:c.1521_1523del"}
+	evidence := &Event{Type: EventEvidenceUpdated, Variant: "NM_000492.3:c.1521_1523del"}
+
+	all := &Webhook{}
+	assert.True(t, all.Matches(batch))
+	assert.True(t, all.Matches(changed))
+	assert.False(t, all.Matches(evidence), "evidence updates need a watched variant")
+
+	watching := &Webhook{Variants: []string{"NM_000492.3:c.1521_1523del"}}
+	assert.True(t, watching.Matches(evidence))
+	assert.True(t, watching.Matches(batch))
+
+	other := &Webhook{Variants: []string{"NM_007294.4:c.5266dup"}}
+	assert.False(t, other.Matches(changed))
+
+	batchOnly := &Webhook{Events: []string{EventBatchCompleted}}
+	assert.True(t, batchOnly.Matches(batch))
+	assert.False(t, batchOnly.Matches(changed))
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	store := createTestStore(t)
+	ctx := context.Background()
+	recv := &receiver{failures: 1}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	require.NoError(t, store.Create(ctx, &Webhook{URL: server.URL, Secret: "s3cret", CreatedBy: "admin", Events: []string{EventClassificationChanged}}))
+	require.NoError(t, store.Create(ctx, &Webhook{URL: server.URL, Secret: "other", CreatedBy: "admin", Events: []string{EventBatchCompleted}}))
+
+	dispatcher := NewDispatcher(logrus.New(), store)
+	dispatcher.SetRetry(2, 0)
+
+	// Act
+	dispatcher.Publish(ctx, &Event{
+		Type:    EventClassificationChanged,
+		Variant: "NM_007294.4:c.5266dup",
+		Data:    map[string]string{"previous_classification": "VUS", "current_classification": "LIKELY_PATHOGENIC"},
+	})
+	dispatcher.Wait()
+
+	// Assert: one failed attempt, then the retry to the subscribed webhook
+	require.Len(t, recv.deliveries, 2)
+	req, body := recv.deliveries[1], recv.bodies[1]
+	assert.Equal(t, EventClassificationChanged, req.Header.Get(HeaderEvent))
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, Sign("s3cret", req.Header.Get(HeaderTimestamp), body), req.Header.Get(HeaderSignature))
+
+	var event Event
+	require.NoError(t, json.Unmarshal(body, &event))
+	assert.Equal(t, req.Header.Get(HeaderDelivery), event.ID)
+	assert.Equal(t, "NM_007294.4:c.5266dup", event.Variant)
+	assert.False(t, event.OccurredAt.IsZero())
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	recv := &receiver{failures: 3}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	dispatcher := NewDispatcher(logrus.New(), createTestStore(t))
+	dispatcher.SetRetry(2, 0)
+
+	// Act
+	err := dispatcher.Deliver(context.Background(), &Webhook{URL: server.URL, Secret: "k"}, &Event{Type: EventBatchCompleted})
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 503")
+	assert.Len(t, recv.deliveries, 2)
+}
+
+func TestSign(t *testing.T) {
+	// Reference value from: printf '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", Sign("secret", "1700000000", []byte("{}")))
+}
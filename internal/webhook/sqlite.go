@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite webhook store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS webhooks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		url TEXT NOT NULL,
+		secret TEXT NOT NULL,
+		events TEXT NOT NULL DEFAULT '[]',
+		variants TEXT NOT NULL DEFAULT '[]',
+		created_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+// Create registers a webhook, setting its ID and creation time.
+func (s *SQLiteStore) Create(ctx context.Context, webhook *Webhook) error {
+	if err := webhook.Validate(); err != nil {
+		return err
+	}
+	webhook.CreatedAt = time.Now().UTC()
+
+	events, _ := json.Marshal(nonNil(webhook.Events))
+	variants, _ := json.Marshal(nonNil(webhook.Variants))
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO webhooks (url, secret, events, variants, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, webhook.URL, webhook.Secret, string(events), string(variants), webhook.CreatedBy, webhook.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert webhook: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get webhook ID: %w", err)
+	}
+	webhook.ID = id
+	return nil
+}
+
+// Remove deletes a webhook.
+func (s *SQLiteStore) Remove(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to remove webhook: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List returns all webhooks, oldest first.
+func (s *SQLiteStore) List(ctx context.Context) ([]*Webhook, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, url, secret, events, variants, created_by, created_at FROM webhooks ORDER BY id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*Webhook
+	for rows.Next() {
+		w := &Webhook{}
+		var events, variants string
+		if err := rows.Scan(&w.ID, &w.URL, &w.Secret, &events, &variants, &w.CreatedBy, &w.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := json.Unmarshal([]byte(events), &w.Events); err != nil {
+			return nil, fmt.Errorf("failed to decode events of webhook %d: %w", w.ID, err)
+		}
+		if err := json.Unmarshal([]byte(variants), &w.Variants); err != nil {
+			return nil, fmt.Errorf("failed to decode variants of webhook %d: %w", w.ID, err)
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+// Close closes the store and releases resources.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// nonNil stores an empty list as [] rather than null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "webhooks.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSQLiteStore_CreateListRemove(t *testing.T) {
+	store := createTestStore(t)
+	ctx := context.Background()
+
+	watching := &Webhook{
+		URL:       "https://lims.example.org/hooks/acmg",
+		Secret:    "s3cret",
+		Events:    []string{" Evidence.Updated "},
+		Variants:  []string{"NM_007294.4:c.5266dup", " "},
+		CreatedBy: "curator1",
+	}
+	require.NoError(t, store.Create(ctx, watching))
+	assert.NotZero(t, watching.ID)
+	assert.Equal(t, []string{EventEvidenceUpdated}, watching.Events)
+	require.NoError(t, store.Create(ctx, &Webhook{URL: "http://localhost:9000/", Secret: "k", CreatedBy: "admin"}))
+
+	webhooks, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 2)
+	assert.Equal(t, "s3cret", webhooks[0].Secret)
+	assert.Equal(t, []string{"NM_007294.4:c.5266dup"}, webhooks[0].Variants)
+	assert.Empty(t, webhooks[1].Events)
+
+	require.NoError(t, store.Remove(ctx, watching.ID))
+	assert.ErrorIs(t, store.Remove(ctx, watching.ID), ErrNotFound)
+	webhooks, err = store.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+}
+
+func TestSQLiteStore_CreateInvalid(t *testing.T) {
+	store := createTestStore(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		webhook Webhook
+	}{
+		{"relative url", Webhook{URL: "/hooks", Secret: "k", CreatedBy: "admin"}},
+		{"unsupported scheme", Webhook{URL: "ftp://example.org", Secret: "k", CreatedBy: "admin"}},
+		{"missing secret", Webhook{URL: "https://example.org", CreatedBy: "admin"}},
+		{"missing creator", Webhook{URL: "https://example.org", Secret: "k"}},
+		{"unknown event", Webhook{URL: "https://example.org", Secret: "k", CreatedBy: "admin", Events: []string{"variant.deleted"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, store.Create(ctx, &tt.webhook), ErrInvalidWebhook)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Package webhook delivers lab events to registered HTTP endpoints. Each
+// webhook names the events it receives and, optionally, the variants it
+// watches; matching events are POSTed as JSON signed with the webhook's
+// secret so the receiver can verify they came from this server.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when a webhook does not exist.
+	ErrNotFound = errors.New("webhook not found")
+	// ErrInvalidWebhook is returned when a webhook is missing required fields.
+	ErrInvalidWebhook = errors.New("invalid webhook")
+)
+
+// Event types
+const (
+	// EventBatchCompleted fires when a batch classification finishes.
+	EventBatchCompleted = "batch.completed"
+	// EventClassificationChanged fires when a variant is classified
+	// differently from its previous recorded classification.
+	EventClassificationChanged = "classification.changed"
+	// EventEvidenceUpdated fires when external database evidence for a
+	// watched variant differs from its previous snapshot.
+	EventEvidenceUpdated = "evidence.updated"
+	// EventPing is sent on request to test a webhook; it cannot be subscribed to.
+	EventPing = "ping"
+)
+
+// EventTypes lists the event types webhooks can subscribe to.
+var EventTypes = []string{EventBatchCompleted, EventClassificationChanged, EventEvidenceUpdated}
+
+// Event is a notification delivered to webhooks.
+type Event struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Variant    string      `json:"variant,omitempty"` // Normalized HGVS of the variant the event concerns
+	Data       interface{} `json:"data"`
+}
+
+// Webhook is a registered delivery endpoint.
+type Webhook struct {
+	ID        int64     `json:"id,omitempty"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"-"`                  // HMAC-SHA256 signing key; never returned
+	Events    []string  `json:"events,omitempty"`   // Event types delivered; all when empty
+	Variants  []string  `json:"variants,omitempty"` // Watched variants, by normalized HGVS
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate normalizes the webhook and checks required fields.
+func (w *Webhook) Validate() error {
+	w.URL = strings.TrimSpace(w.URL)
+	w.Secret = strings.TrimSpace(w.Secret)
+	w.CreatedBy = strings.TrimSpace(w.CreatedBy)
+
+	parsed, err := url.Parse(w.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if w.Secret == "" {
+		return fmt.Errorf("%w: secret is required", ErrInvalidWebhook)
+	}
+	if w.CreatedBy == "" {
+		return fmt.Errorf("%w: creator is required", ErrInvalidWebhook)
+	}
+	for i, event := range w.Events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !contains(EventTypes, event) {
+			return fmt.Errorf("%w: unknown event type %q (valid: %s)", ErrInvalidWebhook, event, strings.Join(EventTypes, ", "))
+		}
+		w.Events[i] = event
+	}
+	variants := w.Variants[:0]
+	for _, variant := range w.Variants {
+		if variant = strings.TrimSpace(variant); variant != "" {
+			variants = append(variants, variant)
+		}
+	}
+	w.Variants = variants
+	return nil
+}
+
+// Matches reports whether the event should be delivered to the webhook.
+// Variant events are limited to watched variants when any are listed, and
+// evidence updates are only delivered for watched variants.
+func (w *Webhook) Matches(event *Event) bool {
+	if len(w.Events) > 0 && !contains(w.Events, event.Type) {
+		return false
+	}
+	if event.Variant == "" {
+		return true
+	}
+	if len(w.Variants) == 0 {
+		return event.Type != EventEvidenceUpdated
+	}
+	return contains(w.Variants, event.Variant)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Publisher accepts events for delivery.
+type Publisher interface {
+	Publish(ctx context.Context, event *Event)
+}
+
+// Store defines the interface for webhook storage operations.
+type Store interface {
+	// Create registers a webhook, setting its ID and creation time.
+	Create(ctx context.Context, webhook *Webhook) error
+
+	// Remove deletes a webhook.
+	Remove(ctx context.Context, id int64) error
+
+	// List returns all webhooks, oldest first.
+	List(ctx context.Context) ([]*Webhook, error)
+
+	// Close closes the store.
+	Close() error
+}
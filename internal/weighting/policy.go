@@ -0,0 +1,238 @@
+// Package weighting loads a laboratory's criterion weighting policy: a named
+// set of overrides that change the strength of met ACMG/AMP criteria, either
+// outright or when an in silico predictor score falls in a range, e.g. PP3
+// at moderate when REVEL is at least 0.932 per the ClinGen calibration
+// (Pejaver et al. 2022). The policy in effect and the overrides it applied
+// are recorded with every classification.
+package weighting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// ErrInvalidPolicy is returned when a weighting policy fails validation.
+var ErrInvalidPolicy = errors.New("invalid weighting policy")
+
+// criteria lists the ACMG/AMP criteria a policy may reweight
+var criteria = map[string]bool{
+	"PVS1": true, "PS1": true, "PS2": true, "PS3": true, "PS4": true,
+	"PM1": true, "PM2": true, "PM3": true, "PM4": true, "PM5": true, "PM6": true,
+	"PP1": true, "PP2": true, "PP3": true, "PP4": true, "PP5": true,
+	"BA1": true, "BS1": true, "BS2": true, "BS3": true, "BS4": true,
+	"BP1": true, "BP2": true, "BP3": true, "BP4": true, "BP5": true, "BP6": true, "BP7": true,
+}
+
+// Predictors lists the in silico predictors a condition may test.
+var Predictors = []string{
+	domain.PredictorREVEL, domain.PredictorCADD, domain.PredictorAlphaMissense, domain.PredictorBayesDel,
+	domain.PredictorSIFT, domain.PredictorPolyPhen, domain.PredictorGERP, domain.PredictorPhyloP,
+}
+
+// Policy is a laboratory's criterion weighting policy.
+type Policy struct {
+	Name        string     `json:"name" yaml:"name"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Overrides   []Override `json:"overrides" yaml:"overrides"`
+	File        string     `json:"file,omitempty" yaml:"-"` // File the policy was loaded from
+}
+
+// Override sets the strength of a met criterion. Overrides are tried in
+// order and the first whose condition holds applies; criteria that were not
+// met are never applied by an override.
+type Override struct {
+	Criterion string              `json:"criterion" yaml:"criterion"`
+	Strength  domain.RuleStrength `json:"strength" yaml:"strength"`
+	When      *Condition          `json:"when,omitempty" yaml:"when,omitempty"` // Unconditional when absent
+	Notes     string              `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// Condition holds when a predictor's score lies in an inclusive range.
+// Variants the predictor did not score do not match.
+type Condition struct {
+	Predictor string   `json:"predictor" yaml:"predictor"`
+	MinScore  *float64 `json:"min_score,omitempty" yaml:"min_score,omitempty"`
+	MaxScore  *float64 `json:"max_score,omitempty" yaml:"max_score,omitempty"`
+}
+
+// Applied records an override applied to a criterion.
+type Applied struct {
+	Criterion string `json:"criterion"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Condition string `json:"condition,omitempty"` // e.g. "REVEL >= 0.932"
+}
+
+// String formats the override as "PP3 SUPPORTING -> MODERATE (REVEL >= 0.932)".
+func (a Applied) String() string {
+	s := fmt.Sprintf("%s %s -> %s", a.Criterion, a.From, a.To)
+	if a.Condition != "" {
+		s += " (" + a.Condition + ")"
+	}
+	return s
+}
+
+// Validate normalizes the policy and checks it for errors.
+func (p *Policy) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidPolicy)
+	}
+	if len(p.Overrides) == 0 {
+		return fmt.Errorf("%w: %s has no overrides", ErrInvalidPolicy, p.Name)
+	}
+	for i := range p.Overrides {
+		if err := p.Overrides[i].validate(); err != nil {
+			return fmt.Errorf("%w: %s override %d: %v", ErrInvalidPolicy, p.Name, i+1, err)
+		}
+	}
+	return nil
+}
+
+func (o *Override) validate() error {
+	o.Criterion = strings.ToUpper(strings.TrimSpace(o.Criterion))
+	if !criteria[o.Criterion] {
+		return fmt.Errorf("unknown criterion %q", o.Criterion)
+	}
+	o.Strength = domain.RuleStrength(strings.ToUpper(strings.TrimSpace(string(o.Strength))))
+	if !o.Strength.IsValid() {
+		return fmt.Errorf("%s: invalid strength %q", o.Criterion, o.Strength)
+	}
+	if o.When == nil {
+		return nil
+	}
+	predictor, ok := predictorName(o.When.Predictor)
+	if !ok {
+		return fmt.Errorf("%s: unknown predictor %q; use one of: %s", o.Criterion, o.When.Predictor, strings.Join(Predictors, ", "))
+	}
+	o.When.Predictor = predictor
+	if o.When.MinScore == nil && o.When.MaxScore == nil {
+		return fmt.Errorf("%s: a condition needs min_score or max_score", o.Criterion)
+	}
+	if o.When.MinScore != nil && o.When.MaxScore != nil && *o.When.MaxScore < *o.When.MinScore {
+		return fmt.Errorf("%s: max_score is below min_score", o.Criterion)
+	}
+	return nil
+}
+
+// predictorName returns the canonical name of a predictor, ignoring case
+func predictorName(name string) (string, bool) {
+	for _, predictor := range Predictors {
+		if strings.EqualFold(strings.TrimSpace(name), predictor) {
+			return predictor, true
+		}
+	}
+	return "", false
+}
+
+// Match returns the first override of a criterion whose condition holds
+// for the computational evidence, or nil.
+func (p *Policy) Match(criterion string, data *domain.ComputationalData) *Override {
+	if p == nil {
+		return nil
+	}
+	for i := range p.Overrides {
+		override := &p.Overrides[i]
+		if override.Criterion == criterion && override.When.Holds(data) {
+			return override
+		}
+	}
+	return nil
+}
+
+// Holds reports whether the predictor scored the variant within the range.
+// A nil condition always holds.
+func (c *Condition) Holds(data *domain.ComputationalData) bool {
+	if c == nil {
+		return true
+	}
+	score, ok := Score(data, c.Predictor)
+	if !ok {
+		return false
+	}
+	if c.MinScore != nil && score < *c.MinScore {
+		return false
+	}
+	if c.MaxScore != nil && score > *c.MaxScore {
+		return false
+	}
+	return true
+}
+
+// String formats the condition as "REVEL >= 0.932" or "0.29 <= REVEL <= 0.5".
+func (c *Condition) String() string {
+	switch {
+	case c == nil:
+		return ""
+	case c.MinScore != nil && c.MaxScore != nil:
+		return fmt.Sprintf("%g <= %s <= %g", *c.MinScore, c.Predictor, *c.MaxScore)
+	case c.MinScore != nil:
+		return fmt.Sprintf("%s >= %g", c.Predictor, *c.MinScore)
+	default:
+		return fmt.Sprintf("%s <= %g", c.Predictor, *c.MaxScore)
+	}
+}
+
+// Score returns a predictor's score for the variant, if it was scored.
+func Score(data *domain.ComputationalData, predictor string) (float64, bool) {
+	if data == nil || !data.Has(predictor) {
+		return 0, false
+	}
+	switch predictor {
+	case domain.PredictorREVEL:
+		return data.REVELScore, true
+	case domain.PredictorCADD:
+		return data.CADDScore, true
+	case domain.PredictorAlphaMissense:
+		return data.AlphaMissenseScore, true
+	case domain.PredictorBayesDel:
+		return data.BayesDelScore, true
+	case domain.PredictorSIFT:
+		return data.SIFTScore, true
+	case domain.PredictorPolyPhen:
+		return data.PolyPhenScore, true
+	case domain.PredictorGERP:
+		return data.GERPScore, true
+	case domain.PredictorPhyloP:
+		return data.PhyloPScore, true
+	}
+	return 0, false
+}
+
+// Load reads a JSON or YAML policy file. A missing file yields no policy.
+func Load(path string) (*Policy, error) {
+	unmarshal := yaml.Unmarshal
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		unmarshal = json.Unmarshal
+	case ".yaml", ".yml":
+	default:
+		return nil, fmt.Errorf("unsupported weighting policy format: %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read weighting policy: %w", err)
+	}
+
+	policy := &Policy{}
+	if err := unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse weighting policy %s: %w", filepath.Base(path), err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	policy.File = path
+	return policy, nil
+}
@@ -0,0 +1,99 @@
+package weighting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func score(f float64) *float64 { return &f }
+
+func TestPolicy_Validate(t *testing.T) {
+	policy := &Policy{Name: " lab ", Overrides: []Override{
+		{Criterion: "pp3", Strength: "moderate", When: &Condition{Predictor: "revel", MinScore: score(0.773)}},
+	}}
+	require.NoError(t, policy.Validate())
+	assert.Equal(t, "lab", policy.Name)
+	assert.Equal(t, "PP3", policy.Overrides[0].Criterion)
+	assert.Equal(t, domain.MODERATE, policy.Overrides[0].Strength)
+	assert.Equal(t, domain.PredictorREVEL, policy.Overrides[0].When.Predictor)
+
+	tests := []struct {
+		name   string
+		policy Policy
+		want   string
+	}{
+		{"no name", Policy{Overrides: []Override{{Criterion: "PP3", Strength: domain.MODERATE}}}, "name is required"},
+		{"no overrides", Policy{Name: "lab"}, "no overrides"},
+		{"unknown criterion", Policy{Name: "lab", Overrides: []Override{{Criterion: "PP9", Strength: domain.MODERATE}}}, "unknown criterion"},
+		{"bad strength", Policy{Name: "lab", Overrides: []Override{{Criterion: "PP3", Strength: "HIGH"}}}, "invalid strength"},
+		{"unknown predictor", Policy{Name: "lab", Overrides: []Override{{Criterion: "PP3", Strength: domain.MODERATE, When: &Condition{Predictor: "MutPred", MinScore: score(0.8)}}}}, "unknown predictor"},
+		{"empty range", Policy{Name: "lab", Overrides: []Override{{Criterion: "PP3", Strength: domain.MODERATE, When: &Condition{Predictor: "REVEL"}}}}, "min_score or max_score"},
+		{"inverted range", Policy{Name: "lab", Overrides: []Override{{Criterion: "PP3", Strength: domain.MODERATE, When: &Condition{Predictor: "REVEL", MinScore: score(0.9), MaxScore: score(0.5)}}}}, "below min_score"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			assert.ErrorIs(t, err, ErrInvalidPolicy)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestPolicy_Match(t *testing.T) {
+	policy, err := Load("../../examples/weighting_policy.yaml")
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+	assert.Equal(t, "example-lab-2024", policy.Name)
+
+	revel := func(s float64) *domain.ComputationalData {
+		return &domain.ComputationalData{REVELScore: s, ScoredBy: []string{domain.PredictorREVEL}}
+	}
+	assert.Equal(t, domain.STRONG, policy.Match("PP3", revel(0.95)).Strength)
+	assert.Equal(t, domain.MODERATE, policy.Match("PP3", revel(0.8)).Strength)
+	assert.Nil(t, policy.Match("PP3", revel(0.7)))
+	assert.Equal(t, domain.MODERATE, policy.Match("BP4", revel(0.1)).Strength)
+	assert.Nil(t, policy.Match("PM2", revel(0.95)))
+
+	// Without a REVEL score no conditional override matches
+	assert.Nil(t, policy.Match("PP3", &domain.ComputationalData{CADDScore: 35}))
+	assert.Nil(t, policy.Match("PP3", nil))
+
+	var none *Policy
+	assert.Nil(t, none.Match("PP3", revel(0.95)))
+}
+
+func TestCondition_String(t *testing.T) {
+	assert.Equal(t, "REVEL >= 0.932", (&Condition{Predictor: "REVEL", MinScore: score(0.932)}).String())
+	assert.Equal(t, "REVEL <= 0.183", (&Condition{Predictor: "REVEL", MaxScore: score(0.183)}).String())
+	assert.Equal(t, "0.29 <= REVEL <= 0.5", (&Condition{Predictor: "REVEL", MinScore: score(0.29), MaxScore: score(0.5)}).String())
+	assert.Equal(t, "PP3 SUPPORTING -> MODERATE (REVEL >= 0.773)", Applied{Criterion: "PP3", From: "SUPPORTING", To: "MODERATE", Condition: "REVEL >= 0.773"}.String())
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	// A missing file means no policy
+	policy, err := Load(filepath.Join(dir, "missing.yaml"))
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	jsonPath := filepath.Join(dir, "weighting_policy.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"name": "lab", "overrides": [{"criterion": "PM2", "strength": "SUPPORTING"}]}`), 0o644))
+	policy, err = Load(jsonPath)
+	require.NoError(t, err)
+	assert.Equal(t, jsonPath, policy.File)
+	assert.NotNil(t, policy.Match("PM2", nil))
+
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"name": "lab", "overrides": [{"criterion": "PM2", "strength": "HIGH"}]}`), 0o644))
+	_, err = Load(jsonPath)
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+
+	_, err = Load(filepath.Join(dir, "weighting_policy.txt"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,213 @@
+package wizard
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements the Store interface using SQLite.
+type SQLiteStore struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteStore creates a new SQLite classification session store.
+// It creates the database file and schema if they don't exist.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
+	}
+
+	// Create schema
+	if err := createSchema(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteStore{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// createSchema creates the database tables and indexes.
+func createSchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS classification_sessions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		hgvs_notation TEXT NOT NULL,
+		variant_id TEXT DEFAULT '',
+		classification_id INTEGER NOT NULL DEFAULT 0,
+		analyst_id TEXT NOT NULL,
+		status TEXT NOT NULL,
+		current_category TEXT DEFAULT '',
+		automated_classification TEXT DEFAULT '',
+		automated_rules TEXT DEFAULT '',
+		assertions TEXT DEFAULT '[]',
+		final_classification TEXT DEFAULT '',
+		final_confidence TEXT DEFAULT '',
+		finalized_by TEXT DEFAULT '',
+		final_record_id INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL,
+		finalized_at DATETIME
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_sessions_variant ON classification_sessions(hgvs_notation, created_at);
+	`
+
+	_, err := db.Exec(schema)
+	return err
+}
+
+const sessionColumns = `id, hgvs_notation, variant_id, classification_id, analyst_id, status,
+	current_category, automated_classification, automated_rules, assertions,
+	final_classification, final_confidence, finalized_by, final_record_id,
+	created_at, updated_at, finalized_at`
+
+// scanSession scans a row into a Session struct.
+func scanSession(row *sql.Row) (*Session, error) {
+	s := &Session{}
+	var status, automatedRules, assertions string
+	var finalizedAt sql.NullTime
+
+	err := row.Scan(
+		&s.ID, &s.HGVSNotation, &s.VariantID, &s.ClassificationID, &s.AnalystID, &status,
+		&s.CurrentCategory, &s.AutomatedClassification, &automatedRules, &assertions,
+		&s.FinalClassification, &s.FinalConfidence, &s.FinalizedBy, &s.FinalRecordID,
+		&s.CreatedAt, &s.UpdatedAt, &finalizedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Status = Status(status)
+	if automatedRules != "" {
+		s.AutomatedRules = json.RawMessage(automatedRules)
+	}
+	if err := json.Unmarshal([]byte(assertions), &s.Assertions); err != nil {
+		return nil, fmt.Errorf("failed to decode assertions: %w", err)
+	}
+	if s.Assertions == nil {
+		s.Assertions = []Assertion{}
+	}
+	if finalizedAt.Valid {
+		t := finalizedAt.Time
+		s.FinalizedAt = &t
+	}
+	return s, nil
+}
+
+// Create saves a new open session, setting its ID and timestamps.
+func (s *SQLiteStore) Create(ctx context.Context, session *Session) error {
+	session.HGVSNotation = strings.TrimSpace(session.HGVSNotation)
+	if session.HGVSNotation == "" {
+		return fmt.Errorf("HGVS notation is required")
+	}
+	session.AnalystID = strings.TrimSpace(session.AnalystID)
+	if session.AnalystID == "" {
+		return fmt.Errorf("analyst ID is required")
+	}
+	if session.Assertions == nil {
+		session.Assertions = []Assertion{}
+	}
+	assertions, err := json.Marshal(session.Assertions)
+	if err != nil {
+		return fmt.Errorf("failed to encode assertions: %w", err)
+	}
+
+	session.Status = StatusOpen
+	session.CreatedAt = time.Now().UTC()
+	session.UpdatedAt = session.CreatedAt
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO classification_sessions (hgvs_notation, variant_id, classification_id, analyst_id, status,
+			current_category, automated_classification, automated_rules, assertions, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, session.HGVSNotation, session.VariantID, session.ClassificationID, session.AnalystID, string(session.Status),
+		session.CurrentCategory, session.AutomatedClassification, string(session.AutomatedRules), string(assertions),
+		session.CreatedAt, session.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert session: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get session ID: %w", err)
+	}
+	session.ID = id
+	return nil
+}
+
+// Get returns a session.
+func (s *SQLiteStore) Get(ctx context.Context, id int64) (*Session, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+sessionColumns+" FROM classification_sessions WHERE id = ?", id)
+	session, err := scanSession(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query session: %w", err)
+	}
+	return session, nil
+}
+
+// Update saves the progress, assertions and final call of an open session.
+func (s *SQLiteStore) Update(ctx context.Context, session *Session) error {
+	assertions, err := json.Marshal(session.Assertions)
+	if err != nil {
+		return fmt.Errorf("failed to encode assertions: %w", err)
+	}
+
+	session.UpdatedAt = time.Now().UTC()
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE classification_sessions SET status = ?, current_category = ?, assertions = ?,
+			final_classification = ?, final_confidence = ?, finalized_by = ?, final_record_id = ?,
+			updated_at = ?, finalized_at = ?
+		WHERE id = ? AND status = ?
+	`, string(session.Status), session.CurrentCategory, string(assertions),
+		session.FinalClassification, session.FinalConfidence, session.FinalizedBy, session.FinalRecordID,
+		session.UpdatedAt, session.FinalizedAt, session.ID, string(StatusOpen))
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	if n == 0 {
+		// Either missing or finalized by an earlier call
+		if _, err := s.Get(ctx, session.ID); err != nil {
+			return err
+		}
+		return ErrSessionFinalized
+	}
+	return nil
+}
+
+// Close closes the store and releases resources.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,94 @@
+package wizard
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "classification_sessions.db"))
+	require.NoError(t, err)
+	return store
+}
+
+func TestSQLiteStore_CreateAndGet(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	session := &Session{
+		HGVSNotation:            "NM_000546.6:c.743G>A",
+		VariantID:               "VAR_1",
+		AnalystID:               "analyst1",
+		CurrentCategory:         "population",
+		AutomatedClassification: "Likely pathogenic",
+		AutomatedRules:          json.RawMessage(`[{"rule_code":"PM2","applied":true}]`),
+	}
+
+	// Act
+	require.NoError(t, store.Create(ctx, session))
+	got, err := store.Get(ctx, session.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotZero(t, session.ID)
+	assert.Equal(t, StatusOpen, got.Status)
+	assert.True(t, got.IsOpen())
+	assert.Equal(t, "population", got.CurrentCategory)
+	assert.JSONEq(t, `[{"rule_code":"PM2","applied":true}]`, string(got.AutomatedRules))
+	assert.Empty(t, got.Assertions)
+	assert.Nil(t, got.FinalizedAt)
+
+	_, err = store.Get(ctx, session.ID+1)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+	assert.Error(t, store.Create(ctx, &Session{HGVSNotation: "NM_000546.6:c.743G>A"}), "analyst required")
+}
+
+func TestSQLiteStore_UpdateAndFinalize(t *testing.T) {
+	store := createTestStore(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	session := &Session{HGVSNotation: "NM_000546.6:c.743G>A", AnalystID: "analyst1", CurrentCategory: "population"}
+	require.NoError(t, store.Create(ctx, session))
+
+	// Act: assert PS3, then revise it
+	session.Assert(Assertion{Code: "PS3", Category: "functional", Applied: true, Justification: "Yeast assay", AssertedBy: "analyst1"})
+	session.Assert(Assertion{Code: "PS3", Category: "functional", Applied: true, Strength: "moderate", Justification: "Single assay, not validated", AssertedBy: "analyst1"})
+	session.CurrentCategory = "segregation"
+	require.NoError(t, store.Update(ctx, session))
+
+	// Assert
+	got, err := store.Get(ctx, session.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Assertions, 1)
+	assertion, ok := got.AssertionFor("PS3")
+	require.True(t, ok)
+	assert.Equal(t, "moderate", assertion.Strength)
+	assert.Equal(t, "segregation", got.CurrentCategory)
+
+	// Finalizing closes the session to further changes
+	now := time.Now().UTC()
+	got.Status = StatusFinalized
+	got.CurrentCategory = ""
+	got.FinalClassification = "Likely pathogenic"
+	got.FinalizedBy = "analyst2"
+	got.FinalizedAt = &now
+	require.NoError(t, store.Update(ctx, got))
+
+	finalized, err := store.Get(ctx, session.ID)
+	require.NoError(t, err)
+	assert.False(t, finalized.IsOpen())
+	assert.Equal(t, "analyst2", finalized.FinalizedBy)
+	require.NotNil(t, finalized.FinalizedAt)
+
+	assert.ErrorIs(t, store.Update(ctx, got), ErrSessionFinalized)
+	assert.ErrorIs(t, store.Update(ctx, &Session{ID: session.ID + 1}), ErrSessionNotFound)
+}
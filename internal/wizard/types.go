@@ -0,0 +1,102 @@
+// Package wizard keeps the state of guided classification sessions. In a
+// session an analyst reviews the criteria of a variant one evidence category
+// at a time, asserts or overrides criteria with a justification, and signs
+// out a final call that merges their assertions with the automated evidence.
+package wizard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrSessionNotFound is returned when a session ID does not exist.
+	ErrSessionNotFound = errors.New("classification session not found")
+	// ErrSessionFinalized is returned when a finalized session is changed.
+	ErrSessionFinalized = errors.New("classification session already finalized")
+)
+
+// Status is the state of a session.
+type Status string
+
+const (
+	StatusOpen      Status = "open"
+	StatusFinalized Status = "finalized"
+)
+
+// Assertion is an analyst's call on one criterion. It replaces the
+// automated result of the criterion in the final call.
+type Assertion struct {
+	Code          string    `json:"code"`
+	Category      string    `json:"category"` // Evidence category the assertion was made in
+	Applied       bool      `json:"applied"`
+	Strength      string    `json:"strength,omitempty"` // Strength applied at; empty for the criterion's default
+	Justification string    `json:"justification"`
+	AssertedBy    string    `json:"asserted_by"`
+	AssertedAt    time.Time `json:"asserted_at"`
+}
+
+// Session is a guided classification of one variant.
+type Session struct {
+	ID                      int64           `json:"id,omitempty"`
+	HGVSNotation            string          `json:"hgvs_notation"`
+	VariantID               string          `json:"variant_id,omitempty"`
+	ClassificationID        int64           `json:"classification_id,omitempty"` // Audit record the session started from, if any
+	AnalystID               string          `json:"analyst_id"`                  // Analyst who started the session
+	Status                  Status          `json:"status"`
+	CurrentCategory         string          `json:"current_category,omitempty"` // Category under review; empty once every category is reviewed
+	AutomatedClassification string          `json:"automated_classification"`
+	AutomatedRules          json.RawMessage `json:"automated_rules,omitempty"` // Every criterion evaluated automatically
+	Assertions              []Assertion     `json:"assertions"`
+	FinalClassification     string          `json:"final_classification,omitempty"`
+	FinalConfidence         string          `json:"final_confidence,omitempty"`
+	FinalizedBy             string          `json:"finalized_by,omitempty"`
+	FinalRecordID           int64           `json:"final_record_id,omitempty"` // Audit record of the final call
+	CreatedAt               time.Time       `json:"created_at"`
+	UpdatedAt               time.Time       `json:"updated_at"`
+	FinalizedAt             *time.Time      `json:"finalized_at,omitempty"`
+}
+
+// IsOpen reports whether the session still accepts evidence.
+func (s *Session) IsOpen() bool {
+	return s.Status == StatusOpen
+}
+
+// Assert records an assertion, replacing any earlier one on the criterion.
+func (s *Session) Assert(assertion Assertion) {
+	for i, existing := range s.Assertions {
+		if existing.Code == assertion.Code {
+			s.Assertions[i] = assertion
+			return
+		}
+	}
+	s.Assertions = append(s.Assertions, assertion)
+}
+
+// AssertionFor returns the analyst's assertion on a criterion, if any.
+func (s *Session) AssertionFor(code string) (Assertion, bool) {
+	for _, assertion := range s.Assertions {
+		if assertion.Code == code {
+			return assertion, true
+		}
+	}
+	return Assertion{}, false
+}
+
+// Store defines the interface for classification session storage.
+type Store interface {
+	// Create saves a new open session, setting its ID and timestamps.
+	Create(ctx context.Context, session *Session) error
+
+	// Get returns a session. Returns ErrSessionNotFound if it does not exist.
+	Get(ctx context.Context, id int64) (*Session, error)
+
+	// Update saves the progress, assertions and final call of an open
+	// session. Returns ErrSessionFinalized if it was already finalized.
+	Update(ctx context.Context, session *Session) error
+
+	// Close closes the store and releases resources.
+	Close() error
+}
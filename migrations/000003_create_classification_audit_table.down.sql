@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_classification_audit_created_at;
+DROP INDEX IF EXISTS idx_classification_audit_classification;
+DROP INDEX IF EXISTS idx_classification_audit_hgvs;
+DROP INDEX IF EXISTS idx_classification_audit_variant_id;
+
+-- Drop table
+DROP TABLE IF EXISTS classification_audit;
@@ -0,0 +1,23 @@
+-- Create classification_audit table for the append-only classification audit trail
+CREATE TABLE IF NOT EXISTS classification_audit (
+    id BIGSERIAL PRIMARY KEY,
+    variant_id VARCHAR(100) NOT NULL DEFAULT '',
+    hgvs_notation TEXT NOT NULL DEFAULT '',
+    request JSONB NOT NULL,
+    evidence JSONB,
+    applied_rules JSONB,
+    classification VARCHAR(50) NOT NULL DEFAULT '',
+    confidence VARCHAR(50) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    engine_version VARCHAR(50) NOT NULL,
+    scoring_mode VARCHAR(20) NOT NULL DEFAULT '',
+    threshold_revision BIGINT NOT NULL DEFAULT 0,
+    specification VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for efficient querying
+CREATE INDEX IF NOT EXISTS idx_classification_audit_variant_id ON classification_audit (variant_id);
+CREATE INDEX IF NOT EXISTS idx_classification_audit_hgvs ON classification_audit (hgvs_notation);
+CREATE INDEX IF NOT EXISTS idx_classification_audit_classification ON classification_audit (classification);
+CREATE INDEX IF NOT EXISTS idx_classification_audit_created_at ON classification_audit (created_at);
@@ -0,0 +1,2 @@
+-- Record the config repository commit each classification was made with
+ALTER TABLE classification_audit ADD COLUMN IF NOT EXISTS config_commit VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE classification_audit DROP COLUMN IF EXISTS dataset_versions;
@@ -0,0 +1,2 @@
+-- Record the dataset versions (ClinVar release, gnomAD dataset, dbNSFP build) each classification was made with
+ALTER TABLE classification_audit ADD COLUMN IF NOT EXISTS dataset_versions JSONB;
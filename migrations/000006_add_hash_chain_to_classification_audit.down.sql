@@ -0,0 +1,2 @@
+ALTER TABLE classification_audit DROP COLUMN IF EXISTS hash;
+ALTER TABLE classification_audit DROP COLUMN IF EXISTS prev_hash;
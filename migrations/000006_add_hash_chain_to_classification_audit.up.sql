@@ -0,0 +1,4 @@
+-- Chain audit records by hash so modified or removed records are detectable;
+-- records written before this migration keep empty hashes
+ALTER TABLE classification_audit ADD COLUMN IF NOT EXISTS prev_hash VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE classification_audit ADD COLUMN IF NOT EXISTS hash VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,246 @@
+package external
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sony/gobreaker"
+)
+
+// defaultBreakerTimeout is gobreaker's open period when Settings.Timeout is unset
+const defaultBreakerTimeout = 60 * time.Second
+
+// Breaker is the circuit breaker in front of one external API. Besides
+// tripping on failures, it can be forced open by an operator, refusing every
+// request until reset, and reset to closed with its counts cleared.
+type Breaker struct {
+	settings gobreaker.Settings
+	timeout  time.Duration
+
+	mu          sync.RWMutex
+	cb          *gobreaker.CircuitBreaker
+	generation  int
+	openedAt    time.Time // When the breaker last tripped open
+	changedAt   time.Time
+	forced      bool
+	forcedBy    string
+	forceReason string
+}
+
+// BreakerStatus is a breaker's state for dashboards
+type BreakerStatus struct {
+	Name                 string     `json:"name"`
+	State                string     `json:"state"` // closed, open or half-open
+	ForcedOpen           bool       `json:"forced_open"`
+	ForcedBy             string     `json:"forced_by,omitempty"`
+	ForceReason          string     `json:"force_reason,omitempty"`
+	Requests             uint32     `json:"requests"` // Counted since the last state change or closed-state interval
+	TotalFailures        uint32     `json:"total_failures"`
+	ConsecutiveFailures  uint32     `json:"consecutive_failures"`
+	ConsecutiveSuccesses uint32     `json:"consecutive_successes"`
+	MaxHalfOpenRequests  uint32     `json:"max_half_open_requests"`
+	OpenTimeout          string     `json:"open_timeout"`
+	OpenedAt             *time.Time `json:"opened_at,omitempty"`
+	NextRetry            *time.Time `json:"next_retry,omitempty"` // When an open breaker lets a trial request through; unset while forced open
+	ChangedAt            *time.Time `json:"changed_at,omitempty"`
+}
+
+// NewBreaker creates a breaker and registers it in CircuitBreakers under
+// settings.Name, replacing any breaker of that name.
+func NewBreaker(settings gobreaker.Settings) *Breaker {
+	b := &Breaker{settings: settings, timeout: settings.Timeout}
+	if b.timeout <= 0 {
+		b.timeout = defaultBreakerTimeout
+	}
+	b.cb = b.newCircuitBreaker()
+	CircuitBreakers.register(b)
+	return b
+}
+
+// newCircuitBreaker creates the underlying breaker for the next generation;
+// callers hold mu or own b exclusively
+func (b *Breaker) newCircuitBreaker() *gobreaker.CircuitBreaker {
+	b.generation++
+	generation := b.generation
+	settings := b.settings
+	onStateChange := settings.OnStateChange
+	settings.OnStateChange = func(name string, from, to gobreaker.State) {
+		b.mu.Lock()
+		if generation == b.generation {
+			b.changedAt = time.Now().UTC()
+			if to == gobreaker.StateOpen {
+				b.openedAt = b.changedAt
+			}
+		}
+		b.mu.Unlock()
+		if onStateChange != nil {
+			onStateChange(name, from, to)
+		}
+	}
+	return gobreaker.NewCircuitBreaker(settings)
+}
+
+// current returns the underlying breaker and whether it is forced open
+func (b *Breaker) current() (*gobreaker.CircuitBreaker, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.cb, b.forced
+}
+
+// Name returns the breaker's name.
+func (b *Breaker) Name() string {
+	return b.settings.Name
+}
+
+// Execute runs req unless the breaker is open, returning
+// gobreaker.ErrOpenState when it is.
+func (b *Breaker) Execute(req func() (interface{}, error)) (interface{}, error) {
+	cb, forced := b.current()
+	if forced {
+		return nil, gobreaker.ErrOpenState
+	}
+	return cb.Execute(req)
+}
+
+// State returns the breaker's state; a forced breaker is open.
+func (b *Breaker) State() gobreaker.State {
+	cb, forced := b.current()
+	if forced {
+		return gobreaker.StateOpen
+	}
+	return cb.State()
+}
+
+// Counts returns the requests counted in the current state.
+func (b *Breaker) Counts() gobreaker.Counts {
+	cb, _ := b.current()
+	return cb.Counts()
+}
+
+// ForceOpen refuses every request until Reset, e.g. while an upstream is
+// known to be degraded.
+func (b *Breaker) ForceOpen(by, reason string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.forced = true
+	b.forcedBy = by
+	b.forceReason = reason
+	b.changedAt = time.Now().UTC()
+	b.openedAt = b.changedAt
+}
+
+// Reset closes the breaker, clearing a forced open and the failure counts.
+func (b *Breaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cb = b.newCircuitBreaker()
+	b.forced = false
+	b.forcedBy = ""
+	b.forceReason = ""
+	b.openedAt = time.Time{}
+	b.changedAt = time.Now().UTC()
+}
+
+// Status reports the breaker's state, counts and next retry.
+func (b *Breaker) Status() BreakerStatus {
+	state := b.State()
+	counts := b.Counts()
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	status := BreakerStatus{
+		Name:                 b.settings.Name,
+		State:                state.String(),
+		ForcedOpen:           b.forced,
+		ForcedBy:             b.forcedBy,
+		ForceReason:          b.forceReason,
+		Requests:             counts.Requests,
+		TotalFailures:        counts.TotalFailures,
+		ConsecutiveFailures:  counts.ConsecutiveFailures,
+		ConsecutiveSuccesses: counts.ConsecutiveSuccesses,
+		MaxHalfOpenRequests:  b.settings.MaxRequests,
+		OpenTimeout:          b.timeout.String(),
+	}
+	if status.MaxHalfOpenRequests == 0 {
+		status.MaxHalfOpenRequests = 1
+	}
+	if !b.changedAt.IsZero() {
+		changedAt := b.changedAt
+		status.ChangedAt = &changedAt
+	}
+	if state == gobreaker.StateOpen && !b.openedAt.IsZero() {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+		if !b.forced {
+			nextRetry := openedAt.Add(b.timeout)
+			status.NextRetry = &nextRetry
+		}
+	}
+	return status
+}
+
+// BreakerRegistry holds the circuit breakers of the external APIs by name.
+type BreakerRegistry struct {
+	mu       sync.RWMutex
+	breakers map[string]*Breaker
+}
+
+// CircuitBreakers holds every breaker created with NewBreaker.
+var CircuitBreakers = NewBreakerRegistry()
+
+// NewBreakerRegistry creates an empty registry.
+func NewBreakerRegistry() *BreakerRegistry {
+	return &BreakerRegistry{breakers: make(map[string]*Breaker)}
+}
+
+func (r *BreakerRegistry) register(b *Breaker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.breakers[strings.ToLower(b.Name())] = b
+}
+
+// Get returns a breaker by name, ignoring case.
+func (r *BreakerRegistry) Get(name string) (*Breaker, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if b, ok := r.breakers[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("unknown circuit breaker %q; known breakers: %s", name, strings.Join(r.namesLocked(), ", "))
+}
+
+// Names returns the registered breaker names in order.
+func (r *BreakerRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.namesLocked()
+}
+
+func (r *BreakerRegistry) namesLocked() []string {
+	names := make([]string, 0, len(r.breakers))
+	for _, b := range r.breakers {
+		names = append(names, b.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Statuses returns every breaker's status, ordered by name.
+func (r *BreakerRegistry) Statuses() []BreakerStatus {
+	r.mu.RLock()
+	breakers := make([]*Breaker, 0, len(r.breakers))
+	for _, b := range r.breakers {
+		breakers = append(breakers, b)
+	}
+	r.mu.RUnlock()
+
+	statuses := make([]BreakerStatus, len(breakers))
+	for i, b := range breakers {
+		statuses[i] = b.Status()
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
@@ -0,0 +1,98 @@
+package external
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBreaker(name string) *Breaker {
+	return NewBreaker(gobreaker.Settings{
+		Name:    name,
+		Timeout: time.Minute,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= 2
+		},
+	})
+}
+
+func TestBreaker_TripsAndReports(t *testing.T) {
+	b := newTestBreaker("TestTrip")
+	fail := func() (interface{}, error) { return nil, errors.New("upstream error") }
+
+	_, _ = b.Execute(fail)
+	status := b.Status()
+	assert.Equal(t, "closed", status.State)
+	assert.Equal(t, uint32(1), status.ConsecutiveFailures)
+	assert.Nil(t, status.NextRetry)
+
+	_, _ = b.Execute(fail)
+	status = b.Status()
+	assert.Equal(t, "open", status.State)
+	require.NotNil(t, status.OpenedAt)
+	require.NotNil(t, status.NextRetry)
+	assert.Equal(t, time.Minute, status.NextRetry.Sub(*status.OpenedAt))
+	assert.Equal(t, "1m0s", status.OpenTimeout)
+
+	_, err := b.Execute(func() (interface{}, error) { return "ok", nil })
+	assert.ErrorIs(t, err, gobreaker.ErrOpenState)
+
+	// Reset closes the breaker with fresh counts
+	b.Reset()
+	status = b.Status()
+	assert.Equal(t, "closed", status.State)
+	assert.Zero(t, status.ConsecutiveFailures)
+	assert.Nil(t, status.OpenedAt)
+	result, err := b.Execute(func() (interface{}, error) { return "ok", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result)
+}
+
+func TestBreaker_ForceOpen(t *testing.T) {
+	b := newTestBreaker("TestForce")
+
+	b.ForceOpen("oncall", "ClinVar maintenance window")
+	called := false
+	_, err := b.Execute(func() (interface{}, error) {
+		called = true
+		return nil, nil
+	})
+	assert.ErrorIs(t, err, gobreaker.ErrOpenState)
+	assert.False(t, called)
+
+	status := b.Status()
+	assert.Equal(t, "open", status.State)
+	assert.True(t, status.ForcedOpen)
+	assert.Equal(t, "oncall", status.ForcedBy)
+	assert.Equal(t, "ClinVar maintenance window", status.ForceReason)
+	assert.NotNil(t, status.OpenedAt)
+	assert.Nil(t, status.NextRetry, "a forced breaker stays open until reset")
+
+	b.Reset()
+	status = b.Status()
+	assert.Equal(t, "closed", status.State)
+	assert.False(t, status.ForcedOpen)
+	assert.Empty(t, status.ForceReason)
+}
+
+func TestBreakerRegistry(t *testing.T) {
+	b := newTestBreaker("TestRegistry")
+
+	got, err := CircuitBreakers.Get("testregistry")
+	require.NoError(t, err)
+	assert.Same(t, b, got)
+	assert.Contains(t, CircuitBreakers.Names(), "TestRegistry")
+
+	_, err = CircuitBreakers.Get("NoSuchSource")
+	assert.ErrorContains(t, err, "TestRegistry")
+
+	var names []string
+	for _, status := range CircuitBreakers.Statuses() {
+		names = append(names, status.Name)
+	}
+	assert.IsIncreasing(t, names)
+}
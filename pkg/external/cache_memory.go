@@ -0,0 +1,301 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/acmg-amp-mcp-server/internal/metrics"
+)
+
+// memoryEntry is a cached value and when it expires
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// size is the memory an entry is charged for: its key and value
+func (e memoryEntry) size(key string) int64 {
+	return int64(len(key) + len(e.value))
+}
+
+// spilledEntry is an entry evicted from memory on its way to disk
+type spilledEntry struct {
+	key   string
+	entry memoryEntry
+}
+
+// MemoryCacheLimits bounds the in-memory cache
+type MemoryCacheLimits struct {
+	MaxEntries int   // defaultCacheMaxEntries if not positive
+	MaxBytes   int64 // Total size of keys and values; unbounded if not positive
+}
+
+// MemoryCacheStats reports the size and counters of the in-memory cache and
+// its disk tier
+type MemoryCacheStats struct {
+	Entries     int   `json:"entries"`
+	Bytes       int64 `json:"bytes"`
+	MaxEntries  int   `json:"max_entries"`
+	MaxBytes    int64 `json:"max_bytes,omitempty"`
+	Hits        int64 `json:"hits"`
+	Misses      int64 `json:"misses"`    // Found neither in memory nor on disk
+	Evictions   int64 `json:"evictions"` // Entries evicted to stay within the limits
+	Expirations int64 `json:"expirations"`
+	DiskEntries int   `json:"disk_entries,omitempty"`
+	DiskHits    int64 `json:"disk_hits,omitempty"` // Memory misses served from disk
+	Spilled     int64 `json:"spilled,omitempty"`   // Evicted entries written to disk
+}
+
+// MemoryCacheStore stores cached external API responses in process memory,
+// evicting the least recently used entries once it holds MaxEntries entries
+// or MaxBytes bytes. With a disk tier, evicted entries are written to disk
+// and read back, and moved back into memory, on a memory miss.
+type MemoryCacheStore struct {
+	mu       sync.Mutex
+	entries  *simplelru.LRU[string, memoryEntry]
+	capacity int
+	maxBytes int64
+	bytes    int64
+	disk     *SQLiteCacheStore // nil without a disk tier
+	now      func() time.Time
+
+	dropping bool           // Removals are deletions, not evictions
+	evicted  []spilledEntry // Evicted by the current operation, to write to disk
+
+	hits, misses, evictions, expirations, diskHits, spilled int64
+}
+
+// NewMemoryCacheStore creates an in-memory store of at most maxEntries
+// entries, or defaultCacheMaxEntries if maxEntries is not positive
+func NewMemoryCacheStore(maxEntries int) (*MemoryCacheStore, error) {
+	return NewTieredCacheStore(MemoryCacheLimits{MaxEntries: maxEntries}, nil)
+}
+
+// NewTieredCacheStore creates an in-memory store within limits that spills
+// evicted entries to disk, or drops them when disk is nil
+func NewTieredCacheStore(limits MemoryCacheLimits, disk *SQLiteCacheStore) (*MemoryCacheStore, error) {
+	if limits.MaxEntries <= 0 {
+		limits.MaxEntries = defaultCacheMaxEntries
+	}
+	s := &MemoryCacheStore{capacity: limits.MaxEntries, maxBytes: limits.MaxBytes, disk: disk, now: time.Now}
+	entries, err := simplelru.NewLRU[string, memoryEntry](limits.MaxEntries, s.onRemove)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create memory cache: %w", err)
+	}
+	s.entries = entries
+	return s, nil
+}
+
+// onRemove accounts for an entry leaving memory. It is called with mu held.
+func (s *MemoryCacheStore) onRemove(key string, entry memoryEntry) {
+	s.bytes -= entry.size(key)
+	if s.dropping {
+		return
+	}
+	s.evictions++
+	metrics.ObserveCacheEviction("evidence")
+	if s.disk != nil {
+		s.evicted = append(s.evicted, spilledEntry{key: key, entry: entry})
+	}
+}
+
+// Backend returns "memory", or "memory+sqlite" with a disk tier
+func (s *MemoryCacheStore) Backend() string {
+	if s.disk != nil {
+		return "memory+sqlite"
+	}
+	return "memory"
+}
+
+// Get retrieves a cached value, removing it if it has expired. On a memory
+// miss the disk tier is consulted and a value found there moves to memory.
+func (s *MemoryCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	entry, ok := s.entries.Get(key)
+	if ok && !entry.expires.IsZero() && !s.now().Before(entry.expires) {
+		s.drop(key)
+		s.expirations++
+		ok = false
+	}
+	if ok {
+		s.hits++
+		s.mu.Unlock()
+		return entry.value, true, nil
+	}
+	if s.disk == nil {
+		s.misses++
+		s.mu.Unlock()
+		return nil, false, nil
+	}
+	s.mu.Unlock()
+
+	value, expires, found, err := s.disk.get(ctx, key)
+	if err != nil || !found {
+		s.mu.Lock()
+		s.misses++
+		s.mu.Unlock()
+		return nil, false, err
+	}
+	if err := s.disk.Delete(ctx, key); err != nil {
+		return nil, false, err
+	}
+	s.mu.Lock()
+	s.diskHits++
+	s.add(key, memoryEntry{value: value, expires: expires})
+	s.mu.Unlock()
+	return value, true, s.spill(ctx)
+}
+
+// Set caches a value that expires after ttl; a ttl of zero never expires
+func (s *MemoryCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expires = s.now().Add(ttl)
+	}
+	if s.disk != nil {
+		// The disk copy of an earlier value is superseded
+		if err := s.disk.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	s.add(key, entry)
+	s.mu.Unlock()
+	return s.spill(ctx)
+}
+
+// add stores an entry and evicts the least recently used entries beyond the
+// byte limit. A value larger than the limit on its own is evicted at once.
+// It is called with mu held.
+func (s *MemoryCacheStore) add(key string, entry memoryEntry) {
+	if old, ok := s.entries.Peek(key); ok {
+		s.bytes -= old.size(key)
+	}
+	s.entries.Add(key, entry)
+	s.bytes += entry.size(key)
+	for s.maxBytes > 0 && s.bytes > s.maxBytes && s.entries.Len() > 0 {
+		s.entries.RemoveOldest()
+	}
+}
+
+// drop removes an entry without counting an eviction. It is called with mu
+// held.
+func (s *MemoryCacheStore) drop(key string) {
+	s.dropping = true
+	s.entries.Remove(key)
+	s.dropping = false
+}
+
+// spill writes the entries evicted from memory to disk, skipping expired ones
+func (s *MemoryCacheStore) spill(ctx context.Context) error {
+	s.mu.Lock()
+	evicted := s.evicted
+	s.evicted = nil
+	s.mu.Unlock()
+
+	now := s.now()
+	var spilled int64
+	for _, e := range evicted {
+		if !e.entry.expires.IsZero() && !now.Before(e.entry.expires) {
+			continue
+		}
+		if err := s.disk.set(ctx, e.key, e.entry.value, e.entry.expires); err != nil {
+			return err
+		}
+		spilled++
+	}
+	if spilled > 0 {
+		s.mu.Lock()
+		s.spilled += spilled
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// Delete removes cached values from memory and disk
+func (s *MemoryCacheStore) Delete(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	for _, key := range keys {
+		s.drop(key)
+	}
+	s.mu.Unlock()
+	if s.disk != nil {
+		return s.disk.Delete(ctx, keys...)
+	}
+	return nil
+}
+
+// Len returns the number of cached values in memory and on disk, including
+// expired values not yet evicted
+func (s *MemoryCacheStore) Len(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	n := s.entries.Len()
+	s.mu.Unlock()
+	if s.disk != nil {
+		onDisk, err := s.disk.Len(ctx)
+		if err != nil {
+			return 0, err
+		}
+		n += onDisk
+	}
+	return n, nil
+}
+
+// Stats returns the size and counters of the cache
+func (s *MemoryCacheStore) Stats(ctx context.Context) MemoryCacheStats {
+	s.mu.Lock()
+	stats := MemoryCacheStats{
+		Entries:     s.entries.Len(),
+		Bytes:       s.bytes,
+		MaxEntries:  s.capacity,
+		MaxBytes:    s.maxBytes,
+		Hits:        s.hits,
+		Misses:      s.misses,
+		Evictions:   s.evictions,
+		Expirations: s.expirations,
+		DiskHits:    s.diskHits,
+		Spilled:     s.spilled,
+	}
+	s.mu.Unlock()
+	if s.disk != nil {
+		stats.DiskEntries, _ = s.disk.Len(ctx)
+	}
+	return stats
+}
+
+// Ping checks the disk tier, if any
+func (s *MemoryCacheStore) Ping(ctx context.Context) error {
+	if s.disk != nil {
+		return s.disk.Ping(ctx)
+	}
+	return nil
+}
+
+// Close drops all values held in memory. With a disk tier they are written
+// to disk first, so the cache persists for the next start.
+func (s *MemoryCacheStore) Close() error {
+	s.mu.Lock()
+	if s.disk != nil {
+		for _, key := range s.entries.Keys() {
+			if entry, ok := s.entries.Peek(key); ok {
+				s.evicted = append(s.evicted, spilledEntry{key: key, entry: entry})
+			}
+		}
+	}
+	s.dropping = true
+	s.entries.Purge()
+	s.dropping = false
+	s.mu.Unlock()
+	if s.disk == nil {
+		return nil
+	}
+	err := s.spill(context.Background())
+	if closeErr := s.disk.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
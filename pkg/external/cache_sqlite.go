@@ -0,0 +1,165 @@
+package external
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteCachePurgeInterval is how many writes pass between purges of
+// expired entries from the disk cache
+const sqliteCachePurgeInterval = 1000
+
+const sqliteCacheSchema = `
+CREATE TABLE IF NOT EXISTS cache_entries (
+	key TEXT PRIMARY KEY,
+	value BLOB NOT NULL,
+	expires INTEGER NOT NULL DEFAULT 0 -- Unix nanoseconds; 0 never expires
+) WITHOUT ROWID;
+CREATE INDEX IF NOT EXISTS idx_cache_entries_expires ON cache_entries (expires);
+`
+
+// SQLiteCacheStore stores cached external API responses in a SQLite file. It
+// backs the in-memory LRU as a spillover tier: entries evicted from memory
+// are written here and read back on a memory miss.
+type SQLiteCacheStore struct {
+	db     *sql.DB
+	path   string
+	writes atomic.Int64
+	now    func() time.Time
+}
+
+// NewSQLiteCacheStore opens or creates a disk cache at path, dropping the
+// entries that expired while it was closed
+func NewSQLiteCacheStore(path string) (*SQLiteCacheStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open disk cache: %w", err)
+	}
+	// SQLite allows one writer; serialize access rather than fail on locks
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(sqliteCacheSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create disk cache schema: %w", err)
+	}
+
+	s := &SQLiteCacheStore{db: db, path: path, now: time.Now}
+	if err := s.purgeExpired(context.Background()); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to purge disk cache: %w", err)
+	}
+	return s, nil
+}
+
+// Backend returns "sqlite"
+func (s *SQLiteCacheStore) Backend() string {
+	return "sqlite"
+}
+
+// Get retrieves a cached value, removing it if it has expired
+func (s *SQLiteCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, _, found, err := s.get(ctx, key)
+	return value, found, err
+}
+
+// get retrieves a cached value and when it expires
+func (s *SQLiteCacheStore) get(ctx context.Context, key string) ([]byte, time.Time, bool, error) {
+	var value []byte
+	var expires int64
+	err := s.db.QueryRowContext(ctx, `SELECT value, expires FROM cache_entries WHERE key = ?`, key).Scan(&value, &expires)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, time.Time{}, false, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, false, fmt.Errorf("failed to get disk cache entry: %w", err)
+	}
+	if expires == 0 {
+		return value, time.Time{}, true, nil
+	}
+	expiresAt := time.Unix(0, expires)
+	if !s.now().Before(expiresAt) {
+		_ = s.Delete(ctx, key)
+		return nil, time.Time{}, false, nil
+	}
+	return value, expiresAt, true, nil
+}
+
+// Set caches a value that expires after ttl; a ttl of zero never expires
+func (s *SQLiteCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var expires time.Time
+	if ttl > 0 {
+		expires = s.now().Add(ttl)
+	}
+	return s.set(ctx, key, value, expires)
+}
+
+// set caches a value until expires; a zero time never expires
+func (s *SQLiteCacheStore) set(ctx context.Context, key string, value []byte, expires time.Time) error {
+	var expiresAt int64
+	if !expires.IsZero() {
+		expiresAt = expires.UnixNano()
+	}
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT OR REPLACE INTO cache_entries (key, value, expires) VALUES (?, ?, ?)`,
+		key, value, expiresAt,
+	); err != nil {
+		return fmt.Errorf("failed to set disk cache entry: %w", err)
+	}
+	if s.writes.Add(1)%sqliteCachePurgeInterval == 0 {
+		return s.purgeExpired(ctx)
+	}
+	return nil
+}
+
+// purgeExpired deletes expired entries
+func (s *SQLiteCacheStore) purgeExpired(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM cache_entries WHERE expires > 0 AND expires <= ?`, s.now().UnixNano())
+	return err
+}
+
+// Delete removes cached values
+func (s *SQLiteCacheStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	args := make([]interface{}, len(keys))
+	for i, key := range keys {
+		args[i] = key
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(keys)), ", ")
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM cache_entries WHERE key IN (`+placeholders+`)`, args...); err != nil {
+		return fmt.Errorf("failed to delete disk cache entries: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of cached values, including expired values not yet
+// purged
+func (s *SQLiteCacheStore) Len(ctx context.Context) (int, error) {
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM cache_entries`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count disk cache entries: %w", err)
+	}
+	return n, nil
+}
+
+// Ping checks the database is readable
+func (s *SQLiteCacheStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// Close closes the database; cached values persist for the next start
+func (s *SQLiteCacheStore) Close() error {
+	return s.db.Close()
+}
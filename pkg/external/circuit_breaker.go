@@ -6,9 +6,18 @@ import (
 	"time"
 
 	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/metrics"
 )
 
+// tracer records a span per external database query
+var tracer = otel.Tracer("github.com/acmg-amp-mcp-server/pkg/external")
+
 // CircuitBreakerConfig represents circuit breaker configuration
 type CircuitBreakerConfig struct {
 	MaxRequests      uint32        `json:"max_requests"`
@@ -20,20 +29,21 @@ type CircuitBreakerConfig struct {
 
 // ResilientExternalClient wraps external API clients with circuit breaker pattern
 type ResilientExternalClient struct {
-	clinVarClient *ClinVarClient
-	gnomADClient  *GnomADClient
+	clinVarClient ClinVarVariantClient
+	gnomADClient  PopulationFrequencyClient
 	cosmicClient  *COSMICClient
 	pubMedClient  *PubMedClient
 	lovdClient    *LOVDClient
 	hgmdClient    *HGMDClient
-	cacheClient   *CacheClient
-	
-	clinVarBreaker *gobreaker.CircuitBreaker
-	gnomADBreaker  *gobreaker.CircuitBreaker
-	cosmicBreaker  *gobreaker.CircuitBreaker
-	pubMedBreaker  *gobreaker.CircuitBreaker
-	lovdBreaker    *gobreaker.CircuitBreaker
-	hgmdBreaker    *gobreaker.CircuitBreaker
+	cache         *EvidenceCache
+	deadlines     sourceDeadlines
+	
+	clinVarBreaker *Breaker
+	gnomADBreaker  *Breaker
+	cosmicBreaker  *Breaker
+	pubMedBreaker  *Breaker
+	lovdBreaker    *Breaker
+	hgmdBreaker    *Breaker
 }
 
 // NewResilientExternalClient creates a new resilient external client with circuit breakers
@@ -49,12 +59,13 @@ func NewResilientExternalClient(
 	
 	// Create individual clients
 	clinVarClient := NewClinVarClient(clinVarConfig)
-	gnomADClient := NewGnomADClient(gnomADConfig)
+	gnomADClient := NewPopulationFrequencyClient(gnomADConfig)
 	cosmicClient := NewCOSMICClient(cosmicConfig)
 	
 	// Create new clients
 	pubMedClient := NewPubMedClient(PubMedConfig{
 		BaseURL:   pubMedConfig.BaseURL,
+		LitVarURL: pubMedConfig.LitVarURL,
 		APIKey:    pubMedConfig.APIKey,
 		Email:     pubMedConfig.Email,
 		Timeout:   pubMedConfig.Timeout,
@@ -77,13 +88,13 @@ func NewResilientExternalClient(
 		RateLimit:      hgmdConfig.RateLimit,
 	})
 	
-	cacheClient, err := NewCacheClient(cacheConfig)
+	cacheStore, err := NewCacheStore(cacheConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create cache client: %w", err)
+		return nil, fmt.Errorf("failed to create cache store: %w", err)
 	}
 	
 	// Create circuit breakers for each service
-	clinVarBreaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+	clinVarBreaker := NewBreaker(gobreaker.Settings{
 		Name:        "ClinVar",
 		MaxRequests: 5,
 		Interval:    30 * time.Second,
@@ -98,7 +109,7 @@ func NewResilientExternalClient(
 		},
 	})
 	
-	gnomADBreaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+	gnomADBreaker := NewBreaker(gobreaker.Settings{
 		Name:        "gnomAD",
 		MaxRequests: 5,
 		Interval:    30 * time.Second,
@@ -112,7 +123,7 @@ func NewResilientExternalClient(
 		},
 	})
 	
-	cosmicBreaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+	cosmicBreaker := NewBreaker(gobreaker.Settings{
 		Name:        "COSMIC",
 		MaxRequests: 5,
 		Interval:    30 * time.Second,
@@ -127,7 +138,7 @@ func NewResilientExternalClient(
 	})
 	
 	// Create circuit breakers for new services
-	pubMedBreaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+	pubMedBreaker := NewBreaker(gobreaker.Settings{
 		Name:        "PubMed",
 		MaxRequests: 3, // More conservative for PubMed
 		Interval:    30 * time.Second,
@@ -141,7 +152,7 @@ func NewResilientExternalClient(
 		},
 	})
 	
-	lovdBreaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+	lovdBreaker := NewBreaker(gobreaker.Settings{
 		Name:        "LOVD",
 		MaxRequests: 5,
 		Interval:    30 * time.Second,
@@ -155,7 +166,7 @@ func NewResilientExternalClient(
 		},
 	})
 	
-	hgmdBreaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+	hgmdBreaker := NewBreaker(gobreaker.Settings{
 		Name:        "HGMD",
 		MaxRequests: 3, // Conservative for HGMD (commercial service)
 		Interval:    30 * time.Second,
@@ -176,7 +187,7 @@ func NewResilientExternalClient(
 		pubMedClient:   pubMedClient,
 		lovdClient:     lovdClient,
 		hgmdClient:     hgmdClient,
-		cacheClient:    cacheClient,
+		cache:          NewEvidenceCache(cacheStore, cacheConfig),
 		clinVarBreaker: clinVarBreaker,
 		gnomADBreaker:  gnomADBreaker,
 		cosmicBreaker:  cosmicBreaker,
@@ -186,293 +197,140 @@ func NewResilientExternalClient(
 	}, nil
 }
 
+// SetClinVarClient replaces the E-utilities ClinVar client, for instance
+// with a local ClinVar import for offline use
+func (r *ResilientExternalClient) SetClinVarClient(client ClinVarVariantClient) {
+	r.clinVarClient = client
+}
+
+// SetGnomADClient replaces the gnomAD API client, for instance with a
+// local gnomAD copy for offline use
+func (r *ResilientExternalClient) SetGnomADClient(client PopulationFrequencyClient) {
+	r.gnomADClient = client
+}
+
 // QueryClinVar queries ClinVar with circuit breaker and caching
 func (r *ResilientExternalClient) QueryClinVar(ctx context.Context, variant *domain.StandardizedVariant) (*domain.ClinVarData, error) {
-	// Check cache first
-	if cachedData, found, err := r.cacheClient.GetClinVarData(ctx, variant); err == nil && found {
-		return cachedData, nil
-	}
-	
-	// Use circuit breaker
-	result, err := r.clinVarBreaker.Execute(func() (interface{}, error) {
+	return cachedQuery(ctx, r.cache, r.clinVarBreaker, CacheSourceClinVar, variant, func(ctx context.Context) (*domain.ClinVarData, error) {
 		return r.clinVarClient.QueryVariant(ctx, variant)
 	})
-	
-	if err != nil {
-		// Check if circuit breaker is open and return cached data if available
-		if err == gobreaker.ErrOpenState {
-			if cachedData, found, cacheErr := r.cacheClient.GetClinVarData(ctx, variant); cacheErr == nil && found {
-				return cachedData, nil
-			}
-			return nil, fmt.Errorf("ClinVar service unavailable (circuit breaker open)")
-		}
-		return nil, fmt.Errorf("ClinVar query failed: %w", err)
-	}
-	
-	data := result.(*domain.ClinVarData)
-	
-	// Cache the result
-	if cacheErr := r.cacheClient.SetClinVarData(ctx, variant, data, 0); cacheErr != nil {
-		// Log cache error but don't fail the request
-		fmt.Printf("Failed to cache ClinVar data: %v\n", cacheErr)
-	}
-	
-	return data, nil
 }
 
 // QueryGnomAD queries gnomAD with circuit breaker and caching
 func (r *ResilientExternalClient) QueryGnomAD(ctx context.Context, variant *domain.StandardizedVariant) (*domain.PopulationData, error) {
-	// Check cache first
-	if cachedData, found, err := r.cacheClient.GetPopulationData(ctx, variant); err == nil && found {
-		return cachedData, nil
-	}
-	
-	// Use circuit breaker
-	result, err := r.gnomADBreaker.Execute(func() (interface{}, error) {
+	return cachedQuery(ctx, r.cache, r.gnomADBreaker, CacheSourceGnomAD, variant, func(ctx context.Context) (*domain.PopulationData, error) {
 		return r.gnomADClient.QueryVariant(ctx, variant)
 	})
-	
-	if err != nil {
-		// Check if circuit breaker is open and return cached data if available
-		if err == gobreaker.ErrOpenState {
-			if cachedData, found, cacheErr := r.cacheClient.GetPopulationData(ctx, variant); cacheErr == nil && found {
-				return cachedData, nil
-			}
-			return nil, fmt.Errorf("gnomAD service unavailable (circuit breaker open)")
-		}
-		return nil, fmt.Errorf("gnomAD query failed: %w", err)
-	}
-	
-	data := result.(*domain.PopulationData)
-	
-	// Cache the result
-	if cacheErr := r.cacheClient.SetPopulationData(ctx, variant, data, 0); cacheErr != nil {
-		// Log cache error but don't fail the request
-		fmt.Printf("Failed to cache population data: %v\n", cacheErr)
-	}
-	
-	return data, nil
 }
 
 // QueryCOSMIC queries COSMIC with circuit breaker and caching
 func (r *ResilientExternalClient) QueryCOSMIC(ctx context.Context, variant *domain.StandardizedVariant) (*domain.SomaticData, error) {
-	// Check cache first
-	if cachedData, found, err := r.cacheClient.GetSomaticData(ctx, variant); err == nil && found {
-		return cachedData, nil
-	}
-	
-	// Use circuit breaker
-	result, err := r.cosmicBreaker.Execute(func() (interface{}, error) {
+	return cachedQuery(ctx, r.cache, r.cosmicBreaker, CacheSourceCOSMIC, variant, func(ctx context.Context) (*domain.SomaticData, error) {
 		return r.cosmicClient.QueryVariant(ctx, variant)
 	})
-	
-	if err != nil {
-		// Check if circuit breaker is open and return cached data if available
-		if err == gobreaker.ErrOpenState {
-			if cachedData, found, cacheErr := r.cacheClient.GetSomaticData(ctx, variant); cacheErr == nil && found {
-				return cachedData, nil
-			}
-			return nil, fmt.Errorf("COSMIC service unavailable (circuit breaker open)")
-		}
-		return nil, fmt.Errorf("COSMIC query failed: %w", err)
-	}
-	
-	data := result.(*domain.SomaticData)
-	
-	// Cache the result
-	if cacheErr := r.cacheClient.SetSomaticData(ctx, variant, data, 0); cacheErr != nil {
-		// Log cache error but don't fail the request
-		fmt.Printf("Failed to cache somatic data: %v\n", cacheErr)
-	}
-	
-	return data, nil
 }
 
 // QueryPubMed queries PubMed with circuit breaker and caching
 func (r *ResilientExternalClient) QueryPubMed(ctx context.Context, variant *domain.StandardizedVariant) (*domain.LiteratureData, error) {
-	// Check cache first (if cache methods exist)
-	// TODO: Add cache methods for literature data
-	
-	// Use circuit breaker
-	result, err := r.pubMedBreaker.Execute(func() (interface{}, error) {
+	return cachedQuery(ctx, r.cache, r.pubMedBreaker, CacheSourcePubMed, variant, func(ctx context.Context) (*domain.LiteratureData, error) {
 		return r.pubMedClient.QueryLiterature(ctx, variant)
 	})
-	
-	if err != nil {
-		// Check if circuit breaker is open
-		if err == gobreaker.ErrOpenState {
-			return nil, fmt.Errorf("PubMed service unavailable (circuit breaker open)")
-		}
-		return nil, fmt.Errorf("PubMed query failed: %w", err)
-	}
-	
-	data := result.(*domain.LiteratureData)
-	
-	// TODO: Cache the result when cache methods are available
-	
-	return data, nil
 }
 
 // QueryLOVD queries LOVD with circuit breaker and caching
 func (r *ResilientExternalClient) QueryLOVD(ctx context.Context, variant *domain.StandardizedVariant) (*domain.LOVDData, error) {
-	// Check cache first (if cache methods exist)
-	// TODO: Add cache methods for LOVD data
-	
-	// Use circuit breaker
-	result, err := r.lovdBreaker.Execute(func() (interface{}, error) {
+	return cachedQuery(ctx, r.cache, r.lovdBreaker, CacheSourceLOVD, variant, func(ctx context.Context) (*domain.LOVDData, error) {
 		return r.lovdClient.QueryVariant(ctx, variant)
 	})
-	
-	if err != nil {
-		// Check if circuit breaker is open
-		if err == gobreaker.ErrOpenState {
-			return nil, fmt.Errorf("LOVD service unavailable (circuit breaker open)")
-		}
-		return nil, fmt.Errorf("LOVD query failed: %w", err)
-	}
-	
-	data := result.(*domain.LOVDData)
-	
-	// TODO: Cache the result when cache methods are available
-	
-	return data, nil
 }
 
 // QueryHGMD queries HGMD with circuit breaker and caching
 func (r *ResilientExternalClient) QueryHGMD(ctx context.Context, variant *domain.StandardizedVariant) (*domain.HGMDData, error) {
-	// Check cache first (if cache methods exist)
-	// TODO: Add cache methods for HGMD data
-	
-	// Use circuit breaker
-	result, err := r.hgmdBreaker.Execute(func() (interface{}, error) {
+	return cachedQuery(ctx, r.cache, r.hgmdBreaker, CacheSourceHGMD, variant, func(ctx context.Context) (*domain.HGMDData, error) {
 		return r.hgmdClient.QueryVariant(ctx, variant)
 	})
-	
-	if err != nil {
-		// Check if circuit breaker is open
-		if err == gobreaker.ErrOpenState {
-			return nil, fmt.Errorf("HGMD service unavailable (circuit breaker open)")
+}
+
+// cachedQuery serves a source's response for a variant from the evidence
+// cache, querying the source through its circuit breaker on a miss or to
+// revalidate a stale response. While the breaker is open, cached responses
+// are still served.
+func cachedQuery[T any](ctx context.Context, cache *EvidenceCache, breaker *Breaker, source string, variant *domain.StandardizedVariant, query func(context.Context) (*T, error)) (*T, error) {
+	ctx, span := tracer.Start(ctx, "external."+source, trace.WithAttributes(attribute.String("source", source)))
+	defer span.End()
+	return cachedFetch(ctx, cache, source, variantCacheKey(source, variant), func(ctx context.Context) (*T, error) {
+		result, err := breaker.Execute(func() (interface{}, error) {
+			return query(ctx)
+		})
+		metrics.ObserveExternalRequest(source, err)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			if err == gobreaker.ErrOpenState {
+				return nil, fmt.Errorf("%s service unavailable (circuit breaker open)", breaker.Name())
+			}
+			return nil, fmt.Errorf("%s query failed: %w", breaker.Name(), err)
 		}
-		return nil, fmt.Errorf("HGMD query failed: %w", err)
-	}
-	
-	data := result.(*domain.HGMDData)
-	
-	// TODO: Cache the result when cache methods are available
-	
-	return data, nil
+		return result.(*T), nil
+	})
 }
 
-// GatherEvidence implements the KnowledgeBaseAccess interface with resilience
+// GatherEvidence implements the KnowledgeBaseAccess interface with resilience,
+// querying the databases concurrently within their deadlines
 func (r *ResilientExternalClient) GatherEvidence(ctx context.Context, variant *domain.StandardizedVariant) (*domain.AggregatedEvidence, error) {
-	evidence := &domain.AggregatedEvidence{
-		GatheredAt: time.Now(),
-	}
-	
-	// Query all databases concurrently with timeout
-	type result struct {
-		clinVarData    *domain.ClinVarData
-		populationData *domain.PopulationData
-		somaticData    *domain.SomaticData
-		literatureData *domain.LiteratureData
-		lovdData       *domain.LOVDData
-		hgmdData       *domain.HGMDData
-		clinVarErr     error
-		populationErr  error
-		somaticErr     error
-		literatureErr  error
-		lovdErr        error
-		hgmdErr        error
-	}
-	
-	results := make(chan result, 1)
-	
-	go func() {
-		res := result{}
-		
-		// Query all databases concurrently
-		// Channel to coordinate concurrent queries
-		done := make(chan struct{})
-		
-		// Query ClinVar
-		go func() {
-			res.clinVarData, res.clinVarErr = r.QueryClinVar(ctx, variant)
-			done <- struct{}{}
-		}()
-		
-		// Query gnomAD
-		go func() {
-			res.populationData, res.populationErr = r.QueryGnomAD(ctx, variant)
-			done <- struct{}{}
-		}()
-		
-		// Query COSMIC
-		go func() {
-			res.somaticData, res.somaticErr = r.QueryCOSMIC(ctx, variant)
-			done <- struct{}{}
-		}()
-		
-		// Query PubMed
-		go func() {
-			res.literatureData, res.literatureErr = r.QueryPubMed(ctx, variant)
-			done <- struct{}{}
-		}()
-		
-		// Query LOVD
-		go func() {
-			res.lovdData, res.lovdErr = r.QueryLOVD(ctx, variant)
-			done <- struct{}{}
-		}()
-		
-		// Query HGMD
-		go func() {
-			res.hgmdData, res.hgmdErr = r.QueryHGMD(ctx, variant)
-			done <- struct{}{}
-		}()
-		
-		// Wait for all queries to complete
-		for i := 0; i < 6; i++ {
-			<-done
-		}
-		
-		results <- res
-	}()
-	
-	select {
-	case res := <-results:
-		// Set data even if some queries failed
-		if res.clinVarErr == nil {
-			evidence.ClinVarData = res.clinVarData
-		}
-		if res.populationErr == nil {
-			evidence.PopulationData = res.populationData
-		}
-		if res.somaticErr == nil {
-			evidence.SomaticData = res.somaticData
-		}
-		if res.literatureErr == nil {
-			evidence.LiteratureData = res.literatureData
-		}
-		if res.lovdErr == nil {
-			evidence.LOVDData = res.lovdData
-		}
-		if res.hgmdErr == nil {
-			evidence.HGMDData = res.hgmdData
-		}
-		
-		// Return error only if all queries failed
-		allFailed := res.clinVarErr != nil && res.populationErr != nil && res.somaticErr != nil &&
-			res.literatureErr != nil && res.lovdErr != nil && res.hgmdErr != nil
-		
-		if allFailed {
-			return nil, fmt.Errorf("all external database queries failed: ClinVar=%v, gnomAD=%v, COSMIC=%v, PubMed=%v, LOVD=%v, HGMD=%v", 
-				res.clinVarErr, res.populationErr, res.somaticErr, res.literatureErr, res.lovdErr, res.hgmdErr)
-		}
-		
-		return evidence, nil
-		
-	case <-ctx.Done():
-		return nil, ctx.Err()
+	return gatherEvidence(ctx, r.evidenceSources(variant, nil), r.deadlines)
+}
+
+// SetSourceDeadlines sets how long evidence gathering waits on each source,
+// keyed by source name, and on sources without a deadline of their own
+func (r *ResilientExternalClient) SetSourceDeadlines(fallback time.Duration, sources map[string]time.Duration) {
+	r.deadlines = sourceDeadlines{fallback: fallback, sources: sources}
+}
+
+// evidenceSources returns the core databases to query for a variant. Notices
+// for the cited articles, if a source is given, are looked up within
+// PubMed's deadline.
+func (r *ResilientExternalClient) evidenceSources(variant *domain.StandardizedVariant, notices CitationNoticeSource) []evidenceSource {
+	return []evidenceSource{
+		{name: CacheSourceClinVar, category: domain.EvidenceCategoryClinical, core: true,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				data, err := r.QueryClinVar(ctx, variant)
+				return func(e *domain.AggregatedEvidence) { e.ClinVarData = data }, err
+			}},
+		{name: CacheSourceGnomAD, category: domain.EvidenceCategoryPopulation, core: true,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				data, err := r.QueryGnomAD(ctx, variant)
+				return func(e *domain.AggregatedEvidence) { e.PopulationData = data }, err
+			}},
+		{name: CacheSourceCOSMIC, category: domain.EvidenceCategorySomatic, core: true,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				data, err := r.QueryCOSMIC(ctx, variant)
+				return func(e *domain.AggregatedEvidence) { e.SomaticData = data }, err
+			}},
+		{name: CacheSourcePubMed, category: domain.EvidenceCategoryLiterature, core: true,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				data, err := r.QueryPubMed(ctx, variant)
+				if err == nil && notices != nil && data != nil && len(data.Citations) > 0 {
+					pmids := make([]string, len(data.Citations))
+					for i, citation := range data.Citations {
+						pmids[i] = citation.PMID
+					}
+					if found, err := notices.CitationNotices(ctx, pmids); err == nil {
+						data.ApplyNotices(found)
+					}
+				}
+				return func(e *domain.AggregatedEvidence) { e.LiteratureData = data }, err
+			}},
+		{name: CacheSourceLOVD, category: domain.EvidenceCategoryClinical, core: true,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				data, err := r.QueryLOVD(ctx, variant)
+				return func(e *domain.AggregatedEvidence) { e.LOVDData = data }, err
+			}},
+		{name: CacheSourceHGMD, category: domain.EvidenceCategoryClinical, core: true,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				data, err := r.QueryHGMD(ctx, variant)
+				return func(e *domain.AggregatedEvidence) { e.HGMDData = data }, err
+			}},
 	}
 }
 
@@ -502,10 +360,10 @@ func (r *ResilientExternalClient) GetCircuitBreakerStates() map[string]gobreaker
 
 // InvalidateCache removes cached data for a variant
 func (r *ResilientExternalClient) InvalidateCache(ctx context.Context, variant *domain.StandardizedVariant) error {
-	return r.cacheClient.InvalidateVariant(ctx, variant)
+	return r.cache.Invalidate(ctx, variant)
 }
 
 // Close closes all connections and resources
 func (r *ResilientExternalClient) Close() error {
-	return r.cacheClient.Close()
+	return r.cache.Close()
 }
\ No newline at end of file
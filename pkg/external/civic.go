@@ -0,0 +1,184 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// civicEvidenceQuery fetches the accepted evidence items of a molecular profile
+const civicEvidenceQuery = `query SomaticEvidence($molecularProfile: String!) {
+  evidenceItems(molecularProfileName: $molecularProfile, status: ACCEPTED, first: 200) {
+    nodes {
+      id
+      evidenceLevel
+      evidenceType
+      evidenceDirection
+      significance
+      disease { name }
+      therapies { name }
+      source { citationId sourceType }
+    }
+  }
+}`
+
+// civicEvidenceTypes maps CIViC evidence types onto somatic evidence types;
+// predisposing, oncogenic and functional evidence does not bear on the tier
+var civicEvidenceTypes = map[string]string{
+	"PREDICTIVE": domain.SomaticTherapeutic,
+	"DIAGNOSTIC": domain.SomaticDiagnostic,
+	"PROGNOSTIC": domain.SomaticPrognostic,
+}
+
+// civicLevels maps CIViC evidence levels onto the AMP/ASCO/CAP levels.
+// Level E (inferential) has no AMP/ASCO/CAP equivalent and is left out.
+var civicLevels = map[string]domain.SomaticEvidenceLevel{
+	"A": domain.LEVEL_A,
+	"B": domain.LEVEL_B,
+	"C": domain.LEVEL_C,
+	"D": domain.LEVEL_D,
+}
+
+// CIViCClient queries the CIViC GraphQL API for somatic evidence
+type CIViCClient struct {
+	baseURL    string
+	httpClient *http.Client
+	rateLimit  time.Duration
+}
+
+// NewCIViCClient creates a new CIViC API client
+func NewCIViCClient(config domain.CIViCConfig) *CIViCClient {
+	rateLimit := config.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = 5
+	}
+	return &CIViCClient{
+		baseURL:    config.BaseURL,
+		httpClient: newHTTPClient(config.Timeout, 0),
+		rateLimit:  time.Second / time.Duration(rateLimit),
+	}
+}
+
+// civicResponse is the GraphQL response to civicEvidenceQuery
+type civicResponse struct {
+	Data struct {
+		EvidenceItems struct {
+			Nodes []struct {
+				ID                int    `json:"id"`
+				EvidenceLevel     string `json:"evidenceLevel"`
+				EvidenceType      string `json:"evidenceType"`
+				EvidenceDirection string `json:"evidenceDirection"`
+				Significance      string `json:"significance"`
+				Disease           *struct {
+					Name string `json:"name"`
+				} `json:"disease"`
+				Therapies []struct {
+					Name string `json:"name"`
+				} `json:"therapies"`
+				Source *struct {
+					CitationID string `json:"citationId"`
+					SourceType string `json:"sourceType"`
+				} `json:"source"`
+			} `json:"nodes"`
+		} `json:"evidenceItems"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Name identifies CIViC in somatic evidence
+func (c *CIViCClient) Name() string {
+	return "CIViC"
+}
+
+// QuerySomaticEvidence returns the accepted evidence items supporting the
+// variant's molecular profile, e.g. "BRAF V600E". Variants without a protein
+// change have no simple molecular profile and return no evidence.
+func (c *CIViCClient) QuerySomaticEvidence(ctx context.Context, variant *domain.StandardizedVariant, tumorType string) ([]domain.SomaticEvidence, error) {
+	gene, alteration := somaticAlteration(variant)
+	if gene == "" || alteration == "" {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     civicEvidenceQuery,
+		"variables": map[string]string{"molecularProfile": gene + " " + alteration},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode CIViC query: %w", err)
+	}
+
+	select {
+	case <-time.After(c.rateLimit):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CIViC request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute CIViC request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CIViC returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CIViC response: %w", err)
+	}
+	return parseCIViCEvidence(body)
+}
+
+// parseCIViCEvidence converts supporting CIViC evidence items to somatic evidence
+func parseCIViCEvidence(body []byte) ([]domain.SomaticEvidence, error) {
+	var response civicResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse CIViC response: %w", err)
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("CIViC query failed: %s", response.Errors[0].Message)
+	}
+
+	var evidence []domain.SomaticEvidence
+	for _, node := range response.Data.EvidenceItems.Nodes {
+		kind, ok := civicEvidenceTypes[node.EvidenceType]
+		level, leveled := civicLevels[node.EvidenceLevel]
+		if !ok || !leveled || node.EvidenceDirection != "SUPPORTS" {
+			continue
+		}
+
+		item := domain.SomaticEvidence{
+			Source:       "CIViC",
+			SourceLevel:  node.EvidenceLevel,
+			Level:        level,
+			Type:         kind,
+			Significance: strings.ReplaceAll(strings.ToLower(node.Significance), "_", " "),
+		}
+		if node.Disease != nil {
+			item.TumorType = node.Disease.Name
+		}
+		for _, therapy := range node.Therapies {
+			item.Therapies = append(item.Therapies, therapy.Name)
+		}
+		if node.Source != nil && node.Source.SourceType == "PUBMED" && node.Source.CitationID != "" {
+			item.References = []string{node.Source.CitationID}
+		}
+		evidence = append(evidence, item)
+	}
+	return evidence, nil
+}
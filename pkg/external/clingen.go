@@ -0,0 +1,230 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// GeneCurationClient looks up the ClinGen gene-disease validity and dosage
+// sensitivity curation of a gene
+type GeneCurationClient interface {
+	QueryGeneCuration(ctx context.Context, gene string) (*domain.GeneCuration, error)
+}
+
+// clinGenGeneQuery finds a gene by symbol with its validity and dosage
+// curations in the ClinGen GeneGraph API
+const clinGenGeneQuery = `query GeneCuration($symbol: String!) {
+  genes(text: $symbol, limit: 10) {
+    gene_list {
+      label
+      hgnc_id
+      dosage_curation {
+        haploinsufficiency_assertion { dosage_classification { ordinal } }
+        triplosensitivity_assertion { dosage_classification { ordinal } }
+      }
+      genetic_conditions {
+        disease { label curie }
+        gene_validity_assertions {
+          classification { label }
+          mode_of_inheritance { label }
+          attributed_to { label }
+          report_date
+        }
+      }
+    }
+  }
+}`
+
+// ClinGenClient queries the ClinGen GeneGraph GraphQL API, which serves the
+// curations published in the ClinGen Evidence Repository
+type ClinGenClient struct {
+	baseURL    string
+	httpClient *http.Client
+	rateLimit  time.Duration
+}
+
+// NewClinGenClient creates a new ClinGen GeneGraph client
+func NewClinGenClient(config domain.ClinGenConfig) *ClinGenClient {
+	rateLimit := config.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = 5
+	}
+	return &ClinGenClient{
+		baseURL:    config.BaseURL,
+		httpClient: newHTTPClient(config.Timeout, 0),
+		rateLimit:  time.Second / time.Duration(rateLimit),
+	}
+}
+
+// clinGenAssertion is a GeneGraph dosage assertion
+type clinGenAssertion struct {
+	DosageClassification *struct {
+		Ordinal *int `json:"ordinal"`
+	} `json:"dosage_classification"`
+}
+
+// clinGenResponse is the GraphQL response to clinGenGeneQuery
+type clinGenResponse struct {
+	Data struct {
+		Genes struct {
+			GeneList []struct {
+				Label          string `json:"label"`
+				HGNCID         string `json:"hgnc_id"`
+				DosageCuration *struct {
+					Haploinsufficiency *clinGenAssertion `json:"haploinsufficiency_assertion"`
+					Triplosensitivity  *clinGenAssertion `json:"triplosensitivity_assertion"`
+				} `json:"dosage_curation"`
+				GeneticConditions []struct {
+					Disease struct {
+						Label string `json:"label"`
+						Curie string `json:"curie"`
+					} `json:"disease"`
+					GeneValidityAssertions []struct {
+						Classification *struct {
+							Label string `json:"label"`
+						} `json:"classification"`
+						ModeOfInheritance *struct {
+							Label string `json:"label"`
+						} `json:"mode_of_inheritance"`
+						AttributedTo *struct {
+							Label string `json:"label"`
+						} `json:"attributed_to"`
+						ReportDate string `json:"report_date"`
+					} `json:"gene_validity_assertions"`
+				} `json:"genetic_conditions"`
+			} `json:"gene_list"`
+		} `json:"genes"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// QueryGeneCuration returns the ClinGen curation of a gene symbol. A gene
+// ClinGen has not curated is returned with dosage scores of -1 and no
+// validity classifications.
+func (c *ClinGenClient) QueryGeneCuration(ctx context.Context, gene string) (*domain.GeneCuration, error) {
+	gene = strings.TrimSpace(gene)
+	if gene == "" {
+		return nil, fmt.Errorf("gene symbol is required")
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     clinGenGeneQuery,
+		"variables": map[string]string{"symbol": gene},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ClinGen query: %w", err)
+	}
+
+	select {
+	case <-time.After(c.rateLimit):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ClinGen request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute ClinGen request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ClinGen returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ClinGen response: %w", err)
+	}
+	return parseClinGenCuration(body, gene)
+}
+
+// parseClinGenCuration extracts the curation of the gene whose symbol
+// matches exactly; the text search also returns genes with similar names
+func parseClinGenCuration(body []byte, gene string) (*domain.GeneCuration, error) {
+	var response clinGenResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse ClinGen response: %w", err)
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("ClinGen query failed: %s", response.Errors[0].Message)
+	}
+
+	curation := &domain.GeneCuration{
+		Gene:               gene,
+		Haploinsufficiency: domain.DosageNotCurated,
+		Triplosensitivity:  domain.DosageNotCurated,
+		Source:             "ClinGen",
+	}
+	for _, entry := range response.Data.Genes.GeneList {
+		if !strings.EqualFold(entry.Label, gene) {
+			continue
+		}
+		curation.Gene = entry.Label
+		curation.HGNCID = entry.HGNCID
+		if entry.DosageCuration != nil {
+			curation.Haploinsufficiency = clinGenDosageScore(entry.DosageCuration.Haploinsufficiency)
+			curation.Triplosensitivity = clinGenDosageScore(entry.DosageCuration.Triplosensitivity)
+		}
+		for _, condition := range entry.GeneticConditions {
+			for _, assertion := range condition.GeneValidityAssertions {
+				if assertion.Classification == nil {
+					continue
+				}
+				validity := domain.GeneDiseaseValidity{
+					Disease:        condition.Disease.Label,
+					DiseaseID:      condition.Disease.Curie,
+					Classification: clinGenLabel(assertion.Classification.Label),
+					ReportDate:     assertion.ReportDate,
+				}
+				if assertion.ModeOfInheritance != nil {
+					validity.ModeOfInheritance = assertion.ModeOfInheritance.Label
+				}
+				if assertion.AttributedTo != nil {
+					validity.ExpertPanel = assertion.AttributedTo.Label
+				}
+				curation.Validity = append(curation.Validity, validity)
+			}
+		}
+		break
+	}
+
+	// Most recent classification first
+	sort.SliceStable(curation.Validity, func(i, j int) bool {
+		return curation.Validity[i].ReportDate > curation.Validity[j].ReportDate
+	})
+	return curation, nil
+}
+
+// clinGenDosageScore returns the score of a dosage assertion, -1 if absent
+func clinGenDosageScore(assertion *clinGenAssertion) int {
+	if assertion == nil || assertion.DosageClassification == nil || assertion.DosageClassification.Ordinal == nil {
+		return domain.DosageNotCurated
+	}
+	return *assertion.DosageClassification.Ordinal
+}
+
+// clinGenLabel capitalizes a GeneGraph classification label, e.g.
+// "definitive evidence" or "definitive" becomes "Definitive"
+func clinGenLabel(label string) string {
+	label = strings.TrimSpace(strings.TrimSuffix(strings.ToLower(label), " evidence"))
+	if label == "" {
+		return ""
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/acmg-amp-mcp-server/internal/domain"
@@ -23,12 +25,10 @@ type ClinVarClient struct {
 // NewClinVarClient creates a new ClinVar API client
 func NewClinVarClient(config domain.ClinVarConfig) *ClinVarClient {
 	return &ClinVarClient{
-		baseURL: config.BaseURL,
-		apiKey:  config.APIKey,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		rateLimit: time.Second / time.Duration(config.RateLimit),
+		baseURL:    config.BaseURL,
+		apiKey:     config.APIKey,
+		httpClient: newHTTPClient(config.Timeout, config.RetryCount),
+		rateLimit:  time.Second / time.Duration(config.RateLimit),
 	}
 }
 
@@ -56,6 +56,12 @@ type DocumentSummary struct {
 		Description    string `xml:"Description"`
 		LastEvaluated  string `xml:"LastEvaluated"`
 	} `xml:"clinical_significance"`
+	// ClinVar reports germline classifications here since 2024, in place of clinical_significance
+	GermlineClassification struct {
+		ReviewStatus string `xml:"review_status"`
+		Description  string `xml:"description"`
+	} `xml:"germline_classification"`
+	ProteinChange string `xml:"protein_change"`
 	Variation struct {
 		Name string `xml:"Name"`
 		Type string `xml:"VariationType"`
@@ -104,15 +110,18 @@ func (c *ClinVarClient) QueryVariant(ctx context.Context, variant *domain.Standa
 // searchVariant searches for variants in ClinVar using E-search
 func (c *ClinVarClient) searchVariant(ctx context.Context, variant *domain.StandardizedVariant) ([]string, error) {
 	// Build search query based on variant information
-	searchTerm := c.buildSearchTerm(variant)
-	
+	return c.search(ctx, c.buildSearchTerm(variant), 20)
+}
+
+// search runs an E-search query and returns up to retmax matching IDs
+func (c *ClinVarClient) search(ctx context.Context, searchTerm string, retmax int) ([]string, error) {
 	// Construct search URL
 	searchURL := fmt.Sprintf("%sesearch.fcgi", c.baseURL)
 	params := url.Values{
 		"db":       {"clinvar"},
 		"term":     {searchTerm},
 		"retmode":  {"xml"},
-		"retmax":   {"20"}, // Limit to 20 results
+		"retmax":   {strconv.Itoa(retmax)},
 	}
 	
 	if c.apiKey != "" {
@@ -151,6 +160,21 @@ func (c *ClinVarClient) searchVariant(ctx context.Context, variant *domain.Stand
 
 // getSummary gets detailed variant information using E-summary
 func (c *ClinVarClient) getSummary(ctx context.Context, variantID string) (*domain.ClinVarData, error) {
+	summaries, err := c.summaries(ctx, []string{variantID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(summaries) == 0 {
+		return &domain.ClinVarData{}, nil
+	}
+
+	// Convert the first document summary to domain ClinVarData
+	return c.convertTodomainClinVarData(summaries[0])
+}
+
+// summaries fetches the E-summary documents of one or more variants
+func (c *ClinVarClient) summaries(ctx context.Context, variantIDs []string) ([]DocumentSummary, error) {
 	// Rate limiting for the second request
 	select {
 	case <-time.After(c.rateLimit):
@@ -162,7 +186,7 @@ func (c *ClinVarClient) getSummary(ctx context.Context, variantID string) (*doma
 	summaryURL := fmt.Sprintf("%sesummary.fcgi", c.baseURL)
 	params := url.Values{
 		"db":       {"clinvar"},
-		"id":       {variantID},
+		"id":       {strings.Join(variantIDs, ",")},
 		"retmode":  {"xml"},
 	}
 	
@@ -197,12 +221,7 @@ func (c *ClinVarClient) getSummary(ctx context.Context, variantID string) (*doma
 		return nil, fmt.Errorf("failed to parse summary response: %w", err)
 	}
 
-	if len(summaryResponse.DocumentSummary) == 0 {
-		return &domain.ClinVarData{}, nil
-	}
-
-	// Convert the first document summary to domain ClinVarData
-	return c.convertTodomainClinVarData(summaryResponse.DocumentSummary[0])
+	return summaryResponse.DocumentSummary, nil
 }
 
 // buildSearchTerm constructs a search term for ClinVar based on variant information
@@ -272,12 +291,22 @@ func (c *ClinVarClient) convertTodomainClinVarData(doc DocumentSummary) (*domain
 		}
 	}
 	
+	significance, reviewStatus := doc.classification()
 	return &domain.ClinVarData{
 		VariationID:          doc.UID,
-		ClinicalSignificance: doc.ClinicalSignificance.Description,
-		ReviewStatus:         doc.ClinicalSignificance.ReviewStatus,
+		ClinicalSignificance: significance,
+		ReviewStatus:         reviewStatus,
 		Submissions:          submissions,
 		LastEvaluated:        lastEvaluated,
 		Conditions:           conditions,
 	}, nil
-}
\ No newline at end of file
+}
+
+// classification returns the variant's aggregate classification and review
+// status, preferring the germline classification ClinVar now reports
+func (doc DocumentSummary) classification() (string, string) {
+	if doc.GermlineClassification.Description != "" {
+		return doc.GermlineClassification.Description, doc.GermlineClassification.ReviewStatus
+	}
+	return doc.ClinicalSignificance.Description, doc.ClinicalSignificance.ReviewStatus
+}
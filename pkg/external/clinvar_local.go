@@ -0,0 +1,738 @@
+package external
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// LocalClinVarSource identifies identifiers resolved from a local ClinVar import
+const LocalClinVarSource = "ClinVar (local)"
+
+// ClinVarVariantClient looks up the aggregate ClinVar record of a variant
+type ClinVarVariantClient interface {
+	QueryVariant(ctx context.Context, variant *domain.StandardizedVariant) (*domain.ClinVarData, error)
+}
+
+const clinVarLocalSchema = `
+CREATE TABLE IF NOT EXISTS clinvar_variants (
+	allele_id TEXT PRIMARY KEY,
+	variation_id TEXT NOT NULL,
+	gene TEXT DEFAULT '',
+	name TEXT DEFAULT '',
+	transcript TEXT DEFAULT '',
+	coding_change TEXT DEFAULT '',
+	hgvs_coding TEXT DEFAULT '',
+	hgvs_protein TEXT DEFAULT '',
+	residue TEXT DEFAULT '',
+	hgvs_genomic TEXT DEFAULT '',
+	chrom TEXT DEFAULT '',
+	pos INTEGER DEFAULT 0,
+	ref TEXT DEFAULT '',
+	alt TEXT DEFAULT '',
+	rsid TEXT DEFAULT '',
+	clinical_significance TEXT DEFAULT '',
+	review_status TEXT DEFAULT '',
+	last_evaluated TEXT DEFAULT '',
+	conditions TEXT DEFAULT ''
+);
+CREATE TABLE IF NOT EXISTS clinvar_rcv (
+	rcv TEXT PRIMARY KEY,
+	variation_id TEXT NOT NULL
+) WITHOUT ROWID;
+CREATE TABLE IF NOT EXISTS clinvar_release (
+	key TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+) WITHOUT ROWID;
+`
+
+// clinVarLocalIndexes are created once the release is loaded, which is much
+// faster than maintaining them during the import
+const clinVarLocalIndexes = `
+CREATE INDEX IF NOT EXISTS clinvar_variants_position ON clinvar_variants (chrom, pos, ref, alt);
+CREATE INDEX IF NOT EXISTS clinvar_variants_genomic ON clinvar_variants (hgvs_genomic);
+CREATE INDEX IF NOT EXISTS clinvar_variants_coding ON clinvar_variants (transcript, coding_change);
+CREATE INDEX IF NOT EXISTS clinvar_variants_rsid ON clinvar_variants (rsid);
+CREATE INDEX IF NOT EXISTS clinvar_variants_residue ON clinvar_variants (gene, residue);
+CREATE INDEX IF NOT EXISTS clinvar_variants_variation ON clinvar_variants (variation_id);
+`
+
+// clinVarVariantColumns are the columns of clinvar_variants in insert order
+var clinVarVariantColumns = []string{
+	"allele_id", "variation_id", "gene", "name", "transcript", "coding_change", "hgvs_coding",
+	"hgvs_protein", "residue", "hgvs_genomic", "chrom", "pos", "ref", "alt", "rsid",
+	"clinical_significance", "review_status", "last_evaluated", "conditions",
+}
+
+// clinVarRecord is one ClinVar allele read from a release file
+type clinVarRecord struct {
+	alleleID, variationID, gene, name         string
+	transcript, codingChange, hgvsCoding      string
+	hgvsProtein, residue, hgvsGenomic         string
+	chrom, ref, alt, rsid                     string
+	pos                                       int64
+	significance, reviewStatus, lastEvaluated string
+	conditions                                []string
+	rcvs                                      []string
+}
+
+// setName parses the ClinVar variation name, such as
+// NM_007294.4(BRCA1):c.5095C>T (p.Arg1699Trp), into the HGVS columns
+func (r *clinVarRecord) setName(name string) {
+	r.name = name
+	m := clinVarVariationName.FindStringSubmatch(name)
+	if m == nil {
+		return
+	}
+	if m[2] != "" {
+		r.gene = m[2]
+	}
+	if strings.HasPrefix(m[3], "g.") {
+		r.hgvsGenomic = m[1] + ":" + m[3]
+		return
+	}
+	if !strings.HasPrefix(m[3], "c.") {
+		return
+	}
+	r.hgvsCoding = m[1] + ":" + m[3]
+	r.transcript = unversionedAccession(m[1])
+	r.codingChange = m[3]
+	r.hgvsProtein = m[4]
+	if change, ok := hgvs.ParseMissense(m[4]); ok {
+		r.residue = fmt.Sprintf("%s%d", change.Ref, change.Position)
+	}
+}
+
+// args returns the record's values in clinVarVariantColumns order
+func (r *clinVarRecord) args() []interface{} {
+	return []interface{}{
+		r.alleleID, r.variationID, r.gene, r.name, r.transcript, r.codingChange, r.hgvsCoding,
+		r.hgvsProtein, r.residue, r.hgvsGenomic, r.chrom, r.pos, r.ref, r.alt, r.rsid,
+		r.significance, r.reviewStatus, r.lastEvaluated, strings.Join(r.conditions, "|"),
+	}
+}
+
+// ClinVarImportOptions configures a ClinVar import
+type ClinVarImportOptions struct {
+	Assembly string // Assembly whose coordinates are imported from variant_summary; GRCh38 if empty
+	Release  string // Release date, e.g. 2024-05-01; read from a VCF's fileDate header when empty
+}
+
+// ClinVarRelease describes a local ClinVar import
+type ClinVarRelease struct {
+	Assembly   string    `json:"assembly"`
+	Release    string    `json:"release,omitempty"` // Release date, when given or read from the files
+	Sources    []string  `json:"sources"`
+	Variants   int       `json:"variants"`
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// ImportClinVar imports a ClinVar release, the tab-delimited
+// variant_summary.txt or the clinvar.vcf (plain or gzipped), into a SQLite
+// database indexed by position, HGVS, rsID and residue. The database is
+// built alongside and then replaces dbPath, so records withdrawn from
+// ClinVar disappear on refresh and readers never see a partial import. It
+// returns the number of variants imported.
+func ImportClinVar(ctx context.Context, dbPath string, files []string, options ClinVarImportOptions) (int, error) {
+	if options.Assembly == "" {
+		options.Assembly = hgvs.AssemblyGRCh38
+	}
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	partial := dbPath + ".partial"
+	os.Remove(partial)
+	total, err := buildClinVarDatabase(ctx, partial, files, options)
+	if err != nil {
+		os.Remove(partial)
+		return total, err
+	}
+	if err := os.Rename(partial, dbPath); err != nil {
+		os.Remove(partial)
+		return 0, fmt.Errorf("failed to replace ClinVar database: %w", err)
+	}
+	return total, nil
+}
+
+func buildClinVarDatabase(ctx context.Context, path string, files []string, options ClinVarImportOptions) (int, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open ClinVar database: %w", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(clinVarLocalSchema); err != nil {
+		return 0, fmt.Errorf("failed to create ClinVar schema: %w", err)
+	}
+
+	total := 0
+	sources := make([]string, 0, len(files))
+	release := options.Release
+	for _, file := range files {
+		n, fileDate, err := importClinVarFile(ctx, db, file, options.Assembly)
+		total += n
+		if err != nil {
+			return total, fmt.Errorf("failed to import %s: %w", file, err)
+		}
+		sources = append(sources, filepath.Base(file))
+		if release == "" {
+			release = fileDate
+		}
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("no %s variants found in %s", options.Assembly, strings.Join(sources, ", "))
+	}
+
+	if _, err := db.ExecContext(ctx, clinVarLocalIndexes); err != nil {
+		return total, fmt.Errorf("failed to index ClinVar database: %w", err)
+	}
+	for key, value := range map[string]string{
+		"assembly":    options.Assembly,
+		"release":     release,
+		"sources":     strings.Join(sources, ","),
+		"imported_at": time.Now().UTC().Format(time.RFC3339),
+	} {
+		if _, err := db.ExecContext(ctx, `INSERT OR REPLACE INTO clinvar_release (key, value) VALUES (?, ?)`, key, value); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// importClinVarFile imports one release file in a single transaction,
+// recognising the format by its first line. It returns the release date
+// from a VCF's fileDate header, if it has one.
+func importClinVarFile(ctx context.Context, db *sql.DB, path, assembly string) (int, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".gz" || ext == ".bgz" {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return 0, "", err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	defer tx.Rollback()
+
+	insert, err := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO clinvar_variants (`+strings.Join(clinVarVariantColumns, ", ")+
+		`) VALUES (?`+strings.Repeat(", ?", len(clinVarVariantColumns)-1)+`)`)
+	if err != nil {
+		return 0, "", err
+	}
+	defer insert.Close()
+	insertRCV, err := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO clinvar_rcv (rcv, variation_id) VALUES (?, ?)`)
+	if err != nil {
+		return 0, "", err
+	}
+	defer insertRCV.Close()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	var parse func(line string) (*clinVarRecord, error)
+	var fileDate string
+	count := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		if parse == nil {
+			switch {
+			case strings.HasPrefix(line, "##fileformat=VCF"):
+				parse = parseClinVarVCFLine
+				continue
+			case strings.HasPrefix(line, "#AlleleID"):
+				columns, err := parseClinVarSummaryHeader(line)
+				if err != nil {
+					return 0, "", err
+				}
+				parse = func(line string) (*clinVarRecord, error) {
+					return columns.parseRecord(line, assembly)
+				}
+				continue
+			default:
+				return 0, "", fmt.Errorf("not a ClinVar variant_summary or VCF file")
+			}
+		}
+		if date, ok := strings.CutPrefix(line, "##fileDate="); ok {
+			fileDate = clinVarFileDate(date)
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		record, err := parse(line)
+		if err != nil {
+			return count, "", err
+		}
+		if record == nil {
+			continue
+		}
+		if _, err := insert.ExecContext(ctx, record.args()...); err != nil {
+			return count, "", err
+		}
+		for _, rcv := range record.rcvs {
+			if _, err := insertRCV.ExecContext(ctx, rcv, record.variationID); err != nil {
+				return count, "", err
+			}
+		}
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return count, "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, "", err
+	}
+	return count, fileDate, nil
+}
+
+// clinVarFileDate formats a VCF fileDate (20240501) as 2024-05-01
+func clinVarFileDate(value string) string {
+	value = strings.TrimSpace(value)
+	if date, err := time.Parse("20060102", value); err == nil {
+		return date.Format("2006-01-02")
+	}
+	return value
+}
+
+// clinVarSummaryColumns maps a variant_summary header to column indexes
+type clinVarSummaryColumns struct {
+	index map[string]int
+}
+
+// variant_summary columns, with the names used by other releases as fallbacks
+var clinVarSummaryColumnNames = map[string][]string{
+	"allele":       {"#AlleleID", "AlleleID"},
+	"variation":    {"VariationID"},
+	"name":         {"Name"},
+	"gene":         {"GeneSymbol"},
+	"significance": {"ClinicalSignificance", "GermlineClassification"},
+	"evaluated":    {"LastEvaluated", "GermlineDateLastEvaluated"},
+	"review":       {"ReviewStatus", "GermlineReviewStatus"},
+	"rsid":         {"RS# (dbSNP)"},
+	"rcv":          {"RCVaccession"},
+	"phenotypes":   {"PhenotypeList"},
+	"assembly":     {"Assembly"},
+	"chrom":        {"Chromosome"},
+	"pos":          {"PositionVCF"},
+	"ref":          {"ReferenceAlleleVCF"},
+	"alt":          {"AlternateAlleleVCF"},
+}
+
+func parseClinVarSummaryHeader(line string) (*clinVarSummaryColumns, error) {
+	names := make(map[string]int)
+	for i, name := range strings.Split(strings.TrimRight(line, "\r"), "\t") {
+		names[name] = i
+	}
+	columns := &clinVarSummaryColumns{index: make(map[string]int)}
+	for column, aliases := range clinVarSummaryColumnNames {
+		if i, ok := lookupColumn(names, aliases); ok {
+			columns.index[column] = i
+		}
+	}
+	for _, required := range []string{"allele", "variation", "name", "significance", "review", "assembly"} {
+		if _, ok := columns.index[required]; !ok {
+			return nil, fmt.Errorf("variant_summary header has no %s column", clinVarSummaryColumnNames[required][0])
+		}
+	}
+	return columns, nil
+}
+
+// parseRecord reads a variant_summary line, returning nil for lines placed
+// on another assembly. Lines without a location are kept for their HGVS.
+func (c *clinVarSummaryColumns) parseRecord(line, assembly string) (*clinVarRecord, error) {
+	fields := strings.Split(strings.TrimRight(line, "\r"), "\t")
+	field := func(column string) string {
+		i, ok := c.index[column]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		switch value := strings.TrimSpace(fields[i]); value {
+		case "-", "na", "-1":
+			return ""
+		default:
+			return value
+		}
+	}
+
+	if placed := field("assembly"); placed != "" && !strings.EqualFold(placed, assembly) {
+		return nil, nil
+	}
+	record := &clinVarRecord{
+		alleleID:     field("allele"),
+		variationID:  field("variation"),
+		significance: field("significance"),
+		reviewStatus: field("review"),
+		rsid:         field("rsid"),
+	}
+	if record.alleleID == "" || record.variationID == "" {
+		return nil, fmt.Errorf("variant_summary line has no AlleleID or VariationID")
+	}
+	if gene := field("gene"); !strings.Contains(gene, ";") {
+		record.gene = gene
+	}
+	record.setName(field("name"))
+	if evaluated := field("evaluated"); evaluated != "" {
+		if parsed, err := time.Parse("Jan 02, 2006", evaluated); err == nil {
+			record.lastEvaluated = parsed.Format("2006-01-02")
+		}
+	}
+	record.conditions = splitClinVarList(field("phenotypes"), "|")
+	for _, rcv := range splitClinVarList(field("rcv"), "|") {
+		record.rcvs = append(record.rcvs, unversionedAccession(rcv))
+	}
+
+	if pos, err := strconv.ParseInt(field("pos"), 10, 64); err == nil && pos > 0 {
+		record.chrom = localClinVarChrom(field("chrom"))
+		record.pos = pos
+		record.ref = field("ref")
+		record.alt = field("alt")
+		if record.hgvsGenomic == "" {
+			record.hgvsGenomic, _ = hgvs.FromVCF(assembly, record.chrom, pos, record.ref, record.alt)
+		}
+	}
+	return record, nil
+}
+
+// parseClinVarVCFLine reads a clinvar.vcf data line, whose ID is the
+// VariationID. The VCF carries no variation name, so records imported from
+// it are found by position, genomic HGVS and rsID only.
+func parseClinVarVCFLine(line string) (*clinVarRecord, error) {
+	fields := strings.Split(strings.TrimRight(line, "\r"), "\t")
+	if len(fields) < 8 {
+		return nil, fmt.Errorf("ClinVar VCF line has %d columns", len(fields))
+	}
+	pos, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ClinVar VCF position %q", fields[1])
+	}
+
+	info := make(map[string]string)
+	for _, entry := range strings.Split(fields[7], ";") {
+		key, value, _ := strings.Cut(entry, "=")
+		info[key] = value
+	}
+	spaced := func(key string) string {
+		return strings.ReplaceAll(info[key], "_", " ")
+	}
+
+	record := &clinVarRecord{
+		alleleID:     info["ALLELEID"],
+		variationID:  fields[2],
+		chrom:        localClinVarChrom(fields[0]),
+		pos:          pos,
+		ref:          fields[3],
+		alt:          fields[4],
+		hgvsGenomic:  info["CLNHGVS"],
+		rsid:         info["RS"],
+		significance: spaced("CLNSIG"),
+		reviewStatus: spaced("CLNREVSTAT"),
+		conditions:   splitClinVarList(spaced("CLNDN"), "|"),
+	}
+	if record.alleleID == "" {
+		record.alleleID = record.variationID
+	}
+	if gene, _, _ := strings.Cut(info["GENEINFO"], ":"); gene != "" {
+		record.gene = gene
+	}
+	if record.alt == "." {
+		// Records without an alternate allele describe no variant to match
+		record.alt = ""
+	}
+	return record, nil
+}
+
+// splitClinVarList splits a ClinVar multi-valued field, dropping empty values
+func splitClinVarList(value, separator string) []string {
+	var values []string
+	for _, part := range strings.Split(value, separator) {
+		if part = strings.TrimSpace(part); part != "" && part != "-" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// unversionedAccession drops the version from an accession such as NM_007294.4
+func unversionedAccession(accession string) string {
+	base, _, _ := strings.Cut(accession, ".")
+	return base
+}
+
+// localClinVarChrom writes chromosomes as ClinVar does: without a chr prefix
+// and with the mitochondrion as MT
+func localClinVarChrom(chrom string) string {
+	chrom = strings.TrimPrefix(strings.TrimPrefix(chrom, "chr"), "Chr")
+	if chrom == "M" {
+		return "MT"
+	}
+	return chrom
+}
+
+// LocalClinVar answers ClinVar variant, residue and identifier lookups from
+// a release imported by "setup clinvar", so classification needs no calls
+// to NCBI
+type LocalClinVar struct {
+	db       *sql.DB
+	minStars int
+	release  ClinVarRelease
+}
+
+// OpenLocalClinVar opens a ClinVar import read-only
+func OpenLocalClinVar(path string) (*LocalClinVar, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to open ClinVar database: %w", err)
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ClinVar database: %w", err)
+	}
+	local := &LocalClinVar{db: db, minStars: DefaultResidueMinStars}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM clinvar_variants`).Scan(&local.release.Variants); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("not a ClinVar import: %s: %w", path, err)
+	}
+
+	rows, err := db.Query(`SELECT key, value FROM clinvar_release`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("not a ClinVar import: %s: %w", path, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			db.Close()
+			return nil, err
+		}
+		switch key {
+		case "assembly":
+			local.release.Assembly = value
+		case "release":
+			local.release.Release = value
+		case "sources":
+			local.release.Sources = strings.Split(value, ",")
+		case "imported_at":
+			local.release.ImportedAt, _ = time.Parse(time.RFC3339, value)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return local, nil
+}
+
+// Release describes the imported release
+func (l *LocalClinVar) Release() ClinVarRelease {
+	return l.release
+}
+
+// Version identifies the release: its date or, when the files carried none,
+// the date it was imported
+func (r ClinVarRelease) Version() string {
+	if r.Release != "" {
+		return r.Release
+	}
+	if r.ImportedAt.IsZero() {
+		return ""
+	}
+	return "imported " + r.ImportedAt.Format("2006-01-02")
+}
+
+// Ping checks the import can be read, for readiness probes
+func (l *LocalClinVar) Ping(ctx context.Context) error {
+	var n int
+	err := l.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (SELECT 1 FROM clinvar_variants LIMIT 1)`).Scan(&n)
+	if err != nil {
+		return fmt.Errorf("local ClinVar unreadable: %w", err)
+	}
+	return nil
+}
+
+// Close releases the database
+func (l *LocalClinVar) Close() error {
+	return l.db.Close()
+}
+
+// clinVarSelect reads the columns scanned by scanClinVarRecords
+const clinVarSelect = `SELECT variation_id, gene, name, transcript, coding_change, hgvs_coding, hgvs_protein,
+	hgvs_genomic, chrom, pos, ref, alt, clinical_significance, review_status, last_evaluated, conditions
+	FROM clinvar_variants `
+
+func scanClinVarRecords(rows *sql.Rows) ([]clinVarRecord, error) {
+	defer rows.Close()
+	var records []clinVarRecord
+	for rows.Next() {
+		var r clinVarRecord
+		var conditions string
+		if err := rows.Scan(&r.variationID, &r.gene, &r.name, &r.transcript, &r.codingChange, &r.hgvsCoding, &r.hgvsProtein,
+			&r.hgvsGenomic, &r.chrom, &r.pos, &r.ref, &r.alt, &r.significance, &r.reviewStatus, &r.lastEvaluated, &conditions); err != nil {
+			return nil, err
+		}
+		r.conditions = splitClinVarList(conditions, "|")
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// find returns the records of the first query that matches
+func (l *LocalClinVar) find(ctx context.Context, queries ...[]interface{}) ([]clinVarRecord, error) {
+	for _, query := range queries {
+		rows, err := l.db.QueryContext(ctx, clinVarSelect+query[0].(string), query[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		records, err := scanClinVarRecords(rows)
+		if err != nil || len(records) > 0 {
+			return records, err
+		}
+	}
+	return nil, nil
+}
+
+// QueryVariant returns the ClinVar record matching the variant's position
+// and alleles, genomic HGVS or coding HGVS, in that order. The transcript
+// version is ignored when matching coding HGVS. A variant not in ClinVar
+// has an empty record, as from the E-utilities client.
+func (l *LocalClinVar) QueryVariant(ctx context.Context, variant *domain.StandardizedVariant) (*domain.ClinVarData, error) {
+	var queries [][]interface{}
+	if variant.Chromosome != "" && variant.Position > 0 && variant.Reference != "" && variant.Alternative != "" {
+		queries = append(queries, []interface{}{`WHERE chrom = ? AND pos = ? AND ref = ? AND alt = ?`,
+			localClinVarChrom(variant.Chromosome), variant.Position, variant.Reference, variant.Alternative})
+	}
+	if variant.HGVSGenomic != "" {
+		queries = append(queries, []interface{}{`WHERE hgvs_genomic = ?`, variant.HGVSGenomic})
+	}
+	if transcript, change, ok := strings.Cut(variant.HGVSCoding, ":"); ok {
+		queries = append(queries, []interface{}{`WHERE transcript = ? AND coding_change = ?`, unversionedAccession(transcript), change})
+	}
+
+	records, err := l.find(ctx, queries...)
+	if err != nil {
+		return nil, fmt.Errorf("local ClinVar lookup failed: %w", err)
+	}
+	if len(records) == 0 {
+		return &domain.ClinVarData{}, nil
+	}
+
+	record := records[0]
+	data := &domain.ClinVarData{
+		VariationID:          record.variationID,
+		ClinicalSignificance: record.significance,
+		ReviewStatus:         record.reviewStatus,
+		Conditions:           record.conditions,
+	}
+	if record.lastEvaluated != "" {
+		data.LastEvaluated, _ = time.Parse("2006-01-02", record.lastEvaluated)
+	}
+	return data, nil
+}
+
+// QueryResidueVariants returns the pathogenic and likely pathogenic
+// variants at a missense variant's residue with at least two review stars,
+// excluding the variant itself, as ClinVarResidueLookup does online
+func (l *LocalClinVar) QueryResidueVariants(ctx context.Context, variant *domain.StandardizedVariant) ([]domain.ResidueVariant, error) {
+	change, ok := hgvs.ParseMissense(variant.HGVSProtein)
+	if !ok || variant.GeneSymbol == "" {
+		return []domain.ResidueVariant{}, nil
+	}
+
+	records, err := l.find(ctx, []interface{}{`WHERE gene = ? AND residue = ?`,
+		variant.GeneSymbol, fmt.Sprintf("%s%d", change.Ref, change.Position)})
+	if err != nil {
+		return nil, fmt.Errorf("local ClinVar residue lookup failed: %w", err)
+	}
+
+	coding := codingChange(variant.HGVSCoding)
+	matches := []domain.ResidueVariant{}
+	for _, record := range records {
+		if match, ok := residueMatch(record.variationID, record.name, record.significance, record.reviewStatus, change, coding, l.minStars); ok {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// ResolveIdentifier looks up the variants an rsID, VCV or RCV accession
+// refers to in the import. Identifiers not in the ClinVar release cannot
+// be resolved offline.
+func (l *LocalClinVar) ResolveIdentifier(ctx context.Context, kind domain.IdentifierKind, identifier string) (*domain.IdentifierMapping, error) {
+	var query []interface{}
+	switch kind {
+	case domain.IdentifierRSID:
+		query = []interface{}{`WHERE rsid = ?`, strings.TrimPrefix(strings.ToLower(identifier), "rs")}
+	case domain.IdentifierVCV:
+		id := strings.TrimLeft(strings.TrimPrefix(strings.ToUpper(unversionedAccession(identifier)), "VCV"), "0")
+		query = []interface{}{`WHERE variation_id = ?`, id}
+	case domain.IdentifierRCV:
+		query = []interface{}{`WHERE variation_id = (SELECT variation_id FROM clinvar_rcv WHERE rcv = ?)`,
+			strings.ToUpper(unversionedAccession(identifier))}
+	default:
+		return nil, fmt.Errorf("unsupported identifier kind: %s", kind)
+	}
+
+	records, err := l.find(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", identifier, err)
+	}
+	if kind != domain.IdentifierRSID && len(records) > 1 {
+		// Haplotypes and compound records describe more than one variant
+		return nil, fmt.Errorf("ClinVar record %s describes %d variants", identifier, len(records))
+	}
+
+	var variants []domain.StandardizedVariant
+	for _, record := range records {
+		if record.hgvsGenomic == "" && record.hgvsCoding == "" {
+			continue
+		}
+		variant := domain.StandardizedVariant{
+			Chromosome:  record.chrom,
+			Position:    record.pos,
+			Reference:   record.ref,
+			Alternative: record.alt,
+			HGVSGenomic: record.hgvsGenomic,
+			HGVSCoding:  record.hgvsCoding,
+			HGVSProtein: record.hgvsProtein,
+			GeneSymbol:  record.gene,
+		}
+		if record.hgvsCoding != "" {
+			variant.TranscriptID, _, _ = strings.Cut(record.hgvsCoding, ":")
+		}
+		variants = append(variants, variant)
+	}
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("%s is not in the local ClinVar release", identifier)
+	}
+
+	return &domain.IdentifierMapping{
+		Identifier: identifier,
+		Kind:       kind,
+		Variants:   variants,
+		Source:     LocalClinVarSource,
+		ResolvedAt: time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,137 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// DefaultResidueMinStars is the ClinVar review status residue matches need
+// by default: two stars, criteria provided by multiple submitters with no
+// conflicts
+const DefaultResidueMinStars = 2
+
+// residueSearchLimit bounds the ClinVar records fetched per residue
+const residueSearchLimit = 50
+
+// clinVarNamePattern captures the coding and protein changes of a ClinVar
+// variant name such as NM_007294.4(BRCA1):c.5095C>T (p.Arg1699Trp)
+var clinVarNamePattern = regexp.MustCompile(`:(c\.[^ ]+)(?: \(p\.([^)]+)\))?`)
+
+// ResidueVariantClient finds curated pathogenic variants at the same protein
+// residue as a missense variant, for PS1 and PM5
+type ResidueVariantClient interface {
+	QueryResidueVariants(ctx context.Context, variant *domain.StandardizedVariant) ([]domain.ResidueVariant, error)
+}
+
+// ClinVarResidueLookup finds pathogenic and likely pathogenic ClinVar
+// variants at the residue of a missense variant, keeping those with at
+// least the configured review stars
+type ClinVarResidueLookup struct {
+	client   *ClinVarClient
+	minStars int
+}
+
+// NewClinVarResidueLookup creates a residue lookup; minStars below one uses
+// DefaultResidueMinStars
+func NewClinVarResidueLookup(config domain.ClinVarConfig, minStars int) *ClinVarResidueLookup {
+	if minStars < 1 {
+		minStars = DefaultResidueMinStars
+	}
+	return &ClinVarResidueLookup{client: NewClinVarClient(config), minStars: minStars}
+}
+
+// QueryResidueVariants returns the qualifying variants at the variant's
+// residue, excluding the variant itself. Variants other than missense have
+// no residue matches.
+func (l *ClinVarResidueLookup) QueryResidueVariants(ctx context.Context, variant *domain.StandardizedVariant) ([]domain.ResidueVariant, error) {
+	change, ok := hgvs.ParseMissense(variant.HGVSProtein)
+	if !ok || variant.GeneSymbol == "" {
+		return []domain.ResidueVariant{}, nil
+	}
+
+	// The search only narrows the candidates; each record's name is checked
+	// against the residue below
+	term := fmt.Sprintf("%s[gene] AND %s%d*[Protein change]", variant.GeneSymbol, change.Ref, change.Position)
+	ids, err := l.client.search(ctx, term, residueSearchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search ClinVar residue %s: %w", change, err)
+	}
+	if len(ids) == 0 {
+		return []domain.ResidueVariant{}, nil
+	}
+
+	docs, err := l.client.summaries(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ClinVar residue summaries: %w", err)
+	}
+
+	coding := codingChange(variant.HGVSCoding)
+	matches := []domain.ResidueVariant{}
+	for _, doc := range docs {
+		name := doc.Title
+		if name == "" {
+			name = doc.Variation.Name
+		}
+		significance, reviewStatus := doc.classification()
+		if match, ok := residueMatch(doc.UID, name, significance, reviewStatus, change, coding, l.minStars); ok {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// residueMatch converts a ClinVar record, by its variation name, to a
+// residue match if it is a different nucleotide change at the same residue,
+// classified pathogenic with at least minStars review stars
+func residueMatch(variationID, name, significance, reviewStatus string, change hgvs.MissenseChange, coding string, minStars int) (domain.ResidueVariant, bool) {
+	parts := clinVarNamePattern.FindStringSubmatch(name)
+	if parts == nil || parts[2] == "" {
+		return domain.ResidueVariant{}, false
+	}
+	if coding != "" && parts[1] == coding {
+		return domain.ResidueVariant{}, false
+	}
+	other, ok := hgvs.ParseMissense(parts[2])
+	if !ok || other.Position != change.Position || other.Ref != change.Ref {
+		return domain.ResidueVariant{}, false
+	}
+
+	stars := domain.ReviewStatusStars(reviewStatus)
+	if !isPathogenicClassification(significance) || stars < minStars {
+		return domain.ResidueVariant{}, false
+	}
+
+	return domain.ResidueVariant{
+		VariationID:          variationID,
+		Name:                 name,
+		ProteinChange:        other.String(),
+		ClinicalSignificance: significance,
+		ReviewStatus:         reviewStatus,
+		Stars:                stars,
+		SameChange:           other.Alt == change.Alt,
+	}, true
+}
+
+// isPathogenicClassification reports whether a ClinVar classification is
+// pathogenic or likely pathogenic without conflicting or benign assertions
+func isPathogenicClassification(significance string) bool {
+	s := strings.ToLower(significance)
+	return strings.Contains(s, "pathogenic") &&
+		!strings.Contains(s, "conflicting") &&
+		!strings.Contains(s, "benign") &&
+		!strings.Contains(s, "uncertain") &&
+		!strings.Contains(s, "low penetrance")
+}
+
+// codingChange returns the c. change of a coding HGVS without its transcript
+func codingChange(hgvsCoding string) string {
+	if i := strings.Index(hgvsCoding, ":"); i >= 0 {
+		return hgvsCoding[i+1:]
+	}
+	return hgvsCoding
+}
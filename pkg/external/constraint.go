@@ -0,0 +1,187 @@
+package external
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// GeneConstraintClient looks up the constraint metrics of a gene
+type GeneConstraintClient interface {
+	QueryConstraint(ctx context.Context, gene string) (*domain.GeneConstraint, error)
+}
+
+// constraintColumns are the accepted column names of each metric: gnomAD
+// v4 constraint_metrics.tsv names first, then v2.1.1 lof_metrics.by_gene.txt
+var constraintColumns = map[string][]string{
+	"gene":       {"gene", "gene_symbol"},
+	"transcript": {"transcript", "transcript_id"},
+	"mane":       {"mane_select"},
+	"canonical":  {"canonical"},
+	"pli":        {"lof.pli", "pli"},
+	"loeuf":      {"lof.oe_ci.upper", "oe_lof_upper"},
+	"missense_z": {"mis.z_score", "mis_z"},
+}
+
+// ConstraintTable is an in-memory index of gnomAD gene constraint metrics,
+// keyed by upper-case gene symbol. Each gene keeps its MANE Select
+// transcript, else its canonical transcript, else the first listed.
+type ConstraintTable struct {
+	genes  map[string]*domain.GeneConstraint
+	source string
+}
+
+// constraintRank orders the transcripts of a gene by preference
+func constraintRank(mane, canonical bool) int {
+	switch {
+	case mane:
+		return 2
+	case canonical:
+		return 1
+	}
+	return 0
+}
+
+// LoadConstraintTable loads a gnomAD constraint table: the v4
+// constraint_metrics.tsv or the v2.1.1 lof_metrics.by_gene.txt, optionally
+// gzipped. The release is recognized from the column names.
+func LoadConstraintTable(path string) (*ConstraintTable, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open constraint table: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".gz" || ext == ".bgz" {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress constraint table: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	table, err := readConstraintTable(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read constraint table %s: %w", path, err)
+	}
+	return table, nil
+}
+
+func readConstraintTable(reader io.Reader) (*ConstraintTable, error) {
+	table := &ConstraintTable{genes: make(map[string]*domain.GeneConstraint), source: "gnomAD v2.1.1"}
+	ranks := make(map[string]int)
+
+	scanner := newScoreScanner(reader)
+	var header map[string]int
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "##") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+
+		if header == nil {
+			names := make(map[string]int, len(fields))
+			for i, name := range fields {
+				names[strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))] = i
+			}
+			header = make(map[string]int)
+			for metric, candidates := range constraintColumns {
+				for _, candidate := range candidates {
+					if i, ok := names[candidate]; ok {
+						header[metric] = i
+						break
+					}
+				}
+			}
+			if _, ok := header["gene"]; !ok {
+				return nil, fmt.Errorf("header is missing the gene column")
+			}
+			_, hasPLI := header["pli"]
+			_, hasLOEUF := header["loeuf"]
+			_, hasMissenseZ := header["missense_z"]
+			if !hasPLI && !hasLOEUF && !hasMissenseZ {
+				return nil, fmt.Errorf("header has none of the pLI, LOEUF or missense Z columns")
+			}
+			if _, ok := names["lof.oe_ci.upper"]; ok {
+				table.source = "gnomAD v4"
+			}
+			continue
+		}
+
+		column := func(metric string) string {
+			if i, ok := header[metric]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		metric := func(name string) (*float64, error) {
+			value := column(name)
+			if value == "" || value == "." || strings.EqualFold(value, "NA") || strings.EqualFold(value, "NaN") {
+				return nil, nil
+			}
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s %q", line, name, value)
+			}
+			return &f, nil
+		}
+
+		gene := strings.ToUpper(column("gene"))
+		if gene == "" {
+			continue
+		}
+		rank := constraintRank(strings.EqualFold(column("mane"), "true"), strings.EqualFold(column("canonical"), "true"))
+		if previous, ok := ranks[gene]; ok && previous >= rank {
+			continue
+		}
+
+		constraint := &domain.GeneConstraint{Gene: column("gene"), Transcript: column("transcript"), Source: table.source}
+		var err error
+		if constraint.PLI, err = metric("pli"); err != nil {
+			return nil, err
+		}
+		if constraint.LOEUF, err = metric("loeuf"); err != nil {
+			return nil, err
+		}
+		if constraint.MissenseZ, err = metric("missense_z"); err != nil {
+			return nil, err
+		}
+		table.genes[gene] = constraint
+		ranks[gene] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("empty constraint table")
+	}
+	return table, nil
+}
+
+// Source returns the gnomAD release of the table
+func (t *ConstraintTable) Source() string {
+	return t.source
+}
+
+// Len returns the number of genes with constraint metrics
+func (t *ConstraintTable) Len() int {
+	return len(t.genes)
+}
+
+// QueryConstraint returns the constraint metrics of a gene, or nil if the
+// table does not list it
+func (t *ConstraintTable) QueryConstraint(_ context.Context, gene string) (*domain.GeneConstraint, error) {
+	return t.genes[strings.ToUpper(strings.TrimSpace(gene))], nil
+}
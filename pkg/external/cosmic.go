@@ -25,12 +25,10 @@ type COSMICClient struct {
 // NewCOSMICClient creates a new COSMIC API client
 func NewCOSMICClient(config domain.COSMICConfig) *COSMICClient {
 	return &COSMICClient{
-		baseURL: config.BaseURL,
-		apiKey:  config.APIKey,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		rateLimit: time.Second / time.Duration(config.RateLimit),
+		baseURL:    config.BaseURL,
+		apiKey:     config.APIKey,
+		httpClient: newHTTPClient(config.Timeout, config.RetryCount),
+		rateLimit:  time.Second / time.Duration(config.RateLimit),
 	}
 }
 
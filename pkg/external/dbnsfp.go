@@ -0,0 +1,560 @@
+package external
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// DbNSFPSource identifies dbNSFP scores in the evidence
+const DbNSFPSource = "dbNSFP"
+
+// ComputationalPredictionClient looks up in silico prediction scores for a variant
+type ComputationalPredictionClient interface {
+	QueryVariant(ctx context.Context, variant *domain.StandardizedVariant) (*domain.ComputationalData, error)
+}
+
+// dbNSFP score columns, with the names used by earlier releases as fallbacks
+var dbNSFPScoreColumns = map[string][]string{
+	domain.PredictorSIFT:          {"SIFT_score"},
+	domain.PredictorPolyPhen:      {"Polyphen2_HVAR_score"},
+	domain.PredictorCADD:          {"CADD_phred", "CADD_phred_hg19"},
+	domain.PredictorREVEL:         {"REVEL_score"},
+	domain.PredictorAlphaMissense: {"AlphaMissense_score"},
+	domain.PredictorBayesDel:      {"BayesDel_noAF_score"},
+	domain.PredictorGERP:          {"GERP++_RS", "GERP_91_mammals"},
+	domain.PredictorPhyloP:        {"phyloP100way_vertebrate"},
+}
+
+// dbNSFPPredictors lists the scored predictors in a fixed order
+var dbNSFPPredictors = []string{
+	domain.PredictorSIFT, domain.PredictorPolyPhen, domain.PredictorCADD, domain.PredictorREVEL,
+	domain.PredictorAlphaMissense, domain.PredictorBayesDel, domain.PredictorGERP, domain.PredictorPhyloP,
+}
+
+// dbNSFPColumns maps a dbNSFP header to column indexes
+type dbNSFPColumns struct {
+	chrom, pos, ref, alt, gene int
+	scores                     map[string]int
+}
+
+// parseDbNSFPHeader locates the variant and score columns in a dbNSFP header line
+func parseDbNSFPHeader(line string) (*dbNSFPColumns, error) {
+	index := make(map[string]int)
+	for i, name := range strings.Split(strings.TrimPrefix(strings.TrimSpace(line), "#"), "\t") {
+		index[name] = i
+	}
+
+	columns := &dbNSFPColumns{gene: -1, scores: make(map[string]int)}
+	for _, required := range []struct {
+		target *int
+		names  []string
+	}{
+		{&columns.chrom, []string{"chr", "hg38_chr"}},
+		{&columns.pos, []string{"pos(1-based)", "hg38_pos(1-based)"}},
+		{&columns.ref, []string{"ref"}},
+		{&columns.alt, []string{"alt"}},
+	} {
+		i, ok := lookupColumn(index, required.names)
+		if !ok {
+			return nil, fmt.Errorf("dbNSFP header has no %s column", required.names[0])
+		}
+		*required.target = i
+	}
+	if i, ok := lookupColumn(index, []string{"genename"}); ok {
+		columns.gene = i
+	}
+	for predictor, names := range dbNSFPScoreColumns {
+		if i, ok := lookupColumn(index, names); ok {
+			columns.scores[predictor] = i
+		}
+	}
+	if len(columns.scores) == 0 {
+		return nil, fmt.Errorf("dbNSFP header has no supported score columns")
+	}
+	return columns, nil
+}
+
+func lookupColumn(index map[string]int, names []string) (int, bool) {
+	for _, name := range names {
+		if i, ok := index[name]; ok {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// dbNSFPRecord is one scored variant
+type dbNSFPRecord struct {
+	chrom, ref, alt, gene string
+	pos                   int64
+	scores                map[string]float64
+}
+
+// parseRecord reads a dbNSFP data line
+func (c *dbNSFPColumns) parseRecord(line string) (*dbNSFPRecord, error) {
+	fields := strings.Split(strings.TrimRight(line, "\r\n"), "\t")
+	for _, i := range []int{c.chrom, c.pos, c.ref, c.alt} {
+		if i >= len(fields) {
+			return nil, fmt.Errorf("dbNSFP line has %d columns", len(fields))
+		}
+	}
+	pos, err := strconv.ParseInt(fields[c.pos], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dbNSFP position %q", fields[c.pos])
+	}
+
+	record := &dbNSFPRecord{
+		chrom:  strings.TrimPrefix(fields[c.chrom], "chr"),
+		pos:    pos,
+		ref:    fields[c.ref],
+		alt:    fields[c.alt],
+		scores: make(map[string]float64),
+	}
+	if c.gene >= 0 && c.gene < len(fields) {
+		record.gene = firstValue(fields[c.gene])
+	}
+	for predictor, i := range c.scores {
+		if i >= len(fields) {
+			continue
+		}
+		// SIFT is more damaging the lower it is; the other scores the higher
+		if score, ok := combineScores(fields[i], predictor == domain.PredictorSIFT); ok {
+			record.scores[predictor] = score
+		}
+	}
+	return record, nil
+}
+
+// combineScores reduces per-transcript scores separated by ";" to the most
+// damaging one; "." marks a missing score
+func combineScores(field string, lowerIsDamaging bool) (float64, bool) {
+	var best float64
+	found := false
+	for _, value := range strings.Split(field, ";") {
+		score, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			continue
+		}
+		if !found || (lowerIsDamaging && score < best) || (!lowerIsDamaging && score > best) {
+			best, found = score, true
+		}
+	}
+	return best, found
+}
+
+// firstValue returns the first of several ";" separated values
+func firstValue(field string) string {
+	value, _, _ := strings.Cut(field, ";")
+	if value == "." {
+		return ""
+	}
+	return value
+}
+
+// computationalData converts the record's scores
+func (r *dbNSFPRecord) computationalData() *domain.ComputationalData {
+	data := &domain.ComputationalData{Source: DbNSFPSource}
+	for _, predictor := range dbNSFPPredictors {
+		score, ok := r.scores[predictor]
+		if !ok {
+			continue
+		}
+		data.ScoredBy = append(data.ScoredBy, predictor)
+		switch predictor {
+		case domain.PredictorSIFT:
+			data.SIFTScore = score
+		case domain.PredictorPolyPhen:
+			data.PolyPhenScore = score
+		case domain.PredictorCADD:
+			data.CADDScore = score
+		case domain.PredictorREVEL:
+			data.REVELScore = score
+		case domain.PredictorAlphaMissense:
+			data.AlphaMissenseScore = score
+		case domain.PredictorBayesDel:
+			data.BayesDelScore = score
+		case domain.PredictorGERP:
+			data.GERPScore = score
+		case domain.PredictorPhyloP:
+			data.PhyloPScore = score
+		}
+	}
+	if len(data.ScoredBy) == 0 {
+		return nil
+	}
+	return data
+}
+
+// dbNSFPBackend looks up one variant's scores
+type dbNSFPBackend interface {
+	lookup(ctx context.Context, chrom string, pos int64, ref, alt string) (*dbNSFPRecord, error)
+	Close() error
+}
+
+// DbNSFPAnnotator looks up REVEL, CADD, AlphaMissense and other in silico
+// scores in a local copy of dbNSFP, either a SQLite import made by
+// "setup dbnsfp" or a bgzipped dbNSFP file with a tabix index
+type DbNSFPAnnotator struct {
+	backend dbNSFPBackend
+	version string
+}
+
+// NewDbNSFPAnnotator opens a dbNSFP SQLite import (.db, .sqlite) or a bgzipped
+// file with a tabix index alongside it (.gz with .gz.tbi).
+func NewDbNSFPAnnotator(path string) (*DbNSFPAnnotator, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".db", ".sqlite", ".sqlite3":
+		backend, err := openDbNSFPSQLite(path)
+		if err != nil {
+			return nil, err
+		}
+		return &DbNSFPAnnotator{backend: backend, version: backend.version}, nil
+	case ".gz", ".bgz":
+		backend, err := openDbNSFPTabix(path)
+		if err != nil {
+			return nil, err
+		}
+		return &DbNSFPAnnotator{backend: backend, version: DbNSFPVersionOf(path)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported dbNSFP file %s (use a SQLite import or a tabix-indexed .gz)", path)
+	}
+}
+
+// QueryVariant returns the variant's scores, or nil when dbNSFP has none
+func (a *DbNSFPAnnotator) QueryVariant(ctx context.Context, variant *domain.StandardizedVariant) (*domain.ComputationalData, error) {
+	if variant.Chromosome == "" || variant.Position == 0 || variant.Reference == "" || variant.Alternative == "" {
+		return nil, nil
+	}
+	chrom := strings.TrimPrefix(variant.Chromosome, "chr")
+	record, err := a.backend.lookup(ctx, chrom, int64(variant.Position), variant.Reference, variant.Alternative)
+	if err != nil {
+		return nil, fmt.Errorf("dbNSFP lookup failed: %w", err)
+	}
+	if record == nil {
+		return nil, nil
+	}
+	return record.computationalData(), nil
+}
+
+// Version returns the dbNSFP build scores are read from, e.g. 4.9a, or ""
+// when it is not known
+func (a *DbNSFPAnnotator) Version() string {
+	return a.version
+}
+
+// Close releases the underlying file or database
+func (a *DbNSFPAnnotator) Close() error {
+	return a.backend.Close()
+}
+
+// dbNSFPSQLite is a dbNSFP import in SQLite
+type dbNSFPSQLite struct {
+	db      *sql.DB
+	version string
+	columns []int // Indexes of the dbNSFPSQLiteColumns present in the import
+}
+
+const dbNSFPSchema = `
+CREATE TABLE IF NOT EXISTS dbnsfp_scores (
+	chrom TEXT NOT NULL,
+	pos INTEGER NOT NULL,
+	ref TEXT NOT NULL,
+	alt TEXT NOT NULL,
+	gene TEXT DEFAULT '',
+	sift REAL,
+	polyphen REAL,
+	cadd REAL,
+	revel REAL,
+	alphamissense REAL,
+	bayesdel REAL,
+	gerp REAL,
+	phylop REAL,
+	PRIMARY KEY (chrom, pos, ref, alt)
+) WITHOUT ROWID;
+CREATE TABLE IF NOT EXISTS dbnsfp_release (
+	key TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+) WITHOUT ROWID;
+`
+
+// dbNSFPFileVersion finds the build in a dbNSFP file name, e.g.
+// dbNSFP4.9a_variant.chr17.gz
+var dbNSFPFileVersion = regexp.MustCompile(`(?i)dbNSFP[_-]?v?(\d+\.\d+[a-z]?)`)
+
+// DbNSFPVersionOf returns the dbNSFP build named in a file path, or ""
+func DbNSFPVersionOf(path string) string {
+	if m := dbNSFPFileVersion.FindStringSubmatch(filepath.Base(path)); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// dbNSFPSQLiteColumns are the score columns in dbNSFPPredictors order
+var dbNSFPSQLiteColumns = []string{"sift", "polyphen", "cadd", "revel", "alphamissense", "bayesdel", "gerp", "phylop"}
+
+func openDbNSFPSQLite(path string) (*dbNSFPSQLite, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to open dbNSFP database: %w", err)
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dbNSFP database: %w", err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM dbnsfp_scores LIMIT 1`).Scan(new(int)); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("not a dbNSFP import: %s: %w", path, err)
+	}
+	present, err := dbNSFPTableColumns(context.Background(), db)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to read dbNSFP schema: %w", err)
+	}
+	s := &dbNSFPSQLite{db: db}
+	for i, column := range dbNSFPSQLiteColumns {
+		if present[column] {
+			s.columns = append(s.columns, i)
+		}
+	}
+	// Imports made before builds were recorded have no release table
+	_ = db.QueryRow(`SELECT value FROM dbnsfp_release WHERE key = 'version'`).Scan(&s.version)
+	return s, nil
+}
+
+// dbNSFPTableColumns returns the columns of the scores table. Imports made
+// before a predictor was supported lack its column.
+func dbNSFPTableColumns(ctx context.Context, db *sql.DB) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM pragma_table_info('dbnsfp_scores')`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
+
+// migrateDbNSFPSchema adds score columns missing from an older import
+func migrateDbNSFPSchema(ctx context.Context, db *sql.DB) error {
+	present, err := dbNSFPTableColumns(ctx, db)
+	if err != nil {
+		return err
+	}
+	for _, column := range dbNSFPSQLiteColumns {
+		if present[column] {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, `ALTER TABLE dbnsfp_scores ADD COLUMN `+column+` REAL`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *dbNSFPSQLite) lookup(ctx context.Context, chrom string, pos int64, ref, alt string) (*dbNSFPRecord, error) {
+	record := &dbNSFPRecord{chrom: chrom, pos: pos, ref: ref, alt: alt, scores: make(map[string]float64)}
+	scores := make([]sql.NullFloat64, len(s.columns))
+	names := make([]string, len(s.columns))
+	dest := []interface{}{&record.gene}
+	for i, column := range s.columns {
+		names[i] = dbNSFPSQLiteColumns[column]
+		dest = append(dest, &scores[i])
+	}
+
+	err := s.db.QueryRowContext(ctx,
+		`SELECT gene, `+strings.Join(names, ", ")+` FROM dbnsfp_scores WHERE chrom = ? AND pos = ? AND ref = ? AND alt = ?`,
+		chrom, pos, ref, alt,
+	).Scan(dest...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, score := range scores {
+		if score.Valid {
+			record.scores[dbNSFPPredictors[s.columns[i]]] = score.Float64
+		}
+	}
+	return record, nil
+}
+
+func (s *dbNSFPSQLite) Close() error {
+	return s.db.Close()
+}
+
+// DbNSFPImportOptions restricts a dbNSFP import
+type DbNSFPImportOptions struct {
+	Genes   []string // Import only these genes; empty imports all variants
+	Version string   // dbNSFP build, e.g. 4.9a; read from the file names when empty
+}
+
+// ImportDbNSFP imports the score columns of dbNSFP variant files (plain or
+// gzipped) into a SQLite database, creating it if needed. Variants already
+// present are replaced. A database holds one build: importing 4.8a files
+// into a 4.9a import fails. It returns the number of variants imported.
+func ImportDbNSFP(ctx context.Context, dbPath string, files []string, options DbNSFPImportOptions) (int, error) {
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open dbNSFP database: %w", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(dbNSFPSchema); err != nil {
+		return 0, fmt.Errorf("failed to create dbNSFP schema: %w", err)
+	}
+	if err := migrateDbNSFPSchema(ctx, db); err != nil {
+		return 0, fmt.Errorf("failed to migrate dbNSFP schema: %w", err)
+	}
+	if err := recordDbNSFPVersion(ctx, db, dbPath, files, options.Version); err != nil {
+		return 0, err
+	}
+
+	genes := make(map[string]bool, len(options.Genes))
+	for _, gene := range options.Genes {
+		genes[strings.ToUpper(strings.TrimSpace(gene))] = true
+	}
+
+	total := 0
+	for _, file := range files {
+		n, err := importDbNSFPFile(ctx, db, file, genes)
+		total += n
+		if err != nil {
+			return total, fmt.Errorf("failed to import %s: %w", file, err)
+		}
+	}
+	return total, nil
+}
+
+// recordDbNSFPVersion records the build being imported, refusing to mix
+// builds in one database
+func recordDbNSFPVersion(ctx context.Context, db *sql.DB, dbPath string, files []string, version string) error {
+	if version == "" {
+		for _, file := range files {
+			fileVersion := DbNSFPVersionOf(file)
+			if version != "" && fileVersion != "" && fileVersion != version {
+				return fmt.Errorf("files are from dbNSFP %s and %s; import one build per database", version, fileVersion)
+			}
+			if fileVersion != "" {
+				version = fileVersion
+			}
+		}
+	}
+	if version == "" {
+		return nil
+	}
+
+	var existing string
+	err := db.QueryRowContext(ctx, `SELECT value FROM dbnsfp_release WHERE key = 'version'`).Scan(&existing)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		_, err = db.ExecContext(ctx, `INSERT INTO dbnsfp_release (key, value) VALUES ('version', ?)`, version)
+		return err
+	case err != nil:
+		return err
+	case existing != version:
+		return fmt.Errorf("%s holds dbNSFP %s, not %s; import into a new database", dbPath, existing, version)
+	}
+	return nil
+}
+
+// importDbNSFPFile imports one file in a single transaction
+func importDbNSFPFile(ctx context.Context, db *sql.DB, path string, genes map[string]bool) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".gz" || ext == ".bgz" {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return 0, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	insert, err := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO dbnsfp_scores (chrom, pos, ref, alt, gene, `+
+		strings.Join(dbNSFPSQLiteColumns, ", ")+`) VALUES (?, ?, ?, ?, ?`+strings.Repeat(", ?", len(dbNSFPSQLiteColumns))+`)`)
+	if err != nil {
+		return 0, err
+	}
+	defer insert.Close()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	var columns *dbNSFPColumns
+	count := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		if columns == nil {
+			if !strings.HasPrefix(line, "#") {
+				return 0, fmt.Errorf("missing dbNSFP header line")
+			}
+			if columns, err = parseDbNSFPHeader(line); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		record, err := columns.parseRecord(line)
+		if err != nil {
+			return count, err
+		}
+		if len(genes) > 0 && !genes[strings.ToUpper(record.gene)] {
+			continue
+		}
+		if len(record.scores) == 0 {
+			continue
+		}
+
+		args := []interface{}{record.chrom, record.pos, record.ref, record.alt, record.gene}
+		for _, predictor := range dbNSFPPredictors {
+			if score, ok := record.scores[predictor]; ok {
+				args = append(args, score)
+			} else {
+				args = append(args, nil)
+			}
+		}
+		if _, err := insert.ExecContext(ctx, args...); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return count, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
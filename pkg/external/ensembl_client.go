@@ -88,11 +88,9 @@ func NewEnsemblClient(config EnsemblConfig) *EnsemblClient {
 	}
 
 	return &EnsemblClient{
-		baseURL: config.BaseURL,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		rateLimit: rate.NewLimiter(rate.Limit(config.RateLimit), 1),
+		baseURL:    config.BaseURL,
+		httpClient: newHTTPClient(config.Timeout, 0),
+		rateLimit:  rate.NewLimiter(rate.Limit(config.RateLimit), 1),
 	}
 }
 
@@ -0,0 +1,368 @@
+package external
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/metrics"
+)
+
+// Evidence sources cached by the evidence cache, as used in cache keys,
+// per-source TTL configuration and cache statistics
+const (
+	CacheSourceClinVar = "clinvar"
+	CacheSourceGnomAD  = "gnomad"
+	CacheSourceCOSMIC  = "cosmic"
+	CacheSourcePubMed  = "pubmed"
+	CacheSourceLOVD    = "lovd"
+	CacheSourceHGMD    = "hgmd"
+	CacheSourceClinGen = "clingen"
+)
+
+// defaultCacheMaxEntries bounds the in-memory cache when no size is configured
+const defaultCacheMaxEntries = 10000
+
+// revalidationTimeout bounds a background refresh of a stale entry
+const revalidationTimeout = 60 * time.Second
+
+// CachePolicy is how long a source's responses are served from the cache.
+// A response is fresh for TTL; for StaleWhileRevalidate after that it is
+// still served, while a refresh runs in the background.
+type CachePolicy struct {
+	TTL                  time.Duration
+	StaleWhileRevalidate time.Duration
+}
+
+// DefaultCachePolicies follow the release cadence of each source: ClinVar
+// and PubMed change weekly, ClinGen curations monthly, gnomAD and HGMD
+// quarterly
+var DefaultCachePolicies = map[string]CachePolicy{
+	CacheSourceClinVar: {TTL: 7 * 24 * time.Hour, StaleWhileRevalidate: 24 * time.Hour},
+	CacheSourceGnomAD:  {TTL: 90 * 24 * time.Hour, StaleWhileRevalidate: 7 * 24 * time.Hour},
+	CacheSourceCOSMIC:  {TTL: 30 * 24 * time.Hour, StaleWhileRevalidate: 7 * 24 * time.Hour},
+	CacheSourcePubMed:  {TTL: 7 * 24 * time.Hour, StaleWhileRevalidate: 24 * time.Hour},
+	CacheSourceLOVD:    {TTL: 30 * 24 * time.Hour, StaleWhileRevalidate: 7 * 24 * time.Hour},
+	CacheSourceHGMD:    {TTL: 90 * 24 * time.Hour, StaleWhileRevalidate: 7 * 24 * time.Hour},
+	CacheSourceClinGen: {TTL: 30 * 24 * time.Hour, StaleWhileRevalidate: 7 * 24 * time.Hour},
+}
+
+// CacheStore is the storage behind the evidence cache: an in-memory LRU for
+// the lite server, Redis for the full server
+type CacheStore interface {
+	// Backend names the storage, e.g. memory or redis
+	Backend() string
+	// Get returns the value stored under key, if any and not expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores a value that expires after ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	// Len returns the number of entries stored
+	Len(ctx context.Context) (int, error)
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// NewCacheStore creates the store for a cache configuration: Redis when a
+// Redis URL is set, otherwise an in-memory LRU bounded by MaxEntries and
+// MaxBytes that spills evicted entries to the SQLite file at SpillPath, if
+// one is set
+func NewCacheStore(config domain.CacheConfig) (CacheStore, error) {
+	if config.RedisURL != "" {
+		return NewRedisCacheStore(config)
+	}
+	var disk *SQLiteCacheStore
+	if config.SpillPath != "" {
+		var err error
+		if disk, err = NewSQLiteCacheStore(config.SpillPath); err != nil {
+			return nil, err
+		}
+	}
+	return NewTieredCacheStore(MemoryCacheLimits{MaxEntries: config.MaxEntries, MaxBytes: config.MaxBytes}, disk)
+}
+
+// CacheStats reports the contents and effectiveness of the evidence cache
+type CacheStats struct {
+	Backend  string                       `json:"backend"`
+	Entries  int                          `json:"entries"`
+	Capacity int                          `json:"capacity,omitempty"` // Maximum entries of the in-memory LRU
+	Errors   int64                        `json:"errors"`             // Store failures, which are treated as misses
+	Memory   *MemoryCacheStats            `json:"memory,omitempty"`   // Size, evictions and hits of the in-memory LRU
+	Sources  map[string]*SourceCacheStats `json:"sources"`
+}
+
+// SourceCacheStats reports the policy and counters of one evidence source
+type SourceCacheStats struct {
+	TTL                  string  `json:"ttl"`
+	StaleWhileRevalidate string  `json:"stale_while_revalidate"`
+	Hits                 int64   `json:"hits"`       // Served fresh
+	StaleHits            int64   `json:"stale_hits"` // Served stale while revalidating
+	Misses               int64   `json:"misses"`
+	Revalidations        int64   `json:"revalidations"`
+	RevalidationErrors   int64   `json:"revalidation_errors"`
+	HitRatio             float64 `json:"hit_ratio"` // Fresh and stale hits over all lookups
+}
+
+// cacheEnvelope records when a cached response was fetched
+type cacheEnvelope[T any] struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Data      *T        `json:"data"`
+}
+
+// EvidenceCache caches evidence source responses with a TTL per source and
+// stale-while-revalidate: a response past its TTL is returned at once and
+// refreshed in the background, so callers wait on a source only on a miss
+type EvidenceCache struct {
+	store    CacheStore
+	capacity int
+	policies map[string]CachePolicy
+	fallback CachePolicy // Policy of sources without one
+	now      func() time.Time
+
+	mu           sync.Mutex
+	stats        map[string]*SourceCacheStats
+	errors       int64
+	revalidating map[string]bool
+}
+
+// NewEvidenceCache creates an evidence cache over store. Per-source TTLs in
+// the configuration override DefaultCachePolicies, and a configured
+// StaleWhileRevalidate replaces every source's stale window.
+func NewEvidenceCache(store CacheStore, config domain.CacheConfig) *EvidenceCache {
+	policies := make(map[string]CachePolicy, len(DefaultCachePolicies))
+	for source, policy := range DefaultCachePolicies {
+		policies[source] = policy
+	}
+	for source, ttl := range config.SourceTTLs {
+		policy := policies[source]
+		policy.TTL = ttl
+		policies[source] = policy
+	}
+	if config.StaleWhileRevalidate > 0 {
+		for source, policy := range policies {
+			policy.StaleWhileRevalidate = config.StaleWhileRevalidate
+			policies[source] = policy
+		}
+	}
+
+	cache := &EvidenceCache{
+		store:        store,
+		policies:     policies,
+		fallback:     CachePolicy{TTL: config.DefaultTTL, StaleWhileRevalidate: config.StaleWhileRevalidate},
+		now:          time.Now,
+		stats:        make(map[string]*SourceCacheStats),
+		revalidating: make(map[string]bool),
+	}
+	if memory, ok := store.(*MemoryCacheStore); ok {
+		cache.capacity = memory.capacity
+	}
+	return cache
+}
+
+// Policy returns the caching policy of a source
+func (c *EvidenceCache) Policy(source string) CachePolicy {
+	if policy, ok := c.policies[source]; ok {
+		return policy
+	}
+	return c.fallback
+}
+
+// cachedFetch returns the cached response under key if it is fresh, or stale
+// within the source's stale window, in which case it is refreshed in the
+// background. Otherwise it fetches and caches the response. A nil cache
+// always fetches.
+func cachedFetch[T any](ctx context.Context, c *EvidenceCache, source, key string, fetch func(context.Context) (*T, error)) (*T, error) {
+	if c == nil {
+		return fetch(ctx)
+	}
+
+	policy := c.Policy(source)
+	if raw, found, err := c.store.Get(ctx, key); err != nil {
+		c.recordError()
+	} else if found {
+		var cached cacheEnvelope[T]
+		if err := json.Unmarshal(raw, &cached); err != nil {
+			// Drop entries that no longer decode, e.g. after a type change
+			_ = c.store.Delete(ctx, key)
+		} else {
+			age := c.now().Sub(cached.FetchedAt)
+			if age < policy.TTL {
+				c.record(source, func(s *SourceCacheStats) { s.Hits++ })
+				metrics.ObserveCache("evidence", metrics.CacheHit)
+				trace.SpanFromContext(ctx).SetAttributes(attribute.String("cache.result", metrics.CacheHit))
+				return cached.Data, nil
+			}
+			if age < policy.TTL+policy.StaleWhileRevalidate {
+				c.record(source, func(s *SourceCacheStats) { s.StaleHits++ })
+				metrics.ObserveCache("evidence", metrics.CacheStale)
+				trace.SpanFromContext(ctx).SetAttributes(attribute.String("cache.result", metrics.CacheStale))
+				c.revalidate(ctx, source, key, func(ctx context.Context) error {
+					data, err := fetch(ctx)
+					if err != nil {
+						return err
+					}
+					return cacheStore(ctx, c, policy, key, data)
+				})
+				return cached.Data, nil
+			}
+		}
+	}
+
+	c.record(source, func(s *SourceCacheStats) { s.Misses++ })
+	metrics.ObserveCache("evidence", metrics.CacheMiss)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("cache.result", metrics.CacheMiss))
+	data, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := cacheStore(ctx, c, policy, key, data); err != nil {
+		c.recordError()
+	}
+	return data, nil
+}
+
+// cacheStore stores a response, keeping it until its stale window ends
+func cacheStore[T any](ctx context.Context, c *EvidenceCache, policy CachePolicy, key string, data *T) error {
+	raw, err := json.Marshal(cacheEnvelope[T]{FetchedAt: c.now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	return c.store.Set(ctx, key, raw, policy.TTL+policy.StaleWhileRevalidate)
+}
+
+// revalidate runs refresh in the background unless a refresh of key is
+// already running. The refresh outlives the request that triggered it.
+func (c *EvidenceCache) revalidate(ctx context.Context, source, key string, refresh func(context.Context) error) {
+	c.mu.Lock()
+	if c.revalidating[key] {
+		c.mu.Unlock()
+		return
+	}
+	c.revalidating[key] = true
+	c.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), revalidationTimeout)
+		defer cancel()
+
+		err := refresh(ctx)
+		c.record(source, func(s *SourceCacheStats) {
+			if err != nil {
+				s.RevalidationErrors++
+			} else {
+				s.Revalidations++
+			}
+		})
+
+		c.mu.Lock()
+		delete(c.revalidating, key)
+		c.mu.Unlock()
+	}()
+}
+
+// record updates the counters of a source
+func (c *EvidenceCache) record(source string, update func(*SourceCacheStats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats, ok := c.stats[source]
+	if !ok {
+		stats = &SourceCacheStats{}
+		c.stats[source] = stats
+	}
+	update(stats)
+}
+
+// recordError counts a store failure
+func (c *EvidenceCache) recordError() {
+	c.mu.Lock()
+	c.errors++
+	c.mu.Unlock()
+}
+
+// Invalidate removes every source's cached response for a variant
+func (c *EvidenceCache) Invalidate(ctx context.Context, variant *domain.StandardizedVariant) error {
+	keys := make([]string, 0, len(c.policies))
+	for source := range c.policies {
+		keys = append(keys, variantCacheKey(source, variant))
+	}
+	sort.Strings(keys)
+	return c.store.Delete(ctx, keys...)
+}
+
+// Stats returns the cache's size and per-source counters. Every source with
+// a policy is listed, including those not yet queried.
+func (c *EvidenceCache) Stats(ctx context.Context) CacheStats {
+	stats := CacheStats{
+		Backend:  c.store.Backend(),
+		Capacity: c.capacity,
+		Sources:  make(map[string]*SourceCacheStats),
+	}
+	if entries, err := c.store.Len(ctx); err == nil {
+		stats.Entries = entries
+	} else {
+		c.recordError()
+	}
+	if memory, ok := c.store.(*MemoryCacheStore); ok {
+		memoryStats := memory.Stats(ctx)
+		stats.Memory = &memoryStats
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats.Errors = c.errors
+	sources := make(map[string]bool)
+	for source := range c.policies {
+		sources[source] = true
+	}
+	for source := range c.stats {
+		sources[source] = true
+	}
+	for source := range sources {
+		entry := SourceCacheStats{}
+		if counted, ok := c.stats[source]; ok {
+			entry = *counted
+		}
+		policy := c.Policy(source)
+		entry.TTL = policy.TTL.String()
+		entry.StaleWhileRevalidate = policy.StaleWhileRevalidate.String()
+		if lookups := entry.Hits + entry.StaleHits + entry.Misses; lookups > 0 {
+			entry.HitRatio = float64(entry.Hits+entry.StaleHits) / float64(lookups)
+		}
+		stats.Sources[source] = &entry
+	}
+	return stats
+}
+
+// Ping checks the cache store is reachable
+func (c *EvidenceCache) Ping(ctx context.Context) error {
+	return c.store.Ping(ctx)
+}
+
+// Close closes the cache store
+func (c *EvidenceCache) Close() error {
+	return c.store.Close()
+}
+
+// geneCacheKey creates the cache key of a source's response for a gene
+func geneCacheKey(source, gene string) string {
+	return fmt.Sprintf("evidence:%s:gene:%s", source, strings.ToUpper(gene))
+}
+
+// variantCacheKey creates the cache key of a source's response for a variant
+func variantCacheKey(source string, variant *domain.StandardizedVariant) string {
+	data := fmt.Sprintf("%s:%d:%s:%s:%s:%s:%s",
+		variant.Chromosome, variant.Position, variant.Reference, variant.Alternative,
+		variant.HGVSGenomic, variant.HGVSCoding, variant.HGVSProtein)
+
+	hash := sha256.Sum256([]byte(data))
+	return fmt.Sprintf("evidence:%s:%x", source, hash[:8])
+}
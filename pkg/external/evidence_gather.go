@@ -0,0 +1,166 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/internal/metrics"
+)
+
+// Evidence sources without a cache of their own, as named in source
+// statuses and deadline overrides
+const (
+	EvidenceSourceSplicing   = "splicing"
+	EvidenceSourcePredictors = "predictors"
+	EvidenceSourceResidues   = "residues"
+	EvidenceSourceConstraint = "constraint"
+)
+
+// DefaultSourceDeadline is how long evidence gathering waits on a source
+// without a deadline of its own
+const DefaultSourceDeadline = 20 * time.Second
+
+// sourceDeadlines bounds how long evidence gathering waits on each source
+type sourceDeadlines struct {
+	fallback time.Duration            // DefaultSourceDeadline if not positive
+	sources  map[string]time.Duration // Keyed by source name
+}
+
+// of returns the deadline of a source
+func (d sourceDeadlines) of(source string) time.Duration {
+	if deadline, ok := d.sources[source]; ok && deadline > 0 {
+		return deadline
+	}
+	if d.fallback > 0 {
+		return d.fallback
+	}
+	return DefaultSourceDeadline
+}
+
+// evidenceSource is one source queried while gathering evidence. Its query
+// returns a function adding what it found to the evidence, which may come
+// with an error when the source returned partial results.
+type evidenceSource struct {
+	name     string
+	category string
+	core     bool // One of the databases gathering fails without
+	query    func(ctx context.Context) (func(*domain.AggregatedEvidence), error)
+}
+
+// sourceOutcome is what a source returned within its deadline
+type sourceOutcome struct {
+	index  int
+	apply  func(*domain.AggregatedEvidence)
+	status domain.SourceStatus
+}
+
+// gatherEvidence queries all sources concurrently, each within its own
+// deadline, and returns the evidence that arrived in time. Sources that
+// failed or missed their deadline are recorded with their categories
+// marked incomplete, so a provisional classification can still be made.
+// It fails only when cancelled or when every core database failed, in
+// which case the evidence from the other sources is returned with the
+// error.
+func gatherEvidence(ctx context.Context, sources []evidenceSource, deadlines sourceDeadlines) (*domain.AggregatedEvidence, error) {
+	evidence := &domain.AggregatedEvidence{GatheredAt: time.Now()}
+
+	outcomes := make(chan sourceOutcome, len(sources))
+	for i, source := range sources {
+		go func(i int, source evidenceSource) {
+			outcome := querySource(ctx, source, deadlines.of(source.name))
+			outcome.index = i
+			outcomes <- outcome
+		}(i, source)
+	}
+
+	// Findings are applied here, one source at a time; a query still
+	// running past its deadline never touches the evidence
+	statuses := make([]domain.SourceStatus, len(sources))
+	for range sources {
+		outcome := <-outcomes
+		if outcome.apply != nil {
+			outcome.apply(evidence)
+		}
+		statuses[outcome.index] = outcome.status
+	}
+
+	// Sources that returned before a cancellation leave the evidence
+	// incomplete, so none of it is returned
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	evidence.Sources = statuses
+	incomplete := make(map[string]bool)
+	var coreFailures []string
+	cores := 0
+	for i, status := range statuses {
+		if sources[i].core {
+			cores++
+		}
+		if status.Status == domain.SourceStatusOK {
+			continue
+		}
+		if !incomplete[status.Category] {
+			incomplete[status.Category] = true
+			evidence.IncompleteCategories = append(evidence.IncompleteCategories, status.Category)
+		}
+		if sources[i].core {
+			coreFailures = append(coreFailures, fmt.Sprintf("%s=%s", status.Source, status.Error))
+		}
+	}
+	sort.Strings(evidence.IncompleteCategories)
+
+	if cores > 0 && len(coreFailures) == cores {
+		return evidence, fmt.Errorf("all external database queries failed: %s", strings.Join(coreFailures, ", "))
+	}
+	return evidence, nil
+}
+
+// querySource runs a source's query within its deadline. A query still
+// running at the deadline is abandoned and reported as timed out.
+func querySource(ctx context.Context, source evidenceSource, deadline time.Duration) sourceOutcome {
+	start := time.Now()
+	queryCtx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	type result struct {
+		apply func(*domain.AggregatedEvidence)
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		apply, err := source.query(queryCtx)
+		done <- result{apply: apply, err: err}
+	}()
+
+	outcome := sourceOutcome{status: domain.SourceStatus{
+		Source:   source.name,
+		Category: source.category,
+		Status:   domain.SourceStatusOK,
+	}}
+	var err error
+	select {
+	case r := <-done:
+		outcome.apply, err = r.apply, r.err
+	case <-queryCtx.Done():
+		err = queryCtx.Err()
+	}
+	outcome.status.DurationMS = time.Since(start).Milliseconds()
+	switch {
+	case err == nil:
+	case ctx.Err() == nil && errors.Is(queryCtx.Err(), context.DeadlineExceeded):
+		outcome.status.Status = domain.SourceStatusTimeout
+		outcome.status.Error = fmt.Sprintf("no response within %s", deadline)
+		metrics.ObserveEvidenceSourceTimeout(source.name)
+	default:
+		outcome.status.Status = domain.SourceStatusFailed
+		outcome.status.Error = err.Error()
+	}
+	return outcome
+}
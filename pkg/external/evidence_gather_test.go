@@ -0,0 +1,101 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestGatherEvidence_PartialResults(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	sources := []evidenceSource{
+		{name: CacheSourceClinVar, category: domain.EvidenceCategoryClinical, core: true,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				data := &domain.ClinVarData{ClinicalSignificance: "Pathogenic"}
+				return func(e *domain.AggregatedEvidence) { e.ClinVarData = data }, nil
+			}},
+		// A slow source that ignores its deadline is not waited on
+		{name: CacheSourceGnomAD, category: domain.EvidenceCategoryPopulation, core: true,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				<-release
+				data := &domain.PopulationData{AlleleFrequency: 0.5}
+				return func(e *domain.AggregatedEvidence) { e.PopulationData = data }, nil
+			}},
+		{name: CacheSourceCOSMIC, category: domain.EvidenceCategorySomatic, core: true,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				return nil, errors.New("COSMIC unavailable")
+			}},
+		// Partial results are kept alongside the error
+		{name: EvidenceSourceSplicing, category: domain.EvidenceCategoryComputational,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				return func(e *domain.AggregatedEvidence) {
+					e.SplicingPredictions = []domain.SplicingPrediction{{Tool: "SpliceAI", Score: 0.02}}
+				}, errors.New("Pangolin unavailable")
+			}},
+	}
+
+	start := time.Now()
+	evidence, err := gatherEvidence(context.Background(), sources, sourceDeadlines{
+		fallback: time.Minute,
+		sources:  map[string]time.Duration{CacheSourceGnomAD: 20 * time.Millisecond},
+	})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+
+	assert.Equal(t, "Pathogenic", evidence.ClinVarData.ClinicalSignificance)
+	assert.Nil(t, evidence.PopulationData)
+	assert.Len(t, evidence.SplicingPredictions, 1)
+	assert.True(t, evidence.Incomplete())
+	assert.Equal(t, []string{domain.EvidenceCategoryComputational, domain.EvidenceCategoryPopulation, domain.EvidenceCategorySomatic}, evidence.IncompleteCategories)
+
+	require.Len(t, evidence.Sources, 4)
+	statuses := make(map[string]domain.SourceStatus)
+	for _, status := range evidence.Sources {
+		statuses[status.Source] = status
+	}
+	assert.Equal(t, domain.SourceStatusOK, statuses[CacheSourceClinVar].Status)
+	assert.Equal(t, domain.SourceStatusTimeout, statuses[CacheSourceGnomAD].Status)
+	assert.Contains(t, statuses[CacheSourceGnomAD].Error, "20ms")
+	assert.Equal(t, domain.SourceStatusFailed, statuses[CacheSourceCOSMIC].Status)
+	assert.Equal(t, "COSMIC unavailable", statuses[CacheSourceCOSMIC].Error)
+	assert.Equal(t, domain.SourceStatusFailed, statuses[EvidenceSourceSplicing].Status)
+}
+
+func TestGatherEvidence_Failures(t *testing.T) {
+	failing := func(name, category string, core bool) evidenceSource {
+		return evidenceSource{name: name, category: category, core: core,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				return nil, errors.New(name + " unavailable")
+			}}
+	}
+	constraint := evidenceSource{name: EvidenceSourceConstraint, category: domain.EvidenceCategoryGene,
+		query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+			return func(e *domain.AggregatedEvidence) { e.GeneConstraint = &domain.GeneConstraint{Gene: "BRCA1"} }, nil
+		}}
+
+	// With every core database down, the other sources' evidence comes
+	// back with the error
+	evidence, err := gatherEvidence(context.Background(), []evidenceSource{
+		failing(CacheSourceClinVar, domain.EvidenceCategoryClinical, true),
+		failing(CacheSourceGnomAD, domain.EvidenceCategoryPopulation, true),
+		constraint,
+	}, sourceDeadlines{})
+	assert.ErrorContains(t, err, "all external database queries failed: clinvar=clinvar unavailable, gnomad=gnomad unavailable")
+	require.NotNil(t, evidence)
+	assert.Equal(t, "BRCA1", evidence.GeneConstraint.Gene)
+
+	// A cancelled gathering returns nothing
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	evidence, err = gatherEvidence(ctx, []evidenceSource{constraint}, sourceDeadlines{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, evidence)
+}
@@ -1,11 +1,20 @@
 package external
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"database/sql"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -128,6 +137,65 @@ func TestClinVarClient_QueryVariant(t *testing.T) {
 	}
 }
 
+func TestClinVarResidueLookup_QueryResidueVariants(t *testing.T) {
+	var searchTerm string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		if strings.HasSuffix(r.URL.Path, "esearch.fcgi") {
+			searchTerm = r.URL.Query().Get("term")
+			fmt.Fprint(w, `<eSearchResult><Count>5</Count><IdList><Id>1</Id><Id>2</Id><Id>3</Id><Id>4</Id><Id>5</Id></IdList></eSearchResult>`)
+			return
+		}
+		fmt.Fprint(w, `<eSummaryResult>
+	<DocumentSummary uid="1">
+		<title>NM_007294.4(BRCA1):c.5096G&gt;A (p.Arg1699Gln)</title>
+		<germline_classification><description>Pathogenic</description><review_status>reviewed by expert panel</review_status></germline_classification>
+	</DocumentSummary>
+	<DocumentSummary uid="2">
+		<title>NM_007294.4(BRCA1):c.5095C&gt;T (p.Arg1699Trp)</title>
+		<germline_classification><description>Pathogenic/Likely pathogenic</description><review_status>criteria provided, multiple submitters, no conflicts</review_status></germline_classification>
+	</DocumentSummary>
+	<DocumentSummary uid="3">
+		<title>NM_007294.4(BRCA1):c.5097A&gt;G (p.Arg1699=)</title>
+		<germline_classification><description>Likely benign</description><review_status>reviewed by expert panel</review_status></germline_classification>
+	</DocumentSummary>
+	<DocumentSummary uid="4">
+		<title>NM_007294.4(BRCA1):c.5095C&gt;A (p.Arg1699Leu)</title>
+		<germline_classification><description>Pathogenic</description><review_status>criteria provided, single submitter</review_status></germline_classification>
+	</DocumentSummary>
+	<DocumentSummary uid="5">
+		<title>NM_007294.4(BRCA1):c.5096G&gt;T (p.Arg1699Leu)</title>
+		<germline_classification><description>Conflicting classifications of pathogenicity</description><review_status>criteria provided, conflicting classifications</review_status></germline_classification>
+	</DocumentSummary>
+</eSummaryResult>`)
+	}))
+	defer server.Close()
+
+	lookup := NewClinVarResidueLookup(domain.ClinVarConfig{BaseURL: server.URL + "/", Timeout: 5 * time.Second, RateLimit: 100}, 0)
+
+	// c.5095C>T itself is the queried variant, so only other nucleotide changes match
+	variant := &domain.StandardizedVariant{GeneSymbol: "BRCA1", HGVSCoding: "NM_007294.4:c.5095C>T", HGVSProtein: "p.Arg1699Trp"}
+	matches, err := lookup.QueryResidueVariants(context.Background(), variant)
+	require.NoError(t, err)
+	assert.Equal(t, "BRCA1[gene] AND R1699*[Protein change]", searchTerm)
+	require.Len(t, matches, 1, "benign, single-star and conflicting records are excluded")
+	assert.Equal(t, "1", matches[0].VariationID)
+	assert.Equal(t, "R1699Q", matches[0].ProteinChange)
+	assert.Equal(t, 3, matches[0].Stars)
+	assert.False(t, matches[0].SameChange)
+
+	variant = &domain.StandardizedVariant{GeneSymbol: "BRCA1", HGVSCoding: "NM_007294.4:c.5096G>A", HGVSProtein: "p.Arg1699Gln"}
+	matches, err = lookup.QueryResidueVariants(context.Background(), variant)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "R1699W", matches[0].ProteinChange)
+
+	// Non-missense variants are not looked up
+	matches, err = lookup.QueryResidueVariants(context.Background(), &domain.StandardizedVariant{GeneSymbol: "BRCA1", HGVSProtein: "p.Arg1699Ter"})
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
 func TestGnomADClient_QueryVariant(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -153,6 +221,7 @@ func TestGnomADClient_QueryVariant(t *testing.T) {
 							AN  int     `json:"an"`
 							AF  float64 `json:"af"`
 							Hom int     `json:"hom"`
+							ACHemi int  `json:"ac_hemi"`
 							Populations []struct {
 								ID string  `json:"id"`
 								AC int     `json:"ac"`
@@ -170,6 +239,7 @@ func TestGnomADClient_QueryVariant(t *testing.T) {
 							AN  int     `json:"an"`
 							AF  float64 `json:"af"`
 							Hom int     `json:"hom"`
+							ACHemi int  `json:"ac_hemi"`
 							Populations []struct {
 								ID string  `json:"id"`
 								AC int     `json:"ac"`
@@ -191,6 +261,7 @@ func TestGnomADClient_QueryVariant(t *testing.T) {
 							AN  int     `json:"an"`
 							AF  float64 `json:"af"`
 							Hom int     `json:"hom"`
+							ACHemi int  `json:"ac_hemi"`
 							Populations []struct {
 								ID string  `json:"id"`
 								AC int     `json:"ac"`
@@ -208,6 +279,7 @@ func TestGnomADClient_QueryVariant(t *testing.T) {
 							AN  int     `json:"an"`
 							AF  float64 `json:"af"`
 							Hom int     `json:"hom"`
+							ACHemi int  `json:"ac_hemi"`
 							Populations []struct {
 								ID string  `json:"id"`
 								AC int     `json:"ac"`
@@ -227,6 +299,7 @@ func TestGnomADClient_QueryVariant(t *testing.T) {
 							AN  int     `json:"an"`
 							AF  float64 `json:"af"`
 							Hom int     `json:"hom"`
+							ACHemi int  `json:"ac_hemi"`
 							Populations []struct {
 								ID string  `json:"id"`
 								AC int     `json:"ac"`
@@ -317,6 +390,111 @@ func TestGnomADClient_QueryVariant(t *testing.T) {
 	}
 }
 
+func TestGnomADV4Client_QueryVariant(t *testing.T) {
+	variant := &domain.StandardizedVariant{
+		Chromosome:  "chr17",
+		Position:    43104121,
+		Reference:   "G",
+		Alternative: "A",
+	}
+
+	tests := []struct {
+		name         string
+		mockResponse string
+		expectedData *domain.PopulationData
+		expectError  bool
+	}{
+		{
+			name: "joint frequencies with filtering allele frequencies",
+			mockResponse: `{"data": {"variant": {"variant_id": "17-43104121-G-A", "joint": {
+				"ac": 30, "an": 1000000, "homozygote_count": 1, "hemizygote_count": 4, "filters": [],
+				"populations": [
+					{"id": "afr", "ac": 20, "an": 100000, "homozygote_count": 1},
+					{"id": "afr_XX", "ac": 12, "an": 50000, "homozygote_count": 1},
+					{"id": "nfe", "ac": 10, "an": 900000, "homozygote_count": 0},
+					{"id": "XX", "ac": 15, "an": 500000, "homozygote_count": 0}
+				],
+				"faf95": {"popmax": 0.00013, "popmax_population": "afr"},
+				"faf99": {"popmax": 0.0001, "popmax_population": "afr"}}}}}`,
+			expectedData: &domain.PopulationData{
+				AlleleFrequency: 0.00003,
+				AlleleCount:     30,
+				AlleleNumber:    1000000,
+				HomozygoteCount: 1,
+				HemizygoteCount: 4,
+				PopulationFrequencies: map[string]float64{
+					"afr": 0.0002,
+					"nfe": 10.0 / 900000,
+				},
+				QualityMetrics: &domain.QualityMetrics{FilterPass: true},
+				FAF95:          0.00013,
+				FAF99:          0.0001,
+				FAFPopulation:  "afr",
+				Dataset:        GnomADDatasetV4,
+			},
+		},
+		{
+			name:         "variant absent from gnomAD",
+			mockResponse: `{"data": {"variant": null}, "errors": [{"message": "Variant not found"}]}`,
+			expectedData: &domain.PopulationData{Dataset: GnomADDatasetV4},
+		},
+		{
+			name:         "API error",
+			mockResponse: `{"data": {"variant": null}, "errors": [{"message": "Unknown dataset"}]}`,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request struct {
+				Variables map[string]string `json:"variables"`
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewDecoder(r.Body).Decode(&request)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, tt.mockResponse)
+			}))
+			defer server.Close()
+
+			client := NewPopulationFrequencyClient(domain.GnomADConfig{
+				BaseURL:   server.URL,
+				Timeout:   5 * time.Second,
+				RateLimit: 100,
+			})
+			require.IsType(t, &GnomADV4Client{}, client)
+
+			result, err := client.QueryVariant(context.Background(), variant)
+
+			assert.Equal(t, "17-43104121-G-A", request.Variables["variantId"])
+			assert.Equal(t, GnomADDatasetV4, request.Variables["dataset"])
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expectedData.AlleleFrequency, result.AlleleFrequency, 1e-12)
+			assert.Equal(t, tt.expectedData.AlleleCount, result.AlleleCount)
+			assert.Equal(t, tt.expectedData.FAF95, result.FAF95)
+			assert.Equal(t, tt.expectedData.FAF99, result.FAF99)
+			assert.Equal(t, tt.expectedData.FAFPopulation, result.FAFPopulation)
+			assert.Equal(t, tt.expectedData.Dataset, result.Dataset)
+			if tt.expectedData.PopulationFrequencies != nil {
+				assert.Len(t, result.PopulationFrequencies, len(tt.expectedData.PopulationFrequencies))
+				for pop, af := range tt.expectedData.PopulationFrequencies {
+					assert.InDelta(t, af, result.PopulationFrequencies[pop], 1e-12)
+				}
+				assert.Equal(t, tt.expectedData.QualityMetrics, result.QualityMetrics)
+			}
+		})
+	}
+}
+
+func TestNewPopulationFrequencyClient_V3(t *testing.T) {
+	client := NewPopulationFrequencyClient(domain.GnomADConfig{Dataset: GnomADDatasetV3, RateLimit: 10})
+	assert.IsType(t, &GnomADClient{}, client)
+}
+
 func TestCOSMICClient_QueryVariant(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -446,6 +624,7 @@ func TestKnowledgeBaseService_GatherEvidence(t *testing.T) {
 						AN  int     `json:"an"`
 						AF  float64 `json:"af"`
 						Hom int     `json:"hom"`
+						ACHemi int  `json:"ac_hemi"`
 						Populations []struct {
 							ID string  `json:"id"`
 							AC int     `json:"ac"`
@@ -463,6 +642,7 @@ func TestKnowledgeBaseService_GatherEvidence(t *testing.T) {
 						AN  int     `json:"an"`
 						AF  float64 `json:"af"`
 						Hom int     `json:"hom"`
+						ACHemi int  `json:"ac_hemi"`
 						Populations []struct {
 							ID string  `json:"id"`
 							AC int     `json:"ac"`
@@ -591,4 +771,1299 @@ func TestKnowledgeBaseService_GatherEvidence(t *testing.T) {
 	assert.NotNil(t, evidence.PopulationData)
 	assert.NotNil(t, evidence.SomaticData)
 	assert.Equal(t, "Pathogenic", evidence.ClinVarData.ClinicalSignificance)
-}
\ No newline at end of file
+}
+func TestSplicingLookupClient_QueryVariant(t *testing.T) {
+	var lastQuery map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastQuery = map[string]string{"path": r.URL.Path, "variant": r.URL.Query().Get("variant"), "hg": r.URL.Query().Get("hg")}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Query().Get("variant") == "chr1-1-A-G":
+			fmt.Fprint(w, `{"variant": "chr1-1-A-G", "error": "Unable to retrieve reference sequence"}`)
+		case r.URL.Path == "/spliceai/":
+			fmt.Fprint(w, `{"variant": "chr7-117548754-G-A", "scores": [
+				{"g_name": "CFTR", "t_id": "ENST00000003084", "DS_AG": "0.00", "DS_AL": "0.01", "DS_DG": "0.02", "DS_DL": "0.91", "DP_AG": "-12", "DP_AL": "4", "DP_DG": "1", "DP_DL": "2"},
+				{"g_name": "CFTR", "t_id": "ENST00000426809", "DS_AG": "0.00", "DS_AL": "0.00", "DS_DG": "0.00", "DS_DL": "0.12", "DP_AG": "0", "DP_AL": "0", "DP_DG": "0", "DP_DL": "2"}
+			]}`)
+		default:
+			fmt.Fprint(w, `{"variant": "chr7-117548754-G-A", "scores": [
+				{"g_name": "CFTR", "DS_SG": 0.05, "DS_SL": -0.74, "DP_SG": 10, "DP_SL": 2}
+			]}`)
+		}
+	}))
+	defer server.Close()
+
+	config := domain.SplicingConfig{BaseURL: server.URL, Timeout: 5 * time.Second, RateLimit: 1000}
+	variant := &domain.StandardizedVariant{Chromosome: "chr7", Position: 117548754, Reference: "G", Alternative: "A"}
+
+	predictions, err := NewSpliceAIClient(config).QueryVariant(context.Background(), variant)
+	require.NoError(t, err)
+	require.Len(t, predictions, 1)
+	assert.Equal(t, "/spliceai/", lastQuery["path"])
+	assert.Equal(t, "chr7-117548754-G-A", lastQuery["variant"])
+	assert.Equal(t, "38", lastQuery["hg"])
+	assert.Equal(t, SplicingToolSpliceAI, predictions[0].Tool)
+	assert.Equal(t, "CFTR", predictions[0].Gene)
+	assert.Equal(t, 0.91, predictions[0].Score)
+	assert.Equal(t, domain.SpliceEventDonorLoss, predictions[0].Event)
+	assert.Equal(t, 2, predictions[0].Position)
+	assert.Len(t, predictions[0].Scores, 4)
+
+	predictions, err = NewPangolinClient(config).QueryVariant(context.Background(), variant)
+	require.NoError(t, err)
+	require.Len(t, predictions, 1)
+	assert.Equal(t, "/pangolin/", lastQuery["path"])
+	assert.Equal(t, 0.74, predictions[0].Score)
+	assert.Equal(t, domain.SpliceEventLoss, predictions[0].Event)
+
+	_, err = NewSpliceAIClient(config).QueryVariant(context.Background(), &domain.StandardizedVariant{Chromosome: "1", Position: 1, Reference: "A", Alternative: "G"})
+	assert.ErrorContains(t, err, "Unable to retrieve reference sequence")
+}
+
+func TestLoadSplicingScores(t *testing.T) {
+	dir := t.TempDir()
+	variant := &domain.StandardizedVariant{Chromosome: "chr7", Position: 117548754, Reference: "G", Alternative: "A"}
+
+	t.Run("VCF with SpliceAI and Pangolin annotations", func(t *testing.T) {
+		path := filepath.Join(dir, "scores.vcf.gz")
+		file, err := os.Create(path)
+		require.NoError(t, err)
+		gz := gzip.NewWriter(file)
+		fmt.Fprint(gz, "##fileformat=VCFv4.2\n"+
+			"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\n"+
+			"chr7\t117548754\t.\tG\tA\t.\t.\tSpliceAI=A|CFTR|0.00|0.01|0.02|0.91|-12|4|1|2;Pangolin=ENSG00000001626.16|10:0.05|2:-0.74|Warnings:\n"+
+			"7\t117559593\t.\tC\tT,G\t.\t.\tSpliceAI=T|CFTR|0.00|0.00|0.01|0.00|3|-7|1|-2,G|CFTR|0.03|0.00|0.00|0.00|3|-7|1|-2\n")
+		require.NoError(t, gz.Close())
+		require.NoError(t, file.Close())
+
+		scores, err := LoadSplicingScores(path)
+		require.NoError(t, err)
+		assert.Equal(t, 3, scores.Len())
+
+		predictions, err := scores.QueryVariant(context.Background(), variant)
+		require.NoError(t, err)
+		require.Len(t, predictions, 2)
+		assert.Equal(t, SplicingToolSpliceAI, predictions[0].Tool)
+		assert.Equal(t, 0.91, predictions[0].Score)
+		assert.Equal(t, "scores_file", predictions[0].Source)
+		assert.Equal(t, SplicingToolPangolin, predictions[1].Tool)
+		assert.Equal(t, 0.74, predictions[1].Score)
+
+		predictions, _ = scores.QueryVariant(context.Background(), &domain.StandardizedVariant{Chromosome: "7", Position: 117559593, Reference: "C", Alternative: "G"})
+		require.Len(t, predictions, 1)
+		assert.Equal(t, domain.SpliceEventAcceptorGain, predictions[0].Event)
+	})
+
+	t.Run("TSV", func(t *testing.T) {
+		path := filepath.Join(dir, "scores.tsv")
+		require.NoError(t, os.WriteFile(path, []byte(
+			"#CHROM\tPOS\tREF\tALT\tSYMBOL\tDS_AG\tDS_AL\tDS_DG\tDS_DL\n"+
+				"7\t117548754\tG\tA\tCFTR\t0.00\t0.01\t0.02\t0.91\n"), 0o644))
+
+		scores, err := LoadSplicingScores(path)
+		require.NoError(t, err)
+		predictions, _ := scores.QueryVariant(context.Background(), variant)
+		require.Len(t, predictions, 1)
+		assert.Equal(t, "CFTR", predictions[0].Gene)
+		assert.Equal(t, 0.91, predictions[0].Score)
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		_, err := LoadSplicingScores(filepath.Join(dir, "absent.vcf"))
+		assert.Error(t, err)
+
+		path := filepath.Join(dir, "scores.bed")
+		require.NoError(t, os.WriteFile(path, []byte("7\t1\t2\n"), 0o644))
+		_, err = LoadSplicingScores(path)
+		assert.Error(t, err)
+
+		path = filepath.Join(dir, "bad.tsv")
+		require.NoError(t, os.WriteFile(path, []byte("CHROM\tPOS\tDS_AG\n7\t1\t0.5\n"), 0o644))
+		_, err = LoadSplicingScores(path)
+		assert.ErrorContains(t, err, "REF")
+	})
+}
+
+func TestSplicingPredictor_QueryVariant(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/pangolin/" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"scores": [{"g_name": "CFTR", "DS_AG": "0.00", "DS_AL": "0.00", "DS_DG": "0.00", "DS_DL": "0.03"}]}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "scores.tsv")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"CHROM\tPOS\tREF\tALT\tTOOL\tDS_SG\tDS_SL\n"+
+			"7\t117548754\tG\tA\tPangolin\t0.01\t-0.02\n"), 0o644))
+
+	_, err := NewSplicingPredictor(domain.SplicingConfig{Tools: []string{"MMSplice"}})
+	assert.Error(t, err)
+
+	predictor, err := NewSplicingPredictor(domain.SplicingConfig{BaseURL: server.URL, ScoresFile: path, RateLimit: 1000, Timeout: 5 * time.Second})
+	require.NoError(t, err)
+
+	// Pangolin comes from the scores file; only SpliceAI is queried remotely
+	predictions, err := predictor.QueryVariant(context.Background(), &domain.StandardizedVariant{Chromosome: "7", Position: 117548754, Reference: "G", Alternative: "A"})
+	require.NoError(t, err)
+	assert.Len(t, predictions, 2)
+	assert.Equal(t, 1, requests)
+
+	// A remote failure is reported alongside the predictions that succeeded
+	predictions, err = predictor.QueryVariant(context.Background(), &domain.StandardizedVariant{Chromosome: "7", Position: 117548755, Reference: "G", Alternative: "A"})
+	assert.Error(t, err)
+	require.Len(t, predictions, 1)
+	assert.Equal(t, SplicingToolSpliceAI, predictions[0].Tool)
+
+	// HGVS-only input has no coordinates to score
+	predictions, err = predictor.QueryVariant(context.Background(), &domain.StandardizedVariant{HGVSCoding: "NM_000492.4:c.1585-1G>A"})
+	assert.NoError(t, err)
+	assert.Empty(t, predictions)
+}
+
+const dbNSFPTestData = "#chr\tpos(1-based)\tref\talt\tgenename\tSIFT_score\tPolyphen2_HVAR_score\tCADD_phred\tREVEL_score\tAlphaMissense_score\tBayesDel_noAF_score\tGERP++_RS\tphyloP100way_vertebrate\n" +
+	"1\t1000\tA\tG\tGENE1\t0.30\t0.10\t12.1\t0.120\t0.08\t-0.52\t1.2\t0.5\n" +
+	"17\t43045712\tC\tT\tBRCA1\t0.01;0.20\t0.999;.\t29.4\t0.912\t0.97;0.95\t0.41\t5.3\t7.9\n" +
+	"17\t43045712\tC\tA\tBRCA1\t.\t.\t24.0\t.\t.\t.\t5.3\t7.9\n"
+
+func writeDbNSFPFile(t *testing.T, dir string) string {
+	t.Helper()
+	path := filepath.Join(dir, "dbNSFP_variant.chr.gz")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	gz := gzip.NewWriter(file)
+	_, err = gz.Write([]byte(dbNSFPTestData))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, file.Close())
+	return path
+}
+
+// writeTabixIndex indexes a single-block test file holding data with one
+// bin per sequence
+func writeTabixIndex(t *testing.T, path, data string) {
+	t.Helper()
+	type span struct {
+		name       string
+		begin, end uint64
+	}
+	var spans []span
+	offset := uint64(0)
+	for _, line := range strings.SplitAfter(data, "\n") {
+		if line == "" {
+			continue
+		}
+		next := offset + uint64(len(line))
+		if line[0] != '#' {
+			name := strings.SplitN(line, "\t", 2)[0]
+			if len(spans) == 0 || spans[len(spans)-1].name != name {
+				spans = append(spans, span{name: name, begin: offset})
+			}
+			spans[len(spans)-1].end = next
+		}
+		offset = next
+	}
+
+	var names bytes.Buffer
+	for _, s := range spans {
+		names.WriteString(s.name + "\x00")
+	}
+	var index bytes.Buffer
+	index.WriteString("TBI\x01")
+	write := func(v interface{}) { require.NoError(t, binary.Write(&index, binary.LittleEndian, v)) }
+	write([]int32{int32(len(spans)), 0, 1, 2, 2, '#', 0, int32(names.Len())})
+	index.Write(names.Bytes())
+	for _, s := range spans {
+		write([]int32{1})               // bins
+		write([]uint32{0})              // bin covering the whole sequence
+		write([]int32{1})               // chunks
+		write([]uint64{s.begin, s.end}) // virtual offsets within the only block
+		write([]int32{0})               // no linear index
+	}
+
+	file, err := os.Create(path + ".tbi")
+	require.NoError(t, err)
+	gz := gzip.NewWriter(file)
+	_, err = gz.Write(index.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, file.Close())
+}
+
+func assertDbNSFPLookups(t *testing.T, annotator *DbNSFPAnnotator) {
+	t.Helper()
+	ctx := context.Background()
+
+	data, err := annotator.QueryVariant(ctx, &domain.StandardizedVariant{Chromosome: "chr17", Position: 43045712, Reference: "C", Alternative: "T"})
+	require.NoError(t, err)
+	require.NotNil(t, data)
+	assert.Equal(t, DbNSFPSource, data.Source)
+	assert.Equal(t, 0.01, data.SIFTScore, "lowest SIFT across transcripts")
+	assert.Equal(t, 0.999, data.PolyPhenScore)
+	assert.Equal(t, 29.4, data.CADDScore)
+	assert.Equal(t, 0.912, data.REVELScore)
+	assert.Equal(t, 0.97, data.AlphaMissenseScore, "highest AlphaMissense across transcripts")
+	assert.Equal(t, 0.41, data.BayesDelScore)
+	assert.True(t, data.Has(domain.PredictorREVEL))
+
+	partial, err := annotator.QueryVariant(ctx, &domain.StandardizedVariant{Chromosome: "17", Position: 43045712, Reference: "C", Alternative: "A"})
+	require.NoError(t, err)
+	require.NotNil(t, partial)
+	assert.Equal(t, []string{domain.PredictorCADD, domain.PredictorGERP, domain.PredictorPhyloP}, partial.ScoredBy)
+	assert.False(t, partial.Has(domain.PredictorSIFT), "missing scores are not reported as zero")
+
+	for _, variant := range []*domain.StandardizedVariant{
+		{Chromosome: "17", Position: 43045712, Reference: "C", Alternative: "G"},
+		{Chromosome: "17", Position: 43045800, Reference: "C", Alternative: "T"},
+		{Chromosome: "X", Position: 1000, Reference: "A", Alternative: "G"},
+		{HGVSCoding: "c.5266dupC"},
+	} {
+		data, err := annotator.QueryVariant(ctx, variant)
+		require.NoError(t, err)
+		assert.Nil(t, data)
+	}
+}
+
+func TestDbNSFPAnnotator_SQLiteImport(t *testing.T) {
+	dir := t.TempDir()
+	source := writeDbNSFPFile(t, dir)
+	dbPath := filepath.Join(dir, "dbnsfp.db")
+
+	n, err := ImportDbNSFP(context.Background(), dbPath, []string{source}, DbNSFPImportOptions{Genes: []string{"brca1"}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n, "only the requested genes are imported")
+
+	annotator, err := NewDbNSFPAnnotator(dbPath)
+	require.NoError(t, err)
+	defer annotator.Close()
+	assertDbNSFPLookups(t, annotator)
+
+	data, err := annotator.QueryVariant(context.Background(), &domain.StandardizedVariant{Chromosome: "1", Position: 1000, Reference: "A", Alternative: "G"})
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestDbNSFPAnnotator_Tabix(t *testing.T) {
+	source := writeDbNSFPFile(t, t.TempDir())
+	writeTabixIndex(t, source, dbNSFPTestData)
+
+	annotator, err := NewDbNSFPAnnotator(source)
+	require.NoError(t, err)
+	defer annotator.Close()
+	assertDbNSFPLookups(t, annotator)
+
+	data, err := annotator.QueryVariant(context.Background(), &domain.StandardizedVariant{Chromosome: "1", Position: 1000, Reference: "A", Alternative: "G"})
+	require.NoError(t, err)
+	require.NotNil(t, data)
+	assert.Equal(t, 0.12, data.REVELScore)
+
+	_, err = NewDbNSFPAnnotator(filepath.Join(t.TempDir(), "scores.tsv"))
+	assert.Error(t, err)
+}
+
+func TestImportDbNSFP_Version(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "dbNSFP4.9a_variant.chr17.gz")
+	require.NoError(t, os.Rename(writeDbNSFPFile(t, dir), source))
+	dbPath := filepath.Join(dir, "dbnsfp.db")
+
+	_, err := ImportDbNSFP(context.Background(), dbPath, []string{source}, DbNSFPImportOptions{})
+	require.NoError(t, err)
+	annotator, err := NewDbNSFPAnnotator(dbPath)
+	require.NoError(t, err)
+	assert.Equal(t, "4.9a", annotator.Version(), "the build is read from the file name")
+	require.NoError(t, annotator.Close())
+
+	_, err = ImportDbNSFP(context.Background(), dbPath, []string{source}, DbNSFPImportOptions{Version: "4.8a"})
+	assert.ErrorContains(t, err, "holds dbNSFP 4.9a", "a database holds one build")
+
+	assert.Equal(t, "4.7c", DbNSFPVersionOf("/data/dbNSFP4.7c_variant.chrX.gz"))
+	assert.Equal(t, "5.1", DbNSFPVersionOf("dbnsfp_v5.1.tsv.gz"))
+	assert.Empty(t, DbNSFPVersionOf("scores.tsv.gz"))
+}
+
+func TestImportDbNSFP_MigratesSchema(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "dbnsfp.db")
+
+	// An import made before BayesDel was supported
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE dbnsfp_scores (chrom TEXT NOT NULL, pos INTEGER NOT NULL, ref TEXT NOT NULL, alt TEXT NOT NULL, gene TEXT DEFAULT '',
+		sift REAL, polyphen REAL, cadd REAL, revel REAL, alphamissense REAL, gerp REAL, phylop REAL, PRIMARY KEY (chrom, pos, ref, alt)) WITHOUT ROWID`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO dbnsfp_scores (chrom, pos, ref, alt, gene, cadd, revel) VALUES ('17', 43045712, 'C', 'T', 'BRCA1', 29.4, 0.912)`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	annotator, err := NewDbNSFPAnnotator(dbPath)
+	require.NoError(t, err, "older imports remain readable")
+	data, err := annotator.QueryVariant(context.Background(), &domain.StandardizedVariant{Chromosome: "17", Position: 43045712, Reference: "C", Alternative: "T"})
+	require.NoError(t, err)
+	require.NotNil(t, data)
+	assert.Equal(t, []string{domain.PredictorCADD, domain.PredictorREVEL}, data.ScoredBy)
+	require.NoError(t, annotator.Close())
+
+	_, err = ImportDbNSFP(context.Background(), dbPath, []string{writeDbNSFPFile(t, dir)}, DbNSFPImportOptions{})
+	require.NoError(t, err, "importing adds the missing columns")
+	annotator, err = NewDbNSFPAnnotator(dbPath)
+	require.NoError(t, err)
+	defer annotator.Close()
+	assertDbNSFPLookups(t, annotator)
+}
+
+func TestTabixBins(t *testing.T) {
+	assert.Equal(t, []uint32{0, 1, 9, 73, 585, 4681}, tabixBins(0, 1))
+	assert.Equal(t, []uint32{0, 1, 9, 73, 585 + 1, 4681 + 8}, tabixBins(1<<17, 1<<17+1))
+}
+
+func TestParsePubMedNotices(t *testing.T) {
+	body := []byte(`<?xml version="1.0"?>
+<PubmedArticleSet>
+  <PubmedArticle>
+    <MedlineCitation>
+      <PMID Version="1">20301425</PMID>
+      <Article><PublicationTypeList><PublicationType UI="D016428">Journal Article</PublicationType><PublicationType UI="D016441">Retracted Publication</PublicationType></PublicationTypeList></Article>
+      <CommentsCorrectionsList>
+        <CommentsCorrections RefType="RetractionIn"><RefSource>Hum Mutat. 2019;40(2):250</RefSource><PMID Version="1">31000001</PMID></CommentsCorrections>
+        <CommentsCorrections RefType="CommentIn"><RefSource>Hum Mutat. 2018</RefSource><PMID Version="1">30000003</PMID></CommentsCorrections>
+      </CommentsCorrectionsList>
+    </MedlineCitation>
+  </PubmedArticle>
+  <PubmedArticle>
+    <MedlineCitation>
+      <PMID Version="1">15920490</PMID>
+      <CommentsCorrectionsList>
+        <CommentsCorrections RefType="ErratumIn"><RefSource>Nat Genet. 2005;37(8):900</RefSource></CommentsCorrections>
+      </CommentsCorrectionsList>
+    </MedlineCitation>
+  </PubmedArticle>
+  <PubmedArticle>
+    <MedlineCitation>
+      <PMID Version="1">12345678</PMID>
+      <Article><PublicationTypeList><PublicationType>Retracted Publication</PublicationType></PublicationTypeList></Article>
+    </MedlineCitation>
+  </PubmedArticle>
+  <PubmedArticle>
+    <MedlineCitation><PMID Version="1">11111111</PMID></MedlineCitation>
+  </PubmedArticle>
+</PubmedArticleSet>`)
+
+	notices := make(map[string][]domain.CitationNotice)
+	require.NoError(t, parsePubMedNotices(body, notices))
+
+	assert.Equal(t, []domain.CitationNotice{{Kind: domain.NoticeRetraction, NoticePMID: "31000001", Source: "Hum Mutat. 2019;40(2):250"}}, notices["20301425"])
+	assert.Equal(t, []domain.CitationNotice{{Kind: domain.NoticeErratum, Source: "Nat Genet. 2005;37(8):900"}}, notices["15920490"])
+	assert.Equal(t, []domain.CitationNotice{{Kind: domain.NoticeRetraction}}, notices["12345678"], "retracted publication type without a linked notice")
+	assert.NotContains(t, notices, "11111111")
+}
+
+func TestIdentifierClient_ResolveIdentifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/esearch.fcgi"):
+			assert.Equal(t, "RCV000019241", query.Get("term"))
+			fmt.Fprint(w, `{"esearchresult":{"count":"1","idlist":["17661"]}}`)
+		case query.Get("db") == "snp" && query.Get("id") == "80357906":
+			fmt.Fprint(w, `{"result":{"uids":["80357906"],"80357906":{"uid":"80357906","chr":"17",
+				"spdi":"NC_000017.11:43057062:G:,NC_000017.11:43057062:G:GG","genes":[{"name":"BRCA1","gene_id":"672"}]}}}`)
+		case query.Get("db") == "snp" && query.Get("id") == "28897696":
+			fmt.Fprint(w, `{"result":{"uids":["28897696"],"28897696":{"uid":"28897696","chr":"17",
+				"spdi":"NC_000017.11:43094463:C:A","genes":[{"name":"BRCA1","gene_id":"672"}]}}}`)
+		case query.Get("db") == "clinvar" && query.Get("id") == "17661":
+			fmt.Fprint(w, `{"result":{"uids":["17661"],"17661":{"uid":"17661","accession":"VCV000017661",
+				"variation_set":[{"variation_name":"NM_007294.4(BRCA1):c.5266dup (p.Gln1756fs)",
+				"canonical_spdi":"NC_000017.11:43057062:GGGGG:GGGGGG",
+				"variation_loc":[{"status":"current","assembly_name":"GRCh38","chr":"17","start":"43057063"},
+				{"status":"previous","assembly_name":"GRCh37","chr":"17","start":"41209080"}]}],
+				"genes":[{"symbol":"BRCA1","geneid":"672"}]}}}`)
+		default:
+			fmt.Fprint(w, `{"result":{"uids":[]}}`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewIdentifierClient(domain.ClinVarConfig{BaseURL: server.URL, RateLimit: 1000, Timeout: 5 * time.Second})
+	ctx := context.Background()
+
+	rsid, err := client.ResolveIdentifier(ctx, domain.IdentifierRSID, "rs80357906")
+	require.NoError(t, err)
+	assert.Equal(t, "dbSNP", rsid.Source)
+	require.Len(t, rsid.Variants, 2, "one variant per allele")
+	assert.Equal(t, "NC_000017.11:g.43057063del", rsid.Variants[0].HGVSGenomic)
+	assert.Equal(t, "NC_000017.11:g.43057063_43057064insG", rsid.Variants[1].HGVSGenomic)
+	assert.Equal(t, "BRCA1", rsid.Variants[0].GeneSymbol)
+
+	snv, err := client.ResolveIdentifier(ctx, domain.IdentifierRSID, "rs28897696")
+	require.NoError(t, err)
+	require.Len(t, snv.Variants, 1)
+	assert.Equal(t, "NC_000017.11:g.43094464C>A", snv.Variants[0].HGVSGenomic)
+	assert.Equal(t, int64(43094464), snv.Variants[0].Position)
+
+	for _, tc := range []struct {
+		kind       domain.IdentifierKind
+		identifier string
+	}{
+		{domain.IdentifierVCV, "VCV000017661"},
+		{domain.IdentifierRCV, "RCV000019241"},
+	} {
+		mapping, err := client.ResolveIdentifier(ctx, tc.kind, tc.identifier)
+		require.NoError(t, err, tc.identifier)
+		require.Len(t, mapping.Variants, 1)
+		variant := mapping.Variants[0]
+		assert.Equal(t, "NM_007294.4:c.5266dup", variant.HGVSCoding)
+		assert.Equal(t, "NM_007294.4", variant.TranscriptID)
+		assert.Equal(t, "p.Gln1756fs", variant.HGVSProtein)
+		assert.Equal(t, "NC_000017.11:g.43057067_43057068insG", variant.HGVSGenomic)
+		assert.Equal(t, "BRCA1", variant.GeneSymbol)
+		assert.Equal(t, int64(43057063), variant.Position)
+	}
+
+	_, err = client.ResolveIdentifier(ctx, domain.IdentifierRSID, "rs1")
+	assert.Error(t, err)
+}
+
+func TestOncoKBClient_QuerySomaticEvidence(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "/annotate/mutations/byProteinChange", r.URL.Path)
+		assert.Equal(t, "BRAF", r.URL.Query().Get("hugoSymbol"))
+		assert.Equal(t, "V600E", r.URL.Query().Get("alteration"))
+		assert.Equal(t, "Melanoma", r.URL.Query().Get("tumorType"))
+		fmt.Fprint(w, `{
+			"treatments": [
+				{"level": "LEVEL_1", "drugs": [{"drugName": "Dabrafenib"}, {"drugName": "Trametinib"}],
+				 "levelAssociatedCancerType": {"name": "Melanoma", "mainType": {"name": "Melanoma"}}, "pmids": ["25265492"]},
+				{"level": "LEVEL_R2", "drugs": [{"drugName": "Cetuximab"}],
+				 "levelAssociatedCancerType": {"name": "", "mainType": {"name": "Colorectal Cancer"}}},
+				{"level": "LEVEL_Fda2", "drugs": []}
+			],
+			"diagnosticImplications": [{"levelOfEvidence": "LEVEL_Dx2", "tumorType": {"name": "Hairy Cell Leukemia"}}],
+			"prognosticImplications": []
+		}`)
+	}))
+	defer server.Close()
+
+	client := NewOncoKBClient(domain.OncoKBConfig{BaseURL: server.URL, Token: "test-token", RateLimit: 1000, Timeout: 5 * time.Second})
+	evidence, err := client.QuerySomaticEvidence(context.Background(), &domain.StandardizedVariant{
+		GeneSymbol: "BRAF", HGVSProtein: "p.Val600Glu",
+	}, "Melanoma")
+
+	require.NoError(t, err)
+	require.Len(t, evidence, 3, "unmapped levels are skipped")
+	assert.Equal(t, domain.LEVEL_A, evidence[0].Level)
+	assert.Equal(t, []string{"Dabrafenib", "Trametinib"}, evidence[0].Therapies)
+	assert.Equal(t, "Melanoma", evidence[0].TumorType)
+	assert.Equal(t, "resistance", evidence[1].Significance)
+	assert.Equal(t, domain.LEVEL_C, evidence[1].Level)
+	assert.Equal(t, "Colorectal Cancer", evidence[1].TumorType)
+	assert.Equal(t, domain.SomaticDiagnostic, evidence[2].Type)
+	assert.Equal(t, domain.LEVEL_B, evidence[2].Level)
+
+	_, err = client.QuerySomaticEvidence(context.Background(), &domain.StandardizedVariant{}, "")
+	assert.Error(t, err, "nothing to annotate by")
+}
+
+func TestCIViCClient_QuerySomaticEvidence(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Variables map[string]string `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "EGFR L858R", request.Variables["molecularProfile"])
+		fmt.Fprint(w, `{"data": {"evidenceItems": {"nodes": [
+			{"id": 1, "evidenceLevel": "A", "evidenceType": "PREDICTIVE", "evidenceDirection": "SUPPORTS",
+			 "significance": "SENSITIVITYRESPONSE", "disease": {"name": "Lung Non-small Cell Carcinoma"},
+			 "therapies": [{"name": "Osimertinib"}], "source": {"citationId": "28885881", "sourceType": "PUBMED"}},
+			{"id": 2, "evidenceLevel": "B", "evidenceType": "PROGNOSTIC", "evidenceDirection": "SUPPORTS",
+			 "significance": "BETTER_OUTCOME", "disease": {"name": "Lung Adenocarcinoma"}, "therapies": []},
+			{"id": 3, "evidenceLevel": "C", "evidenceType": "PREDICTIVE", "evidenceDirection": "DOES_NOT_SUPPORT"},
+			{"id": 4, "evidenceLevel": "E", "evidenceType": "PREDICTIVE", "evidenceDirection": "SUPPORTS"},
+			{"id": 5, "evidenceLevel": "B", "evidenceType": "PREDISPOSING", "evidenceDirection": "SUPPORTS"}
+		]}}}`)
+	}))
+	defer server.Close()
+
+	client := NewCIViCClient(domain.CIViCConfig{BaseURL: server.URL, RateLimit: 1000, Timeout: 5 * time.Second})
+	evidence, err := client.QuerySomaticEvidence(context.Background(), &domain.StandardizedVariant{
+		GeneSymbol: "EGFR", HGVSProtein: "p.(Leu858Arg)",
+	}, "")
+
+	require.NoError(t, err)
+	require.Len(t, evidence, 2)
+	assert.Equal(t, domain.SomaticEvidence{
+		Source: "CIViC", SourceLevel: "A", Level: domain.LEVEL_A, Type: domain.SomaticTherapeutic,
+		Significance: "sensitivityresponse", TumorType: "Lung Non-small Cell Carcinoma",
+		Therapies: []string{"Osimertinib"}, References: []string{"28885881"},
+	}, evidence[0])
+	assert.Equal(t, "better outcome", evidence[1].Significance)
+
+	none, err := client.QuerySomaticEvidence(context.Background(), &domain.StandardizedVariant{GeneSymbol: "EGFR"}, "")
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestClinGenClient_QueryGeneCuration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Variables map[string]string `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		if request.Variables["symbol"] != "BRCA1" {
+			fmt.Fprint(w, `{"data": {"genes": {"gene_list": []}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": {"genes": {"gene_list": [
+			{"label": "BRCA1P1", "hgnc_id": "HGNC:28470", "dosage_curation": null, "genetic_conditions": []},
+			{"label": "BRCA1", "hgnc_id": "HGNC:1100",
+			 "dosage_curation": {
+				"haploinsufficiency_assertion": {"dosage_classification": {"ordinal": 3}},
+				"triplosensitivity_assertion": {"dosage_classification": {"ordinal": 1}}},
+			 "genetic_conditions": [
+				{"disease": {"label": "Fanconi anemia", "curie": "MONDO:0019391"},
+				 "gene_validity_assertions": [{"classification": {"label": "limited evidence"},
+					"mode_of_inheritance": {"label": "Autosomal recessive inheritance"}, "report_date": "2019-03-01"}]},
+				{"disease": {"label": "hereditary breast ovarian cancer syndrome", "curie": "MONDO:0003582"},
+				 "gene_validity_assertions": [{"classification": {"label": "definitive evidence"},
+					"mode_of_inheritance": {"label": "Autosomal dominant inheritance"},
+					"attributed_to": {"label": "Hereditary Breast, Ovarian and Pancreatic Cancer GCEP"}, "report_date": "2023-05-18"}]}
+			 ]}
+		]}}}`)
+	}))
+	defer server.Close()
+
+	client := NewClinGenClient(domain.ClinGenConfig{BaseURL: server.URL, RateLimit: 1000, Timeout: 5 * time.Second})
+	curation, err := client.QueryGeneCuration(context.Background(), "BRCA1")
+	require.NoError(t, err)
+	assert.Equal(t, "HGNC:1100", curation.HGNCID, "only the exact symbol match is used")
+	assert.Equal(t, domain.DosageSufficientEvidence, curation.Haploinsufficiency)
+	assert.Equal(t, domain.DosageLittleEvidence, curation.Triplosensitivity)
+	require.Len(t, curation.Validity, 2)
+	assert.Equal(t, domain.GeneDiseaseValidity{
+		Disease: "hereditary breast ovarian cancer syndrome", DiseaseID: "MONDO:0003582", Classification: "Definitive",
+		ModeOfInheritance: "Autosomal dominant inheritance", ExpertPanel: "Hereditary Breast, Ovarian and Pancreatic Cancer GCEP", ReportDate: "2023-05-18",
+	}, curation.Validity[0], "most recent first")
+	assert.Equal(t, "Limited", curation.Validity[1].Classification)
+	assert.Len(t, curation.SupportedDiseases(), 1)
+	established, curated := curation.LossOfFunctionMechanism()
+	assert.True(t, established)
+	assert.True(t, curated)
+
+	uncurated, err := client.QueryGeneCuration(context.Background(), "FAKE1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DosageNotCurated, uncurated.Haploinsufficiency)
+	_, curated = uncurated.LossOfFunctionMechanism()
+	assert.False(t, curated)
+
+	_, err = client.QueryGeneCuration(context.Background(), " ")
+	assert.Error(t, err)
+
+	_, err = parseClinGenCuration([]byte(`{"errors": [{"message": "bad query"}]}`), "BRCA1")
+	assert.ErrorContains(t, err, "bad query")
+}
+
+func TestReadConstraintTable(t *testing.T) {
+	v4 := "gene\tgene_id\ttranscript\tcanonical\tmane_select\tlof.pLI\tlof.oe_ci.upper\tmis.z_score\n" +
+		"BRCA1\tENSG00000012048\tENST00000461574\tfalse\tfalse\t0.01\t1.20\t0.50\n" +
+		"BRCA1\tENSG00000012048\tENST00000357654\ttrue\ttrue\t0.00\t0.79\t1.46\n" +
+		"BRCA1\tENSG00000012048\tENST00000352993\ttrue\tfalse\t0.02\t0.95\t1.10\n" +
+		"SCN1A\tENSG00000144285\tENST00000674923\ttrue\ttrue\t1.00\t0.15\t6.02\n" +
+		"OR4F5\tENSG00000186092\tENST00000641515\ttrue\ttrue\tNA\tNA\t-0.33\n"
+	table, err := readConstraintTable(strings.NewReader(v4))
+	require.NoError(t, err)
+	assert.Equal(t, "gnomAD v4", table.Source())
+	assert.Equal(t, 3, table.Len())
+
+	brca1, err := table.QueryConstraint(context.Background(), "brca1")
+	require.NoError(t, err)
+	assert.Equal(t, "ENST00000357654", brca1.Transcript, "the MANE Select transcript is kept")
+	assert.InDelta(t, 0.79, *brca1.LOEUF, 1e-9)
+	assert.InDelta(t, 1.46, *brca1.MissenseZ, 1e-9)
+
+	olfactory, _ := table.QueryConstraint(context.Background(), "OR4F5")
+	assert.Nil(t, olfactory.PLI, "NA is missing, not zero")
+	assert.Nil(t, olfactory.LOEUF)
+	assert.InDelta(t, -0.33, *olfactory.MissenseZ, 1e-9)
+
+	missing, err := table.QueryConstraint(context.Background(), "FAKE1")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	v2 := "gene\ttranscript\tcanonical\tpLI\toe_lof_upper\tmis_z\n" +
+		"SCN1A\tENST00000303395\ttrue\t1.0000\t0.094\t5.61\n"
+	table, err = readConstraintTable(strings.NewReader(v2))
+	require.NoError(t, err)
+	assert.Equal(t, "gnomAD v2.1.1", table.Source())
+	scn1a, _ := table.QueryConstraint(context.Background(), "SCN1A")
+	assert.InDelta(t, 1.0, *scn1a.PLI, 1e-9)
+	assert.Equal(t, "gnomAD v2.1.1", scn1a.Source)
+
+	_, err = readConstraintTable(strings.NewReader("gene\tcanonical\nBRCA1\ttrue\n"))
+	assert.Error(t, err, "no metric columns")
+	_, err = readConstraintTable(strings.NewReader(v4 + "TP53\tENSG\tENST\ttrue\ttrue\thigh\t0.4\t3.2\n"))
+	assert.ErrorContains(t, err, "line 7")
+}
+
+// testClock is an adjustable clock for cache tests
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestEvidenceCache_StaleWhileRevalidate(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store, err := NewMemoryCacheStore(10)
+	require.NoError(t, err)
+	store.now = clock.Now
+	cache := NewEvidenceCache(store, domain.CacheConfig{})
+	cache.now = clock.Now
+
+	var fetches atomic.Int32
+	fetch := func(ctx context.Context) (*domain.ClinVarData, error) {
+		n := fetches.Add(1)
+		return &domain.ClinVarData{VariationID: fmt.Sprintf("v%d", n)}, nil
+	}
+	ctx := context.Background()
+	key := "evidence:clinvar:test"
+
+	// Miss: fetched synchronously
+	data, err := cachedFetch(ctx, cache, CacheSourceClinVar, key, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", data.VariationID)
+
+	// Fresh within the weekly ClinVar TTL
+	clock.Advance(6 * 24 * time.Hour)
+	data, err = cachedFetch(ctx, cache, CacheSourceClinVar, key, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", data.VariationID)
+	assert.Equal(t, int32(1), fetches.Load())
+
+	// Stale: served at once and refreshed in the background
+	clock.Advance(36 * time.Hour)
+	data, err = cachedFetch(ctx, cache, CacheSourceClinVar, key, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", data.VariationID)
+	assert.Eventually(t, func() bool {
+		return cache.Stats(ctx).Sources[CacheSourceClinVar].Revalidations == 1
+	}, time.Second, 10*time.Millisecond)
+
+	data, err = cachedFetch(ctx, cache, CacheSourceClinVar, key, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", data.VariationID, "the refreshed response is served")
+
+	// Past the stale window: fetched again
+	clock.Advance(9 * 24 * time.Hour)
+	data, err = cachedFetch(ctx, cache, CacheSourceClinVar, key, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "v3", data.VariationID)
+
+	stats := cache.Stats(ctx).Sources[CacheSourceClinVar]
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, int64(1), stats.StaleHits)
+	assert.Equal(t, int64(2), stats.Misses)
+	assert.Equal(t, 0.6, stats.HitRatio)
+	assert.Equal(t, "168h0m0s", stats.TTL)
+}
+
+func TestEvidenceCache_FetchErrorsAreNotCached(t *testing.T) {
+	store, err := NewMemoryCacheStore(10)
+	require.NoError(t, err)
+	cache := NewEvidenceCache(store, domain.CacheConfig{})
+
+	_, err = cachedFetch(context.Background(), cache, CacheSourceLOVD, "evidence:lovd:test", func(context.Context) (*domain.LOVDData, error) {
+		return nil, fmt.Errorf("LOVD query failed")
+	})
+	assert.Error(t, err)
+	entries, _ := store.Len(context.Background())
+	assert.Zero(t, entries)
+}
+
+func TestEvidenceCache_Policies(t *testing.T) {
+	store, err := NewMemoryCacheStore(0)
+	require.NoError(t, err)
+	cache := NewEvidenceCache(store, domain.CacheConfig{
+		DefaultTTL:           time.Hour,
+		SourceTTLs:           map[string]time.Duration{CacheSourceClinVar: 72 * time.Hour},
+		StaleWhileRevalidate: 6 * time.Hour,
+	})
+
+	assert.Equal(t, CachePolicy{TTL: 72 * time.Hour, StaleWhileRevalidate: 6 * time.Hour}, cache.Policy(CacheSourceClinVar))
+	assert.Equal(t, CachePolicy{TTL: 90 * 24 * time.Hour, StaleWhileRevalidate: 6 * time.Hour}, cache.Policy(CacheSourceGnomAD))
+	assert.Equal(t, CachePolicy{TTL: time.Hour, StaleWhileRevalidate: 6 * time.Hour}, cache.Policy("oncokb"))
+
+	stats := cache.Stats(context.Background())
+	assert.Equal(t, "memory", stats.Backend)
+	assert.Equal(t, defaultCacheMaxEntries, stats.Capacity)
+	assert.Len(t, stats.Sources, len(DefaultCachePolicies))
+}
+
+func TestEvidenceCache_Invalidate(t *testing.T) {
+	store, err := NewMemoryCacheStore(10)
+	require.NoError(t, err)
+	cache := NewEvidenceCache(store, domain.CacheConfig{})
+	ctx := context.Background()
+	variant := &domain.StandardizedVariant{Chromosome: "17", Position: 43104121, Reference: "G", Alternative: "A"}
+	other := &domain.StandardizedVariant{Chromosome: "13", Position: 32340300, Reference: "C", Alternative: "T"}
+
+	for _, v := range []*domain.StandardizedVariant{variant, other} {
+		for _, source := range []string{CacheSourceClinVar, CacheSourceGnomAD} {
+			_, err := cachedFetch(ctx, cache, source, variantCacheKey(source, v), func(context.Context) (*domain.ClinVarData, error) {
+				return &domain.ClinVarData{}, nil
+			})
+			require.NoError(t, err)
+		}
+	}
+	assert.NotEqual(t, variantCacheKey(CacheSourceClinVar, variant), variantCacheKey(CacheSourceGnomAD, variant))
+
+	require.NoError(t, cache.Invalidate(ctx, variant))
+	entries, _ := store.Len(ctx)
+	assert.Equal(t, 2, entries)
+	_, found, _ := store.Get(ctx, variantCacheKey(CacheSourceClinVar, other))
+	assert.True(t, found)
+}
+
+func TestMemoryCacheStore(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store, err := NewMemoryCacheStore(2)
+	require.NoError(t, err)
+	store.now = clock.Now
+	ctx := context.Background()
+
+	require.NoError(t, store.Set(ctx, "a", []byte("1"), time.Hour))
+	require.NoError(t, store.Set(ctx, "b", []byte("2"), 0))
+	_, found, _ := store.Get(ctx, "a") // a is now the most recently used
+	assert.True(t, found)
+	require.NoError(t, store.Set(ctx, "c", []byte("3"), time.Hour))
+
+	_, found, _ = store.Get(ctx, "b")
+	assert.False(t, found, "the least recently used entry is evicted")
+
+	clock.Advance(time.Hour)
+	_, found, _ = store.Get(ctx, "a")
+	assert.False(t, found, "expired entries are not returned")
+	entries, _ := store.Len(ctx)
+	assert.Equal(t, 1, entries)
+}
+
+func TestMemoryCacheStore_MaxBytes(t *testing.T) {
+	store, err := NewTieredCacheStore(MemoryCacheLimits{MaxEntries: 100, MaxBytes: 10}, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Set(ctx, "a", []byte("1234"), 0))
+	require.NoError(t, store.Set(ctx, "b", []byte("1234"), 0))
+	require.NoError(t, store.Set(ctx, "c", []byte("1234"), 0))
+	_, found, _ := store.Get(ctx, "a")
+	assert.False(t, found, "the oldest entry is evicted once the byte limit is exceeded")
+	_, found, _ = store.Get(ctx, "c")
+	assert.True(t, found)
+
+	require.NoError(t, store.Set(ctx, "big", []byte("0123456789"), 0))
+	_, found, _ = store.Get(ctx, "big")
+	assert.False(t, found, "a value over the limit on its own is not kept")
+
+	stats := store.Stats(ctx)
+	assert.LessOrEqual(t, stats.Bytes, int64(10))
+	assert.Equal(t, int64(4), stats.Evictions)
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(2), stats.Misses)
+}
+
+func TestTieredCacheStore_Spill(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	path := filepath.Join(t.TempDir(), "evidence_cache.db")
+	disk, err := NewSQLiteCacheStore(path)
+	require.NoError(t, err)
+	disk.now = clock.Now
+	store, err := NewTieredCacheStore(MemoryCacheLimits{MaxEntries: 2}, disk)
+	require.NoError(t, err)
+	store.now = clock.Now
+	ctx := context.Background()
+	assert.Equal(t, "memory+sqlite", store.Backend())
+
+	require.NoError(t, store.Set(ctx, "a", []byte("1"), time.Hour))
+	require.NoError(t, store.Set(ctx, "b", []byte("2"), time.Hour))
+	require.NoError(t, store.Set(ctx, "c", []byte("3"), time.Hour))
+	entries, _ := store.Len(ctx)
+	assert.Equal(t, 3, entries, "the evicted entry is kept on disk")
+
+	value, found, err := store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("1"), value, "a memory miss is served from disk")
+
+	stats := store.Stats(ctx)
+	assert.Equal(t, int64(2), stats.Evictions, "a, then b to make room for a")
+	assert.Equal(t, int64(2), stats.Spilled)
+	assert.Equal(t, int64(1), stats.DiskHits)
+	assert.Equal(t, 1, stats.DiskEntries)
+
+	// Entries keep their expiry on disk
+	clock.Advance(time.Hour)
+	_, found, _ = store.Get(ctx, "b")
+	assert.False(t, found)
+
+	require.NoError(t, store.Set(ctx, "d", []byte("4"), 0))
+	require.NoError(t, store.Delete(ctx, "a"))
+	require.NoError(t, store.Close())
+
+	// Entries in memory are written to disk on close and survive a restart
+	disk, err = NewSQLiteCacheStore(path)
+	require.NoError(t, err)
+	store, err = NewTieredCacheStore(MemoryCacheLimits{MaxEntries: 2}, disk)
+	require.NoError(t, err)
+	defer store.Close()
+	entries, _ = store.Len(ctx)
+	assert.Equal(t, 1, entries, "deleted and expired entries are gone")
+	value, found, err = store.Get(ctx, "d")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("4"), value)
+}
+
+func TestPubMedClient_QueryLiterature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/variant/autocomplete/"):
+			assert.Equal(t, "rs80357906", r.URL.Query().Get("query"))
+			fmt.Fprint(w, `[{"_id":"litvar@rs80357906##","rsid":"rs80357906","gene":["BRCA1"],"hgvs":"p.Q1756fs","pmids_count":2}]`)
+		case strings.HasSuffix(r.URL.Path, "/publications"):
+			assert.Contains(t, r.URL.Path, "/variant/get/litvar@rs80357906")
+			fmt.Fprint(w, `{"pmids":[20301425,15920490]}`)
+		case strings.HasSuffix(r.URL.Path, "/efetch.fcgi"):
+			assert.Equal(t, "20301425,15920490", r.URL.Query().Get("id"))
+			fmt.Fprint(w, `<?xml version="1.0"?>
+<PubmedArticleSet>
+  <PubmedArticle>
+    <MedlineCitation>
+      <PMID Version="1">20301425</PMID>
+      <Article>
+        <Journal><ISSN>1059-7794</ISSN><JournalIssue><PubDate><Year>2010</Year></PubDate></JournalIssue><Title>Human mutation</Title></Journal>
+        <ArticleTitle>Functional analysis of <i>BRCA1</i> c.5266dupC</ArticleTitle>
+        <Abstract>
+          <AbstractText Label="RESULTS">In vitro assays showed abolished transcriptional activity of the c.5266dupC variant. The variant is pathogenic.</AbstractText>
+        </Abstract>
+        <AuthorList><Author><LastName>Smith</LastName><ForeName>Jane</ForeName><Initials>J</Initials></Author></AuthorList>
+        <PublicationTypeList><PublicationType>Journal Article</PublicationType></PublicationTypeList>
+      </Article>
+    </MedlineCitation>
+    <PubmedData><ArticleIdList><ArticleId IdType="pubmed">20301425</ArticleId><ArticleId IdType="doi">10.1002/humu.21234</ArticleId></ArticleIdList></PubmedData>
+  </PubmedArticle>
+  <PubmedArticle>
+    <MedlineCitation>
+      <PMID Version="1">15920490</PMID>
+      <Article>
+        <Journal><JournalIssue><PubDate><MedlineDate>2005 Jul-Aug</MedlineDate></PubDate></JournalIssue><Title>Familial cancer</Title></Journal>
+        <ArticleTitle>A founder mutation in an Ashkenazi family</ArticleTitle>
+        <Abstract><AbstractText>We report a proband with early-onset breast cancer.</AbstractText></Abstract>
+        <PublicationTypeList><PublicationType>Case Reports</PublicationType></PublicationTypeList>
+      </Article>
+    </MedlineCitation>
+  </PubmedArticle>
+</PubmedArticleSet>`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewPubMedClient(PubMedConfig{BaseURL: server.URL, LitVarURL: server.URL, RateLimit: 1000, Timeout: 5 * time.Second})
+	variant := &domain.StandardizedVariant{ID: "rs80357906", GeneSymbol: "BRCA1", HGVSCoding: "NM_007294.4:c.5266dupC"}
+
+	literature, err := client.QueryLiterature(context.Background(), variant)
+	require.NoError(t, err)
+	assert.Equal(t, "rs80357906", literature.SearchQuery)
+	assert.Equal(t, 2, literature.TotalCitations)
+	require.Len(t, literature.Citations, 2)
+
+	functional := literature.Citations[0]
+	assert.Equal(t, "20301425", functional.PMID)
+	assert.Equal(t, "Functional analysis of BRCA1 c.5266dupC", functional.Title)
+	assert.Equal(t, []string{"Smith J"}, functional.Authors)
+	assert.Equal(t, "10.1002/humu.21234", functional.DOI)
+	assert.Equal(t, 2010, functional.Year)
+	assert.Equal(t, "high", functional.Relevance, "abstract names the variant")
+	assert.Equal(t, []string{domain.SignalFunctionalDamaging, domain.SignalReportedPathogenic}, functional.Signals)
+	assert.NotEmpty(t, functional.KeyFindings)
+
+	caseReport := literature.Citations[1]
+	assert.Equal(t, 2005, caseReport.Year)
+	assert.Equal(t, "case_report", caseReport.StudyType)
+	assert.Equal(t, []string{domain.SignalCaseReport}, caseReport.Signals)
+}
+
+func TestMineLiteratureSignals(t *testing.T) {
+	signals, _ := mineLiteratureSignals("", "Minigene assays showed normal splicing comparable to wild-type. The variant is likely benign.", nil)
+	assert.Equal(t, []string{domain.SignalFunctionalNormal}, signals)
+
+	signals, findings := mineLiteratureSignals("Genetic testing in a large cohort", "No functional data were available.", nil)
+	assert.Empty(t, signals)
+	assert.Empty(t, findings)
+}
+
+// clinVarVariantSummary is a variant_summary.txt excerpt with a GRCh37 row
+// that the GRCh38 import skips
+const clinVarVariantSummary = "#AlleleID\tType\tName\tGeneID\tGeneSymbol\tClinicalSignificance\tLastEvaluated\tRS# (dbSNP)\tRCVaccession\tPhenotypeList\tAssembly\tChromosome\tReviewStatus\tVariationID\tPositionVCF\tReferenceAlleleVCF\tAlternateAlleleVCF\n" +
+	"32123\tsingle nucleotide variant\tNM_007294.4(BRCA1):c.5096G>A (p.Arg1699Gln)\t672\tBRCA1\tPathogenic\tMar 14, 2023\t41293459\tRCV000048763|RCV000112456\tHereditary breast ovarian cancer syndrome|not provided\tGRCh38\t17\treviewed by expert panel\t37643\t43057063\tC\tT\n" +
+	"32123\tsingle nucleotide variant\tNM_007294.4(BRCA1):c.5096G>A (p.Arg1699Gln)\t672\tBRCA1\tPathogenic\tMar 14, 2023\t41293459\tRCV000048763\t-\tGRCh37\t17\treviewed by expert panel\t37643\t41209080\tC\tT\n" +
+	"32124\tsingle nucleotide variant\tNM_007294.4(BRCA1):c.5095C>T (p.Arg1699Trp)\t672\tBRCA1\tPathogenic/Likely pathogenic\t-\t55770810\tRCV000031207\tnot provided\tGRCh38\t17\tcriteria provided, multiple submitters, no conflicts\t37644\t43057064\tG\tA\n" +
+	"32125\tDeletion\tNM_007294.4(BRCA1):c.68_69del (p.Glu23fs)\t672\tBRCA1\tPathogenic\tJan 05, 2024\t80357914\tRCV000031201\tBreast-ovarian cancer, familial 1\tGRCh38\t17\tpractice guideline\t17662\t43124027\tACT\tA\n"
+
+func TestLocalClinVar(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "variant_summary.txt")
+	require.NoError(t, os.WriteFile(source, []byte(clinVarVariantSummary), 0644))
+	dbPath := filepath.Join(dir, "clinvar.db")
+
+	n, err := ImportClinVar(context.Background(), dbPath, []string{source}, ClinVarImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n, "GRCh37 rows are not imported")
+	_, err = os.Stat(dbPath + ".partial")
+	assert.True(t, os.IsNotExist(err))
+
+	local, err := OpenLocalClinVar(dbPath)
+	require.NoError(t, err)
+	defer local.Close()
+	require.NoError(t, local.Ping(context.Background()))
+	release := local.Release()
+	assert.Equal(t, "GRCh38", release.Assembly)
+	assert.Equal(t, []string{"variant_summary.txt"}, release.Sources)
+	assert.Equal(t, 3, release.Variants)
+	assert.Empty(t, release.Release, "variant_summary carries no release date")
+	assert.Equal(t, "imported "+release.ImportedAt.Format("2006-01-02"), release.Version())
+	ctx := context.Background()
+
+	for name, variant := range map[string]*domain.StandardizedVariant{
+		"position":  {Chromosome: "chr17", Position: 43057063, Reference: "C", Alternative: "T"},
+		"genomic":   {HGVSGenomic: "NC_000017.11:g.43057063C>T"},
+		"coding":    {HGVSCoding: "NM_007294.3:c.5096G>A"},
+		"preferred": {Chromosome: "17", Position: 43057063, Reference: "C", Alternative: "T", HGVSCoding: "NM_007294.4:c.5095C>T"},
+	} {
+		data, err := local.QueryVariant(ctx, variant)
+		require.NoError(t, err, name)
+		assert.Equal(t, "37643", data.VariationID, name)
+		assert.Equal(t, "Pathogenic", data.ClinicalSignificance, name)
+		assert.Equal(t, "reviewed by expert panel", data.ReviewStatus, name)
+		assert.Equal(t, []string{"Hereditary breast ovarian cancer syndrome", "not provided"}, data.Conditions, name)
+		assert.Equal(t, time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC), data.LastEvaluated, name)
+	}
+
+	data, err := local.QueryVariant(ctx, &domain.StandardizedVariant{HGVSCoding: "NM_007294.4:c.5097A>G"})
+	require.NoError(t, err)
+	assert.Empty(t, data.VariationID, "variants not in ClinVar have an empty record")
+
+	matches, err := local.QueryResidueVariants(ctx, &domain.StandardizedVariant{GeneSymbol: "BRCA1", HGVSCoding: "NM_007294.4:c.5095C>T", HGVSProtein: "p.Arg1699Trp"})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "37643", matches[0].VariationID)
+	assert.Equal(t, "R1699Q", matches[0].ProteinChange)
+	assert.Equal(t, 3, matches[0].Stars)
+
+	mapping, err := local.ResolveIdentifier(ctx, domain.IdentifierRSID, "rs80357914")
+	require.NoError(t, err)
+	assert.Equal(t, LocalClinVarSource, mapping.Source)
+	require.Len(t, mapping.Variants, 1)
+	assert.Equal(t, domain.StandardizedVariant{
+		Chromosome: "17", Position: 43124027, Reference: "ACT", Alternative: "A",
+		HGVSGenomic: "NC_000017.11:g.43124028_43124029del", HGVSCoding: "NM_007294.4:c.68_69del",
+		HGVSProtein: "p.Glu23fs", GeneSymbol: "BRCA1", TranscriptID: "NM_007294.4",
+	}, mapping.Variants[0])
+
+	mapping, err = local.ResolveIdentifier(ctx, domain.IdentifierVCV, "VCV000037644")
+	require.NoError(t, err)
+	assert.Equal(t, "NM_007294.4:c.5095C>T", mapping.Variants[0].HGVSCoding)
+	mapping, err = local.ResolveIdentifier(ctx, domain.IdentifierRCV, "RCV000112456")
+	require.NoError(t, err)
+	assert.Equal(t, "NM_007294.4:c.5096G>A", mapping.Variants[0].HGVSCoding)
+	_, err = local.ResolveIdentifier(ctx, domain.IdentifierRSID, "rs1")
+	assert.Error(t, err)
+}
+
+func TestImportClinVar_VCF(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "clinvar.vcf.gz")
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	fmt.Fprint(gz, "##fileformat=VCFv4.1\n##fileDate=20240501\n##reference=GRCh38\n#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\n"+
+		"17\t43057063\t37643\tC\tT\t.\t.\tALLELEID=32123;CLNDN=Hereditary_breast_ovarian_cancer_syndrome|not_provided;CLNHGVS=NC_000017.11:g.43057063C>T;CLNREVSTAT=reviewed_by_expert_panel;CLNSIG=Pathogenic;GENEINFO=BRCA1:672;RS=41293459\n")
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(source, buf.Bytes(), 0644))
+	dbPath := filepath.Join(dir, "clinvar.db")
+
+	n, err := ImportClinVar(context.Background(), dbPath, []string{source}, ClinVarImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	local, err := OpenLocalClinVar(dbPath)
+	require.NoError(t, err)
+	defer local.Close()
+	assert.Equal(t, "2024-05-01", local.Release().Version(), "the release date is read from the fileDate header")
+	data, err := local.QueryVariant(context.Background(), &domain.StandardizedVariant{Chromosome: "17", Position: 43057063, Reference: "C", Alternative: "T"})
+	require.NoError(t, err)
+	assert.Equal(t, "37643", data.VariationID)
+	assert.Equal(t, "reviewed by expert panel", data.ReviewStatus)
+	assert.Equal(t, []string{"Hereditary breast ovarian cancer syndrome", "not provided"}, data.Conditions)
+
+	_, err = ImportClinVar(context.Background(), filepath.Join(dir, "empty.db"), []string{filepath.Join(dir, "clinvar.db")}, ClinVarImportOptions{})
+	assert.Error(t, err, "files that are not ClinVar releases are rejected")
+	_, err = os.Stat(filepath.Join(dir, "empty.db"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+// gnomADSitesVCF has a joint v4 site, a filtered site and a site outside the
+// imported region
+const gnomADSitesVCF = "##fileformat=VCFv4.2\n" +
+	"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\n" +
+	"chr17\t43045712\trs80357906\tC\tT\t.\tPASS\tAC_joint=30;AN_joint=1500000;nhomalt_joint=1;AC_joint_nfe=25;AN_joint_nfe=1000000;AC_joint_afr=5;AN_joint_afr=100000;AC_joint_XX=12;AN_joint_XX=700000;fafmax_faf95_max_joint=0.0000201;fafmax_faf95_max_gen_anc_joint=afr;fafmax_faf99_max_joint=0.0000150\n" +
+	"chr17\t43045712\t.\tC\tG\t.\tAC0\tAC_joint=0;AN_joint=1400000;nhomalt_joint=0\n" +
+	"chr17\t50000000\t.\tA\tG\t.\tPASS\tAC_joint=1;AN_joint=1500000;nhomalt_joint=0\n"
+
+func assertGnomADLookups(t *testing.T, annotator *GnomADAnnotator) {
+	t.Helper()
+	ctx := context.Background()
+
+	data, err := annotator.QueryVariant(ctx, &domain.StandardizedVariant{Chromosome: "17", Position: 43045712, Reference: "C", Alternative: "T"})
+	require.NoError(t, err)
+	assert.Equal(t, GnomADDatasetV4, data.Dataset)
+	assert.Equal(t, 30, data.AlleleCount)
+	assert.Equal(t, 1500000, data.AlleleNumber)
+	assert.Equal(t, 1, data.HomozygoteCount)
+	assert.InDelta(t, 0.00002, data.AlleleFrequency, 1e-9)
+	assert.Equal(t, map[string]float64{"nfe": 0.000025, "afr": 0.00005}, data.PopulationFrequencies)
+	assert.Equal(t, 0.0000201, data.FAF95)
+	assert.Equal(t, "afr", data.FAFPopulation)
+	assert.Equal(t, 0.0000150, data.FAF99)
+	assert.True(t, data.QualityMetrics.FilterPass)
+
+	filtered, err := annotator.QueryVariant(ctx, &domain.StandardizedVariant{Chromosome: "chr17", Position: 43045712, Reference: "C", Alternative: "G"})
+	require.NoError(t, err)
+	assert.False(t, filtered.QualityMetrics.FilterPass)
+
+	absent, err := annotator.QueryVariant(ctx, &domain.StandardizedVariant{Chromosome: "17", Position: 43045712, Reference: "C", Alternative: "A"})
+	require.NoError(t, err)
+	assert.Equal(t, GnomADDatasetV4, absent.Dataset)
+	assert.Zero(t, absent.AlleleCount)
+
+	_, err = annotator.QueryVariant(ctx, &domain.StandardizedVariant{HGVSCoding: "NM_007294.4:c.5266dupC"})
+	assert.Error(t, err)
+}
+
+func TestGnomADAnnotator_SQLiteImport(t *testing.T) {
+	dir := t.TempDir()
+	sites := filepath.Join(dir, "gnomad.joint.sites.vcf")
+	require.NoError(t, os.WriteFile(sites, []byte(gnomADSitesVCF), 0644))
+	coverage := filepath.Join(dir, "gnomad.exomes.coverage.summary.tsv")
+	require.NoError(t, os.WriteFile(coverage, []byte("locus\tmean\tmedian_approx\ttotal_DP\nchr17:43045712\t41.6\t41\t30000\nchr17:43045800\t38.2\t38\t28000\nchr1:1000\t12.0\t12\t8000\n"), 0644))
+	dbPath := filepath.Join(dir, "gnomad.db")
+
+	region, err := ParseGenomicRegion("chr17:43,044,295-43,170,245")
+	require.NoError(t, err)
+	result, err := ImportGnomAD(context.Background(), dbPath, []string{sites, coverage}, GnomADImportOptions{Regions: []GenomicRegion{region}})
+	require.NoError(t, err)
+	assert.Equal(t, &GnomADImportResult{Variants: 2, Positions: 2}, result, "sites and positions outside the region are skipped")
+
+	annotator, err := NewGnomADAnnotator(dbPath, "")
+	require.NoError(t, err)
+	defer annotator.Close()
+	require.NoError(t, annotator.Ping(context.Background()))
+	assert.Equal(t, GnomADDatasetV4, annotator.Dataset())
+	assertGnomADLookups(t, annotator)
+
+	data, err := annotator.QueryVariant(context.Background(), &domain.StandardizedVariant{Chromosome: "17", Position: 43045800, Reference: "G", Alternative: "A"})
+	require.NoError(t, err)
+	assert.Zero(t, data.AlleleCount)
+	assert.Equal(t, 38, data.QualityMetrics.Coverage, "absent variants report the coverage at their position")
+
+	_, err = ImportGnomAD(context.Background(), dbPath, []string{sites}, GnomADImportOptions{Dataset: GnomADDatasetV3})
+	assert.Error(t, err, "a database holds one dataset")
+}
+
+func TestGnomADAnnotator_Tabix(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "gnomad.joint.sites.chr17.vcf.bgz")
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(gnomADSitesVCF))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(source, buf.Bytes(), 0644))
+	writeTabixIndex(t, source, gnomADSitesVCF)
+
+	annotator, err := NewGnomADAnnotator(source, GnomADDatasetV4)
+	require.NoError(t, err)
+	defer annotator.Close()
+	assertGnomADLookups(t, annotator)
+}
+
+func TestParseGenomicRegion(t *testing.T) {
+	region, err := ParseGenomicRegion("chrX")
+	require.NoError(t, err)
+	assert.True(t, region.contains("X", 155000000))
+
+	for _, invalid := range []string{"", "17:100", "17:200-100", "17:a-b"} {
+		_, err := ParseGenomicRegion(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestVEPClient_Annotate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/vep/human/region/17:7674220-7674220:1/T", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("mane"))
+		assert.Equal(t, "1", r.URL.Query().Get("merged"))
+		assert.Equal(t, "1", r.URL.Query().Get("LoF"))
+		fmt.Fprint(w, `[{
+			"most_severe_consequence": "missense_variant",
+			"transcript_consequences": [
+				{"transcript_id": "ENST00000413465.6", "gene_symbol": "TP53", "biotype": "protein_coding",
+				 "consequence_terms": ["missense_variant", "splice_region_variant"], "hgvsc": "ENST00000413465.6:c.743G>A"},
+				{"transcript_id": "ENST00000504290.5", "gene_symbol": "TP53", "biotype": "nonsense_mediated_decay",
+				 "consequence_terms": ["3_prime_UTR_variant"]},
+				{"transcript_id": "ENST00000269305.9", "gene_symbol": "TP53", "biotype": "protein_coding",
+				 "consequence_terms": ["missense_variant"], "hgvsc": "ENST00000269305.9:c.743G>A",
+				 "hgvsp": "ENSP00000269305.4:p.Arg248Gln", "mane_select": "NM_000546.6"},
+				{"transcript_id": "ENST00000359597.8", "gene_symbol": "TP53", "biotype": "protein_coding",
+				 "consequence_terms": ["synonymous_variant"], "hgvsp": "ENSP00000352610.4:p.Arg248%3D"}
+			]
+		}]`)
+	}))
+	defer server.Close()
+
+	client := NewVEPClient(domain.VEPConfig{BaseURL: server.URL, Transcripts: "merged", Plugins: []string{"LoF"}, RateLimit: 1000})
+	consequences, err := client.Annotate(context.Background(), &domain.NormalizedVariant{
+		Chromosome: "chr17", Start: 7674220, End: 7674220, Reference: "C", Alternative: "T",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, consequences, 3, "non-coding transcripts are skipped")
+	assert.Equal(t, domain.TranscriptConsequence{
+		TranscriptID: "ENST00000269305.9", GeneSymbol: "TP53", HGVSCoding: "ENST00000269305.9:c.743G>A",
+		HGVSProtein: "p.Arg248Gln", Consequence: "missense_variant", ClinicallyRelevant: true, MANE: "MANE Select",
+	}, consequences[0], "MANE Select is listed first")
+	assert.Equal(t, "missense_variant&splice_region_variant", consequences[1].Consequence)
+	assert.Equal(t, "p.Arg248=", consequences[2].HGVSProtein)
+
+	region, err := vepRegion(&domain.NormalizedVariant{Chromosome: "1", Start: 101, End: 100, Alternative: "GA"})
+	require.NoError(t, err)
+	assert.Equal(t, "1:101-100:1/GA", region, "insertions end before they start")
+	region, err = vepRegion(&domain.NormalizedVariant{Chromosome: "1", Start: 100, End: 102, Reference: "GAT"})
+	require.NoError(t, err)
+	assert.Equal(t, "1:100-102:1/-", region)
+	_, err = client.Annotate(context.Background(), &domain.NormalizedVariant{})
+	assert.Error(t, err)
+}
+
+func TestOMIMClient_QueryGeneDiseases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("ApiKey"))
+		assert.Equal(t, "approved_gene_symbol:CFTR", r.URL.Query().Get("search"))
+		fmt.Fprint(w, `{"omim": {"searchResponse": {"geneMapList": [
+			{"geneMap": {"mimNumber": 602421, "approvedGeneSymbols": "CFTR", "geneSymbols": "CFTR, ABCC7",
+			 "phenotypeMapList": [
+				{"phenotypeMap": {"phenotype": "Cystic fibrosis", "phenotypeMimNumber": 219700, "phenotypeMappingKey": 3,
+					"phenotypeInheritance": "Autosomal recessive", "phenotypicSeriesNumber": "PS219700"}},
+				{"phenotypeMap": {"phenotype": "{Bronchiectasis with or without elevated sweat chloride 1}", "phenotypeMimNumber": 211400,
+					"phenotypeMappingKey": 3, "phenotypeInheritance": "Autosomal dominant; Autosomal recessive"}}
+			 ]}},
+			{"geneMap": {"mimNumber": 600000, "approvedGeneSymbols": "CFTR-AS1", "phenotypeMapList": [
+				{"phenotypeMap": {"phenotype": "Unrelated", "phenotypeMimNumber": 100000}}]}}
+		]}}}`)
+	}))
+	defer server.Close()
+
+	client := NewOMIMClient(domain.OMIMConfig{BaseURL: server.URL, APIKey: "secret", RateLimit: 1000, Timeout: 5 * time.Second})
+	diseases, err := client.QueryGeneDiseases(context.Background(), "CFTR")
+	require.NoError(t, err)
+	require.Len(t, diseases, 2, "only the exact symbol match is used")
+	assert.Equal(t, domain.DiseaseAssociation{
+		Source: domain.DiseaseSourceOMIM, ID: "OMIM:219700", Name: "Cystic fibrosis",
+		Inheritance: []string{"Autosomal recessive"}, MappingKey: 3, PhenotypicSeries: "PS219700",
+	}, diseases[0])
+	assert.Equal(t, []string{"Autosomal dominant", "Autosomal recessive"}, diseases[1].Inheritance)
+
+	_, err = NewOMIMClient(domain.OMIMConfig{BaseURL: server.URL}).QueryGeneDiseases(context.Background(), "CFTR")
+	assert.Error(t, err, "an API key is required")
+}
+
+func TestLoadOrphanetDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "en_product6.xml"), []byte(`<?xml version="1.0" encoding="ISO-8859-1"?>
+<JDBOR><DisorderList count="1">
+<Disorder id="17601"><OrphaCode>586</OrphaCode><Name lang="en">Cystic fibrosis</Name>
+ <DisorderGeneAssociationList count="1"><DisorderGeneAssociation>
+  <Gene id="20160"><Name lang="en">CF transmembrane conductance regulator</Name><Symbol>CFTR</Symbol></Gene>
+  <DisorderGeneAssociationType id="17949"><Name lang="en">Disease-causing germline mutation(s) in</Name></DisorderGeneAssociationType>
+ </DisorderGeneAssociation></DisorderGeneAssociationList>
+</Disorder>
+<Disorder id="2430"><OrphaCode>816</OrphaCode><Name lang="en">Sj`+"\xf6"+`gren-Larsson syndrome</Name>
+ <DisorderGeneAssociationList count="1"><DisorderGeneAssociation>
+  <Gene id="1"><Symbol>ALDH3A2</Symbol></Gene>
+  <DisorderGeneAssociationType id="17949"><Name lang="en">Disease-causing germline mutation(s) in</Name></DisorderGeneAssociationType>
+ </DisorderGeneAssociation></DisorderGeneAssociationList>
+</Disorder>
+</DisorderList></JDBOR>`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "en_product9_prev.xml"), []byte(`<?xml version="1.0"?>
+<JDBOR><DisorderList count="1">
+<Disorder id="17601"><OrphaCode>586</OrphaCode><Name lang="en">Cystic fibrosis</Name>
+ <PrevalenceList count="1"><Prevalence id="1">
+  <PrevalenceType id="40183"><Name lang="en">Point prevalence</Name></PrevalenceType>
+  <PrevalenceClass id="409968"><Name lang="en">1-5 / 10 000</Name></PrevalenceClass>
+  <ValMoy>0.74</ValMoy>
+  <PrevalenceGeographic id="52428"><Name lang="en">Europe</Name></PrevalenceGeographic>
+  <PrevalenceValidationStatus id="64308"><Name lang="en">Validated</Name></PrevalenceValidationStatus>
+ </Prevalence></PrevalenceList>
+</Disorder>
+</DisorderList></JDBOR>`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "en_product9_ages.xml"), []byte(`<?xml version="1.0"?>
+<JDBOR><DisorderList count="1">
+<Disorder id="17601"><OrphaCode>586</OrphaCode><Name lang="en">Cystic fibrosis</Name>
+ <AverageAgeOfOnsetList count="1"><AverageAgeOfOnset id="1"><Name lang="en">Infancy</Name></AverageAgeOfOnset></AverageAgeOfOnsetList>
+ <TypeOfInheritanceList count="1"><TypeOfInheritance id="1"><Name lang="en">Autosomal recessive</Name></TypeOfInheritance></TypeOfInheritanceList>
+</Disorder>
+</DisorderList></JDBOR>`), 0644))
+
+	data, err := LoadOrphanetDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, data.Count())
+
+	diseases, err := data.QueryGeneDiseases(context.Background(), "cftr")
+	require.NoError(t, err)
+	require.Len(t, diseases, 1)
+	assert.Equal(t, domain.DiseaseAssociation{
+		Source: domain.DiseaseSourceOrphanet, ID: "ORPHA:586", Name: "Cystic fibrosis",
+		Inheritance: []string{"Autosomal recessive"}, AssociationType: "Disease-causing germline mutation(s) in",
+		AgeOfOnset: []string{"Infancy"},
+		Prevalence: []domain.DiseasePrevalence{{Type: "Point prevalence", Class: "1-5 / 10 000", Value: 0.74, Geographic: "Europe", Validated: true}},
+	}, diseases[0])
+
+	diseases, err = data.QueryGeneDiseases(context.Background(), "ALDH3A2")
+	require.NoError(t, err)
+	require.Len(t, diseases, 1)
+	assert.Equal(t, "Sjögren-Larsson syndrome", diseases[0].Name, "ISO-8859-1 is decoded")
+
+	diseases, err = data.QueryGeneDiseases(context.Background(), "BRCA1")
+	require.NoError(t, err)
+	assert.Empty(t, diseases)
+
+	empty, err := LoadOrphanetDir(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, empty.Count())
+}
@@ -13,7 +13,7 @@ import (
 	"github.com/acmg-amp-mcp-server/internal/domain"
 )
 
-// GnomADClient handles interactions with the gnomAD API
+// GnomADClient handles interactions with the gnomAD v3 API
 type GnomADClient struct {
 	baseURL    string
 	apiKey     string
@@ -24,12 +24,10 @@ type GnomADClient struct {
 // NewGnomADClient creates a new gnomAD API client
 func NewGnomADClient(config domain.GnomADConfig) *GnomADClient {
 	return &GnomADClient{
-		baseURL: config.BaseURL,
-		apiKey:  config.APIKey,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		rateLimit: time.Second / time.Duration(config.RateLimit),
+		baseURL:    config.BaseURL,
+		apiKey:     config.APIKey,
+		httpClient: newHTTPClient(config.Timeout, config.RetryCount),
+		rateLimit:  time.Second / time.Duration(config.RateLimit),
 	}
 }
 
@@ -43,6 +41,7 @@ type GnomADVariantResponse struct {
 				AN  int     `json:"an"`
 				AF  float64 `json:"af"`
 				Hom int     `json:"hom"`
+				ACHemi int  `json:"ac_hemi"`
 				Populations []struct {
 					ID string  `json:"id"`
 					AC int     `json:"ac"`
@@ -60,6 +59,7 @@ type GnomADVariantResponse struct {
 				AN  int     `json:"an"`
 				AF  float64 `json:"af"`
 				Hom int     `json:"hom"`
+				ACHemi int  `json:"ac_hemi"`
 				Populations []struct {
 					ID string  `json:"id"`
 					AC int     `json:"ac"`
@@ -112,6 +112,11 @@ func (g *GnomADClient) QueryVariant(ctx context.Context, variant *domain.Standar
 
 // buildVariantID constructs a gnomAD variant identifier
 func (g *GnomADClient) buildVariantID(variant *domain.StandardizedVariant) (string, error) {
+	return gnomADVariantID(variant)
+}
+
+// gnomADVariantID constructs a gnomAD variant identifier
+func gnomADVariantID(variant *domain.StandardizedVariant) (string, error) {
 	// gnomAD uses format: chrom-pos-ref-alt
 	if variant.Chromosome == "" || variant.Position == 0 || variant.Reference == "" || variant.Alternative == "" {
 		return "", fmt.Errorf("insufficient variant information for gnomAD query: need chrom, pos, ref, alt")
@@ -128,13 +133,14 @@ func (g *GnomADClient) queryGraphQL(ctx context.Context, variantID string) (*Gno
 	// GraphQL query for variant frequency data
 	query := `
 	query VariantQuery($variantId: String!) {
-		variant(variantId: $variantId, dataset: gnomad_r4) {
+		variant(variantId: $variantId, dataset: gnomad_r3) {
 			variantId
 			genome {
 				ac
 				an
 				af
 				hom
+				ac_hemi
 				populations {
 					id
 					ac
@@ -152,6 +158,7 @@ func (g *GnomADClient) queryGraphQL(ctx context.Context, variantID string) (*Gno
 				an
 				af
 				hom
+				ac_hemi
 				populations {
 					id
 					ac
@@ -221,7 +228,7 @@ func (g *GnomADClient) convertToPopulationData(response *GnomADVariantResponse)
 	variant := response.Data.Variant
 	
 	// Combine genome and exome data, preferring genome data when available
-	var ac, an, hom int
+	var ac, an, hom, hemi int
 	var af float64
 	var qualityMetrics *domain.QualityMetrics
 	populationFreqs := make(map[string]float64)
@@ -232,6 +239,7 @@ func (g *GnomADClient) convertToPopulationData(response *GnomADVariantResponse)
 		an = variant.Genome.AN
 		af = variant.Genome.AF
 		hom = variant.Genome.Hom
+		hemi = variant.Genome.ACHemi
 		
 		// Quality metrics from genome data
 		qualityMetrics = &domain.QualityMetrics{
@@ -252,6 +260,7 @@ func (g *GnomADClient) convertToPopulationData(response *GnomADVariantResponse)
 		an = variant.Exome.AN
 		af = variant.Exome.AF
 		hom = variant.Exome.Hom
+		hemi = variant.Exome.ACHemi
 		
 		// Quality metrics from exome data
 		qualityMetrics = &domain.QualityMetrics{
@@ -274,7 +283,9 @@ func (g *GnomADClient) convertToPopulationData(response *GnomADVariantResponse)
 		AlleleNumber:          an,
 		PopulationFrequencies: populationFreqs,
 		HomozygoteCount:       hom,
+		HemizygoteCount:       hemi,
 		QualityMetrics:        qualityMetrics,
+		Dataset:               GnomADDatasetV3,
 	}
 }
 
@@ -361,6 +372,10 @@ func (g *GnomADClient) parseCoordinateResponse(response map[string]interface{})
 		populationData.HomozygoteCount = int(hom)
 	}
 	
+	if hemi, ok := response["ac_hemi"].(float64); ok {
+		populationData.HemizygoteCount = int(hemi)
+	}
+	
 	// Extract population-specific frequencies
 	if populations, ok := response["populations"].(map[string]interface{}); ok {
 		populationFreqs := make(map[string]float64)
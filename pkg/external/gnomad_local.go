@@ -0,0 +1,597 @@
+package external
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// gnomADAncestryGroups are the genetic ancestry groups read from sites VCFs;
+// v4 reports "remaining" where v3 reported "oth"
+var gnomADAncestryGroups = []string{"afr", "ami", "amr", "asj", "eas", "fin", "mid", "nfe", "remaining", "oth", "sas"}
+
+// GenomicRegion is a 1-based, inclusive chromosome interval
+type GenomicRegion struct {
+	Chrom      string
+	Start, End int64
+}
+
+// ParseGenomicRegion parses a region such as 17:43044295-43170245 or a
+// whole chromosome such as chr17
+func ParseGenomicRegion(region string) (GenomicRegion, error) {
+	chrom, span, hasSpan := strings.Cut(strings.TrimSpace(region), ":")
+	parsed := GenomicRegion{Chrom: strings.TrimPrefix(chrom, "chr"), End: math.MaxInt64}
+	if parsed.Chrom == "" {
+		return GenomicRegion{}, fmt.Errorf("invalid region %q", region)
+	}
+	if !hasSpan {
+		return parsed, nil
+	}
+	start, end, ok := strings.Cut(strings.ReplaceAll(span, ",", ""), "-")
+	var err1, err2 error
+	parsed.Start, err1 = strconv.ParseInt(start, 10, 64)
+	parsed.End, err2 = strconv.ParseInt(end, 10, 64)
+	if !ok || err1 != nil || err2 != nil || parsed.Start < 1 || parsed.End < parsed.Start {
+		return GenomicRegion{}, fmt.Errorf("invalid region %q (expected chrom:start-end)", region)
+	}
+	return parsed, nil
+}
+
+// contains reports whether the region holds a position
+func (r GenomicRegion) contains(chrom string, pos int64) bool {
+	return r.Chrom == chrom && pos >= r.Start && pos <= r.End
+}
+
+// inRegions reports whether a position is in any of the regions; no
+// regions means everywhere
+func inRegions(regions []GenomicRegion, chrom string, pos int64) bool {
+	if len(regions) == 0 {
+		return true
+	}
+	for _, region := range regions {
+		if region.contains(chrom, pos) {
+			return true
+		}
+	}
+	return false
+}
+
+// gnomADSite is one variant's frequencies read from a sites VCF
+type gnomADSite struct {
+	chrom, ref, alt string
+	pos             int64
+	frequencies     GnomADV4Frequencies
+}
+
+// parseGnomADVCFLine reads a gnomAD sites VCF line. Joint v4 files carry
+// their counts in _joint INFO fields, exome and genome files without a
+// suffix; the fields of either are read.
+func parseGnomADVCFLine(line string) (*gnomADSite, error) {
+	fields := strings.SplitN(strings.TrimRight(line, "\r\n"), "\t", 9)
+	if len(fields) < 8 {
+		return nil, fmt.Errorf("gnomAD VCF line has %d columns", len(fields))
+	}
+	pos, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gnomAD VCF position %q", fields[1])
+	}
+
+	info := make(map[string]string)
+	for _, entry := range strings.Split(fields[7], ";") {
+		key, value, _ := strings.Cut(entry, "=")
+		info[key] = value
+	}
+	suffix := ""
+	if _, ok := info["AN_joint"]; ok {
+		suffix = "_joint"
+	}
+	count := func(key string) int {
+		n, _ := strconv.Atoi(info[key])
+		return n
+	}
+
+	site := &gnomADSite{
+		chrom: strings.TrimPrefix(fields[0], "chr"),
+		pos:   pos,
+		ref:   fields[3],
+		alt:   fields[4],
+		frequencies: GnomADV4Frequencies{
+			AC:              count("AC" + suffix),
+			AN:              count("AN" + suffix),
+			HomozygoteCount: count("nhomalt" + suffix),
+		},
+	}
+	if filter := fields[6]; filter != "PASS" && filter != "." {
+		site.frequencies.Filters = strings.Split(filter, ";")
+	}
+	for _, group := range gnomADAncestryGroups {
+		an := count("AN" + suffix + "_" + group)
+		if an == 0 {
+			continue
+		}
+		site.frequencies.Populations = append(site.frequencies.Populations, GnomADV4Population{
+			ID:              group,
+			AC:              count("AC" + suffix + "_" + group),
+			AN:              an,
+			HomozygoteCount: count("nhomalt" + suffix + "_" + group),
+		})
+	}
+	if faf, err := strconv.ParseFloat(info["fafmax_faf95_max"+suffix], 64); err == nil {
+		site.frequencies.FAF95 = &GnomADFilteringAF{Popmax: faf, PopmaxPopulation: info["fafmax_faf95_max_gen_anc"+suffix]}
+	}
+	if faf, err := strconv.ParseFloat(info["fafmax_faf99_max"+suffix], 64); err == nil {
+		site.frequencies.FAF99 = &GnomADFilteringAF{Popmax: faf, PopmaxPopulation: info["fafmax_faf99_max_gen_anc"+suffix]}
+	}
+	return site, nil
+}
+
+// gnomADBackend looks up one variant's frequencies and a position's coverage
+type gnomADBackend interface {
+	site(ctx context.Context, chrom string, pos int64, ref, alt string) (*GnomADV4Frequencies, error)
+	coverage(ctx context.Context, chrom string, pos int64) (float64, bool, error)
+	ping(ctx context.Context) error
+	Close() error
+}
+
+// GnomADAnnotator looks up gnomAD allele frequencies, filtering allele
+// frequencies and coverage in a local copy of gnomAD, either a SQLite
+// import made by "setup gnomad" or a bgzipped sites VCF with a tabix
+// index, so BA1, BS1 and PM2 need no calls to the gnomAD API
+type GnomADAnnotator struct {
+	backend gnomADBackend
+	dataset string
+}
+
+// NewGnomADAnnotator opens a gnomAD SQLite import (.db, .sqlite) or a
+// bgzipped sites VCF with a tabix index alongside it (.bgz or .gz with a
+// .tbi). Results report dataset, or the dataset recorded by the import.
+func NewGnomADAnnotator(path, dataset string) (*GnomADAnnotator, error) {
+	if dataset == "" {
+		dataset = GnomADDatasetV4
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".db", ".sqlite", ".sqlite3":
+		backend, err := openGnomADSQLite(path)
+		if err != nil {
+			return nil, err
+		}
+		if backend.dataset != "" {
+			dataset = backend.dataset
+		}
+		return &GnomADAnnotator{backend: backend, dataset: dataset}, nil
+	case ".gz", ".bgz":
+		backend, err := openTabixFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gnomAD sites VCF: %w", err)
+		}
+		return &GnomADAnnotator{backend: &gnomADTabix{file: backend, path: path}, dataset: dataset}, nil
+	default:
+		return nil, fmt.Errorf("unsupported gnomAD file %s (use a SQLite import or a tabix-indexed sites VCF)", path)
+	}
+}
+
+// QueryVariant returns the variant's frequencies. A variant absent from
+// gnomAD yields empty frequency data, with the coverage at its position
+// when the import has it, as from the gnomAD API.
+func (a *GnomADAnnotator) QueryVariant(ctx context.Context, variant *domain.StandardizedVariant) (*domain.PopulationData, error) {
+	if variant.Chromosome == "" || variant.Position == 0 || variant.Reference == "" || variant.Alternative == "" {
+		return nil, fmt.Errorf("insufficient variant information for gnomAD lookup: need chrom, pos, ref, alt")
+	}
+	chrom := strings.TrimPrefix(variant.Chromosome, "chr")
+
+	frequencies, err := a.backend.site(ctx, chrom, variant.Position, variant.Reference, variant.Alternative)
+	if err != nil {
+		return nil, fmt.Errorf("local gnomAD lookup failed: %w", err)
+	}
+	data := &domain.PopulationData{Dataset: a.dataset}
+	if frequencies != nil {
+		data = convertJointFrequencies(a.dataset, frequencies)
+	}
+
+	mean, ok, err := a.backend.coverage(ctx, chrom, variant.Position)
+	if err != nil {
+		return nil, fmt.Errorf("local gnomAD coverage lookup failed: %w", err)
+	}
+	if ok {
+		if data.QualityMetrics == nil {
+			data.QualityMetrics = &domain.QualityMetrics{}
+		}
+		data.QualityMetrics.Coverage = int(math.Round(mean))
+	}
+	return data, nil
+}
+
+// Dataset returns the gnomAD dataset the local copy belongs to, e.g. gnomad_r4
+func (a *GnomADAnnotator) Dataset() string {
+	return a.dataset
+}
+
+// Ping checks the local copy can be read, for readiness probes
+func (a *GnomADAnnotator) Ping(ctx context.Context) error {
+	return a.backend.ping(ctx)
+}
+
+// Close releases the underlying file or database
+func (a *GnomADAnnotator) Close() error {
+	return a.backend.Close()
+}
+
+// gnomADTabix reads a bgzipped sites VCF through its tabix index
+type gnomADTabix struct {
+	file *tabixFile
+	path string
+}
+
+func (t *gnomADTabix) site(ctx context.Context, chrom string, pos int64, ref, alt string) (*GnomADV4Frequencies, error) {
+	var found *GnomADV4Frequencies
+	err := t.file.scan(ctx, chrom, pos, func(line string) (bool, error) {
+		site, err := parseGnomADVCFLine(line)
+		if err != nil {
+			return true, err
+		}
+		if site.ref == ref && site.alt == alt {
+			found = &site.frequencies
+			return true, nil
+		}
+		return false, nil
+	})
+	return found, err
+}
+
+// coverage is not available from a sites VCF
+func (t *gnomADTabix) coverage(ctx context.Context, chrom string, pos int64) (float64, bool, error) {
+	return 0, false, nil
+}
+
+func (t *gnomADTabix) ping(ctx context.Context) error {
+	_, err := os.Stat(t.path)
+	return err
+}
+
+func (t *gnomADTabix) Close() error {
+	return t.file.Close()
+}
+
+const gnomADSchema = `
+CREATE TABLE IF NOT EXISTS gnomad_frequencies (
+	chrom TEXT NOT NULL,
+	pos INTEGER NOT NULL,
+	ref TEXT NOT NULL,
+	alt TEXT NOT NULL,
+	ac INTEGER NOT NULL,
+	an INTEGER NOT NULL,
+	nhomalt INTEGER NOT NULL,
+	filters TEXT DEFAULT '',
+	populations TEXT DEFAULT '',
+	faf95 REAL,
+	faf95_population TEXT DEFAULT '',
+	faf99 REAL,
+	PRIMARY KEY (chrom, pos, ref, alt)
+) WITHOUT ROWID;
+CREATE TABLE IF NOT EXISTS gnomad_coverage (
+	chrom TEXT NOT NULL,
+	pos INTEGER NOT NULL,
+	mean REAL NOT NULL,
+	PRIMARY KEY (chrom, pos)
+) WITHOUT ROWID;
+CREATE TABLE IF NOT EXISTS gnomad_release (
+	key TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+) WITHOUT ROWID;
+`
+
+// gnomADSQLite is a gnomAD import in SQLite
+type gnomADSQLite struct {
+	db      *sql.DB
+	dataset string
+}
+
+func openGnomADSQLite(path string) (*gnomADSQLite, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to open gnomAD database: %w", err)
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gnomAD database: %w", err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM gnomad_frequencies LIMIT 1`).Scan(new(int)); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("not a gnomAD import: %s: %w", path, err)
+	}
+	s := &gnomADSQLite{db: db}
+	err = db.QueryRow(`SELECT value FROM gnomad_release WHERE key = 'dataset'`).Scan(&s.dataset)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		db.Close()
+		return nil, fmt.Errorf("not a gnomAD import: %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *gnomADSQLite) site(ctx context.Context, chrom string, pos int64, ref, alt string) (*GnomADV4Frequencies, error) {
+	var (
+		frequencies     GnomADV4Frequencies
+		filters, groups string
+		faf95Population string
+		faf95, faf99    sql.NullFloat64
+	)
+	err := s.db.QueryRowContext(ctx,
+		`SELECT ac, an, nhomalt, filters, populations, faf95, faf95_population, faf99 FROM gnomad_frequencies WHERE chrom = ? AND pos = ? AND ref = ? AND alt = ?`,
+		chrom, pos, ref, alt,
+	).Scan(&frequencies.AC, &frequencies.AN, &frequencies.HomozygoteCount, &filters, &groups, &faf95, &faf95Population, &faf99)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if filters != "" {
+		frequencies.Filters = strings.Split(filters, ";")
+	}
+	frequencies.Populations = decodeGnomADPopulations(groups)
+	if faf95.Valid {
+		frequencies.FAF95 = &GnomADFilteringAF{Popmax: faf95.Float64, PopmaxPopulation: faf95Population}
+	}
+	if faf99.Valid {
+		frequencies.FAF99 = &GnomADFilteringAF{Popmax: faf99.Float64}
+	}
+	return &frequencies, nil
+}
+
+func (s *gnomADSQLite) coverage(ctx context.Context, chrom string, pos int64) (float64, bool, error) {
+	var mean float64
+	err := s.db.QueryRowContext(ctx, `SELECT mean FROM gnomad_coverage WHERE chrom = ? AND pos = ?`, chrom, pos).Scan(&mean)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return mean, true, nil
+}
+
+func (s *gnomADSQLite) ping(ctx context.Context) error {
+	return s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (SELECT 1 FROM gnomad_frequencies LIMIT 1)`).Scan(new(int))
+}
+
+func (s *gnomADSQLite) Close() error {
+	return s.db.Close()
+}
+
+// encodeGnomADPopulations stores ancestry group counts as "afr:ac:an:hom;..."
+func encodeGnomADPopulations(populations []GnomADV4Population) string {
+	parts := make([]string, len(populations))
+	for i, pop := range populations {
+		parts[i] = fmt.Sprintf("%s:%d:%d:%d", pop.ID, pop.AC, pop.AN, pop.HomozygoteCount)
+	}
+	return strings.Join(parts, ";")
+}
+
+func decodeGnomADPopulations(value string) []GnomADV4Population {
+	var populations []GnomADV4Population
+	for _, part := range strings.Split(value, ";") {
+		fields := strings.Split(part, ":")
+		if len(fields) != 4 {
+			continue
+		}
+		pop := GnomADV4Population{ID: fields[0]}
+		pop.AC, _ = strconv.Atoi(fields[1])
+		pop.AN, _ = strconv.Atoi(fields[2])
+		pop.HomozygoteCount, _ = strconv.Atoi(fields[3])
+		populations = append(populations, pop)
+	}
+	return populations
+}
+
+// GnomADImportOptions configures a gnomAD import
+type GnomADImportOptions struct {
+	Dataset string          // gnomAD dataset the files belong to; GnomADDatasetV4 if empty
+	Regions []GenomicRegion // Import only these regions; empty imports every site
+}
+
+// GnomADImportResult counts what a gnomAD import added
+type GnomADImportResult struct {
+	Variants  int // Sites imported from sites VCFs
+	Positions int // Positions imported from coverage summaries
+}
+
+// ImportGnomAD imports gnomAD sites VCFs and coverage summaries (plain or
+// gzipped) into a SQLite database, creating it if needed. Files are
+// recognised by their first line; variants and positions already present
+// are replaced, so per-chromosome files can be imported one at a time. A
+// database holds one dataset: importing v3 files into a v4 import fails.
+func ImportGnomAD(ctx context.Context, dbPath string, files []string, options GnomADImportOptions) (*GnomADImportResult, error) {
+	if options.Dataset == "" {
+		options.Dataset = GnomADDatasetV4
+	}
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gnomAD database: %w", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(gnomADSchema); err != nil {
+		return nil, fmt.Errorf("failed to create gnomAD schema: %w", err)
+	}
+
+	var existing string
+	err = db.QueryRowContext(ctx, `SELECT value FROM gnomad_release WHERE key = 'dataset'`).Scan(&existing)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		if _, err := db.ExecContext(ctx, `INSERT INTO gnomad_release (key, value) VALUES ('dataset', ?)`, options.Dataset); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case existing != options.Dataset:
+		return nil, fmt.Errorf("%s holds %s, not %s; import into a new database", dbPath, existing, options.Dataset)
+	}
+
+	result := &GnomADImportResult{}
+	for _, file := range files {
+		if err := importGnomADFile(ctx, db, file, options.Regions, result); err != nil {
+			return result, fmt.Errorf("failed to import %s: %w", file, err)
+		}
+	}
+	return result, nil
+}
+
+// importGnomADFile imports one sites VCF or coverage summary in a single
+// transaction
+func importGnomADFile(ctx context.Context, db *sql.DB, path string, regions []GenomicRegion, result *GnomADImportResult) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".gz" || ext == ".bgz" {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("empty file")
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	first := scanner.Text()
+	if strings.HasPrefix(first, "##fileformat=VCF") {
+		n, err := importGnomADSites(ctx, tx, scanner, regions)
+		if err != nil {
+			return err
+		}
+		result.Variants += n
+	} else {
+		n, err := importGnomADCoverage(ctx, tx, scanner, first, regions)
+		if err != nil {
+			return err
+		}
+		result.Positions += n
+	}
+	return tx.Commit()
+}
+
+func importGnomADSites(ctx context.Context, tx *sql.Tx, scanner *bufio.Scanner, regions []GenomicRegion) (int, error) {
+	insert, err := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO gnomad_frequencies
+		(chrom, pos, ref, alt, ac, an, nhomalt, filters, populations, faf95, faf95_population, faf99)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return 0, err
+	}
+	defer insert.Close()
+
+	count := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		site, err := parseGnomADVCFLine(line)
+		if err != nil {
+			return count, err
+		}
+		if !inRegions(regions, site.chrom, site.pos) {
+			continue
+		}
+
+		f := site.frequencies
+		var faf95, faf99 interface{}
+		faf95Population := ""
+		if f.FAF95 != nil {
+			faf95, faf95Population = f.FAF95.Popmax, f.FAF95.PopmaxPopulation
+		}
+		if f.FAF99 != nil {
+			faf99 = f.FAF99.Popmax
+		}
+		if _, err := insert.ExecContext(ctx, site.chrom, site.pos, site.ref, site.alt, f.AC, f.AN, f.HomozygoteCount,
+			strings.Join(f.Filters, ";"), encodeGnomADPopulations(f.Populations), faf95, faf95Population, faf99); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, scanner.Err()
+}
+
+// importGnomADCoverage reads a coverage summary whose header names a locus
+// column (chr17:43044295, v4) or chrom and pos columns (v2 and v3), and a
+// mean column
+func importGnomADCoverage(ctx context.Context, tx *sql.Tx, scanner *bufio.Scanner, header string, regions []GenomicRegion) (int, error) {
+	index := make(map[string]int)
+	for i, name := range strings.Split(strings.TrimPrefix(strings.TrimSpace(header), "#"), "\t") {
+		index[name] = i
+	}
+	locus, hasLocus := index["locus"]
+	chromColumn, hasChrom := index["chrom"]
+	posColumn, hasPos := index["pos"]
+	meanColumn, hasMean := index["mean"]
+	if !hasMean || !(hasLocus || (hasChrom && hasPos)) {
+		return 0, fmt.Errorf("not a gnomAD sites VCF or coverage summary")
+	}
+
+	insert, err := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO gnomad_coverage (chrom, pos, mean) VALUES (?, ?, ?)`)
+	if err != nil {
+		return 0, err
+	}
+	defer insert.Close()
+
+	count := 0
+	for scanner.Scan() {
+		fields := strings.Split(strings.TrimRight(scanner.Text(), "\r"), "\t")
+		if len(fields) <= meanColumn {
+			continue
+		}
+		var chrom, pos string
+		if hasLocus && locus < len(fields) {
+			chrom, pos, _ = strings.Cut(fields[locus], ":")
+		} else if chromColumn < len(fields) && posColumn < len(fields) {
+			chrom, pos = fields[chromColumn], fields[posColumn]
+		}
+		position, err := strconv.ParseInt(pos, 10, 64)
+		if err != nil {
+			return count, fmt.Errorf("invalid coverage position %q", pos)
+		}
+		mean, err := strconv.ParseFloat(fields[meanColumn], 64)
+		if err != nil {
+			return count, fmt.Errorf("invalid coverage mean %q", fields[meanColumn])
+		}
+		chrom = strings.TrimPrefix(chrom, "chr")
+		if !inRegions(regions, chrom, position) {
+			continue
+		}
+		if _, err := insert.ExecContext(ctx, chrom, position, mean); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, scanner.Err()
+}
@@ -0,0 +1,244 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// gnomAD GraphQL dataset identifiers
+const (
+	GnomADDatasetV3 = "gnomad_r3"
+	GnomADDatasetV4 = "gnomad_r4"
+)
+
+// PopulationFrequencyClient queries population allele frequencies for a variant
+type PopulationFrequencyClient interface {
+	QueryVariant(ctx context.Context, variant *domain.StandardizedVariant) (*domain.PopulationData, error)
+}
+
+// NewPopulationFrequencyClient returns the gnomAD client for the configured
+// dataset. gnomAD v4 is the default; v3 remains available for labs that have
+// not yet revalidated against v4.
+func NewPopulationFrequencyClient(config domain.GnomADConfig) PopulationFrequencyClient {
+	if config.Dataset == GnomADDatasetV3 {
+		return NewGnomADClient(config)
+	}
+	return NewGnomADV4Client(config)
+}
+
+// GnomADV4Client queries the gnomAD v4 joint exome and genome frequencies,
+// including the filtering allele frequencies used for BA1, BS1 and PM2.
+type GnomADV4Client struct {
+	baseURL    string
+	apiKey     string
+	dataset    string
+	httpClient *http.Client
+	rateLimit  time.Duration
+}
+
+// NewGnomADV4Client creates a new gnomAD v4 GraphQL client
+func NewGnomADV4Client(config domain.GnomADConfig) *GnomADV4Client {
+	dataset := config.Dataset
+	if dataset == "" {
+		dataset = GnomADDatasetV4
+	}
+	rateLimit := 10
+	if config.RateLimit > 0 {
+		rateLimit = config.RateLimit
+	}
+	return &GnomADV4Client{
+		baseURL:    config.BaseURL,
+		apiKey:     config.APIKey,
+		dataset:    dataset,
+		httpClient: newHTTPClient(config.Timeout, config.RetryCount),
+		rateLimit:  time.Second / time.Duration(rateLimit),
+	}
+}
+
+// gnomADV4Query requests the joint exome+genome frequencies of a variant
+const gnomADV4Query = `
+query VariantQuery($variantId: String!, $dataset: DatasetId!) {
+	variant(variantId: $variantId, dataset: $dataset) {
+		variant_id
+		joint {
+			ac
+			an
+			homozygote_count
+			hemizygote_count
+			filters
+			populations {
+				id
+				ac
+				an
+				homozygote_count
+			}
+			faf95 {
+				popmax
+				popmax_population
+			}
+			faf99 {
+				popmax
+				popmax_population
+			}
+		}
+	}
+}`
+
+// GnomADFilteringAF is a popmax filtering allele frequency: the lower bound of
+// the confidence interval in the genetic ancestry group where it is highest
+type GnomADFilteringAF struct {
+	Popmax           float64 `json:"popmax"`
+	PopmaxPopulation string  `json:"popmax_population"`
+}
+
+// GnomADV4Population is a genetic ancestry group's joint allele counts
+type GnomADV4Population struct {
+	ID              string `json:"id"`
+	AC              int    `json:"ac"`
+	AN              int    `json:"an"`
+	HomozygoteCount int    `json:"homozygote_count"`
+}
+
+// GnomADV4Frequencies is the joint exome and genome frequency data
+type GnomADV4Frequencies struct {
+	AC              int                  `json:"ac"`
+	AN              int                  `json:"an"`
+	HomozygoteCount int                  `json:"homozygote_count"`
+	HemizygoteCount int                  `json:"hemizygote_count"`
+	Filters         []string             `json:"filters"`
+	Populations     []GnomADV4Population `json:"populations"`
+	FAF95           *GnomADFilteringAF   `json:"faf95"`
+	FAF99           *GnomADFilteringAF   `json:"faf99"`
+}
+
+// GnomADV4VariantResponse represents the GraphQL response from the gnomAD v4 API
+type GnomADV4VariantResponse struct {
+	Data struct {
+		Variant *struct {
+			VariantID string               `json:"variant_id"`
+			Joint     *GnomADV4Frequencies `json:"joint"`
+		} `json:"variant"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// QueryVariant queries gnomAD v4 for joint population frequency data.
+// A variant absent from gnomAD yields empty frequency data, not an error.
+func (g *GnomADV4Client) QueryVariant(ctx context.Context, variant *domain.StandardizedVariant) (*domain.PopulationData, error) {
+	// Rate limiting
+	select {
+	case <-time.After(g.rateLimit):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	variantID, err := gnomADVariantID(variant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build variant ID for gnomAD: %w", err)
+	}
+
+	response, err := g.queryGraphQL(ctx, variantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query gnomAD: %w", err)
+	}
+
+	for _, apiErr := range response.Errors {
+		if strings.Contains(strings.ToLower(apiErr.Message), "variant not found") {
+			return &domain.PopulationData{Dataset: g.dataset}, nil
+		}
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("gnomAD API error: %s", response.Errors[0].Message)
+	}
+	if response.Data.Variant == nil || response.Data.Variant.Joint == nil {
+		return &domain.PopulationData{Dataset: g.dataset}, nil
+	}
+
+	return convertJointFrequencies(g.dataset, response.Data.Variant.Joint), nil
+}
+
+// queryGraphQL executes the v4 variant query
+func (g *GnomADV4Client) queryGraphQL(ctx context.Context, variantID string) (*GnomADV4VariantResponse, error) {
+	jsonBody, err := json.Marshal(map[string]interface{}{
+		"query": gnomADV4Query,
+		"variables": map[string]interface{}{
+			"variantId": variantID,
+			"dataset":   g.dataset,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal GraphQL request: %w", err)
+	}
+
+	queryURL := fmt.Sprintf("%s/graphql", strings.TrimSuffix(g.baseURL, "/"))
+	req, err := http.NewRequestWithContext(ctx, "POST", queryURL, strings.NewReader(string(jsonBody)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GraphQL request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+g.apiKey)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute GraphQL request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GraphQL response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gnomAD API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response GnomADV4VariantResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL response: %w", err)
+	}
+	return &response, nil
+}
+
+// convertJointFrequencies converts v4 joint frequencies to domain PopulationData
+func convertJointFrequencies(dataset string, joint *GnomADV4Frequencies) *domain.PopulationData {
+	data := &domain.PopulationData{
+		AlleleCount:           joint.AC,
+		AlleleNumber:          joint.AN,
+		HomozygoteCount:       joint.HomozygoteCount,
+		HemizygoteCount:       joint.HemizygoteCount,
+		PopulationFrequencies: make(map[string]float64),
+		QualityMetrics:        &domain.QualityMetrics{FilterPass: len(joint.Filters) == 0},
+		Dataset:               dataset,
+	}
+	if joint.AN > 0 {
+		data.AlleleFrequency = float64(joint.AC) / float64(joint.AN)
+	}
+
+	// Only genetic ancestry groups; sex-specific subsets are reported as afr_XX, XY and so on
+	for _, pop := range joint.Populations {
+		if pop.AN == 0 || pop.AC == 0 || strings.Contains(pop.ID, "_") || pop.ID == "XX" || pop.ID == "XY" {
+			continue
+		}
+		data.PopulationFrequencies[pop.ID] = float64(pop.AC) / float64(pop.AN)
+	}
+
+	if joint.FAF95 != nil {
+		data.FAF95 = joint.FAF95.Popmax
+		data.FAFPopulation = joint.FAF95.PopmaxPopulation
+	}
+	if joint.FAF99 != nil {
+		data.FAF99 = joint.FAF99.Popmax
+	}
+	return data
+}
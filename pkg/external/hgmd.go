@@ -48,14 +48,12 @@ func NewHGMDClient(config HGMDConfig) *HGMDClient {
 	}
 	
 	return &HGMDClient{
-		baseURL:       config.BaseURL,
-		apiKey:        config.APIKey,
-		license:       config.License,
+		baseURL:        config.BaseURL,
+		apiKey:         config.APIKey,
+		license:        config.License,
 		isProfessional: config.IsProfessional && config.License != "",
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		rateLimit: time.Second / time.Duration(config.RateLimit),
+		httpClient:     newHTTPClient(config.Timeout, 0),
+		rateLimit:      time.Second / time.Duration(config.RateLimit),
 	}
 }
 
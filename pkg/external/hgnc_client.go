@@ -112,11 +112,9 @@ func NewHGNCClient(config HGNCConfig) *HGNCClient {
 	}
 
 	return &HGNCClient{
-		baseURL: config.BaseURL,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		rateLimit: rate.NewLimiter(rate.Limit(config.RateLimit), 1),
+		baseURL:    config.BaseURL,
+		httpClient: newHTTPClient(config.Timeout, 0),
+		rateLimit:  rate.NewLimiter(rate.Limit(config.RateLimit), 1),
 	}
 }
 
@@ -0,0 +1,263 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// Defaults of the shared transport, used for settings left unset
+const (
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 16
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultRetryBaseDelay      = 250 * time.Millisecond
+	defaultRetryMaxDelay       = 10 * time.Second
+
+	// maxDrainBytes is how much of a discarded response body is read so its
+	// connection can be reused
+	maxDrainBytes = 64 << 10
+)
+
+// sharedTransport carries the requests of every external API client, so
+// connections to a host are pooled and reused across clients and requests
+var sharedTransport = newPooledTransport(domain.HTTPConfig{})
+
+// pooledTransport is a tuned http.Transport with per-host timeouts and the
+// retry policy of the external API clients
+type pooledTransport struct {
+	mu             sync.RWMutex
+	transport      *http.Transport
+	hostTimeouts   map[string]time.Duration
+	maxRetries     int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+}
+
+func newPooledTransport(config domain.HTTPConfig) *pooledTransport {
+	t := &pooledTransport{}
+	t.configure(config)
+	return t
+}
+
+// ConfigureTransport applies config to the transport shared by the external
+// API clients, including clients already created. Idle connections of the
+// previous transport are closed.
+func ConfigureTransport(config domain.HTTPConfig) {
+	sharedTransport.configure(config)
+}
+
+func (t *pooledTransport) configure(config domain.HTTPConfig) {
+	if config.MaxIdleConns <= 0 {
+		config.MaxIdleConns = defaultMaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost <= 0 {
+		config.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	}
+	if config.IdleConnTimeout <= 0 {
+		config.IdleConnTimeout = defaultIdleConnTimeout
+	}
+	if config.RetryBaseDelay <= 0 {
+		config.RetryBaseDelay = defaultRetryBaseDelay
+	}
+	if config.RetryMaxDelay <= 0 {
+		config.RetryMaxDelay = defaultRetryMaxDelay
+	}
+	hostTimeouts := make(map[string]time.Duration, len(config.HostTimeouts))
+	for host, timeout := range config.HostTimeouts {
+		if timeout > 0 {
+			hostTimeouts[strings.ToLower(strings.TrimSpace(host))] = timeout
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     !config.DisableHTTP2,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       config.MaxConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	t.mu.Lock()
+	previous := t.transport
+	t.transport = transport
+	t.hostTimeouts = hostTimeouts
+	t.maxRetries = config.MaxRetries
+	t.retryBaseDelay = config.RetryBaseDelay
+	t.retryMaxDelay = config.RetryMaxDelay
+	t.mu.Unlock()
+	if previous != nil {
+		previous.CloseIdleConnections()
+	}
+}
+
+// newHTTPClient returns a client for one external API on the shared
+// transport. Each attempt times out after timeout unless the host has its own
+// timeout, and idempotent requests failing with a network error, 429 or 5xx
+// are retried up to retries times, or the shared default if retries is zero.
+func newHTTPClient(timeout time.Duration, retries int) *http.Client {
+	return &http.Client{Transport: &retryTransport{pool: sharedTransport, timeout: timeout, retries: retries}}
+}
+
+// retryTransport sends requests on a pooledTransport with a per-attempt
+// timeout, retrying transient failures with jittered exponential backoff
+type retryTransport struct {
+	pool    *pooledTransport
+	timeout time.Duration
+	retries int
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.pool.mu.RLock()
+	transport := r.pool.transport
+	timeout := r.timeout
+	if hostTimeout, ok := r.pool.hostTimeouts[strings.ToLower(req.URL.Hostname())]; ok {
+		timeout = hostTimeout
+	}
+	retries := r.retries
+	if retries <= 0 {
+		retries = r.pool.maxRetries
+	}
+	baseDelay, maxDelay := r.pool.retryBaseDelay, r.pool.retryMaxDelay
+	r.pool.mu.RUnlock()
+
+	if !replayable(req) {
+		retries = 0
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := r.attempt(transport, req, timeout, attempt)
+		if attempt >= retries || req.Context().Err() != nil || !retryable(resp, err) {
+			return resp, err
+		}
+		delay := backoff(attempt, baseDelay, maxDelay)
+		if resp != nil {
+			if after, ok := retryAfter(resp.Header.Get("Retry-After"), maxDelay); ok {
+				delay = after
+			}
+			drain(resp.Body)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt sends one try of req, with its body rewound for a retry
+func (r *retryTransport) attempt(transport *http.Transport, req *http.Request, timeout time.Duration, attempt int) (*http.Response, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	try := req.WithContext(ctx)
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		try.Body = body
+	}
+	resp, err := transport.RoundTrip(try)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The attempt's deadline covers reading the body
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// replayable reports whether req may be sent again. Every external API call
+// is a read-only lookup, including the POSTed GraphQL queries and VEP
+// batches, so any request whose body can be rewound is safe to retry.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// retryable reports whether an attempt failed transiently: a network error
+// or timeout, too many requests, or a 5xx other than 501 Not Implemented
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before retry attempt+1: half the exponential
+// delay plus a random share of the other half, so concurrent clients
+// spread their retries
+func backoff(attempt int, base, limit time.Duration) time.Duration {
+	delay := limit
+	if attempt < 30 && base<<attempt > 0 && base<<attempt < limit {
+		delay = base << attempt
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryAfter parses a Retry-After header of seconds or an HTTP date, capped
+// at limit
+func retryAfter(value string, limit time.Duration) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		delay = time.Until(at)
+	} else {
+		return 0, false
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > limit {
+		delay = limit
+	}
+	return delay, true
+}
+
+// drain reads and closes a discarded response body so its connection
+// returns to the pool
+func drain(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+	body.Close()
+}
+
+// cancelOnClose releases an attempt's timeout once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,137 @@
+package external
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// configureTestTransport applies config for the duration of a test
+func configureTestTransport(t *testing.T, config domain.HTTPConfig) {
+	t.Helper()
+	ConfigureTransport(config)
+	t.Cleanup(func() { ConfigureTransport(domain.HTTPConfig{}) })
+}
+
+func TestHTTPClient_RetriesTransientFailures(t *testing.T) {
+	configureTestTransport(t, domain.HTTPConfig{RetryBaseDelay: time.Millisecond, RetryMaxDelay: 5 * time.Millisecond})
+
+	var requests atomic.Int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		switch requests.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	client := newHTTPClient(5*time.Second, 2)
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"query":"x"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, int32(3), requests.Load())
+	assert.Equal(t, []string{`{"query":"x"}`, `{"query":"x"}`, `{"query":"x"}`}, bodies, "the body is resent on each retry")
+
+	// Out of retries, the last response is returned
+	requests.Store(0)
+	resp, err = newHTTPClient(5*time.Second, 1).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Client errors are not retried, and without its own retry count a
+	// client uses the shared default of none
+	requests.Store(0)
+	notFound := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer notFound.Close()
+	resp, err = newHTTPClient(5*time.Second, 3).Get(notFound.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int32(1), requests.Load())
+
+	requests.Store(0)
+	resp, err = newHTTPClient(5*time.Second, 0).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestHTTPClient_HostTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+	}))
+	defer server.Close()
+
+	// The host's timeout overrides the client's, for each attempt
+	configureTestTransport(t, domain.HTTPConfig{
+		HostTimeouts:   map[string]time.Duration{"127.0.0.1": 20 * time.Millisecond},
+		MaxRetries:     1,
+		RetryBaseDelay: time.Millisecond,
+	})
+	start := time.Now()
+	_, err := newHTTPClient(5*time.Second, 0).Get(server.URL)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 400*time.Millisecond)
+
+	// A cancelled caller is not retried
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	time.AfterFunc(5*time.Millisecond, cancel)
+	_, err = newHTTPClient(time.Second, 0).Do(req)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 0; attempt < 40; attempt++ {
+		delay := backoff(attempt, 100*time.Millisecond, 2*time.Second)
+		want := 100 * time.Millisecond << attempt
+		if attempt >= 5 {
+			want = 2 * time.Second
+		}
+		assert.GreaterOrEqual(t, delay, want/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, delay, want, "attempt %d", attempt)
+	}
+
+	delay, ok := retryAfter("3", time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+	delay, ok = retryAfter("120", time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, delay, "Retry-After is capped")
+	delay, ok = retryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), time.Minute)
+	assert.True(t, ok)
+	assert.Zero(t, delay)
+	_, ok = retryAfter("soon", time.Minute)
+	assert.False(t, ok)
+}
@@ -22,7 +22,7 @@ type UnifiedGeneAPIClient struct {
 	refSeqClient   *RefSeqClient
 	ensemblClient  *EnsemblClient
 	logger         *logrus.Logger
-	circuitBreaker *gobreaker.CircuitBreaker
+	circuitBreaker *Breaker
 }
 
 // UnifiedGeneAPIConfig represents configuration for the unified client
@@ -88,7 +88,7 @@ func NewUnifiedGeneAPIClient(config UnifiedGeneAPIConfig, logger *logrus.Logger)
 		refSeqClient:   NewRefSeqClient(config.RefSeqConfig),
 		ensemblClient:  NewEnsemblClient(config.EnsemblConfig),
 		logger:         logger,
-		circuitBreaker: gobreaker.NewCircuitBreaker(cbSettings),
+		circuitBreaker: NewBreaker(cbSettings),
 	}
 }
 
@@ -2,6 +2,8 @@ package external
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/acmg-amp-mcp-server/internal/domain"
 )
@@ -10,6 +12,18 @@ import (
 // It provides a clean interface to external databases with resilience patterns
 type KnowledgeBaseService struct {
 	resilientClient *ResilientExternalClient
+	splicing        SplicingPredictionClient
+	predictors      ComputationalPredictionClient
+	notices         CitationNoticeSource
+	residues        ResidueVariantClient
+	curations       GeneCurationClient
+	constraint      GeneConstraintClient
+}
+
+// CitationNoticeSource supplies the retraction and erratum notices recorded
+// for cited articles, keyed by PMID
+type CitationNoticeSource interface {
+	CitationNotices(ctx context.Context, pmids []string) (map[string][]domain.CitationNotice, error)
 }
 
 // NewKnowledgeBaseService creates a new knowledge base service
@@ -40,9 +54,130 @@ func NewKnowledgeBaseService(
 	}, nil
 }
 
-// GatherEvidence gathers evidence from all external databases
+// SetClinVarClient makes evidence gathering look up ClinVar records with
+// client, such as a local ClinVar import, instead of NCBI E-utilities
+func (k *KnowledgeBaseService) SetClinVarClient(client ClinVarVariantClient) {
+	k.resilientClient.SetClinVarClient(client)
+}
+
+// SetPopulationFrequencyClient makes evidence gathering look up allele
+// frequencies with client, such as a local gnomAD copy, instead of the
+// gnomAD API
+func (k *KnowledgeBaseService) SetPopulationFrequencyClient(client PopulationFrequencyClient) {
+	k.resilientClient.SetGnomADClient(client)
+}
+
+// SetSplicingPredictor enables SpliceAI/Pangolin splicing predictions during
+// evidence gathering; nil disables them
+func (k *KnowledgeBaseService) SetSplicingPredictor(predictor SplicingPredictionClient) {
+	k.splicing = predictor
+}
+
+// SetComputationalPredictor enables in silico score lookup, such as a local
+// dbNSFP copy, during evidence gathering; nil disables it
+func (k *KnowledgeBaseService) SetComputationalPredictor(predictor ComputationalPredictionClient) {
+	k.predictors = predictor
+}
+
+// SetCitationNotices enables annotation of retracted and corrected citations
+// during evidence gathering; nil disables it
+func (k *KnowledgeBaseService) SetCitationNotices(source CitationNoticeSource) {
+	k.notices = source
+}
+
+// SetResidueLookup enables the lookup of pathogenic variants at the same
+// protein residue for PS1 and PM5 during evidence gathering; nil disables it
+func (k *KnowledgeBaseService) SetResidueLookup(lookup ResidueVariantClient) {
+	k.residues = lookup
+}
+
+// SetGeneCurationClient enables the lookup of the gene's ClinGen validity
+// and dosage curation during evidence gathering; nil disables it.
+// Curations are cached per gene in the evidence cache.
+func (k *KnowledgeBaseService) SetGeneCurationClient(client GeneCurationClient) {
+	k.curations = client
+}
+
+// SetConstraintClient enables the lookup of gene constraint metrics, such
+// as a local gnomAD constraint table, during evidence gathering; nil
+// disables it
+func (k *KnowledgeBaseService) SetConstraintClient(client GeneConstraintClient) {
+	k.constraint = client
+}
+
+// GatherEvidence gathers evidence from all external databases and the
+// supplementary sources concurrently, each within its deadline. Sources that
+// fail or miss their deadline are reported in the evidence's source
+// statuses and incomplete categories.
 func (k *KnowledgeBaseService) GatherEvidence(ctx context.Context, variant *domain.StandardizedVariant) (*domain.AggregatedEvidence, error) {
-	return k.resilientClient.GatherEvidence(ctx, variant)
+	sources := k.resilientClient.evidenceSources(variant, k.notices)
+
+	// Splicing predictions are supplementary: keep whatever the tools
+	// returned, even alongside an error
+	if k.splicing != nil {
+		sources = append(sources, evidenceSource{name: EvidenceSourceSplicing, category: domain.EvidenceCategoryComputational,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				predictions, err := k.splicing.QueryVariant(ctx, variant)
+				return func(e *domain.AggregatedEvidence) {
+					e.SplicingPredictions = append(e.SplicingPredictions, predictions...)
+				}, err
+			}})
+	}
+
+	if k.predictors != nil {
+		sources = append(sources, evidenceSource{name: EvidenceSourcePredictors, category: domain.EvidenceCategoryComputational,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				data, err := k.predictors.QueryVariant(ctx, variant)
+				return func(e *domain.AggregatedEvidence) {
+					if data != nil {
+						e.ComputationalData = data
+					}
+				}, err
+			}})
+	}
+
+	// Without residue matches PS1 and PM5 report that no lookup was made
+	if k.residues != nil {
+		sources = append(sources, evidenceSource{name: EvidenceSourceResidues, category: domain.EvidenceCategoryClinical,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				matches, err := k.residues.QueryResidueVariants(ctx, variant)
+				if err != nil {
+					return nil, err
+				}
+				return func(e *domain.AggregatedEvidence) { e.ResidueVariants = matches }, nil
+			}})
+	}
+
+	// Without a curation PVS1 falls back to the configured gene models
+	if k.curations != nil && variant.GeneSymbol != "" {
+		sources = append(sources, evidenceSource{name: CacheSourceClinGen, category: domain.EvidenceCategoryGene,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				curation, err := cachedFetch(ctx, k.resilientClient.cache, CacheSourceClinGen, geneCacheKey(CacheSourceClinGen, variant.GeneSymbol),
+					func(ctx context.Context) (*domain.GeneCuration, error) {
+						return k.curations.QueryGeneCuration(ctx, variant.GeneSymbol)
+					})
+				return func(e *domain.AggregatedEvidence) { e.GeneCuration = curation }, err
+			}})
+	}
+
+	if k.constraint != nil && variant.GeneSymbol != "" {
+		sources = append(sources, evidenceSource{name: EvidenceSourceConstraint, category: domain.EvidenceCategoryGene,
+			query: func(ctx context.Context) (func(*domain.AggregatedEvidence), error) {
+				constraint, err := k.constraint.QueryConstraint(ctx, variant.GeneSymbol)
+				return func(e *domain.AggregatedEvidence) { e.GeneConstraint = constraint }, err
+			}})
+	}
+
+	return gatherEvidence(ctx, sources, k.resilientClient.deadlines)
+}
+
+// SetSourceDeadlines sets how long evidence gathering waits on each source,
+// keyed by clinvar, gnomad, cosmic, pubmed, lovd, hgmd, clingen, splicing,
+// predictors, residues or constraint, and on sources without a deadline of
+// their own. A source missing its deadline leaves its evidence category
+// incomplete rather than holding up the classification.
+func (k *KnowledgeBaseService) SetSourceDeadlines(fallback time.Duration, sources map[string]time.Duration) {
+	k.resilientClient.SetSourceDeadlines(fallback, sources)
 }
 
 // QueryClinVar queries ClinVar database
@@ -84,14 +219,17 @@ func (k *KnowledgeBaseService) GetStats(ctx context.Context) (map[string]interfa
 	stats["circuit_breaker_states"] = k.resilientClient.GetCircuitBreakerStates()
 	
 	// Cache stats
-	cacheStats, err := k.resilientClient.cacheClient.GetStats(ctx)
-	if err == nil {
-		stats["cache_stats"] = cacheStats
-	}
+	stats["cache_stats"] = k.CacheStats(ctx)
 	
 	return stats, nil
 }
 
+// CacheStats returns the size of the evidence cache and its per-source
+// policies and hit counters
+func (k *KnowledgeBaseService) CacheStats(ctx context.Context) CacheStats {
+	return k.resilientClient.cache.Stats(ctx)
+}
+
 // InvalidateCache removes cached data for a variant
 func (k *KnowledgeBaseService) InvalidateCache(ctx context.Context, variant *domain.StandardizedVariant) error {
 	return k.resilientClient.InvalidateCache(ctx, variant)
@@ -102,6 +240,14 @@ func (k *KnowledgeBaseService) Close() error {
 	return k.resilientClient.Close()
 }
 
+// PingCache checks the evidence cache store is reachable
+func (k *KnowledgeBaseService) PingCache(ctx context.Context) error {
+	if k.resilientClient.cache == nil {
+		return fmt.Errorf("evidence cache not configured")
+	}
+	return k.resilientClient.cache.Ping(ctx)
+}
+
 // HealthCheck performs health checks on all external services
 func (k *KnowledgeBaseService) HealthCheck(ctx context.Context) map[string]bool {
 	health := make(map[string]bool)
@@ -114,7 +260,7 @@ func (k *KnowledgeBaseService) HealthCheck(ctx context.Context) map[string]bool
 	}
 	
 	// Check cache connectivity
-	if err := k.resilientClient.cacheClient.Ping(ctx); err == nil {
+	if err := k.resilientClient.cache.Ping(ctx); err == nil {
 		health["cache"] = true
 	} else {
 		health["cache"] = false
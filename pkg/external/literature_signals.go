@@ -0,0 +1,100 @@
+package external
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// maxKeyFindings bounds the abstract sentences kept as a citation's key findings
+const maxKeyFindings = 3
+
+// sentenceBoundary splits abstracts into sentences
+var sentenceBoundary = regexp.MustCompile(`[.!?;]\s+`)
+
+// Phrases that mine literature signals from abstract sentences
+var (
+	functionalContextPattern  = regexp.MustCompile(`\b(functional(ly)?|in vitro|in vivo|assays?|minigene|reporter|enzym\w*|activity|expression|knock-?in|patch[- ]clamp|electrophysiolog\w*|transfect\w*|splicing analysis)\b`)
+	functionalDamagingPattern = regexp.MustCompile(`\b(loss[- ]of[- ]function|gain[- ]of[- ]function|dominant[- ]negative|abolish\w*|abrogat\w*|impair\w*|disrupt\w*|(reduced|decreased|diminished|loss of|no residual) (\w+ )?(activity|function|expression|binding|stability)|deleterious|damaging|aberrant splicing|exon skipping)\b`)
+	functionalNormalPattern   = regexp.MustCompile(`\b(normal (\w+ )?(activity|function|splicing)|comparable to (the )?wild[- ]type|similar to (the )?wild[- ]type|indistinguishable from (the )?wild[- ]type|no (significant )?(effect|impact|difference)|did not (affect|alter|impair|disrupt)|functionally (neutral|normal)|retained (\w+ )?(activity|function))\b`)
+	caseReportPattern         = regexp.MustCompile(`\b(case reports?|we (report|describe|present)|proband|index patient|affected (individuals?|members?|siblings?)|(patients?|families|family|individuals?) (carrying|harbou?ring|with the|heterozygous|homozygous))\b`)
+	pathogenicPattern         = regexp.MustCompile(`\b((likely )?pathogenic|disease[- ]causing|causative|causal (variant|mutation))\b`)
+	notPathogenicPattern      = regexp.MustCompile(`\b(non[- ]?pathogenic|not pathogenic|benign|uncertain significance|polymorphism)\b`)
+)
+
+// mineLiteratureSignals finds functional study, case report and reported
+// pathogenicity signals in an article's title and abstract. It returns the
+// signals and the sentences they were found in.
+func mineLiteratureSignals(title, abstract string, publicationTypes []string) ([]string, []string) {
+	found := make(map[string]bool)
+	var findings []string
+
+	for _, pt := range publicationTypes {
+		if strings.EqualFold(strings.TrimSpace(pt), "Case Reports") {
+			found[domain.SignalCaseReport] = true
+		}
+	}
+
+	sentences := append([]string{title}, sentenceBoundary.Split(abstract, -1)...)
+	for _, sentence := range sentences {
+		text := strings.ToLower(sentence)
+		var signals []string
+
+		if functionalContextPattern.MatchString(text) {
+			switch {
+			case functionalNormalPattern.MatchString(text):
+				signals = append(signals, domain.SignalFunctionalNormal)
+			case functionalDamagingPattern.MatchString(text):
+				signals = append(signals, domain.SignalFunctionalDamaging)
+			}
+		}
+		if caseReportPattern.MatchString(text) {
+			signals = append(signals, domain.SignalCaseReport)
+		}
+		if pathogenicPattern.MatchString(text) && !notPathogenicPattern.MatchString(text) {
+			signals = append(signals, domain.SignalReportedPathogenic)
+		}
+
+		isFinding := false
+		for _, signal := range signals {
+			if signal != domain.SignalCaseReport {
+				isFinding = true
+			}
+			found[signal] = true
+		}
+		if isFinding && len(findings) < maxKeyFindings {
+			findings = append(findings, strings.TrimSpace(sentence))
+		}
+	}
+
+	var signals []string
+	for _, signal := range []string{
+		domain.SignalFunctionalDamaging,
+		domain.SignalFunctionalNormal,
+		domain.SignalCaseReport,
+		domain.SignalReportedPathogenic,
+	} {
+		if found[signal] {
+			signals = append(signals, signal)
+		}
+	}
+	return signals, findings
+}
+
+// mentionsVariant reports whether text names the variant by rsID or by its
+// protein or coding change
+func mentionsVariant(text string, variant *domain.StandardizedVariant) bool {
+	text = strings.ToLower(text)
+	names := []string{variantRSID(variant)}
+	for _, hgvs := range []string{variant.HGVSProtein, variant.HGVSCoding} {
+		change := strings.ToLower(hgvsChange(hgvs))
+		names = append(names, change, strings.TrimPrefix(strings.TrimPrefix(change, "p."), "c."))
+	}
+	for _, name := range names {
+		if len(name) > 3 && strings.Contains(text, name) {
+			return true
+		}
+	}
+	return false
+}
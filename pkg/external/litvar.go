@@ -0,0 +1,150 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// DefaultLitVarURL is the LitVar2 API, which links variants to the PubMed
+// articles that mention them under any of their names
+const DefaultLitVarURL = "https://www.ncbi.nlm.nih.gov/research/litvar2-api"
+
+// rsIDPattern matches a dbSNP reference SNP ID
+var rsIDPattern = regexp.MustCompile(`^rs\d+$`)
+
+// litVarMatch is a LitVar2 autocomplete suggestion for a variant
+type litVarMatch struct {
+	ID         string   `json:"_id"` // e.g. litvar@rs80357906##
+	RSID       string   `json:"rsid"`
+	Gene       []string `json:"gene"`
+	HGVS       string   `json:"hgvs"`
+	PMIDsCount int      `json:"pmids_count"`
+}
+
+// litVarPublications lists the articles LitVar2 links to a variant
+type litVarPublications struct {
+	PMIDs []json.Number `json:"pmids"`
+}
+
+// variantRSID returns the variant's dbSNP ID, when it was identified by one
+func variantRSID(variant *domain.StandardizedVariant) string {
+	id := strings.ToLower(strings.TrimSpace(variant.ID))
+	if rsIDPattern.MatchString(id) {
+		return id
+	}
+	return ""
+}
+
+// litVarQuery builds the LitVar2 search text for a variant: its rsID, or the
+// gene with the protein or coding change
+func litVarQuery(variant *domain.StandardizedVariant) string {
+	if rsID := variantRSID(variant); rsID != "" {
+		return rsID
+	}
+	if variant.GeneSymbol == "" {
+		return ""
+	}
+	for _, hgvs := range []string{variant.HGVSProtein, variant.HGVSCoding} {
+		if change := hgvsChange(hgvs); change != "" {
+			return variant.GeneSymbol + " " + change
+		}
+	}
+	return ""
+}
+
+// hgvsChange strips the reference sequence from an HGVS expression, leaving
+// e.g. p.Arg1699Trp
+func hgvsChange(hgvs string) string {
+	if _, change, ok := strings.Cut(hgvs, ":"); ok {
+		return strings.TrimSpace(change)
+	}
+	return strings.TrimSpace(hgvs)
+}
+
+// litVarPMIDs resolves the variant in LitVar2 and returns the PMIDs of the
+// articles mentioning it. A variant LitVar2 does not know has no PMIDs.
+func (p *PubMedClient) litVarPMIDs(ctx context.Context, variant *domain.StandardizedVariant) (string, []string, error) {
+	query := litVarQuery(variant)
+	if query == "" {
+		return "", nil, nil
+	}
+
+	var matches []litVarMatch
+	if err := p.getLitVar(ctx, "/variant/autocomplete/?"+url.Values{"query": {query}}.Encode(), &matches); err != nil {
+		return query, nil, fmt.Errorf("failed to resolve %q in LitVar: %w", query, err)
+	}
+	match, ok := bestLitVarMatch(matches, variant)
+	if !ok {
+		return query, nil, nil
+	}
+
+	var publications litVarPublications
+	if err := p.getLitVar(ctx, "/variant/get/"+url.PathEscape(match.ID)+"/publications", &publications); err != nil {
+		return query, nil, fmt.Errorf("failed to get LitVar publications for %s: %w", match.ID, err)
+	}
+	pmids := make([]string, 0, len(publications.PMIDs))
+	for _, pmid := range publications.PMIDs {
+		pmids = append(pmids, pmid.String())
+	}
+	return query, pmids, nil
+}
+
+// bestLitVarMatch picks the suggestion for the variant: the one with its
+// rsID, otherwise the first in the variant's gene
+func bestLitVarMatch(matches []litVarMatch, variant *domain.StandardizedVariant) (litVarMatch, bool) {
+	rsID := variantRSID(variant)
+	for _, match := range matches {
+		if match.ID == "" {
+			continue
+		}
+		if rsID != "" {
+			if strings.EqualFold(match.RSID, rsID) {
+				return match, true
+			}
+			continue
+		}
+		for _, gene := range match.Gene {
+			if strings.EqualFold(gene, variant.GeneSymbol) {
+				return match, true
+			}
+		}
+	}
+	return litVarMatch{}, false
+}
+
+// getLitVar fetches a LitVar2 API path into target, within the NCBI rate limit
+func (p *PubMedClient) getLitVar(ctx context.Context, path string, target interface{}) error {
+	if err := p.wait(ctx); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(p.litVarURL, "/")+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create LitVar request: %w", err)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute LitVar request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("LitVar returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read LitVar response: %w", err)
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("failed to parse LitVar response: %w", err)
+	}
+	return nil
+}
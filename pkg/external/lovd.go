@@ -39,12 +39,10 @@ func NewLOVDClient(config LOVDConfig) *LOVDClient {
 	}
 	
 	return &LOVDClient{
-		baseURL: config.BaseURL,
-		apiKey:  config.APIKey,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		rateLimit: time.Second / time.Duration(config.RateLimit),
+		baseURL:    config.BaseURL,
+		apiKey:     config.APIKey,
+		httpClient: newHTTPClient(config.Timeout, 0),
+		rateLimit:  time.Second / time.Duration(config.RateLimit),
 	}
 }
 
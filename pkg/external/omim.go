@@ -0,0 +1,154 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// GeneDiseaseClient looks up the diseases associated with a gene
+type GeneDiseaseClient interface {
+	QueryGeneDiseases(ctx context.Context, gene string) ([]domain.DiseaseAssociation, error)
+}
+
+// OMIMClient queries the gene map of the OMIM API, which requires an API key
+type OMIMClient struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	rateLimit  time.Duration
+}
+
+// NewOMIMClient creates a new OMIM API client
+func NewOMIMClient(config domain.OMIMConfig) *OMIMClient {
+	rateLimit := config.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = 4
+	}
+	return &OMIMClient{
+		baseURL:    strings.TrimRight(config.BaseURL, "/"),
+		apiKey:     config.APIKey,
+		httpClient: newHTTPClient(config.Timeout, 0),
+		rateLimit:  time.Second / time.Duration(rateLimit),
+	}
+}
+
+// omimResponse is the response to a gene map search
+type omimResponse struct {
+	OMIM struct {
+		SearchResponse struct {
+			GeneMapList []struct {
+				GeneMap struct {
+					MIMNumber           int    `json:"mimNumber"`
+					ApprovedGeneSymbols string `json:"approvedGeneSymbols"`
+					GeneSymbols         string `json:"geneSymbols"`
+					PhenotypeMapList    []struct {
+						PhenotypeMap struct {
+							Phenotype              string `json:"phenotype"`
+							PhenotypeMIMNumber     int    `json:"phenotypeMimNumber"`
+							PhenotypeMappingKey    int    `json:"phenotypeMappingKey"`
+							PhenotypeInheritance   string `json:"phenotypeInheritance"`
+							PhenotypicSeriesNumber string `json:"phenotypicSeriesNumber"`
+						} `json:"phenotypeMap"`
+					} `json:"phenotypeMapList"`
+				} `json:"geneMap"`
+			} `json:"geneMapList"`
+		} `json:"searchResponse"`
+	} `json:"omim"`
+}
+
+// QueryGeneDiseases returns the phenotypes OMIM maps to a gene
+func (c *OMIMClient) QueryGeneDiseases(ctx context.Context, gene string) ([]domain.DiseaseAssociation, error) {
+	gene = strings.TrimSpace(gene)
+	if gene == "" {
+		return nil, fmt.Errorf("gene symbol is required")
+	}
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("OMIM API key is not configured")
+	}
+
+	params := url.Values{}
+	params.Set("search", "approved_gene_symbol:"+gene)
+	params.Set("format", "json")
+	params.Set("limit", "10")
+
+	select {
+	case <-time.After(c.rateLimit):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/geneMap/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OMIM request: %w", err)
+	}
+	req.Header.Set("ApiKey", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute OMIM request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OMIM returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OMIM response: %w", err)
+	}
+	return parseOMIMGeneMap(body, gene)
+}
+
+// parseOMIMGeneMap extracts the phenotypes mapped to the gene whose approved
+// symbol matches exactly. Phenotypes keep OMIM's notation: braces mark
+// susceptibility to multifactorial disorders, brackets nondiseases and a
+// question mark a provisional relationship.
+func parseOMIMGeneMap(body []byte, gene string) ([]domain.DiseaseAssociation, error) {
+	var response omimResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse OMIM response: %w", err)
+	}
+
+	diseases := []domain.DiseaseAssociation{}
+	for _, entry := range response.OMIM.SearchResponse.GeneMapList {
+		geneMap := entry.GeneMap
+		if !strings.EqualFold(strings.TrimSpace(geneMap.ApprovedGeneSymbols), gene) {
+			continue
+		}
+		for _, p := range geneMap.PhenotypeMapList {
+			phenotype := p.PhenotypeMap
+			disease := domain.DiseaseAssociation{
+				Source:           domain.DiseaseSourceOMIM,
+				Name:             phenotype.Phenotype,
+				MappingKey:       phenotype.PhenotypeMappingKey,
+				PhenotypicSeries: phenotype.PhenotypicSeriesNumber,
+				Inheritance:      splitOMIMInheritance(phenotype.PhenotypeInheritance),
+			}
+			if phenotype.PhenotypeMIMNumber > 0 {
+				disease.ID = fmt.Sprintf("OMIM:%d", phenotype.PhenotypeMIMNumber)
+			}
+			diseases = append(diseases, disease)
+		}
+	}
+	return diseases, nil
+}
+
+// splitOMIMInheritance splits a phenotype's inheritance, e.g.
+// "Autosomal dominant; Autosomal recessive"
+func splitOMIMInheritance(inheritance string) []string {
+	var modes []string
+	for _, mode := range strings.Split(inheritance, ";") {
+		if mode = strings.TrimSpace(mode); mode != "" {
+			modes = append(modes, mode)
+		}
+	}
+	return modes
+}
@@ -0,0 +1,208 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// oncoKBLevels maps OncoKB levels of evidence onto the AMP/ASCO/CAP levels
+// (Li et al. 2017), following OncoKB's published correspondence
+var oncoKBLevels = map[string]domain.SomaticEvidenceLevel{
+	"LEVEL_1":   domain.LEVEL_A,
+	"LEVEL_2":   domain.LEVEL_A,
+	"LEVEL_3A":  domain.LEVEL_B,
+	"LEVEL_3B":  domain.LEVEL_C,
+	"LEVEL_4":   domain.LEVEL_D,
+	"LEVEL_R1":  domain.LEVEL_A,
+	"LEVEL_R2":  domain.LEVEL_C,
+	"LEVEL_Dx1": domain.LEVEL_A,
+	"LEVEL_Dx2": domain.LEVEL_B,
+	"LEVEL_Dx3": domain.LEVEL_C,
+	"LEVEL_Px1": domain.LEVEL_A,
+	"LEVEL_Px2": domain.LEVEL_B,
+	"LEVEL_Px3": domain.LEVEL_C,
+}
+
+// OncoKBClient queries the OncoKB annotation API for somatic evidence
+type OncoKBClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	rateLimit  time.Duration
+}
+
+// NewOncoKBClient creates a new OncoKB API client
+func NewOncoKBClient(config domain.OncoKBConfig) *OncoKBClient {
+	rateLimit := config.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = 5
+	}
+	return &OncoKBClient{
+		baseURL:    config.BaseURL,
+		token:      config.Token,
+		httpClient: newHTTPClient(config.Timeout, 0),
+		rateLimit:  time.Second / time.Duration(rateLimit),
+	}
+}
+
+// oncoKBTumorType is an OncoKB cancer type
+type oncoKBTumorType struct {
+	Name     string `json:"name"`
+	MainType struct {
+		Name string `json:"name"`
+	} `json:"mainType"`
+}
+
+// label returns the most specific name of the cancer type
+func (t *oncoKBTumorType) label() string {
+	if t == nil {
+		return ""
+	}
+	if t.Name != "" {
+		return t.Name
+	}
+	return t.MainType.Name
+}
+
+// oncoKBImplication is a diagnostic or prognostic implication
+type oncoKBImplication struct {
+	LevelOfEvidence string           `json:"levelOfEvidence"`
+	TumorType       *oncoKBTumorType `json:"tumorType"`
+	PMIDs           []string         `json:"pmids"`
+}
+
+// oncoKBAnnotation is the part of an OncoKB annotation used for tiering
+type oncoKBAnnotation struct {
+	Treatments []struct {
+		Level string `json:"level"`
+		Drugs []struct {
+			DrugName string `json:"drugName"`
+		} `json:"drugs"`
+		LevelAssociatedCancerType *oncoKBTumorType `json:"levelAssociatedCancerType"`
+		PMIDs                     []string         `json:"pmids"`
+	} `json:"treatments"`
+	DiagnosticImplications []oncoKBImplication `json:"diagnosticImplications"`
+	PrognosticImplications []oncoKBImplication `json:"prognosticImplications"`
+}
+
+// Name identifies OncoKB in somatic evidence
+func (c *OncoKBClient) Name() string {
+	return "OncoKB"
+}
+
+// QuerySomaticEvidence annotates the variant by protein change, or by genomic
+// change when the protein change is not known.
+func (c *OncoKBClient) QuerySomaticEvidence(ctx context.Context, variant *domain.StandardizedVariant, tumorType string) ([]domain.SomaticEvidence, error) {
+	params := url.Values{}
+	endpoint := ""
+	if gene, alteration := somaticAlteration(variant); gene != "" && alteration != "" {
+		endpoint = "annotate/mutations/byProteinChange"
+		params.Set("hugoSymbol", gene)
+		params.Set("alteration", alteration)
+	} else if variant.Chromosome != "" && variant.Position > 0 && variant.Reference != "" && variant.Alternative != "" {
+		endpoint = "annotate/mutations/byGenomicChange"
+		end := variant.Position + int64(len(variant.Reference)) - 1
+		params.Set("genomicLocation", fmt.Sprintf("%s,%d,%d,%s,%s", variant.Chromosome, variant.Position, end, variant.Reference, variant.Alternative))
+		params.Set("referenceGenome", "GRCh38")
+	} else {
+		return nil, fmt.Errorf("OncoKB needs a gene and protein change or genomic coordinates")
+	}
+	if tumorType != "" {
+		params.Set("tumorType", tumorType)
+	}
+
+	select {
+	case <-time.After(c.rateLimit):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	requestURL := strings.TrimRight(c.baseURL, "/") + "/" + endpoint + "?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OncoKB request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute OncoKB request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OncoKB returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OncoKB response: %w", err)
+	}
+	return parseOncoKBAnnotation(body)
+}
+
+// parseOncoKBAnnotation converts the leveled treatments and implications of
+// an annotation to somatic evidence
+func parseOncoKBAnnotation(body []byte) ([]domain.SomaticEvidence, error) {
+	var annotation oncoKBAnnotation
+	if err := json.Unmarshal(body, &annotation); err != nil {
+		return nil, fmt.Errorf("failed to parse OncoKB response: %w", err)
+	}
+
+	var evidence []domain.SomaticEvidence
+	for _, treatment := range annotation.Treatments {
+		level, ok := oncoKBLevels[treatment.Level]
+		if !ok {
+			continue
+		}
+		item := domain.SomaticEvidence{
+			Source:       "OncoKB",
+			SourceLevel:  treatment.Level,
+			Level:        level,
+			Type:         domain.SomaticTherapeutic,
+			Significance: "sensitivity",
+			TumorType:    treatment.LevelAssociatedCancerType.label(),
+			References:   treatment.PMIDs,
+		}
+		if strings.HasPrefix(treatment.Level, "LEVEL_R") {
+			item.Significance = "resistance"
+		}
+		for _, drug := range treatment.Drugs {
+			item.Therapies = append(item.Therapies, drug.DrugName)
+		}
+		evidence = append(evidence, item)
+	}
+
+	implications := []struct {
+		kind  string
+		items []oncoKBImplication
+	}{
+		{domain.SomaticDiagnostic, annotation.DiagnosticImplications},
+		{domain.SomaticPrognostic, annotation.PrognosticImplications},
+	}
+	for _, group := range implications {
+		for _, implication := range group.items {
+			level, ok := oncoKBLevels[implication.LevelOfEvidence]
+			if !ok {
+				continue
+			}
+			evidence = append(evidence, domain.SomaticEvidence{
+				Source:      "OncoKB",
+				SourceLevel: implication.LevelOfEvidence,
+				Level:       level,
+				Type:        group.kind,
+				TumorType:   implication.TumorType.label(),
+				References:  implication.PMIDs,
+			})
+		}
+	}
+	return evidence, nil
+}
@@ -0,0 +1,226 @@
+package external
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// OrphanetData holds the Orphadata gene-disease associations (product 6),
+// with the prevalence (product 9, prevalence) and natural history (product
+// 9, ages) of each disorder. OrphanetData is read-only after loading and
+// safe for concurrent use.
+type OrphanetData struct {
+	disorders map[string]*domain.DiseaseAssociation // OrphaCode -> disorder
+	genes     map[string][]orphanetGeneLink         // Upper-case gene symbol -> disorders
+	count     int
+}
+
+// orphanetGeneLink associates a gene with a disorder
+type orphanetGeneLink struct {
+	orphaCode       string
+	associationType string
+}
+
+// orphanetDisorder is a Disorder element of any of the Orphadata products
+type orphanetDisorder struct {
+	OrphaCode    string `xml:"OrphaCode"`
+	Name         string `xml:"Name"`
+	Associations []struct {
+		Symbol string `xml:"Gene>Symbol"`
+		Type   string `xml:"DisorderGeneAssociationType>Name"`
+	} `xml:"DisorderGeneAssociationList>DisorderGeneAssociation"`
+	Prevalence []struct {
+		Type       string `xml:"PrevalenceType>Name"`
+		Class      string `xml:"PrevalenceClass>Name"`
+		Value      string `xml:"ValMoy"`
+		Geographic string `xml:"PrevalenceGeographic>Name"`
+		Status     string `xml:"PrevalenceValidationStatus>Name"`
+	} `xml:"PrevalenceList>Prevalence"`
+	AgeOfOnset  []string `xml:"AverageAgeOfOnsetList>AverageAgeOfOnset>Name"`
+	Inheritance []string `xml:"TypeOfInheritanceList>TypeOfInheritance>Name"`
+}
+
+// NewOrphanetData creates an empty Orphanet store
+func NewOrphanetData() *OrphanetData {
+	return &OrphanetData{
+		disorders: make(map[string]*domain.DiseaseAssociation),
+		genes:     make(map[string][]orphanetGeneLink),
+	}
+}
+
+// LoadOrphanetDir loads the Orphadata XML products in a directory: the
+// gene associations (en_product6.xml), prevalence (en_product9_prev.xml)
+// and natural history (en_product9_ages.xml). A missing directory yields
+// an empty store.
+func LoadOrphanetDir(dir string) (*OrphanetData, error) {
+	data := NewOrphanetData()
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Orphanet directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := strings.ToLower(entry.Name())
+		if entry.IsDir() || !strings.HasSuffix(name, ".xml") || !strings.Contains(name, "product") {
+			continue
+		}
+		file, err := os.Open(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open Orphanet file: %w", err)
+		}
+		err = data.read(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Orphanet file %s: %w", entry.Name(), err)
+		}
+	}
+	return data, nil
+}
+
+// read merges the disorders of an Orphadata product into the store. The
+// files are large, so disorders are decoded one at a time.
+func (o *OrphanetData) read(reader io.Reader) error {
+	decoder := xml.NewDecoder(reader)
+	decoder.CharsetReader = orphanetCharsetReader
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "Disorder" {
+			continue
+		}
+		var disorder orphanetDisorder
+		if err := decoder.DecodeElement(&disorder, &start); err != nil {
+			return err
+		}
+		o.merge(&disorder)
+	}
+}
+
+// orphanetCharsetReader decodes the ISO-8859-1 the Orphadata products
+// declare; each byte is the code point of its character
+func orphanetCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "latin-1":
+		return &latin1Reader{source: bufio.NewReader(input)}, nil
+	}
+	return nil, fmt.Errorf("unsupported Orphanet encoding %q", charset)
+}
+
+// latin1Reader converts ISO-8859-1 to UTF-8
+type latin1Reader struct {
+	source  *bufio.Reader
+	pending []byte
+}
+
+func (r *latin1Reader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.pending) > 0 {
+			copied := copy(p[n:], r.pending)
+			r.pending = r.pending[copied:]
+			n += copied
+			continue
+		}
+		b, err := r.source.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		if b < utf8.RuneSelf {
+			p[n] = b
+			n++
+			continue
+		}
+		r.pending = utf8.AppendRune(nil, rune(b))
+	}
+	return n, nil
+}
+
+// merge adds what a product says about a disorder
+func (o *OrphanetData) merge(disorder *orphanetDisorder) {
+	code := strings.TrimSpace(disorder.OrphaCode)
+	if code == "" {
+		return
+	}
+	entry, ok := o.disorders[code]
+	if !ok {
+		entry = &domain.DiseaseAssociation{Source: domain.DiseaseSourceOrphanet, ID: "ORPHA:" + code}
+		o.disorders[code] = entry
+	}
+	if name := strings.TrimSpace(disorder.Name); name != "" {
+		entry.Name = name
+	}
+
+	for _, association := range disorder.Associations {
+		symbol := strings.ToUpper(strings.TrimSpace(association.Symbol))
+		if symbol == "" {
+			continue
+		}
+		o.genes[symbol] = append(o.genes[symbol], orphanetGeneLink{orphaCode: code, associationType: strings.TrimSpace(association.Type)})
+		o.count++
+	}
+	for _, p := range disorder.Prevalence {
+		prevalence := domain.DiseasePrevalence{
+			Type:       strings.TrimSpace(p.Type),
+			Class:      strings.TrimSpace(p.Class),
+			Geographic: strings.TrimSpace(p.Geographic),
+			Validated:  strings.EqualFold(strings.TrimSpace(p.Status), "Validated"),
+		}
+		if value, err := strconv.ParseFloat(strings.TrimSpace(p.Value), 64); err == nil {
+			prevalence.Value = value
+		}
+		entry.Prevalence = append(entry.Prevalence, prevalence)
+	}
+	for _, onset := range disorder.AgeOfOnset {
+		if onset = strings.TrimSpace(onset); onset != "" {
+			entry.AgeOfOnset = append(entry.AgeOfOnset, onset)
+		}
+	}
+	for _, inheritance := range disorder.Inheritance {
+		if inheritance = strings.TrimSpace(inheritance); inheritance != "" {
+			entry.Inheritance = append(entry.Inheritance, inheritance)
+		}
+	}
+}
+
+// QueryGeneDiseases returns the disorders Orphanet associates with a gene
+func (o *OrphanetData) QueryGeneDiseases(ctx context.Context, gene string) ([]domain.DiseaseAssociation, error) {
+	links := o.genes[strings.ToUpper(strings.TrimSpace(gene))]
+	diseases := make([]domain.DiseaseAssociation, 0, len(links))
+	for _, link := range links {
+		disease := *o.disorders[link.orphaCode]
+		disease.AssociationType = link.associationType
+		diseases = append(diseases, disease)
+	}
+	return diseases, nil
+}
+
+// Count returns the number of loaded gene-disease associations
+func (o *OrphanetData) Count() int {
+	if o == nil {
+		return 0
+	}
+	return o.count
+}
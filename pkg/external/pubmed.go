@@ -4,28 +4,39 @@ import (
 	"context"
 	"encoding/xml"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"github.com/acmg-amp-mcp-server/internal/domain"
 )
 
-// PubMedClient handles interactions with NCBI PubMed via E-utilities
+// maxLiteratureArticles bounds the articles whose abstracts are fetched per variant
+const maxLiteratureArticles = 20
+
+// PubMedClient handles interactions with NCBI PubMed via E-utilities, and
+// with LitVar2 to find the articles mentioning a variant. E-utilities and
+// LitVar2 requests share one NCBI rate limit.
 type PubMedClient struct {
 	baseURL    string
+	litVarURL  string
 	apiKey     string
 	httpClient *http.Client
-	rateLimit  time.Duration
+	limiter    *rate.Limiter
 	email      string // Required by NCBI for large-scale queries
 }
 
 // PubMedConfig contains configuration for PubMed client
 type PubMedConfig struct {
 	BaseURL   string
+	LitVarURL string // Defaults to DefaultLitVarURL
 	APIKey    string
 	Email     string
 	Timeout   time.Duration
@@ -37,19 +48,37 @@ func NewPubMedClient(config PubMedConfig) *PubMedClient {
 	if config.BaseURL == "" {
 		config.BaseURL = "https://eutils.ncbi.nlm.nih.gov/entrez/eutils/"
 	}
+	if config.LitVarURL == "" {
+		config.LitVarURL = DefaultLitVarURL
+	}
 	if config.RateLimit == 0 {
 		config.RateLimit = 3 // 3 requests per second (with API key)
 	}
 	
 	return &PubMedClient{
-		baseURL: config.BaseURL,
-		apiKey:  config.APIKey,
-		email:   config.Email,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		rateLimit: time.Second / time.Duration(config.RateLimit),
+		baseURL:    config.BaseURL,
+		litVarURL:  config.LitVarURL,
+		apiKey:     config.APIKey,
+		email:      config.Email,
+		httpClient: newHTTPClient(config.Timeout, 0),
+		limiter:    rate.NewLimiter(rate.Limit(config.RateLimit), 1),
+	}
+}
+
+// wait blocks until the NCBI rate limit allows another request
+func (p *PubMedClient) wait(ctx context.Context) error {
+	return p.limiter.Wait(ctx)
+}
+
+// eutilsURL returns the URL of an E-utility with its query parameters
+func (p *PubMedClient) eutilsURL(utility string, params url.Values) string {
+	if p.apiKey != "" {
+		params.Set("api_key", p.apiKey)
 	}
+	if p.email != "" {
+		params.Set("email", p.email)
+	}
+	return strings.TrimRight(p.baseURL, "/") + "/" + utility + "?" + params.Encode()
 }
 
 // PubMedSearchResponse represents the XML response from PubMed search
@@ -88,55 +117,67 @@ type PubMedAbstractResponse struct {
 	Articles     []PubmedArticle `xml:"PubmedArticle"`
 }
 
-// PubmedArticle represents a complete article from PubMed
+// PubmedArticle represents a complete article from PubMed. The title and
+// abstract keep their inline markup, such as <i>, for cleanXMLValue.
 type PubmedArticle struct {
 	MedlineCitation struct {
 		PMID    string `xml:"PMID"`
 		Article struct {
-			ArticleTitle string `xml:"ArticleTitle"`
+			ArticleTitle struct {
+				Text string `xml:",innerxml"`
+			} `xml:"ArticleTitle"`
 			Abstract     struct {
-				AbstractText []string `xml:"AbstractText"`
+				AbstractText []struct {
+					Label string `xml:"Label,attr"`
+					Text  string `xml:",innerxml"`
+				} `xml:"AbstractText"`
 			} `xml:"Abstract"`
 			AuthorList struct {
 				Authors []struct {
 					LastName  string `xml:"LastName"`
 					ForeName  string `xml:"ForeName"`
+					Initials  string `xml:"Initials"`
 				} `xml:"Author"`
 			} `xml:"AuthorList"`
 			Journal struct {
 				Title         string `xml:"Title"`
 				ISOAbbreviation string `xml:"ISOAbbreviation"`
+				ISSN          string `xml:"ISSN"`
 				JournalIssue struct {
 					PubDate struct {
-						Year  string `xml:"Year"`
-						Month string `xml:"Month"`
+						Year        string `xml:"Year"`
+						Month       string `xml:"Month"`
+						MedlineDate string `xml:"MedlineDate"`
 					} `xml:"PubDate"`
 				} `xml:"JournalIssue"`
 			} `xml:"Journal"`
+			PublicationTypes []string `xml:"PublicationTypeList>PublicationType"`
 		} `xml:"Article"`
 	} `xml:"MedlineCitation"`
+	PubmedData struct {
+		ArticleIDs []struct {
+			IDType string `xml:"IdType,attr"`
+			Value  string `xml:",chardata"`
+		} `xml:"ArticleIdList>ArticleId"`
+	} `xml:"PubmedData"`
 }
 
-// QueryLiterature searches PubMed for literature related to a variant
+// QueryLiterature finds the literature on a variant: the articles LitVar2
+// links to its rsID or HGVS names, falling back to a PubMed search when
+// LitVar2 has none. Abstracts of up to maxLiteratureArticles articles are
+// fetched and mined for functional study and case report signals.
 func (p *PubMedClient) QueryLiterature(ctx context.Context, variant *domain.StandardizedVariant) (*domain.LiteratureData, error) {
-	// Rate limiting
-	select {
-	case <-time.After(p.rateLimit):
-		// Proceed after rate limit delay
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	}
-
-	// Build search query for the variant
-	searchQuery := p.buildSearchQuery(variant)
-	if searchQuery == "" {
-		return &domain.LiteratureData{}, nil
-	}
-
-	// Search PubMed for articles
-	pmids, err := p.searchArticles(ctx, searchQuery)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search PubMed: %w", err)
+	// LitVar2 is best effort: the PubMed search still finds articles naming the gene
+	searchQuery, pmids, err := p.litVarPMIDs(ctx, variant)
+	if err != nil || len(pmids) == 0 {
+		searchQuery = p.buildSearchQuery(variant)
+		if searchQuery == "" {
+			return &domain.LiteratureData{}, nil
+		}
+		pmids, err = p.searchArticles(ctx, searchQuery)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search PubMed: %w", err)
+		}
 	}
 
 	if len(pmids) == 0 {
@@ -146,22 +187,24 @@ func (p *PubMedClient) QueryLiterature(ctx context.Context, variant *domain.Stan
 		}, nil
 	}
 
-	// Get summaries for the first 20 articles
-	maxResults := 20
-	if len(pmids) > maxResults {
-		pmids = pmids[:maxResults]
+	total := len(pmids)
+	if len(pmids) > maxLiteratureArticles {
+		pmids = pmids[:maxLiteratureArticles]
 	}
 
-	summaries, err := p.getArticleSummaries(ctx, pmids)
+	articles, err := p.fetchAbstracts(ctx, pmids)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get article summaries: %w", err)
+		return nil, fmt.Errorf("failed to get article abstracts: %w", err)
 	}
 
 	// Convert to domain objects
-	citations := p.convertToCitations(summaries)
+	citations := make([]domain.Citation, 0, len(articles))
+	for _, article := range articles {
+		citations = append(citations, p.articleCitation(article, variant))
+	}
 
 	return &domain.LiteratureData{
-		TotalCitations:      len(pmids),
+		TotalCitations:      total,
 		RetrievedCitations:  len(citations),
 		Citations:           citations,
 		SearchQuery:         searchQuery,
@@ -173,8 +216,10 @@ func (p *PubMedClient) QueryLiterature(ctx context.Context, variant *domain.Stan
 
 // searchArticles performs the initial search and returns PMIDs
 func (p *PubMedClient) searchArticles(ctx context.Context, query string) ([]string, error) {
-	searchURL := fmt.Sprintf("%sesearch.fcgi", p.baseURL)
-	
+	if err := p.wait(ctx); err != nil {
+		return nil, err
+	}
+
 	params := url.Values{
 		"db":       {"pubmed"},
 		"term":     {query},
@@ -182,15 +227,7 @@ func (p *PubMedClient) searchArticles(ctx context.Context, query string) ([]stri
 		"retmax":   {"100"}, // Get up to 100 results
 		"usehistory": {"y"}, // Use history for large result sets
 	}
-	
-	if p.apiKey != "" {
-		params.Set("api_key", p.apiKey)
-	}
-	if p.email != "" {
-		params.Set("email", p.email)
-	}
-
-	fullURL := fmt.Sprintf("%s?%s", searchURL, params.Encode())
+	fullURL := p.eutilsURL("esearch.fcgi", params)
 	
 	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
 	if err != nil {
@@ -220,56 +257,103 @@ func (p *PubMedClient) searchArticles(ctx context.Context, query string) ([]stri
 	return searchResponse.IDList.IDs, nil
 }
 
-// getArticleSummaries retrieves summaries for given PMIDs
-func (p *PubMedClient) getArticleSummaries(ctx context.Context, pmids []string) ([]PubMedDocumentSummary, error) {
-	// Rate limiting for the second request
-	select {
-	case <-time.After(p.rateLimit):
-		// Proceed after rate limit delay
-	case <-ctx.Done():
-		return nil, ctx.Err()
+// fetchAbstracts retrieves the full records, abstracts included, for PMIDs
+func (p *PubMedClient) fetchAbstracts(ctx context.Context, pmids []string) ([]PubmedArticle, error) {
+	if err := p.wait(ctx); err != nil {
+		return nil, err
 	}
 
-	summaryURL := fmt.Sprintf("%sesummary.fcgi", p.baseURL)
-	
 	params := url.Values{
 		"db":      {"pubmed"},
 		"id":      {strings.Join(pmids, ",")},
 		"retmode": {"xml"},
 	}
-	
-	if p.apiKey != "" {
-		params.Set("api_key", p.apiKey)
-	}
-
-	fullURL := fmt.Sprintf("%s?%s", summaryURL, params.Encode())
-	
-	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.eutilsURL("efetch.fcgi", params), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create summary request: %w", err)
+		return nil, fmt.Errorf("failed to create fetch request: %w", err)
 	}
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute summary request: %w", err)
+		return nil, fmt.Errorf("failed to execute fetch request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("PubMed summary returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("PubMed fetch returned status %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read summary response: %w", err)
+		return nil, fmt.Errorf("failed to read fetch response: %w", err)
+	}
+
+	var response PubMedAbstractResponse
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse fetch response: %w", err)
+	}
+	return response.Articles, nil
+}
+
+// articleCitation converts a PubMed record to a citation and mines its
+// abstract for evidence signals
+func (p *PubMedClient) articleCitation(article PubmedArticle, variant *domain.StandardizedVariant) domain.Citation {
+	record := article.MedlineCitation.Article
+	citation := domain.Citation{
+		PMID:     strings.TrimSpace(article.MedlineCitation.PMID),
+		Title:    p.cleanXMLValue(record.ArticleTitle.Text),
+		Journal:  p.cleanXMLValue(record.Journal.Title),
+		ISSN:     strings.TrimSpace(record.Journal.ISSN),
+		Database: "PubMed",
+	}
+	if citation.Journal == "" {
+		citation.Journal = p.cleanXMLValue(record.Journal.ISOAbbreviation)
+	}
+
+	for _, author := range record.AuthorList.Authors {
+		name := strings.TrimSpace(author.LastName + " " + author.Initials)
+		if name != "" {
+			citation.Authors = append(citation.Authors, name)
+		}
 	}
 
-	var summaryResponse PubMedSummaryResponse
-	if err := xml.Unmarshal(body, &summaryResponse); err != nil {
-		return nil, fmt.Errorf("failed to parse summary response: %w", err)
+	pubDate := record.Journal.JournalIssue.PubDate
+	for _, date := range []string{pubDate.Year, pubDate.MedlineDate} {
+		if year, err := p.extractYear(date); err == nil {
+			citation.Year = year
+			break
+		}
 	}
 
-	return summaryResponse.DocumentSummary, nil
+	for _, id := range article.PubmedData.ArticleIDs {
+		if id.IDType == "doi" {
+			citation.DOI = strings.TrimSpace(id.Value)
+		}
+	}
+
+	var sections []string
+	for _, text := range record.Abstract.AbstractText {
+		section := p.cleanXMLValue(text.Text)
+		if text.Label != "" && section != "" {
+			section = text.Label + ": " + section
+		}
+		if section != "" {
+			sections = append(sections, section)
+		}
+	}
+	citation.Abstract = strings.Join(sections, " ")
+
+	citation.Signals, citation.KeyFindings = mineLiteratureSignals(citation.Title, citation.Abstract, record.PublicationTypes)
+	citation.StudyType = p.determineStudyType(citation.Title)
+	if citation.StudyType == "other" && citation.HasSignal(domain.SignalCaseReport) {
+		citation.StudyType = "case_report"
+	}
+	citation.Relevance = p.assessRelevance(citation.Title)
+	if mentionsVariant(citation.Title+" "+citation.Abstract, variant) {
+		citation.Relevance = "high"
+	}
+
+	return citation
 }
 
 // buildSearchQuery constructs a search query for PubMed
@@ -316,57 +400,6 @@ func (p *PubMedClient) buildSearchQuery(variant *domain.StandardizedVariant) str
 	return ""
 }
 
-// convertToCitations converts PubMed summaries to domain citations
-func (p *PubMedClient) convertToCitations(summaries []PubMedDocumentSummary) []domain.Citation {
-	var citations []domain.Citation
-
-	for _, summary := range summaries {
-		citation := domain.Citation{
-			PMID:        summary.UID,
-			Database:    "PubMed",
-		}
-
-		// Extract fields from items
-		for _, item := range summary.Items {
-			switch item.Name {
-			case "Title":
-				citation.Title = p.cleanXMLValue(item.Value)
-			case "AuthorList":
-				citation.Authors = p.parseAuthors(item.Value)
-			case "Source":
-				citation.Journal = p.cleanXMLValue(item.Value)
-			case "PubDate":
-				if year, err := p.extractYear(item.Value); err == nil {
-					citation.Year = year
-				}
-			case "ISSN":
-				citation.ISSN = p.cleanXMLValue(item.Value)
-			}
-		}
-
-		// Assess relevance based on title and context
-		citation.Relevance = p.assessRelevance(citation.Title)
-		citation.StudyType = p.determineStudyType(citation.Title)
-
-		citations = append(citations, citation)
-	}
-
-	return citations
-}
-
-// parseAuthors extracts author names from XML
-func (p *PubMedClient) parseAuthors(authorXML string) []string {
-	// Simple extraction - in practice, this would need more sophisticated XML parsing
-	authors := strings.Split(authorXML, ",")
-	var cleanAuthors []string
-	for _, author := range authors {
-		if trimmed := strings.TrimSpace(author); trimmed != "" {
-			cleanAuthors = append(cleanAuthors, trimmed)
-		}
-	}
-	return cleanAuthors
-}
-
 // extractYear extracts publication year from date string
 func (p *PubMedClient) extractYear(dateStr string) (int, error) {
 	// Try different date formats
@@ -384,22 +417,14 @@ func (p *PubMedClient) extractYear(dateStr string) (int, error) {
 	return 0, fmt.Errorf("could not extract year from: %s", dateStr)
 }
 
-// cleanXMLValue removes XML tags and cleans up text
+// xmlTagPattern matches inline markup such as <i> or <sup> in titles and abstracts
+var xmlTagPattern = regexp.MustCompile(`</?[A-Za-z][^>]*>`)
+
+// cleanXMLValue removes inline XML markup, decodes entities and collapses whitespace
 func (p *PubMedClient) cleanXMLValue(value string) string {
-	// Remove common XML tags
-	cleaners := []string{
-		"<b>", "</b>",
-		"<i>", "</i>",
-		"<em>", "</em>",
-		"<strong>", "</strong>",
-	}
-	
-	result := value
-	for _, cleaner := range cleaners {
-		result = strings.ReplaceAll(result, cleaner, "")
-	}
-	
-	return strings.TrimSpace(result)
+	result := xmlTagPattern.ReplaceAllString(value, "")
+	result = html.UnescapeString(result)
+	return strings.Join(strings.Fields(result), " ")
 }
 
 // assessRelevance determines the relevance score of a citation
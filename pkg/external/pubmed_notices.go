@@ -0,0 +1,125 @@
+package external
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// pubMedNoticeBatch is the number of PMIDs fetched per efetch request
+const pubMedNoticeBatch = 200
+
+// pubMedNoticeKinds maps PubMed comment/correction types onto notice kinds
+var pubMedNoticeKinds = map[string]string{
+	"RetractionIn": domain.NoticeRetraction,
+	"ErratumIn":    domain.NoticeErratum,
+}
+
+// pubMedNoticeResponse is the part of an efetch response that carries notices
+type pubMedNoticeResponse struct {
+	Articles []struct {
+		MedlineCitation struct {
+			PMID    string `xml:"PMID"`
+			Article struct {
+				PublicationTypes []string `xml:"PublicationTypeList>PublicationType"`
+			} `xml:"Article"`
+			CommentsCorrections []struct {
+				RefType   string `xml:"RefType,attr"`
+				RefSource string `xml:"RefSource"`
+				PMID      string `xml:"PMID"`
+			} `xml:"CommentsCorrectionsList>CommentsCorrections"`
+		} `xml:"MedlineCitation"`
+	} `xml:"PubmedArticle"`
+}
+
+// QueryNotices looks up retraction and erratum notices for articles. Articles
+// without notices are left out of the result.
+func (p *PubMedClient) QueryNotices(ctx context.Context, pmids []string) (map[string][]domain.CitationNotice, error) {
+	notices := make(map[string][]domain.CitationNotice)
+	for start := 0; start < len(pmids); start += pubMedNoticeBatch {
+		end := start + pubMedNoticeBatch
+		if end > len(pmids) {
+			end = len(pmids)
+		}
+		if err := p.fetchNotices(ctx, pmids[start:end], notices); err != nil {
+			return nil, err
+		}
+	}
+	return notices, nil
+}
+
+// fetchNotices fetches one batch of records and adds their notices
+func (p *PubMedClient) fetchNotices(ctx context.Context, pmids []string, notices map[string][]domain.CitationNotice) error {
+	if err := p.wait(ctx); err != nil {
+		return err
+	}
+
+	params := url.Values{
+		"db":      {"pubmed"},
+		"id":      {strings.Join(pmids, ",")},
+		"retmode": {"xml"},
+	}
+	fetchURL := p.eutilsURL("efetch.fcgi", params)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fetchURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create fetch request: %w", err)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute fetch request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("PubMed fetch returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read fetch response: %w", err)
+	}
+	return parsePubMedNotices(body, notices)
+}
+
+// parsePubMedNotices adds the notices in an efetch response to notices
+func parsePubMedNotices(body []byte, notices map[string][]domain.CitationNotice) error {
+	var response pubMedNoticeResponse
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to parse fetch response: %w", err)
+	}
+
+	for _, article := range response.Articles {
+		citation := article.MedlineCitation
+		pmid := strings.TrimSpace(citation.PMID)
+		retracted := false
+		for _, cc := range citation.CommentsCorrections {
+			kind, ok := pubMedNoticeKinds[cc.RefType]
+			if !ok {
+				continue
+			}
+			retracted = retracted || kind == domain.NoticeRetraction
+			notices[pmid] = append(notices[pmid], domain.CitationNotice{
+				Kind:       kind,
+				NoticePMID: strings.TrimSpace(cc.PMID),
+				Source:     strings.TrimSpace(cc.RefSource),
+			})
+		}
+
+		// Some retractions are flagged by publication type before the notice is linked
+		if !retracted {
+			for _, pt := range citation.Article.PublicationTypes {
+				if strings.EqualFold(strings.TrimSpace(pt), "Retracted Publication") {
+					notices[pmid] = append(notices[pmid], domain.CitationNotice{Kind: domain.NoticeRetraction})
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
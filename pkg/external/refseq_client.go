@@ -88,7 +88,7 @@ func NewRefSeqClient(config RefSeqConfig) *RefSeqClient {
 	return &RefSeqClient{
 		baseURL:    config.BaseURL,
 		apiKey:     config.APIKey,
-		httpClient: &http.Client{Timeout: config.Timeout},
+		httpClient: newHTTPClient(config.Timeout, 0),
 		rateLimit:  rate.NewLimiter(rate.Limit(config.RateLimit), 1),
 	}
 }
@@ -0,0 +1,26 @@
+package external
+
+import (
+	"context"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// SomaticEvidenceClient queries a cancer knowledge base for therapeutic,
+// diagnostic and prognostic evidence on a somatic variant
+type SomaticEvidenceClient interface {
+	// Name identifies the knowledge base in results, e.g. OncoKB
+	Name() string
+
+	// QuerySomaticEvidence returns the evidence for the variant. The tumor type
+	// may be empty; matching evidence to the tumor type is left to the caller.
+	QuerySomaticEvidence(ctx context.Context, variant *domain.StandardizedVariant, tumorType string) ([]domain.SomaticEvidence, error)
+}
+
+// somaticAlteration returns the gene and one-letter protein change that
+// cancer knowledge bases index variants by, e.g. BRAF and V600E
+func somaticAlteration(variant *domain.StandardizedVariant) (string, string) {
+	return strings.ToUpper(strings.TrimSpace(variant.GeneSymbol)), hgvs.ShortProteinChange(variant.HGVSProtein)
+}
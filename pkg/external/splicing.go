@@ -0,0 +1,321 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// Splicing prediction tools
+const (
+	SplicingToolSpliceAI = "SpliceAI"
+	SplicingToolPangolin = "Pangolin"
+)
+
+// SplicingPredictionClient predicts the splicing impact of a variant
+type SplicingPredictionClient interface {
+	QueryVariant(ctx context.Context, variant *domain.StandardizedVariant) ([]domain.SplicingPrediction, error)
+}
+
+// splicingEvents maps delta score fields to prediction events. SpliceAI
+// reports acceptor and donor gain/loss; Pangolin reports gain and loss only.
+var splicingEvents = map[string]string{
+	"DS_AG": domain.SpliceEventAcceptorGain,
+	"DS_AL": domain.SpliceEventAcceptorLoss,
+	"DS_DG": domain.SpliceEventDonorGain,
+	"DS_DL": domain.SpliceEventDonorLoss,
+	"DS_SG": domain.SpliceEventGain,
+	"DS_SL": domain.SpliceEventLoss,
+}
+
+// newSplicingPrediction builds a prediction from delta scores keyed by DS_*
+// field and positions keyed by DP_* field. Pangolin reports splice loss as a
+// negative delta, so scores are compared by magnitude.
+func newSplicingPrediction(tool, gene, source string, scores map[string]float64, positions map[string]int) domain.SplicingPrediction {
+	prediction := domain.SplicingPrediction{
+		Tool:   tool,
+		Gene:   gene,
+		Scores: make(map[string]float64, len(scores)),
+		Source: source,
+	}
+
+	// Sorted for a deterministic event when two deltas tie
+	fields := make([]string, 0, len(scores))
+	for field := range scores {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		event, ok := splicingEvents[field]
+		if !ok {
+			continue
+		}
+		score := math.Abs(scores[field])
+		prediction.Scores[event] = score
+		if prediction.Event == "" || score > prediction.Score {
+			prediction.Score = score
+			prediction.Event = event
+			prediction.Position = positions["DP_"+strings.TrimPrefix(field, "DS_")]
+		}
+	}
+	return prediction
+}
+
+// SplicingLookupClient queries a SpliceAI lookup API (the Broad Institute
+// spliceailookup service or a self-hosted copy) for SpliceAI or Pangolin scores
+type SplicingLookupClient struct {
+	baseURL    string
+	tool       string
+	genome     string
+	distance   int
+	httpClient *http.Client
+	rateLimit  time.Duration
+}
+
+// NewSpliceAIClient creates a lookup API client for SpliceAI scores
+func NewSpliceAIClient(config domain.SplicingConfig) *SplicingLookupClient {
+	return newSplicingLookupClient(SplicingToolSpliceAI, config)
+}
+
+// NewPangolinClient creates a lookup API client for Pangolin scores
+func NewPangolinClient(config domain.SplicingConfig) *SplicingLookupClient {
+	return newSplicingLookupClient(SplicingToolPangolin, config)
+}
+
+func newSplicingLookupClient(tool string, config domain.SplicingConfig) *SplicingLookupClient {
+	genome := "38"
+	if config.Genome != "" {
+		genome = strings.TrimPrefix(strings.ToLower(config.Genome), "grch")
+	}
+	distance := 500
+	if config.Distance > 0 {
+		distance = config.Distance
+	}
+	rateLimit := 2
+	if config.RateLimit > 0 {
+		rateLimit = config.RateLimit
+	}
+	return &SplicingLookupClient{
+		baseURL:    config.BaseURL,
+		tool:       tool,
+		genome:     genome,
+		distance:   distance,
+		httpClient: newHTTPClient(config.Timeout, 0),
+		rateLimit:  time.Second / time.Duration(rateLimit),
+	}
+}
+
+// SplicingLookupResponse represents a response from the SpliceAI lookup API.
+// Score values are returned as strings or numbers depending on the version.
+type SplicingLookupResponse struct {
+	Variant string                   `json:"variant"`
+	Scores  []map[string]interface{} `json:"scores"`
+	Error   string                   `json:"error"`
+}
+
+// QueryVariant returns the strongest prediction across transcripts. A variant
+// without scores yields no predictions, not an error.
+func (s *SplicingLookupClient) QueryVariant(ctx context.Context, variant *domain.StandardizedVariant) ([]domain.SplicingPrediction, error) {
+	// Rate limiting
+	select {
+	case <-time.After(s.rateLimit):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	variantID, err := gnomADVariantID(variant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build variant ID for %s: %w", s.tool, err)
+	}
+	if s.genome == "38" {
+		variantID = "chr" + variantID
+	}
+
+	response, err := s.lookup(ctx, variantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", s.tool, err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("%s API error: %s", s.tool, response.Error)
+	}
+
+	var best *domain.SplicingPrediction
+	for _, transcript := range response.Scores {
+		prediction := s.convertScores(transcript)
+		if best == nil || prediction.Score > best.Score {
+			best = &prediction
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+	return []domain.SplicingPrediction{*best}, nil
+}
+
+// lookup executes a lookup API request
+func (s *SplicingLookupClient) lookup(ctx context.Context, variantID string) (*SplicingLookupResponse, error) {
+	path := "spliceai"
+	if s.tool == SplicingToolPangolin {
+		path = "pangolin"
+	}
+	params := url.Values{}
+	params.Set("hg", s.genome)
+	params.Set("distance", strconv.Itoa(s.distance))
+	params.Set("mask", "0")
+	params.Set("variant", variantID)
+	queryURL := fmt.Sprintf("%s/%s/?%s", strings.TrimSuffix(s.baseURL, "/"), path, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", queryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s API returned status %d: %s", s.tool, resp.StatusCode, string(body))
+	}
+
+	var response SplicingLookupResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &response, nil
+}
+
+// convertScores converts one transcript's lookup scores to a prediction
+func (s *SplicingLookupClient) convertScores(transcript map[string]interface{}) domain.SplicingPrediction {
+	scores := make(map[string]float64)
+	positions := make(map[string]int)
+	for field, value := range transcript {
+		number, ok := lookupNumber(value)
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(field, "DS_"):
+			scores[field] = number
+		case strings.HasPrefix(field, "DP_"):
+			positions[field] = int(number)
+		}
+	}
+
+	gene, _ := transcript["g_name"].(string)
+	if gene == "" {
+		gene, _ = transcript["SYMBOL"].(string)
+	}
+	return newSplicingPrediction(s.tool, gene, "lookup_api", scores, positions)
+}
+
+// lookupNumber reads a score that may be encoded as a string or a number
+func lookupNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		number, err := strconv.ParseFloat(v, 64)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+// SplicingPredictor combines precomputed scores with the lookup API: tools
+// found in the scores file are not queried remotely
+type SplicingPredictor struct {
+	scores  *SplicingScores
+	clients []*SplicingLookupClient
+	tools   map[string]bool
+}
+
+// NewSplicingPredictor creates a predictor from the splicing configuration.
+// The scores file, when set, is loaded eagerly so a bad path fails at startup.
+func NewSplicingPredictor(config domain.SplicingConfig) (*SplicingPredictor, error) {
+	tools := config.Tools
+	if len(tools) == 0 {
+		tools = []string{SplicingToolSpliceAI, SplicingToolPangolin}
+	}
+
+	predictor := &SplicingPredictor{tools: make(map[string]bool)}
+	for _, tool := range tools {
+		switch {
+		case strings.EqualFold(tool, SplicingToolSpliceAI):
+			predictor.tools[SplicingToolSpliceAI] = true
+		case strings.EqualFold(tool, SplicingToolPangolin):
+			predictor.tools[SplicingToolPangolin] = true
+		default:
+			return nil, fmt.Errorf("unknown splicing prediction tool %q", tool)
+		}
+	}
+
+	if config.ScoresFile != "" {
+		scores, err := LoadSplicingScores(config.ScoresFile)
+		if err != nil {
+			return nil, err
+		}
+		predictor.scores = scores
+	}
+	if config.BaseURL != "" {
+		if predictor.tools[SplicingToolSpliceAI] {
+			predictor.clients = append(predictor.clients, NewSpliceAIClient(config))
+		}
+		if predictor.tools[SplicingToolPangolin] {
+			predictor.clients = append(predictor.clients, NewPangolinClient(config))
+		}
+	}
+	return predictor, nil
+}
+
+// QueryVariant returns one prediction per tool. Predictions from tools that
+// answered are returned alongside the errors of those that did not.
+func (p *SplicingPredictor) QueryVariant(ctx context.Context, variant *domain.StandardizedVariant) ([]domain.SplicingPrediction, error) {
+	// Scores are keyed by genomic coordinates; HGVS-only input has none to look up
+	if _, err := gnomADVariantID(variant); err != nil {
+		return nil, nil
+	}
+
+	var predictions []domain.SplicingPrediction
+	found := make(map[string]bool)
+	if p.scores != nil {
+		local, _ := p.scores.QueryVariant(ctx, variant)
+		for _, prediction := range local {
+			if p.tools[prediction.Tool] {
+				predictions = append(predictions, prediction)
+				found[prediction.Tool] = true
+			}
+		}
+	}
+
+	var errs []error
+	for _, client := range p.clients {
+		if found[client.tool] {
+			continue
+		}
+		remote, err := client.QueryVariant(ctx, variant)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		predictions = append(predictions, remote...)
+	}
+	return predictions, errors.Join(errs...)
+}
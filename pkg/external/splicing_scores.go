@@ -0,0 +1,264 @@
+package external
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// SplicingScores is an in-memory index of precomputed SpliceAI and Pangolin
+// scores, keyed by chrom-pos-ref-alt without a "chr" prefix
+type SplicingScores struct {
+	predictions map[string][]domain.SplicingPrediction
+}
+
+// LoadSplicingScores loads precomputed scores from a VCF annotated by SpliceAI
+// and/or Pangolin, or from a TSV with CHROM, POS, REF, ALT and DS_* columns.
+// Files ending in .gz are decompressed.
+func LoadSplicingScores(path string) (*SplicingScores, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open splicing scores: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	name := path
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress splicing scores: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+		name = strings.TrimSuffix(name, ".gz")
+	}
+
+	scores := &SplicingScores{predictions: make(map[string][]domain.SplicingPrediction)}
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".vcf"):
+		err = scores.readVCF(reader)
+	case strings.HasSuffix(lower, ".tsv"), strings.HasSuffix(lower, ".txt"):
+		err = scores.readTSV(reader)
+	default:
+		return nil, fmt.Errorf("unsupported splicing scores format: %s (use .vcf or .tsv, optionally .gz)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read splicing scores %s: %w", path, err)
+	}
+	return scores, nil
+}
+
+// Len returns the number of variants with scores
+func (s *SplicingScores) Len() int {
+	return len(s.predictions)
+}
+
+// QueryVariant returns the precomputed predictions for a variant
+func (s *SplicingScores) QueryVariant(_ context.Context, variant *domain.StandardizedVariant) ([]domain.SplicingPrediction, error) {
+	key, err := gnomADVariantID(variant)
+	if err != nil {
+		return nil, nil
+	}
+	return s.predictions[key], nil
+}
+
+// add records a prediction, keeping the strongest per tool when a variant is
+// scored against several genes
+func (s *SplicingScores) add(chrom, pos, ref, alt string, prediction domain.SplicingPrediction) {
+	key := fmt.Sprintf("%s-%s-%s-%s", strings.TrimPrefix(chrom, "chr"), pos, ref, alt)
+	for i, existing := range s.predictions[key] {
+		if existing.Tool == prediction.Tool {
+			if prediction.Score > existing.Score {
+				s.predictions[key][i] = prediction
+			}
+			return
+		}
+	}
+	s.predictions[key] = append(s.predictions[key], prediction)
+}
+
+// newScoreScanner returns a line scanner that accepts long INFO columns
+func newScoreScanner(reader io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	return scanner
+}
+
+// readVCF reads SpliceAI and Pangolin INFO annotations:
+//
+//	SpliceAI=ALLELE|SYMBOL|DS_AG|DS_AL|DS_DG|DS_DL|DP_AG|DP_AL|DP_DG|DP_DL[,...]
+//	Pangolin=gene|pos:largest_increase|pos:largest_decrease|Warnings:[||...]
+func (s *SplicingScores) readVCF(reader io.Reader) error {
+	scanner := newScoreScanner(reader)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) < 8 {
+			return fmt.Errorf("line %d: expected at least 8 VCF columns, got %d", line, len(fields))
+		}
+		chrom, pos, ref, alts := fields[0], fields[1], fields[3], strings.Split(fields[4], ",")
+
+		for _, entry := range strings.Split(fields[7], ";") {
+			key, value, _ := strings.Cut(entry, "=")
+			switch key {
+			case "SpliceAI":
+				for _, annotation := range strings.Split(value, ",") {
+					alt, prediction, err := parseSpliceAIAnnotation(annotation)
+					if err != nil {
+						return fmt.Errorf("line %d: %w", line, err)
+					}
+					s.add(chrom, pos, ref, alt, prediction)
+				}
+			case "Pangolin":
+				// Pangolin annotations carry no allele; multi-allelic records must be split first
+				if len(alts) != 1 {
+					continue
+				}
+				for _, annotation := range strings.Split(value, "||") {
+					prediction, err := parsePangolinAnnotation(annotation)
+					if err != nil {
+						return fmt.Errorf("line %d: %w", line, err)
+					}
+					s.add(chrom, pos, ref, alts[0], prediction)
+				}
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// parseSpliceAIAnnotation parses one allele's SpliceAI INFO annotation
+func parseSpliceAIAnnotation(annotation string) (string, domain.SplicingPrediction, error) {
+	parts := strings.Split(annotation, "|")
+	if len(parts) < 10 {
+		return "", domain.SplicingPrediction{}, fmt.Errorf("invalid SpliceAI annotation %q", annotation)
+	}
+
+	scores := make(map[string]float64)
+	positions := make(map[string]int)
+	for i, suffix := range []string{"AG", "AL", "DG", "DL"} {
+		score, err := strconv.ParseFloat(parts[2+i], 64)
+		if err != nil {
+			return "", domain.SplicingPrediction{}, fmt.Errorf("invalid SpliceAI DS_%s %q", suffix, parts[2+i])
+		}
+		scores["DS_"+suffix] = score
+		if position, err := strconv.Atoi(parts[6+i]); err == nil {
+			positions["DP_"+suffix] = position
+		}
+	}
+	return parts[0], newSplicingPrediction(SplicingToolSpliceAI, parts[1], "scores_file", scores, positions), nil
+}
+
+// parsePangolinAnnotation parses one gene's Pangolin INFO annotation
+func parsePangolinAnnotation(annotation string) (domain.SplicingPrediction, error) {
+	parts := strings.Split(annotation, "|")
+	if len(parts) < 3 {
+		return domain.SplicingPrediction{}, fmt.Errorf("invalid Pangolin annotation %q", annotation)
+	}
+
+	scores := make(map[string]float64)
+	positions := make(map[string]int)
+	for i, suffix := range []string{"SG", "SL"} {
+		position, score, ok := strings.Cut(parts[1+i], ":")
+		if !ok {
+			return domain.SplicingPrediction{}, fmt.Errorf("invalid Pangolin score %q", parts[1+i])
+		}
+		value, err := strconv.ParseFloat(score, 64)
+		if err != nil {
+			return domain.SplicingPrediction{}, fmt.Errorf("invalid Pangolin score %q", parts[1+i])
+		}
+		scores["DS_"+suffix] = value
+		if offset, err := strconv.Atoi(position); err == nil {
+			positions["DP_"+suffix] = offset
+		}
+	}
+	return newSplicingPrediction(SplicingToolPangolin, parts[0], "scores_file", scores, positions), nil
+}
+
+// readTSV reads a tab-separated score table. The header names the columns:
+// CHROM, POS, REF and ALT are required; SYMBOL (or GENE) and TOOL are optional,
+// TOOL defaulting to SpliceAI. Score columns are DS_AG, DS_AL, DS_DG, DS_DL for
+// SpliceAI or DS_SG, DS_SL for Pangolin, with matching DP_* positions.
+func (s *SplicingScores) readTSV(reader io.Reader) error {
+	scanner := newScoreScanner(reader)
+	var header map[string]int
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "##") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+
+		if header == nil {
+			header = make(map[string]int, len(fields))
+			for i, name := range fields {
+				header[strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(name), "#"))] = i
+			}
+			for _, required := range []string{"CHROM", "POS", "REF", "ALT"} {
+				if _, ok := header[required]; !ok {
+					return fmt.Errorf("header is missing the %s column", required)
+				}
+			}
+			continue
+		}
+
+		column := func(name string) string {
+			if i, ok := header[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		scores := make(map[string]float64)
+		positions := make(map[string]int)
+		for name := range header {
+			value := column(name)
+			if value == "" || value == "." {
+				continue
+			}
+			switch {
+			case strings.HasPrefix(name, "DS_"):
+				score, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return fmt.Errorf("line %d: invalid %s %q", line, name, value)
+				}
+				scores[name] = score
+			case strings.HasPrefix(name, "DP_"):
+				if position, err := strconv.Atoi(value); err == nil {
+					positions[name] = position
+				}
+			}
+		}
+		if len(scores) == 0 {
+			continue
+		}
+
+		tool := SplicingToolSpliceAI
+		if strings.EqualFold(column("TOOL"), SplicingToolPangolin) {
+			tool = SplicingToolPangolin
+		}
+		gene := column("SYMBOL")
+		if gene == "" {
+			gene = column("GENE")
+		}
+		s.add(column("CHROM"), column("POS"), column("REF"), column("ALT"),
+			newSplicingPrediction(tool, gene, "scores_file", scores, positions))
+	}
+	return scanner.Err()
+}
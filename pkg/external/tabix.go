@@ -0,0 +1,330 @@
+package external
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// tabixIndex is a parsed tabix (.tbi) index
+type tabixIndex struct {
+	colSeq, colBeg int // 1-based columns holding the sequence name and start
+	meta           byte
+	refs           map[string]*tabixRef
+}
+
+// tabixRef is the index of one sequence
+type tabixRef struct {
+	bins   map[uint32][]tabixChunk
+	linear []uint64 // Smallest virtual offset per 16kb window
+}
+
+// tabixChunk is a range of BGZF virtual offsets
+type tabixChunk struct {
+	begin, end uint64
+}
+
+// readTabixIndex parses a BGZF-compressed tabix index
+func readTabixIndex(path string) (*tabixIndex, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tabix index: %w", err)
+	}
+	defer gz.Close()
+	r := bufio.NewReader(gz)
+
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil || string(magic[:]) != "TBI\x01" {
+		return nil, fmt.Errorf("invalid tabix index: bad magic")
+	}
+	var header struct {
+		NRef, Format, ColSeq, ColBeg, ColEnd, Meta, Skip, LNames int32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("invalid tabix index: %w", err)
+	}
+	names := make([]byte, header.LNames)
+	if _, err := io.ReadFull(r, names); err != nil {
+		return nil, fmt.Errorf("invalid tabix index: %w", err)
+	}
+
+	index := &tabixIndex{
+		colSeq: int(header.ColSeq),
+		colBeg: int(header.ColBeg),
+		meta:   byte(header.Meta),
+		refs:   make(map[string]*tabixRef, header.NRef),
+	}
+	refNames := strings.Split(strings.TrimRight(string(names), "\x00"), "\x00")
+	if len(refNames) != int(header.NRef) {
+		return nil, fmt.Errorf("invalid tabix index: %d names for %d sequences", len(refNames), header.NRef)
+	}
+	for _, name := range refNames {
+		ref := &tabixRef{bins: make(map[uint32][]tabixChunk)}
+		var nBins int32
+		if err := binary.Read(r, binary.LittleEndian, &nBins); err != nil {
+			return nil, fmt.Errorf("invalid tabix index: %w", err)
+		}
+		for i := int32(0); i < nBins; i++ {
+			var bin struct {
+				Bin    uint32
+				NChunk int32
+			}
+			if err := binary.Read(r, binary.LittleEndian, &bin); err != nil {
+				return nil, fmt.Errorf("invalid tabix index: %w", err)
+			}
+			offsets := make([]uint64, 2*bin.NChunk)
+			if err := binary.Read(r, binary.LittleEndian, offsets); err != nil {
+				return nil, fmt.Errorf("invalid tabix index: %w", err)
+			}
+			for j := 0; j < len(offsets); j += 2 {
+				ref.bins[bin.Bin] = append(ref.bins[bin.Bin], tabixChunk{begin: offsets[j], end: offsets[j+1]})
+			}
+		}
+		var nIntervals int32
+		if err := binary.Read(r, binary.LittleEndian, &nIntervals); err != nil {
+			return nil, fmt.Errorf("invalid tabix index: %w", err)
+		}
+		ref.linear = make([]uint64, nIntervals)
+		if err := binary.Read(r, binary.LittleEndian, ref.linear); err != nil {
+			return nil, fmt.Errorf("invalid tabix index: %w", err)
+		}
+		index.refs[name] = ref
+	}
+	return index, nil
+}
+
+// startOffset returns the virtual offset from which records overlapping the
+// 1-based position can be found, or false if none can
+func (ref *tabixRef) startOffset(pos int64) (uint64, bool) {
+	beg := pos - 1
+	var minOffset uint64
+	if window := beg >> 14; window < int64(len(ref.linear)) {
+		minOffset = ref.linear[window]
+	}
+
+	found := false
+	var start uint64
+	for _, bin := range tabixBins(beg, beg+1) {
+		for _, chunk := range ref.bins[bin] {
+			if chunk.end <= minOffset {
+				continue
+			}
+			begin := chunk.begin
+			if begin < minOffset {
+				begin = minOffset
+			}
+			if !found || begin < start {
+				start, found = begin, true
+			}
+		}
+	}
+	return start, found
+}
+
+// tabixBins lists the bins that may hold records overlapping the 0-based
+// half-open interval [beg, end), as in the SAM specification
+func tabixBins(beg, end int64) []uint32 {
+	end--
+	bins := []uint32{0}
+	for _, level := range []struct {
+		offset uint32
+		shift  uint
+	}{{1, 26}, {9, 23}, {73, 20}, {585, 17}, {4681, 14}} {
+		for k := level.offset + uint32(beg>>level.shift); k <= level.offset+uint32(end>>level.shift); k++ {
+			bins = append(bins, k)
+		}
+	}
+	return bins
+}
+
+// tabixFile reads the records at a position of a bgzipped file through its
+// tabix index
+type tabixFile struct {
+	mu    sync.Mutex
+	file  *os.File
+	index *tabixIndex
+}
+
+func openTabixFile(path string) (*tabixFile, error) {
+	index, err := readTabixIndex(path + ".tbi")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tabix index: %w", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &tabixFile{file: file, index: index}, nil
+}
+
+// metaLines returns the meta lines at the start of the file
+func (t *tabixFile) metaLines() ([]string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, closer, err := t.readerAt(0)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		if len(line) == 0 || line[0] != t.index.meta {
+			break
+		}
+		lines = append(lines, line)
+		if err != nil {
+			break
+		}
+	}
+	return lines, nil
+}
+
+// readerAt returns a reader positioned at a BGZF virtual offset
+func (t *tabixFile) readerAt(offset uint64) (*bufio.Reader, io.Closer, error) {
+	if _, err := t.file.Seek(int64(offset>>16), io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+	gz, err := gzip.NewReader(t.file)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := bufio.NewReader(gz)
+	if _, err := r.Discard(int(offset & 0xffff)); err != nil {
+		gz.Close()
+		return nil, nil, err
+	}
+	return r, gz, nil
+}
+
+// scan calls match with each record starting at the 1-based position on
+// chrom, with or without a chr prefix, until match reports it is done
+func (t *tabixFile) scan(ctx context.Context, chrom string, pos int64, match func(line string) (bool, error)) error {
+	name := chrom
+	sequence, ok := t.index.refs[name]
+	if !ok {
+		name = "chr" + chrom
+		if sequence, ok = t.index.refs[name]; !ok {
+			return nil
+		}
+	}
+	start, ok := sequence.startOffset(pos)
+	if !ok {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, closer, err := t.readerAt(start)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	// Records are sorted, so scan until past the position
+	position := []byte(strconv.FormatInt(pos, 10))
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line, readErr := r.ReadBytes('\n')
+		if len(line) > 0 && line[0] != t.index.meta {
+			fields := bytes.SplitN(bytes.TrimRight(line, "\r\n"), []byte("\t"), max(t.index.colSeq, t.index.colBeg)+1)
+			if len(fields) < t.index.colSeq || len(fields) < t.index.colBeg {
+				return fmt.Errorf("tabix line has %d columns", len(fields))
+			}
+			if string(fields[t.index.colSeq-1]) != name {
+				return nil
+			}
+			linePos, err := strconv.ParseInt(string(fields[t.index.colBeg-1]), 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid position %q", fields[t.index.colBeg-1])
+			}
+			if linePos > pos {
+				return nil
+			}
+			if bytes.Equal(fields[t.index.colBeg-1], position) {
+				done, err := match(string(line))
+				if done || err != nil {
+					return err
+				}
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+func (t *tabixFile) Close() error {
+	return t.file.Close()
+}
+
+// dbNSFPTabix reads a bgzipped dbNSFP file through its tabix index
+type dbNSFPTabix struct {
+	file    *tabixFile
+	columns *dbNSFPColumns
+}
+
+func openDbNSFPTabix(path string) (*dbNSFPTabix, error) {
+	file, err := openTabixFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dbNSFP file: %w", err)
+	}
+
+	t := &dbNSFPTabix{file: file}
+	if t.columns, err = t.readHeader(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read dbNSFP header: %w", err)
+	}
+	return t, nil
+}
+
+// readHeader parses the last meta line at the start of the file
+func (t *dbNSFPTabix) readHeader() (*dbNSFPColumns, error) {
+	lines, err := t.file.metaLines()
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("missing dbNSFP header line")
+	}
+	return parseDbNSFPHeader(lines[len(lines)-1])
+}
+
+func (t *dbNSFPTabix) lookup(ctx context.Context, chrom string, pos int64, ref, alt string) (*dbNSFPRecord, error) {
+	var found *dbNSFPRecord
+	err := t.file.scan(ctx, chrom, pos, func(line string) (bool, error) {
+		record, err := t.columns.parseRecord(line)
+		if err != nil {
+			return true, err
+		}
+		if record.ref == ref && record.alt == alt {
+			found = record
+			return true, nil
+		}
+		return false, nil
+	})
+	return found, err
+}
+
+func (t *dbNSFPTabix) Close() error {
+	return t.file.Close()
+}
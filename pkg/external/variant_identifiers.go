@@ -0,0 +1,317 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// clinVarVariationName splits a ClinVar variation name such as
+// "NM_007294.4(BRCA1):c.5266dup (p.Gln1756fs)" into its parts
+var clinVarVariationName = regexp.MustCompile(`^([A-Z]{2}_\d+(?:\.\d+)?)(?:\(([^)]+)\))?:([cgnm]\.[^ ]+)(?: \((p\.[^)]+)\))?`)
+
+// IdentifierClient resolves dbSNP rsIDs and ClinVar VCV/RCV accessions to
+// variants via NCBI E-utilities
+type IdentifierClient struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	rateLimit  time.Duration
+}
+
+// NewIdentifierClient creates an identifier client. It shares the ClinVar
+// E-utilities configuration, including the NCBI API key.
+func NewIdentifierClient(config domain.ClinVarConfig) *IdentifierClient {
+	rateLimit := config.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = 3
+	}
+	return &IdentifierClient{
+		baseURL:    config.BaseURL,
+		apiKey:     config.APIKey,
+		httpClient: newHTTPClient(config.Timeout, 0),
+		rateLimit:  time.Second / time.Duration(rateLimit),
+	}
+}
+
+// dbSNPSummary is the part of a dbSNP esummary record used for resolution
+type dbSNPSummary struct {
+	UID   string `json:"uid"`
+	Chr   string `json:"chr"`
+	SPDI  string `json:"spdi"`
+	Genes []struct {
+		Name string `json:"name"`
+	} `json:"genes"`
+	Error string `json:"error"`
+}
+
+// clinVarJSONSummary is the part of a ClinVar esummary record used for resolution
+type clinVarJSONSummary struct {
+	UID          string `json:"uid"`
+	Accession    string `json:"accession"`
+	VariationSet []struct {
+		VariationName string `json:"variation_name"`
+		CanonicalSPDI string `json:"canonical_spdi"`
+		VariationLoc  []struct {
+			Status       string `json:"status"`
+			AssemblyName string `json:"assembly_name"`
+			Chr          string `json:"chr"`
+			Start        string `json:"start"`
+		} `json:"variation_loc"`
+	} `json:"variation_set"`
+	Genes []struct {
+		Symbol string `json:"symbol"`
+	} `json:"genes"`
+	Error string `json:"error"`
+}
+
+// ResolveIdentifier looks up the variants an rsID, VCV or RCV accession
+// refers to. Accessions are expected in canonical form (e.g. rs80357906,
+// VCV000017661) without a version.
+func (c *IdentifierClient) ResolveIdentifier(ctx context.Context, kind domain.IdentifierKind, identifier string) (*domain.IdentifierMapping, error) {
+	var (
+		variants []domain.StandardizedVariant
+		source   string
+		err      error
+	)
+	switch kind {
+	case domain.IdentifierRSID:
+		source = "dbSNP"
+		variants, err = c.resolveRSID(ctx, strings.TrimPrefix(strings.ToLower(identifier), "rs"))
+	case domain.IdentifierVCV:
+		source = "ClinVar"
+		id := strings.TrimLeft(strings.TrimPrefix(strings.ToUpper(identifier), "VCV"), "0")
+		variants, err = c.resolveClinVar(ctx, id)
+	case domain.IdentifierRCV:
+		source = "ClinVar"
+		var id string
+		if id, err = c.searchClinVar(ctx, strings.ToUpper(identifier)); err == nil {
+			variants, err = c.resolveClinVar(ctx, id)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported identifier kind: %s", kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", identifier, err)
+	}
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("%s %s has no variant placed on the genome", source, identifier)
+	}
+
+	return &domain.IdentifierMapping{
+		Identifier: identifier,
+		Kind:       kind,
+		Variants:   variants,
+		Source:     source,
+		ResolvedAt: time.Now().UTC(),
+	}, nil
+}
+
+// resolveRSID converts the SPDI alleles of a dbSNP record to variants
+func (c *IdentifierClient) resolveRSID(ctx context.Context, id string) ([]domain.StandardizedVariant, error) {
+	var summary dbSNPSummary
+	if err := c.summary(ctx, "snp", id, &summary); err != nil {
+		return nil, err
+	}
+	if summary.Error != "" {
+		return nil, fmt.Errorf("dbSNP: %s", summary.Error)
+	}
+
+	gene := ""
+	if len(summary.Genes) > 0 {
+		gene = summary.Genes[0].Name
+	}
+	var variants []domain.StandardizedVariant
+	for _, spdi := range strings.Split(summary.SPDI, ",") {
+		variant, ok := spdiVariant(strings.TrimSpace(spdi))
+		if !ok {
+			continue
+		}
+		variant.Chromosome = summary.Chr
+		variant.GeneSymbol = gene
+		variants = append(variants, variant)
+	}
+	return variants, nil
+}
+
+// resolveClinVar converts a ClinVar variation record to a variant
+func (c *IdentifierClient) resolveClinVar(ctx context.Context, id string) ([]domain.StandardizedVariant, error) {
+	if id == "" {
+		return nil, fmt.Errorf("invalid ClinVar accession")
+	}
+	var summary clinVarJSONSummary
+	if err := c.summary(ctx, "clinvar", id, &summary); err != nil {
+		return nil, err
+	}
+	if summary.Error != "" {
+		return nil, fmt.Errorf("ClinVar: %s", summary.Error)
+	}
+	if len(summary.VariationSet) != 1 {
+		// Haplotypes and compound records describe more than one variant
+		return nil, fmt.Errorf("ClinVar record %s describes %d variants", summary.Accession, len(summary.VariationSet))
+	}
+
+	record := summary.VariationSet[0]
+	variant, _ := spdiVariant(record.CanonicalSPDI)
+	if m := clinVarVariationName.FindStringSubmatch(record.VariationName); m != nil {
+		notation := m[1] + ":" + m[3]
+		if strings.HasPrefix(m[3], "g.") {
+			variant.HGVSGenomic = notation
+		} else {
+			variant.HGVSCoding = notation
+			variant.TranscriptID = m[1]
+		}
+		variant.HGVSProtein = m[4]
+		variant.GeneSymbol = m[2]
+	}
+	if variant.HGVSGenomic == "" && variant.HGVSCoding == "" {
+		return nil, nil
+	}
+	for _, loc := range record.VariationLoc {
+		if loc.Status == "current" && loc.AssemblyName == "GRCh38" {
+			variant.Chromosome = loc.Chr
+			if pos, err := strconv.ParseInt(loc.Start, 10, 64); err == nil {
+				variant.Position = pos
+			}
+		}
+	}
+	if variant.GeneSymbol == "" && len(summary.Genes) > 0 {
+		variant.GeneSymbol = summary.Genes[0].Symbol
+	}
+	return []domain.StandardizedVariant{variant}, nil
+}
+
+// searchClinVar finds the variation record a ClinVar accession belongs to
+func (c *IdentifierClient) searchClinVar(ctx context.Context, accession string) (string, error) {
+	var response struct {
+		ESearchResult struct {
+			IDList []string `json:"idlist"`
+		} `json:"esearchresult"`
+	}
+	params := url.Values{"db": {"clinvar"}, "term": {accession}, "retmode": {"json"}}
+	if err := c.get(ctx, "esearch.fcgi", params, &response); err != nil {
+		return "", err
+	}
+	switch ids := response.ESearchResult.IDList; len(ids) {
+	case 0:
+		return "", fmt.Errorf("ClinVar has no record for %s", accession)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%s matches %d ClinVar records", accession, len(ids))
+	}
+}
+
+// summary fetches a single esummary record into record
+func (c *IdentifierClient) summary(ctx context.Context, db, id string, record interface{}) error {
+	var response struct {
+		Result map[string]json.RawMessage `json:"result"`
+	}
+	params := url.Values{"db": {db}, "id": {id}, "retmode": {"json"}}
+	if err := c.get(ctx, "esummary.fcgi", params, &response); err != nil {
+		return err
+	}
+	raw, ok := response.Result[id]
+	if !ok {
+		return fmt.Errorf("no %s record with ID %s", db, id)
+	}
+	if err := json.Unmarshal(raw, record); err != nil {
+		return fmt.Errorf("failed to parse %s summary: %w", db, err)
+	}
+	return nil
+}
+
+// get performs a rate-limited E-utilities request and decodes the JSON response
+func (c *IdentifierClient) get(ctx context.Context, endpoint string, params url.Values, out interface{}) error {
+	select {
+	case <-time.After(c.rateLimit):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if c.apiKey != "" {
+		params.Set("api_key", c.apiKey)
+	}
+	requestURL := strings.TrimRight(c.baseURL, "/") + "/" + endpoint + "?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("E-utilities %s returned status %d", endpoint, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// spdiVariant converts an SPDI allele (sequence:0-based position:deleted:inserted)
+// to a genomic HGVS variant
+func spdiVariant(spdi string) (domain.StandardizedVariant, bool) {
+	parts := strings.Split(spdi, ":")
+	if len(parts) != 4 {
+		return domain.StandardizedVariant{}, false
+	}
+	accession, deleted, inserted := parts[0], strings.ToUpper(parts[2]), strings.ToUpper(parts[3])
+	pos, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || deleted == inserted {
+		return domain.StandardizedVariant{}, false
+	}
+
+	// Drop shared context so the edit covers only the changed bases
+	for len(deleted) > 0 && len(inserted) > 0 && deleted[0] == inserted[0] {
+		deleted, inserted = deleted[1:], inserted[1:]
+		pos++
+	}
+	for len(deleted) > 0 && len(inserted) > 0 && deleted[len(deleted)-1] == inserted[len(inserted)-1] {
+		deleted, inserted = deleted[:len(deleted)-1], inserted[:len(inserted)-1]
+	}
+
+	start, end := pos+1, pos+int64(len(deleted))
+	var edit string
+	switch {
+	case len(deleted) == 1 && len(inserted) == 1:
+		edit = fmt.Sprintf("%d%s>%s", start, deleted, inserted)
+	case deleted == "":
+		edit = fmt.Sprintf("%d_%dins%s", pos, pos+1, inserted)
+	case inserted == "":
+		edit = spdiRange(start, end) + "del"
+	default:
+		edit = spdiRange(start, end) + "delins" + inserted
+	}
+
+	return domain.StandardizedVariant{
+		Position:    start,
+		Reference:   deleted,
+		Alternative: inserted,
+		HGVSGenomic: accession + ":g." + edit,
+	}, true
+}
+
+// spdiRange formats a 1-based HGVS position range
+func spdiRange(start, end int64) string {
+	if start == end {
+		return strconv.FormatInt(start, 10)
+	}
+	return fmt.Sprintf("%d_%d", start, end)
+}
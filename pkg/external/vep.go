@@ -0,0 +1,209 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+	"github.com/acmg-amp-mcp-server/pkg/hgvs"
+)
+
+// VEP transcript sets
+const (
+	VEPTranscriptsEnsembl = "ensembl"
+	VEPTranscriptsRefSeq  = "refseq"
+	VEPTranscriptsMerged  = "merged"
+)
+
+// VEPClient annotates variants with the Ensembl Variant Effect Predictor
+// REST API (rest.ensembl.org or a self-hosted VEP REST deployment)
+type VEPClient struct {
+	baseURL     string
+	transcripts string
+	plugins     []string
+	httpClient  *http.Client
+	rateLimit   time.Duration
+}
+
+// NewVEPClient creates a new VEP REST API client
+func NewVEPClient(config domain.VEPConfig) *VEPClient {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://rest.ensembl.org"
+	}
+	transcripts := strings.ToLower(config.Transcripts)
+	if transcripts == "" {
+		transcripts = VEPTranscriptsEnsembl
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	rateLimit := config.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = 15 // Ensembl allows 15 requests per second
+	}
+	return &VEPClient{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		transcripts: transcripts,
+		plugins:     config.Plugins,
+		httpClient:  newHTTPClient(timeout, 0),
+		rateLimit:   time.Second / time.Duration(rateLimit),
+	}
+}
+
+// vepResult is the part of a VEP annotation used for transcript consequences
+type vepResult struct {
+	TranscriptConsequences []struct {
+		TranscriptID     string   `json:"transcript_id"`
+		GeneSymbol       string   `json:"gene_symbol"`
+		Biotype          string   `json:"biotype"`
+		ConsequenceTerms []string `json:"consequence_terms"`
+		HGVSc            string   `json:"hgvsc"`
+		HGVSp            string   `json:"hgvsp"`
+		MANESelect       string   `json:"mane_select"`
+		MANEPlusClinical string   `json:"mane_plus_clinical"`
+	} `json:"transcript_consequences"`
+	Error string `json:"error"`
+}
+
+// Annotate returns the consequences of a variant on each protein-coding
+// transcript VEP reports, MANE Select first and then MANE Plus Clinical.
+// The variant is given to VEP by its genomic coordinates.
+func (c *VEPClient) Annotate(ctx context.Context, variant *domain.NormalizedVariant) ([]domain.TranscriptConsequence, error) {
+	region, err := vepRegion(variant)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("content-type", "application/json")
+	params.Set("hgvs", "1")
+	params.Set("mane", "1")
+	params.Set("transcript_version", "1")
+	switch c.transcripts {
+	case VEPTranscriptsRefSeq:
+		params.Set("refseq", "1")
+	case VEPTranscriptsMerged:
+		params.Set("merged", "1")
+	}
+	for _, plugin := range c.plugins {
+		params.Set(plugin, "1")
+	}
+
+	select {
+	case <-time.After(c.rateLimit):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	requestURL := c.baseURL + "/vep/human/region/" + region + "?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create VEP request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute VEP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read VEP response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var failure vepResult
+		if json.Unmarshal(body, &failure) == nil && failure.Error != "" {
+			return nil, fmt.Errorf("VEP returned status %d: %s", resp.StatusCode, failure.Error)
+		}
+		return nil, fmt.Errorf("VEP returned status %d", resp.StatusCode)
+	}
+	return parseVEPResponse(body)
+}
+
+// vepRegion formats a variant in VEP's region notation,
+// chromosome:start-end:strand/allele, with "-" for a deleted allele.
+// Insertions have an end one before the start, as in NormalizedVariant.
+func vepRegion(variant *domain.NormalizedVariant) (string, error) {
+	if variant == nil || variant.Chromosome == "" || variant.Start <= 0 || variant.End < variant.Start-1 {
+		return "", fmt.Errorf("VEP needs the genomic coordinates of the variant")
+	}
+	chromosome := strings.TrimPrefix(strings.TrimPrefix(variant.Chromosome, "chr"), "Chr")
+	allele := strings.ToUpper(variant.Alternative)
+	if allele == "" {
+		allele = "-"
+	}
+	return fmt.Sprintf("%s:%d-%d:1/%s", chromosome, variant.Start, variant.End, allele), nil
+}
+
+// parseVEPResponse converts the transcript consequences of a VEP response.
+// Consequence terms are joined with '&' as in VEP's own output, and protein
+// notation is reported without the protein accession.
+func parseVEPResponse(body []byte) ([]domain.TranscriptConsequence, error) {
+	var results []vepResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse VEP response: %w", err)
+	}
+
+	var consequences []domain.TranscriptConsequence
+	for _, result := range results {
+		for _, tc := range result.TranscriptConsequences {
+			if tc.Biotype != "" && tc.Biotype != "protein_coding" {
+				continue
+			}
+			consequence := domain.TranscriptConsequence{
+				TranscriptID: tc.TranscriptID,
+				GeneSymbol:   tc.GeneSymbol,
+				HGVSCoding:   tc.HGVSc,
+				HGVSProtein:  vepProtein(tc.HGVSp),
+				Consequence:  strings.Join(tc.ConsequenceTerms, "&"),
+			}
+			switch {
+			case tc.MANESelect != "":
+				consequence.MANE = hgvs.MANESelect
+			case tc.MANEPlusClinical != "":
+				consequence.MANE = hgvs.MANEPlusClinical
+			}
+			consequence.ClinicallyRelevant = consequence.MANE != ""
+			consequences = append(consequences, consequence)
+		}
+	}
+
+	sort.SliceStable(consequences, func(i, j int) bool {
+		return maneOrder(consequences[i].MANE) < maneOrder(consequences[j].MANE)
+	})
+	return consequences, nil
+}
+
+// vepProtein strips the protein accession from VEP's hgvsp and decodes the
+// '=' VEP escapes in synonymous changes
+func vepProtein(hgvsp string) string {
+	if idx := strings.Index(hgvsp, ":"); idx >= 0 {
+		hgvsp = hgvsp[idx+1:]
+	}
+	if decoded, err := url.PathUnescape(hgvsp); err == nil {
+		return decoded
+	}
+	return hgvsp
+}
+
+func maneOrder(mane string) int {
+	switch mane {
+	case hgvs.MANESelect:
+		return 0
+	case hgvs.MANEPlusClinical:
+		return 1
+	default:
+		return 2
+	}
+}
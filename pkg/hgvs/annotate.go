@@ -0,0 +1,279 @@
+package hgvs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// Sequence Ontology consequence terms assigned by Annotate
+const (
+	ConsequenceSpliceAcceptor  = "splice_acceptor_variant"
+	ConsequenceSpliceDonor     = "splice_donor_variant"
+	ConsequenceStopGained      = "stop_gained"
+	ConsequenceFrameshift      = "frameshift_variant"
+	ConsequenceStopLost        = "stop_lost"
+	ConsequenceStartLost       = "start_lost"
+	ConsequenceInframeInsert   = "inframe_insertion"
+	ConsequenceInframeDelete   = "inframe_deletion"
+	ConsequenceMissense        = "missense_variant"
+	ConsequenceProteinAltering = "protein_altering_variant"
+	ConsequenceSpliceRegion    = "splice_region_variant"
+	ConsequenceSynonymous      = "synonymous_variant"
+	ConsequenceUTR5            = "5_prime_UTR_variant"
+	ConsequenceUTR3            = "3_prime_UTR_variant"
+	ConsequenceIntron          = "intron_variant"
+)
+
+// Intronic offsets of the canonical splice sites and the wider splice region
+const (
+	spliceSiteMaxOffset   = 2
+	spliceRegionMaxOffset = 8
+)
+
+// Annotate describes a normalized variant on every coding transcript
+// overlapping it: the c. notation, the Sequence Ontology consequence and,
+// for substitutions in the coding region, the protein change. MANE Select
+// and MANE Plus Clinical transcripts come first and are flagged clinically
+// relevant. Returns nil when the transcript database cannot be searched by
+// position.
+func (n *Normalizer) Annotate(variant *domain.NormalizedVariant) ([]domain.TranscriptConsequence, error) {
+	overlaps, ok := n.transcripts.(OverlapSource)
+	if !ok || variant == nil {
+		return nil, nil
+	}
+
+	edit := genomicEdit{
+		chromosome: variant.Chromosome,
+		start:      variant.Start,
+		end:        variant.End,
+		ref:        variant.Reference,
+		alt:        variant.Alternative,
+	}
+	window := &sequenceWindow{source: n.reference, chromosome: edit.chromosome}
+
+	var consequences []domain.TranscriptConsequence
+	for _, tx := range overlaps.Overlapping(edit.chromosome, edit.start, edit.end) {
+		if !tx.IsCoding() {
+			continue
+		}
+		consequence, err := annotateTranscript(window, tx, edit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to annotate %s: %w", tx.ID, err)
+		}
+		if consequence != nil {
+			consequences = append(consequences, *consequence)
+		}
+	}
+	return consequences, nil
+}
+
+// annotateTranscript describes an edit on one transcript, or returns nil
+// when the edit lies outside its exons and introns
+func annotateTranscript(window *sequenceWindow, tx *Transcript, edit genomicEdit) (*domain.TranscriptConsequence, error) {
+	coding, err := shiftEdit(window, edit, tx.direction())
+	if err != nil {
+		return nil, err
+	}
+	sites, ok := tx.affectedSites(coding)
+	if !ok {
+		return nil, nil
+	}
+	description, err := describe(window, coding, tx.direction(), tx.CodingPosition, tx.Strand == '-')
+	if err != nil {
+		return nil, err
+	}
+
+	consequence := &domain.TranscriptConsequence{
+		TranscriptID:       tx.ID,
+		GeneSymbol:         tx.Gene,
+		HGVSCoding:         tx.ID + ":c." + description,
+		MANE:               tx.MANE,
+		ClinicallyRelevant: tx.MANE != "",
+	}
+	consequence.Consequence, consequence.HGVSProtein, err = tx.consequence(window, coding, sites)
+	if err != nil {
+		return nil, err
+	}
+	return consequence, nil
+}
+
+// site locates a genomic base on a transcript
+type site struct {
+	codingPos int64 // 1-based position in the coding sequence; below 1 in the 5' UTR, beyond the stop codon in the 3' UTR
+	offset    int64 // Intronic offset from the nearest exonic base, 0 when exonic
+}
+
+// affectedSites locates the bases an edit changes, or for an insertion the
+// two bases flanking it. It reports false when any lies outside the
+// transcript.
+func (tx *Transcript) affectedSites(edit genomicEdit) ([]site, bool) {
+	cdsStart, _, err := tx.cdsBounds()
+	if err != nil {
+		return nil, false
+	}
+	first, last := edit.start, edit.end
+	if edit.ref == "" {
+		first, last = edit.end, edit.start
+	}
+
+	sites := make([]site, 0, last-first+1)
+	for pos := first; pos <= last; pos++ {
+		txPos, ok := tx.genomicToTranscript(pos)
+		var offset int64
+		if !ok {
+			if txPos, offset, ok = tx.intronicAnchor(pos); !ok {
+				return nil, false
+			}
+		}
+		sites = append(sites, site{codingPos: txPos - cdsStart + 1, offset: offset})
+	}
+	return sites, true
+}
+
+// consequence determines the most severe consequence of an edit on the
+// transcript and, for a substitution in a codon, the protein change
+func (tx *Transcript) consequence(window *sequenceWindow, edit genomicEdit, sites []site) (string, string, error) {
+	cdsStart, cdsEnd, _ := tx.cdsBounds()
+	stopCodon := cdsEnd - cdsStart + 1 // c. position of the last base of the stop codon
+	insertion := edit.ref == ""
+
+	var spliceAcceptor, spliceDonor, spliceRegion, coding, utr5, utr3, startCodon, stop bool
+	exonic := 0
+	for _, s := range sites {
+		switch {
+		case s.offset != 0:
+			distance := absInt(s.offset)
+			switch {
+			case distance <= spliceSiteMaxOffset && s.offset > 0:
+				spliceDonor = true
+			case distance <= spliceSiteMaxOffset:
+				spliceAcceptor = true
+			case distance <= spliceRegionMaxOffset:
+				spliceRegion = true
+			}
+		case s.codingPos < 1:
+			utr5 = true
+			exonic++
+		case s.codingPos > stopCodon:
+			utr3 = true
+			exonic++
+		default:
+			coding = true
+			exonic++
+			startCodon = startCodon || s.codingPos <= 3
+			stop = stop || s.codingPos > stopCodon-3
+		}
+	}
+	// An insertion changes a splice site or the coding sequence only when
+	// both flanking bases are in it
+	if insertion {
+		spliceDonor = spliceDonor && exonic == 0 && sites[0].offset > 0 && sites[1].offset > 0
+		spliceAcceptor = spliceAcceptor && exonic == 0 && sites[0].offset < 0 && sites[1].offset < 0
+		coding = coding && exonic == 2 && sites[0].codingPos >= 1 && sites[1].codingPos >= 1 &&
+			sites[0].codingPos <= stopCodon && sites[1].codingPos <= stopCodon
+		startCodon = startCodon && sites[0].codingPos <= 3 && sites[1].codingPos <= 3
+	}
+
+	switch {
+	case spliceAcceptor:
+		return ConsequenceSpliceAcceptor, "", nil
+	case spliceDonor:
+		return ConsequenceSpliceDonor, "", nil
+	case coding:
+		return tx.codingConsequence(window, edit, sites, startCodon, stop)
+	case spliceRegion:
+		return ConsequenceSpliceRegion, "", nil
+	case utr5:
+		return ConsequenceUTR5, "", nil
+	case utr3:
+		return ConsequenceUTR3, "", nil
+	default:
+		return ConsequenceIntron, "", nil
+	}
+}
+
+// codingConsequence classifies an edit within the coding sequence
+func (tx *Transcript) codingConsequence(window *sequenceWindow, edit genomicEdit, sites []site, startCodon, stop bool) (string, string, error) {
+	change := len(edit.alt) - len(edit.ref)
+	switch {
+	case change%3 != 0:
+		return ConsequenceFrameshift, "", nil
+	case startCodon:
+		return ConsequenceStartLost, "p.Met1?", nil
+	case change > 0:
+		return ConsequenceInframeInsert, "", nil
+	case change < 0 && stop:
+		return ConsequenceStopLost, "", nil
+	case change < 0:
+		return ConsequenceInframeDelete, "", nil
+	case len(edit.ref) > 1:
+		return ConsequenceProteinAltering, "", nil
+	}
+
+	// A single-base substitution: translate the reference and altered codon
+	position := sites[0].codingPos
+	codonNumber := (position-1)/3 + 1
+	codonStart := (codonNumber-1)*3 + 1
+	cdsStart, _, _ := tx.cdsBounds()
+	var codon strings.Builder
+	for i := int64(0); i < 3; i++ {
+		pos := tx.transcriptToGenomic(cdsStart + codonStart - 1 + i)
+		base, err := window.span(pos, pos)
+		if err != nil {
+			return "", "", err
+		}
+		if tx.Strand == '-' {
+			base = reverseComplement(base)
+		}
+		codon.WriteString(base)
+	}
+	ref := codon.String()
+	altBase := edit.alt
+	if tx.Strand == '-' {
+		altBase = reverseComplement(altBase)
+	}
+	index := (position - 1) % 3
+	alt := ref[:index] + altBase + ref[index+1:]
+
+	refAA, altAA := translateCodon(ref), translateCodon(alt)
+	switch {
+	case refAA == altAA:
+		return ConsequenceSynonymous, fmt.Sprintf("p.%s%d=", refAA, codonNumber), nil
+	case refAA == "Ter":
+		return ConsequenceStopLost, fmt.Sprintf("p.Ter%d%sext*?", codonNumber, altAA), nil
+	case altAA == "Ter":
+		return ConsequenceStopGained, fmt.Sprintf("p.%s%dTer", refAA, codonNumber), nil
+	default:
+		return ConsequenceMissense, fmt.Sprintf("p.%s%d%s", refAA, codonNumber, altAA), nil
+	}
+}
+
+// geneticCode is the standard codon table, with three-letter amino acid codes
+var geneticCode = map[string]string{
+	"TTT": "Phe", "TTC": "Phe", "TTA": "Leu", "TTG": "Leu",
+	"CTT": "Leu", "CTC": "Leu", "CTA": "Leu", "CTG": "Leu",
+	"ATT": "Ile", "ATC": "Ile", "ATA": "Ile", "ATG": "Met",
+	"GTT": "Val", "GTC": "Val", "GTA": "Val", "GTG": "Val",
+	"TCT": "Ser", "TCC": "Ser", "TCA": "Ser", "TCG": "Ser",
+	"CCT": "Pro", "CCC": "Pro", "CCA": "Pro", "CCG": "Pro",
+	"ACT": "Thr", "ACC": "Thr", "ACA": "Thr", "ACG": "Thr",
+	"GCT": "Ala", "GCC": "Ala", "GCA": "Ala", "GCG": "Ala",
+	"TAT": "Tyr", "TAC": "Tyr", "TAA": "Ter", "TAG": "Ter",
+	"CAT": "His", "CAC": "His", "CAA": "Gln", "CAG": "Gln",
+	"AAT": "Asn", "AAC": "Asn", "AAA": "Lys", "AAG": "Lys",
+	"GAT": "Asp", "GAC": "Asp", "GAA": "Glu", "GAG": "Glu",
+	"TGT": "Cys", "TGC": "Cys", "TGA": "Ter", "TGG": "Trp",
+	"CGT": "Arg", "CGC": "Arg", "CGA": "Arg", "CGG": "Arg",
+	"AGT": "Ser", "AGC": "Ser", "AGA": "Arg", "AGG": "Arg",
+	"GGT": "Gly", "GGC": "Gly", "GGA": "Gly", "GGG": "Gly",
+}
+
+// translateCodon returns the three-letter amino acid code of a codon, Xaa if unknown
+func translateCodon(codon string) string {
+	if aa, ok := geneticCode[strings.ToUpper(codon)]; ok {
+		return aa
+	}
+	return "Xaa"
+}
@@ -0,0 +1,151 @@
+package hgvs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// maneGTF adds a MANE Select RefSeq transcript sharing the exons of
+// NM_000001.1 and tags the Ensembl transcript MANE Plus Clinical
+const maneGTF = testGTF + `chr17	test	exon	101	200	.	+	.	gene_id "PLUS"; transcript_id "NM_000003.2"; gene_name "PLUS"; tag "MANE Select";
+chr17	test	exon	301	400	.	+	.	gene_id "PLUS"; transcript_id "NM_000003.2"; gene_name "PLUS"; tag "MANE Select";
+chr17	test	CDS	151	200	.	+	0	gene_id "PLUS"; transcript_id "NM_000003.2"; gene_name "PLUS"; tag "MANE Select";
+chr17	test	CDS	301	347	.	+	1	gene_id "PLUS"; transcript_id "NM_000003.2"; gene_name "PLUS"; tag "MANE Select";
+chr17	test	stop_codon	348	350	.	+	0	gene_id "PLUS"; transcript_id "NM_000003.2"; gene_name "PLUS"; tag "MANE Select";
+17	test	exon	1001	1300	.	-	.	gene_id "ENSG1"; transcript_id "ENST00000000004"; transcript_version "1"; gene_name "MINUS"; tag "basic"; tag "MANE_Plus_Clinical";
+`
+
+func createAnnotatingNormalizer(t *testing.T) *Normalizer {
+	t.Helper()
+	fasta, err := OpenIndexedFasta(writeIndexedFasta(t, t.TempDir(), "chr17", testReference()))
+	if err != nil {
+		t.Fatalf("OpenIndexedFasta() error = %v", err)
+	}
+	t.Cleanup(func() { fasta.Close() })
+	transcripts, err := LoadGTF(strings.NewReader(maneGTF))
+	if err != nil {
+		t.Fatalf("LoadGTF() error = %v", err)
+	}
+	normalizer, err := NewNormalizer("hg38", transcripts, fasta)
+	if err != nil {
+		t.Fatalf("NewNormalizer() error = %v", err)
+	}
+	return normalizer
+}
+
+func TestTranscriptIndexOverlapping(t *testing.T) {
+	transcripts, err := LoadGTF(strings.NewReader(maneGTF))
+	if err != nil {
+		t.Fatalf("LoadGTF() error = %v", err)
+	}
+
+	var ids []string
+	for _, tx := range transcripts.Overlapping("chr17", 160, 160) {
+		ids = append(ids, tx.ID+"/"+tx.MANE)
+	}
+	if got, want := strings.Join(ids, ","), "NM_000003.2/MANE Select,NM_000001.1/"; got != want {
+		t.Errorf("Overlapping() = %s, want %s", got, want)
+	}
+
+	minus := transcripts.Overlapping("17", 1040, 1060)
+	if len(minus) != 2 || minus[0].ID != "ENST00000000004.1" || minus[0].MANE != MANEPlusClinical {
+		t.Errorf("MANE Plus Clinical transcript is not listed first: %+v", minus)
+	}
+	if got := transcripts.Overlapping("17", 500, 900); len(got) != 0 {
+		t.Errorf("intergenic interval overlaps %d transcripts", len(got))
+	}
+}
+
+func TestNormalizerAnnotate(t *testing.T) {
+	normalizer := createAnnotatingNormalizer(t)
+
+	tests := []struct {
+		name        string
+		input       string
+		coding      string
+		consequence string
+		protein     string
+	}{
+		{"missense", "NC_000017.11:g.154A>G", "c.4A>G", ConsequenceMissense, "p.Ile2Val"},
+		{"synonymous", "NC_000017.11:g.159T>C", "c.9T>C", ConsequenceSynonymous, "p.Asp3="},
+		{"stop gained", "NC_000017.11:g.160C>T", "c.10C>T", ConsequenceStopGained, "p.Arg4Ter"},
+		{"start codon", "NC_000017.11:g.152C>A", "c.2C>A", ConsequenceStartLost, "p.Met1?"},
+		{"frameshift", "NC_000017.11:g.163del", "c.13del", ConsequenceFrameshift, ""},
+		{"splice donor", "NC_000017.11:g.201G>A", "c.50+1G>A", ConsequenceSpliceDonor, ""},
+		{"splice acceptor", "NC_000017.11:g.300C>A", "c.51-1C>A", ConsequenceSpliceAcceptor, ""},
+		{"splice region", "NC_000017.11:g.205G>A", "c.50+5G>A", ConsequenceSpliceRegion, ""},
+		{"intron", "NC_000017.11:g.240C>A", "c.50+40C>A", ConsequenceIntron, ""},
+		{"5' UTR", "NC_000017.11:g.120C>A", "c.-31C>A", ConsequenceUTR5, ""},
+		{"3' UTR", "NC_000017.11:g.360C>A", "c.*10C>A", ConsequenceUTR3, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := normalizer.Normalize(tt.input, "")
+			if err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			consequences, err := normalizer.Annotate(normalized)
+			if err != nil {
+				t.Fatalf("Annotate() error = %v", err)
+			}
+			if len(consequences) != 2 {
+				t.Fatalf("Annotate() = %d transcripts, want 2", len(consequences))
+			}
+			mane, other := consequences[0], consequences[1]
+			if mane.TranscriptID != "NM_000003.2" || !mane.ClinicallyRelevant || mane.MANE != MANESelect || mane.GeneSymbol != "PLUS" {
+				t.Errorf("MANE Select transcript not first: %+v", mane)
+			}
+			if other.ClinicallyRelevant {
+				t.Errorf("non-MANE transcript flagged clinically relevant: %+v", other)
+			}
+			want := domain.TranscriptConsequence{
+				TranscriptID: "NM_000001.1", GeneSymbol: "PLUS", HGVSCoding: "NM_000001.1:" + tt.coding,
+				HGVSProtein: tt.protein, Consequence: tt.consequence,
+			}
+			if other != want {
+				t.Errorf("Annotate() = %+v, want %+v", other, want)
+			}
+		})
+	}
+}
+
+func TestNormalizerAnnotateMinusStrand(t *testing.T) {
+	normalizer := createAnnotatingNormalizer(t)
+
+	// c.22C>T on the minus strand is g.1229G>A
+	normalized, err := normalizer.Normalize("NC_000017.11:g.1229G>A", "")
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	consequences, err := normalizer.Annotate(normalized)
+	if err != nil {
+		t.Fatalf("Annotate() error = %v", err)
+	}
+
+	// The MANE Plus Clinical transcript is non-coding here and skipped
+	if len(consequences) != 1 {
+		t.Fatalf("Annotate() = %+v, want one coding transcript", consequences)
+	}
+	got := consequences[0]
+	if got.HGVSCoding != "ENST00000000002.3:c.22C>T" || got.Consequence != ConsequenceStopGained || got.HGVSProtein != "p.Arg8Ter" {
+		t.Errorf("Annotate() = %+v", got)
+	}
+}
+
+func TestNormalizerAnnotateWithoutIndex(t *testing.T) {
+	fasta, err := OpenIndexedFasta(writeIndexedFasta(t, t.TempDir(), "chr17", testReference()))
+	if err != nil {
+		t.Fatalf("OpenIndexedFasta() error = %v", err)
+	}
+	defer fasta.Close()
+	normalizer, err := NewNormalizer("hg38", nil, fasta)
+	if err != nil {
+		t.Fatalf("NewNormalizer() error = %v", err)
+	}
+	consequences, err := normalizer.Annotate(&domain.NormalizedVariant{Chromosome: "17", Start: 154, End: 154, Reference: "A", Alternative: "G"})
+	if err != nil || consequences != nil {
+		t.Errorf("Annotate() = %v, %v; want nothing without a transcript index", consequences, err)
+	}
+}
@@ -0,0 +1,97 @@
+package hgvs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Supported genome assemblies
+const (
+	AssemblyGRCh37 = "GRCh37"
+	AssemblyGRCh38 = "GRCh38"
+)
+
+// chromosomeOrder lists chromosomes in RefSeq accession order
+var chromosomeOrder = []string{
+	"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12",
+	"13", "14", "15", "16", "17", "18", "19", "20", "21", "22", "X", "Y",
+}
+
+// accessionVersions holds the RefSeq NC_ accession version of each chromosome
+// per assembly, in chromosomeOrder
+var accessionVersions = map[string][]int{
+	AssemblyGRCh37: {10, 11, 11, 11, 9, 11, 13, 10, 11, 10, 9, 11, 10, 8, 9, 9, 10, 9, 9, 10, 8, 10, 10, 9},
+	AssemblyGRCh38: {11, 12, 12, 12, 10, 12, 14, 11, 12, 11, 10, 12, 11, 9, 10, 10, 11, 10, 10, 11, 9, 11, 11, 10},
+}
+
+// mitochondrialAccession is shared by both assemblies
+const mitochondrialAccession = "NC_012920.1"
+
+// ParseAssembly returns the canonical name of an assembly such as "hg38" or "GRCh37".
+func ParseAssembly(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "grch37", "hg19", "37":
+		return AssemblyGRCh37, nil
+	case "grch38", "hg38", "38":
+		return AssemblyGRCh38, nil
+	default:
+		return "", fmt.Errorf("unsupported genome assembly: %s", name)
+	}
+}
+
+// otherAssembly returns the assembly variants are lifted to
+func otherAssembly(assembly string) string {
+	if assembly == AssemblyGRCh37 {
+		return AssemblyGRCh38
+	}
+	return AssemblyGRCh37
+}
+
+// ChromosomeAccession returns the RefSeq accession of a chromosome in an assembly.
+func ChromosomeAccession(assembly, chromosome string) (string, bool) {
+	chromosome = normalizeChromosomeName(chromosome)
+	if chromosome == "MT" {
+		return mitochondrialAccession, true
+	}
+	versions, ok := accessionVersions[assembly]
+	if !ok {
+		return "", false
+	}
+	for i, name := range chromosomeOrder {
+		if name == chromosome {
+			return fmt.Sprintf("NC_%06d.%d", i+1, versions[i]), true
+		}
+	}
+	return "", false
+}
+
+// accessionChromosome returns the chromosome and assembly of a RefSeq NC_
+// accession. The assembly is empty for accessions shared by both.
+func accessionChromosome(accession string) (chromosome, assembly string, ok bool) {
+	if accession == mitochondrialAccession {
+		return "MT", "", true
+	}
+	var number, version int
+	if _, err := fmt.Sscanf(accession, "NC_%06d.%d", &number, &version); err != nil || number < 1 || number > len(chromosomeOrder) {
+		return "", "", false
+	}
+	for _, name := range []string{AssemblyGRCh37, AssemblyGRCh38} {
+		if accessionVersions[name][number-1] == version {
+			return chromosomeOrder[number-1], name, true
+		}
+	}
+	return "", "", false
+}
+
+// normalizeChromosomeName maps chr-prefixed and mitochondrial names onto
+// the bare chromosome names used throughout, e.g. chr17 -> 17, chrM -> MT
+func normalizeChromosomeName(name string) string {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "chr")
+	if name == "M" {
+		return "MT"
+	}
+	if chromosome, _, ok := accessionChromosome(name); ok {
+		return chromosome
+	}
+	return name
+}
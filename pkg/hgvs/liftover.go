@@ -0,0 +1,131 @@
+package hgvs
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// chainBlock is an ungapped alignment block between two assemblies,
+// 0-based and half-open on the source
+type chainBlock struct {
+	start  int64
+	end    int64
+	qStart int64
+	qName  string
+	qSize  int64
+	qMinus bool
+}
+
+// Liftover converts coordinates between assemblies using a UCSC chain file.
+type Liftover struct {
+	From   string
+	To     string
+	blocks map[string][]chainBlock
+}
+
+// LoadChainFile reads a UCSC chain file such as hg19ToHg38.over.chain.gz.
+func LoadChainFile(path, from, to string) (*Liftover, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open chain file: %w", err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chain file: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return LoadChain(r, from, to)
+}
+
+// LoadChain reads UCSC chain records converting coordinates from one assembly to another.
+func LoadChain(r io.Reader, from, to string) (*Liftover, error) {
+	lift := &Liftover{From: from, To: to, blocks: make(map[string][]chainBlock)}
+
+	var (
+		inChain bool
+		tName   string
+		qName   string
+		qSize   int64
+		qMinus  bool
+		t, q    int64
+	)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			inChain = false
+			continue
+		}
+		if fields[0] == "chain" {
+			if len(fields) < 12 {
+				return nil, fmt.Errorf("line %d: malformed chain header", line)
+			}
+			var tStart, qStart int64
+			fmt.Sscan(fields[5], &tStart)
+			fmt.Sscan(fields[10], &qStart)
+			fmt.Sscan(fields[8], &qSize)
+			tName = normalizeChromosomeName(fields[2])
+			qName = normalizeChromosomeName(fields[7])
+			qMinus = fields[9] == "-"
+			t, q = tStart, qStart
+			inChain = true
+			continue
+		}
+		if !inChain {
+			return nil, fmt.Errorf("line %d: alignment data outside a chain", line)
+		}
+
+		var size, dt, dq int64
+		if _, err := fmt.Sscan(fields[0], &size); err != nil {
+			return nil, fmt.Errorf("line %d: invalid block size %q", line, fields[0])
+		}
+		lift.blocks[tName] = append(lift.blocks[tName], chainBlock{
+			start: t, end: t + size, qStart: q, qName: qName, qSize: qSize, qMinus: qMinus,
+		})
+		if len(fields) >= 3 {
+			fmt.Sscan(fields[1], &dt)
+			fmt.Sscan(fields[2], &dq)
+		}
+		t += size + dt
+		q += size + dq
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read chain file: %w", err)
+	}
+
+	for chrom := range lift.blocks {
+		blocks := lift.blocks[chrom]
+		sort.Slice(blocks, func(i, j int) bool { return blocks[i].start < blocks[j].start })
+	}
+	return lift, nil
+}
+
+// Lift converts a 1-based position. The reverse flag is set when the
+// position maps to the opposite strand of the target assembly.
+func (l *Liftover) Lift(chromosome string, pos int64) (string, int64, bool, error) {
+	blocks := l.blocks[normalizeChromosomeName(chromosome)]
+	p := pos - 1
+	i := sort.Search(len(blocks), func(i int) bool { return blocks[i].end > p })
+	if i == len(blocks) || blocks[i].start > p {
+		return "", 0, false, fmt.Errorf("%s:%d does not lift over from %s to %s", chromosome, pos, l.From, l.To)
+	}
+	block := blocks[i]
+	q := block.qStart + p - block.start
+	if block.qMinus {
+		q = block.qSize - 1 - q
+	}
+	return block.qName, q + 1, block.qMinus, nil
+}
@@ -0,0 +1,607 @@
+package hgvs
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+var (
+	// Positions in c. notation: 76, -14, *32, 1234+5, 1235-12
+	codingPositionPattern = regexp.MustCompile(`^([-*]?)(\d+)(?:([+-])(\d+))?$`)
+
+	// Nucleotide-level variants the normalizer can place on the genome
+	normalizablePattern = regexp.MustCompile(`^([A-Za-z0-9_.]+?)(?:\([A-Za-z0-9-]+\))?:([gc])\.([-*]?\d+(?:[+-]\d+)?)(?:_([-*]?\d+(?:[+-]\d+)?))?((?:[ACGT]+>[ACGT]+)|(?:delins[ACGT]+)|(?:del[ACGT]*)|(?:ins[ACGT]+)|(?:dup[ACGT]*))$`)
+)
+
+// sequenceWindowSize is the number of bases fetched around a position while shifting
+const sequenceWindowSize = 2048
+
+// Normalizer rewrites HGVS notations in their normalized form: c. notations
+// are mapped to the genome through reference transcripts, indels are
+// shifted to their most 3' position, insertions repeating the preceding
+// sequence become duplications, and genomic coordinates are lifted over
+// between GRCh37 and GRCh38.
+type Normalizer struct {
+	assembly    string
+	transcripts TranscriptSource
+	reference   SequenceSource
+	toOther     *Liftover
+	fromOther   *Liftover
+}
+
+// NewNormalizer creates a normalizer for the assembly of the reference
+// sequence and transcripts. The transcript source may be nil, in which
+// case only g. notations are normalized.
+func NewNormalizer(assembly string, transcripts TranscriptSource, reference SequenceSource) (*Normalizer, error) {
+	canonical, err := ParseAssembly(assembly)
+	if err != nil {
+		return nil, err
+	}
+	if reference == nil {
+		return nil, fmt.Errorf("reference sequence is required for normalization")
+	}
+	return &Normalizer{assembly: canonical, transcripts: transcripts, reference: reference}, nil
+}
+
+// SetLiftover attaches a chain converting to or from the normalizer's assembly.
+func (n *Normalizer) SetLiftover(lift *Liftover) error {
+	switch {
+	case lift.From == n.assembly && lift.To == otherAssembly(n.assembly):
+		n.toOther = lift
+	case lift.From == otherAssembly(n.assembly) && lift.To == n.assembly:
+		n.fromOther = lift
+	default:
+		return fmt.Errorf("liftover from %s to %s does not apply to %s", lift.From, lift.To, n.assembly)
+	}
+	return nil
+}
+
+// Close releases the reference sequence, if it holds open files
+func (n *Normalizer) Close() error {
+	if closer, ok := n.reference.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Assembly returns the genome assembly variants are normalized on
+func (n *Normalizer) Assembly() string {
+	return n.assembly
+}
+
+// genomicEdit is a change to the forward strand of the genome. Start and end
+// span the replaced bases, 1-based and inclusive; insertions have
+// end = start-1, with start the base following the insertion point.
+type genomicEdit struct {
+	chromosome string
+	start      int64
+	end        int64
+	ref        string
+	alt        string
+	duplicate  bool // Parsed dup, converted to an insertion once ref is known
+}
+
+// Normalize normalizes a g. or c. notation. The transcript, if given,
+// describes a g. input on that transcript as well.
+func (n *Normalizer) Normalize(notation, transcript string) (*domain.NormalizedVariant, error) {
+	notation = strings.TrimSpace(notation)
+	match := normalizablePattern.FindStringSubmatch(notation)
+	if match == nil {
+		return nil, fmt.Errorf("cannot normalize %s: only g. and c. nucleotide substitutions, deletions, insertions, duplications and delins are supported", notation)
+	}
+	accession, coordinate, first, last, change := match[1], match[2], match[3], match[4], match[5]
+
+	result := &domain.NormalizedVariant{Input: notation, Assembly: n.assembly}
+	var (
+		tx  *Transcript
+		err error
+	)
+	if coordinate == "c" {
+		transcript = accession
+	}
+	if transcript != "" {
+		if tx, err = n.transcript(transcript); err != nil {
+			return nil, err
+		}
+		result.TranscriptID = tx.ID
+		result.GeneSymbol = tx.Gene
+		if !strings.Contains(transcript, ".") {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s resolved to %s", transcript, tx.ID))
+		}
+	}
+
+	var edit *genomicEdit
+	if coordinate == "c" {
+		edit, err = n.codingEdit(tx, first, last, change)
+	} else {
+		edit, err = n.genomicInput(accession, first, last, change, result)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	window := &sequenceWindow{source: n.reference, chromosome: edit.chromosome}
+	if err := completeEdit(window, edit); err != nil {
+		return nil, fmt.Errorf("cannot normalize %s: %w", notation, err)
+	}
+
+	genomic, err := shiftEdit(window, *edit, 1)
+	if err != nil {
+		return nil, err
+	}
+	result.Chromosome = genomic.chromosome
+	result.Start, result.End = genomic.start, genomic.end
+	result.Reference, result.Alternative = genomic.ref, genomic.alt
+	if result.HGVSGenomic, err = n.formatGenomic(window, n.assembly, genomic); err != nil {
+		return nil, err
+	}
+	shifted := genomic.start != edit.start
+
+	if tx != nil {
+		if tx.Chromosome != edit.chromosome {
+			return nil, fmt.Errorf("transcript %s is on chromosome %s, not %s", tx.ID, tx.Chromosome, edit.chromosome)
+		}
+		coding, err := shiftEdit(window, *edit, tx.direction())
+		if err != nil {
+			return nil, err
+		}
+		description, err := describe(window, coding, tx.direction(), func(pos int64) (string, error) {
+			return tx.CodingPosition(pos)
+		}, tx.Strand == '-')
+		if err != nil {
+			return nil, err
+		}
+		result.HGVSCoding = tx.ID + ":c." + description
+		if coordinate == "c" {
+			shifted = coding.start != edit.start
+		}
+	}
+	result.Shifted = shifted
+
+	if n.toOther != nil {
+		lifted, err := n.lift(window, genomic)
+		if err != nil {
+			result.Warnings = append(result.Warnings, err.Error())
+		} else {
+			result.Liftover = lifted
+		}
+	}
+	return result, nil
+}
+
+// transcript looks up a transcript in the transcript database
+func (n *Normalizer) transcript(id string) (*Transcript, error) {
+	if n.transcripts == nil {
+		return nil, fmt.Errorf("no transcript database is configured to map %s", id)
+	}
+	tx, ok := n.transcripts.Transcript(id)
+	if !ok {
+		return nil, fmt.Errorf("transcript %s not in transcript database", id)
+	}
+	if !tx.IsCoding() {
+		return nil, fmt.Errorf("transcript %s is non-coding", tx.ID)
+	}
+	return tx, nil
+}
+
+// codingEdit places a c. change on the forward strand of the genome
+func (n *Normalizer) codingEdit(tx *Transcript, first, last, change string) (*genomicEdit, error) {
+	start, err := tx.GenomicPosition(first)
+	if err != nil {
+		return nil, err
+	}
+	end := start
+	if last != "" {
+		if end, err = tx.GenomicPosition(last); err != nil {
+			return nil, err
+		}
+	}
+	if tx.Strand == '-' {
+		change = reverseComplementChange(change)
+	}
+	return parseChange(tx.Chromosome, start, end, change, last != "")
+}
+
+// genomicInput places a g. change on the normalizer's assembly, lifting it
+// over when it is described on the other assembly
+func (n *Normalizer) genomicInput(accession, first, last, change string, result *domain.NormalizedVariant) (*genomicEdit, error) {
+	chromosome, assembly, ok := accessionChromosome(accession)
+	if !ok {
+		if strings.HasPrefix(accession, "NC_") {
+			return nil, fmt.Errorf("unknown chromosome accession: %s", accession)
+		}
+		chromosome, assembly = normalizeChromosomeName(accession), ""
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid genomic position: %s", first)
+	}
+	end := start
+	if last != "" {
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid genomic position: %s", last)
+		}
+	}
+
+	if assembly != "" && assembly != n.assembly {
+		if n.fromOther == nil {
+			return nil, fmt.Errorf("%s is a %s accession and no liftover to %s is configured", accession, assembly, n.assembly)
+		}
+		liftedChromosome, liftedStart, reverse, err := n.fromOther.Lift(chromosome, start)
+		if err != nil {
+			return nil, err
+		}
+		_, liftedEnd, _, err := n.fromOther.Lift(chromosome, end)
+		if err != nil {
+			return nil, err
+		}
+		if absInt(liftedEnd-liftedStart) != absInt(end-start) {
+			return nil, fmt.Errorf("%s spans a gap in the %s to %s alignment", accession+":g."+first, assembly, n.assembly)
+		}
+		if reverse {
+			change = reverseComplementChange(change)
+		}
+		result.Warnings = append(result.Warnings, fmt.Sprintf("lifted over from %s", assembly))
+		chromosome, start, end = liftedChromosome, liftedStart, liftedEnd
+	}
+	return parseChange(chromosome, start, end, change, last != "")
+}
+
+// parseChange builds a genomic edit from the positions of a notation and its
+// change description, already on the forward strand
+func parseChange(chromosome string, start, end int64, change string, isRange bool) (*genomicEdit, error) {
+	if start > end {
+		start, end = end, start
+	}
+	edit := &genomicEdit{chromosome: chromosome, start: start, end: end}
+
+	switch {
+	case strings.Contains(change, ">"):
+		ref, alt, _ := strings.Cut(change, ">")
+		if int64(len(ref)) != end-start+1 {
+			return nil, fmt.Errorf("reference allele %s does not match the described range", ref)
+		}
+		edit.ref, edit.alt = ref, alt
+	case strings.HasPrefix(change, "delins"):
+		edit.ref, edit.alt = "?", strings.TrimPrefix(change, "delins")
+	case strings.HasPrefix(change, "del"):
+		edit.ref = strings.TrimPrefix(change, "del")
+		if edit.ref == "" {
+			edit.ref = "?"
+		}
+	case strings.HasPrefix(change, "ins"):
+		if !isRange || end-start != 1 {
+			return nil, fmt.Errorf("insertion must be between two adjacent bases")
+		}
+		edit.start, edit.end = end, start
+		edit.ref, edit.alt = "", strings.TrimPrefix(change, "ins")
+	case strings.HasPrefix(change, "dup"):
+		edit.ref, edit.duplicate = strings.TrimPrefix(change, "dup"), true
+		if edit.ref == "" {
+			edit.ref = "?"
+		}
+	}
+	return edit, nil
+}
+
+// completeEdit fills in and checks reference alleles against the genome and
+// reduces the edit to the bases that change
+func completeEdit(window *sequenceWindow, edit *genomicEdit) error {
+	if edit.start <= edit.end {
+		ref, err := window.span(edit.start, edit.end)
+		if err != nil {
+			return err
+		}
+		if int64(len(ref)) != edit.end-edit.start+1 {
+			return fmt.Errorf("%s:%d-%d is beyond the end of the chromosome", edit.chromosome, edit.start, edit.end)
+		}
+		if edit.ref != "?" && edit.ref != ref {
+			return fmt.Errorf("reference allele %s does not match the genome (%s at %s:%d)", edit.ref, ref, edit.chromosome, edit.start)
+		}
+		edit.ref = ref
+	}
+	if edit.duplicate {
+		edit.alt, edit.ref = edit.ref, ""
+		edit.start, edit.duplicate = edit.end+1, false
+	}
+
+	for len(edit.ref) > 0 && len(edit.alt) > 0 && edit.ref[len(edit.ref)-1] == edit.alt[len(edit.alt)-1] {
+		edit.ref, edit.alt = edit.ref[:len(edit.ref)-1], edit.alt[:len(edit.alt)-1]
+		edit.end--
+	}
+	for len(edit.ref) > 0 && len(edit.alt) > 0 && edit.ref[0] == edit.alt[0] {
+		edit.ref, edit.alt = edit.ref[1:], edit.alt[1:]
+		edit.start++
+	}
+	if edit.ref == "" && edit.alt == "" {
+		return fmt.Errorf("variant does not change the reference sequence")
+	}
+	return nil
+}
+
+// shiftEdit moves a deletion or insertion as far as possible in the given
+// direction along the genome: +1 for the 3' end of the forward strand, -1
+// for the 3' end of a minus-strand transcript
+func shiftEdit(window *sequenceWindow, edit genomicEdit, direction int64) (genomicEdit, error) {
+	switch {
+	case edit.ref != "" && edit.alt == "":
+		for {
+			var next, first byte
+			var err error
+			if direction > 0 {
+				next, err = window.base(edit.end + 1)
+				first = edit.ref[0]
+			} else {
+				next, err = window.base(edit.start - 1)
+				first = edit.ref[len(edit.ref)-1]
+			}
+			if err != nil {
+				return edit, err
+			}
+			if next == 0 || next != first {
+				break
+			}
+			if direction > 0 {
+				edit.ref = edit.ref[1:] + string(next)
+			} else {
+				edit.ref = string(next) + edit.ref[:len(edit.ref)-1]
+			}
+			edit.start += direction
+			edit.end += direction
+		}
+	case edit.ref == "" && edit.alt != "":
+		for {
+			var next, first byte
+			var err error
+			if direction > 0 {
+				next, err = window.base(edit.start)
+				first = edit.alt[0]
+			} else {
+				next, err = window.base(edit.end)
+				first = edit.alt[len(edit.alt)-1]
+			}
+			if err != nil {
+				return edit, err
+			}
+			if next == 0 || next != first {
+				break
+			}
+			if direction > 0 {
+				edit.alt = edit.alt[1:] + string(next)
+			} else {
+				edit.alt = string(next) + edit.alt[:len(edit.alt)-1]
+			}
+			edit.start += direction
+			edit.end += direction
+		}
+	}
+	return edit, nil
+}
+
+// describe formats the change part of an HGVS notation. Positions are
+// formatted by pos, and reverse describes the change on the minus strand.
+// Insertions of the sequence immediately 5' of them (in the direction of
+// description) are duplications.
+func describe(window *sequenceWindow, edit genomicEdit, direction int64, pos func(int64) (string, error), reverse bool) (string, error) {
+	kind := "delins"
+	first, last := edit.start, edit.end
+	seq := edit.alt
+	switch {
+	case len(edit.ref) == 1 && len(edit.alt) == 1:
+		kind = "sub"
+	case edit.alt == "":
+		kind, seq = "del", ""
+	case edit.ref == "":
+		dupStart, dupEnd, isDup, err := duplication(window, edit, direction)
+		if err != nil {
+			return "", err
+		}
+		if isDup {
+			kind, seq = "dup", ""
+			first, last = dupStart, dupEnd
+		} else {
+			kind = "ins"
+			first, last = edit.end, edit.start
+		}
+	}
+
+	if reverse {
+		first, last = last, first
+		seq = reverseComplement(seq)
+	}
+	from, err := pos(first)
+	if err != nil {
+		return "", err
+	}
+	to, err := pos(last)
+	if err != nil {
+		return "", err
+	}
+	positions := from
+	if kind == "ins" || first != last {
+		positions += "_" + to
+	}
+
+	switch kind {
+	case "sub":
+		ref, alt := edit.ref, edit.alt
+		if reverse {
+			ref, alt = reverseComplement(ref), reverseComplement(alt)
+		}
+		return positions + ref + ">" + alt, nil
+	case "del", "dup":
+		return positions + kind, nil
+	default:
+		return positions + kind + seq, nil
+	}
+}
+
+// duplication reports whether an insertion repeats the sequence immediately
+// 5' of it in the given direction, and the bases it duplicates
+func duplication(window *sequenceWindow, edit genomicEdit, direction int64) (int64, int64, bool, error) {
+	length := int64(len(edit.alt))
+	start, end := edit.start-length, edit.start-1
+	if direction < 0 {
+		start, end = edit.start, edit.start+length-1
+	}
+	preceding, err := window.span(start, end)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return start, end, preceding == edit.alt, nil
+}
+
+// formatGenomic formats a g. notation on the chromosome's RefSeq accession
+func (n *Normalizer) formatGenomic(window *sequenceWindow, assembly string, edit genomicEdit) (string, error) {
+	description, err := describe(window, edit, 1, func(pos int64) (string, error) {
+		return strconv.FormatInt(pos, 10), nil
+	}, false)
+	if err != nil {
+		return "", err
+	}
+	accession, ok := ChromosomeAccession(assembly, edit.chromosome)
+	if !ok {
+		accession = "chr" + edit.chromosome
+	}
+	return accession + ":g." + description, nil
+}
+
+// lift converts a normalized edit to the other assembly. Duplications are
+// carried over on the duplicated bases rather than the insertion point.
+func (n *Normalizer) lift(window *sequenceWindow, edit genomicEdit) (*domain.LiftedVariant, error) {
+	start, end := edit.start, edit.end
+	isDup := false
+	if edit.ref == "" {
+		dupStart, dupEnd, ok, err := duplication(window, edit, 1)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			start, end, isDup = dupStart, dupEnd, true
+		}
+	}
+
+	chromosome, liftedStart, reverse, err := n.toOther.Lift(edit.chromosome, start)
+	if err != nil {
+		return nil, err
+	}
+	_, liftedEnd, _, err := n.toOther.Lift(edit.chromosome, end)
+	if err != nil {
+		return nil, err
+	}
+	if reverse {
+		liftedStart, liftedEnd = liftedEnd, liftedStart
+	}
+	if liftedEnd-liftedStart != end-start {
+		return nil, fmt.Errorf("%s:%d-%d spans a gap in the %s to %s alignment", edit.chromosome, start, end, n.assembly, n.toOther.To)
+	}
+
+	accession, ok := ChromosomeAccession(n.toOther.To, chromosome)
+	if !ok {
+		accession = "chr" + chromosome
+	}
+	var hgvsGenomic string
+	if isDup {
+		hgvsGenomic = accession + ":g." + strconv.FormatInt(liftedStart, 10)
+		if liftedStart != liftedEnd {
+			hgvsGenomic += "_" + strconv.FormatInt(liftedEnd, 10)
+		}
+		hgvsGenomic += "dup"
+	} else {
+		lifted := genomicEdit{chromosome: chromosome, start: liftedStart, end: liftedEnd, ref: edit.ref, alt: edit.alt}
+		if reverse {
+			lifted.ref, lifted.alt = reverseComplement(edit.ref), reverseComplement(edit.alt)
+		}
+		// The other assembly's sequence is not loaded, so insertions stay insertions
+		if hgvsGenomic, err = n.formatGenomic(&sequenceWindow{}, n.toOther.To, lifted); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.LiftedVariant{
+		Assembly:    n.toOther.To,
+		HGVSGenomic: hgvsGenomic,
+		Chromosome:  chromosome,
+		Start:       liftedStart,
+		End:         liftedEnd,
+	}, nil
+}
+
+// sequenceWindow caches reference sequence around the positions being
+// examined. A window without a source returns no sequence.
+type sequenceWindow struct {
+	source     SequenceSource
+	chromosome string
+	start      int64
+	seq        string
+}
+
+// base returns the reference base at pos, or 0 beyond the chromosome
+func (w *sequenceWindow) base(pos int64) (byte, error) {
+	if w.source == nil || pos < 1 {
+		return 0, nil
+	}
+	if pos < w.start || pos >= w.start+int64(len(w.seq)) {
+		start := pos - sequenceWindowSize/2
+		if start < 1 {
+			start = 1
+		}
+		seq, err := w.source.Sequence(w.chromosome, start, start+sequenceWindowSize-1)
+		if err != nil {
+			return 0, err
+		}
+		w.start, w.seq = start, seq
+		if pos >= w.start+int64(len(w.seq)) {
+			return 0, nil
+		}
+	}
+	return w.seq[pos-w.start], nil
+}
+
+// span returns the reference bases from start to end, inclusive
+func (w *sequenceWindow) span(start, end int64) (string, error) {
+	if w.source == nil {
+		return "", nil
+	}
+	if start >= w.start && end < w.start+int64(len(w.seq)) {
+		return w.seq[start-w.start : end-w.start+1], nil
+	}
+	return w.source.Sequence(w.chromosome, start, end)
+}
+
+// reverseComplementChange converts the sequence in a change description to the other strand
+func reverseComplementChange(change string) string {
+	if ref, alt, ok := strings.Cut(change, ">"); ok {
+		return reverseComplement(ref) + ">" + reverseComplement(alt)
+	}
+	for _, kind := range []string{"delins", "del", "ins", "dup"} {
+		if strings.HasPrefix(change, kind) {
+			return kind + reverseComplement(strings.TrimPrefix(change, kind))
+		}
+	}
+	return change
+}
+
+var complements = map[byte]byte{'A': 'T', 'C': 'G', 'G': 'C', 'T': 'A', 'N': 'N'}
+
+// reverseComplement returns the reverse complement of a DNA sequence
+func reverseComplement(seq string) string {
+	out := make([]byte, len(seq))
+	for i := 0; i < len(seq); i++ {
+		out[len(seq)-1-i] = complements[seq[i]]
+	}
+	return string(out)
+}
+
+func absInt(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,292 @@
+package hgvs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testGTF describes a plus-strand transcript with exons 101-200 and 301-400
+// (coding 151-350) and a minus-strand transcript with exons 1201-1300 and
+// 1001-1100 (coding 1050-1250), both on chromosome 17.
+const testGTF = `#!genome-build GRCh38
+chr17	test	exon	101	200	.	+	.	gene_id "PLUS"; transcript_id "NM_000001.1"; gene_name "PLUS";
+chr17	test	exon	301	400	.	+	.	gene_id "PLUS"; transcript_id "NM_000001.1"; gene_name "PLUS";
+chr17	test	CDS	151	200	.	+	0	gene_id "PLUS"; transcript_id "NM_000001.1"; gene_name "PLUS";
+chr17	test	CDS	301	347	.	+	1	gene_id "PLUS"; transcript_id "NM_000001.1"; gene_name "PLUS";
+chr17	test	stop_codon	348	350	.	+	0	gene_id "PLUS"; transcript_id "NM_000001.1"; gene_name "PLUS";
+17	test	exon	1001	1100	.	-	.	gene_id "ENSG1"; transcript_id "ENST00000000002"; transcript_version "3"; gene_name "MINUS";
+17	test	exon	1201	1300	.	-	.	gene_id "ENSG1"; transcript_id "ENST00000000002"; transcript_version "3"; gene_name "MINUS";
+17	test	CDS	1053	1100	.	-	0	gene_id "ENSG1"; transcript_id "ENST00000000002"; transcript_version "3"; gene_name "MINUS";
+17	test	CDS	1201	1250	.	-	0	gene_id "ENSG1"; transcript_id "ENST00000000002"; transcript_version "3"; gene_name "MINUS";
+17	test	stop_codon	1050	1052	.	-	0	gene_id "ENSG1"; transcript_id "ENST00000000002"; transcript_version "3"; gene_name "MINUS";
+`
+
+// testReference builds a 2 kb chromosome 17 without homopolymers, except
+// for the repeats the tests shift through
+func testReference() []byte {
+	seq := []byte(strings.Repeat("GATC", 500))
+	set := func(pos int, bases string) { copy(seq[pos-1:], bases) }
+	set(169, "GTTTC")    // c.19-c.23 on the plus strand: T run at 170-172
+	set(179, "ACAGCAGT") // CAG repeat at 180-185
+	set(1239, "CAAAG")   // A run at 1240-1242, a T run at c.9-c.11 on the minus strand
+	return seq
+}
+
+// writeIndexedFasta writes a FASTA file with a samtools-style .fai index
+func writeIndexedFasta(t *testing.T, dir, name string, seq []byte) string {
+	t.Helper()
+	const width = 60
+	var b strings.Builder
+	header := ">" + name + " test\n"
+	b.WriteString(header)
+	for i := 0; i < len(seq); i += width {
+		end := i + width
+		if end > len(seq) {
+			end = len(seq)
+		}
+		b.Write(seq[i:end])
+		b.WriteString("\n")
+	}
+	path := filepath.Join(dir, "reference.fa")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fai := fmt.Sprintf("%s\t%d\t%d\t%d\t%d\n", name, len(seq), len(header), width, width+1)
+	if err := os.WriteFile(path+".fai", []byte(fai), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func createTestNormalizer(t *testing.T) (*Normalizer, []byte) {
+	t.Helper()
+	seq := testReference()
+	fasta, err := OpenIndexedFasta(writeIndexedFasta(t, t.TempDir(), "chr17", seq))
+	if err != nil {
+		t.Fatalf("OpenIndexedFasta() error = %v", err)
+	}
+	t.Cleanup(func() { fasta.Close() })
+
+	transcripts, err := LoadGTF(strings.NewReader(testGTF))
+	if err != nil {
+		t.Fatalf("LoadGTF() error = %v", err)
+	}
+	normalizer, err := NewNormalizer("hg38", transcripts, fasta)
+	if err != nil {
+		t.Fatalf("NewNormalizer() error = %v", err)
+	}
+
+	// GRCh37 coordinates on chromosome 17 are 100 bases higher
+	toGRCh37, err := LoadChain(strings.NewReader("chain 1000 chr17 2000 + 0 2000 chr17 2100 + 100 2100 1\n2000\n\n"), AssemblyGRCh38, AssemblyGRCh37)
+	if err != nil {
+		t.Fatalf("LoadChain() error = %v", err)
+	}
+	fromGRCh37, err := LoadChain(strings.NewReader("chain 1000 chr17 2100 + 100 2100 chr17 2000 + 0 2000 2\n2000\n\n"), AssemblyGRCh37, AssemblyGRCh38)
+	if err != nil {
+		t.Fatalf("LoadChain() error = %v", err)
+	}
+	for _, lift := range []*Liftover{toGRCh37, fromGRCh37} {
+		if err := normalizer.SetLiftover(lift); err != nil {
+			t.Fatalf("SetLiftover() error = %v", err)
+		}
+	}
+	return normalizer, seq
+}
+
+func TestLoadGTF(t *testing.T) {
+	transcripts, err := LoadGTF(strings.NewReader(testGTF))
+	if err != nil {
+		t.Fatalf("LoadGTF() error = %v", err)
+	}
+	if transcripts.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", transcripts.Len())
+	}
+
+	plus, ok := transcripts.Transcript("NM_000001")
+	if !ok {
+		t.Fatal("unversioned accession did not resolve")
+	}
+	if plus.ID != "NM_000001.1" || plus.Gene != "PLUS" || plus.Chromosome != "17" {
+		t.Errorf("unexpected transcript %+v", plus)
+	}
+	if plus.CDSStart != 151 || plus.CDSEnd != 350 {
+		t.Errorf("coding region = %d-%d, want 151-350 including the stop codon", plus.CDSStart, plus.CDSEnd)
+	}
+
+	minus, ok := transcripts.Transcript("ENST00000000002.3")
+	if !ok {
+		t.Fatal("Ensembl transcript_version was not appended")
+	}
+	if minus.Strand != '-' || minus.Exons[0].Start != 1201 {
+		t.Errorf("minus-strand exons are not in transcript order: %+v", minus.Exons)
+	}
+	if _, ok := transcripts.Transcript("ENST00000000002.2"); ok {
+		t.Error("a different transcript version must not resolve")
+	}
+}
+
+func TestTranscriptCodingPositions(t *testing.T) {
+	transcripts, err := LoadGTF(strings.NewReader(testGTF))
+	if err != nil {
+		t.Fatalf("LoadGTF() error = %v", err)
+	}
+	plus, _ := transcripts.Transcript("NM_000001.1")
+	minus, _ := transcripts.Transcript("ENST00000000002.3")
+
+	tests := []struct {
+		name       string
+		transcript *Transcript
+		genomic    int64
+		coding     string
+	}{
+		{"start codon", plus, 151, "1"},
+		{"5' UTR", plus, 146, "-5"},
+		{"last base of exon", plus, 200, "50"},
+		{"donor side of intron", plus, 250, "50+50"},
+		{"acceptor side of intron", plus, 251, "51-50"},
+		{"first base of exon", plus, 301, "51"},
+		{"stop codon", plus, 350, "100"},
+		{"3' UTR", plus, 351, "*1"},
+		{"minus strand start codon", minus, 1250, "1"},
+		{"minus strand exonic", minus, 1240, "11"},
+		{"minus strand intron", minus, 1200, "50+1"},
+		{"minus strand acceptor", minus, 1101, "51-1"},
+		{"minus strand stop codon", minus, 1050, "101"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coding, err := tt.transcript.CodingPosition(tt.genomic)
+			if err != nil {
+				t.Fatalf("CodingPosition() error = %v", err)
+			}
+			if coding != tt.coding {
+				t.Errorf("CodingPosition(%d) = %s, want %s", tt.genomic, coding, tt.coding)
+			}
+			genomic, err := tt.transcript.GenomicPosition(tt.coding)
+			if err != nil {
+				t.Fatalf("GenomicPosition() error = %v", err)
+			}
+			if genomic != tt.genomic {
+				t.Errorf("GenomicPosition(%s) = %d, want %d", tt.coding, genomic, tt.genomic)
+			}
+		})
+	}
+}
+
+func TestNormalizerNormalize(t *testing.T) {
+	normalizer, seq := createTestNormalizer(t)
+	base := func(pos int) string { return string(seq[pos-1]) }
+
+	tests := []struct {
+		name       string
+		input      string
+		transcript string
+		genomic    string
+		coding     string
+		lifted     string
+		shifted    bool
+	}{
+		{
+			name:    "coding substitution",
+			input:   "NM_000001.1:c.1" + base(151) + ">G",
+			genomic: "NC_000017.11:g.151" + base(151) + ">G",
+			coding:  "NM_000001.1:c.1" + base(151) + ">G",
+			lifted:  "NC_000017.10:g.251" + base(151) + ">G",
+		},
+		{
+			name:    "deletion is shifted 3'",
+			input:   "NM_000001.1:c.20del",
+			genomic: "NC_000017.11:g.172del",
+			coding:  "NM_000001.1:c.22del",
+			lifted:  "NC_000017.10:g.272del",
+			shifted: true,
+		},
+		{
+			name:    "insertion of the preceding sequence is a duplication",
+			input:   "NC_000017.11:g.179_180insCAG",
+			genomic: "NC_000017.11:g.183_185dup",
+			lifted:  "NC_000017.10:g.283_285dup",
+			shifted: true,
+		},
+		{
+			name:       "genomic input described on a transcript",
+			input:      "NC_000017.11:g.180dupC",
+			transcript: "NM_000001.1",
+			genomic:    "NC_000017.11:g.180dup",
+			coding:     "NM_000001.1:c.30dup",
+			lifted:     "NC_000017.10:g.280dup",
+		},
+		{
+			name:    "minus strand is shifted 3' of the transcript",
+			input:   "ENST00000000002.3:c.9delT",
+			genomic: "NC_000017.11:g.1242del",
+			coding:  "ENST00000000002.3:c.11del",
+			lifted:  "NC_000017.10:g.1342del",
+			shifted: true,
+		},
+		{
+			name:    "delins is trimmed to a substitution",
+			input:   "chr17:g.151_152delins" + base(151) + "A",
+			genomic: "NC_000017.11:g.152" + base(152) + ">A",
+			lifted:  "NC_000017.10:g.252" + base(152) + ">A",
+		},
+		{
+			name:    "GRCh37 input is lifted over",
+			input:   "NC_000017.10:g.251" + base(151) + ">G",
+			genomic: "NC_000017.11:g.151" + base(151) + ">G",
+			lifted:  "NC_000017.10:g.251" + base(151) + ">G",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := normalizer.Normalize(tt.input, tt.transcript)
+			if err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			if result.HGVSGenomic != tt.genomic {
+				t.Errorf("HGVSGenomic = %s, want %s", result.HGVSGenomic, tt.genomic)
+			}
+			if result.HGVSCoding != tt.coding {
+				t.Errorf("HGVSCoding = %s, want %s", result.HGVSCoding, tt.coding)
+			}
+			if result.Liftover == nil || result.Liftover.HGVSGenomic != tt.lifted {
+				t.Errorf("Liftover = %+v, want %s", result.Liftover, tt.lifted)
+			}
+			if result.Shifted != tt.shifted {
+				t.Errorf("Shifted = %v, want %v", result.Shifted, tt.shifted)
+			}
+			if result.Assembly != AssemblyGRCh38 {
+				t.Errorf("Assembly = %s, want GRCh38", result.Assembly)
+			}
+		})
+	}
+}
+
+func TestNormalizerNormalizeErrors(t *testing.T) {
+	normalizer, seq := createTestNormalizer(t)
+	wrong := "A"
+	if seq[150] == 'A' {
+		wrong = "C"
+	}
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"reference mismatch", "NM_000001.1:c.1" + wrong + ">T"},
+		{"unknown transcript", "NM_999999.1:c.1A>T"},
+		{"protein notation", "NP_000537.3:p.Arg248Gln"},
+		{"non-adjacent insertion", "NC_000017.11:g.179_185insA"},
+		{"no change", "chr17:g.151_152delins" + string(seq[150:152])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := normalizer.Normalize(tt.input, ""); err == nil {
+				t.Errorf("Normalize(%s) expected an error", tt.input)
+			}
+		})
+	}
+}
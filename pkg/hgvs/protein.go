@@ -0,0 +1,55 @@
+package hgvs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// threeLetterAminoAcid matches three-letter amino acid codes in a protein change
+var threeLetterAminoAcid = regexp.MustCompile(`Ala|Arg|Asn|Asp|Cys|Gln|Glu|Gly|His|Ile|Leu|Lys|Met|Phe|Pro|Ser|Thr|Trp|Tyr|Val|Ter`)
+
+// ShortProteinChange returns a protein change in the one-letter form cancer
+// knowledge bases use, e.g. "p.(Val600Glu)" becomes "V600E". The "p." prefix,
+// any transcript accession and predicted-change parentheses are removed.
+func ShortProteinChange(protein string) string {
+	if i := strings.Index(protein, ":"); i >= 0 {
+		protein = protein[i+1:]
+	}
+	protein = strings.TrimPrefix(strings.TrimSpace(protein), "p.")
+	protein = strings.TrimSuffix(strings.TrimPrefix(protein, "("), ")")
+	return threeLetterAminoAcid.ReplaceAllStringFunc(protein, func(code string) string {
+		return aminoAcidCodes[code]
+	})
+}
+
+// missensePattern matches a one-letter missense change such as R1699W
+var missensePattern = regexp.MustCompile(`^([ACDEFGHIKLMNPQRSTVWY])(\d+)([ACDEFGHIKLMNPQRSTVWY])$`)
+
+// MissenseChange is a single amino acid substitution
+type MissenseChange struct {
+	Ref      string // One-letter reference amino acid
+	Position int
+	Alt      string // One-letter substituted amino acid
+}
+
+// String returns the change in one-letter form, e.g. R1699W
+func (m MissenseChange) String() string {
+	return fmt.Sprintf("%s%d%s", m.Ref, m.Position, m.Alt)
+}
+
+// ParseMissense parses a protein change in three- or one-letter form as a
+// missense substitution. Synonymous, nonsense, frameshift and in-frame
+// changes are not missense and return false.
+func ParseMissense(protein string) (MissenseChange, bool) {
+	match := missensePattern.FindStringSubmatch(ShortProteinChange(protein))
+	if match == nil || match[1] == match[3] {
+		return MissenseChange{}, false
+	}
+	position, err := strconv.Atoi(match[2])
+	if err != nil || position <= 0 {
+		return MissenseChange{}, false
+	}
+	return MissenseChange{Ref: match[1], Position: position, Alt: match[3]}, true
+}
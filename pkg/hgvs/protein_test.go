@@ -0,0 +1,38 @@
+package hgvs
+
+import "testing"
+
+func TestShortProteinChange(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"p.Val600Glu", "V600E"},
+		{"p.(Val600Glu)", "V600E"},
+		{"NP_004324.2:p.Val600Glu", "V600E"},
+		{"p.V600E", "V600E"},
+		{"p.Arg273Ter", "R273*"},
+		{"p.Gln1756ProfsTer74", "Q1756Pfs*74"},
+		{"p.Glu746_Ala750del", "E746_A750del"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ShortProteinChange(tt.input); got != tt.want {
+			t.Errorf("ShortProteinChange(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseMissense(t *testing.T) {
+	change, ok := ParseMissense("NP_009225.1:p.(Arg1699Trp)")
+	if !ok || change != (MissenseChange{Ref: "R", Position: 1699, Alt: "W"}) || change.String() != "R1699W" {
+		t.Errorf("ParseMissense = %+v, %v", change, ok)
+	}
+
+	for _, input := range []string{"p.Arg273Ter", "p.Ala482=", "p.Ala482Ala", "p.Gln1756ProfsTer74", "p.Glu746_Ala750del", "c.5095C>T", ""} {
+		if _, ok := ParseMissense(input); ok {
+			t.Errorf("ParseMissense(%q) should not be missense", input)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package hgvs
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SequenceSource returns reference genome sequence.
+type SequenceSource interface {
+	// Sequence returns the bases from start to end, 1-based and inclusive.
+	// The range is clipped to the chromosome.
+	Sequence(chromosome string, start, end int64) (string, error)
+}
+
+// faiEntry is one line of a samtools .fai index
+type faiEntry struct {
+	length    int64
+	offset    int64
+	lineBases int64
+	lineWidth int64
+}
+
+// IndexedFasta reads sequence from an uncompressed FASTA file with a
+// samtools faidx index alongside it (<path>.fai).
+type IndexedFasta struct {
+	mu    sync.Mutex
+	file  *os.File
+	index map[string]faiEntry
+}
+
+// OpenIndexedFasta opens a FASTA file and its .fai index. Sequence names
+// such as chr17, 17 and NC_000017.11 all resolve to chromosome 17.
+func OpenIndexedFasta(path string) (*IndexedFasta, error) {
+	idxFile, err := os.Open(path + ".fai")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open FASTA index: %w", err)
+	}
+	defer idxFile.Close()
+
+	index := make(map[string]faiEntry)
+	scanner := bufio.NewScanner(idxFile)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 5 {
+			continue
+		}
+		var entry faiEntry
+		values := []*int64{&entry.length, &entry.offset, &entry.lineBases, &entry.lineWidth}
+		for i, v := range values {
+			n, err := strconv.ParseInt(fields[i+1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid FASTA index entry for %s: %w", fields[0], err)
+			}
+			*v = n
+		}
+		index[normalizeChromosomeName(fields[0])] = entry
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read FASTA index: %w", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open FASTA file: %w", err)
+	}
+	return &IndexedFasta{file: file, index: index}, nil
+}
+
+// Sequence returns the uppercase bases from start to end, 1-based and inclusive.
+func (f *IndexedFasta) Sequence(chromosome string, start, end int64) (string, error) {
+	entry, ok := f.index[normalizeChromosomeName(chromosome)]
+	if !ok {
+		return "", fmt.Errorf("chromosome %s not in reference sequence", chromosome)
+	}
+	if start < 1 {
+		start = 1
+	}
+	if end > entry.length {
+		end = entry.length
+	}
+	if start > end {
+		return "", nil
+	}
+
+	first := f.byteOffset(entry, start-1)
+	last := f.byteOffset(entry, end-1)
+	buf := make([]byte, last-first+1)
+
+	f.mu.Lock()
+	_, err := f.file.ReadAt(buf, first)
+	f.mu.Unlock()
+	if err != nil {
+		return "", fmt.Errorf("failed to read reference sequence: %w", err)
+	}
+
+	seq := make([]byte, 0, end-start+1)
+	for _, b := range buf {
+		if b != '\n' && b != '\r' {
+			seq = append(seq, b)
+		}
+	}
+	return strings.ToUpper(string(seq)), nil
+}
+
+// Length returns the number of bases in a chromosome.
+func (f *IndexedFasta) Length(chromosome string) (int64, error) {
+	entry, ok := f.index[normalizeChromosomeName(chromosome)]
+	if !ok {
+		return 0, fmt.Errorf("chromosome %s not in reference sequence", chromosome)
+	}
+	return entry.length, nil
+}
+
+// byteOffset returns the file offset of a 0-based base position
+func (f *IndexedFasta) byteOffset(entry faiEntry, pos int64) int64 {
+	return entry.offset + pos/entry.lineBases*entry.lineWidth + pos%entry.lineBases
+}
+
+// Close closes the FASTA file
+func (f *IndexedFasta) Close() error {
+	return f.file.Close()
+}
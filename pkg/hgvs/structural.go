@@ -0,0 +1,206 @@
+package hgvs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+// Structural variant patterns. Positions may carry intronic offsets and the
+// UTR prefixes of c. notation.
+var (
+	// NM_002111.8:c.52CAG[45], NC_000004.12:g.3074877_3074939CAG[(40_45)]
+	repeatPattern = regexp.MustCompile(`^((?:NC|NG|NM|NR)_\d+\.\d+):([cgn])\.([*\-]?\d+(?:[+\-]\d+)?(?:_[*\-]?\d+(?:[+\-]\d+)?)?)([ACGTNacgtn]+)\[(\(?)(\d+)(?:_(\d+))?(\)?)\]$`)
+
+	// NC_000023.11:g.154000000_154500000inv
+	inversionPattern = regexp.MustCompile(`^((?:NC|NG|NM|NR)_\d+\.\d+|chr(?:\d+|[XYM])):([cgn])\.([*\-]?\d+(?:[+\-]\d+)?)_([*\-]?\d+(?:[+\-]\d+)?)inv$`)
+
+	// NC_000009.12:g.130714455::NC_000022.11:g.23180365, each side optionally
+	// a segment to the chromosome end: g.pter_8247756::g.15825273_qter
+	junctionSidePattern = regexp.MustCompile(`^(NC_\d+\.\d+):g\.(pter|\d+)(?:_(\d+|qter))?$`)
+
+	// VCF breakend ALT alleles: t[p[, t]p], ]p]t and [p[t
+	breakendPattern = regexp.MustCompile(`^([ACGTNacgtn]*)([\[\]])([^\[\]:]+):(\d+)([\[\]])([ACGTNacgtn]*)$`)
+
+	// repeatShape recognizes a repeat unit count such as CAG[45]
+	repeatShape = regexp.MustCompile(`[ACGTNacgtn]\[\(?\d`)
+)
+
+// minStructuralInversion is the length from which an inversion is a
+// structural variant; shorter inversions are sequence variants
+const minStructuralInversion = 50
+
+// maxRepeatCount bounds repeat unit counts; the largest expansions reported
+// (e.g. FMR1 full mutations) run to a few thousand units
+const maxRepeatCount = 10000
+
+// IsStructural reports whether a notation has the shape of a repeat
+// expansion, an inversion of at least 50 bases or a translocation junction.
+// ParseStructural validates it.
+func IsStructural(notation string) bool {
+	notation = strings.TrimSpace(notation)
+	if strings.Contains(notation, "::") || repeatShape.MatchString(notation) {
+		return true
+	}
+	m := inversionPattern.FindStringSubmatch(notation)
+	if m == nil {
+		return false
+	}
+	start, startErr := strconv.ParseInt(m[3], 10, 64)
+	end, endErr := strconv.ParseInt(m[4], 10, 64)
+	return startErr == nil && endErr == nil && end-start+1 >= minStructuralInversion
+}
+
+// ParseStructural parses a repeat expansion (NM_002111.8:c.52CAG[45]), an
+// inversion (NC_000023.11:g.154000000_154500000inv) or a translocation
+// junction (NC_000009.12:g.130714455::NC_000022.11:g.23180365) and returns
+// it with its notation normalized: repeat units upper case and uncertain
+// counts in parentheses.
+func ParseStructural(notation string) (*domain.StructuralVariant, error) {
+	notation = strings.TrimSpace(notation)
+
+	if m := repeatPattern.FindStringSubmatch(notation); m != nil {
+		return parseRepeat(notation, m)
+	}
+	if m := inversionPattern.FindStringSubmatch(notation); m != nil {
+		return parseInversion(notation, m)
+	}
+	if strings.Contains(notation, "::") {
+		return parseJunction(notation)
+	}
+	return nil, domain.NewValidationError("hgvs", "Not a repeat expansion, inversion or translocation notation", notation)
+}
+
+func parseRepeat(notation string, m []string) (*domain.StructuralVariant, error) {
+	if (m[5] == "(") != (m[8] == ")") {
+		return nil, domain.NewValidationError("hgvs", "Unbalanced parentheses in repeat count", notation)
+	}
+	count, err := strconv.Atoi(m[6])
+	if err != nil || count < 1 || count > maxRepeatCount {
+		return nil, domain.NewValidationError("hgvs", fmt.Sprintf("Repeat count must be between 1 and %d", maxRepeatCount), notation)
+	}
+	sv := &domain.StructuralVariant{
+		Kind:        domain.RepeatExpansion,
+		RepeatUnit:  strings.ToUpper(m[4]),
+		RepeatCount: count,
+	}
+
+	countText := m[6]
+	if m[7] != "" {
+		max, err := strconv.Atoi(m[7])
+		if err != nil || max <= count || max > maxRepeatCount {
+			return nil, domain.NewValidationError("hgvs", "Uncertain repeat count must be a range from low to high, e.g. [(40_45)]", notation)
+		}
+		sv.RepeatCountMax = max
+		countText = fmt.Sprintf("(%d_%d)", count, max)
+	}
+
+	sv.Notation = fmt.Sprintf("%s:%s.%s%s[%s]", m[1], m[2], m[3], sv.RepeatUnit, countText)
+	if m[2] == "g" {
+		if start, err := strconv.ParseInt(strings.SplitN(m[3], "_", 2)[0], 10, 64); err == nil {
+			sv.Breakpoints = []domain.Breakend{breakend(m[1], start)}
+		}
+	}
+	return sv, nil
+}
+
+func parseInversion(notation string, m []string) (*domain.StructuralVariant, error) {
+	start, startErr := strconv.ParseInt(m[3], 10, 64)
+	end, endErr := strconv.ParseInt(m[4], 10, 64)
+	if startErr == nil && endErr == nil && start >= end {
+		return nil, domain.NewValidationError("hgvs", "Inversion must span at least two bases, from low to high position", notation)
+	}
+
+	sv := &domain.StructuralVariant{Kind: domain.Inversion, Notation: notation}
+	if m[2] == "g" && startErr == nil && endErr == nil {
+		sv.Breakpoints = []domain.Breakend{breakend(m[1], start), breakend(m[1], end)}
+	}
+	return sv, nil
+}
+
+// parseJunction parses a translocation junction. The breakpoints are the
+// positions either side of the "::", e.g. 8247756 and 15825273 in
+// NC_000002.12:g.pter_8247756::NC_000011.10:g.15825273_qter.
+func parseJunction(notation string) (*domain.StructuralVariant, error) {
+	sides := strings.Split(notation, "::")
+	if len(sides) != 2 {
+		return nil, domain.NewValidationError("hgvs", "Translocation junction must join exactly two chromosomal segments with '::'", notation)
+	}
+
+	var breakpoints []domain.Breakend
+	var assemblies []string
+	for i, side := range sides {
+		m := junctionSidePattern.FindStringSubmatch(side)
+		if m == nil {
+			return nil, domain.NewValidationError("hgvs", "Translocation sides must be chromosomal g. positions, e.g. NC_000009.12:g.130714455", notation)
+		}
+		// The first side ends at its breakpoint; the second starts at it
+		position := m[2]
+		if i == 0 && m[3] != "" {
+			position = m[3]
+		}
+		pos, err := strconv.ParseInt(position, 10, 64)
+		if err != nil || pos < 1 {
+			return nil, domain.NewValidationError("hgvs", "Translocation breakpoints must be positions, not chromosome ends", notation)
+		}
+		chromosome, assembly, ok := accessionChromosome(m[1])
+		if !ok {
+			return nil, domain.NewValidationError("hgvs", "Unknown chromosome accession "+m[1], notation)
+		}
+		breakpoints = append(breakpoints, domain.Breakend{Reference: m[1], Chromosome: chromosome, Position: pos})
+		if assembly != "" {
+			assemblies = append(assemblies, assembly)
+		}
+	}
+
+	if breakpoints[0].Chromosome == breakpoints[1].Chromosome {
+		return nil, domain.NewValidationError("hgvs", "Junction within one chromosome; describe it as an inversion, deletion or duplication", notation)
+	}
+	if len(assemblies) == 2 && assemblies[0] != assemblies[1] {
+		return nil, domain.NewValidationError("hgvs", "Translocation breakpoints are on different assemblies", notation)
+	}
+	return &domain.StructuralVariant{Kind: domain.Translocation, Notation: notation, Breakpoints: breakpoints}, nil
+}
+
+// breakend places a position on an accession, naming the chromosome when
+// the accession is one
+func breakend(reference string, position int64) domain.Breakend {
+	b := domain.Breakend{Reference: reference, Position: position}
+	if strings.HasPrefix(reference, "chr") {
+		b.Chromosome = normalizeChromosomeName(reference)
+	} else if chromosome, _, ok := accessionChromosome(reference); ok {
+		b.Chromosome = chromosome
+	}
+	return b
+}
+
+// fromBreakend describes a VCF breakend ALT between two chromosomes as an
+// HGVS translocation junction. The piece before the "::" is the one the
+// ALT joins first: the mate when the ALT starts with a bracket.
+func fromBreakend(assembly, accession string, pos int64, alt string) (string, error) {
+	m := breakendPattern.FindStringSubmatch(alt)
+	if m == nil || m[2] != m[5] || (m[1] == "") == (m[6] == "") {
+		return "", fmt.Errorf("invalid breakend ALT %q", alt)
+	}
+	matePos, err := strconv.ParseInt(m[4], 10, 64)
+	if err != nil || matePos < 1 {
+		return "", fmt.Errorf("invalid breakend mate position in %q", alt)
+	}
+	mateAccession, ok := ChromosomeAccession(assembly, m[3])
+	if !ok {
+		return "", fmt.Errorf("unknown breakend mate chromosome %q for %s", m[3], assembly)
+	}
+	if mateAccession == accession {
+		return "", fmt.Errorf("breakend within one chromosome; describe it in HGVS as an inversion, deletion or duplication")
+	}
+
+	local := fmt.Sprintf("%s:g.%d", accession, pos)
+	mate := fmt.Sprintf("%s:g.%d", mateAccession, matePos)
+	if m[1] == "" {
+		return mate + "::" + local, nil
+	}
+	return local + "::" + mate, nil
+}
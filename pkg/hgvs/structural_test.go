@@ -0,0 +1,85 @@
+package hgvs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/acmg-amp-mcp-server/internal/domain"
+)
+
+func TestParseStructural(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		want     domain.StructuralVariant
+	}{
+		{"repeat count", "NM_002111.8:c.52CAG[45]", domain.StructuralVariant{
+			Kind: domain.RepeatExpansion, Notation: "NM_002111.8:c.52CAG[45]", RepeatUnit: "CAG", RepeatCount: 45}},
+		{"uncertain repeat count normalized", "NC_000004.12:g.3074877_3074939cag[40_45]", domain.StructuralVariant{
+			Kind: domain.RepeatExpansion, Notation: "NC_000004.12:g.3074877_3074939CAG[(40_45)]", RepeatUnit: "CAG",
+			RepeatCount: 40, RepeatCountMax: 45,
+			Breakpoints: []domain.Breakend{{Reference: "NC_000004.12", Chromosome: "4", Position: 3074877}}}},
+		{"inversion", "NC_000023.11:g.154000000_154500000inv", domain.StructuralVariant{
+			Kind: domain.Inversion, Notation: "NC_000023.11:g.154000000_154500000inv",
+			Breakpoints: []domain.Breakend{
+				{Reference: "NC_000023.11", Chromosome: "X", Position: 154000000},
+				{Reference: "NC_000023.11", Chromosome: "X", Position: 154500000}}}},
+		{"coding inversion", "NM_000492.4:c.1210-12_1210-6inv", domain.StructuralVariant{
+			Kind: domain.Inversion, Notation: "NM_000492.4:c.1210-12_1210-6inv"}},
+		{"translocation", "NC_000009.12:g.130714455::NC_000022.11:g.23180365", domain.StructuralVariant{
+			Kind: domain.Translocation, Notation: "NC_000009.12:g.130714455::NC_000022.11:g.23180365",
+			Breakpoints: []domain.Breakend{
+				{Reference: "NC_000009.12", Chromosome: "9", Position: 130714455},
+				{Reference: "NC_000022.11", Chromosome: "22", Position: 23180365}}}},
+		{"translocation to chromosome ends", "NC_000002.12:g.pter_8247756::NC_000011.10:g.15825273_qter", domain.StructuralVariant{
+			Kind: domain.Translocation, Notation: "NC_000002.12:g.pter_8247756::NC_000011.10:g.15825273_qter",
+			Breakpoints: []domain.Breakend{
+				{Reference: "NC_000002.12", Chromosome: "2", Position: 8247756},
+				{Reference: "NC_000011.10", Chromosome: "11", Position: 15825273}}}},
+	}
+	for _, tt := range tests {
+		if !IsStructural(tt.notation) && tt.want.Kind != domain.Inversion {
+			t.Errorf("%s: IsStructural(%s) = false", tt.name, tt.notation)
+		}
+		got, err := ParseStructural(tt.notation)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(*got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, *got, tt.want)
+		}
+	}
+}
+
+func TestParseStructural_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+	}{
+		{"zero repeat count", "NM_002111.8:c.52CAG[0]"},
+		{"reversed repeat range", "NM_002111.8:c.52CAG[(45_40)]"},
+		{"unbalanced parentheses", "NM_002111.8:c.52CAG[(45]"},
+		{"single base inversion", "NC_000001.11:g.100_100inv"},
+		{"reversed inversion", "NC_000001.11:g.200_100inv"},
+		{"same chromosome junction", "NC_000009.12:g.100::NC_000009.12:g.5000"},
+		{"mixed assembly junction", "NC_000009.11:g.100::NC_000022.11:g.5000"},
+		{"transcript junction", "NM_002111.8:c.52::NC_000022.11:g.5000"},
+		{"three-way junction", "NC_000009.12:g.100::NC_000022.11:g.5000::NC_000001.11:g.10"},
+		{"substitution", "NM_000492.4:c.1521G>A"},
+	}
+	for _, tt := range tests {
+		if got, err := ParseStructural(tt.notation); err == nil {
+			t.Errorf("%s: expected an error, got %+v", tt.name, got)
+		}
+	}
+
+	for _, notation := range []string{"NM_000492.4:c.1521_1523delCTT", "NM_000492.4:c.1210-12_1210-6inv", "NC_000001.11:g.100_120inv"} {
+		if IsStructural(notation) {
+			t.Errorf("%s is a sequence variant", notation)
+		}
+	}
+	if !IsStructural("NC_000023.11:g.154000000_154500000inv") {
+		t.Error("a 500 kb inversion is structural")
+	}
+}
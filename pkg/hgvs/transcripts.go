@@ -0,0 +1,498 @@
+package hgvs
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Exon is a transcribed genomic interval, 1-based and inclusive
+type Exon struct {
+	Start int64
+	End   int64
+}
+
+// Transcript describes the exon structure of a reference transcript on the genome
+type Transcript struct {
+	ID         string
+	Gene       string
+	Chromosome string
+	Strand     byte   // '+' or '-'
+	Exons      []Exon // In transcript order, 5' to 3'
+	CDSStart   int64  // Lowest genomic position of the coding region, including the stop codon
+	CDSEnd     int64  // Highest genomic position of the coding region, including the stop codon
+	MANE       string // MANESelect or MANEPlusClinical when the transcript is tagged as such
+}
+
+// MANE transcript designations, as reported on Transcript.MANE
+const (
+	MANESelect       = "MANE Select"
+	MANEPlusClinical = "MANE Plus Clinical"
+)
+
+// OverlapSource finds the reference transcripts overlapping a genomic interval.
+type OverlapSource interface {
+	Overlapping(chromosome string, start, end int64) []*Transcript
+}
+
+// TranscriptSource looks up reference transcripts by accession.
+type TranscriptSource interface {
+	Transcript(id string) (*Transcript, bool)
+}
+
+// TranscriptIndex is an in-memory TranscriptSource
+type TranscriptIndex struct {
+	transcripts  map[string]*Transcript
+	latest       map[string]*Transcript   // Highest loaded version per unversioned accession
+	byChromosome map[string][]*Transcript // Sorted by the start of the first exon on the genome
+}
+
+// NewTranscriptIndex creates an index over the given transcripts.
+func NewTranscriptIndex(transcripts []*Transcript) *TranscriptIndex {
+	idx := &TranscriptIndex{
+		transcripts:  make(map[string]*Transcript, len(transcripts)),
+		latest:       make(map[string]*Transcript, len(transcripts)),
+		byChromosome: make(map[string][]*Transcript),
+	}
+	for _, tx := range transcripts {
+		idx.transcripts[tx.ID] = tx
+		base, version := splitAccessionVersion(tx.ID)
+		if current, ok := idx.latest[base]; !ok || version > accessionVersion(current.ID) {
+			idx.latest[base] = tx
+		}
+		if len(tx.Exons) > 0 {
+			idx.byChromosome[tx.Chromosome] = append(idx.byChromosome[tx.Chromosome], tx)
+		}
+	}
+	for _, list := range idx.byChromosome {
+		sort.Slice(list, func(i, j int) bool {
+			first, _ := list[i].Span()
+			other, _ := list[j].Span()
+			return first < other
+		})
+	}
+	return idx
+}
+
+// Overlapping returns the transcripts whose span from first to last exon
+// overlaps start-end on the chromosome, MANE Select first, then MANE Plus
+// Clinical, then by accession.
+func (idx *TranscriptIndex) Overlapping(chromosome string, start, end int64) []*Transcript {
+	if end < start {
+		start, end = end, start
+	}
+	var overlapping []*Transcript
+	for _, tx := range idx.byChromosome[normalizeChromosomeName(chromosome)] {
+		first, last := tx.Span()
+		if first > end {
+			break
+		}
+		if last >= start {
+			overlapping = append(overlapping, tx)
+		}
+	}
+	sort.SliceStable(overlapping, func(i, j int) bool {
+		if rank, other := maneRank(overlapping[i].MANE), maneRank(overlapping[j].MANE); rank != other {
+			return rank < other
+		}
+		return overlapping[i].ID < overlapping[j].ID
+	})
+	return overlapping
+}
+
+// maneRank orders MANE Select before MANE Plus Clinical before other transcripts
+func maneRank(mane string) int {
+	switch mane {
+	case MANESelect:
+		return 0
+	case MANEPlusClinical:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Transcript returns the transcript with the given accession. An
+// unversioned accession resolves to the highest version loaded.
+func (idx *TranscriptIndex) Transcript(id string) (*Transcript, bool) {
+	if tx, ok := idx.transcripts[id]; ok {
+		return tx, true
+	}
+	if !strings.Contains(id, ".") {
+		tx, ok := idx.latest[id]
+		return tx, ok
+	}
+	return nil, false
+}
+
+// Len returns the number of transcripts in the index
+func (idx *TranscriptIndex) Len() int {
+	return len(idx.transcripts)
+}
+
+// LoadGTFFile reads transcripts from a RefSeq or Ensembl GTF file, gzipped or not.
+func LoadGTFFile(path string) (*TranscriptIndex, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transcript file: %w", err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read transcript file: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return LoadGTF(r)
+}
+
+// LoadGTF reads transcripts from GTF records. Only exon, CDS, start_codon and
+// stop_codon features are used; Ensembl transcript_version attributes are
+// appended to the transcript ID.
+func LoadGTF(r io.Reader) (*TranscriptIndex, error) {
+	byID := make(map[string]*Transcript)
+	var order []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) < 9 {
+			return nil, fmt.Errorf("line %d: expected 9 GTF columns, found %d", line, len(fields))
+		}
+		feature := fields[2]
+		if feature != "exon" && feature != "CDS" && feature != "start_codon" && feature != "stop_codon" {
+			continue
+		}
+
+		var start, end int64
+		if _, err := fmt.Sscan(fields[3], &start); err != nil {
+			return nil, fmt.Errorf("line %d: invalid start %q", line, fields[3])
+		}
+		if _, err := fmt.Sscan(fields[4], &end); err != nil {
+			return nil, fmt.Errorf("line %d: invalid end %q", line, fields[4])
+		}
+		attrs := parseGTFAttributes(fields[8])
+		id := attrs["transcript_id"]
+		if id == "" {
+			continue
+		}
+		if version := attrs["transcript_version"]; version != "" && !strings.Contains(id, ".") {
+			id += "." + version
+		}
+
+		tx, ok := byID[id]
+		if !ok {
+			tx = &Transcript{
+				ID:         id,
+				Gene:       firstNonEmpty(attrs["gene_name"], attrs["gene"], attrs["gene_id"]),
+				Chromosome: normalizeChromosomeName(fields[0]),
+				Strand:     fields[6][0],
+			}
+			byID[id] = tx
+			order = append(order, id)
+		}
+		if tx.MANE == "" {
+			tx.MANE = maneTag(fields[8])
+		}
+
+		switch feature {
+		case "exon":
+			tx.Exons = append(tx.Exons, Exon{Start: start, End: end})
+		default:
+			if tx.CDSStart == 0 || start < tx.CDSStart {
+				tx.CDSStart = start
+			}
+			if end > tx.CDSEnd {
+				tx.CDSEnd = end
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read transcript file: %w", err)
+	}
+
+	transcripts := make([]*Transcript, 0, len(order))
+	for _, id := range order {
+		tx := byID[id]
+		if len(tx.Exons) == 0 {
+			continue
+		}
+		sort.Slice(tx.Exons, func(i, j int) bool {
+			if tx.Strand == '-' {
+				return tx.Exons[i].Start > tx.Exons[j].Start
+			}
+			return tx.Exons[i].Start < tx.Exons[j].Start
+		})
+		transcripts = append(transcripts, tx)
+	}
+	return NewTranscriptIndex(transcripts), nil
+}
+
+// parseGTFAttributes parses the key "value"; attribute column of a GTF record
+func parseGTFAttributes(column string) map[string]string {
+	attrs := make(map[string]string)
+	for _, attr := range strings.Split(column, ";") {
+		attr = strings.TrimSpace(attr)
+		key, value, ok := strings.Cut(attr, " ")
+		if !ok {
+			continue
+		}
+		if _, exists := attrs[key]; !exists {
+			attrs[key] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return attrs
+}
+
+// maneTag returns the MANE designation among the tag attributes of a GTF
+// record: Ensembl writes tag "MANE_Select", RefSeq tag "MANE Select"
+func maneTag(column string) string {
+	designation := ""
+	for _, attr := range strings.Split(column, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(attr), " ")
+		if !ok || key != "tag" {
+			continue
+		}
+		switch strings.ToLower(strings.ReplaceAll(strings.Trim(strings.TrimSpace(value), `"`), "_", " ")) {
+		case "mane select":
+			return MANESelect
+		case "mane plus clinical":
+			designation = MANEPlusClinical
+		}
+	}
+	return designation
+}
+
+// Span returns the lowest and highest genomic positions of the transcript's exons
+func (tx *Transcript) Span() (int64, int64) {
+	if len(tx.Exons) == 0 {
+		return 0, 0
+	}
+	first, last := tx.Exons[0], tx.Exons[len(tx.Exons)-1]
+	if tx.Strand == '-' {
+		first, last = last, first
+	}
+	return first.Start, last.End
+}
+
+// IsCoding reports whether the transcript has an annotated coding region
+func (tx *Transcript) IsCoding() bool {
+	return tx.CDSStart > 0 && tx.CDSEnd >= tx.CDSStart
+}
+
+// Length returns the number of transcribed bases
+func (tx *Transcript) Length() int64 {
+	var length int64
+	for _, exon := range tx.Exons {
+		length += exon.End - exon.Start + 1
+	}
+	return length
+}
+
+// direction is +1 when the transcript runs with increasing genomic
+// coordinates and -1 otherwise
+func (tx *Transcript) direction() int64 {
+	if tx.Strand == '-' {
+		return -1
+	}
+	return 1
+}
+
+// exonFirst and exonLast return the first and last transcribed base of an exon
+func (tx *Transcript) exonFirst(exon Exon) int64 {
+	if tx.Strand == '-' {
+		return exon.End
+	}
+	return exon.Start
+}
+
+func (tx *Transcript) exonLast(exon Exon) int64 {
+	if tx.Strand == '-' {
+		return exon.Start
+	}
+	return exon.End
+}
+
+// genomicToTranscript returns the 1-based transcript position of an exonic base
+func (tx *Transcript) genomicToTranscript(pos int64) (int64, bool) {
+	var offset int64
+	for _, exon := range tx.Exons {
+		if pos >= exon.Start && pos <= exon.End {
+			return offset + (pos-tx.exonFirst(exon))*tx.direction() + 1, true
+		}
+		offset += exon.End - exon.Start + 1
+	}
+	return 0, false
+}
+
+// transcriptToGenomic returns the genomic position of a 1-based transcript
+// position. Positions outside the transcript extend beyond its first or last
+// exon.
+func (tx *Transcript) transcriptToGenomic(txPos int64) int64 {
+	if txPos < 1 {
+		return tx.exonFirst(tx.Exons[0]) - (1-txPos)*tx.direction()
+	}
+	remaining := txPos
+	for _, exon := range tx.Exons {
+		length := exon.End - exon.Start + 1
+		if remaining <= length {
+			return tx.exonFirst(exon) + (remaining-1)*tx.direction()
+		}
+		remaining -= length
+	}
+	last := tx.Exons[len(tx.Exons)-1]
+	return tx.exonLast(last) + remaining*tx.direction()
+}
+
+// cdsBounds returns the transcript positions of the first base of the start
+// codon and the last base of the stop codon
+func (tx *Transcript) cdsBounds() (int64, int64, error) {
+	if !tx.IsCoding() {
+		return 0, 0, fmt.Errorf("transcript %s has no coding region", tx.ID)
+	}
+	first, last := tx.CDSStart, tx.CDSEnd
+	if tx.Strand == '-' {
+		first, last = last, first
+	}
+	start, ok := tx.genomicToTranscript(first)
+	if !ok {
+		return 0, 0, fmt.Errorf("transcript %s coding start is not exonic", tx.ID)
+	}
+	end, ok := tx.genomicToTranscript(last)
+	if !ok {
+		return 0, 0, fmt.Errorf("transcript %s coding end is not exonic", tx.ID)
+	}
+	return start, end, nil
+}
+
+// CodingPosition formats the c. position of a genomic base, including
+// intronic offsets and UTR positions, e.g. 76, -14, *32 or 1234+5.
+func (tx *Transcript) CodingPosition(pos int64) (string, error) {
+	cdsStart, cdsEnd, err := tx.cdsBounds()
+	if err != nil {
+		return "", err
+	}
+
+	txPos, ok := tx.genomicToTranscript(pos)
+	var offset int64
+	if !ok {
+		txPos, offset, ok = tx.intronicAnchor(pos)
+		if !ok {
+			return "", fmt.Errorf("position %d is outside transcript %s", pos, tx.ID)
+		}
+	}
+
+	var coding string
+	switch {
+	case txPos < cdsStart:
+		coding = fmt.Sprintf("-%d", cdsStart-txPos)
+	case txPos <= cdsEnd:
+		coding = fmt.Sprintf("%d", txPos-cdsStart+1)
+	default:
+		coding = fmt.Sprintf("*%d", txPos-cdsEnd)
+	}
+	switch {
+	case offset > 0:
+		coding += fmt.Sprintf("+%d", offset)
+	case offset < 0:
+		coding += fmt.Sprintf("%d", offset)
+	}
+	return coding, nil
+}
+
+// intronicAnchor returns the nearest exonic transcript position of an
+// intronic base and its offset from it. The central base of an odd-length
+// intron is described from the upstream exon, per HGVS.
+func (tx *Transcript) intronicAnchor(pos int64) (int64, int64, bool) {
+	var txOffset int64
+	for i := 0; i < len(tx.Exons)-1; i++ {
+		upstream, downstream := tx.Exons[i], tx.Exons[i+1]
+		txOffset += upstream.End - upstream.Start + 1
+		fromUpstream := (pos - tx.exonLast(upstream)) * tx.direction()
+		toDownstream := (tx.exonFirst(downstream) - pos) * tx.direction()
+		if fromUpstream <= 0 || toDownstream <= 0 {
+			continue
+		}
+		if fromUpstream <= toDownstream {
+			return txOffset, fromUpstream, true
+		}
+		return txOffset + 1, -toDownstream, true
+	}
+	return 0, 0, false
+}
+
+// GenomicPosition resolves a c. position such as 76, -14, *32 or 1234+5
+// to its genomic coordinate.
+func (tx *Transcript) GenomicPosition(coding string) (int64, error) {
+	cdsStart, cdsEnd, err := tx.cdsBounds()
+	if err != nil {
+		return 0, err
+	}
+	match := codingPositionPattern.FindStringSubmatch(coding)
+	if match == nil {
+		return 0, fmt.Errorf("invalid coding position: %s", coding)
+	}
+
+	var base, offset int64
+	fmt.Sscan(match[2], &base)
+	if match[4] != "" {
+		fmt.Sscan(match[4], &offset)
+		if match[3] == "-" {
+			offset = -offset
+		}
+	}
+
+	var txPos int64
+	switch match[1] {
+	case "-":
+		txPos = cdsStart - base
+	case "*":
+		txPos = cdsEnd + base
+	default:
+		if base == 0 {
+			return 0, fmt.Errorf("invalid coding position: %s", coding)
+		}
+		txPos = cdsStart + base - 1
+	}
+	return tx.transcriptToGenomic(txPos) + offset*tx.direction(), nil
+}
+
+// splitAccessionVersion splits NM_000546.6 into NM_000546 and 6
+func splitAccessionVersion(id string) (string, int) {
+	base, version, ok := strings.Cut(id, ".")
+	if !ok {
+		return id, 0
+	}
+	var v int
+	fmt.Sscan(version, &v)
+	return base, v
+}
+
+func accessionVersion(id string) int {
+	_, version := splitAccessionVersion(id)
+	return version
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package hgvs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FromVCF describes a VCF-style variant (1-based position, REF and ALT
+// alleles) as genomic HGVS on the chromosome's RefSeq accession, e.g.
+// 17, 43094464, A, G -> NC_000017.11:g.43094464A>G. Shared leading and
+// trailing bases such as the VCF padding base are trimmed; the result is
+// not shifted 3', which the normalizer does against the reference. A
+// breakend ALT joining another chromosome, e.g. N[chr22:23180365[, is
+// described as a translocation junction.
+func FromVCF(assembly, chromosome string, pos int64, ref, alt string) (string, error) {
+	accession, ok := ChromosomeAccession(assembly, chromosome)
+	if !ok {
+		return "", fmt.Errorf("unknown chromosome %q for %s", chromosome, assembly)
+	}
+	if pos < 1 {
+		return "", fmt.Errorf("position must be positive, got %d", pos)
+	}
+
+	// Breakends between chromosomes become translocation junctions
+	if alt = strings.TrimSpace(alt); strings.ContainsAny(alt, "[]") {
+		return fromBreakend(assembly, accession, pos, alt)
+	}
+	if strings.HasPrefix(alt, "<") {
+		return "", fmt.Errorf("symbolic ALT %s is not supported; describe the variant in HGVS, e.g. NC_000001.11:g.100_200inv", alt)
+	}
+
+	ref = strings.ToUpper(strings.TrimSpace(ref))
+	alt = strings.ToUpper(alt)
+	if ref == "." || ref == "-" {
+		ref = ""
+	}
+	if alt == "." || alt == "-" || alt == "*" {
+		alt = ""
+	}
+	if strings.Contains(alt, ",") {
+		return "", fmt.Errorf("multi-allelic ALT %q; split into one allele per row", alt)
+	}
+	for _, allele := range []string{ref, alt} {
+		if strings.Trim(allele, "ACGTN") != "" {
+			return "", fmt.Errorf("unsupported allele %q; only A, C, G, T and N are allowed", allele)
+		}
+	}
+	if ref == alt {
+		return "", fmt.Errorf("REF and ALT are identical")
+	}
+
+	// Trim the shared suffix, then the shared prefix, advancing the position
+	for len(ref) > 0 && len(alt) > 0 && ref[len(ref)-1] == alt[len(alt)-1] {
+		ref, alt = ref[:len(ref)-1], alt[:len(alt)-1]
+	}
+	for len(ref) > 0 && len(alt) > 0 && ref[0] == alt[0] {
+		ref, alt = ref[1:], alt[1:]
+		pos++
+	}
+
+	end := pos + int64(len(ref)) - 1
+	var change string
+	switch {
+	case len(ref) == 1 && len(alt) == 1:
+		change = fmt.Sprintf("%d%s>%s", pos, ref, alt)
+	case ref == "":
+		if pos < 2 {
+			return "", fmt.Errorf("insertion before the first base cannot be described")
+		}
+		change = fmt.Sprintf("%d_%dins%s", pos-1, pos, alt)
+	case alt == "":
+		change = rangeOf(pos, end) + "del"
+	default:
+		change = rangeOf(pos, end) + "delins" + alt
+	}
+	return accession + ":g." + change, nil
+}
+
+// rangeOf formats a position or a position range
+func rangeOf(start, end int64) string {
+	if start == end {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d_%d", start, end)
+}
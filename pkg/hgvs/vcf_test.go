@@ -0,0 +1,62 @@
+package hgvs
+
+import "testing"
+
+func TestFromVCF(t *testing.T) {
+	tests := []struct {
+		name       string
+		assembly   string
+		chromosome string
+		pos        int64
+		ref, alt   string
+		want       string
+	}{
+		{"substitution", AssemblyGRCh38, "17", 43094464, "A", "G", "NC_000017.11:g.43094464A>G"},
+		{"chr prefix GRCh37", AssemblyGRCh37, "chr17", 41246747, "a", "g", "NC_000017.10:g.41246747A>G"},
+		{"padded deletion", AssemblyGRCh38, "7", 117559590, "ATCT", "A", "NC_000007.14:g.117559591_117559593del"},
+		{"single base deletion", AssemblyGRCh38, "7", 100, "AT", "A", "NC_000007.14:g.101del"},
+		{"padded insertion", AssemblyGRCh38, "X", 100, "A", "ACT", "NC_000023.11:g.100_101insCT"},
+		{"unpadded insertion", AssemblyGRCh38, "X", 101, "-", "CT", "NC_000023.11:g.100_101insCT"},
+		{"delins", AssemblyGRCh38, "1", 100, "GAT", "GCC", "NC_000001.11:g.101_102delinsCC"},
+		{"mnv", AssemblyGRCh38, "1", 100, "AT", "GC", "NC_000001.11:g.100_101delinsGC"},
+		{"shared suffix", AssemblyGRCh38, "2", 100, "CAG", "TAG", "NC_000002.12:g.100C>T"},
+		{"mitochondrial", AssemblyGRCh38, "chrM", 3243, "A", "G", "NC_012920.1:g.3243A>G"},
+		{"breakend mate after", AssemblyGRCh38, "9", 130714455, "G", "G[chr22:23180365[", "NC_000009.12:g.130714455::NC_000022.11:g.23180365"},
+		{"breakend mate before", AssemblyGRCh38, "22", 23180365, "T", "]9:130714455]T", "NC_000009.12:g.130714455::NC_000022.11:g.23180365"},
+	}
+	for _, tt := range tests {
+		got, err := FromVCF(tt.assembly, tt.chromosome, tt.pos, tt.ref, tt.alt)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFromVCF_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		chromosome string
+		pos        int64
+		ref, alt   string
+	}{
+		{"unknown chromosome", "chrUn_gl000220", 100, "A", "G"},
+		{"zero position", "1", 0, "A", "G"},
+		{"multi-allelic", "1", 100, "A", "G,T"},
+		{"symbolic allele", "1", 100, "A", "<DEL>"},
+		{"identical alleles", "1", 100, "A", "A"},
+		{"insertion before first base", "1", 1, "-", "A"},
+		{"intrachromosomal breakend", "1", 100, "A", "A[1:5000["},
+		{"mismatched breakend brackets", "1", 100, "A", "A[2:5000]"},
+		{"breakend on both sides", "1", 100, "A", "A[2:5000[A"},
+		{"unknown breakend mate", "1", 100, "A", "A[chrUn:5000["},
+	}
+	for _, tt := range tests {
+		if got, err := FromVCF(AssemblyGRCh38, tt.chromosome, tt.pos, tt.ref, tt.alt); err == nil {
+			t.Errorf("%s: expected an error, got %s", tt.name, got)
+		}
+	}
+}